@@ -14,11 +14,8 @@ require (
 	github.com/cloudflare/circl v1.6.3
 	github.com/unix-world/smartgo v0.0.0-20260117025406-ff3ea76b574d
 	golang.org/x/crypto v0.47.0
+	golang.org/x/sys v0.40.0
 	golang.org/x/term v0.39.0
 )
 
-require (
-	golang.org/x/sys v0.40.0 // indirect
-)
-
 tool golang.org/x/tools/cmd/deadcode
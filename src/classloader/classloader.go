@@ -582,6 +582,7 @@ func convertToPostableClass(fullyParsedClass *ParsedClass) ClData {
 	if len(fullyParsedClass.fields) > 0 {
 		for i := 0; i < len(fullyParsedClass.fields); i++ {
 			kdf := Field{}
+			kdf.AccessFlags = fullyParsedClass.fields[i].accessFlags
 			kdf.Name = uint16(fullyParsedClass.fields[i].name)
 			kdf.NameStr = fullyParsedClass.utf8Refs[kdf.Name].content // temporarily include field name. JACOBIN-611
 			kdf.Desc = uint16(fullyParsedClass.fields[i].description)
@@ -31,8 +31,11 @@ const (
 	ClassCastException
 	ClassNotFoundException
 	ClassNotPreparedException
+	ClosedChannelException
+	ClosedWatchServiceException
 	CMMException
 	CompletionException
+	CancellationException
 	ConcurrentModificationException
 	DateTimeException
 	DateTimeParseException
@@ -42,6 +45,7 @@ const (
 	EmptyStackException             // in HotSpot, used by Stack class; in Jacobin, for all stack underflows
 	EnumConstantNotPresentException // typically, used in annotation processing
 	EventException
+	FileAlreadyExistsException
 	FileNotFoundException
 	FileSystemAlreadyExistsException
 	FileSystemNotFoundException
@@ -62,6 +66,7 @@ const (
 	IncompleteAnnotationException
 	InconsistentDebugInfoException
 	IndexOutOfBoundsException
+	InputMismatchException
 	InstantiationException
 	InternalException
 	InvalidCodeIndexException
@@ -83,10 +88,15 @@ const (
 	NegativeArraySizeException
 	NoSuchDynamicMethodException
 	NoSuchElementException
+	NoSuchFileException
+	NotDirectoryException
 	NoSuchMechanismException
+	NonReadableChannelException
+	NonWritableChannelException
 	NullPointerException
 	NumberFormatException
 	ObjectCollectedException
+	OverlappingFileLockException
 	PatternSyntaxException
 	ProfileDataException
 	ProviderException
@@ -151,6 +161,19 @@ const (
 	InvalidTypeException
 	InvocationException
 	IOException
+	EOFException
+	ZipException
+	InvalidPropertiesFormatException
+	SocketException
+	SocketTimeoutException
+	ConnectException
+	BindException
+	NoRouteToHostException
+	UnknownHostException
+	MalformedURLException
+	ProtocolException
+	HttpTimeoutException
+	HttpConnectTimeoutException
 	JMException
 	JShellException
 	KeySelectorException
@@ -230,18 +253,45 @@ const (
 	ChangedCharSetException
 	CharacterCodingException
 	CharConversionException
+	IllegalCharsetNameException
+	MalformedInputException
+	UnmappableCharacterException
+	UnsupportedCharsetException
 	UnsupportedEncodingException
 	UTFDataFormatException
 
 	// Java and JCA Security exceptions
 	NoSuchAlgorithmException
 	InvalidKeyException
+	InvalidKeySpecException
 	SignatureException
 	InvalidAlgorithmParameterException
 	ShortBufferException
 	IllegalBlockSizeException
 	BadPaddingException
 	NoSuchPaddingException
+	CertificateEncodingException
+	CertificateExpiredException
+	CertificateNotYetValidException
+	CertificateParsingException
+	CertPathValidatorException
+	KeyStoreException
+	UnrecoverableKeyException
+	KeyManagementException
+	SSLException
+	SSLHandshakeException
+	SSLPeerUnverifiedException
+	DecapsulateException
+	NoSuchProviderException
+
+	// Java serialization exceptions
+	NotSerializableException
+	InvalidClassException
+	StreamCorruptedException
+	OptionalDataException
+	WriteAbortedException
+	InvalidObjectException
+	NotActiveException
 )
 
 // -----------------------------------------------------------------------//
@@ -266,8 +316,11 @@ var JVMexceptionNamesJacobin = []string{
 	"java.lang.ClassCastException",                           // VERIFIED
 	"java.lang.ClassNotFoundException",                       // VERIFIED
 	"org.jacobin.ClassNotPreparedException",                  // VERIFIED
+	"java.nio.channels.ClosedChannelException",               // VERIFIED
+	"java.nio.file.ClosedWatchServiceException",
 	"java.awt.color.CMMException",                            // VERIFIED
 	"java.util.concurrent.CompletionException",               // VERIFIED
+	"java.util.concurrent.CancellationException",
 	"java.util.ConcurrentModificationException",              // VERIFIED
 	"java.time.DateTimeException",                            // VERIFIED
 	"java.time.format.DateTimeParseException",						  // VERIFIED
@@ -277,6 +330,7 @@ var JVMexceptionNamesJacobin = []string{
 	"java.util.EmptyStackException",                          // VERIFIED
 	"java.lang.EnumConstantNotPresentException",              // VERIFIED
 	"org.w3c.dom.events.EventException",                      // VERIFIED
+	"java.nio.file.FileAlreadyExistsException",               // VERIFIED
 	"java.io.FileNotFoundException",                          // VERIFIED
 	"java.nio.file.FileSystemAlreadyExistsException",         // VERIFIED
 	"java.nio.file.FileSystemNotFoundException",              // VERIFIED
@@ -297,6 +351,7 @@ var JVMexceptionNamesJacobin = []string{
 	"java.lang.annotaion.IncompleteAnnotationException",      // VERIFIED
 	"org.jacobin.InconsistentDebugInfoException",             // VERIFIED
 	"java.lang.IndexOutOfBoundsException",                    // VERIFIED
+	"java.util.InputMismatchException",                       // VERIFIED
 	"java.lang.InstantiationException",                       // VERIFIED
 	"org.jacobin.InternalException",                          // VERIFIED
 	"org.jacobin.InvalidCodeIndexException",                  // VERIFIED
@@ -318,10 +373,15 @@ var JVMexceptionNamesJacobin = []string{
 	"java.lang.NegativeArraySizeException",                   // VERIFIED
 	"jdk.dynalink.NoSuchDynamicMethodException",              // VERIFIED
 	"java.util.NoSuchElementException",                       // VERIFIED
+	"java.nio.file.NoSuchFileException",                      // VERIFIED
+	"java.nio.file.NotDirectoryException",
 	"javax.xml.crypto.NoSuchMechanismException",              // VERIFIED
+	"java.nio.channels.NonReadableChannelException",          // VERIFIED
+	"java.nio.channels.NonWritableChannelException",          // VERIFIED
 	"java.lang.NullPointerException",                         // VERIFIED
 	"java.lang.NumberFormatException",                        // VERIFIED
 	"org.jacobin.ObjectCollectedException",                   // VERIFIED
+	"java.nio.channels.OverlappingFileLockException",         // VERIFIED
 	"java.util.regex.PatternSyntaxException",                 // VERIFIED
 	"java.awt.color.ProfileDataException",                    // VERIFIED
 	"java.security.ProviderException",                        // VERIFIED
@@ -386,6 +446,19 @@ var JVMexceptionNamesJacobin = []string{
 	"org.jacobin.InvalidTypeException",                          // VERIFIED
 	"org.jacobin.InvocationException",                           // VERIFIED
 	"java.io.IOException",                                       // VERIFIED
+	"java.io.EOFException",
+	"java.util.zip.ZipException",
+	"java.util.InvalidPropertiesFormatException",
+	"java.net.SocketException",
+	"java.net.SocketTimeoutException",
+	"java.net.ConnectException",
+	"java.net.BindException",
+	"java.net.NoRouteToHostException",
+	"java.net.UnknownHostException",
+	"java.net.MalformedURLException",
+	"java.net.ProtocolException",
+	"java.net.http.HttpTimeoutException",
+	"java.net.http.HttpConnectTimeoutException",
 	"javax.management.JMException",                              // VERIFIED
 	"jdk.jshell.JShellException",                                // VERIFIED
 	"javax.xml.crypto.KeySelectorException",                     // VERIFIED
@@ -465,18 +538,45 @@ var JVMexceptionNamesJacobin = []string{
 	"javax.swing.text.ChangedCharSetException",  // VERIFIED
 	"java.nio.charset.CharacterCodingException", // VERIFIED
 	"java.io.CharConversionException",           // VERIFIED
-	"java.io.UnsupportedEncodingException",      // VERIFIED
-	"java.io.UTFDataFormatException",            // VERIFIED
+	"java.nio.charset.IllegalCharsetNameException",
+	"java.nio.charset.MalformedInputException",
+	"java.nio.charset.UnmappableCharacterException",
+	"java.nio.charset.UnsupportedCharsetException",
+	"java.io.UnsupportedEncodingException", // VERIFIED
+	"java.io.UTFDataFormatException",       // VERIFIED
 
 	// Java and JCA Security exceptions
 	"java.security.NoSuchAlgorithmException",
 	"java.security.InvalidKeyException",
+	"java.security.spec.InvalidKeySpecException",
 	"java.security.SignatureException",
 	"java.security.InvalidAlgorithmParameterException",
 	"javax.crypto.ShortBufferException",
 	"javax.crypto.IllegalBlockSizeException",
 	"javax.crypto.BadPaddingException",
 	"javax.crypto.NoSuchPaddingException",
+	"java.security.cert.CertificateEncodingException",
+	"java.security.cert.CertificateExpiredException",
+	"java.security.cert.CertificateNotYetValidException",
+	"java.security.cert.CertificateParsingException",
+	"java.security.cert.CertPathValidatorException",
+	"java.security.KeyStoreException",
+	"java.security.UnrecoverableKeyException",
+	"java.security.KeyManagementException",
+	"javax.net.ssl.SSLException",
+	"javax.net.ssl.SSLHandshakeException",
+	"javax.net.ssl.SSLPeerUnverifiedException",
+	"javax.crypto.DecapsulateException",
+	"java.security.NoSuchProviderException",
+
+	// Java serialization exceptions
+	"java.io.NotSerializableException",
+	"java.io.InvalidClassException",
+	"java.io.StreamCorruptedException",
+	"java.io.OptionalDataException",
+	"java.io.WriteAbortedException",
+	"java.io.InvalidObjectException",
+	"java.io.NotActiveException",
 }
 
 // -----------------------------------------------------------------------//
@@ -500,8 +600,11 @@ var JVMexceptionNames = []string{
 	"java.lang.ClassCastException",                           // VERIFIED
 	"java.lang.ClassNotFoundException",                       // VERIFIED
 	"com.sun.jdi.ClassNotPreparedException",                  // VERIFIED
+	"java.nio.channels.ClosedChannelException",               // VERIFIED
+	"java.nio.file.ClosedWatchServiceException",
 	"java.awt.color.CMMException",                            // VERIFIED
 	"java.util.concurrent.CompletionException",               // VERIFIED
+	"java.util.concurrent.CancellationException",
 	"java.util.ConcurrentModificationException",              // VERIFIED
 	"java.time.DateTimeException",                            // VERIFIED
 	"java.time.format.DateTimeParseException",						  // VERIFIED
@@ -511,6 +614,7 @@ var JVMexceptionNames = []string{
 	"java.util.EmptyStackException",                          // VERIFIED
 	"java.lang.EnumConstantNotPresentException",              // VERIFIED
 	"org.w3c.dom.events.EventException",                      // VERIFIED
+	"java.nio.file.FileAlreadyExistsException",               // VERIFIED
 	"java.io.FileNotFoundException",                          // VERiFIED
 	"java.nio.file.FileSystemAlreadyExistsException",         // VERIFIED
 	"java.nio.file.FileSystemNotFoundException",              // VERIFIED
@@ -531,6 +635,7 @@ var JVMexceptionNames = []string{
 	"java.lang.annotaion.IncompleteAnnotationException",      // VERIFIED
 	"com.sun.jdi.InconsistentDebugInfoException",             // VERIFIED
 	"java.lang.IndexOutOfBoundsException",                    // VERIFIED
+	"java.util.InputMismatchException",                       // VERIFIED
 	"java.lang.InstantiationException",                       // VERIFIED
 	"com.sun.jdi.InternalException",                          // VERIFIED
 	"com.sun.jdi.InvalidCodeIndexException",                  // VERIFIED
@@ -552,10 +657,15 @@ var JVMexceptionNames = []string{
 	"java.lang.NegativeArraySizeException",                   // VERIFIED
 	"jdk.dynalink.NoSuchDynamicMethodException",              // VERIFIED
 	"java.util.NoSuchElementException",                       // VERIFIED
+	"java.nio.file.NoSuchFileException",                      // VERIFIED
+	"java.nio.file.NotDirectoryException",
 	"javax.xml.crypto.NoSuchMechanismException",              // VERIFIED
+	"java.nio.channels.NonReadableChannelException",          // VERIFIED
+	"java.nio.channels.NonWritableChannelException",          // VERIFIED
 	"java.lang.NullPointerException",                         // VERIFIED
 	"java.lang.NumberFormatException",                        // VERIFIED
 	"com.sun.jdi.ObjectCollectedException",                   // VERIFIED
+	"java.nio.channels.OverlappingFileLockException",         // VERIFIED
 	"java.util.regex.PatternSyntaxException",                 // VERIFIED
 	"java.awt.color.ProfileDataException",                    // VERIFIED
 	"java.security.ProviderException",                        // VERIFIED
@@ -620,6 +730,19 @@ var JVMexceptionNames = []string{
 	"com.sun.jdi.InvalidTypeException",                          // VERIFIED
 	"com.sun.jdi.InvocationException",                           // VERIFIED
 	"java.io.IOException",                                       // VERIFIED
+	"java.io.EOFException",
+	"java.util.zip.ZipException",
+	"java.util.InvalidPropertiesFormatException",
+	"java.net.SocketException",
+	"java.net.SocketTimeoutException",
+	"java.net.ConnectException",
+	"java.net.BindException",
+	"java.net.NoRouteToHostException",
+	"java.net.UnknownHostException",
+	"java.net.MalformedURLException",
+	"java.net.ProtocolException",
+	"java.net.http.HttpTimeoutException",
+	"java.net.http.HttpConnectTimeoutException",
 	"javax.management.JMException",                              // VERIFIED
 	"jdk.jshell.JShellException",                                // VERIFIED
 	"javax.xml.crypto.KeySelectorException",                     // VERIFIED
@@ -699,12 +822,43 @@ var JVMexceptionNames = []string{
 	"javax.swing.text.ChangedCharSetException",  // VERIFIED
 	"java.nio.charset.CharacterCodingException", // VERIFIED
 	"java.io.CharConversionException",           // VERIFIED
-	"java.io.UnsupportedEncodingException",      // VERIFIED
-	"java.io.UTFDataFormatException",            // VERIFIED
+	"java.nio.charset.IllegalCharsetNameException",
+	"java.nio.charset.MalformedInputException",
+	"java.nio.charset.UnmappableCharacterException",
+	"java.nio.charset.UnsupportedCharsetException",
+	"java.io.UnsupportedEncodingException", // VERIFIED
+	"java.io.UTFDataFormatException",       // VERIFIED
 
 	// Java and JCA Security exceptions
 	"java.security.NoSuchAlgorithmException",
 	"java.security.InvalidKeyException",
+	"java.security.spec.InvalidKeySpecException",
 	"java.security.SignatureException",
 	"java.security.InvalidAlgorithmParameterException",
+	"javax.crypto.ShortBufferException",
+	"javax.crypto.IllegalBlockSizeException",
+	"javax.crypto.BadPaddingException",
+	"javax.crypto.NoSuchPaddingException",
+	"java.security.cert.CertificateEncodingException",
+	"java.security.cert.CertificateExpiredException",
+	"java.security.cert.CertificateNotYetValidException",
+	"java.security.cert.CertificateParsingException",
+	"java.security.cert.CertPathValidatorException",
+	"java.security.KeyStoreException",
+	"java.security.UnrecoverableKeyException",
+	"java.security.KeyManagementException",
+	"javax.net.ssl.SSLException",
+	"javax.net.ssl.SSLHandshakeException",
+	"javax.net.ssl.SSLPeerUnverifiedException",
+	"javax.crypto.DecapsulateException",
+	"java.security.NoSuchProviderException",
+
+	// Java serialization exceptions
+	"java.io.NotSerializableException",
+	"java.io.InvalidClassException",
+	"java.io.StreamCorruptedException",
+	"java.io.OptionalDataException",
+	"java.io.WriteAbortedException",
+	"java.io.InvalidObjectException",
+	"java.io.NotActiveException",
 }
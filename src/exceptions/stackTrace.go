@@ -0,0 +1,241 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package exceptions
+
+import (
+	"fmt"
+	"jacobin/src/object"
+	"jacobin/src/trace"
+	"jacobin/src/types"
+	"jacobin/src/util"
+)
+
+// This file formats the stack trace of a Throwable exactly as the JDK's
+// Throwable.printStackTrace() does, including the traces of the exceptions
+// it suppressed and of its chain of causes. Both printStackTrace() and the
+// output for an uncaught exception use it.
+
+// The captions that the JDK puts before enclosed stack traces
+const (
+	SuppressedCaption = "Suppressed: "
+	CauseCaption      = "Caused by: "
+)
+
+// StackTraceLines returns the lines that Throwable.printStackTrace() prints for throwable.
+// describe returns the text for a throwable, which is its toString(); if describe is nil,
+// ThrowableString is used. The lines have no line terminators.
+func StackTraceLines(throwable *object.Object, describe func(*object.Object) string) []string {
+	if describe == nil {
+		describe = ThrowableString
+	}
+
+	dejaVu := map[*object.Object]bool{throwable: true}
+	lines := []string{describe(throwable)}
+	ourTrace := shownStackTrace(throwable)
+	for _, ste := range ourTrace {
+		lines = append(lines, "\tat "+StackTraceElementString(ste))
+	}
+	for _, suppressed := range SuppressedExceptions(throwable) {
+		lines = enclosedStackTrace(lines, suppressed, ourTrace, SuppressedCaption, "\t", dejaVu, describe)
+	}
+	if cause := ThrowableCause(throwable); cause != nil {
+		lines = enclosedStackTrace(lines, cause, ourTrace, CauseCaption, "", dejaVu, describe)
+	}
+	return lines
+}
+
+// enclosedStackTrace appends the stack trace of a suppressed exception or a cause to lines.
+// The frames it has in common with the trace of the enclosing throwable are not repeated,
+// but counted in a "... n more" line.
+func enclosedStackTrace(lines []string, throwable *object.Object, enclosingTrace []*object.Object,
+	caption, prefix string, dejaVu map[*object.Object]bool, describe func(*object.Object) string) []string {
+
+	if dejaVu[throwable] {
+		return append(lines, prefix+caption+"[CIRCULAR REFERENCE: "+describe(throwable)+"]")
+	}
+	dejaVu[throwable] = true
+
+	// compute the number of frames in common with the enclosing trace
+	ourTrace := shownStackTrace(throwable)
+	m := len(ourTrace) - 1
+	n := len(enclosingTrace) - 1
+	for m >= 0 && n >= 0 && sameStackTraceElement(ourTrace[m], enclosingTrace[n]) {
+		m--
+		n--
+	}
+	framesInCommon := len(ourTrace) - 1 - m
+
+	lines = append(lines, prefix+caption+describe(throwable))
+	for i := 0; i <= m; i++ {
+		lines = append(lines, prefix+"\tat "+StackTraceElementString(ourTrace[i]))
+	}
+	if framesInCommon != 0 {
+		lines = append(lines, fmt.Sprintf("%s\t... %d more", prefix, framesInCommon))
+	}
+
+	for _, suppressed := range SuppressedExceptions(throwable) {
+		lines = enclosedStackTrace(lines, suppressed, ourTrace, SuppressedCaption, prefix+"\t", dejaVu, describe)
+	}
+	if cause := ThrowableCause(throwable); cause != nil {
+		lines = enclosedStackTrace(lines, cause, ourTrace, CauseCaption, prefix, dejaVu, describe)
+	}
+	return lines
+}
+
+// ThrowableString returns what Throwable.toString() returns for throwable: the name of its
+// class followed, if it has a detail message, by a colon and the message.
+func ThrowableString(throwable *object.Object) string {
+	className := util.ConvertInternalClassNameToUserFormat(object.GoStringFromStringPoolIndex(throwable.KlassName))
+	msg, ok := ThrowableMessage(throwable)
+	if !ok {
+		return className
+	}
+	return className + ": " + msg
+}
+
+// ThrowableMessage returns the detail message of throwable and whether it has one.
+func ThrowableMessage(throwable *object.Object) (string, bool) {
+	value, ok := lockedFieldValue(throwable, "detailMessage")
+	if !ok {
+		return "", false
+	}
+	switch msg := value.(type) {
+	case *object.Object:
+		if object.IsNull(msg) {
+			return "", false
+		}
+		return object.GoStringFromStringObject(msg), true
+	case []types.JavaByte:
+		return object.GoStringFromJavaByteArray(msg), true
+	}
+	return "", false
+}
+
+// ThrowableCause returns the cause of throwable, or nil if it has none.
+func ThrowableCause(throwable *object.Object) *object.Object {
+	value, _ := lockedFieldValue(throwable, "cause")
+	cause, ok := value.(*object.Object)
+	if !ok || object.IsNull(cause) || cause == throwable {
+		return nil
+	}
+	return cause
+}
+
+// SuppressedExceptions returns the exceptions that throwable suppressed, which
+// Throwable.addSuppressed() keeps in an array of Throwables.
+func SuppressedExceptions(throwable *object.Object) []*object.Object {
+	return refArrayField(throwable, "suppressedExceptions")
+}
+
+// StackTraceElements returns the StackTraceElements of the stack trace of throwable.
+func StackTraceElements(throwable *object.Object) []*object.Object {
+	return refArrayField(throwable, "stackTrace")
+}
+
+// shownStackTrace returns the elements of the stack trace of throwable that are printed. Frames
+// of the methods of Throwable, Error and AssertionError are left out, as HotSpot never has them
+// in a stack trace.
+func shownStackTrace(throwable *object.Object) []*object.Object {
+	var shown []*object.Object
+	for _, ste := range StackTraceElements(throwable) {
+		switch steField(ste, "declaringClass") {
+		case "java/lang/Throwable", "java/lang/Error", "java/lang/AssertionError":
+			continue
+		}
+		shown = append(shown, ste)
+	}
+	return shown
+}
+
+// refArrayField returns the elements of the reference array in the field fieldName of obj,
+// or nil if the field does not hold an array.
+func refArrayField(obj *object.Object, fieldName string) []*object.Object {
+	value, _ := lockedFieldValue(obj, fieldName)
+	arr, ok := value.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil
+	}
+	value, _ = lockedFieldValue(arr, "value")
+	elements, _ := value.([]*object.Object)
+	return elements
+}
+
+// lockedFieldValue returns the value of the field fieldName of obj, read under the object's
+// lock, as other threads can change a throwable while its stack trace is being printed.
+func lockedFieldValue(obj *object.Object, fieldName string) (any, bool) {
+	obj.ThMutex.RLock()
+	defer obj.ThMutex.RUnlock()
+	fld, ok := obj.FieldTable[fieldName]
+	return fld.Fvalue, ok
+}
+
+// StackTraceElementString returns what StackTraceElement.toString() returns for ste, such as
+// "com.example.Main.main(Main.java:12)" or "java.base/java.util.ArrayList.get(ArrayList.java:427)".
+// As all of Jacobin's class loaders are built-in ones, the JDK leaves out their names.
+func StackTraceElementString(ste *object.Object) string {
+	str := util.ConvertInternalClassNameToUserFormat(steField(ste, "declaringClass"))
+	if module := steField(ste, "moduleName"); module != "" {
+		str = module + "/" + str
+	}
+	str += "." + steField(ste, "methodName")
+
+	fileName := steField(ste, "fileName")
+	sourceLine := steField(ste, "sourceLine")
+	switch {
+	case fileName == "":
+		return str + "(Unknown Source)"
+	case sourceLine == "":
+		return str + "(" + fileName + ")"
+	default:
+		return str + "(" + fileName + ":" + sourceLine + ")"
+	}
+}
+
+// sameStackTraceElement reports whether two StackTraceElements are equal, as
+// StackTraceElement.equals() determines.
+func sameStackTraceElement(ste1, ste2 *object.Object) bool {
+	if ste1 == ste2 {
+		return true
+	}
+	for _, name := range []string{"declaringClass", "methodName", "fileName", "sourceLine",
+		"moduleName", "classLoaderName"} {
+		if steField(ste1, name) != steField(ste2, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// steField returns the value of a field of a StackTraceElement, which holds a Go string.
+func steField(ste *object.Object, name string) string {
+	if ste == nil {
+		return ""
+	}
+	value, _ := lockedFieldValue(ste, name)
+	str, _ := value.(string)
+	return str
+}
+
+// ShowJVMstackTrace prints the stack trace of an uncaught throwable as the JDK does:
+// the line 'Exception in thread "main" ' followed by the output of printStackTrace().
+// describe is passed to StackTraceLines.
+func ShowJVMstackTrace(throwable *object.Object, thread int, describe func(*object.Object) string) {
+	lines := StackTraceLines(throwable, describe)
+	trace.AsIs(UncaughtExceptionPrefix(thread) + lines[0])
+	for _, line := range lines[1:] {
+		trace.AsIs(line)
+	}
+}
+
+// UncaughtExceptionPrefix returns the text printed before the description of an exception
+// that was not caught on the given thread.
+func UncaughtExceptionPrefix(thread int) string {
+	if thread == 1 { // if it's thread #1, use its name, "main"
+		return "Exception in thread \"main\" "
+	}
+	return fmt.Sprintf("Exception in thread %d ", thread)
+}
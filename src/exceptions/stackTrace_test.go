@@ -0,0 +1,142 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package exceptions
+
+import (
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strings"
+	"testing"
+)
+
+// makeSTE makes a StackTraceElement with the fields that Jacobin fills in.
+func makeSTE(className, methName, fileName, line string) *object.Object {
+	ste := object.MakeEmptyObject()
+	for name, value := range map[string]string{"declaringClass": className, "methodName": methName,
+		"fileName": fileName, "sourceLine": line, "moduleName": "", "classLoaderName": "app"} {
+		ste.FieldTable[name] = object.Field{Ftype: types.GolangString, Fvalue: value}
+	}
+	return ste
+}
+
+// makeThrowable makes a throwable of the class className, with the message msg (none if
+// empty) and the given stack trace.
+func makeThrowable(className, msg string, stes ...*object.Object) *object.Object {
+	throwable := object.MakeEmptyObjectWithClassName(&className)
+	throwable.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName, Fvalue: object.Null}
+	if msg != "" {
+		throwable.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName,
+			Fvalue: object.StringObjectFromGoString(msg)}
+	}
+	throwable.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: object.Null}
+	arr := object.Make1DimRefArray("java/lang/StackTraceElement;", int64(len(stes)))
+	copy(arr.FieldTable["value"].Fvalue.([]*object.Object), stes)
+	throwable.FieldTable["stackTrace"] = object.Field{Ftype: types.Ref, Fvalue: arr}
+	return throwable
+}
+
+func setRefArrayField(obj *object.Object, fieldName string, elements ...*object.Object) {
+	arr := object.Make1DimRefArray("java/lang/Throwable;", int64(len(elements)))
+	copy(arr.FieldTable["value"].Fvalue.([]*object.Object), elements)
+	obj.FieldTable[fieldName] = object.Field{Ftype: "[Ljava/lang/Throwable;", Fvalue: arr}
+}
+
+func TestStackTraceElementString(t *testing.T) {
+	globals.InitStringPool()
+
+	tests := []struct {
+		ste      *object.Object
+		expected string
+	}{
+		{makeSTE("com/example/Main", "main", "Main.java", "12"), "com.example.Main.main(Main.java:12)"},
+		{makeSTE("com/example/Main", "run", "Main.java", ""), "com.example.Main.run(Main.java)"},
+		{makeSTE("com/example/Main", "run", "", ""), "com.example.Main.run(Unknown Source)"},
+	}
+	for _, test := range tests {
+		if observed := StackTraceElementString(test.ste); observed != test.expected {
+			t.Errorf("expected %q, observed %q", test.expected, observed)
+		}
+	}
+
+	ste := makeSTE("java/util/ArrayList", "get", "ArrayList.java", "427")
+	ste.FieldTable["moduleName"] = object.Field{Ftype: types.GolangString, Fvalue: "java.base"}
+	expected := "java.base/java.util.ArrayList.get(ArrayList.java:427)"
+	if observed := StackTraceElementString(ste); observed != expected {
+		t.Errorf("expected %q, observed %q", expected, observed)
+	}
+}
+
+func TestThrowableString(t *testing.T) {
+	globals.InitStringPool()
+
+	if observed := ThrowableString(makeThrowable("java/lang/IllegalStateException", "bad state")); observed != "java.lang.IllegalStateException: bad state" {
+		t.Errorf("unexpected string for a throwable with a message: %q", observed)
+	}
+	if observed := ThrowableString(makeThrowable("java/lang/RuntimeException", "")); observed != "java.lang.RuntimeException" {
+		t.Errorf("unexpected string for a throwable without a message: %q", observed)
+	}
+}
+
+func TestStackTraceLines_CauseWithFramesInCommon(t *testing.T) {
+	globals.InitStringPool()
+
+	mainFrame := makeSTE("Main", "main", "Main.java", "5")
+	cause := makeThrowable("java/io/IOException", "disk full",
+		makeSTE("Store", "write", "Store.java", "30"),
+		makeSTE("Store", "save", "Store.java", "20"),
+		makeSTE("Main", "main", "Main.java", "5"))
+	wrapper := makeThrowable("java/lang/RuntimeException", "save failed",
+		makeSTE("Store", "save", "Store.java", "22"), mainFrame)
+	wrapper.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: cause}
+
+	expected := []string{
+		"java.lang.RuntimeException: save failed",
+		"\tat Store.save(Store.java:22)",
+		"\tat Main.main(Main.java:5)",
+		"Caused by: java.io.IOException: disk full",
+		"\tat Store.write(Store.java:30)",
+		"\tat Store.save(Store.java:20)",
+		"\t... 1 more",
+	}
+	observed := StackTraceLines(wrapper, nil)
+	if strings.Join(observed, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected:\n%s\nobserved:\n%s", strings.Join(expected, "\n"), strings.Join(observed, "\n"))
+	}
+}
+
+func TestStackTraceLines_SuppressedAndCircularCause(t *testing.T) {
+	globals.InitStringPool()
+
+	mainFrame := makeSTE("Main", "main", "Main.java", "9")
+	primary := makeThrowable("java/lang/IllegalStateException", "in body",
+		makeSTE("Main", "body", "Main.java", "14"), mainFrame)
+	closing := makeThrowable("java/io/IOException", "on close",
+		makeSTE("Resource", "close", "Resource.java", "7"), mainFrame)
+	setRefArrayField(primary, "suppressedExceptions", closing)
+
+	// a cause whose own cause is the exception that it caused
+	cause := makeThrowable("java/lang/Exception", "", mainFrame)
+	cause.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: primary}
+	closing.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: cause}
+
+	expected := []string{
+		"java.lang.IllegalStateException: in body",
+		"\tat Main.body(Main.java:14)",
+		"\tat Main.main(Main.java:9)",
+		"\tSuppressed: java.io.IOException: on close",
+		"\t\tat Resource.close(Resource.java:7)",
+		"\t\t... 1 more",
+		"\tCaused by: java.lang.Exception",
+		"\t\t... 1 more",
+		"\tCaused by: [CIRCULAR REFERENCE: java.lang.IllegalStateException: in body]",
+	}
+	observed := StackTraceLines(primary, nil)
+	if strings.Join(observed, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected:\n%s\nobserved:\n%s", strings.Join(expected, "\n"), strings.Join(observed, "\n"))
+	}
+}
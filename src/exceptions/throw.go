@@ -182,13 +182,13 @@ func ThrowEx(which int, msg string, f *frames.Frame) bool {
 	params := []any{fs, throwObj}
 	glob.FuncFillInStackTrace(params)
 
-	excInfo := fmt.Sprintf("%s: FQN: %s, %s", exceptionNameForUser, frames.FormatFQN(f), msg)
-	_, _ = fmt.Fprintln(os.Stderr, excInfo)
-
-	stackTrace := throwObj.FieldTable["stackTrace"].Fvalue.(*object.Object)
-	traceEntries := stackTrace.FieldTable["value"].Fvalue.([]*object.Object)
+	// under -strictJDK, only the JDK's header and frames are printed
+	if !glob.StrictJDK {
+		excInfo := fmt.Sprintf("%s: FQN: %s, %s", exceptionNameForUser, frames.FormatFQN(f), msg)
+		_, _ = fmt.Fprintln(os.Stderr, excInfo)
+	}
 
-	ShowJVMstackTrace(traceEntries, glob)
+	ShowJVMstackTrace(throwObj, f.Thread, nil)
 
 	if !glob.StrictJDK {
 		// the next statement disables showing the line that identifies
@@ -203,40 +203,6 @@ func ThrowEx(which int, msg string, f *frames.Frame) bool {
 	return NotCaught                          // only applies to tests
 }
 
-func ShowJVMstackTrace(traceEntries []*object.Object, glob *globals.Globals) {
-	// now print out the JVM stack
-	for _, traceEntry := range traceEntries {
-		// HotSpot uses a slightly different format for method names:
-		// package.class.method, we prefer package/class.method, so we format
-		// method name according to whether -strictJDK is in force
-		var declaringClass string
-		if glob.StrictJDK {
-			declaringClass = util.ConvertInternalClassNameToUserFormat(
-				traceEntry.FieldTable["declaringClass"].Fvalue.(string))
-		} else {
-			declaringClass = traceEntry.FieldTable["declaringClass"].Fvalue.(string)
-		}
-
-		var traceInfo string
-		if traceEntry.FieldTable["sourceLine"].Fvalue.(string) == "" {
-			// if no source line number is available, just print the method name
-			traceInfo = fmt.Sprintf("  at %s.%s(%s)",
-				declaringClass,
-				traceEntry.FieldTable["methodName"].Fvalue.(string),
-				traceEntry.FieldTable["fileName"].Fvalue.(string))
-		} else {
-			traceInfo = fmt.Sprintf("  at %s.%s(%s:%s) PC: %s",
-				// otherwise, print the method name and source file + line number
-				declaringClass,
-				traceEntry.FieldTable["methodName"].Fvalue.(string),
-				traceEntry.FieldTable["fileName"].Fvalue.(string),
-				traceEntry.FieldTable["sourceLine"].Fvalue.(string),
-				traceEntry.FieldTable["PC"].Fvalue.(string))
-		}
-		trace.AsIs(traceInfo)
-	}
-}
-
 // MinimalAbort is the exception thrown when the frame info is not available,
 // such as during start-up, if the main class can't be found, etc.
 func MinimalAbort(whichException int, msg string) {
@@ -15,6 +15,7 @@ import (
 	"jacobin/src/gfunction/javaIo"
 	"jacobin/src/gfunction/javaLang"
 	"jacobin/src/gfunction/javaMath"
+	"jacobin/src/gfunction/javaNet"
 	"jacobin/src/gfunction/javaNio"
 	"jacobin/src/gfunction/javaSecurity"
 	"jacobin/src/gfunction/javaText"
@@ -53,7 +54,10 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaIo.Load_Io_BufferedWriter()
 	javaIo.Load_Io_ByteArrayInputStream()
 	javaIo.Load_Io_ByteArrayOutputStream()
+	javaIo.Load_Io_CharArrayWriter()
 	javaIo.Load_Io_Console()
+	javaIo.Load_Io_DataInputStream()
+	javaIo.Load_Io_DataOutputStream()
 	javaIo.Load_Io_File()
 	javaIo.Load_Io_FileInputStream()
 	javaIo.Load_Io_FileOutputStream()
@@ -63,9 +67,22 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaIo.Load_Io_FilterOutputStream()
 	javaIo.Load_Io_InputStream()
 	javaIo.Load_Io_InputStreamReader()
+	javaIo.Load_Io_ObjectInputFilter()
+	javaIo.Load_Io_ObjectInputStream()
+	javaIo.Load_Io_ObjectOutputStream()
+	javaIo.Load_Io_ObjectStreamClass()
 	javaIo.Load_Io_OutputStreamWriter()
+	javaIo.Load_Io_PipedInputStream()
+	javaIo.Load_Io_PipedOutputStream()
+	javaIo.Load_Io_PipedReader()
+	javaIo.Load_Io_PipedWriter()
 	javaIo.Load_Io_PrintStream()
+	javaIo.Load_Io_PrintWriter()
+	javaIo.Load_Io_PushbackInputStream()
 	javaIo.Load_Io_RandomAccessFile()
+	javaIo.Load_Io_SequenceInputStream()
+	javaIo.Load_Io_StringReader()
+	javaIo.Load_Io_StringWriter()
 
 	// java/lang/*
 	javaLang.ClassClinitIsh() // Special case clinit for java/lang/Class.
@@ -108,15 +125,41 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaMath.Load_Math_Math_Context()
 	javaMath.Load_Math_Rounding_Mode()
 
+	// java/net/*
+	javaNet.Load_Net_DatagramPacket()
+	javaNet.Load_Net_DatagramSocket()
+	javaNet.Load_Net_HttpClient()
+	javaNet.Load_Net_HttpRequest()
+	javaNet.Load_Net_HttpResponse()
+	javaNet.Load_Net_HttpURLConnection()
+	javaNet.Load_Net_InetAddress()
+	javaNet.Load_Net_InetSocketAddress()
+	javaNet.Load_Net_MulticastSocket()
+	javaNet.Load_Net_ServerSocket()
+	javaNet.Load_Net_Socket()
+	javaNet.Load_Net_URI()
+	javaNet.Load_Net_URL()
+
 	// java/nio/*
+	javaNio.Load_Nio_Buffer()
+	javaNio.Load_Nio_ByteBuffer()
+	javaNio.Load_Nio_ByteOrder()
+	javaNio.Load_Nio_Channels_FileChannel()
+	javaNio.Load_Nio_CharBuffer()
+	javaNio.Load_Nio_Charset()
 	javaNio.Load_Nio_File_Attribute_BasicFileAttributes()
 	javaNio.Load_Nio_File_Attribute_FileTime()
+	javaNio.Load_Nio_File_Attribute_Posix()
+	javaNio.Load_Nio_File_DirectoryStream()
 	javaNio.Load_Nio_File_Files()
+	javaNio.Load_Nio_File_FileSystems()
 	javaNio.Load_Nio_File_FileVisitResult()
 	javaNio.Load_Nio_File_FileVisitor()
 	javaNio.Load_Nio_File_SimpleFileVisitor()
+	javaNio.Load_Nio_File_WatchService()
 	javaNio.Load_Nio_File_Path()
 	javaNio.Load_Nio_File_Paths()
+	javaNio.Load_Nio_MappedByteBuffer() // after Load_Nio_ByteBuffer, whose methods it inherits
 
 	// java/text/*
 	javaText.Load_Text_ChoiceFormat()
@@ -149,6 +192,11 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaSecurity.Load_Security_AlgorithmParameters()
 	javaSecurity.Load_Security_Spec_NamedParameterSpec()
 	javaSecurity.Load_Security_Spec_AlgorithmParameterSpec()
+	javaSecurity.Load_Security_KeyStore()
+	javaSecurity.Load_Security_Cert_CertificateFactory()
+	javaSecurity.Load_Security_Cert_CertPath()
+	javaSecurity.Load_Security_Cert_X509Certificate()
+	javaSecurity.Load_Security_Auth_X500Principal()
 
 	// java.time/*
 	javaTime.Load_Time_Traps()
@@ -162,6 +210,7 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaxCrypto.Load_Crypto_KeyAgreement()
 	javaxCrypto.Load_Crypto_Mac()
 	javaxCrypto.Load_Crypto_SecretKeyFactory()
+	javaxCrypto.Load_Crypto_Spec_DESedeKeySpec()
 	javaxCrypto.Load_Crypto_Spec_DHParameterSpec()
 	javaxCrypto.Load_Crypto_Spec_GCMParameterSpec()
 	javaxCrypto.Load_Crypto_Spec_IvParameterSpec()
@@ -170,16 +219,21 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaxCrypto.Load_Crypto_Spec_SecretKeySpec()
 
 	// java/util/*
+	javaUtil.Load_Util_ArrayDeque()
 	javaUtil.Load_Util_ArrayList()
 	javaUtil.Load_Util_Arrays()
 	javaUtil.Load_Util_Base64()
 	javaUtil.Load_Util_BitSet()
 	javaUtil.Load_Util_Collection()
+	javaUtil.Load_Util_Collections()
 	javaUtil.Load_Util_Concurrent_Atomic_AtomicInteger()
 	javaUtil.Load_Util_Concurrent_Atomic_Atomic_Long()
+	javaUtil.Load_Util_Concurrent_CompletableFuture()
 	javaUtil.Load_Util_Concurrent_CyclicBarrier()
 	javaUtil.Load_Util_Date()
+	javaUtil.Load_Util_Enumeration()
 	javaUtil.Load_Util_Iterator()
+	javaUtil.Load_Util_Jar_Manifest()
 	javaUtil.Load_Util_List()
 	javaUtil.Load_Util_ListIterator()
 	javaUtil.Load_Util_Set()
@@ -190,6 +244,7 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaUtil.Load_Util_Hash_Map()
 	javaUtil.Load_Util_Hash_Set()
 	javaUtil.Load_Util_HexFormat()
+	javaUtil.Load_Util_LinkedHashMap()
 	javaUtil.Load_Util_LinkedList()
 	javaUtil.Load_Util_Locale()
 	javaUtil.Load_Util_Logging_Logger()
@@ -197,15 +252,33 @@ func MTableLoadGFunctions(MTable *classloader.MT) {
 	javaUtil.Load_Util_Properties()
 	javaUtil.Load_Util_Objects()
 	javaUtil.Load_Util_Optional()
+	javaUtil.Load_Util_PriorityQueue()
 	javaUtil.Load_Util_Random()
+	javaUtil.Load_Util_Scanner()
 	javaUtil.Load_Util_TimeZone()
+	javaUtil.Load_Util_TreeMap()
+	javaUtil.Load_Util_TreeSet()
 	javaUtil.Load_Util_Vector()
 	javaUtil.Load_Util_Zip_Adler32()
 	javaUtil.Load_Util_Zip_CheckedInputStream()
 	javaUtil.Load_Util_Zip_Crc32_Crc32c()
+	javaUtil.Load_Util_Zip_Deflater()
+	javaUtil.Load_Util_Zip_GZIPInputStream()
+	javaUtil.Load_Util_Zip_GZIPOutputStream()
+	javaUtil.Load_Util_Zip_Inflater()
+	javaUtil.Load_Util_Zip_ZipEntry()
+	javaUtil.Load_Util_Zip_ZipFile()
+	javaUtil.Load_Util_Zip_ZipInputStream()
+	javaUtil.Load_Util_Zip_ZipOutputStream()
 
 	// javax.*
-	misc.Load_Javax_Net_Ssl_SSLContext()
+	javaNet.Load_Javax_Net_Ssl_SSLContext()
+	javaNet.Load_Javax_Net_Ssl_KeyManagerFactory()
+	javaNet.Load_Javax_Net_Ssl_SSLParameters()
+	javaNet.Load_Javax_Net_Ssl_SSLSession()
+	javaNet.Load_Javax_Net_Ssl_SSLSocket()
+	javaNet.Load_Javax_Net_Ssl_SSLSocketFactory()
+	javaNet.Load_Javax_Net_Ssl_TrustManagerFactory()
 
 	// jdk/internal/misc/*
 	misc.Load_Jdk_Internal_Misc_Unsafe()
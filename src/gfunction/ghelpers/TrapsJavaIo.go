@@ -26,12 +26,6 @@ func Load_Traps_Java_Io() {
 			GFunction:  TrapClass,
 		}
 
-	MethodSignatures["java/io/CharArrayWriter.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
 	MethodSignatures["java/io/DefaultFileSystem.getFileSystem()Ljava/io/FileSystem;"] =
 		GMeth{
 			ParamSlots: 0,
@@ -50,18 +44,6 @@ func Load_Traps_Java_Io() {
 			GFunction:  TrapClass,
 		}
 
-	MethodSignatures["java/io/PipedReader.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/io/StringReader.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
 	MethodSignatures["java/io/FilterOutputStream.<clinit>()V"] =
 		GMeth{
 			ParamSlots: 0,
@@ -158,16 +140,4 @@ func Load_Traps_Java_Io() {
 			GFunction:  TrapClass,
 		}
 
-	MethodSignatures["java/io/PipedWriter.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/io/StringWriter.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
 }
@@ -8,12 +8,6 @@ package ghelpers
 
 func Load_Traps_Java_Nio() {
 
-	MethodSignatures["java/nio/ByteBuffer.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
 	MethodSignatures["java/nio/channels/AsynchronousFileChannel.<clinit>()V"] =
 		GMeth{
 			ParamSlots: 0,
@@ -86,186 +80,6 @@ func Load_Traps_Java_Nio() {
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/channels/FileChannel.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.close()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.force(Z)V"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.isOpen()Z"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.lock()Ljava/nio/channels/FileLock;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.lock(JJZ)Ljava/nio/channels/FileLock;"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.map(Ljava/nio/channels/FileChannel$MapMode;JJ)Ljava/nio/MappedByteBuffer;"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.position()J"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.position(J)Ljava/nio/channels/FileChannel;"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.read(Ljava/nio/ByteBuffer;)I"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.read(Ljava/nio/ByteBuffer;J)I"] =
-		GMeth{
-			ParamSlots: 2,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.read([Ljava/nio/ByteBuffer;)J"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.read([Ljava/nio/ByteBuffer;II)J"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.size()J"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.truncate(J)Ljava/nio/channels/FileChannel;"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.transferFrom(Ljava/nio/channels/ReadableByteChannel;JJ)J"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.transferTo(JJLjava/nio/channels/WritableByteChannel;)J"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.write(Ljava/nio/ByteBuffer;)I"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.write(Ljava/nio/ByteBuffer;J)I"] =
-		GMeth{
-			ParamSlots: 2,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.write([Ljava/nio/ByteBuffer;)J"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.write([Ljava/nio/ByteBuffer;II)J"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)Ljava/nio/channels/FileChannel;"] =
-		GMeth{
-			ParamSlots: 2,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/channels/FileChannel.open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;Ljava/util/concurrent/ExecutorService;)Ljava/nio/channels/FileChannel;"] =
-		GMeth{
-			ParamSlots: 3,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.ISO_8859_1()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.US_ASCII()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.UTF_16()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.UTF_16BE()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.UTF_16LE()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/charset/StandardCharsets.UTF_8()Ljava/nio/charset/Charset;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/AccessMode.<clinit>()V"] =
 		GMeth{
 			ParamSlots: 0,
@@ -410,12 +224,6 @@ func Load_Traps_Java_Nio() {
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/Files.newInputStream(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)Ljava/io/InputStream;"] =
 		GMeth{
 			ParamSlots: 2,
@@ -524,90 +332,24 @@ func Load_Traps_Java_Nio() {
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/file/FileSystem.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/nio/file/FileSystem.close()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/FileSystem.getFileStores()Ljava/lang/Iterable;"] =
 		GMeth{
 			ParamSlots: 0,
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/file/FileSystem.getPath(Ljava/lang/String;[Ljava/lang/String;)Ljava/nio/file/Path;"] =
-		GMeth{
-			ParamSlots: 2,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/file/FileSystem.getPathMatcher(Ljava/lang/String;)Ljava/nio/file/PathMatcher;"] =
-		GMeth{
-			ParamSlots: 1,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/FileSystem.getRootDirectories()Ljava/lang/Iterable;"] =
 		GMeth{
 			ParamSlots: 0,
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/file/FileSystem.getSeparator()Ljava/lang/String;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/file/FileSystem.isOpen()Z"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/file/FileSystem.isReadOnly()Z"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/file/FileSystem.newWatchService()Ljava/nio/file/WatchService;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/FileSystem.provider()Ljava/nio/file/spi/FileSystemProvider;"] =
 		GMeth{
 			ParamSlots: 0,
 			GFunction:  TrapFunction,
 		}
 
-	MethodSignatures["java/nio/file/FileSystem.supportedFileAttributeViews()Ljava/util/Set;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
-	MethodSignatures["java/nio/file/FileSystems.<clinit>()V"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapClass,
-		}
-
-	MethodSignatures["java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;"] =
-		GMeth{
-			ParamSlots: 0,
-			GFunction:  TrapFunction,
-		}
-
 	MethodSignatures["java/nio/file/FileSystems.newFileSystem(Ljava/net/URI;[Ljava/lang/Map;)Ljava/nio/file/FileSystem;"] =
 		GMeth{
 			ParamSlots: 2,
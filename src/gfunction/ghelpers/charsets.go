@@ -0,0 +1,694 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The charsets that Jacobin supports and the conversions between their bytes and the text of
+// Go strings. The java.nio.charset classes in package javaNio wrap these in Charset,
+// CharsetEncoder and CharsetDecoder objects; String, the java.io readers and writers and
+// java.nio.file.Files use them directly.
+//
+// Decoding reports two kinds of bad input as the JDK does: malformed input, a byte sequence that
+// the charset does not allow, and unmappable input, a valid sequence with no Unicode character
+// (only the undefined bytes of windows-1252). Encoding reports unmappable characters, those
+// outside the charset's repertoire, and malformed input, which in a Go string is bytes that are
+// not UTF-8, such as the encoding of a lone surrogate.
+
+// CodingAction is what a conversion does with bad input, as java.nio.charset.CodingErrorAction.
+type CodingAction int
+
+const (
+	CodingReport CodingAction = iota
+	CodingIgnore
+	CodingReplace
+)
+
+// CodingError describes the first bad input of a conversion with CodingReport. Length is in
+// bytes when decoding and in chars when encoding.
+type CodingError struct {
+	Unmappable bool
+	Length     int
+}
+
+func (e *CodingError) Error() string {
+	if e.Unmappable {
+		return fmt.Sprintf("unmappable input of length %d", e.Length)
+	}
+	return fmt.Sprintf("malformed input of length %d", e.Length)
+}
+
+// GErrBlk returns the MalformedInputException or UnmappableCharacterException for e.
+func (e *CodingError) GErrBlk() *GErrBlk {
+	excType := excNames.MalformedInputException
+	if e.Unmappable {
+		excType = excNames.UnmappableCharacterException
+	}
+	return GetGErrBlk(excType, fmt.Sprintf("Input length = %d", e.Length))
+}
+
+type charsetKind int
+
+const (
+	singleByteCharset charsetKind = iota
+	utf8Charset
+	utf16BECharset
+	utf16LECharset
+	utf16Charset // big-endian unless a byte-order mark says otherwise; encodes with a mark
+)
+
+type Charset struct {
+	Name                string
+	Aliases             []string
+	HistoricalName      string  // as returned by InputStreamReader.getEncoding()
+	AverageBytesPerChar float64 // as CharsetEncoder reports them
+	MaxBytesPerChar     float64
+	AverageCharsPerByte float64 // as CharsetDecoder reports them
+	MaxCharsPerByte     float64
+	kind                charsetKind
+	table               *[256]rune    // single-byte charsets: the char of each byte, or -1
+	reverse             map[rune]byte // single-byte charsets: the byte of each char
+}
+
+const unmappedByte = -1
+
+func singleByteTable(limit int, overrides map[byte]rune) *[256]rune {
+	var table [256]rune
+	for ix := range table {
+		table[ix] = unmappedByte
+		if ix < limit {
+			table[ix] = rune(ix)
+		}
+	}
+	for b, r := range overrides {
+		table[b] = r
+	}
+	return &table
+}
+
+// windows1252 maps the bytes 0x80-0x9F, which are C1 controls in ISO-8859-1. The five bytes it
+// leaves undefined are unmappable, as in the JDK.
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x81: unmappedByte, 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…',
+	0x86: '†', 0x87: '‡', 0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹',
+	0x8C: 'Œ', 0x8D: unmappedByte, 0x8E: 'Ž', 0x8F: unmappedByte, 0x90: unmappedByte,
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–',
+	0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ',
+	0x9D: unmappedByte, 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+var (
+	CharsetUTF8 = &Charset{Name: "UTF-8", Aliases: []string{"unicode-1-1-utf-8", "UTF8"},
+		HistoricalName: "UTF8", AverageBytesPerChar: 1.1, MaxBytesPerChar: 3, AverageCharsPerByte: 1,
+		MaxCharsPerByte: 1, kind: utf8Charset}
+	CharsetUTF16BE = &Charset{Name: "UTF-16BE", Aliases: []string{"UTF_16BE", "ISO-10646-UCS-2", "X-UTF-16BE", "UnicodeBigUnmarked"},
+		HistoricalName: "UnicodeBigUnmarked", AverageBytesPerChar: 2, MaxBytesPerChar: 2, AverageCharsPerByte: 0.5,
+		MaxCharsPerByte: 1, kind: utf16BECharset}
+	CharsetUTF16LE = &Charset{Name: "UTF-16LE", Aliases: []string{"UnicodeLittleUnmarked", "UTF_16LE", "X-UTF-16LE"},
+		HistoricalName: "UnicodeLittleUnmarked", AverageBytesPerChar: 2, MaxBytesPerChar: 2, AverageCharsPerByte: 0.5,
+		MaxCharsPerByte: 1, kind: utf16LECharset}
+	CharsetUTF16 = &Charset{Name: "UTF-16", Aliases: []string{"UTF_16", "utf16", "unicode", "UnicodeBig"},
+		HistoricalName: "UTF-16", AverageBytesPerChar: 2, MaxBytesPerChar: 4, AverageCharsPerByte: 0.5,
+		MaxCharsPerByte: 1, kind: utf16Charset}
+	CharsetUSASCII = &Charset{Name: "US-ASCII", Aliases: []string{"iso-ir-6", "ANSI_X3.4-1986", "ISO_646.irv:1991",
+		"ASCII", "iso_646.irv:1983", "ANSI_X3.4-1968", "ISO646-US", "default", "cp367", "csASCII", "ibm367",
+		"us", "646", "IBM367", "ascii7"},
+		HistoricalName: "ASCII", AverageBytesPerChar: 1, MaxBytesPerChar: 1, AverageCharsPerByte: 1,
+		MaxCharsPerByte: 1, kind: singleByteCharset, table: singleByteTable(0x80, nil)}
+	CharsetISO88591 = &Charset{Name: "ISO-8859-1", Aliases: []string{"iso-ir-100", "ISO_8859-1", "latin1", "l1",
+		"IBM819", "ISO_8859-1:1987", "csISOLatin1", "819", "ISO8859-1", "8859_1", "cp819", "ISO8859_1",
+		"IBM-819", "ISO_8859_1"},
+		HistoricalName: "ISO8859_1", AverageBytesPerChar: 1, MaxBytesPerChar: 1, AverageCharsPerByte: 1,
+		MaxCharsPerByte: 1, kind: singleByteCharset, table: singleByteTable(0x100, nil)}
+	CharsetWindows1252 = &Charset{Name: "windows-1252", Aliases: []string{"cp1252", "cp5348", "ibm-1252", "ibm1252"},
+		HistoricalName: "Cp1252", AverageBytesPerChar: 1, MaxBytesPerChar: 1, AverageCharsPerByte: 1,
+		MaxCharsPerByte: 1, kind: singleByteCharset, table: singleByteTable(0x100, windows1252)}
+)
+
+// Charsets lists the supported charsets, sorted by name as Charset.availableCharsets() is.
+var Charsets = []*Charset{CharsetISO88591, CharsetUSASCII, CharsetUTF16, CharsetUTF16BE, CharsetUTF16LE,
+	CharsetUTF8, CharsetWindows1252}
+
+func init() {
+	for _, cs := range Charsets {
+		if cs.table != nil {
+			cs.reverse = make(map[rune]byte)
+			for b, r := range cs.table {
+				if r != unmappedByte {
+					cs.reverse[r] = byte(b)
+				}
+			}
+		}
+	}
+	sort.Slice(Charsets, func(i, j int) bool {
+		return strings.ToUpper(Charsets[i].Name) < strings.ToUpper(Charsets[j].Name)
+	})
+}
+
+// LookupCharset returns the charset with the given name or alias, ignoring case, or nil.
+func LookupCharset(name string) *Charset {
+	for _, cs := range Charsets {
+		if strings.EqualFold(cs.Name, name) {
+			return cs
+		}
+		for _, alias := range cs.Aliases {
+			if strings.EqualFold(alias, name) {
+				return cs
+			}
+		}
+	}
+	return nil
+}
+
+// IsLegalCharsetName reports whether name follows the rules for charset names: letters, digits
+// and the characters - + : _ . with a letter or digit first.
+func IsLegalCharsetName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for ix, ch := range name {
+		switch {
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9':
+		case ix > 0 && strings.ContainsRune("-+:_.", ch):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultCharset returns the charset of globals.FileEncoding, or UTF-8 if it is not supported.
+func DefaultCharset() *Charset {
+	if cs := LookupCharset(globals.GetCharsetName()); cs != nil {
+		return cs
+	}
+	return CharsetUTF8
+}
+
+// CharsetFromObject returns the charset of a java.nio.charset Charset, CharsetEncoder or
+// CharsetDecoder object: from the Charset's name field, or the coder's charset field.
+func CharsetFromObject(arg any) (*Charset, *GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, GetGErrBlk(excNames.NullPointerException, "charset is null")
+	}
+	if coderCharset, ok := obj.FieldTable["charset"].Fvalue.(*object.Object); ok {
+		obj = coderCharset
+	}
+	nameObj, ok := obj.FieldTable["name"].Fvalue.(*object.Object)
+	if !ok {
+		return nil, GetGErrBlk(excNames.IllegalArgumentException, "not a charset")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	cs := LookupCharset(name)
+	if cs == nil {
+		return nil, GetGErrBlk(excNames.UnsupportedCharsetException, name)
+	}
+	return cs, nil
+}
+
+// CharsetFromName returns the charset for the charset name argument of a java.io constructor or
+// String method, which throw UnsupportedEncodingException for a name they do not know.
+func CharsetFromName(arg any) (*Charset, *GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, GetGErrBlk(excNames.NullPointerException, "charsetName is null")
+	}
+	name := object.GoStringFromStringObject(obj)
+	cs := LookupCharset(name)
+	if cs == nil {
+		return nil, GetGErrBlk(excNames.UnsupportedEncodingException, name)
+	}
+	return cs, nil
+}
+
+// DefaultReplacement returns the bytes that an encoder for cs writes in place of bad input.
+func (cs *Charset) DefaultReplacement() []byte {
+	switch cs.kind {
+	case utf16BECharset, utf16Charset:
+		return []byte{0xFF, 0xFD}
+	case utf16LECharset:
+		return []byte{0xFD, 0xFF}
+	}
+	return []byte{'?'}
+}
+
+// CanEncode reports whether cs has a byte sequence for the character r.
+func (cs *Charset) CanEncode(r rune) bool {
+	if utf16.IsSurrogate(r) {
+		return false
+	}
+	_, ok := cs.encodeRune(nil, r)
+	return ok
+}
+
+// Contains reports whether every character that other can encode, cs can encode too.
+func (cs *Charset) Contains(other *Charset) bool {
+	switch {
+	case cs == other, cs.table == nil:
+		return true
+	case other.table == nil:
+		return false
+	}
+	for r := range other.reverse {
+		if _, ok := cs.reverse[r]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// --- decoding ---
+
+// decodeRune decodes the char at the start of p in the byte order given by bigEndian, which
+// matters only for UTF-16. It returns the char and the number of bytes it used; bad describes
+// the first n bytes if they are not a char. n is 0 if p is the start of a char that needs more
+// bytes, and eof says that there will be none.
+func (cs *Charset) decodeRune(p []byte, bigEndian, eof bool) (r rune, n int, bad *CodingError) {
+	if len(p) == 0 {
+		return 0, 0, nil
+	}
+	switch cs.kind {
+	case singleByteCharset:
+		if r = cs.table[p[0]]; r == unmappedByte {
+			return 0, 1, &CodingError{Unmappable: cs != CharsetUSASCII, Length: 1}
+		}
+		return r, 1, nil
+	case utf8Charset:
+		if !utf8.FullRune(p) {
+			if eof {
+				return 0, len(p), &CodingError{Length: len(p)}
+			}
+			return 0, 0, nil
+		}
+		r, n = utf8.DecodeRune(p)
+		if r == utf8.RuneError && n == 1 {
+			return 0, 1, &CodingError{Length: 1}
+		}
+		return r, n, nil
+	}
+
+	unit := func(b []byte) rune {
+		if bigEndian {
+			return rune(b[0])<<8 | rune(b[1])
+		}
+		return rune(b[1])<<8 | rune(b[0])
+	}
+	if len(p) < 2 {
+		if eof {
+			return 0, 1, &CodingError{Length: 1}
+		}
+		return 0, 0, nil
+	}
+	r = unit(p)
+	switch {
+	case utf16.IsSurrogate(r) && r >= 0xDC00:
+		return 0, 2, &CodingError{Length: 2}
+	case !utf16.IsSurrogate(r):
+		return r, 2, nil
+	case len(p) < 4:
+		if eof {
+			return 0, 2, &CodingError{Length: 2}
+		}
+		return 0, 0, nil
+	}
+	low := unit(p[2:])
+	if low < 0xDC00 || low > 0xDFFF {
+		return 0, 2, &CodingError{Length: 2}
+	}
+	return utf16.DecodeRune(r, low), 4, nil
+}
+
+// byteOrderMark returns the byte order of UTF-16 input and the length of its byte-order mark.
+func (cs *Charset) byteOrderMark(p []byte) (bigEndian bool, skip int) {
+	switch cs.kind {
+	case utf16LECharset:
+		return false, 0
+	case utf16Charset:
+		if len(p) >= 2 && p[0] == 0xFE && p[1] == 0xFF {
+			return true, 2
+		}
+		if len(p) >= 2 && p[0] == 0xFF && p[1] == 0xFE {
+			return false, 2
+		}
+	}
+	return true, 0
+}
+
+// Decode converts b to a string. Bad input is reported, skipped or replaced by replacement,
+// according to the actions. A reported error stops the conversion.
+func (cs *Charset) Decode(b []byte, malformed, unmappable CodingAction, replacement string) (string, *CodingError) {
+	bigEndian, ix := cs.byteOrderMark(b)
+	var sb strings.Builder
+	for ix < len(b) {
+		r, n, bad := cs.decodeRune(b[ix:], bigEndian, true)
+		if bad != nil {
+			action := malformed
+			if bad.Unmappable {
+				action = unmappable
+			}
+			switch action {
+			case CodingReport:
+				return sb.String(), bad
+			case CodingReplace:
+				sb.WriteString(replacement)
+			}
+		} else {
+			sb.WriteRune(r)
+		}
+		ix += n
+	}
+	return sb.String(), nil
+}
+
+// DecodeReplacing converts b to a string, replacing bad input with U+FFFD, as the String
+// constructors and the java.io readers do.
+func (cs *Charset) DecodeReplacing(b []byte) string {
+	s, _ := cs.Decode(b, CodingReplace, CodingReplace, "\uFFFD")
+	return s
+}
+
+// --- encoding ---
+
+// encodeRune appends the bytes of r to dst. It returns false if cs cannot encode r.
+func (cs *Charset) encodeRune(dst []byte, r rune) ([]byte, bool) {
+	switch cs.kind {
+	case singleByteCharset:
+		b, ok := cs.reverse[r]
+		if !ok {
+			return dst, false
+		}
+		return append(dst, b), true
+	case utf8Charset:
+		return utf8.AppendRune(dst, r), true
+	}
+	units := []rune{r}
+	if r > 0xFFFF {
+		high, low := utf16.EncodeRune(r)
+		units = []rune{high, low}
+	}
+	for _, u := range units {
+		if cs.kind == utf16LECharset {
+			dst = append(dst, byte(u), byte(u>>8))
+		} else {
+			dst = append(dst, byte(u>>8), byte(u))
+		}
+	}
+	return dst, true
+}
+
+// Encode converts the text of s to bytes. Bad input is reported, skipped or replaced by
+// replacement, according to the actions. A reported error stops the conversion.
+func (cs *Charset) Encode(s string, malformed, unmappable CodingAction, replacement []byte) ([]byte, *CodingError) {
+	return cs.EncodeChars(charsOf(s), malformed, unmappable, replacement)
+}
+
+// charsOf returns the characters of s. Bytes that are not UTF-8 become lone surrogates, which
+// are malformed input to every charset.
+func charsOf(s string) []rune {
+	chars := make([]rune, 0, len(s))
+	for ix := 0; ix < len(s); {
+		r, n := utf8.DecodeRuneInString(s[ix:])
+		if r == utf8.RuneError && n == 1 {
+			r = 0xDFFF
+		}
+		chars = append(chars, r)
+		ix += n
+	}
+	return chars
+}
+
+// EncodeChars converts Java chars to bytes, as Encode does. A surrogate pair is one character; a
+// lone surrogate is malformed input.
+func (cs *Charset) EncodeChars(chars []rune, malformed, unmappable CodingAction, replacement []byte) ([]byte, *CodingError) {
+	var out []byte
+	if cs.kind == utf16Charset && len(chars) > 0 {
+		out = append(out, 0xFE, 0xFF)
+	}
+	for ix := 0; ix < len(chars); ix++ {
+		r := chars[ix]
+		var bad *CodingError
+		if utf16.IsSurrogate(r) {
+			if r < 0xDC00 && ix+1 < len(chars) && chars[ix+1] >= 0xDC00 && chars[ix+1] <= 0xDFFF {
+				ix++
+				r = utf16.DecodeRune(r, chars[ix])
+			} else {
+				bad = &CodingError{Length: 1}
+			}
+		}
+		if bad == nil {
+			var ok bool
+			if out, ok = cs.encodeRune(out, r); !ok {
+				bad = &CodingError{Unmappable: true, Length: len(utf16.Encode([]rune{r}))}
+			}
+		}
+		if bad != nil {
+			action := malformed
+			if bad.Unmappable {
+				action = unmappable
+			}
+			switch action {
+			case CodingReport:
+				return out, bad
+			case CodingReplace:
+				out = append(out, replacement...)
+			}
+		}
+	}
+	return out, nil
+}
+
+// EncodeReplacing converts the text of s to bytes, replacing what cs cannot encode, as
+// String.getBytes() and the java.io writers do.
+func (cs *Charset) EncodeReplacing(s string) []byte {
+	b, _ := cs.Encode(s, CodingReplace, CodingReplace, cs.DefaultReplacement())
+	return b
+}
+
+// --- streams ---
+
+// CharReader decodes chars from a byte stream, one at a time, reading no more bytes than it
+// needs. Bad input becomes U+FFFD unless the actions say otherwise; a reported error is returned
+// as a *CodingError.
+type CharReader struct {
+	Malformed  CodingAction
+	Unmappable CodingAction
+	cs         *Charset
+	r          io.Reader
+	pending    []byte // bytes read but not yet decoded
+	started    bool   // the byte-order mark has been looked for
+	bigEndian  bool
+	lowChar    rune // the low surrogate of a supplementary char that ReadChar split, or 0
+}
+
+func NewCharReader(cs *Charset, r io.Reader) *CharReader {
+	return &CharReader{Malformed: CodingReplace, Unmappable: CodingReplace, cs: cs, r: r, bigEndian: true}
+}
+
+// SetReader makes cr read what follows from r. Bytes that cr has read but not yet decoded are
+// decoded first. G functions that read a Java stream set a reader that uses their frame stack.
+func (cr *CharReader) SetReader(r io.Reader) {
+	cr.r = r
+}
+
+// Buffered reports whether cr can return a char without reading more bytes.
+func (cr *CharReader) Buffered() bool {
+	return len(cr.pending) > 0 || cr.lowChar != 0
+}
+
+// Over returns a new CharReader of r with the charset and the actions of cr.
+func (cr *CharReader) Over(r io.Reader) *CharReader {
+	ncr := NewCharReader(cr.cs, r)
+	ncr.Malformed, ncr.Unmappable = cr.Malformed, cr.Unmappable
+	return ncr
+}
+
+// Charset returns the charset that cr decodes.
+func (cr *CharReader) Charset() *Charset {
+	return cr.cs
+}
+
+// fill reads one more byte into the pending bytes.
+func (cr *CharReader) fill() error {
+	var b [1]byte
+	for {
+		n, err := cr.r.Read(b[:])
+		if n == 1 {
+			cr.pending = append(cr.pending, b[0])
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ReadCodePoint returns the next character. It returns io.EOF at the end of the stream.
+func (cr *CharReader) ReadCodePoint() (rune, error) {
+	if cr.lowChar != 0 {
+		r := cr.lowChar
+		cr.lowChar = 0
+		return r, nil
+	}
+	if !cr.started && cr.cs.kind == utf16Charset {
+		for len(cr.pending) < 2 {
+			if err := cr.fill(); err != nil {
+				break
+			}
+		}
+		var skip int
+		cr.bigEndian, skip = cr.cs.byteOrderMark(cr.pending)
+		cr.pending = cr.pending[skip:]
+	} else if !cr.started {
+		cr.bigEndian, _ = cr.cs.byteOrderMark(nil)
+	}
+	cr.started = true
+
+	eof := false
+	for {
+		if len(cr.pending) == 0 && eof {
+			return 0, io.EOF
+		}
+		r, n, bad := cr.cs.decodeRune(cr.pending, cr.bigEndian, eof)
+		if n > 0 {
+			cr.pending = cr.pending[n:]
+			if bad == nil {
+				return r, nil
+			}
+			action := cr.Malformed
+			if bad.Unmappable {
+				action = cr.Unmappable
+			}
+			switch action {
+			case CodingReport:
+				return 0, bad
+			case CodingReplace:
+				return '�', nil
+			}
+			continue
+		}
+		if err := cr.fill(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return 0, err
+			}
+			eof = true
+		}
+	}
+}
+
+// ReadChar returns the next Java char, which is half of a surrogate pair for a supplementary
+// character. It returns io.EOF at the end of the stream.
+func (cr *CharReader) ReadChar() (rune, error) {
+	if cr.lowChar != 0 {
+		return cr.ReadCodePoint()
+	}
+	r, err := cr.ReadCodePoint()
+	if err == nil && r > 0xFFFF {
+		high, low := utf16.EncodeRune(r)
+		r, cr.lowChar = high, low
+	}
+	return r, err
+}
+
+// CharEncoder encodes the chars written to a writer. A high surrogate at the end of one write is
+// kept for the next, so that a supplementary character can be written a char at a time.
+type CharEncoder struct {
+	Malformed   CodingAction
+	Unmappable  CodingAction
+	Replacement []byte
+	cs          *Charset
+	high        rune // a high surrogate from the previous write, or 0
+	started     bool // a UTF-16 byte-order mark has been written
+}
+
+func NewCharEncoder(cs *Charset) *CharEncoder {
+	return &CharEncoder{Malformed: CodingReplace, Unmappable: CodingReplace, Replacement: cs.DefaultReplacement(), cs: cs}
+}
+
+// Charset returns the charset that ce encodes.
+func (ce *CharEncoder) Charset() *Charset {
+	return ce.cs
+}
+
+// Encode returns the bytes of chars. A reported error is returned as a *CodingError.
+func (ce *CharEncoder) Encode(chars []rune) ([]byte, error) {
+	if ce.high != 0 {
+		chars = append([]rune{ce.high}, chars...)
+		ce.high = 0
+	}
+	if n := len(chars); n > 0 && chars[n-1] >= 0xD800 && chars[n-1] < 0xDC00 {
+		ce.high = chars[n-1]
+		chars = chars[:n-1]
+	}
+	if len(chars) == 0 {
+		return nil, nil
+	}
+	out, bad := ce.cs.EncodeChars(chars, ce.Malformed, ce.Unmappable, ce.Replacement)
+	if ce.cs.kind == utf16Charset {
+		if ce.started {
+			out = out[2:] // only the first write has the byte-order mark
+		}
+		ce.started = true
+	}
+	if bad != nil {
+		return out, bad
+	}
+	return out, nil
+}
+
+// WriterEncoder returns the encoder of a java.io writer object, which is made on the first write
+// in the writer's charset, or the default charset.
+func WriterEncoder(obj *object.Object) *CharEncoder {
+	if ce, ok := obj.FieldTable[FileEncoder].Fvalue.(*CharEncoder); ok {
+		return ce
+	}
+	cs, ok := obj.FieldTable[FileCharset].Fvalue.(*Charset)
+	if !ok {
+		cs = DefaultCharset()
+	}
+	ce := NewCharEncoder(cs)
+	obj.FieldTable[FileEncoder] = object.Field{Ftype: types.RawGoPointer, Fvalue: ce}
+	return ce
+}
+
+// encodingWriter converts the UTF-8 text written to it to another charset.
+type encodingWriter struct {
+	ce *CharEncoder
+	w  io.Writer
+}
+
+func (ew encodingWriter) Write(p []byte) (int, error) {
+	b, codingErr := ew.ce.Encode(charsOf(string(p)))
+	if _, err := ew.w.Write(b); err != nil {
+		return 0, err
+	}
+	if codingErr != nil {
+		return 0, codingErr
+	}
+	return len(p), nil
+}
+
+// EncodingWriter returns a writer that converts the UTF-8 text written to it with ce and
+// writes the result to w. Each Write must hold whole characters.
+func EncodingWriter(w io.Writer, ce *CharEncoder) io.Writer {
+	if ce == nil || (ce.cs == CharsetUTF8 && ce.Malformed == CodingReplace) {
+		return w
+	}
+	return encodingWriter{ce: ce, w: w}
+}
@@ -0,0 +1,162 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"jacobin/src/excNames"
+	"testing"
+)
+
+func TestLookupCharset_NamesAndAliases(t *testing.T) {
+	tests := map[string]*Charset{
+		"UTF-8":        CharsetUTF8,
+		"utf8":         CharsetUTF8,
+		"latin1":       CharsetISO88591,
+		"ISO8859_1":    CharsetISO88591,
+		"ascii":        CharsetUSASCII,
+		"Cp1252":       CharsetWindows1252,
+		"UTF-16LE":     CharsetUTF16LE,
+		"x-no-charset": nil,
+	}
+	for name, want := range tests {
+		if got := LookupCharset(name); got != want {
+			t.Errorf("LookupCharset(%q): got %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestIsLegalCharsetName(t *testing.T) {
+	for _, name := range []string{"UTF-8", "x.y:z_1+2"} {
+		if !IsLegalCharsetName(name) {
+			t.Errorf("IsLegalCharsetName(%q): got false", name)
+		}
+	}
+	for _, name := range []string{"", "-UTF8", "UTF 8", "é"} {
+		if IsLegalCharsetName(name) {
+			t.Errorf("IsLegalCharsetName(%q): got true", name)
+		}
+	}
+}
+
+func TestCharset_RoundTrips(t *testing.T) {
+	tests := []struct {
+		cs    *Charset
+		text  string
+		bytes []byte
+	}{
+		{CharsetUTF8, "aé€", []byte{'a', 0xC3, 0xA9, 0xE2, 0x82, 0xAC}},
+		{CharsetISO88591, "aé", []byte{'a', 0xE9}},
+		{CharsetWindows1252, "a€é", []byte{'a', 0x80, 0xE9}},
+		{CharsetUSASCII, "abc", []byte("abc")},
+		{CharsetUTF16BE, "a😀", []byte{0, 'a', 0xD8, 0x3D, 0xDE, 0x00}},
+		{CharsetUTF16LE, "a😀", []byte{'a', 0, 0x3D, 0xD8, 0x00, 0xDE}},
+		{CharsetUTF16, "a", []byte{0xFE, 0xFF, 0, 'a'}},
+	}
+	for _, tt := range tests {
+		b, bad := tt.cs.Encode(tt.text, CodingReport, CodingReport, nil)
+		if bad != nil || !bytes.Equal(b, tt.bytes) {
+			t.Errorf("%s: Encode(%q): got % X, %v", tt.cs.Name, tt.text, b, bad)
+		}
+		s, bad := tt.cs.Decode(tt.bytes, CodingReport, CodingReport, "")
+		if bad != nil || s != tt.text {
+			t.Errorf("%s: Decode(% X): got %q, %v", tt.cs.Name, tt.bytes, s, bad)
+		}
+	}
+}
+
+func TestCharset_DecodeUTF16LittleEndianBOM(t *testing.T) {
+	s, bad := CharsetUTF16.Decode([]byte{0xFF, 0xFE, 'h', 0, 'i', 0}, CodingReport, CodingReport, "")
+	if bad != nil || s != "hi" {
+		t.Errorf("got %q, %v", s, bad)
+	}
+}
+
+func TestCharset_ErrorActions(t *testing.T) {
+	// 0x81 is not mapped in windows-1252
+	if _, bad := CharsetWindows1252.Decode([]byte{'a', 0x81}, CodingReport, CodingReport, ""); bad == nil || !bad.Unmappable {
+		t.Errorf("windows-1252 0x81: expected an unmappable character, got %v", bad)
+	}
+	if s, _ := CharsetWindows1252.Decode([]byte{'a', 0x81, 'b'}, CodingReport, CodingIgnore, ""); s != "ab" {
+		t.Errorf("windows-1252 ignore: got %q", s)
+	}
+	if s := CharsetUTF8.DecodeReplacing([]byte{'a', 0xFF}); s != "a\uFFFD" {
+		t.Errorf("UTF-8 replace: got %q", s)
+	}
+	if _, bad := CharsetUTF8.Decode([]byte{0xC3}, CodingReport, CodingReport, ""); bad == nil || bad.Unmappable {
+		t.Errorf("truncated UTF-8: expected malformed input, got %v", bad)
+	}
+
+	if b := CharsetUSASCII.EncodeReplacing("né"); string(b) != "n?" {
+		t.Errorf("US-ASCII replace: got %q", b)
+	}
+	_, bad := CharsetISO88591.Encode("€", CodingReport, CodingReport, nil)
+	if bad == nil || bad.GErrBlk().ExceptionType != excNames.UnmappableCharacterException ||
+		bad.GErrBlk().ErrMsg != "Input length = 1" {
+		t.Errorf("ISO-8859-1 €: expected an unmappable character, got %v", bad)
+	}
+	if _, bad := CharsetUTF8.EncodeChars([]rune{0xD800, 'a'}, CodingReport, CodingReport, nil); bad == nil || bad.Unmappable {
+		t.Errorf("lone surrogate: expected malformed input, got %v", bad)
+	}
+}
+
+func TestCharReader_ReadsSplitCharacters(t *testing.T) {
+	cr := NewCharReader(CharsetUTF8, io.MultiReader(bytes.NewReader([]byte{'a', 0xF0, 0x9F}),
+		bytes.NewReader([]byte{0x98, 0x80})))
+	var got []rune
+	for {
+		ch, err := cr.ReadChar()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadChar: %v", err)
+		}
+		got = append(got, ch)
+	}
+	want := []rune{'a', 0xD83D, 0xDE00}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("got %X, want %X", got, want)
+	}
+}
+
+func TestCharReader_Report(t *testing.T) {
+	cr := NewCharReader(CharsetUSASCII, bytes.NewReader([]byte{0xE9}))
+	cr.Malformed = CodingReport
+	var codingErr *CodingError
+	if _, err := cr.ReadCodePoint(); !errors.As(err, &codingErr) {
+		t.Errorf("expected a *CodingError, got %v", err)
+	}
+}
+
+func TestCharEncoder_KeepsSurrogatePairsAndBOM(t *testing.T) {
+	ce := NewCharEncoder(CharsetUTF16)
+	first, err := ce.Encode([]rune{'a', 0xD83D})
+	if err != nil || !bytes.Equal(first, []byte{0xFE, 0xFF, 0, 'a'}) {
+		t.Errorf("first write: got % X, %v", first, err)
+	}
+	second, err := ce.Encode([]rune{0xDE00})
+	if err != nil || !bytes.Equal(second, []byte{0xD8, 0x3D, 0xDE, 0x00}) {
+		t.Errorf("second write: got % X, %v", second, err)
+	}
+}
+
+func TestEncodingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := EncodingWriter(&buf, NewCharEncoder(CharsetISO88591))
+	if _, err := io.WriteString(w, "café"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{'c', 'a', 'f', 0xE9}) {
+		t.Errorf("got % X", buf.Bytes())
+	}
+	if EncodingWriter(&buf, NewCharEncoder(CharsetUTF8)) != io.Writer(&buf) {
+		t.Errorf("a UTF-8 writer should be returned unchanged")
+	}
+}
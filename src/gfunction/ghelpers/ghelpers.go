@@ -51,11 +51,14 @@ func GetGErrBlk(exceptionType int, errMsg string) *GErrBlk {
 }
 
 // File I/O and stream Field keys:
-var FileStatus string = "status"     // using this value in case some member function is looking at it
-var FilePath string = "FilePath"     // full absolute path of a file aka canonical path
-var FileHandle string = "FileHandle" // *os.File
-var FileMark string = "FileMark"     // file position relative to beginning (0)
-var FileAtEOF string = "FileAtEOF"   // file at EOF
+var FileStatus string = "status"       // using this value in case some member function is looking at it
+var FilePath string = "FilePath"       // full absolute path of a file aka canonical path
+var FileHandle string = "FileHandle"   // *os.File
+var FileMark string = "FileMark"       // file position relative to beginning (0)
+var FileAtEOF string = "FileAtEOF"     // file at EOF
+var FileCharset string = "FileCharset" // *Charset that a reader or writer converts with; the default charset if absent
+var FileDecoder string = "FileDecoder" // *CharReader of a reader, made on its first read
+var FileEncoder string = "FileEncoder" // *CharEncoder of a writer, made on its first write
 
 // File I/O constants:
 var CreateFilePermissions os.FileMode = 0664 // When creating, read and write for user and group, others read-only
@@ -0,0 +1,176 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/frames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/stringPool"
+	"jacobin/src/types"
+	"jacobin/src/util"
+	"strings"
+)
+
+// SplitContext separates the JVM frame stack from the rest of the parameters of a G function
+// that is registered with NeedsContext: true. RunGfunction places the frame stack at params[0].
+// If params[0] is not a frame stack (e.g., in unit tests), the frame stack returned is nil and
+// params is returned unchanged.
+func SplitContext(params []interface{}) (*list.List, []interface{}) {
+	if len(params) > 0 {
+		if fs, ok := params[0].(*list.List); ok {
+			return fs, params[1:]
+		}
+	}
+	return nil, params
+}
+
+// FindInstanceMethod searches the class of obj and then its superclasses for the method
+// methName+methType, in the same order that invokevirtual would. It returns the MTable entry
+// and the name of the class that holds the method. G functions take precedence over Java methods
+// at each level of the class hierarchy. If nothing is found, the returned class name is empty.
+func FindInstanceMethod(obj *object.Object, methName, methType string) (classloader.MTentry, string) {
+	if obj == nil || object.IsNull(obj) {
+		return classloader.MTentry{}, ""
+	}
+	className := stringPool.GetStringPointer(obj.KlassName)
+	if className == nil {
+		return classloader.MTentry{}, ""
+	}
+	currClass := *className
+	for currClass != "" {
+		fqn := currClass + "." + methName + methType
+		if gm, ok := MethodSignatures[fqn]; ok {
+			return classloader.MTentry{Meth: gm, MType: 'G'}, currClass
+		}
+
+		klass := classloader.MethAreaFetch(currClass)
+		if klass == nil || klass.Data == nil {
+			break
+		}
+		if _, ok := klass.Data.MethodTable[methName+methType]; ok {
+			mte, err := classloader.FetchMethodAndCP(currClass, methName, methType)
+			if err == nil && mte.Meth != nil {
+				return mte, currClass
+			}
+		}
+
+		if currClass == types.ObjectClassName || klass.Data.SuperclassIndex == types.InvalidStringIndex {
+			break
+		}
+		currClass = *stringPool.GetStringPointer(klass.Data.SuperclassIndex)
+	}
+	return classloader.MTentry{}, ""
+}
+
+// HasJavaOverride reports whether the class of obj, or one of its superclasses below stopClass,
+// implements methName+methType in Java bytecode. G functions use this to decide whether a
+// user subclass has overridden a method that would otherwise be handled natively.
+func HasJavaOverride(obj *object.Object, methName, methType, stopClass string) bool {
+	mte, clName := FindInstanceMethod(obj, methName, methType)
+	return clName != "" && clName != stopClass && mte.MType == 'J'
+}
+
+// InvokeMethodOnObject calls the instance method methName+methType on obj, dispatching through
+// the class hierarchy as invokevirtual would. G functions are called directly; Java methods are
+// run via RunJavaFromG on the frame stack fs. The method's return value is returned, or nil for
+// a void method. If the method cannot be found or run, a *GErrBlk is returned.
+func InvokeMethodOnObject(fs *list.List, obj *object.Object, methName, methType string, args ...any) any {
+	if obj == nil || object.IsNull(obj) {
+		errMsg := fmt.Sprintf("InvokeMethodOnObject: cannot invoke %s%s on a null object", methName, methType)
+		return GetGErrBlk(excNames.NullPointerException, errMsg)
+	}
+
+	mte, clName := FindInstanceMethod(obj, methName, methType)
+	if clName == "" {
+		className := object.GoStringFromStringPoolIndex(obj.KlassName)
+		errMsg := fmt.Sprintf("InvokeMethodOnObject: method %s.%s%s not found", className, methName, methType)
+		return GetGErrBlk(excNames.NoSuchMethodError, errMsg)
+	}
+	return invokeMethodEntry(fs, obj, mte, clName, methName, methType, args)
+}
+
+// InvokeMethodInClass calls methName+methType as it is declared in className on obj, without
+// virtual dispatch, as invokespecial would. It is used for constructors and for private methods,
+// such as the readObject and writeObject hooks of serializable classes. The return value is
+// that of InvokeMethodOnObject.
+func InvokeMethodInClass(fs *list.List, obj *object.Object, className, methName, methType string, args ...any) any {
+	if obj == nil || object.IsNull(obj) {
+		errMsg := fmt.Sprintf("InvokeMethodInClass: cannot invoke %s.%s%s on a null object", className, methName, methType)
+		return GetGErrBlk(excNames.NullPointerException, errMsg)
+	}
+
+	if gm, ok := MethodSignatures[className+"."+methName+methType]; ok {
+		return invokeMethodEntry(fs, obj, classloader.MTentry{Meth: gm, MType: 'G'}, className, methName, methType, args)
+	}
+	klass := classloader.MethAreaFetch(className)
+	if klass != nil && klass.Data != nil {
+		if _, ok := klass.Data.MethodTable[methName+methType]; ok {
+			mte, err := classloader.FetchMethodAndCP(className, methName, methType)
+			if err == nil && mte.Meth != nil {
+				return invokeMethodEntry(fs, obj, mte, className, methName, methType, args)
+			}
+		}
+	}
+	errMsg := fmt.Sprintf("InvokeMethodInClass: method %s.%s%s not found", className, methName, methType)
+	return GetGErrBlk(excNames.NoSuchMethodError, errMsg)
+}
+
+// invokeMethodEntry runs the method mte, which was found in clName, on obj.
+func invokeMethodEntry(fs *list.List, obj *object.Object, mte classloader.MTentry, clName, methName, methType string, args []any) any {
+	switch mte.MType {
+	case 'G':
+		gm := mte.Meth.(GMeth)
+		params := append([]any{obj}, args...)
+		if gm.NeedsContext {
+			params = append([]any{fs}, params...)
+		}
+		return gm.GFunction(params)
+
+	case 'J':
+		if fs == nil || fs.Len() == 0 {
+			errMsg := fmt.Sprintf("InvokeMethodOnObject: no frame stack available to run %s.%s%s",
+				clName, methName, methType)
+			return GetGErrBlk(excNames.IllegalStateException, errMsg)
+		}
+
+		// Longs and doubles occupy two local-variable slots in the called frame.
+		locals := []any{obj}
+		paramTypes := util.ParseIncomingParamsFromMethTypeString(methType)
+		for ix, arg := range args {
+			locals = append(locals, arg)
+			if ix < len(paramTypes) && (paramTypes[ix] == types.Long || paramTypes[ix] == types.Double) {
+				locals = append(locals, int64(0))
+			}
+		}
+
+		caller := fs.Front().Value.(*frames.Frame)
+		tosBefore := caller.TOS
+		globals.GetGlobalRef().FuncRunJavaFromG(fs, clName, methName, methType, locals...)
+
+		// The return value, if any, was pushed onto the operand stack of the calling frame.
+		if strings.HasSuffix(methType, ")V") {
+			return nil
+		}
+		caller = fs.Front().Value.(*frames.Frame)
+		if caller.TOS > tosBefore {
+			res := caller.OpStack[caller.TOS]
+			caller.TOS--
+			return res
+		}
+		errMsg := fmt.Sprintf("InvokeMethodOnObject: %s.%s%s did not return a value", clName, methName, methType)
+		return GetGErrBlk(excNames.IllegalStateException, errMsg)
+	}
+
+	errMsg := fmt.Sprintf("InvokeMethodOnObject: unexpected method type %c for %s.%s%s",
+		mte.MType, clName, methName, methType)
+	return GetGErrBlk(excNames.VirtualMachineError, errMsg)
+}
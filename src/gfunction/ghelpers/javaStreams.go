@@ -0,0 +1,238 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"os"
+	"unicode/utf16"
+)
+
+// GoWriterFor returns an io.Writer that writes to target, which is what a G function receives
+// for a Java OutputStream, Writer, or PrintStream. target can be a Go writer such as the *os.File
+// of the default System.out, a stream object with a FileHandle, or any other stream object, which
+// is then written by calling its own write method.
+//
+// As it has no frame stack, the writer it returns can call only write methods that are G
+// functions. G functions that have their frame stack use GoWriterWithContext instead.
+func GoWriterFor(target any, caller string) (io.Writer, *GErrBlk) {
+	return GoWriterWithContext(nil, target, caller)
+}
+
+// GoWriterWithContext is GoWriterFor for a G function registered with NeedsContext, whose frame
+// stack fs lets the writer run write methods written in Java, such as those of a user's
+// subclass of OutputStream or Writer.
+func GoWriterWithContext(fs *list.List, target any, caller string) (io.Writer, *GErrBlk) {
+	switch t := target.(type) {
+	case *object.Object:
+		if object.IsNull(t) {
+			return nil, GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+		}
+		if f, ok := t.FieldTable[FileHandle].Fvalue.(*os.File); ok {
+			_, hasEncoder := t.FieldTable[FileEncoder]
+			if _, hasCharset := t.FieldTable[FileCharset]; hasEncoder || hasCharset {
+				return EncodingWriter(f, WriterEncoder(t)), nil // a writer with a charset
+			}
+			return f, nil
+		}
+		if _, clName := FindInstanceMethod(t, "write", "([BII)V"); clName != "" {
+			return javaOutputStream{fs, t}, nil
+		}
+		if _, clName := FindInstanceMethod(t, "write", "(Ljava/lang/String;II)V"); clName != "" {
+			return javaWriter{fs, t}, nil
+		}
+		className := object.GoStringFromStringPoolIndex(t.KlassName)
+		errMsg := fmt.Sprintf("%s: %s is not an output stream or writer", caller, className)
+		return nil, GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	case io.Writer:
+		return t, nil
+	case nil:
+		return nil, GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	errMsg := fmt.Sprintf("%s: expected a stream, observed %T", caller, target)
+	return nil, GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+}
+
+// StreamError is the error that the readers and writers of Java stream objects return when a
+// method of the stream throws an exception, which it keeps.
+type StreamError struct {
+	GErr *GErrBlk
+}
+
+func (e *StreamError) Error() string {
+	return e.GErr.ErrMsg
+}
+
+// StreamErrBlk returns the exception for an error from a reader or writer of this file: the
+// exception that a Java stream threw, or otherwise an IOException.
+func StreamErrBlk(err error) *GErrBlk {
+	var streamErr *StreamError
+	if errors.As(err, &streamErr) {
+		return streamErr.GErr
+	}
+	return GetGErrBlk(excNames.IOException, err.Error())
+}
+
+// invokeStream calls a method of a Java stream, returning the exception it throws as an error.
+func invokeStream(fs *list.List, stream *object.Object, methName, methType string, args ...any) (any, error) {
+	ret := InvokeMethodOnObject(fs, stream, methName, methType, args...)
+	if gerr, ok := ret.(*GErrBlk); ok {
+		return nil, &StreamError{gerr}
+	}
+	return ret, nil
+}
+
+// javaOutputStream writes to a Java OutputStream through its write([BII)V method.
+type javaOutputStream struct {
+	fs     *list.List
+	stream *object.Object
+}
+
+func (w javaOutputStream) Write(p []byte) (int, error) {
+	arr := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(p))
+	if _, err := invokeStream(w.fs, w.stream, "write", "([BII)V", arr, int64(0), int64(len(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w javaOutputStream) Flush() error {
+	return invokeFlush(w.fs, w.stream)
+}
+
+// javaWriter writes to a Java Writer through its write(Ljava/lang/String;II)V method.
+type javaWriter struct {
+	fs     *list.List
+	writer *object.Object
+}
+
+func (w javaWriter) Write(p []byte) (int, error) {
+	str := object.StringObjectFromGoString(string(p))
+	length := int64(len(utf16.Encode([]rune(string(p)))))
+	if _, err := invokeStream(w.fs, w.writer, "write", "(Ljava/lang/String;II)V", str, int64(0), length); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w javaWriter) Flush() error {
+	return invokeFlush(w.fs, w.writer)
+}
+
+func invokeFlush(fs *list.List, stream *object.Object) error {
+	if _, clName := FindInstanceMethod(stream, "flush", "()V"); clName == "" {
+		return nil
+	}
+	_, err := invokeStream(fs, stream, "flush", "()V")
+	return err
+}
+
+// GoReaderFor returns an io.Reader that reads from source, which is what a G function receives
+// for a Java InputStream. source can be a Go reader, a stream object with a FileHandle, or any
+// other input stream object, which is then read by calling its own read([BII)I method.
+//
+// As it has no frame stack, the reader it returns can call only read methods that are G
+// functions. G functions that have their frame stack use GoReaderWithContext instead.
+func GoReaderFor(source any, caller string) (io.Reader, *GErrBlk) {
+	return GoReaderWithContext(nil, source, caller)
+}
+
+// GoReaderWithContext is GoReaderFor for a G function registered with NeedsContext, whose frame
+// stack fs lets the reader run read methods written in Java, such as those of a user's
+// subclass of InputStream.
+func GoReaderWithContext(fs *list.List, source any, caller string) (io.Reader, *GErrBlk) {
+	switch s := source.(type) {
+	case *object.Object:
+		if object.IsNull(s) {
+			return nil, GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+		}
+		if f, ok := s.FieldTable[FileHandle].Fvalue.(*os.File); ok {
+			return f, nil
+		}
+		if _, clName := FindInstanceMethod(s, "read", "([BII)I"); clName != "" {
+			return javaInputStream{fs, s}, nil
+		}
+		className := object.GoStringFromStringPoolIndex(s.KlassName)
+		errMsg := fmt.Sprintf("%s: %s is not an input stream", caller, className)
+		return nil, GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	case io.Reader:
+		return s, nil
+	case nil:
+		return nil, GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	errMsg := fmt.Sprintf("%s: expected a stream, observed %T", caller, source)
+	return nil, GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+}
+
+// javaInputStream reads from a Java InputStream through its read([BII)I method.
+type javaInputStream struct {
+	fs     *list.List
+	stream *object.Object
+}
+
+func (r javaInputStream) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	jbytes := make([]types.JavaByte, len(p))
+	arr := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, jbytes)
+	ret, err := invokeStream(r.fs, r.stream, "read", "([BII)I", arr, int64(0), int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	n, ok := ret.(int64)
+	if !ok || n < 0 {
+		return 0, io.EOF
+	}
+	copy(p, object.GoByteArrayFromJavaByteArray(jbytes[:n]))
+	return int(n), nil
+}
+
+// CharSource is what a G function reads the chars of a Java Reader from. ReadChar returns the
+// next UTF-16 char, or io.EOF at the end. A CharReader is a CharSource.
+type CharSource interface {
+	ReadChar() (rune, error)
+}
+
+// GoCharSourceWithContext returns a CharSource for the Java Reader source, which is read by
+// calling its own read()I method. Java methods run on the frame stack fs.
+func GoCharSourceWithContext(fs *list.List, source any, caller string) (CharSource, *GErrBlk) {
+	s, ok := source.(*object.Object)
+	if !ok || object.IsNull(s) {
+		return nil, GetGErrBlk(excNames.NullPointerException, caller+": reader is null")
+	}
+	if _, clName := FindInstanceMethod(s, "read", "()I"); clName == "" {
+		className := object.GoStringFromStringPoolIndex(s.KlassName)
+		errMsg := fmt.Sprintf("%s: %s is not a reader", caller, className)
+		return nil, GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	return javaReader{fs, s}, nil
+}
+
+// javaReader reads from a Java Reader through its read()I method.
+type javaReader struct {
+	fs     *list.List
+	reader *object.Object
+}
+
+func (r javaReader) ReadChar() (rune, error) {
+	ret, err := invokeStream(r.fs, r.reader, "read", "()I")
+	if err != nil {
+		return 0, err
+	}
+	ch, ok := ret.(int64)
+	if !ok || ch < 0 {
+		return 0, io.EOF
+	}
+	return rune(ch), nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/frames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"time"
+)
+
+// How often a G function that blocks checks whether its thread has been interrupted.
+// Thread.interrupt() only wakes threads that wait on an object monitor.
+const interruptPollInterval = 20 * time.Millisecond
+
+// CurrentThread returns the java.lang.Thread object of the thread that runs on the frame stack
+// fs, or nil if there is none (as in unit tests).
+func CurrentThread(fs *list.List) *object.Object {
+	if fs == nil || fs.Len() == 0 {
+		return nil
+	}
+	frame, ok := fs.Front().Value.(*frames.Frame)
+	if !ok {
+		return nil
+	}
+	gr := globals.GetGlobalRef()
+	gr.ThreadLock.RLock()
+	defer gr.ThreadLock.RUnlock()
+	th, _ := gr.Threads[frame.Thread].(*object.Object)
+	return th
+}
+
+// TakeInterrupt reports whether the thread that runs on fs has been interrupted. If it has,
+// its interrupt status is cleared, as it is when a blocking method throws InterruptedException.
+func TakeInterrupt(fs *list.List) bool {
+	th := CurrentThread(fs)
+	if th == nil {
+		return false
+	}
+	th.ThMutex.Lock()
+	defer th.ThMutex.Unlock()
+	fld, ok := th.FieldTable["interrupted"]
+	if !ok || fld.Fvalue != types.JavaBoolTrue {
+		return false
+	}
+	fld.Fvalue = types.JavaBoolFalse
+	th.FieldTable["interrupted"] = fld
+	return true
+}
+
+// AwaitInterruptibly blocks the thread that runs on fs until ready is closed or receives,
+// the timeout passes, or the thread is interrupted. A negative timeout waits for ever.
+// It returns true if ready fired, false on a timeout, and an InterruptedException if the
+// thread was interrupted.
+func AwaitInterruptibly(fs *list.List, ready <-chan struct{}, timeout time.Duration) (bool, *GErrBlk) {
+	var deadline <-chan time.Time
+	if timeout >= 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interruptPollInterval)
+	defer ticker.Stop()
+	for {
+		if TakeInterrupt(fs) {
+			return false, GetGErrBlk(excNames.InterruptedException, "interrupted while waiting")
+		}
+		select {
+		case <-ready:
+			return true, nil
+		case <-deadline:
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
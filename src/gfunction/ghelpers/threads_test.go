@@ -0,0 +1,27 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package ghelpers
+
+import (
+	"container/list"
+	"testing"
+	"time"
+)
+
+func TestAwaitInterruptibly(t *testing.T) {
+	ready := make(chan struct{}, 1)
+	ready <- struct{}{}
+	if woken, gerr := AwaitInterruptibly(list.New(), ready, -1); !woken || gerr != nil {
+		t.Errorf("ready channel: got %v, %v", woken, gerr)
+	}
+	if woken, gerr := AwaitInterruptibly(nil, ready, 10*time.Millisecond); woken || gerr != nil {
+		t.Errorf("timeout: got %v, %v", woken, gerr)
+	}
+	if th := CurrentThread(list.New()); th != nil {
+		t.Errorf("CurrentThread with no frames: got %v", th)
+	}
+}
@@ -7,13 +7,12 @@
 package javaIo
 
 import (
-	"fmt"
+	"container/list"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
-	"jacobin/src/globals"
 	"jacobin/src/object"
-	"jacobin/src/stringPool"
 	"jacobin/src/types"
+	"math"
 )
 
 const defaultBufferSize = 8192
@@ -39,14 +38,16 @@ func Load_Io_BufferedInputStream() {
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.available()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  BufferedInputStreamAvailable,
+			ParamSlots:   0,
+			GFunction:    BufferedInputStreamAvailable,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  BufferedInputStreamClose,
+			ParamSlots:   0,
+			GFunction:    BufferedInputStreamClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.mark(I)V"] =
@@ -63,14 +64,16 @@ func Load_Io_BufferedInputStream() {
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.read()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  BufferedInputStreamRead,
+			ParamSlots:   0,
+			GFunction:    BufferedInputStreamRead,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.read([BII)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  BufferedInputStreamReadRange,
+			ParamSlots:   3,
+			GFunction:    BufferedInputStreamReadRange,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.reset()V"] =
@@ -81,8 +84,9 @@ func Load_Io_BufferedInputStream() {
 
 	ghelpers.MethodSignatures["java/io/BufferedInputStream.skip(J)J"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  BufferedInputStreamSkip,
+			ParamSlots:   1,
+			GFunction:    BufferedInputStreamSkip,
+			NeedsContext: true,
 		}
 }
 
@@ -130,7 +134,9 @@ func getBufIfOpen(self *object.Object) ([]types.JavaByte, interface{}) {
 	return bufField.Fvalue.([]types.JavaByte), nil
 }
 
-func fill(self *object.Object) interface{} {
+// fill reads more bytes into the buffer, as the JDK does. The frame stack fs lets it call read
+// methods written in Java.
+func fill(fs *list.List, self *object.Object) interface{} {
 	buf, err := getBufIfOpen(self)
 	if err != nil {
 		return err
@@ -172,24 +178,10 @@ func fill(self *object.Object) interface{} {
 		return err
 	}
 
-	// Read into buf from pos
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	// We need to call read([BII)I on 'in'
-	method := fmt.Sprintf("%s.read([BII)I", *inClassName)
-
-	// Prepare [B object for buf
-	// Since we are operating on the internal byte slice, we might need a temporary object wrapper
-	// if ghelpers.Invoke expects it.
-	// Actually, most read implementations in Jacobin expect an *object.Object representing [B.
-
-	// Create a temporary [B object wrapper
-	tempBufObj, _ := globals.GetGlobalRef().FuncInstantiateClass("[B", nil)
-	bufWrapper := tempBufObj.(*object.Object)
-	bufWrapper.FieldTable["value"] = object.Field{Ftype: "[B", Fvalue: buf}
-
-	n := ghelpers.Invoke(method, []interface{}{in, bufWrapper, pos, int64(len(buf)) - pos})
-
-	if errBlk, ok := n.(ghelpers.GErrBlk); ok {
+	// Read into buf from pos, through the read method of whatever stream 'in' is.
+	bufWrapper := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, buf)
+	n := ghelpers.InvokeMethodOnObject(fs, in, "read", "([BII)I", bufWrapper, pos, int64(len(buf))-pos)
+	if errBlk, ok := n.(*ghelpers.GErrBlk); ok {
 		return errBlk
 	}
 
@@ -204,6 +196,7 @@ func fill(self *object.Object) interface{} {
 }
 
 func BufferedInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	if _, err := getBufIfOpen(self); err != nil {
 		return err
@@ -213,8 +206,7 @@ func BufferedInputStreamRead(params []interface{}) interface{} {
 	count := self.FieldTable["count"].Fvalue.(int64)
 
 	if pos >= count {
-		res := fill(self)
-		if err, ok := res.(ghelpers.GErrBlk); ok {
+		if err := fill(fs, self); err != nil {
 			return err
 		}
 		pos = self.FieldTable["pos"].Fvalue.(int64)
@@ -235,6 +227,7 @@ func BufferedInputStreamRead(params []interface{}) interface{} {
 }
 
 func BufferedInputStreamReadRange(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	if _, err := getBufIfOpen(self); err != nil {
 		return err
@@ -258,8 +251,7 @@ func BufferedInputStreamReadRange(params []interface{}) interface{} {
 	for {
 		avail := self.FieldTable["count"].Fvalue.(int64) - self.FieldTable["pos"].Fvalue.(int64)
 		if avail <= 0 {
-			res := fill(self)
-			if err, ok := res.(ghelpers.GErrBlk); ok {
+			if err := fill(fs, self); err != nil {
 				return err
 			}
 			avail = self.FieldTable["count"].Fvalue.(int64) - self.FieldTable["pos"].Fvalue.(int64)
@@ -298,16 +290,15 @@ func BufferedInputStreamReadRange(params []interface{}) interface{} {
 		if err != nil {
 			return err
 		}
-		inClassName := stringPool.GetStringPointer(in.KlassName)
-		method := fmt.Sprintf("%s.available()I", *inClassName)
-		inAvail := ghelpers.Invoke(method, []interface{}{in}).(int64)
-		if inAvail <= 0 {
+		inAvail, ok := ghelpers.InvokeMethodOnObject(fs, in, "available", "()I").(int64)
+		if !ok || inAvail <= 0 {
 			return totalRead
 		}
 	}
 }
 
 func BufferedInputStreamAvailable(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	in, err := getInIfOpen(self)
 	if err != nil {
@@ -316,14 +307,16 @@ func BufferedInputStreamAvailable(params []interface{}) interface{} {
 
 	avail := self.FieldTable["count"].Fvalue.(int64) - self.FieldTable["pos"].Fvalue.(int64)
 
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.available()I", *inClassName)
-	inAvail := ghelpers.Invoke(method, []interface{}{in}).(int64)
-
-	return avail + inAvail
+	ret := ghelpers.InvokeMethodOnObject(fs, in, "available", "()I")
+	inAvail, ok := ret.(int64)
+	if !ok {
+		return ret
+	}
+	return min(avail+inAvail, math.MaxInt32)
 }
 
 func BufferedInputStreamSkip(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	if _, err := getBufIfOpen(self); err != nil {
 		return err
@@ -342,13 +335,10 @@ func BufferedInputStreamSkip(params []interface{}) interface{} {
 
 		markpos := self.FieldTable["markpos"].Fvalue.(int64)
 		if markpos < 0 {
-			inClassName := stringPool.GetStringPointer(in.KlassName)
-			method := fmt.Sprintf("%s.skip(J)J", *inClassName)
-			return ghelpers.Invoke(method, []interface{}{in, n})
+			return ghelpers.InvokeMethodOnObject(fs, in, "skip", "(J)J", n)
 		}
 
-		res := fill(self)
-		if err, ok := res.(ghelpers.GErrBlk); ok {
+		if err := fill(fs, self); err != nil {
 			return err
 		}
 		avail = self.FieldTable["count"].Fvalue.(int64) - self.FieldTable["pos"].Fvalue.(int64)
@@ -388,6 +378,7 @@ func BufferedInputStreamReset(params []interface{}) interface{} {
 }
 
 func BufferedInputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	buf, _ := self.FieldTable["buf"]
 	if !object.IsNull(buf.Fvalue) {
@@ -395,10 +386,8 @@ func BufferedInputStreamClose(params []interface{}) interface{} {
 		inField, ok := self.FieldTable["in"]
 		if ok && !object.IsNull(inField.Fvalue) {
 			in := inField.Fvalue.(*object.Object)
-			inClassName := stringPool.GetStringPointer(in.KlassName)
-			method := fmt.Sprintf("%s.close()V", *inClassName)
-			ghelpers.Invoke(method, []interface{}{in})
 			self.FieldTable["in"] = object.Field{Ftype: "Ljava/io/InputStream;", Fvalue: nil}
+			return ghelpers.InvokeMethodOnObject(fs, in, "close", "()V")
 		}
 	}
 	return nil
@@ -7,11 +7,10 @@
 package javaIo
 
 import (
-	"fmt"
+	"container/list"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
-	"jacobin/src/stringPool"
 	"jacobin/src/types"
 )
 
@@ -40,26 +39,30 @@ func Load_Io_BufferedOutputStream() {
 
 	ghelpers.MethodSignatures["java/io/BufferedOutputStream.write(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  BufferedOutputStreamWriteInt,
+			ParamSlots:   1,
+			GFunction:    BufferedOutputStreamWriteInt,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedOutputStream.write([BII)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  BufferedOutputStreamWriteRange,
+			ParamSlots:   3,
+			GFunction:    BufferedOutputStreamWriteRange,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedOutputStream.flush()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  BufferedOutputStreamFlush,
+			ParamSlots:   0,
+			GFunction:    BufferedOutputStreamFlush,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedOutputStream.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  BufferedOutputStreamClose,
+			ParamSlots:   0,
+			GFunction:    BufferedOutputStreamClose,
+			NeedsContext: true,
 		}
 }
 
@@ -75,26 +78,26 @@ func BufferedOutputStreamInit(params []interface{}) interface{} {
 		}
 	}
 
-	self.FieldTable["out"] = object.Field{Ftype: "Ljava/io/OutputStream;", Fvalue: out}
+	self.FieldTable[fieldNameOut] = object.Field{Ftype: outputStreamType, Fvalue: out}
 	self.FieldTable["buf"] = object.Field{Ftype: "[B", Fvalue: make([]types.JavaByte, size)}
 	self.FieldTable["count"] = object.Field{Ftype: "I", Fvalue: int64(0)}
 
 	return nil
 }
 
-func flushBuffer(self *object.Object) interface{} {
+// The buffered bytes are written by calling the methods of the wrapped stream, whichever class
+// that stream is. Java methods run on the frame stack fs.
+func flushBuffer(fs *list.List, self *object.Object) interface{} {
 	count := self.FieldTable["count"].Fvalue.(int64)
 	if count > 0 {
-		out := self.FieldTable["out"].Fvalue.(*object.Object)
+		out := self.FieldTable[fieldNameOut].Fvalue.(*object.Object)
 		bufObj := &object.Object{
 			FieldTable: map[string]object.Field{
 				"value": self.FieldTable["buf"],
 			},
 		}
 
-		outClassName := stringPool.GetStringPointer(out.KlassName)
-		method := fmt.Sprintf("%s.write([BII)V", *outClassName)
-		res := ghelpers.Invoke(method, []interface{}{out, bufObj, int64(0), count})
+		res := ghelpers.InvokeMethodOnObject(fs, out, "write", "([BII)V", bufObj, int64(0), count)
 		if res != nil {
 			return res
 		}
@@ -104,6 +107,7 @@ func flushBuffer(self *object.Object) interface{} {
 }
 
 func BufferedOutputStreamWriteInt(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	b := params[1].(int64)
 
@@ -111,7 +115,7 @@ func BufferedOutputStreamWriteInt(params []interface{}) interface{} {
 	count := self.FieldTable["count"].Fvalue.(int64)
 
 	if count >= int64(len(buf)) {
-		if res := flushBuffer(self); res != nil {
+		if res := flushBuffer(fs, self); res != nil {
 			return res
 		}
 		count = 0
@@ -124,6 +128,7 @@ func BufferedOutputStreamWriteInt(params []interface{}) interface{} {
 }
 
 func BufferedOutputStreamWriteRange(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	bObj := params[1].(*object.Object)
 	off := params[2].(int64)
@@ -145,17 +150,15 @@ func BufferedOutputStreamWriteRange(params []interface{}) interface{} {
 		/* If the request length exceeds the size of the output buffer,
 		   flush the output buffer and then write the data directly.
 		   In this way buffered streams will cascade harmlessly. */
-		if res := flushBuffer(self); res != nil {
+		if res := flushBuffer(fs, self); res != nil {
 			return res
 		}
-		out := self.FieldTable["out"].Fvalue.(*object.Object)
-		outClassName := stringPool.GetStringPointer(out.KlassName)
-		method := fmt.Sprintf("%s.write([BII)V", *outClassName)
-		return ghelpers.Invoke(method, []interface{}{out, bObj, off, lenVal})
+		out := self.FieldTable[fieldNameOut].Fvalue.(*object.Object)
+		return ghelpers.InvokeMethodOnObject(fs, out, "write", "([BII)V", bObj, off, lenVal)
 	}
 
 	if lenVal > int64(len(buf))-count {
-		if res := flushBuffer(self); res != nil {
+		if res := flushBuffer(fs, self); res != nil {
 			return res
 		}
 		count = 0
@@ -168,39 +171,28 @@ func BufferedOutputStreamWriteRange(params []interface{}) interface{} {
 }
 
 func BufferedOutputStreamFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	if res := flushBuffer(self); res != nil {
+	if res := flushBuffer(fs, self); res != nil {
 		return res
 	}
-	out := self.FieldTable["out"].Fvalue.(*object.Object)
-	outClassName := stringPool.GetStringPointer(out.KlassName)
-	method := fmt.Sprintf("%s.flush()V", *outClassName)
-
-	if _, ok := ghelpers.MethodSignatures[method]; !ok {
-		// Fallback to FilterOutputStream.flush() which is often inherited
-		method = "java/io/FilterOutputStream.flush()V"
-	}
-
-	return ghelpers.Invoke(method, []interface{}{out})
+	ret, _ := writerOutCall(fs, self, "flush")
+	return ret
 }
 
 func BufferedOutputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 
 	// 1. Flush the buffer
-	if res := BufferedOutputStreamFlush(params); res != nil {
+	if res := flushBuffer(fs, self); res != nil {
 		return res
 	}
-
-	// 2. Close the underlying stream
-	out := self.FieldTable["out"].Fvalue.(*object.Object)
-	outClassName := stringPool.GetStringPointer(out.KlassName)
-	method := fmt.Sprintf("%s.close()V", *outClassName)
-
-	if _, ok := ghelpers.MethodSignatures[method]; !ok {
-		// Fallback to FilterOutputStream.close() which is often inherited
-		method = "java/io/FilterOutputStream.close()V"
+	if res, _ := writerOutCall(fs, self, "flush"); res != nil {
+		return res
 	}
 
-	return ghelpers.Invoke(method, []interface{}{out})
+	// 2. Close the underlying stream
+	ret, _ := writerOutCall(fs, self, "close")
+	return ret
 }
@@ -7,6 +7,7 @@
 package javaIo
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
 	"jacobin/src/object"
@@ -20,6 +21,7 @@ import (
 func TestBufferedOutputStream_Basic(t *testing.T) {
 	globals.InitGlobals("test")
 	globals.InitStringPool()
+	classloader.InitMethodArea()
 	Load_Io_FileOutputStream()
 	Load_Io_FilterOutputStream()
 	Load_Io_BufferedOutputStream()
@@ -82,6 +84,7 @@ func TestBufferedOutputStream_Basic(t *testing.T) {
 func TestBufferedOutputStream_WriteRange(t *testing.T) {
 	globals.InitGlobals("test")
 	globals.InitStringPool()
+	classloader.InitMethodArea()
 	Load_Io_FileOutputStream()
 	Load_Io_FilterOutputStream()
 	Load_Io_BufferedOutputStream()
@@ -123,6 +126,7 @@ func TestBufferedOutputStream_WriteRange(t *testing.T) {
 func TestBufferedOutputStream_Close(t *testing.T) {
 	globals.InitGlobals("test")
 	globals.InitStringPool()
+	classloader.InitMethodArea()
 	Load_Io_FileOutputStream()
 	Load_Io_FilterOutputStream()
 	Load_Io_BufferedOutputStream()
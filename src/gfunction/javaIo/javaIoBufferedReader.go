@@ -14,6 +14,7 @@ import (
 	"jacobin/src/object"
 	"jacobin/src/types"
 	"os"
+	"unicode/utf16"
 )
 
 func Load_Io_BufferedReader() {
@@ -38,8 +39,9 @@ func Load_Io_BufferedReader() {
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrClose,
+			ParamSlots:   0,
+			GFunction:    isrClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.lines()Ljava/util/stream/Stream;"] =
@@ -62,26 +64,30 @@ func Load_Io_BufferedReader() {
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.read()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrReadOneChar,
+			ParamSlots:   0,
+			GFunction:    isrReadOneChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.read([CII)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  isrReadCharBufferSubset,
+			ParamSlots:   3,
+			GFunction:    isrReadCharBufferSubset,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.readLine()Ljava/lang/String;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  bufferedReaderReadLine,
+			ParamSlots:   0,
+			GFunction:    bufferedReaderReadLine,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.ready()Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrReady,
+			ParamSlots:   0,
+			GFunction:    isrReady,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedReader.reset()V"] =
@@ -94,8 +100,17 @@ func Load_Io_BufferedReader() {
 
 // "java/io/BufferedReader.<init>(Ljava/io/Reader;])V"
 func bufferedReaderInit(params []interface{}) interface{} {
-	fld1, ok := params[1].(*object.Object).FieldTable[ghelpers.FilePath]
+	inner, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(inner) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "bufferedReaderInit: Reader is null")
+	}
+	fld1, ok := inner.FieldTable[ghelpers.FilePath]
 	if !ok {
+		// Any other Reader is read through its own read methods.
+		if _, clName := ghelpers.FindInstanceMethod(inner, "read", "()I"); clName != "" {
+			params[0].(*object.Object).FieldTable[fieldNameIn] = object.Field{Ftype: readerType, Fvalue: inner}
+			return nil
+		}
 		errMsg := "Reader object lacks a ghelpers.FilePath field"
 		return ghelpers.GetGErrBlk(excNames.InvalidTypeException, errMsg)
 	}
@@ -114,6 +129,15 @@ func bufferedReaderInit(params []interface{}) interface{} {
 	fld = object.Field{Ftype: types.Ref, Fvalue: osFile}
 	params[0].(*object.Object).FieldTable[ghelpers.FileHandle] = fld
 
+	// Decode as the Reader does.
+	if fld, ok := inner.FieldTable[ghelpers.FileCharset]; ok {
+		params[0].(*object.Object).FieldTable[ghelpers.FileCharset] = fld
+	}
+	if cr, ok := inner.FieldTable[ghelpers.FileDecoder].Fvalue.(*ghelpers.CharReader); ok {
+		fld = object.Field{Ftype: types.RawGoPointer, Fvalue: cr.Over(osFile)}
+		params[0].(*object.Object).FieldTable[ghelpers.FileDecoder] = fld
+	}
+
 	return nil
 }
 
@@ -124,6 +148,8 @@ func bufferedReaderMarkSupported([]interface{}) interface{} {
 
 // "java/io/BufferedReader.readLine()Ljava/lang/String;"
 func bufferedReaderReadLine(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+
 	// Get BufferedReader object.
 	obj := params[0].(*object.Object)
 
@@ -132,39 +158,35 @@ func bufferedReaderReadLine(params []interface{}) interface{} {
 		return object.Null
 	}
 
-	// Get file handle.
-	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "Reader object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Get the source of the chars.
+	chars, gerr := readerChars(fs, obj, "bufferedReaderReadLine")
+	if gerr != nil {
+		return gerr
 	}
 
-	// Need a one-byte buffer.
-	byteBuf := make([]byte, 1)
-	var buffer []byte
-	var err error
+	// Read chars up to the end of the line.
+	var line []uint16
 	for {
-		_, err = osFile.Read(byteBuf)
+		ch, err := chars.ReadChar()
 		if err == io.EOF {
 			ghelpers.EofSet(obj, true)
-			if len(buffer) > 0 {
+			if len(line) > 0 {
 				break
 			}
 			return object.Null
 		}
 		if err != nil {
-			errMsg := fmt.Sprintf("osFile.Read failed, reason: %s", err.Error())
-			return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+			return readerError("bufferedReaderReadLine", err)
 		}
-		if byteBuf[0] == '\r' {
+		if ch == '\r' {
 			continue
 		}
-		if byteBuf[0] == '\n' {
+		if ch == '\n' {
 			break
 		}
-		buffer = append(buffer, byteBuf[0])
+		line = append(line, uint16(ch))
 	}
 
 	// Return the string.
-	return object.StringObjectFromByteArray(buffer)
+	return object.StringObjectFromGoString(string(utf16.Decode(line)))
 }
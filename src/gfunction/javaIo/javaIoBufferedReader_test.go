@@ -1,6 +1,7 @@
 package javaIo
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
@@ -180,3 +181,39 @@ func TestBufferedReaderReadLine_MultiLineSequential(t *testing.T) {
 		}
 	}
 }
+
+func TestBufferedReaderReadLine_OverJavaReaders(t *testing.T) {
+	setup()
+	classloader.InitMethodArea()
+	Load_Io_ByteArrayInputStream()
+	Load_Io_InputStreamReader()
+	Load_Io_StringReader()
+
+	className := "java/io/StringReader"
+	sr := object.MakeEmptyObjectWithClassName(&className)
+	stringReaderInit([]interface{}{sr, object.StringObjectFromGoString("one\r\ntwo\nthree")})
+
+	className = "java/io/InputStreamReader"
+	isr := object.MakeEmptyObjectWithClassName(&className)
+	inputStreamReaderInit([]interface{}{isr, newTestByteArrayInputStream([]byte("one\r\ntwo\nthree"))})
+
+	for _, reader := range []*object.Object{sr, isr} {
+		brObj := object.MakeEmptyObject()
+		if res := bufferedReaderInit([]interface{}{brObj, reader}); res != nil {
+			t.Fatalf("bufferedReaderInit returned error: %v", res)
+		}
+		for _, want := range []string{"one", "two", "three"} {
+			res := bufferedReaderReadLine([]interface{}{brObj})
+			line, ok := res.(*object.Object)
+			if !ok || object.IsNull(line) {
+				t.Fatalf("readLine: expected %q, got %v", want, res)
+			}
+			if got := object.GoStringFromStringObject(line); got != want {
+				t.Errorf("readLine: expected %q, got %q", want, got)
+			}
+		}
+		if res := bufferedReaderReadLine([]interface{}{brObj}); res != object.Null {
+			t.Errorf("readLine at EOF: expected null, got %v", res)
+		}
+	}
+}
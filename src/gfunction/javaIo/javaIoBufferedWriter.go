@@ -39,38 +39,44 @@ func Load_Io_BufferedWriter() {
 	// Core operations
 	ghelpers.MethodSignatures["java/io/BufferedWriter.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  bwClose,
+			ParamSlots:   0,
+			GFunction:    bwClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedWriter.flush()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  bwFlush,
+			ParamSlots:   0,
+			GFunction:    bwFlush,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedWriter.newLine()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  bufferedWriterNewLine,
+			ParamSlots:   0,
+			GFunction:    bufferedWriterNewLine,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedWriter.write(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  bwWriteOneChar,
+			ParamSlots:   1,
+			GFunction:    bwWriteOneChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedWriter.write([CII)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  bwWriteCharBuffer,
+			ParamSlots:   3,
+			GFunction:    bwWriteCharBuffer,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/BufferedWriter.write(Ljava/lang/String;II)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  bwWriteStringBuffer,
+			ParamSlots:   3,
+			GFunction:    bwWriteStringBuffer,
+			NeedsContext: true,
 		}
 
 	// Append variants — currently trapped
@@ -100,8 +106,17 @@ func bufferedWriterInit(params []interface{}) interface{} {
     if params[0].(*object.Object).FieldTable == nil {
         params[0].(*object.Object).FieldTable = make(map[string]object.Field)
     }
-    fldPath, ok := params[1].(*object.Object).FieldTable[ghelpers.FilePath]
+    out, ok := params[1].(*object.Object)
+    if !ok || object.IsNull(out) {
+        return ghelpers.GetGErrBlk(excNames.NullPointerException, "bufferedWriterInit: Writer is null")
+    }
+    fldPath, ok := out.FieldTable[ghelpers.FilePath]
     if !ok {
+        // Any other Writer is written through its own write methods.
+        if _, ok = out.FieldTable[ghelpers.FileHandle]; !ok {
+            params[0].(*object.Object).FieldTable[fieldNameOut] = object.Field{Ftype: writerType, Fvalue: out}
+            return nil
+        }
         errMsg := "bufferedWriterInit: Writer object lacks a ghelpers.FilePath field"
         return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
     }
@@ -128,10 +143,18 @@ func bufferedWriterInit(params []interface{}) interface{} {
 	params[0].(*object.Object).FieldTable[ghelpers.FilePath] = fldPath
 	params[0].(*object.Object).FieldTable[ghelpers.FileHandle] = fldHandle
 
+	// Encode as the Writer does, with the same encoder, since both write to the same file.
+	params[0].(*object.Object).FieldTable[ghelpers.FileEncoder] =
+		object.Field{Ftype: types.RawGoPointer, Fvalue: ghelpers.WriterEncoder(params[1].(*object.Object))}
+
 	return nil
 }
 
 func bwClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if ret, ok := writerOutCall(fs, params[0].(*object.Object), "close"); ok {
+		return ret
+	}
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
 	if !ok {
 		errMsg := "bwClose: BufferedWriter object lacks a ghelpers.FileHandle field"
@@ -145,6 +168,10 @@ func bwClose(params []interface{}) interface{} {
 }
 
 func bwFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if ret, ok := writerOutCall(fs, params[0].(*object.Object), "flush"); ok {
+		return ret
+	}
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
 	if !ok {
 		errMsg := "bwFlush: BufferedWriter object lacks a ghelpers.FileHandle field"
@@ -159,47 +186,32 @@ func bwFlush(params []interface{}) interface{} {
 
 // "java/io/BufferedWriter.newLine()V"
 func bufferedWriterNewLine(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "bufferedWriterNewLine: BufferedWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
+	fs, params := ghelpers.SplitContext(params)
 	// Java uses platform-independent newline via writer; here we use \n
-	if _, err := osFile.Write([]byte{'\n'}); err != nil {
-		errMsg := fmt.Sprintf("bufferedWriterNewLine: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	if gerr := writeChars(fs, params[0].(*object.Object), []rune{'\n'}, "bufferedWriterNewLine"); gerr != nil {
+		return gerr
 	}
 	return nil
 }
 
 // "java/io/BufferedWriter.write(I)V"
 func bwWriteOneChar(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	obj := params[0].(*object.Object)
-	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "bwWriteOneChar: BufferedWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
 	wint, ok := params[1].(int64)
 	if !ok {
 		errMsg := "bwWriteOneChar: Error in integer argument"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
-	buffer := []byte{byte(wint % 256)}
-	if _, err := osFile.Write(buffer); err != nil {
-		errMsg := fmt.Sprintf("bwWriteOneChar: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	if gerr := writeChars(fs, obj, []rune{rune(wint & 0xFFFF)}, "bwWriteOneChar"); gerr != nil {
+		return gerr
 	}
 	return nil
 }
 
 // "java/io/BufferedWriter.write([CII)V"
 func bwWriteCharBuffer(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "bwWriteCharBuffer: BufferedWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
+	fs, params := ghelpers.SplitContext(params)
 
 	intArray, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]int64)
 	if !ok {
@@ -218,52 +230,39 @@ func bwWriteCharBuffer(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 	}
 
-	outBytes := make([]byte, length)
+	chars := make([]rune, length)
 	for ii := int64(0); ii < length; ii++ {
-		outBytes[ii] = byte(intArray[offset+ii])
+		chars[ii] = rune(intArray[offset+ii])
 	}
-
-	if _, err := osFile.Write(outBytes); err != nil {
-		errMsg := fmt.Sprintf("bwWriteCharBuffer: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	if gerr := writeChars(fs, params[0].(*object.Object), chars, "bwWriteCharBuffer"); gerr != nil {
+		return gerr
 	}
 	return nil
 }
 
 // "java/io/BufferedWriter.write(Ljava/lang/String;II)V"
 func bwWriteStringBuffer(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "bwWriteStringBuffer: BufferedWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
+	fs, params := ghelpers.SplitContext(params)
 
-	javaBytes, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
-	if !ok {
+	strObj, ok := params[1].(*object.Object)
+	if !ok || !object.IsStringObject(strObj) {
 		errMsg := "bwWriteStringBuffer: Trouble with value field"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
-	paramBytes := object.GoByteArrayFromJavaByteArray(javaBytes)
+	chars := stringChars(strObj)
 	offset := params[2].(int64)
 	length := params[3].(int64)
 
 	if length == 0 {
 		return int64(0)
 	}
-	if length < 0 || offset < 0 || length > (int64(len(paramBytes))-offset) {
-		errMsg := fmt.Sprintf("bwWriteStringBuffer: Error in parameters: offset=%d, length=%d, char.array.length=%d",
-			offset, length, len(paramBytes))
+	if length < 0 || offset < 0 || length > (int64(len(chars))-offset) {
+		errMsg := fmt.Sprintf("bwWriteStringBuffer: Error in parameters: offset=%d, length=%d, string.length=%d",
+			offset, length, len(chars))
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 	}
-
-	outBytes := make([]byte, length)
-	for ii := int64(0); ii < length; ii++ {
-		outBytes[ii] = paramBytes[offset+ii]
-	}
-
-	if _, err := osFile.Write(outBytes); err != nil {
-		errMsg := fmt.Sprintf("bwWriteStringBuffer: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	if gerr := writeChars(fs, params[0].(*object.Object), chars[offset:offset+length], "bwWriteStringBuffer"); gerr != nil {
+		return gerr
 	}
 	return nil
 }
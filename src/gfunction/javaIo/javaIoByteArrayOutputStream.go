@@ -238,14 +238,27 @@ func ByteArrayOutputStreamToString(params []interface{}) interface{} {
 }
 
 func ByteArrayOutputStreamToStringCharsetName(params []interface{}) interface{} {
-	// For now, we don't have full charset support in gfunctions easily accessible
-	// without more complex logic. Let's trap it as a reminder or implement if simple.
-	// Actually, most Jacobin strings are UTF-8.
-	return ghelpers.TrapFunction(params)
+	cs, gerr := ghelpers.CharsetFromName(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return byteArrayOutputStreamDecode(params[0].(*object.Object), cs)
 }
 
 func ByteArrayOutputStreamToStringCharset(params []interface{}) interface{} {
-	return ghelpers.TrapFunction(params)
+	cs, gerr := ghelpers.CharsetFromObject(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return byteArrayOutputStreamDecode(params[0].(*object.Object), cs)
+}
+
+// byteArrayOutputStreamDecode decodes the bytes written so far, replacing malformed input.
+func byteArrayOutputStreamDecode(self *object.Object, cs *ghelpers.Charset) interface{} {
+	count := self.FieldTable["count"].Fvalue.(int64)
+	buf := self.FieldTable["buf"].Fvalue.([]types.JavaByte)
+	str := cs.DecodeReplacing(object.GoByteArrayFromJavaByteArray(buf[:count]))
+	return object.StringObjectFromGoString(str)
 }
 
 func ByteArrayOutputStreamWriteTo(params []interface{}) interface{} {
@@ -0,0 +1,189 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// CharArrayWriter shares its implementation with StringWriter. See javaIoStringWriter.go.
+
+func Load_Io_CharArrayWriter() {
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  charArrayWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.<init>(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  charArrayWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.append(C)Ljava/io/CharArrayWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterAppendChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.append(Ljava/lang/CharSequence;)Ljava/io/CharArrayWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    textWriterAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.append(Ljava/lang/CharSequence;II)Ljava/io/CharArrayWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    textWriterAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.JustReturn,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.JustReturn,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.reset()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  charArrayWriterReset,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.size()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  charArrayWriterSize,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.toCharArray()[C"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  charArrayWriterToCharArray,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  textWriterToString,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.write([C)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.write([CII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  textWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.write(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteString,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.write(Ljava/lang/String;II)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  textWriterWriteString,
+		}
+
+	ghelpers.MethodSignatures["java/io/CharArrayWriter.writeTo(Ljava/io/Writer;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    charArrayWriterWriteTo,
+			NeedsContext: true,
+		}
+}
+
+// java/io/CharArrayWriter.<init>()V and <init>(I)V
+func charArrayWriterInit(params []interface{}) interface{} {
+	if len(params) == 2 && params[1].(int64) < 0 {
+		errMsg := fmt.Sprintf("Negative initial size: %d", params[1].(int64))
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	return textWriterInit(params)
+}
+
+// java/io/CharArrayWriter.reset()V
+func charArrayWriterReset(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "reset")
+	if gerr != nil {
+		return gerr
+	}
+	text.Reset()
+	return nil
+}
+
+// java/io/CharArrayWriter.size()I
+func charArrayWriterSize(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "size")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(text.Len())
+}
+
+// java/io/CharArrayWriter.toCharArray()[C
+func charArrayWriterToCharArray(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "toCharArray")
+	if gerr != nil {
+		return gerr
+	}
+	var chars []int64
+	for _, r := range text.String() {
+		chars = append(chars, int64(r))
+	}
+	return object.MakePrimitiveObject("[C", types.CharArray, chars)
+}
+
+// java/io/CharArrayWriter.writeTo(Ljava/io/Writer;)V
+func charArrayWriterWriteTo(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	text, gerr := textWriterText(params[0], "writeTo")
+	if gerr != nil {
+		return gerr
+	}
+	writer, gerr := ghelpers.GoWriterWithContext(fs, params[1], "writeTo")
+	if gerr != nil {
+		return gerr
+	}
+	if _, err := writer.Write([]byte(text.String())); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "writeTo: "+err.Error())
+	}
+	return nil
+}
@@ -36,7 +36,7 @@ func Load_Io_Console() {
 	ghelpers.MethodSignatures["java/io/Console.charset()Ljava/nio/charset/Charset;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  printstreamCharset,
 		}
 	ghelpers.MethodSignatures["java/io/Console.print(Ljava/lang/Object;)Ljava/io/Console;"] =
 		ghelpers.GMeth{
@@ -150,12 +150,28 @@ func consoleClinit([]interface{}) interface{} {
 	return nil
 }
 
+// The console reads and writes System.in and System.out, or the process's standard input and
+// output if those have been redirected to Java streams with System.setIn() or System.setOut().
+func consoleStdin() *os.File {
+	if f, ok := statics.GetStaticValue("java/lang/System", "in").(*os.File); ok {
+		return f
+	}
+	return os.Stdin
+}
+
+func consoleStdout() *os.File {
+	if f, ok := statics.GetStaticValue("java/lang/System", "out").(*os.File); ok {
+		return f
+	}
+	return os.Stdout
+}
+
 // Flush java/lang/System.in/out/err.
 // "java/io/Console.flush()V"
 func consoleFlush([]interface{}) interface{} {
-	stdinout := statics.GetStaticValue("java/lang/System", "in").(*os.File)
+	stdinout := consoleStdin()
 	_ = stdinout.Sync()
-	stdinout = statics.GetStaticValue("java/lang/System", "out").(*os.File)
+	stdinout = consoleStdout()
 	_ = stdinout.Sync()
 	// Note: java/lang/System.err is not associated with the system console.
 	return nil
@@ -175,7 +191,7 @@ func consolePrintf(params []interface{}) interface{} {
 	}
 	objPtr := retval.(*object.Object)
 	str := object.GoStringFromStringObject(objPtr)
-	stdout := statics.GetStaticValue("java/lang/System", "out").(*os.File)
+	stdout := consoleStdout()
 	_, _ = fmt.Fprint(stdout, str)
 	return stdout // Return the *os.File
 
@@ -188,7 +204,7 @@ func consoleReadLine([]interface{}) interface{} {
 	var bb = []byte{0x00}
 	var nbytes int
 	var err error
-	stdin := statics.GetStaticValue("java/lang/System", "in").(*os.File)
+	stdin := consoleStdin()
 	for {
 		nbytes, err = stdin.Read(bb)
 		if nbytes == 0 {
@@ -223,7 +239,7 @@ func consoleReadPassword([]interface{}) interface{} {
 		errMsg := fmt.Sprintf("consoleReadPassword: stdin.ReadPassword failed, reason: %s", err.Error())
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
-	stdout := statics.GetStaticValue("java/lang/System", "out").(*os.File)
+	stdout := consoleStdout()
 	_, _ = fmt.Fprint(stdout, "\n")
 
 	// Convert password to int64 array, insert into an object, and return to caller
@@ -39,7 +39,7 @@ func TestLoad_Io_Console_RegistersMethods(t *testing.T) {
 		fn    func([]interface{}) interface{}
 	}{
 		{"java/io/Console.<clinit>()V", 0, consoleClinit},
-		{"java/io/Console.charset()Ljava/nio/charset/Charset;", 0, ghelpers.TrapFunction},
+		{"java/io/Console.charset()Ljava/nio/charset/Charset;", 0, printstreamCharset},
 		{"java/io/Console.flush()V", 0, consoleFlush},
 		{"java/io/Console.format(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/Console;", 2, consolePrintf},
 		{"java/io/Console.printf(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/Console;", 2, consolePrintf},
@@ -0,0 +1,491 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+)
+
+// java.io.DataInputStream reads big-endian primitive values and modified UTF-8 strings from
+// the stream in its "in" field, which can be any InputStream. The bytes are read through the
+// stream's own read method, so nothing is read ahead of what a value needs.
+
+func Load_Io_DataInputStream() {
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  dataInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.available()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamAvailable,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamRead,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.read([B)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.read([BII)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    dataInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readBoolean()Z"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadBoolean,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readByte()B"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadByte,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readChar()C"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadChar,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readDouble()D"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadDouble,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readFloat()F"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadFloat,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readFully([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataInputStreamReadFully,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readFully([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    dataInputStreamReadFully,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readInt()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadInt,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readLine()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapDeprecated,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readLong()J"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadLong,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readShort()S"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadShort,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readUnsignedByte()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadUnsignedByte,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readUnsignedShort()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadUnsignedShort,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readUTF()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataInputStreamReadUTF,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.readUTF(Ljava/io/DataInput;)Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataInputStreamReadUTFFrom,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.skip(J)J"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataInputStreamSkip,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataInputStream.skipBytes(I)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataInputStreamSkipBytes,
+			NeedsContext: true,
+		}
+}
+
+// java/io/DataInputStream.<init>(Ljava/io/InputStream;)V
+func dataInputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	self.FieldTable["in"] = object.Field{Ftype: "Ljava/io/InputStream;", Fvalue: params[1]}
+	return nil
+}
+
+// filteredStream returns the stream in the "in" or "out" field of a filter stream.
+func filteredStream(this any, fieldName, caller string) (*object.Object, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	stream, ok := self.FieldTable[fieldName].Fvalue.(*object.Object)
+	if !ok || object.IsNull(stream) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": underlying stream is null")
+	}
+	return stream, nil
+}
+
+// byteArrayRange returns the byte array argument at params[ix] and, if they follow it, the
+// offset and length arguments, checked against the array's length.
+func byteArrayRange(params []interface{}, ix int, caller string) ([]types.JavaByte, int64, int64, *ghelpers.GErrBlk) {
+	arrObj, ok := params[ix].(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": byte array is null")
+	}
+	jbytes, _ := arrObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := int64(0), int64(len(jbytes))
+	if len(params) > ix+2 {
+		off, length = params[ix+1].(int64), params[ix+2].(int64)
+		if off < 0 || length < 0 || off+length > int64(len(jbytes)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", off, off, length, len(jbytes))
+			return nil, 0, 0, ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+	}
+	return jbytes, off, length, nil
+}
+
+// dataInputFill reads exactly len(p) bytes from the stream of a DataInputStream. Running out of
+// input is an EOFException.
+func dataInputFill(fs *list.List, this any, p []byte) *ghelpers.GErrBlk {
+	in, gerr := filteredStream(this, "in", "DataInputStream")
+	if gerr != nil {
+		return gerr
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, in, "DataInputStream")
+	if gerr != nil {
+		return gerr
+	}
+	if _, err := io.ReadFull(r, p); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ghelpers.GetGErrBlk(excNames.EOFException, "")
+		}
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	return nil
+}
+
+// java/io/DataInputStream.available()I
+func dataInputStreamAvailable(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.available")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "available", "()I")
+}
+
+// java/io/DataInputStream.close()V
+func dataInputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.close")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "close", "()V")
+}
+
+// java/io/DataInputStream.read()I
+func dataInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "read", "()I")
+}
+
+// java/io/DataInputStream.read([B)I and read([BII)I -- reads what the underlying stream has
+func dataInputStreamReadBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	_, off, length, gerr := byteArrayRange(params, 1, "DataInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "read", "([BII)I", params[1], off, length)
+}
+
+// java/io/DataInputStream.readFully([B)V and readFully([BII)V
+func dataInputStreamReadFully(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "DataInputStream.readFully")
+	if gerr != nil {
+		return gerr
+	}
+	buf := make([]byte, length)
+	if gerr := dataInputFill(fs, params[0], buf); gerr != nil {
+		return gerr
+	}
+	copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf))
+	return nil
+}
+
+// dataInputValue reads n bytes from a DataInputStream, for one of the readXXX methods.
+func dataInputValue(fs *list.List, params []interface{}, n int) ([]byte, *ghelpers.GErrBlk) {
+	buf := make([]byte, n)
+	if gerr := dataInputFill(fs, params[0], buf); gerr != nil {
+		return nil, gerr
+	}
+	return buf, nil
+}
+
+// java/io/DataInputStream.readBoolean()Z
+func dataInputStreamReadBoolean(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 1)
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b[0] != 0)
+}
+
+// java/io/DataInputStream.readByte()B
+func dataInputStreamReadByte(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 1)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(int8(b[0]))
+}
+
+// java/io/DataInputStream.readUnsignedByte()I
+func dataInputStreamReadUnsignedByte(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 1)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(b[0])
+}
+
+// java/io/DataInputStream.readShort()S
+func dataInputStreamReadShort(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 2)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(int16(binary.BigEndian.Uint16(b)))
+}
+
+// java/io/DataInputStream.readUnsignedShort()I
+func dataInputStreamReadUnsignedShort(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 2)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(binary.BigEndian.Uint16(b))
+}
+
+// java/io/DataInputStream.readChar()C -- params still hold the frame stack, for readUnsignedShort
+func dataInputStreamReadChar(params []interface{}) interface{} {
+	return dataInputStreamReadUnsignedShort(params)
+}
+
+// java/io/DataInputStream.readInt()I
+func dataInputStreamReadInt(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 4)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(int32(binary.BigEndian.Uint32(b)))
+}
+
+// java/io/DataInputStream.readLong()J
+func dataInputStreamReadLong(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 8)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+// java/io/DataInputStream.readFloat()F
+func dataInputStreamReadFloat(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 4)
+	if gerr != nil {
+		return gerr
+	}
+	return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+}
+
+// java/io/DataInputStream.readDouble()D
+func dataInputStreamReadDouble(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 8)
+	if gerr != nil {
+		return gerr
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b))
+}
+
+// java/io/DataInputStream.readUTF()Ljava/lang/String; -- a two-byte length, then that many
+// bytes of modified UTF-8
+func dataInputStreamReadUTF(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := dataInputValue(fs, params, 2)
+	if gerr != nil {
+		return gerr
+	}
+	data, gerr := dataInputValue(fs, params, int(binary.BigEndian.Uint16(b)))
+	if gerr != nil {
+		return gerr
+	}
+	return stringFromModifiedUTF8(data)
+}
+
+// java/io/DataInputStream.readUTF(Ljava/io/DataInput;)Ljava/lang/String; -- reads the string
+// with the readUnsignedShort and readFully methods of any DataInput
+func dataInputStreamReadUTFFrom(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(in) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "DataInputStream.readUTF: input is null")
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, in, "readUnsignedShort", "()I")
+	length, ok := ret.(int64)
+	if !ok {
+		return ret
+	}
+	arr := object.Make1DimArray(object.T_BYTE, length)
+	if ret := ghelpers.InvokeMethodOnObject(fs, in, "readFully", "([B)V", arr); ret != nil {
+		return ret
+	}
+	data := object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte))
+	return stringFromModifiedUTF8(data)
+}
+
+// stringFromModifiedUTF8 returns the String whose modified UTF-8 encoding is data.
+func stringFromModifiedUTF8(data []byte) interface{} {
+	s, err := decodeModifiedUTF8(data)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UTFDataFormatException, err.Error())
+	}
+	return object.StringObjectFromGoString(s)
+}
+
+// java/io/DataInputStream.skip(J)J
+func dataInputStreamSkip(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.skip")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "skip", "(J)J", params[1])
+}
+
+// java/io/DataInputStream.skipBytes(I)I -- skips up to n bytes, fewer at the end of the stream
+func dataInputStreamSkipBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	n := params[1].(int64)
+	if n <= 0 {
+		return int64(0)
+	}
+	in, gerr := filteredStream(params[0], "in", "DataInputStream.skipBytes")
+	if gerr != nil {
+		return gerr
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, in, "DataInputStream.skipBytes")
+	if gerr != nil {
+		return gerr
+	}
+	skipped, err := io.CopyN(io.Discard, r, n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	return skipped
+}
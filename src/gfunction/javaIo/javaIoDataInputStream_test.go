@@ -0,0 +1,305 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"bytes"
+	"container/list"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/frames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/stringPool"
+	"jacobin/src/types"
+	"math"
+	"testing"
+)
+
+// newTestByteArrayInputStream returns a ByteArrayInputStream that reads data.
+func newTestByteArrayInputStream(data []byte) *object.Object {
+	className := "java/io/ByteArrayInputStream"
+	bais := object.MakeEmptyObjectWithClassName(&className)
+	arr := object.Make1DimArray(object.T_BYTE, 0)
+	arr.FieldTable["value"] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoByteArray(data)}
+	ByteArrayInputStreamInit([]any{bais, arr})
+	return bais
+}
+
+// newTestByteArrayOutputStream returns an empty ByteArrayOutputStream.
+func newTestByteArrayOutputStream() *object.Object {
+	className := "java/io/ByteArrayOutputStream"
+	baos := object.MakeEmptyObjectWithClassName(&className)
+	ByteArrayOutputStreamInit([]any{baos})
+	return baos
+}
+
+func byteArrayOutputStreamBytes(baos *object.Object) []byte {
+	arr := ByteArrayOutputStreamToByteArray([]any{baos}).(*object.Object)
+	return object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte))
+}
+
+// The tests run the methods of Java subclasses of the stream classes with Go stand-ins for
+// their bytecode. A stand-in gets the object and the method's arguments and returns the
+// method's result, or nil for a void method.
+type javaTestMethod func(this *object.Object, args []any) any
+
+// javaTestMethods holds the stand-ins by class name and then by method name and type.
+var javaTestMethods = map[string]map[string]javaTestMethod{}
+
+// Java subclasses of InputStream and OutputStream that read from and write to the Go reader
+// and writer in their "source" and "sink" fields.
+const (
+	javaTestInputStreamClassName  = "jacobin/test/JavaInputStream"
+	javaTestOutputStreamClassName = "jacobin/test/JavaOutputStream"
+)
+
+// insertJavaTestClass puts a class that extends superName and has the methods in the method
+// area, with the methods as Java ones.
+func insertJavaTestClass(className, superName string, methods map[string]javaTestMethod) {
+	cd := &classloader.ClData{
+		Name:            className,
+		NameIndex:       stringPool.GetStringIndex(&className),
+		SuperclassIndex: stringPool.GetStringIndex(&superName),
+		MethodTable:     make(map[string]*classloader.Method),
+	}
+	for meth := range methods {
+		cd.MethodTable[meth] = &classloader.Method{}
+		classloader.AddEntry(&classloader.MTable, className+"."+meth,
+			classloader.MTentry{Meth: classloader.JmEntry{}, MType: 'J'})
+	}
+	classloader.MethAreaInsert(className, &classloader.Klass{Status: 'N', Loader: "test", Data: cd})
+	javaTestMethods[className] = methods
+}
+
+// newJavaTestFrameStack returns the frame stack on which the Java methods of the test classes
+// run. Running one on any other frame stack fails the test.
+func newJavaTestFrameStack(t *testing.T) *list.List {
+	t.Helper()
+	setup()
+	classloader.InitMethodArea()
+	insertJavaTestStreamClasses()
+
+	fs := frames.CreateFrameStack()
+	_ = frames.PushFrame(fs, frames.CreateFrame(4))
+	glob := globals.GetGlobalRef()
+	runJavaFromG := glob.FuncRunJavaFromG
+	t.Cleanup(func() { glob.FuncRunJavaFromG = runJavaFromG })
+	glob.FuncRunJavaFromG = func(runFs *list.List, clName, methName, methType string, locals ...any) {
+		if runFs != fs {
+			t.Errorf("%s.%s%s was run on another frame stack", clName, methName, methType)
+			return
+		}
+		if ret := javaTestMethods[clName][methName+methType](locals[0].(*object.Object), locals[1:]); ret != nil {
+			caller := fs.Front().Value.(*frames.Frame)
+			caller.TOS++
+			caller.OpStack[caller.TOS] = ret
+		}
+	}
+	return fs
+}
+
+func insertJavaTestStreamClasses() {
+	closeStream := func(this *object.Object, _ []any) any {
+		this.FieldTable["closed"] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+		return nil
+	}
+	insertJavaTestClass(javaTestInputStreamClassName, "java/io/InputStream", map[string]javaTestMethod{
+		"read()I": func(this *object.Object, _ []any) any {
+			c, err := this.FieldTable["source"].Fvalue.(*bytes.Reader).ReadByte()
+			if err != nil {
+				return int64(-1)
+			}
+			return int64(c)
+		},
+		"read([BII)I": func(this *object.Object, args []any) any {
+			jbytes := args[0].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
+			off, length := args[1].(int64), args[2].(int64)
+			buf := make([]byte, length)
+			n, err := this.FieldTable["source"].Fvalue.(*bytes.Reader).Read(buf)
+			if err != nil {
+				return int64(-1)
+			}
+			copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+			return int64(n)
+		},
+		"available()I": func(this *object.Object, _ []any) any {
+			return int64(this.FieldTable["source"].Fvalue.(*bytes.Reader).Len())
+		},
+		"close()V": closeStream,
+	})
+	insertJavaTestClass(javaTestOutputStreamClassName, "java/io/OutputStream", map[string]javaTestMethod{
+		"write([BII)V": func(this *object.Object, args []any) any {
+			jbytes := args[0].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
+			off, length := args[1].(int64), args[2].(int64)
+			this.FieldTable["sink"].Fvalue.(*bytes.Buffer).Write(object.GoByteArrayFromJavaByteArray(jbytes[off : off+length]))
+			return nil
+		},
+		"flush()V": func(*object.Object, []any) any { return nil },
+		"close()V": closeStream,
+	})
+}
+
+// newJavaTestInputStream returns a Java InputStream that reads data.
+func newJavaTestInputStream(data []byte) *object.Object {
+	className := javaTestInputStreamClassName
+	in := object.MakeEmptyObjectWithClassName(&className)
+	in.FieldTable["source"] = object.Field{Ftype: types.RawGoPointer, Fvalue: bytes.NewReader(data)}
+	return in
+}
+
+// newJavaTestOutputStream returns a Java OutputStream that writes to sink.
+func newJavaTestOutputStream(sink *bytes.Buffer) *object.Object {
+	className := javaTestOutputStreamClassName
+	out := object.MakeEmptyObjectWithClassName(&className)
+	out.FieldTable["sink"] = object.Field{Ftype: types.RawGoPointer, Fvalue: sink}
+	return out
+}
+
+func javaTestStreamClosed(stream *object.Object) bool {
+	return stream.FieldTable["closed"].Fvalue == types.JavaBoolTrue
+}
+
+func TestDataStreams_RoundTrip(t *testing.T) {
+	setup()
+	Load_Io_ByteArrayInputStream()
+	Load_Io_ByteArrayOutputStream()
+
+	baos := newTestByteArrayOutputStream()
+	dos := object.MakeEmptyObject()
+	dataOutputStreamInit([]any{dos, baos})
+	for _, ret := range []any{
+		dataOutputStreamWriteInt([]any{dos, int64(-2)}),
+		dataOutputStreamWriteLong([]any{dos, int64(math.MaxInt64)}),
+		dataOutputStreamWriteDouble([]any{dos, 1.5}),
+		dataOutputStreamWriteBoolean([]any{dos, types.JavaBoolTrue}),
+		dataOutputStreamWriteShort([]any{dos, int64(0x12345)}),
+		dataOutputStreamWriteUTF([]any{dos, object.StringObjectFromGoString("a\u0000é")}),
+	} {
+		if ret != nil {
+			t.Fatalf("write failed: %v", ret)
+		}
+	}
+	if size := dataOutputStreamSize([]any{dos}); size != int64(4+8+8+1+2+2+5) {
+		t.Errorf("size: expected 30, observed %v", size)
+	}
+
+	data := byteArrayOutputStreamBytes(baos)
+	if data[0] != 0xFF || data[3] != 0xFE {
+		t.Errorf("writeInt(-2) is not big-endian: % X", data[:4])
+	}
+
+	dis := object.MakeEmptyObject()
+	dataInputStreamInit([]any{dis, newTestByteArrayInputStream(data)})
+	if v := dataInputStreamReadInt([]any{dis}); v != int64(-2) {
+		t.Errorf("readInt: expected -2, observed %v", v)
+	}
+	if v := dataInputStreamReadLong([]any{dis}); v != int64(math.MaxInt64) {
+		t.Errorf("readLong: expected MaxInt64, observed %v", v)
+	}
+	if v := dataInputStreamReadDouble([]any{dis}); v != 1.5 {
+		t.Errorf("readDouble: expected 1.5, observed %v", v)
+	}
+	if v := dataInputStreamReadBoolean([]any{dis}); v != types.JavaBoolTrue {
+		t.Errorf("readBoolean: expected true, observed %v", v)
+	}
+	if v := dataInputStreamReadShort([]any{dis}); v != int64(0x2345) {
+		t.Errorf("readShort: expected 0x2345, observed %v", v)
+	}
+	str, ok := dataInputStreamReadUTF([]any{dis}).(*object.Object)
+	if !ok || object.GoStringFromStringObject(str) != "a\u0000é" {
+		t.Errorf("readUTF: expected \"a\\u0000é\", observed %v", str)
+	}
+
+	ret := dataInputStreamReadInt([]any{dis})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.EOFException {
+		t.Errorf("readInt at the end: expected EOFException, observed %v", ret)
+	}
+}
+
+func TestDataInputStream_ReadFullyAndSkipBytes(t *testing.T) {
+	setup()
+	Load_Io_ByteArrayInputStream()
+
+	dis := object.MakeEmptyObject()
+	dataInputStreamInit([]any{dis, newTestByteArrayInputStream([]byte{1, 2, 3, 4, 5})})
+	if n := dataInputStreamSkipBytes([]any{dis, int64(2)}); n != int64(2) {
+		t.Errorf("skipBytes(2): expected 2, observed %v", n)
+	}
+
+	arr := object.Make1DimArray(object.T_BYTE, 4)
+	ret := dataInputStreamReadFully([]any{dis, arr})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.EOFException {
+		t.Errorf("readFully of 4 bytes from 3: expected EOFException, observed %v", ret)
+	}
+}
+
+func TestDataOutputStream_WriteUTFTooLong(t *testing.T) {
+	setup()
+	Load_Io_ByteArrayOutputStream()
+
+	dos := object.MakeEmptyObject()
+	dataOutputStreamInit([]any{dos, newTestByteArrayOutputStream()})
+	long := make([]byte, 70000)
+	for ix := range long {
+		long[ix] = 'x'
+	}
+	ret := dataOutputStreamWriteUTF([]any{dos, object.StringObjectFromGoString(string(long))})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.UTFDataFormatException {
+		t.Fatalf("expected UTFDataFormatException, observed %v", ret)
+	}
+	expected := "encoded string (xxxxxxxx...xxxxxxxx) too long: 70000 bytes"
+	if gerr.ErrMsg != expected {
+		t.Errorf("expected %q, observed %q", expected, gerr.ErrMsg)
+	}
+}
+
+func TestDataStreams_OverJavaStreams(t *testing.T) {
+	fs := newJavaTestFrameStack(t)
+
+	var sink bytes.Buffer
+	out := newJavaTestOutputStream(&sink)
+	dos := object.MakeEmptyObject()
+	dataOutputStreamInit([]any{dos, out})
+	for _, ret := range []any{
+		dataOutputStreamWriteInt([]any{fs, dos, int64(-2)}),
+		dataOutputStreamWriteUTF([]any{fs, dos, object.StringObjectFromGoString("é")}),
+		dataOutputStreamClose([]any{fs, dos}),
+	} {
+		if ret != nil {
+			t.Fatalf("write to a Java stream failed: %v", ret)
+		}
+	}
+	if !javaTestStreamClosed(out) {
+		t.Errorf("close did not close the Java stream")
+	}
+
+	in := newJavaTestInputStream(sink.Bytes())
+	dis := object.MakeEmptyObject()
+	dataInputStreamInit([]any{dis, in})
+	if n := dataInputStreamAvailable([]any{fs, dis}); n != int64(8) {
+		t.Errorf("available: expected 8, observed %v", n)
+	}
+	if v := dataInputStreamReadInt([]any{fs, dis}); v != int64(-2) {
+		t.Errorf("readInt: expected -2, observed %v", v)
+	}
+	str, ok := dataInputStreamReadUTF([]any{fs, dis}).(*object.Object)
+	if !ok || object.GoStringFromStringObject(str) != "é" {
+		t.Errorf("readUTF: expected \"é\", observed %v", str)
+	}
+	if c := dataInputStreamRead([]any{fs, dis}); c != int64(-1) {
+		t.Errorf("read at the end: expected -1, observed %v", c)
+	}
+
+	// without the frame stack, the Java methods cannot run
+	ret := dataInputStreamRead([]any{dis})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalStateException {
+		t.Errorf("read without a frame stack: expected IllegalStateException, observed %v", ret)
+	}
+}
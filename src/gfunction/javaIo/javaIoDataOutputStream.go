@@ -0,0 +1,343 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"container/list"
+	"encoding/binary"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+)
+
+// java.io.DataOutputStream writes big-endian primitive values and modified UTF-8 strings to
+// the stream in its "out" field, which can be any OutputStream. Like the JDK's, it counts the
+// bytes written in its "written" field, which size() returns.
+
+func Load_Io_DataOutputStream() {
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.<init>(Ljava/io/OutputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  dataOutputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataOutputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    dataOutputStreamFlush,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.size()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  dataOutputStreamSize,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteByte,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.write([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.write([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    dataOutputStreamWriteBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeBoolean(Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteBoolean,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeByte(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteByte,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeBytes(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteStringBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeChar(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteShort,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeChars(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteChars,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeDouble(D)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteDouble,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeFloat(F)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteFloat,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeInt(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteInt,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeLong(J)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteLong,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeShort(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteShort,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/DataOutputStream.writeUTF(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    dataOutputStreamWriteUTF,
+			NeedsContext: true,
+		}
+}
+
+// java/io/DataOutputStream.<init>(Ljava/io/OutputStream;)V
+func dataOutputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	self.FieldTable["out"] = object.Field{Ftype: "Ljava/io/OutputStream;", Fvalue: params[1]}
+	self.FieldTable["written"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+	return nil
+}
+
+// dataOutputPut writes data to the stream of a DataOutputStream and adds its length to the
+// count of bytes written, which stops at Integer.MAX_VALUE.
+func dataOutputPut(fs *list.List, this any, data []byte) *ghelpers.GErrBlk {
+	out, gerr := filteredStream(this, "out", "DataOutputStream")
+	if gerr != nil {
+		return gerr
+	}
+	w, gerr := ghelpers.GoWriterWithContext(fs, out, "DataOutputStream")
+	if gerr != nil {
+		return gerr
+	}
+	if _, err := w.Write(data); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+
+	self := this.(*object.Object)
+	written, _ := self.FieldTable["written"].Fvalue.(int64)
+	written = min(written+int64(len(data)), math.MaxInt32)
+	self.FieldTable["written"] = object.Field{Ftype: types.Int, Fvalue: written}
+	return nil
+}
+
+// java/io/DataOutputStream.close()V -- flushes the stream, then closes the underlying stream
+func dataOutputStreamClose(params []interface{}) interface{} {
+	if gerr := dataOutputStreamFlush(params); gerr != nil {
+		return gerr
+	}
+	fs, params := ghelpers.SplitContext(params)
+	out, gerr := filteredStream(params[0], "out", "DataOutputStream.close")
+	if gerr != nil {
+		return gerr
+	}
+	return ghelpers.InvokeMethodOnObject(fs, out, "close", "()V")
+}
+
+// java/io/DataOutputStream.flush()V
+func dataOutputStreamFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	out, gerr := filteredStream(params[0], "out", "DataOutputStream.flush")
+	if gerr != nil {
+		return gerr
+	}
+	w, gerr := ghelpers.GoWriterWithContext(fs, out, "DataOutputStream.flush")
+	if gerr != nil {
+		return gerr
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+		}
+	}
+	return nil
+}
+
+// java/io/DataOutputStream.size()I
+func dataOutputStreamSize(params []interface{}) interface{} {
+	written, _ := params[0].(*object.Object).FieldTable["written"].Fvalue.(int64)
+	return written
+}
+
+// java/io/DataOutputStream.write([B)V and write([BII)V
+func dataOutputStreamWriteBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "DataOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := dataOutputPut(fs, params[0], object.GoByteArrayFromJavaByteArray(jbytes[off:off+length])); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// dataOutputValue writes the primitive value in params[1] as the type typeCode. params can
+// start with the frame stack.
+func dataOutputValue(params []interface{}, typeCode byte) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if gerr := dataOutputPut(fs, params[0], appendPrimitive(nil, typeCode, params[1])); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/DataOutputStream.write(I)V and writeByte(I)V -- writes the low-order byte
+func dataOutputStreamWriteByte(params []interface{}) interface{} {
+	return dataOutputValue(params, 'B')
+}
+
+// java/io/DataOutputStream.writeBoolean(Z)V
+func dataOutputStreamWriteBoolean(params []interface{}) interface{} {
+	return dataOutputValue(params, 'Z')
+}
+
+// java/io/DataOutputStream.writeShort(I)V and writeChar(I)V -- writes the low-order two bytes
+func dataOutputStreamWriteShort(params []interface{}) interface{} {
+	return dataOutputValue(params, 'S')
+}
+
+// java/io/DataOutputStream.writeInt(I)V
+func dataOutputStreamWriteInt(params []interface{}) interface{} {
+	return dataOutputValue(params, 'I')
+}
+
+// java/io/DataOutputStream.writeLong(J)V
+func dataOutputStreamWriteLong(params []interface{}) interface{} {
+	return dataOutputValue(params, 'J')
+}
+
+// java/io/DataOutputStream.writeFloat(F)V
+func dataOutputStreamWriteFloat(params []interface{}) interface{} {
+	return dataOutputValue(params, 'F')
+}
+
+// java/io/DataOutputStream.writeDouble(D)V
+func dataOutputStreamWriteDouble(params []interface{}) interface{} {
+	return dataOutputValue(params, 'D')
+}
+
+// stringArgChars returns the chars of the String argument in params[1].
+func stringArgChars(params []interface{}, caller string) ([]uint16, *ghelpers.GErrBlk) {
+	str, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": string is null")
+	}
+	return javaChars(object.GoStringFromStringObject(str)), nil
+}
+
+// java/io/DataOutputStream.writeBytes(Ljava/lang/String;)V -- writes the low byte of each char
+func dataOutputStreamWriteStringBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	chars, gerr := stringArgChars(params, "DataOutputStream.writeBytes")
+	if gerr != nil {
+		return gerr
+	}
+	data := make([]byte, len(chars))
+	for ix, c := range chars {
+		data[ix] = byte(c)
+	}
+	if gerr := dataOutputPut(fs, params[0], data); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/DataOutputStream.writeChars(Ljava/lang/String;)V
+func dataOutputStreamWriteChars(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	chars, gerr := stringArgChars(params, "DataOutputStream.writeChars")
+	if gerr != nil {
+		return gerr
+	}
+	data := make([]byte, 0, 2*len(chars))
+	for _, c := range chars {
+		data = binary.BigEndian.AppendUint16(data, c)
+	}
+	if gerr := dataOutputPut(fs, params[0], data); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/DataOutputStream.writeUTF(Ljava/lang/String;)V -- a two-byte length, then the string
+// in modified UTF-8
+func dataOutputStreamWriteUTF(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	str, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "DataOutputStream.writeUTF: string is null")
+	}
+	s := object.GoStringFromStringObject(str)
+	enc := encodeModifiedUTF8(s)
+	if len(enc) > 0xFFFF {
+		return ghelpers.GetGErrBlk(excNames.UTFDataFormatException, utfTooLongMsg(s, len(enc)))
+	}
+	data := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(enc)), uint16(len(enc)))
+	if gerr := dataOutputPut(fs, params[0], append(data, enc...)); gerr != nil {
+		return gerr
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaNio"
 	"jacobin/src/object"
 	"jacobin/src/stringPool"
 	"jacobin/src/types"
@@ -120,7 +121,7 @@ func Load_Io_FileInputStream() {
 	ghelpers.MethodSignatures["java/io/FileInputStream.getChannel()Ljava/nio/channels/FileChannel;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  fisGetChannel,
 		}
 
 	ghelpers.MethodSignatures["java/io/FileInputStream.getFD()Ljava/io/FileDescriptor;"] =
@@ -435,3 +436,32 @@ func fisClose(params []interface{}) interface{} {
 	}
 	return nil
 }
+
+// "java/io/FileInputStream.getChannel()Ljava/nio/channels/FileChannel;"
+// The channel is read-only and shares the file and its position with the stream.
+func fisGetChannel(params []interface{}) interface{} {
+	return streamChannel(params[0].(*object.Object), "fisGetChannel", false)
+}
+
+// streamChannel returns the FileChannel of a stream object that has a FileHandle field, making
+// it on the first call. The channel is readable, and writable if writable is true.
+func streamChannel(obj *object.Object, caller string, writable bool) interface{} {
+	if ch, ok := obj.FieldTable["channel"].Fvalue.(*object.Object); ok {
+		return ch
+	}
+	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
+	if !ok {
+		errMsg := fmt.Sprintf("%s: object lacks a ghelpers.FileHandle field", caller)
+		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	}
+	var path string
+	switch p := obj.FieldTable[ghelpers.FilePath].Fvalue.(type) {
+	case []byte:
+		path = string(p)
+	case []types.JavaByte:
+		path = object.GoStringFromJavaByteArray(p)
+	}
+	ch := javaNio.NewFileChannelObject(osFile, path, true, writable)
+	obj.FieldTable["channel"] = object.Field{Ftype: "Ljava/nio/channels/FileChannel;", Fvalue: ch}
+	return ch
+}
@@ -35,53 +35,59 @@ func Load_Io_FileReader() {
 			GFunction:  initFileReaderString,
 		}
 
-	// -----------------------------------------
-	// traps that do nothing but return an error
-	// -----------------------------------------
-
-	ghelpers.MethodSignatures["java/io/FileReader.<init>(Ljava/io/FileDescriptor;)V"] =
-		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
 	ghelpers.MethodSignatures["java/io/FileReader.<init>(Ljava/io/File;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initFileReaderCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/FileReader.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initFileReaderCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/FileReader.close()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  isrClose,
 		}
 
 	ghelpers.MethodSignatures["java/io/FileReader.getEncoding()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  isrGetEncoding,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileReader.mark(I)V"] =
+	ghelpers.MethodSignatures["java/io/FileReader.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  isrReadOneChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/FileReader.read([CII)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  isrReadCharBufferSubset,
+		}
+
+	// -----------------------------------------
+	// traps that do nothing but return an error
+	// -----------------------------------------
+
+	ghelpers.MethodSignatures["java/io/FileReader.<init>(Ljava/io/FileDescriptor;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
 			GFunction:  ghelpers.TrapFunction,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileReader.markSupported()Z"] =
+	ghelpers.MethodSignatures["java/io/FileReader.mark(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
+			ParamSlots: 1,
 			GFunction:  ghelpers.TrapFunction,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileReader.read()I"] =
+	ghelpers.MethodSignatures["java/io/FileReader.markSupported()Z"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
 			GFunction:  ghelpers.TrapFunction,
@@ -93,12 +99,6 @@ func Load_Io_FileReader() {
 			GFunction:  ghelpers.TrapFunction,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileReader.read([CII)I"] =
-		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
 	ghelpers.MethodSignatures["java/io/FileReader.read(Ljava/nio/CharBuffer;)I"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
@@ -175,3 +175,27 @@ func initFileReaderString(params []interface{}) interface{} {
 
 	return nil
 }
+
+// "java/io/FileReader.<init>(Ljava/io/File;Ljava/nio/charset/Charset;)V" and
+// "java/io/FileReader.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;)V"
+func initFileReaderCharset(params []interface{}) interface{} {
+	cs, gerr := ghelpers.CharsetFromObject(params[2])
+	if gerr != nil {
+		return gerr
+	}
+
+	var ret interface{}
+	if object.IsStringObject(params[1].(*object.Object)) {
+		ret = initFileReaderString(params[:2])
+	} else {
+		ret = initFileReader(params[:2])
+	}
+	if ret != nil {
+		return ret
+	}
+
+	// Field ghelpers.FileCharset = the charset that reads decode
+	fld := object.Field{Ftype: types.RawGoPointer, Fvalue: cs}
+	params[0].(*object.Object).FieldTable[ghelpers.FileCharset] = fld
+	return nil
+}
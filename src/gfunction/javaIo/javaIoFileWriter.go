@@ -8,6 +8,8 @@ package javaIo
 
 import (
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
 )
 
 func Load_Io_FileWriter() {
@@ -72,44 +74,76 @@ func Load_Io_FileWriter() {
 			GFunction:  oswWriteStringBuffer,
 		}
 
-	// -----------------------------------------
-	// traps that do nothing but return an error
-	// -----------------------------------------
-
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/lang.String;)V"] =
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initFileWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/FileDescriptor;)V"] =
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/nio/charset/Charset;Z)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots: 3,
+			GFunction:  initFileWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/nio/charset/Charset;)V"] =
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initFileWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/nio/charset/Charset;Z)V"] =
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;Z)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initFileWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;)V"] =
+	// -----------------------------------------
+	// traps that do nothing but return an error
+	// -----------------------------------------
+
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/File;Ljava/lang.String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
 			GFunction:  ghelpers.TrapFunction,
 		}
 
-	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/lang/String;Ljava/nio/charset/Charset;Z)V"] =
+	ghelpers.MethodSignatures["java/io/FileWriter.<init>(Ljava/io/FileDescriptor;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
+			ParamSlots: 1,
 			GFunction:  ghelpers.TrapFunction,
 		}
 
 }
+
+// "java/io/FileWriter.<init>(Ljava/io/File;Ljava/nio/charset/Charset;)V" and the variants with a
+// path string or an append flag
+func initFileWriterCharset(params []interface{}) interface{} {
+	cs, gerr := ghelpers.CharsetFromObject(params[2])
+	if gerr != nil {
+		return gerr
+	}
+
+	// Open the file as FileOutputStream does, without the charset argument.
+	streamParams := append([]interface{}{params[0], params[1]}, params[3:]...)
+	isPath := object.IsStringObject(params[1].(*object.Object))
+	var ret interface{}
+	switch {
+	case isPath && len(params) == 4:
+		ret = initFileOutputStreamStringBoolean(streamParams)
+	case isPath:
+		ret = initFileOutputStreamString(streamParams)
+	case len(params) == 4:
+		ret = initFileOutputStreamFileBoolean(streamParams)
+	default:
+		ret = initFileOutputStreamFile(streamParams)
+	}
+	if ret != nil {
+		return ret
+	}
+
+	// Field ghelpers.FileCharset = the charset that writes encode
+	fld := object.Field{Ftype: types.RawGoPointer, Fvalue: cs}
+	params[0].(*object.Object).FieldTable[ghelpers.FileCharset] = fld
+	return nil
+}
@@ -7,10 +7,10 @@
 package javaIo
 
 import (
-	"fmt"
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
-	"jacobin/src/stringPool"
+	"jacobin/src/types"
 )
 
 func Load_Io_FilterInputStream() {
@@ -29,56 +29,65 @@ func Load_Io_FilterInputStream() {
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.available()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filterInputStreamAvailable,
+			ParamSlots:   0,
+			GFunction:    filterInputStreamAvailable,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filterInputStreamClose,
+			ParamSlots:   0,
+			GFunction:    filterInputStreamClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.mark(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  filterInputStreamMark,
+			ParamSlots:   1,
+			GFunction:    filterInputStreamMark,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.markSupported()Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filterInputStreamMarkSupported,
+			ParamSlots:   0,
+			GFunction:    filterInputStreamMarkSupported,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.read()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filterInputStreamRead,
+			ParamSlots:   0,
+			GFunction:    filterInputStreamRead,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.read([B)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  filterInputStreamReadByteArray,
+			ParamSlots:   1,
+			GFunction:    filterInputStreamReadByteArray,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.read([BII)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  filterInputStreamReadByteArrayOffset,
+			ParamSlots:   3,
+			GFunction:    filterInputStreamReadByteArrayOffset,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.reset()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filterInputStreamReset,
+			ParamSlots:   0,
+			GFunction:    filterInputStreamReset,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterInputStream.skip(J)J"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  filterInputStreamSkip,
+			ParamSlots:   1,
+			GFunction:    filterInputStreamSkip,
+			NeedsContext: true,
 		}
 
 }
@@ -90,80 +99,76 @@ func initFilterInputStream(params []interface{}) interface{} {
 	return nil
 }
 
+// The methods of a FilterInputStream call those of the stream it wraps, whichever class that
+// stream is. Java methods run on the frame stack fs.
+
+func filterInputStreamIn(self *object.Object) *object.Object {
+	in, _ := self.FieldTable["in"].Fvalue.(*object.Object)
+	return in
+}
+
 func filterInputStreamAvailable(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.available()I", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "available", "()I")
 }
 
 func filterInputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.close()V", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "close", "()V")
 }
 
 func filterInputStreamMark(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	readlimit := params[1].(int64)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.mark(I)V", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in, readlimit})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "mark", "(I)V", readlimit)
 }
 
 func filterInputStreamMarkSupported(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.markSupported()Z", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "markSupported", "()Z")
 }
 
 func filterInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.read()I", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "read", "()I")
 }
 
+// read([B)I is read(b, 0, b.length) on this stream, so a subclass that overrides read([BII)I
+// sees every read of an array, as in the JDK.
 func filterInputStreamReadByteArray(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	buf := params[1].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.read([B)I", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in, buf})
+	buf, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(buf) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "FilterInputStream.read: byte array is null")
+	}
+	length := int64(len(buf.FieldTable["value"].Fvalue.([]types.JavaByte)))
+	return ghelpers.InvokeMethodOnObject(fs, self, "read", "([BII)I", buf, int64(0), length)
 }
 
 func filterInputStreamReadByteArrayOffset(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	buf := params[1].(*object.Object)
 	off := params[2].(int64)
 	lenVal := params[3].(int64)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.read([BII)I", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in, buf, off, lenVal})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "read", "([BII)I", buf, off, lenVal)
 }
 
 func filterInputStreamReset(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.reset()V", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "reset", "()V")
 }
 
 func filterInputStreamSkip(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self := params[0].(*object.Object)
 	n := params[1].(int64)
-	in := self.FieldTable["in"].Fvalue.(*object.Object)
-	inClassName := stringPool.GetStringPointer(in.KlassName)
-	method := fmt.Sprintf("%s.skip(J)J", *inClassName)
-	return ghelpers.Invoke(method, []interface{}{in, n})
+	return ghelpers.InvokeMethodOnObject(fs, filterInputStreamIn(self), "skip", "(J)J", n)
 }
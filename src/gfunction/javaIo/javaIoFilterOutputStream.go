@@ -7,7 +7,10 @@
 package javaIo
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
+	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
@@ -31,32 +34,37 @@ func Load_Io_FilterOutputStream() {
 
 	ghelpers.MethodSignatures["java/io/FilterOutputStream.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filteroutputstreamClose,
+			ParamSlots:   0,
+			GFunction:    filteroutputstreamClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterOutputStream.flush()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  filteroutputstreamFlush,
+			ParamSlots:   0,
+			GFunction:    filteroutputstreamFlush,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterOutputStream.write(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  filteroutputstreamWrite,
+			ParamSlots:   1,
+			GFunction:    filteroutputstreamWrite,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterOutputStream.write([B)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  filteroutputstreamWriteBytes,
+			ParamSlots:   1,
+			GFunction:    filteroutputstreamWriteBytes,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/FilterOutputStream.write([BII)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  filteroutputstreamWriteBytesRange,
+			ParamSlots:   3,
+			GFunction:    filteroutputstreamWriteBytesRange,
+			NeedsContext: true,
 		}
 }
 
@@ -70,8 +78,17 @@ func filteroutputstreamInit(params []interface{}) interface{} {
 	if underlying == nil {
 		return ghelpers.GetGErrBlk(excNames.NullPointerException, "filteroutputstreamInit: underlying OutputStream is null")
 	}
+	self, _ := params[0].(*object.Object)
+	if self == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "filteroutputstreamInit: self is not object")
+	}
 	fldPath, ok := underlying.FieldTable[ghelpers.FilePath]
 	if !ok {
+		// Any other OutputStream is written through its own write methods.
+		if _, ok = underlying.FieldTable[ghelpers.FileHandle]; !ok {
+			self.FieldTable[fieldNameOut] = object.Field{Ftype: outputStreamType, Fvalue: underlying}
+			return nil
+		}
 		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamInit: underlying OutputStream lacks ghelpers.FilePath field")
 	}
 	fldHandle, ok := underlying.FieldTable[ghelpers.FileHandle]
@@ -88,16 +105,56 @@ func filteroutputstreamInit(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("filteroutputstreamInit: os.Stat(%s) failed: %v", pathStr, err))
 	}
 	// Copy fields onto this FilterOutputStream instance
-	self, _ := params[0].(*object.Object)
-	if self == nil {
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "filteroutputstreamInit: self is not object")
-	}
 	self.FieldTable[ghelpers.FilePath] = fldPath
 	self.FieldTable[ghelpers.FileHandle] = fldHandle
 	return nil
 }
 
+// filteroutputstreamWriteTo writes buf as FilterOutputStream does: byte by byte through write(I)
+// if a subclass overrides it, as in the JDK, and otherwise to the file or the Java stream that
+// self wraps.
+func filteroutputstreamWriteTo(fs *list.List, self *object.Object, buf []byte, caller string) interface{} {
+	if ghelpers.HasJavaOverride(self, "write", "(I)V", "java/io/FilterOutputStream") {
+		for _, b := range buf {
+			if gerr, ok := ghelpers.InvokeMethodOnObject(fs, self, "write", "(I)V", int64(b)).(*ghelpers.GErrBlk); ok {
+				return gerr
+			}
+		}
+		return nil
+	}
+
+	var w io.Writer
+	if osFile, ok := self.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+		w = osFile
+	} else if out, ok := self.FieldTable[fieldNameOut].Fvalue.(*object.Object); ok {
+		var gerr *ghelpers.GErrBlk
+		if w, gerr = ghelpers.GoWriterWithContext(fs, out, caller); gerr != nil {
+			return gerr
+		}
+	} else {
+		return ghelpers.GetGErrBlk(excNames.IOException, caller+": missing ghelpers.FileHandle field")
+	}
+	if _, err := w.Write(buf); err != nil {
+		var streamErr *ghelpers.StreamError
+		if errors.As(err, &streamErr) {
+			return streamErr.GErr
+		}
+		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: Write failed: %v", caller, err))
+	}
+	return nil
+}
+
+// close()V flushes this stream and closes the one it wraps, as in the JDK.
 func filteroutputstreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	if _, ok := self.FieldTable[fieldNameOut]; ok {
+		if gerr, ok := ghelpers.InvokeMethodOnObject(fs, self, "flush", "()V").(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		ret, _ := writerOutCall(fs, self, "close")
+		return ret
+	}
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
 	if !ok {
 		return nil
@@ -109,6 +166,10 @@ func filteroutputstreamClose(params []interface{}) interface{} {
 }
 
 func filteroutputstreamFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if ret, ok := writerOutCall(fs, params[0].(*object.Object), "flush"); ok {
+		return ret
+	}
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
 	if !ok {
 		return nil
@@ -120,14 +181,21 @@ func filteroutputstreamFlush(params []interface{}) interface{} {
 }
 
 func filteroutputstreamWrite(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWrite: missing ghelpers.FileHandle field")
-	}
+	fs, params := ghelpers.SplitContext(params)
 	wint, ok := params[1].(int64)
 	if !ok {
 		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWrite: missing int argument")
 	}
+	if out, ok := params[0].(*object.Object).FieldTable[fieldNameOut].Fvalue.(*object.Object); ok {
+		if gerr, ok := ghelpers.InvokeMethodOnObject(fs, out, "write", "(I)V", wint).(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		return nil
+	}
+	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWrite: missing ghelpers.FileHandle field")
+	}
 	buf := []byte{byte(wint % 256)}
 	if _, err := osFile.Write(buf); err != nil {
 		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("filteroutputstreamWrite: Write failed: %v", err))
@@ -136,26 +204,17 @@ func filteroutputstreamWrite(params []interface{}) interface{} {
 }
 
 func filteroutputstreamWriteBytes(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWriteBytes: missing ghelpers.FileHandle field")
-	}
+	fs, params := ghelpers.SplitContext(params)
 	javaBytes, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
 	if !ok {
 		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWriteBytes: byte[] lacks value field")
 	}
 	buf := object.GoByteArrayFromJavaByteArray(javaBytes)
-	if _, err := osFile.Write(buf); err != nil {
-		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("filteroutputstreamWriteBytes: Write failed: %v", err))
-	}
-	return nil
+	return filteroutputstreamWriteTo(fs, params[0].(*object.Object), buf, "filteroutputstreamWriteBytes")
 }
 
 func filteroutputstreamWriteBytesRange(params []interface{}) interface{} {
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWriteBytesRange: missing ghelpers.FileHandle field")
-	}
+	fs, params := ghelpers.SplitContext(params)
 	javaBytes, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
 	if !ok {
 		return ghelpers.GetGErrBlk(excNames.IOException, "filteroutputstreamWriteBytesRange: byte[] lacks value field")
@@ -169,8 +228,5 @@ func filteroutputstreamWriteBytesRange(params []interface{}) interface{} {
 	if length < 0 || offset < 0 || length > int64(len(buf))-offset {
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, fmt.Sprintf("filteroutputstreamWriteBytesRange: bad params offset=%d length=%d len=%d", offset, length, len(buf)))
 	}
-	if _, err := osFile.Write(buf[offset : offset+length]); err != nil {
-		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("filteroutputstreamWriteBytesRange: Write failed: %v", err))
-	}
-	return nil
+	return filteroutputstreamWriteTo(fs, params[0].(*object.Object), buf[offset:offset+length], "filteroutputstreamWriteBytesRange")
 }
@@ -1,6 +1,7 @@
 package javaIo
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -111,3 +112,31 @@ func TestFilterOutputStream_WriteRange_ParamError(t *testing.T) {
 	}
 	_ = filteroutputstreamClose([]interface{}{target})
 }
+
+func TestFilterOutputStream_OverJavaStream(t *testing.T) {
+	setup()
+	classloader.InitMethodArea()
+	Load_Io_ByteArrayOutputStream()
+
+	baos := newTestByteArrayOutputStream()
+	fos := object.MakeEmptyObject()
+	if res := filteroutputstreamInit([]interface{}{fos, baos}); res != nil {
+		t.Fatalf("filteroutputstreamInit returned error: %v", res)
+	}
+
+	if res := filteroutputstreamWrite([]interface{}{fos, int64('a')}); res != nil {
+		t.Fatalf("write(I) returned error: %v", res)
+	}
+	arr := object.Make1DimArray(object.T_BYTE, 0)
+	arr.FieldTable["value"] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoString("xbcx")}
+	if res := filteroutputstreamWriteBytesRange([]interface{}{fos, arr, int64(1), int64(2)}); res != nil {
+		t.Fatalf("write([BII) returned error: %v", res)
+	}
+	if res := filteroutputstreamFlush([]interface{}{fos}); res != nil {
+		t.Fatalf("flush returned error: %v", res)
+	}
+
+	if got := string(byteArrayOutputStreamBytes(baos)); got != "abc" {
+		t.Errorf("expected %q, got %q", "abc", got)
+	}
+}
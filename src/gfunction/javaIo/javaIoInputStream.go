@@ -7,6 +7,7 @@
 package javaIo
 
 import (
+	"fmt"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
@@ -23,19 +24,19 @@ func Load_Io_InputStream() {
 	ghelpers.MethodSignatures["java/io/InputStream.close()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  ghelpers.JustReturn,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.mark(I)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  ghelpers.JustReturn,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.markSupported()Z"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  ghelpers.ReturnFalse,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.nullInputStream()Ljava/io/InputStream;"] =
@@ -52,14 +53,16 @@ func Load_Io_InputStream() {
 
 	ghelpers.MethodSignatures["java/io/InputStream.read([B)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  inputStreamReadIntoByteArray,
+			ParamSlots:   1,
+			GFunction:    inputStreamReadIntoByteArray,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.read([BII)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   3,
+			GFunction:    inputStreamReadIntoByteArray,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.readAllBytes()[B"] =
@@ -83,13 +86,14 @@ func Load_Io_InputStream() {
 	ghelpers.MethodSignatures["java/io/InputStream.reset()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  inputStreamReset,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.skip(J)J"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    inputStreamSkip,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStream.skipNBytes(J)V"] =
@@ -109,28 +113,77 @@ func inputStreamAvailable(params []any) any {
 	return int64(0)
 }
 
-// java/io/InputStream.read([B)I reads stdin contents to a byte array
-// returns number of bytes processed or -1 if EOF
+// java/io/InputStream.read([B)I and read([BII)I -- as in the JDK, these call read()I for each
+// byte, so that a subclass need only implement read()I. Reading stops at the end of the
+// stream, which is -1 if no byte was read.
 func inputStreamReadIntoByteArray(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
 	if object.IsNull(params[1]) {
 		errMsg := "java.lang.io.inputStream.read() called with null array"
 		return ghelpers.GetGErrBlk(excNames.NullPointerException, errMsg)
 	}
 
-	byteArray, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
-	if !ok {
-		if object.IsNull(byteArray) {
-			errMsg := "java.lang.io.inputStream.read() called with null array"
-			return ghelpers.GetGErrBlk(excNames.NullPointerException, errMsg)
+	byteArray, _ := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := int64(0), int64(len(byteArray))
+	if len(params) == 4 {
+		off, _ = params[2].(int64)
+		length, _ = params[3].(int64)
+		if off < 0 || length < 0 || off+length > int64(len(byteArray)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", off, off, length, len(byteArray))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 		}
 	}
+	if length == 0 {
+		return int64(0)
+	}
+
+	self := params[0].(*object.Object)
+	for n := int64(0); n < length; n++ {
+		ret := ghelpers.InvokeMethodOnObject(fs, self, "read", "()I")
+		c, ok := ret.(int64)
+		if !ok {
+			if n > 0 {
+				return n // as in the JDK, the exception is lost once bytes have been read
+			}
+			return ret
+		}
+		if c < 0 {
+			if n == 0 {
+				return int64(-1)
+			}
+			return n
+		}
+		byteArray[off+n] = types.JavaByte(c)
+	}
+	return length
+}
 
-	if len(byteArray) == 0 {
+// java/io/InputStream.skip(J)J -- reads and discards up to n bytes
+func inputStreamSkip(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	n := params[1].(int64)
+	if n <= 0 {
 		return int64(0)
 	}
+	self := params[0].(*object.Object)
+	buf := object.Make1DimArray(object.T_BYTE, min(n, 2048))
+	remaining := n
+	for remaining > 0 {
+		size := min(remaining, 2048)
+		ret := ghelpers.InvokeMethodOnObject(fs, self, "read", "([BII)I", buf, int64(0), size)
+		nr, ok := ret.(int64)
+		if !ok {
+			return ret
+		}
+		if nr < 0 {
+			break
+		}
+		remaining -= nr
+	}
+	return n - remaining
+}
 
-	// for the time being, we return -1 to indicate EOF
-	// TODO: add the system interface to read from stdin
-	// for both this function and available()
-	return int64(-1)
+// java/io/InputStream.reset()V
+func inputStreamReset(params []any) any {
+	return ghelpers.GetGErrBlk(excNames.IOException, "mark/reset not supported")
 }
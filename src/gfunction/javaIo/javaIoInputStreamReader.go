@@ -7,10 +7,13 @@
 package javaIo
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
 	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaNio"
 	"jacobin/src/object"
 	"jacobin/src/types"
 	"os"
@@ -33,59 +36,80 @@ func Load_Io_InputStreamReader() {
 	ghelpers.MethodSignatures["java/io/InputStreamReader.<init>(Ljava/io/InputStream;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  inputStreamReaderInitCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.<init>(Ljava/io/InputStream;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  inputStreamReaderInitCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.<init>(Ljava/io/InputStream;Ljava/nio/charset/CharsetDecoder;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  inputStreamReaderInitCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrClose,
+			ParamSlots:   0,
+			GFunction:    isrClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.getEncoding()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.ReturnCharsetName,
+			GFunction:  isrGetEncoding,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.read()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrReadOneChar,
+			ParamSlots:   0,
+			GFunction:    isrReadOneChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.read([CII)I"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  isrReadCharBufferSubset,
+			ParamSlots:   3,
+			GFunction:    isrReadCharBufferSubset,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/InputStreamReader.ready()Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  isrReady,
+			ParamSlots:   0,
+			GFunction:    isrReady,
+			NeedsContext: true,
 		}
 
 }
 
+// fieldNameIn holds the Java stream or reader that a decorator reads from when it is not over a
+// file that Go has open. Its Ftype tells which of the two it is.
+const fieldNameIn = "in"
+
+const (
+	inputStreamType = "Ljava/io/InputStream;"
+	readerType      = "Ljava/io/Reader;"
+)
+
 // "java/io/InputStreamReader.<init>(Ljava/io/InputStream;)V"
 func inputStreamReaderInit(params []interface{}) interface{} {
+	in, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(in) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "inputStreamReaderInit: InputStream is null")
+	}
 
-	// Get file path field.
-	fldPath, ok := params[1].(*object.Object).FieldTable[ghelpers.FilePath]
+	// Any other InputStream is read through its own read methods.
+	fldPath, ok := in.FieldTable[ghelpers.FilePath]
 	if !ok {
+		if _, ok = in.FieldTable[ghelpers.FileHandle]; !ok {
+			params[0].(*object.Object).FieldTable[fieldNameIn] = object.Field{Ftype: inputStreamType, Fvalue: in}
+			return nil
+		}
 		errMsg := "inputStreamReaderInit: InputStream object lacks a ghelpers.FilePath field"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
@@ -115,12 +139,120 @@ func inputStreamReaderInit(params []interface{}) interface{} {
 	return nil
 }
 
+// "java/io/InputStreamReader.<init>(Ljava/io/InputStream;Ljava/lang/String;)V" and the variants
+// with a Charset and a CharsetDecoder. A decoder's error actions apply to every read.
+func inputStreamReaderInitCharset(params []interface{}) interface{} {
+	var cs *ghelpers.Charset
+	var gerr *ghelpers.GErrBlk
+	malformed, unmappable := ghelpers.CodingReplace, ghelpers.CodingReplace
+	arg, _ := params[2].(*object.Object)
+	switch {
+	case arg != nil && object.IsStringObject(arg):
+		cs, gerr = ghelpers.CharsetFromName(arg)
+	case arg != nil && !object.IsNull(arg) && arg.FieldTable["charset"].Fvalue != nil:
+		cs, malformed, unmappable, gerr = javaNio.CoderFromObject(arg)
+	default:
+		cs, gerr = ghelpers.CharsetFromObject(arg)
+	}
+	if gerr != nil {
+		return gerr
+	}
+
+	if ret := inputStreamReaderInit(params[:2]); ret != nil {
+		return ret
+	}
+	obj := params[0].(*object.Object)
+	obj.FieldTable[ghelpers.FileCharset] = object.Field{Ftype: types.RawGoPointer, Fvalue: cs}
+	osFile, _ := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
+	var r io.Reader
+	if osFile != nil {
+		r = osFile
+	}
+	cr := ghelpers.NewCharReader(cs, r)
+	cr.Malformed, cr.Unmappable = malformed, unmappable
+	obj.FieldTable[ghelpers.FileDecoder] = object.Field{Ftype: types.RawGoPointer, Fvalue: cr}
+	return nil
+}
+
+// readerDecoder returns the decoder of a reader over r, which is made on the first read in the
+// reader's charset, or the default charset.
+func readerDecoder(obj *object.Object, r io.Reader) *ghelpers.CharReader {
+	if cr, ok := obj.FieldTable[ghelpers.FileDecoder].Fvalue.(*ghelpers.CharReader); ok {
+		return cr
+	}
+	cs, ok := obj.FieldTable[ghelpers.FileCharset].Fvalue.(*ghelpers.Charset)
+	if !ok {
+		cs = ghelpers.DefaultCharset()
+	}
+	cr := ghelpers.NewCharReader(cs, r)
+	obj.FieldTable[ghelpers.FileDecoder] = object.Field{Ftype: types.RawGoPointer, Fvalue: cr}
+	return cr
+}
+
+// readerChars returns what a reader reads its chars from: the decoder of the file it has open,
+// or else the Java reader or stream in its "in" field. The chars of a stream are decoded by the
+// reader's decoder, which reads the stream's bytes by calling its read methods on fs.
+func readerChars(fs *list.List, obj *object.Object, caller string) (ghelpers.CharSource, *ghelpers.GErrBlk) {
+	if osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+		return readerDecoder(obj, osFile), nil
+	}
+
+	fld, ok := obj.FieldTable[fieldNameIn]
+	if !ok {
+		errMsg := fmt.Sprintf("%s: Reader object lacks a ghelpers.FileHandle field", caller)
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	}
+	if fld.Ftype == readerType {
+		return ghelpers.GoCharSourceWithContext(fs, fld.Fvalue, caller)
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, fld.Fvalue, caller)
+	if gerr != nil {
+		return nil, gerr
+	}
+	cr := readerDecoder(obj, r)
+	cr.SetReader(r)
+	return cr, nil
+}
+
+// readerError returns the exception for an error from a reader's decoder or from the Java stream
+// that it reads.
+func readerError(caller string, err error) *ghelpers.GErrBlk {
+	var codingErr *ghelpers.CodingError
+	if errors.As(err, &codingErr) {
+		return codingErr.GErrBlk()
+	}
+	var streamErr *ghelpers.StreamError
+	if errors.As(err, &streamErr) {
+		return streamErr.GErr
+	}
+	errMsg := fmt.Sprintf("%s: osFile.Read failed, reason: %s", caller, err.Error())
+	return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+}
+
+// "java/io/InputStreamReader.getEncoding()Ljava/lang/String;" -- the historical name of the charset
+func isrGetEncoding(params []interface{}) interface{} {
+	obj := params[0].(*object.Object)
+	cs, ok := obj.FieldTable[ghelpers.FileCharset].Fvalue.(*ghelpers.Charset)
+	if !ok {
+		cs = ghelpers.DefaultCharset()
+	}
+	return object.StringObjectFromGoString(cs.HistoricalName)
+}
+
 // "java/io/InputStreamReader.close()V"
 func isrClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 
 	// Get file handle.
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
+	obj := params[0].(*object.Object)
+	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
 	if !ok {
+		if in, ok := obj.FieldTable[fieldNameIn].Fvalue.(*object.Object); ok {
+			if gerr, ok := ghelpers.InvokeMethodOnObject(fs, in, "close", "()V").(*ghelpers.GErrBlk); ok {
+				return gerr
+			}
+			return nil
+		}
 		errMsg := "isrClose: InputStreamReader object lacks a ghelpers.FileHandle field"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
@@ -137,48 +269,38 @@ func isrClose(params []interface{}) interface{} {
 // Almost a duplicate of fisReadOne in fileInputStream.go
 // "java/io/InputStreamReader.read()I"
 func isrReadOneChar(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 
 	// Get InputStream object.
 	obj := params[0].(*object.Object)
 
-	// Get file handle.
-	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "isrReadOneChar: InputStreamReader object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Get the source of the chars.
+	chars, gerr := readerChars(fs, obj, "isrReadOneChar")
+	if gerr != nil {
+		return gerr
 	}
 
-	// Need a one-byte buffer.
-	buffer := make([]byte, 1)
-
-	// Read one byte.
-	_, err := osFile.Read(buffer)
+	// Read one char.
+	ch, err := chars.ReadChar()
 	if err == io.EOF {
 		ghelpers.EofSet(obj, true)
 		return int64(-1) // return -1 on EOF
 	}
 	if err != nil {
-		errMsg := fmt.Sprintf("isrReadOneChar: osFile.Read failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+		return readerError("isrReadOneChar", err)
 	}
 
-	// Return the byte as an integer.
-	return int64(buffer[0])
+	// Return the char as an integer.
+	return int64(ch)
 }
 
 // "java/io/InputStreamReader.read([CII)I"
 func isrReadCharBufferSubset(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 
 	// Get InputStream object.
 	obj := params[0].(*object.Object)
 
-	// Get file handle.
-	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "isrReadCharBufferSubset: InputStreamReader object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
-
 	// Get the parameter buffer, offset, and length.
 	intArray, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]int64)
 	if !ok {
@@ -198,34 +320,48 @@ func isrReadCharBufferSubset(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 	}
 
-	// Fill the replacement byte buffer.
-	inBytes := make([]byte, length)
-	nbytes, err := osFile.Read(inBytes)
-	if err == io.EOF {
-		ghelpers.EofSet(obj, true)
-		return int64(-1) // return -1 on EOF
-	}
-	if err != nil {
-		errMsg := fmt.Sprintf("isrReadCharBufferSubset: osFile.Read failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Get the source of the chars.
+	chars, gerr := readerChars(fs, obj, "isrReadCharBufferSubset")
+	if gerr != nil {
+		return gerr
 	}
 
-	// Update the parameter buffer, beginning at the offset.
-	for ii := int64(0); ii < int64(nbytes); ii++ {
-		intArray[offset+ii] = int64(inBytes[ii])
+	// Read chars into the parameter buffer, beginning at the offset.
+	var nchars int64
+	for nchars < length {
+		ch, err := chars.ReadChar()
+		if err == io.EOF {
+			ghelpers.EofSet(obj, true)
+			break
+		}
+		if err != nil {
+			return readerError("isrReadCharBufferSubset", err)
+		}
+		intArray[offset+nchars] = int64(ch)
+		nchars++
+	}
+	if nchars == 0 {
+		return int64(-1) // return -1 on EOF
 	}
 
 	// Update the parameter buffer.
 	fld := object.Field{Ftype: types.IntArray, Fvalue: intArray}
 	params[1].(*object.Object).FieldTable["value"] = fld
 
-	// Return the number of bytes.
-	return int64(nbytes)
+	// Return the number of chars.
+	return nchars
 
 }
 
 // "java/io/InputStreamReader.ready()Z"
 func isrReady(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+
+	// A reader over a Java stream is ready if it has chars left to decode or the stream has bytes.
+	obj := params[0].(*object.Object)
+	if fld, ok := obj.FieldTable[fieldNameIn]; ok {
+		return readerReady(fs, obj, fld)
+	}
 
 	// Get file path.
 	fldPath, ok := params[1].(*object.Object).FieldTable[ghelpers.FilePath]
@@ -254,3 +390,19 @@ func isrReady(params []interface{}) interface{} {
 
 	return types.JavaBoolTrue
 }
+
+// readerReady returns ready()Z of a reader over the Java reader or stream in its field fld.
+func readerReady(fs *list.List, obj *object.Object, fld object.Field) interface{} {
+	in := fld.Fvalue.(*object.Object)
+	if fld.Ftype == readerType {
+		return ghelpers.InvokeMethodOnObject(fs, in, "ready", "()Z")
+	}
+	if cr, ok := obj.FieldTable[ghelpers.FileDecoder].Fvalue.(*ghelpers.CharReader); ok && cr.Buffered() {
+		return types.JavaBoolTrue
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, in, "available", "()I")
+	if n, ok := ret.(int64); ok {
+		return types.ConvertGoBoolToJavaBool(n > 0)
+	}
+	return ret
+}
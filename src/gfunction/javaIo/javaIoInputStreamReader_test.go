@@ -1,6 +1,7 @@
 package javaIo
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -172,3 +173,76 @@ func TestInputStreamReader_Ready_And_Close(t *testing.T) {
 		t.Fatalf("expected error on closing already closed file, got nil")
 	}
 }
+
+func TestInputStreamReader_Latin1(t *testing.T) {
+	globals.InitStringPool()
+
+	filePath := filepath.Join(t.TempDir(), "isr_latin1.txt")
+	if err := os.WriteFile(filePath, []byte{'c', 0xE9}, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	inStreamObj := makeInputStreamObjForFile(t, filePath)
+	target := object.MakeEmptyObject()
+	charsetName := object.StringObjectFromGoString("ISO-8859-1")
+	if res := inputStreamReaderInitCharset([]interface{}{target, inStreamObj, charsetName}); res != nil {
+		t.Fatalf("inputStreamReaderInitCharset returned error: %v", res)
+	}
+
+	for _, want := range []int64{'c', 0xE9, -1} {
+		if got := isrReadOneChar([]interface{}{target}); got != want {
+			t.Errorf("isrReadOneChar: got %v, want %v", got, want)
+		}
+	}
+	enc := isrGetEncoding([]interface{}{target}).(*object.Object)
+	if object.GoStringFromStringObject(enc) != "ISO8859_1" {
+		t.Errorf("getEncoding: got %q", object.GoStringFromStringObject(enc))
+	}
+	_ = isrClose([]interface{}{target})
+}
+
+func TestInputStreamReader_UnsupportedEncoding(t *testing.T) {
+	globals.InitStringPool()
+
+	filePath := filepath.Join(t.TempDir(), "isr_bad.txt")
+	if err := os.WriteFile(filePath, []byte("x"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	inStreamObj := makeInputStreamObjForFile(t, filePath)
+	defer inStreamObj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File).Close()
+
+	res := inputStreamReaderInitCharset([]interface{}{object.MakeEmptyObject(), inStreamObj,
+		object.StringObjectFromGoString("x-no-such-charset")})
+	gerr, ok := res.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.UnsupportedEncodingException {
+		t.Errorf("expected UnsupportedEncodingException, got %v", res)
+	}
+}
+
+func TestInputStreamReader_OverJavaStream(t *testing.T) {
+	setup()
+	classloader.InitMethodArea()
+	Load_Io_ByteArrayInputStream()
+
+	in := newTestByteArrayInputStream([]byte("h\xc3\xa9\xf0\x9f\x98\x80"))
+	target := object.MakeEmptyObject()
+	charsetName := object.StringObjectFromGoString("UTF-8")
+	if res := inputStreamReaderInitCharset([]interface{}{target, in, charsetName}); res != nil {
+		t.Fatalf("inputStreamReaderInitCharset returned error: %v", res)
+	}
+
+	if v := isrReady([]interface{}{target}); v != types.JavaBoolTrue {
+		t.Errorf("ready: got %v, want true", v)
+	}
+	for _, want := range []int64{'h', 0xE9, 0xD83D, 0xDE00, -1} {
+		if got := isrReadOneChar([]interface{}{target}); got != want {
+			t.Errorf("isrReadOneChar: got %v, want %v", got, want)
+		}
+	}
+	if v := isrReady([]interface{}{target}); v != types.JavaBoolFalse {
+		t.Errorf("ready at the end: got %v, want false", v)
+	}
+	if res := isrClose([]interface{}{target}); res != nil {
+		t.Errorf("isrClose returned error: %v", res)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf16"
+)
+
+// DataOutput.writeUTF and the serialization protocol encode strings in "modified UTF-8":
+// the string's UTF-16 code units are encoded one at a time, so a supplementary character is
+// written as two three-byte surrogates, and U+0000 is written as the two bytes 0xC0 0x80.
+
+// encodeModifiedUTF8 returns the modified UTF-8 encoding of s, without a length prefix.
+func encodeModifiedUTF8(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	out := make([]byte, 0, len(units))
+	for _, c := range units {
+		switch {
+		case c >= 0x0001 && c <= 0x007F:
+			out = append(out, byte(c))
+		case c <= 0x07FF: // includes U+0000
+			out = append(out, byte(0xC0|(c>>6)&0x1F), byte(0x80|c&0x3F))
+		default:
+			out = append(out, byte(0xE0|(c>>12)&0x0F), byte(0x80|(c>>6)&0x3F), byte(0x80|c&0x3F))
+		}
+	}
+	return out
+}
+
+// decodeModifiedUTF8 decodes the modified UTF-8 bytes in b. The error message is the one
+// DataInputStream.readUTF puts in its UTFDataFormatException.
+func decodeModifiedUTF8(b []byte) (string, error) {
+	units := make([]uint16, 0, len(b))
+	for ix := 0; ix < len(b); {
+		c := b[ix]
+		switch c >> 4 {
+		case 0, 1, 2, 3, 4, 5, 6, 7: // 0xxxxxxx
+			units = append(units, uint16(c))
+			ix++
+		case 12, 13: // 110x xxxx  10xx xxxx
+			if ix+2 > len(b) {
+				return "", errors.New("malformed input: partial character at end")
+			}
+			c2 := b[ix+1]
+			if c2&0xC0 != 0x80 {
+				return "", fmt.Errorf("malformed input around byte %d", ix+1)
+			}
+			units = append(units, uint16(c&0x1F)<<6|uint16(c2&0x3F))
+			ix += 2
+		case 14: // 1110 xxxx  10xx xxxx  10xx xxxx
+			if ix+3 > len(b) {
+				return "", errors.New("malformed input: partial character at end")
+			}
+			c2, c3 := b[ix+1], b[ix+2]
+			if c2&0xC0 != 0x80 || c3&0xC0 != 0x80 {
+				return "", fmt.Errorf("malformed input around byte %d", ix+2)
+			}
+			units = append(units, uint16(c&0x0F)<<12|uint16(c2&0x3F)<<6|uint16(c3&0x3F))
+			ix += 3
+		default: // 10xx xxxx, 1111 xxxx
+			return "", fmt.Errorf("malformed input around byte %d", ix)
+		}
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// javaChars returns the UTF-16 code units of s, which are the chars of a Java string.
+func javaChars(s string) []uint16 {
+	return utf16.Encode([]rune(s))
+}
+
+// utfTooLongMsg is the message of the UTFDataFormatException that DataOutputStream.writeUTF
+// throws for a string whose encoding, of length utflen, does not fit in 65535 bytes.
+func utfTooLongMsg(s string, utflen int) string {
+	chars := javaChars(s)
+	head := string(utf16.Decode(chars[:8]))
+	tail := string(utf16.Decode(chars[len(chars)-8:]))
+	return fmt.Sprintf("encoded string (%s...%s) too long: %d bytes", head, tail, utflen)
+}
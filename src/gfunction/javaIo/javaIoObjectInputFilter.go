@@ -0,0 +1,486 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// java.io.ObjectInputFilter lets an application check the classes, array sizes, and graph
+// size of what an ObjectInputStream reads. ObjectInputFilter.Config.createFilter builds a
+// filter from a pattern string, such as "maxdepth=5;java.util.*;!*"; that filter is a Go
+// serialFilter. Filters written in Java are called through their checkInput method.
+
+const (
+	filterStatusClassName = "java/io/ObjectInputFilter$Status"
+	globalFilterClassName = "java/io/ObjectInputFilter$Config$Global"
+	filterInfoClassName   = "java/io/ObjectInputStream$FilterValues"
+	filterInfoDesc        = "(Ljava/io/ObjectInputFilter$FilterInfo;)Ljava/io/ObjectInputFilter$Status;"
+	serialFilterField     = "$filter" // the *serialFilter of a filter made by createFilter
+	filterInfoField       = "$info"   // the *filterInfo of a FilterValues object
+
+	statusUndecided = "UNDECIDED"
+	statusAllowed   = "ALLOWED"
+	statusRejected  = "REJECTED"
+)
+
+// serialFilter is a filter made from a pattern string.
+type serialFilter struct {
+	spec      string
+	maxDepth  int64
+	maxRefs   int64
+	maxBytes  int64
+	maxArray  int64
+	checkType bool // the patterns check the component type of arrays
+	patterns  []filterPattern
+}
+
+type filterPattern struct {
+	negate bool
+	kind   byte   // '=' an exact class name, '.' a package, '*' a package and its subpackages, 'p' a prefix
+	value  string // a binary class name, or a package or prefix of one
+}
+
+// filterInfo is what a filter checks: the class being read, or null for a back reference;
+// the length of an array, or -1; and the size of the graph read so far.
+type filterInfo struct {
+	className   string
+	arrayLength int64
+	depth       int64
+	references  int64
+	streamBytes int64
+}
+
+var (
+	globalSerialFilter *object.Object
+	globalFilterLock   sync.Mutex
+)
+
+func Load_Io_ObjectInputFilter() {
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.createFilter(Ljava/lang/String;)Ljava/io/ObjectInputFilter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectInputFilterCreateFilter,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.getSerialFilter()Ljava/io/ObjectInputFilter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputFilterGetSerialFilter,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.getSerialFilterFactory()Ljava/util/function/BinaryOperator;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.setSerialFilter(Ljava/io/ObjectInputFilter;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectInputFilterSetSerialFilter,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config.setSerialFilterFactory(Ljava/util/function/BinaryOperator;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config$Global.checkInput(Ljava/io/ObjectInputFilter$FilterInfo;)Ljava/io/ObjectInputFilter$Status;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    objectInputFilterCheckInput,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputFilter$Config$Global.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputFilterToString,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream$FilterValues.arrayLength()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  filterValuesArrayLength,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream$FilterValues.depth()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  filterValuesDepth,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream$FilterValues.references()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  filterValuesReferences,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream$FilterValues.serialClass()Ljava/lang/Class;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  filterValuesSerialClass,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream$FilterValues.streamBytes()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  filterValuesStreamBytes,
+		}
+}
+
+// parseSerialFilter parses a pattern string, as described in ObjectInputFilter.Config.
+// It returns nil if the string has no patterns or limits.
+func parseSerialFilter(spec string) (*serialFilter, error) {
+	f := &serialFilter{spec: spec, maxDepth: math.MaxInt64, maxRefs: math.MaxInt64,
+		maxBytes: math.MaxInt64, maxArray: math.MaxInt64}
+	empty := true
+	for _, p := range strings.Split(spec, ";") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		empty = false
+
+		if eq := strings.Index(p, "="); eq >= 0 {
+			name, val := p[:eq], p[eq+1:]
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid %s value: %s", name, val)
+			}
+			switch name {
+			case "maxdepth":
+				f.maxDepth = n
+			case "maxrefs":
+				f.maxRefs = n
+			case "maxbytes":
+				f.maxBytes = n
+			case "maxarray":
+				f.maxArray = n
+			default:
+				return nil, fmt.Errorf("unknown limit: %s", name)
+			}
+			continue
+		}
+
+		pat := filterPattern{negate: strings.HasPrefix(p, "!")}
+		name := strings.TrimPrefix(p, "!")
+		if slash := strings.Index(name, "/"); slash >= 0 {
+			name = name[slash+1:] // the module of the class is not checked
+		}
+		if name == "" {
+			return nil, fmt.Errorf("class or package missing in: \"%s\"", p)
+		}
+		f.checkType = true
+		switch {
+		case strings.HasSuffix(name, ".**"):
+			pat.kind, pat.value = '*', strings.TrimSuffix(name, "**")
+		case strings.HasSuffix(name, ".*"):
+			pat.kind, pat.value = '.', strings.TrimSuffix(name, "*")
+		case strings.HasSuffix(name, "*"):
+			pat.kind, pat.value = 'p', strings.TrimSuffix(name, "*")
+		default:
+			pat.kind, pat.value = '=', name
+		}
+		f.patterns = append(f.patterns, pat)
+	}
+	if empty {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// matches reports whether the binary class name matches the pattern.
+func (p filterPattern) matches(name string) bool {
+	switch p.kind {
+	case '=':
+		return name == p.value
+	case '.':
+		return strings.HasPrefix(name, p.value) && !strings.Contains(name[len(p.value):], ".")
+	case '*':
+		return strings.HasPrefix(name, p.value)
+	}
+	return strings.HasPrefix(name, p.value)
+}
+
+// check returns the status of info: REJECTED if a limit is exceeded or the first pattern
+// that matches the class is negated, ALLOWED if that pattern is not negated, and
+// UNDECIDED otherwise.
+func (f *serialFilter) check(info filterInfo) string {
+	if info.references < 0 || info.depth < 0 || info.streamBytes < 0 ||
+		info.references > f.maxRefs || info.depth > f.maxDepth || info.streamBytes > f.maxBytes ||
+		info.arrayLength > f.maxArray {
+		return statusRejected
+	}
+	name := info.className
+	if name == "" {
+		return statusUndecided
+	}
+	if strings.HasPrefix(name, "[") {
+		if !f.checkType {
+			return statusUndecided
+		}
+		name = strings.TrimLeft(name, "[")
+		if !strings.HasPrefix(name, "L") {
+			return statusUndecided // an array of a primitive type
+		}
+		name = strings.TrimSuffix(name[1:], ";")
+	}
+	if _, ok := primitiveTypeNames[name]; ok {
+		return statusUndecided
+	}
+	name = binaryName(name)
+	for _, p := range f.patterns {
+		if p.matches(name) {
+			if p.negate {
+				return statusRejected
+			}
+			return statusAllowed
+		}
+	}
+	return statusUndecided
+}
+
+// newFilterObject makes the ObjectInputFilter object of a Go filter.
+func newFilterObject(f *serialFilter) *object.Object {
+	className := globalFilterClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[serialFilterField] = object.Field{Ftype: types.RawGoPointer, Fvalue: f}
+	return obj
+}
+
+// filterStatus returns the ObjectInputFilter.Status constant named name.
+func filterStatus(name string) *object.Object {
+	if st, ok := statics.QueryStatic(filterStatusClassName, name); ok {
+		if obj, ok := st.Value.(*object.Object); ok && !object.IsNull(obj) {
+			return obj
+		}
+	}
+	className := filterStatusClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	return obj
+}
+
+// newFilterInfoObject makes the ObjectInputFilter.FilterInfo object passed to a Java filter.
+func newFilterInfoObject(info *filterInfo) *object.Object {
+	className := filterInfoClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[filterInfoField] = object.Field{Ftype: types.RawGoPointer, Fvalue: info}
+	return obj
+}
+
+// getSerialFilter returns the JVM-wide filter, or nil if none was set.
+func getSerialFilter() *object.Object {
+	globalFilterLock.Lock()
+	defer globalFilterLock.Unlock()
+	return globalSerialFilter
+}
+
+// filterCheck calls the stream's filter, if it has one, for a class being read (or "" for a
+// back reference) and the length of an array (or -1).
+func (r *objectReader) filterCheck(className string, arrayLength int64) *ghelpers.GErrBlk {
+	if r.filter == nil || object.IsNull(r.filter) {
+		return nil
+	}
+	info := filterInfo{className: className, arrayLength: arrayLength, depth: r.depth,
+		references: r.totalRefs, streamBytes: r.bytesRead}
+
+	status := "null"
+	if f, ok := r.filter.FieldTable[serialFilterField].Fvalue.(*serialFilter); ok {
+		status = f.check(info)
+	} else {
+		ret := ghelpers.InvokeMethodOnObject(r.fs, r.filter, "checkInput", filterInfoDesc, newFilterInfoObject(&info))
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		if st, ok := ret.(*object.Object); ok && !object.IsNull(st) {
+			if name, ok := st.FieldTable["name"].Fvalue.(*object.Object); ok {
+				status = object.GoStringFromStringObject(name)
+			}
+		}
+	}
+	if status == "null" || status == statusRejected {
+		return ghelpers.GetGErrBlk(excNames.InvalidClassException, "filter status: "+status)
+	}
+	return nil
+}
+
+// java/io/ObjectInputFilter$Config.createFilter(Ljava/lang/String;)Ljava/io/ObjectInputFilter;
+func objectInputFilterCreateFilter(params []interface{}) interface{} {
+	specObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(specObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputFilter.Config.createFilter: pattern is null")
+	}
+	f, err := parseSerialFilter(object.GoStringFromStringObject(specObj))
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, err.Error())
+	}
+	if f == nil {
+		return object.Null
+	}
+	return newFilterObject(f)
+}
+
+// java/io/ObjectInputFilter$Config.getSerialFilter()Ljava/io/ObjectInputFilter;
+func objectInputFilterGetSerialFilter(params []interface{}) interface{} {
+	if f := getSerialFilter(); f != nil {
+		return f
+	}
+	return object.Null
+}
+
+// java/io/ObjectInputFilter$Config.setSerialFilter(Ljava/io/ObjectInputFilter;)V
+func objectInputFilterSetSerialFilter(params []interface{}) interface{} {
+	filter, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(filter) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputFilter.Config.setSerialFilter: filter is null")
+	}
+	globalFilterLock.Lock()
+	defer globalFilterLock.Unlock()
+	if globalSerialFilter != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "Serial filter can only be set once")
+	}
+	globalSerialFilter = filter
+	return nil
+}
+
+// java/io/ObjectInputFilter$Config$Global.checkInput(Ljava/io/ObjectInputFilter$FilterInfo;)Ljava/io/ObjectInputFilter$Status;
+func objectInputFilterCheckInput(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	f, ok := args[0].(*object.Object).FieldTable[serialFilterField].Fvalue.(*serialFilter)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "ObjectInputFilter.checkInput: filter is not initialized")
+	}
+	infoObj, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(infoObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputFilter.checkInput: filter info is null")
+	}
+	if info, ok := infoObj.FieldTable[filterInfoField].Fvalue.(*filterInfo); ok {
+		return filterStatus(f.check(*info))
+	}
+
+	// A FilterInfo implemented in Java
+	var info filterInfo
+	for _, v := range []struct {
+		meth string
+		dst  *int64
+	}{{"arrayLength", &info.arrayLength}, {"depth", &info.depth},
+		{"references", &info.references}, {"streamBytes", &info.streamBytes}} {
+		ret := ghelpers.InvokeMethodOnObject(fs, infoObj, v.meth, "()J")
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		*v.dst, _ = ret.(int64)
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, infoObj, "serialClass", "()Ljava/lang/Class;")
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	if cls, ok := ret.(*object.Object); ok && !object.IsNull(cls) {
+		info.className = classNameOfClassObject(cls)
+	}
+	return filterStatus(f.check(info))
+}
+
+// java/io/ObjectInputFilter$Config$Global.toString()Ljava/lang/String; -- the pattern string
+func objectInputFilterToString(params []interface{}) interface{} {
+	f, ok := params[0].(*object.Object).FieldTable[serialFilterField].Fvalue.(*serialFilter)
+	if !ok {
+		return object.StringObjectFromGoString("")
+	}
+	return object.StringObjectFromGoString(f.spec)
+}
+
+// java/io/ObjectInputStream.getObjectInputFilter()Ljava/io/ObjectInputFilter;
+func objectInputStreamGetObjectInputFilter(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if r.filter == nil {
+		return object.Null
+	}
+	return r.filter
+}
+
+// java/io/ObjectInputStream.setObjectInputFilter(Ljava/io/ObjectInputFilter;)V
+func objectInputStreamSetObjectInputFilter(params []interface{}) interface{} {
+	r, args, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	filter, _ := args[0].(*object.Object)
+	if object.IsNull(filter) {
+		filter = nil
+	}
+	switch {
+	case r.filterSet:
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "filter can not be set more than once")
+	case r.started:
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "filter can not be set after an object has been read")
+	case r.filter != nil && filter == nil:
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "filter can not be set to null")
+	}
+	r.filter = filter
+	r.filterSet = true
+	return nil
+}
+
+func filterInfoOf(params []interface{}) *filterInfo {
+	if info, ok := params[0].(*object.Object).FieldTable[filterInfoField].Fvalue.(*filterInfo); ok {
+		return info
+	}
+	return &filterInfo{arrayLength: -1}
+}
+
+// java/io/ObjectInputStream$FilterValues.arrayLength()J
+func filterValuesArrayLength(params []interface{}) interface{} {
+	return filterInfoOf(params).arrayLength
+}
+
+// java/io/ObjectInputStream$FilterValues.depth()J
+func filterValuesDepth(params []interface{}) interface{} {
+	return filterInfoOf(params).depth
+}
+
+// java/io/ObjectInputStream$FilterValues.references()J
+func filterValuesReferences(params []interface{}) interface{} {
+	return filterInfoOf(params).references
+}
+
+// java/io/ObjectInputStream$FilterValues.serialClass()Ljava/lang/Class;
+func filterValuesSerialClass(params []interface{}) interface{} {
+	info := filterInfoOf(params)
+	if info.className == "" {
+		return object.Null
+	}
+	return classObjectFor(info.className)
+}
+
+// java/io/ObjectInputStream$FilterValues.streamBytes()J
+func filterValuesStreamBytes(params []interface{}) interface{} {
+	return filterInfoOf(params).streamBytes
+}
@@ -0,0 +1,69 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"testing"
+)
+
+func TestSerialFilter_Patterns(t *testing.T) {
+	f, err := parseSerialFilter("java.util.*;!java.lang.**;com.example.Foo;org.acme.Pre*;!*")
+	if err != nil || f == nil {
+		t.Fatalf("parseSerialFilter failed: %v", err)
+	}
+	tests := map[string]string{
+		"java/util/ArrayList":           statusAllowed,
+		"java/util/concurrent/Executor": statusRejected, // java.util.* excludes subpackages
+		"java/lang/String":              statusRejected,
+		"java/lang/invoke/MethodHandle": statusRejected,
+		"com/example/Foo":               statusAllowed,
+		"com/example/Foo2":              statusRejected,
+		"org/acme/Prefixed":             statusAllowed,
+		"[Ljava/util/HashMap;":          statusAllowed, // the component type is checked
+		"[[I":                           statusUndecided,
+		"":                              statusUndecided,
+	}
+	for className, expected := range tests {
+		info := filterInfo{className: className, arrayLength: -1}
+		if status := f.check(info); status != expected {
+			t.Errorf("%q: expected %s, observed %s", className, expected, status)
+		}
+	}
+}
+
+func TestSerialFilter_Limits(t *testing.T) {
+	f, err := parseSerialFilter("maxdepth=2;maxrefs=10;maxbytes=100;maxarray=5")
+	if err != nil {
+		t.Fatalf("parseSerialFilter failed: %v", err)
+	}
+	ok := filterInfo{className: "[I", arrayLength: 5, depth: 2, references: 10, streamBytes: 100}
+	if status := f.check(ok); status != statusUndecided {
+		t.Errorf("expected UNDECIDED within the limits, observed %s", status)
+	}
+	for _, info := range []filterInfo{
+		{arrayLength: 6, depth: 1},
+		{arrayLength: -1, depth: 3},
+		{arrayLength: -1, references: 11},
+		{arrayLength: -1, streamBytes: 101},
+	} {
+		if status := f.check(info); status != statusRejected {
+			t.Errorf("%+v: expected REJECTED, observed %s", info, status)
+		}
+	}
+}
+
+func TestSerialFilter_Errors(t *testing.T) {
+	if f, err := parseSerialFilter(" ; "); f != nil || err != nil {
+		t.Errorf("an empty pattern should give no filter, observed %v, %v", f, err)
+	}
+	if _, err := parseSerialFilter("maxdepth=-1"); err == nil {
+		t.Error("a negative limit should be an error")
+	}
+	if _, err := parseSerialFilter("maxfoo=1"); err == nil || err.Error() != "unknown limit: maxfoo" {
+		t.Errorf("expected unknown limit: maxfoo, observed %v", err)
+	}
+}
@@ -0,0 +1,1500 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/stringPool"
+	"jacobin/src/types"
+	"strings"
+)
+
+// java.io.ObjectInputStream reads objects written in the Java Object Serialization Stream
+// Protocol, by ObjectOutputStream or by any other JVM. The Go state of a stream is an
+// objectReader. It reads exactly the bytes of the serialized data from the underlying stream,
+// so that whatever follows them can still be read from that stream.
+
+const (
+	objectInputStreamClassName = "java/io/ObjectInputStream"
+	objectReaderField          = "$reader" // the *objectReader of an ObjectInputStream
+)
+
+type objectReader struct {
+	self      *object.Object // the ObjectInputStream
+	source    *object.Object // the underlying stream, if it is a Java object
+	in        io.Reader
+	fs        *list.List // the frame stack of the thread that is reading
+	peeked    int        // a byte that was peeked at but not consumed, or -1
+	bytesRead int64
+
+	blocking  bool // in block data mode
+	blkRemain int  // the bytes left in the current block, or -1 at the end of the block data
+
+	handles        []any // the objects, strings, and class descriptions read so far
+	depth          int64
+	totalRefs      int64
+	contexts       []*readContext
+	defaultDataEnd bool // the fields written by defaultWriteObject have been read
+
+	filter    *object.Object // the ObjectInputFilter, if any
+	filterSet bool           // setObjectInputFilter was called
+	started   bool           // an object has been read
+}
+
+// readContext is the object and class whose readObject method is running. defaultReadObject
+// reads the fields of that class.
+type readContext struct {
+	obj  *object.Object
+	desc *streamClass
+}
+
+// unsharedHandle marks the handle of an object that was read with readUnshared.
+type unsharedHandle struct{}
+
+func Load_Io_ObjectInputStream() {
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapProtected,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    objectInputStreamInit,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.available()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamAvailable,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectInputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.defaultReadObject()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectInputStreamDefaultReadObject,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.getObjectInputFilter()Ljava/io/ObjectInputFilter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamGetObjectInputFilter,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamRead,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.read([BII)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  objectInputStreamReadBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readBoolean()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadBoolean,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readByte()B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadByte,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readChar()C"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readDouble()D"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadDouble,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readFields()Ljava/io/ObjectInputStream$GetField;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readFloat()F"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadFloat,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readFully([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectInputStreamReadFully,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readFully([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  objectInputStreamReadFully,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readInt()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadInt,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readLine()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapDeprecated,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readLong()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadLong,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readObject()Ljava/lang/Object;"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectInputStreamReadObject,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readShort()S"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadShort,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readUnshared()Ljava/lang/Object;"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectInputStreamReadUnshared,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readUnsignedByte()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadUnsignedByte,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readUnsignedShort()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadUnsignedShort,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.readUTF()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectInputStreamReadUTF,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.registerValidation(Ljava/io/ObjectInputValidation;I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.setObjectInputFilter(Ljava/io/ObjectInputFilter;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectInputStreamSetObjectInputFilter,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectInputStream.skipBytes(I)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectInputStreamSkipBytes,
+		}
+}
+
+// java/io/ObjectInputStream.<init>(Ljava/io/InputStream;)V -- reads and checks the stream header
+func objectInputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	in, gerr := ghelpers.GoReaderWithContext(fs, params[1], "ObjectInputStream")
+	if gerr != nil {
+		return gerr
+	}
+	r := &objectReader{self: self, fs: fs, in: in, peeked: -1, filter: getSerialFilter()}
+	r.source, _ = params[1].(*object.Object)
+
+	var hdr [4]byte
+	if err := r.readRaw(hdr[:]); err != nil {
+		return r.ioError(err)
+	}
+	magic, version := binary.BigEndian.Uint16(hdr[:]), binary.BigEndian.Uint16(hdr[2:])
+	if magic != streamMagic || version != streamVersion {
+		errMsg := fmt.Sprintf("invalid stream header: %04X%04X", magic, version)
+		return ghelpers.GetGErrBlk(excNames.StreamCorruptedException, errMsg)
+	}
+	r.setBlockMode(true)
+	self.FieldTable[objectReaderField] = object.Field{Ftype: types.RawGoPointer, Fvalue: r}
+	return nil
+}
+
+// objectReaderOf returns the objectReader of the ObjectInputStream in params, after removing
+// the frame stack, if the G function is called with one.
+func objectReaderOf(params []interface{}) (*objectReader, []interface{}, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	self, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputStream: stream is null")
+	}
+	r, ok := self.FieldTable[objectReaderField].Fvalue.(*objectReader)
+	if !ok {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IOException, "ObjectInputStream: stream is not initialized")
+	}
+	if fs != nil {
+		r.fs = fs
+	}
+	return r, args[1:], nil
+}
+
+// ---- low-level input ----
+
+// readRaw reads exactly len(p) bytes from the underlying stream.
+func (r *objectReader) readRaw(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	n := 0
+	if r.peeked >= 0 {
+		p[0] = byte(r.peeked)
+		r.peeked = -1
+		n = 1
+	}
+	if n < len(p) {
+		m, err := io.ReadFull(r.in, p[n:])
+		n += m
+		if err != nil {
+			r.bytesRead += int64(n)
+			return err
+		}
+	}
+	r.bytesRead += int64(n)
+	return nil
+}
+
+func (r *objectReader) readRawByte() (byte, error) {
+	var b [1]byte
+	err := r.readRaw(b[:])
+	return b[0], err
+}
+
+// peekRaw returns the next byte without consuming it.
+func (r *objectReader) peekRaw() (byte, error) {
+	if r.peeked < 0 {
+		var b [1]byte
+		if _, err := io.ReadFull(r.in, b[:]); err != nil {
+			return 0, err
+		}
+		r.peeked = int(b[0])
+	}
+	return byte(r.peeked), nil
+}
+
+// ioError converts an error from the underlying stream to an EOFException or IOException.
+func (r *objectReader) ioError(err error) *ghelpers.GErrBlk {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "")
+	}
+	return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+}
+
+// setBlockMode turns block data mode on or off. Turning it off with data left in the current
+// block is an error.
+func (r *objectReader) setBlockMode(mode bool) (bool, *ghelpers.GErrBlk) {
+	if r.blocking == mode {
+		return mode, nil
+	}
+	if !mode && r.blkRemain > 0 {
+		return true, ghelpers.GetGErrBlk(excNames.IllegalStateException, "unread block data")
+	}
+	r.blocking = mode
+	r.blkRemain = 0
+	return !mode, nil
+}
+
+// refill reads the header of the next block of data. If the next item in the stream is not
+// block data, the end of the block data is reached and blkRemain is set to -1.
+func (r *objectReader) refill() *ghelpers.GErrBlk {
+	for r.blkRemain == 0 {
+		tc, err := r.peekRaw()
+		if err != nil {
+			r.blkRemain = -1
+			return nil
+		}
+		switch tc {
+		case tcBlockData:
+			var hdr [2]byte
+			if err := r.readRaw(hdr[:]); err != nil {
+				return r.ioError(err)
+			}
+			r.blkRemain = int(hdr[1])
+		case tcBlockDataLong:
+			var hdr [5]byte
+			if err := r.readRaw(hdr[:]); err != nil {
+				return r.ioError(err)
+			}
+			n := int32(binary.BigEndian.Uint32(hdr[1:]))
+			if n < 0 {
+				errMsg := fmt.Sprintf("illegal block data header length: %d", n)
+				return ghelpers.GetGErrBlk(excNames.StreamCorruptedException, errMsg)
+			}
+			r.blkRemain = int(n)
+		case tcReset:
+			_, _ = r.readRawByte()
+			if gerr := r.handleReset(); gerr != nil {
+				return gerr
+			}
+		default:
+			r.blkRemain = -1
+		}
+	}
+	return nil
+}
+
+// readSome reads up to len(p) bytes of data, as read([BII)I does, and returns -1 at the end of
+// the data.
+func (r *objectReader) readSome(p []byte) (int, *ghelpers.GErrBlk) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !r.blocking {
+		if err := r.readRaw(p[:1]); err != nil {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	if r.blkRemain == 0 {
+		if gerr := r.refill(); gerr != nil {
+			return 0, gerr
+		}
+	}
+	if r.blkRemain < 0 {
+		return -1, nil
+	}
+	n := min(len(p), r.blkRemain)
+	if err := r.readRaw(p[:n]); err != nil {
+		return 0, r.ioError(err)
+	}
+	r.blkRemain -= n
+	return n, nil
+}
+
+// readData reads exactly len(p) bytes of data, which can span several blocks in block data mode.
+func (r *objectReader) readData(p []byte) *ghelpers.GErrBlk {
+	if !r.blocking {
+		if err := r.readRaw(p); err != nil {
+			return r.ioError(err)
+		}
+		return nil
+	}
+	for len(p) > 0 {
+		n, gerr := r.readSome(p)
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			return ghelpers.GetGErrBlk(excNames.EOFException, "")
+		}
+		p = p[n:]
+	}
+	return nil
+}
+
+// skipBlockData skips the rest of the consecutive blocks of data.
+func (r *objectReader) skipBlockData() *ghelpers.GErrBlk {
+	buf := make([]byte, maxBlockSize)
+	for {
+		n, gerr := r.readSome(buf)
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			return nil
+		}
+	}
+}
+
+func (r *objectReader) readUTF() (string, *ghelpers.GErrBlk) {
+	var n [2]byte
+	if gerr := r.readData(n[:]); gerr != nil {
+		return "", gerr
+	}
+	return r.readUTFBody(int64(binary.BigEndian.Uint16(n[:])))
+}
+
+func (r *objectReader) readUTFBody(length int64) (string, *ghelpers.GErrBlk) {
+	if length < 0 || length > int64(^uint32(0)>>1) {
+		return "", ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid string length: %d", length))
+	}
+	buf := make([]byte, length)
+	if gerr := r.readData(buf); gerr != nil {
+		return "", gerr
+	}
+	s, err := decodeModifiedUTF8(buf)
+	if err != nil {
+		return "", ghelpers.GetGErrBlk(excNames.UTFDataFormatException, err.Error())
+	}
+	return s, nil
+}
+
+func (r *objectReader) readInt() (int32, *ghelpers.GErrBlk) {
+	var b [4]byte
+	if gerr := r.readData(b[:]); gerr != nil {
+		return 0, gerr
+	}
+	return int32(binary.BigEndian.Uint32(b[:])), nil
+}
+
+// ---- handles ----
+
+func (r *objectReader) assignHandle(v any) int {
+	r.handles = append(r.handles, v)
+	return len(r.handles) - 1
+}
+
+func (r *objectReader) handleReset() *ghelpers.GErrBlk {
+	if r.depth > 0 {
+		return ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "unexpected reset; recursion depth: "+fmt.Sprint(r.depth))
+	}
+	r.handles = nil
+	return nil
+}
+
+// readHandle reads a back reference to an object, string, or class description read before.
+func (r *objectReader) readHandle(unshared bool) (any, *ghelpers.GErrBlk) {
+	var b [5]byte
+	if gerr := r.readData(b[:]); gerr != nil {
+		return nil, gerr
+	}
+	h := int32(binary.BigEndian.Uint32(b[1:]))
+	ix := int(h) - baseWireHandle
+	if ix < 0 || ix >= len(r.handles) {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid handle value: %08X", uint32(h)))
+	}
+	if unshared {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidObjectException, "cannot read back reference as unshared")
+	}
+	v := r.handles[ix]
+	if _, ok := v.(unsharedHandle); ok {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidObjectException, "cannot read back reference to unshared object")
+	}
+	if gerr := r.filterCheck("", -1); gerr != nil {
+		return nil, gerr
+	}
+	return v, nil
+}
+
+// ---- objects ----
+
+// readObject reads the next object in the stream: null, a back reference, or a new object
+// with everything it refers to.
+func (r *objectReader) readObject(unshared bool) (any, *ghelpers.GErrBlk) {
+	r.started = true
+	oldMode := r.blocking
+	if oldMode {
+		if r.blkRemain > 0 {
+			return nil, ghelpers.GetGErrBlk(excNames.OptionalDataException, fmt.Sprintf("length: %d", r.blkRemain))
+		}
+		if r.defaultDataEnd {
+			return nil, ghelpers.GetGErrBlk(excNames.OptionalDataException, "eof: true")
+		}
+		if _, gerr := r.setBlockMode(false); gerr != nil {
+			return nil, gerr
+		}
+	}
+	defer func() { _, _ = r.setBlockMode(oldMode) }()
+
+	tc, err := r.peekRaw()
+	if err != nil {
+		return nil, r.ioError(err)
+	}
+	for tc == tcReset {
+		_, _ = r.readRawByte()
+		if gerr := r.handleReset(); gerr != nil {
+			return nil, gerr
+		}
+		if tc, err = r.peekRaw(); err != nil {
+			return nil, r.ioError(err)
+		}
+	}
+
+	r.depth++
+	r.totalRefs++
+	defer func() { r.depth-- }()
+
+	switch tc {
+	case tcNull:
+		_, _ = r.readRawByte()
+		return object.Null, nil
+	case tcReference:
+		v, gerr := r.readHandle(unshared)
+		if desc, ok := v.(*streamClass); ok {
+			return newObjectStreamClass(desc), gerr
+		}
+		return v, gerr
+	case tcClass:
+		return r.readClass(unshared)
+	case tcClassDesc, tcProxyClassDesc:
+		desc, gerr := r.readClassDesc(unshared)
+		if gerr != nil || desc == nil {
+			return object.Null, gerr
+		}
+		return newObjectStreamClass(desc), nil
+	case tcString, tcLongString:
+		return r.readString(unshared)
+	case tcArray:
+		return r.readArray(unshared)
+	case tcEnum:
+		return r.readEnum(unshared)
+	case tcObject:
+		return r.readOrdinaryObject(unshared)
+	case tcException:
+		return nil, r.readFatalException()
+	case tcBlockData, tcBlockDataLong:
+		if oldMode {
+			_, _ = r.setBlockMode(true)
+			if gerr := r.refill(); gerr != nil {
+				return nil, gerr
+			}
+			return nil, ghelpers.GetGErrBlk(excNames.OptionalDataException, fmt.Sprintf("length: %d", max(r.blkRemain, 0)))
+		}
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "unexpected block data")
+	case tcEndBlockData:
+		if oldMode {
+			return nil, ghelpers.GetGErrBlk(excNames.OptionalDataException, "eof: true")
+		}
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "unexpected end of block data")
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid type code: %02X", tc))
+}
+
+// readObjectRef reads an object that is assigned to a reference, such as a field or an array
+// element.
+func (r *objectReader) readObjectRef() (*object.Object, *ghelpers.GErrBlk) {
+	v, gerr := r.readObject(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	obj, _ := v.(*object.Object)
+	return obj, nil
+}
+
+// readFatalException reads the exception that stopped the writer, which is reported as a
+// WriteAbortedException.
+func (r *objectReader) readFatalException() *ghelpers.GErrBlk {
+	_, _ = r.readRawByte()
+	r.handles = nil
+	ex, gerr := r.readObject(false)
+	if gerr != nil {
+		return gerr
+	}
+	r.handles = nil
+	errMsg := "writing aborted"
+	if exObj, ok := ex.(*object.Object); ok && !object.IsNull(exObj) {
+		errMsg += "; " + binaryName(streamClassNameOf(exObj))
+	}
+	return ghelpers.GetGErrBlk(excNames.WriteAbortedException, errMsg)
+}
+
+// readString reads a TC_STRING or TC_LONGSTRING.
+func (r *objectReader) readString(unshared bool) (*object.Object, *ghelpers.GErrBlk) {
+	tc, err := r.readRawByte()
+	if err != nil {
+		return nil, r.ioError(err)
+	}
+	var s string
+	var gerr *ghelpers.GErrBlk
+	switch tc {
+	case tcString:
+		s, gerr = r.readUTF()
+	case tcLongString:
+		var n [8]byte
+		if gerr = r.readData(n[:]); gerr == nil {
+			s, gerr = r.readUTFBody(int64(binary.BigEndian.Uint64(n[:])))
+		}
+	default:
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid type code: %02X", tc))
+	}
+	if gerr != nil {
+		return nil, gerr
+	}
+	str := object.StringObjectFromGoString(s)
+	r.assignShared(str, unshared)
+	return str, nil
+}
+
+func (r *objectReader) assignShared(v any, unshared bool) int {
+	if unshared {
+		return r.assignHandle(unsharedHandle{})
+	}
+	return r.assignHandle(v)
+}
+
+// readTypeString reads the type of an object field in a class description.
+func (r *objectReader) readTypeString() (string, *ghelpers.GErrBlk) {
+	tc, err := r.peekRaw()
+	if err != nil {
+		return "", r.ioError(err)
+	}
+	switch tc {
+	case tcNull:
+		_, _ = r.readRawByte()
+		return "", nil
+	case tcReference:
+		v, gerr := r.readHandle(false)
+		if gerr != nil {
+			return "", gerr
+		}
+		if str, ok := v.(*object.Object); ok && object.IsStringObject(str) {
+			return object.GoStringFromStringObject(str), nil
+		}
+		return "", ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "invalid type string reference")
+	case tcString, tcLongString:
+		str, gerr := r.readString(false)
+		if gerr != nil {
+			return "", gerr
+		}
+		return object.GoStringFromStringObject(str), nil
+	}
+	return "", ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid type code: %02X", tc))
+}
+
+// readClassDesc reads a class description, a back reference to one, or null.
+func (r *objectReader) readClassDesc(unshared bool) (*streamClass, *ghelpers.GErrBlk) {
+	tc, err := r.peekRaw()
+	if err != nil {
+		return nil, r.ioError(err)
+	}
+	switch tc {
+	case tcNull:
+		_, _ = r.readRawByte()
+		return nil, nil
+	case tcReference:
+		v, gerr := r.readHandle(unshared)
+		if gerr != nil {
+			return nil, gerr
+		}
+		if desc, ok := v.(*streamClass); ok {
+			return desc, nil
+		}
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "invalid class descriptor reference")
+	case tcClassDesc:
+		return r.readNonProxyDesc(unshared)
+	case tcProxyClassDesc:
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, "dynamic proxy classes are not supported")
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, fmt.Sprintf("invalid type code: %02X", tc))
+}
+
+// readNonProxyDesc reads a TC_CLASSDESC and binds it to the local class of the same name.
+func (r *objectReader) readNonProxyDesc(unshared bool) (*streamClass, *ghelpers.GErrBlk) {
+	_, _ = r.readRawByte()
+	desc := &streamClass{hasStreamFields: true}
+	r.assignShared(desc, unshared)
+
+	name, gerr := r.readUTF()
+	if gerr != nil {
+		return nil, gerr
+	}
+	var hdr [11]byte // serialVersionUID, flags, and the number of fields
+	if gerr := r.readData(hdr[:]); gerr != nil {
+		return nil, gerr
+	}
+	desc.name = name
+	desc.className = internalName(name)
+	desc.suid = int64(binary.BigEndian.Uint64(hdr[:]))
+	desc.flags = hdr[8]
+	desc.isEnum = desc.flags&scEnum != 0
+	desc.isArray = strings.HasPrefix(name, "[")
+	desc.serializable = desc.flags&scSerializable != 0
+	desc.externalizable = desc.flags&scExternalizable != 0
+	if desc.serializable && desc.externalizable {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, name+"; serializable and externalizable flags conflict")
+	}
+
+	numFields := int(binary.BigEndian.Uint16(hdr[9:]))
+	for ix := 0; ix < numFields; ix++ {
+		tcode, err := r.readRawByte()
+		if err != nil {
+			return nil, r.ioError(err)
+		}
+		fname, gerr := r.readUTF()
+		if gerr != nil {
+			return nil, gerr
+		}
+		f := streamField{name: fname, typeCode: tcode, signature: string(tcode)}
+		switch tcode {
+		case 'B', 'C', 'D', 'F', 'I', 'J', 'S', 'Z':
+		case 'L', '[':
+			if f.signature, gerr = r.readTypeString(); gerr != nil {
+				return nil, gerr
+			}
+		default:
+			return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, fmt.Sprintf("%s; invalid typecode for field %s", name, fname))
+		}
+		desc.fields = append(desc.fields, f)
+	}
+
+	local, gerr := lookupStreamClass(desc.className)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if gerr := r.filterCheck(desc.className, -1); gerr != nil {
+		return nil, gerr
+	}
+	if gerr := r.skipCustomData(); gerr != nil { // the data written by annotateClass
+		return nil, gerr
+	}
+
+	r.totalRefs++
+	r.depth++
+	super, gerr := r.readClassDesc(false)
+	r.depth--
+	if gerr != nil {
+		return nil, gerr
+	}
+	desc.super = super
+	if gerr := desc.bindLocal(local); gerr != nil {
+		return nil, gerr
+	}
+	return desc, nil
+}
+
+// bindLocal checks that the class description read from the stream is compatible with the
+// local class, as ObjectStreamClass.initNonProxy does.
+func (d *streamClass) bindLocal(local *streamClass) *ghelpers.GErrBlk {
+	invalid := func(msg string) *ghelpers.GErrBlk {
+		return ghelpers.GetGErrBlk(excNames.InvalidClassException, local.name+"; "+msg)
+	}
+	switch {
+	case d.isEnum != local.isEnum && d.isEnum:
+		return invalid("cannot bind enum descriptor to a non-enum class")
+	case d.isEnum != local.isEnum:
+		return invalid("cannot bind non-enum descriptor to an enum class")
+	case d.serializable == local.serializable && !local.isArray && d.suid != local.suid:
+		return invalid(fmt.Sprintf("local class incompatible: stream classdesc serialVersionUID = %d, "+
+			"local class serialVersionUID = %d", d.suid, local.suid))
+	}
+	if !d.isEnum {
+		if d.serializable == local.serializable && d.externalizable != local.externalizable {
+			return invalid("Serializable incompatible with Externalizable")
+		}
+		if local.serializable && !local.externalizable && !d.serializable && !d.isArray {
+			return invalid("class invalid for deserialization")
+		}
+	}
+
+	localFields := make(map[string]streamField, len(local.fields))
+	for _, f := range local.fields {
+		localFields[f.name] = f
+	}
+	for _, f := range d.fields {
+		if lf, ok := localFields[f.name]; ok && (f.isPrimitive() || lf.isPrimitive()) && f.typeCode != lf.typeCode {
+			return invalid("incompatible types for field " + f.name)
+		}
+	}
+	d.local = local
+	return nil
+}
+
+// hasLocalField reports whether the local class has a serializable field named name.
+func (d *streamClass) hasLocalField(name string) bool {
+	if d.local == nil {
+		return false
+	}
+	for _, f := range d.local.fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skipCustomData skips the block data and objects up to the next TC_ENDBLOCKDATA, which is the
+// data written by a writeObject method that its readObject method did not read.
+func (r *objectReader) skipCustomData() *ghelpers.GErrBlk {
+	for {
+		if r.blocking {
+			if gerr := r.skipBlockData(); gerr != nil {
+				return gerr
+			}
+			r.blkRemain = 0
+			if _, gerr := r.setBlockMode(false); gerr != nil {
+				return gerr
+			}
+		}
+		tc, err := r.peekRaw()
+		if err != nil {
+			return r.ioError(err)
+		}
+		switch tc {
+		case tcBlockData, tcBlockDataLong:
+			_, _ = r.setBlockMode(true)
+		case tcEndBlockData:
+			_, _ = r.readRawByte()
+			return nil
+		default:
+			if _, gerr := r.readObject(false); gerr != nil {
+				return gerr
+			}
+		}
+	}
+}
+
+// readClass reads a TC_CLASS and returns the java.lang.Class object of the class.
+func (r *objectReader) readClass(unshared bool) (any, *ghelpers.GErrBlk) {
+	_, _ = r.readRawByte()
+	desc, gerr := r.readClassDesc(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if desc == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "null class descriptor")
+	}
+	cls := classObjectFor(desc.className)
+	r.assignShared(cls, unshared)
+	return cls, nil
+}
+
+// readArray reads a TC_ARRAY and its elements.
+func (r *objectReader) readArray(unshared bool) (any, *ghelpers.GErrBlk) {
+	_, _ = r.readRawByte()
+	desc, gerr := r.readClassDesc(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if desc == nil || !desc.isArray {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "invalid array descriptor")
+	}
+	length, gerr := r.readInt()
+	if gerr != nil {
+		return nil, gerr
+	}
+	if length < 0 {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "Array length is negative")
+	}
+	if gerr := r.filterCheck(desc.className, int64(length)); gerr != nil {
+		return nil, gerr
+	}
+
+	arr := newArrayObject(desc.className, int64(length))
+	r.assignShared(arr, unshared)
+
+	elemType := desc.className[1]
+	switch elems := arr.FieldTable["value"].Fvalue.(type) {
+	case []*object.Object:
+		for ix := range elems {
+			elem, gerr := r.readObjectRef()
+			if gerr != nil {
+				return nil, gerr
+			}
+			elems[ix] = elem
+		}
+	case []types.JavaByte:
+		buf := make([]byte, length)
+		if gerr := r.readData(buf); gerr != nil {
+			return nil, gerr
+		}
+		for ix, b := range buf {
+			if elemType == 'Z' && b != 0 {
+				b = 1
+			}
+			elems[ix] = types.JavaByte(b)
+		}
+	case []int64, []float64:
+		size := streamField{typeCode: elemType}.size()
+		buf := make([]byte, int(length)*size)
+		if gerr := r.readData(buf); gerr != nil {
+			return nil, gerr
+		}
+		for ix := 0; ix < int(length); ix++ {
+			v := primitiveValue(elemType, buf[ix*size:])
+			if ints, ok := elems.([]int64); ok {
+				ints[ix] = v.(int64)
+			} else {
+				elems.([]float64)[ix] = v.(float64)
+			}
+		}
+	}
+	return arr, nil
+}
+
+// newArrayObject makes an array of the class className, such as [I or [[Ljava/lang/String;
+func newArrayObject(className string, length int64) *object.Object {
+	switch className[1] {
+	case 'Z':
+		return object.Make1DimArray(object.T_BOOLEAN, length)
+	case 'B':
+		return object.Make1DimArray(object.T_BYTE, length)
+	case 'C':
+		return object.Make1DimArray(object.T_CHAR, length)
+	case 'S':
+		return object.Make1DimArray(object.T_SHORT, length)
+	case 'I':
+		return object.Make1DimArray(object.T_INT, length)
+	case 'J':
+		return object.Make1DimArray(object.T_LONG, length)
+	case 'F':
+		return object.Make1DimArray(object.T_FLOAT, length)
+	case 'D':
+		return object.Make1DimArray(object.T_DOUBLE, length)
+	case 'L':
+		return object.Make1DimRefArray(className[2:], length)
+	}
+	arr := object.MakeEmptyObject() // an array of arrays
+	arr.FieldTable["value"] = object.Field{Ftype: className, Fvalue: make([]*object.Object, length)}
+	arr.KlassName = stringPool.GetStringIndex(&className)
+	return arr
+}
+
+// readEnum reads a TC_ENUM and returns the enum constant of the local class.
+func (r *objectReader) readEnum(unshared bool) (any, *ghelpers.GErrBlk) {
+	_, _ = r.readRawByte()
+	desc, gerr := r.readClassDesc(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if desc == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "null enum descriptor")
+	}
+	if !desc.isEnum {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, "non-enum class: "+desc.name)
+	}
+	h := r.assignShared(nil, unshared)
+	nameObj, gerr := r.readString(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	name := object.GoStringFromStringObject(nameObj)
+
+	if r.fs != nil {
+		if k := classloader.MethAreaFetch(desc.className); k != nil && k.Data.ClInit == types.ClInitNotRun {
+			// The constants are created by <clinit>, which instantiating the class runs.
+			if _, err := globals.GetGlobalRef().FuncInstantiateClass(desc.className, r.fs); err != nil {
+				return nil, ghelpers.GetGErrBlk(excNames.InvalidObjectException, err.Error())
+			}
+		}
+	}
+	st, ok := statics.QueryStatic(desc.className, name)
+	constant, isObj := st.Value.(*object.Object)
+	if !ok || !isObj || object.IsNull(constant) {
+		errMsg := fmt.Sprintf("enum constant %s does not exist in class %s", name, desc.name)
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidObjectException, errMsg)
+	}
+	if !unshared {
+		r.handles[h] = constant
+	}
+	return constant, nil
+}
+
+// readOrdinaryObject reads a TC_OBJECT: creates an instance of the local class and reads its
+// data, either with readExternal or field by field and with the readObject methods.
+func (r *objectReader) readOrdinaryObject(unshared bool) (any, *ghelpers.GErrBlk) {
+	_, _ = r.readRawByte()
+	desc, gerr := r.readClassDesc(false)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if desc == nil || desc.local == nil || desc.isArray || desc.isEnum {
+		return nil, ghelpers.GetGErrBlk(excNames.StreamCorruptedException, "invalid class descriptor")
+	}
+	local := desc.local
+	if !local.serializable {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, local.name+"; class invalid for deserialization")
+	}
+
+	obj, gerr := r.newInstance(local)
+	if gerr != nil {
+		return nil, gerr
+	}
+	h := r.assignShared(obj, unshared)
+
+	if desc.externalizable {
+		gerr = r.readExternalData(obj, desc)
+	} else {
+		gerr = r.readSerialData(obj, desc)
+	}
+	if gerr != nil {
+		return nil, gerr
+	}
+
+	if local.readResolveIn == "" || unshared {
+		return obj, nil
+	}
+	ret := ghelpers.InvokeMethodInClass(r.fs, obj, local.readResolveIn, "readResolve", "()Ljava/lang/Object;")
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return nil, gerr
+	}
+	r.handles[h] = ret
+	return ret, nil
+}
+
+// newInstance creates an instance of the local class for deserialization. Externalizable
+// classes are initialized by their public no-arg constructor; serializable ones by the no-arg
+// constructor of their first non-serializable superclass only.
+func (r *objectReader) newInstance(local *streamClass) (*object.Object, *ghelpers.GErrBlk) {
+	if local.ctorErr != "" {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, local.name+"; "+local.ctorErr)
+	}
+	inst, err := globals.GetGlobalRef().FuncInstantiateClass(local.className, r.fs)
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, local.name+"; "+err.Error())
+	}
+	obj, ok := inst.(*object.Object)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidClassException, local.name+"; cannot instantiate")
+	}
+	if local.ctorClass != "" && local.ctorClass != types.ObjectClassName {
+		ret := ghelpers.InvokeMethodInClass(r.fs, obj, local.ctorClass, "<init>", "()V")
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return nil, gerr
+		}
+	}
+	return obj, nil
+}
+
+// readExternalData calls the readExternal method of obj.
+func (r *objectReader) readExternalData(obj *object.Object, desc *streamClass) *ghelpers.GErrBlk {
+	r.contexts = append(r.contexts, nil)
+	defer func() { r.contexts = r.contexts[:len(r.contexts)-1] }()
+
+	blocked := desc.hasWriteObjectData()
+	if blocked {
+		_, _ = r.setBlockMode(true)
+	}
+	ret := ghelpers.InvokeMethodOnObject(r.fs, obj, "readExternal", "(Ljava/io/ObjectInput;)V", r.self)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	if blocked {
+		return r.skipCustomData()
+	}
+	return nil
+}
+
+// readSerialData reads the data of each class in the stream's description of obj, from the
+// topmost superclass down. Data of classes that are not superclasses of the local class is
+// read and discarded. Local superclasses that are not in the stream get readObjectNoData.
+func (r *objectReader) readSerialData(obj *object.Object, desc *streamClass) *ghelpers.GErrBlk {
+	slots := desc.hierarchy()
+	inStream := make(map[string]bool, len(slots))
+	for _, slot := range slots {
+		inStream[slot.className] = true
+	}
+	for _, ld := range desc.local.hierarchy() {
+		if !inStream[ld.className] && ld.hasReadNoData {
+			ret := ghelpers.InvokeMethodInClass(r.fs, obj, ld.className, "readObjectNoData", "()V")
+			if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+				return gerr
+			}
+		}
+	}
+
+	objClass := streamClassNameOf(obj)
+	for _, slot := range slots {
+		target := obj
+		if slot.local == nil || !isSubclassOf(objClass, slot.className) {
+			target = nil
+		}
+
+		if target != nil && slot.local.hasReadObject {
+			r.contexts = append(r.contexts, &readContext{obj: obj, desc: slot})
+			_, _ = r.setBlockMode(true)
+			ret := ghelpers.InvokeMethodInClass(r.fs, obj, slot.className, "readObject", objectInputStreamDesc, r.self)
+			r.contexts = r.contexts[:len(r.contexts)-1]
+			r.defaultDataEnd = false
+			if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+				return gerr
+			}
+		} else if gerr := r.readFields(target, slot); gerr != nil {
+			return gerr
+		}
+
+		if slot.hasWriteObjectData() {
+			if gerr := r.skipCustomData(); gerr != nil {
+				return gerr
+			}
+		} else {
+			r.blkRemain = max(r.blkRemain, 0)
+			if _, gerr := r.setBlockMode(false); gerr != nil {
+				return gerr
+			}
+		}
+	}
+	return nil
+}
+
+// readFields reads the values of the fields of the class desc and sets those that the local
+// class has in obj. If obj is nil, the values are discarded.
+func (r *objectReader) readFields(obj *object.Object, desc *streamClass) *ghelpers.GErrBlk {
+	size := 0
+	for _, f := range desc.fields {
+		size += f.size()
+	}
+	prims := make([]byte, size)
+	if gerr := r.readData(prims); gerr != nil {
+		return gerr
+	}
+	pos := 0
+	for _, f := range desc.fields {
+		if !f.isPrimitive() {
+			continue
+		}
+		v := primitiveValue(f.typeCode, prims[pos:])
+		pos += f.size()
+		if obj != nil && desc.hasLocalField(f.name) {
+			setField(obj, f.name, string(f.typeCode), v)
+		}
+	}
+
+	for _, f := range desc.fields {
+		if f.isPrimitive() {
+			continue
+		}
+		v, gerr := r.readObjectRef()
+		if gerr != nil {
+			return gerr
+		}
+		if obj != nil && desc.hasLocalField(f.name) {
+			setField(obj, f.name, f.signature, v)
+		}
+	}
+	return nil
+}
+
+// setField sets a field of obj, keeping the type that the object's field already has. Arrays
+// are stored in fields as their Go slices, as PUTFIELD stores them.
+func setField(obj *object.Object, name, signature string, v any) {
+	ftype := signature
+	if fld, ok := obj.FieldTable[name]; ok && fld.Ftype != "" {
+		ftype = fld.Ftype
+	}
+	if arr, ok := v.(*object.Object); ok {
+		if arr == nil || object.IsNull(arr) {
+			v = nil
+		} else if types.IsArray(streamClassNameOf(arr)) {
+			v = arr.FieldTable["value"].Fvalue
+		}
+	}
+	obj.FieldTable[name] = object.Field{Ftype: ftype, Fvalue: v}
+}
+
+// ---- G functions ----
+
+// java/io/ObjectInputStream.readObject()Ljava/lang/Object;
+func objectInputStreamReadObject(params []interface{}) interface{} {
+	return readObjectParam(params, false)
+}
+
+// java/io/ObjectInputStream.readUnshared()Ljava/lang/Object;
+func objectInputStreamReadUnshared(params []interface{}) interface{} {
+	return readObjectParam(params, true)
+}
+
+func readObjectParam(params []interface{}, unshared bool) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	v, gerr := r.readObject(unshared)
+	if gerr != nil {
+		return gerr
+	}
+	if v == nil {
+		return object.Null
+	}
+	return v
+}
+
+// java/io/ObjectInputStream.defaultReadObject()V -- reads the fields of the class whose
+// readObject method is running
+func objectInputStreamDefaultReadObject(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if len(r.contexts) == 0 || r.contexts[len(r.contexts)-1] == nil {
+		return ghelpers.GetGErrBlk(excNames.NotActiveException, "not in call to readObject")
+	}
+	ctx := r.contexts[len(r.contexts)-1]
+	if r.blkRemain < 0 {
+		r.blkRemain = 0
+	}
+	if _, gerr := r.setBlockMode(false); gerr != nil {
+		return gerr
+	}
+	if gerr := r.readFields(ctx.obj, ctx.desc); gerr != nil {
+		return gerr
+	}
+	_, _ = r.setBlockMode(true)
+	if !ctx.desc.hasWriteObjectData() {
+		r.defaultDataEnd = true
+	}
+	return nil
+}
+
+// java/io/ObjectInputStream.available()I -- the bytes left in the current block of data
+func objectInputStreamAvailable(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if r.blocking && r.blkRemain == 0 && !r.defaultDataEnd {
+		if gerr := r.refill(); gerr != nil {
+			return gerr
+		}
+	}
+	return int64(max(r.blkRemain, 0))
+}
+
+// java/io/ObjectInputStream.close()V -- closes the underlying stream
+func objectInputStreamClose(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if r.source != nil {
+		if _, clName := ghelpers.FindInstanceMethod(r.source, "close", "()V"); clName != "" {
+			return ghelpers.InvokeMethodOnObject(r.fs, r.source, "close", "()V")
+		}
+	}
+	return nil
+}
+
+// java/io/ObjectInputStream.read()I
+func objectInputStreamRead(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if r.defaultDataEnd {
+		return int64(-1)
+	}
+	var b [1]byte
+	n, gerr := r.readSome(b[:])
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	return int64(b[0])
+}
+
+// java/io/ObjectInputStream.read([BII)I
+func objectInputStreamReadBytes(params []interface{}) interface{} {
+	r, args, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputStream.read: array is null")
+	}
+	jbytes := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := args[1].(int64), args[2].(int64)
+	if off < 0 || length < 0 || off+length > int64(len(jbytes)) {
+		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, "ObjectInputStream.read: invalid offset or length")
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	if r.defaultDataEnd {
+		return int64(-1)
+	}
+	buf := make([]byte, length)
+	n, gerr := r.readSome(buf)
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+	return int64(n)
+}
+
+// java/io/ObjectInputStream.readFully([B)V and readFully([BII)V
+func objectInputStreamReadFully(params []interface{}) interface{} {
+	r, args, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectInputStream.readFully: array is null")
+	}
+	jbytes := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := int64(0), int64(len(jbytes))
+	if len(args) == 3 {
+		off, length = args[1].(int64), args[2].(int64)
+		if off < 0 || length < 0 || off+length > int64(len(jbytes)) {
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, "ObjectInputStream.readFully: invalid offset or length")
+		}
+	}
+	buf := make([]byte, length)
+	if gerr := r.readPrimitiveData(buf); gerr != nil {
+		return gerr
+	}
+	copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf))
+	return nil
+}
+
+// readPrimitiveData reads data for readInt, readFully, and the like, which find the end of the
+// data at the end of the fields written by defaultWriteObject.
+func (r *objectReader) readPrimitiveData(p []byte) *ghelpers.GErrBlk {
+	if r.defaultDataEnd && len(p) > 0 {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "")
+	}
+	return r.readData(p)
+}
+
+func readPrimitiveParam(params []interface{}, typeCode byte) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	buf := make([]byte, streamField{typeCode: typeCode}.size())
+	if gerr := r.readPrimitiveData(buf); gerr != nil {
+		return gerr
+	}
+	return primitiveValue(typeCode, buf)
+}
+
+// java/io/ObjectInputStream.readBoolean()Z
+func objectInputStreamReadBoolean(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'Z')
+}
+
+// java/io/ObjectInputStream.readByte()B
+func objectInputStreamReadByte(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'B')
+}
+
+// java/io/ObjectInputStream.readChar()C
+func objectInputStreamReadChar(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'C')
+}
+
+// java/io/ObjectInputStream.readShort()S
+func objectInputStreamReadShort(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'S')
+}
+
+// java/io/ObjectInputStream.readInt()I
+func objectInputStreamReadInt(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'I')
+}
+
+// java/io/ObjectInputStream.readLong()J
+func objectInputStreamReadLong(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'J')
+}
+
+// java/io/ObjectInputStream.readFloat()F
+func objectInputStreamReadFloat(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'F')
+}
+
+// java/io/ObjectInputStream.readDouble()D
+func objectInputStreamReadDouble(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'D')
+}
+
+// java/io/ObjectInputStream.readUnsignedByte()I
+func objectInputStreamReadUnsignedByte(params []interface{}) interface{} {
+	v := readPrimitiveParam(params, 'B')
+	if b, ok := v.(int64); ok {
+		return b & 0xFF
+	}
+	return v
+}
+
+// java/io/ObjectInputStream.readUnsignedShort()I
+func objectInputStreamReadUnsignedShort(params []interface{}) interface{} {
+	return readPrimitiveParam(params, 'C')
+}
+
+// java/io/ObjectInputStream.readUTF()Ljava/lang/String;
+func objectInputStreamReadUTF(params []interface{}) interface{} {
+	r, _, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if r.defaultDataEnd {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "")
+	}
+	s, gerr := r.readUTF()
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(s)
+}
+
+// java/io/ObjectInputStream.skipBytes(I)I
+func objectInputStreamSkipBytes(params []interface{}) interface{} {
+	r, args, gerr := objectReaderOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	n := args[0].(int64)
+	if n <= 0 || r.defaultDataEnd {
+		return int64(0)
+	}
+	buf := make([]byte, min(n, maxBlockSize))
+	skipped := int64(0)
+	for skipped < n {
+		m, gerr := r.readSome(buf[:min(n-skipped, int64(len(buf)))])
+		if gerr != nil {
+			return gerr
+		}
+		if m < 0 {
+			break
+		}
+		skipped += int64(m)
+	}
+	return skipped
+}
@@ -0,0 +1,187 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"bytes"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+const testPointClass = "test/Point"
+
+// insertPointClass defines a serializable class test.Point with int fields x and y, a String
+// field label, a transient int field cache, and a serialVersionUID of 42.
+func insertPointClass() {
+	insertSerialClass(testPointClass, []string{serializableClassName}, []classloader.Field{
+		{NameStr: "serialVersionUID", DescStr: types.Long, IsStatic: true, ConstValue: int64(42),
+			AccessFlags: classloader.ACC_PRIVATE | classloader.ACC_STATIC | classloader.ACC_FINAL},
+		{NameStr: "y", DescStr: types.Int},
+		{NameStr: "x", DescStr: types.Int},
+		{NameStr: "label", DescStr: "Ljava/lang/String;"},
+		{NameStr: "cache", DescStr: types.Int, AccessFlags: accTransient},
+	})
+}
+
+func newPoint(x, y int64, label *object.Object) *object.Object {
+	className := testPointClass
+	p := object.MakeEmptyObjectWithClassName(&className)
+	p.FieldTable["x"] = object.Field{Ftype: types.Int, Fvalue: x}
+	p.FieldTable["y"] = object.Field{Ftype: types.Int, Fvalue: y}
+	p.FieldTable["label"] = object.Field{Ftype: "Ljava/lang/String;", Fvalue: label}
+	p.FieldTable["cache"] = object.Field{Ftype: types.Int, Fvalue: int64(99)}
+	return p
+}
+
+// newTestObjectInputStream returns an ObjectInputStream that reads data.
+func newTestObjectInputStream(t *testing.T, data []byte) *object.Object {
+	className := objectInputStreamClassName
+	ois := object.MakeEmptyObjectWithClassName(&className)
+	if ret := objectInputStreamInit([]any{ois, bytes.NewReader(data)}); ret != nil {
+		t.Fatalf("ObjectInputStream.<init> failed: %v", ret)
+	}
+	return ois
+}
+
+func TestObjectInputStream_RoundTrip(t *testing.T) {
+	setupSerialization()
+	insertPointClass()
+
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+	label := object.StringObjectFromGoString("origin")
+	p1 := newPoint(3, -4, label)
+	for _, obj := range []*object.Object{p1, p1, newPoint(5, 6, label)} {
+		if ret := objectOutputStreamWriteObject([]any{oos, obj}); ret != nil {
+			t.Fatalf("writeObject failed: %v", ret)
+		}
+	}
+	_ = objectOutputStreamWriteLong([]any{oos, int64(-1)})
+	_ = objectOutputStreamFlush([]any{oos})
+
+	ois := newTestObjectInputStream(t, buf.Bytes())
+	first, ok := objectInputStreamReadObject([]any{ois}).(*object.Object)
+	if !ok {
+		t.Fatal("readObject did not return an object")
+	}
+	if x, y := first.FieldTable["x"].Fvalue, first.FieldTable["y"].Fvalue; x != int64(3) || y != int64(-4) {
+		t.Errorf("expected x=3, y=-4, observed x=%v, y=%v", x, y)
+	}
+	if _, ok := first.FieldTable["cache"]; ok {
+		t.Error("the transient field cache should not be read")
+	}
+	firstLabel := first.FieldTable["label"].Fvalue.(*object.Object)
+	if s := object.GoStringFromStringObject(firstLabel); s != "origin" {
+		t.Errorf("expected label \"origin\", observed %q", s)
+	}
+
+	if again := objectInputStreamReadObject([]any{ois}); again != first {
+		t.Error("a back reference should return the same object")
+	}
+	second := objectInputStreamReadObject([]any{ois}).(*object.Object)
+	if second == first || second.FieldTable["x"].Fvalue != int64(5) {
+		t.Error("the second point was not read correctly")
+	}
+	if second.FieldTable["label"].Fvalue != firstLabel {
+		t.Error("a shared string should be read once")
+	}
+	if v := objectInputStreamReadLong([]any{ois}); v != int64(-1) {
+		t.Errorf("expected readLong to return -1, observed %v", v)
+	}
+}
+
+func TestObjectInputStream_IntArray(t *testing.T) {
+	setupSerialization()
+	data := []byte{0xAC, 0xED, 0x00, 0x05, 0x75, 0x72, 0x00, 0x02, 0x5B, 0x49, 0x4D, 0xBA, 0x60, 0x26,
+		0x76, 0xEA, 0xB2, 0xA5, 0x02, 0x00, 0x00, 0x78, 0x70, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02}
+	ois := newTestObjectInputStream(t, data)
+	arr, ok := objectInputStreamReadObject([]any{ois}).(*object.Object)
+	if !ok {
+		t.Fatal("readObject did not return an array")
+	}
+	values := arr.FieldTable["value"].Fvalue.([]int64)
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("expected [1 2], observed %v", values)
+	}
+}
+
+func TestObjectInputStream_BadHeader(t *testing.T) {
+	setupSerialization()
+	className := objectInputStreamClassName
+	ois := object.MakeEmptyObjectWithClassName(&className)
+	ret := objectInputStreamInit([]any{ois, bytes.NewReader([]byte{0xCA, 0xFE, 0xBA, 0xBE})})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.StreamCorruptedException ||
+		gerr.ErrMsg != "invalid stream header: CAFEBABE" {
+		t.Errorf("expected StreamCorruptedException: invalid stream header: CAFEBABE, observed %v", ret)
+	}
+}
+
+func TestObjectInputStream_IncompatibleSUID(t *testing.T) {
+	setupSerialization()
+	insertPointClass()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+	_ = objectOutputStreamWriteObject([]any{oos, newPoint(1, 2, nil)})
+	_ = objectOutputStreamFlush([]any{oos})
+
+	// change the serialVersionUID in the stream from 42 to 43
+	data := buf.Bytes()
+	suidAt := bytes.Index(data, []byte("test.Point")) + len("test.Point")
+	data[suidAt+7] = 43
+
+	ois := newTestObjectInputStream(t, data)
+	ret := objectInputStreamReadObject([]any{ois})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	expected := "test.Point; local class incompatible: stream classdesc serialVersionUID = 43, " +
+		"local class serialVersionUID = 42"
+	if !ok || gerr.ExceptionType != excNames.InvalidClassException || gerr.ErrMsg != expected {
+		t.Errorf("expected InvalidClassException: %s, observed %v", expected, ret)
+	}
+}
+
+func TestObjectInputStream_OptionalData(t *testing.T) {
+	setupSerialization()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+	_ = objectOutputStreamWriteInt([]any{oos, int64(1)})
+	_ = objectOutputStreamFlush([]any{oos})
+
+	ois := newTestObjectInputStream(t, buf.Bytes())
+	ret := objectInputStreamReadObject([]any{ois})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.OptionalDataException {
+		t.Errorf("expected OptionalDataException, observed %v", ret)
+	}
+	if v := objectInputStreamReadInt([]any{ois}); v != int64(1) {
+		t.Errorf("expected readInt to return 1, observed %v", v)
+	}
+}
+
+func TestObjectInputStream_FilterRejects(t *testing.T) {
+	setupSerialization()
+	insertPointClass()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+	_ = objectOutputStreamWriteObject([]any{oos, newPoint(1, 2, nil)})
+	_ = objectOutputStreamFlush([]any{oos})
+
+	ois := newTestObjectInputStream(t, buf.Bytes())
+	filter := objectInputFilterCreateFilter([]any{object.StringObjectFromGoString("!test.*")})
+	if ret := objectInputStreamSetObjectInputFilter([]any{ois, filter}); ret != nil {
+		t.Fatalf("setObjectInputFilter failed: %v", ret)
+	}
+	ret := objectInputStreamReadObject([]any{ois})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.InvalidClassException || gerr.ErrMsg != "filter status: REJECTED" {
+		t.Errorf("expected InvalidClassException: filter status: REJECTED, observed %v", ret)
+	}
+}
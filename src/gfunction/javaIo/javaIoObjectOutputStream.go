@@ -0,0 +1,1146 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"reflect"
+)
+
+// java.io.ObjectOutputStream writes objects in the Java Object Serialization Stream Protocol.
+// The Go state of a stream is an objectWriter. Like the JDK's, it buffers its output, so the
+// underlying stream sees the data only when it is flushed, closed, or the buffer fills up.
+// Primitive data written to the stream is framed in blocks of at most 1024 bytes.
+
+const (
+	objectOutputStreamClassName = "java/io/ObjectOutputStream"
+	objectWriterField           = "$writer" // the *objectWriter of an ObjectOutputStream
+
+	protocolVersion1 = 1
+	protocolVersion2 = 2
+)
+
+type objectWriter struct {
+	self     *object.Object // the ObjectOutputStream
+	target   *object.Object // the underlying stream, if it is a Java object
+	out      io.Writer
+	fs       *list.List // the frame stack of the thread that is writing
+	pending  []byte     // data waiting to be written to out
+	block    []byte     // primitive data waiting to be written as a block
+	blocking bool       // in block data mode
+	protocol int
+	depth    int
+
+	handles     map[any]int32    // the handles of the objects, arrays, and strings written so far
+	descHandles map[string]int32 // the handles of the class descriptions, by class name
+	typeHandles map[string]int32 // the handles of the field type strings, by content
+	nextHandle  int32
+	contexts    []*writeContext
+}
+
+// writeContext is the object and class whose writeObject method is running. defaultWriteObject
+// writes the fields of that class.
+type writeContext struct {
+	obj  *object.Object
+	desc *streamClass
+}
+
+func Load_Io_ObjectOutputStream() {
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapProtected,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.<init>(Ljava/io/OutputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    objectOutputStreamInit,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectOutputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.defaultWriteObject()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    objectOutputStreamDefaultWriteObject,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectOutputStreamFlush,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.putFields()Ljava/io/ObjectOutputStream$PutField;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.reset()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectOutputStreamReset,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.useProtocolVersion(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamUseProtocolVersion,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteByte,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.write([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.write([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  objectOutputStreamWriteBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeBoolean(Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteBoolean,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeByte(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteByte,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeBytes(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteStringBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeChar(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeChars(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeDouble(D)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteDouble,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeFields()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeFloat(F)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteFloat,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeInt(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteInt,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeLong(J)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteLong,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeObject(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    objectOutputStreamWriteObject,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeShort(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteShort,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeUnshared(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    objectOutputStreamWriteUnshared,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectOutputStream.writeUTF(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectOutputStreamWriteUTF,
+		}
+}
+
+// java/io/ObjectOutputStream.<init>(Ljava/io/OutputStream;)V -- writes the stream header
+func objectOutputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	out, gerr := ghelpers.GoWriterWithContext(fs, params[1], "ObjectOutputStream")
+	if gerr != nil {
+		return gerr
+	}
+	w := &objectWriter{self: self, fs: fs, out: out, protocol: protocolVersion2}
+	w.target, _ = params[1].(*object.Object)
+	w.clearHandles()
+	w.pending = binary.BigEndian.AppendUint16(w.pending, streamMagic)
+	w.pending = binary.BigEndian.AppendUint16(w.pending, streamVersion)
+	w.setBlockMode(true)
+	self.FieldTable[objectWriterField] = object.Field{Ftype: types.RawGoPointer, Fvalue: w}
+	return nil
+}
+
+// objectWriterOf returns the objectWriter of the ObjectOutputStream in params, after removing
+// the frame stack, if the G function is called with one.
+func objectWriterOf(params []interface{}) (*objectWriter, []interface{}, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	self, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectOutputStream: stream is null")
+	}
+	w, ok := self.FieldTable[objectWriterField].Fvalue.(*objectWriter)
+	if !ok {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IOException, "ObjectOutputStream: stream is not initialized")
+	}
+	if fs != nil {
+		w.fs = fs
+	}
+	return w, args[1:], nil
+}
+
+func (w *objectWriter) clearHandles() {
+	w.handles = make(map[any]int32)
+	w.descHandles = make(map[string]int32)
+	w.typeHandles = make(map[string]int32)
+	w.nextHandle = baseWireHandle
+}
+
+func (w *objectWriter) assignHandle() int32 {
+	h := w.nextHandle
+	w.nextHandle++
+	return h
+}
+
+// ---- low-level output ----
+
+// setBlockMode turns block data mode on or off, writing out any pending block of data.
+// It returns the previous mode.
+func (w *objectWriter) setBlockMode(mode bool) bool {
+	if w.blocking == mode {
+		return mode
+	}
+	w.drainBlock()
+	w.blocking = mode
+	return !mode
+}
+
+// drainBlock writes the pending primitive data as a TC_BLOCKDATA or TC_BLOCKDATALONG record.
+func (w *objectWriter) drainBlock() {
+	if len(w.block) == 0 {
+		return
+	}
+	if len(w.block) <= 0xFF {
+		w.pending = append(w.pending, tcBlockData, byte(len(w.block)))
+	} else {
+		w.pending = append(w.pending, tcBlockDataLong)
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(w.block)))
+	}
+	w.pending = append(w.pending, w.block...)
+	w.block = w.block[:0]
+}
+
+// writePrimitive writes a primitive value. In block data mode, a value is never split between
+// two blocks.
+func (w *objectWriter) writePrimitive(p []byte) {
+	if !w.blocking {
+		w.pending = append(w.pending, p...)
+		return
+	}
+	if len(w.block)+len(p) > maxBlockSize {
+		w.drainBlock()
+	}
+	w.block = append(w.block, p...)
+}
+
+// writeData writes an array of bytes, which is split into blocks of maxBlockSize bytes in
+// block data mode.
+func (w *objectWriter) writeData(p []byte) {
+	if !w.blocking {
+		w.pending = append(w.pending, p...)
+		return
+	}
+	for len(p) > 0 {
+		if len(w.block) >= maxBlockSize {
+			w.drainBlock()
+		}
+		n := min(len(p), maxBlockSize-len(w.block))
+		w.block = append(w.block, p[:n]...)
+		p = p[n:]
+	}
+}
+
+// writeUTF writes a modified UTF-8 string with a two-byte length. In block data mode, an
+// encoded character is never split between two blocks.
+func (w *objectWriter) writeUTF(s string) *ghelpers.GErrBlk {
+	enc := encodeModifiedUTF8(s)
+	if len(enc) > 0xFFFF {
+		return ghelpers.GetGErrBlk(excNames.UTFDataFormatException, "")
+	}
+	w.writePrimitive(binary.BigEndian.AppendUint16(nil, uint16(len(enc))))
+	if !w.blocking || len(enc) == len([]rune(s)) {
+		w.writeData(enc)
+		return nil
+	}
+	for ix := 0; ix < len(enc); {
+		n := utf8SequenceLength(enc[ix])
+		if len(w.block) >= maxBlockSize-3 {
+			w.drainBlock()
+		}
+		w.block = append(w.block, enc[ix:ix+n]...)
+		ix += n
+	}
+	return nil
+}
+
+func utf8SequenceLength(b byte) int {
+	switch {
+	case b&0x80 == 0:
+		return 1
+	case b&0xE0 == 0xC0:
+		return 2
+	}
+	return 3
+}
+
+// flushPending writes the buffered data to the underlying stream.
+func (w *objectWriter) flushPending() *ghelpers.GErrBlk {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	data := w.pending
+	w.pending = nil
+	if _, err := w.out.Write(data); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	return nil
+}
+
+// checkPending writes the buffered data once it reaches the size of a block.
+func (w *objectWriter) checkPending() *ghelpers.GErrBlk {
+	if len(w.pending) >= maxBlockSize {
+		return w.flushPending()
+	}
+	return nil
+}
+
+// flush writes out all the data, including the pending block of primitive data.
+func (w *objectWriter) flush() *ghelpers.GErrBlk {
+	w.drainBlock()
+	if gerr := w.flushPending(); gerr != nil {
+		return gerr
+	}
+	if f, ok := w.out.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+		}
+	}
+	return nil
+}
+
+// ---- objects ----
+
+// writeObject writes obj, which can be null, and everything it refers to that has not been
+// written yet. unshared objects get a new handle, even if they were written before.
+func (w *objectWriter) writeObject(obj *object.Object, unshared bool) *ghelpers.GErrBlk {
+	oldMode := w.setBlockMode(false)
+	w.depth++
+	defer func() {
+		w.depth--
+		w.setBlockMode(oldMode)
+	}()
+
+	if obj == nil || object.IsNull(obj) {
+		w.pending = append(w.pending, tcNull)
+		return w.checkPending()
+	}
+	if w.writeHandle(obj, unshared) {
+		return w.checkPending()
+	}
+
+	className := streamClassNameOf(obj)
+	switch {
+	case className == types.ClassNameJavaLangClass:
+		return w.writeClass(obj, unshared)
+	case object.IsStringObject(obj):
+		w.writeString(object.GoStringFromStringObject(obj), obj, unshared)
+		return w.checkPending()
+	case types.IsArray(className):
+		return w.writeArray(obj, unshared)
+	}
+
+	desc, gerr := lookupStreamClass(className)
+	if gerr != nil {
+		return gerr
+	}
+
+	// writeReplace can substitute another object, which is then written instead.
+	for desc.writeReplaceIn != "" {
+		ret := ghelpers.InvokeMethodInClass(w.fs, obj, desc.writeReplaceIn, "writeReplace", "()Ljava/lang/Object;")
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		rep, _ := ret.(*object.Object)
+		if rep == nil || object.IsNull(rep) {
+			w.pending = append(w.pending, tcNull)
+			return w.checkPending()
+		}
+		if rep == obj {
+			break
+		}
+		obj = rep
+		if w.writeHandle(obj, unshared) {
+			return w.checkPending()
+		}
+		repClass := streamClassNameOf(obj)
+		if repClass == className || repClass == types.ClassNameJavaLangClass || object.IsStringObject(obj) || types.IsArray(repClass) {
+			return w.writeObjectNoReplace(obj, unshared)
+		}
+		className = repClass
+		if desc, gerr = lookupStreamClass(className); gerr != nil {
+			return gerr
+		}
+	}
+
+	switch {
+	case desc.isEnum:
+		return w.writeEnum(obj, desc, unshared)
+	case desc.serializable:
+		return w.writeOrdinaryObject(obj, desc, unshared)
+	}
+	return ghelpers.GetGErrBlk(excNames.NotSerializableException, desc.name)
+}
+
+// writeObjectNoReplace writes an object that replaced the one being written, without looking
+// for a further replacement.
+func (w *objectWriter) writeObjectNoReplace(obj *object.Object, unshared bool) *ghelpers.GErrBlk {
+	className := streamClassNameOf(obj)
+	switch {
+	case className == types.ClassNameJavaLangClass:
+		return w.writeClass(obj, unshared)
+	case object.IsStringObject(obj):
+		w.writeString(object.GoStringFromStringObject(obj), obj, unshared)
+		return w.checkPending()
+	case types.IsArray(className):
+		return w.writeArray(obj, unshared)
+	}
+	desc, gerr := lookupStreamClass(className)
+	if gerr != nil {
+		return gerr
+	}
+	switch {
+	case desc.isEnum:
+		return w.writeEnum(obj, desc, unshared)
+	case desc.serializable:
+		return w.writeOrdinaryObject(obj, desc, unshared)
+	}
+	return ghelpers.GetGErrBlk(excNames.NotSerializableException, desc.name)
+}
+
+// writeHandle writes a back reference to obj if it has been written before.
+func (w *objectWriter) writeHandle(obj *object.Object, unshared bool) bool {
+	if unshared {
+		return false
+	}
+	h, ok := w.handles[handleKey(obj)]
+	if !ok {
+		return false
+	}
+	w.pending = append(w.pending, tcReference)
+	w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(h))
+	return true
+}
+
+// share records the handle h of obj, so that later references to obj are written as back
+// references.
+func (w *objectWriter) share(obj any, h int32, unshared bool) {
+	if !unshared {
+		w.handles[handleKey(obj)] = h
+	}
+}
+
+// handleKey identifies an object for the handle table. Jacobin does not keep the identity of
+// an array that is stored in a field, only the Go slice that holds its elements, so arrays
+// are identified by that slice.
+func handleKey(obj any) any {
+	if o, ok := obj.(*object.Object); ok {
+		if !types.IsArray(streamClassNameOf(o)) {
+			return o
+		}
+		obj = o.FieldTable["value"].Fvalue
+	}
+	if key, ok := sliceKey(obj); ok {
+		return key
+	}
+	return obj
+}
+
+// sliceKey returns the address and length of the elements of a Go slice, which identify an
+// array whose object is not kept.
+func sliceKey(v any) (any, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Len() == 0 {
+		return nil, false
+	}
+	return [2]uintptr{rv.Pointer(), uintptr(rv.Len())}, true
+}
+
+// writeString writes s, which is the value of the String object str, if there is one.
+func (w *objectWriter) writeString(s string, str *object.Object, unshared bool) {
+	h := w.assignHandle()
+	if str != nil {
+		w.share(str, h, unshared)
+	}
+	enc := encodeModifiedUTF8(s)
+	if len(enc) <= 0xFFFF {
+		w.pending = append(w.pending, tcString)
+		w.pending = binary.BigEndian.AppendUint16(w.pending, uint16(len(enc)))
+	} else {
+		w.pending = append(w.pending, tcLongString)
+		w.pending = binary.BigEndian.AppendUint64(w.pending, uint64(len(enc)))
+	}
+	w.pending = append(w.pending, enc...)
+}
+
+// writeTypeString writes the type of an object field in a class description. Type strings with
+// the same content share a handle, as interned strings do in the JDK.
+func (w *objectWriter) writeTypeString(sig string) {
+	if h, ok := w.typeHandles[sig]; ok {
+		w.pending = append(w.pending, tcReference)
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(h))
+		return
+	}
+	w.typeHandles[sig] = w.nextHandle
+	w.writeString(sig, nil, false)
+}
+
+// writeClass writes a java.lang.Class object as TC_CLASS and the description of the class.
+func (w *objectWriter) writeClass(cls *object.Object, unshared bool) *ghelpers.GErrBlk {
+	desc, gerr := lookupStreamClass(classNameOfClassObject(cls))
+	if gerr != nil {
+		return gerr
+	}
+	w.pending = append(w.pending, tcClass)
+	if gerr := w.writeClassDesc(desc); gerr != nil {
+		return gerr
+	}
+	w.share(cls, w.assignHandle(), unshared)
+	return w.checkPending()
+}
+
+// writeClassDesc writes the description of a class and of its serializable superclasses, or a
+// back reference to it.
+func (w *objectWriter) writeClassDesc(desc *streamClass) *ghelpers.GErrBlk {
+	if desc == nil {
+		w.pending = append(w.pending, tcNull)
+		return nil
+	}
+	if h, ok := w.descHandles[desc.className]; ok {
+		w.pending = append(w.pending, tcReference)
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(h))
+		return nil
+	}
+
+	w.pending = append(w.pending, tcClassDesc)
+	w.descHandles[desc.className] = w.assignHandle()
+	if gerr := w.writeUTF(desc.name); gerr != nil {
+		return gerr
+	}
+	w.pending = binary.BigEndian.AppendUint64(w.pending, uint64(desc.suid))
+	flags := desc.flags
+	if desc.externalizable && w.protocol == protocolVersion1 {
+		flags &^= scBlockData
+	}
+	w.pending = append(w.pending, flags)
+	w.pending = binary.BigEndian.AppendUint16(w.pending, uint16(len(desc.fields)))
+	for _, f := range desc.fields {
+		w.pending = append(w.pending, f.typeCode)
+		if gerr := w.writeUTF(f.name); gerr != nil {
+			return gerr
+		}
+		if !f.isPrimitive() {
+			w.writeTypeString(f.signature)
+		}
+	}
+
+	// ObjectOutputStream.annotateClass writes nothing.
+	w.pending = append(w.pending, tcEndBlockData)
+	if gerr := w.checkPending(); gerr != nil {
+		return gerr
+	}
+	return w.writeClassDesc(desc.super)
+}
+
+// writeArray writes an array object and its elements.
+func (w *objectWriter) writeArray(arr *object.Object, unshared bool) *ghelpers.GErrBlk {
+	className := streamArrayClassName(streamClassNameOf(arr))
+	desc := lookupArrayClass(className)
+	w.pending = append(w.pending, tcArray)
+	if gerr := w.writeClassDesc(desc); gerr != nil {
+		return gerr
+	}
+	w.share(arr, w.assignHandle(), unshared)
+	return w.writeArrayElements(className, arr.FieldTable["value"].Fvalue)
+}
+
+// streamArrayClassName converts Jacobin's names of its char and Go byte arrays to [C and [B.
+func streamArrayClassName(className string) string {
+	switch className {
+	case types.CharArray:
+		return "[C"
+	case types.GoByteArray:
+		return types.JavaByteArray
+	}
+	return className
+}
+
+func (w *objectWriter) writeArrayElements(className string, elements any) *ghelpers.GErrBlk {
+	switch elems := elements.(type) {
+	case []*object.Object:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		for _, elem := range elems {
+			if gerr := w.writeObject(elem, false); gerr != nil {
+				return gerr
+			}
+		}
+		return w.checkPending()
+	case []types.JavaByte:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		for _, b := range elems {
+			w.pending = append(w.pending, byte(b))
+		}
+	case []byte:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		w.pending = append(w.pending, elems...)
+	case []bool:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		for _, b := range elems {
+			w.pending = append(w.pending, byte(types.ConvertGoBoolToJavaBool(b)))
+		}
+	case []int64:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		for _, v := range elems {
+			w.pending = appendPrimitive(w.pending, className[1], v)
+		}
+	case []float64:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, uint32(len(elems)))
+		for _, v := range elems {
+			w.pending = appendPrimitive(w.pending, className[1], v)
+		}
+	case nil:
+		w.pending = binary.BigEndian.AppendUint32(w.pending, 0)
+	default:
+		errMsg := fmt.Sprintf("ObjectOutputStream: unsupported array representation %T for %s", elements, className)
+		return ghelpers.GetGErrBlk(excNames.NotSerializableException, errMsg)
+	}
+	return w.checkPending()
+}
+
+// writeEnum writes an enum constant as its class and its name.
+func (w *objectWriter) writeEnum(obj *object.Object, desc *streamClass, unshared bool) *ghelpers.GErrBlk {
+	w.pending = append(w.pending, tcEnum)
+	if desc.super != nil && desc.super.className != enumClassName { // a constant with a class body
+		desc = desc.super
+	}
+	if gerr := w.writeClassDesc(desc); gerr != nil {
+		return gerr
+	}
+	w.share(obj, w.assignHandle(), unshared)
+	nameObj, _ := obj.FieldTable["name"].Fvalue.(*object.Object)
+	if nameObj == nil || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NotSerializableException, desc.name+": enum constant has no name")
+	}
+	w.writeString(object.GoStringFromStringObject(nameObj), nameObj, false)
+	return w.checkPending()
+}
+
+// writeOrdinaryObject writes a serializable or externalizable object: TC_OBJECT, the
+// description of its class, and its data.
+func (w *objectWriter) writeOrdinaryObject(obj *object.Object, desc *streamClass, unshared bool) *ghelpers.GErrBlk {
+	w.pending = append(w.pending, tcObject)
+	if gerr := w.writeClassDesc(desc); gerr != nil {
+		return gerr
+	}
+	w.share(obj, w.assignHandle(), unshared)
+	if desc.externalizable {
+		return w.writeExternalData(obj)
+	}
+	return w.writeSerialData(obj, desc)
+}
+
+// writeExternalData calls the writeExternal method of obj. With protocol version 2, its data
+// is written in block data mode and ends with TC_ENDBLOCKDATA.
+func (w *objectWriter) writeExternalData(obj *object.Object) *ghelpers.GErrBlk {
+	w.contexts = append(w.contexts, nil)
+	defer func() { w.contexts = w.contexts[:len(w.contexts)-1] }()
+
+	blocked := w.protocol != protocolVersion1
+	if blocked {
+		w.setBlockMode(true)
+	}
+	ret := ghelpers.InvokeMethodOnObject(w.fs, obj, "writeExternal", "(Ljava/io/ObjectOutput;)V", w.self)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	if blocked {
+		w.setBlockMode(false)
+		w.pending = append(w.pending, tcEndBlockData)
+	}
+	return w.checkPending()
+}
+
+// writeSerialData writes the data of each serializable class of obj, from the topmost one
+// down: either its fields or whatever its writeObject method writes.
+func (w *objectWriter) writeSerialData(obj *object.Object, desc *streamClass) *ghelpers.GErrBlk {
+	for _, slot := range desc.hierarchy() {
+		if !slot.hasWriteObject {
+			if gerr := w.writeFields(obj, slot); gerr != nil {
+				return gerr
+			}
+			continue
+		}
+
+		w.contexts = append(w.contexts, &writeContext{obj: obj, desc: slot})
+		w.setBlockMode(true)
+		ret := ghelpers.InvokeMethodInClass(w.fs, obj, slot.className, "writeObject", objectOutputStreamDesc, w.self)
+		w.contexts = w.contexts[:len(w.contexts)-1]
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		w.setBlockMode(false)
+		w.pending = append(w.pending, tcEndBlockData)
+		if gerr := w.checkPending(); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// writeFields writes the values of the serializable fields of the class desc in obj: first the
+// primitive values, then the objects.
+func (w *objectWriter) writeFields(obj *object.Object, desc *streamClass) *ghelpers.GErrBlk {
+	var prims []byte
+	for _, f := range desc.fields {
+		if f.isPrimitive() {
+			prims = appendPrimitive(prims, f.typeCode, obj.FieldTable[f.name].Fvalue)
+		}
+	}
+	w.writeData(prims)
+	for _, f := range desc.fields {
+		if f.isPrimitive() {
+			continue
+		}
+		if gerr := w.writeObject(fieldObject(obj.FieldTable[f.name], f.signature), false); gerr != nil {
+			return gerr
+		}
+	}
+	return w.checkPending()
+}
+
+// fieldObject returns the object held by an object field. Jacobin holds arrays in fields as
+// their Go slices, and strings in several forms, which are converted to objects here.
+func fieldObject(fld object.Field, signature string) *object.Object {
+	switch v := fld.Fvalue.(type) {
+	case *object.Object:
+		return v
+	case nil:
+		return nil
+	case []types.JavaByte:
+		if signature == "Ljava/lang/String;" || fld.Ftype == types.StringClassRef {
+			return object.StringObjectFromJavaByteArray(v)
+		}
+	case []byte:
+		if signature == "Ljava/lang/String;" || fld.Ftype == types.StringClassRef {
+			return object.StringObjectFromByteArray(v)
+		}
+	case uint32:
+		if fld.Ftype == types.StringIndex {
+			return object.StringObjectFromPoolIndex(v)
+		}
+	case string:
+		return object.StringObjectFromGoString(v)
+	}
+
+	arrType := signature
+	if !types.IsArray(arrType) {
+		arrType = fld.Ftype
+	}
+	if types.IsArray(arrType) {
+		arr := object.MakeEmptyObject()
+		arr.FieldTable["value"] = object.Field{Ftype: arrType, Fvalue: fld.Fvalue}
+		arr.KlassName = object.StringPoolIndexFromGoString(arrType)
+		return arr
+	}
+	return nil
+}
+
+// ---- primitive values ----
+
+// appendPrimitive appends the big-endian encoding of the primitive value v, whose type is
+// typeCode, to buf. Jacobin holds integral values as int64 and floating-point ones as float64.
+func appendPrimitive(buf []byte, typeCode byte, v any) []byte {
+	switch typeCode {
+	case 'Z', 'B':
+		return append(buf, byte(int64Of(v)))
+	case 'C', 'S':
+		return binary.BigEndian.AppendUint16(buf, uint16(int64Of(v)))
+	case 'I':
+		return binary.BigEndian.AppendUint32(buf, uint32(int64Of(v)))
+	case 'J':
+		return binary.BigEndian.AppendUint64(buf, uint64(int64Of(v)))
+	case 'F':
+		return binary.BigEndian.AppendUint32(buf, math.Float32bits(float32(float64Of(v))))
+	case 'D':
+		return binary.BigEndian.AppendUint64(buf, math.Float64bits(float64Of(v)))
+	}
+	return buf
+}
+
+// primitiveValue decodes a primitive value of type typeCode as Jacobin holds it.
+func primitiveValue(typeCode byte, b []byte) any {
+	switch typeCode {
+	case 'Z':
+		if b[0] != 0 {
+			return types.JavaBoolTrue
+		}
+		return types.JavaBoolFalse
+	case 'B':
+		return int64(int8(b[0]))
+	case 'C':
+		return int64(binary.BigEndian.Uint16(b))
+	case 'S':
+		return int64(int16(binary.BigEndian.Uint16(b)))
+	case 'I':
+		return int64(int32(binary.BigEndian.Uint32(b)))
+	case 'J':
+		return int64(binary.BigEndian.Uint64(b))
+	case 'F':
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case 'D':
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+	return nil
+}
+
+func int64Of(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int32:
+		return int64(n)
+	case int:
+		return int64(n)
+	case uint8:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case types.JavaByte:
+		return int64(n)
+	case bool:
+		return types.ConvertGoBoolToJavaBool(n)
+	case float64:
+		return int64(n)
+	}
+	return 0
+}
+
+func float64Of(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return 0
+}
+
+// ---- G functions ----
+
+// java/io/ObjectOutputStream.writeObject(Ljava/lang/Object;)V
+func objectOutputStreamWriteObject(params []interface{}) interface{} {
+	return writeObjectParam(params, false)
+}
+
+// java/io/ObjectOutputStream.writeUnshared(Ljava/lang/Object;)V
+func objectOutputStreamWriteUnshared(params []interface{}) interface{} {
+	return writeObjectParam(params, true)
+}
+
+func writeObjectParam(params []interface{}, unshared bool) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	obj, _ := args[0].(*object.Object)
+	if gerr := w.writeObject(obj, unshared); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/ObjectOutputStream.defaultWriteObject()V -- writes the fields of the class whose
+// writeObject method is running
+func objectOutputStreamDefaultWriteObject(params []interface{}) interface{} {
+	w, _, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if len(w.contexts) == 0 || w.contexts[len(w.contexts)-1] == nil {
+		return ghelpers.GetGErrBlk(excNames.NotActiveException, "not in call to writeObject")
+	}
+	ctx := w.contexts[len(w.contexts)-1]
+	w.setBlockMode(false)
+	if gerr := w.writeFields(ctx.obj, ctx.desc); gerr != nil {
+		return gerr
+	}
+	w.setBlockMode(true)
+	return nil
+}
+
+// java/io/ObjectOutputStream.reset()V -- forgets the objects written so far
+func objectOutputStreamReset(params []interface{}) interface{} {
+	w, _, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if w.depth != 0 {
+		return ghelpers.GetGErrBlk(excNames.IOException, "stream active")
+	}
+	w.setBlockMode(false)
+	w.pending = append(w.pending, tcReset)
+	w.clearHandles()
+	w.setBlockMode(true)
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.useProtocolVersion(I)V
+func objectOutputStreamUseProtocolVersion(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if len(w.handles) != 0 || len(w.descHandles) != 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "stream non-empty")
+	}
+	version := args[0].(int64)
+	if version != protocolVersion1 && version != protocolVersion2 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("unknown version: %d", version))
+	}
+	w.protocol = int(version)
+	return nil
+}
+
+// java/io/ObjectOutputStream.flush()V
+func objectOutputStreamFlush(params []interface{}) interface{} {
+	w, _, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := w.flush(); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/ObjectOutputStream.close()V -- flushes the stream and closes the underlying stream
+func objectOutputStreamClose(params []interface{}) interface{} {
+	w, _, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := w.flush(); gerr != nil {
+		return gerr
+	}
+	if w.target != nil {
+		if _, clName := ghelpers.FindInstanceMethod(w.target, "close", "()V"); clName != "" {
+			return ghelpers.InvokeMethodOnObject(w.fs, w.target, "close", "()V")
+		}
+	}
+	return nil
+}
+
+// java/io/ObjectOutputStream.write(I)V and writeByte(I)V
+func objectOutputStreamWriteByte(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	w.writePrimitive([]byte{byte(args[0].(int64))})
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.write([B)V and write([BII)V
+func objectOutputStreamWriteBytes(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectOutputStream.write: array is null")
+	}
+	data := object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte))
+	if len(args) == 3 {
+		off, length := args[1].(int64), args[2].(int64)
+		if off < 0 || length < 0 || off+length > int64(len(data)) {
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, "ObjectOutputStream.write: invalid offset or length")
+		}
+		data = data[off : off+length]
+	}
+	w.writeData(data)
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.writeBoolean(Z)V
+func objectOutputStreamWriteBoolean(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'Z')
+}
+
+// java/io/ObjectOutputStream.writeChar(I)V
+func objectOutputStreamWriteChar(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'C')
+}
+
+// java/io/ObjectOutputStream.writeShort(I)V
+func objectOutputStreamWriteShort(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'S')
+}
+
+// java/io/ObjectOutputStream.writeInt(I)V
+func objectOutputStreamWriteInt(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'I')
+}
+
+// java/io/ObjectOutputStream.writeLong(J)V
+func objectOutputStreamWriteLong(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'J')
+}
+
+// java/io/ObjectOutputStream.writeFloat(F)V
+func objectOutputStreamWriteFloat(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'F')
+}
+
+// java/io/ObjectOutputStream.writeDouble(D)V
+func objectOutputStreamWriteDouble(params []interface{}) interface{} {
+	return writePrimitiveParam(params, 'D')
+}
+
+func writePrimitiveParam(params []interface{}, typeCode byte) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	w.writePrimitive(appendPrimitive(nil, typeCode, args[0]))
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.writeBytes(Ljava/lang/String;)V -- writes the low byte of each char
+func objectOutputStreamWriteStringBytes(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	str, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectOutputStream.writeBytes: string is null")
+	}
+	var data []byte
+	for _, c := range javaChars(object.GoStringFromStringObject(str)) {
+		data = append(data, byte(c))
+	}
+	w.writeData(data)
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.writeChars(Ljava/lang/String;)V
+func objectOutputStreamWriteChars(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	str, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectOutputStream.writeChars: string is null")
+	}
+	for _, c := range javaChars(object.GoStringFromStringObject(str)) {
+		w.writePrimitive(binary.BigEndian.AppendUint16(nil, c))
+	}
+	return w.checkPending()
+}
+
+// java/io/ObjectOutputStream.writeUTF(Ljava/lang/String;)V
+func objectOutputStreamWriteUTF(params []interface{}) interface{} {
+	w, args, gerr := objectWriterOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	str, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectOutputStream.writeUTF: string is null")
+	}
+	if gerr := w.writeUTF(object.GoStringFromStringObject(str)); gerr != nil {
+		return gerr
+	}
+	return w.checkPending()
+}
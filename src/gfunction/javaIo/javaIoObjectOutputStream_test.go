@@ -0,0 +1,160 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"bytes"
+	"container/list"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/stringPool"
+	"jacobin/src/types"
+	"testing"
+)
+
+// setupSerialization prepares the method area and a class instantiator that makes empty
+// objects, as the tests have no class files to load.
+func setupSerialization() {
+	setup()
+	classloader.InitMethodArea()
+	globals.GetGlobalRef().FuncInstantiateClass = func(name string, _ *list.List) (any, error) {
+		return object.MakeEmptyObjectWithClassName(&name), nil
+	}
+	insertSerialClass(serializableClassName, nil, nil)
+}
+
+// insertSerialClass puts a class that extends Object and implements the interfaces in the
+// method area.
+func insertSerialClass(name string, interfaces []string, fields []classloader.Field) {
+	cd := &classloader.ClData{
+		Name:            name,
+		NameIndex:       stringPool.GetStringIndex(&name),
+		SuperclassIndex: types.StringPoolObjectIndex,
+		Fields:          fields,
+		MethodTable:     make(map[string]*classloader.Method),
+	}
+	for _, intf := range interfaces {
+		cd.Interfaces = append(cd.Interfaces, uint16(stringPool.GetStringIndex(&intf)))
+	}
+	classloader.MethAreaInsert(name, &classloader.Klass{Status: 'N', Loader: "test", Data: cd})
+}
+
+// newTestObjectOutputStream returns an ObjectOutputStream that writes to buf.
+func newTestObjectOutputStream(t *testing.T, buf *bytes.Buffer) *object.Object {
+	className := objectOutputStreamClassName
+	oos := object.MakeEmptyObjectWithClassName(&className)
+	if ret := objectOutputStreamInit([]any{oos, buf}); ret != nil {
+		t.Fatalf("ObjectOutputStream.<init> failed: %v", ret)
+	}
+	return oos
+}
+
+func TestObjectOutputStream_IntArrayBytes(t *testing.T) {
+	setupSerialization()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+
+	arr := object.Make1DimArray(object.T_INT, 2)
+	arr.FieldTable["value"] = object.Field{Ftype: types.IntArray, Fvalue: []int64{1, 2}}
+	if ret := objectOutputStreamWriteObject([]any{oos, arr}); ret != nil {
+		t.Fatalf("writeObject failed: %v", ret)
+	}
+	if ret := objectOutputStreamFlush([]any{oos}); ret != nil {
+		t.Fatalf("flush failed: %v", ret)
+	}
+
+	expected := []byte{0xAC, 0xED, 0x00, 0x05, 0x75, 0x72, 0x00, 0x02, 0x5B, 0x49, 0x4D, 0xBA, 0x60, 0x26,
+		0x76, 0xEA, 0xB2, 0xA5, 0x02, 0x00, 0x00, 0x78, 0x70, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("int[]{1, 2}: expected % X, observed % X", expected, buf.Bytes())
+	}
+}
+
+func TestObjectOutputStream_StringBytes(t *testing.T) {
+	setupSerialization()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+
+	str := object.StringObjectFromGoString("hi")
+	_ = objectOutputStreamWriteObject([]any{oos, str})
+	_ = objectOutputStreamWriteObject([]any{oos, str}) // a back reference
+	_ = objectOutputStreamFlush([]any{oos})
+
+	expected := []byte{0xAC, 0xED, 0x00, 0x05, 0x74, 0x00, 0x02, 0x68, 0x69, 0x71, 0x00, 0x7E, 0x00, 0x00}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("\"hi\" twice: expected % X, observed % X", expected, buf.Bytes())
+	}
+}
+
+func TestObjectOutputStream_PrimitivesInBlockData(t *testing.T) {
+	setupSerialization()
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+
+	_ = objectOutputStreamWriteInt([]any{oos, int64(7)})
+	_ = objectOutputStreamWriteUTF([]any{oos, object.StringObjectFromGoString("a\u0000")})
+	_ = objectOutputStreamFlush([]any{oos})
+
+	expected := []byte{0xAC, 0xED, 0x00, 0x05, 0x77, 0x09, 0x00, 0x00, 0x00, 0x07, 0x00, 0x03, 0x61, 0xC0, 0x80}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("expected % X, observed % X", expected, buf.Bytes())
+	}
+}
+
+func TestObjectOutputStream_NotSerializable(t *testing.T) {
+	setupSerialization()
+	insertSerialClass("test/Plain", nil, nil)
+	var buf bytes.Buffer
+	oos := newTestObjectOutputStream(t, &buf)
+
+	className := "test/Plain"
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	ret := objectOutputStreamWriteObject([]any{oos, obj})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok {
+		t.Fatalf("expected an error, observed %v", ret)
+	}
+	if gerr.ExceptionType != excNames.NotSerializableException || gerr.ErrMsg != "test.Plain" {
+		t.Errorf("expected NotSerializableException: test.Plain, observed %d: %s", gerr.ExceptionType, gerr.ErrMsg)
+	}
+}
+
+func TestObjectStreamClass_ArraySUIDs(t *testing.T) {
+	setupSerialization()
+	tests := map[string]int64{
+		"[I":                  5600894804908749477,
+		"[B":                  -5984413125824719648,
+		"[Ljava/lang/String;": -5921575005990323385,
+		"[Ljava/lang/Object;": -8012369246846506644,
+	}
+	for className, expected := range tests {
+		if suid := arraySUID(className); suid != expected {
+			t.Errorf("%s: expected serialVersionUID %d, observed %d", className, expected, suid)
+		}
+	}
+}
+
+func TestModifiedUTF8_RoundTrip(t *testing.T) {
+	for _, s := range []string{"", "abc", "a\u0000b", "é€", "\U0001F600"} {
+		enc := encodeModifiedUTF8(s)
+		dec, err := decodeModifiedUTF8(enc)
+		if err != nil || dec != s {
+			t.Errorf("%q: round trip gave %q, %v", s, dec, err)
+		}
+	}
+	if enc := encodeModifiedUTF8("\U0001F600"); len(enc) != 6 {
+		t.Errorf("a supplementary character should take 6 bytes, observed % X", enc)
+	}
+	if _, err := decodeModifiedUTF8([]byte{0xE0, 0x80}); err == nil ||
+		err.Error() != "malformed input: partial character at end" {
+		t.Errorf("expected a partial character error, observed %v", err)
+	}
+}
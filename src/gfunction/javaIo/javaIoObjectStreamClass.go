@@ -0,0 +1,809 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/stringPool"
+	"jacobin/src/types"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The Java Object Serialization Stream Protocol, as ObjectOutputStream and ObjectInputStream
+// implement it. See https://docs.oracle.com/en/java/javase/21/docs/specs/serialization/protocol.html
+// A streamClass is the Go form of an ObjectStreamClass: the description of a class that is
+// written to, or read from, the stream. Descriptions of local classes are built from the
+// field and method metadata in ClData.
+
+const (
+	streamMagic    = 0xACED
+	streamVersion  = 5
+	baseWireHandle = 0x7E0000
+	maxBlockSize   = 1024 // the largest block of data written by ObjectOutputStream
+
+	tcNull           = 0x70
+	tcReference      = 0x71
+	tcClassDesc      = 0x72
+	tcObject         = 0x73
+	tcString         = 0x74
+	tcArray          = 0x75
+	tcClass          = 0x76
+	tcBlockData      = 0x77
+	tcEndBlockData   = 0x78
+	tcReset          = 0x79
+	tcBlockDataLong  = 0x7A
+	tcException      = 0x7B
+	tcLongString     = 0x7C
+	tcProxyClassDesc = 0x7D
+	tcEnum           = 0x7E
+
+	scWriteMethod    = 0x01
+	scSerializable   = 0x02
+	scExternalizable = 0x04
+	scBlockData      = 0x08
+	scEnum           = 0x10
+
+	serializableClassName   = "java/io/Serializable"
+	externalizableClassName = "java/io/Externalizable"
+	enumClassName           = "java/lang/Enum"
+	objectStreamClassName   = "java/io/ObjectStreamClass"
+	objectOutputStreamDesc  = "(Ljava/io/ObjectOutputStream;)V"
+	objectInputStreamDesc   = "(Ljava/io/ObjectInputStream;)V"
+
+	accTransient   = 0x0080
+	fieldModMask   = 0x00DF // public, private, protected, static, final, volatile, transient
+	methodModMask  = 0x0D3F // public, private, protected, static, final, synchronized, native, abstract, strict
+	classModMask   = classloader.ACC_PUBLIC | classloader.ACC_FINAL | classloader.ACC_INTERFACE | classloader.ACC_ABSTRACT
+	streamClassFld = "$desc" // the *streamClass of an ObjectStreamClass object
+)
+
+// streamField is a serializable field: its name, its type code (one of BCDFIJSZ, L, or [),
+// and for object fields, its type as a field descriptor such as Ljava/lang/String;
+type streamField struct {
+	name      string
+	typeCode  byte
+	signature string
+}
+
+func (f streamField) isPrimitive() bool {
+	return f.typeCode != 'L' && f.typeCode != '['
+}
+
+// size returns the number of bytes of a primitive field in the stream.
+func (f streamField) size() int {
+	switch f.typeCode {
+	case 'B', 'Z':
+		return 1
+	case 'C', 'S':
+		return 2
+	case 'I', 'F':
+		return 4
+	case 'J', 'D':
+		return 8
+	}
+	return 0
+}
+
+// streamClass describes a class in the stream. Descriptions of local classes have a klass;
+// descriptions read from a stream have the description of the matching local class in local.
+type streamClass struct {
+	name      string // the binary name, as written: java.lang.Integer, [I
+	className string // the internal name: java/lang/Integer
+	suid      int64
+	flags     byte
+	fields    []streamField
+	super     *streamClass
+	klass     *classloader.Klass
+	local     *streamClass
+
+	isEnum          bool
+	isArray         bool
+	serializable    bool
+	externalizable  bool
+	hasWriteObject  bool
+	hasReadObject   bool
+	hasReadNoData   bool
+	writeReplaceIn  string // the class that declares writeReplace()Ljava/lang/Object;, if any
+	readResolveIn   string // the class that declares readResolve()Ljava/lang/Object;, if any
+	ctorClass       string // the class whose no-arg constructor initializes a new instance
+	ctorErr         string // why there is no usable constructor, if there is none
+	hasStreamFields bool   // the fields were read from the stream
+}
+
+// hasWriteObjectData reports whether the stream has custom data, terminated by
+// TC_ENDBLOCKDATA, after the fields written for this class.
+func (d *streamClass) hasWriteObjectData() bool {
+	if d.externalizable {
+		return d.flags&scBlockData != 0
+	}
+	return d.flags&scWriteMethod != 0
+}
+
+// hierarchy returns the description of this class and its serializable superclasses, from
+// the topmost superclass down, which is the order in which their data is in the stream.
+func (d *streamClass) hierarchy() []*streamClass {
+	var chain []*streamClass
+	for c := d; c != nil; c = c.super {
+		chain = append([]*streamClass{c}, chain...)
+	}
+	return chain
+}
+
+var (
+	streamClassCache = make(map[string]*streamClass)
+	streamClassMutex sync.Mutex
+)
+
+// binaryName converts an internal class name (java/lang/String, [Ljava/lang/String;) to the
+// binary name used in the stream (java.lang.String, [Ljava.lang.String;).
+func binaryName(className string) string {
+	return strings.ReplaceAll(className, "/", ".")
+}
+
+// internalName converts a binary class name to an internal one.
+func internalName(name string) string {
+	return strings.ReplaceAll(name, ".", "/")
+}
+
+// loadStreamKlass returns the loaded class className, loading it if necessary, or nil if it
+// cannot be loaded.
+func loadStreamKlass(className string) *classloader.Klass {
+	if k := classloader.MethAreaFetch(className); k != nil && k.Data != nil {
+		return k
+	}
+	if err := classloader.LoadClassFromNameOnly(className); err != nil {
+		return nil
+	}
+	if err := classloader.WaitForClassStatus(className); err != nil {
+		return nil
+	}
+	k := classloader.MethAreaFetch(className)
+	if k == nil || k.Data == nil {
+		return nil
+	}
+	return k
+}
+
+// superclassOf returns the name of the superclass of k, or "" if it is java/lang/Object, which
+// has nothing to do with serialization.
+func superclassOf(k *classloader.Klass) string {
+	if k.Data.Name == types.ObjectClassName || k.Data.SuperclassIndex == types.InvalidStringIndex {
+		return ""
+	}
+	if superName := *stringPool.GetStringPointer(k.Data.SuperclassIndex); superName != types.ObjectClassName {
+		return superName
+	}
+	return ""
+}
+
+// interfacesOf returns the names of the interfaces that k declares.
+func interfacesOf(k *classloader.Klass) []string {
+	var names []string
+	for _, ix := range k.Data.Interfaces {
+		names = append(names, *stringPool.GetStringPointer(uint32(ix)))
+	}
+	return names
+}
+
+// implementsInterface reports whether className is, or implements, the interface iface.
+func implementsInterface(className, iface string) bool {
+	for className != "" {
+		if className == iface {
+			return true
+		}
+		k := loadStreamKlass(className)
+		if k == nil {
+			return false
+		}
+		for _, intf := range interfacesOf(k) {
+			if implementsInterface(intf, iface) {
+				return true
+			}
+		}
+		className = superclassOf(k)
+	}
+	return false
+}
+
+// isSubclassOf reports whether className is ancestor or one of its subclasses.
+func isSubclassOf(className, ancestor string) bool {
+	for className != "" {
+		if className == ancestor {
+			return true
+		}
+		k := loadStreamKlass(className)
+		if k == nil {
+			return false
+		}
+		className = superclassOf(k)
+	}
+	return false
+}
+
+// isSerializableClass reports whether instances of className can be serialized.
+func isSerializableClass(className string) bool {
+	if strings.HasPrefix(className, "[") || className == types.StringClassName || className == enumClassName {
+		return true
+	}
+	return implementsInterface(className, serializableClassName) ||
+		implementsInterface(className, externalizableClassName)
+}
+
+// lookupStreamClass returns the description of the local class className, which can be an
+// array class or the name of a primitive type. Non-serializable classes have a description
+// with no flags, as ObjectStreamClass.lookupAny returns.
+func lookupStreamClass(className string) (*streamClass, *ghelpers.GErrBlk) {
+	switch {
+	case strings.HasPrefix(className, "["):
+		return lookupArrayClass(className), nil
+	case primitiveTypeNames[className] != 0:
+		return &streamClass{name: className, className: className}, nil
+	}
+
+	k := loadStreamKlass(className)
+	if k == nil {
+		if className == enumClassName { // the superclass of all enums, when it is not loadable
+			return &streamClass{name: binaryName(className), className: className,
+				flags: scSerializable | scEnum, isEnum: true, serializable: true}, nil
+		}
+		return nil, ghelpers.GetGErrBlk(excNames.ClassNotFoundException, binaryName(className))
+	}
+
+	streamClassMutex.Lock()
+	desc, ok := streamClassCache[className]
+	streamClassMutex.Unlock()
+	if ok && desc.klass.Data == k.Data {
+		return desc, nil
+	}
+
+	desc = &streamClass{name: binaryName(className), className: className, klass: k}
+	desc.isEnum = isSubclassOf(className, enumClassName)
+	desc.serializable = desc.isEnum || isSerializableClass(className)
+
+	if superName := superclassOf(k); superName != "" && isSerializableClass(superName) {
+		super, gerr := lookupStreamClass(superName)
+		if gerr != nil {
+			return nil, gerr
+		}
+		desc.super = super
+	}
+
+	switch {
+	case !desc.serializable:
+		// no serialVersionUID, flags, or fields
+	case desc.isEnum:
+		desc.flags = scSerializable | scEnum
+	default:
+		desc.externalizable = implementsInterface(className, externalizableClassName)
+		desc.suid = declaredSUID(k)
+		if desc.suid == 0 && !hasDeclaredSUID(k) {
+			desc.suid = computeDefaultSUID(k)
+		}
+		if desc.externalizable {
+			desc.flags = scExternalizable | scBlockData
+			desc.ctorClass = className
+			if !hasMethod(k, "<init>()V", classloader.ACC_PUBLIC) {
+				desc.ctorErr = "no valid constructor"
+			}
+		} else {
+			desc.flags = scSerializable
+			desc.fields = serialFields(k)
+			desc.hasWriteObject = hasPrivateHook(k, "writeObject"+objectOutputStreamDesc)
+			desc.hasReadObject = hasPrivateHook(k, "readObject"+objectInputStreamDesc)
+			desc.hasReadNoData = hasPrivateHook(k, "readObjectNoData()V")
+			if desc.hasWriteObject {
+				desc.flags |= scWriteMethod
+			}
+			desc.ctorClass, desc.ctorErr = serialConstructorClass(k)
+		}
+		desc.writeReplaceIn = inheritableMethod(k, "writeReplace()Ljava/lang/Object;")
+		desc.readResolveIn = inheritableMethod(k, "readResolve()Ljava/lang/Object;")
+	}
+
+	streamClassMutex.Lock()
+	streamClassCache[className] = desc
+	streamClassMutex.Unlock()
+	return desc, nil
+}
+
+// lookupArrayClass describes an array class. Arrays are serializable, have no fields, and
+// always have the computed serialVersionUID.
+func lookupArrayClass(className string) *streamClass {
+	return &streamClass{
+		name:         binaryName(className),
+		className:    className,
+		suid:         arraySUID(className),
+		flags:        scSerializable,
+		isArray:      true,
+		serializable: true,
+	}
+}
+
+var primitiveTypeNames = map[string]byte{
+	"boolean": 'Z', "byte": 'B', "char": 'C', "short": 'S',
+	"int": 'I', "long": 'J', "float": 'F', "double": 'D', "void": 'V',
+}
+
+// hasDeclaredSUID reports whether k declares a static final long serialVersionUID.
+func hasDeclaredSUID(k *classloader.Klass) bool {
+	for _, f := range k.Data.Fields {
+		if f.NameStr == "serialVersionUID" && f.DescStr == types.Long && f.IsStatic {
+			return true
+		}
+	}
+	return false
+}
+
+// declaredSUID returns the value of the serialVersionUID field of k, or 0 if there is none.
+func declaredSUID(k *classloader.Klass) int64 {
+	for _, f := range k.Data.Fields {
+		if f.NameStr != "serialVersionUID" || f.DescStr != types.Long || !f.IsStatic {
+			continue
+		}
+		if v, ok := f.ConstValue.(int64); ok {
+			return v
+		}
+		if st, ok := statics.QueryStatic(k.Data.Name, f.NameStr); ok { // set in <clinit>
+			if v, ok := st.Value.(int64); ok {
+				return v
+			}
+		}
+	}
+	return 0
+}
+
+// serialFields returns the default serializable fields of k, which are its non-static,
+// non-transient fields, in the order of the stream: primitive fields first, then by name.
+func serialFields(k *classloader.Klass) []streamField {
+	var fields []streamField
+	for _, f := range k.Data.Fields {
+		if f.IsStatic || f.AccessFlags&(classloader.ACC_STATIC|accTransient) != 0 || f.DescStr == "" {
+			continue
+		}
+		fields = append(fields, streamField{name: f.NameStr, typeCode: f.DescStr[0], signature: f.DescStr})
+	}
+	sortStreamFields(fields)
+	return fields
+}
+
+func sortStreamFields(fields []streamField) {
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].isPrimitive() != fields[j].isPrimitive() {
+			return fields[i].isPrimitive()
+		}
+		return fields[i].name < fields[j].name
+	})
+}
+
+// hasMethod reports whether k declares the method key (name+descriptor) with all of flags.
+func hasMethod(k *classloader.Klass, key string, flags int) bool {
+	m, ok := k.Data.MethodTable[key]
+	return ok && m.AccessFlags&flags == flags
+}
+
+// hasPrivateHook reports whether k declares the private, non-static method key, which is how
+// serializable classes declare writeObject, readObject, and readObjectNoData.
+func hasPrivateHook(k *classloader.Klass, key string) bool {
+	m, ok := k.Data.MethodTable[key]
+	return ok && m.AccessFlags&classloader.ACC_PRIVATE != 0 && m.AccessFlags&classloader.ACC_STATIC == 0
+}
+
+// inheritableMethod returns the class, k or one of its superclasses, whose method key would be
+// called on an instance of k, as for writeReplace and readResolve. A private method counts only
+// in k itself, and a package-private one only in the package of k.
+func inheritableMethod(k *classloader.Klass, key string) string {
+	pkg := packageOf(k.Data.Name)
+	for c := k; c != nil; {
+		if m, ok := c.Data.MethodTable[key]; ok {
+			switch {
+			case m.AccessFlags&(classloader.ACC_STATIC|classloader.ACC_ABSTRACT) != 0:
+				return ""
+			case m.AccessFlags&(classloader.ACC_PUBLIC|classloader.ACC_PROTECTED) != 0:
+				return c.Data.Name
+			case m.AccessFlags&classloader.ACC_PRIVATE != 0:
+				if c == k {
+					return c.Data.Name
+				}
+				return ""
+			default:
+				if packageOf(c.Data.Name) == pkg {
+					return c.Data.Name
+				}
+				return ""
+			}
+		}
+		superName := superclassOf(c)
+		if superName == "" {
+			break
+		}
+		c = loadStreamKlass(superName)
+	}
+	return ""
+}
+
+func packageOf(className string) string {
+	if ix := strings.LastIndex(className, "/"); ix >= 0 {
+		return className[:ix]
+	}
+	return ""
+}
+
+// serialConstructorClass returns the first non-serializable superclass of k, whose no-arg
+// constructor initializes the instances that are deserialized. The constructor must be
+// accessible to k. If there is no such constructor, the reason is returned.
+func serialConstructorClass(k *classloader.Klass) (string, string) {
+	className := superclassOf(k)
+	for className != "" && isSerializableClass(className) {
+		sk := loadStreamKlass(className)
+		if sk == nil {
+			return "", "no valid constructor"
+		}
+		className = superclassOf(sk)
+	}
+	if className == "" || className == types.ObjectClassName {
+		return types.ObjectClassName, ""
+	}
+	if _, ok := ghelpers.MethodSignatures[className+".<init>()V"]; ok {
+		return className, ""
+	}
+	sk := loadStreamKlass(className)
+	if sk == nil {
+		return "", "no valid constructor"
+	}
+	m, ok := sk.Data.MethodTable["<init>()V"]
+	if !ok || m.AccessFlags&classloader.ACC_PRIVATE != 0 ||
+		(m.AccessFlags&(classloader.ACC_PUBLIC|classloader.ACC_PROTECTED) == 0 && packageOf(className) != packageOf(k.Data.Name)) {
+		return "", "no valid constructor"
+	}
+	return className, ""
+}
+
+// classModifiers returns the modifiers of the class k as Class.getModifiers does. A nested
+// class takes its modifiers from its entry in the InnerClasses attribute.
+func classModifiers(k *classloader.Klass) int {
+	if flags, ok := innerClassFlags(k.Data); ok {
+		return flags
+	}
+	mods := 0
+	access := k.Data.Access
+	if access.ClassIsPublic {
+		mods |= classloader.ACC_PUBLIC
+	}
+	if access.ClassIsFinal {
+		mods |= classloader.ACC_FINAL
+	}
+	if access.ClassIsInterface {
+		mods |= classloader.ACC_INTERFACE
+	}
+	if access.ClassIsAbstract {
+		mods |= classloader.ACC_ABSTRACT
+	}
+	return mods
+}
+
+// innerClassFlags returns the inner_class_access_flags of the class in cd from its own
+// InnerClasses attribute, if it is a nested class.
+func innerClassFlags(cd *classloader.ClData) (int, bool) {
+	for _, attr := range cd.Attributes {
+		if int(attr.AttrName) >= len(cd.CP.Utf8Refs) || cd.CP.Utf8Refs[attr.AttrName] != "InnerClasses" {
+			continue
+		}
+		content := attr.AttrContent
+		if len(content) < 2 {
+			return 0, false
+		}
+		count := int(binary.BigEndian.Uint16(content))
+		for ix := 0; ix < count && 2+ix*8+8 <= len(content); ix++ {
+			entry := content[2+ix*8:]
+			inner := binary.BigEndian.Uint16(entry)
+			if classloader.GetClassNameFromCPclassref(&cd.CP, inner) == cd.Name {
+				return int(binary.BigEndian.Uint16(entry[6:])), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// computeDefaultSUID computes the serialVersionUID of a class that does not declare one, as
+// ObjectStreamClass does: the first eight bytes, little-endian, of the SHA-1 hash of the
+// class name, modifiers, interfaces, fields, static initializer, constructors, and methods.
+func computeDefaultSUID(k *classloader.Klass) int64 {
+	var dout bytes.Buffer
+	writeUTF := func(s string) {
+		enc := encodeModifiedUTF8(s)
+		_ = binary.Write(&dout, binary.BigEndian, uint16(len(enc)))
+		dout.Write(enc)
+	}
+	writeInt := func(v int) {
+		_ = binary.Write(&dout, binary.BigEndian, int32(v))
+	}
+
+	type member struct {
+		name, sig string
+		mods      int
+	}
+	var ctors, methods []member
+	for key, m := range k.Data.MethodTable {
+		ix := strings.Index(key, "(")
+		if ix < 0 {
+			continue
+		}
+		mem := member{name: key[:ix], sig: binaryName(key[ix:]), mods: m.AccessFlags & methodModMask}
+		switch mem.name {
+		case "<clinit>":
+		case "<init>":
+			ctors = append(ctors, mem)
+		default:
+			methods = append(methods, mem)
+		}
+	}
+
+	writeUTF(binaryName(k.Data.Name))
+
+	mods := classModifiers(k) & classModMask
+	if mods&classloader.ACC_INTERFACE != 0 {
+		if len(methods) > 0 {
+			mods |= classloader.ACC_ABSTRACT
+		} else {
+			mods &^= classloader.ACC_ABSTRACT
+		}
+	}
+	writeInt(mods)
+
+	intfs := interfacesOf(k)
+	for ix := range intfs {
+		intfs[ix] = binaryName(intfs[ix])
+	}
+	sort.Strings(intfs)
+	for _, intf := range intfs {
+		writeUTF(intf)
+	}
+
+	fields := append([]classloader.Field(nil), k.Data.Fields...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].NameStr < fields[j].NameStr })
+	for _, f := range fields {
+		fmods := f.AccessFlags & fieldModMask
+		if f.IsStatic {
+			fmods |= classloader.ACC_STATIC
+		}
+		if fmods&classloader.ACC_PRIVATE != 0 && fmods&(classloader.ACC_STATIC|accTransient) != 0 {
+			continue
+		}
+		writeUTF(f.NameStr)
+		writeInt(fmods)
+		writeUTF(f.DescStr)
+	}
+
+	if _, ok := k.Data.MethodTable["<clinit>()V"]; ok {
+		writeUTF("<clinit>")
+		writeInt(classloader.ACC_STATIC)
+		writeUTF("()V")
+	}
+
+	sort.Slice(ctors, func(i, j int) bool { return ctors[i].sig < ctors[j].sig })
+	for _, c := range ctors {
+		if c.mods&classloader.ACC_PRIVATE == 0 {
+			writeUTF("<init>")
+			writeInt(c.mods)
+			writeUTF(c.sig)
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].name != methods[j].name {
+			return methods[i].name < methods[j].name
+		}
+		return methods[i].sig < methods[j].sig
+	})
+	for _, m := range methods {
+		if m.mods&classloader.ACC_PRIVATE == 0 {
+			writeUTF(m.name)
+			writeInt(m.mods)
+			writeUTF(m.sig)
+		}
+	}
+
+	return suidFromHash(dout.Bytes())
+}
+
+// arraySUID computes the serialVersionUID of an array class, which is hashed from its name and
+// its modifiers only. An array class is final and abstract, and has the access of its
+// element type.
+func arraySUID(className string) int64 {
+	elem := strings.TrimLeft(className, "[")
+	mods := classloader.ACC_FINAL | classloader.ACC_ABSTRACT | classloader.ACC_PUBLIC
+	if strings.HasPrefix(elem, "L") {
+		if k := loadStreamKlass(strings.TrimSuffix(elem[1:], ";")); k != nil {
+			mods = classloader.ACC_FINAL | classloader.ACC_ABSTRACT |
+				classModifiers(k)&(classloader.ACC_PUBLIC|classloader.ACC_PRIVATE|classloader.ACC_PROTECTED)
+		}
+	}
+
+	name := encodeModifiedUTF8(binaryName(className))
+	buf := make([]byte, 2, 2+len(name)+4)
+	binary.BigEndian.PutUint16(buf, uint16(len(name)))
+	buf = append(buf, name...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(mods&classModMask))
+	return suidFromHash(buf)
+}
+
+func suidFromHash(data []byte) int64 {
+	sum := sha1.Sum(data)
+	var hash int64
+	for ix := min(len(sum), 8) - 1; ix >= 0; ix-- {
+		hash = hash<<8 | int64(sum[ix])
+	}
+	return hash
+}
+
+// className returns the internal name of the class of obj, as the stream sees it.
+func streamClassNameOf(obj *object.Object) string {
+	return *stringPool.GetStringPointer(obj.KlassName)
+}
+
+// classNameOfClassObject returns the internal class name held by a java/lang/Class object.
+func classNameOfClassObject(cls *object.Object) string {
+	if nameObj, ok := cls.FieldTable["name"].Fvalue.(*object.Object); ok {
+		return object.GoStringFromStringObject(nameObj)
+	}
+	if cd, ok := cls.FieldTable["$klass"].Fvalue.(*classloader.ClData); ok && cd != nil {
+		return cd.Name
+	}
+	return ""
+}
+
+// classObjectFor returns the java/lang/Class object of className.
+func classObjectFor(className string) *object.Object {
+	if !strings.HasPrefix(className, "[") && primitiveTypeNames[className] == 0 {
+		if k := loadStreamKlass(className); k != nil && k.Data.ClassObject != nil {
+			return k.Data.ClassObject
+		}
+	}
+	return classloader.MakeJlcObject(className)
+}
+
+// ---- java.io.ObjectStreamClass ----
+
+func Load_Io_ObjectStreamClass() {
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.forClass()Ljava/lang/Class;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectStreamClassForClass,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.getName()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectStreamClassGetName,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.getSerialVersionUID()J"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectStreamClassGetSerialVersionUID,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.lookup(Ljava/lang/Class;)Ljava/io/ObjectStreamClass;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectStreamClassLookup,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.lookupAny(Ljava/lang/Class;)Ljava/io/ObjectStreamClass;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  objectStreamClassLookupAny,
+		}
+
+	ghelpers.MethodSignatures["java/io/ObjectStreamClass.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  objectStreamClassToString,
+		}
+}
+
+// newObjectStreamClass wraps desc in a java.io.ObjectStreamClass object.
+func newObjectStreamClass(desc *streamClass) *object.Object {
+	className := objectStreamClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[streamClassFld] = object.Field{Ftype: types.RawGoPointer, Fvalue: desc}
+	return obj
+}
+
+func streamClassOf(params []interface{}) (*streamClass, *ghelpers.GErrBlk) {
+	self, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectStreamClass: object is null")
+	}
+	desc, ok := self.FieldTable[streamClassFld].Fvalue.(*streamClass)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "ObjectStreamClass: no class description")
+	}
+	return desc, nil
+}
+
+// java/io/ObjectStreamClass.lookup(Ljava/lang/Class;)Ljava/io/ObjectStreamClass; -- returns
+// null for a class that is not serializable
+func objectStreamClassLookup(params []interface{}) interface{} {
+	return lookupClassObject(params, false)
+}
+
+// java/io/ObjectStreamClass.lookupAny(Ljava/lang/Class;)Ljava/io/ObjectStreamClass;
+func objectStreamClassLookupAny(params []interface{}) interface{} {
+	return lookupClassObject(params, true)
+}
+
+func lookupClassObject(params []interface{}, any bool) interface{} {
+	cls, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(cls) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ObjectStreamClass.lookup: class is null")
+	}
+	desc, gerr := lookupStreamClass(classNameOfClassObject(cls))
+	if gerr != nil {
+		return gerr
+	}
+	if !desc.serializable && !any {
+		return object.Null
+	}
+	return newObjectStreamClass(desc)
+}
+
+// java/io/ObjectStreamClass.forClass()Ljava/lang/Class;
+func objectStreamClassForClass(params []interface{}) interface{} {
+	desc, gerr := streamClassOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	if desc.klass == nil && desc.local == nil && !desc.isArray && primitiveTypeNames[desc.className] == 0 {
+		return object.Null
+	}
+	return classObjectFor(desc.className)
+}
+
+// java/io/ObjectStreamClass.getName()Ljava/lang/String;
+func objectStreamClassGetName(params []interface{}) interface{} {
+	desc, gerr := streamClassOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(desc.name)
+}
+
+// java/io/ObjectStreamClass.getSerialVersionUID()J
+func objectStreamClassGetSerialVersionUID(params []interface{}) interface{} {
+	desc, gerr := streamClassOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	return desc.suid
+}
+
+// java/io/ObjectStreamClass.toString()Ljava/lang/String;
+func objectStreamClassToString(params []interface{}) interface{} {
+	desc, gerr := streamClassOf(params)
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(fmt.Sprintf("%s: static final long serialVersionUID = %dL;", desc.name, desc.suid))
+}
@@ -7,12 +7,17 @@
 package javaIo
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
+	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaNio"
 	"jacobin/src/object"
 	"jacobin/src/types"
 	"os"
+	"unicode/utf16"
 )
 
 func Load_Io_OutputStreamWriter() {
@@ -31,70 +36,88 @@ func Load_Io_OutputStreamWriter() {
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  oswClose,
+			ParamSlots:   0,
+			GFunction:    oswClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.flush()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  oswFlush,
+			ParamSlots:   0,
+			GFunction:    oswFlush,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.write(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  oswWriteOneChar,
+			ParamSlots:   1,
+			GFunction:    oswWriteOneChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.write([CII)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  oswWriteCharBuffer,
+			ParamSlots:   3,
+			GFunction:    oswWriteCharBuffer,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.write(Ljava/lang/String;II)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  oswWriteStringBuffer,
+			ParamSlots:   3,
+			GFunction:    oswWriteStringBuffer,
+			NeedsContext: true,
 		}
 
-	// -----------------------------------------
-	// traps that do nothing but return an error
-	// -----------------------------------------
-
-	ghelpers.MethodSignatures["java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/lang.String;)V"] =
+	ghelpers.MethodSignatures["java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initOutputStreamWriterCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initOutputStreamWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/nio/charset/CharsetDecoder;)Ljava/lang.String;"] =
+	ghelpers.MethodSignatures["java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/nio/charset/CharsetEncoder;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  initOutputStreamWriterCharset,
 		}
 
-	ghelpers.MethodSignatures["java/io/OutputStreamWriter.getEncoding()V"] =
+	ghelpers.MethodSignatures["java/io/OutputStreamWriter.getEncoding()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  oswGetEncoding,
 		}
 
 }
 
+// fieldNameOut holds the Java stream or writer that a decorator writes to when it is not over a
+// file that Go has open. Its Ftype tells which of the two it is.
+const fieldNameOut = "out"
+
+const (
+	outputStreamType = "Ljava/io/OutputStream;"
+	writerType       = "Ljava/io/Writer;"
+)
+
 // "java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;)V"
 func initOutputStreamWriter(params []interface{}) interface{} {
+	out, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(out) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "initOutputStreamWriter: OutputStream is null")
+	}
 
-	// Get file path field.
-	fldPath, ok := params[1].(*object.Object).FieldTable[ghelpers.FilePath]
+	// Any other OutputStream is written through its own write methods.
+	fldPath, ok := out.FieldTable[ghelpers.FilePath]
 	if !ok {
+		if _, ok = out.FieldTable[ghelpers.FileHandle]; !ok {
+			params[0].(*object.Object).FieldTable[fieldNameOut] = object.Field{Ftype: outputStreamType, Fvalue: out}
+			return nil
+		}
 		errMsg := "initOutputStreamWriter: OutputStream object lacks a ghelpers.FilePath field"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
@@ -124,7 +147,113 @@ func initOutputStreamWriter(params []interface{}) interface{} {
 	return nil
 }
 
+// "java/io/OutputStreamWriter.<init>(Ljava/io/OutputStream;Ljava/lang/String;)V" and the variants
+// with a Charset and a CharsetEncoder. An encoder's error actions apply to every write.
+func initOutputStreamWriterCharset(params []interface{}) interface{} {
+	var cs *ghelpers.Charset
+	var gerr *ghelpers.GErrBlk
+	malformed, unmappable := ghelpers.CodingReplace, ghelpers.CodingReplace
+	arg, _ := params[2].(*object.Object)
+	switch {
+	case arg != nil && object.IsStringObject(arg):
+		cs, gerr = ghelpers.CharsetFromName(arg)
+	case arg != nil && !object.IsNull(arg) && arg.FieldTable["charset"].Fvalue != nil:
+		cs, malformed, unmappable, gerr = javaNio.CoderFromObject(arg)
+	default:
+		cs, gerr = ghelpers.CharsetFromObject(arg)
+	}
+	if gerr != nil {
+		return gerr
+	}
+
+	if ret := initOutputStreamWriter(params[:2]); ret != nil {
+		return ret
+	}
+	encoder := ghelpers.NewCharEncoder(cs)
+	encoder.Malformed, encoder.Unmappable = malformed, unmappable
+	fld := object.Field{Ftype: types.RawGoPointer, Fvalue: encoder}
+	params[0].(*object.Object).FieldTable[ghelpers.FileEncoder] = fld
+	return nil
+}
+
+// writeChars writes chars to what the writer obj writes to: the file it has open or the Java
+// stream in its "out" field, encoded as obj does, or else the Java writer in that field. The
+// methods of a Java stream or writer run on the frame stack fs.
+func writeChars(fs *list.List, obj *object.Object, chars []rune, caller string) *ghelpers.GErrBlk {
+	var w io.Writer
+	var outBytes []byte
+	if osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+		w = osFile
+	} else if fld, ok := obj.FieldTable[fieldNameOut]; ok {
+		var gerr *ghelpers.GErrBlk
+		if w, gerr = ghelpers.GoWriterWithContext(fs, fld.Fvalue, caller); gerr != nil {
+			return gerr
+		}
+		if fld.Ftype == writerType {
+			units := make([]uint16, len(chars))
+			for ix, ch := range chars {
+				units[ix] = uint16(ch)
+			}
+			outBytes = []byte(string(utf16.Decode(units)))
+		}
+	} else {
+		errMsg := fmt.Sprintf("%s: Writer object lacks a ghelpers.FileHandle field", caller)
+		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	}
+
+	if outBytes == nil {
+		var err error
+		if outBytes, err = ghelpers.WriterEncoder(obj).Encode(chars); err != nil {
+			return err.(*ghelpers.CodingError).GErrBlk()
+		}
+	}
+	if _, err := w.Write(outBytes); err != nil {
+		var streamErr *ghelpers.StreamError
+		if errors.As(err, &streamErr) {
+			return streamErr.GErr
+		}
+		errMsg := fmt.Sprintf("%s: osFile.Write failed, reason: %s", caller, err.Error())
+		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	}
+	return nil
+}
+
+// stringChars returns the UTF-16 chars of a String, which its offsets and lengths count.
+func stringChars(strObj *object.Object) []rune {
+	units := utf16.Encode([]rune(object.GoStringFromStringObject(strObj)))
+	chars := make([]rune, len(units))
+	for ix, unit := range units {
+		chars[ix] = rune(unit)
+	}
+	return chars
+}
+
+// writerOutCall calls flush()V or close()V on the Java stream or writer that the writer obj
+// writes to, and reports whether obj has one. A stream without the method needs no call.
+func writerOutCall(fs *list.List, obj *object.Object, methName string) (interface{}, bool) {
+	out, ok := obj.FieldTable[fieldNameOut].Fvalue.(*object.Object)
+	if !ok {
+		return nil, false
+	}
+	if _, clName := ghelpers.FindInstanceMethod(out, methName, "()V"); clName == "" {
+		return nil, true
+	}
+	if gerr, ok := ghelpers.InvokeMethodOnObject(fs, out, methName, "()V").(*ghelpers.GErrBlk); ok {
+		return gerr, true
+	}
+	return nil, true
+}
+
+// "java/io/OutputStreamWriter.getEncoding()Ljava/lang/String;" -- the historical name of the charset
+func oswGetEncoding(params []interface{}) interface{} {
+	return object.StringObjectFromGoString(ghelpers.WriterEncoder(params[0].(*object.Object)).Charset().HistoricalName)
+}
+
 func oswClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if ret, ok := writerOutCall(fs, params[0].(*object.Object), "close"); ok {
+		return ret
+	}
 
 	// Get file handle.
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
@@ -143,6 +272,10 @@ func oswClose(params []interface{}) interface{} {
 }
 
 func oswFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if ret, ok := writerOutCall(fs, params[0].(*object.Object), "flush"); ok {
+		return ret
+	}
 
 	// Get file handle.
 	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
@@ -162,17 +295,11 @@ func oswFlush(params []interface{}) interface{} {
 
 // "java/io/OutputStreamWriter.write(I)"
 func oswWriteOneChar(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 
 	// Get OutputStream object.
 	obj := params[0].(*object.Object)
 
-	// Get file handle.
-	osFile, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "oswWriteOneChar: OutputStreamWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
-
 	// Get the integer argument.
 	wint, ok := params[1].(int64)
 	if !ok {
@@ -180,15 +307,9 @@ func oswWriteOneChar(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
 
-	// Create a one-byte buffer.
-	buffer := make([]byte, 1)
-	buffer[0] = byte(wint % 256)
-
-	// Write one byte.
-	_, err := osFile.Write(buffer)
-	if err != nil {
-		errMsg := fmt.Sprintf("oswWriteOneChar: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Write the char in the low 16 bits.
+	if gerr := writeChars(fs, obj, []rune{rune(wint & 0xFFFF)}, "oswWriteOneChar"); gerr != nil {
+		return gerr
 	}
 
 	return nil
@@ -196,13 +317,7 @@ func oswWriteOneChar(params []interface{}) interface{} {
 
 // "java/io/OutputStreamWriter.write([CII)I"
 func oswWriteCharBuffer(params []interface{}) interface{} {
-
-	// Get file handle.
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "oswWriteCharBuffer: OutputStreamWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
+	fs, params := ghelpers.SplitContext(params)
 
 	// Get the parameter buffer, offset, and length.
 	intArray, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]int64)
@@ -223,17 +338,15 @@ func oswWriteCharBuffer(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 	}
 
-	// Create and fill a byte buffer.
-	outBytes := make([]byte, length)
+	// Create and fill a char buffer.
+	chars := make([]rune, length)
 	for ii := int64(0); ii < length; ii++ {
-		outBytes[ii] = byte(intArray[offset+ii])
+		chars[ii] = rune(intArray[offset+ii])
 	}
 
-	// Write the byte buffer.
-	_, err := osFile.Write(outBytes)
-	if err != nil {
-		errMsg := fmt.Sprintf("oswWriteCharBuffer: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Write the char buffer.
+	if gerr := writeChars(fs, params[0].(*object.Object), chars, "oswWriteCharBuffer"); gerr != nil {
+		return gerr
 	}
 
 	return nil
@@ -241,21 +354,15 @@ func oswWriteCharBuffer(params []interface{}) interface{} {
 
 // "java/io/OutputStreamWriter.write(Ljava/lang/String;II)I"
 func oswWriteStringBuffer(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 
-	// Get file handle.
-	osFile, ok := params[0].(*object.Object).FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
-	if !ok {
-		errMsg := "oswWriteStringBuffer: OutputStreamWriter object lacks a ghelpers.FileHandle field"
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
-	}
-
-	// Get the parameter string byte array, offset, and length.
-	javaBytes, ok := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
-	if !ok {
+	// Get the parameter string's chars, offset, and length.
+	strObj, ok := params[1].(*object.Object)
+	if !ok || !object.IsStringObject(strObj) {
 		errMsg := "oswWriteStringBuffer: Trouble with value field"
 		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
 	}
-	paramBytes := object.GoByteArrayFromJavaByteArray(javaBytes)
+	chars := stringChars(strObj)
 	offset := params[2].(int64)
 	length := params[3].(int64)
 
@@ -263,23 +370,15 @@ func oswWriteStringBuffer(params []interface{}) interface{} {
 	if length == 0 {
 		return int64(0)
 	}
-	if length < 0 || offset < 0 || length > (int64(len(paramBytes))-offset) {
-		errMsg := fmt.Sprintf("oswWriteStringBuffer: Error in parameters: offset=%d, length=%d, char.array.length=%d",
-			offset, length, len(paramBytes))
+	if length < 0 || offset < 0 || length > (int64(len(chars))-offset) {
+		errMsg := fmt.Sprintf("oswWriteStringBuffer: Error in parameters: offset=%d, length=%d, string.length=%d",
+			offset, length, len(chars))
 		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
 	}
 
-	// Create and fill a byte buffer.
-	outBytes := make([]byte, length)
-	for ii := int64(0); ii < length; ii++ {
-		outBytes[ii] = paramBytes[offset+ii]
-	}
-
-	// Write the byte buffer.
-	_, err := osFile.Write(outBytes)
-	if err != nil {
-		errMsg := fmt.Sprintf("oswWriteStringBuffer: osFile.Write failed, reason: %s", err.Error())
-		return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+	// Write the chars.
+	if gerr := writeChars(fs, params[0].(*object.Object), chars[offset:offset+length], "oswWriteStringBuffer"); gerr != nil {
+		return gerr
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package javaIo
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -159,3 +160,67 @@ func TestOutputStreamWriter_WriteStringBuffer(t *testing.T) {
 
 	_ = oswClose([]interface{}{target})
 }
+
+func TestOutputStreamWriter_Windows1252(t *testing.T) {
+	globals.InitStringPool()
+
+	filePath := filepath.Join(t.TempDir(), "osw_cp1252.txt")
+	outStreamObj := makeOutputStreamObjForFile(t, filePath)
+	target := object.MakeEmptyObject()
+	charsetName := object.StringObjectFromGoString("windows-1252")
+	if res := initOutputStreamWriterCharset([]interface{}{target, outStreamObj, charsetName}); res != nil {
+		t.Fatalf("initOutputStreamWriterCharset returned error: %v", res)
+	}
+
+	// "€ab" from offset 0, length 2: the euro sign is one char
+	if res := oswWriteStringBuffer([]interface{}{target, object.StringObjectFromGoString("€ab"), int64(0), int64(2)}); res != nil {
+		t.Fatalf("oswWriteStringBuffer error: %v", res)
+	}
+	if res := oswWriteOneChar([]interface{}{target, int64(0x4E2D)}); res != nil {
+		t.Fatalf("oswWriteOneChar error: %v", res)
+	}
+	_ = oswClose([]interface{}{target})
+
+	got, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(got) != "\x80a?" {
+		t.Errorf("content mismatch: got % X", got)
+	}
+}
+
+func TestOutputStreamWriter_OverJavaStream(t *testing.T) {
+	setup()
+	classloader.InitMethodArea()
+	Load_Io_ByteArrayOutputStream()
+	Load_Io_OutputStreamWriter()
+
+	baos := newTestByteArrayOutputStream()
+	className := "java/io/OutputStreamWriter"
+	osw := object.MakeEmptyObjectWithClassName(&className)
+	charsetName := object.StringObjectFromGoString("UTF-8")
+	if res := initOutputStreamWriterCharset([]interface{}{osw, baos, charsetName}); res != nil {
+		t.Fatalf("initOutputStreamWriterCharset returned error: %v", res)
+	}
+
+	// A BufferedWriter over the OutputStreamWriter writes through its write methods.
+	bw := object.MakeEmptyObject()
+	if res := bufferedWriterInit([]interface{}{bw, osw}); res != nil {
+		t.Fatalf("bufferedWriterInit returned error: %v", res)
+	}
+	str := object.StringObjectFromGoString("hé\U0001F600")
+	if res := bwWriteStringBuffer([]interface{}{bw, str, int64(0), int64(4)}); res != nil {
+		t.Fatalf("bwWriteStringBuffer returned error: %v", res)
+	}
+	if res := bufferedWriterNewLine([]interface{}{bw}); res != nil {
+		t.Fatalf("bufferedWriterNewLine returned error: %v", res)
+	}
+	if res := bwFlush([]interface{}{bw}); res != nil {
+		t.Fatalf("bwFlush returned error: %v", res)
+	}
+
+	if got := string(byteArrayOutputStreamBytes(baos)); got != "hé\U0001F600\n" {
+		t.Errorf("expected %q, got %q", "hé\U0001F600\n", got)
+	}
+}
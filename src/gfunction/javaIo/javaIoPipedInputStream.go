@@ -0,0 +1,374 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"sync"
+)
+
+// java.io.PipedInputStream is the reading end of a pipe whose writing end is a PipedOutputStream.
+// The pipe is a Go struct, shared with PipedReader and PipedWriter, that holds a circular buffer
+// of bytes or chars. The reading end owns it, in its "pipe" field; the writing end keeps the
+// reading end in its "sink" field. As Java threads are goroutines, a read of an empty pipe and
+// a write to a full pipe wait on the pipe's condition variable.
+//
+// The JDK also throws "Write end dead" and "Read end dead" when the thread last seen at the
+// other end of the pipe has ended. Jacobin does not track which thread uses each end, so a
+// reader whose writer ends without closing the pipe waits until the pipe is closed.
+
+const (
+	fieldNamePipe   = "pipe" // the *pipe of a PipedInputStream or PipedReader
+	fieldNameSink   = "sink" // the PipedInputStream or PipedReader of a PipedOutputStream or PipedWriter
+	defaultPipeSize = 1024
+
+	pipedInputStreamType = "Ljava/io/PipedInputStream;"
+)
+
+type pipe[T any] struct {
+	mu             sync.Mutex
+	cond           *sync.Cond
+	buf            []T
+	next           int // the index of the next element to read
+	count          int // the number of elements in buf
+	connected      bool
+	closedByWriter bool
+	closedByReader bool
+}
+
+func newPipe[T any](size int64) *pipe[T] {
+	p := &pipe[T]{buf: make([]T, size)}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// connect joins the writing end to the pipe, which can be done only once.
+func (p *pipe[T]) connect() *ghelpers.GErrBlk {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.connected {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Already connected")
+	}
+	p.connected = true
+	return nil
+}
+
+// readState checks that the pipe can be read. The caller holds the lock.
+func (p *pipe[T]) readState() *ghelpers.GErrBlk {
+	if !p.connected {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Pipe not connected")
+	}
+	if p.closedByReader {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Pipe closed")
+	}
+	return nil
+}
+
+// writeState checks that the pipe can be written. The caller holds the lock.
+func (p *pipe[T]) writeState() *ghelpers.GErrBlk {
+	if !p.connected {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Pipe not connected")
+	}
+	if p.closedByWriter || p.closedByReader {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Pipe closed")
+	}
+	return nil
+}
+
+// read copies up to len(dst) elements into dst, waiting until there is at least one. It returns
+// -1 if the writing end has been closed and the pipe is empty.
+func (p *pipe[T]) read(dst []T) (int, *ghelpers.GErrBlk) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if gerr := p.readState(); gerr != nil {
+		return 0, gerr
+	}
+	for p.count == 0 {
+		if p.closedByWriter {
+			return -1, nil
+		}
+		p.cond.Wait()
+		if gerr := p.readState(); gerr != nil {
+			return 0, gerr
+		}
+	}
+
+	n := 0
+	for n < len(dst) && p.count > 0 {
+		dst[n] = p.buf[p.next]
+		p.next = (p.next + 1) % len(p.buf)
+		p.count--
+		n++
+	}
+	p.cond.Broadcast()
+	return n, nil
+}
+
+// write adds the elements of src to the pipe, waiting while it is full.
+func (p *pipe[T]) write(src []T) *ghelpers.GErrBlk {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, v := range src {
+		if gerr := p.writeState(); gerr != nil {
+			return gerr
+		}
+		for p.count == len(p.buf) {
+			p.cond.Broadcast()
+			p.cond.Wait()
+			if gerr := p.writeState(); gerr != nil {
+				return gerr
+			}
+		}
+		p.buf[(p.next+p.count)%len(p.buf)] = v
+		p.count++
+	}
+	p.cond.Broadcast()
+	return nil
+}
+
+// available returns the number of elements that can be read without waiting.
+func (p *pipe[T]) available() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.count
+}
+
+// wake lets a waiting reader see what has been written.
+func (p *pipe[T]) wake() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+// closeWriter marks the end of the data: once the pipe is empty, reads return -1.
+func (p *pipe[T]) closeWriter() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closedByWriter = true
+	p.cond.Broadcast()
+}
+
+// closeReader discards what is in the pipe. Later writes fail.
+func (p *pipe[T]) closeReader() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closedByReader = true
+	p.count = 0
+	p.cond.Broadcast()
+}
+
+// pipeOf returns the pipe owned by the reading end of a pipe.
+func pipeOf[T any](this any, caller string) (*pipe[T], *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	p, ok := self.FieldTable[fieldNamePipe].Fvalue.(*pipe[T])
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": stream was not initialized")
+	}
+	return p, nil
+}
+
+// sinkPipeOf returns the pipe of the reading end that the writing end of a pipe is connected to.
+func sinkPipeOf[T any](this any, caller string) (*pipe[T], *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	sink, ok := self.FieldTable[fieldNameSink].Fvalue.(*object.Object)
+	if !ok || object.IsNull(sink) {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "Pipe not connected")
+	}
+	return pipeOf[T](sink, caller)
+}
+
+// connectPipe connects the writing end of a pipe, writer, to its reading end, reader, whose
+// type is sinkType.
+func connectPipe[T any](writer, reader any, sinkType, caller string) *ghelpers.GErrBlk {
+	w, ok := writer.(*object.Object)
+	r, rok := reader.(*object.Object)
+	if !ok || object.IsNull(w) || !rok || object.IsNull(r) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": stream is null")
+	}
+	if sink, ok := w.FieldTable[fieldNameSink].Fvalue.(*object.Object); ok && !object.IsNull(sink) {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Already connected")
+	}
+	p, gerr := pipeOf[T](r, caller)
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := p.connect(); gerr != nil {
+		return gerr
+	}
+	w.FieldTable[fieldNameSink] = object.Field{Ftype: sinkType, Fvalue: r}
+	return nil
+}
+
+func Load_Io_PipedInputStream() {
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.<init>(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.<init>(Ljava/io/PipedOutputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.<init>(Ljava/io/PipedOutputStream;I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  pipedInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.available()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedInputStreamAvailable,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedInputStreamClose,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.connect(Ljava/io/PipedOutputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedInputStreamConnect,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedInputStreamRead,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.read([B)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedInputStreamReadBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.read([BII)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pipedInputStreamReadBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedInputStream.receive(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  ghelpers.TrapProtected,
+		}
+}
+
+// java/io/PipedInputStream.<init>()V, <init>(I)V, <init>(Ljava/io/PipedOutputStream;)V, and
+// <init>(Ljava/io/PipedOutputStream;I)V
+func pipedInputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	size := int64(defaultPipeSize)
+	if n, ok := params[len(params)-1].(int64); ok {
+		size = n
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Pipe Size <= 0")
+		}
+	}
+	self.FieldTable[fieldNamePipe] = object.Field{Ftype: types.RawGoPointer, Fvalue: newPipe[types.JavaByte](size)}
+	if len(params) > 1 {
+		if src, ok := params[1].(*object.Object); ok {
+			return connectPipe[types.JavaByte](src, self, pipedInputStreamType, "PipedInputStream")
+		}
+	}
+	return nil
+}
+
+// java/io/PipedInputStream.connect(Ljava/io/PipedOutputStream;)V
+func pipedInputStreamConnect(params []interface{}) interface{} {
+	if gerr := connectPipe[types.JavaByte](params[1], params[0], pipedInputStreamType, "PipedInputStream.connect"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedInputStream.read()I -- waits for a byte; returns -1 once the writer has closed
+// the pipe and it is empty
+func pipedInputStreamRead(params []interface{}) interface{} {
+	p, gerr := pipeOf[types.JavaByte](params[0], "PipedInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	var b [1]types.JavaByte
+	n, gerr := p.read(b[:])
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	return int64(uint8(b[0]))
+}
+
+// java/io/PipedInputStream.read([B)I and read([BII)I -- waits for the first byte, then reads what
+// is in the pipe
+func pipedInputStreamReadBytes(params []interface{}) interface{} {
+	p, gerr := pipeOf[types.JavaByte](params[0], "PipedInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "PipedInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	n, gerr := p.read(jbytes[off : off+length])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(n)
+}
+
+// java/io/PipedInputStream.available()I
+func pipedInputStreamAvailable(params []interface{}) interface{} {
+	p, gerr := pipeOf[types.JavaByte](params[0], "PipedInputStream.available")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(p.available())
+}
+
+// java/io/PipedInputStream.close()V
+func pipedInputStreamClose(params []interface{}) interface{} {
+	p, gerr := pipeOf[types.JavaByte](params[0], "PipedInputStream.close")
+	if gerr != nil {
+		return gerr
+	}
+	p.closeReader()
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func TestPipedStreams_WriterGoroutine(t *testing.T) {
+	setup()
+
+	pis := object.MakeEmptyObject()
+	if ret := pipedInputStreamInit([]any{pis, int64(4)}); ret != nil {
+		t.Fatalf("PipedInputStream.<init> failed: %v", ret)
+	}
+	pos := object.MakeEmptyObject()
+	if ret := pipedOutputStreamInit([]any{pos, pis}); ret != nil {
+		t.Fatalf("PipedOutputStream.<init> failed: %v", ret)
+	}
+
+	// More bytes than the pipe holds, so the writer waits for the reader.
+	data := []byte("hello, pipe")
+	go func() {
+		arr := object.Make1DimArray(object.T_BYTE, 0)
+		arr.FieldTable["value"] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoByteArray(data)}
+		_ = pipedOutputStreamWriteBytes([]any{pos, arr})
+		_ = pipedOutputStreamClose([]any{pos})
+	}()
+
+	var got []byte
+	for {
+		c := pipedInputStreamRead([]any{pis})
+		if c == int64(-1) {
+			break
+		}
+		b, ok := c.(int64)
+		if !ok {
+			t.Fatalf("read failed: %v", c)
+		}
+		got = append(got, byte(b))
+	}
+	if string(got) != string(data) {
+		t.Errorf("expected %q, observed %q", data, got)
+	}
+}
+
+func TestPipedStreams_ConnectAndClose(t *testing.T) {
+	setup()
+
+	pis := object.MakeEmptyObject()
+	_ = pipedInputStreamInit([]any{pis})
+	ret := pipedInputStreamRead([]any{pis})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ErrMsg != "Pipe not connected" {
+		t.Errorf("read of an unconnected pipe: expected IOException, observed %v", ret)
+	}
+
+	pos := object.MakeEmptyObject()
+	_ = pipedOutputStreamInit([]any{pos})
+	if ret := pipedInputStreamConnect([]any{pis, pos}); ret != nil {
+		t.Fatalf("connect failed: %v", ret)
+	}
+	other := object.MakeEmptyObject()
+	_ = pipedOutputStreamInit([]any{other})
+	ret = pipedOutputStreamConnect([]any{other, pis})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ErrMsg != "Already connected" {
+		t.Errorf("second connect: expected IOException, observed %v", ret)
+	}
+
+	_ = pipedInputStreamClose([]any{pis})
+	ret = pipedOutputStreamWrite([]any{pos, int64(1)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IOException || gerr.ErrMsg != "Pipe closed" {
+		t.Errorf("write after the reader closed: expected IOException, observed %v", ret)
+	}
+
+	ret = pipedInputStreamInit([]any{object.MakeEmptyObject(), int64(0)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("pipe size 0: expected IllegalArgumentException, observed %v", ret)
+	}
+}
+
+func TestPipedReaderWriter(t *testing.T) {
+	setup()
+
+	pr := object.MakeEmptyObject()
+	_ = pipedReaderInit([]any{pr})
+	pw := object.MakeEmptyObject()
+	if ret := pipedWriterInit([]any{pw, pr}); ret != nil {
+		t.Fatalf("PipedWriter.<init> failed: %v", ret)
+	}
+	if ret := pipedWriterWriteString([]any{pw, object.StringObjectFromGoString("xyzé"), int64(1), int64(3)}); ret != nil {
+		t.Fatalf("write failed: %v", ret)
+	}
+	if ready := pipedReaderReady([]any{pr}); ready != types.JavaBoolTrue {
+		t.Errorf("ready: expected true, observed %v", ready)
+	}
+	_ = pipedWriterClose([]any{pw})
+
+	arr := object.Make1DimArray(object.T_CHAR, 8)
+	if n := pipedReaderReadChars([]any{pr, arr, int64(0), int64(8)}); n != int64(3) {
+		t.Errorf("read: expected 3 chars, observed %v", n)
+	}
+	chars := arr.FieldTable["value"].Fvalue.([]int64)
+	if chars[0] != 'y' || chars[1] != 'z' || chars[2] != 'é' {
+		t.Errorf("read: expected \"yzé\", observed %v", chars[:3])
+	}
+	if c := pipedReaderRead([]any{pr}); c != int64(-1) {
+		t.Errorf("read after the writer closed: expected -1, observed %v", c)
+	}
+}
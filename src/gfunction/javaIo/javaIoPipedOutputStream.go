@@ -0,0 +1,134 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.io.PipedOutputStream is the writing end of a pipe. See javaIoPipedInputStream.go.
+
+func Load_Io_PipedOutputStream() {
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedOutputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.<init>(Ljava/io/PipedInputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedOutputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedOutputStreamClose,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.connect(Ljava/io/PipedInputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedOutputStreamConnect,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedOutputStreamFlush,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedOutputStreamWrite,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.write([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedOutputStreamWriteBytes,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedOutputStream.write([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pipedOutputStreamWriteBytes,
+		}
+}
+
+// java/io/PipedOutputStream.<init>()V and <init>(Ljava/io/PipedInputStream;)V
+func pipedOutputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	self.FieldTable[fieldNameSink] = object.Field{Ftype: pipedInputStreamType, Fvalue: object.Null}
+	if len(params) > 1 {
+		return pipedOutputStreamConnect(params)
+	}
+	return nil
+}
+
+// java/io/PipedOutputStream.connect(Ljava/io/PipedInputStream;)V
+func pipedOutputStreamConnect(params []interface{}) interface{} {
+	if gerr := connectPipe[types.JavaByte](params[0], params[1], pipedInputStreamType, "PipedOutputStream.connect"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedOutputStream.write(I)V -- writes the low-order byte, waiting while the pipe is full
+func pipedOutputStreamWrite(params []interface{}) interface{} {
+	p, gerr := sinkPipeOf[types.JavaByte](params[0], "PipedOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := p.write([]types.JavaByte{types.JavaByte(params[1].(int64))}); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedOutputStream.write([B)V and write([BII)V
+func pipedOutputStreamWriteBytes(params []interface{}) interface{} {
+	p, gerr := sinkPipeOf[types.JavaByte](params[0], "PipedOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "PipedOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := p.write(jbytes[off : off+length]); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedOutputStream.flush()V -- wakes a reader waiting for bytes
+func pipedOutputStreamFlush(params []interface{}) interface{} {
+	if p, gerr := sinkPipeOf[types.JavaByte](params[0], "PipedOutputStream.flush"); gerr == nil {
+		p.wake()
+	}
+	return nil
+}
+
+// java/io/PipedOutputStream.close()V -- once what was written has been read, the reader gets -1
+func pipedOutputStreamClose(params []interface{}) interface{} {
+	if p, gerr := sinkPipeOf[types.JavaByte](params[0], "PipedOutputStream.close"); gerr == nil {
+		p.closeWriter()
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.io.PipedReader is the reading end of a pipe of chars whose writing end is a PipedWriter.
+// It works like PipedInputStream; see javaIoPipedInputStream.go. Chars are kept as int64s, as
+// they are in char arrays.
+
+const pipedReaderType = "Ljava/io/PipedReader;"
+
+func Load_Io_PipedReader() {
+
+	ghelpers.MethodSignatures["java/io/PipedReader.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedReaderInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.<init>(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedReaderInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.<init>(Ljava/io/PipedWriter;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedReaderInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.<init>(Ljava/io/PipedWriter;I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  pipedReaderInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedReaderClose,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.connect(Ljava/io/PipedWriter;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedReaderConnect,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedReaderRead,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.read([C)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedReaderReadChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.read([CII)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pipedReaderReadChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.ready()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedReaderReady,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedReader.skip(J)J"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedReaderSkip,
+		}
+}
+
+// java/io/PipedReader.<init>()V, <init>(I)V, <init>(Ljava/io/PipedWriter;)V, and
+// <init>(Ljava/io/PipedWriter;I)V
+func pipedReaderInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	size := int64(defaultPipeSize)
+	if n, ok := params[len(params)-1].(int64); ok {
+		size = n
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Pipe size <= 0")
+		}
+	}
+	self.FieldTable[fieldNamePipe] = object.Field{Ftype: types.RawGoPointer, Fvalue: newPipe[int64](size)}
+	if len(params) > 1 {
+		if src, ok := params[1].(*object.Object); ok {
+			return connectPipe[int64](src, self, pipedReaderType, "PipedReader")
+		}
+	}
+	return nil
+}
+
+// java/io/PipedReader.connect(Ljava/io/PipedWriter;)V
+func pipedReaderConnect(params []interface{}) interface{} {
+	if gerr := connectPipe[int64](params[1], params[0], pipedReaderType, "PipedReader.connect"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedReader.read()I -- waits for a char; returns -1 once the writer has closed the
+// pipe and it is empty
+func pipedReaderRead(params []interface{}) interface{} {
+	p, gerr := pipeOf[int64](params[0], "PipedReader.read")
+	if gerr != nil {
+		return gerr
+	}
+	var ch [1]int64
+	n, gerr := p.read(ch[:])
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	return ch[0]
+}
+
+// java/io/PipedReader.read([C)I and read([CII)I -- waits for the first char, then reads what is
+// in the pipe
+func pipedReaderReadChars(params []interface{}) interface{} {
+	p, gerr := pipeOf[int64](params[0], "PipedReader.read")
+	if gerr != nil {
+		return gerr
+	}
+	arrObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "PipedReader.read: char array is null")
+	}
+	chars, _ := arrObj.FieldTable["value"].Fvalue.([]int64)
+	offset, length := int64(0), int64(len(chars))
+	if len(params) == 4 {
+		offset, length = params[2].(int64), params[3].(int64)
+		if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", offset, offset, length, len(chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	n, gerr := p.read(chars[offset : offset+length])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(n)
+}
+
+// java/io/PipedReader.ready()Z -- whether there are chars in the pipe
+func pipedReaderReady(params []interface{}) interface{} {
+	p, gerr := pipeOf[int64](params[0], "PipedReader.ready")
+	if gerr != nil {
+		return gerr
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if gerr := p.readState(); gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(p.count > 0)
+}
+
+// java/io/PipedReader.skip(J)J -- reads and discards up to n chars, waiting as read does
+func pipedReaderSkip(params []interface{}) interface{} {
+	p, gerr := pipeOf[int64](params[0], "PipedReader.skip")
+	if gerr != nil {
+		return gerr
+	}
+	n := params[1].(int64)
+	if n < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "skip value is negative")
+	}
+	skipped := int64(0)
+	buf := make([]int64, min(n, defaultPipeSize))
+	for skipped < n {
+		count, gerr := p.read(buf[:min(n-skipped, int64(len(buf)))])
+		if gerr != nil {
+			return gerr
+		}
+		if count < 0 {
+			break
+		}
+		skipped += int64(count)
+	}
+	return skipped
+}
+
+// java/io/PipedReader.close()V
+func pipedReaderClose(params []interface{}) interface{} {
+	p, gerr := pipeOf[int64](params[0], "PipedReader.close")
+	if gerr != nil {
+		return gerr
+	}
+	p.closeReader()
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+)
+
+// java.io.PipedWriter is the writing end of a pipe of chars. See javaIoPipedInputStream.go.
+// All of Writer's write methods are implemented here, as Writer's own lock on the stream
+// depends on a constructor that Jacobin does not run.
+
+func Load_Io_PipedWriter() {
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.<init>(Ljava/io/PipedReader;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedWriterClose,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.connect(Ljava/io/PipedReader;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedWriterConnect,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pipedWriterFlush,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedWriterWrite,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.write([C)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.write([CII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pipedWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.write(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pipedWriterWriteString,
+		}
+
+	ghelpers.MethodSignatures["java/io/PipedWriter.write(Ljava/lang/String;II)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pipedWriterWriteString,
+		}
+}
+
+// java/io/PipedWriter.<init>()V and <init>(Ljava/io/PipedReader;)V
+func pipedWriterInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	self.FieldTable[fieldNameSink] = object.Field{Ftype: pipedReaderType, Fvalue: object.Null}
+	if len(params) > 1 {
+		return pipedWriterConnect(params)
+	}
+	return nil
+}
+
+// java/io/PipedWriter.connect(Ljava/io/PipedReader;)V
+func pipedWriterConnect(params []interface{}) interface{} {
+	if gerr := connectPipe[int64](params[0], params[1], pipedReaderType, "PipedWriter.connect"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// pipedWriterPut writes chars to the pipe of a PipedWriter, waiting while it is full.
+func pipedWriterPut(this any, chars []int64) interface{} {
+	p, gerr := sinkPipeOf[int64](this, "PipedWriter.write")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := p.write(chars); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/io/PipedWriter.write(I)V -- writes the char in the low-order 16 bits of the argument
+func pipedWriterWrite(params []interface{}) interface{} {
+	return pipedWriterPut(params[0], []int64{int64(uint16(params[1].(int64)))})
+}
+
+// java/io/PipedWriter.write([C)V and write([CII)V
+func pipedWriterWriteChars(params []interface{}) interface{} {
+	arrObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "PipedWriter.write: char array is null")
+	}
+	chars, _ := arrObj.FieldTable["value"].Fvalue.([]int64)
+	if len(params) == 4 {
+		offset, length := params[2].(int64), params[3].(int64)
+		if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", offset, offset, length, len(chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+		chars = chars[offset : offset+length]
+	}
+	return pipedWriterPut(params[0], chars)
+}
+
+// java/io/PipedWriter.write(Ljava/lang/String;)V and write(Ljava/lang/String;II)V
+func pipedWriterWriteString(params []interface{}) interface{} {
+	utf16Chars, gerr := stringArgChars(params, "PipedWriter.write")
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 4 {
+		offset, length := params[2].(int64), params[3].(int64)
+		if offset < 0 || length < 0 || offset+length > int64(len(utf16Chars)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", offset, offset, length, len(utf16Chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+		utf16Chars = utf16Chars[offset : offset+length]
+	}
+	chars := make([]int64, len(utf16Chars))
+	for ix, c := range utf16Chars {
+		chars[ix] = int64(c)
+	}
+	return pipedWriterPut(params[0], chars)
+}
+
+// java/io/PipedWriter.flush()V -- wakes a reader waiting for chars. As in the JDK, flushing a
+// pipe the reader has closed is an IOException.
+func pipedWriterFlush(params []interface{}) interface{} {
+	p, gerr := sinkPipeOf[int64](params[0], "PipedWriter.flush")
+	if gerr != nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closedByReader || p.closedByWriter {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Pipe closed")
+	}
+	p.cond.Broadcast()
+	return nil
+}
+
+// java/io/PipedWriter.close()V -- once what was written has been read, the reader gets -1
+func pipedWriterClose(params []interface{}) interface{} {
+	if p, gerr := sinkPipeOf[int64](params[0], "PipedWriter.close"); gerr == nil {
+		p.closeWriter()
+	}
+	return nil
+}
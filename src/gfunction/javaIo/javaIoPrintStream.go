@@ -7,16 +7,20 @@
 package javaIo
 
 import (
+	"container/list"
 	"fmt"
 	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/gfunction/javaLang"
 	"jacobin/src/gfunction/javaMath"
+	"jacobin/src/gfunction/javaNio"
 	"jacobin/src/gfunction/misc"
 	"jacobin/src/object"
 	"jacobin/src/types"
+	"os"
 	"strconv"
+	"unicode/utf16"
 )
 
 /*
@@ -38,28 +42,87 @@ import (
 
 func Load_Io_PrintStream() {
 
-	ghelpers.MethodSignatures["java/io/PrintStream.append(C)Ljava/io/PrintStream;"] =
+	ghelpers.MethodSignatures["java/io/PrintStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	// constructors: text is encoded in the charset given, else in the default charset
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/OutputStream;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  printstreamInitStream,
 		}
 
-	ghelpers.MethodSignatures["java/io/PrintStream.append(Ljava/lang/CharSequence;)Ljava/io/PrintStream;"] =
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/OutputStream;Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/OutputStream;ZLjava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/OutputStream;ZLjava/nio/charset/Charset;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/lang/String;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/File;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.<init>(Ljava/io/File;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.append(C)Ljava/io/PrintStream;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printstreamAppendChar,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintStream.append(Ljava/lang/CharSequence;)Ljava/io/PrintStream;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printstreamAppend,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.append(Ljava/lang/CharSequence;II)Ljava/io/PrintStream;"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   3,
+			GFunction:    printstreamAppend,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.charset()Ljava/nio/charset/Charset;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  printstreamCharset,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.checkError()Z"] =
@@ -70,20 +133,23 @@ func Load_Io_PrintStream() {
 
 	ghelpers.MethodSignatures["java/io/PrintStream.close()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    printstreamClose,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.flush()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  PrintFlush,
+			ParamSlots:   0,
+			GFunction:    PrintFlush,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.format(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintStream;"] =
 		ghelpers.GMeth{ // PrintStream.format(String, Object[]) is an old-fashioned printf
-			ParamSlots: 2,
-			GFunction:  Printf,
+			ParamSlots:   2,
+			GFunction:    Printf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.format(Ljava/util/Locale;Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintStream;"] =
@@ -94,116 +160,135 @@ func Load_Io_PrintStream() {
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(B)V"] = // print byte
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintBIS,
+			ParamSlots:   1,
+			GFunction:    PrintBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(C)V"] = // print char
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintChar,
+			ParamSlots:   1,
+			GFunction:    PrintChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(D)V"] = // print double
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintDouble,
+			ParamSlots:   1,
+			GFunction:    PrintDouble,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(F)V"] = // print float
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintFloat,
+			ParamSlots:   1,
+			GFunction:    PrintFloat,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(I)V"] = // print int
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintBIS,
+			ParamSlots:   1,
+			GFunction:    PrintBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(J)V"] = // print long
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintLong,
+			ParamSlots:   1,
+			GFunction:    PrintLong,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(Ljava/lang/Object;)V"] = // print object
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(Ljava/lang/String;)V"] = // print string
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintString,
+			ParamSlots:   1,
+			GFunction:    PrintString,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(S)V"] = // print short
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintBIS,
+			ParamSlots:   1,
+			GFunction:    PrintBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print(Z)V"] = // print boolean
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintBoolean,
+			ParamSlots:   1,
+			GFunction:    PrintBoolean,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([B)V"] = // print byte array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([C)V"] = // print char array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([D)V"] = // print double array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([F)V"] = // print float array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([I)V"] = // print int array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([J)V"] = // print long array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([S)V"] = // print int array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.print([Z)V"] = // print boolean array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintObject,
+			ParamSlots:   1,
+			GFunction:    PrintObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.printf(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintStream;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  Printf,
+			ParamSlots:   2,
+			GFunction:    Printf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.printf(Ljava/util/Locale;Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintStream;"] =
@@ -214,151 +299,383 @@ func Load_Io_PrintStream() {
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println()V"] = // println void
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  PrintlnV,
+			ParamSlots:   0,
+			GFunction:    PrintlnV,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(B)V"] = // println byte
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnBIS,
+			ParamSlots:   1,
+			GFunction:    PrintlnBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(C)V"] = // println char
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnChar,
+			ParamSlots:   1,
+			GFunction:    PrintlnChar,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(D)V"] = // println double
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnDouble,
+			ParamSlots:   1,
+			GFunction:    PrintlnDouble,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(F)V"] = // println float
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnFloat,
+			ParamSlots:   1,
+			GFunction:    PrintlnFloat,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(I)V"] = // println int
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnBIS,
+			ParamSlots:   1,
+			GFunction:    PrintlnBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(J)V"] = // println long
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnLong,
+			ParamSlots:   1,
+			GFunction:    PrintlnLong,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(Ljava/lang/Object;)V"] = // println object
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(Ljava/lang/String;)V"] = // println string
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnString,
+			ParamSlots:   1,
+			GFunction:    PrintlnString,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(S)V"] = // println short
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnBIS,
+			ParamSlots:   1,
+			GFunction:    PrintlnBIS,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println(Z)V"] = // println boolean
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnBoolean,
+			ParamSlots:   1,
+			GFunction:    PrintlnBoolean,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([B)V"] = // println byte array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([C)V"] = // println char array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([D)V"] = // println double array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([F)V"] = // println float array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([I)V"] = // println int array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([J)V"] = // println long array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([S)V"] = // println int array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.println([Z)V"] = // println boolean array
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  PrintlnObject,
+			ParamSlots:   1,
+			GFunction:    PrintlnObject,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.write(I)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    printstreamWriteByte,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.write([B)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  printstreamWriteFromByteArray,
+			ParamSlots:   1,
+			GFunction:    printstreamWriteFromByteArray,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.write([BII)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  printstreamWriteFromByteArray,
+			ParamSlots:   3,
+			GFunction:    printstreamWriteFromByteArray,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/io/PrintStream.writeBytes([B)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  printstreamWriteFromByteArray,
+			ParamSlots:   1,
+			GFunction:    printstreamWriteFromByteArray,
+			NeedsContext: true,
 		}
 
 }
 
-// "java/io/PrintStream.flush()V"
-func PrintFlush(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
+// printStreamWriter returns the Go writer that a PrintStream prints text to. ps is either a Go writer,
+// such as the *os.File of the default System.out and System.err, or a PrintStream object made by
+// one of the constructors below, which holds its OutputStream in the "out" field or, if it
+// opened a file itself, a FileHandle. Text is encoded in the charset given to the constructor.
+func printStreamWriter(fs *list.List, ps any, caller string) (io.Writer, *ghelpers.GErrBlk) {
+	writer, gerr := printStreamTarget(fs, ps, caller)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if obj, ok := ps.(*object.Object); ok && !object.IsNull(obj) {
+		if _, ok := obj.FieldTable[ghelpers.FileCharset]; ok {
+			return ghelpers.EncodingWriter(writer, ghelpers.WriterEncoder(obj)), nil
+		}
+	}
+	return writer, nil
+}
+
+// printStreamTarget returns the Go writer underneath a PrintStream, which the write methods
+// use to pass bytes through unchanged.
+func printStreamTarget(fs *list.List, ps any, caller string) (io.Writer, *ghelpers.GErrBlk) {
+	obj, ok := ps.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return ghelpers.GoWriterWithContext(fs, ps, caller)
+	}
+	if out, ok := obj.FieldTable["out"]; ok {
+		return ghelpers.GoWriterWithContext(fs, out.Fvalue, caller)
+	}
+	if f, ok := obj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+		return f, nil
+	}
+	errMsg := fmt.Sprintf("%s: stream has no target", caller)
+	return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+}
+
+// printstreamSetCharset records the charset argument of a constructor, which is either
+// a charset name or a Charset object.
+func printstreamSetCharset(self *object.Object, arg any) *ghelpers.GErrBlk {
+	var cs *ghelpers.Charset
+	var gerr *ghelpers.GErrBlk
+	if obj, ok := arg.(*object.Object); ok && object.IsStringObject(obj) {
+		cs, gerr = ghelpers.CharsetFromName(obj)
+	} else {
+		cs, gerr = ghelpers.CharsetFromObject(arg)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	self.FieldTable[ghelpers.FileCharset] = object.Field{Ftype: types.RawGoPointer, Fvalue: cs}
+	return nil
+}
+
+// java/io/PrintStream.charset()Ljava/nio/charset/Charset;
+func printstreamCharset(params []interface{}) interface{} {
+	if self, ok := params[0].(*object.Object); ok && !object.IsNull(self) {
+		if cs, ok := self.FieldTable[ghelpers.FileCharset].Fvalue.(*ghelpers.Charset); ok {
+			return javaNio.CharsetObject(cs)
+		}
+	}
+	return javaNio.CharsetObject(ghelpers.DefaultCharset())
+}
+
+// java/io/PrintStream.<init>(Ljava/io/OutputStream;)V and the variants with autoflush and charset.
+// Nothing is buffered here, so autoflush has no effect.
+func printstreamInitStream(params []interface{}) interface{} {
+	self, ok := params[0].(*object.Object)
 	if !ok {
-		errMsg := fmt.Sprintf("PrintChar: Expected io.Writer, observed %T", params[0])
+		errMsg := fmt.Sprintf("printstreamInitStream: Expected PrintStream object, observed %T", params[0])
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
 	}
+	if params[1] == nil || object.IsNull(params[1]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "printstreamInitStream: Null output stream")
+	}
+	self.FieldTable["out"] = object.Field{Ftype: "Ljava/io/OutputStream;", Fvalue: params[1]}
+	if len(params) > 3 {
+		if gerr := printstreamSetCharset(self, params[3]); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// java/io/PrintStream.<init>(Ljava/lang/String;)V and <init>(Ljava/io/File;)V, with or without a charset name.
+// The file is created, or truncated if it exists.
+func printstreamInitFile(params []interface{}) interface{} {
+	self, ok := params[0].(*object.Object)
+	if !ok {
+		errMsg := fmt.Sprintf("printstreamInitFile: Expected PrintStream object, observed %T", params[0])
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	arg, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arg) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "printstreamInitFile: Null file name")
+	}
+
+	var pathStr string
+	if object.IsStringObject(arg) {
+		pathStr = object.GoStringFromStringObject(arg)
+	} else {
+		path, ok := arg.FieldTable[ghelpers.FilePath].Fvalue.([]types.JavaByte)
+		if !ok {
+			errMsg := "printstreamInitFile: File object lacks a ghelpers.FilePath field"
+			return ghelpers.GetGErrBlk(excNames.IOException, errMsg)
+		}
+		pathStr = object.GoStringFromJavaByteArray(path)
+	}
+
+	if len(params) > 2 {
+		if gerr := printstreamSetCharset(self, params[2]); gerr != nil {
+			return gerr
+		}
+	}
+
+	osFile, err := os.OpenFile(pathStr, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, ghelpers.CreateFilePermissions)
+	if err != nil {
+		errMsg := fmt.Sprintf("printstreamInitFile: os.OpenFile(%s) failed, reason: %s", pathStr, err.Error())
+		return ghelpers.GetGErrBlk(excNames.FileNotFoundException, errMsg)
+	}
+	self.FieldTable[ghelpers.FilePath] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoString(pathStr)}
+	self.FieldTable[ghelpers.FileHandle] = object.Field{Ftype: ghelpers.FileHandle, Fvalue: osFile}
+	return nil
+}
+
+// java/io/PrintStream.close()V
+// Closes the file or stream underneath. The standard output and error streams are left open.
+func printstreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self, ok := params[0].(*object.Object)
+	if !ok {
+		return nil // System.out or System.err
+	}
+	if osFile, ok := self.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+		_ = osFile.Close()
+		delete(self.FieldTable, ghelpers.FileHandle)
+		return nil
+	}
+	if out, ok := self.FieldTable["out"].Fvalue.(*object.Object); ok && !object.IsNull(out) {
+		ret := ghelpers.InvokeMethodOnObject(fs, out, "close", "()V")
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// java/io/PrintStream.write(I)V -- writes the low-order byte of the argument
+func printstreamWriteByte(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamTarget(fs, params[0], "printstreamWriteByte")
+	if gerr != nil {
+		return gerr
+	}
+	_, _ = writer.Write([]byte{byte(params[1].(int64))})
+	return nil
+}
+
+// java/io/PrintStream.append(C)Ljava/io/PrintStream;
+func printstreamAppendChar(params []interface{}) interface{} {
+	if ret := PrintChar(params); ret != nil {
+		return ret
+	}
+	_, params = ghelpers.SplitContext(params)
+	return params[0]
+}
+
+// java/io/PrintStream.append(Ljava/lang/CharSequence;)Ljava/io/PrintStream;
+// java/io/PrintStream.append(Ljava/lang/CharSequence;II)Ljava/io/PrintStream;
+func printstreamAppend(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "printstreamAppend")
+	if gerr != nil {
+		return gerr
+	}
+	str, gerr := charSequenceToGoString(fs, params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 4 { // start and end index the chars of the sequence, not its UTF-8 bytes
+		chars := javaChars(str)
+		start, end := params[2].(int64), params[3].(int64)
+		if start < 0 || end < start || end > int64(len(chars)) {
+			errMsg := fmt.Sprintf("start %d, end %d, length %d", start, end, len(chars))
+			return ghelpers.GetGErrBlk(excNames.StringIndexOutOfBoundsException, errMsg)
+		}
+		str = string(utf16.Decode(chars[start:end]))
+	}
+	fmt.Fprint(writer, str)
+	return params[0]
+}
+
+// charSequenceToGoString returns the text of a CharSequence argument, which is "null" for a null
+// reference, as append() requires.
+func charSequenceToGoString(fs *list.List, arg any) (string, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return types.NullString, nil
+	}
+	if object.IsStringObject(obj) {
+		return object.GoStringFromStringObject(obj), nil
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, obj, "toString", "()Ljava/lang/String;")
+	switch r := ret.(type) {
+	case *object.Object:
+		return object.GoStringFromStringObject(r), nil
+	case *ghelpers.GErrBlk:
+		return "", r
+	}
+	return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "charSequenceToGoString: toString() failed")
+}
+
+// "java/io/PrintStream.flush()V"
+func PrintFlush(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamTarget(fs, params[0], "PrintFlush")
+	if gerr != nil {
+		return gerr
+	}
 	if f, ok := writer.(interface{ Flush() error }); ok {
 		_ = f.Flush()
 	}
@@ -368,10 +685,10 @@ func PrintFlush(params []interface{}) interface{} {
 // java/io/PrintStream.write([B)V
 // java/io/PrintStream.write([BII)V
 func printstreamWriteFromByteArray(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("java/io/PrintStream.write: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamTarget(fs, params[0], "java/io/PrintStream.write")
+	if gerr != nil {
+		return gerr
 	}
 
 	byteArrayObj, ok := params[1].(*object.Object)
@@ -411,10 +728,10 @@ func printstreamWriteFromByteArray(params []interface{}) interface{} {
 // PrintlnV = java/io/Prinstream.println() -- println() prints a newline (V = void)
 // "java/io/PrintStream.println()V"
 func PrintlnV(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnV: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnV")
+	if gerr != nil {
+		return gerr
 	}
 	fmt.Fprintln(writer, "")
 	return nil
@@ -422,10 +739,10 @@ func PrintlnV(params []interface{}) interface{} {
 
 // "java/io/PrintStream.println(C)V"
 func PrintlnChar(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnChar: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnChar")
+	if gerr != nil {
+		return gerr
 	}
 	bb := byte(params[1].(int64))
 	fmt.Fprintln(writer, string(bb))
@@ -436,10 +753,10 @@ func PrintlnChar(params []interface{}) interface{} {
 // "java/io/PrintStream.println(I)V"
 // "java/io/PrintStream.println(S)V"
 func PrintlnBIS(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnBIS: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnBIS")
+	if gerr != nil {
+		return gerr
 	}
 	intToPrint, ok := params[1].(int64) // contains an int
 	if !ok {
@@ -451,10 +768,10 @@ func PrintlnBIS(params []interface{}) interface{} {
 
 // "java/io/PrintStream.println(Z)V"
 func PrintlnBoolean(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnBoolean: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnBoolean")
+	if gerr != nil {
+		return gerr
 	}
 	var boolToPrint bool
 	boolAsInt64 := params[1].(int64) // contains an int64
@@ -469,10 +786,10 @@ func PrintlnBoolean(params []interface{}) interface{} {
 
 // "java/io/PrintStream.println(J)V"
 func PrintlnLong(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnLong: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnLong")
+	if gerr != nil {
+		return gerr
 	}
 	longToPrint := params[1].(int64) // contains to an int64--the equivalent of a Java long
 	fmt.Fprintln(writer, longToPrint)
@@ -481,10 +798,10 @@ func PrintlnLong(params []interface{}) interface{} {
 
 // PrintlnDouble = java/io/Prinstream.print(double)
 func PrintlnDouble(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnDouble: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnDouble")
+	if gerr != nil {
+		return gerr
 	}
 	xx := params[1].(float64) // contains to a float64--the equivalent of a Java double
 	fmt.Fprintln(writer, strconv.FormatFloat(xx, 'g', -1, 64))
@@ -493,10 +810,10 @@ func PrintlnDouble(params []interface{}) interface{} {
 
 // PrintlnFloat = java/io/Prinstream.print(float)
 func PrintlnFloat(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintlnFloat: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintlnFloat")
+	if gerr != nil {
+		return gerr
 	}
 	xx := params[1].(float64) // contains to a float64--the equivalent of a Java double
 	fmt.Fprintln(writer, strconv.FormatFloat(xx, 'g', -1, 32))
@@ -505,10 +822,10 @@ func PrintlnFloat(params []interface{}) interface{} {
 
 // "java/io/PrintStream.print(C)V"
 func PrintChar(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintChar: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintChar")
+	if gerr != nil {
+		return gerr
 	}
 	bb := byte(params[1].(int64))
 	fmt.Fprint(writer, string(bb))
@@ -519,10 +836,10 @@ func PrintChar(params []interface{}) interface{} {
 // "java/io/PrintStream.print(I)V"
 // "java/io/PrintStream.print(S)V"
 func PrintBIS(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintBIS: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintBIS")
+	if gerr != nil {
+		return gerr
 	}
 	intToPrint, ok := params[1].(int64) // contains an int
 	if !ok {
@@ -535,10 +852,10 @@ func PrintBIS(params []interface{}) interface{} {
 // PrintBoolean = java/io/Prinstream.print(boolean)
 // "java/io/PrintStream.print(Z)V"
 func PrintBoolean(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintBoolean: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintBoolean")
+	if gerr != nil {
+		return gerr
 	}
 	var boolToPrint bool
 	boolAsInt64 := params[1].(int64) // contains an int64
@@ -555,10 +872,10 @@ func PrintBoolean(params []interface{}) interface{} {
 // Long in Java are 64-bit ints, so we just duplicated the logic for println(int)
 // "java/io/PrintStream.print(J)V"
 func PrintLong(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintLong: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintLong")
+	if gerr != nil {
+		return gerr
 	}
 	longToPrint := params[1].(int64) // contains to an int64--the equivalent of a Java long
 	fmt.Fprint(writer, longToPrint)
@@ -567,10 +884,10 @@ func PrintLong(params []interface{}) interface{} {
 
 // PrintDouble = java/io/Prinstream.print(double)
 func PrintDouble(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintDouble: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintDouble")
+	if gerr != nil {
+		return gerr
 	}
 	xx := params[1].(float64) // contains to a float64--the equivalent of a Java double
 	fmt.Fprint(writer, strconv.FormatFloat(xx, 'g', -1, 64))
@@ -579,10 +896,10 @@ func PrintDouble(params []interface{}) interface{} {
 
 // PrintFloat = java/io/Prinstream.print(float)
 func PrintFloat(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("PrintFloat: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "PrintFloat")
+	if gerr != nil {
+		return gerr
 	}
 	xx := params[1].(float64) // contains to a float64--the equivalent of a Java double
 	fmt.Fprint(writer, strconv.FormatFloat(xx, 'g', -1, 32))
@@ -592,10 +909,10 @@ func PrintFloat(params []interface{}) interface{} {
 // Printf -- handle the variable args and then call golang's own printf function
 // "java/io/PrintStream.printf(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintStream;"
 func Printf(params []interface{}) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("Printf: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "Printf")
+	if gerr != nil {
+		return gerr
 	}
 
 	var intfSprintf = new([]interface{})
@@ -616,11 +933,10 @@ func Printf(params []interface{}) interface{} {
 }
 
 // "java/io/PrintStream.println(Ljava/lang/String;)V"
-func _printString(params []interface{}, newLine bool) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("_printString: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+func _printString(fs *list.List, params []interface{}, newLine bool) interface{} {
+	writer, gerr := printStreamWriter(fs, params[0], "_printString")
+	if gerr != nil {
+		return gerr
 	}
 
 	var str string
@@ -667,20 +983,21 @@ func _printString(params []interface{}, newLine bool) interface{} {
 // Print string
 // "java/io/PrintStream.print(Ljava/lang/String;)V"
 func PrintString(params []interface{}) interface{} {
-	return _printString(params, false)
+	fs, params := ghelpers.SplitContext(params)
+	return _printString(fs, params, false)
 }
 
 // "java/io/PrintStream.println(Ljava/lang/String;)V"
 func PrintlnString(params []interface{}) interface{} {
-	return _printString(params, true)
+	fs, params := ghelpers.SplitContext(params)
+	return _printString(fs, params, true)
 }
 
 // Called by PrintObject and PrintlnObject
-func _printObject(params []interface{}, newLine bool) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("_printObject: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+func _printObject(fs *list.List, params []interface{}, newLine bool) interface{} {
+	writer, gerr := printStreamWriter(fs, params[0], "_printObject")
+	if gerr != nil {
+		return gerr
 	}
 
 	var strBuffer string
@@ -759,12 +1076,12 @@ func _printObject(params []interface{}, newLine bool) interface{} {
 // Print an Object's contents
 // "java/io/PrintStream.print(Ljava/lang/Object;)V"
 func PrintObject(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	// Check for null object.
 	if params[1] == nil || object.IsNull(params[1]) {
-		writer, ok := params[0].(io.Writer)
-		if !ok {
-			errMsg := fmt.Sprintf("PrintObject: Expected io.Writer, observed %T", params[0])
-			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		writer, gerr := printStreamWriter(fs, params[0], "PrintObject")
+		if gerr != nil {
+			return gerr
 		}
 		fmt.Fprint(writer, types.NullString)
 		return nil
@@ -772,22 +1089,22 @@ func PrintObject(params []interface{}) interface{} {
 
 	// Check for linked list object.
 	if object.GoStringFromStringPoolIndex(params[1].(*object.Object).KlassName) == types.ClassNameLinkedList {
-		return _printLinkedList(params, false)
+		return _printLinkedList(fs, params, false)
 	}
 
 	// It's some other object.
-	return _printObject(params, false)
+	return _printObject(fs, params, false)
 }
 
 // Println an Object's contents
 // "java/io/PrintStream.println(Ljava/lang/Object;)V"
 func PrintlnObject(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	// Check for null object.
 	if params[1] == nil || object.IsNull(params[1]) {
-		writer, ok := params[0].(io.Writer)
-		if !ok {
-			errMsg := fmt.Sprintf("PrintlnObject: Expected io.Writer, observed %T", params[0])
-			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		writer, gerr := printStreamWriter(fs, params[0], "PrintlnObject")
+		if gerr != nil {
+			return gerr
 		}
 		fmt.Fprintln(writer, types.NullString)
 		return nil
@@ -795,19 +1112,18 @@ func PrintlnObject(params []interface{}) interface{} {
 
 	// Check for linked list object.
 	if object.GoStringFromStringPoolIndex(params[1].(*object.Object).KlassName) == types.ClassNameLinkedList {
-		return _printLinkedList(params, true)
+		return _printLinkedList(fs, params, true)
 	}
 
 	// It's some other object.
-	return _printObject(params, true)
+	return _printObject(fs, params, true)
 }
 
 // Print a linked list like this: [A, B, C]
-func _printLinkedList(params []interface{}, newLine bool) interface{} {
-	writer, ok := params[0].(io.Writer)
-	if !ok {
-		errMsg := fmt.Sprintf("_printLinkedList: Expected io.Writer, observed %T", params[0])
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+func _printLinkedList(fs *list.List, params []interface{}, newLine bool) interface{} {
+	writer, gerr := printStreamWriter(fs, params[0], "_printLinkedList")
+	if gerr != nil {
+		return gerr
 	}
 
 	var strBuffer string
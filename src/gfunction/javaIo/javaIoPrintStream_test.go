@@ -2,6 +2,7 @@ package javaIo
 
 import (
 	"bytes"
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/gfunction/javaLang"
 	"jacobin/src/gfunction/javaMath"
@@ -10,6 +11,8 @@ import (
 	"jacobin/src/object"
 	"jacobin/src/statics"
 	"jacobin/src/types"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -490,3 +493,88 @@ func TestPrintStreamWriteFromByteArray(t *testing.T) {
 		t.Errorf("printstreamWriteFromByteArray with invalid object did not return GErrBlk, got %T", ret)
 	}
 }
+
+func TestPrintStreamCharset(t *testing.T) {
+	globals.InitGlobals("test")
+	filePath := filepath.Join(t.TempDir(), "ps_latin1.txt")
+	ps := object.MakeEmptyObject()
+	ret := printstreamInitFile([]interface{}{ps, makeStringObject(filePath), makeStringObject("ISO-8859-1")})
+	if ret != nil {
+		t.Fatalf("printstreamInitFile returned %v", ret)
+	}
+
+	if ret := PrintString([]interface{}{ps, makeStringObject("olé€")}); ret != nil {
+		t.Fatalf("PrintString returned %v", ret)
+	}
+	// write() passes bytes through unchanged
+	raw := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, []types.JavaByte{-1})
+	if ret := printstreamWriteFromByteArray([]interface{}{ps, raw}); ret != nil {
+		t.Fatalf("printstreamWriteFromByteArray returned %v", ret)
+	}
+	cs := printstreamCharset([]interface{}{ps}).(*object.Object)
+	_ = printstreamClose([]interface{}{ps})
+
+	got, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !bytes.Equal(got, []byte{'o', 'l', 0xE9, '?', 0xFF}) {
+		t.Errorf("content mismatch: got % X", got)
+	}
+	name := cs.FieldTable["name"].Fvalue.(*object.Object)
+	if object.GoStringFromStringObject(name) != "ISO-8859-1" {
+		t.Errorf("charset(): got %q", object.GoStringFromStringObject(name))
+	}
+
+	ret = printstreamInitFile([]interface{}{object.MakeEmptyObject(), makeStringObject(filePath), makeStringObject("x-no-such-charset")})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.UnsupportedEncodingException {
+		t.Errorf("expected UnsupportedEncodingException, got %v", ret)
+	}
+}
+
+func TestPrintStream_OverJavaStream(t *testing.T) {
+	fs := newJavaTestFrameStack(t)
+
+	// a Java CharSequence whose toString() returns the text in its "text" field
+	seqClassName := "jacobin/test/JavaCharSequence"
+	insertJavaTestClass(seqClassName, "java/lang/Object", map[string]javaTestMethod{
+		"toString()Ljava/lang/String;": func(this *object.Object, _ []any) any {
+			return makeStringObject(this.FieldTable["text"].Fvalue.(string))
+		},
+	})
+	seq := object.MakeEmptyObjectWithClassName(&seqClassName)
+	seq.FieldTable["text"] = object.Field{Ftype: types.GolangString, Fvalue: "añb"}
+
+	var sink bytes.Buffer
+	out := newJavaTestOutputStream(&sink)
+	ps := object.MakeEmptyObject()
+	if ret := printstreamInitStream([]interface{}{ps, out}); ret != nil {
+		t.Fatalf("PrintStream(OutputStream) returned %v", ret)
+	}
+	for _, ret := range []interface{}{
+		PrintlnString([]interface{}{fs, ps, makeStringObject("héllo")}),
+		PrintBIS([]interface{}{fs, ps, int64(42)}),
+		printstreamAppend([]interface{}{fs, ps, seq, int64(1), int64(3)}),
+		PrintFlush([]interface{}{fs, ps}),
+	} {
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			t.Fatalf("print to a Java stream failed: %s", gerr.ErrMsg)
+		}
+	}
+	if got := sink.String(); got != "héllo\n42ñb" {
+		t.Errorf("output: expected %q, observed %q", "héllo\n42ñb", got)
+	}
+
+	if ret := printstreamClose([]interface{}{fs, ps}); ret != nil {
+		t.Fatalf("close returned %v", ret)
+	}
+	if !javaTestStreamClosed(out) {
+		t.Errorf("close did not close the Java stream")
+	}
+
+	// without the frame stack, the Java methods cannot run
+	ret := printstreamAppend([]interface{}{ps, seq})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalStateException {
+		t.Errorf("append without a frame stack: expected IllegalStateException, observed %v", ret)
+	}
+}
@@ -0,0 +1,292 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"unicode/utf16"
+)
+
+// A PrintWriter holds its target the same way a PrintStream does: a Writer or OutputStream in
+// the "out" field, or the FileHandle of a file it opened itself. So the print and println
+// functions of PrintStream serve PrintWriter too. Nothing is buffered, so output appears
+// whether or not autoflush was requested.
+
+func Load_Io_PrintWriter() {
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	// constructors: text is encoded in the charset given, else in the default charset
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/Writer;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/Writer;Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/OutputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/OutputStream;Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/OutputStream;ZLjava/nio/charset/Charset;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  printstreamInitStream,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/lang/String;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/File;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  printstreamInitFile,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.<init>(Ljava/io/File;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  printstreamInitFile,
+		}
+
+	// print and println, as for PrintStream
+
+	for sig, gfunc := range map[string]func([]interface{}) interface{}{
+		"print(C)V":                    PrintChar,
+		"print(D)V":                    PrintDouble,
+		"print(F)V":                    PrintFloat,
+		"print(I)V":                    PrintBIS,
+		"print(J)V":                    PrintLong,
+		"print(Ljava/lang/Object;)V":   PrintObject,
+		"print(Ljava/lang/String;)V":   PrintString,
+		"print(Z)V":                    PrintBoolean,
+		"print([C)V":                   printWriterWriteChars,
+		"println(C)V":                  PrintlnChar,
+		"println(D)V":                  PrintlnDouble,
+		"println(F)V":                  PrintlnFloat,
+		"println(I)V":                  PrintlnBIS,
+		"println(J)V":                  PrintlnLong,
+		"println(Ljava/lang/Object;)V": PrintlnObject,
+		"println(Ljava/lang/String;)V": PrintlnString,
+		"println(Z)V":                  PrintlnBoolean,
+		"println([C)V":                 printWriterPrintlnChars,
+	} {
+		ghelpers.MethodSignatures["java/io/PrintWriter."+sig] = ghelpers.GMeth{ParamSlots: 1, GFunction: gfunc, NeedsContext: true}
+	}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.println()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    PrintlnV,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.format(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   2,
+			GFunction:    Printf,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.printf(Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   2,
+			GFunction:    Printf,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.format(Ljava/util/Locale;Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.printf(Ljava/util/Locale;Ljava/lang/String;[Ljava/lang/Object;)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	// Writer methods
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.append(C)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printWriterAppendChar,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.append(Ljava/lang/CharSequence;)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printstreamAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.append(Ljava/lang/CharSequence;II)Ljava/io/PrintWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    printstreamAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.checkError()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    printstreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    PrintFlush,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printWriterWriteChar,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.write([C)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    printWriterWriteChars,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.write([CII)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    printWriterWriteChars,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.write(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    PrintString,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PrintWriter.write(Ljava/lang/String;II)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    printWriterWriteString,
+			NeedsContext: true,
+		}
+}
+
+// java/io/PrintWriter.write(I)V -- writes the character in the low-order 16 bits of the argument
+func printWriterWriteChar(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "printWriterWriteChar")
+	if gerr != nil {
+		return gerr
+	}
+	fmt.Fprint(writer, string(rune(uint16(params[1].(int64)))))
+	return nil
+}
+
+// java/io/PrintWriter.append(C)Ljava/io/PrintWriter;
+func printWriterAppendChar(params []interface{}) interface{} {
+	if ret := printWriterWriteChar(params); ret != nil {
+		return ret
+	}
+	_, params = ghelpers.SplitContext(params)
+	return params[0]
+}
+
+// java/io/PrintWriter.write([C)V, write([CII)V and print([C)V
+func printWriterWriteChars(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "printWriterWriteChars")
+	if gerr != nil {
+		return gerr
+	}
+	str, gerr := charArrayText(params, "printWriterWriteChars")
+	if gerr != nil {
+		return gerr
+	}
+	fmt.Fprint(writer, str)
+	return nil
+}
+
+// java/io/PrintWriter.println([C)V
+func printWriterPrintlnChars(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "printWriterPrintlnChars")
+	if gerr != nil {
+		return gerr
+	}
+	str, gerr := charArrayText(params, "printWriterPrintlnChars")
+	if gerr != nil {
+		return gerr
+	}
+	fmt.Fprintln(writer, str)
+	return nil
+}
+
+// java/io/PrintWriter.write(Ljava/lang/String;II)V
+func printWriterWriteString(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	writer, gerr := printStreamWriter(fs, params[0], "printWriterWriteString")
+	if gerr != nil {
+		return gerr
+	}
+	strObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "printWriterWriteString: string is null")
+	}
+	chars := javaChars(object.GoStringFromStringObject(strObj))
+	offset, length := params[2].(int64), params[3].(int64)
+	if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+		errMsg := fmt.Sprintf("begin %d, end %d, length %d", offset, offset+length, len(chars))
+		return ghelpers.GetGErrBlk(excNames.StringIndexOutOfBoundsException, errMsg)
+	}
+	fmt.Fprint(writer, string(utf16.Decode(chars[offset:offset+length])))
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaLang"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"os"
+	"testing"
+)
+
+func newStringWriter() *object.Object {
+	sw := object.MakeEmptyObjectWithClassName(new("java/io/StringWriter"))
+	stringWriterInit([]interface{}{sw})
+	return sw
+}
+
+func stringWriterContents(t *testing.T, sw *object.Object) string {
+	t.Helper()
+	return object.GoStringFromStringObject(textWriterToString([]interface{}{sw}).(*object.Object))
+}
+
+func charArrayOf(s string) *object.Object {
+	var chars []int64
+	for _, r := range s {
+		chars = append(chars, int64(r))
+	}
+	return object.MakePrimitiveObject("[C", types.CharArray, chars)
+}
+
+func TestSystemSetOut_RedirectsPrintStreamFunctions(t *testing.T) {
+	globals.InitStringPool()
+	javaLang.Load_Lang_System()
+	Load_Io_ByteArrayOutputStream()
+	Load_Io_PrintStream()
+	defer ghelpers.Invoke("java/lang/System.setOut(Ljava/io/PrintStream;)V", []interface{}{os.Stdout})
+
+	baos := object.MakeEmptyObjectWithClassName(new("java/io/ByteArrayOutputStream"))
+	ByteArrayOutputStreamInit([]interface{}{baos})
+	ps := object.MakeEmptyObjectWithClassName(new("java/io/PrintStream"))
+	if ret := printstreamInitStream([]interface{}{ps, baos}); ret != nil {
+		t.Fatalf("PrintStream(OutputStream) returned %v", ret)
+	}
+	ghelpers.Invoke("java/lang/System.setOut(Ljava/io/PrintStream;)V", []interface{}{ps})
+
+	out := statics.GetStaticValue("java/lang/System", "out")
+	if out != ps {
+		t.Fatalf("System.out should be the new PrintStream, got %T", out)
+	}
+	PrintlnString([]interface{}{out, makeStringObject("captured")})
+	PrintBIS([]interface{}{out, int64(42)})
+	printstreamAppendChar([]interface{}{out, int64('!')})
+
+	got := object.GoStringFromStringObject(ByteArrayOutputStreamToString([]interface{}{baos}).(*object.Object))
+	if got != "captured\n42!" {
+		t.Errorf("captured output: got %q", got)
+	}
+}
+
+func TestPrintStream_AppendSubSequenceOfMultiByteText(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+
+	baos := object.MakeEmptyObjectWithClassName(new("java/io/ByteArrayOutputStream"))
+	ByteArrayOutputStreamInit([]interface{}{baos})
+	ps := object.MakeEmptyObjectWithClassName(new("java/io/PrintStream"))
+	if ret := printstreamInitStream([]interface{}{ps, baos}); ret != nil {
+		t.Fatalf("PrintStream(OutputStream) returned %v", ret)
+	}
+
+	// five chars: 'a', 'ñ', the surrogate pair of '😀' and 'b', in eight UTF-8 bytes
+	text := makeStringObject("añ😀b")
+	if ret := printstreamAppend([]interface{}{ps, text, int64(1), int64(4)}); ret != ps {
+		t.Fatalf("append(csq, 1, 4) returned %v", ret)
+	}
+	got := object.GoStringFromStringObject(ByteArrayOutputStreamToString([]interface{}{baos}).(*object.Object))
+	if got != "ñ😀" {
+		t.Errorf("append(csq, 1, 4): got %q", got)
+	}
+
+	ret := printstreamAppend([]interface{}{ps, text, int64(2), int64(6)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.StringIndexOutOfBoundsException {
+		t.Errorf("append past the last char: expected StringIndexOutOfBoundsException, got %v", ret)
+	}
+}
+
+func TestPrintWriter_OverStringWriter(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	Load_Io_StringWriter()
+
+	sw := newStringWriter()
+	pw := object.MakeEmptyObjectWithClassName(new("java/io/PrintWriter"))
+	if ret := printstreamInitStream([]interface{}{pw, sw}); ret != nil {
+		t.Fatalf("PrintWriter(Writer) returned %v", ret)
+	}
+
+	PrintString([]interface{}{pw, makeStringObject("x=")})
+	PrintlnBIS([]interface{}{pw, int64(7)})
+	printWriterWriteChars([]interface{}{pw, charArrayOf("héllo"), int64(1), int64(3)})
+	printWriterWriteString([]interface{}{pw, makeStringObject("abcdef"), int64(2), int64(2)})
+	printWriterWriteChar([]interface{}{pw, int64('\n')})
+	PrintFlush([]interface{}{pw})
+
+	if got := stringWriterContents(t, sw); got != "x=7\néllcd\n" {
+		t.Errorf("StringWriter contents: got %q", got)
+	}
+
+	ret := printWriterWriteString([]interface{}{pw, makeStringObject("abc"), int64(2), int64(5)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.StringIndexOutOfBoundsException {
+		t.Errorf("write past the end of the string: expected StringIndexOutOfBoundsException, got %v", ret)
+	}
+
+	// the offset and length count UTF-16 chars: 'ñ' is one, '😀' is two
+	if ret := printWriterWriteString([]interface{}{pw, makeStringObject("añ😀b"), int64(1), int64(3)}); ret != nil {
+		t.Fatalf("write(\"añ😀b\", 1, 3) returned %v", ret)
+	}
+	if got := stringWriterContents(t, sw); got != "x=7\néllcd\nñ😀" {
+		t.Errorf("StringWriter contents after a multi-byte write: got %q", got)
+	}
+}
+
+func TestPrintWriter_ToFile(t *testing.T) {
+	globals.InitStringPool()
+	path := t.TempDir() + "/out.txt"
+
+	pw := object.MakeEmptyObjectWithClassName(new("java/io/PrintWriter"))
+	if ret := printstreamInitFile([]interface{}{pw, makeStringObject(path)}); ret != nil {
+		t.Fatalf("PrintWriter(String) returned %v", ret)
+	}
+	PrintlnString([]interface{}{pw, makeStringObject("line one")})
+	printstreamClose([]interface{}{pw})
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "line one\n" {
+		t.Errorf("file contents: got %q, err %v", data, err)
+	}
+}
+
+func TestStringWriterAndCharArrayWriter(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	Load_Io_StringWriter()
+
+	sw := newStringWriter()
+	textWriterWriteString([]interface{}{sw, makeStringObject("ab")})
+	textWriterAppend([]interface{}{sw, makeStringObject("xcdx"), int64(1), int64(3)})
+	textWriterAppend([]interface{}{sw, object.Null})
+	if got := stringWriterContents(t, sw); got != "abcdnull" {
+		t.Errorf("StringWriter: got %q", got)
+	}
+	multi := newStringWriter()
+	textWriterWriteString([]interface{}{multi, makeStringObject("😀añ"), int64(0), int64(3)})
+	textWriterAppend([]interface{}{multi, makeStringObject("añ😀b"), int64(2), int64(5)})
+	if got := stringWriterContents(t, multi); got != "😀a😀b" {
+		t.Errorf("StringWriter with multi-byte text: got %q", got)
+	}
+	ret := stringWriterInit([]interface{}{object.MakeEmptyObjectWithClassName(new("java/io/StringWriter")), int64(-1)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("StringWriter(-1): expected IllegalArgumentException, got %v", ret)
+	}
+
+	caw := object.MakeEmptyObjectWithClassName(new("java/io/CharArrayWriter"))
+	charArrayWriterInit([]interface{}{caw})
+	textWriterWriteChars([]interface{}{caw, charArrayOf("xyz")})
+	textWriterWriteChar([]interface{}{caw, int64('!')})
+	if size := charArrayWriterSize([]interface{}{caw}).(int64); size != 4 {
+		t.Errorf("CharArrayWriter.size: expected 4, got %d", size)
+	}
+	chars := charArrayWriterToCharArray([]interface{}{caw}).(*object.Object).FieldTable["value"].Fvalue.([]int64)
+	if len(chars) != 4 || chars[0] != 'x' || chars[3] != '!' {
+		t.Errorf("CharArrayWriter.toCharArray: got %v", chars)
+	}
+
+	target := newStringWriter()
+	if ret := charArrayWriterWriteTo([]interface{}{caw, target}); ret != nil {
+		t.Fatalf("writeTo returned %v", ret)
+	}
+	if got := stringWriterContents(t, target); got != "xyz!" {
+		t.Errorf("CharArrayWriter.writeTo: got %q", got)
+	}
+	charArrayWriterReset([]interface{}{caw})
+	if size := charArrayWriterSize([]interface{}{caw}).(int64); size != 0 {
+		t.Errorf("size after reset: expected 0, got %d", size)
+	}
+}
+
+func TestStringReader(t *testing.T) {
+	globals.InitStringPool()
+
+	sr := object.MakeEmptyObjectWithClassName(new("java/io/StringReader"))
+	stringReaderInit([]interface{}{sr, makeStringObject("héllo")})
+
+	if ch := stringReaderRead([]interface{}{sr}).(int64); ch != 'h' {
+		t.Errorf("read: expected 'h', got %q", rune(ch))
+	}
+	stringReaderMark([]interface{}{sr, int64(10)})
+	if ch := stringReaderRead([]interface{}{sr}).(int64); ch != 'é' {
+		t.Errorf("read: expected 'é', got %q", rune(ch))
+	}
+	stringReaderReset([]interface{}{sr})
+	if n := stringReaderSkip([]interface{}{sr, int64(2)}).(int64); n != 2 {
+		t.Errorf("skip(2): expected 2, got %d", n)
+	}
+
+	buf := charArrayOf("_____")
+	if n := stringReaderReadChars([]interface{}{sr, buf, int64(1), int64(4)}).(int64); n != 2 {
+		t.Errorf("read(char[], 1, 4): expected 2, got %d", n)
+	}
+	if chars := buf.FieldTable["value"].Fvalue.([]int64); chars[1] != 'l' || chars[2] != 'o' {
+		t.Errorf("read(char[]): got %v", chars)
+	}
+	if ch := stringReaderRead([]interface{}{sr}).(int64); ch != -1 {
+		t.Errorf("read at end: expected -1, got %d", ch)
+	}
+
+	stringReaderClose([]interface{}{sr})
+	ret := stringReaderRead([]interface{}{sr})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IOException {
+		t.Errorf("read after close: expected IOException, got %v", ret)
+	}
+}
@@ -0,0 +1,306 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+)
+
+// java.io.PushbackInputStream reads from the stream in its "in" field and lets bytes be
+// "unread" into its pushback buffer, from which they are read again first. As in the JDK, the
+// buffer is the "buf" field and is filled from its end; "pos" is the index of the next byte
+// to read from it, and equals the buffer's length when it is empty.
+
+func Load_Io_PushbackInputStream() {
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pushbackInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.<init>(Ljava/io/InputStream;I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  pushbackInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.available()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    pushbackInputStreamAvailable,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    pushbackInputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.mark(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  ghelpers.JustReturn,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.markSupported()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ReturnFalse,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    pushbackInputStreamRead,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.read([B)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    pushbackInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.read([BII)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    pushbackInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.reset()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pushbackInputStreamReset,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.skip(J)J"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    pushbackInputStreamSkip,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.unread(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pushbackInputStreamUnreadByte,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.unread([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pushbackInputStreamUnread,
+		}
+
+	ghelpers.MethodSignatures["java/io/PushbackInputStream.unread([BII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  pushbackInputStreamUnread,
+		}
+}
+
+// java/io/PushbackInputStream.<init>(Ljava/io/InputStream;)V and <init>(Ljava/io/InputStream;I)V
+func pushbackInputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	size := int64(1)
+	if len(params) == 3 {
+		size = params[2].(int64)
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "size <= 0")
+		}
+	}
+	self.FieldTable["in"] = object.Field{Ftype: "Ljava/io/InputStream;", Fvalue: params[1]}
+	self.FieldTable["buf"] = object.Field{Ftype: types.JavaByteArray, Fvalue: make([]types.JavaByte, size)}
+	self.FieldTable["pos"] = object.Field{Ftype: types.Int, Fvalue: size}
+	return nil
+}
+
+// pushbackState returns the underlying stream, pushback buffer, and position of a
+// PushbackInputStream. A closed stream is an IOException.
+func pushbackState(this any) (*object.Object, []types.JavaByte, int64, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, nil, 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "PushbackInputStream: stream is null")
+	}
+	in, _ := self.FieldTable["in"].Fvalue.(*object.Object)
+	buf, _ := self.FieldTable["buf"].Fvalue.([]types.JavaByte)
+	if in == nil || object.IsNull(in) || buf == nil {
+		return nil, nil, 0, ghelpers.GetGErrBlk(excNames.IOException, "Stream closed")
+	}
+	pos, _ := self.FieldTable["pos"].Fvalue.(int64)
+	return in, buf, pos, nil
+}
+
+func setPushbackPos(this any, pos int64) {
+	this.(*object.Object).FieldTable["pos"] = object.Field{Ftype: types.Int, Fvalue: pos}
+}
+
+// java/io/PushbackInputStream.read()I
+func pushbackInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if pos < int64(len(buf)) {
+		setPushbackPos(params[0], pos+1)
+		return int64(uint8(buf[pos]))
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "read", "()I")
+}
+
+// java/io/PushbackInputStream.read([B)I and read([BII)I -- reads pushed-back bytes first, then
+// from the underlying stream
+func pushbackInputStreamReadBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "PushbackInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	if length == 0 {
+		return int64(0)
+	}
+
+	avail := min(int64(len(buf))-pos, length)
+	if avail > 0 {
+		copy(jbytes[off:], buf[pos:pos+avail])
+		setPushbackPos(params[0], pos+avail)
+		off += avail
+		length -= avail
+	}
+	if length == 0 {
+		return avail
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, in, "read", "([BII)I", params[1], off, length)
+	n, ok := ret.(int64)
+	if !ok {
+		return ret
+	}
+	if n == -1 {
+		if avail == 0 {
+			return int64(-1)
+		}
+		return avail
+	}
+	return avail + n
+}
+
+// java/io/PushbackInputStream.unread(I)V -- pushes back the low-order byte of the argument
+func pushbackInputStreamUnreadByte(params []interface{}) interface{} {
+	_, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if pos == 0 {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Push back buffer is full")
+	}
+	buf[pos-1] = types.JavaByte(params[1].(int64))
+	setPushbackPos(params[0], pos-1)
+	return nil
+}
+
+// java/io/PushbackInputStream.unread([B)V and unread([BII)V -- pushes back the bytes so that
+// the first of them is read next
+func pushbackInputStreamUnread(params []interface{}) interface{} {
+	_, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params, 1, "PushbackInputStream.unread")
+	if gerr != nil {
+		return gerr
+	}
+	if length > pos {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Push back buffer is full")
+	}
+	copy(buf[pos-length:], jbytes[off:off+length])
+	setPushbackPos(params[0], pos-length)
+	return nil
+}
+
+// java/io/PushbackInputStream.available()I -- the pushed-back bytes plus what the underlying
+// stream has available
+func pushbackInputStreamAvailable(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, in, "available", "()I")
+	avail, ok := ret.(int64)
+	if !ok {
+		return ret
+	}
+	return min(int64(len(buf))-pos+avail, math.MaxInt32)
+}
+
+// java/io/PushbackInputStream.skip(J)J
+func pushbackInputStreamSkip(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	in, buf, pos, gerr := pushbackState(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	n := params[1].(int64)
+	if n <= 0 {
+		return int64(0)
+	}
+	skipped := min(int64(len(buf))-pos, n)
+	if skipped > 0 {
+		setPushbackPos(params[0], pos+skipped)
+		n -= skipped
+	}
+	if n > 0 {
+		ret := ghelpers.InvokeMethodOnObject(fs, in, "skip", "(J)J", n)
+		more, ok := ret.(int64)
+		if !ok {
+			return ret
+		}
+		skipped += more
+	}
+	return skipped
+}
+
+// java/io/PushbackInputStream.reset()V
+func pushbackInputStreamReset(params []interface{}) interface{} {
+	return ghelpers.GetGErrBlk(excNames.IOException, "mark/reset not supported")
+}
+
+// java/io/PushbackInputStream.close()V -- closes the underlying stream; closing twice does nothing
+func pushbackInputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	if self.FieldTable["buf"].Fvalue == nil {
+		return nil
+	}
+	in, _ := self.FieldTable["in"].Fvalue.(*object.Object)
+	self.FieldTable["in"] = object.Field{Ftype: "Ljava/io/InputStream;", Fvalue: object.Null}
+	self.FieldTable["buf"] = object.Field{Ftype: types.JavaByteArray, Fvalue: nil}
+	if in == nil || object.IsNull(in) {
+		return nil
+	}
+	return ghelpers.InvokeMethodOnObject(fs, in, "close", "()V")
+}
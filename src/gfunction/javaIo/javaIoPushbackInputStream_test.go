@@ -0,0 +1,85 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func TestPushbackInputStream_Unread(t *testing.T) {
+	setup()
+	Load_Io_ByteArrayInputStream()
+
+	pis := object.MakeEmptyObject()
+	if ret := pushbackInputStreamInit([]any{pis, newTestByteArrayInputStream([]byte("bcd")), int64(2)}); ret != nil {
+		t.Fatalf("init failed: %v", ret)
+	}
+	if c := pushbackInputStreamRead([]any{pis}); c != int64('b') {
+		t.Errorf("read: expected 'b', observed %v", c)
+	}
+
+	arr := object.Make1DimArray(object.T_BYTE, 2)
+	arr.FieldTable["value"] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoByteArray([]byte("za"))}
+	if ret := pushbackInputStreamUnread([]any{pis, arr}); ret != nil {
+		t.Fatalf("unread failed: %v", ret)
+	}
+	ret := pushbackInputStreamUnreadByte([]any{pis, int64('y')})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IOException ||
+		gerr.ErrMsg != "Push back buffer is full" {
+		t.Errorf("unread into a full buffer: expected IOException, observed %v", ret)
+	}
+	if n := pushbackInputStreamAvailable([]any{pis}); n != int64(4) {
+		t.Errorf("available: expected 4, observed %v", n)
+	}
+
+	out := object.Make1DimArray(object.T_BYTE, 5)
+	if n := pushbackInputStreamReadBytes([]any{pis, out, int64(0), int64(5)}); n != int64(4) {
+		t.Errorf("read([BII): expected 4, observed %v", n)
+	}
+	got := object.GoByteArrayFromJavaByteArray(out.FieldTable["value"].Fvalue.([]types.JavaByte))
+	if string(got[:4]) != "zacd" {
+		t.Errorf("read([BII): expected \"zacd\", observed %q", got[:4])
+	}
+
+	_ = pushbackInputStreamClose([]any{pis})
+	ret = pushbackInputStreamRead([]any{pis})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ErrMsg != "Stream closed" {
+		t.Errorf("read after close: expected IOException, observed %v", ret)
+	}
+}
+
+func TestPushbackInputStream_OverJavaStream(t *testing.T) {
+	fs := newJavaTestFrameStack(t)
+
+	in := newJavaTestInputStream([]byte("xyz"))
+	pis := object.MakeEmptyObject()
+	if ret := pushbackInputStreamInit([]any{pis, in}); ret != nil {
+		t.Fatalf("init failed: %v", ret)
+	}
+	if c := pushbackInputStreamRead([]any{fs, pis}); c != int64('x') {
+		t.Errorf("read: expected 'x', observed %v", c)
+	}
+	pushbackInputStreamUnreadByte([]any{pis, int64('w')})
+	if n := pushbackInputStreamAvailable([]any{fs, pis}); n != int64(3) {
+		t.Errorf("available: expected 3, observed %v", n)
+	}
+
+	arr := object.Make1DimArray(object.T_BYTE, 3)
+	if n := pushbackInputStreamReadBytes([]any{fs, pis, arr, int64(0), int64(3)}); n != int64(3) {
+		t.Errorf("read([BII): expected 3, observed %v", n)
+	}
+	if got := object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte)); string(got) != "wyz" {
+		t.Errorf("expected \"wyz\", observed %q", got)
+	}
+	if ret := pushbackInputStreamClose([]any{fs, pis}); ret != nil || !javaTestStreamClosed(in) {
+		t.Errorf("close did not close the Java stream: %v", ret)
+	}
+}
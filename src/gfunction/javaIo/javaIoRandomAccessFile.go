@@ -48,7 +48,7 @@ func Load_Io_RandomAccessFile() {
 	ghelpers.MethodSignatures["java/io/RandomAccessFile.getChannel()Ljava/nio/channels/FileChannel;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  rafGetChannel,
 		}
 
 	ghelpers.MethodSignatures["java/io/RandomAccessFile.getFD()Ljava/io/FileDescriptor;"] =
@@ -366,6 +366,10 @@ func rafOpen0(params []interface{}) interface{} {
 	fld = object.Field{Ftype: ghelpers.FileHandle, Fvalue: osFile}
 	params[0].(*object.Object).FieldTable[ghelpers.FileHandle] = fld
 
+	// Record whether the file is writable, as the JDK's rw field does.
+	fld = object.Field{Ftype: types.Bool, Fvalue: types.ConvertGoBoolToJavaBool(mode&2 != 0)}
+	params[0].(*object.Object).FieldTable["rw"] = fld
+
 	return nil
 }
 
@@ -383,7 +387,7 @@ func rafInitString(params []interface{}) interface{} {
 	case "r":
 		modeInt = os.O_RDONLY
 	case "rw", "rws", "rwd":
-		modeInt = os.O_RDWR | os.O_CREATE | os.O_APPEND
+		modeInt = os.O_RDWR | os.O_CREATE
 	default:
 		errMsg := fmt.Sprintf("rafInitString: mode string (%s) invalid", modeStr)
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
@@ -404,6 +408,10 @@ func rafInitString(params []interface{}) interface{} {
 	fld = object.Field{Ftype: ghelpers.FileHandle, Fvalue: osFile}
 	params[0].(*object.Object).FieldTable[ghelpers.FileHandle] = fld
 
+	// Record whether the file is writable, as the JDK's rw field does.
+	fld = object.Field{Ftype: types.Bool, Fvalue: types.ConvertGoBoolToJavaBool(modeStr != "r")}
+	params[0].(*object.Object).FieldTable["rw"] = fld
+
 	return nil
 
 }
@@ -428,7 +436,7 @@ func rafInitFile(params []interface{}) interface{} {
 	case "r":
 		modeInt = os.O_RDONLY
 	case "rw", "rws", "rwd":
-		modeInt = os.O_RDWR | os.O_CREATE | os.O_APPEND
+		modeInt = os.O_RDWR | os.O_CREATE
 	default:
 		errMsg := fmt.Sprintf("rafInitFile: mode string (%s) invalid", modeStr)
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
@@ -449,6 +457,10 @@ func rafInitFile(params []interface{}) interface{} {
 	fld = object.Field{Ftype: ghelpers.FileHandle, Fvalue: osFile}
 	params[0].(*object.Object).FieldTable[ghelpers.FileHandle] = fld
 
+	// Record whether the file is writable, as the JDK's rw field does.
+	fld = object.Field{Ftype: types.Bool, Fvalue: types.ConvertGoBoolToJavaBool(modeStr != "r")}
+	params[0].(*object.Object).FieldTable["rw"] = fld
+
 	return nil
 
 }
@@ -1151,3 +1163,11 @@ func rafWriteUTF(params []interface{}) interface{} {
 	}
 	return nil
 }
+
+// "java/io/RandomAccessFile.getChannel()Ljava/nio/channels/FileChannel;"
+// The channel shares the file and its position with the RandomAccessFile.
+func rafGetChannel(params []interface{}) interface{} {
+	obj := params[0].(*object.Object)
+	writable := obj.FieldTable["rw"].Fvalue == types.JavaBoolTrue
+	return streamChannel(obj, "rafGetChannel", writable)
+}
@@ -711,3 +711,46 @@ func TestRafWriteDataMethods(t *testing.T) {
 		t.Errorf("writeUTF/readUTF: expected %q, got %q", expectedStr, gotStr)
 	}
 }
+
+func TestRafGetChannelSharesFile(t *testing.T) {
+	globals.InitGlobals("test")
+	tmpFile, err := os.CreateTemp("", "raf_channel_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	rafObj := newRAFObject()
+	params := []interface{}{rafObj, object.StringObjectFromGoString(tmpFile.Name()), object.StringObjectFromGoString("rw")}
+	if ret := rafInitString(params); ret != nil {
+		t.Fatalf("rafInitString returned error: %v", ret)
+	}
+	fh := rafObj.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File)
+	defer fh.Close()
+
+	ch, ok := rafGetChannel([]interface{}{rafObj}).(*object.Object)
+	if !ok {
+		t.Fatalf("rafGetChannel did not return a channel")
+	}
+	if rafGetChannel([]interface{}{rafObj}) != ch {
+		t.Errorf("getChannel should return the same channel each time")
+	}
+	if ch.FieldTable[ghelpers.FileHandle].Fvalue != fh {
+		t.Errorf("the channel should share the RandomAccessFile's file handle")
+	}
+
+	// The file is not opened for appending, so a write at the file pointer overwrites.
+	if _, err := fh.Write([]byte("abcdef")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fh.Seek(2, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fh.Write([]byte("XY")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(tmpFile.Name()); string(data) != "abXYef" {
+		t.Errorf("expected %q, got %q", "abXYef", data)
+	}
+}
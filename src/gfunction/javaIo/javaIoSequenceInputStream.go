@@ -0,0 +1,257 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.io.SequenceInputStream reads its streams one after the other: when one reaches its
+// end, it is closed and the next one is read. The streams come from an Enumeration or are the
+// two constructor arguments. The Go state is a sequenceState.
+
+const fieldNameSequence = "sequence" // the *sequenceState of a SequenceInputStream
+
+type sequenceState struct {
+	in      *object.Object   // the stream being read, or nil after the last one
+	pending []*object.Object // the streams still to read, if the constructor was given two
+	enum    *object.Object   // the Enumeration of the streams still to read, otherwise
+}
+
+func Load_Io_SequenceInputStream() {
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.<init>(Ljava/io/InputStream;Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  sequenceInputStreamInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.<init>(Ljava/util/Enumeration;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    sequenceInputStreamInitEnumeration,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.available()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    sequenceInputStreamAvailable,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    sequenceInputStreamClose,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots:   0,
+			GFunction:    sequenceInputStreamRead,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.read([B)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    sequenceInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.read([BII)I"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    sequenceInputStreamReadBytes,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/SequenceInputStream.transferTo(Ljava/io/OutputStream;)J"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  ghelpers.TrapFunction,
+		}
+}
+
+// java/io/SequenceInputStream.<init>(Ljava/io/InputStream;Ljava/io/InputStream;)V
+func sequenceInputStreamInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	var streams []*object.Object
+	for _, p := range params[1:] {
+		s, ok := p.(*object.Object)
+		if !ok || object.IsNull(s) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "SequenceInputStream: stream is null")
+		}
+		streams = append(streams, s)
+	}
+	state := &sequenceState{in: streams[0], pending: streams[1:]}
+	self.FieldTable[fieldNameSequence] = object.Field{Ftype: types.RawGoPointer, Fvalue: state}
+	return nil
+}
+
+// java/io/SequenceInputStream.<init>(Ljava/util/Enumeration;)V
+func sequenceInputStreamInitEnumeration(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	enum, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(enum) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "SequenceInputStream: enumeration is null")
+	}
+	state := &sequenceState{enum: enum}
+	if gerr := state.peekNextStream(fs); gerr != nil {
+		return gerr
+	}
+	self.FieldTable[fieldNameSequence] = object.Field{Ftype: types.RawGoPointer, Fvalue: state}
+	return nil
+}
+
+func sequenceStateOf(this any) (*sequenceState, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "SequenceInputStream: stream is null")
+	}
+	state, ok := self.FieldTable[fieldNameSequence].Fvalue.(*sequenceState)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "SequenceInputStream: stream is not initialized")
+	}
+	return state, nil
+}
+
+// peekNextStream makes the next stream the current one, or sets it to nil if there are none.
+func (s *sequenceState) peekNextStream(fs *list.List) *ghelpers.GErrBlk {
+	s.in = nil
+	if s.enum == nil {
+		if len(s.pending) > 0 {
+			s.in, s.pending = s.pending[0], s.pending[1:]
+		}
+		return nil
+	}
+
+	ret := ghelpers.InvokeMethodOnObject(fs, s.enum, "hasMoreElements", "()Z")
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	if ret != types.JavaBoolTrue {
+		return nil
+	}
+	ret = ghelpers.InvokeMethodOnObject(fs, s.enum, "nextElement", "()Ljava/lang/Object;")
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	next, ok := ret.(*object.Object)
+	if !ok || object.IsNull(next) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "SequenceInputStream: stream is null")
+	}
+	s.in = next
+	return nil
+}
+
+// nextStream closes the current stream and moves on to the next one.
+func (s *sequenceState) nextStream(fs *list.List) *ghelpers.GErrBlk {
+	if s.in != nil {
+		if gerr, ok := ghelpers.InvokeMethodOnObject(fs, s.in, "close", "()V").(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return s.peekNextStream(fs)
+}
+
+// java/io/SequenceInputStream.available()I -- what the current stream has available
+func sequenceInputStreamAvailable(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	state, gerr := sequenceStateOf(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if state.in == nil {
+		return int64(0)
+	}
+	return ghelpers.InvokeMethodOnObject(fs, state.in, "available", "()I")
+}
+
+// java/io/SequenceInputStream.read()I
+func sequenceInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	state, gerr := sequenceStateOf(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	for state.in != nil {
+		ret := ghelpers.InvokeMethodOnObject(fs, state.in, "read", "()I")
+		if c, ok := ret.(int64); !ok || c != -1 {
+			return ret
+		}
+		if gerr := state.nextStream(fs); gerr != nil {
+			return gerr
+		}
+	}
+	return int64(-1)
+}
+
+// java/io/SequenceInputStream.read([B)I and read([BII)I -- reads from the current stream, moving
+// on to the next one at its end
+func sequenceInputStreamReadBytes(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	state, gerr := sequenceStateOf(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	_, off, length, gerr := byteArrayRange(params, 1, "SequenceInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	if state.in == nil {
+		return int64(-1)
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	for state.in != nil {
+		ret := ghelpers.InvokeMethodOnObject(fs, state.in, "read", "([BII)I", params[1], off, length)
+		if n, ok := ret.(int64); !ok || n > 0 {
+			return ret
+		}
+		if gerr := state.nextStream(fs); gerr != nil {
+			return gerr
+		}
+	}
+	return int64(-1)
+}
+
+// java/io/SequenceInputStream.close()V -- closes the current stream and all those still to read
+func sequenceInputStreamClose(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	state, gerr := sequenceStateOf(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	var firstErr *ghelpers.GErrBlk
+	for state.in != nil {
+		if gerr, ok := ghelpers.InvokeMethodOnObject(fs, state.in, "close", "()V").(*ghelpers.GErrBlk); ok && firstErr == nil {
+			firstErr = gerr
+		}
+		if gerr := state.peekNextStream(fs); gerr != nil {
+			return gerr
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaIo
+
+import (
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func TestSequenceInputStream_ReadsStreamsInTurn(t *testing.T) {
+	setup()
+	Load_Io_ByteArrayInputStream()
+
+	first := newTestByteArrayInputStream([]byte("ab"))
+	second := newTestByteArrayInputStream([]byte("cde"))
+	sis := object.MakeEmptyObject()
+	if ret := sequenceInputStreamInit([]any{sis, first, second}); ret != nil {
+		t.Fatalf("init failed: %v", ret)
+	}
+
+	arr := object.Make1DimArray(object.T_BYTE, 4)
+	if n := sequenceInputStreamReadBytes([]any{sis, arr, int64(0), int64(4)}); n != int64(2) {
+		t.Errorf("first read: expected 2 bytes from the first stream, observed %v", n)
+	}
+	if n := sequenceInputStreamReadBytes([]any{sis, arr, int64(2), int64(2)}); n != int64(2) {
+		t.Errorf("second read: expected 2 bytes from the second stream, observed %v", n)
+	}
+	got := object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte))
+	if string(got) != "abcd" {
+		t.Errorf("expected \"abcd\", observed %q", got)
+	}
+	if c := sequenceInputStreamRead([]any{sis}); c != int64('e') {
+		t.Errorf("read: expected 'e', observed %v", c)
+	}
+	if c := sequenceInputStreamRead([]any{sis}); c != int64(-1) {
+		t.Errorf("read at the end: expected -1, observed %v", c)
+	}
+	if n := sequenceInputStreamAvailable([]any{sis}); n != int64(0) {
+		t.Errorf("available at the end: expected 0, observed %v", n)
+	}
+}
+
+func TestSequenceInputStream_OverJavaStreams(t *testing.T) {
+	fs := newJavaTestFrameStack(t)
+
+	first := newJavaTestInputStream([]byte("ab"))
+	second := newJavaTestInputStream([]byte("c"))
+	sis := object.MakeEmptyObject()
+	if ret := sequenceInputStreamInit([]any{sis, first, second}); ret != nil {
+		t.Fatalf("init failed: %v", ret)
+	}
+
+	arr := object.Make1DimArray(object.T_BYTE, 4)
+	if n := sequenceInputStreamReadBytes([]any{fs, sis, arr, int64(0), int64(4)}); n != int64(2) {
+		t.Errorf("first read: expected 2 bytes from the first stream, observed %v", n)
+	}
+	if c := sequenceInputStreamRead([]any{fs, sis}); c != int64('c') {
+		t.Errorf("read: expected 'c', observed %v", c)
+	}
+	if !javaTestStreamClosed(first) {
+		t.Errorf("the first stream was not closed at its end")
+	}
+	if ret := sequenceInputStreamClose([]any{fs, sis}); ret != nil || !javaTestStreamClosed(second) {
+		t.Errorf("close did not close the second stream: %v", ret)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// A StringReader reads the characters of a string. Its state is a *stringReaderState held in
+// the fieldNameReader field.
+
+const fieldNameReader = "reader"
+
+type stringReaderState struct {
+	chars  []rune
+	next   int
+	mark   int
+	closed bool
+}
+
+func Load_Io_StringReader() {
+
+	ghelpers.MethodSignatures["java/io/StringReader.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.<init>(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  stringReaderInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  stringReaderClose,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.mark(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  stringReaderMark,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.markSupported()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ReturnTrue,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.read()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  stringReaderRead,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.read([C)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  stringReaderReadChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.read([CII)I"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  stringReaderReadChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.ready()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  stringReaderReady,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.reset()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  stringReaderReset,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringReader.skip(J)J"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  stringReaderSkip,
+		}
+}
+
+// stringReaderThis returns the state of an open StringReader.
+func stringReaderThis(this any, caller string) (*stringReaderState, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": reader is null")
+	}
+	state, ok := self.FieldTable[fieldNameReader].Fvalue.(*stringReaderState)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": reader was not initialized")
+	}
+	if state.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "Stream closed")
+	}
+	return state, nil
+}
+
+// java/io/StringReader.<init>(Ljava/lang/String;)V
+func stringReaderInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	strObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "stringReaderInit: string is null")
+	}
+	state := &stringReaderState{chars: []rune(object.GoStringFromStringObject(strObj))}
+	self.FieldTable[fieldNameReader] = object.Field{Ftype: types.RawGoPointer, Fvalue: state}
+	return nil
+}
+
+// java/io/StringReader.close()V -- closing a closed reader has no effect
+func stringReaderClose(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	if state, ok := self.FieldTable[fieldNameReader].Fvalue.(*stringReaderState); ok {
+		state.closed = true
+	}
+	return nil
+}
+
+// java/io/StringReader.mark(I)V -- the read-ahead limit is irrelevant, as the whole string is kept
+func stringReaderMark(params []interface{}) interface{} {
+	state, gerr := stringReaderThis(params[0], "mark")
+	if gerr != nil {
+		return gerr
+	}
+	if params[1].(int64) < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Read-ahead limit < 0")
+	}
+	state.mark = state.next
+	return nil
+}
+
+// java/io/StringReader.read()I -- returns -1 at the end of the string
+func stringReaderRead(params []interface{}) interface{} {
+	state, gerr := stringReaderThis(params[0], "read")
+	if gerr != nil {
+		return gerr
+	}
+	if state.next >= len(state.chars) {
+		return int64(-1)
+	}
+	ch := state.chars[state.next]
+	state.next++
+	return int64(ch)
+}
+
+// java/io/StringReader.read([C)I and read([CII)I -- return the number of characters read, or -1
+func stringReaderReadChars(params []interface{}) interface{} {
+	state, gerr := stringReaderThis(params[0], "read")
+	if gerr != nil {
+		return gerr
+	}
+	arrObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "read: char array is null")
+	}
+	chars, _ := arrObj.FieldTable["value"].Fvalue.([]int64)
+	offset, length := int64(0), int64(len(chars))
+	if len(params) == 4 {
+		offset, length = params[2].(int64), params[3].(int64)
+		if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", offset, offset, length, len(chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	if state.next >= len(state.chars) {
+		return int64(-1)
+	}
+	count := min(int(length), len(state.chars)-state.next)
+	for ix := 0; ix < count; ix++ {
+		chars[int(offset)+ix] = int64(state.chars[state.next+ix])
+	}
+	state.next += count
+	return int64(count)
+}
+
+// java/io/StringReader.ready()Z -- a string is always ready
+func stringReaderReady(params []interface{}) interface{} {
+	if _, gerr := stringReaderThis(params[0], "ready"); gerr != nil {
+		return gerr
+	}
+	return types.JavaBoolTrue
+}
+
+// java/io/StringReader.reset()V -- returns to the mark, or to the start if there is no mark
+func stringReaderReset(params []interface{}) interface{} {
+	state, gerr := stringReaderThis(params[0], "reset")
+	if gerr != nil {
+		return gerr
+	}
+	state.next = state.mark
+	return nil
+}
+
+// java/io/StringReader.skip(J)J
+// As in the JDK, a negative count skips backwards, but not past the start of the string.
+// Nothing is skipped at the end of the string.
+func stringReaderSkip(params []interface{}) interface{} {
+	state, gerr := stringReaderThis(params[0], "skip")
+	if gerr != nil {
+		return gerr
+	}
+	if state.next >= len(state.chars) {
+		return int64(0)
+	}
+	n := params[1].(int64)
+	n = min(n, int64(len(state.chars)-state.next))
+	n = max(n, int64(-state.next))
+	state.next += int(n)
+	return n
+}
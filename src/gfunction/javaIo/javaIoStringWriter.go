@@ -0,0 +1,266 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaIo
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strings"
+	"unicode/utf16"
+)
+
+// StringWriter and CharArrayWriter both collect the text written to them in a strings.Builder,
+// which is kept in the fieldNameText field. The functions named textWriter* serve both classes.
+// As with Jacobin's strings, text is held as UTF-8: string offsets and lengths count bytes,
+// while char arrays hold one character per element.
+
+const fieldNameText = "text"
+
+func Load_Io_StringWriter() {
+
+	ghelpers.MethodSignatures["java/io/StringWriter.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.<init>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  stringWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.<init>(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  stringWriterInit,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.append(C)Ljava/io/StringWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterAppendChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.append(Ljava/lang/CharSequence;)Ljava/io/StringWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    textWriterAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.append(Ljava/lang/CharSequence;II)Ljava/io/StringWriter;"] =
+		ghelpers.GMeth{
+			ParamSlots:   3,
+			GFunction:    textWriterAppend,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.close()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.JustReturn,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.flush()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.JustReturn,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.getBuffer()Ljava/lang/StringBuffer;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.TrapFunction,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  textWriterToString,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.write(I)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteChar,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.write([C)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.write([CII)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  textWriterWriteChars,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.write(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  textWriterWriteString,
+		}
+
+	ghelpers.MethodSignatures["java/io/StringWriter.write(Ljava/lang/String;II)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  textWriterWriteString,
+		}
+}
+
+// java/io/StringWriter.<init>()V and <init>(I)V
+func stringWriterInit(params []interface{}) interface{} {
+	if len(params) == 2 && params[1].(int64) < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative buffer size")
+	}
+	return textWriterInit(params)
+}
+
+func textWriterInit(params []interface{}) interface{} {
+	self, ok := params[0].(*object.Object)
+	if !ok {
+		errMsg := fmt.Sprintf("textWriterInit: Expected a writer object, observed %T", params[0])
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	text := new(strings.Builder)
+	if len(params) == 2 {
+		text.Grow(int(params[1].(int64)))
+	}
+	self.FieldTable[fieldNameText] = object.Field{Ftype: types.RawGoPointer, Fvalue: text}
+	return nil
+}
+
+// textWriterText returns the text collected by a StringWriter or CharArrayWriter.
+func textWriterText(this any, caller string) (*strings.Builder, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": writer is null")
+	}
+	text, ok := self.FieldTable[fieldNameText].Fvalue.(*strings.Builder)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": writer was not initialized")
+	}
+	return text, nil
+}
+
+// charArrayText returns the characters of a char[] argument, or of the slice [offset, offset+length)
+// if params holds an offset and length after the array.
+func charArrayText(params []interface{}, caller string) (string, *ghelpers.GErrBlk) {
+	arrObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": char array is null")
+	}
+	chars, _ := arrObj.FieldTable["value"].Fvalue.([]int64)
+	if len(params) == 4 {
+		offset, length := params[2].(int64), params[3].(int64)
+		if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+			errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", offset, offset, length, len(chars))
+			return "", ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+		chars = chars[offset : offset+length]
+	}
+	runes := make([]rune, len(chars))
+	for ix, ch := range chars {
+		runes[ix] = rune(ch)
+	}
+	return string(runes), nil
+}
+
+// write(I)V -- writes the character in the low-order 16 bits of the argument
+func textWriterWriteChar(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "write")
+	if gerr != nil {
+		return gerr
+	}
+	text.WriteRune(rune(uint16(params[1].(int64))))
+	return nil
+}
+
+// write([C)V and write([CII)V
+func textWriterWriteChars(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "write")
+	if gerr != nil {
+		return gerr
+	}
+	str, gerr := charArrayText(params, "write")
+	if gerr != nil {
+		return gerr
+	}
+	text.WriteString(str)
+	return nil
+}
+
+// write(Ljava/lang/String;)V and write(Ljava/lang/String;II)V
+func textWriterWriteString(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "write")
+	if gerr != nil {
+		return gerr
+	}
+	strObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "write: string is null")
+	}
+	str := object.GoStringFromStringObject(strObj)
+	if len(params) == 4 {
+		offset, length := params[2].(int64), params[3].(int64)
+		chars := javaChars(str)
+		if offset < 0 || length < 0 || offset+length > int64(len(chars)) {
+			errMsg := fmt.Sprintf("start %d, end %d, length %d", offset, offset+length, len(chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+		str = string(utf16.Decode(chars[offset : offset+length]))
+	}
+	text.WriteString(str)
+	return nil
+}
+
+// append(C) -- returns the writer
+func textWriterAppendChar(params []interface{}) interface{} {
+	if ret := textWriterWriteChar(params); ret != nil {
+		return ret
+	}
+	return params[0]
+}
+
+// append(Ljava/lang/CharSequence;) and append(Ljava/lang/CharSequence;II) -- return the writer
+func textWriterAppend(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	text, gerr := textWriterText(params[0], "append")
+	if gerr != nil {
+		return gerr
+	}
+	str, gerr := charSequenceToGoString(fs, params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 4 { // start and end index the chars of the sequence, not its UTF-8 bytes
+		chars := javaChars(str)
+		start, end := params[2].(int64), params[3].(int64)
+		if start < 0 || end < start || end > int64(len(chars)) {
+			errMsg := fmt.Sprintf("start %d, end %d, length %d", start, end, len(chars))
+			return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+		}
+		str = string(utf16.Decode(chars[start:end]))
+	}
+	text.WriteString(str)
+	return params[0]
+}
+
+// toString()Ljava/lang/String;
+func textWriterToString(params []interface{}) interface{} {
+	text, gerr := textWriterText(params[0], "toString")
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(text.String())
+}
@@ -22,6 +22,7 @@ import (
 	"jacobin/src/util"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 // StackTraceElement is a class primarily used by Throwable to gather data about the
@@ -108,7 +109,7 @@ func Load_Lang_StackTraceELement() {
 	ghelpers.MethodSignatures["java/lang/StackTraceElement.toString()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  steToString,
 		}
 
 }
@@ -195,6 +196,16 @@ func steGetModuleName(params []interface{}) interface{} {
 	return object.StringObjectFromGoString(str)
 }
 
+// java/lang/StackTraceElement.toString()Ljava/lang/String; -- in the format of printStackTrace()
+func steToString(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		errMsg := fmt.Sprintf("steToString: params[0] not an object, saw: %T", this)
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	return object.StringObjectFromGoString(exceptions.StackTraceElementString(this))
+}
+
 /*
 	 From the Java code for this method:
 
@@ -259,10 +270,28 @@ func initStackTraceElements(params []interface{}) interface{} {
 	rawSteArray := arrayObj.FieldTable["value"].Fvalue.([]*object.Object)
 
 	throwable := params[1].(*object.Object) // pointer to the Throwable object
-	jvmStack := throwable.FieldTable["frameStackRef"].Fvalue.(*list.List)
 
-	var i = 0
 	isFirstFrame := true
+	for i, frame := range stackTraceFrames(throwable) {
+		if i >= len(rawSteArray) {
+			break
+		}
+		initStackTraceElement(rawSteArray[i], frame, isFirstFrame)
+		isFirstFrame = false
+	}
+
+	return nil
+}
+
+// stackTraceFrames returns the frames of the JVM stack that the stack trace of throwable shows,
+// from the top of the stack down. As in HotSpot, the frames at the top that created the
+// throwable are left out: those of fillInStackTrace() and of the constructors of the
+// throwable's class and its superclasses.
+func stackTraceFrames(throwable *object.Object) []*frames.Frame {
+	jvmStack := throwable.FieldTable["frameStackRef"].Fvalue.(*list.List)
+
+	var stackFrames []*frames.Frame
+	var throwableClasses map[string]bool // looked up at the first constructor frame
 	for e := jvmStack.Front(); e != nil; e = e.Next() {
 		frame := e.Value.(*frames.Frame)
 
@@ -273,13 +302,36 @@ func initStackTraceElements(params []interface{}) interface{} {
 			continue
 		}
 
-		ste := rawSteArray[i]
-		i += 1
-		initStackTraceElement(ste, frame, isFirstFrame)
-		isFirstFrame = false
+		if len(stackFrames) == 0 {
+			if frame.MethName == "fillInStackTrace" {
+				continue
+			}
+			if frame.MethName == "<init>" {
+				if throwableClasses == nil {
+					throwableClasses = classAndSuperclasses(object.GoStringFromStringPoolIndex(throwable.KlassName))
+				}
+				if throwableClasses[frame.ClName] {
+					continue
+				}
+			}
+		}
+		stackFrames = append(stackFrames, frame)
 	}
+	return stackFrames
+}
 
-	return nil
+// classAndSuperclasses returns the set of the names of className and its superclasses.
+func classAndSuperclasses(className string) map[string]bool {
+	names := make(map[string]bool)
+	for className != "" && !names[className] {
+		names[className] = true
+		klass := classloader.MethAreaFetch(className)
+		if klass == nil || klass.Data == nil || klass.Data.SuperclassIndex == types.InvalidStringIndex {
+			break
+		}
+		className = object.GoStringFromStringPoolIndex(klass.Data.SuperclassIndex)
+	}
+	return names
 }
 
 // initStackTraceElement accepts a single stackTraceElement and JVM stack
@@ -312,7 +364,13 @@ func initStackTraceElement(ste *object.Object, frm *frames.Frame, isFirstFrame b
 	}
 	addField("classLoaderName", methClass.Loader)
 	addField("fileName", methClass.Data.SourceFile)
-	addField("moduleName", methClass.Data.Module)
+
+	// classes of the JDK have the name of the module in the jmod file they come from
+	moduleName := methClass.Data.Module
+	if moduleName == "" && classloader.JmodMapSize() > 0 {
+		moduleName = strings.TrimSuffix(classloader.JmodMapFetch(frame.ClName), ".jmod")
+	}
+	addField("moduleName", moduleName)
 
 	// now get the source line number for any non-JDK classes and non-constructors
 	// Unsure why this limitation. It's commented out for the moment (JACOBIN-781)
@@ -71,32 +71,32 @@ func Load_Lang_String() {
 			GFunction:  ghelpers.TrapDeprecated,
 		}
 
-	// TODO: String(byte[] bytes, int offset, int length, String charsetName) *********** CHARSET
+	// String(byte[] bytes, int offset, int length, String charsetName)
 	ghelpers.MethodSignatures["java/lang/String.<init>([BIILjava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 4,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  newStringFromBytesCharset,
 		}
 
-	// TODO: String(byte[] bytes, int offset, int length, Charset charset) ************** CHARSET
+	// String(byte[] bytes, int offset, int length, Charset charset)
 	ghelpers.MethodSignatures["java/lang/String.<init>([BIILjava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 4,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  newStringFromBytesCharset,
 		}
 
-	// TODO: String(byte[] bytes, String charsetName) *********************************** CHARSET
+	// String(byte[] bytes, String charsetName)
 	ghelpers.MethodSignatures["java/lang/String.<init>([BLjava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  newStringFromBytesCharset,
 		}
 
-	// TODO: String(byte[] bytes, Charset charset) ************************************** CHARSET
+	// String(byte[] bytes, Charset charset)
 	ghelpers.MethodSignatures["java/lang/String.<init>([BLjava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  newStringFromBytesCharset,
 		}
 
 	// Instantiate a String from a character array
@@ -148,34 +148,6 @@ func Load_Lang_String() {
 			GFunction:  newStringFromString,
 		}
 
-	// TODO: String(byte[] bytes, int offset, int length, String charsetName) *********** CHARSET
-	ghelpers.MethodSignatures["java/lang/String.<init>([BIILjava/lang/String;)V"] =
-		ghelpers.GMeth{
-			ParamSlots: 4,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
-	// TODO: String(byte[] bytes, int offset, int length, Charset charset) ************** CHARSET
-	ghelpers.MethodSignatures["java/lang/String.<init>([BIILjava/nio/charset/Charset;)V"] =
-		ghelpers.GMeth{
-			ParamSlots: 4,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
-	// TODO: String(byte[] bytes, String charsetName) *********************************** CHARSET
-	ghelpers.MethodSignatures["java/lang/String.<init>([BLjava/lang/String;)V"] =
-		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
-	// TODO: String(byte[] bytes, Charset charset) ************************************** CHARSET
-	ghelpers.MethodSignatures["java/lang/String.<init>([BLjava/nio/charset/Charset;)V"] =
-		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
-		}
-
 	// TODO: String(byte[] bytes, int hibyte, int offset, int count) *** DEPRECATED
 	ghelpers.MethodSignatures["java/lang/String.<init>([BIII)V"] =
 		ghelpers.GMeth{
@@ -371,14 +343,14 @@ func Load_Lang_String() {
 			GFunction:  ghelpers.TrapDeprecated,
 		}
 
-	// TODO: Encodes this String into a sequence of bytes using the given charset, storing the result into a new byte array.
+	// Encodes this String into a sequence of bytes using the given charset, storing the result into a new byte array.
 	ghelpers.MethodSignatures["java/lang/String.getBytes(Ljava/nio/charset/Charset;)[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  getBytesFromString,
 		}
 
-	// Encodes this String into a sequence of bytes using the named charset, storing the result into a new byte array. ************************ CHARSET
+	// Encodes this String into a sequence of bytes using the named charset, storing the result into a new byte array.
 	ghelpers.MethodSignatures["java/lang/String.getBytes(Ljava/lang/String;)[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
@@ -973,10 +945,10 @@ func newStringFromBytes(params []interface{}) interface{} {
 	switch fld.Fvalue.(type) {
 	case []byte:
 		bytes := object.JavaByteArrayFromGoByteArray(fld.Fvalue.([]byte))
-		object.UpdateValueFieldFromJavaBytes(obj, bytes)
+		object.UpdateValueFieldFromJavaBytes(obj, decodeWithDefaultCharset(bytes))
 	case []types.JavaByte:
 		bytes := fld.Fvalue.([]types.JavaByte)
-		object.UpdateValueFieldFromJavaBytes(obj, bytes)
+		object.UpdateValueFieldFromJavaBytes(obj, decodeWithDefaultCharset(bytes))
 	}
 	return nil
 }
@@ -1018,10 +990,65 @@ func newStringFromBytesSubset(params []interface{}) interface{} {
 
 	// Compute subarray and update params[0].
 	bytes = bytes[ssStart : ssStart+ssLen]
-	object.UpdateValueFieldFromJavaBytes(obj, bytes)
+	object.UpdateValueFieldFromJavaBytes(obj, decodeWithDefaultCharset(bytes))
 	return nil
 }
 
+// decodeWithDefaultCharset converts bytes in the default charset to the UTF-8 of a String.
+func decodeWithDefaultCharset(bytes []types.JavaByte) []types.JavaByte {
+	cs := ghelpers.DefaultCharset()
+	if cs == ghelpers.CharsetUTF8 {
+		return bytes
+	}
+	return object.JavaByteArrayFromGoString(cs.DecodeReplacing(object.GoByteArrayFromJavaByteArray(bytes)))
+}
+
+// Construct a string object from a byte array, or a subset of one, in the given charset.
+// "java/lang/String.<init>([BLjava/nio/charset/Charset;)V", "java/lang/String.<init>([BLjava/lang/String;)V"
+// and the subset variants, "java/lang/String.<init>([BIILjava/nio/charset/Charset;)V" and
+// "java/lang/String.<init>([BIILjava/lang/String;)V". Bad input becomes U+FFFD.
+func newStringFromBytesCharset(params []interface{}) interface{} {
+	// params[0] = reference string (to be updated with byte array)
+	// params[1] = byte array object
+	// params[2], params[3] = start offset and length, for the subset variants
+	// last = Charset object or charset name
+	arr, ok := params[1].(*object.Object)
+	if params[0] == nil || !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "newStringFromBytesCharset: null parameter")
+	}
+	var bytes []byte
+	switch value := arr.FieldTable["value"].Fvalue.(type) {
+	case []byte:
+		bytes = value
+	case []types.JavaByte:
+		bytes = object.GoByteArrayFromJavaByteArray(value)
+	}
+	if len(params) == 5 {
+		ssStart, ssLen := params[2].(int64), params[3].(int64)
+		if ssStart < 0 || ssLen < 0 || ssStart+ssLen > int64(len(bytes)) {
+			errMsg := fmt.Sprintf("newStringFromBytesCharset: offset %d, count %d, length %d", ssStart, ssLen, len(bytes))
+			return ghelpers.GetGErrBlk(excNames.StringIndexOutOfBoundsException, errMsg)
+		}
+		bytes = bytes[ssStart : ssStart+ssLen]
+	}
+	cs, gerr := charsetArgument(params[len(params)-1])
+	if gerr != nil {
+		return gerr
+	}
+	object.UpdateValueFieldFromJavaBytes(params[0].(*object.Object),
+		object.JavaByteArrayFromGoString(cs.DecodeReplacing(bytes)))
+	return nil
+}
+
+// charsetArgument returns the charset for a Charset argument or, as with the String methods that
+// take a charset name, a String that names one.
+func charsetArgument(arg any) (*ghelpers.Charset, *ghelpers.GErrBlk) {
+	if obj, ok := arg.(*object.Object); ok && object.IsStringObject(obj) {
+		return ghelpers.CharsetFromName(obj)
+	}
+	return ghelpers.CharsetFromObject(arg)
+}
+
 // Instantiate a new string object from a Go int64 array (Java char array).
 // "java/lang/String.<init>([C)V"
 func newStringFromChars(params []interface{}) interface{} {
@@ -1274,18 +1301,22 @@ func sprintf(params []interface{}) interface{} {
 	return misc.StringFormatter(params)
 }
 
-// java/lang/String.getBytes()[B
+// java/lang/String.getBytes()[B, getBytes(Ljava/lang/String;)[B and getBytes(Ljava/nio/charset/Charset;)[B
+// What the charset cannot encode is replaced by its replacement bytes.
 func getBytesFromString(params []interface{}) interface{} {
 	// params[0] = reference string with byte array to be returned
+	// params[1] = Charset object or charset name, if any
 	bytes := object.JavaByteArrayFromStringObject(params[0].(*object.Object))
+	cs := ghelpers.DefaultCharset()
 	if len(params) > 1 {
-		obj := params[1].(*object.Object)
-		charsetName := object.GoStringFromStringObject(obj)
-		if strings.ToUpper(charsetName) != "UTF-8" {
-			errMsg := fmt.Sprintf("getBytesFromString: expected UTF-8, observed: %s", charsetName)
-			return ghelpers.GetGErrBlk(excNames.UnsupportedEncodingException, errMsg)
+		var gerr *ghelpers.GErrBlk
+		if cs, gerr = charsetArgument(params[1]); gerr != nil {
+			return gerr
 		}
 	}
+	if cs != ghelpers.CharsetUTF8 {
+		bytes = object.JavaByteArrayFromGoByteArray(cs.EncodeReplacing(object.GoStringFromJavaByteArray(bytes)))
+	}
 	return object.MakePrimitiveObject("[B", types.JavaByteArray, bytes)
 }
 
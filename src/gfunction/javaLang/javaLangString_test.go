@@ -1277,7 +1277,7 @@ func TestString_GetBytes_CaseInsensitive(t *testing.T) {
 func TestString_GetBytes_UnsupportedEncoding(t *testing.T) {
 	globals.InitStringPool()
 	strObj := object.StringObjectFromGoString("Hello")
-	charsetObj := object.StringObjectFromGoString("UTF-16")
+	charsetObj := object.StringObjectFromGoString("x-no-such-charset")
 	out := getBytesFromString([]interface{}{strObj, charsetObj})
 	errBlk, ok := out.(*ghelpers.GErrBlk)
 	if !ok {
@@ -1286,11 +1286,40 @@ func TestString_GetBytes_UnsupportedEncoding(t *testing.T) {
 	if errBlk.ExceptionType != excNames.UnsupportedEncodingException {
 		t.Fatalf("expected ExceptionType %d, got %d", excNames.UnsupportedEncodingException, errBlk.ExceptionType)
 	}
-	if !strings.Contains(errBlk.ErrMsg, "UTF-16") {
+	if !strings.Contains(errBlk.ErrMsg, "x-no-such-charset") {
 		t.Fatalf("error message mismatch: %s", errBlk.ErrMsg)
 	}
 }
 
+func TestString_GetBytes_Latin1(t *testing.T) {
+	globals.InitStringPool()
+	strObj := object.StringObjectFromGoString("olé€")
+	charsetObj := object.StringObjectFromGoString("ISO-8859-1")
+	out := getBytesFromString([]interface{}{strObj, charsetObj})
+	arrObj, ok := out.(*object.Object)
+	if !ok {
+		t.Fatalf("getBytesFromString did not return object, got %T", out)
+	}
+	// the euro sign is not in Latin-1, so it is replaced
+	if gotBytes := bytesFromByteArrayObject(arrObj); string(gotBytes) != "ol\xe9?" {
+		t.Fatalf("byte content mismatch: got % X", gotBytes)
+	}
+}
+
+func TestString_NewFromBytesCharset_Windows1252(t *testing.T) {
+	globals.InitStringPool()
+	strObj := object.StringObjectFromGoString("")
+	arr := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray([]byte{'x', 0x80, 0xE9, 'y'}))
+	ret := newStringFromBytesCharset([]interface{}{strObj, arr, int64(1), int64(2), object.StringObjectFromGoString("Cp1252")})
+	if ret != nil {
+		t.Fatalf("newStringFromBytesCharset returned %v", ret)
+	}
+	if got := object.GoStringFromStringObject(strObj); got != "€é" {
+		t.Fatalf("string mismatch: got %q", got)
+	}
+}
+
 func TestString_GetBytes_NullByte(t *testing.T) {
 	globals.InitStringPool()
 	// Test a string containing a null byte: "" + "\000"
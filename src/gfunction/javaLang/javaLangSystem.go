@@ -146,19 +146,19 @@ func Load_Lang_System() {
 	ghelpers.MethodSignatures["java/lang/System.setIn(Ljava/io/InputStream;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  systemSetIn,
 		}
 
 	ghelpers.MethodSignatures["java/lang/System.setOut(Ljava/io/PrintStream;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  systemSetOut,
 		}
 
 	ghelpers.MethodSignatures["java/lang/System.setErr(Ljava/io/PrintStream;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  systemSetErr,
 		}
 
 	ghelpers.MethodSignatures["java/lang/System.inheritedChannel()Ljava/nio/channels/Channel;"] =
@@ -441,6 +441,32 @@ func systemArrayCopy(params []interface{}) interface{} {
 	return nil
 }
 
+// java/lang/System.setIn(Ljava/io/InputStream;)V
+func systemSetIn(params []interface{}) interface{} {
+	return systemSetStream("in", "Ljava/io/InputStream;", params[0])
+}
+
+// java/lang/System.setOut(Ljava/io/PrintStream;)V
+func systemSetOut(params []interface{}) interface{} {
+	return systemSetStream("out", "Ljava/io/PrintStream;", params[0])
+}
+
+// java/lang/System.setErr(Ljava/io/PrintStream;)V
+func systemSetErr(params []interface{}) interface{} {
+	return systemSetStream("err", "Ljava/io/PrintStream;", params[0])
+}
+
+// systemSetStream replaces System.in, System.out, or System.err. Initially these statics hold
+// the *os.File of the standard stream; after redirection they hold the Java stream object, which
+// the PrintStream G functions resolve to its target. Passing the original *os.File restores it.
+func systemSetStream(name, ftype string, stream any) interface{} {
+	if _, ok := stream.(*os.File); ok {
+		ftype = "GS"
+	}
+	_ = statics.AddStatic("java/lang/System."+name, statics.Static{Type: ftype, Value: stream})
+	return nil
+}
+
 // Return the system input console as a *os.File.
 func systemConsole([]interface{}) interface{} {
 	return statics.GetStaticValue("java/lang/System", "in")
@@ -10,14 +10,17 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"io"
 	"jacobin/src/excNames"
+	"jacobin/src/exceptions"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
 	"jacobin/src/shutdown"
 	"jacobin/src/statics"
-	"jacobin/src/stringPool"
 	"jacobin/src/trace"
 	"jacobin/src/types"
+	"jacobin/src/util"
+	"strings"
 )
 
 func Load_Lang_Throwable() {
@@ -44,20 +47,29 @@ func Load_Lang_Throwable() {
 
 	ghelpers.MethodSignatures["java/lang/Throwable.<init>(Ljava/lang/String;Ljava/lang/Throwable;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    throwableInitStringCause,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/lang/Throwable.<init>(Ljava/lang/String;Ljava/lang/Throwable;ZZ)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   4,
+			GFunction:    throwableInitStringCauseFlags,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.<init>(Ljava/lang/Throwable;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    throwableInitWithCause,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.addSuppressed(Ljava/lang/Throwable;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  throwableAddSuppressed,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.fillInStackTrace()Ljava/lang/Throwable;"] =
@@ -70,13 +82,14 @@ func Load_Lang_Throwable() {
 	ghelpers.MethodSignatures["java/lang/Throwable.getCause()Ljava/lang/Throwable;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  throwableGetCause,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.getLocalizedMessage()Ljava/lang/String;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    throwableGetLocalizedMessage,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.getMessage()Ljava/lang/String;"] =
@@ -95,52 +108,70 @@ func Load_Lang_Throwable() {
 	ghelpers.MethodSignatures["java/lang/Throwable.getStackTrace()[Ljava/lang/StackTraceElement;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  throwableGetStackTrace,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.getSuppressed()[Ljava/lang/Throwable;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  throwableGetSuppressed,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.initCause(Ljava/lang/Throwable;)Ljava/lang/Throwable;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    throwableInitCause,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.printStackTrace()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  printStackTrace,
+			ParamSlots:   0,
+			GFunction:    printStackTrace,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.printStackTrace(Ljava/io/PrintStream;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  printStackTraceToPrintStream,
+			ParamSlots:   1,
+			GFunction:    printStackTraceToPrintStream,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.printStackTrace(Ljava/io/PrintWriter;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  printStackTraceToPrintWriter,
+			ParamSlots:   1,
+			GFunction:    printStackTraceToPrintWriter,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.setStackTrace([Ljava/lang/StackTraceElement;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  throwableSetStackTrace,
 		}
 
 	ghelpers.MethodSignatures["java/lang/Throwable.toString()Ljava/lang/String;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    throwableToString,
+			NeedsContext: true,
 		}
 }
 
+const (
+	throwableClassName = "java/lang/Throwable"
+	throwableType      = "Ljava/lang/Throwable;"
+	stringReturnType   = "()Ljava/lang/String;"
+
+	// Fields of a Throwable that are particular to Jacobin. The JDK marks a cause that has not
+	// been set by having the cause field refer to the Throwable itself, and turns suppression off
+	// by making suppressedExceptions null. In Jacobin, a Throwable made by the JVM starts out with
+	// null in both fields, so these fields record those states instead.
+	fieldNameCauseSet            = "causeSet"            // types.Bool: the cause can no longer be set
+	fieldNameSuppressionDisabled = "suppressionDisabled" // types.Bool: addSuppressed() does nothing
+)
+
 // This method duplicates the following bytecode, with these exceptions:
 //  1. we don't check for assertion status, which is determined already at start-up
 //  2. for the nonce, Throwable.SUPPRESSED_SENTINEL is set to nil. It's unlikely we'll
@@ -203,16 +234,8 @@ func ThrowableInitNull(params []interface{}) interface{} {
 		return errors.New(errMsg)
 	}
 
-	// detailMessage = null
-	thisObj.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName, Fvalue: object.Null}
-	// cause = null (not strictly required elsewhere yet)
-	thisObj.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: object.Null}
-	// suppressedExceptions left unset for now; can be initialized lazily if needed
-
-	// Populate stack trace similar to fillInStackTrace()
-	if frameStack != nil {
-		_ = FillInStackTrace([]interface{}{frameStack, thisObj})
-	}
+	// detailMessage = null, cause not yet set
+	initThrowable(frameStack, thisObj, object.Null, nil, true, true)
 	return nil
 }
 
@@ -237,166 +260,292 @@ func throwableInitString(params []interface{}) interface{} {
 		shutdown.Exit(shutdown.JVM_EXCEPTION)
 		return errors.New(errMsg)
 	}
-	thisObj.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName, Fvalue: msgObj}
 
-	// cause = null by default
-	thisObj.FieldTable["cause"] = object.Field{Ftype: "Ljava/lang/Throwable;", Fvalue: object.Null}
+	// cause not yet set
+	initThrowable(frameStack, thisObj, msgObj, nil, true, true)
+	return nil
+}
 
-	// Populate stack trace
-	if frameStack != nil {
-		_ = FillInStackTrace([]interface{}{frameStack, thisObj})
+// java/lang/Throwable.<init>(Ljava/lang/String;Ljava/lang/Throwable;)V
+func throwableInitStringCause(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	this := params[0].(*object.Object)
+	initThrowable(fs, this, params[1].(*object.Object), params[2].(*object.Object), true, true)
+	return nil
+}
+
+// java/lang/Throwable.<init>(Ljava/lang/String;Ljava/lang/Throwable;ZZ)V -- the protected
+// constructor that can turn off suppression and the stack trace
+func throwableInitStringCauseFlags(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	this := params[0].(*object.Object)
+	enableSuppression := params[3].(int64) != 0
+	writableStackTrace := params[4].(int64) != 0
+	initThrowable(fs, this, params[1].(*object.Object), params[2].(*object.Object),
+		enableSuppression, writableStackTrace)
+	return nil
+}
+
+// java/lang/Throwable.<init>(Ljava/lang/Throwable;)V -- the detail message is cause.toString()
+func throwableInitWithCause(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	this := params[0].(*object.Object)
+	cause := params[1].(*object.Object)
+	message := object.Null
+	if !object.IsNull(cause) {
+		message = object.StringObjectFromGoString(ThrowableDescriber(fs)(cause))
 	}
+	initThrowable(fs, this, message, cause, true, true)
 	return nil
 }
 
-// printStackTrace prints the stack trace to System.err
-func printStackTrace(params []interface{}) interface{} {
-	if len(params) != 1 {
-		errMsg := fmt.Sprintf("printStackTrace: expected 1 parameter, got: %d", len(params))
-		trace.Error(errMsg)
-		shutdown.Exit(shutdown.JVM_EXCEPTION)
-		return errors.New(errMsg)
+// initThrowable sets the fields of a new Throwable, as its constructors do. cause is nil if the
+// constructor does not set it, in which case it can be set once later by initCause(). If the
+// stack trace is not writable, the stackTrace field is null, as in the JDK, and otherwise it is
+// filled in from the frame stack fs, if there is one.
+func initThrowable(fs *list.List, this, message, cause *object.Object, enableSuppression, writableStackTrace bool) {
+	this.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName, Fvalue: message}
+	if cause == nil {
+		this.FieldTable["cause"] = object.Field{Ftype: throwableType, Fvalue: object.Null}
+	} else {
+		this.FieldTable["cause"] = object.Field{Ftype: throwableType, Fvalue: cause}
+		this.FieldTable[fieldNameCauseSet] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	}
+	if !enableSuppression {
+		this.FieldTable[fieldNameSuppressionDisabled] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
 	}
 
-	throwable := params[0].(*object.Object)
-	printStackTraceToWriter(throwable, statics.GetStaticValue("java/lang/System", "err"))
-	return nil
+	if !writableStackTrace {
+		this.FieldTable["stackTrace"] = object.Field{Ftype: types.Ref, Fvalue: object.Null}
+	} else if fs != nil {
+		_ = FillInStackTrace([]interface{}{fs, this})
+	}
 }
 
-// printStackTraceToPrintStream prints the stack trace to the specified PrintStream
-func printStackTraceToPrintStream(params []interface{}) interface{} {
-	if len(params) != 2 {
-		errMsg := fmt.Sprintf("printStackTraceToPrintStream: expected 2 parameters, got: %d", len(params))
-		trace.Error(errMsg)
-		shutdown.Exit(shutdown.JVM_EXCEPTION)
-		return errors.New(errMsg)
+// java/lang/Throwable.getCause()Ljava/lang/Throwable;
+func throwableGetCause(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	if cause := exceptions.ThrowableCause(this); cause != nil {
+		return cause
 	}
+	return object.Null
+}
 
-	throwable := params[0].(*object.Object)
-	printStream := params[1]
-	printStackTraceToWriter(throwable, printStream)
-	return nil
+// java/lang/Throwable.initCause(Ljava/lang/Throwable;)Ljava/lang/Throwable; -- sets the cause
+// if no constructor or earlier call has set it, and returns this Throwable
+func throwableInitCause(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	this := params[0].(*object.Object)
+	cause := params[1].(*object.Object)
+
+	if this.FieldTable[fieldNameCauseSet].Fvalue == types.JavaBoolTrue {
+		causeString := "a null"
+		if !object.IsNull(cause) {
+			causeString = ThrowableDescriber(fs)(cause)
+		}
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "Can't overwrite cause with "+causeString)
+	}
+	if cause == this {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Self-causation not permitted")
+	}
+
+	this.FieldTable["cause"] = object.Field{Ftype: throwableType, Fvalue: cause}
+	this.FieldTable[fieldNameCauseSet] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	return this
 }
 
-// printStackTraceToPrintWriter prints the stack trace to the specified PrintWriter
-func printStackTraceToPrintWriter(params []interface{}) interface{} {
-	if len(params) != 2 {
-		errMsg := fmt.Sprintf("printStackTraceToPrintWriter: expected 2 parameters, got: %d", len(params))
-		trace.Error(errMsg)
-		shutdown.Exit(shutdown.JVM_EXCEPTION)
-		return errors.New(errMsg)
+// java/lang/Throwable.addSuppressed(Ljava/lang/Throwable;)V -- as try-with-resources does with
+// the exceptions thrown by closing its resources
+func throwableAddSuppressed(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	exception := params[1].(*object.Object)
+	if exception == this {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Self-suppression not permitted")
+	}
+	if object.IsNull(exception) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Cannot suppress a null exception.")
+	}
+	if this.FieldTable[fieldNameSuppressionDisabled].Fvalue == types.JavaBoolTrue {
+		return nil
 	}
 
-	throwable := params[0].(*object.Object)
-	printWriter := params[1]
-	printStackTraceToWriter(throwable, printWriter)
+	suppressed := append(exceptions.SuppressedExceptions(this), exception)
+	this.FieldTable["suppressedExceptions"] = object.Field{
+		Ftype:  types.RefArray + throwableClassName + ";",
+		Fvalue: refArrayOf(throwableClassName+";", suppressed),
+	}
 	return nil
 }
 
-// printStackTraceToWriter is the common implementation for printing stack traces
-func printStackTraceToWriter(throwable *object.Object, writer interface{}) {
-	// Get the stack trace from the throwable's stackTrace field
-	var stackTraceObj *object.Object
-	if stackTraceField, ok := throwable.FieldTable["stackTrace"]; ok {
-		if stackTraceField.Fvalue != object.Null {
-			stackTraceObj = stackTraceField.Fvalue.(*object.Object)
+// java/lang/Throwable.getSuppressed()[Ljava/lang/Throwable;
+func throwableGetSuppressed(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	return refArrayOf(throwableClassName+";", exceptions.SuppressedExceptions(this))
+}
+
+// java/lang/Throwable.getStackTrace()[Ljava/lang/StackTraceElement; -- returns a copy of the
+// stack trace, so that changes to it do not affect the Throwable
+func throwableGetStackTrace(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	return refArrayOf("java/lang/StackTraceElement;", exceptions.StackTraceElements(this))
+}
+
+// java/lang/Throwable.setStackTrace([Ljava/lang/StackTraceElement;)V -- sets a copy of the
+// given stack trace, unless the Throwable was made with a stack trace that is not writable
+func throwableSetStackTrace(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	arr := params[1].(*object.Object)
+	if object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException,
+			"Cannot invoke \"Object.clone()\" because \"stackTrace\" is null")
+	}
+	elements, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	for i, ste := range elements {
+		if object.IsNull(ste) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, fmt.Sprintf("stackTrace[%d]", i))
 		}
 	}
 
-	// Get the exception class name
-	classNamePtr := stringPool.GetStringPointer(throwable.KlassName)
-	className := ""
-	if classNamePtr != nil {
-		className = *classNamePtr
-	}
-
-	// Get the detail message if present
-	var message string
-	if detailMsgField, ok := throwable.FieldTable["detailMessage"]; ok {
-		if detailMsgField.Fvalue != object.Null {
-			if msgObj, ok := detailMsgField.Fvalue.(*object.Object); ok {
-				if msgObj.FieldTable != nil {
-					if valueField, ok := msgObj.FieldTable["value"]; ok {
-						switch v := valueField.Fvalue.(type) {
-						case []byte:
-							message = string(v)
-						case []types.JavaByte:
-							message = object.GoStringFromJavaByteArray(v)
-						}
-					}
-				}
-			}
-		}
+	if fld, ok := this.FieldTable["stackTrace"]; ok && fld.Fvalue == object.Null {
+		return nil // the stack trace is not writable
 	}
+	this.FieldTable["stackTrace"] = object.Field{
+		Ftype:  types.Ref,
+		Fvalue: refArrayOf("java/lang/StackTraceElement;", elements),
+	}
+	return nil
+}
 
-	// Print the exception header
-	if message != "" {
-		fmt.Fprintf(writer.(interface{ Write([]byte) (int, error) }), "%s: %s\n", className, message)
+// refArrayOf returns a new array of the element type elemType that holds a copy of elements.
+func refArrayOf(elemType string, elements []*object.Object) *object.Object {
+	arr := object.Make1DimRefArray(elemType, int64(len(elements)))
+	copy(arr.FieldTable["value"].Fvalue.([]*object.Object), elements)
+	return arr
+}
+
+// java/lang/Throwable.toString()Ljava/lang/String; -- the class name and the localized
+// message, if there is one
+func throwableToString(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	this := params[0].(*object.Object)
+	str, gerr := throwableString(fs, this)
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(str)
+}
+
+// throwableString returns what Throwable.toString() returns for this, calling overrides of
+// getLocalizedMessage() and getMessage() on the frame stack fs.
+func throwableString(fs *list.List, this *object.Object) (string, *ghelpers.GErrBlk) {
+	className := util.ConvertInternalClassNameToUserFormat(object.GoStringFromStringPoolIndex(this.KlassName))
+	var msg *object.Object
+	var gerr *ghelpers.GErrBlk
+	if fs != nil && ghelpers.HasJavaOverride(this, "getLocalizedMessage", stringReturnType, throwableClassName) {
+		msg, gerr = invokeStringMethod(fs, this, "getLocalizedMessage")
 	} else {
-		fmt.Fprintf(writer.(interface{ Write([]byte) (int, error) }), "%s\n", className)
-	}
-
-	// Print each stack trace element
-	if stackTraceObj != nil && stackTraceObj.FieldTable != nil {
-		if arrayField, ok := stackTraceObj.FieldTable["value"]; ok {
-			if elements, ok := arrayField.Fvalue.([]*object.Object); ok {
-				for _, element := range elements {
-					if element != nil && element.FieldTable != nil {
-						// Extract className, methodName, fileName, sourceLine from StackTraceElement
-						var elemClassName, methodName, fileName, sourceLine string
-
-						if field, ok := element.FieldTable["declaringClass"]; ok && field.Fvalue != object.Null {
-							if str, ok := field.Fvalue.(string); ok {
-								elemClassName = str
-							}
-						}
-
-						// Filter out internal exception classes
-						if elemClassName == "java/lang/Throwable" || // don't show Throwable methods
-							elemClassName == "java/lang/Error" || // don't show Error methods
-							elemClassName == "java/lang/AssertionError" { // don't show AssertionError methods
-							continue
-						}
-
-						if field, ok := element.FieldTable["methodName"]; ok && field.Fvalue != object.Null {
-							if str, ok := field.Fvalue.(string); ok {
-								methodName = str
-							}
-						}
-
-						// Filter out constructors
-						if methodName == "<init>" { // don't show constructors
-							continue
-						}
-
-						if field, ok := element.FieldTable["fileName"]; ok && field.Fvalue != object.Null {
-							if str, ok := field.Fvalue.(string); ok {
-								fileName = str
-							}
-						}
-
-						if field, ok := element.FieldTable["sourceLine"]; ok && field.Fvalue != object.Null {
-							if str, ok := field.Fvalue.(string); ok {
-								sourceLine = str
-							}
-						}
-
-						// Format and print the stack trace element
-						if fileName != "" && sourceLine != "" {
-							fmt.Fprintf(writer.(interface{ Write([]byte) (int, error) }), "\tat %s.%s(%s:%s)\n",
-								elemClassName, methodName, fileName, sourceLine)
-						} else if fileName != "" {
-							fmt.Fprintf(writer.(interface{ Write([]byte) (int, error) }), "\tat %s.%s(%s)\n",
-								elemClassName, methodName, fileName)
-						} else {
-							fmt.Fprintf(writer.(interface{ Write([]byte) (int, error) }), "\tat %s.%s(Unknown Source)\n",
-								elemClassName, methodName)
-						}
-					}
+		msg, gerr = throwableMessage(fs, this)
+	}
+	if gerr != nil {
+		return "", gerr
+	}
+	if object.IsNull(msg) {
+		return className, nil
+	}
+	return className + ": " + object.GoStringFromStringObject(msg), nil
+}
+
+// ThrowableDescriber returns a function that gives the toString() of a Throwable, calling an
+// override of toString() on the frame stack fs. It is what exceptions.StackTraceLines uses to
+// describe the throwables in a stack trace.
+func ThrowableDescriber(fs *list.List) func(*object.Object) string {
+	return func(throwable *object.Object) string {
+		if fs != nil && ghelpers.HasJavaOverride(throwable, "toString", stringReturnType, throwableClassName) {
+			if str, gerr := invokeStringMethod(fs, throwable, "toString"); gerr == nil {
+				if object.IsNull(str) {
+					return "null"
 				}
+				return object.GoStringFromStringObject(str)
 			}
 		}
+		if str, gerr := throwableString(fs, throwable); gerr == nil {
+			return str
+		}
+		return exceptions.ThrowableString(throwable)
+	}
+}
+
+// invokeStringMethod calls a method of obj that takes no arguments and returns a String.
+func invokeStringMethod(fs *list.List, obj *object.Object, methName string) (*object.Object, *ghelpers.GErrBlk) {
+	switch ret := ghelpers.InvokeMethodOnObject(fs, obj, methName, stringReturnType).(type) {
+	case *ghelpers.GErrBlk:
+		return nil, ret
+	case *object.Object:
+		return ret, nil
+	}
+	return object.Null, nil
+}
+
+// printStackTrace prints the stack trace to System.err
+func printStackTrace(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	throwable := params[0].(*object.Object)
+	return printStackTraceToWriter(fs, throwable, statics.GetStaticValue("java/lang/System", "err"))
+}
+
+// printStackTraceToPrintStream prints the stack trace to the specified PrintStream
+func printStackTraceToPrintStream(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	throwable := params[0].(*object.Object)
+	return printStackTraceToWriter(fs, throwable, params[1])
+}
+
+// printStackTraceToPrintWriter prints the stack trace to the specified PrintWriter
+func printStackTraceToPrintWriter(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	throwable := params[0].(*object.Object)
+	return printStackTraceToWriter(fs, throwable, params[1])
+}
+
+// printStackTraceToWriter is the common implementation for printing stack traces. As in the
+// JDK, the trace shows the suppressed exceptions and the chain of causes, and the output is
+// written to the stream in one piece. Like the println() of PrintStream and PrintWriter, it
+// does not report errors in writing.
+func printStackTraceToWriter(fs *list.List, throwable *object.Object, stream interface{}) interface{} {
+	writer, gerr := ghelpers.GoWriterWithContext(fs, stream, "printStackTrace")
+	if gerr != nil {
+		return gerr
+	}
+
+	lines := exceptions.StackTraceLines(throwable, ThrowableDescriber(fs))
+	_, _ = io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	return nil
+}
+
+// java/lang/Throwable.getLocalizedMessage()Ljava/lang/String; -- which is getMessage(), unless
+// a subclass overrides it
+func throwableGetLocalizedMessage(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	msg, gerr := throwableMessage(fs, params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return msg
+}
+
+// throwableMessage returns what getMessage() returns for this, calling an override of it on
+// the frame stack fs.
+func throwableMessage(fs *list.List, this *object.Object) (*object.Object, *ghelpers.GErrBlk) {
+	if fs != nil && ghelpers.HasJavaOverride(this, "getMessage", stringReturnType, throwableClassName) {
+		return invokeStringMethod(fs, this, "getMessage")
+	}
+	if msg, ok := this.FieldTable["detailMessage"].Fvalue.(*object.Object); ok {
+		return msg, nil
+	}
+	if msg, ok := exceptions.ThrowableMessage(this); ok {
+		return object.StringObjectFromGoString(msg), nil
 	}
+	return object.Null, nil
 }
 
 // This function is called by Throwable.<init>().
@@ -474,8 +623,7 @@ func getOurStackTrace(params []interface{}) interface{} {
 // slice of entries representing each frame in the JVM stack
 func GetStackTraces(params []interface{}) *object.Object {
 	throwable := params[0].(*object.Object)
-	stack := throwable.FieldTable["frameStackRef"].Fvalue.(*list.List)
-	depth := stack.Len()
+	depth := len(stackTraceFrames(throwable))
 	args := []interface{}{throwable, int64(depth)}
 	retVal := of(args) // this is javaLangStackTraceElement.of()
 	return retVal.(*object.Object)
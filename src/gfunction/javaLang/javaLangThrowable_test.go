@@ -9,7 +9,9 @@ package javaLang
 import (
 	"container/list"
 	"jacobin/src/classloader"
+	"jacobin/src/excNames"
 	"jacobin/src/frames"
+	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
 	"jacobin/src/object"
 	"jacobin/src/statics"
@@ -352,3 +354,185 @@ func TestThrowableInitString_SetsMessageAndStack(t *testing.T) {
 		t.Errorf("classLoaderName mismatch: %v", ste["classLoaderName"].Fvalue)
 	}
 }
+
+// newTestThrowable makes a Throwable of the class className with the message msg, without a
+// stack trace, as Throwable(String) does.
+func newTestThrowable(className, msg string) *object.Object {
+	th := object.MakeEmptyObjectWithClassName(&className)
+	_ = throwableInitString([]interface{}{th, object.StringObjectFromGoString(msg)})
+	return th
+}
+
+func TestThrowableCauseAndInitCause(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.InitStringPool()
+
+	cause := newTestThrowable("java/io/IOException", "disk full")
+	cls := throwableClassName
+	th := object.MakeEmptyObjectWithClassName(&cls)
+	_ = throwableInitStringCause([]interface{}{th, object.StringObjectFromGoString("save failed"), cause})
+	if ret := throwableGetCause([]interface{}{th}); ret != cause {
+		t.Errorf("getCause: expected the cause, observed %v", ret)
+	}
+
+	// a cause set by a constructor cannot be overwritten, even with the same one
+	ret := throwableInitCause([]interface{}{th, cause})
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.IllegalStateException ||
+		gerr.ErrMsg != "Can't overwrite cause with java.io.IOException: disk full" {
+		t.Errorf("initCause after the constructor: expected IllegalStateException, observed %v", ret)
+	}
+
+	// initCause sets the cause once, which can be null
+	th = newTestThrowable("java/lang/RuntimeException", "outer")
+	if ret := throwableGetCause([]interface{}{th}); ret != object.Null {
+		t.Errorf("getCause: expected null, observed %v", ret)
+	}
+	if ret := throwableInitCause([]interface{}{th, th}); ret.(*ghelpers.GErrBlk).ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("initCause(this): expected IllegalArgumentException, observed %v", ret)
+	}
+	if ret := throwableInitCause([]interface{}{th, object.Null}); ret != th {
+		t.Errorf("initCause: expected this, observed %v", ret)
+	}
+	ret = throwableInitCause([]interface{}{th, cause})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ErrMsg != "Can't overwrite cause with java.io.IOException: disk full" {
+		t.Errorf("second initCause: expected IllegalStateException, observed %v", ret)
+	}
+}
+
+func TestThrowableInitWithCause_MessageIsCauseToString(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.InitStringPool()
+
+	cause := newTestThrowable("java/lang/IllegalStateException", "bad state")
+	cls := "java/lang/RuntimeException"
+	th := object.MakeEmptyObjectWithClassName(&cls)
+	_ = throwableInitWithCause([]interface{}{th, cause})
+
+	str := throwableToString([]interface{}{th}).(*object.Object)
+	expected := "java.lang.RuntimeException: java.lang.IllegalStateException: bad state"
+	if object.GoStringFromStringObject(str) != expected {
+		t.Errorf("toString: expected %q, observed %q", expected, object.GoStringFromStringObject(str))
+	}
+
+	th = object.MakeEmptyObjectWithClassName(&cls)
+	_ = throwableInitWithCause([]interface{}{th, object.Null})
+	str = throwableToString([]interface{}{th}).(*object.Object)
+	if object.GoStringFromStringObject(str) != "java.lang.RuntimeException" {
+		t.Errorf("toString with a null cause: observed %q", object.GoStringFromStringObject(str))
+	}
+}
+
+func TestThrowableAddSuppressed(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.InitStringPool()
+
+	th := newTestThrowable("java/lang/IllegalStateException", "in body")
+	if ret := throwableAddSuppressed([]interface{}{th, th}); ret.(*ghelpers.GErrBlk).ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("addSuppressed(this): expected IllegalArgumentException, observed %v", ret)
+	}
+	if ret := throwableAddSuppressed([]interface{}{th, object.Null}); ret.(*ghelpers.GErrBlk).ExceptionType != excNames.NullPointerException {
+		t.Errorf("addSuppressed(null): expected NullPointerException, observed %v", ret)
+	}
+
+	first := newTestThrowable("java/io/IOException", "first")
+	second := newTestThrowable("java/io/IOException", "second")
+	_ = throwableAddSuppressed([]interface{}{th, first})
+	_ = throwableAddSuppressed([]interface{}{th, second})
+	arr := throwableGetSuppressed([]interface{}{th}).(*object.Object)
+	suppressed := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	if len(suppressed) != 2 || suppressed[0] != first || suppressed[1] != second {
+		t.Errorf("getSuppressed: expected [first, second], observed %v", suppressed)
+	}
+
+	// changing the returned array does not change the suppressed exceptions
+	suppressed[0] = second
+	arr = throwableGetSuppressed([]interface{}{th}).(*object.Object)
+	if arr.FieldTable["value"].Fvalue.([]*object.Object)[0] != first {
+		t.Errorf("getSuppressed did not return a copy")
+	}
+
+	// with suppression disabled, addSuppressed() does nothing
+	cls := throwableClassName
+	th = object.MakeEmptyObjectWithClassName(&cls)
+	_ = throwableInitStringCauseFlags([]interface{}{th, object.Null, object.Null, types.JavaBoolFalse, types.JavaBoolTrue})
+	if ret := throwableAddSuppressed([]interface{}{th, first}); ret != nil {
+		t.Errorf("addSuppressed with suppression disabled: unexpected %v", ret)
+	}
+	arr = throwableGetSuppressed([]interface{}{th}).(*object.Object)
+	if len(arr.FieldTable["value"].Fvalue.([]*object.Object)) != 0 {
+		t.Errorf("getSuppressed with suppression disabled: expected no exceptions")
+	}
+}
+
+func TestThrowableGetAndSetStackTrace(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.InitStringPool()
+
+	th := newTestThrowable("java/lang/RuntimeException", "oops")
+	ste := object.MakeEmptyObject()
+	ste.FieldTable["declaringClass"] = object.Field{Ftype: types.GolangString, Fvalue: "Main"}
+	arr := object.Make1DimRefArray("java/lang/StackTraceElement;", 1)
+	arr.FieldTable["value"].Fvalue.([]*object.Object)[0] = ste
+
+	if ret := throwableSetStackTrace([]interface{}{th, arr}); ret != nil {
+		t.Fatalf("setStackTrace: unexpected %v", ret)
+	}
+	arr.FieldTable["value"].Fvalue.([]*object.Object)[0] = object.Null // does not change the trace
+
+	got := throwableGetStackTrace([]interface{}{th}).(*object.Object)
+	elements := got.FieldTable["value"].Fvalue.([]*object.Object)
+	if len(elements) != 1 || elements[0] != ste {
+		t.Errorf("getStackTrace: expected the element that was set, observed %v", elements)
+	}
+
+	ret := throwableSetStackTrace([]interface{}{th, arr})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NullPointerException || gerr.ErrMsg != "stackTrace[0]" {
+		t.Errorf("setStackTrace with a null element: expected NullPointerException, observed %v", ret)
+	}
+	if ret := throwableSetStackTrace([]interface{}{th, object.Null}); ret.(*ghelpers.GErrBlk).ExceptionType != excNames.NullPointerException {
+		t.Errorf("setStackTrace(null): expected NullPointerException, observed %v", ret)
+	}
+}
+
+func TestThrowablePrintStackTrace_SkipsConstructorsOfTheThrowable(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.InitStringPool()
+	trace.Init()
+	classloader.InitMethodArea()
+	globals.GetGlobalRef().FuncInstantiateClass = InstantiateFillIn
+
+	exClass := "com/example/MyException"
+	superclass := "java/lang/RuntimeException"
+	classloader.MethAreaInsert(exClass, &classloader.Klass{Loader: "app", Data: &classloader.ClData{
+		SuperclassIndex: stringPool.GetStringIndex(&superclass), SourceFile: "MyException.java"}})
+	classloader.MethAreaInsert("com/example/Resource", &classloader.Klass{Loader: "app",
+		Data: &classloader.ClData{SourceFile: "Resource.java"}})
+	classloader.MethAreaInsert("com/example/Main", &classloader.Klass{Loader: "app",
+		Data: &classloader.ClData{SourceFile: "Main.java"}})
+
+	// main() calls the constructor of Resource, which creates a MyException
+	jvmStack := frames.CreateFrameStack()
+	for _, names := range [][2]string{{"com/example/Main", "main"}, {"com/example/Resource", "<init>"},
+		{exClass, "<init>"}, {superclass, "<init>"}} {
+		f := frames.CreateFrame(2)
+		f.ClName, f.MethName, f.MethType = names[0], names[1], "()V"
+		_ = frames.PushFrame(jvmStack, f)
+	}
+
+	cause := newTestThrowable("java/io/IOException", "disk full")
+	th := object.MakeEmptyObjectWithClassName(&exClass)
+	_ = throwableInitStringCause([]interface{}{jvmStack, th, object.StringObjectFromGoString("boom"), cause})
+
+	var out strings.Builder
+	if ret := printStackTraceToPrintStream([]interface{}{th, &out}); ret != nil {
+		t.Fatalf("printStackTrace: unexpected %v", ret)
+	}
+	expected := "com.example.MyException: boom\n" +
+		"\tat com.example.Resource.<init>(Resource.java)\n" +
+		"\tat com.example.Main.main(Main.java)\n" +
+		"Caused by: java.io.IOException: disk full\n"
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\nobserved:\n%s", expected, out.String())
+	}
+}
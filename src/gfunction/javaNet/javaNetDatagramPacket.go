@@ -0,0 +1,257 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.net.DatagramPacket: a byte array, the part of it that holds the datagram, and the
+// address the datagram is sent to or was received from. The array is the caller's, so that a
+// received datagram appears in it, as in the JDK.
+
+const datagramPacketClassName = "java/net/DatagramPacket"
+
+func Load_Net_DatagramPacket() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                                 {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>([BI)V":                                {ParamSlots: 2, GFunction: datagramPacketInit},
+		"<init>([BII)V":                               {ParamSlots: 3, GFunction: datagramPacketInit},
+		"<init>([BILjava/net/InetAddress;I)V":         {ParamSlots: 4, GFunction: datagramPacketInit},
+		"<init>([BIILjava/net/InetAddress;I)V":        {ParamSlots: 5, GFunction: datagramPacketInit},
+		"<init>([BILjava/net/SocketAddress;)V":        {ParamSlots: 3, GFunction: datagramPacketInit},
+		"<init>([BIILjava/net/SocketAddress;)V":       {ParamSlots: 4, GFunction: datagramPacketInit},
+		"getAddress()Ljava/net/InetAddress;":          {ParamSlots: 0, GFunction: datagramPacketGetAddress},
+		"getData()[B":                                 {ParamSlots: 0, GFunction: datagramPacketGetData},
+		"getLength()I":                                {ParamSlots: 0, GFunction: datagramPacketGetLength},
+		"getOffset()I":                                {ParamSlots: 0, GFunction: datagramPacketGetOffset},
+		"getPort()I":                                  {ParamSlots: 0, GFunction: datagramPacketGetPort},
+		"getSocketAddress()Ljava/net/SocketAddress;":  {ParamSlots: 0, GFunction: datagramPacketGetSocketAddress},
+		"setAddress(Ljava/net/InetAddress;)V":         {ParamSlots: 1, GFunction: datagramPacketSetAddress},
+		"setData([B)V":                                {ParamSlots: 1, GFunction: datagramPacketSetData},
+		"setData([BII)V":                              {ParamSlots: 3, GFunction: datagramPacketSetData},
+		"setLength(I)V":                               {ParamSlots: 1, GFunction: datagramPacketSetLength},
+		"setPort(I)V":                                 {ParamSlots: 1, GFunction: datagramPacketSetPort},
+		"setSocketAddress(Ljava/net/SocketAddress;)V": {ParamSlots: 1, GFunction: datagramPacketSetSocketAddress},
+	} {
+		ghelpers.MethodSignatures[datagramPacketClassName+"."+sig] = gmeth
+	}
+}
+
+// datagramPacket is the Go state of a DatagramPacket. length is the length of the datagram;
+// bufLength is the space there is for a received one, which receiving does not change.
+type datagramPacket struct {
+	buf       *object.Object
+	offset    int
+	length    int
+	bufLength int
+	address   *object.Object // an InetAddress, or nil if none has been set
+	port      int            // -1 if none has been set
+}
+
+// getDatagramPacket returns the Go state of the DatagramPacket obj.
+func getDatagramPacket(obj any) (*datagramPacket, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "packet is null")
+	}
+	dp, ok := o.FieldTable[netStateField].Fvalue.(*datagramPacket)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a DatagramPacket")
+	}
+	return dp, nil
+}
+
+// bytes returns the whole byte array of the packet.
+func (dp *datagramPacket) bytes() []types.JavaByte {
+	jbytes, _ := dp.buf.FieldTable["value"].Fvalue.([]types.JavaByte)
+	return jbytes
+}
+
+// setData sets the array of the packet and the part of it that is used.
+func (dp *datagramPacket) setData(arg any, offset, length int64) *ghelpers.GErrBlk {
+	arr, ok := arg.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Null packet buffer")
+	}
+	jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	if length < 0 || offset < 0 || offset+length > int64(len(jbytes)) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "illegal length or offset")
+	}
+	dp.buf, dp.offset, dp.length, dp.bufLength = arr, int(offset), int(length), int(length)
+	return nil
+}
+
+// setPort sets the port of the packet.
+func (dp *datagramPacket) setPort(port int64) *ghelpers.GErrBlk {
+	if port < 0 || port > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("Port out of range:%d", port))
+	}
+	dp.port = int(port)
+	return nil
+}
+
+// setSocketAddress sets the address and port of the packet from an InetSocketAddress.
+func (dp *datagramPacket) setSocketAddress(arg any) *ghelpers.GErrBlk {
+	if obj, ok := arg.(*object.Object); !ok || object.IsNull(obj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "unsupported address type")
+	}
+	isa, gerr := getInetSocketAddress(arg)
+	if gerr != nil {
+		return gerr
+	}
+	if isa.addr == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "unresolved address")
+	}
+	dp.address, dp.port = isa.addr, isa.port
+	return nil
+}
+
+// java/net/DatagramPacket.<init> -- all six forms: the array, an optional offset, the length,
+// and an optional InetAddress and port or SocketAddress
+func datagramPacketInit(params []interface{}) interface{} {
+	dp := &datagramPacket{port: -1}
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: dp}
+
+	// the offset is there if the argument after the first int is also an int
+	args := params[2:]
+	offset, length := int64(0), args[0].(int64)
+	if len(args) > 1 {
+		if l, ok := args[1].(int64); ok {
+			offset, length = args[0].(int64), l
+			args = args[1:]
+		}
+	}
+	args = args[1:]
+	if gerr := dp.setData(params[1], offset, length); gerr != nil {
+		return gerr
+	}
+
+	switch len(args) {
+	case 1: // a SocketAddress
+		if gerr := dp.setSocketAddress(args[0]); gerr != nil {
+			return gerr
+		}
+	case 2: // an InetAddress and a port
+		if addr, ok := args[0].(*object.Object); ok && !object.IsNull(addr) {
+			dp.address = addr
+		}
+		if gerr := dp.setPort(args[1].(int64)); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// datagramPacketState runs get on the state of the packet in params[0].
+func datagramPacketState(params []interface{}, get func(dp *datagramPacket) interface{}) interface{} {
+	dp, gerr := getDatagramPacket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(dp)
+}
+
+// datagramPacketUpdate runs set on the state of the packet in params[0].
+func datagramPacketUpdate(params []interface{}, set func(dp *datagramPacket) *ghelpers.GErrBlk) interface{} {
+	dp, gerr := getDatagramPacket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return gerrOrNil(set(dp))
+}
+
+// java/net/DatagramPacket.getAddress()Ljava/net/InetAddress; -- null if none has been set
+func datagramPacketGetAddress(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} {
+		if dp.address == nil {
+			return object.Null
+		}
+		return dp.address
+	})
+}
+
+func datagramPacketGetPort(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} { return int64(dp.port) })
+}
+
+// java/net/DatagramPacket.getData()[B -- the packet's array itself, not a copy
+func datagramPacketGetData(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} { return dp.buf })
+}
+
+func datagramPacketGetOffset(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} { return int64(dp.offset) })
+}
+
+func datagramPacketGetLength(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} { return int64(dp.length) })
+}
+
+// java/net/DatagramPacket.getSocketAddress()Ljava/net/SocketAddress;
+func datagramPacketGetSocketAddress(params []interface{}) interface{} {
+	return datagramPacketState(params, func(dp *datagramPacket) interface{} {
+		addr := dp.address
+		if addr == nil {
+			addr = anyLocalAddress()
+		}
+		return newInetSocketAddress(addr, max(dp.port, 0))
+	})
+}
+
+func datagramPacketSetAddress(params []interface{}) interface{} {
+	return datagramPacketUpdate(params, func(dp *datagramPacket) *ghelpers.GErrBlk {
+		dp.address = nil
+		if addr, ok := params[1].(*object.Object); ok && !object.IsNull(addr) {
+			dp.address = addr
+		}
+		return nil
+	})
+}
+
+func datagramPacketSetPort(params []interface{}) interface{} {
+	return datagramPacketUpdate(params, func(dp *datagramPacket) *ghelpers.GErrBlk {
+		return dp.setPort(params[1].(int64))
+	})
+}
+
+func datagramPacketSetSocketAddress(params []interface{}) interface{} {
+	return datagramPacketUpdate(params, func(dp *datagramPacket) *ghelpers.GErrBlk {
+		return dp.setSocketAddress(params[1])
+	})
+}
+
+// java/net/DatagramPacket.setData([B)V and setData([BII)V
+func datagramPacketSetData(params []interface{}) interface{} {
+	return datagramPacketUpdate(params, func(dp *datagramPacket) *ghelpers.GErrBlk {
+		if len(params) > 2 {
+			return dp.setData(params[1], params[2].(int64), params[3].(int64))
+		}
+		arr, ok := params[1].(*object.Object)
+		if !ok || object.IsNull(arr) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Null packet buffer")
+		}
+		jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+		return dp.setData(arr, 0, int64(len(jbytes)))
+	})
+}
+
+// java/net/DatagramPacket.setLength(I)V -- sets the space for a received datagram, too
+func datagramPacketSetLength(params []interface{}) interface{} {
+	return datagramPacketUpdate(params, func(dp *datagramPacket) *ghelpers.GErrBlk {
+		length := params[1].(int64)
+		if length < 0 || int64(dp.offset)+length > int64(len(dp.bytes())) {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "illegal length")
+		}
+		dp.length, dp.bufLength = int(length), int(length)
+		return nil
+	})
+}
@@ -0,0 +1,633 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// java.net.DatagramSocket, a UDP socket, over a Go *net.UDPConn. Go cannot connect a UDP socket
+// after it has been bound, so a connected DatagramSocket keeps the address it is connected to,
+// sends there, and drops the datagrams that come from anywhere else, as the JDK does while it
+// connects.
+//
+// MulticastSocket shares this state and these methods (see javaNetMulticastSocket.go).
+
+const datagramSocketClassName = "java/net/DatagramSocket"
+
+// datagramSocketMethods are the methods of DatagramSocket, which MulticastSocket inherits.
+var datagramSocketMethods = map[string]ghelpers.GMeth{
+	"bind(Ljava/net/SocketAddress;)V":                  {ParamSlots: 1, GFunction: datagramSocketBind},
+	"close()V":                                         {ParamSlots: 0, GFunction: datagramSocketClose},
+	"connect(Ljava/net/InetAddress;I)V":                {ParamSlots: 2, GFunction: datagramSocketConnect},
+	"connect(Ljava/net/SocketAddress;)V":               {ParamSlots: 1, GFunction: datagramSocketConnect},
+	"disconnect()V":                                    {ParamSlots: 0, GFunction: datagramSocketDisconnect},
+	"getBroadcast()Z":                                  {ParamSlots: 0, GFunction: datagramSocketGetBroadcast},
+	"getInetAddress()Ljava/net/InetAddress;":           {ParamSlots: 0, GFunction: datagramSocketGetInetAddress},
+	"getLocalAddress()Ljava/net/InetAddress;":          {ParamSlots: 0, GFunction: datagramSocketGetLocalAddress},
+	"getLocalPort()I":                                  {ParamSlots: 0, GFunction: datagramSocketGetLocalPort},
+	"getLocalSocketAddress()Ljava/net/SocketAddress;":  {ParamSlots: 0, GFunction: datagramSocketGetLocalSocketAddress},
+	"getPort()I":                                       {ParamSlots: 0, GFunction: datagramSocketGetPort},
+	"getReceiveBufferSize()I":                          {ParamSlots: 0, GFunction: datagramSocketGetReceiveBufferSize},
+	"getRemoteSocketAddress()Ljava/net/SocketAddress;": {ParamSlots: 0, GFunction: datagramSocketGetRemoteSocketAddress},
+	"getReuseAddress()Z":                               {ParamSlots: 0, GFunction: datagramSocketGetReuseAddress},
+	"getSendBufferSize()I":                             {ParamSlots: 0, GFunction: datagramSocketGetSendBufferSize},
+	"getSoTimeout()I":                                  {ParamSlots: 0, GFunction: datagramSocketGetSoTimeout},
+	"isBound()Z":                                       {ParamSlots: 0, GFunction: datagramSocketIsBound},
+	"isClosed()Z":                                      {ParamSlots: 0, GFunction: datagramSocketIsClosed},
+	"isConnected()Z":                                   {ParamSlots: 0, GFunction: datagramSocketIsConnected},
+	"receive(Ljava/net/DatagramPacket;)V":              {ParamSlots: 1, GFunction: datagramSocketReceive, NeedsContext: true},
+	"send(Ljava/net/DatagramPacket;)V":                 {ParamSlots: 1, GFunction: datagramSocketSend},
+	"setBroadcast(Z)V":                                 {ParamSlots: 1, GFunction: datagramSocketSetBroadcast},
+	"setReceiveBufferSize(I)V":                         {ParamSlots: 1, GFunction: datagramSocketSetReceiveBufferSize},
+	"setReuseAddress(Z)V":                              {ParamSlots: 1, GFunction: datagramSocketSetReuseAddress},
+	"setSendBufferSize(I)V":                            {ParamSlots: 1, GFunction: datagramSocketSetSendBufferSize},
+	"setSoTimeout(I)V":                                 {ParamSlots: 1, GFunction: datagramSocketSetSoTimeout},
+}
+
+func Load_Net_DatagramSocket() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                       {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                         {ParamSlots: 0, GFunction: datagramSocketInit},
+		"<init>(I)V":                        {ParamSlots: 1, GFunction: datagramSocketInit},
+		"<init>(ILjava/net/InetAddress;)V":  {ParamSlots: 2, GFunction: datagramSocketInit},
+		"<init>(Ljava/net/SocketAddress;)V": {ParamSlots: 1, GFunction: datagramSocketInit},
+	} {
+		ghelpers.MethodSignatures[datagramSocketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range datagramSocketMethods {
+		ghelpers.MethodSignatures[datagramSocketClassName+"."+sig] = gmeth
+	}
+}
+
+// datagramSocket is the Go state of a DatagramSocket or a MulticastSocket.
+type datagramSocket struct {
+	mu         sync.Mutex
+	conn       *net.UDPConn   // nil until the socket is bound
+	remote     *net.UDPAddr   // the address connected to, or nil
+	remoteAddr *object.Object // the InetAddress connected to
+	closed     bool
+
+	soTimeout    time.Duration // for receive(); 0 is no timeout
+	broadcast    bool
+	reuseAddress bool
+	sendBuffer   int // 0 until it has been set
+	recvBuffer   int
+}
+
+func newDatagramSocket(multicast bool) *datagramSocket {
+	// a MulticastSocket sets SO_REUSEADDR before it binds, so that several can share a port
+	return &datagramSocket{broadcast: true, reuseAddress: multicast}
+}
+
+// getDatagramSocket returns the Go state of a DatagramSocket or a MulticastSocket.
+func getDatagramSocket(obj any) (*datagramSocket, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "DatagramSocket is null")
+	}
+	ds, ok := o.FieldTable[netStateField].Fvalue.(*datagramSocket)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "DatagramSocket is not initialized")
+	}
+	return ds, nil
+}
+
+// bind binds the socket to addr. The caller holds the lock.
+func (ds *datagramSocket) bind(addr *net.UDPAddr) *ghelpers.GErrBlk {
+	switch {
+	case ds.closed:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	case ds.conn != nil:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "already bound")
+	}
+	var lc net.ListenConfig
+	if ds.reuseAddress {
+		lc.Control = reuseAddress
+	}
+	pc, err := lc.ListenPacket(context.Background(), "udp", addr.String())
+	if err != nil {
+		return socketError(err)
+	}
+	ds.conn = pc.(*net.UDPConn)
+	if !ds.broadcast {
+		_ = setBroadcast(ds.conn, false)
+	}
+	if ds.sendBuffer > 0 {
+		_ = ds.conn.SetWriteBuffer(ds.sendBuffer)
+	}
+	if ds.recvBuffer > 0 {
+		_ = ds.conn.SetReadBuffer(ds.recvBuffer)
+	}
+	return nil
+}
+
+// openConn returns the connection, binding the socket to an ephemeral port of the wildcard
+// address if it is not bound, or the exception to throw if the socket is closed.
+func (ds *datagramSocket) openConn() (*net.UDPConn, *ghelpers.GErrBlk) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	}
+	if ds.conn == nil {
+		if gerr := ds.bind(&net.UDPAddr{IP: net.IPv4zero}); gerr != nil {
+			return nil, gerr
+		}
+	}
+	return ds.conn, nil
+}
+
+func (ds *datagramSocket) close() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.closed {
+		return nil
+	}
+	ds.closed = true
+	if ds.conn != nil {
+		return ds.conn.Close()
+	}
+	return nil
+}
+
+// sysConn returns the connection for the system calls of the socket options, or nil if the
+// socket is not bound.
+func (ds *datagramSocket) sysConn() syscall.Conn {
+	if ds.conn == nil {
+		return nil
+	}
+	return ds.conn
+}
+
+// udpAddrFor returns the Go address of an InetSocketAddress. A null address is the wildcard
+// address with an ephemeral port.
+func udpAddrFor(arg any) (*net.UDPAddr, *object.Object, *ghelpers.GErrBlk) {
+	addr, addrObj, gerr := tcpAddrFor(arg)
+	if gerr != nil {
+		return nil, nil, gerr
+	}
+	return &net.UDPAddr{IP: addr.IP, Port: addr.Port}, addrObj, nil
+}
+
+// localInetAddress returns the InetAddress of a local IP address, 0.0.0.0 for the wildcard
+// address of a dual-stack socket.
+func localInetAddress(ip net.IP) *object.Object {
+	if ip.IsUnspecified() {
+		return anyLocalAddress()
+	}
+	return newInetAddress(ip, "")
+}
+
+// java/net/DatagramSocket.<init>()V, <init>(I)V, <init>(ILjava/net/InetAddress;)V and
+// <init>(Ljava/net/SocketAddress;)V -- a null SocketAddress leaves the socket unbound
+func datagramSocketInit(params []interface{}) interface{} {
+	return initDatagramSocket(params, newDatagramSocket(false))
+}
+
+func initDatagramSocket(params []interface{}, ds *datagramSocket) interface{} {
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: ds}
+	addr := &net.UDPAddr{IP: net.IPv4zero}
+	switch {
+	case len(params) == 1:
+	case isInt(params[1]):
+		port := params[1].(int64)
+		if port < 0 || port > 0xffff {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("Port out of range:%d", port))
+		}
+		addr.Port = int(port)
+		if len(params) > 2 {
+			if local, ok := params[2].(*object.Object); ok && !object.IsNull(local) {
+				addr.IP = local.FieldTable[netStateField].Fvalue.(*inetAddress).ip
+			}
+		}
+	default:
+		if obj, ok := params[1].(*object.Object); !ok || object.IsNull(obj) {
+			return nil // unbound
+		}
+		var gerr *ghelpers.GErrBlk
+		if addr, _, gerr = udpAddrFor(params[1]); gerr != nil {
+			return gerr
+		}
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return gerrOrNil(ds.bind(addr))
+}
+
+func isInt(arg any) bool {
+	_, ok := arg.(int64)
+	return ok
+}
+
+// java/net/DatagramSocket.bind(Ljava/net/SocketAddress;)V
+func datagramSocketBind(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	addr, _, gerr := udpAddrFor(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return gerrOrNil(ds.bind(addr))
+}
+
+// java/net/DatagramSocket.connect(Ljava/net/InetAddress;I)V and connect(Ljava/net/SocketAddress;)V
+func datagramSocketConnect(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	target, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(target) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Address can't be null")
+	}
+	var remote *net.UDPAddr
+	var remoteAddr *object.Object
+	if len(params) > 2 {
+		port := params[2].(int64)
+		if port < 0 || port > 0xffff {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("connect: %d", port))
+		}
+		remoteAddr = target
+		remote = &net.UDPAddr{IP: target.FieldTable[netStateField].Fvalue.(*inetAddress).ip, Port: int(port)}
+	} else {
+		isa, gerr := getInetSocketAddress(target)
+		if gerr != nil {
+			return gerr
+		}
+		if isa.addr == nil {
+			return ghelpers.GetGErrBlk(excNames.SocketException, "Unresolved address")
+		}
+		remoteAddr = isa.addr
+		remote = &net.UDPAddr{IP: isa.ip(), Port: isa.port}
+	}
+
+	if _, gerr = ds.openConn(); gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.remote, ds.remoteAddr = remote, remoteAddr
+	return nil
+}
+
+// java/net/DatagramSocket.disconnect()V
+func datagramSocketDisconnect(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.remote, ds.remoteAddr = nil, nil
+	return nil
+}
+
+// java/net/DatagramSocket.close()V -- a thread blocked in receive() gets a SocketException
+func datagramSocketClose(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if err := ds.close(); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// java/net/DatagramSocket.send(Ljava/net/DatagramPacket;)V -- a packet without an address goes
+// to the address the socket is connected to
+func datagramSocketSend(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	dp, gerr := getDatagramPacket(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+
+	ds.mu.Lock()
+	remote, remoteAddr := ds.remote, ds.remoteAddr
+	ds.mu.Unlock()
+	if remote != nil {
+		if dp.address == nil {
+			dp.address, dp.port = remoteAddr, remote.Port
+		} else if !dp.address.FieldTable[netStateField].Fvalue.(*inetAddress).ip.Equal(remote.IP) {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "connected address and packet address differ")
+		} else if dp.port != remote.Port {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "connected port and packet port differ")
+		}
+	}
+	if dp.address == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Address not set")
+	}
+	if dp.port < 0 || dp.port > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("port out of range: %d", dp.port))
+	}
+
+	data := object.GoByteArrayFromJavaByteArray(dp.bytes()[dp.offset : dp.offset+dp.length])
+	to := &net.UDPAddr{IP: dp.address.FieldTable[netStateField].Fvalue.(*inetAddress).ip, Port: dp.port}
+	if _, err := conn.WriteToUDP(data, to); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// java/net/DatagramSocket.receive(Ljava/net/DatagramPacket;)V -- waits for a datagram for at
+// most the SO_TIMEOUT, waking up to see whether the thread has been interrupted. A datagram
+// that does not fit the packet is cut short.
+func datagramSocketReceive(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	dp, gerr := getDatagramPacket(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	timeout := ds.soTimeout
+	ds.mu.Unlock()
+
+	buf := make([]byte, dp.bufLength)
+	n, from, gerr := ds.receive(fs, conn, buf, timeout)
+	if gerr != nil {
+		return gerr
+	}
+	copy(dp.bytes()[dp.offset:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+	dp.length = n
+	dp.address, dp.port = newInetAddress(from.IP, ""), from.Port
+	return nil
+}
+
+// receive reads a datagram into buf, dropping those that do not come from the address the
+// socket is connected to.
+func (ds *datagramSocket) receive(fs *list.List, conn *net.UDPConn, buf []byte, timeout time.Duration) (int, *net.UDPAddr, *ghelpers.GErrBlk) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	interruptible := ghelpers.CurrentThread(fs) != nil
+	for {
+		if ghelpers.TakeInterrupt(fs) {
+			return 0, nil, closedByInterrupt(func() { _ = ds.close() })
+		}
+		wake := deadline
+		if interruptible {
+			if next := time.Now().Add(socketPollInterval); wake.IsZero() || next.Before(wake) {
+				wake = next
+			}
+		}
+		_ = conn.SetReadDeadline(wake)
+		n, from, err := conn.ReadFromUDP(buf)
+		switch {
+		case err == nil:
+			ds.mu.Lock()
+			remote := ds.remote
+			ds.mu.Unlock()
+			if remote == nil || (remote.IP.Equal(from.IP) && remote.Port == from.Port) {
+				return n, from, nil
+			}
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				return 0, nil, ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Receive timed out")
+			}
+		default:
+			return 0, nil, socketError(err)
+		}
+	}
+}
+
+// datagramSocketState runs get on the state of the socket in params[0], under its lock.
+func datagramSocketState(params []interface{}, get func(ds *datagramSocket) interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return get(ds)
+}
+
+// datagramSocketOption runs set on the state of the socket in params[0], under its lock, unless
+// the socket is closed.
+func datagramSocketOption(params []interface{}, set func(ds *datagramSocket) *ghelpers.GErrBlk) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.closed {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	}
+	return gerrOrNil(set(ds))
+}
+
+func datagramSocketIsBound(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ds.conn != nil)
+	})
+}
+
+func datagramSocketIsClosed(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ds.closed)
+	})
+}
+
+func datagramSocketIsConnected(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ds.remote != nil)
+	})
+}
+
+// java/net/DatagramSocket.getInetAddress()Ljava/net/InetAddress; -- null if not connected
+func datagramSocketGetInetAddress(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		if ds.remote == nil {
+			return object.Null
+		}
+		return ds.remoteAddr
+	})
+}
+
+// java/net/DatagramSocket.getPort()I -- -1 if not connected
+func datagramSocketGetPort(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		if ds.remote == nil {
+			return int64(-1)
+		}
+		return int64(ds.remote.Port)
+	})
+}
+
+func datagramSocketGetRemoteSocketAddress(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		if ds.remote == nil {
+			return object.Null
+		}
+		return newInetSocketAddress(ds.remoteAddr, ds.remote.Port)
+	})
+}
+
+// java/net/DatagramSocket.getLocalPort()I -- -1 if the socket is closed or not bound
+func datagramSocketGetLocalPort(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		if ds.closed || ds.conn == nil {
+			return int64(-1)
+		}
+		return int64(ds.conn.LocalAddr().(*net.UDPAddr).Port)
+	})
+}
+
+// java/net/DatagramSocket.getLocalAddress()Ljava/net/InetAddress; -- null if the socket is
+// closed, and the wildcard address if it is not bound
+func datagramSocketGetLocalAddress(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		switch {
+		case ds.closed:
+			return object.Null
+		case ds.conn == nil:
+			return anyLocalAddress()
+		}
+		return localInetAddress(ds.conn.LocalAddr().(*net.UDPAddr).IP)
+	})
+}
+
+// java/net/DatagramSocket.getLocalSocketAddress()Ljava/net/SocketAddress; -- null if the socket
+// is closed or not bound
+func datagramSocketGetLocalSocketAddress(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		if ds.closed || ds.conn == nil {
+			return object.Null
+		}
+		local := ds.conn.LocalAddr().(*net.UDPAddr)
+		return newInetSocketAddress(localInetAddress(local.IP), local.Port)
+	})
+}
+
+func datagramSocketGetSoTimeout(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} { return ds.soTimeout.Milliseconds() })
+}
+
+// java/net/DatagramSocket.setSoTimeout(I)V -- the timeout of receive() in milliseconds; 0 is none
+func datagramSocketSetSoTimeout(params []interface{}) interface{} {
+	timeout := params[1].(int64)
+	return datagramSocketOption(params, func(ds *datagramSocket) *ghelpers.GErrBlk {
+		if timeout < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "timeout < 0")
+		}
+		ds.soTimeout = time.Duration(timeout) * time.Millisecond
+		return nil
+	})
+}
+
+func datagramSocketGetBroadcast(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ds.broadcast)
+	})
+}
+
+// java/net/DatagramSocket.setBroadcast(Z)V -- SO_BROADCAST, which is on by default
+func datagramSocketSetBroadcast(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return datagramSocketOption(params, func(ds *datagramSocket) *ghelpers.GErrBlk {
+		ds.broadcast = on
+		if ds.conn != nil {
+			if err := setBroadcast(ds.conn, on); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func datagramSocketGetReuseAddress(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ds.reuseAddress)
+	})
+}
+
+// java/net/DatagramSocket.setReuseAddress(Z)V -- takes effect when the socket is bound
+func datagramSocketSetReuseAddress(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return datagramSocketOption(params, func(ds *datagramSocket) *ghelpers.GErrBlk {
+		ds.reuseAddress = on
+		return nil
+	})
+}
+
+func datagramSocketGetSendBufferSize(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return int64(bufferSize(ds.sysConn(), false, ds.sendBuffer))
+	})
+}
+
+func datagramSocketSetSendBufferSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	return datagramSocketOption(params, func(ds *datagramSocket) *ghelpers.GErrBlk {
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "negative send size")
+		}
+		ds.sendBuffer = int(size)
+		if ds.conn != nil {
+			if err := ds.conn.SetWriteBuffer(ds.sendBuffer); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func datagramSocketGetReceiveBufferSize(params []interface{}) interface{} {
+	return datagramSocketState(params, func(ds *datagramSocket) interface{} {
+		return int64(bufferSize(ds.sysConn(), true, ds.recvBuffer))
+	})
+}
+
+func datagramSocketSetReceiveBufferSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	return datagramSocketOption(params, func(ds *datagramSocket) *ghelpers.GErrBlk {
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid receive size")
+		}
+		ds.recvBuffer = int(size)
+		if ds.conn != nil {
+			if err := ds.conn.SetReadBuffer(ds.recvBuffer); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,288 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLoopbackDatagramSocket returns a DatagramSocket bound to an ephemeral port of the
+// loopback address.
+func newLoopbackDatagramSocket(t *testing.T) (*object.Object, int64) {
+	t.Helper()
+	ds := object.MakeEmptyObjectWithClassName(new(datagramSocketClassName))
+	if ret := datagramSocketInit([]interface{}{ds, int64(0), loopbackAddress()}); ret != nil {
+		t.Fatalf("DatagramSocket.<init>: %v", ret)
+	}
+	t.Cleanup(func() { datagramSocketClose([]interface{}{ds}) })
+	return ds, datagramSocketGetLocalPort([]interface{}{ds}).(int64)
+}
+
+func newTestPacket(t *testing.T, data []byte, args ...interface{}) *object.Object {
+	t.Helper()
+	dp := object.MakeEmptyObjectWithClassName(new(datagramPacketClassName))
+	params := append([]interface{}{dp, object.MakeArrayFromRawArray(data)}, args...)
+	if ret := datagramPacketInit(params); ret != nil {
+		t.Fatalf("DatagramPacket.<init>: %v", ret)
+	}
+	return dp
+}
+
+// packetString returns the datagram that a packet holds.
+func packetString(dp *object.Object) string {
+	state := dp.FieldTable[netStateField].Fvalue.(*datagramPacket)
+	return string(object.GoByteArrayFromJavaByteArray(state.bytes()[state.offset : state.offset+state.length]))
+}
+
+func TestDatagramSocket_LoopbackSendReceive(t *testing.T) {
+	globals.InitStringPool()
+	receiver, port := newLoopbackDatagramSocket(t)
+	sender := object.MakeEmptyObjectWithClassName(new(datagramSocketClassName))
+	datagramSocketInit([]interface{}{sender})
+	defer datagramSocketClose([]interface{}{sender})
+
+	out := newTestPacket(t, []byte("app.requests:1|c"), int64(16), loopbackAddress(), port)
+	if ret := datagramSocketSend([]interface{}{sender, out}); ret != nil {
+		t.Fatalf("send: %v", ret)
+	}
+
+	in := newTestPacket(t, make([]byte, 64), int64(64))
+	datagramSocketSetSoTimeout([]interface{}{receiver, int64(2000)})
+	if ret := datagramSocketReceive([]interface{}{list.New(), receiver, in}); ret != nil {
+		t.Fatalf("receive: %v", ret)
+	}
+	if got := packetString(in); got != "app.requests:1|c" {
+		t.Errorf("received %q", got)
+	}
+	senderPort := datagramSocketGetLocalPort([]interface{}{sender}).(int64)
+	if got := datagramPacketGetPort([]interface{}{in}).(int64); got != senderPort {
+		t.Errorf("packet port %d, expected %d", got, senderPort)
+	}
+	from := datagramPacketGetAddress([]interface{}{in}).(*object.Object)
+	if got := netTestGoString(t, inetAddressGetHostAddress([]interface{}{from})); got != "127.0.0.1" {
+		t.Errorf("packet address %s", got)
+	}
+
+	// a datagram larger than the packet is cut short, and the space for the next one is kept
+	datagramSocketSend([]interface{}{sender, newTestPacket(t, []byte("0123456789"), int64(10), loopbackAddress(), port)})
+	small := newTestPacket(t, make([]byte, 8), int64(2), int64(4))
+	if ret := datagramSocketReceive([]interface{}{list.New(), receiver, small}); ret != nil {
+		t.Fatalf("receive: %v", ret)
+	}
+	if got := packetString(small); got != "0123" {
+		t.Errorf("truncated datagram %q", got)
+	}
+	if state := small.FieldTable[netStateField].Fvalue.(*datagramPacket); state.bufLength != 4 {
+		t.Errorf("bufLength %d after receive", state.bufLength)
+	}
+}
+
+func TestDatagramSocket_ReceiveTimeoutAndClose(t *testing.T) {
+	globals.InitStringPool()
+	ds, _ := newLoopbackDatagramSocket(t)
+	in := newTestPacket(t, make([]byte, 16), int64(16))
+
+	datagramSocketSetSoTimeout([]interface{}{ds, int64(30)})
+	testutil.ExpectGErr(t, datagramSocketReceive([]interface{}{list.New(), ds, in}),
+		excNames.SocketTimeoutException, "Receive timed out")
+
+	datagramSocketSetSoTimeout([]interface{}{ds, int64(0)})
+	done := make(chan interface{})
+	go func() { done <- datagramSocketReceive([]interface{}{list.New(), ds, in}) }()
+	time.Sleep(20 * time.Millisecond)
+	datagramSocketClose([]interface{}{ds})
+	select {
+	case ret := <-done:
+		testutil.ExpectGErr(t, ret, excNames.SocketException, "closed")
+	case <-time.After(2 * time.Second):
+		t.Fatal("receive() did not return after close()")
+	}
+	testutil.ExpectGErr(t, datagramSocketSend([]interface{}{ds, in}), excNames.SocketException, "Socket is closed")
+	if got := datagramSocketGetLocalPort([]interface{}{ds}).(int64); got != -1 {
+		t.Errorf("getLocalPort of a closed socket: %d", got)
+	}
+}
+
+func TestDatagramSocket_Connected(t *testing.T) {
+	globals.InitStringPool()
+	receiver, port := newLoopbackDatagramSocket(t)
+	sender, _ := newLoopbackDatagramSocket(t)
+	stranger, _ := newLoopbackDatagramSocket(t)
+
+	if ret := datagramSocketConnect([]interface{}{sender, loopbackAddress(), port}); ret != nil {
+		t.Fatalf("connect: %v", ret)
+	}
+	if datagramSocketIsConnected([]interface{}{sender}) != types.JavaBoolTrue {
+		t.Error("the socket should be connected")
+	}
+	if got := datagramSocketGetPort([]interface{}{sender}).(int64); got != port {
+		t.Errorf("getPort: %d", got)
+	}
+
+	// a packet without an address goes to the connected address
+	if ret := datagramSocketSend([]interface{}{sender, newTestPacket(t, []byte("hi"), int64(2))}); ret != nil {
+		t.Fatalf("send: %v", ret)
+	}
+	elsewhere := newTestPacket(t, []byte("x"), int64(1), loopbackAddress(), port+1)
+	testutil.ExpectGErr(t, datagramSocketSend([]interface{}{sender, elsewhere}),
+		excNames.IllegalArgumentException, "connected port and packet port differ")
+
+	// the receiver, connected to the sender, drops what the stranger sends
+	senderPort := datagramSocketGetLocalPort([]interface{}{sender}).(int64)
+	datagramSocketConnect([]interface{}{receiver, newInetSocketAddress(loopbackAddress(), int(senderPort))})
+	datagramSocketSend([]interface{}{stranger, newTestPacket(t, []byte("noise"), int64(5), loopbackAddress(), port)})
+	datagramSocketSend([]interface{}{sender, newTestPacket(t, []byte("signal"), int64(6))})
+	datagramSocketSetSoTimeout([]interface{}{receiver, int64(2000)})
+	var got []string
+	for range 2 {
+		in := newTestPacket(t, make([]byte, 16), int64(16))
+		if ret := datagramSocketReceive([]interface{}{list.New(), receiver, in}); ret != nil {
+			t.Fatalf("receive: %v", ret)
+		}
+		got = append(got, packetString(in))
+	}
+	if strings.Join(got, ",") != "hi,signal" {
+		t.Errorf("received %v", got)
+	}
+
+	datagramSocketDisconnect([]interface{}{sender})
+	testutil.ExpectGErr(t, datagramSocketSend([]interface{}{sender, newTestPacket(t, []byte("x"), int64(1))}),
+		excNames.IllegalArgumentException, "Address not set")
+}
+
+func TestDatagramSocket_BindingAndOptions(t *testing.T) {
+	globals.InitStringPool()
+	ds := object.MakeEmptyObjectWithClassName(new(datagramSocketClassName))
+	datagramSocketInit([]interface{}{ds, object.Null})
+	defer datagramSocketClose([]interface{}{ds})
+	if datagramSocketIsBound([]interface{}{ds}) != types.JavaBoolFalse {
+		t.Error("a socket made with a null address should not be bound")
+	}
+	if got := datagramSocketGetLocalPort([]interface{}{ds}).(int64); got != -1 {
+		t.Errorf("getLocalPort of an unbound socket: %d", got)
+	}
+	if ret := datagramSocketBind([]interface{}{ds, newInetSocketAddress(loopbackAddress(), 0)}); ret != nil {
+		t.Fatalf("bind: %v", ret)
+	}
+	testutil.ExpectGErr(t, datagramSocketBind([]interface{}{ds, object.Null}), excNames.SocketException, "already bound")
+	local := datagramSocketGetLocalSocketAddress([]interface{}{ds}).(*object.Object)
+	if got := netTestGoString(t, inetSocketAddressToString([]interface{}{local})); !strings.HasPrefix(got, "/127.0.0.1:") {
+		t.Errorf("getLocalSocketAddress: %s", got)
+	}
+
+	if datagramSocketGetBroadcast([]interface{}{ds}) != types.JavaBoolTrue {
+		t.Error("SO_BROADCAST should be on by default")
+	}
+	if ret := datagramSocketSetBroadcast([]interface{}{ds, types.JavaBoolFalse}); ret != nil {
+		t.Fatalf("setBroadcast: %v", ret)
+	}
+	if datagramSocketGetBroadcast([]interface{}{ds}) != types.JavaBoolFalse {
+		t.Error("SO_BROADCAST should be off")
+	}
+	testutil.ExpectGErr(t, datagramSocketSetSoTimeout([]interface{}{ds, int64(-5)}),
+		excNames.IllegalArgumentException, "timeout < 0")
+
+	// the wildcard address of a socket bound to a port only
+	wild := object.MakeEmptyObjectWithClassName(new(datagramSocketClassName))
+	datagramSocketInit([]interface{}{wild})
+	defer datagramSocketClose([]interface{}{wild})
+	addr := datagramSocketGetLocalAddress([]interface{}{wild}).(*object.Object)
+	if got := netTestGoString(t, inetAddressGetHostAddress([]interface{}{addr})); got != "0.0.0.0" {
+		t.Errorf("getLocalAddress of a wildcard socket: %s", got)
+	}
+}
+
+func TestDatagramPacket(t *testing.T) {
+	globals.InitStringPool()
+	dp := newTestPacket(t, []byte("abcdef"), int64(1), int64(3), newInetSocketAddress(loopbackAddress(), 8125))
+	if got := packetString(dp); got != "bcd" {
+		t.Errorf("data %q", got)
+	}
+	if got := datagramPacketGetOffset([]interface{}{dp}).(int64); got != 1 {
+		t.Errorf("getOffset: %d", got)
+	}
+	sa := datagramPacketGetSocketAddress([]interface{}{dp}).(*object.Object)
+	if got := netTestGoString(t, inetSocketAddressToString([]interface{}{sa})); got != "localhost/127.0.0.1:8125" {
+		t.Errorf("getSocketAddress: %s", got)
+	}
+
+	testutil.ExpectGErr(t, datagramPacketSetLength([]interface{}{dp, int64(6)}),
+		excNames.IllegalArgumentException, "illegal length")
+	testutil.ExpectGErr(t, datagramPacketSetPort([]interface{}{dp, int64(-2)}),
+		excNames.IllegalArgumentException, "Port out of range:-2")
+	ret := datagramPacketInit([]interface{}{object.MakeEmptyObjectWithClassName(new(datagramPacketClassName)), object.MakeArrayFromRawArray(make([]byte, 4)), int64(5)})
+	testutil.ExpectGErr(t, ret, excNames.IllegalArgumentException, "illegal length or offset")
+
+	datagramPacketSetData([]interface{}{dp, object.MakeArrayFromRawArray([]byte("xyz"))})
+	if got := datagramPacketGetLength([]interface{}{dp}).(int64); got != 3 {
+		t.Errorf("getLength after setData: %d", got)
+	}
+	if unset := newTestPacket(t, make([]byte, 1), int64(1)); datagramPacketGetPort([]interface{}{unset}).(int64) != -1 {
+		t.Error("the port of a packet without an address should be -1")
+	}
+}
+
+func TestMulticastSocket(t *testing.T) {
+	globals.InitStringPool()
+	ms := object.MakeEmptyObjectWithClassName(new(multicastSocketClassName))
+	if ret := multicastSocketInit([]interface{}{ms, int64(0)}); ret != nil {
+		t.Fatalf("MulticastSocket.<init>: %v", ret)
+	}
+	defer datagramSocketClose([]interface{}{ms})
+	if datagramSocketGetReuseAddress([]interface{}{ms}) != types.JavaBoolTrue {
+		t.Error("a MulticastSocket should reuse addresses")
+	}
+	port := datagramSocketGetLocalPort([]interface{}{ms}).(int64)
+
+	testutil.ExpectGErr(t, multicastSocketJoinGroup([]interface{}{ms, loopbackAddress()}),
+		excNames.SocketException, "Not a multicast address")
+	testutil.ExpectGErr(t, multicastSocketSetTimeToLive([]interface{}{ms, int64(256)}),
+		excNames.IllegalArgumentException, "ttl out of range")
+
+	if ret := multicastSocketSetTimeToLive([]interface{}{ms, int64(4)}); ret != nil {
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok && strings.Contains(gerr.ErrMsg, "not supported") {
+			t.Skip("multicast socket options are not supported here")
+		}
+		t.Fatalf("setTimeToLive: %v", ret)
+	}
+	if got := multicastSocketGetTimeToLive([]interface{}{ms}).(int64); got != 4 {
+		t.Errorf("getTimeToLive: %d", got)
+	}
+	multicastSocketSetLoopbackMode([]interface{}{ms, types.JavaBoolFalse})
+	if multicastSocketGetLoopbackMode([]interface{}{ms}) != types.JavaBoolFalse {
+		t.Error("loopback should be enabled")
+	}
+
+	group := getByName(t, "239.255.42.99")
+	if ret := multicastSocketJoinGroup([]interface{}{ms, group}); ret != nil {
+		t.Skipf("cannot join a multicast group here: %v", ret.(*ghelpers.GErrBlk).ErrMsg)
+	}
+	sender := object.MakeEmptyObjectWithClassName(new(multicastSocketClassName))
+	multicastSocketInit([]interface{}{sender})
+	defer datagramSocketClose([]interface{}{sender})
+	datagramSocketSend([]interface{}{sender, newTestPacket(t, []byte("hello group"), int64(11), group, port)})
+	in := newTestPacket(t, make([]byte, 32), int64(32))
+	datagramSocketSetSoTimeout([]interface{}{ms, int64(2000)})
+	if ret := datagramSocketReceive([]interface{}{list.New(), ms, in}); ret != nil {
+		t.Skipf("no multicast route here: %v", ret.(*ghelpers.GErrBlk).ErrMsg)
+	}
+	if got := packetString(in); got != "hello group" {
+		t.Errorf("received %q", got)
+	}
+	if ret := multicastSocketLeaveGroup([]interface{}{ms, group}); ret != nil {
+		t.Errorf("leaveGroup: %v", ret)
+	}
+}
@@ -0,0 +1,457 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// java.net.http.HttpClient over Go's net/http. Only HTTP/1.1 is spoken: a client built for
+// HTTP_2 says so, as the JDK's does, but its exchanges fall back to HTTP/1.1, which the JDK also
+// does when the server does not offer HTTP/2.
+//
+// send() blocks the calling thread, and can be interrupted; sendAsync() runs the exchange in a
+// goroutine and returns a CompletableFuture.
+
+const (
+	httpClientClassName        = "java/net/http/HttpClient"
+	httpClientBuilderClassName = "java/net/http/HttpClient$Builder"
+	httpRedirectClassName      = "java/net/http/HttpClient$Redirect"
+	httpVersionClassName       = "java/net/http/HttpClient$Version"
+)
+
+// maxRedirects is the number of redirects that a client follows, as in the JDK.
+const maxRedirects = 5
+
+func Load_Net_HttpClient() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                          {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"close()V":                             {ParamSlots: 0, GFunction: httpClientClose},
+		"connectTimeout()Ljava/util/Optional;": {ParamSlots: 0, GFunction: httpClientConnectTimeout},
+		"followRedirects()Ljava/net/http/HttpClient$Redirect;": {ParamSlots: 0,
+			GFunction: httpClientFollowRedirects},
+		"newBuilder()Ljava/net/http/HttpClient$Builder;": {ParamSlots: 0, GFunction: httpClientNewBuilder},
+		"newHttpClient()Ljava/net/http/HttpClient;":      {ParamSlots: 0, GFunction: httpClientNewHttpClient},
+		"send(Ljava/net/http/HttpRequest;Ljava/net/http/HttpResponse$BodyHandler;)Ljava/net/http/HttpResponse;": {
+			ParamSlots: 2, GFunction: httpClientSend, NeedsContext: true},
+		"sendAsync(Ljava/net/http/HttpRequest;Ljava/net/http/HttpResponse$BodyHandler;)Ljava/util/concurrent/CompletableFuture;": {
+			ParamSlots: 2, GFunction: httpClientSendAsync},
+		"shutdownNow()V": {ParamSlots: 0, GFunction: httpClientClose},
+		"version()Ljava/net/http/HttpClient$Version;": {ParamSlots: 0, GFunction: httpClientVersion},
+		"toString()Ljava/lang/String;":                {ParamSlots: 0, GFunction: httpClientToString},
+	} {
+		ghelpers.MethodSignatures[httpClientClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"build()Ljava/net/http/HttpClient;": {ParamSlots: 0, GFunction: httpClientBuilderBuild},
+		"connectTimeout(Ljava/time/Duration;)Ljava/net/http/HttpClient$Builder;": {ParamSlots: 1,
+			GFunction: httpClientBuilderConnectTimeout},
+		"followRedirects(Ljava/net/http/HttpClient$Redirect;)Ljava/net/http/HttpClient$Builder;": {ParamSlots: 1,
+			GFunction: httpClientBuilderFollowRedirects},
+		"version(Ljava/net/http/HttpClient$Version;)Ljava/net/http/HttpClient$Builder;": {ParamSlots: 1,
+			GFunction: httpClientBuilderVersion},
+	} {
+		ghelpers.MethodSignatures[httpClientBuilderClassName+"."+sig] = gmeth
+	}
+
+	for _, className := range []string{httpRedirectClassName, httpVersionClassName} {
+		ghelpers.MethodSignatures[className+".<clinit>()V"] = ghelpers.GMeth{ParamSlots: 0, GFunction: httpEnumsClinit}
+		ghelpers.MethodSignatures[className+".name()Ljava/lang/String;"] = ghelpers.GMeth{ParamSlots: 0, GFunction: httpEnumName}
+		ghelpers.MethodSignatures[className+".toString()Ljava/lang/String;"] = ghelpers.GMeth{ParamSlots: 0, GFunction: httpEnumName}
+	}
+}
+
+var httpEnumsMutex = sync.Mutex{}
+var httpEnums map[string]*object.Object // by class name and constant name, e.g. "...$Redirect.NEVER"
+
+// ensureHttpEnumsInited creates the constants of HttpClient.Redirect and HttpClient.Version.
+func ensureHttpEnumsInited() {
+	httpEnumsMutex.Lock()
+	defer httpEnumsMutex.Unlock()
+	if httpEnums != nil {
+		return
+	}
+	httpEnums = make(map[string]*object.Object)
+	makeConstant := func(className, name string) {
+		obj := object.MakeEmptyObjectWithClassName(&className)
+		obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+		_ = statics.AddStatic(className+"."+name, statics.Static{Type: "L" + className + ";", Value: obj})
+		httpEnums[className+"."+name] = obj
+	}
+	for _, name := range []string{"NEVER", "ALWAYS", "NORMAL"} {
+		makeConstant(httpRedirectClassName, name)
+	}
+	for _, name := range []string{"HTTP_1_1", "HTTP_2"} {
+		makeConstant(httpVersionClassName, name)
+	}
+}
+
+func httpEnumsClinit([]interface{}) interface{} {
+	ensureHttpEnumsInited()
+	return nil
+}
+
+// httpEnum returns the constant name of the enum className.
+func httpEnum(className, name string) *object.Object {
+	ensureHttpEnumsInited()
+	return httpEnums[className+"."+name]
+}
+
+// httpEnumConstant returns the name of the Redirect or Version constant in arg.
+func httpEnumConstant(arg any) (string, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "enum constant is null")
+	}
+	name, ok := optString(obj.FieldTable["name"].Fvalue)
+	if !ok {
+		return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an enum constant")
+	}
+	return name, nil
+}
+
+// java/net/http/HttpClient$Redirect.name()Ljava/lang/String; and the same of Version
+func httpEnumName(params []interface{}) interface{} {
+	name, gerr := httpEnumConstant(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(name)
+}
+
+// httpClient is the Go state of an HttpClient, and of the HttpClient.Builder that builds one.
+type httpClient struct {
+	connectTimeout    time.Duration // 0 is no timeout
+	connectTimeoutObj *object.Object
+	redirect          string // the name of the Redirect constant
+	version           string // the name of the Version constant
+	closed            atomic.Bool
+}
+
+func getHttpClient(obj any) (*httpClient, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "HttpClient is null")
+	}
+	c, ok := o.FieldTable[netStateField].Fvalue.(*httpClient)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an HttpClient")
+	}
+	return c, nil
+}
+
+// newHttpClientObject returns an object of the class given with the state c.
+func newHttpClientObject(className string, c *httpClient) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: c}
+	return obj
+}
+
+// durationArg returns the java.time.Duration in arg as a time.Duration, saturated.
+func durationArg(arg any) (time.Duration, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "duration is null")
+	}
+	seconds, _ := obj.FieldTable["seconds"].Fvalue.(int64)
+	nanos, _ := obj.FieldTable["nanos"].Fvalue.(int64)
+	switch {
+	case seconds > math.MaxInt64/int64(time.Second)-1:
+		return math.MaxInt64, nil
+	case seconds < math.MinInt64/int64(time.Second)+1:
+		return math.MinInt64, nil
+	}
+	return time.Duration(seconds)*time.Second + time.Duration(nanos), nil
+}
+
+// positiveDurationArg is durationArg for a Duration that must be positive.
+func positiveDurationArg(arg any) (time.Duration, *ghelpers.GErrBlk) {
+	d, gerr := durationArg(arg)
+	if gerr != nil {
+		return 0, gerr
+	}
+	if d <= 0 {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Invalid duration: "+d.String())
+	}
+	return d, nil
+}
+
+// java/net/http/HttpClient.newHttpClient()Ljava/net/http/HttpClient; -- a client with the
+// defaults: no connect timeout, no redirects followed
+func httpClientNewHttpClient([]interface{}) interface{} {
+	return newHttpClientObject(httpClientClassName, &httpClient{redirect: "NEVER", version: "HTTP_2"})
+}
+
+// java/net/http/HttpClient.newBuilder()Ljava/net/http/HttpClient$Builder;
+func httpClientNewBuilder([]interface{}) interface{} {
+	return newHttpClientObject(httpClientBuilderClassName, &httpClient{redirect: "NEVER", version: "HTTP_2"})
+}
+
+// httpClientBuilderSet runs set on the state of the builder in params[0] and returns the builder.
+func httpClientBuilderSet(params []interface{}, set func(c *httpClient) *ghelpers.GErrBlk) interface{} {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := set(c); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+// java/net/http/HttpClient$Builder.connectTimeout(Ljava/time/Duration;)Ljava/net/http/HttpClient$Builder;
+func httpClientBuilderConnectTimeout(params []interface{}) interface{} {
+	return httpClientBuilderSet(params, func(c *httpClient) *ghelpers.GErrBlk {
+		timeout, gerr := positiveDurationArg(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		c.connectTimeout, c.connectTimeoutObj = timeout, params[1].(*object.Object)
+		return nil
+	})
+}
+
+// java/net/http/HttpClient$Builder.followRedirects(Ljava/net/http/HttpClient$Redirect;)Ljava/net/http/HttpClient$Builder;
+func httpClientBuilderFollowRedirects(params []interface{}) interface{} {
+	return httpClientBuilderSet(params, func(c *httpClient) *ghelpers.GErrBlk {
+		name, gerr := httpEnumConstant(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		c.redirect = name
+		return nil
+	})
+}
+
+// java/net/http/HttpClient$Builder.version(Ljava/net/http/HttpClient$Version;)Ljava/net/http/HttpClient$Builder;
+func httpClientBuilderVersion(params []interface{}) interface{} {
+	return httpClientBuilderSet(params, func(c *httpClient) *ghelpers.GErrBlk {
+		name, gerr := httpEnumConstant(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		c.version = name
+		return nil
+	})
+}
+
+// java/net/http/HttpClient$Builder.build()Ljava/net/http/HttpClient; -- the builder may go on
+// to build other clients
+func httpClientBuilderBuild(params []interface{}) interface{} {
+	b, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return newHttpClientObject(httpClientClassName, &httpClient{connectTimeout: b.connectTimeout,
+		connectTimeoutObj: b.connectTimeoutObj, redirect: b.redirect, version: b.version})
+}
+
+// java/net/http/HttpClient.connectTimeout()Ljava/util/Optional;
+func httpClientConnectTimeout(params []interface{}) interface{} {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if c.connectTimeoutObj == nil {
+		return object.MakeEmptyObjectWithClassName(&types.ClassNameOptional)
+	}
+	return object.MakePrimitiveObject(types.ClassNameOptional, "Ljava/time/Duration;", c.connectTimeoutObj)
+}
+
+func httpClientFollowRedirects(params []interface{}) interface{} {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return httpEnum(httpRedirectClassName, c.redirect)
+}
+
+func httpClientVersion(params []interface{}) interface{} {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return httpEnum(httpVersionClassName, c.version)
+}
+
+// java/net/http/HttpClient.close()V and shutdownNow()V -- exchanges in progress are not
+// stopped, but no more are started
+func httpClientClose(params []interface{}) interface{} {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.closed.Store(true)
+	return nil
+}
+
+func httpClientToString(params []interface{}) interface{} {
+	return object.StringObjectFromGoString(fmt.Sprintf("jdk.internal.net.http.HttpClientImpl@%x",
+		params[0].(*object.Object).Mark.Hash))
+}
+
+// exchange sends req and returns the response, with its body handled by handler. The request
+// timeout runs until the head of the response is received. Closing abort, if it is not nil,
+// stops the exchange.
+func (c *httpClient) exchange(abort <-chan struct{}, reqObj *object.Object, req *httpRequest,
+	handler *bodyHandler) (*object.Object, *ghelpers.GErrBlk) {
+	if c.closed.Load() {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "HttpClient is closed")
+	}
+	target, gerr := getURI(req.uri)
+	if gerr != nil {
+		return nil, gerr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if abort != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-abort:
+				cancel()
+			case <-stop:
+			}
+		}()
+	}
+	var timedOut atomic.Bool
+	if req.timeout > 0 {
+		timer := time.AfterFunc(req.timeout, func() {
+			timedOut.Store(true)
+			cancel()
+		})
+		defer timer.Stop()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	hreq, err := http.NewRequestWithContext(ctx, req.method, target.str, body)
+	if err != nil {
+		cancel()
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, err.Error())
+	}
+	hreq.Header = req.header.Clone()
+
+	client := &http.Client{Transport: &http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		DialContext:       dialer(c.connectTimeout),
+		DisableKeepAlives: true,
+	}}
+	client.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		switch {
+		case c.redirect == "NEVER":
+			return http.ErrUseLastResponse
+		case c.redirect == "NORMAL" && via[len(via)-1].URL.Scheme == "https" && next.URL.Scheme != "https":
+			return http.ErrUseLastResponse
+		case len(via) > maxRedirects:
+			return errTooManyRedirects
+		}
+		return nil
+	}
+
+	resp, err := client.Do(hreq)
+	if err != nil {
+		cancel()
+		switch {
+		case timedOut.Load():
+			return nil, ghelpers.GetGErrBlk(excNames.HttpTimeoutException, "request timed out")
+		case errors.Is(err, errConnectTimeout):
+			return nil, ghelpers.GetGErrBlk(excNames.HttpConnectTimeoutException, "HTTP connect timed out")
+		case errors.Is(err, errTooManyRedirects):
+			return nil, ghelpers.GetGErrBlk(excNames.IOException, "too many redirects")
+		case errors.Is(err, context.Canceled):
+			return nil, ghelpers.GetGErrBlk(excNames.IOException, "request cancelled")
+		}
+		return nil, httpError(err)
+	}
+	if timedOut.Load() {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, ghelpers.GetGErrBlk(excNames.HttpTimeoutException, "request timed out")
+	}
+
+	respBody, gerr := handler.handle(resp, cancel)
+	if gerr != nil {
+		return nil, gerr
+	}
+	return newHttpResponse(resp, reqObj, respBody), nil
+}
+
+// errTooManyRedirects stops a client that has followed maxRedirects redirects.
+var errTooManyRedirects = errors.New("too many redirects")
+
+// sendArgs returns the request and body handler that send() and sendAsync() are given.
+func sendArgs(params []interface{}) (*httpClient, *object.Object, *httpRequest, *bodyHandler, *ghelpers.GErrBlk) {
+	c, gerr := getHttpClient(params[0])
+	if gerr != nil {
+		return nil, nil, nil, nil, gerr
+	}
+	req, gerr := getHttpRequest(params[1])
+	if gerr != nil {
+		return nil, nil, nil, nil, gerr
+	}
+	handler, gerr := getBodyHandler(params[2])
+	if gerr != nil {
+		return nil, nil, nil, nil, gerr
+	}
+	return c, params[1].(*object.Object), req, handler, nil
+}
+
+// java/net/http/HttpClient.send(Ljava/net/http/HttpRequest;Ljava/net/http/HttpResponse$BodyHandler;)Ljava/net/http/HttpResponse;
+// -- an interrupt stops the exchange and throws an InterruptedException
+func httpClientSend(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	c, reqObj, req, handler, gerr := sendArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	abort := make(chan struct{})
+	done := make(chan struct{})
+	var resp *object.Object
+	go func() {
+		defer close(done)
+		resp, gerr = c.exchange(abort, reqObj, req, handler)
+	}()
+	if _, interrupted := ghelpers.AwaitInterruptibly(fs, done, -1); interrupted != nil {
+		close(abort)
+		return interrupted
+	}
+	if gerr != nil {
+		return gerr
+	}
+	return resp
+}
+
+// java/net/http/HttpClient.sendAsync(Ljava/net/http/HttpRequest;Ljava/net/http/HttpResponse$BodyHandler;)Ljava/util/concurrent/CompletableFuture;
+func httpClientSendAsync(params []interface{}) interface{} {
+	c, reqObj, req, handler, gerr := sendArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	return javaUtil.SupplyAsync(func() (any, *ghelpers.GErrBlk) {
+		return c.exchange(nil, reqObj, req, handler)
+	})
+}
@@ -0,0 +1,490 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// java.net.http.HttpRequest, its Builder, and the BodyPublishers that give a request its body.
+// A request is immutable; each build() of a builder makes a new one.
+
+const (
+	httpRequestClassName        = "java/net/http/HttpRequest"
+	httpRequestBuilderClassName = "java/net/http/HttpRequest$Builder"
+	bodyPublishersClassName     = "java/net/http/HttpRequest$BodyPublishers"
+	bodyPublisherClassName      = "java/net/http/HttpRequest$BodyPublisher"
+)
+
+func Load_Net_HttpRequest() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                                     {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"expectContinue()Z":                               {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+		"headers()Ljava/net/http/HttpHeaders;":            {ParamSlots: 0, GFunction: httpRequestHeaders},
+		"method()Ljava/lang/String;":                      {ParamSlots: 0, GFunction: httpRequestMethod},
+		"newBuilder()Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 0, GFunction: httpRequestNewBuilder},
+		"newBuilder(Ljava/net/URI;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestNewBuilder},
+		"timeout()Ljava/util/Optional;": {ParamSlots: 0, GFunction: httpRequestTimeout},
+		"toString()Ljava/lang/String;":  {ParamSlots: 0, GFunction: httpRequestToString},
+		"uri()Ljava/net/URI;":           {ParamSlots: 0, GFunction: httpRequestURI},
+		"version()Ljava/util/Optional;": {ParamSlots: 0, GFunction: httpRequestVersion},
+	} {
+		ghelpers.MethodSignatures[httpRequestClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"DELETE()Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 0, GFunction: httpRequestBuilderDELETE},
+		"GET()Ljava/net/http/HttpRequest$Builder;":    {ParamSlots: 0, GFunction: httpRequestBuilderGET},
+		"HEAD()Ljava/net/http/HttpRequest$Builder;":   {ParamSlots: 0, GFunction: httpRequestBuilderHEAD},
+		"POST(Ljava/net/http/HttpRequest$BodyPublisher;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderPOST},
+		"PUT(Ljava/net/http/HttpRequest$BodyPublisher;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderPUT},
+		"build()Ljava/net/http/HttpRequest;":        {ParamSlots: 0, GFunction: httpRequestBuilderBuild},
+		"copy()Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 0, GFunction: httpRequestBuilderCopy},
+		"expectContinue(Z)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderExpectContinue},
+		"header(Ljava/lang/String;Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 2,
+			GFunction: httpRequestBuilderHeader},
+		"headers([Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderHeaders},
+		"method(Ljava/lang/String;Ljava/net/http/HttpRequest$BodyPublisher;)Ljava/net/http/HttpRequest$Builder;": {
+			ParamSlots: 2, GFunction: httpRequestBuilderMethod},
+		"setHeader(Ljava/lang/String;Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 2,
+			GFunction: httpRequestBuilderSetHeader},
+		"timeout(Ljava/time/Duration;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderTimeout},
+		"uri(Ljava/net/URI;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1, GFunction: httpRequestBuilderURI},
+		"version(Ljava/net/http/HttpClient$Version;)Ljava/net/http/HttpRequest$Builder;": {ParamSlots: 1,
+			GFunction: httpRequestBuilderVersion},
+	} {
+		ghelpers.MethodSignatures[httpRequestBuilderClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"noBody()Ljava/net/http/HttpRequest$BodyPublisher;": {ParamSlots: 0, GFunction: bodyPublishersNoBody},
+		"ofByteArray([B)Ljava/net/http/HttpRequest$BodyPublisher;": {ParamSlots: 1,
+			GFunction: bodyPublishersOfByteArray},
+		"ofByteArray([BII)Ljava/net/http/HttpRequest$BodyPublisher;": {ParamSlots: 3,
+			GFunction: bodyPublishersOfByteArray},
+		"ofString(Ljava/lang/String;)Ljava/net/http/HttpRequest$BodyPublisher;": {ParamSlots: 1,
+			GFunction: bodyPublishersOfString},
+		"ofString(Ljava/lang/String;Ljava/nio/charset/Charset;)Ljava/net/http/HttpRequest$BodyPublisher;": {
+			ParamSlots: 2, GFunction: bodyPublishersOfString},
+	} {
+		ghelpers.MethodSignatures[bodyPublishersClassName+"."+sig] = gmeth
+	}
+
+	ghelpers.MethodSignatures[bodyPublisherClassName+".contentLength()J"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: bodyPublisherContentLength}
+}
+
+// restrictedHeaders are the headers that the client sets itself, and that a request may not.
+var restrictedHeaders = []string{"Connection", "Content-Length", "Expect", "Host", "Upgrade"}
+
+// httpRequest is the Go state of an HttpRequest, and of the HttpRequest.Builder that builds one.
+type httpRequest struct {
+	uri        *object.Object
+	method     string
+	header     http.Header
+	timeout    time.Duration // 0 is no timeout
+	timeoutObj *object.Object
+	body       []byte // nil if the request has no body
+	version    string // the name of a Version constant, or "" if the client's is used
+}
+
+// clone returns a copy of r that shares nothing that may change.
+func (r *httpRequest) clone() *httpRequest {
+	c := *r
+	c.header = r.header.Clone()
+	return &c
+}
+
+func getHttpRequest(obj any) (*httpRequest, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "HttpRequest is null")
+	}
+	r, ok := o.FieldTable[netStateField].Fvalue.(*httpRequest)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an HttpRequest")
+	}
+	return r, nil
+}
+
+func newHttpRequestObject(className string, r *httpRequest) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: r}
+	return obj
+}
+
+// checkRequestURI returns an IllegalArgumentException if the URI in arg is not an absolute
+// http or https URI.
+func checkRequestURI(arg any) *ghelpers.GErrBlk {
+	u, gerr := getURI(arg)
+	if gerr != nil {
+		return gerr
+	}
+	scheme := strings.ToLower(u.scheme)
+	if scheme != "http" && scheme != "https" {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid URI scheme "+u.scheme)
+	}
+	if u.host == "" {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "unsupported URI "+u.str)
+	}
+	return nil
+}
+
+// java/net/http/HttpRequest.newBuilder()Ljava/net/http/HttpRequest$Builder; and
+// newBuilder(Ljava/net/URI;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestNewBuilder(params []interface{}) interface{} {
+	r := &httpRequest{method: "GET", header: http.Header{}}
+	if len(params) > 0 {
+		if gerr := checkRequestURI(params[0]); gerr != nil {
+			return gerr
+		}
+		r.uri = params[0].(*object.Object)
+	}
+	return newHttpRequestObject(httpRequestBuilderClassName, r)
+}
+
+// httpRequestBuilderSet runs set on the state of the builder in params[0] and returns the builder.
+func httpRequestBuilderSet(params []interface{}, set func(r *httpRequest) *ghelpers.GErrBlk) interface{} {
+	r, gerr := getHttpRequest(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := set(r); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+// java/net/http/HttpRequest$Builder.uri(Ljava/net/URI;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderURI(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		if gerr := checkRequestURI(params[1]); gerr != nil {
+			return gerr
+		}
+		r.uri = params[1].(*object.Object)
+		return nil
+	})
+}
+
+// headerArgs returns the name and value of a header, checked as the JDK does.
+func headerArgs(nameArg, valueArg any) (string, string, *ghelpers.GErrBlk) {
+	name, ok := optString(nameArg)
+	if !ok {
+		return "", "", ghelpers.GetGErrBlk(excNames.NullPointerException, "header name is null")
+	}
+	value, ok := optString(valueArg)
+	if !ok {
+		return "", "", ghelpers.GetGErrBlk(excNames.NullPointerException, "header value is null")
+	}
+	if name == "" || strings.ContainsAny(name, " \t\r\n:()<>@,;\\\"/[]?={}") {
+		return "", "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException,
+			fmt.Sprintf("invalid header name: \"%s\"", name))
+	}
+	if slices.Contains(restrictedHeaders, http.CanonicalHeaderKey(name)) {
+		return "", "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException,
+			fmt.Sprintf("restricted header name: \"%s\"", name))
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException,
+			fmt.Sprintf("invalid header value for header '%s'", name))
+	}
+	return name, value, nil
+}
+
+// java/net/http/HttpRequest$Builder.header(Ljava/lang/String;Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderHeader(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		name, value, gerr := headerArgs(params[1], params[2])
+		if gerr != nil {
+			return gerr
+		}
+		r.header.Add(name, value)
+		return nil
+	})
+}
+
+// java/net/http/HttpRequest$Builder.setHeader(Ljava/lang/String;Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderSetHeader(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		name, value, gerr := headerArgs(params[1], params[2])
+		if gerr != nil {
+			return gerr
+		}
+		r.header.Set(name, value)
+		return nil
+	})
+}
+
+// java/net/http/HttpRequest$Builder.headers([Ljava/lang/String;)Ljava/net/http/HttpRequest$Builder;
+// -- names and values, alternately
+func httpRequestBuilderHeaders(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		arr, ok := params[1].(*object.Object)
+		if !ok || object.IsNull(arr) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "headers is null")
+		}
+		strs, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+		if len(strs) == 0 || len(strs)%2 != 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException,
+				fmt.Sprintf("wrong number, %d, of parameters", len(strs)))
+		}
+		for i := 0; i < len(strs); i += 2 {
+			name, value, gerr := headerArgs(strs[i], strs[i+1])
+			if gerr != nil {
+				return gerr
+			}
+			r.header.Add(name, value)
+		}
+		return nil
+	})
+}
+
+// java/net/http/HttpRequest$Builder.timeout(Ljava/time/Duration;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderTimeout(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		timeout, gerr := positiveDurationArg(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		r.timeout, r.timeoutObj = timeout, params[1].(*object.Object)
+		return nil
+	})
+}
+
+// java/net/http/HttpRequest$Builder.version(Ljava/net/http/HttpClient$Version;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderVersion(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		name, gerr := httpEnumConstant(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		r.version = name
+		return nil
+	})
+}
+
+// java/net/http/HttpRequest$Builder.expectContinue(Z)Ljava/net/http/HttpRequest$Builder; -- the
+// body is always sent at once, so this is ignored
+func httpRequestBuilderExpectContinue(params []interface{}) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk { return nil })
+}
+
+// setMethod sets the method and body of the builder in params[0]. A nil publisher means no body.
+func setMethod(params []interface{}, method string, publisher any) interface{} {
+	return httpRequestBuilderSet(params, func(r *httpRequest) *ghelpers.GErrBlk {
+		r.body = nil
+		if publisher != nil {
+			p, gerr := getBodyPublisher(publisher)
+			if gerr != nil {
+				return gerr
+			}
+			r.body = p.data
+		}
+		r.method = method
+		return nil
+	})
+}
+
+func httpRequestBuilderGET(params []interface{}) interface{} {
+	return setMethod(params, "GET", nil)
+}
+
+func httpRequestBuilderHEAD(params []interface{}) interface{} {
+	return setMethod(params, "HEAD", nil)
+}
+
+func httpRequestBuilderDELETE(params []interface{}) interface{} {
+	return setMethod(params, "DELETE", nil)
+}
+
+func httpRequestBuilderPOST(params []interface{}) interface{} {
+	return setMethod(params, "POST", params[1])
+}
+
+func httpRequestBuilderPUT(params []interface{}) interface{} {
+	return setMethod(params, "PUT", params[1])
+}
+
+// java/net/http/HttpRequest$Builder.method(Ljava/lang/String;Ljava/net/http/HttpRequest$BodyPublisher;)Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderMethod(params []interface{}) interface{} {
+	method, ok := optString(params[1])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "method is null")
+	}
+	switch {
+	case method == "":
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "illegal method <empty string>")
+	case method == "CONNECT":
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "method CONNECT is not supported")
+	case strings.ContainsAny(method, " \t\r\n:()<>@,;\\\"/[]?={}"):
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "illegal method \""+method+"\"")
+	}
+	return setMethod([]interface{}{params[0]}, method, params[2])
+}
+
+// java/net/http/HttpRequest$Builder.copy()Ljava/net/http/HttpRequest$Builder;
+func httpRequestBuilderCopy(params []interface{}) interface{} {
+	r, gerr := getHttpRequest(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return newHttpRequestObject(httpRequestBuilderClassName, r.clone())
+}
+
+// java/net/http/HttpRequest$Builder.build()Ljava/net/http/HttpRequest;
+func httpRequestBuilderBuild(params []interface{}) interface{} {
+	r, gerr := getHttpRequest(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if r.uri == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "uri is null")
+	}
+	return newHttpRequestObject(httpRequestClassName, r.clone())
+}
+
+// httpRequestState runs get on the state of the request in params[0].
+func httpRequestState(params []interface{}, get func(r *httpRequest) interface{}) interface{} {
+	r, gerr := getHttpRequest(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(r)
+}
+
+func httpRequestURI(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} { return r.uri })
+}
+
+func httpRequestMethod(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} {
+		return object.StringObjectFromGoString(r.method)
+	})
+}
+
+func httpRequestHeaders(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} { return newHttpHeaders(r.header) })
+}
+
+// java/net/http/HttpRequest.timeout()Ljava/util/Optional;
+func httpRequestTimeout(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} {
+		if r.timeoutObj == nil {
+			return object.MakeEmptyObjectWithClassName(&types.ClassNameOptional)
+		}
+		return object.MakePrimitiveObject(types.ClassNameOptional, "Ljava/time/Duration;", r.timeoutObj)
+	})
+}
+
+// java/net/http/HttpRequest.version()Ljava/util/Optional;
+func httpRequestVersion(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} {
+		if r.version == "" {
+			return object.MakeEmptyObjectWithClassName(&types.ClassNameOptional)
+		}
+		return object.MakePrimitiveObject(types.ClassNameOptional, "L"+httpVersionClassName+";",
+			httpEnum(httpVersionClassName, r.version))
+	})
+}
+
+// java/net/http/HttpRequest.toString()Ljava/lang/String; -- as in the JDK, the URI and the method
+func httpRequestToString(params []interface{}) interface{} {
+	return httpRequestState(params, func(r *httpRequest) interface{} {
+		u, gerr := getURI(r.uri)
+		if gerr != nil {
+			return gerr
+		}
+		return object.StringObjectFromGoString(u.str + " " + r.method)
+	})
+}
+
+// bodyPublisher is the Go state of a BodyPublisher: the whole body.
+type bodyPublisher struct {
+	data []byte
+}
+
+func getBodyPublisher(obj any) (*bodyPublisher, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "BodyPublisher is null")
+	}
+	p, ok := o.FieldTable[netStateField].Fvalue.(*bodyPublisher)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a BodyPublisher")
+	}
+	return p, nil
+}
+
+func newBodyPublisher(data []byte) *object.Object {
+	className := bodyPublisherClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: &bodyPublisher{data: data}}
+	return obj
+}
+
+// java/net/http/HttpRequest$BodyPublishers.noBody()Ljava/net/http/HttpRequest$BodyPublisher;
+func bodyPublishersNoBody([]interface{}) interface{} {
+	return newBodyPublisher([]byte{})
+}
+
+// java/net/http/HttpRequest$BodyPublishers.ofString(Ljava/lang/String;)Ljava/net/http/HttpRequest$BodyPublisher;
+// and ofString(Ljava/lang/String;Ljava/nio/charset/Charset;) -- UTF-8 if no charset is given
+func bodyPublishersOfString(params []interface{}) interface{} {
+	str, ok := optString(params[0])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "body is null")
+	}
+	cs := ghelpers.CharsetUTF8
+	if len(params) > 1 {
+		var gerr *ghelpers.GErrBlk
+		if cs, gerr = ghelpers.CharsetFromObject(params[1]); gerr != nil {
+			return gerr
+		}
+	}
+	return newBodyPublisher(cs.EncodeReplacing(str))
+}
+
+// java/net/http/HttpRequest$BodyPublishers.ofByteArray([B)Ljava/net/http/HttpRequest$BodyPublisher;
+// and ofByteArray([BII) -- the bytes are copied
+func bodyPublishersOfByteArray(params []interface{}) interface{} {
+	if len(params) == 1 {
+		arr, ok := params[0].(*object.Object)
+		if !ok || object.IsNull(arr) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "BodyPublishers.ofByteArray: byte array is null")
+		}
+		jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+		return newBodyPublisher(object.GoByteArrayFromJavaByteArray(jbytes))
+	}
+	jbytes, off, length, gerr := byteArrayRange(params, "BodyPublishers.ofByteArray")
+	if gerr != nil {
+		return gerr
+	}
+	return newBodyPublisher(object.GoByteArrayFromJavaByteArray(jbytes[off : off+length]))
+}
+
+// java/net/http/HttpRequest$BodyPublisher.contentLength()J
+func bodyPublisherContentLength(params []interface{}) interface{} {
+	p, gerr := getBodyPublisher(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(p.data))
+}
@@ -0,0 +1,356 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// java.net.http.HttpResponse, the BodyHandlers that turn a response body into a Java value,
+// and HttpHeaders.
+
+const (
+	httpResponseClassName        = "java/net/http/HttpResponse"
+	bodyHandlersClassName        = "java/net/http/HttpResponse$BodyHandlers"
+	bodyHandlerClassName         = "java/net/http/HttpResponse$BodyHandler"
+	httpHeadersClassName         = "java/net/http/HttpHeaders"
+	responseInputStreamClassName = "jdk/internal/net/http/ResponseSubscribers$HttpResponseInputStream"
+)
+
+func Load_Net_HttpResponse() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"body()Ljava/lang/Object;":                    {ParamSlots: 0, GFunction: httpResponseBody},
+		"headers()Ljava/net/http/HttpHeaders;":        {ParamSlots: 0, GFunction: httpResponseHeaders},
+		"previousResponse()Ljava/util/Optional;":      {ParamSlots: 0, GFunction: httpResponsePreviousResponse},
+		"request()Ljava/net/http/HttpRequest;":        {ParamSlots: 0, GFunction: httpResponseRequest},
+		"statusCode()I":                               {ParamSlots: 0, GFunction: httpResponseStatusCode},
+		"toString()Ljava/lang/String;":                {ParamSlots: 0, GFunction: httpResponseToString},
+		"uri()Ljava/net/URI;":                         {ParamSlots: 0, GFunction: httpResponseURI},
+		"version()Ljava/net/http/HttpClient$Version;": {ParamSlots: 0, GFunction: httpResponseVersion},
+	} {
+		ghelpers.MethodSignatures[httpResponseClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"discarding()Ljava/net/http/HttpResponse$BodyHandler;": {ParamSlots: 0, GFunction: bodyHandlersDiscarding},
+		"ofByteArray()Ljava/net/http/HttpResponse$BodyHandler;": {ParamSlots: 0,
+			GFunction: bodyHandlersOfByteArray},
+		"ofInputStream()Ljava/net/http/HttpResponse$BodyHandler;": {ParamSlots: 0,
+			GFunction: bodyHandlersOfInputStream},
+		"ofString()Ljava/net/http/HttpResponse$BodyHandler;": {ParamSlots: 0, GFunction: bodyHandlersOfString},
+		"ofString(Ljava/nio/charset/Charset;)Ljava/net/http/HttpResponse$BodyHandler;": {ParamSlots: 1,
+			GFunction: bodyHandlersOfString},
+	} {
+		ghelpers.MethodSignatures[bodyHandlersClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"allValues(Ljava/lang/String;)Ljava/util/List;":      {ParamSlots: 1, GFunction: httpHeadersAllValues},
+		"firstValue(Ljava/lang/String;)Ljava/util/Optional;": {ParamSlots: 1, GFunction: httpHeadersFirstValue},
+		"firstValueAsLong(Ljava/lang/String;)Ljava/util/OptionalLong;": {ParamSlots: 1,
+			GFunction: httpHeadersFirstValueAsLong},
+		"map()Ljava/util/Map;":         {ParamSlots: 0, GFunction: httpHeadersMap},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: httpHeadersToString},
+	} {
+		ghelpers.MethodSignatures[httpHeadersClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range httpInputStreamMethods {
+		ghelpers.MethodSignatures[responseInputStreamClassName+"."+sig] = gmeth
+	}
+}
+
+// bodyHandler is the Go state of a BodyHandler.
+type bodyHandler struct {
+	kind    string            // "string", "bytes", "stream" or "discard"
+	charset *ghelpers.Charset // for "string": nil means the charset of the Content-Type, or UTF-8
+}
+
+func getBodyHandler(obj any) (*bodyHandler, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "BodyHandler is null")
+	}
+	h, ok := o.FieldTable[netStateField].Fvalue.(*bodyHandler)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a BodyHandler")
+	}
+	return h, nil
+}
+
+func newBodyHandler(h *bodyHandler) *object.Object {
+	className := bodyHandlerClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: h}
+	return obj
+}
+
+// java/net/http/HttpResponse$BodyHandlers.ofString()Ljava/net/http/HttpResponse$BodyHandler; and
+// ofString(Ljava/nio/charset/Charset;)
+func bodyHandlersOfString(params []interface{}) interface{} {
+	h := &bodyHandler{kind: "string"}
+	if len(params) > 0 {
+		cs, gerr := ghelpers.CharsetFromObject(params[0])
+		if gerr != nil {
+			return gerr
+		}
+		h.charset = cs
+	}
+	return newBodyHandler(h)
+}
+
+func bodyHandlersOfByteArray([]interface{}) interface{} {
+	return newBodyHandler(&bodyHandler{kind: "bytes"})
+}
+
+func bodyHandlersOfInputStream([]interface{}) interface{} {
+	return newBodyHandler(&bodyHandler{kind: "stream"})
+}
+
+func bodyHandlersDiscarding([]interface{}) interface{} {
+	return newBodyHandler(&bodyHandler{kind: "discard"})
+}
+
+// responseCharset returns the charset named by the Content-Type of resp, or UTF-8.
+func responseCharset(resp *http.Response) *ghelpers.Charset {
+	_, mediaParams, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err == nil {
+		if cs := ghelpers.LookupCharset(mediaParams["charset"]); cs != nil {
+			return cs
+		}
+	}
+	return ghelpers.CharsetUTF8
+}
+
+// handle returns the body of resp as the Java value that h makes of it. release is called once
+// the body is no longer needed: at once, unless the body is streamed, when it is called as the
+// stream is closed.
+func (h *bodyHandler) handle(resp *http.Response, release context.CancelFunc) (any, *ghelpers.GErrBlk) {
+	if h.kind == "stream" {
+		return newHttpInputStream(responseInputStreamClassName, &releasingBody{resp.Body, release}, 0), nil
+	}
+	defer release()
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, httpError(err)
+	}
+	switch h.kind {
+	case "string":
+		cs := h.charset
+		if cs == nil {
+			cs = responseCharset(resp)
+		}
+		return object.StringObjectFromGoString(cs.DecodeReplacing(data)), nil
+	case "bytes":
+		return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+			object.JavaByteArrayFromGoByteArray(data)), nil
+	}
+	return object.Null, nil
+}
+
+// releasingBody is a response body that calls release when it is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release context.CancelFunc
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// httpResponse is the Go state of an HttpResponse.
+type httpResponse struct {
+	status  int
+	header  http.Header
+	body    any
+	uri     *object.Object
+	request *object.Object
+	version string
+}
+
+// newHttpResponse returns the HttpResponse to the request reqObj, with the body given.
+func newHttpResponse(resp *http.Response, reqObj *object.Object, body any) *object.Object {
+	r := &httpResponse{status: resp.StatusCode, header: resp.Header, body: body, request: reqObj,
+		version: "HTTP_1_1"}
+	if resp.ProtoMajor == 2 {
+		r.version = "HTTP_2"
+	}
+	if u, gerr := parseURI(resp.Request.URL.String()); gerr == nil {
+		r.uri = newURIObject(u)
+	} else if req, gerr := getHttpRequest(reqObj); gerr == nil {
+		r.uri = req.uri
+	}
+	className := httpResponseClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: r}
+	return obj
+}
+
+// httpResponseState runs get on the state of the response in params[0].
+func httpResponseState(params []interface{}, get func(r *httpResponse) interface{}) interface{} {
+	o, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(o) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "HttpResponse is null")
+	}
+	r, ok := o.FieldTable[netStateField].Fvalue.(*httpResponse)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an HttpResponse")
+	}
+	return get(r)
+}
+
+func httpResponseStatusCode(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} { return int64(r.status) })
+}
+
+func httpResponseBody(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} { return r.body })
+}
+
+func httpResponseHeaders(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} { return newHttpHeaders(r.header) })
+}
+
+func httpResponseRequest(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} { return r.request })
+}
+
+func httpResponseURI(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} { return r.uri })
+}
+
+func httpResponseVersion(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} {
+		return httpEnum(httpVersionClassName, r.version)
+	})
+}
+
+// java/net/http/HttpResponse.previousResponse()Ljava/util/Optional; -- redirects are followed
+// inside the exchange, so there is never one
+func httpResponsePreviousResponse(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} {
+		return object.MakeEmptyObjectWithClassName(&types.ClassNameOptional)
+	})
+}
+
+// java/net/http/HttpResponse.toString()Ljava/lang/String; -- as in the JDK, "(GET uri) 200"
+func httpResponseToString(params []interface{}) interface{} {
+	return httpResponseState(params, func(r *httpResponse) interface{} {
+		req, gerr := getHttpRequest(r.request)
+		if gerr != nil {
+			return gerr
+		}
+		u, gerr := getURI(r.uri)
+		if gerr != nil {
+			return gerr
+		}
+		return object.StringObjectFromGoString(fmt.Sprintf("(%s %s) %d", req.method, u.str, r.status))
+	})
+}
+
+// newHttpHeaders returns an HttpHeaders of a copy of header.
+func newHttpHeaders(header http.Header) *object.Object {
+	className := httpHeadersClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: header.Clone()}
+	return obj
+}
+
+// httpHeadersState runs get on the header of the HttpHeaders in params[0].
+func httpHeadersState(params []interface{}, get func(header http.Header) interface{}) interface{} {
+	o, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(o) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "HttpHeaders is null")
+	}
+	header, ok := o.FieldTable[netStateField].Fvalue.(http.Header)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an HttpHeaders")
+	}
+	return get(header)
+}
+
+// headerName returns the header name in arg.
+func headerName(arg any) (string, *ghelpers.GErrBlk) {
+	name, ok := optString(arg)
+	if !ok {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "header name is null")
+	}
+	return name, nil
+}
+
+// java/net/http/HttpHeaders.firstValue(Ljava/lang/String;)Ljava/util/Optional;
+func httpHeadersFirstValue(params []interface{}) interface{} {
+	return httpHeadersState(params, func(header http.Header) interface{} {
+		name, gerr := headerName(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		values := header.Values(name)
+		if len(values) == 0 {
+			return object.MakeEmptyObjectWithClassName(&types.ClassNameOptional)
+		}
+		return object.MakePrimitiveObject(types.ClassNameOptional, types.StringClassRef,
+			object.StringObjectFromGoString(values[0]))
+	})
+}
+
+// java/net/http/HttpHeaders.firstValueAsLong(Ljava/lang/String;)Ljava/util/OptionalLong;
+func httpHeadersFirstValueAsLong(params []interface{}) interface{} {
+	return httpHeadersState(params, func(header http.Header) interface{} {
+		name, gerr := headerName(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		className := "java/util/OptionalLong"
+		values := header.Values(name)
+		if len(values) == 0 {
+			return object.MakeEmptyObjectWithClassName(&className)
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64)
+		if err != nil {
+			return ghelpers.GetGErrBlk(excNames.NumberFormatException,
+				fmt.Sprintf("For input string: \"%s\"", values[0]))
+		}
+		return object.MakePrimitiveObject(className, types.Long, n)
+	})
+}
+
+// java/net/http/HttpHeaders.allValues(Ljava/lang/String;)Ljava/util/List;
+func httpHeadersAllValues(params []interface{}) interface{} {
+	return httpHeadersState(params, func(header http.Header) interface{} {
+		name, gerr := headerName(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		return newStringList(header.Values(name))
+	})
+}
+
+// java/net/http/HttpHeaders.map()Ljava/util/Map;
+func httpHeadersMap(params []interface{}) interface{} {
+	return httpHeadersState(params, func(header http.Header) interface{} { return newHeaderMap(header, "") })
+}
+
+// java/net/http/HttpHeaders.toString()Ljava/lang/String;
+func httpHeadersToString(params []interface{}) interface{} {
+	return httpHeadersState(params, func(header http.Header) interface{} {
+		return object.StringObjectFromGoString(fmt.Sprintf("java.net.http.HttpHeaders@%x %v",
+			params[0].(*object.Object).Mark.Hash, map[string][]string(header)))
+	})
+}
@@ -0,0 +1,1059 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// java.net.HttpURLConnection over Go's net/http. The request is sent when the response is first
+// needed, or by connect() if there is no request body. A body written to the output stream is
+// buffered, and sent in one piece; the response body is streamed.
+//
+// The streams are objects of the JDK's own classes, HttpURLConnection$HttpInputStream and
+// PosterOutputStream. The input stream class is shared with the response bodies of HttpClient.
+
+const (
+	httpURLConnectionClassName  = "java/net/HttpURLConnection"
+	httpInputStreamClassName    = "sun/net/www/protocol/http/HttpURLConnection$HttpInputStream"
+	posterOutputStreamClassName = "sun/net/www/http/PosterOutputStream"
+)
+
+func Load_Net_HttpURLConnection() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V": {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"addRequestProperty(Ljava/lang/String;Ljava/lang/String;)V": {ParamSlots: 2,
+			GFunction: httpURLConnectionAddRequestProperty},
+		"connect()V":                             {ParamSlots: 0, GFunction: httpURLConnectionConnect},
+		"disconnect()V":                          {ParamSlots: 0, GFunction: httpURLConnectionDisconnect},
+		"getConnectTimeout()I":                   {ParamSlots: 0, GFunction: httpURLConnectionGetConnectTimeout},
+		"getContentEncoding()Ljava/lang/String;": {ParamSlots: 0, GFunction: httpURLConnectionGetContentEncoding},
+		"getContentLength()I":                    {ParamSlots: 0, GFunction: httpURLConnectionGetContentLength},
+		"getContentLengthLong()J":                {ParamSlots: 0, GFunction: httpURLConnectionGetContentLengthLong},
+		"getContentType()Ljava/lang/String;":     {ParamSlots: 0, GFunction: httpURLConnectionGetContentType},
+		"getDoInput()Z":                          {ParamSlots: 0, GFunction: httpURLConnectionGetDoInput},
+		"getDoOutput()Z":                         {ParamSlots: 0, GFunction: httpURLConnectionGetDoOutput},
+		"getErrorStream()Ljava/io/InputStream;":  {ParamSlots: 0, GFunction: httpURLConnectionGetErrorStream},
+		"getFollowRedirects()Z":                  {ParamSlots: 0, GFunction: httpURLConnectionGetFollowRedirects},
+		"getHeaderField(I)Ljava/lang/String;":    {ParamSlots: 1, GFunction: httpURLConnectionGetHeaderField},
+		"getHeaderField(Ljava/lang/String;)Ljava/lang/String;": {ParamSlots: 1,
+			GFunction: httpURLConnectionGetHeaderField},
+		"getHeaderFieldInt(Ljava/lang/String;I)I":  {ParamSlots: 2, GFunction: httpURLConnectionGetHeaderFieldLong},
+		"getHeaderFieldKey(I)Ljava/lang/String;":   {ParamSlots: 1, GFunction: httpURLConnectionGetHeaderFieldKey},
+		"getHeaderFieldLong(Ljava/lang/String;J)J": {ParamSlots: 2, GFunction: httpURLConnectionGetHeaderFieldLong},
+		"getHeaderFields()Ljava/util/Map;":         {ParamSlots: 0, GFunction: httpURLConnectionGetHeaderFields},
+		"getInputStream()Ljava/io/InputStream;": {ParamSlots: 0, GFunction: httpURLConnectionGetInputStream,
+			NeedsContext: true},
+		"getInstanceFollowRedirects()Z":           {ParamSlots: 0, GFunction: httpURLConnectionGetInstanceFollowRedirects},
+		"getOutputStream()Ljava/io/OutputStream;": {ParamSlots: 0, GFunction: httpURLConnectionGetOutputStream},
+		"getReadTimeout()I":                       {ParamSlots: 0, GFunction: httpURLConnectionGetReadTimeout},
+		"getRequestMethod()Ljava/lang/String;":    {ParamSlots: 0, GFunction: httpURLConnectionGetRequestMethod},
+		"getRequestProperties()Ljava/util/Map;":   {ParamSlots: 0, GFunction: httpURLConnectionGetRequestProperties},
+		"getRequestProperty(Ljava/lang/String;)Ljava/lang/String;": {ParamSlots: 1,
+			GFunction: httpURLConnectionGetRequestProperty},
+		"getResponseCode()I":                     {ParamSlots: 0, GFunction: httpURLConnectionGetResponseCode},
+		"getResponseMessage()Ljava/lang/String;": {ParamSlots: 0, GFunction: httpURLConnectionGetResponseMessage},
+		"getURL()Ljava/net/URL;":                 {ParamSlots: 0, GFunction: httpURLConnectionGetURL},
+		"getUseCaches()Z":                        {ParamSlots: 0, GFunction: httpURLConnectionGetUseCaches},
+		"setChunkedStreamingMode(I)V":            {ParamSlots: 1, GFunction: httpURLConnectionSetStreamingMode},
+		"setConnectTimeout(I)V":                  {ParamSlots: 1, GFunction: httpURLConnectionSetConnectTimeout},
+		"setDoInput(Z)V":                         {ParamSlots: 1, GFunction: httpURLConnectionSetDoInput},
+		"setDoOutput(Z)V":                        {ParamSlots: 1, GFunction: httpURLConnectionSetDoOutput},
+		"setFixedLengthStreamingMode(I)V":        {ParamSlots: 1, GFunction: httpURLConnectionSetStreamingMode},
+		"setFixedLengthStreamingMode(J)V":        {ParamSlots: 1, GFunction: httpURLConnectionSetStreamingMode},
+		"setFollowRedirects(Z)V":                 {ParamSlots: 1, GFunction: httpURLConnectionSetFollowRedirects},
+		"setInstanceFollowRedirects(Z)V":         {ParamSlots: 1, GFunction: httpURLConnectionSetInstanceFollowRedirects},
+		"setReadTimeout(I)V":                     {ParamSlots: 1, GFunction: httpURLConnectionSetReadTimeout},
+		"setRequestMethod(Ljava/lang/String;)V":  {ParamSlots: 1, GFunction: httpURLConnectionSetRequestMethod},
+		"setRequestProperty(Ljava/lang/String;Ljava/lang/String;)V": {ParamSlots: 2,
+			GFunction: httpURLConnectionSetRequestProperty},
+		"setUseCaches(Z)V":             {ParamSlots: 1, GFunction: httpURLConnectionSetUseCaches},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: httpURLConnectionToString},
+		"usingProxy()Z":                {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+	} {
+		ghelpers.MethodSignatures[httpURLConnectionClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range httpInputStreamMethods {
+		ghelpers.MethodSignatures[httpInputStreamClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"close()V":     {ParamSlots: 0, GFunction: ghelpers.ReturnNull},
+		"flush()V":     {ParamSlots: 0, GFunction: ghelpers.ReturnNull},
+		"write(I)V":    {ParamSlots: 1, GFunction: posterOutputStreamWrite},
+		"write([B)V":   {ParamSlots: 1, GFunction: posterOutputStreamWrite},
+		"write([BII)V": {ParamSlots: 3, GFunction: posterOutputStreamWrite},
+	} {
+		ghelpers.MethodSignatures[posterOutputStreamClassName+"."+sig] = gmeth
+	}
+}
+
+// httpInputStreamMethods are the methods of the streams over response bodies.
+var httpInputStreamMethods = map[string]ghelpers.GMeth{
+	"available()I":     {ParamSlots: 0, GFunction: httpInputStreamAvailable},
+	"close()V":         {ParamSlots: 0, GFunction: httpInputStreamClose},
+	"markSupported()Z": {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+	"read()I":          {ParamSlots: 0, GFunction: httpInputStreamRead},
+	"read([B)I":        {ParamSlots: 1, GFunction: httpInputStreamRead},
+	"read([BII)I":      {ParamSlots: 3, GFunction: httpInputStreamRead},
+	"readAllBytes()[B": {ParamSlots: 0, GFunction: httpInputStreamReadAllBytes},
+	"skip(J)J":         {ParamSlots: 1, GFunction: httpInputStreamSkip},
+}
+
+// followRedirects is the default of instanceFollowRedirects for new connections.
+var followRedirects atomic.Bool
+
+func init() {
+	followRedirects.Store(true)
+}
+
+// validHttpMethods are the request methods that setRequestMethod() accepts.
+var validHttpMethods = []string{"GET", "POST", "HEAD", "OPTIONS", "PUT", "DELETE", "TRACE"}
+
+// httpURLConnection is the Go state of an HttpURLConnection.
+type httpURLConnection struct {
+	mu     sync.Mutex
+	urlObj *object.Object
+	target *url
+	method string
+	header http.Header // the request properties
+
+	doInput, doOutput bool
+	useCaches         bool
+	followRedirects   bool
+	connectTimeout    time.Duration // 0 is no timeout
+	readTimeout       time.Duration
+
+	body      *bytes.Buffer // what was written to the output stream, if it was asked for
+	out       *object.Object
+	connected bool
+	resp      *http.Response
+	respErr   *ghelpers.GErrBlk
+	in        *object.Object
+}
+
+// newHttpURLConnection returns an unconnected HttpURLConnection to u.
+func newHttpURLConnection(urlObj *object.Object, u *url) *object.Object {
+	className := httpURLConnectionClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	c := &httpURLConnection{urlObj: urlObj, target: u, method: "GET", header: http.Header{}, doInput: true,
+		useCaches: true, followRedirects: followRedirects.Load()}
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: c}
+	return obj
+}
+
+// getHttpURLConnection returns the Go state of the HttpURLConnection obj.
+func getHttpURLConnection(obj any) (*httpURLConnection, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "HttpURLConnection is null")
+	}
+	c, ok := o.FieldTable[netStateField].Fvalue.(*httpURLConnection)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an HttpURLConnection")
+	}
+	return c, nil
+}
+
+// errConnectTimeout is the error of a dial that has timed out.
+var errConnectTimeout = errors.New("connect timed out")
+
+// dialer returns a DialContext function that times out after timeout, if it is not 0, with
+// errConnectTimeout.
+func dialer(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	d := &net.Dialer{Timeout: timeout}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, network, addr)
+		var netErr net.Error
+		if timeout > 0 && errors.As(err, &netErr) && netErr.Timeout() && ctx.Err() == nil {
+			return nil, errConnectTimeout
+		}
+		return conn, err
+	}
+}
+
+// httpError returns the exception for an error of an HTTP exchange.
+func httpError(err error) *ghelpers.GErrBlk {
+	var urlErr *neturl.Error
+	if errors.As(err, &urlErr) {
+		if errors.Is(urlErr.Err, errConnectTimeout) {
+			return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Connect timed out")
+		}
+		if urlErr.Timeout() {
+			return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Read timed out")
+		}
+		err = urlErr.Err
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return socketError(err)
+	}
+	return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+}
+
+// checkUnconnected returns an IllegalStateException if the connection has been made.
+func (c *httpURLConnection) checkUnconnected() *ghelpers.GErrBlk {
+	if c.connected {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "Already connected")
+	}
+	return nil
+}
+
+// response returns the response, sending the request if it has not yet been sent. An error is
+// remembered, and returned again by later calls, as in the JDK.
+func (c *httpURLConnection) response() (*http.Response, *ghelpers.GErrBlk) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resp != nil || c.respErr != nil {
+		return c.resp, c.respErr
+	}
+	c.connected = true
+	c.resp, c.respErr = c.exchange()
+	return c.resp, c.respErr
+}
+
+// exchange sends the request and receives the head of the response.
+func (c *httpURLConnection) exchange() (*http.Response, *ghelpers.GErrBlk) {
+	var body io.Reader
+	if c.body != nil {
+		body = bytes.NewReader(c.body.Bytes())
+	}
+	req, err := http.NewRequest(c.method, c.target.String(), body)
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	req.Header = c.header.Clone()
+	if c.body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer(c.connectTimeout),
+		ResponseHeaderTimeout: c.readTimeout,
+		DisableKeepAlives:     true,
+	}
+	client := &http.Client{Transport: transport}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		// the JDK does not follow a redirect to another protocol
+		if !c.followRedirects || req.URL.Scheme != via[0].URL.Scheme {
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= 20 {
+			return fmt.Errorf("Server redirected too many  times (%d)", len(via))
+		}
+		return nil
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, httpError(err)
+	}
+	return resp, nil
+}
+
+// bodyStream returns the stream over the body of the response.
+func (c *httpURLConnection) bodyStream() *object.Object {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.in == nil {
+		c.in = newHttpInputStream(httpInputStreamClassName, c.resp.Body, c.readTimeout)
+	}
+	return c.in
+}
+
+// httpURLConnectionState runs get on the state of the connection in params[0].
+func httpURLConnectionState(params []interface{}, get func(c *httpURLConnection) interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(c)
+}
+
+// httpURLConnectionSetup runs set on the state of the connection in params[0], if the
+// connection has not yet been made.
+func httpURLConnectionSetup(params []interface{}, set func(c *httpURLConnection) *ghelpers.GErrBlk) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gerr := c.checkUnconnected(); gerr != nil {
+		return gerr
+	}
+	return gerrOrNil(set(c))
+}
+
+// java/net/HttpURLConnection.setRequestMethod(Ljava/lang/String;)V
+func httpURLConnectionSetRequestMethod(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	method, _ := optString(params[1])
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected {
+		return ghelpers.GetGErrBlk(excNames.ProtocolException, "Can't reset method: already connected")
+	}
+	if !slices.Contains(validHttpMethods, method) {
+		return ghelpers.GetGErrBlk(excNames.ProtocolException, "Invalid HTTP method: "+method)
+	}
+	c.method = method
+	return nil
+}
+
+func httpURLConnectionGetRequestMethod(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return object.StringObjectFromGoString(c.method)
+	})
+}
+
+// java/net/HttpURLConnection.setRequestProperty(Ljava/lang/String;Ljava/lang/String;)V
+func httpURLConnectionSetRequestProperty(params []interface{}) interface{} {
+	return setRequestProperty(params, false)
+}
+
+// java/net/HttpURLConnection.addRequestProperty(Ljava/lang/String;Ljava/lang/String;)V
+func httpURLConnectionAddRequestProperty(params []interface{}) interface{} {
+	return setRequestProperty(params, true)
+}
+
+func setRequestProperty(params []interface{}, add bool) interface{} {
+	return httpURLConnectionSetup(params, func(c *httpURLConnection) *ghelpers.GErrBlk {
+		key, ok := optString(params[1])
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "key is null")
+		}
+		value, _ := optString(params[2])
+		if add {
+			c.header.Add(key, value)
+		} else {
+			c.header.Set(key, value)
+		}
+		return nil
+	})
+}
+
+// java/net/HttpURLConnection.getRequestProperty(Ljava/lang/String;)Ljava/lang/String; -- the
+// last value set for the key
+func httpURLConnectionGetRequestProperty(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gerr := c.checkUnconnected(); gerr != nil {
+		return gerr
+	}
+	key, _ := optString(params[1])
+	values := c.header.Values(key)
+	if len(values) == 0 {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(values[len(values)-1])
+}
+
+// java/net/HttpURLConnection.getRequestProperties()Ljava/util/Map;
+func httpURLConnectionGetRequestProperties(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gerr := c.checkUnconnected(); gerr != nil {
+		return gerr
+	}
+	return newHeaderMap(c.header, "")
+}
+
+func httpURLConnectionSetDoOutput(params []interface{}) interface{} {
+	return httpURLConnectionSetup(params, func(c *httpURLConnection) *ghelpers.GErrBlk {
+		c.doOutput = params[1].(int64) == types.JavaBoolTrue
+		return nil
+	})
+}
+
+func httpURLConnectionGetDoOutput(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return types.ConvertGoBoolToJavaBool(c.doOutput)
+	})
+}
+
+func httpURLConnectionSetDoInput(params []interface{}) interface{} {
+	return httpURLConnectionSetup(params, func(c *httpURLConnection) *ghelpers.GErrBlk {
+		c.doInput = params[1].(int64) == types.JavaBoolTrue
+		return nil
+	})
+}
+
+func httpURLConnectionGetDoInput(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return types.ConvertGoBoolToJavaBool(c.doInput)
+	})
+}
+
+// java/net/HttpURLConnection.setUseCaches(Z)V -- there is no cache, so this is only recorded
+func httpURLConnectionSetUseCaches(params []interface{}) interface{} {
+	return httpURLConnectionSetup(params, func(c *httpURLConnection) *ghelpers.GErrBlk {
+		c.useCaches = params[1].(int64) == types.JavaBoolTrue
+		return nil
+	})
+}
+
+func httpURLConnectionGetUseCaches(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return types.ConvertGoBoolToJavaBool(c.useCaches)
+	})
+}
+
+// java/net/HttpURLConnection.setFixedLengthStreamingMode and setChunkedStreamingMode -- the
+// body is always buffered and sent with its length, so these only check their argument
+func httpURLConnectionSetStreamingMode(params []interface{}) interface{} {
+	return httpURLConnectionSetup(params, func(c *httpURLConnection) *ghelpers.GErrBlk {
+		if params[1].(int64) < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid content length")
+		}
+		return nil
+	})
+}
+
+// timeoutArg returns the timeout in milliseconds in arg as a Duration.
+func timeoutArg(arg any) (time.Duration, *ghelpers.GErrBlk) {
+	ms := arg.(int64)
+	if ms < 0 {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "timeouts can't be negative")
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func httpURLConnectionSetConnectTimeout(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	timeout, gerr := timeoutArg(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	c.connectTimeout = timeout
+	c.mu.Unlock()
+	return nil
+}
+
+func httpURLConnectionGetConnectTimeout(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return c.connectTimeout.Milliseconds()
+	})
+}
+
+func httpURLConnectionSetReadTimeout(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	timeout, gerr := timeoutArg(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	c.readTimeout = timeout
+	c.mu.Unlock()
+	return nil
+}
+
+func httpURLConnectionGetReadTimeout(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return c.readTimeout.Milliseconds()
+	})
+}
+
+// java/net/HttpURLConnection.setInstanceFollowRedirects(Z)V -- may be called until the
+// response is received
+func httpURLConnectionSetInstanceFollowRedirects(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	c.followRedirects = params[1].(int64) == types.JavaBoolTrue
+	c.mu.Unlock()
+	return nil
+}
+
+func httpURLConnectionGetInstanceFollowRedirects(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return types.ConvertGoBoolToJavaBool(c.followRedirects)
+	})
+}
+
+// java/net/HttpURLConnection.setFollowRedirects(Z)V -- static: the default for new connections
+func httpURLConnectionSetFollowRedirects(params []interface{}) interface{} {
+	followRedirects.Store(params[0].(int64) == types.JavaBoolTrue)
+	return nil
+}
+
+func httpURLConnectionGetFollowRedirects(params []interface{}) interface{} {
+	return types.ConvertGoBoolToJavaBool(followRedirects.Load())
+}
+
+func httpURLConnectionGetURL(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} { return c.urlObj })
+}
+
+// java/net/HttpURLConnection.connect()V -- sends the request now, unless there is a body to
+// be written first
+func httpURLConnectionConnect(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	deferred := c.doOutput
+	if deferred {
+		c.connected = true
+	}
+	c.mu.Unlock()
+	if deferred {
+		return nil
+	}
+	_, gerr = c.response()
+	return gerrOrNil(gerr)
+}
+
+// java/net/HttpURLConnection.disconnect()V -- closes the response body
+func httpURLConnectionDisconnect(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.resp != nil {
+			_ = c.resp.Body.Close()
+		}
+		return nil
+	})
+}
+
+// java/net/HttpURLConnection.getOutputStream()Ljava/io/OutputStream; -- a GET becomes a POST,
+// as in the JDK
+func httpURLConnectionGetOutputStream(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.doOutput {
+		return ghelpers.GetGErrBlk(excNames.ProtocolException,
+			"cannot write to a URLConnection if doOutput=false - call setDoOutput(true)")
+	}
+	if c.resp != nil || c.respErr != nil {
+		return ghelpers.GetGErrBlk(excNames.ProtocolException, "Cannot write output after reading input.")
+	}
+	if c.out == nil {
+		if c.method == "GET" {
+			c.method = "POST"
+		}
+		c.connected = true
+		c.body = &bytes.Buffer{}
+		className := posterOutputStreamClassName
+		c.out = object.MakeEmptyObjectWithClassName(&className)
+		c.out.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: c.body}
+	}
+	return c.out
+}
+
+// java/net/HttpURLConnection.getInputStream()Ljava/io/InputStream; -- an error status is an
+// IOException, or a FileNotFoundException for 404 and 410, as in the JDK
+func httpURLConnectionGetInputStream(params []interface{}) interface{} {
+	_, params = ghelpers.SplitContext(params)
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if !c.doInput {
+		return ghelpers.GetGErrBlk(excNames.ProtocolException,
+			"Cannot read from URLConnection if doInput=false (call setDoInput(true))")
+	}
+	resp, gerr := c.response()
+	if gerr != nil {
+		return gerr
+	}
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return ghelpers.GetGErrBlk(excNames.FileNotFoundException, c.target.String())
+	case code >= 400:
+		return ghelpers.GetGErrBlk(excNames.IOException,
+			fmt.Sprintf("Server returned HTTP response code: %d for URL: %s", code, c.target.String()))
+	}
+	return c.bodyStream()
+}
+
+// java/net/HttpURLConnection.getErrorStream()Ljava/io/InputStream; -- the body of an error
+// response; null if the response is not an error or has not been received
+func httpURLConnectionGetErrorStream(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	c.mu.Lock()
+	resp := c.resp
+	c.mu.Unlock()
+	if resp == nil || resp.StatusCode < 400 {
+		return object.Null
+	}
+	return c.bodyStream()
+}
+
+// java/net/HttpURLConnection.getResponseCode()I
+func httpURLConnectionGetResponseCode(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	resp, gerr := c.response()
+	if gerr != nil {
+		return gerr
+	}
+	return int64(resp.StatusCode)
+}
+
+// java/net/HttpURLConnection.getResponseMessage()Ljava/lang/String; -- the reason phrase
+func httpURLConnectionGetResponseMessage(params []interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	resp, gerr := c.response()
+	if gerr != nil {
+		return gerr
+	}
+	msg := strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode))
+	return optStringObject(strings.TrimSpace(msg), strings.TrimSpace(msg) != "")
+}
+
+// headerFields returns the header fields of a response in a fixed order: the status line, with
+// no key, and then the fields sorted by key.
+func headerFields(resp *http.Response) (keys, values []string) {
+	keys = append(keys, "")
+	values = append(values, fmt.Sprintf("%s %s", resp.Proto, resp.Status))
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			keys = append(keys, name)
+			values = append(values, value)
+		}
+	}
+	return keys, values
+}
+
+// headerState runs get on the response of the connection in params[0]. As in the JDK, a
+// failure to get the response is not thrown: get is passed nil instead.
+func headerState(params []interface{}, get func(resp *http.Response) interface{}) interface{} {
+	c, gerr := getHttpURLConnection(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	resp, _ := c.response()
+	return get(resp)
+}
+
+// java/net/HttpURLConnection.getHeaderField(Ljava/lang/String;)Ljava/lang/String; and
+// getHeaderField(I)Ljava/lang/String; -- the last value of a field, or the nth field, where the
+// status line is the 0th
+func httpURLConnectionGetHeaderField(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		if resp == nil {
+			return object.Null
+		}
+		if n, ok := params[1].(int64); ok {
+			_, values := headerFields(resp)
+			if n < 0 || n >= int64(len(values)) {
+				return object.Null
+			}
+			return object.StringObjectFromGoString(values[n])
+		}
+		key, ok := optString(params[1])
+		if !ok {
+			_, values := headerFields(resp)
+			return object.StringObjectFromGoString(values[0])
+		}
+		values := resp.Header.Values(key)
+		if len(values) == 0 {
+			return object.Null
+		}
+		return object.StringObjectFromGoString(values[len(values)-1])
+	})
+}
+
+// java/net/HttpURLConnection.getHeaderFieldKey(I)Ljava/lang/String; -- null for the status line
+func httpURLConnectionGetHeaderFieldKey(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		n := params[1].(int64)
+		if resp == nil {
+			return object.Null
+		}
+		keys, _ := headerFields(resp)
+		if n <= 0 || n >= int64(len(keys)) {
+			return object.Null
+		}
+		return object.StringObjectFromGoString(keys[n])
+	})
+}
+
+// java/net/HttpURLConnection.getHeaderFieldInt(Ljava/lang/String;I)I and
+// getHeaderFieldLong(Ljava/lang/String;J)J -- the default if the field is missing or malformed
+func httpURLConnectionGetHeaderFieldLong(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		key, _ := optString(params[1])
+		if resp == nil || resp.Header.Get(key) == "" {
+			return params[2]
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(resp.Header.Get(key)), 10, 64)
+		if err != nil {
+			return params[2]
+		}
+		return value
+	})
+}
+
+// java/net/HttpURLConnection.getHeaderFields()Ljava/util/Map; -- a map from each key to its
+// values, in which the status line has the key null
+func httpURLConnectionGetHeaderFields(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		if resp == nil {
+			return newHeaderMap(nil, "")
+		}
+		_, values := headerFields(resp)
+		return newHeaderMap(resp.Header, values[0])
+	})
+}
+
+func httpURLConnectionGetContentType(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		if resp == nil || resp.Header.Get("Content-Type") == "" {
+			return object.Null
+		}
+		return object.StringObjectFromGoString(resp.Header.Get("Content-Type"))
+	})
+}
+
+func httpURLConnectionGetContentEncoding(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		if resp == nil || resp.Header.Get("Content-Encoding") == "" {
+			return object.Null
+		}
+		return object.StringObjectFromGoString(resp.Header.Get("Content-Encoding"))
+	})
+}
+
+// java/net/HttpURLConnection.getContentLengthLong()J -- -1 if the length is not known
+func httpURLConnectionGetContentLengthLong(params []interface{}) interface{} {
+	return headerState(params, func(resp *http.Response) interface{} {
+		if resp == nil {
+			return int64(-1)
+		}
+		return contentLength(resp)
+	})
+}
+
+// java/net/HttpURLConnection.getContentLength()I -- -1 also if the length is too large for an int
+func httpURLConnectionGetContentLength(params []interface{}) interface{} {
+	length := httpURLConnectionGetContentLengthLong(params)
+	if n, ok := length.(int64); ok && n > math.MaxInt32 {
+		return int64(-1)
+	}
+	return length
+}
+
+// contentLength returns the length of the body of resp as given by its Content-Length header,
+// or -1.
+func contentLength(resp *http.Response) int64 {
+	if resp.ContentLength >= 0 {
+		return resp.ContentLength
+	}
+	if n, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		return n
+	}
+	return -1
+}
+
+// java/net/HttpURLConnection.toString()Ljava/lang/String;
+func httpURLConnectionToString(params []interface{}) interface{} {
+	return httpURLConnectionState(params, func(c *httpURLConnection) interface{} {
+		return object.StringObjectFromGoString("java.net.HttpURLConnection:" + c.target.String())
+	})
+}
+
+// newHeaderMap returns a java.util.Map from each header field name to a List of its values,
+// sorted by name. A status line that is not "" has the key null.
+func newHeaderMap(header http.Header, statusLine string) interface{} {
+	className := "java/util/LinkedHashMap"
+	m := object.MakeEmptyObjectWithClassName(&className)
+	if ret := ghelpers.InvokeMethodOnObject(nil, m, "<init>", "()V"); ret != nil {
+		return ret
+	}
+	put := func(key any, values []string) *ghelpers.GErrBlk {
+		list := newStringList(values)
+		if gerr, ok := list.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		ret := ghelpers.InvokeMethodOnObject(nil, m, "put", "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;",
+			key, list)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		return nil
+	}
+	if statusLine != "" {
+		if gerr := put(object.Null, []string{statusLine}); gerr != nil {
+			return gerr
+		}
+	}
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if gerr := put(object.StringObjectFromGoString(name), header[name]); gerr != nil {
+			return gerr
+		}
+	}
+	return m
+}
+
+// newStringList returns a java.util.ArrayList of the strings.
+func newStringList(strs []string) interface{} {
+	className := "java/util/ArrayList"
+	list := object.MakeEmptyObjectWithClassName(&className)
+	if ret := ghelpers.InvokeMethodOnObject(nil, list, "<init>", "()V"); ret != nil {
+		return ret
+	}
+	for _, str := range strs {
+		ret := ghelpers.InvokeMethodOnObject(nil, list, "add", "(Ljava/lang/Object;)Z", object.StringObjectFromGoString(str))
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return list
+}
+
+// sun/net/www/http/PosterOutputStream.write(I)V, write([B)V and write([BII)V
+func posterOutputStreamWrite(params []interface{}) interface{} {
+	buf, ok := params[0].(*object.Object).FieldTable[netStateField].Fvalue.(*bytes.Buffer)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IOException, "stream is closed")
+	}
+	if b, ok := params[1].(int64); ok {
+		buf.WriteByte(byte(b))
+		return nil
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "PosterOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	buf.Write(object.GoByteArrayFromJavaByteArray(jbytes[off : off+length]))
+	return nil
+}
+
+// httpInputStream is the Go state of a stream over a response body. A read that takes longer
+// than timeout, if it is not 0, closes the body and throws a SocketTimeoutException.
+type httpInputStream struct {
+	mu      sync.Mutex
+	body    io.ReadCloser
+	timeout time.Duration
+	closed  bool
+}
+
+// newHttpInputStream returns a stream of the class given over body.
+func newHttpInputStream(className string, body io.ReadCloser, timeout time.Duration) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer,
+		Fvalue: &httpInputStream{body: body, timeout: timeout}}
+	return obj
+}
+
+func getHttpInputStream(obj any) (*httpInputStream, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "stream is null")
+	}
+	s, ok := o.FieldTable[netStateField].Fvalue.(*httpInputStream)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a response body stream")
+	}
+	return s, nil
+}
+
+// read reads into p; it returns -1 at the end of the body.
+func (s *httpInputStream) read(p []byte) (int, *ghelpers.GErrBlk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, ghelpers.GetGErrBlk(excNames.IOException, "stream is closed")
+	}
+	var timedOut atomic.Bool
+	if s.timeout > 0 {
+		timer := time.AfterFunc(s.timeout, func() {
+			timedOut.Store(true)
+			_ = s.body.Close()
+		})
+		defer timer.Stop()
+	}
+	for {
+		n, err := s.body.Read(p)
+		if timedOut.Load() {
+			s.closed = true
+			return 0, ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Read timed out")
+		}
+		if n > 0 || len(p) == 0 {
+			return n, nil
+		}
+		if err == io.EOF {
+			return -1, nil
+		}
+		if err != nil {
+			return 0, httpError(err)
+		}
+	}
+}
+
+// java/.../HttpInputStream.read()I, read([B)I and read([BII)I
+func httpInputStreamRead(params []interface{}) interface{} {
+	s, gerr := getHttpInputStream(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 1 {
+		var b [1]byte
+		n, gerr := s.read(b[:])
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			return int64(-1)
+		}
+		return int64(b[0])
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "InputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	buf := make([]byte, length)
+	n, gerr := s.read(buf)
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+	return int64(n)
+}
+
+// java/.../HttpInputStream.readAllBytes()[B
+func httpInputStreamReadAllBytes(params []interface{}) interface{} {
+	s, gerr := getHttpInputStream(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	var all []byte
+	buf := make([]byte, 8192)
+	for {
+		n, gerr := s.read(buf)
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			break
+		}
+		all = append(all, buf[:n]...)
+	}
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(all))
+}
+
+// java/.../HttpInputStream.skip(J)J
+func httpInputStreamSkip(params []interface{}) interface{} {
+	s, gerr := getHttpInputStream(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	remaining := params[1].(int64)
+	var skipped int64
+	buf := make([]byte, min(max(remaining, 0), 8192))
+	for remaining > 0 {
+		n, gerr := s.read(buf[:min(remaining, int64(len(buf)))])
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			break
+		}
+		skipped += int64(n)
+		remaining -= int64(n)
+	}
+	return skipped
+}
+
+// java/.../HttpInputStream.available()I -- 0, since Go does not tell what is buffered
+func httpInputStreamAvailable(params []interface{}) interface{} {
+	s, gerr := getHttpInputStream(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ghelpers.GetGErrBlk(excNames.IOException, "stream is closed")
+	}
+	return int64(0)
+}
+
+func httpInputStreamClose(params []interface{}) interface{} {
+	s, gerr := getHttpInputStream(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		_ = s.body.Close()
+	}
+	return nil
+}
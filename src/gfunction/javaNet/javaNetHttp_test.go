@@ -0,0 +1,373 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestHttpServer returns an in-process HTTP server with the handlers given, by path.
+func newTestHttpServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	for path, handler := range handlers {
+		mux.HandleFunc(path, handler)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func openTestConnection(t *testing.T, spec string) *object.Object {
+	t.Helper()
+	ret := urlOpenConnection([]interface{}{newTestURL(t, spec)})
+	conn, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("openConnection(%q): %v", spec, ret)
+	}
+	return conn
+}
+
+// readStream reads an InputStream of a response body to its end.
+func readStream(t *testing.T, in interface{}) string {
+	t.Helper()
+	if gerr, ok := in.(*ghelpers.GErrBlk); ok {
+		t.Fatalf("expected a stream, got %s: %s", excNames.JVMexceptionNames[gerr.ExceptionType], gerr.ErrMsg)
+	}
+	ret := httpInputStreamReadAllBytes([]interface{}{in})
+	arr, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("readAllBytes: %v", ret)
+	}
+	httpInputStreamClose([]interface{}{in})
+	return string(object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte)))
+}
+
+func TestHttpURLConnection_GetAndHeaders(t *testing.T) {
+	globals.InitStringPool()
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/hello": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Set("X-Answer", "42")
+			_, _ = fmt.Fprintf(w, "hello, %s %s", r.Method, r.Header.Get("X-Greeting"))
+		},
+	})
+
+	conn := openTestConnection(t, server.URL+"/hello")
+	httpURLConnectionSetRequestProperty([]interface{}{conn, object.StringObjectFromGoString("X-Greeting"), object.StringObjectFromGoString("hi")})
+	if got := netTestGoString(t, httpURLConnectionGetRequestProperty([]interface{}{conn, object.StringObjectFromGoString("x-greeting")})); got != "hi" {
+		t.Errorf("getRequestProperty: got %q", got)
+	}
+
+	if code := httpURLConnectionGetResponseCode([]interface{}{conn}); code != int64(200) {
+		t.Fatalf("getResponseCode: expected 200, got %v", code)
+	}
+	if got := netTestGoString(t, httpURLConnectionGetResponseMessage([]interface{}{conn})); got != "OK" {
+		t.Errorf("getResponseMessage: got %q", got)
+	}
+	if got := netTestGoString(t, httpURLConnectionGetHeaderField([]interface{}{conn, object.StringObjectFromGoString("x-answer")})); got != "42" {
+		t.Errorf("getHeaderField: got %q", got)
+	}
+	if got := httpURLConnectionGetHeaderFieldLong([]interface{}{conn, object.StringObjectFromGoString("X-Answer"), int64(-1)}); got != int64(42) {
+		t.Errorf("getHeaderFieldInt: got %v", got)
+	}
+	if got := netTestGoString(t, httpURLConnectionGetHeaderField([]interface{}{conn, int64(0)})); got != "HTTP/1.1 200 OK" {
+		t.Errorf("getHeaderField(0): got %q", got)
+	}
+	if httpURLConnectionGetHeaderFieldKey([]interface{}{conn, int64(0)}) != object.Null {
+		t.Error("getHeaderFieldKey(0): expected null for the status line")
+	}
+	if got := netTestGoString(t, httpURLConnectionGetContentType([]interface{}{conn})); got != "text/plain; charset=utf-8" {
+		t.Errorf("getContentType: got %q", got)
+	}
+	if got := readStream(t, httpURLConnectionGetInputStream([]interface{}{list.New(), conn})); got != "hello, GET hi" {
+		t.Errorf("body: got %q", got)
+	}
+
+	testutil.ExpectGErr(t, httpURLConnectionSetRequestMethod([]interface{}{conn, object.StringObjectFromGoString("POST")}),
+		excNames.ProtocolException, "Can't reset method: already connected")
+	testutil.ExpectGErr(t, httpURLConnectionSetRequestProperty([]interface{}{conn, object.StringObjectFromGoString("A"), object.StringObjectFromGoString("b")}),
+		excNames.IllegalStateException, "Already connected")
+}
+
+func TestHttpURLConnection_Post(t *testing.T) {
+	globals.InitStringPool()
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/echo": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("Content-Type"), body)
+		},
+	})
+
+	conn := openTestConnection(t, server.URL+"/echo")
+	testutil.ExpectGErr(t, httpURLConnectionGetOutputStream([]interface{}{conn}),
+		excNames.ProtocolException, "doOutput=false")
+	testutil.ExpectGErr(t, httpURLConnectionSetRequestMethod([]interface{}{conn, object.StringObjectFromGoString("FETCH")}),
+		excNames.ProtocolException, "Invalid HTTP method: FETCH")
+
+	httpURLConnectionSetDoOutput([]interface{}{conn, types.JavaBoolTrue})
+	out, ok := httpURLConnectionGetOutputStream([]interface{}{conn}).(*object.Object)
+	if !ok {
+		t.Fatal("getOutputStream: expected a stream")
+	}
+	posterOutputStreamWrite([]interface{}{out, object.MakeArrayFromRawArray([]byte("a=1"))})
+	posterOutputStreamWrite([]interface{}{out, int64('&')})
+	posterOutputStreamWrite([]interface{}{out, object.MakeArrayFromRawArray([]byte("xxb=2")), int64(2), int64(3)})
+
+	want := "POST application/x-www-form-urlencoded a=1&b=2"
+	if got := readStream(t, httpURLConnectionGetInputStream([]interface{}{list.New(), conn})); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	testutil.ExpectGErr(t, httpURLConnectionGetOutputStream([]interface{}{conn}),
+		excNames.ProtocolException, "Cannot write output after reading input.")
+}
+
+func TestHttpURLConnection_ErrorsAndRedirects(t *testing.T) {
+	globals.InitStringPool()
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/missing": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "no such page", http.StatusNotFound)
+		},
+		"/broken": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "oops", http.StatusInternalServerError)
+		},
+		"/moved": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/target", http.StatusFound)
+		},
+		"/target": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "arrived")
+		},
+	})
+
+	missing := openTestConnection(t, server.URL+"/missing")
+	testutil.ExpectGErr(t, httpURLConnectionGetInputStream([]interface{}{list.New(), missing}),
+		excNames.FileNotFoundException, server.URL+"/missing")
+	if got := readStream(t, httpURLConnectionGetErrorStream([]interface{}{missing})); got != "no such page\n" {
+		t.Errorf("getErrorStream: got %q", got)
+	}
+
+	broken := openTestConnection(t, server.URL+"/broken")
+	testutil.ExpectGErr(t, httpURLConnectionGetInputStream([]interface{}{list.New(), broken}),
+		excNames.IOException, "Server returned HTTP response code: 500 for URL: "+server.URL+"/broken")
+
+	moved := openTestConnection(t, server.URL+"/moved")
+	if got := readStream(t, httpURLConnectionGetInputStream([]interface{}{list.New(), moved})); got != "arrived" {
+		t.Errorf("followed redirect: got %q", got)
+	}
+
+	unfollowed := openTestConnection(t, server.URL+"/moved")
+	httpURLConnectionSetInstanceFollowRedirects([]interface{}{unfollowed, types.JavaBoolFalse})
+	if code := httpURLConnectionGetResponseCode([]interface{}{unfollowed}); code != int64(302) {
+		t.Errorf("expected 302 when redirects are not followed, got %v", code)
+	}
+	if got := netTestGoString(t, httpURLConnectionGetHeaderField([]interface{}{unfollowed, object.StringObjectFromGoString("Location")})); got != "/target" {
+		t.Errorf("Location: got %q", got)
+	}
+	if errStream := httpURLConnectionGetErrorStream([]interface{}{unfollowed}); errStream != object.Null {
+		t.Errorf("getErrorStream: expected null for a redirect, got %v", errStream)
+	}
+}
+
+func TestHttpURLConnection_ReadTimeout(t *testing.T) {
+	globals.InitStringPool()
+	release := make(chan struct{})
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/slow": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		},
+	})
+	defer close(release)
+
+	conn := openTestConnection(t, server.URL+"/slow")
+	testutil.ExpectGErr(t, httpURLConnectionSetReadTimeout([]interface{}{conn, int64(-1)}),
+		excNames.IllegalArgumentException, "timeouts can't be negative")
+	httpURLConnectionSetReadTimeout([]interface{}{conn, int64(100)})
+	testutil.ExpectGErr(t, httpURLConnectionGetResponseCode([]interface{}{conn}),
+		excNames.SocketTimeoutException, "Read timed out")
+}
+
+// newTestRequest builds an HttpRequest for the URI spec; configure, if not nil, is applied
+// to the builder first.
+func newTestRequest(t *testing.T, spec string, configure func(builder *object.Object)) *object.Object {
+	t.Helper()
+	builder := httpRequestNewBuilder([]interface{}{newTestURI(t, spec)}).(*object.Object)
+	if configure != nil {
+		configure(builder)
+	}
+	req, ok := httpRequestBuilderBuild([]interface{}{builder}).(*object.Object)
+	if !ok {
+		t.Fatal("build: expected a request")
+	}
+	return req
+}
+
+func send(t *testing.T, client, req, handler *object.Object) *object.Object {
+	t.Helper()
+	ret := httpClientSend([]interface{}{list.New(), client, req, handler})
+	resp, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("send: %v", ret)
+	}
+	return resp
+}
+
+func TestHttpClient_Send(t *testing.T) {
+	globals.InitStringPool()
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/echo": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Add("X-Multi", "a")
+			w.Header().Add("X-Multi", "b")
+			w.Header().Set("Content-Type", "text/plain; charset=ISO-8859-1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(append([]byte(r.Method+" "+r.Header.Get("X-Token")+" "), body...))
+			_, _ = w.Write([]byte{0xE9})
+		},
+	})
+
+	client := httpClientNewHttpClient(nil).(*object.Object)
+	req := newTestRequest(t, server.URL+"/echo", func(builder *object.Object) {
+		httpRequestBuilderHeader([]interface{}{builder, object.StringObjectFromGoString("X-Token"), object.StringObjectFromGoString("t1")})
+		httpRequestBuilderPOST([]interface{}{builder, bodyPublishersOfString([]interface{}{object.StringObjectFromGoString("payload")})})
+	})
+	resp := send(t, client, req, bodyHandlersOfString(nil).(*object.Object))
+
+	if code := httpResponseStatusCode([]interface{}{resp}); code != int64(201) {
+		t.Errorf("statusCode: expected 201, got %v", code)
+	}
+	if got := netTestGoString(t, httpResponseBody([]interface{}{resp})); got != "POST t1 payloadé" {
+		t.Errorf("body: got %q", got)
+	}
+	headers := httpResponseHeaders([]interface{}{resp}).(*object.Object)
+	first := httpHeadersFirstValue([]interface{}{headers, object.StringObjectFromGoString("x-multi")}).(*object.Object)
+	if got := netTestGoString(t, first.FieldTable["value"].Fvalue); got != "a" {
+		t.Errorf("firstValue: got %q", got)
+	}
+	absent := httpHeadersFirstValue([]interface{}{headers, object.StringObjectFromGoString("X-None")}).(*object.Object)
+	if _, present := absent.FieldTable["value"]; present {
+		t.Error("firstValue: expected an empty Optional for a missing header")
+	}
+	if got := netTestGoString(t, httpResponseToString([]interface{}{resp})); got != "(POST "+server.URL+"/echo) 201" {
+		t.Errorf("toString: got %q", got)
+	}
+
+	bytesResp := send(t, client, newTestRequest(t, server.URL+"/echo", nil), bodyHandlersOfByteArray(nil).(*object.Object))
+	arr := httpResponseBody([]interface{}{bytesResp}).(*object.Object)
+	if got := object.GoByteArrayFromJavaByteArray(arr.FieldTable["value"].Fvalue.([]types.JavaByte)); string(got) != "GET  \xe9" {
+		t.Errorf("ofByteArray: got %q", got)
+	}
+
+	streamResp := send(t, client, newTestRequest(t, server.URL+"/echo", nil), bodyHandlersOfInputStream(nil).(*object.Object))
+	if got := readStream(t, httpResponseBody([]interface{}{streamResp})); got != "GET  \xe9" {
+		t.Errorf("ofInputStream: got %q", got)
+	}
+}
+
+func TestHttpClient_RequestBuilderErrors(t *testing.T) {
+	globals.InitStringPool()
+	builder := httpRequestNewBuilder(nil).(*object.Object)
+	testutil.ExpectGErr(t, httpRequestBuilderBuild([]interface{}{builder}), excNames.IllegalStateException, "uri is null")
+	testutil.ExpectGErr(t, httpRequestBuilderURI([]interface{}{builder, newTestURI(t, "ftp://host/")}),
+		excNames.IllegalArgumentException, "invalid URI scheme ftp")
+	testutil.ExpectGErr(t, httpRequestBuilderHeader([]interface{}{builder, object.StringObjectFromGoString("Host"), object.StringObjectFromGoString("x")}),
+		excNames.IllegalArgumentException, `restricted header name: "Host"`)
+	testutil.ExpectGErr(t, httpRequestBuilderMethod([]interface{}{builder, object.StringObjectFromGoString(""), bodyPublishersNoBody(nil)}),
+		excNames.IllegalArgumentException, "illegal method <empty string>")
+}
+
+func TestHttpClient_RedirectsAndTimeout(t *testing.T) {
+	globals.InitStringPool()
+	release := make(chan struct{})
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/moved": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/target", http.StatusMovedPermanently)
+		},
+		"/target": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "arrived")
+		},
+		"/slow": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		},
+	})
+	defer close(release)
+	handler := bodyHandlersOfString(nil).(*object.Object)
+
+	never := httpClientNewHttpClient(nil).(*object.Object)
+	resp := send(t, never, newTestRequest(t, server.URL+"/moved", nil), handler)
+	if code := httpResponseStatusCode([]interface{}{resp}); code != int64(301) {
+		t.Errorf("Redirect.NEVER: expected 301, got %v", code)
+	}
+
+	builder := httpClientNewBuilder(nil).(*object.Object)
+	httpClientBuilderFollowRedirects([]interface{}{builder, httpEnum(httpRedirectClassName, "NORMAL")})
+	normal := httpClientBuilderBuild([]interface{}{builder}).(*object.Object)
+	resp = send(t, normal, newTestRequest(t, server.URL+"/moved", nil), handler)
+	if got := netTestGoString(t, httpResponseBody([]interface{}{resp})); got != "arrived" {
+		t.Errorf("Redirect.NORMAL: got %q", got)
+	}
+	if got := netTestGoString(t, uriToString([]interface{}{httpResponseURI([]interface{}{resp})})); got != server.URL+"/target" {
+		t.Errorf("uri after redirect: got %q", got)
+	}
+
+	className := "java/time/Duration"
+	timeout := object.MakeEmptyObjectWithClassName(&className)
+	timeout.FieldTable["seconds"] = object.Field{Ftype: types.Long, Fvalue: int64(0)}
+	timeout.FieldTable["nanos"] = object.Field{Ftype: types.Int, Fvalue: int64(100 * time.Millisecond)}
+	slow := newTestRequest(t, server.URL+"/slow", func(builder *object.Object) {
+		httpRequestBuilderTimeout([]interface{}{builder, timeout})
+	})
+	testutil.ExpectGErr(t, httpClientSend([]interface{}{list.New(), normal, slow, handler}),
+		excNames.HttpTimeoutException, "request timed out")
+}
+
+func TestHttpClient_SendAsync(t *testing.T) {
+	globals.InitStringPool()
+	javaUtil.Load_Util_Concurrent_CompletableFuture()
+	server := newTestHttpServer(t, map[string]http.HandlerFunc{
+		"/async": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "later")
+		},
+	})
+
+	client := httpClientNewHttpClient(nil).(*object.Object)
+	future := httpClientSendAsync([]interface{}{client, newTestRequest(t, server.URL+"/async", nil),
+		bodyHandlersOfString(nil)})
+	join := ghelpers.MethodSignatures["java/util/concurrent/CompletableFuture.join()Ljava/lang/Object;"].GFunction
+	resp, ok := join([]interface{}{list.New(), future}).(*object.Object)
+	if !ok {
+		t.Fatal("join: expected a response")
+	}
+	if got := netTestGoString(t, httpResponseBody([]interface{}{resp})); got != "later" {
+		t.Errorf("body: got %q", got)
+	}
+
+	closed := httpClientNewHttpClient(nil).(*object.Object)
+	httpClientClose([]interface{}{closed})
+	future = httpClientSendAsync([]interface{}{closed, newTestRequest(t, server.URL+"/async", nil),
+		bodyHandlersOfString(nil)})
+	testutil.ExpectGErr(t, join([]interface{}{list.New(), future}),
+		excNames.CompletionException, "java.io.IOException: HttpClient is closed")
+}
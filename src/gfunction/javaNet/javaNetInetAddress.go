@@ -0,0 +1,486 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf16"
+)
+
+// java.net.InetAddress and its subclasses Inet4Address and Inet6Address. An address is an
+// object of one of the subclasses that holds an inetAddress: the IP address and, if it is
+// known, the host name. Names are resolved with Go's resolver; IPv4 addresses come first, as
+// they do in the JDK when java.net.preferIPv6Addresses is not set.
+
+const (
+	inetAddressClassName  = "java/net/InetAddress"
+	inet4AddressClassName = "java/net/Inet4Address"
+	inet6AddressClassName = "java/net/Inet6Address"
+
+	// the field in which the objects of this package keep their Go state
+	netStateField = "netState"
+)
+
+func Load_Net_InetAddress() {
+	for _, className := range []string{inetAddressClassName, inet4AddressClassName, inet6AddressClassName} {
+		for sig, gmeth := range map[string]ghelpers.GMeth{
+			"<clinit>()V":                              {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+			"equals(Ljava/lang/Object;)Z":              {ParamSlots: 1, GFunction: inetAddressEquals},
+			"getAddress()[B":                           {ParamSlots: 0, GFunction: inetAddressGetAddress},
+			"getCanonicalHostName()Ljava/lang/String;": {ParamSlots: 0, GFunction: inetAddressGetCanonicalHostName},
+			"getHostAddress()Ljava/lang/String;":       {ParamSlots: 0, GFunction: inetAddressGetHostAddress},
+			"getHostName()Ljava/lang/String;":          {ParamSlots: 0, GFunction: inetAddressGetHostName},
+			"hashCode()I":                              {ParamSlots: 0, GFunction: inetAddressHashCode},
+			"isAnyLocalAddress()Z":                     {ParamSlots: 0, GFunction: inetAddressIsAnyLocal},
+			"isLinkLocalAddress()Z":                    {ParamSlots: 0, GFunction: inetAddressIsLinkLocal},
+			"isLoopbackAddress()Z":                     {ParamSlots: 0, GFunction: inetAddressIsLoopback},
+			"isMulticastAddress()Z":                    {ParamSlots: 0, GFunction: inetAddressIsMulticast},
+			"isReachable(I)Z":                          {ParamSlots: 1, GFunction: inetAddressIsReachable},
+			"isSiteLocalAddress()Z":                    {ParamSlots: 0, GFunction: inetAddressIsSiteLocal},
+			"toString()Ljava/lang/String;":             {ParamSlots: 0, GFunction: inetAddressToString},
+		} {
+			ghelpers.MethodSignatures[className+"."+sig] = gmeth
+		}
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"getAllByName(Ljava/lang/String;)[Ljava/net/InetAddress;": {ParamSlots: 1, GFunction: inetAddressGetAllByName},
+		"getByAddress([B)Ljava/net/InetAddress;":                  {ParamSlots: 1, GFunction: inetAddressGetByAddress},
+		"getByAddress(Ljava/lang/String;[B)Ljava/net/InetAddress;": {ParamSlots: 2,
+			GFunction: inetAddressGetByAddress},
+		"getByName(Ljava/lang/String;)Ljava/net/InetAddress;": {ParamSlots: 1, GFunction: inetAddressGetByName},
+		"getLocalHost()Ljava/net/InetAddress;":                {ParamSlots: 0, GFunction: inetAddressGetLocalHost},
+		"getLoopbackAddress()Ljava/net/InetAddress;":          {ParamSlots: 0, GFunction: inetAddressGetLoopbackAddress},
+	} {
+		ghelpers.MethodSignatures[inetAddressClassName+"."+sig] = gmeth
+	}
+}
+
+// inetAddress is the Go state of an InetAddress.
+type inetAddress struct {
+	ip      net.IP // 4 bytes for an Inet4Address, 16 for an Inet6Address
+	host    string
+	hasHost bool // host has been given or looked up
+}
+
+// newInetAddress returns an Inet4Address or Inet6Address for ip, with the host name host if it
+// is not empty. IPv4-mapped IPv6 addresses become Inet4Addresses, as in the JDK.
+func newInetAddress(ip net.IP, host string) *object.Object {
+	className := inet6AddressClassName
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		className = inet4AddressClassName
+	} else {
+		ip = ip.To16()
+	}
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	state := &inetAddress{ip: ip, host: host, hasHost: host != ""}
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: state}
+	return obj
+}
+
+// getInetAddress returns the Go state of the InetAddress obj.
+func getInetAddress(obj any) (*inetAddress, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "InetAddress is null")
+	}
+	ia, ok := o.FieldTable[netStateField].Fvalue.(*inetAddress)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not an InetAddress")
+	}
+	return ia, nil
+}
+
+// hostAddress returns the literal form of the address. IPv6 addresses are written in full,
+// without "::", as the JDK writes them.
+func (ia *inetAddress) hostAddress() string {
+	if len(ia.ip) == net.IPv4len {
+		return ia.ip.String()
+	}
+	groups := make([]string, 8)
+	for ix := range groups {
+		groups[ix] = strconv.FormatUint(uint64(ia.ip[2*ix])<<8|uint64(ia.ip[2*ix+1]), 16)
+	}
+	return strings.Join(groups, ":")
+}
+
+// hostName returns the host name, looking it up if it is not known. If the lookup fails, the
+// literal address is returned.
+func (ia *inetAddress) hostName() string {
+	if !ia.hasHost {
+		ia.host = reverseLookup(ia.ip)
+		ia.hasHost = true
+	}
+	return ia.host
+}
+
+func reverseLookup(ip net.IP) string {
+	names, err := net.LookupAddr(ip.String())
+	if err != nil || len(names) == 0 {
+		return (&inetAddress{ip: ip}).hostAddress()
+	}
+	return strings.TrimSuffix(names[0], ".")
+}
+
+func (ia *inetAddress) String() string {
+	host := ""
+	if ia.hasHost {
+		host = ia.host
+	}
+	return host + "/" + ia.hostAddress()
+}
+
+// isSiteLocal reports whether the address is private: 10/8, 172.16/12 or 192.168/16 for IPv4,
+// and fec0::/10 for IPv6.
+func (ia *inetAddress) isSiteLocal() bool {
+	if len(ia.ip) == net.IPv4len {
+		return ia.ip.IsPrivate()
+	}
+	return ia.ip[0] == 0xfe && ia.ip[1]&0xc0 == 0xc0
+}
+
+// hashCode is the hash code of the JDK: the address as an int for IPv4, and the sum of its four
+// ints for IPv6.
+func (ia *inetAddress) hashCode() int32 {
+	if len(ia.ip) == net.IPv4len {
+		return int32(uint32(ia.ip[0])<<24 | uint32(ia.ip[1])<<16 | uint32(ia.ip[2])<<8 | uint32(ia.ip[3]))
+	}
+	var hash int32
+	for ix := 0; ix < len(ia.ip); ix += 4 {
+		var component int32
+		for _, b := range ia.ip[ix : ix+4] {
+			component = component<<8 + int32(int8(b)) // the JDK adds signed bytes
+		}
+		hash += component
+	}
+	return hash
+}
+
+// parseIPv4Literal parses the dotted forms of an IPv4 address that the JDK accepts: a.b.c.d,
+// a.b.c (c is 16 bits), a.b (b is 24 bits) and a (32 bits), all in decimal.
+func parseIPv4Literal(s string) net.IP {
+	parts := strings.Split(s, ".")
+	if len(parts) > 4 {
+		return nil
+	}
+	values := make([]uint64, len(parts))
+	for ix, part := range parts {
+		if part == "" || len(part) > 10 {
+			return nil
+		}
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return nil
+			}
+		}
+		values[ix], _ = strconv.ParseUint(part, 10, 64)
+	}
+	last := len(values) - 1
+	for ix := 0; ix < last; ix++ {
+		if values[ix] > 0xff {
+			return nil
+		}
+	}
+	if values[last] >= 1<<(8*(4-last)) {
+		return nil
+	}
+	var addr uint32
+	for ix := 0; ix < last; ix++ {
+		addr |= uint32(values[ix]) << (24 - 8*ix)
+	}
+	addr |= uint32(values[last])
+	return net.IPv4(byte(addr>>24), byte(addr>>16), byte(addr>>8), byte(addr)).To4()
+}
+
+// parseIPLiteral parses host as an IPv4 or IPv6 literal. IPv6 literals may be in brackets and
+// may carry a zone, which is ignored. It returns nil if host is not a literal, and an error if it
+// is an IPv6 literal that is not valid.
+func parseIPLiteral(host string) (net.IP, *ghelpers.GErrBlk) {
+	invalid := ghelpers.GetGErrBlk(excNames.UnknownHostException, host+": invalid IPv6 address")
+	if strings.HasPrefix(host, "[") {
+		if !strings.HasSuffix(host, "]") || len(host) < 3 {
+			return nil, invalid
+		}
+		literal, _, _ := strings.Cut(host[1:len(host)-1], "%")
+		if ip := net.ParseIP(literal); ip != nil && strings.Contains(literal, ":") {
+			return ip, nil
+		}
+		return nil, invalid
+	}
+	if ip := parseIPv4Literal(host); ip != nil {
+		return ip, nil
+	}
+	if strings.Contains(host, ":") {
+		literal, _, _ := strings.Cut(host, "%")
+		if ip := net.ParseIP(literal); ip != nil {
+			return ip, nil
+		}
+		return nil, invalid
+	}
+	return nil, nil
+}
+
+// lookupHost returns the addresses of host, a name or a literal, IPv4 addresses first. A null
+// or empty host is the loopback address.
+func lookupHost(host string) ([]*object.Object, *ghelpers.GErrBlk) {
+	if host == "" {
+		return []*object.Object{loopbackAddress()}, nil
+	}
+	ip, gerr := parseIPLiteral(host)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if ip != nil {
+		return []*object.Object{newInetAddress(ip, "")}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return nil, ghelpers.GetGErrBlk(excNames.UnknownHostException, host+": Name or service not known")
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return addrs[i].IP.To4() != nil && addrs[j].IP.To4() == nil
+	})
+	result := make([]*object.Object, 0, len(addrs))
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if key := addr.IP.String(); !seen[key] {
+			seen[key] = true
+			result = append(result, newInetAddress(addr.IP, host))
+		}
+	}
+	return result, nil
+}
+
+func loopbackAddress() *object.Object {
+	return newInetAddress(net.IPv4(127, 0, 0, 1), "localhost")
+}
+
+// anyLocalAddress returns the wildcard address, 0.0.0.0.
+func anyLocalAddress() *object.Object {
+	return newInetAddress(net.IPv4zero, "0.0.0.0")
+}
+
+// hostArg returns the host name in arg, a String or null.
+func hostArg(arg any) string {
+	if obj, ok := arg.(*object.Object); ok && !object.IsNull(obj) {
+		return object.GoStringFromStringObject(obj)
+	}
+	return ""
+}
+
+// java/net/InetAddress.getByName(Ljava/lang/String;)Ljava/net/InetAddress;
+func inetAddressGetByName(params []interface{}) interface{} {
+	addrs, gerr := lookupHost(hostArg(params[0]))
+	if gerr != nil {
+		return gerr
+	}
+	return addrs[0]
+}
+
+// java/net/InetAddress.getAllByName(Ljava/lang/String;)[Ljava/net/InetAddress;
+func inetAddressGetAllByName(params []interface{}) interface{} {
+	addrs, gerr := lookupHost(hostArg(params[0]))
+	if gerr != nil {
+		return gerr
+	}
+	return object.MakePrimitiveObject("[L"+inetAddressClassName+";", types.RefArray, addrs)
+}
+
+// java/net/InetAddress.getByAddress([B)Ljava/net/InetAddress; and
+// getByAddress(Ljava/lang/String;[B)Ljava/net/InetAddress; -- no lookup is done
+func inetAddressGetByAddress(params []interface{}) interface{} {
+	host := ""
+	arrArg := params[0]
+	if len(params) > 1 {
+		host, arrArg = hostArg(params[0]), params[1]
+	}
+	arr, ok := arrArg.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.UnknownHostException, "addr is of illegal length")
+	}
+	jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	if len(jbytes) != net.IPv4len && len(jbytes) != net.IPv6len {
+		return ghelpers.GetGErrBlk(excNames.UnknownHostException, "addr is of illegal length")
+	}
+	ip := net.IP(object.GoByteArrayFromJavaByteArray(jbytes))
+	return newInetAddress(ip, host)
+}
+
+// java/net/InetAddress.getLoopbackAddress()Ljava/net/InetAddress; -- localhost/127.0.0.1
+func inetAddressGetLoopbackAddress([]interface{}) interface{} {
+	return loopbackAddress()
+}
+
+// java/net/InetAddress.getLocalHost()Ljava/net/InetAddress; -- if the host name does not
+// resolve, the loopback address is returned with the host name.
+func inetAddressGetLocalHost([]interface{}) interface{} {
+	name, err := os.Hostname()
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UnknownHostException, err.Error())
+	}
+	addrs, gerr := lookupHost(name)
+	if gerr != nil {
+		return newInetAddress(net.IPv4(127, 0, 0, 1), name)
+	}
+	return addrs[0]
+}
+
+func inetAddressGetHostAddress(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(ia.hostAddress())
+}
+
+func inetAddressGetHostName(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(ia.hostName())
+}
+
+func inetAddressGetCanonicalHostName(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(reverseLookup(ia.ip))
+}
+
+func inetAddressGetAddress(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(ia.ip))
+}
+
+func inetAddressIsAnyLocal(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ia.ip.IsUnspecified())
+}
+
+func inetAddressIsLoopback(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ia.ip.IsLoopback())
+}
+
+func inetAddressIsMulticast(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ia.ip.IsMulticast())
+}
+
+func inetAddressIsLinkLocal(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ia.ip.IsLinkLocalUnicast())
+}
+
+func inetAddressIsSiteLocal(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ia.isSiteLocal())
+}
+
+// java/net/InetAddress.isReachable(I)Z -- without ICMP, the JDK tries a TCP connection to the
+// echo port; a refused connection also means that the host is reachable.
+func inetAddressIsReachable(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	timeout := params[1].(int64)
+	if timeout < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "timeout can't be negative")
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ia.ip.String(), "7"), time.Duration(timeout)*time.Millisecond)
+	if err == nil {
+		_ = conn.Close()
+		return types.JavaBoolTrue
+	}
+	return types.ConvertGoBoolToJavaBool(errors.Is(err, syscall.ECONNREFUSED))
+}
+
+func inetAddressToString(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(ia.String())
+}
+
+// java/net/InetAddress.equals(Ljava/lang/Object;)Z -- addresses are equal if their IP addresses
+// are; the host names do not matter.
+func inetAddressEquals(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return types.JavaBoolFalse
+	}
+	oa, ok := other.FieldTable[netStateField].Fvalue.(*inetAddress)
+	if !ok {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(len(ia.ip) == len(oa.ip) && ia.ip.Equal(oa.ip))
+}
+
+func inetAddressHashCode(params []interface{}) interface{} {
+	ia, gerr := getInetAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(ia.hashCode())
+}
+
+// javaStringHashCode returns String.hashCode() of str.
+func javaStringHashCode(str string) int32 {
+	var hash int32
+	for _, c := range utf16.Encode([]rune(str)) {
+		hash = 31*hash + int32(c)
+	}
+	return hash
+}
+
+// portOutOfRange returns the exception for a port that is not in 0..65535.
+func portOutOfRange(port int64) *ghelpers.GErrBlk {
+	return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("port out of range:%d", port))
+}
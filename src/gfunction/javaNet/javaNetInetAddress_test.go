@@ -0,0 +1,159 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+func netTestGoString(t *testing.T, ret interface{}) string {
+	t.Helper()
+	obj, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("expected a String, got %T (%v)", ret, ret)
+	}
+	return object.GoStringFromStringObject(obj)
+}
+
+func getByName(t *testing.T, host string) *object.Object {
+	t.Helper()
+	ret := inetAddressGetByName([]interface{}{object.StringObjectFromGoString(host)})
+	addr, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("getByName(%q): %v", host, ret)
+	}
+	return addr
+}
+
+func TestInetAddress_Literals(t *testing.T) {
+	globals.InitStringPool()
+	tests := []struct {
+		host, class, literal string
+	}{
+		{"127.0.0.1", inet4AddressClassName, "127.0.0.1"},
+		{"10.1", inet4AddressClassName, "10.0.0.1"},
+		{"192.168.257", inet4AddressClassName, "192.168.1.1"},
+		{"16909060", inet4AddressClassName, "1.2.3.4"},
+		{"::1", inet6AddressClassName, "0:0:0:0:0:0:0:1"},
+		{"[fe80::1%eth0]", inet6AddressClassName, "fe80:0:0:0:0:0:0:1"},
+		{"::ffff:10.0.0.1", inet4AddressClassName, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		addr := getByName(t, tt.host)
+		if className := object.GoStringFromStringPoolIndex(addr.KlassName); className != tt.class {
+			t.Errorf("%s: class %s, expected %s", tt.host, className, tt.class)
+		}
+		if got := netTestGoString(t, inetAddressGetHostAddress([]interface{}{addr})); got != tt.literal {
+			t.Errorf("%s: getHostAddress %q, expected %q", tt.host, got, tt.literal)
+		}
+		if got := netTestGoString(t, inetAddressToString([]interface{}{addr})); got != "/"+tt.literal {
+			t.Errorf("%s: toString %q", tt.host, got)
+		}
+	}
+
+	testutil.ExpectGErr(t, inetAddressGetByName([]interface{}{object.StringObjectFromGoString("[::1")}),
+		excNames.UnknownHostException, "invalid IPv6 address")
+	testutil.ExpectGErr(t, inetAddressGetByName([]interface{}{object.StringObjectFromGoString("1::2::3")}),
+		excNames.UnknownHostException, "invalid IPv6 address")
+}
+
+func TestInetAddress_Loopback(t *testing.T) {
+	globals.InitStringPool()
+	for _, addr := range []*object.Object{
+		inetAddressGetLoopbackAddress(nil).(*object.Object),
+		inetAddressGetByName([]interface{}{object.Null}).(*object.Object),
+		getByName(t, "localhost"),
+	} {
+		if inetAddressIsLoopback([]interface{}{addr}) != types.JavaBoolTrue {
+			t.Errorf("%s is not a loopback address", netTestGoString(t, inetAddressToString([]interface{}{addr})))
+		}
+		if got := netTestGoString(t, inetAddressGetHostName([]interface{}{addr})); got != "localhost" {
+			t.Errorf("getHostName: %q", got)
+		}
+	}
+	if got := netTestGoString(t, inetAddressToString([]interface{}{loopbackAddress()})); got != "localhost/127.0.0.1" {
+		t.Errorf("toString of the loopback address: %q", got)
+	}
+}
+
+func TestInetAddress_GetByAddressAndPredicates(t *testing.T) {
+	globals.InitStringPool()
+	raw := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray([]byte{192, 168, 0, 7}))
+	addr := inetAddressGetByAddress([]interface{}{object.StringObjectFromGoString("printer"), raw}).(*object.Object)
+	if got := netTestGoString(t, inetAddressToString([]interface{}{addr})); got != "printer/192.168.0.7" {
+		t.Errorf("toString: %q", got)
+	}
+	if inetAddressIsSiteLocal([]interface{}{addr}) != types.JavaBoolTrue {
+		t.Error("192.168.0.7 should be site local")
+	}
+	if inetAddressIsMulticast([]interface{}{getByName(t, "224.0.0.251")}) != types.JavaBoolTrue {
+		t.Error("224.0.0.251 should be multicast")
+	}
+	if inetAddressIsAnyLocal([]interface{}{getByName(t, "0.0.0.0")}) != types.JavaBoolTrue {
+		t.Error("0.0.0.0 should be the wildcard address")
+	}
+	if inetAddressIsLinkLocal([]interface{}{getByName(t, "fe80::1")}) != types.JavaBoolTrue {
+		t.Error("fe80::1 should be link local")
+	}
+
+	other := getByName(t, "192.168.0.7")
+	if inetAddressEquals([]interface{}{addr, other}) != types.JavaBoolTrue {
+		t.Error("addresses with the same IP address should be equal")
+	}
+	if hash := inetAddressHashCode([]interface{}{addr}).(int64); hash != int64(int32(-1062731769)) {
+		t.Errorf("hashCode: %d", hash)
+	}
+	if hash := inetAddressHashCode([]interface{}{getByName(t, "::1")}).(int64); hash != 1 {
+		t.Errorf("hashCode of ::1: %d", hash)
+	}
+
+	bad := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, make([]types.JavaByte, 5))
+	testutil.ExpectGErr(t, inetAddressGetByAddress([]interface{}{bad}), excNames.UnknownHostException, "illegal length")
+}
+
+func TestInetSocketAddress(t *testing.T) {
+	globals.InitStringPool()
+	className := inetSocketAddressClassName
+	isa := object.MakeEmptyObjectWithClassName(&className)
+	if ret := inetSocketAddressInitAddress([]interface{}{isa, loopbackAddress(), int64(8080)}); ret != nil {
+		t.Fatalf("<init>: %v", ret)
+	}
+	if got := netTestGoString(t, inetSocketAddressToString([]interface{}{isa})); got != "localhost/127.0.0.1:8080" {
+		t.Errorf("toString: %q", got)
+	}
+	if got := netTestGoString(t, inetSocketAddressGetHostString([]interface{}{isa})); got != "localhost" {
+		t.Errorf("getHostString: %q", got)
+	}
+
+	v6 := newInetSocketAddress(getByName(t, "::1"), 80)
+	if got := netTestGoString(t, inetSocketAddressToString([]interface{}{v6})); got != "/[0:0:0:0:0:0:0:1]:80" {
+		t.Errorf("toString of an IPv6 address: %q", got)
+	}
+
+	unresolved := inetSocketAddressCreateUnresolved([]interface{}{object.StringObjectFromGoString("example.invalid"), int64(443)})
+	if inetSocketAddressIsUnresolved([]interface{}{unresolved}) != types.JavaBoolTrue {
+		t.Error("createUnresolved should be unresolved")
+	}
+	if got := netTestGoString(t, inetSocketAddressToString([]interface{}{unresolved})); got != "example.invalid/<unresolved>:443" {
+		t.Errorf("toString of an unresolved address: %q", got)
+	}
+	other := inetSocketAddressCreateUnresolved([]interface{}{object.StringObjectFromGoString("EXAMPLE.invalid"), int64(443)})
+	if inetSocketAddressEquals([]interface{}{unresolved, other}) != types.JavaBoolTrue {
+		t.Error("unresolved addresses compare host names ignoring case")
+	}
+
+	testutil.ExpectGErr(t, inetSocketAddressInitPort([]interface{}{isa, int64(70000)}),
+		excNames.IllegalArgumentException, "port out of range:70000")
+	testutil.ExpectGErr(t, inetSocketAddressInitHost([]interface{}{isa, object.Null, int64(1)}),
+		excNames.IllegalArgumentException, "hostname can't be null")
+}
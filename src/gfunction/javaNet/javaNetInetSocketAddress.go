@@ -0,0 +1,276 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"strings"
+)
+
+// java.net.InetSocketAddress: an IP address, or a host name that has not been resolved, and a
+// port. Sockets take and return their addresses in this form.
+
+const inetSocketAddressClassName = "java/net/InetSocketAddress"
+
+func Load_Net_InetSocketAddress() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                        {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>(I)V":                         {ParamSlots: 1, GFunction: inetSocketAddressInitPort},
+		"<init>(Ljava/net/InetAddress;I)V":   {ParamSlots: 2, GFunction: inetSocketAddressInitAddress},
+		"<init>(Ljava/lang/String;I)V":       {ParamSlots: 2, GFunction: inetSocketAddressInitHost},
+		"equals(Ljava/lang/Object;)Z":        {ParamSlots: 1, GFunction: inetSocketAddressEquals},
+		"getAddress()Ljava/net/InetAddress;": {ParamSlots: 0, GFunction: inetSocketAddressGetAddress},
+		"getHostName()Ljava/lang/String;":    {ParamSlots: 0, GFunction: inetSocketAddressGetHostName},
+		"getHostString()Ljava/lang/String;":  {ParamSlots: 0, GFunction: inetSocketAddressGetHostString},
+		"getPort()I":                         {ParamSlots: 0, GFunction: inetSocketAddressGetPort},
+		"hashCode()I":                        {ParamSlots: 0, GFunction: inetSocketAddressHashCode},
+		"isUnresolved()Z":                    {ParamSlots: 0, GFunction: inetSocketAddressIsUnresolved},
+		"toString()Ljava/lang/String;":       {ParamSlots: 0, GFunction: inetSocketAddressToString},
+		"createUnresolved(Ljava/lang/String;I)Ljava/net/InetSocketAddress;": {ParamSlots: 2,
+			GFunction: inetSocketAddressCreateUnresolved},
+	} {
+		ghelpers.MethodSignatures[inetSocketAddressClassName+"."+sig] = gmeth
+	}
+}
+
+// inetSocketAddress is the Go state of an InetSocketAddress. addr is nil if the address is
+// unresolved, in which case host holds the name.
+type inetSocketAddress struct {
+	addr *object.Object
+	host string
+	port int
+}
+
+// newInetSocketAddress returns an InetSocketAddress for the InetAddress addr and port.
+func newInetSocketAddress(addr *object.Object, port int) *object.Object {
+	className := inetSocketAddressClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	setInetSocketAddress(obj, &inetSocketAddress{addr: addr, port: port})
+	return obj
+}
+
+// inetSocketAddressFor returns an InetSocketAddress for a Go address, as returned by a socket.
+func inetSocketAddressFor(addr net.Addr) *object.Object {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return newInetSocketAddress(newInetAddress(a.IP, ""), a.Port)
+	case *net.UDPAddr:
+		return newInetSocketAddress(newInetAddress(a.IP, ""), a.Port)
+	}
+	return nil
+}
+
+func setInetSocketAddress(obj *object.Object, isa *inetSocketAddress) {
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: isa}
+}
+
+// getInetSocketAddress returns the Go state of the InetSocketAddress obj.
+func getInetSocketAddress(obj any) (*inetSocketAddress, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "socket address is null")
+	}
+	isa, ok := o.FieldTable[netStateField].Fvalue.(*inetSocketAddress)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(o.KlassName)
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Unsupported address type: "+className)
+	}
+	return isa, nil
+}
+
+// hostString returns the host name if there is one, and otherwise the literal address.
+func (isa *inetSocketAddress) hostString() string {
+	if isa.addr == nil {
+		return isa.host
+	}
+	ia := isa.addr.FieldTable[netStateField].Fvalue.(*inetAddress)
+	if ia.hasHost {
+		return ia.host
+	}
+	return ia.hostAddress()
+}
+
+// ip returns the IP address of a resolved address.
+func (isa *inetSocketAddress) ip() net.IP {
+	return isa.addr.FieldTable[netStateField].Fvalue.(*inetAddress).ip
+}
+
+func (isa *inetSocketAddress) String() string {
+	var host string
+	if isa.addr == nil {
+		host = isa.host + "/<unresolved>"
+	} else {
+		host = isa.addr.FieldTable[netStateField].Fvalue.(*inetAddress).String()
+		if len(isa.ip()) == net.IPv6len {
+			// the literal part of an IPv6 address goes in brackets
+			name, literal, _ := strings.Cut(host, "/")
+			host = name + "/[" + literal + "]"
+		}
+	}
+	return fmt.Sprintf("%s:%d", host, isa.port)
+}
+
+// checkPort returns an exception if port is not a valid port.
+func checkPort(port int64) *ghelpers.GErrBlk {
+	if port < 0 || port > 0xffff {
+		return portOutOfRange(port)
+	}
+	return nil
+}
+
+// java/net/InetSocketAddress.<init>(I)V -- the wildcard address
+func inetSocketAddressInitPort(params []interface{}) interface{} {
+	port := params[1].(int64)
+	if gerr := checkPort(port); gerr != nil {
+		return gerr
+	}
+	setInetSocketAddress(params[0].(*object.Object), &inetSocketAddress{addr: anyLocalAddress(), port: int(port)})
+	return nil
+}
+
+// java/net/InetSocketAddress.<init>(Ljava/net/InetAddress;I)V -- a null address is the wildcard
+func inetSocketAddressInitAddress(params []interface{}) interface{} {
+	port := params[2].(int64)
+	if gerr := checkPort(port); gerr != nil {
+		return gerr
+	}
+	addr, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(addr) {
+		addr = anyLocalAddress()
+	}
+	setInetSocketAddress(params[0].(*object.Object), &inetSocketAddress{addr: addr, port: int(port)})
+	return nil
+}
+
+// java/net/InetSocketAddress.<init>(Ljava/lang/String;I)V -- the host name is resolved; if it
+// does not resolve, the address is unresolved.
+func inetSocketAddressInitHost(params []interface{}) interface{} {
+	port := params[2].(int64)
+	if gerr := checkPort(port); gerr != nil {
+		return gerr
+	}
+	hostObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(hostObj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "hostname can't be null")
+	}
+	host := object.GoStringFromStringObject(hostObj)
+	isa := &inetSocketAddress{host: host, port: int(port)}
+	if addrs, gerr := lookupHost(host); gerr == nil {
+		isa.addr = addrs[0]
+	}
+	setInetSocketAddress(params[0].(*object.Object), isa)
+	return nil
+}
+
+// java/net/InetSocketAddress.createUnresolved(Ljava/lang/String;I)Ljava/net/InetSocketAddress;
+func inetSocketAddressCreateUnresolved(params []interface{}) interface{} {
+	port := params[1].(int64)
+	if gerr := checkPort(port); gerr != nil {
+		return gerr
+	}
+	hostObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(hostObj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "hostname can't be null")
+	}
+	obj := newInetSocketAddress(nil, int(port))
+	obj.FieldTable[netStateField].Fvalue.(*inetSocketAddress).host = object.GoStringFromStringObject(hostObj)
+	return obj
+}
+
+func inetSocketAddressGetPort(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(isa.port)
+}
+
+func inetSocketAddressGetAddress(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if isa.addr == nil {
+		return object.Null
+	}
+	return isa.addr
+}
+
+// java/net/InetSocketAddress.getHostName()Ljava/lang/String; -- may do a reverse lookup
+func inetSocketAddressGetHostName(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if isa.addr == nil {
+		return object.StringObjectFromGoString(isa.host)
+	}
+	return object.StringObjectFromGoString(isa.addr.FieldTable[netStateField].Fvalue.(*inetAddress).hostName())
+}
+
+// java/net/InetSocketAddress.getHostString()Ljava/lang/String; -- never does a lookup
+func inetSocketAddressGetHostString(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(isa.hostString())
+}
+
+func inetSocketAddressIsUnresolved(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(isa.addr == nil)
+}
+
+func inetSocketAddressToString(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(isa.String())
+}
+
+// java/net/InetSocketAddress.equals(Ljava/lang/Object;)Z -- resolved addresses are compared by
+// their IP addresses, unresolved ones by their host names, ignoring case
+func inetSocketAddressEquals(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return types.JavaBoolFalse
+	}
+	oa, ok := other.FieldTable[netStateField].Fvalue.(*inetSocketAddress)
+	if !ok || isa.port != oa.port || (isa.addr == nil) != (oa.addr == nil) {
+		return types.JavaBoolFalse
+	}
+	if isa.addr == nil {
+		return types.ConvertGoBoolToJavaBool(strings.EqualFold(isa.host, oa.host))
+	}
+	return types.ConvertGoBoolToJavaBool(isa.ip().Equal(oa.ip()) && len(isa.ip()) == len(oa.ip()))
+}
+
+func inetSocketAddressHashCode(params []interface{}) interface{} {
+	isa, gerr := getInetSocketAddress(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if isa.addr == nil {
+		return int64(javaStringHashCode(strings.ToLower(isa.host)) + int32(isa.port))
+	}
+	ia := isa.addr.FieldTable[netStateField].Fvalue.(*inetAddress)
+	return int64(ia.hashCode() + int32(isa.port))
+}
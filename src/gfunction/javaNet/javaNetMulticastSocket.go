@@ -0,0 +1,180 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+)
+
+// java.net.MulticastSocket, a DatagramSocket that can join multicast groups. Groups are joined on
+// the default interface: NetworkInterface is not yet implemented, so the methods that take one
+// accept only null.
+
+const multicastSocketClassName = "java/net/MulticastSocket"
+
+func Load_Net_MulticastSocket() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                        {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                          {ParamSlots: 0, GFunction: multicastSocketInit},
+		"<init>(I)V":                         {ParamSlots: 1, GFunction: multicastSocketInit},
+		"<init>(Ljava/net/SocketAddress;)V":  {ParamSlots: 1, GFunction: multicastSocketInit},
+		"getLoopbackMode()Z":                 {ParamSlots: 0, GFunction: multicastSocketGetLoopbackMode},
+		"getTimeToLive()I":                   {ParamSlots: 0, GFunction: multicastSocketGetTimeToLive},
+		"joinGroup(Ljava/net/InetAddress;)V": {ParamSlots: 1, GFunction: multicastSocketJoinGroup},
+		"joinGroup(Ljava/net/SocketAddress;Ljava/net/NetworkInterface;)V": {ParamSlots: 2,
+			GFunction: multicastSocketJoinGroup},
+		"leaveGroup(Ljava/net/InetAddress;)V": {ParamSlots: 1, GFunction: multicastSocketLeaveGroup},
+		"leaveGroup(Ljava/net/SocketAddress;Ljava/net/NetworkInterface;)V": {ParamSlots: 2,
+			GFunction: multicastSocketLeaveGroup},
+		"setLoopbackMode(Z)V": {ParamSlots: 1, GFunction: multicastSocketSetLoopbackMode},
+		"setTimeToLive(I)V":   {ParamSlots: 1, GFunction: multicastSocketSetTimeToLive},
+	} {
+		ghelpers.MethodSignatures[multicastSocketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range datagramSocketMethods {
+		ghelpers.MethodSignatures[multicastSocketClassName+"."+sig] = gmeth
+	}
+}
+
+// java/net/MulticastSocket.<init>()V, <init>(I)V and <init>(Ljava/net/SocketAddress;)V -- as
+// for DatagramSocket, but with SO_REUSEADDR set before the socket is bound
+func multicastSocketInit(params []interface{}) interface{} {
+	return initDatagramSocket(params, newDatagramSocket(true))
+}
+
+// java/net/MulticastSocket.joinGroup(Ljava/net/InetAddress;)V and
+// joinGroup(Ljava/net/SocketAddress;Ljava/net/NetworkInterface;)V
+func multicastSocketJoinGroup(params []interface{}) interface{} {
+	return changeMembership(params, true)
+}
+
+// java/net/MulticastSocket.leaveGroup(Ljava/net/InetAddress;)V and
+// leaveGroup(Ljava/net/SocketAddress;Ljava/net/NetworkInterface;)V
+func multicastSocketLeaveGroup(params []interface{}) interface{} {
+	return changeMembership(params, false)
+}
+
+func changeMembership(params []interface{}, join bool) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	group, gerr := groupArg(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) > 2 {
+		if ni, ok := params[2].(*object.Object); ok && !object.IsNull(ni) {
+			return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+				"MulticastSocket: joining a group on a given NetworkInterface is not yet supported")
+		}
+	}
+	if !group.IsMulticast() {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Not a multicast address")
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	if err := setMembership(conn, group, join); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// groupArg returns the IP address of a group, given as an InetAddress or an InetSocketAddress.
+func groupArg(arg any) (net.IP, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Unsupported address type")
+	}
+	if ia, ok := obj.FieldTable[netStateField].Fvalue.(*inetAddress); ok {
+		return ia.ip, nil
+	}
+	isa, gerr := getInetSocketAddress(obj)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if isa.addr == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Unresolved address")
+	}
+	return isa.ip(), nil
+}
+
+// java/net/MulticastSocket.getTimeToLive()I -- IP_MULTICAST_TTL, 1 by default
+func multicastSocketGetTimeToLive(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	ttl, err := multicastTTL(conn)
+	if err != nil {
+		return socketError(err)
+	}
+	return int64(ttl)
+}
+
+func multicastSocketSetTimeToLive(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ttl := params[1].(int64)
+	if ttl < 0 || ttl > 255 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "ttl out of range")
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	if err := setMulticastTTL(conn, int(ttl)); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// java/net/MulticastSocket.getLoopbackMode()Z -- true if loopback is disabled, as in the JDK
+func multicastSocketGetLoopbackMode(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	loop, err := multicastLoop(conn)
+	if err != nil {
+		return socketError(err)
+	}
+	return types.ConvertGoBoolToJavaBool(!loop)
+}
+
+// java/net/MulticastSocket.setLoopbackMode(Z)V -- true disables IP_MULTICAST_LOOP
+func multicastSocketSetLoopbackMode(params []interface{}) interface{} {
+	ds, gerr := getDatagramSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	disable := params[1].(int64) == types.JavaBoolTrue
+	conn, gerr := ds.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	if err := setMulticastLoop(conn, !disable); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"errors"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// java.net.ServerSocket, over a Go *net.TCPListener. The backlog is only a hint in the JDK, and
+// it is ignored here: Go always listens with the system's maximum backlog.
+
+const serverSocketClassName = "java/net/ServerSocket"
+
+// serverSocketMethods are the methods of ServerSocket, which SSLServerSocket inherits.
+var serverSocketMethods = map[string]ghelpers.GMeth{
+	"accept()Ljava/net/Socket;":                       {ParamSlots: 0, GFunction: serverSocketAccept, NeedsContext: true},
+	"bind(Ljava/net/SocketAddress;)V":                 {ParamSlots: 1, GFunction: serverSocketBind},
+	"bind(Ljava/net/SocketAddress;I)V":                {ParamSlots: 2, GFunction: serverSocketBind},
+	"close()V":                                        {ParamSlots: 0, GFunction: serverSocketClose},
+	"getInetAddress()Ljava/net/InetAddress;":          {ParamSlots: 0, GFunction: serverSocketGetInetAddress},
+	"getLocalPort()I":                                 {ParamSlots: 0, GFunction: serverSocketGetLocalPort},
+	"getLocalSocketAddress()Ljava/net/SocketAddress;": {ParamSlots: 0, GFunction: serverSocketGetLocalSocketAddress},
+	"getReceiveBufferSize()I":                         {ParamSlots: 0, GFunction: serverSocketGetReceiveBufferSize},
+	"getReuseAddress()Z":                              {ParamSlots: 0, GFunction: serverSocketGetReuseAddress},
+	"getSoTimeout()I":                                 {ParamSlots: 0, GFunction: serverSocketGetSoTimeout},
+	"isBound()Z":                                      {ParamSlots: 0, GFunction: serverSocketIsBound},
+	"isClosed()Z":                                     {ParamSlots: 0, GFunction: serverSocketIsClosed},
+	"setReceiveBufferSize(I)V":                        {ParamSlots: 1, GFunction: serverSocketSetReceiveBufferSize},
+	"setReuseAddress(Z)V":                             {ParamSlots: 1, GFunction: serverSocketSetReuseAddress},
+	"setSoTimeout(I)V":                                {ParamSlots: 1, GFunction: serverSocketSetSoTimeout},
+	"toString()Ljava/lang/String;":                    {ParamSlots: 0, GFunction: serverSocketToString},
+}
+
+func Load_Net_ServerSocket() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                       {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                         {ParamSlots: 0, GFunction: serverSocketInit},
+		"<init>(I)V":                        {ParamSlots: 1, GFunction: serverSocketInit},
+		"<init>(II)V":                       {ParamSlots: 2, GFunction: serverSocketInit},
+		"<init>(IILjava/net/InetAddress;)V": {ParamSlots: 3, GFunction: serverSocketInit},
+	} {
+		ghelpers.MethodSignatures[serverSocketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range serverSocketMethods {
+		ghelpers.MethodSignatures[serverSocketClassName+"."+sig] = gmeth
+	}
+}
+
+// serverSocket is the Go state of a ServerSocket.
+type serverSocket struct {
+	mu           sync.Mutex
+	ln           *net.TCPListener
+	addr         *object.Object // the InetAddress bound to
+	closed       bool
+	soTimeout    time.Duration // for accept(); 0 is no timeout
+	reuseAddress bool
+	recvBuffer   int // set on the accepted sockets; 0 until it has been set
+}
+
+// getServerSocket returns the Go state of a ServerSocket.
+func getServerSocket(obj any) (*serverSocket, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "ServerSocket is null")
+	}
+	ss, ok := o.FieldTable[netStateField].Fvalue.(*serverSocket)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "ServerSocket is not initialized")
+	}
+	return ss, nil
+}
+
+// bind starts listening on addr. addrObj is the InetAddress of addr.
+func (ss *serverSocket) bind(addr *net.TCPAddr, addrObj *object.Object) *ghelpers.GErrBlk {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	switch {
+	case ss.closed:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	case ss.ln != nil:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Already bound")
+	}
+	ln, err := net.ListenTCP("tcp", addr)
+	if err != nil {
+		return socketError(err)
+	}
+	ss.ln = ln
+	ss.addr = addrObj
+	return nil
+}
+
+// java/net/ServerSocket.<init>()V, <init>(I)V, <init>(II)V and <init>(IILjava/net/InetAddress;)V
+// -- all but the first bind the socket. A null address is the wildcard address.
+func serverSocketInit(params []interface{}) interface{} {
+	ss := &serverSocket{reuseAddress: true}
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: ss}
+	if len(params) == 1 {
+		return nil
+	}
+
+	port := params[1].(int64)
+	if port < 0 || port > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("Port value out of range: %d", port))
+	}
+	addrObj := anyLocalAddress()
+	if len(params) > 3 {
+		if obj, ok := params[3].(*object.Object); ok && !object.IsNull(obj) {
+			addrObj = obj
+		}
+	}
+	ip := addrObj.FieldTable[netStateField].Fvalue.(*inetAddress).ip
+	if gerr := ss.bind(&net.TCPAddr{IP: ip, Port: int(port)}, addrObj); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/net/ServerSocket.bind(Ljava/net/SocketAddress;)V and bind(Ljava/net/SocketAddress;I)V
+func serverSocketBind(params []interface{}) interface{} {
+	ss, gerr := getServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	addr, addrObj, gerr := tcpAddrFor(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if gerr = ss.bind(addr, addrObj); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/net/ServerSocket.accept()Ljava/net/Socket; -- waits for a connection for at most the
+// SO_TIMEOUT, waking up to see whether the thread has been interrupted
+func serverSocketAccept(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	ss, gerr := getServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ss.mu.Lock()
+	ln, closed, timeout, recvBuffer := ss.ln, ss.closed, ss.soTimeout, ss.recvBuffer
+	ss.mu.Unlock()
+	switch {
+	case closed:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	case ln == nil:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is not bound yet")
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	interruptible := ghelpers.CurrentThread(fs) != nil
+	for {
+		if ghelpers.TakeInterrupt(fs) {
+			return closedByInterrupt(func() { _ = ss.close() })
+		}
+		wake := deadline
+		if interruptible {
+			if next := time.Now().Add(socketPollInterval); wake.IsZero() || next.Before(wake) {
+				wake = next
+			}
+		}
+		_ = ln.SetDeadline(wake)
+		conn, err := ln.AcceptTCP()
+		switch {
+		case err == nil:
+			if recvBuffer > 0 {
+				_ = conn.SetReadBuffer(recvBuffer)
+			}
+			return newConnectedSocket(conn, recvBuffer)
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Accept timed out")
+			}
+		default:
+			return socketError(err)
+		}
+	}
+}
+
+func (ss *serverSocket) close() error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.closed {
+		return nil
+	}
+	ss.closed = true
+	if ss.ln != nil {
+		return ss.ln.Close()
+	}
+	return nil
+}
+
+// java/net/ServerSocket.close()V -- a thread blocked in accept() gets a SocketException
+func serverSocketClose(params []interface{}) interface{} {
+	ss, gerr := getServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if err := ss.close(); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// serverSocketState runs get on the state of the server socket in params[0], under its lock.
+func serverSocketState(params []interface{}, get func(ss *serverSocket) interface{}) interface{} {
+	ss, gerr := getServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return get(ss)
+}
+
+// serverSocketOption runs set on the state of the server socket in params[0], under its lock,
+// unless the socket is closed.
+func serverSocketOption(params []interface{}, set func(ss *serverSocket) *ghelpers.GErrBlk) interface{} {
+	ss, gerr := getServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.closed {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	}
+	if gerr = set(ss); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func serverSocketIsBound(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ss.ln != nil)
+	})
+}
+
+func serverSocketIsClosed(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ss.closed)
+	})
+}
+
+// java/net/ServerSocket.getLocalPort()I -- -1 if the socket is not bound
+func serverSocketGetLocalPort(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		if ss.ln == nil {
+			return int64(-1)
+		}
+		return int64(ss.ln.Addr().(*net.TCPAddr).Port)
+	})
+}
+
+// java/net/ServerSocket.getInetAddress()Ljava/net/InetAddress; -- null if the socket is not bound
+func serverSocketGetInetAddress(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		if ss.ln == nil {
+			return object.Null
+		}
+		return ss.addr
+	})
+}
+
+func serverSocketGetLocalSocketAddress(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		if ss.ln == nil {
+			return object.Null
+		}
+		return newInetSocketAddress(ss.addr, ss.ln.Addr().(*net.TCPAddr).Port)
+	})
+}
+
+func serverSocketGetSoTimeout(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} { return ss.soTimeout.Milliseconds() })
+}
+
+// java/net/ServerSocket.setSoTimeout(I)V -- the timeout of accept() in milliseconds; 0 is none
+func serverSocketSetSoTimeout(params []interface{}) interface{} {
+	timeout := params[1].(int64)
+	return serverSocketOption(params, func(ss *serverSocket) *ghelpers.GErrBlk {
+		if timeout < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "timeout < 0")
+		}
+		ss.soTimeout = time.Duration(timeout) * time.Millisecond
+		return nil
+	})
+}
+
+func serverSocketGetReuseAddress(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		return types.ConvertGoBoolToJavaBool(ss.reuseAddress)
+	})
+}
+
+// java/net/ServerSocket.setReuseAddress(Z)V -- recorded only: Go sets SO_REUSEADDR on listeners
+func serverSocketSetReuseAddress(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return serverSocketOption(params, func(ss *serverSocket) *ghelpers.GErrBlk {
+		ss.reuseAddress = on
+		return nil
+	})
+}
+
+func serverSocketGetReceiveBufferSize(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		if ss.ln == nil {
+			return int64(bufferSize(nil, true, ss.recvBuffer))
+		}
+		return int64(bufferSize(ss.ln, true, ss.recvBuffer))
+	})
+}
+
+// java/net/ServerSocket.setReceiveBufferSize(I)V -- the size is given to the accepted sockets
+func serverSocketSetReceiveBufferSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	return serverSocketOption(params, func(ss *serverSocket) *ghelpers.GErrBlk {
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "negative receive size")
+		}
+		ss.recvBuffer = int(size)
+		return nil
+	})
+}
+
+// java/net/ServerSocket.toString()Ljava/lang/String;
+func serverSocketToString(params []interface{}) interface{} {
+	return serverSocketState(params, func(ss *serverSocket) interface{} {
+		if ss.ln == nil {
+			return object.StringObjectFromGoString("ServerSocket[unbound]")
+		}
+		addr := ss.addr.FieldTable[netStateField].Fvalue.(*inetAddress)
+		str := fmt.Sprintf("ServerSocket[addr=%s,localport=%d]", addr.String(), ss.ln.Addr().(*net.TCPAddr).Port)
+		return object.StringObjectFromGoString(str)
+	})
+}
@@ -0,0 +1,1025 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// java.net.Socket, a TCP client socket, over a Go *net.TCPConn. Its input and output streams
+// are objects of the JDK's own inner classes, Socket$SocketInputStream and
+// Socket$SocketOutputStream, that share the socket's state.
+//
+// A blocking connect, read, or write wakes up every socketPollInterval to see whether its thread
+// has been interrupted. If it has, the socket is closed and a SocketException is thrown, as the
+// JDK does for virtual threads.
+
+const (
+	socketClassName             = "java/net/Socket"
+	socketInputStreamClassName  = "java/net/Socket$SocketInputStream"
+	socketOutputStreamClassName = "java/net/Socket$SocketOutputStream"
+
+	socketPollInterval = 20 * time.Millisecond
+)
+
+// socketMethods are the methods of Socket, which SSLSocket inherits.
+var socketMethods = map[string]ghelpers.GMeth{
+	"bind(Ljava/net/SocketAddress;)V":                 {ParamSlots: 1, GFunction: socketBind},
+	"close()V":                                        {ParamSlots: 0, GFunction: socketClose},
+	"connect(Ljava/net/SocketAddress;)V":              {ParamSlots: 1, GFunction: socketConnect, NeedsContext: true},
+	"connect(Ljava/net/SocketAddress;I)V":             {ParamSlots: 2, GFunction: socketConnect, NeedsContext: true},
+	"getInetAddress()Ljava/net/InetAddress;":          {ParamSlots: 0, GFunction: socketGetInetAddress},
+	"getInputStream()Ljava/io/InputStream;":           {ParamSlots: 0, GFunction: socketGetInputStream},
+	"getKeepAlive()Z":                                 {ParamSlots: 0, GFunction: socketGetKeepAlive},
+	"getLocalAddress()Ljava/net/InetAddress;":         {ParamSlots: 0, GFunction: socketGetLocalAddress},
+	"getLocalPort()I":                                 {ParamSlots: 0, GFunction: socketGetLocalPort},
+	"getLocalSocketAddress()Ljava/net/SocketAddress;": {ParamSlots: 0, GFunction: socketGetLocalSocketAddress},
+	"getOutputStream()Ljava/io/OutputStream;":         {ParamSlots: 0, GFunction: socketGetOutputStream},
+	"getPort()I":                                      {ParamSlots: 0, GFunction: socketGetPort},
+	"getReceiveBufferSize()I":                         {ParamSlots: 0, GFunction: socketGetReceiveBufferSize},
+	"getRemoteSocketAddress()Ljava/net/SocketAddress;": {ParamSlots: 0,
+		GFunction: socketGetRemoteSocketAddress},
+	"getReuseAddress()Z":           {ParamSlots: 0, GFunction: socketGetReuseAddress},
+	"getSendBufferSize()I":         {ParamSlots: 0, GFunction: socketGetSendBufferSize},
+	"getSoLinger()I":               {ParamSlots: 0, GFunction: socketGetSoLinger},
+	"getSoTimeout()I":              {ParamSlots: 0, GFunction: socketGetSoTimeout},
+	"getTcpNoDelay()Z":             {ParamSlots: 0, GFunction: socketGetTcpNoDelay},
+	"isBound()Z":                   {ParamSlots: 0, GFunction: socketIsBound},
+	"isClosed()Z":                  {ParamSlots: 0, GFunction: socketIsClosed},
+	"isConnected()Z":               {ParamSlots: 0, GFunction: socketIsConnected},
+	"isInputShutdown()Z":           {ParamSlots: 0, GFunction: socketIsInputShutdown},
+	"isOutputShutdown()Z":          {ParamSlots: 0, GFunction: socketIsOutputShutdown},
+	"setKeepAlive(Z)V":             {ParamSlots: 1, GFunction: socketSetKeepAlive},
+	"setReceiveBufferSize(I)V":     {ParamSlots: 1, GFunction: socketSetReceiveBufferSize},
+	"setReuseAddress(Z)V":          {ParamSlots: 1, GFunction: socketSetReuseAddress},
+	"setSendBufferSize(I)V":        {ParamSlots: 1, GFunction: socketSetSendBufferSize},
+	"setSoLinger(ZI)V":             {ParamSlots: 2, GFunction: socketSetSoLinger},
+	"setSoTimeout(I)V":             {ParamSlots: 1, GFunction: socketSetSoTimeout},
+	"setTcpNoDelay(Z)V":            {ParamSlots: 1, GFunction: socketSetTcpNoDelay},
+	"shutdownInput()V":             {ParamSlots: 0, GFunction: socketShutdownInput},
+	"shutdownOutput()V":            {ParamSlots: 0, GFunction: socketShutdownOutput},
+	"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: socketToString},
+}
+
+func Load_Net_Socket() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V": {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":   {ParamSlots: 0, GFunction: socketInit},
+		"<init>(Ljava/lang/String;I)V": {ParamSlots: 2, GFunction: socketInitConnect,
+			NeedsContext: true},
+		"<init>(Ljava/net/InetAddress;I)V": {ParamSlots: 2, GFunction: socketInitConnect,
+			NeedsContext: true},
+		"<init>(Ljava/lang/String;ILjava/net/InetAddress;I)V": {ParamSlots: 4, GFunction: socketInitConnect,
+			NeedsContext: true},
+		"<init>(Ljava/net/InetAddress;ILjava/net/InetAddress;I)V": {ParamSlots: 4, GFunction: socketInitConnect,
+			NeedsContext: true},
+	} {
+		ghelpers.MethodSignatures[socketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range socketMethods {
+		ghelpers.MethodSignatures[socketClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"available()I":     {ParamSlots: 0, GFunction: socketInputStreamAvailable},
+		"close()V":         {ParamSlots: 0, GFunction: socketClose},
+		"read()I":          {ParamSlots: 0, GFunction: socketInputStreamRead, NeedsContext: true},
+		"read([B)I":        {ParamSlots: 1, GFunction: socketInputStreamRead, NeedsContext: true},
+		"read([BII)I":      {ParamSlots: 3, GFunction: socketInputStreamRead, NeedsContext: true},
+		"skip(J)J":         {ParamSlots: 1, GFunction: socketInputStreamSkip, NeedsContext: true},
+		"markSupported()Z": {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+	} {
+		ghelpers.MethodSignatures[socketInputStreamClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"close()V":     {ParamSlots: 0, GFunction: socketClose},
+		"flush()V":     {ParamSlots: 0, GFunction: ghelpers.ReturnNull},
+		"write(I)V":    {ParamSlots: 1, GFunction: socketOutputStreamWrite, NeedsContext: true},
+		"write([B)V":   {ParamSlots: 1, GFunction: socketOutputStreamWrite, NeedsContext: true},
+		"write([BII)V": {ParamSlots: 3, GFunction: socketOutputStreamWrite, NeedsContext: true},
+	} {
+		ghelpers.MethodSignatures[socketOutputStreamClassName+"."+sig] = gmeth
+	}
+}
+
+// socket is the Go state of a Socket and of its streams.
+type socket struct {
+	mu         sync.Mutex
+	conn       *net.TCPConn
+	localAddr  *net.TCPAddr   // the address given to bind(), until the socket is connected
+	remote     *object.Object // the InetAddress connected to, as given to connect()
+	connected  bool           // stays true after close(), as in the JDK
+	closed     bool
+	inputShut  bool
+	outputShut bool
+
+	soTimeout    time.Duration // for reads; 0 is no timeout
+	tcpNoDelay   bool
+	keepAlive    bool
+	reuseAddress bool
+	linger       int // seconds, or -1 if SO_LINGER is off
+	sendBuffer   int // 0 until it has been set
+	recvBuffer   int
+
+	in, out *object.Object
+
+	ssl *sslSocket // the TLS state of an SSLSocket; nil for a Socket
+	tls *tls.Conn  // the connection of an SSLSocket once it handshakes
+}
+
+func newSocket() *socket {
+	return &socket{linger: -1}
+}
+
+// newConnectedSocket returns a Socket for a connection accepted by a ServerSocket.
+func newConnectedSocket(conn *net.TCPConn, recvBuffer int) *object.Object {
+	s := newSocket()
+	s.conn = conn
+	s.connected = true
+	s.recvBuffer = recvBuffer
+	s.remote = newInetAddress(conn.RemoteAddr().(*net.TCPAddr).IP, "")
+	_ = conn.SetNoDelay(false) // Go turns Nagle's algorithm off; Java leaves it on
+	className := socketClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+	return obj
+}
+
+// getSocket returns the Go state of a Socket or of one of its streams.
+func getSocket(obj any) (*socket, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "Socket is null")
+	}
+	s, ok := o.FieldTable[netStateField].Fvalue.(*socket)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "Socket is not initialized")
+	}
+	return s, nil
+}
+
+// socketError converts an error from a Go socket to the exception that the JDK throws.
+func socketError(err error) *ghelpers.GErrBlk {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, net.ErrClosed):
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket closed")
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ghelpers.GetGErrBlk(excNames.ConnectException, "Connection refused")
+	case errors.Is(err, syscall.ECONNRESET):
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Connection reset")
+	case errors.Is(err, syscall.EPIPE):
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Broken pipe")
+	case errors.Is(err, syscall.EADDRINUSE):
+		return ghelpers.GetGErrBlk(excNames.BindException, "Address already in use")
+	case errors.Is(err, syscall.EADDRNOTAVAIL):
+		return ghelpers.GetGErrBlk(excNames.BindException, "Cannot assign requested address")
+	case errors.Is(err, syscall.EHOSTUNREACH):
+		return ghelpers.GetGErrBlk(excNames.NoRouteToHostException, "No route to host")
+	case errors.Is(err, syscall.ENETUNREACH):
+		return ghelpers.GetGErrBlk(excNames.ConnectException, "Network is unreachable")
+	case errors.As(err, &dnsErr):
+		return ghelpers.GetGErrBlk(excNames.UnknownHostException, dnsErr.Name)
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "timed out")
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Err != nil {
+		return ghelpers.GetGErrBlk(excNames.SocketException, opErr.Err.Error())
+	}
+	return ghelpers.GetGErrBlk(excNames.SocketException, err.Error())
+}
+
+// closedByInterrupt closes the socket of a thread that has been interrupted while it blocked.
+func closedByInterrupt(closer func()) *ghelpers.GErrBlk {
+	closer()
+	return ghelpers.GetGErrBlk(excNames.SocketException, "Closed by interrupt")
+}
+
+// tcpAddrFor returns the Go address of an InetSocketAddress. A null address is the wildcard
+// address with an ephemeral port.
+func tcpAddrFor(arg any) (*net.TCPAddr, *object.Object, *ghelpers.GErrBlk) {
+	if obj, ok := arg.(*object.Object); !ok || object.IsNull(obj) {
+		return &net.TCPAddr{IP: net.IPv4zero}, anyLocalAddress(), nil
+	}
+	isa, gerr := getInetSocketAddress(arg)
+	if gerr != nil {
+		return nil, nil, gerr
+	}
+	if isa.addr == nil {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.UnknownHostException, isa.host)
+	}
+	return &net.TCPAddr{IP: isa.ip(), Port: isa.port}, isa.addr, nil
+}
+
+// connect connects the socket to addr, waiting at most timeout (0 is for ever). The dial runs
+// in a goroutine so that the thread can be interrupted.
+func (s *socket) connect(fs *list.List, addr *net.TCPAddr, remote *object.Object, timeout time.Duration) *ghelpers.GErrBlk {
+	s.mu.Lock()
+	switch {
+	case s.closed:
+		s.mu.Unlock()
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	case s.connected:
+		s.mu.Unlock()
+		return ghelpers.GetGErrBlk(excNames.SocketException, "already connected")
+	}
+	dialer := net.Dialer{Timeout: timeout, KeepAlive: -1}
+	if s.localAddr != nil {
+		dialer.LocalAddr = s.localAddr
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var conn net.Conn
+	var err error
+	done := make(chan struct{})
+	go func() {
+		conn, err = dialer.DialContext(ctx, "tcp", addr.String())
+		close(done)
+	}()
+	if _, gerr := ghelpers.AwaitInterruptibly(fs, done, -1); gerr != nil {
+		cancel()
+		<-done
+		if conn != nil {
+			_ = conn.Close()
+		}
+		return closedByInterrupt(func() { _ = s.close() })
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+			return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Connect timed out")
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Timeout() {
+			return ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Connect timed out")
+		}
+		return socketError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed { // closed by another thread while connecting
+		_ = conn.Close()
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket closed")
+	}
+	s.conn = conn.(*net.TCPConn)
+	s.connected = true
+	s.remote = remote
+	_ = s.conn.SetNoDelay(s.tcpNoDelay)
+	_ = s.conn.SetKeepAlive(s.keepAlive)
+	_ = s.conn.SetLinger(s.linger)
+	if s.sendBuffer > 0 {
+		_ = s.conn.SetWriteBuffer(s.sendBuffer)
+	}
+	if s.recvBuffer > 0 {
+		_ = s.conn.SetReadBuffer(s.recvBuffer)
+	}
+	return nil
+}
+
+func (s *socket) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.ssl != nil {
+		s.ssl.shutdown()
+	}
+	switch {
+	case s.tls != nil:
+		return s.tls.Close()
+	case s.conn != nil && (s.ssl == nil || s.ssl.autoClose):
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// openConn returns the connection, or the exception to throw if it cannot be used.
+func (s *socket) openConn() (*net.TCPConn, *ghelpers.GErrBlk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	}
+	if !s.connected {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "Socket is not connected")
+	}
+	return s.conn, nil
+}
+
+// read reads into p. It returns -1 at the end of the stream, after which reads return -1.
+func (s *socket) read(fs *list.List, p []byte) (int, *ghelpers.GErrBlk) {
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return 0, gerr
+	}
+	s.mu.Lock()
+	timeout, inputShut := s.soTimeout, s.inputShut
+	s.mu.Unlock()
+	if inputShut {
+		return -1, nil
+	}
+	var rc net.Conn = conn
+	if s.ssl != nil {
+		tc, gerr := s.handshake(fs)
+		if gerr != nil {
+			return 0, gerr
+		}
+		rc = tc
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	interruptible := ghelpers.CurrentThread(fs) != nil
+	for {
+		if ghelpers.TakeInterrupt(fs) {
+			return 0, closedByInterrupt(func() { _ = s.close() })
+		}
+		wake := deadline
+		if interruptible {
+			if next := time.Now().Add(socketPollInterval); wake.IsZero() || next.Before(wake) {
+				wake = next
+			}
+		}
+		_ = conn.SetReadDeadline(wake)
+		n, err := rc.Read(p)
+		switch {
+		case n > 0:
+			return n, nil
+		case err == io.EOF:
+			return -1, nil
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				return 0, ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Read timed out")
+			}
+		case err != nil && s.ssl != nil:
+			return 0, sslError(err, false)
+		case err != nil:
+			return 0, socketError(err)
+		}
+	}
+}
+
+// write writes all of p.
+func (s *socket) write(fs *list.List, p []byte) *ghelpers.GErrBlk {
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	outputShut := s.outputShut
+	s.mu.Unlock()
+	if outputShut {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket output is shutdown")
+	}
+	if s.ssl != nil {
+		return s.writeTLS(fs, p)
+	}
+
+	interruptible := ghelpers.CurrentThread(fs) != nil
+	for len(p) > 0 {
+		if ghelpers.TakeInterrupt(fs) {
+			return closedByInterrupt(func() { _ = s.close() })
+		}
+		var wake time.Time
+		if interruptible {
+			wake = time.Now().Add(socketPollInterval)
+		}
+		_ = conn.SetWriteDeadline(wake)
+		n, err := conn.Write(p)
+		p = p[n:]
+		if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+			return socketError(err)
+		}
+	}
+	return nil
+}
+
+// java/net/Socket.<init>()V -- an unconnected socket
+func socketInit(params []interface{}) interface{} {
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: newSocket()}
+	return nil
+}
+
+// java/net/Socket.<init>(Ljava/lang/String;I)V, <init>(Ljava/net/InetAddress;I)V, and the
+// forms that also take the local address and port -- a connected socket. A null host name is
+// the loopback address.
+func socketInitConnect(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	s := newSocket()
+	self.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+
+	remote, gerr := addressArg(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	port := params[2].(int64)
+	if gerr = checkPort(port); gerr != nil {
+		return gerr
+	}
+	if len(params) > 3 {
+		localPort := params[4].(int64)
+		if gerr = checkPort(localPort); gerr != nil {
+			return gerr
+		}
+		local, ok := params[3].(*object.Object)
+		if !ok || object.IsNull(local) {
+			local = anyLocalAddress()
+		}
+		s.localAddr = &net.TCPAddr{IP: local.FieldTable[netStateField].Fvalue.(*inetAddress).ip, Port: int(localPort)}
+	}
+	ip := remote.FieldTable[netStateField].Fvalue.(*inetAddress).ip
+	return gerrOrNil(s.connect(fs, &net.TCPAddr{IP: ip, Port: int(port)}, remote, 0))
+}
+
+// addressArg returns the InetAddress in arg, an InetAddress or a host name to resolve.
+func addressArg(arg any) (*object.Object, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return loopbackAddress(), nil
+	}
+	if _, ok = obj.FieldTable[netStateField].Fvalue.(*inetAddress); ok {
+		return obj, nil
+	}
+	addrs, gerr := lookupHost(object.GoStringFromStringObject(obj))
+	if gerr != nil {
+		return nil, gerr
+	}
+	return addrs[0], nil
+}
+
+// java/net/Socket.connect(Ljava/net/SocketAddress;)V and connect(Ljava/net/SocketAddress;I)V
+func socketConnect(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if obj, ok := params[1].(*object.Object); !ok || object.IsNull(obj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "connect: The address can't be null")
+	}
+	var timeout int64
+	if len(params) > 2 {
+		timeout = params[2].(int64)
+		if timeout < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "connect: timeout can't be negative")
+		}
+	}
+	addr, remote, gerr := tcpAddrFor(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return gerrOrNil(s.connect(fs, addr, remote, time.Duration(timeout)*time.Millisecond))
+}
+
+// java/net/Socket.bind(Ljava/net/SocketAddress;)V -- the address is used when the socket
+// connects. A null address is the wildcard address with an ephemeral port.
+func socketBind(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	addr, _, gerr := tcpAddrFor(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.closed:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	case s.localAddr != nil || s.connected:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Already bound")
+	}
+	s.localAddr = addr
+	return nil
+}
+
+// java/net/Socket.close()V, also the close() of the socket's streams
+func socketClose(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if err := s.close(); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// java/net/Socket.getInputStream()Ljava/io/InputStream;
+func socketGetInputStream(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if _, gerr = s.openConn(); gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inputShut {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket input is shutdown")
+	}
+	if s.in == nil {
+		className := socketInputStreamClassName
+		s.in = object.MakeEmptyObjectWithClassName(&className)
+		s.in.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+	}
+	return s.in
+}
+
+// java/net/Socket.getOutputStream()Ljava/io/OutputStream;
+func socketGetOutputStream(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if _, gerr = s.openConn(); gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.outputShut {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket output is shutdown")
+	}
+	if s.out == nil {
+		className := socketOutputStreamClassName
+		s.out = object.MakeEmptyObjectWithClassName(&className)
+		s.out.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+	}
+	return s.out
+}
+
+// java/net/Socket.shutdownInput()V -- reads then return -1
+func socketShutdownInput(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inputShut {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket input is already shutdown")
+	}
+	s.inputShut = true
+	if err := conn.CloseRead(); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// java/net/Socket.shutdownOutput()V -- sends a FIN to the peer
+func socketShutdownOutput(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.outputShut {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket output is already shutdown")
+	}
+	s.outputShut = true
+	if s.tls != nil {
+		_ = s.tls.CloseWrite() // sends a close_notify
+	}
+	if err := conn.CloseWrite(); err != nil {
+		return socketError(err)
+	}
+	return nil
+}
+
+// socketState runs get on the state of the socket in params[0], under its lock.
+func socketState(params []interface{}, get func(s *socket) interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return get(s)
+}
+
+// socketOption runs set on the state of the socket in params[0], under its lock, unless the
+// socket is closed.
+func socketOption(params []interface{}, set func(s *socket) *ghelpers.GErrBlk) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket is closed")
+	}
+	if gerr = set(s); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func socketIsBound(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		return types.ConvertGoBoolToJavaBool(s.connected || s.localAddr != nil)
+	})
+}
+
+func socketIsClosed(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.closed) })
+}
+
+func socketIsConnected(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.connected) })
+}
+
+func socketIsInputShutdown(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.inputShut) })
+}
+
+func socketIsOutputShutdown(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.outputShut) })
+}
+
+// java/net/Socket.getInetAddress()Ljava/net/InetAddress; -- null if the socket is not connected
+func socketGetInetAddress(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if !s.connected {
+			return object.Null
+		}
+		return s.remote
+	})
+}
+
+// java/net/Socket.getPort()I -- 0 if the socket is not connected
+func socketGetPort(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if !s.connected {
+			return int64(0)
+		}
+		return int64(s.conn.RemoteAddr().(*net.TCPAddr).Port)
+	})
+}
+
+func socketGetRemoteSocketAddress(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if !s.connected {
+			return object.Null
+		}
+		return newInetSocketAddress(s.remote, s.conn.RemoteAddr().(*net.TCPAddr).Port)
+	})
+}
+
+// sysConn returns the connection for the system calls of bufferSize and bytesAvailable, or nil
+// if the socket is not connected.
+func (s *socket) sysConn() syscall.Conn {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn
+}
+
+// localTCPAddr returns the local address of the socket, or nil if it is not bound.
+func (s *socket) localTCPAddr() *net.TCPAddr {
+	if s.conn != nil {
+		return s.conn.LocalAddr().(*net.TCPAddr)
+	}
+	return s.localAddr
+}
+
+// java/net/Socket.getLocalPort()I -- -1 if the socket is not bound
+func socketGetLocalPort(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if addr := s.localTCPAddr(); addr != nil {
+			return int64(addr.Port)
+		}
+		return int64(-1)
+	})
+}
+
+// java/net/Socket.getLocalAddress()Ljava/net/InetAddress; -- the wildcard address if the socket
+// is not bound
+func socketGetLocalAddress(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if addr := s.localTCPAddr(); addr != nil && !s.closed {
+			return newInetAddress(addr.IP, "")
+		}
+		return anyLocalAddress()
+	})
+}
+
+func socketGetLocalSocketAddress(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		addr := s.localTCPAddr()
+		if addr == nil {
+			return object.Null
+		}
+		return inetSocketAddressFor(addr)
+	})
+}
+
+func socketGetSoTimeout(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return s.soTimeout.Milliseconds() })
+}
+
+// java/net/Socket.setSoTimeout(I)V -- the timeout of reads in milliseconds; 0 is none
+func socketSetSoTimeout(params []interface{}) interface{} {
+	timeout := params[1].(int64)
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		if timeout < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "timeout can't be negative")
+		}
+		s.soTimeout = time.Duration(timeout) * time.Millisecond
+		return nil
+	})
+}
+
+func socketGetTcpNoDelay(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.tcpNoDelay) })
+}
+
+func socketSetTcpNoDelay(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		s.tcpNoDelay = on
+		if s.conn != nil {
+			if err := s.conn.SetNoDelay(on); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func socketGetKeepAlive(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.keepAlive) })
+}
+
+func socketSetKeepAlive(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		s.keepAlive = on
+		if s.conn != nil {
+			if err := s.conn.SetKeepAlive(on); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func socketGetReuseAddress(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return types.ConvertGoBoolToJavaBool(s.reuseAddress) })
+}
+
+// java/net/Socket.setReuseAddress(Z)V -- recorded only: Go sets SO_REUSEADDR itself
+func socketSetReuseAddress(params []interface{}) interface{} {
+	on := params[1].(int64) == types.JavaBoolTrue
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		s.reuseAddress = on
+		return nil
+	})
+}
+
+func socketGetSoLinger(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} { return int64(s.linger) })
+}
+
+// java/net/Socket.setSoLinger(ZI)V -- the linger time is capped at 65535 seconds
+func socketSetSoLinger(params []interface{}) interface{} {
+	on, linger := params[1].(int64) == types.JavaBoolTrue, params[2].(int64)
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		switch {
+		case !on:
+			s.linger = -1
+		case linger < 0:
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid value for SO_LINGER")
+		default:
+			s.linger = int(min(linger, 65535))
+		}
+		if s.conn != nil {
+			if err := s.conn.SetLinger(s.linger); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// java/net/Socket.getSendBufferSize()I -- the size that the system uses, which may differ from
+// the size that was set
+func socketGetSendBufferSize(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		return int64(bufferSize(s.sysConn(), false, s.sendBuffer))
+	})
+}
+
+func socketSetSendBufferSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "negative send size")
+		}
+		s.sendBuffer = int(size)
+		if s.conn != nil {
+			if err := s.conn.SetWriteBuffer(s.sendBuffer); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func socketGetReceiveBufferSize(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		return int64(bufferSize(s.sysConn(), true, s.recvBuffer))
+	})
+}
+
+func socketSetReceiveBufferSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	return socketOption(params, func(s *socket) *ghelpers.GErrBlk {
+		if size <= 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid receive size")
+		}
+		s.recvBuffer = int(size)
+		if s.conn != nil {
+			if err := s.conn.SetReadBuffer(s.recvBuffer); err != nil {
+				return socketError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// java/net/Socket.toString()Ljava/lang/String;
+func socketToString(params []interface{}) interface{} {
+	return socketState(params, func(s *socket) interface{} {
+		if !s.connected {
+			return object.StringObjectFromGoString("Socket[unconnected]")
+		}
+		remote := s.remote.FieldTable[netStateField].Fvalue.(*inetAddress)
+		str := fmt.Sprintf("Socket[addr=%s,port=%d,localport=%d]", remote.String(),
+			s.conn.RemoteAddr().(*net.TCPAddr).Port, s.conn.LocalAddr().(*net.TCPAddr).Port)
+		return object.StringObjectFromGoString(str)
+	})
+}
+
+// java/net/Socket$SocketInputStream.read()I, read([B)I and read([BII)I
+func socketInputStreamRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 1 {
+		var b [1]byte
+		n, gerr := s.read(fs, b[:])
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			return int64(-1)
+		}
+		return int64(b[0])
+	}
+
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "SocketInputStream.read")
+	if gerr != nil {
+		return gerr
+	}
+	if length == 0 {
+		return int64(0)
+	}
+	buf := make([]byte, length)
+	n, gerr := s.read(fs, buf)
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+	return int64(n)
+}
+
+// java/net/Socket$SocketInputStream.skip(J)J
+func socketInputStreamSkip(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	remaining := params[1].(int64)
+	var skipped int64
+	buf := make([]byte, min(max(remaining, 0), 8192))
+	for remaining > 0 {
+		n, gerr := s.read(fs, buf[:min(remaining, int64(len(buf)))])
+		if gerr != nil {
+			return gerr
+		}
+		if n < 0 {
+			break
+		}
+		skipped += int64(n)
+		remaining -= int64(n)
+	}
+	return skipped
+}
+
+// java/net/Socket$SocketInputStream.available()I -- the bytes that can be read without blocking
+func socketInputStreamAvailable(params []interface{}) interface{} {
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	inputShut := s.inputShut
+	s.mu.Unlock()
+	if inputShut || s.ssl != nil { // what TLS has decrypted is not known
+		return int64(0)
+	}
+	return int64(bytesAvailable(conn))
+}
+
+// java/net/Socket$SocketOutputStream.write(I)V, write([B)V and write([BII)V
+func socketOutputStreamWrite(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if b, ok := params[1].(int64); ok {
+		return gerrOrNil(s.write(fs, []byte{byte(b)}))
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "SocketOutputStream.write")
+	if gerr != nil {
+		return gerr
+	}
+	return gerrOrNil(s.write(fs, object.GoByteArrayFromJavaByteArray(jbytes[off:off+length])))
+}
+
+// writeResult returns nil, not a nil *GErrBlk, if a write has succeeded.
+func gerrOrNil(gerr *ghelpers.GErrBlk) interface{} {
+	if gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// byteArrayRange returns the bytes of the byte array in args[0] and the offset and length in
+// args[1] and args[2], or the whole array if they are not given.
+func byteArrayRange(args []interface{}, caller string) ([]types.JavaByte, int64, int64, *ghelpers.GErrBlk) {
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": byte array is null")
+	}
+	jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := int64(0), int64(len(jbytes))
+	if len(args) >= 3 {
+		off, length = args[1].(int64), args[2].(int64)
+	}
+	if off < 0 || length < 0 || off+length > int64(len(jbytes)) {
+		errMsg := fmt.Sprintf("%s: offset %d, length %d, array length %d", caller, off, length, len(jbytes))
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	return jbytes, off, length, nil
+}
@@ -0,0 +1,153 @@
+//go:build linux
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// The socket options that Go's net package does not read back or set, on Linux.
+
+// bufferSize returns SO_RCVBUF (receive) or SO_SNDBUF of conn. If conn is nil or the option
+// cannot be read, it returns size, the size that was set.
+func bufferSize(conn syscall.Conn, receive bool, size int) int {
+	opt := unix.SO_SNDBUF
+	if receive {
+		opt = unix.SO_RCVBUF
+	}
+	value := size
+	withFd(conn, func(fd int) {
+		if v, err := unix.GetsockoptInt(fd, unix.SOL_SOCKET, opt); err == nil {
+			value = v
+		}
+	})
+	return value
+}
+
+// bytesAvailable returns the number of bytes that can be read from conn without blocking.
+func bytesAvailable(conn syscall.Conn) int {
+	available := 0
+	withFd(conn, func(fd int) {
+		if n, err := unix.IoctlGetInt(fd, unix.TIOCINQ); err == nil {
+			available = n
+		}
+	})
+	return available
+}
+
+// withFd runs f on the file descriptor of conn, if conn is not nil.
+func withFd(conn syscall.Conn, f func(fd int)) {
+	if conn == nil {
+		return
+	}
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return
+	}
+	_ = rc.Control(func(fd uintptr) { f(int(fd)) })
+}
+
+// controlFd runs f on the file descriptor of conn and returns its error.
+func controlFd(conn syscall.Conn, f func(fd int) error) error {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var ferr error
+	if err = rc.Control(func(fd uintptr) { ferr = f(int(fd)) }); err != nil {
+		return err
+	}
+	return ferr
+}
+
+// reuseAddress sets SO_REUSEADDR on a socket before it is bound, as the Control function of a
+// net.ListenConfig.
+func reuseAddress(_, _ string, rc syscall.RawConn) error {
+	var serr error
+	if err := rc.Control(func(fd uintptr) {
+		serr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1)
+	}); err != nil {
+		return err
+	}
+	return serr
+}
+
+// setBroadcast sets SO_BROADCAST, which Go turns on for every UDP socket.
+func setBroadcast(conn syscall.Conn, on bool) error {
+	return controlFd(conn, func(fd int) error {
+		return unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_BROADCAST, boolInt(on))
+	})
+}
+
+// setMembership joins (join) or leaves the multicast group on the default interface.
+// IPv4 options also work on the dual-stack IPv6 sockets that Go opens for the wildcard address.
+func setMembership(conn syscall.Conn, group net.IP, join bool) error {
+	return controlFd(conn, func(fd int) error {
+		if ip4 := group.To4(); ip4 != nil {
+			mreq := &unix.IPMreq{Multiaddr: [4]byte(ip4)}
+			opt := unix.IP_DROP_MEMBERSHIP
+			if join {
+				opt = unix.IP_ADD_MEMBERSHIP
+			}
+			return unix.SetsockoptIPMreq(fd, unix.IPPROTO_IP, opt, mreq)
+		}
+		mreq := &unix.IPv6Mreq{Multiaddr: [16]byte(group.To16())}
+		opt := unix.IPV6_LEAVE_GROUP
+		if join {
+			opt = unix.IPV6_JOIN_GROUP
+		}
+		return unix.SetsockoptIPv6Mreq(fd, unix.IPPROTO_IPV6, opt, mreq)
+	})
+}
+
+// multicastTTL returns IP_MULTICAST_TTL, the time-to-live of multicast datagrams.
+func multicastTTL(conn syscall.Conn) (int, error) {
+	var ttl int
+	err := controlFd(conn, func(fd int) (err error) {
+		ttl, err = unix.GetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_MULTICAST_TTL)
+		return err
+	})
+	return ttl, err
+}
+
+// setMulticastTTL sets IP_MULTICAST_TTL, and IPV6_MULTICAST_HOPS where the socket has it.
+func setMulticastTTL(conn syscall.Conn, ttl int) error {
+	return controlFd(conn, func(fd int) error {
+		_ = unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_HOPS, ttl)
+		return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_MULTICAST_TTL, ttl)
+	})
+}
+
+// multicastLoop returns IP_MULTICAST_LOOP: whether the host receives its own multicast datagrams.
+func multicastLoop(conn syscall.Conn) (bool, error) {
+	var loop int
+	err := controlFd(conn, func(fd int) (err error) {
+		loop, err = unix.GetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_MULTICAST_LOOP)
+		return err
+	})
+	return loop != 0, err
+}
+
+// setMulticastLoop sets IP_MULTICAST_LOOP, and IPV6_MULTICAST_LOOP where the socket has it.
+func setMulticastLoop(conn syscall.Conn, on bool) error {
+	return controlFd(conn, func(fd int) error {
+		_ = unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_LOOP, boolInt(on))
+		return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_MULTICAST_LOOP, boolInt(on))
+	})
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+//go:build !linux
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// On other systems the socket options that Go's net package does not read back are reported as
+// they were set, and the multicast options that it does not set are not supported.
+
+var errMulticastUnsupported = errors.New("multicast socket options are not supported on this platform")
+
+// bufferSize returns size, the buffer size that was set, or 64K if none was.
+func bufferSize(_ syscall.Conn, _ bool, size int) int {
+	if size == 0 {
+		return 65536
+	}
+	return size
+}
+
+// bytesAvailable returns 0: the bytes waiting on a socket cannot be counted here.
+func bytesAvailable(syscall.Conn) int {
+	return 0
+}
+
+// reuseAddress leaves SO_REUSEADDR as Go sets it.
+func reuseAddress(string, string, syscall.RawConn) error {
+	return nil
+}
+
+// setBroadcast does nothing: Go turns SO_BROADCAST on for every UDP socket.
+func setBroadcast(syscall.Conn, bool) error {
+	return nil
+}
+
+func setMembership(syscall.Conn, net.IP, bool) error {
+	return errMulticastUnsupported
+}
+
+func multicastTTL(syscall.Conn) (int, error) {
+	return 1, nil
+}
+
+func setMulticastTTL(syscall.Conn, int) error {
+	return errMulticastUnsupported
+}
+
+func multicastLoop(syscall.Conn) (bool, error) {
+	return true, nil
+}
+
+func setMulticastLoop(syscall.Conn, bool) error {
+	return errMulticastUnsupported
+}
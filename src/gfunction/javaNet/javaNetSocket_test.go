@@ -0,0 +1,274 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/frames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLoopbackServer returns a ServerSocket bound to an ephemeral port of the loopback address.
+func newLoopbackServer(t *testing.T) (*object.Object, int64) {
+	t.Helper()
+	server := object.MakeEmptyObjectWithClassName(new(serverSocketClassName))
+	if ret := serverSocketInit([]interface{}{server, int64(0), int64(5), loopbackAddress()}); ret != nil {
+		t.Fatalf("ServerSocket.<init>: %v", ret)
+	}
+	t.Cleanup(func() { serverSocketClose([]interface{}{server}) })
+	return server, serverSocketGetLocalPort([]interface{}{server}).(int64)
+}
+
+// connectLoopback returns a Socket connected to port on the loopback address.
+func connectLoopback(t *testing.T, port int64) *object.Object {
+	t.Helper()
+	client := object.MakeEmptyObjectWithClassName(new(socketClassName))
+	if ret := socketInitConnect([]interface{}{list.New(), client, object.StringObjectFromGoString("127.0.0.1"), port}); ret != nil {
+		t.Fatalf("Socket.<init>: %v", ret)
+	}
+	t.Cleanup(func() { socketClose([]interface{}{client}) })
+	return client
+}
+
+func accept(t *testing.T, server *object.Object) *object.Object {
+	t.Helper()
+	ret := serverSocketAccept([]interface{}{list.New(), server})
+	conn, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("accept: %v", ret)
+	}
+	t.Cleanup(func() { socketClose([]interface{}{conn}) })
+	return conn
+}
+
+// readN reads exactly n bytes from the input stream in.
+func readN(t *testing.T, in *object.Object, n int) []byte {
+	t.Helper()
+	var out []byte
+	buf := object.MakeArrayFromRawArray(make([]byte, n))
+	for len(out) < n {
+		ret := socketInputStreamRead([]interface{}{list.New(), in, buf, int64(0), int64(n - len(out))})
+		count, ok := ret.(int64)
+		if !ok || count < 0 {
+			t.Fatalf("read: %v", ret)
+		}
+		out = append(out, object.GoByteArrayFromJavaByteArray(buf.FieldTable["value"].Fvalue.([]types.JavaByte))[:count]...)
+	}
+	return out
+}
+
+func TestSocket_LoopbackEcho(t *testing.T) {
+	globals.InitStringPool()
+	server, port := newLoopbackServer(t)
+	client := connectLoopback(t, port)
+	conn := accept(t, server)
+
+	out := socketGetOutputStream([]interface{}{client}).(*object.Object)
+	if className := object.GoStringFromStringPoolIndex(out.KlassName); className != socketOutputStreamClassName {
+		t.Errorf("output stream class: %s", className)
+	}
+	if ret := socketOutputStreamWrite([]interface{}{list.New(), out, object.MakeArrayFromRawArray([]byte("ping"))}); ret != nil {
+		t.Fatalf("write: %v", ret)
+	}
+	in := socketGetInputStream([]interface{}{conn}).(*object.Object)
+	if got := string(readN(t, in, 4)); got != "ping" {
+		t.Errorf("server read %q", got)
+	}
+
+	// and back, one byte at a time
+	socketOutputStreamWrite([]interface{}{list.New(), socketGetOutputStream([]interface{}{conn}), int64('!')})
+	clientIn := socketGetInputStream([]interface{}{client}).(*object.Object)
+	if b := socketInputStreamRead([]interface{}{list.New(), clientIn}).(int64); b != '!' {
+		t.Errorf("client read %d", b)
+	}
+
+	// the end of the stream after the peer shuts its output down
+	if ret := socketShutdownOutput([]interface{}{client}); ret != nil {
+		t.Fatalf("shutdownOutput: %v", ret)
+	}
+	if b := socketInputStreamRead([]interface{}{list.New(), in}).(int64); b != -1 {
+		t.Errorf("read after the peer's shutdownOutput: %d", b)
+	}
+	testutil.ExpectGErr(t, socketOutputStreamWrite([]interface{}{list.New(), out, int64(1)}),
+		excNames.SocketException, "Socket output is shutdown")
+
+	if got := socketGetPort([]interface{}{client}).(int64); got != port {
+		t.Errorf("getPort: %d, expected %d", got, port)
+	}
+	if got := socketGetLocalPort([]interface{}{conn}).(int64); got != port {
+		t.Errorf("getLocalPort of the accepted socket: %d, expected %d", got, port)
+	}
+	str := netTestGoString(t, socketToString([]interface{}{client}))
+	if !strings.HasPrefix(str, "Socket[addr=/127.0.0.1,port=") {
+		t.Errorf("toString: %q", str)
+	}
+	if got := netTestGoString(t, serverSocketToString([]interface{}{server})); !strings.HasPrefix(got, "ServerSocket[addr=localhost/127.0.0.1,localport=") {
+		t.Errorf("ServerSocket.toString: %q", got)
+	}
+}
+
+func TestSocket_Options(t *testing.T) {
+	globals.InitStringPool()
+	server, port := newLoopbackServer(t)
+	client := connectLoopback(t, port)
+	accept(t, server)
+
+	if socketGetTcpNoDelay([]interface{}{client}) != types.JavaBoolFalse {
+		t.Error("TCP_NODELAY should be off by default")
+	}
+	socketSetTcpNoDelay([]interface{}{client, types.JavaBoolTrue})
+	if socketGetTcpNoDelay([]interface{}{client}) != types.JavaBoolTrue {
+		t.Error("TCP_NODELAY should be on")
+	}
+	socketSetSoTimeout([]interface{}{client, int64(250)})
+	if got := socketGetSoTimeout([]interface{}{client}).(int64); got != 250 {
+		t.Errorf("getSoTimeout: %d", got)
+	}
+	testutil.ExpectGErr(t, socketSetSoTimeout([]interface{}{client, int64(-1)}),
+		excNames.IllegalArgumentException, "timeout can't be negative")
+	if got := socketGetReceiveBufferSize([]interface{}{client}).(int64); got <= 0 {
+		t.Errorf("getReceiveBufferSize: %d", got)
+	}
+	socketSetSoLinger([]interface{}{client, types.JavaBoolTrue, int64(3)})
+	if got := socketGetSoLinger([]interface{}{client}).(int64); got != 3 {
+		t.Errorf("getSoLinger: %d", got)
+	}
+}
+
+func TestSocket_ReadTimeout(t *testing.T) {
+	globals.InitStringPool()
+	server, port := newLoopbackServer(t)
+	client := connectLoopback(t, port)
+	accept(t, server)
+
+	socketSetSoTimeout([]interface{}{client, int64(50)})
+	in := socketGetInputStream([]interface{}{client}).(*object.Object)
+	start := time.Now()
+	testutil.ExpectGErr(t, socketInputStreamRead([]interface{}{list.New(), in}),
+		excNames.SocketTimeoutException, "Read timed out")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("read timed out after %v", elapsed)
+	}
+	if socketIsClosed([]interface{}{client}) != types.JavaBoolFalse {
+		t.Error("a read timeout should not close the socket")
+	}
+}
+
+func TestServerSocket_AcceptTimeoutAndClose(t *testing.T) {
+	globals.InitStringPool()
+	server, _ := newLoopbackServer(t)
+	serverSocketSetSoTimeout([]interface{}{server, int64(30)})
+	testutil.ExpectGErr(t, serverSocketAccept([]interface{}{list.New(), server}),
+		excNames.SocketTimeoutException, "Accept timed out")
+
+	// close() wakes up a thread blocked in accept()
+	serverSocketSetSoTimeout([]interface{}{server, int64(0)})
+	done := make(chan interface{})
+	go func() { done <- serverSocketAccept([]interface{}{list.New(), server}) }()
+	time.Sleep(20 * time.Millisecond)
+	serverSocketClose([]interface{}{server})
+	select {
+	case ret := <-done:
+		testutil.ExpectGErr(t, ret, excNames.SocketException, "closed")
+	case <-time.After(2 * time.Second):
+		t.Fatal("accept() did not return after close()")
+	}
+	testutil.ExpectGErr(t, serverSocketAccept([]interface{}{list.New(), server}),
+		excNames.SocketException, "Socket is closed")
+
+	unbound := object.MakeEmptyObjectWithClassName(new(serverSocketClassName))
+	serverSocketInit([]interface{}{unbound})
+	testutil.ExpectGErr(t, serverSocketAccept([]interface{}{list.New(), unbound}),
+		excNames.SocketException, "Socket is not bound yet")
+	if got := netTestGoString(t, serverSocketToString([]interface{}{unbound})); got != "ServerSocket[unbound]" {
+		t.Errorf("toString: %q", got)
+	}
+	testutil.ExpectGErr(t, serverSocketInit([]interface{}{unbound, int64(-1)}),
+		excNames.IllegalArgumentException, "Port value out of range: -1")
+}
+
+func TestServerSocket_AcceptInterrupted(t *testing.T) {
+	globals.InitGlobals("test")
+	server, _ := newLoopbackServer(t)
+
+	// a frame stack whose thread is interrupted while it waits in accept()
+	frame := frames.CreateFrame(1)
+	frame.Thread = 4711
+	fs := frames.CreateFrameStack()
+	fs.PushFront(frame)
+	th := object.MakeEmptyObject()
+	th.FieldTable["interrupted"] = object.Field{Ftype: types.Int, Fvalue: types.JavaBoolFalse}
+	gr := globals.GetGlobalRef()
+	gr.ThreadLock.Lock()
+	gr.Threads[frame.Thread] = th
+	gr.ThreadLock.Unlock()
+	defer func() {
+		gr.ThreadLock.Lock()
+		delete(gr.Threads, frame.Thread)
+		gr.ThreadLock.Unlock()
+	}()
+
+	done := make(chan interface{})
+	go func() { done <- serverSocketAccept([]interface{}{fs, server}) }()
+	time.Sleep(30 * time.Millisecond)
+	th.ThMutex.Lock()
+	th.FieldTable["interrupted"] = object.Field{Ftype: types.Int, Fvalue: types.JavaBoolTrue}
+	th.ThMutex.Unlock()
+	select {
+	case ret := <-done:
+		testutil.ExpectGErr(t, ret, excNames.SocketException, "Closed by interrupt")
+	case <-time.After(2 * time.Second):
+		t.Fatal("accept() did not return after the interrupt")
+	}
+	if serverSocketIsClosed([]interface{}{server}) != types.JavaBoolTrue {
+		t.Error("an interrupted accept() should close the server socket")
+	}
+}
+
+func TestSocket_ConnectErrors(t *testing.T) {
+	globals.InitStringPool()
+	server, port := newLoopbackServer(t)
+	serverSocketClose([]interface{}{server}) // nothing listens on port now
+
+	client := object.MakeEmptyObjectWithClassName(new(socketClassName))
+	socketInit([]interface{}{client})
+	if socketIsConnected([]interface{}{client}) != types.JavaBoolFalse {
+		t.Error("a new socket should not be connected")
+	}
+	testutil.ExpectGErr(t, socketGetInputStream([]interface{}{client}), excNames.SocketException, "Socket is not connected")
+	if got := netTestGoString(t, socketToString([]interface{}{client})); got != "Socket[unconnected]" {
+		t.Errorf("toString: %q", got)
+	}
+
+	addr := newInetSocketAddress(loopbackAddress(), int(port))
+	testutil.ExpectGErr(t, socketConnect([]interface{}{list.New(), client, addr, int64(1000)}),
+		excNames.ConnectException, "Connection refused")
+	testutil.ExpectGErr(t, socketConnect([]interface{}{list.New(), client, addr, int64(-1)}),
+		excNames.IllegalArgumentException, "timeout can't be negative")
+
+	unresolved := inetSocketAddressCreateUnresolved([]interface{}{object.StringObjectFromGoString("example.invalid"), int64(80)})
+	testutil.ExpectGErr(t, socketConnect([]interface{}{list.New(), client, unresolved}),
+		excNames.UnknownHostException, "example.invalid")
+
+	socketClose([]interface{}{client})
+	testutil.ExpectGErr(t, socketConnect([]interface{}{list.New(), client, addr}),
+		excNames.SocketException, "Socket is closed")
+
+	// a second server on the port of a live one
+	live, livePort := newLoopbackServer(t)
+	_ = live
+	clash := object.MakeEmptyObjectWithClassName(new(serverSocketClassName))
+	testutil.ExpectGErr(t, serverSocketInit([]interface{}{clash, livePort, int64(0), loopbackAddress()}),
+		excNames.BindException, "Address already in use")
+}
@@ -0,0 +1,923 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// java.net.URI, parsed by the rules of RFC 2396 as the JDK applies them: the same strings are
+// accepted and rejected, with the same URISyntaxException messages, and the components are
+// split in the same way. A URI keeps its components in their raw, escaped form; the getters
+// that are not getRawXxx() decode them.
+
+const uriClassName = "java/net/URI"
+
+func Load_Net_URI() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                 {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>(Ljava/lang/String;)V": {ParamSlots: 1, GFunction: uriInit},
+		"<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V": {ParamSlots: 3,
+			GFunction: uriInitOpaque},
+		"<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V": {ParamSlots: 4,
+			GFunction: uriInitHostPath},
+		"<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V": {
+			ParamSlots: 5, GFunction: uriInitAuthority},
+		"<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;ILjava/lang/String;Ljava/lang/String;Ljava/lang/String;)V": {
+			ParamSlots: 7, GFunction: uriInitServer},
+		"create(Ljava/lang/String;)Ljava/net/URI;": {ParamSlots: 1, GFunction: uriCreate},
+		"equals(Ljava/lang/Object;)Z":              {ParamSlots: 1, GFunction: uriEquals},
+		"getAuthority()Ljava/lang/String;":         {ParamSlots: 0, GFunction: uriGetAuthority},
+		"getFragment()Ljava/lang/String;":          {ParamSlots: 0, GFunction: uriGetFragment},
+		"getHost()Ljava/lang/String;":              {ParamSlots: 0, GFunction: uriGetHost},
+		"getPath()Ljava/lang/String;":              {ParamSlots: 0, GFunction: uriGetPath},
+		"getPort()I":                               {ParamSlots: 0, GFunction: uriGetPort},
+		"getQuery()Ljava/lang/String;":             {ParamSlots: 0, GFunction: uriGetQuery},
+		"getRawAuthority()Ljava/lang/String;":      {ParamSlots: 0, GFunction: uriGetRawAuthority},
+		"getRawFragment()Ljava/lang/String;":       {ParamSlots: 0, GFunction: uriGetRawFragment},
+		"getRawPath()Ljava/lang/String;":           {ParamSlots: 0, GFunction: uriGetRawPath},
+		"getRawQuery()Ljava/lang/String;":          {ParamSlots: 0, GFunction: uriGetRawQuery},
+		"getRawSchemeSpecificPart()Ljava/lang/String;": {ParamSlots: 0,
+			GFunction: uriGetRawSchemeSpecificPart},
+		"getRawUserInfo()Ljava/lang/String;":        {ParamSlots: 0, GFunction: uriGetRawUserInfo},
+		"getScheme()Ljava/lang/String;":             {ParamSlots: 0, GFunction: uriGetScheme},
+		"getSchemeSpecificPart()Ljava/lang/String;": {ParamSlots: 0, GFunction: uriGetSchemeSpecificPart},
+		"getUserInfo()Ljava/lang/String;":           {ParamSlots: 0, GFunction: uriGetUserInfo},
+		"hashCode()I":                               {ParamSlots: 0, GFunction: uriHashCode},
+		"isAbsolute()Z":                             {ParamSlots: 0, GFunction: uriIsAbsolute},
+		"isOpaque()Z":                               {ParamSlots: 0, GFunction: uriIsOpaque},
+		"normalize()Ljava/net/URI;":                 {ParamSlots: 0, GFunction: uriNormalize},
+		"parseServerAuthority()Ljava/net/URI;":      {ParamSlots: 0, GFunction: uriParseServerAuthority},
+		"relativize(Ljava/net/URI;)Ljava/net/URI;":  {ParamSlots: 1, GFunction: uriRelativize},
+		"resolve(Ljava/lang/String;)Ljava/net/URI;": {ParamSlots: 1, GFunction: uriResolve},
+		"resolve(Ljava/net/URI;)Ljava/net/URI;":     {ParamSlots: 1, GFunction: uriResolve},
+		"toASCIIString()Ljava/lang/String;":         {ParamSlots: 0, GFunction: uriToASCIIString},
+		"toString()Ljava/lang/String;":              {ParamSlots: 0, GFunction: uriToString},
+		"toURL()Ljava/net/URL;":                     {ParamSlots: 0, GFunction: uriToURL},
+	} {
+		ghelpers.MethodSignatures[uriClassName+"."+sig] = gmeth
+	}
+}
+
+// uri is the Go state of a URI. The components are raw; those that can be undefined, as
+// opposed to empty, have a flag that says whether they are defined. host is "" for a
+// registry-based authority.
+type uri struct {
+	str       string
+	scheme    string
+	ssp       string // the raw scheme-specific part
+	authority string
+	userInfo  string
+	host      string
+	port      int // -1 if undefined
+	path      string
+	query     string
+	fragment  string
+
+	opaque, hasAuthority, hasUserInfo, hasQuery, hasFragment bool
+}
+
+// The characters that may appear unescaped in the components of a URI, besides the letters and
+// digits and the non-ASCII characters that are neither controls nor spaces.
+const (
+	uriUnreserved = "-_.!~*'()"
+	uriPunct      = ",;:$&+="
+	uriReserved   = ";/?:@&=+$,[]"
+	uriPathChars  = uriUnreserved + uriPunct + "@/"
+	uriUricChars  = uriUnreserved + uriReserved
+	uriUserChars  = uriUnreserved + ";:&=+$,"
+	uriRegChars   = uriUnreserved + "$,;:@&=+"
+)
+
+// uriSyntaxError returns a URISyntaxException with the message the JDK gives.
+func uriSyntaxError(input, reason string, index int) *ghelpers.GErrBlk {
+	if index < 0 {
+		return ghelpers.GetGErrBlk(excNames.URISyntaxException, reason+": "+input)
+	}
+	return ghelpers.GetGErrBlk(excNames.URISyntaxException, fmt.Sprintf("%s at index %d: %s", reason, index, input))
+}
+
+// isAlnum reports whether c is an ASCII letter or digit.
+func isAlnum(c rune) bool {
+	return c < utf8.RuneSelf && (unicode.IsLetter(c) || unicode.IsDigit(c))
+}
+
+// checkChars checks that s[start:end] holds only characters that are allowed by the ASCII
+// characters in allowed, and well-formed escapes. what names the component in the message.
+func checkChars(s string, start, end int, allowed, what string) *ghelpers.GErrBlk {
+	for i := start; i < end; {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == '%':
+			if i+2 >= end || !isHex(s, i+1) || !isHex(s, i+2) {
+				return uriSyntaxError(s, "Malformed escape pair", i)
+			}
+			size = 3
+		case c < utf8.RuneSelf:
+			if !isAlnum(c) && !strings.ContainsRune(allowed, c) {
+				return uriSyntaxError(s, "Illegal character in "+what, i)
+			}
+		case unicode.IsControl(c) || unicode.IsSpace(c):
+			return uriSyntaxError(s, "Illegal character in "+what, i)
+		}
+		i += size
+	}
+	return nil
+}
+
+func isHex(s string, i int) bool {
+	if i >= len(s) {
+		return false
+	}
+	c := s[i]
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// scanTo returns the index of the first of the characters in stops in s[start:], or len(s).
+func scanTo(s string, start int, stops string) int {
+	if i := strings.IndexAny(s[start:], stops); i >= 0 {
+		return start + i
+	}
+	return len(s)
+}
+
+// parseURI parses s as the JDK's URI(String) constructor does.
+func parseURI(s string) (*uri, *ghelpers.GErrBlk) {
+	u := &uri{str: s, port: -1}
+	p := 0
+	if i := scanTo(s, 0, ":/?#"); i < len(s) && s[i] == ':' {
+		if i == 0 {
+			return nil, uriSyntaxError(s, "Expected scheme name", 0)
+		}
+		if !isAlnum(rune(s[0])) || unicode.IsDigit(rune(s[0])) {
+			return nil, uriSyntaxError(s, "Illegal character in scheme name", 0)
+		}
+		for j, c := range s[:i] {
+			if !isAlnum(c) && !strings.ContainsRune("+-.", c) {
+				return nil, uriSyntaxError(s, "Illegal character in scheme name", j)
+			}
+		}
+		u.scheme = s[:i]
+		p = i + 1
+		if p == len(s) {
+			return nil, uriSyntaxError(s, "Expected scheme-specific part", p)
+		}
+		if s[p] != '/' {
+			u.opaque = true
+			q := scanTo(s, p, "#")
+			if gerr := checkChars(s, p, q, uriUricChars, "opaque part"); gerr != nil {
+				return nil, gerr
+			}
+			u.ssp = s[p:q]
+			return u, u.parseFragment(s, q)
+		}
+	}
+
+	sspEnd := scanTo(s, p, "#")
+	u.ssp = s[p:sspEnd]
+	if strings.HasPrefix(s[p:], "//") {
+		p += 2
+		q := scanTo(s, p, "/?#")
+		if q > p {
+			if gerr := u.parseAuthority(s, p, q); gerr != nil {
+				return nil, gerr
+			}
+		} else if q >= len(s) {
+			return nil, uriSyntaxError(s, "Expected authority", p)
+		}
+		p = q
+	}
+	q := scanTo(s, p, "?#")
+	if gerr := checkChars(s, p, q, uriPathChars, "path"); gerr != nil {
+		return nil, gerr
+	}
+	u.path = s[p:q]
+	p = q
+	if p < len(s) && s[p] == '?' {
+		p++
+		q = scanTo(s, p, "#")
+		if gerr := checkChars(s, p, q, uriUricChars, "query"); gerr != nil {
+			return nil, gerr
+		}
+		u.query, u.hasQuery = s[p:q], true
+		p = q
+	}
+	return u, u.parseFragment(s, p)
+}
+
+// parseFragment parses the fragment, if s[p] is a '#'.
+func (u *uri) parseFragment(s string, p int) *ghelpers.GErrBlk {
+	if p >= len(s) {
+		return nil
+	}
+	if gerr := checkChars(s, p+1, len(s), uriUricChars, "fragment"); gerr != nil {
+		return gerr
+	}
+	u.fragment, u.hasFragment = s[p+1:], true
+	return nil
+}
+
+// parseAuthority parses the authority in s[start:end]. It is server-based, with a host, if it
+// can be; otherwise it is registry-based, as long as its characters are legal in a reg_name.
+func (u *uri) parseAuthority(s string, start, end int) *ghelpers.GErrBlk {
+	u.authority, u.hasAuthority = s[start:end], true
+	serverErr := u.parseServer(s, start, end)
+	if serverErr == nil {
+		return nil
+	}
+	if strings.ContainsAny(s[start:end], "[]") {
+		return serverErr // a malformed IPv6 address is not a reg_name
+	}
+	if gerr := checkChars(s, start, end, uriRegChars, "authority"); gerr != nil {
+		return gerr
+	}
+	u.userInfo, u.hasUserInfo, u.host, u.port = "", false, "", -1
+	return nil
+}
+
+// parseServer parses s[start:end] as [userinfo@]host[:port].
+func (u *uri) parseServer(s string, start, end int) *ghelpers.GErrBlk {
+	p := start
+	if at := strings.IndexByte(s[start:end], '@'); at >= 0 {
+		if gerr := checkChars(s, start, start+at, uriUserChars, "user info"); gerr != nil {
+			return gerr
+		}
+		u.userInfo, u.hasUserInfo = s[start:start+at], true
+		p = start + at + 1
+	}
+
+	var hostEnd int
+	if p < end && s[p] == '[' {
+		closing := strings.IndexByte(s[p:end], ']')
+		if closing < 0 {
+			return uriSyntaxError(s, "Expected closing bracket for IPv6 address", end)
+		}
+		hostEnd = p + closing + 1
+		literal := s[p+1 : hostEnd-1]
+		if zone := strings.IndexByte(literal, '%'); zone >= 0 {
+			literal = literal[:zone]
+		}
+		if ip := net.ParseIP(literal); ip == nil || !strings.Contains(literal, ":") {
+			return uriSyntaxError(s, "Malformed IPv6 address", p+1)
+		}
+	} else {
+		hostEnd = scanTo(s[:end], p, ":")
+		if !isHostname(s[p:hostEnd]) && parseIPv4Literal(s[p:hostEnd]) == nil {
+			return uriSyntaxError(s, "Illegal character in hostname", p)
+		}
+	}
+	u.host = s[p:hostEnd]
+
+	if hostEnd < end {
+		if s[hostEnd] != ':' {
+			return uriSyntaxError(s, "Illegal character in authority", hostEnd)
+		}
+		portStr := s[hostEnd+1 : end]
+		if portStr != "" {
+			port, err := strconv.Atoi(portStr)
+			if err != nil || strings.ContainsAny(portStr, "+-") {
+				return uriSyntaxError(s, "Illegal character in port number", hostEnd+1)
+			}
+			u.port = port
+		}
+	}
+	return nil
+}
+
+// isHostname reports whether host is a domain name of RFC 2396: dot-separated labels of
+// letters, digits and inner hyphens, the last of which starts with a letter.
+func isHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" {
+		return false
+	}
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !isAlnum(c) && c != '-' {
+				return false
+			}
+		}
+	}
+	top := labels[len(labels)-1]
+	return !unicode.IsDigit(rune(top[0]))
+}
+
+// decode returns s with its escapes decoded as UTF-8; malformed sequences become U+FFFD.
+func decode(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && isHex(s, i+1) && isHex(s, i+2) {
+			v, _ := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			b = append(b, byte(v))
+			i += 2
+			continue
+		}
+		b = append(b, s[i])
+	}
+	return strings.ToValidUTF8(string(b), "�")
+}
+
+// quote escapes the characters of s that are not allowed by the ASCII characters in allowed,
+// as the JDK's multi-argument URI constructors do. A '%' is always escaped.
+func quote(s, allowed string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		switch {
+		case c < utf8.RuneSelf && (isAlnum(c) || c != '%' && strings.ContainsRune(allowed, c)):
+			sb.WriteRune(c)
+		case c >= utf8.RuneSelf && !unicode.IsControl(c) && !unicode.IsSpace(c):
+			sb.WriteRune(c)
+		default:
+			for _, b := range []byte(string(c)) {
+				fmt.Fprintf(&sb, "%%%02X", b)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// build returns the string form of u, made from its components.
+func (u *uri) build() string {
+	var sb strings.Builder
+	if u.scheme != "" {
+		sb.WriteString(u.scheme + ":")
+	}
+	if u.opaque {
+		sb.WriteString(u.ssp)
+	} else {
+		if u.hasAuthority {
+			sb.WriteString("//" + u.authority)
+		}
+		sb.WriteString(u.path)
+		if u.hasQuery {
+			sb.WriteString("?" + u.query)
+		}
+	}
+	if u.hasFragment {
+		sb.WriteString("#" + u.fragment)
+	}
+	return sb.String()
+}
+
+// rebuilt returns u after its string form and scheme-specific part have been rebuilt from
+// its components.
+func (u *uri) rebuilt() *uri {
+	u.str = u.build()
+	if !u.opaque {
+		start, end := 0, len(u.str)
+		if u.scheme != "" {
+			start = len(u.scheme) + 1
+		}
+		if u.hasFragment {
+			end -= len(u.fragment) + 1
+		}
+		u.ssp = u.str[start:end]
+	}
+	return u
+}
+
+// newURIObject returns a URI object with the state u.
+func newURIObject(u *uri) *object.Object {
+	className := uriClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: u}
+	return obj
+}
+
+// getURI returns the Go state of the URI obj.
+func getURI(obj any) (*uri, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "URI is null")
+	}
+	u, ok := o.FieldTable[netStateField].Fvalue.(*uri)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a URI")
+	}
+	return u, nil
+}
+
+// optString returns the Go string of a String argument, and false if it is null.
+func optString(arg any) (string, bool) {
+	if obj, ok := arg.(*object.Object); ok && !object.IsNull(obj) {
+		return object.GoStringFromStringObject(obj), true
+	}
+	return "", false
+}
+
+// optStringObject returns str as a String, or null if it is undefined.
+func optStringObject(str string, defined bool) interface{} {
+	if !defined {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(str)
+}
+
+// setURI parses s and stores the result as the state of the URI obj.
+func setURI(obj any, s string) interface{} {
+	u, gerr := parseURI(s)
+	if gerr != nil {
+		return gerr
+	}
+	obj.(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: u}
+	return nil
+}
+
+// java/net/URI.<init>(Ljava/lang/String;)V
+func uriInit(params []interface{}) interface{} {
+	s, ok := optString(params[1])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "URI string is null")
+	}
+	return setURI(params[0], s)
+}
+
+// java/net/URI.create(Ljava/lang/String;)Ljava/net/URI; -- as URI(String), but a syntax error
+// is an IllegalArgumentException
+func uriCreate(params []interface{}) interface{} {
+	s, ok := optString(params[0])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "URI string is null")
+	}
+	u, gerr := parseURI(s)
+	if gerr != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, gerr.ErrMsg)
+	}
+	return newURIObject(u)
+}
+
+// java/net/URI.<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V -- scheme,
+// scheme-specific part and fragment
+func uriInitOpaque(params []interface{}) interface{} {
+	var sb strings.Builder
+	if scheme, ok := optString(params[1]); ok {
+		sb.WriteString(scheme + ":")
+	}
+	if ssp, ok := optString(params[2]); ok {
+		sb.WriteString(quote(ssp, uriUricChars))
+	}
+	if fragment, ok := optString(params[3]); ok {
+		sb.WriteString("#" + quote(fragment, uriUricChars))
+	}
+	return setURI(params[0], sb.String())
+}
+
+// java/net/URI.<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V
+// -- scheme, host, path and fragment
+func uriInitHostPath(params []interface{}) interface{} {
+	return uriInitServer([]interface{}{params[0], params[1], object.Null, params[2], int64(-1), params[3],
+		object.Null, params[4]})
+}
+
+// java/net/URI.<init> with scheme, user info, host, port, path, query and fragment
+func uriInitServer(params []interface{}) interface{} {
+	var authority strings.Builder
+	userInfo, hasUserInfo := optString(params[2])
+	host, hasHost := optString(params[3])
+	port := params[4].(int64)
+	if hasUserInfo {
+		authority.WriteString(quote(userInfo, uriUserChars) + "@")
+	}
+	if hasHost {
+		if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+			host = "[" + host + "]"
+		}
+		authority.WriteString(host)
+	}
+	if port != -1 {
+		authority.WriteString(":" + strconv.FormatInt(port, 10))
+	}
+	hasAuthority := hasUserInfo || hasHost || port != -1
+	return buildHierarchical(params[0], params[1], authority.String(), hasAuthority, params[5], params[6], params[7])
+}
+
+// java/net/URI.<init> with scheme, authority, path, query and fragment
+func uriInitAuthority(params []interface{}) interface{} {
+	authority, hasAuthority := optString(params[2])
+	return buildHierarchical(params[0], params[1], quote(authority, uriRegChars+"[]"), hasAuthority,
+		params[3], params[4], params[5])
+}
+
+// buildHierarchical builds the string of a hierarchical URI from its components, quoting them,
+// and parses it into the URI obj.
+func buildHierarchical(obj, schemeArg any, authority string, hasAuthority bool, pathArg, queryArg, fragmentArg any) interface{} {
+	var sb strings.Builder
+	scheme, hasScheme := optString(schemeArg)
+	path, _ := optString(pathArg)
+	if hasScheme {
+		sb.WriteString(scheme + ":")
+	}
+	if hasAuthority {
+		sb.WriteString("//" + authority)
+	}
+	sb.WriteString(quote(path, uriPathChars))
+	if query, ok := optString(queryArg); ok {
+		sb.WriteString("?" + quote(query, uriUricChars))
+	}
+	if fragment, ok := optString(fragmentArg); ok {
+		sb.WriteString("#" + quote(fragment, uriUricChars))
+	}
+	if hasScheme && path != "" && !strings.HasPrefix(path, "/") {
+		return uriSyntaxError(sb.String(), "Relative path in absolute URI", -1)
+	}
+	return setURI(obj, sb.String())
+}
+
+// uriState runs get on the state of the URI in params[0].
+func uriState(params []interface{}, get func(u *uri) interface{}) interface{} {
+	u, gerr := getURI(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(u)
+}
+
+func uriGetScheme(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.scheme, u.scheme != "") })
+}
+
+func uriGetRawSchemeSpecificPart(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return object.StringObjectFromGoString(u.ssp) })
+}
+
+func uriGetSchemeSpecificPart(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return object.StringObjectFromGoString(decode(u.ssp)) })
+}
+
+func uriGetRawAuthority(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.authority, u.hasAuthority) })
+}
+
+func uriGetAuthority(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(decode(u.authority), u.hasAuthority) })
+}
+
+func uriGetRawUserInfo(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.userInfo, u.hasUserInfo) })
+}
+
+func uriGetUserInfo(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(decode(u.userInfo), u.hasUserInfo) })
+}
+
+// java/net/URI.getHost()Ljava/lang/String; -- null for a registry-based authority; an IPv6
+// address is in brackets, as in the JDK
+func uriGetHost(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.host, u.host != "") })
+}
+
+func uriGetPort(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return int64(u.port) })
+}
+
+// java/net/URI.getRawPath()Ljava/lang/String; -- null for an opaque URI
+func uriGetRawPath(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.path, !u.opaque) })
+}
+
+func uriGetPath(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(decode(u.path), !u.opaque) })
+}
+
+func uriGetRawQuery(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.query, u.hasQuery) })
+}
+
+func uriGetQuery(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(decode(u.query), u.hasQuery) })
+}
+
+func uriGetRawFragment(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(u.fragment, u.hasFragment) })
+}
+
+func uriGetFragment(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return optStringObject(decode(u.fragment), u.hasFragment) })
+}
+
+func uriIsAbsolute(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return types.ConvertGoBoolToJavaBool(u.scheme != "") })
+}
+
+func uriIsOpaque(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return types.ConvertGoBoolToJavaBool(u.opaque) })
+}
+
+func uriToString(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} { return object.StringObjectFromGoString(u.str) })
+}
+
+// java/net/URI.toASCIIString()Ljava/lang/String; -- the string with its non-ASCII characters
+// escaped as UTF-8
+func uriToASCIIString(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} {
+		var sb strings.Builder
+		for _, c := range u.str {
+			if c < utf8.RuneSelf {
+				sb.WriteRune(c)
+				continue
+			}
+			for _, b := range []byte(string(c)) {
+				fmt.Fprintf(&sb, "%%%02X", b)
+			}
+		}
+		return object.StringObjectFromGoString(sb.String())
+	})
+}
+
+// java/net/URI.parseServerAuthority()Ljava/net/URI; -- a URISyntaxException if the authority
+// is not server-based
+func uriParseServerAuthority(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} {
+		if u.hasAuthority && u.host == "" {
+			start := len(u.scheme) + 3
+			if u.scheme == "" {
+				start = 2
+			}
+			var probe uri
+			if gerr := probe.parseServer(u.str, start, start+len(u.authority)); gerr != nil {
+				return gerr
+			}
+		}
+		return params[0]
+	})
+}
+
+// normalizePath removes the "." segments of a path and the ".." segments that follow a
+// segment that is not "..", as the JDK's URI.normalize() does.
+func normalizePath(path string) string {
+	if path == "" {
+		return path
+	}
+	absolute := strings.HasPrefix(path, "/")
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	trailing := false
+	var out []string
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		switch {
+		case seg == "." || (seg == "" && !last):
+			trailing = last
+		case seg == "..":
+			if len(out) > 0 && out[len(out)-1] != ".." {
+				out = out[:len(out)-1]
+				trailing = last
+			} else if absolute {
+				out = append(out, seg) // the JDK keeps a ".." that cannot be removed
+				trailing = false
+			} else {
+				out = append(out, seg)
+				trailing = false
+			}
+		default:
+			out = append(out, seg)
+			trailing = false
+		}
+	}
+	result := strings.Join(out, "/")
+	if trailing && result != "" {
+		result += "/"
+	}
+	if absolute {
+		result = "/" + result
+	} else if len(out) > 0 && strings.Contains(out[0], ":") {
+		result = "./" + result // so that the first segment is not taken for a scheme
+	}
+	return result
+}
+
+// java/net/URI.normalize()Ljava/net/URI; -- the URI itself if its path is already normal
+func uriNormalize(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} {
+		if u.opaque {
+			return params[0]
+		}
+		path := normalizePath(u.path)
+		if path == u.path {
+			return params[0]
+		}
+		v := *u
+		v.path = path
+		return newURIObject(v.rebuilt())
+	})
+}
+
+// resolveURI resolves child against base by the rules of RFC 2396, section 5.2, as the JDK
+// does. It returns nil if the result is child itself.
+func resolveURI(base, child *uri) *uri {
+	if child.opaque || base.opaque {
+		return nil
+	}
+	// a reference to a fragment of the base
+	if child.scheme == "" && !child.hasAuthority && child.path == "" && !child.hasQuery && child.hasFragment {
+		if base.hasFragment && base.fragment == child.fragment {
+			return base
+		}
+		v := *base
+		v.fragment, v.hasFragment = child.fragment, true
+		return v.rebuilt()
+	}
+	if child.scheme != "" {
+		return nil
+	}
+
+	v := *child
+	v.scheme = base.scheme
+	if !child.hasAuthority {
+		v.authority, v.hasAuthority = base.authority, base.hasAuthority
+		v.userInfo, v.hasUserInfo, v.host, v.port = base.userInfo, base.hasUserInfo, base.host, base.port
+		if !strings.HasPrefix(child.path, "/") {
+			dir := ""
+			if i := strings.LastIndexByte(base.path, '/'); i >= 0 {
+				dir = base.path[:i+1]
+			}
+			v.path = normalizePath(dir + child.path)
+		}
+	}
+	return v.rebuilt()
+}
+
+// java/net/URI.resolve(Ljava/net/URI;)Ljava/net/URI; and resolve(Ljava/lang/String;)Ljava/net/URI;
+func uriResolve(params []interface{}) interface{} {
+	base, gerr := getURI(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	childObj := params[1]
+	if s, ok := params[1].(*object.Object); ok && !object.IsNull(s) && object.IsStringObject(s) {
+		u, gerr := parseURI(object.GoStringFromStringObject(s))
+		if gerr != nil {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, gerr.ErrMsg)
+		}
+		childObj = newURIObject(u)
+	}
+	child, gerr := getURI(childObj)
+	if gerr != nil {
+		return gerr
+	}
+	if v := resolveURI(base, child); v != nil {
+		if v == base {
+			return params[0]
+		}
+		return newURIObject(v)
+	}
+	return childObj
+}
+
+// java/net/URI.relativize(Ljava/net/URI;)Ljava/net/URI; -- the child relative to this URI, or
+// the child itself if it is not below this URI
+func uriRelativize(params []interface{}) interface{} {
+	base, gerr := getURI(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	child, gerr := getURI(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if base.opaque || child.opaque || !strings.EqualFold(base.scheme, child.scheme) ||
+		base.authority != child.authority || base.hasAuthority != child.hasAuthority {
+		return params[1]
+	}
+	bp, cp := normalizePath(base.path), normalizePath(child.path)
+	if bp != cp {
+		if !strings.HasSuffix(bp, "/") {
+			bp += "/"
+		}
+		if !strings.HasPrefix(cp, bp) {
+			return params[1]
+		}
+	}
+	v := &uri{port: -1, path: cp[min(len(bp), len(cp)):], query: child.query, hasQuery: child.hasQuery,
+		fragment: child.fragment, hasFragment: child.hasFragment}
+	return newURIObject(v.rebuilt())
+}
+
+// java/net/URI.toURL()Ljava/net/URL;
+func uriToURL(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} {
+		if u.scheme == "" {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "URI is not absolute")
+		}
+		url, gerr := parseURL(u.str, nil)
+		if gerr != nil {
+			return gerr
+		}
+		return newURLObject(url)
+	})
+}
+
+// upperEscapes returns s with the hex digits of its escapes in upper case, since escapes are
+// compared without regard to case.
+func upperEscapes(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	b := []byte(s)
+	for i := 0; i < len(b); i++ {
+		if b[i] == '%' && i+2 < len(b) {
+			b[i+1], b[i+2] = byte(unicode.ToUpper(rune(b[i+1]))), byte(unicode.ToUpper(rune(b[i+2])))
+			i += 2
+		}
+	}
+	return string(b)
+}
+
+// equalURIs compares URIs as the JDK's URI.equals() does.
+func equalURIs(a, b *uri) bool {
+	if a.opaque != b.opaque || !strings.EqualFold(a.scheme, b.scheme) || a.hasFragment != b.hasFragment ||
+		upperEscapes(a.fragment) != upperEscapes(b.fragment) {
+		return false
+	}
+	if a.opaque {
+		return upperEscapes(a.ssp) == upperEscapes(b.ssp)
+	}
+	if upperEscapes(a.path) != upperEscapes(b.path) || a.hasQuery != b.hasQuery ||
+		upperEscapes(a.query) != upperEscapes(b.query) || a.hasAuthority != b.hasAuthority {
+		return false
+	}
+	if a.host != "" {
+		return a.hasUserInfo == b.hasUserInfo && upperEscapes(a.userInfo) == upperEscapes(b.userInfo) &&
+			strings.EqualFold(a.host, b.host) && a.port == b.port
+	}
+	return upperEscapes(a.authority) == upperEscapes(b.authority)
+}
+
+func uriEquals(params []interface{}) interface{} {
+	a, gerr := getURI(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return types.JavaBoolFalse
+	}
+	b, ok := other.FieldTable[netStateField].Fvalue.(*uri)
+	if !ok {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(equalURIs(a, b))
+}
+
+// java/net/URI.hashCode()I -- the JDK's hash, which agrees with equals()
+func uriHashCode(params []interface{}) interface{} {
+	return uriState(params, func(u *uri) interface{} {
+		h := hashIgnoringCase(0, u.scheme, u.scheme != "")
+		h = hashEscaped(h, u.fragment, u.hasFragment)
+		if u.opaque {
+			h = hashEscaped(h, u.ssp, true)
+		} else {
+			h = hashEscaped(h, u.path, true)
+			h = hashEscaped(h, u.query, u.hasQuery)
+			if u.host != "" {
+				h = hashEscaped(h, u.userInfo, u.hasUserInfo)
+				h = hashIgnoringCase(h, u.host, true)
+				h += 1949 * int32(u.port)
+			} else {
+				h = hashEscaped(h, u.authority, u.hasAuthority)
+			}
+		}
+		return int64(h)
+	})
+}
+
+func hashIgnoringCase(h int32, s string, defined bool) int32 {
+	if !defined {
+		return h
+	}
+	for _, c := range utf16.Encode([]rune(s)) {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		h = 31*h + int32(c)
+	}
+	return h
+}
+
+func hashEscaped(h int32, s string, defined bool) int32 {
+	if !defined {
+		return h
+	}
+	return h*127 + javaStringHashCode(upperEscapes(s))
+}
@@ -0,0 +1,187 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newTestURI(t *testing.T, s string) *object.Object {
+	t.Helper()
+	obj := object.MakeEmptyObjectWithClassName(new(uriClassName))
+	if ret := uriInit([]interface{}{obj, object.StringObjectFromGoString(s)}); ret != nil {
+		t.Fatalf("URI(%q): %v", s, ret)
+	}
+	return obj
+}
+
+func newTestURL(t *testing.T, s string) *object.Object {
+	t.Helper()
+	obj := object.MakeEmptyObjectWithClassName(new(urlClassName))
+	if ret := urlInit([]interface{}{obj, object.StringObjectFromGoString(s)}); ret != nil {
+		t.Fatalf("URL(%q): %v", s, ret)
+	}
+	return obj
+}
+
+func TestURI_Components(t *testing.T) {
+	globals.InitStringPool()
+	u := newTestURI(t, "http://user%20x@Example.COM:8080/a%2Fb/c?q=1%262#frag%21")
+
+	checks := []struct {
+		name string
+		get  func([]interface{}) interface{}
+		want string
+	}{
+		{"getScheme", uriGetScheme, "http"},
+		{"getRawAuthority", uriGetRawAuthority, "user%20x@Example.COM:8080"},
+		{"getUserInfo", uriGetUserInfo, "user x"},
+		{"getHost", uriGetHost, "Example.COM"},
+		{"getRawPath", uriGetRawPath, "/a%2Fb/c"},
+		{"getPath", uriGetPath, "/a/b/c"},
+		{"getRawQuery", uriGetRawQuery, "q=1%262"},
+		{"getQuery", uriGetQuery, "q=1&2"},
+		{"getFragment", uriGetFragment, "frag!"},
+	}
+	for _, c := range checks {
+		if got := netTestGoString(t, c.get([]interface{}{u})); got != c.want {
+			t.Errorf("%s: expected %q, got %q", c.name, c.want, got)
+		}
+	}
+	if port := uriGetPort([]interface{}{u}); port != int64(8080) {
+		t.Errorf("getPort: expected 8080, got %v", port)
+	}
+	if uriIsAbsolute([]interface{}{u}) != types.JavaBoolTrue || uriIsOpaque([]interface{}{u}) != types.JavaBoolFalse {
+		t.Error("expected an absolute, hierarchical URI")
+	}
+
+	opaque := newTestURI(t, "mailto:someone@example.com")
+	if uriIsOpaque([]interface{}{opaque}) != types.JavaBoolTrue {
+		t.Error("expected mailto: URI to be opaque")
+	}
+	if got := netTestGoString(t, uriGetSchemeSpecificPart([]interface{}{opaque})); got != "someone@example.com" {
+		t.Errorf("getSchemeSpecificPart: got %q", got)
+	}
+	if uriGetPath([]interface{}{opaque}) != object.Null {
+		t.Error("expected an opaque URI to have no path")
+	}
+}
+
+func TestURI_SyntaxErrors(t *testing.T) {
+	globals.InitStringPool()
+	cases := []struct{ input, msg string }{
+		{"http://host/a b", "Illegal character in path at index 13: http://host/a b"},
+		{":foo", "Expected scheme name at index 0: :foo"},
+		{"http://ho st/", "Illegal character in authority at index 9: http://ho st/"},
+		{"http://[::1/", "Expected closing bracket for IPv6 address at index 11: http://[::1/"},
+		{"a%2", "Malformed escape pair at index 1: a%2"},
+	}
+	for _, c := range cases {
+		obj := object.MakeEmptyObjectWithClassName(new(uriClassName))
+		testutil.ExpectGErr(t, uriInit([]interface{}{obj, object.StringObjectFromGoString(c.input)}), excNames.URISyntaxException, c.msg)
+	}
+	testutil.ExpectGErr(t, uriCreate([]interface{}{object.StringObjectFromGoString("a b:")}),
+		excNames.IllegalArgumentException, "Illegal character")
+}
+
+func TestURI_ResolveNormalizeRelativize(t *testing.T) {
+	globals.InitStringPool()
+	base := newTestURI(t, "http://a/b/c/d;p?q")
+	cases := map[string]string{
+		"g":          "http://a/b/c/g",
+		"./g":        "http://a/b/c/g",
+		"g/":         "http://a/b/c/g/",
+		"/g":         "http://a/g",
+		"//g":        "http://g",
+		"?y":         "http://a/b/c/?y",
+		"g?y":        "http://a/b/c/g?y",
+		"#s":         "http://a/b/c/d;p?q#s",
+		"../g":       "http://a/b/g",
+		"../../g":    "http://a/g",
+		"g;x=1/../y": "http://a/b/c/y",
+	}
+	for ref, want := range cases {
+		got := netTestGoString(t, uriToString([]interface{}{uriResolve([]interface{}{base, object.StringObjectFromGoString(ref)})}))
+		if got != want {
+			t.Errorf("resolve(%q): expected %q, got %q", ref, want, got)
+		}
+	}
+
+	norm := uriNormalize([]interface{}{newTestURI(t, "http://h/a/./b/../c/")})
+	if got := netTestGoString(t, uriToString([]interface{}{norm})); got != "http://h/a/c/" {
+		t.Errorf("normalize: got %q", got)
+	}
+
+	rel := uriRelativize([]interface{}{newTestURI(t, "http://h/a/"), newTestURI(t, "http://h/a/b/c?x")})
+	if got := netTestGoString(t, uriToString([]interface{}{rel})); got != "b/c?x" {
+		t.Errorf("relativize: got %q", got)
+	}
+}
+
+func TestURI_EqualsAndHashCode(t *testing.T) {
+	globals.InitStringPool()
+	a := newTestURI(t, "HTTP://Example.com/%7e")
+	b := newTestURI(t, "http://EXAMPLE.com/%7E")
+	if uriEquals([]interface{}{a, b}) != types.JavaBoolTrue {
+		t.Fatal("expected URIs differing in scheme case, host case and escape case to be equal")
+	}
+	if uriHashCode([]interface{}{a}) != uriHashCode([]interface{}{b}) {
+		t.Fatal("expected equal URIs to have equal hash codes")
+	}
+	if uriEquals([]interface{}{a, newTestURI(t, "http://example.com/~")}) != types.JavaBoolFalse {
+		t.Fatal("expected an escaped and an unescaped character to differ")
+	}
+}
+
+func TestURL_Parsing(t *testing.T) {
+	globals.InitStringPool()
+	u := newTestURL(t, "https://www.example.com/docs/index.html?name=net#top")
+	checks := []struct {
+		name string
+		get  func([]interface{}) interface{}
+		want string
+	}{
+		{"getProtocol", urlGetProtocol, "https"},
+		{"getHost", urlGetHost, "www.example.com"},
+		{"getFile", urlGetFile, "/docs/index.html?name=net"},
+		{"getPath", urlGetPath, "/docs/index.html"},
+		{"getQuery", urlGetQuery, "name=net"},
+		{"getRef", urlGetRef, "top"},
+	}
+	for _, c := range checks {
+		if got := netTestGoString(t, c.get([]interface{}{u})); got != c.want {
+			t.Errorf("%s: expected %q, got %q", c.name, c.want, got)
+		}
+	}
+	if port := urlGetPort([]interface{}{u}); port != int64(-1) {
+		t.Errorf("getPort: expected -1, got %v", port)
+	}
+	if port := urlGetDefaultPort([]interface{}{u}); port != int64(443) {
+		t.Errorf("getDefaultPort: expected 443, got %v", port)
+	}
+
+	rel := object.MakeEmptyObjectWithClassName(new(urlClassName))
+	if ret := urlInitContext([]interface{}{rel, u, object.StringObjectFromGoString("../img/a.png")}); ret != nil {
+		t.Fatalf("URL(URL, String): %v", ret)
+	}
+	if got := netTestGoString(t, urlToString([]interface{}{rel})); got != "https://www.example.com/img/a.png" {
+		t.Errorf("relative URL: got %q", got)
+	}
+
+	bad := object.MakeEmptyObjectWithClassName(new(urlClassName))
+	testutil.ExpectGErr(t, urlInit([]interface{}{bad, object.StringObjectFromGoString("example.com/x")}),
+		excNames.MalformedURLException, "no protocol: example.com/x")
+	testutil.ExpectGErr(t, urlInit([]interface{}{bad, object.StringObjectFromGoString("gopher://x/")}),
+		excNames.MalformedURLException, "unknown protocol: gopher")
+	testutil.ExpectGErr(t, urlInit([]interface{}{bad, object.StringObjectFromGoString("http://x:y/")}),
+		excNames.MalformedURLException, "Invalid port number :y")
+}
@@ -0,0 +1,438 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// java.net.URL, parsed as the JDK's URLStreamHandler parses it: more leniently than a URI, so
+// that, for instance, a space is accepted. Only http and https URLs can be opened; their
+// connections are HttpURLConnections.
+
+const urlClassName = "java/net/URL"
+
+func Load_Net_URL() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                               {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>(Ljava/lang/String;)V":               {ParamSlots: 1, GFunction: urlInit},
+		"<init>(Ljava/net/URL;Ljava/lang/String;)V": {ParamSlots: 2, GFunction: urlInitContext},
+		"<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V": {ParamSlots: 3,
+			GFunction: urlInitParts},
+		"<init>(Ljava/lang/String;Ljava/lang/String;ILjava/lang/String;)V": {ParamSlots: 4,
+			GFunction: urlInitParts},
+		"equals(Ljava/lang/Object;)Z":              {ParamSlots: 1, GFunction: urlEquals},
+		"getAuthority()Ljava/lang/String;":         {ParamSlots: 0, GFunction: urlGetAuthority},
+		"getDefaultPort()I":                        {ParamSlots: 0, GFunction: urlGetDefaultPort},
+		"getFile()Ljava/lang/String;":              {ParamSlots: 0, GFunction: urlGetFile},
+		"getHost()Ljava/lang/String;":              {ParamSlots: 0, GFunction: urlGetHost},
+		"getPath()Ljava/lang/String;":              {ParamSlots: 0, GFunction: urlGetPath},
+		"getPort()I":                               {ParamSlots: 0, GFunction: urlGetPort},
+		"getProtocol()Ljava/lang/String;":          {ParamSlots: 0, GFunction: urlGetProtocol},
+		"getQuery()Ljava/lang/String;":             {ParamSlots: 0, GFunction: urlGetQuery},
+		"getRef()Ljava/lang/String;":               {ParamSlots: 0, GFunction: urlGetRef},
+		"getUserInfo()Ljava/lang/String;":          {ParamSlots: 0, GFunction: urlGetUserInfo},
+		"hashCode()I":                              {ParamSlots: 0, GFunction: urlHashCode},
+		"openConnection()Ljava/net/URLConnection;": {ParamSlots: 0, GFunction: urlOpenConnection},
+		"openStream()Ljava/io/InputStream;":        {ParamSlots: 0, GFunction: urlOpenStream, NeedsContext: true},
+		"sameFile(Ljava/net/URL;)Z":                {ParamSlots: 1, GFunction: urlSameFile},
+		"toExternalForm()Ljava/lang/String;":       {ParamSlots: 0, GFunction: urlToString},
+		"toString()Ljava/lang/String;":             {ParamSlots: 0, GFunction: urlToString},
+		"toURI()Ljava/net/URI;":                    {ParamSlots: 0, GFunction: urlToURI},
+	} {
+		ghelpers.MethodSignatures[urlClassName+"."+sig] = gmeth
+	}
+}
+
+// urlDefaultPorts holds the protocols that a URL may have, and their default ports.
+var urlDefaultPorts = map[string]int{
+	"file":   -1,
+	"ftp":    21,
+	"http":   80,
+	"https":  443,
+	"jar":    -1,
+	"jrt":    -1,
+	"mailto": -1,
+}
+
+// url is the Go state of a URL. The components are as given, not decoded.
+type url struct {
+	protocol  string
+	authority string
+	userInfo  string
+	host      string
+	port      int // -1 if none was given
+	path      string
+	query     string
+	ref       string
+
+	hasAuthority, hasQuery, hasRef bool
+}
+
+func malformedURL(msg string) *ghelpers.GErrBlk {
+	return ghelpers.GetGErrBlk(excNames.MalformedURLException, msg)
+}
+
+// parseURL parses spec, relative to context if that is not nil, as URL(URL, String) does.
+func parseURL(spec string, context *url) (*url, *ghelpers.GErrBlk) {
+	original := spec
+	spec = strings.TrimFunc(spec, func(r rune) bool { return r <= ' ' })
+	if len(spec) >= 4 && strings.EqualFold(spec[:4], "url:") {
+		spec = spec[4:]
+	}
+
+	u := &url{port: -1}
+	rest := spec
+	if i := scanTo(spec, 0, ":/?#"); i < len(spec) && spec[i] == ':' && i > 0 && isScheme(spec[:i]) {
+		u.protocol = strings.ToLower(spec[:i])
+		rest = spec[i+1:]
+	}
+	if context != nil && (u.protocol == "" || u.protocol == context.protocol && !strings.HasPrefix(rest, "/")) {
+		// a spec relative to the context, which supplies what the spec lacks
+		inherited := *context
+		inherited.ref, inherited.hasRef = "", false
+		u = &inherited
+	} else if u.protocol == "" {
+		return nil, malformedURL("no protocol: " + original)
+	}
+	if _, ok := urlDefaultPorts[u.protocol]; !ok {
+		return nil, malformedURL("unknown protocol: " + u.protocol)
+	}
+
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		u.ref, u.hasRef = rest[i+1:], true
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		u.query, u.hasQuery = rest[i+1:], true
+		rest = rest[:i]
+	} else if rest != "" {
+		u.query, u.hasQuery = "", false // a new path has the query of the spec, if any
+	}
+	if strings.HasPrefix(rest, "//") {
+		end := scanTo(rest, 2, "/")
+		if gerr := u.setAuthority(rest[2:end]); gerr != nil {
+			return nil, gerr
+		}
+		u.path = rest[end:]
+	} else if rest != "" {
+		if strings.HasPrefix(rest, "/") || u.path == "" && !u.hasAuthority {
+			u.path = rest
+		} else {
+			dir := ""
+			if i := strings.LastIndexByte(u.path, '/'); i >= 0 {
+				dir = u.path[:i+1]
+			} else if u.hasAuthority {
+				dir = "/"
+			}
+			u.path = normalizePath(dir + rest)
+		}
+	}
+	return u, nil
+}
+
+// isScheme reports whether s is a well-formed scheme: a letter, then letters, digits, '+',
+// '-' and '.'.
+func isScheme(s string) bool {
+	for i, c := range s {
+		if i == 0 && (!isAlnum(c) || unicode.IsDigit(c)) || !isAlnum(c) && !strings.ContainsRune("+-.", c) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// setAuthority sets the authority of u and the user info, host and port in it.
+func (u *url) setAuthority(authority string) *ghelpers.GErrBlk {
+	u.authority, u.hasAuthority = authority, true
+	u.userInfo, u.host, u.port = "", authority, -1
+	if i := strings.LastIndexByte(authority, '@'); i >= 0 {
+		u.userInfo, u.host = authority[:i], authority[i+1:]
+	}
+	portStr := ""
+	if strings.HasPrefix(u.host, "[") {
+		end := strings.IndexByte(u.host, ']')
+		if end < 0 {
+			return malformedURL("Invalid host: " + u.host)
+		}
+		if rest := u.host[end+1:]; rest != "" {
+			if rest[0] != ':' {
+				return malformedURL("Invalid authority field: " + authority)
+			}
+			portStr = rest[1:]
+		}
+		u.host = u.host[:end+1]
+	} else if i := strings.IndexByte(u.host, ':'); i >= 0 {
+		u.host, portStr = u.host[:i], u.host[i+1:]
+	}
+	if portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 0 || strings.ContainsAny(portStr, "+-") {
+			return malformedURL("Invalid port number :" + portStr)
+		}
+		u.port = port
+	}
+	return nil
+}
+
+// file returns the path and the query, as URL.getFile() does.
+func (u *url) file() string {
+	if u.hasQuery {
+		return u.path + "?" + u.query
+	}
+	return u.path
+}
+
+// String returns the external form of u.
+func (u *url) String() string {
+	var sb strings.Builder
+	sb.WriteString(u.protocol + ":")
+	if u.authority != "" {
+		sb.WriteString("//" + u.authority)
+	}
+	sb.WriteString(u.file())
+	if u.hasRef {
+		sb.WriteString("#" + u.ref)
+	}
+	return sb.String()
+}
+
+// newURLObject returns a URL object with the state u.
+func newURLObject(u *url) *object.Object {
+	className := urlClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: u}
+	return obj
+}
+
+// getURL returns the Go state of the URL obj.
+func getURL(obj any) (*url, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "URL is null")
+	}
+	u, ok := o.FieldTable[netStateField].Fvalue.(*url)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a URL")
+	}
+	return u, nil
+}
+
+// java/net/URL.<init>(Ljava/lang/String;)V
+func urlInit(params []interface{}) interface{} {
+	return urlInitContext([]interface{}{params[0], object.Null, params[1]})
+}
+
+// java/net/URL.<init>(Ljava/net/URL;Ljava/lang/String;)V -- a spec relative to a context URL
+func urlInitContext(params []interface{}) interface{} {
+	spec, ok := optString(params[2])
+	if !ok {
+		return malformedURL("Cannot invoke \"String.length()\" because \"spec\" is null")
+	}
+	var context *url
+	if obj, ok := params[1].(*object.Object); ok && !object.IsNull(obj) {
+		c, gerr := getURL(obj)
+		if gerr != nil {
+			return gerr
+		}
+		context = c
+	}
+	u, gerr := parseURL(spec, context)
+	if gerr != nil {
+		return gerr
+	}
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: u}
+	return nil
+}
+
+// java/net/URL.<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V and
+// <init>(Ljava/lang/String;Ljava/lang/String;ILjava/lang/String;)V -- protocol, host, an
+// optional port, and file
+func urlInitParts(params []interface{}) interface{} {
+	protocol, _ := optString(params[1])
+	host, _ := optString(params[2])
+	port := int64(-1)
+	fileArg := params[3]
+	if len(params) > 4 {
+		port, fileArg = params[3].(int64), params[4]
+	}
+	file, _ := optString(fileArg)
+
+	protocol = strings.ToLower(protocol)
+	if _, ok := urlDefaultPorts[protocol]; !ok {
+		return malformedURL("unknown protocol: " + protocol)
+	}
+	if port < -1 || port > 0xffff {
+		return malformedURL(fmt.Sprintf("Invalid port number :%d", port))
+	}
+	if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+		host = "[" + host + "]"
+	}
+	u := &url{protocol: protocol, host: host, port: int(port), authority: host, hasAuthority: host != ""}
+	if port != -1 {
+		u.authority += ":" + strconv.FormatInt(port, 10)
+	}
+	if i := strings.IndexByte(file, '#'); i >= 0 {
+		u.ref, u.hasRef = file[i+1:], true
+		file = file[:i]
+	}
+	u.path = file
+	if i := strings.IndexByte(file, '?'); i >= 0 {
+		u.path, u.query, u.hasQuery = file[:i], file[i+1:], true
+	}
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: u}
+	return nil
+}
+
+// urlState runs get on the state of the URL in params[0].
+func urlState(params []interface{}, get func(u *url) interface{}) interface{} {
+	u, gerr := getURL(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(u)
+}
+
+func urlGetProtocol(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return object.StringObjectFromGoString(u.protocol) })
+}
+
+func urlGetAuthority(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return optStringObject(u.authority, u.hasAuthority) })
+}
+
+func urlGetUserInfo(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return optStringObject(u.userInfo, u.userInfo != "") })
+}
+
+// java/net/URL.getHost()Ljava/lang/String; -- "" if there is none
+func urlGetHost(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return object.StringObjectFromGoString(u.host) })
+}
+
+func urlGetPort(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return int64(u.port) })
+}
+
+func urlGetDefaultPort(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return int64(urlDefaultPorts[u.protocol]) })
+}
+
+func urlGetFile(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return object.StringObjectFromGoString(u.file()) })
+}
+
+func urlGetPath(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return object.StringObjectFromGoString(u.path) })
+}
+
+func urlGetQuery(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return optStringObject(u.query, u.hasQuery) })
+}
+
+func urlGetRef(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return optStringObject(u.ref, u.hasRef) })
+}
+
+func urlToString(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} { return object.StringObjectFromGoString(u.String()) })
+}
+
+// java/net/URL.toURI()Ljava/net/URI; -- a URISyntaxException if the URL is not a legal URI
+func urlToURI(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} {
+		parsed, gerr := parseURI(u.String())
+		if gerr != nil {
+			return gerr
+		}
+		return newURIObject(parsed)
+	})
+}
+
+// sameURL reports whether a and b are equal, apart from their refs if withRef is false. Hosts
+// are compared without regard to case, but, unlike in the JDK, are not resolved.
+func sameURL(a, b *url, withRef bool) bool {
+	portOf := func(u *url) int {
+		if u.port == -1 {
+			return urlDefaultPorts[u.protocol]
+		}
+		return u.port
+	}
+	return a.protocol == b.protocol && strings.EqualFold(a.host, b.host) && portOf(a) == portOf(b) &&
+		a.file() == b.file() && (!withRef || a.hasRef == b.hasRef && a.ref == b.ref)
+}
+
+func urlEquals(params []interface{}) interface{} {
+	a, gerr := getURL(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return types.JavaBoolFalse
+	}
+	b, ok := other.FieldTable[netStateField].Fvalue.(*url)
+	return types.ConvertGoBoolToJavaBool(ok && sameURL(a, b, true))
+}
+
+// java/net/URL.sameFile(Ljava/net/URL;)Z -- equals(), without the refs
+func urlSameFile(params []interface{}) interface{} {
+	a, gerr := getURL(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	b, gerr := getURL(params[1])
+	if gerr != nil {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(sameURL(a, b, false))
+}
+
+// java/net/URL.hashCode()I -- agrees with equals()
+func urlHashCode(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} {
+		h := javaStringHashCode(u.protocol) + javaStringHashCode(strings.ToLower(u.host))
+		port := u.port
+		if port == -1 {
+			port = urlDefaultPorts[u.protocol]
+		}
+		h += int32(port) + javaStringHashCode(u.file())
+		if u.hasRef {
+			h += javaStringHashCode(u.ref)
+		}
+		return int64(h)
+	})
+}
+
+// java/net/URL.openConnection()Ljava/net/URLConnection; -- an HttpURLConnection, which does
+// not connect until it is used
+func urlOpenConnection(params []interface{}) interface{} {
+	return urlState(params, func(u *url) interface{} {
+		if u.protocol != "http" && u.protocol != "https" {
+			return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+				"URL.openConnection: "+u.protocol+" URLs are not yet supported")
+		}
+		return newHttpURLConnection(params[0].(*object.Object), u)
+	})
+}
+
+// java/net/URL.openStream()Ljava/io/InputStream; -- openConnection().getInputStream()
+func urlOpenStream(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	conn := urlOpenConnection(params)
+	if gerr, ok := conn.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	return httpURLConnectionGetInputStream([]interface{}{fs, conn})
+}
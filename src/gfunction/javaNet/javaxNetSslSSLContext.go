@@ -0,0 +1,693 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// javax.net.ssl.SSLContext, and the KeyManagerFactory and TrustManagerFactory whose managers
+// initialize it. TLS is done by crypto/tls over the sockets of this package.
+//
+// The key managers hold the private key entries of a KeyStore. The trust managers hold the
+// trusted certificate entries of a KeyStore, or the system's roots if the KeyStore is null; an
+// X509TrustManager written in Java can also be given to SSLContext.init. Key managers written in
+// Java are not supported, nor are SSLEngine and the session contexts. The default SSLContext
+// has no key material and trusts the system's roots: the javax.net.ssl system properties are not
+// read.
+
+const (
+	sslContextClassName          = "javax/net/ssl/SSLContext"
+	keyManagerFactoryClassName   = "javax/net/ssl/KeyManagerFactory"
+	trustManagerFactoryClassName = "javax/net/ssl/TrustManagerFactory"
+	keyManagerClassName          = "javax/net/ssl/X509ExtendedKeyManager"
+	trustManagerClassName        = "javax/net/ssl/X509ExtendedTrustManager"
+)
+
+// sslContextProtocols are the protocols for which there is an SSLContext, and the protocols
+// that the context's sockets enable by default.
+var sslContextProtocols = map[string][]string{
+	"TLS":     sslDefaultProtocols,
+	"SSL":     sslDefaultProtocols,
+	"TLSv1.3": sslDefaultProtocols,
+	"TLSv1.2": {"TLSv1.2"},
+	"TLSv1.1": {"TLSv1.1"},
+	"TLSv1":   {"TLSv1"},
+	"Default": sslDefaultProtocols,
+}
+
+var (
+	defaultSSLContextMu sync.Mutex
+	defaultSSLContext   *object.Object
+)
+
+func Load_Javax_Net_Ssl_SSLContext() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V": {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"createSSLEngine()Ljavax/net/ssl/SSLEngine;": {ParamSlots: 0, GFunction: ghelpers.TrapFunction},
+		"createSSLEngine(Ljava/lang/String;I)Ljavax/net/ssl/SSLEngine;": {ParamSlots: 2,
+			GFunction: ghelpers.TrapFunction},
+		"getClientSessionContext()Ljavax/net/ssl/SSLSessionContext;": {ParamSlots: 0,
+			GFunction: ghelpers.TrapFunction},
+		"getDefault()Ljavax/net/ssl/SSLContext;":                    {ParamSlots: 0, GFunction: sslContextGetDefault},
+		"getDefaultSSLParameters()Ljavax/net/ssl/SSLParameters;":    {ParamSlots: 0, GFunction: sslContextGetDefaultSSLParameters},
+		"getInstance(Ljava/lang/String;)Ljavax/net/ssl/SSLContext;": {ParamSlots: 1, GFunction: sslContextGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/net/ssl/SSLContext;": {ParamSlots: 2,
+			GFunction: sslContextGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljavax/net/ssl/SSLContext;": {ParamSlots: 2,
+			GFunction: sslContextGetInstance},
+		"getProtocol()Ljava/lang/String;":       {ParamSlots: 0, GFunction: sslContextGetProtocol},
+		"getProvider()Ljava/security/Provider;": {ParamSlots: 0, GFunction: sslGetProvider},
+		"getServerSessionContext()Ljavax/net/ssl/SSLSessionContext;": {ParamSlots: 0,
+			GFunction: ghelpers.TrapFunction},
+		"getServerSocketFactory()Ljavax/net/ssl/SSLServerSocketFactory;": {ParamSlots: 0,
+			GFunction: sslContextGetServerSocketFactory},
+		"getSocketFactory()Ljavax/net/ssl/SSLSocketFactory;": {ParamSlots: 0, GFunction: sslContextGetSocketFactory},
+		"getSupportedSSLParameters()Ljavax/net/ssl/SSLParameters;": {ParamSlots: 0,
+			GFunction: sslContextGetSupportedSSLParameters},
+		"init([Ljavax/net/ssl/KeyManager;[Ljavax/net/ssl/TrustManager;Ljava/security/SecureRandom;)V": {ParamSlots: 3,
+			GFunction: sslContextInit},
+		"setDefault(Ljavax/net/ssl/SSLContext;)V": {ParamSlots: 1, GFunction: sslContextSetDefault},
+	} {
+		ghelpers.MethodSignatures[sslContextClassName+"."+sig] = gmeth
+	}
+}
+
+func Load_Javax_Net_Ssl_KeyManagerFactory() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                             {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"getAlgorithm()Ljava/lang/String;":        {ParamSlots: 0, GFunction: managerFactoryGetAlgorithm},
+		"getDefaultAlgorithm()Ljava/lang/String;": {ParamSlots: 0, GFunction: keyManagerFactoryGetDefaultAlgorithm},
+		"getInstance(Ljava/lang/String;)Ljavax/net/ssl/KeyManagerFactory;": {ParamSlots: 1,
+			GFunction: keyManagerFactoryGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/net/ssl/KeyManagerFactory;": {ParamSlots: 2,
+			GFunction: keyManagerFactoryGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljavax/net/ssl/KeyManagerFactory;": {ParamSlots: 2,
+			GFunction: keyManagerFactoryGetInstance},
+		"getKeyManagers()[Ljavax/net/ssl/KeyManager;": {ParamSlots: 0, GFunction: keyManagerFactoryGetKeyManagers},
+		"getProvider()Ljava/security/Provider;":       {ParamSlots: 0, GFunction: sslGetProvider},
+		"init(Ljava/security/KeyStore;[C)V":           {ParamSlots: 2, GFunction: keyManagerFactoryInit},
+		"init(Ljavax/net/ssl/ManagerFactoryParameters;)V": {ParamSlots: 1,
+			GFunction: managerFactoryInitParameters},
+	} {
+		ghelpers.MethodSignatures[keyManagerFactoryClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"chooseClientAlias([Ljava/lang/String;[Ljava/security/Principal;Ljava/net/Socket;)Ljava/lang/String;": {
+			ParamSlots: 3, GFunction: keyManagerChooseAlias},
+		"chooseServerAlias(Ljava/lang/String;[Ljava/security/Principal;Ljava/net/Socket;)Ljava/lang/String;": {
+			ParamSlots: 3, GFunction: keyManagerChooseAlias},
+		"getCertificateChain(Ljava/lang/String;)[Ljava/security/cert/X509Certificate;": {ParamSlots: 1,
+			GFunction: keyManagerGetCertificateChain},
+		"getClientAliases(Ljava/lang/String;[Ljava/security/Principal;)[Ljava/lang/String;": {ParamSlots: 2,
+			GFunction: keyManagerGetAliases},
+		"getPrivateKey(Ljava/lang/String;)Ljava/security/PrivateKey;": {ParamSlots: 1,
+			GFunction: keyManagerGetPrivateKey},
+		"getServerAliases(Ljava/lang/String;[Ljava/security/Principal;)[Ljava/lang/String;": {ParamSlots: 2,
+			GFunction: keyManagerGetAliases},
+	} {
+		ghelpers.MethodSignatures[keyManagerClassName+"."+sig] = gmeth
+	}
+}
+
+func Load_Javax_Net_Ssl_TrustManagerFactory() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                             {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"getAlgorithm()Ljava/lang/String;":        {ParamSlots: 0, GFunction: managerFactoryGetAlgorithm},
+		"getDefaultAlgorithm()Ljava/lang/String;": {ParamSlots: 0, GFunction: trustManagerFactoryGetDefaultAlgorithm},
+		"getInstance(Ljava/lang/String;)Ljavax/net/ssl/TrustManagerFactory;": {ParamSlots: 1,
+			GFunction: trustManagerFactoryGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/net/ssl/TrustManagerFactory;": {ParamSlots: 2,
+			GFunction: trustManagerFactoryGetInstance},
+		"getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljavax/net/ssl/TrustManagerFactory;": {ParamSlots: 2,
+			GFunction: trustManagerFactoryGetInstance},
+		"getProvider()Ljava/security/Provider;":           {ParamSlots: 0, GFunction: sslGetProvider},
+		"getTrustManagers()[Ljavax/net/ssl/TrustManager;": {ParamSlots: 0, GFunction: trustManagerFactoryGetTrustManagers},
+		"init(Ljava/security/KeyStore;)V":                 {ParamSlots: 1, GFunction: trustManagerFactoryInit},
+		"init(Ljavax/net/ssl/ManagerFactoryParameters;)V": {ParamSlots: 1,
+			GFunction: managerFactoryInitParameters},
+	} {
+		ghelpers.MethodSignatures[trustManagerFactoryClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"checkClientTrusted([Ljava/security/cert/X509Certificate;Ljava/lang/String;)V": {ParamSlots: 2,
+			GFunction: trustManagerCheckClientTrusted},
+		"checkClientTrusted([Ljava/security/cert/X509Certificate;Ljava/lang/String;Ljava/net/Socket;)V": {
+			ParamSlots: 3, GFunction: trustManagerCheckClientTrusted},
+		"checkServerTrusted([Ljava/security/cert/X509Certificate;Ljava/lang/String;)V": {ParamSlots: 2,
+			GFunction: trustManagerCheckServerTrusted},
+		"checkServerTrusted([Ljava/security/cert/X509Certificate;Ljava/lang/String;Ljava/net/Socket;)V": {
+			ParamSlots: 3, GFunction: trustManagerCheckServerTrusted},
+		"getAcceptedIssuers()[Ljava/security/cert/X509Certificate;": {ParamSlots: 0,
+			GFunction: trustManagerGetAcceptedIssuers},
+	} {
+		ghelpers.MethodSignatures[trustManagerClassName+"."+sig] = gmeth
+	}
+}
+
+// sslContext is the Go state of an SSLContext, and of its socket factories.
+type sslContext struct {
+	mu          sync.Mutex
+	protocol    string
+	isDefault   bool
+	initialized bool
+	keys        *keyManager    // nil if there is no key material
+	trust       *trustManager  // nil if javaTrust is set
+	javaTrust   *object.Object // an X509TrustManager written in Java
+}
+
+// getSSLContext returns the Go state of an SSLContext or of one of its socket factories, which
+// must have been initialized.
+func getSSLContext(obj any) (*sslContext, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "SSLContext is null")
+	}
+	ctx, ok := o.FieldTable[netStateField].Fvalue.(*sslContext)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "SSLContext is not initialized")
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.initialized {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "SSLContext is not initialized")
+	}
+	return ctx, nil
+}
+
+// defaultParameters returns the settings of the context's new sockets.
+func (ctx *sslContext) defaultParameters() *sslParameters {
+	return &sslParameters{
+		cipherSuites: defaultCipherSuites(),
+		protocols:    slices.Clone(sslContextProtocols[ctx.protocol]),
+	}
+}
+
+func supportedParameters() *sslParameters {
+	return &sslParameters{cipherSuites: supportedCipherSuites(), protocols: slices.Clone(sslProtocols)}
+}
+
+// newSSLContextObject returns an object of className, an SSLContext or one of its socket
+// factories, for ctx.
+func newSSLContextObject(className string, ctx *sslContext) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: ctx}
+	return obj
+}
+
+// getDefaultSSLContext returns the default SSLContext, creating it the first time.
+func getDefaultSSLContext() *object.Object {
+	defaultSSLContextMu.Lock()
+	defer defaultSSLContextMu.Unlock()
+	if defaultSSLContext == nil {
+		ctx := &sslContext{protocol: "Default", isDefault: true, initialized: true, trust: &trustManager{}}
+		defaultSSLContext = newSSLContextObject(sslContextClassName, ctx)
+	}
+	return defaultSSLContext
+}
+
+// javax/net/ssl/SSLContext.getInstance(Ljava/lang/String;)Ljavax/net/ssl/SSLContext; and the forms
+// that name a provider, which is ignored
+func sslContextGetInstance(params []any) any {
+	protocol, gerr := algorithmArg(params[0], "SSLContext")
+	if gerr != nil {
+		return gerr
+	}
+	for name := range sslContextProtocols {
+		if strings.EqualFold(name, protocol) {
+			if name == "Default" {
+				return getDefaultSSLContext()
+			}
+			return newSSLContextObject(sslContextClassName, &sslContext{protocol: name})
+		}
+	}
+	return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException, protocol+" SSLContext not available")
+}
+
+// algorithmArg returns the algorithm name given to the getInstance of service.
+func algorithmArg(arg any, service string) (string, *ghelpers.GErrBlk) {
+	str, ok := arg.(*object.Object)
+	if !ok || object.IsNull(str) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "null "+service+" algorithm name")
+	}
+	return object.GoStringFromStringObject(str), nil
+}
+
+func sslContextGetDefault([]any) any {
+	return getDefaultSSLContext()
+}
+
+func sslContextSetDefault(params []any) any {
+	ctxObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(ctxObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "SSLContext.setDefault: context is null")
+	}
+	defaultSSLContextMu.Lock()
+	defer defaultSSLContextMu.Unlock()
+	defaultSSLContext = ctxObj
+	return nil
+}
+
+// javax/net/ssl/SSLContext.init([Ljavax/net/ssl/KeyManager;[Ljavax/net/ssl/TrustManager;Ljava/security/SecureRandom;)V
+// -- the first key manager and the first trust manager are used. A null array leaves the context
+// with no key material, or trusting the system's roots. The SecureRandom is not used.
+func sslContextInit(params []any) any {
+	self := params[0].(*object.Object)
+	ctx, ok := self.FieldTable[netStateField].Fvalue.(*sslContext)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "SSLContext is not initialized")
+	}
+	if ctx.isDefault {
+		return ghelpers.GetGErrBlk(excNames.KeyManagementException, "Default SSLContext is initialized automatically")
+	}
+
+	var keys *keyManager
+	if km := firstElement(params[1]); km != nil {
+		if keys, ok = km.FieldTable[netStateField].Fvalue.(*keyManager); !ok {
+			errMsg := "unsupported key manager: " + object.GoStringFromStringPoolIndex(km.KlassName)
+			return ghelpers.GetGErrBlk(excNames.KeyManagementException, errMsg)
+		}
+	}
+	trust, javaTrust := &trustManager{}, (*object.Object)(nil)
+	if tm := firstElement(params[2]); tm != nil {
+		if trust, ok = tm.FieldTable[netStateField].Fvalue.(*trustManager); !ok {
+			trust, javaTrust = nil, tm
+		}
+	}
+
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.keys, ctx.trust, ctx.javaTrust = keys, trust, javaTrust
+	ctx.initialized = true
+	return nil
+}
+
+// firstElement returns the first non-null element of an array, or nil.
+func firstElement(arg any) *object.Object {
+	arr, ok := arg.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil
+	}
+	elements, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	for _, element := range elements {
+		if !object.IsNull(element) {
+			return element
+		}
+	}
+	return nil
+}
+
+func sslContextGetProtocol(params []any) any {
+	ctx, ok := params[0].(*object.Object).FieldTable[netStateField].Fvalue.(*sslContext)
+	if !ok {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(ctx.protocol)
+}
+
+// getProvider()Ljava/security/Provider; of SSLContext and the manager factories
+func sslGetProvider([]any) any {
+	return ghelpers.GetDefaultSecurityProvider()
+}
+
+func sslContextGetSocketFactory(params []any) any {
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return newSSLContextObject(sslSocketFactoryClassName, ctx)
+}
+
+func sslContextGetServerSocketFactory(params []any) any {
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return newSSLContextObject(sslServerSocketFactoryClassName, ctx)
+}
+
+func sslContextGetDefaultSSLParameters(params []any) any {
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return newSSLParametersObject(ctx.defaultParameters())
+}
+
+func sslContextGetSupportedSSLParameters(params []any) any {
+	if _, gerr := getSSLContext(params[0]); gerr != nil {
+		return gerr
+	}
+	return newSSLParametersObject(supportedParameters())
+}
+
+// managerFactory is the Go state of a KeyManagerFactory or a TrustManagerFactory. manager is nil
+// until the factory is initialized.
+type managerFactory struct {
+	algorithm string
+	manager   *object.Object
+}
+
+func newManagerFactory(className string, arg any, algorithms []string) any {
+	algorithm, gerr := algorithmArg(arg, className[strings.LastIndex(className, "/")+1:])
+	if gerr != nil {
+		return gerr
+	}
+	for _, name := range algorithms {
+		if strings.EqualFold(name, algorithm) {
+			obj := object.MakeEmptyObjectWithClassName(&className)
+			obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: &managerFactory{algorithm: algorithm}}
+			return obj
+		}
+	}
+	errMsg := fmt.Sprintf("%s %s not available", algorithm, className[strings.LastIndex(className, "/")+1:])
+	return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException, errMsg)
+}
+
+func getManagerFactory(obj any) *managerFactory {
+	mf, _ := obj.(*object.Object).FieldTable[netStateField].Fvalue.(*managerFactory)
+	return mf
+}
+
+func managerFactoryGetAlgorithm(params []any) any {
+	return object.StringObjectFromGoString(getManagerFactory(params[0]).algorithm)
+}
+
+// init(Ljavax/net/ssl/ManagerFactoryParameters;)V of the manager factories
+func managerFactoryInitParameters(params []any) any {
+	return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException,
+		getManagerFactory(params[0]).algorithm+" does not use ManagerFactoryParameters")
+}
+
+// managerArray returns the array of the one manager of an initialized factory.
+func managerArray(params []any, arrayType, className string) any {
+	mf := getManagerFactory(params[0])
+	if mf.manager == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, className+" is not initialized")
+	}
+	return object.MakePrimitiveObject(arrayType, types.RefArray, []*object.Object{mf.manager})
+}
+
+func keyManagerFactoryGetInstance(params []any) any {
+	return newManagerFactory(keyManagerFactoryClassName, params[0], []string{"SunX509", "NewSunX509", "PKIX"})
+}
+
+func keyManagerFactoryGetDefaultAlgorithm([]any) any {
+	return object.StringObjectFromGoString("SunX509")
+}
+
+// javax/net/ssl/KeyManagerFactory.init(Ljava/security/KeyStore;[C)V -- the keys of all the private
+// key entries are decrypted with the password. A null KeyStore has no entries.
+func keyManagerFactoryInit(params []any) any {
+	keys := &keyManager{}
+	if ks, ok := params[1].(*object.Object); ok && !object.IsNull(ks) {
+		entries, gerr := javaSecurity.KeyStoreKeyEntries(ks, javaSecurity.PasswordRunes(params[2]))
+		if gerr != nil {
+			return gerr
+		}
+		keys.entries = entries
+	}
+	className := keyManagerClassName
+	km := object.MakeEmptyObjectWithClassName(&className)
+	km.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: keys}
+	getManagerFactory(params[0]).manager = km
+	return nil
+}
+
+func keyManagerFactoryGetKeyManagers(params []any) any {
+	return managerArray(params, "[Ljavax/net/ssl/KeyManager;", "KeyManagerFactory")
+}
+
+func trustManagerFactoryGetInstance(params []any) any {
+	return newManagerFactory(trustManagerFactoryClassName, params[0], []string{"PKIX", "SunX509", "X509", "X.509"})
+}
+
+func trustManagerFactoryGetDefaultAlgorithm([]any) any {
+	return object.StringObjectFromGoString("PKIX")
+}
+
+// javax/net/ssl/TrustManagerFactory.init(Ljava/security/KeyStore;)V -- the trusted certificate
+// entries are the trust anchors. A null KeyStore trusts the system's roots.
+func trustManagerFactoryInit(params []any) any {
+	trust := &trustManager{}
+	if ks, ok := params[1].(*object.Object); ok && !object.IsNull(ks) {
+		certs, gerr := javaSecurity.KeyStoreTrustedCertificates(ks)
+		if gerr != nil {
+			return gerr
+		}
+		trust = newTrustManager(certs)
+	}
+	className := trustManagerClassName
+	tm := object.MakeEmptyObjectWithClassName(&className)
+	tm.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: trust}
+	getManagerFactory(params[0]).manager = tm
+	return nil
+}
+
+func trustManagerFactoryGetTrustManagers(params []any) any {
+	return managerArray(params, "[Ljavax/net/ssl/TrustManager;", "TrustManagerFactory")
+}
+
+// keyManager is the Go state of a key manager.
+type keyManager struct {
+	entries []javaSecurity.KeyStoreKeyEntry
+}
+
+// certificates returns the key material for crypto/tls.
+func (km *keyManager) certificates() []tls.Certificate {
+	var certs []tls.Certificate
+	for _, entry := range km.entries {
+		cert := tls.Certificate{PrivateKey: entry.Key, Leaf: entry.Chain[0]}
+		for _, c := range entry.Chain {
+			cert.Certificate = append(cert.Certificate, c.Raw)
+		}
+		certs = append(certs, cert)
+	}
+	return certs
+}
+
+// keyAlgorithm returns the JDK's name for the algorithm of a key.
+func keyAlgorithm(key any) string {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return "RSA"
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return "EC"
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return "EdDSA"
+	}
+	return "UNKNOWN"
+}
+
+// aliases returns the aliases of the entries whose keys are of one of keyTypes.
+func (km *keyManager) aliases(keyTypes []string) []string {
+	var aliases []string
+	for _, entry := range km.entries {
+		if slices.Contains(keyTypes, keyAlgorithm(entry.Key)) {
+			aliases = append(aliases, entry.Alias)
+		}
+	}
+	return aliases
+}
+
+func (km *keyManager) find(alias string) *javaSecurity.KeyStoreKeyEntry {
+	for i := range km.entries {
+		if strings.EqualFold(km.entries[i].Alias, alias) {
+			return &km.entries[i]
+		}
+	}
+	return nil
+}
+
+func getKeyManager(obj any) *keyManager {
+	km, _ := obj.(*object.Object).FieldTable[netStateField].Fvalue.(*keyManager)
+	return km
+}
+
+// getClientAliases and getServerAliases(Ljava/lang/String;[Ljava/security/Principal;)[Ljava/lang/String;
+// -- the issuers are not used to choose
+func keyManagerGetAliases(params []any) any {
+	keyType, _ := params[1].(*object.Object)
+	aliases := getKeyManager(params[0]).aliases([]string{object.GoStringFromStringObject(keyType)})
+	if len(aliases) == 0 {
+		return object.Null
+	}
+	return stringArrayObject(aliases)
+}
+
+// chooseClientAlias, which takes an array of key types, and chooseServerAlias, which takes one
+func keyManagerChooseAlias(params []any) any {
+	var keyTypes []string
+	if keyType, ok := params[1].(*object.Object); ok && !object.IsNull(keyType) {
+		if _, isArray := keyType.FieldTable["value"].Fvalue.([]*object.Object); isArray {
+			keyTypes, _ = stringArrayArg(keyType)
+		} else {
+			keyTypes = []string{object.GoStringFromStringObject(keyType)}
+		}
+	}
+	aliases := getKeyManager(params[0]).aliases(keyTypes)
+	if len(aliases) == 0 {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(aliases[0])
+}
+
+func keyManagerGetCertificateChain(params []any) any {
+	alias, _ := params[1].(*object.Object)
+	entry := getKeyManager(params[0]).find(object.GoStringFromStringObject(alias))
+	if entry == nil {
+		return object.Null
+	}
+	return x509CertificateArray(entry.Chain)
+}
+
+func keyManagerGetPrivateKey(params []any) any {
+	alias, _ := params[1].(*object.Object)
+	entry := getKeyManager(params[0]).find(object.GoStringFromStringObject(alias))
+	if entry == nil {
+		return object.Null
+	}
+	keyObj, err := javaSecurity.NewPrivateKeyObject(entry.Key)
+	if err != nil {
+		return object.Null
+	}
+	return keyObj
+}
+
+// x509CertificateArray returns an X509Certificate[] of certs.
+func x509CertificateArray(certs []*x509.Certificate) *object.Object {
+	certObjs := make([]*object.Object, len(certs))
+	for i, cert := range certs {
+		certObjs[i] = javaSecurity.NewX509CertificateObject(cert)
+	}
+	return object.MakePrimitiveObject("[Ljava/security/cert/X509Certificate;", types.RefArray, certObjs)
+}
+
+// trustManager is the Go state of a trust manager. If anchors is nil, it trusts the system's
+// roots.
+type trustManager struct {
+	anchors []*x509.Certificate
+	pool    *x509.CertPool
+}
+
+func newTrustManager(anchors []*x509.Certificate) *trustManager {
+	tm := &trustManager{anchors: anchors, pool: x509.NewCertPool()}
+	if tm.anchors == nil {
+		tm.anchors = []*x509.Certificate{}
+	}
+	for _, cert := range anchors {
+		tm.pool.AddCert(cert)
+	}
+	return tm
+}
+
+// roots returns the trust anchors as a pool, or nil for the system's roots.
+func (tm *trustManager) roots() *x509.CertPool {
+	if tm.anchors == nil {
+		return nil
+	}
+	return tm.pool
+}
+
+// verify checks that chain, which starts with the peer's certificate, leads to a trust anchor
+// and may be used for usage. If host is not "", it must match the peer's certificate. The
+// error is a CertificateException with the JDK's message.
+func (tm *trustManager) verify(chain []*x509.Certificate, usage x509.ExtKeyUsage, host string) *ghelpers.GErrBlk {
+	leaf := chain[0]
+	now := time.Now()
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return ghelpers.GetGErrBlk(excNames.CertificateException,
+			"PKIX path validation failed: java.security.cert.CertPathValidatorException: validity check failed")
+	}
+	// A certificate that is itself a trust anchor is trusted.
+	trusted := slices.ContainsFunc(tm.anchors, leaf.Equal)
+	if !trusted {
+		intermediates := x509.NewCertPool()
+		for _, cert := range chain[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{Roots: tm.roots(), Intermediates: intermediates,
+			KeyUsages: []x509.ExtKeyUsage{usage}})
+		var invalid x509.CertificateInvalidError
+		switch {
+		case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+			return ghelpers.GetGErrBlk(excNames.CertificateException,
+				"PKIX path validation failed: java.security.cert.CertPathValidatorException: validity check failed")
+		case errors.As(err, &invalid) && invalid.Reason == x509.IncompatibleUsage:
+			side := "server"
+			if usage == x509.ExtKeyUsageClientAuth {
+				side = "client"
+			}
+			return ghelpers.GetGErrBlk(excNames.CertificateException,
+				"Extended key usage does not permit use for TLS "+side+" authentication")
+		case err != nil:
+			return ghelpers.GetGErrBlk(excNames.CertificateException,
+				"PKIX path building failed: sun.security.provider.certpath.SunCertPathBuilderException: "+
+					"unable to find valid certification path to requested target")
+		}
+	}
+	if host != "" {
+		return checkHostname(leaf, host)
+	}
+	return nil
+}
+
+// trustManagerCheck checks the chain and authentication type given to a trust manager.
+func trustManagerCheck(params []any, usage x509.ExtKeyUsage) any {
+	tm, _ := params[0].(*object.Object).FieldTable[netStateField].Fvalue.(*trustManager)
+	var chain []*x509.Certificate
+	if arr, ok := params[1].(*object.Object); ok && !object.IsNull(arr) {
+		elements, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+		for _, element := range elements {
+			if object.IsNull(element) {
+				continue
+			}
+			if cert, ok := element.FieldTable["value"].Fvalue.(*x509.Certificate); ok {
+				chain = append(chain, cert)
+			}
+		}
+	}
+	if len(chain) == 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "null or zero-length certificate chain")
+	}
+	if authType, ok := params[2].(*object.Object); !ok || object.GoStringFromStringObject(authType) == "" {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "null or zero-length authentication type")
+	}
+	return gerrOrNil(tm.verify(chain, usage, ""))
+}
+
+func trustManagerCheckClientTrusted(params []any) any {
+	return trustManagerCheck(params, x509.ExtKeyUsageClientAuth)
+}
+
+func trustManagerCheckServerTrusted(params []any) any {
+	return trustManagerCheck(params, x509.ExtKeyUsageServerAuth)
+}
+
+// javax/net/ssl/X509ExtendedTrustManager.getAcceptedIssuers()[Ljava/security/cert/X509Certificate;
+// -- empty for the system's roots, which Go cannot list
+func trustManagerGetAcceptedIssuers(params []any) any {
+	tm, _ := params[0].(*object.Object).FieldTable[netStateField].Fvalue.(*trustManager)
+	return x509CertificateArray(tm.anchors)
+}
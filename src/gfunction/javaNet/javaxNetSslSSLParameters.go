@@ -0,0 +1,307 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"crypto/tls"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
+)
+
+// javax.net.ssl.SSLParameters, and the protocol and cipher suite names that it and the SSL
+// sockets use. Cipher suites have their IANA names, which the JDK also uses, and are those that
+// crypto/tls implements. Go always enables all of its TLS 1.3 cipher suites, so TLS 1.3 is only
+// negotiated if at least one of them is enabled.
+
+const sslParametersClassName = "javax/net/ssl/SSLParameters"
+
+// sslProtocols are the protocols that can be enabled, from the newest to the oldest.
+var sslProtocols = []string{"TLSv1.3", "TLSv1.2", "TLSv1.1", "TLSv1"}
+
+// sslDefaultProtocols are the protocols enabled by default. The JDK disables TLS 1.1 and 1.0.
+var sslDefaultProtocols = []string{"TLSv1.3", "TLSv1.2"}
+
+var sslProtocolVersions = map[string]uint16{
+	"TLSv1.3": tls.VersionTLS13,
+	"TLSv1.2": tls.VersionTLS12,
+	"TLSv1.1": tls.VersionTLS11,
+	"TLSv1":   tls.VersionTLS10,
+}
+
+func Load_Javax_Net_Ssl_SSLParameters() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                  {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                    {ParamSlots: 0, GFunction: sslParametersInit},
+		"<init>([Ljava/lang/String;)V": {ParamSlots: 1, GFunction: sslParametersInit},
+		"<init>([Ljava/lang/String;[Ljava/lang/String;)V": {ParamSlots: 2, GFunction: sslParametersInit},
+		"getApplicationProtocols()[Ljava/lang/String;":    {ParamSlots: 0, GFunction: sslParametersGetApplicationProtocols},
+		"getCipherSuites()[Ljava/lang/String;":            {ParamSlots: 0, GFunction: sslParametersGetCipherSuites},
+		"getEndpointIdentificationAlgorithm()Ljava/lang/String;": {ParamSlots: 0,
+			GFunction: sslParametersGetEndpointIdentificationAlgorithm},
+		"getNeedClientAuth()Z":                          {ParamSlots: 0, GFunction: sslParametersGetNeedClientAuth},
+		"getProtocols()[Ljava/lang/String;":             {ParamSlots: 0, GFunction: sslParametersGetProtocols},
+		"getWantClientAuth()Z":                          {ParamSlots: 0, GFunction: sslParametersGetWantClientAuth},
+		"setApplicationProtocols([Ljava/lang/String;)V": {ParamSlots: 1, GFunction: sslParametersSetApplicationProtocols},
+		"setCipherSuites([Ljava/lang/String;)V":         {ParamSlots: 1, GFunction: sslParametersSetCipherSuites},
+		"setEndpointIdentificationAlgorithm(Ljava/lang/String;)V": {ParamSlots: 1,
+			GFunction: sslParametersSetEndpointIdentificationAlgorithm},
+		"setNeedClientAuth(Z)V":              {ParamSlots: 1, GFunction: sslParametersSetNeedClientAuth},
+		"setProtocols([Ljava/lang/String;)V": {ParamSlots: 1, GFunction: sslParametersSetProtocols},
+		"setWantClientAuth(Z)V":              {ParamSlots: 1, GFunction: sslParametersSetWantClientAuth},
+	} {
+		ghelpers.MethodSignatures[sslParametersClassName+"."+sig] = gmeth
+	}
+}
+
+// sslParameters are the settings of an SSLParameters, and of an SSL socket. In an SSLParameters,
+// nil cipher suites or protocols are unset, and leave those of a socket as they are.
+type sslParameters struct {
+	cipherSuites           []string
+	protocols              []string
+	needClientAuth         bool
+	wantClientAuth         bool
+	endpointIdentification string // "" if no identification is done
+	applicationProtocols   []string
+}
+
+func (p *sslParameters) clone() *sslParameters {
+	c := *p
+	c.cipherSuites = slices.Clone(p.cipherSuites)
+	c.protocols = slices.Clone(p.protocols)
+	c.applicationProtocols = slices.Clone(p.applicationProtocols)
+	return &c
+}
+
+// newSSLParametersObject returns an SSLParameters that holds a copy of p.
+func newSSLParametersObject(p *sslParameters) *object.Object {
+	className := sslParametersClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: p.clone()}
+	return obj
+}
+
+func getSSLParameters(obj any) (*sslParameters, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "SSLParameters is null")
+	}
+	p, ok := o.FieldTable[netStateField].Fvalue.(*sslParameters)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "SSLParameters is not initialized")
+	}
+	return p, nil
+}
+
+// supportedCipherSuites returns the names of the cipher suites that can be enabled, the secure
+// ones first.
+func supportedCipherSuites() []string {
+	var names []string
+	for _, suite := range tls.CipherSuites() {
+		names = append(names, suite.Name)
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		names = append(names, suite.Name)
+	}
+	return names
+}
+
+// defaultCipherSuites returns the names of the cipher suites enabled by default.
+func defaultCipherSuites() []string {
+	var names []string
+	for _, suite := range tls.CipherSuites() {
+		names = append(names, suite.Name)
+	}
+	return names
+}
+
+// checkCipherSuites checks that the cipher suites can be enabled.
+func checkCipherSuites(names []string) *ghelpers.GErrBlk {
+	supported := supportedCipherSuites()
+	for _, name := range names {
+		if !slices.Contains(supported, name) {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Unsupported CipherSuite: "+name)
+		}
+	}
+	return nil
+}
+
+// checkProtocols checks that the protocols can be enabled.
+func checkProtocols(names []string) *ghelpers.GErrBlk {
+	for _, name := range names {
+		if _, ok := sslProtocolVersions[name]; !ok {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Unsupported protocol: "+name)
+		}
+	}
+	return nil
+}
+
+// stringArrayArg returns the strings of a String[], or nil for a null array. An element that is
+// null is an IllegalArgumentException, as it is for the setters of SSLParameters and sockets.
+func stringArrayArg(arg any) ([]string, *ghelpers.GErrBlk) {
+	arr, ok := arg.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, nil
+	}
+	elements, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	strs := make([]string, len(elements))
+	for i, element := range elements {
+		if object.IsNull(element) {
+			return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "An element of the array is null")
+		}
+		strs[i] = object.GoStringFromStringObject(element)
+	}
+	return strs, nil
+}
+
+// stringArrayObject returns a String[] of strs.
+func stringArrayObject(strs []string) *object.Object {
+	return object.MakePrimitiveObject("[Ljava/lang/String;", types.RefArray, object.StringObjectArrayFromGoStringArray(strs))
+}
+
+// stringArrayOrNull returns a String[] of strs, or null if strs is nil.
+func stringArrayOrNull(strs []string) any {
+	if strs == nil {
+		return object.Null
+	}
+	return stringArrayObject(strs)
+}
+
+// javax/net/ssl/SSLParameters.<init>()V, <init>([Ljava/lang/String;)V and
+// <init>([Ljava/lang/String;[Ljava/lang/String;)V -- the cipher suites and then the protocols
+func sslParametersInit(params []any) any {
+	p := &sslParameters{}
+	var gerr *ghelpers.GErrBlk
+	if len(params) > 1 {
+		if p.cipherSuites, gerr = stringArrayArg(params[1]); gerr != nil {
+			return gerr
+		}
+	}
+	if len(params) > 2 {
+		if p.protocols, gerr = stringArrayArg(params[2]); gerr != nil {
+			return gerr
+		}
+	}
+	params[0].(*object.Object).FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: p}
+	return nil
+}
+
+// sslParametersGet runs get on the settings of the SSLParameters in params[0].
+func sslParametersGet(params []any, get func(p *sslParameters) any) any {
+	p, gerr := getSSLParameters(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return get(p)
+}
+
+// sslParametersSet runs set on the settings of the SSLParameters in params[0].
+func sslParametersSet(params []any, set func(p *sslParameters) *ghelpers.GErrBlk) any {
+	p, gerr := getSSLParameters(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return gerrOrNil(set(p))
+}
+
+func sslParametersGetCipherSuites(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any { return stringArrayOrNull(p.cipherSuites) })
+}
+
+func sslParametersSetCipherSuites(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		names, gerr := stringArrayArg(params[1])
+		p.cipherSuites = names
+		return gerr
+	})
+}
+
+func sslParametersGetProtocols(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any { return stringArrayOrNull(p.protocols) })
+}
+
+func sslParametersSetProtocols(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		names, gerr := stringArrayArg(params[1])
+		p.protocols = names
+		return gerr
+	})
+}
+
+func sslParametersGetNeedClientAuth(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any { return types.ConvertGoBoolToJavaBool(p.needClientAuth) })
+}
+
+// javax/net/ssl/SSLParameters.setNeedClientAuth(Z)V -- also clears wantClientAuth
+func sslParametersSetNeedClientAuth(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		p.needClientAuth, p.wantClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslParametersGetWantClientAuth(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any { return types.ConvertGoBoolToJavaBool(p.wantClientAuth) })
+}
+
+// javax/net/ssl/SSLParameters.setWantClientAuth(Z)V -- also clears needClientAuth
+func sslParametersSetWantClientAuth(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		p.wantClientAuth, p.needClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslParametersGetEndpointIdentificationAlgorithm(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any {
+		if p.endpointIdentification == "" {
+			return object.Null
+		}
+		return object.StringObjectFromGoString(p.endpointIdentification)
+	})
+}
+
+// javax/net/ssl/SSLParameters.setEndpointIdentificationAlgorithm(Ljava/lang/String;)V -- "HTTPS"
+// and "LDAPS" check the peer's host name against its certificate; null checks nothing
+func sslParametersSetEndpointIdentificationAlgorithm(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		p.endpointIdentification = ""
+		if str, ok := params[1].(*object.Object); ok && !object.IsNull(str) {
+			p.endpointIdentification = object.GoStringFromStringObject(str)
+		}
+		return nil
+	})
+}
+
+// javax/net/ssl/SSLParameters.getApplicationProtocols()[Ljava/lang/String; -- the ALPN protocols,
+// which are empty, not null, if there are none
+func sslParametersGetApplicationProtocols(params []any) any {
+	return sslParametersGet(params, func(p *sslParameters) any {
+		return stringArrayObject(append([]string{}, p.applicationProtocols...))
+	})
+}
+
+func sslParametersSetApplicationProtocols(params []any) any {
+	return sslParametersSet(params, func(p *sslParameters) *ghelpers.GErrBlk {
+		if arr, ok := params[1].(*object.Object); !ok || object.IsNull(arr) {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "protocols was null")
+		}
+		names, gerr := stringArrayArg(params[1])
+		if gerr != nil {
+			return gerr
+		}
+		for _, name := range names {
+			if name == "" {
+				return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "An element of protocols was null/empty")
+			}
+		}
+		p.applicationProtocols = names
+		return nil
+	})
+}
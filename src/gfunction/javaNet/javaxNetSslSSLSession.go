@@ -0,0 +1,236 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"sync"
+	"time"
+)
+
+// javax.net.ssl.SSLSession, the result of the handshake of an SSLSocket. Sessions are not
+// resumed, and a session's id is random, as the JDK's are for TLS 1.3.
+
+const sslSessionClassName = "javax/net/ssl/SSLSession"
+
+func Load_Javax_Net_Ssl_SSLSession() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"getApplicationBufferSize()I":        {ParamSlots: 0, GFunction: sslSessionGetApplicationBufferSize},
+		"getCipherSuite()Ljava/lang/String;": {ParamSlots: 0, GFunction: sslSessionGetCipherSuite},
+		"getCreationTime()J":                 {ParamSlots: 0, GFunction: sslSessionGetCreationTime},
+		"getId()[B":                          {ParamSlots: 0, GFunction: sslSessionGetId},
+		"getLastAccessedTime()J":             {ParamSlots: 0, GFunction: sslSessionGetLastAccessedTime},
+		"getLocalCertificates()[Ljava/security/cert/Certificate;": {ParamSlots: 0, GFunction: sslSessionGetLocalCertificates},
+		"getLocalPrincipal()Ljava/security/Principal;":            {ParamSlots: 0, GFunction: sslSessionGetLocalPrincipal},
+		"getPacketBufferSize()I":                                  {ParamSlots: 0, GFunction: sslSessionGetPacketBufferSize},
+		"getPeerCertificates()[Ljava/security/cert/Certificate;":  {ParamSlots: 0, GFunction: sslSessionGetPeerCertificates},
+		"getPeerHost()Ljava/lang/String;":                         {ParamSlots: 0, GFunction: sslSessionGetPeerHost},
+		"getPeerPort()I":                                          {ParamSlots: 0, GFunction: sslSessionGetPeerPort},
+		"getPeerPrincipal()Ljava/security/Principal;":             {ParamSlots: 0, GFunction: sslSessionGetPeerPrincipal},
+		"getProtocol()Ljava/lang/String;":                         {ParamSlots: 0, GFunction: sslSessionGetProtocol},
+		"invalidate()V":                                           {ParamSlots: 0, GFunction: sslSessionInvalidate},
+		"isValid()Z":                                              {ParamSlots: 0, GFunction: sslSessionIsValid},
+		"toString()Ljava/lang/String;":                            {ParamSlots: 0, GFunction: sslSessionToString},
+	} {
+		ghelpers.MethodSignatures[sslSessionClassName+"."+sig] = gmeth
+	}
+}
+
+// sslSession is the Go state of an SSLSession. The null session, of a failed handshake, has no
+// cipher suite and is never valid.
+type sslSession struct {
+	mu         sync.Mutex
+	null       bool
+	state      tls.ConnectionState
+	localChain []*x509.Certificate
+	peerHost   string
+	peerPort   int
+	id         []byte
+	created    time.Time
+	valid      bool
+}
+
+// newSSLSessionObject returns the session of the handshake of s, whose connection state is cs.
+func newSSLSessionObject(s *socket, cs tls.ConnectionState) *object.Object {
+	session := &sslSession{state: cs, peerHost: s.peerHost(), peerPort: -1, id: make([]byte, 32),
+		created: time.Now(), valid: true}
+	if addr, ok := s.conn.RemoteAddr().(*net.TCPAddr); ok {
+		session.peerPort = addr.Port
+	}
+	_, _ = rand.Read(session.id)
+	s.ssl.mu.Lock()
+	if s.ssl.localCert != nil {
+		session.localChain = localChain(s.ssl.localCert)
+	}
+	s.ssl.mu.Unlock()
+	className := sslSessionClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: session}
+	return obj
+}
+
+func newNullSSLSessionObject() *object.Object {
+	className := sslSessionClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer,
+		Fvalue: &sslSession{null: true, peerPort: -1, id: []byte{}, created: time.Now()}}
+	return obj
+}
+
+// localChain returns the certificates of the key material sent to the peer.
+func localChain(cert *tls.Certificate) []*x509.Certificate {
+	var chain []*x509.Certificate
+	for _, der := range cert.Certificate {
+		if c, err := x509.ParseCertificate(der); err == nil {
+			chain = append(chain, c)
+		}
+	}
+	return chain
+}
+
+func getSSLSession(obj any) *sslSession {
+	session, _ := obj.(*object.Object).FieldTable[netStateField].Fvalue.(*sslSession)
+	return session
+}
+
+func (session *sslSession) cipherSuite() string {
+	if session.null {
+		return "SSL_NULL_WITH_NULL_NULL"
+	}
+	return tls.CipherSuiteName(session.state.CipherSuite)
+}
+
+func (session *sslSession) protocol() string {
+	for name, version := range sslProtocolVersions {
+		if !session.null && version == session.state.Version {
+			return name
+		}
+	}
+	return "NONE"
+}
+
+// String is the JDK's form: the creation time and the cipher suite.
+func (session *sslSession) String() string {
+	return fmt.Sprintf("Session(%d|%s)", session.created.UnixMilli(), session.cipherSuite())
+}
+
+func sslSessionGetCipherSuite(params []any) any {
+	return object.StringObjectFromGoString(getSSLSession(params[0]).cipherSuite())
+}
+
+func sslSessionGetProtocol(params []any) any {
+	return object.StringObjectFromGoString(getSSLSession(params[0]).protocol())
+}
+
+// certificateArray returns a Certificate[] of certs.
+func certificateArray(certs []*x509.Certificate) *object.Object {
+	certObjs := make([]*object.Object, len(certs))
+	for i, cert := range certs {
+		certObjs[i] = javaSecurity.NewX509CertificateObject(cert)
+	}
+	return object.MakePrimitiveObject("[Ljava/security/cert/Certificate;", types.RefArray, certObjs)
+}
+
+// javax/net/ssl/SSLSession.getPeerCertificates()[Ljava/security/cert/Certificate; -- the peer's
+// chain, starting with its own certificate
+func sslSessionGetPeerCertificates(params []any) any {
+	session := getSSLSession(params[0])
+	if len(session.state.PeerCertificates) == 0 {
+		return ghelpers.GetGErrBlk(excNames.SSLPeerUnverifiedException, "peer not authenticated")
+	}
+	return certificateArray(session.state.PeerCertificates)
+}
+
+// javax/net/ssl/SSLSession.getLocalCertificates()[Ljava/security/cert/Certificate; -- null if no
+// certificate was sent to the peer
+func sslSessionGetLocalCertificates(params []any) any {
+	session := getSSLSession(params[0])
+	if len(session.localChain) == 0 {
+		return object.Null
+	}
+	return certificateArray(session.localChain)
+}
+
+func sslSessionGetPeerPrincipal(params []any) any {
+	session := getSSLSession(params[0])
+	if len(session.state.PeerCertificates) == 0 {
+		return ghelpers.GetGErrBlk(excNames.SSLPeerUnverifiedException, "peer not authenticated")
+	}
+	return javaSecurity.NewX500PrincipalObject(session.state.PeerCertificates[0].RawSubject)
+}
+
+func sslSessionGetLocalPrincipal(params []any) any {
+	session := getSSLSession(params[0])
+	if len(session.localChain) == 0 {
+		return object.Null
+	}
+	return javaSecurity.NewX500PrincipalObject(session.localChain[0].RawSubject)
+}
+
+func sslSessionGetPeerHost(params []any) any {
+	session := getSSLSession(params[0])
+	if session.peerHost == "" {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(session.peerHost)
+}
+
+func sslSessionGetPeerPort(params []any) any {
+	return int64(getSSLSession(params[0]).peerPort)
+}
+
+func sslSessionGetId(params []any) any {
+	id := getSSLSession(params[0]).id
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(id))
+}
+
+func sslSessionGetCreationTime(params []any) any {
+	return getSSLSession(params[0]).created.UnixMilli()
+}
+
+// javax/net/ssl/SSLSession.getLastAccessedTime()J -- sessions are not resumed, so this is when
+// the session was created
+func sslSessionGetLastAccessedTime(params []any) any {
+	return getSSLSession(params[0]).created.UnixMilli()
+}
+
+func sslSessionIsValid(params []any) any {
+	session := getSSLSession(params[0])
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return types.ConvertGoBoolToJavaBool(session.valid)
+}
+
+func sslSessionInvalidate(params []any) any {
+	session := getSSLSession(params[0])
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.valid = false
+	return nil
+}
+
+// getApplicationBufferSize()I and getPacketBufferSize()I -- the JDK's sizes for a TLS record
+func sslSessionGetApplicationBufferSize([]any) any {
+	return int64(16384)
+}
+
+func sslSessionGetPacketBufferSize([]any) any {
+	return int64(16709)
+}
+
+func sslSessionToString(params []any) any {
+	return object.StringObjectFromGoString(getSSLSession(params[0]).String())
+}
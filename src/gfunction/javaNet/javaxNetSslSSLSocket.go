@@ -0,0 +1,994 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// javax.net.ssl.SSLSocket and SSLServerSocket, and their factories. An SSLSocket is a Socket
+// whose connection is wrapped in a crypto/tls connection when it first handshakes, which is
+// done by startHandshake(), getSession(), or the first read or write. The handshake runs in a
+// goroutine while the thread waits for it, interruptibly and for at most the SO_TIMEOUT, and
+// runs the checks of an X509TrustManager written in Java when the handshake asks for them.
+
+const (
+	sslSocketClassName              = "javax/net/ssl/SSLSocket"
+	sslServerSocketClassName        = "javax/net/ssl/SSLServerSocket"
+	sslSocketFactoryClassName       = "javax/net/ssl/SSLSocketFactory"
+	sslServerSocketFactoryClassName = "javax/net/ssl/SSLServerSocketFactory"
+
+	sslStateField = "sslState" // the sslServerSocket of an SSLServerSocket
+)
+
+func Load_Javax_Net_Ssl_SSLSocketFactory() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                     {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"createSocket()Ljava/net/Socket;": {ParamSlots: 0, GFunction: sslSocketFactoryCreateSocket},
+		"createSocket(Ljava/lang/String;I)Ljava/net/Socket;": {ParamSlots: 2,
+			GFunction: sslSocketFactoryCreateSocket, NeedsContext: true},
+		"createSocket(Ljava/net/InetAddress;I)Ljava/net/Socket;": {ParamSlots: 2,
+			GFunction: sslSocketFactoryCreateSocket, NeedsContext: true},
+		"createSocket(Ljava/lang/String;ILjava/net/InetAddress;I)Ljava/net/Socket;": {ParamSlots: 4,
+			GFunction: sslSocketFactoryCreateSocket, NeedsContext: true},
+		"createSocket(Ljava/net/InetAddress;ILjava/net/InetAddress;I)Ljava/net/Socket;": {ParamSlots: 4,
+			GFunction: sslSocketFactoryCreateSocket, NeedsContext: true},
+		"createSocket(Ljava/net/Socket;Ljava/lang/String;IZ)Ljava/net/Socket;": {ParamSlots: 4,
+			GFunction: sslSocketFactoryCreateLayeredSocket},
+		"getDefault()Ljavax/net/SocketFactory;":         {ParamSlots: 0, GFunction: sslSocketFactoryGetDefault},
+		"getDefaultCipherSuites()[Ljava/lang/String;":   {ParamSlots: 0, GFunction: sslGetDefaultCipherSuites},
+		"getSupportedCipherSuites()[Ljava/lang/String;": {ParamSlots: 0, GFunction: sslGetSupportedCipherSuites},
+	} {
+		ghelpers.MethodSignatures[sslSocketFactoryClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V": {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"createServerSocket()Ljava/net/ServerSocket;": {ParamSlots: 0, GFunction: sslServerSocketFactoryCreateServerSocket},
+		"createServerSocket(I)Ljava/net/ServerSocket;": {ParamSlots: 1,
+			GFunction: sslServerSocketFactoryCreateServerSocket},
+		"createServerSocket(II)Ljava/net/ServerSocket;": {ParamSlots: 2,
+			GFunction: sslServerSocketFactoryCreateServerSocket},
+		"createServerSocket(IILjava/net/InetAddress;)Ljava/net/ServerSocket;": {ParamSlots: 3,
+			GFunction: sslServerSocketFactoryCreateServerSocket},
+		"getDefault()Ljavax/net/ServerSocketFactory;":   {ParamSlots: 0, GFunction: sslServerSocketFactoryGetDefault},
+		"getDefaultCipherSuites()[Ljava/lang/String;":   {ParamSlots: 0, GFunction: sslGetDefaultCipherSuites},
+		"getSupportedCipherSuites()[Ljava/lang/String;": {ParamSlots: 0, GFunction: sslGetSupportedCipherSuites},
+	} {
+		ghelpers.MethodSignatures[sslServerSocketFactoryClassName+"."+sig] = gmeth
+	}
+}
+
+func Load_Javax_Net_Ssl_SSLSocket() {
+	for sig, gmeth := range socketMethods {
+		ghelpers.MethodSignatures[sslSocketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V": {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"getApplicationProtocol()Ljava/lang/String;":      {ParamSlots: 0, GFunction: sslSocketGetApplicationProtocol},
+		"getEnabledCipherSuites()[Ljava/lang/String;":     {ParamSlots: 0, GFunction: sslSocketGetEnabledCipherSuites},
+		"getEnabledProtocols()[Ljava/lang/String;":        {ParamSlots: 0, GFunction: sslSocketGetEnabledProtocols},
+		"getNeedClientAuth()Z":                            {ParamSlots: 0, GFunction: sslSocketGetNeedClientAuth},
+		"getSSLParameters()Ljavax/net/ssl/SSLParameters;": {ParamSlots: 0, GFunction: sslSocketGetSSLParameters},
+		"getSession()Ljavax/net/ssl/SSLSession;": {ParamSlots: 0, GFunction: sslSocketGetSession,
+			NeedsContext: true},
+		"getSupportedCipherSuites()[Ljava/lang/String;":    {ParamSlots: 0, GFunction: sslGetSupportedCipherSuites},
+		"getSupportedProtocols()[Ljava/lang/String;":       {ParamSlots: 0, GFunction: sslGetSupportedProtocols},
+		"getUseClientMode()Z":                              {ParamSlots: 0, GFunction: sslSocketGetUseClientMode},
+		"getWantClientAuth()Z":                             {ParamSlots: 0, GFunction: sslSocketGetWantClientAuth},
+		"setEnabledCipherSuites([Ljava/lang/String;)V":     {ParamSlots: 1, GFunction: sslSocketSetEnabledCipherSuites},
+		"setEnabledProtocols([Ljava/lang/String;)V":        {ParamSlots: 1, GFunction: sslSocketSetEnabledProtocols},
+		"setNeedClientAuth(Z)V":                            {ParamSlots: 1, GFunction: sslSocketSetNeedClientAuth},
+		"setSSLParameters(Ljavax/net/ssl/SSLParameters;)V": {ParamSlots: 1, GFunction: sslSocketSetSSLParameters},
+		"setUseClientMode(Z)V":                             {ParamSlots: 1, GFunction: sslSocketSetUseClientMode},
+		"setWantClientAuth(Z)V":                            {ParamSlots: 1, GFunction: sslSocketSetWantClientAuth},
+		"startHandshake()V":                                {ParamSlots: 0, GFunction: sslSocketStartHandshake, NeedsContext: true},
+		"toString()Ljava/lang/String;":                     {ParamSlots: 0, GFunction: sslSocketToString},
+	} {
+		ghelpers.MethodSignatures[sslSocketClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range serverSocketMethods {
+		ghelpers.MethodSignatures[sslServerSocketClassName+"."+sig] = gmeth
+	}
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                                      {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"accept()Ljava/net/Socket;":                        {ParamSlots: 0, GFunction: sslServerSocketAccept, NeedsContext: true},
+		"getEnabledCipherSuites()[Ljava/lang/String;":      {ParamSlots: 0, GFunction: sslServerSocketGetEnabledCipherSuites},
+		"getEnabledProtocols()[Ljava/lang/String;":         {ParamSlots: 0, GFunction: sslServerSocketGetEnabledProtocols},
+		"getNeedClientAuth()Z":                             {ParamSlots: 0, GFunction: sslServerSocketGetNeedClientAuth},
+		"getSSLParameters()Ljavax/net/ssl/SSLParameters;":  {ParamSlots: 0, GFunction: sslServerSocketGetSSLParameters},
+		"getSupportedCipherSuites()[Ljava/lang/String;":    {ParamSlots: 0, GFunction: sslGetSupportedCipherSuites},
+		"getSupportedProtocols()[Ljava/lang/String;":       {ParamSlots: 0, GFunction: sslGetSupportedProtocols},
+		"getUseClientMode()Z":                              {ParamSlots: 0, GFunction: sslServerSocketGetUseClientMode},
+		"getWantClientAuth()Z":                             {ParamSlots: 0, GFunction: sslServerSocketGetWantClientAuth},
+		"setEnabledCipherSuites([Ljava/lang/String;)V":     {ParamSlots: 1, GFunction: sslServerSocketSetEnabledCipherSuites},
+		"setEnabledProtocols([Ljava/lang/String;)V":        {ParamSlots: 1, GFunction: sslServerSocketSetEnabledProtocols},
+		"setNeedClientAuth(Z)V":                            {ParamSlots: 1, GFunction: sslServerSocketSetNeedClientAuth},
+		"setSSLParameters(Ljavax/net/ssl/SSLParameters;)V": {ParamSlots: 1, GFunction: sslServerSocketSetSSLParameters},
+		"setUseClientMode(Z)V":                             {ParamSlots: 1, GFunction: sslServerSocketSetUseClientMode},
+		"setWantClientAuth(Z)V":                            {ParamSlots: 1, GFunction: sslServerSocketSetWantClientAuth},
+		"toString()Ljava/lang/String;":                     {ParamSlots: 0, GFunction: sslServerSocketToString},
+	} {
+		ghelpers.MethodSignatures[sslServerSocketClassName+"."+sig] = gmeth
+	}
+}
+
+// sslSocket is the TLS state of the socket of an SSLSocket. Its settings are guarded by the
+// socket's lock.
+type sslSocket struct {
+	ctx        *sslContext
+	params     *sslParameters
+	clientMode bool
+	peerHost   string // the host name the socket was created with, or ""
+	autoClose  bool   // false if closing a layered socket leaves the socket below it open
+
+	// callbacks carries Java code that the handshake needs run on the waiting thread. closing
+	// is closed with the socket, so that the handshake stops asking.
+	callbacks chan func(fs *list.List)
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	mu        sync.Mutex
+	localCert *tls.Certificate // the key material sent to the peer, if any
+	session   *object.Object   // set when the handshake is done
+}
+
+// sslHandshakeError is an error of the handshake that has the JDK's message.
+type sslHandshakeError struct {
+	msg string
+}
+
+func (e *sslHandshakeError) Error() string {
+	return e.msg
+}
+
+// errSSLTimeout ends a handshake that has taken longer than the SO_TIMEOUT.
+var errSSLTimeout = errors.New("handshake timed out")
+
+func newSSLSocket(ctx *sslContext, params *sslParameters, clientMode bool) *sslSocket {
+	return &sslSocket{ctx: ctx, params: params, clientMode: clientMode, autoClose: true,
+		callbacks: make(chan func(fs *list.List)), closing: make(chan struct{})}
+}
+
+// newSSLSocketObject returns an SSLSocket for s.
+func newSSLSocketObject(s *socket) *object.Object {
+	className := sslSocketClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[netStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+	return obj
+}
+
+// getSSLSocket returns the Go state of an SSLSocket.
+func getSSLSocket(obj any) (*socket, *ghelpers.GErrBlk) {
+	s, gerr := getSocket(obj)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if s.ssl == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "SSLSocket is not initialized")
+	}
+	return s, nil
+}
+
+// shutdown stops the handshake's callbacks when the socket is closed.
+func (ss *sslSocket) shutdown() {
+	ss.closeOnce.Do(func() { close(ss.closing) })
+}
+
+// peerHost returns the host name of the peer, or its address if there is no name.
+func (s *socket) peerHost() string {
+	if s.ssl.peerHost != "" {
+		return s.ssl.peerHost
+	}
+	if s.remote == nil {
+		return ""
+	}
+	ia := s.remote.FieldTable[netStateField].Fvalue.(*inetAddress)
+	if ia.hasHost {
+		return ia.host
+	}
+	return ia.hostAddress()
+}
+
+// cipherSuitesByName has the cipher suites of crypto/tls by name.
+var cipherSuitesByName = func() map[string]*tls.CipherSuite {
+	suites := make(map[string]*tls.CipherSuite)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites[suite.Name] = suite
+	}
+	return suites
+}()
+
+// tlsConfig returns the configuration of the handshake. It is called with the socket locked.
+func (s *socket) tlsConfig() (*tls.Config, *ghelpers.GErrBlk) {
+	ss := s.ssl
+	ss.ctx.mu.Lock()
+	keys, trust, javaTrust := ss.ctx.keys, ss.ctx.trust, ss.ctx.javaTrust
+	ss.ctx.mu.Unlock()
+
+	var minVersion, maxVersion uint16
+	for _, protocol := range ss.params.protocols {
+		version := sslProtocolVersions[protocol]
+		if minVersion == 0 || version < minVersion {
+			minVersion = version
+		}
+		maxVersion = max(maxVersion, version)
+	}
+	var suites []uint16
+	tls13 := false
+	for _, name := range ss.params.cipherSuites {
+		suite := cipherSuitesByName[name]
+		if slices.Equal(suite.SupportedVersions, []uint16{tls.VersionTLS13}) {
+			tls13 = true
+		} else {
+			suites = append(suites, suite.ID)
+		}
+	}
+	if !tls13 && maxVersion == tls.VersionTLS13 {
+		maxVersion = tls.VersionTLS12
+	}
+	if len(suites) == 0 && minVersion < tls.VersionTLS13 {
+		minVersion = tls.VersionTLS13
+	}
+	if maxVersion == 0 || minVersion > maxVersion {
+		return nil, ghelpers.GetGErrBlk(excNames.SSLHandshakeException,
+			"No appropriate protocol (protocol is disabled or cipher suites are inappropriate)")
+	}
+
+	host := s.peerHost()
+	config := &tls.Config{
+		MinVersion:         minVersion,
+		MaxVersion:         maxVersion,
+		CipherSuites:       suites,
+		NextProtos:         slices.Clone(ss.params.applicationProtocols),
+		InsecureSkipVerify: true, // the trust manager verifies the peer, in VerifyConnection
+	}
+	var certs []tls.Certificate
+	if keys != nil {
+		certs = keys.certificates()
+	}
+	if ss.clientMode {
+		config.ServerName = host
+		config.GetClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			for i := range certs {
+				if cri.SupportsCertificate(&certs[i]) == nil {
+					return ss.sending(&certs[i]), nil
+				}
+			}
+			return &tls.Certificate{}, nil
+		}
+	} else {
+		config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			for i := range certs {
+				if hello.SupportsCertificate(&certs[i]) == nil {
+					return ss.sending(&certs[i]), nil
+				}
+			}
+			return nil, &sslHandshakeError{"No available authentication scheme"}
+		}
+		switch {
+		case ss.params.needClientAuth:
+			config.ClientAuth = tls.RequireAnyClientCert
+		case ss.params.wantClientAuth:
+			config.ClientAuth = tls.RequestClientCert
+		}
+	}
+
+	endpoint := strings.ToUpper(ss.params.endpointIdentification)
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		chain := cs.PeerCertificates
+		if len(chain) == 0 { // a client that has not sent a certificate, which the server allows
+			return nil
+		}
+		usage, checkHost := x509.ExtKeyUsageClientAuth, ""
+		if ss.clientMode {
+			usage = x509.ExtKeyUsageServerAuth
+			if endpoint == "HTTPS" || endpoint == "LDAPS" {
+				checkHost = host
+			}
+		}
+		var gerr *ghelpers.GErrBlk
+		if javaTrust != nil {
+			if gerr = ss.checkTrustedInJava(javaTrust, cs); gerr == nil && checkHost != "" {
+				gerr = checkHostname(chain[0], checkHost)
+			}
+		} else {
+			gerr = trust.verify(chain, usage, checkHost)
+		}
+		if gerr != nil {
+			return &sslHandshakeError{gerr.ErrMsg}
+		}
+		return nil
+	}
+	return config, nil
+}
+
+// sending records the key material that the handshake sends to the peer.
+func (ss *sslSocket) sending(cert *tls.Certificate) *tls.Certificate {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.localCert = cert
+	return cert
+}
+
+// checkTrustedInJava runs the checkServerTrusted or checkClientTrusted of an X509TrustManager
+// written in Java on a thread that waits for the handshake.
+func (ss *sslSocket) checkTrustedInJava(tm *object.Object, cs tls.ConnectionState) *ghelpers.GErrBlk {
+	methName := "checkClientTrusted"
+	authType := keyAlgorithm(cs.PeerCertificates[0].PublicKey)
+	if ss.clientMode {
+		methName = "checkServerTrusted"
+		name := tls.CipherSuiteName(cs.CipherSuite)
+		if kx, _, ok := strings.Cut(strings.TrimPrefix(name, "TLS_"), "_WITH_"); ok && cs.Version != tls.VersionTLS13 {
+			authType = kx
+		}
+	}
+	result := make(chan any, 1)
+	call := func(fs *list.List) {
+		result <- ghelpers.InvokeMethodOnObject(fs, tm, methName,
+			"([Ljava/security/cert/X509Certificate;Ljava/lang/String;)V",
+			x509CertificateArray(cs.PeerCertificates), object.StringObjectFromGoString(authType))
+	}
+	select {
+	case ss.callbacks <- call:
+	case <-ss.closing:
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Socket closed")
+	}
+	if gerr, ok := (<-result).(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	return nil
+}
+
+// awaitTLS runs op, a handshake or a write, in a goroutine and waits for it for at most timeout
+// (0 is for ever), running the callbacks of the handshake. If the thread is interrupted, the
+// socket is closed.
+func (s *socket) awaitTLS(fs *list.List, timeout time.Duration, op func() error) (error, *ghelpers.GErrBlk) {
+	done := make(chan error, 1)
+	go func() { done <- op() }()
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(socketPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err, nil
+		case call := <-s.ssl.callbacks:
+			call(fs)
+		case <-deadline:
+			return errSSLTimeout, nil
+		case <-ticker.C:
+			if ghelpers.TakeInterrupt(fs) {
+				return nil, closedByInterrupt(func() { _ = s.close() })
+			}
+		}
+	}
+}
+
+// handshake returns the TLS connection of the socket once it has been handshaken. A socket
+// whose handshake fails is closed.
+func (s *socket) handshake(fs *list.List) (*tls.Conn, *ghelpers.GErrBlk) {
+	conn, gerr := s.openConn()
+	if gerr != nil {
+		return nil, gerr
+	}
+	s.mu.Lock()
+	if s.tls == nil {
+		config, gerr := s.tlsConfig()
+		if gerr != nil {
+			s.mu.Unlock()
+			return nil, gerr
+		}
+		var raw net.Conn = conn
+		if !s.ssl.autoClose {
+			raw = noCloseConn{conn}
+		}
+		if s.ssl.clientMode {
+			s.tls = tls.Client(raw, config)
+		} else {
+			s.tls = tls.Server(raw, config)
+		}
+	}
+	tc, timeout := s.tls, s.soTimeout
+	s.mu.Unlock()
+	if s.handshaken() {
+		return tc, nil
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+	err, gerr := s.awaitTLS(fs, timeout, tc.Handshake)
+	switch {
+	case gerr != nil:
+		return nil, gerr
+	case err == errSSLTimeout:
+		_ = s.close()
+		return nil, ghelpers.GetGErrBlk(excNames.SocketTimeoutException, "Read timed out")
+	case err != nil:
+		_ = s.close()
+		return nil, sslError(err, true)
+	}
+
+	session := newSSLSessionObject(s, tc.ConnectionState())
+	s.ssl.mu.Lock()
+	defer s.ssl.mu.Unlock()
+	if s.ssl.session == nil {
+		s.ssl.session = session
+	}
+	return tc, nil
+}
+
+// handshaken reports whether the handshake of the socket is done.
+func (s *socket) handshaken() bool {
+	s.ssl.mu.Lock()
+	defer s.ssl.mu.Unlock()
+	return s.ssl.session != nil
+}
+
+// writeTLS writes all of p to the TLS connection. A TLS connection cannot be written to with
+// deadlines, which break it, so the write runs in a goroutine.
+func (s *socket) writeTLS(fs *list.List, p []byte) *ghelpers.GErrBlk {
+	tc, gerr := s.handshake(fs)
+	if gerr != nil {
+		return gerr
+	}
+	err, gerr := s.awaitTLS(fs, 0, func() error {
+		_, err := tc.Write(p)
+		return err
+	})
+	switch {
+	case gerr != nil:
+		return gerr
+	case err != nil:
+		return sslError(err, false)
+	}
+	return nil
+}
+
+// sslAlerts are the JDK's names of the TLS alerts whose names in Go differ by more than their
+// spaces.
+var sslAlerts = map[string]string{
+	"unknown certificate authority":  "unknown_ca",
+	"protocol version not supported": "protocol_version",
+	"error decoding message":         "decode_error",
+	"error decrypting message":       "decrypt_error",
+	"unsupported extension":          "unsupported_extension",
+}
+
+// sslError converts an error of a TLS connection to the exception that the JDK throws. An
+// error of a handshake is an SSLHandshakeException.
+func sslError(err error, handshaking bool) *ghelpers.GErrBlk {
+	exc := excNames.SSLException
+	if handshaking {
+		exc = excNames.SSLHandshakeException
+	}
+	var handshakeErr *sslHandshakeError
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &handshakeErr):
+		return ghelpers.GetGErrBlk(excNames.SSLHandshakeException, handshakeErr.msg)
+	case errors.As(err, &opErr) && opErr.Op == "remote error":
+		alert := strings.TrimPrefix(opErr.Err.Error(), "tls: ")
+		name, ok := sslAlerts[alert]
+		if !ok {
+			name = strings.ReplaceAll(alert, " ", "_")
+		}
+		return ghelpers.GetGErrBlk(exc, "Received fatal alert: "+name)
+	case handshaking && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)):
+		return ghelpers.GetGErrBlk(exc, "Remote host terminated the handshake")
+	case strings.HasPrefix(err.Error(), "tls: "):
+		return ghelpers.GetGErrBlk(exc, strings.TrimPrefix(err.Error(), "tls: "))
+	}
+	return socketError(err)
+}
+
+// checkHostname checks that cert is for host, with the JDK's messages.
+func checkHostname(cert *x509.Certificate, host string) *ghelpers.GErrBlk {
+	if cert.VerifyHostname(host) == nil {
+		return nil
+	}
+	var errMsg string
+	switch {
+	case net.ParseIP(host) != nil:
+		errMsg = fmt.Sprintf("No subject alternative names matching IP address %s found", host)
+	case len(cert.DNSNames) == 0:
+		errMsg = "No subject alternative names present"
+	default:
+		errMsg = fmt.Sprintf("No subject alternative DNS name matching %s found.", host)
+	}
+	return ghelpers.GetGErrBlk(excNames.CertificateException, errMsg)
+}
+
+// noCloseConn is the connection of a layered socket that does not close the socket below it.
+type noCloseConn struct {
+	*net.TCPConn
+}
+
+func (noCloseConn) Close() error {
+	return nil
+}
+
+// getDefault() of SSLSocketFactory and of SSLServerSocketFactory
+func sslSocketFactoryGetDefault([]any) any {
+	return newSSLContextObject(sslSocketFactoryClassName, getDefaultSSLContext().FieldTable[netStateField].Fvalue.(*sslContext))
+}
+
+func sslServerSocketFactoryGetDefault([]any) any {
+	return newSSLContextObject(sslServerSocketFactoryClassName,
+		getDefaultSSLContext().FieldTable[netStateField].Fvalue.(*sslContext))
+}
+
+// getDefaultCipherSuites()[Ljava/lang/String; of the factories
+func sslGetDefaultCipherSuites([]any) any {
+	return stringArrayObject(defaultCipherSuites())
+}
+
+// getSupportedCipherSuites()[Ljava/lang/String; of the factories and the sockets
+func sslGetSupportedCipherSuites([]any) any {
+	return stringArrayObject(supportedCipherSuites())
+}
+
+// getSupportedProtocols()[Ljava/lang/String; of the sockets
+func sslGetSupportedProtocols([]any) any {
+	return stringArrayObject(sslProtocols)
+}
+
+// javax/net/ssl/SSLSocketFactory.createSocket()Ljava/net/Socket; and the forms that connect the
+// socket to a host, a String or an InetAddress, and a port
+func sslSocketFactoryCreateSocket(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ss := newSSLSocket(ctx, ctx.defaultParameters(), true)
+	if len(params) == 1 {
+		s := newSocket()
+		s.ssl = ss
+		return newSSLSocketObject(s)
+	}
+
+	className := sslSocketClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	ret := socketInitConnect(append([]any{fs, obj}, params[1:]...))
+	s, _ := obj.FieldTable[netStateField].Fvalue.(*socket)
+	if s != nil {
+		s.ssl = ss
+	}
+	if ret != nil {
+		return ret
+	}
+	if host, ok := params[1].(*object.Object); ok && !object.IsNull(host) {
+		if _, isAddr := host.FieldTable[netStateField].Fvalue.(*inetAddress); !isAddr {
+			ss.peerHost = object.GoStringFromStringObject(host)
+		}
+	}
+	return obj
+}
+
+// javax/net/ssl/SSLSocketFactory.createSocket(Ljava/net/Socket;Ljava/lang/String;IZ)Ljava/net/Socket;
+// -- an SSLSocket layered over a connected socket, which is closed with it if autoClose is true
+func sslSocketFactoryCreateLayeredSocket(params []any) any {
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	base, gerr := getSocket(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	base.mu.Lock()
+	conn, connected, closed := base.conn, base.connected, base.closed
+	remote, timeout := base.remote, base.soTimeout
+	base.mu.Unlock()
+	if !connected || closed {
+		return ghelpers.GetGErrBlk(excNames.SocketException, "Underlying socket is not connected")
+	}
+
+	s := newSocket()
+	s.conn, s.connected, s.remote, s.soTimeout = conn, true, remote, timeout
+	s.ssl = newSSLSocket(ctx, ctx.defaultParameters(), true)
+	s.ssl.autoClose = params[4].(int64) == types.JavaBoolTrue
+	if host, ok := params[2].(*object.Object); ok && !object.IsNull(host) {
+		s.ssl.peerHost = object.GoStringFromStringObject(host)
+	}
+	return newSSLSocketObject(s)
+}
+
+// javax/net/ssl/SSLSocket.startHandshake()V
+func sslSocketStartHandshake(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	_, gerr = s.handshake(fs)
+	return gerrOrNil(gerr)
+}
+
+// javax/net/ssl/SSLSocket.getSession()Ljavax/net/ssl/SSLSession; -- handshakes if need be. If
+// the handshake fails, the session is an invalid one with no cipher suite, as in the JDK.
+func sslSocketGetSession(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if _, gerr = s.handshake(fs); gerr != nil {
+		return newNullSSLSessionObject()
+	}
+	s.ssl.mu.Lock()
+	defer s.ssl.mu.Unlock()
+	return s.ssl.session
+}
+
+// sslSocketState runs get on the socket in params[0], under its lock.
+func sslSocketState(params []any, get func(s *socket) any) any {
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return get(s)
+}
+
+// sslSocketSetting runs set on the settings of the socket in params[0], under its lock.
+func sslSocketSetting(params []any, set func(s *socket) *ghelpers.GErrBlk) any {
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return gerrOrNil(set(s))
+}
+
+func sslSocketGetEnabledCipherSuites(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return stringArrayObject(s.ssl.params.cipherSuites) })
+}
+
+func sslSocketSetEnabledCipherSuites(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		return setEnabledCipherSuites(s.ssl.params, params[1])
+	})
+}
+
+func sslSocketGetEnabledProtocols(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return stringArrayObject(s.ssl.params.protocols) })
+}
+
+func sslSocketSetEnabledProtocols(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		return setEnabledProtocols(s.ssl.params, params[1])
+	})
+}
+
+func sslSocketGetNeedClientAuth(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return types.ConvertGoBoolToJavaBool(s.ssl.params.needClientAuth) })
+}
+
+func sslSocketSetNeedClientAuth(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		s.ssl.params.needClientAuth, s.ssl.params.wantClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslSocketGetWantClientAuth(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return types.ConvertGoBoolToJavaBool(s.ssl.params.wantClientAuth) })
+}
+
+func sslSocketSetWantClientAuth(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		s.ssl.params.wantClientAuth, s.ssl.params.needClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslSocketGetUseClientMode(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return types.ConvertGoBoolToJavaBool(s.ssl.clientMode) })
+}
+
+// javax/net/ssl/SSLSocket.setUseClientMode(Z)V -- only before the handshake
+func sslSocketSetUseClientMode(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		if s.tls != nil {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Cannot change mode after SSL traffic has started")
+		}
+		s.ssl.clientMode = params[1].(int64) == types.JavaBoolTrue
+		return nil
+	})
+}
+
+func sslSocketGetSSLParameters(params []any) any {
+	return sslSocketState(params, func(s *socket) any { return newSSLParametersObject(s.ssl.params) })
+}
+
+func sslSocketSetSSLParameters(params []any) any {
+	return sslSocketSetting(params, func(s *socket) *ghelpers.GErrBlk {
+		return setSSLParameters(s.ssl.params, params[1])
+	})
+}
+
+// javax/net/ssl/SSLSocket.getApplicationProtocol()Ljava/lang/String; -- null before the handshake,
+// and "" if no application protocol was negotiated
+func sslSocketGetApplicationProtocol(params []any) any {
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.ssl.mu.Lock()
+	defer s.ssl.mu.Unlock()
+	if s.ssl.session == nil {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(getSSLSession(s.ssl.session).state.NegotiatedProtocol)
+}
+
+// javax/net/ssl/SSLSocket.toString()Ljava/lang/String;
+func sslSocketToString(params []any) any {
+	s, gerr := getSSLSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	s.ssl.mu.Lock()
+	session := s.ssl.session
+	s.ssl.mu.Unlock()
+	if session == nil {
+		session = newNullSSLSessionObject()
+	}
+	return sslSocketState(params, func(s *socket) any {
+		port := 0
+		if s.connected {
+			port = s.conn.RemoteAddr().(*net.TCPAddr).Port
+		}
+		str := fmt.Sprintf("SSLSocket[hostname=%s, port=%d, %s]", s.peerHost(), port,
+			session.FieldTable[netStateField].Fvalue.(*sslSession).String())
+		return object.StringObjectFromGoString(str)
+	})
+}
+
+// setEnabledCipherSuites, setEnabledProtocols and setSSLParameters change the settings of a
+// socket or a server socket.
+
+func setEnabledCipherSuites(p *sslParameters, arg any) *ghelpers.GErrBlk {
+	if arr, ok := arg.(*object.Object); !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "CipherSuites cannot be null")
+	}
+	names, gerr := stringArrayArg(arg)
+	if gerr == nil {
+		gerr = checkCipherSuites(names)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	p.cipherSuites = names
+	return nil
+}
+
+func setEnabledProtocols(p *sslParameters, arg any) *ghelpers.GErrBlk {
+	if arr, ok := arg.(*object.Object); !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Protocols cannot be null")
+	}
+	names, gerr := stringArrayArg(arg)
+	if gerr == nil {
+		gerr = checkProtocols(names)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	p.protocols = names
+	return nil
+}
+
+// setSSLParameters sets the settings of p from an SSLParameters. Its cipher suites and protocols
+// are only set if they are not null.
+func setSSLParameters(p *sslParameters, arg any) *ghelpers.GErrBlk {
+	from, gerr := getSSLParameters(arg)
+	if gerr != nil {
+		return gerr
+	}
+	if from.cipherSuites != nil {
+		if gerr = checkCipherSuites(from.cipherSuites); gerr != nil {
+			return gerr
+		}
+	}
+	if from.protocols != nil {
+		if gerr = checkProtocols(from.protocols); gerr != nil {
+			return gerr
+		}
+	}
+	from = from.clone()
+	if from.cipherSuites != nil {
+		p.cipherSuites = from.cipherSuites
+	}
+	if from.protocols != nil {
+		p.protocols = from.protocols
+	}
+	p.needClientAuth, p.wantClientAuth = from.needClientAuth, from.wantClientAuth
+	p.endpointIdentification = from.endpointIdentification
+	p.applicationProtocols = from.applicationProtocols
+	return nil
+}
+
+// sslServerSocket is the TLS state of an SSLServerSocket: the settings of the sockets it
+// accepts.
+type sslServerSocket struct {
+	mu         sync.Mutex
+	ctx        *sslContext
+	params     *sslParameters
+	clientMode bool
+}
+
+func getSSLServerSocket(obj any) (*sslServerSocket, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "SSLServerSocket is null")
+	}
+	sss, ok := o.FieldTable[sslStateField].Fvalue.(*sslServerSocket)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.SocketException, "SSLServerSocket is not initialized")
+	}
+	return sss, nil
+}
+
+// javax/net/ssl/SSLServerSocketFactory.createServerSocket()Ljava/net/ServerSocket; and the forms
+// that bind the socket, as the constructors of ServerSocket do
+func sslServerSocketFactoryCreateServerSocket(params []any) any {
+	ctx, gerr := getSSLContext(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	className := sslServerSocketClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	if ret := serverSocketInit(append([]any{obj}, params[1:]...)); ret != nil {
+		return ret
+	}
+	sss := &sslServerSocket{ctx: ctx, params: ctx.defaultParameters()}
+	obj.FieldTable[sslStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: sss}
+	return obj
+}
+
+// javax/net/ssl/SSLServerSocket.accept()Ljava/net/Socket; -- an SSLSocket in server mode, which
+// handshakes when it is first used
+func sslServerSocketAccept(params []any) any {
+	_, args := ghelpers.SplitContext(params)
+	sss, gerr := getSSLServerSocket(args[0])
+	if gerr != nil {
+		return gerr
+	}
+	ret := serverSocketAccept(params)
+	accepted, ok := ret.(*object.Object)
+	if !ok {
+		return ret
+	}
+	s := accepted.FieldTable[netStateField].Fvalue.(*socket)
+	sss.mu.Lock()
+	s.ssl = newSSLSocket(sss.ctx, sss.params.clone(), sss.clientMode)
+	sss.mu.Unlock()
+	return newSSLSocketObject(s)
+}
+
+// sslServerSocketState runs get on the settings of the server socket in params[0], under its
+// lock.
+func sslServerSocketState(params []any, get func(sss *sslServerSocket) any) any {
+	sss, gerr := getSSLServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	sss.mu.Lock()
+	defer sss.mu.Unlock()
+	return get(sss)
+}
+
+func sslServerSocketSetting(params []any, set func(sss *sslServerSocket) *ghelpers.GErrBlk) any {
+	sss, gerr := getSSLServerSocket(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	sss.mu.Lock()
+	defer sss.mu.Unlock()
+	return gerrOrNil(set(sss))
+}
+
+func sslServerSocketGetEnabledCipherSuites(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any { return stringArrayObject(sss.params.cipherSuites) })
+}
+
+func sslServerSocketSetEnabledCipherSuites(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		return setEnabledCipherSuites(sss.params, params[1])
+	})
+}
+
+func sslServerSocketGetEnabledProtocols(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any { return stringArrayObject(sss.params.protocols) })
+}
+
+func sslServerSocketSetEnabledProtocols(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		return setEnabledProtocols(sss.params, params[1])
+	})
+}
+
+func sslServerSocketGetNeedClientAuth(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any {
+		return types.ConvertGoBoolToJavaBool(sss.params.needClientAuth)
+	})
+}
+
+func sslServerSocketSetNeedClientAuth(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		sss.params.needClientAuth, sss.params.wantClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslServerSocketGetWantClientAuth(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any {
+		return types.ConvertGoBoolToJavaBool(sss.params.wantClientAuth)
+	})
+}
+
+func sslServerSocketSetWantClientAuth(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		sss.params.wantClientAuth, sss.params.needClientAuth = params[1].(int64) == types.JavaBoolTrue, false
+		return nil
+	})
+}
+
+func sslServerSocketGetUseClientMode(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any { return types.ConvertGoBoolToJavaBool(sss.clientMode) })
+}
+
+func sslServerSocketSetUseClientMode(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		sss.clientMode = params[1].(int64) == types.JavaBoolTrue
+		return nil
+	})
+}
+
+func sslServerSocketGetSSLParameters(params []any) any {
+	return sslServerSocketState(params, func(sss *sslServerSocket) any { return newSSLParametersObject(sss.params) })
+}
+
+func sslServerSocketSetSSLParameters(params []any) any {
+	return sslServerSocketSetting(params, func(sss *sslServerSocket) *ghelpers.GErrBlk {
+		return setSSLParameters(sss.params, params[1])
+	})
+}
+
+// javax/net/ssl/SSLServerSocket.toString()Ljava/lang/String; -- as the JDK's, that of ServerSocket
+// marked as SSL
+func sslServerSocketToString(params []any) any {
+	ret := serverSocketToString(params)
+	str, ok := ret.(*object.Object)
+	if !ok {
+		return ret
+	}
+	return object.StringObjectFromGoString("[SSL: " + object.GoStringFromStringObject(str) + "]")
+}
@@ -0,0 +1,388 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNet
+
+import (
+	"container/list"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sslTestIdentity is a self-signed certificate for localhost, and its key.
+type sslTestIdentity struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newSSLTestIdentity(t *testing.T, cn string, ips ...net.IP) sslTestIdentity {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	return sslTestIdentity{cert: cert, key: key}
+}
+
+func sslTestPassword() *object.Object {
+	return object.MakePrimitiveObject("[C", types.CharArray, []int64{'s', 'e', 'c', 'r', 'e', 't'})
+}
+
+// invokeSSLTest runs a G function of another package by its signature.
+func invokeSSLTest(t *testing.T, sig string, params ...any) any {
+	t.Helper()
+	gmeth, ok := ghelpers.MethodSignatures[sig]
+	if !ok {
+		t.Fatalf("%s is not registered", sig)
+	}
+	ret := gmeth.GFunction(params)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		t.Fatalf("%s: %s", sig, gerr.ErrMsg)
+	}
+	return ret
+}
+
+// newSSLTestKeyStore returns a PKCS12 KeyStore with the identity, if there is one, as the
+// private key entry "identity", and the trusted certificates.
+func newSSLTestKeyStore(t *testing.T, identity *sslTestIdentity, trusted ...*x509.Certificate) *object.Object {
+	t.Helper()
+	javaSecurity.Load_Security_KeyStore()
+	ks := invokeSSLTest(t, "java/security/KeyStore.getInstance(Ljava/lang/String;)Ljava/security/KeyStore;",
+		object.StringObjectFromGoString("PKCS12")).(*object.Object)
+	invokeSSLTest(t, "java/security/KeyStore.load(Ljava/io/InputStream;[C)V", ks, object.Null, object.Null)
+	if identity != nil {
+		key, err := javaSecurity.NewPrivateKeyObject(identity.key)
+		if err != nil {
+			t.Fatalf("NewPrivateKeyObject: %v", err)
+		}
+		chain := certificateArray([]*x509.Certificate{identity.cert})
+		invokeSSLTest(t, "java/security/KeyStore.setKeyEntry(Ljava/lang/String;Ljava/security/Key;[C[Ljava/security/cert/Certificate;)V",
+			ks, object.StringObjectFromGoString("identity"), key, sslTestPassword(), chain)
+	}
+	for i, cert := range trusted {
+		invokeSSLTest(t, "java/security/KeyStore.setCertificateEntry(Ljava/lang/String;Ljava/security/cert/Certificate;)V",
+			ks, object.StringObjectFromGoString("trusted"+string(rune('a'+i))), javaSecurity.NewX509CertificateObject(cert))
+	}
+	return ks
+}
+
+// newSSLTestContext returns an initialized SSLContext with the identity as its key material, if
+// there is one, that trusts the trusted certificates.
+func newSSLTestContext(t *testing.T, identity *sslTestIdentity, trusted ...*x509.Certificate) *object.Object {
+	t.Helper()
+	kmf := keyManagerFactoryGetInstance([]any{object.StringObjectFromGoString("SunX509")}).(*object.Object)
+	if ret := keyManagerFactoryInit([]any{kmf, newSSLTestKeyStore(t, identity), sslTestPassword()}); ret != nil {
+		t.Fatalf("KeyManagerFactory.init: %v", ret)
+	}
+	tmf := trustManagerFactoryGetInstance([]any{object.StringObjectFromGoString("PKIX")}).(*object.Object)
+	if ret := trustManagerFactoryInit([]any{tmf, newSSLTestKeyStore(t, nil, trusted...)}); ret != nil {
+		t.Fatalf("TrustManagerFactory.init: %v", ret)
+	}
+	ctx := sslContextGetInstance([]any{object.StringObjectFromGoString("TLS")}).(*object.Object)
+	ret := sslContextInit([]any{ctx, keyManagerFactoryGetKeyManagers([]any{kmf}),
+		trustManagerFactoryGetTrustManagers([]any{tmf}), object.Null})
+	if ret != nil {
+		t.Fatalf("SSLContext.init: %v", ret)
+	}
+	return ctx
+}
+
+// newSSLTestServer returns an SSLServerSocket of ctx bound to an ephemeral port of the loopback
+// address.
+func newSSLTestServer(t *testing.T, ctx *object.Object) (*object.Object, int64) {
+	t.Helper()
+	factory := sslContextGetServerSocketFactory([]any{ctx})
+	ret := sslServerSocketFactoryCreateServerSocket([]any{factory, int64(0), int64(5), loopbackAddress()})
+	server, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("createServerSocket: %v", ret)
+	}
+	t.Cleanup(func() { serverSocketClose([]any{server}) })
+	return server, serverSocketGetLocalPort([]any{server}).(int64)
+}
+
+// sslTestEcho accepts one connection on server and echoes what it reads until the end of the
+// stream. The accepted socket, or the error that ended the echo, is sent on the channel.
+func sslTestEcho(server *object.Object) <-chan any {
+	result := make(chan any, 2)
+	go func() {
+		ret := sslServerSocketAccept([]any{list.New(), server})
+		conn, ok := ret.(*object.Object)
+		if !ok {
+			result <- ret
+			return
+		}
+		defer socketClose([]any{conn})
+		result <- conn
+		in := socketGetInputStream([]any{conn})
+		out := socketGetOutputStream([]any{conn})
+		buf := object.MakeArrayFromRawArray(make([]byte, 64))
+		for {
+			n := socketInputStreamRead([]any{list.New(), in, buf})
+			count, ok := n.(int64)
+			if !ok || count < 0 {
+				result <- n
+				return
+			}
+			if ret := socketOutputStreamWrite([]any{list.New(), out, buf, int64(0), count}); ret != nil {
+				result <- ret
+				return
+			}
+		}
+	}()
+	return result
+}
+
+// connectSSLTest returns an SSLSocket of ctx connected to port on the loopback address.
+func connectSSLTest(t *testing.T, ctx *object.Object, host any, port int64) *object.Object {
+	t.Helper()
+	factory := sslContextGetSocketFactory([]any{ctx})
+	ret := sslSocketFactoryCreateSocket([]any{list.New(), factory, host, port})
+	client, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("createSocket: %v", ret)
+	}
+	t.Cleanup(func() { socketClose([]any{client}) })
+	return client
+}
+
+func TestLoad_Javax_Net_Ssl(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Javax_Net_Ssl_SSLContext()
+	Load_Javax_Net_Ssl_SSLSocket()
+	Load_Javax_Net_Ssl_SSLSocketFactory()
+
+	methods := []string{
+		"javax/net/ssl/SSLContext.init([Ljavax/net/ssl/KeyManager;[Ljavax/net/ssl/TrustManager;Ljava/security/SecureRandom;)V",
+		"javax/net/ssl/SSLSocket.startHandshake()V",
+		"javax/net/ssl/SSLSocket.getInputStream()Ljava/io/InputStream;", // inherited from Socket
+		"javax/net/ssl/SSLServerSocket.setNeedClientAuth(Z)V",
+		"javax/net/ssl/SSLServerSocket.getLocalPort()I", // inherited from ServerSocket
+		"javax/net/ssl/SSLSocketFactory.createSocket(Ljava/net/Socket;Ljava/lang/String;IZ)Ljava/net/Socket;",
+	}
+	for _, m := range methods {
+		if _, ok := ghelpers.MethodSignatures[m]; !ok {
+			t.Errorf("SSL method signature not registered: %s", m)
+		}
+	}
+	if ghelpers.MethodSignatures["javax/net/ssl/SSLServerSocket.accept()Ljava/net/Socket;"].GFunction == nil {
+		t.Error("SSLServerSocket.accept is not registered")
+	}
+}
+
+func TestSSLSocket_LoopbackEcho(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	serverID := newSSLTestIdentity(t, "server")
+	server, port := newSSLTestServer(t, newSSLTestContext(t, &serverID))
+	echo := sslTestEcho(server)
+
+	clientCtx := newSSLTestContext(t, nil, serverID.cert)
+	client := connectSSLTest(t, clientCtx, loopbackAddress(), port)
+	sslParams := sslSocketGetSSLParameters([]any{client}).(*object.Object)
+	sslParametersSetEndpointIdentificationAlgorithm([]any{sslParams, object.StringObjectFromGoString("HTTPS")})
+	if ret := sslSocketSetSSLParameters([]any{client, sslParams}); ret != nil {
+		t.Fatalf("setSSLParameters: %v", ret)
+	}
+
+	out := socketGetOutputStream([]any{client}).(*object.Object)
+	if ret := socketOutputStreamWrite([]any{list.New(), out, object.MakeArrayFromRawArray([]byte("ping"))}); ret != nil {
+		t.Fatalf("write: %v", ret)
+	}
+	if got := string(readN(t, socketGetInputStream([]any{client}).(*object.Object), 4)); got != "ping" {
+		t.Errorf("echo: %q", got)
+	}
+
+	session := sslSocketGetSession([]any{list.New(), client}).(*object.Object)
+	if got := netTestGoString(t, sslSessionGetProtocol([]any{session})); got != "TLSv1.3" {
+		t.Errorf("protocol: %s", got)
+	}
+	if got := netTestGoString(t, sslSessionGetCipherSuite([]any{session})); !strings.HasPrefix(got, "TLS_") {
+		t.Errorf("cipher suite: %s", got)
+	}
+	if got := netTestGoString(t, sslSessionGetPeerHost([]any{session})); got != "localhost" {
+		t.Errorf("peer host: %s", got)
+	}
+	peers := sslSessionGetPeerCertificates([]any{session}).(*object.Object).FieldTable["value"].Fvalue.([]*object.Object)
+	if len(peers) != 1 || !peers[0].FieldTable["value"].Fvalue.(*x509.Certificate).Equal(serverID.cert) {
+		t.Errorf("peer certificates are not the server's")
+	}
+	if sslSessionGetLocalCertificates([]any{session}) != object.Null {
+		t.Errorf("the client sent a certificate")
+	}
+	if got := netTestGoString(t, sslSocketGetApplicationProtocol([]any{client})); got != "" {
+		t.Errorf("application protocol: %q", got)
+	}
+
+	conn, ok := (<-echo).(*object.Object)
+	if !ok {
+		t.Fatal("accept failed")
+	}
+	if className := object.GoStringFromStringPoolIndex(conn.KlassName); className != sslSocketClassName {
+		t.Errorf("accepted socket class: %s", className)
+	}
+	if sslSocketGetUseClientMode([]any{conn}) != types.JavaBoolFalse {
+		t.Errorf("the accepted socket is in client mode")
+	}
+	serverSession := sslSocketGetSession([]any{list.New(), conn}).(*object.Object)
+	testutil.ExpectGErr(t, sslSessionGetPeerCertificates([]any{serverSession}),
+		excNames.SSLPeerUnverifiedException, "peer not authenticated")
+
+	// a close_notify ends the server's stream
+	socketShutdownOutput([]any{client})
+	if ret := <-echo; ret != int64(-1) {
+		t.Errorf("server read after the client's shutdownOutput: %v", ret)
+	}
+}
+
+func TestSSLSocket_MutualAuthentication(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	serverID := newSSLTestIdentity(t, "server")
+	clientID := newSSLTestIdentity(t, "client")
+	server, port := newSSLTestServer(t, newSSLTestContext(t, &serverID, clientID.cert))
+	sslServerSocketSetNeedClientAuth([]any{server, types.JavaBoolTrue})
+
+	// with the client's certificate
+	echo := sslTestEcho(server)
+	client := connectSSLTest(t, newSSLTestContext(t, &clientID, serverID.cert), loopbackAddress(), port)
+	out := socketGetOutputStream([]any{client}).(*object.Object)
+	socketOutputStreamWrite([]any{list.New(), out, object.MakeArrayFromRawArray([]byte("mTLS"))})
+	if got := string(readN(t, socketGetInputStream([]any{client}).(*object.Object), 4)); got != "mTLS" {
+		t.Errorf("echo: %q", got)
+	}
+	session := sslSocketGetSession([]any{list.New(), client}).(*object.Object)
+	if sslSessionGetLocalCertificates([]any{session}) == object.Null {
+		t.Errorf("the client did not send its certificate")
+	}
+	conn := (<-echo).(*object.Object)
+	serverSession := sslSocketGetSession([]any{list.New(), conn}).(*object.Object)
+	principal := sslSessionGetPeerPrincipal([]any{serverSession}).(*object.Object)
+	if der := principal.FieldTable["value"].Fvalue.([]byte); string(der) != string(clientID.cert.RawSubject) {
+		t.Errorf("peer principal is not the client's subject")
+	}
+	socketClose([]any{client})
+	<-echo
+
+	// without one, the server fails the handshake, and the client learns of it when it reads
+	echo = sslTestEcho(server)
+	anonymous := connectSSLTest(t, newSSLTestContext(t, nil, serverID.cert), loopbackAddress(), port)
+	if ret := sslSocketStartHandshake([]any{list.New(), anonymous}); ret != nil {
+		t.Fatalf("startHandshake: %v", ret) // TLS 1.3 clients finish before the server checks them
+	}
+	<-echo
+	testutil.ExpectGErr(t, <-echo, excNames.SSLHandshakeException, "")
+	ret := socketInputStreamRead([]any{list.New(), socketGetInputStream([]any{anonymous})})
+	testutil.ExpectGErr(t, ret, excNames.SSLException, "Received fatal alert: certificate_required")
+}
+
+func TestSSLSocket_UntrustedServer(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	serverID := newSSLTestIdentity(t, "server")
+	otherID := newSSLTestIdentity(t, "other")
+	server, port := newSSLTestServer(t, newSSLTestContext(t, &serverID))
+	echo := sslTestEcho(server)
+
+	client := connectSSLTest(t, newSSLTestContext(t, nil, otherID.cert), loopbackAddress(), port)
+	testutil.ExpectGErr(t, sslSocketStartHandshake([]any{list.New(), client}),
+		excNames.SSLHandshakeException, "PKIX path building failed")
+	if socketIsClosed([]any{client}) != types.JavaBoolTrue {
+		t.Errorf("the socket is open after its handshake failed")
+	}
+	session := sslSocketGetSession([]any{list.New(), client}).(*object.Object)
+	if got := netTestGoString(t, sslSessionGetCipherSuite([]any{session})); got != "SSL_NULL_WITH_NULL_NULL" {
+		t.Errorf("cipher suite of a failed handshake: %s", got)
+	}
+	<-echo
+	testutil.ExpectGErr(t, <-echo, excNames.SSLHandshakeException, "Received fatal alert: bad_certificate")
+}
+
+func TestSSLSocket_HostnameVerification(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	serverID := newSSLTestIdentity(t, "server") // for localhost, not for 127.0.0.1
+	server, port := newSSLTestServer(t, newSSLTestContext(t, &serverID))
+	clientCtx := newSSLTestContext(t, nil, serverID.cert)
+
+	// without endpoint identification, the name is not checked
+	sslTestEcho(server)
+	client := connectSSLTest(t, clientCtx, object.StringObjectFromGoString("127.0.0.1"), port)
+	if ret := sslSocketStartHandshake([]any{list.New(), client}); ret != nil {
+		t.Fatalf("startHandshake: %v", ret)
+	}
+
+	sslTestEcho(server)
+	client = connectSSLTest(t, clientCtx, object.StringObjectFromGoString("127.0.0.1"), port)
+	sslParams := newSSLParametersObject(&sslParameters{endpointIdentification: "HTTPS"})
+	sslSocketSetSSLParameters([]any{client, sslParams})
+	testutil.ExpectGErr(t, sslSocketStartHandshake([]any{list.New(), client}),
+		excNames.SSLHandshakeException, "No subject alternative names matching IP address 127.0.0.1 found")
+}
+
+func TestSSLSocket_ProtocolsAndCipherSuites(t *testing.T) {
+	globals.InitStringPool()
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	serverID := newSSLTestIdentity(t, "server")
+	server, port := newSSLTestServer(t, newSSLTestContext(t, &serverID))
+	sslTestEcho(server)
+
+	client := connectSSLTest(t, newSSLTestContext(t, nil, serverID.cert), loopbackAddress(), port)
+	testutil.ExpectGErr(t, sslSocketSetEnabledProtocols([]any{client, stringArrayObject([]string{"SSLv2"})}),
+		excNames.IllegalArgumentException, "Unsupported protocol: SSLv2")
+	testutil.ExpectGErr(t, sslSocketSetEnabledCipherSuites([]any{client, object.Null}),
+		excNames.IllegalArgumentException, "CipherSuites cannot be null")
+
+	suite := "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"
+	sslSocketSetEnabledProtocols([]any{client, stringArrayObject([]string{"TLSv1.2"})})
+	sslSocketSetEnabledCipherSuites([]any{client, stringArrayObject([]string{suite})})
+	session := sslSocketGetSession([]any{list.New(), client}).(*object.Object)
+	if got := netTestGoString(t, sslSessionGetProtocol([]any{session})); got != "TLSv1.2" {
+		t.Errorf("protocol: %s", got)
+	}
+	if got := netTestGoString(t, sslSessionGetCipherSuite([]any{session})); got != suite {
+		t.Errorf("cipher suite: %s", got)
+	}
+
+	supported := sslContextGetSupportedSSLParameters([]any{sslContextGetInstance([]any{object.StringObjectFromGoString("TLSv1.2")})})
+	testutil.ExpectGErr(t, supported, excNames.IllegalStateException, "SSLContext is not initialized")
+	testutil.ExpectGErr(t, sslContextGetInstance([]any{object.StringObjectFromGoString("SSLv2")}),
+		excNames.NoSuchAlgorithmException, "SSLv2 SSLContext not available")
+}
@@ -0,0 +1,1129 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+)
+
+// The java.nio buffers: ByteBuffer, CharBuffer, ShortBuffer, IntBuffer, LongBuffer, FloatBuffer
+// and DoubleBuffer. A buffer object holds a *nioBuffer in its nioBufferField field. Buffers made by
+// slice(), duplicate() or wrap() share their storage with the buffer or Java array they came from,
+// so a change made through one of them is seen through the others, as in the JDK.
+//
+// Storage is either the []types.JavaByte of a byte buffer or the []int64 or []float64 of a Java
+// array of the buffer's own element type. The typed views of a byte buffer (asIntBuffer() and so
+// on) keep the byte storage and encode each element in the view's byte order.
+//
+// This file holds the state and the methods common to all buffer types. ByteBuffer's typed
+// accessors and views are in javaNioByteBuffer.go; CharBuffer's CharSequence methods are in
+// javaNioCharBuffer.go.
+
+const nioBufferField = "buffer"
+
+// bufferKind describes the element type of one of the buffer classes.
+type bufferKind struct {
+	name      string // as in the class name: "Byte", "Char", ...
+	desc      string // the element's field descriptor: "B", "C", ...
+	size      int    // the size of an element in bytes
+	arrayType uint8  // the array type passed to object.Make1DimArray
+}
+
+func (k *bufferKind) className() string { return "java/nio/" + k.name + "Buffer" }
+func (k *bufferKind) classType() string { return "Ljava/nio/" + k.name + "Buffer;" }
+func (k *bufferKind) isFloat() bool     { return k.desc == "F" || k.desc == "D" }
+
+var bufferKinds = map[byte]*bufferKind{
+	'B': {"Byte", "B", 1, object.T_BYTE},
+	'C': {"Char", "C", 2, object.T_CHAR},
+	'S': {"Short", "S", 2, object.T_SHORT},
+	'I': {"Int", "I", 4, object.T_INT},
+	'J': {"Long", "J", 8, object.T_LONG},
+	'F': {"Float", "F", 4, object.T_FLOAT},
+	'D': {"Double", "D", 8, object.T_DOUBLE},
+}
+
+// bufferDescs lists the element descriptors in the order in which the classes are loaded.
+var bufferDescs = []byte{'B', 'C', 'S', 'I', 'J', 'F', 'D'}
+
+// nativeBigEndian is true if ByteOrder.nativeOrder() is BIG_ENDIAN.
+var nativeBigEndian = binary.NativeEndian.Uint16([]byte{0, 1}) == 1
+
+type nioBuffer struct {
+	kind      *bufferKind
+	view      bool             // true for a typed view of byte storage
+	bytes     []types.JavaByte // storage of byte buffers and views
+	ints      []int64          // storage of other char, short, int and long buffers
+	floats    []float64        // storage of other float and double buffers
+	offset    int              // storage index of element 0, in bytes for byte storage
+	capacity  int
+	limit     int
+	position  int
+	mark      int // -1 if no mark is set
+	bigEndian bool
+	direct    bool
+	readOnly  bool
+	array     *object.Object // the accessible Java array that holds the storage, or nil
+	mapped    *mappedRegion  // the file mapping that holds the storage, or nil
+}
+
+func Load_Nio_Buffer() {
+
+	ghelpers.MethodSignatures["java/nio/Buffer.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/nio/Buffer.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapProtected}
+
+	loadBufferStateMethods("java/nio/Buffer", "Ljava/nio/Buffer;")
+	ghelpers.MethodSignatures["java/nio/Buffer.array()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: bufferArray}
+	ghelpers.MethodSignatures["java/nio/Buffer.slice()Ljava/nio/Buffer;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: bufferSlice}
+	ghelpers.MethodSignatures["java/nio/Buffer.slice(II)Ljava/nio/Buffer;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: bufferSlice}
+	ghelpers.MethodSignatures["java/nio/Buffer.duplicate()Ljava/nio/Buffer;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: bufferDuplicate}
+
+	for _, desc := range bufferDescs {
+		loadBufferClass(bufferKinds[desc])
+	}
+}
+
+// loadBufferStateMethods loads the methods of java.nio.Buffer that every buffer class inherits
+// or overrides with a covariant return type.
+func loadBufferStateMethods(className, selfType string) {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"capacity()I":                  {ParamSlots: 0, GFunction: bufferCapacity},
+		"position()I":                  {ParamSlots: 0, GFunction: bufferPosition},
+		"limit()I":                     {ParamSlots: 0, GFunction: bufferLimit},
+		"remaining()I":                 {ParamSlots: 0, GFunction: bufferRemaining},
+		"hasRemaining()Z":              {ParamSlots: 0, GFunction: bufferHasRemaining},
+		"hasArray()Z":                  {ParamSlots: 0, GFunction: bufferHasArray},
+		"arrayOffset()I":               {ParamSlots: 0, GFunction: bufferArrayOffset},
+		"isDirect()Z":                  {ParamSlots: 0, GFunction: bufferIsDirect},
+		"isReadOnly()Z":                {ParamSlots: 0, GFunction: bufferIsReadOnly},
+		"position(I)" + selfType:       {ParamSlots: 1, GFunction: bufferSetPosition},
+		"limit(I)" + selfType:          {ParamSlots: 1, GFunction: bufferSetLimit},
+		"mark()" + selfType:            {ParamSlots: 0, GFunction: bufferMark},
+		"reset()" + selfType:           {ParamSlots: 0, GFunction: bufferReset},
+		"clear()" + selfType:           {ParamSlots: 0, GFunction: bufferClear},
+		"flip()" + selfType:            {ParamSlots: 0, GFunction: bufferFlip},
+		"rewind()" + selfType:          {ParamSlots: 0, GFunction: bufferRewind},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: bufferToString},
+	} {
+		ghelpers.MethodSignatures[className+"."+sig] = gmeth
+	}
+}
+
+// loadBufferClass loads the methods that all the typed buffer classes have in common.
+func loadBufferClass(k *bufferKind) {
+	cls, self, elem, arr := k.className(), k.classType(), k.desc, "["+k.desc
+
+	ghelpers.MethodSignatures[cls+".<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures[cls+".<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapProtected}
+
+	loadBufferStateMethods(cls, self)
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		// static factories
+		"allocate(I)" + self:         {ParamSlots: 1, GFunction: bufferAllocateFor(k, false)},
+		"wrap(" + arr + ")" + self:   {ParamSlots: 1, GFunction: bufferWrap},
+		"wrap(" + arr + "II)" + self: {ParamSlots: 3, GFunction: bufferWrap},
+
+		// new buffers over the same storage
+		"slice()" + self:            {ParamSlots: 0, GFunction: bufferSlice},
+		"slice(II)" + self:          {ParamSlots: 2, GFunction: bufferSlice},
+		"duplicate()" + self:        {ParamSlots: 0, GFunction: bufferDuplicate},
+		"asReadOnlyBuffer()" + self: {ParamSlots: 0, GFunction: bufferAsReadOnly},
+
+		// single elements
+		"get()" + elem:              {ParamSlots: 0, GFunction: bufferGet},
+		"get(I)" + elem:             {ParamSlots: 1, GFunction: bufferGetAt},
+		"put(" + elem + ")" + self:  {ParamSlots: 1, GFunction: bufferPut},
+		"put(I" + elem + ")" + self: {ParamSlots: 2, GFunction: bufferPutAt},
+
+		// bulk transfers
+		"get(" + arr + ")" + self:     {ParamSlots: 1, GFunction: bufferGetArray},
+		"get(" + arr + "II)" + self:   {ParamSlots: 3, GFunction: bufferGetArray},
+		"get(I" + arr + ")" + self:    {ParamSlots: 2, GFunction: bufferGetArrayAt},
+		"get(I" + arr + "II)" + self:  {ParamSlots: 4, GFunction: bufferGetArrayAt},
+		"put(" + arr + ")" + self:     {ParamSlots: 1, GFunction: bufferPutArray},
+		"put(" + arr + "II)" + self:   {ParamSlots: 3, GFunction: bufferPutArray},
+		"put(I" + arr + ")" + self:    {ParamSlots: 2, GFunction: bufferPutArrayAt},
+		"put(I" + arr + "II)" + self:  {ParamSlots: 4, GFunction: bufferPutArrayAt},
+		"put(" + self + ")" + self:    {ParamSlots: 1, GFunction: bufferPutBuffer},
+		"put(I" + self + "II)" + self: {ParamSlots: 4, GFunction: bufferPutBufferAt},
+		"compact()" + self:            {ParamSlots: 0, GFunction: bufferCompact},
+		"array()" + arr:               {ParamSlots: 0, GFunction: bufferArray},
+		"order()Ljava/nio/ByteOrder;": {ParamSlots: 0, GFunction: bufferOrder},
+
+		// comparison
+		"equals(Ljava/lang/Object;)Z":    {ParamSlots: 1, GFunction: bufferEquals},
+		"hashCode()I":                    {ParamSlots: 0, GFunction: bufferHashCode},
+		"compareTo(" + self + ")I":       {ParamSlots: 1, GFunction: bufferCompareTo},
+		"compareTo(Ljava/lang/Object;)I": {ParamSlots: 1, GFunction: bufferCompareTo},
+		"mismatch(" + self + ")I":        {ParamSlots: 1, GFunction: bufferMismatch},
+	} {
+		ghelpers.MethodSignatures[cls+"."+sig] = gmeth
+	}
+}
+
+// --- buffer objects ---
+
+// newBufferObject returns a buffer object of the class that matches the kind of b.
+func newBufferObject(b *nioBuffer) *object.Object {
+	className := b.kind.className()
+	if b.mapped != nil && !b.view {
+		className = mappedByteBufferClassName
+	}
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[nioBufferField] = object.Field{Ftype: types.RawGoPointer, Fvalue: b}
+	return obj
+}
+
+// bufferThis returns the state of a buffer object.
+func bufferThis(this any, caller string) (*nioBuffer, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": buffer is null")
+	}
+	b, ok := self.FieldTable[nioBufferField].Fvalue.(*nioBuffer)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": buffer was not initialized")
+	}
+	return b, nil
+}
+
+// newArrayBuffer returns a buffer of kind k whose storage is the Java array arr.
+func newArrayBuffer(k *bufferKind, arr *object.Object) *nioBuffer {
+	b := &nioBuffer{kind: k, mark: -1, array: arr, bigEndian: nativeBigEndian}
+	switch storage := arr.FieldTable["value"].Fvalue.(type) {
+	case []types.JavaByte:
+		b.bytes, b.capacity, b.bigEndian = storage, len(storage), true
+	case []int64:
+		b.ints, b.capacity = storage, len(storage)
+	case []float64:
+		b.floats, b.capacity = storage, len(storage)
+	}
+	b.limit = b.capacity
+	return b
+}
+
+// unit is the number of storage elements that hold one buffer element.
+func (b *nioBuffer) unit() int {
+	if b.view {
+		return b.kind.size
+	}
+	return 1
+}
+
+func (b *nioBuffer) remaining() int {
+	return max(b.limit-b.position, 0)
+}
+
+// get returns element ix as a Java value: an int64 for the integral types, a float64 for float
+// and double. ix is not checked.
+func (b *nioBuffer) get(ix int) interface{} {
+	switch {
+	case b.view || b.kind.desc == "B":
+		return readElement(b.bytes, b.offset+ix*b.kind.size, b.kind.desc[0], b.bigEndian)
+	case b.kind.isFloat():
+		return b.floats[b.offset+ix]
+	default:
+		return b.ints[b.offset+ix]
+	}
+}
+
+// set stores value as element ix, narrowing it to the element type. ix is not checked.
+func (b *nioBuffer) set(ix int, value interface{}) {
+	switch {
+	case b.view || b.kind.desc == "B":
+		writeElement(b.bytes, b.offset+ix*b.kind.size, b.kind.desc[0], b.bigEndian, value)
+	case b.kind.isFloat():
+		b.floats[b.offset+ix] = narrowFloat(b.kind.desc[0], value)
+	default:
+		b.ints[b.offset+ix] = narrowInt(b.kind.desc[0], value)
+	}
+}
+
+// derive returns a buffer over the storage of b that starts at element start of b. As in the
+// JDK, a new byte buffer is big-endian whatever the order of b.
+func (b *nioBuffer) derive(start, capacity int) *nioBuffer {
+	nb := *b
+	nb.offset = b.offset + start*b.unit()
+	nb.capacity, nb.limit, nb.position, nb.mark = capacity, capacity, 0, -1
+	if b.kind.desc == "B" {
+		nb.bigEndian = true
+	}
+	return &nb
+}
+
+// implName returns the name of the JDK class that implements a buffer like b, for toString().
+func (b *nioBuffer) implName() string {
+	readOnly := ""
+	if b.readOnly {
+		readOnly = "R"
+	}
+	switch {
+	case b.view && b.direct:
+		swapped := "U"
+		if b.bigEndian != nativeBigEndian {
+			swapped = "S"
+		}
+		return "java.nio.Direct" + b.kind.name + "Buffer" + readOnly + swapped
+	case b.view:
+		order := "L"
+		if b.bigEndian {
+			order = "B"
+		}
+		return "java.nio.ByteBufferAs" + b.kind.name + "Buffer" + readOnly + order
+	case b.direct:
+		return "java.nio.Direct" + b.kind.name + "Buffer" + readOnly
+	default:
+		return "java.nio.Heap" + b.kind.name + "Buffer" + readOnly
+	}
+}
+
+// --- element encoding ---
+
+func byteOrderOf(bigEndian bool) binary.ByteOrder {
+	if bigEndian {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// readElement decodes the element of type desc that starts at bytes[at].
+func readElement(bytes []types.JavaByte, at int, desc byte, bigEndian bool) interface{} {
+	if desc == 'B' {
+		return int64(bytes[at])
+	}
+	var raw [8]byte
+	for ix := 0; ix < bufferKinds[desc].size; ix++ {
+		raw[ix] = byte(bytes[at+ix])
+	}
+	order := byteOrderOf(bigEndian)
+	switch desc {
+	case 'C':
+		return int64(order.Uint16(raw[:]))
+	case 'S':
+		return int64(int16(order.Uint16(raw[:])))
+	case 'I':
+		return int64(int32(order.Uint32(raw[:])))
+	case 'J':
+		return int64(order.Uint64(raw[:]))
+	case 'F':
+		return float64(math.Float32frombits(order.Uint32(raw[:])))
+	default:
+		return math.Float64frombits(order.Uint64(raw[:]))
+	}
+}
+
+// writeElement encodes value as an element of type desc starting at bytes[at].
+func writeElement(bytes []types.JavaByte, at int, desc byte, bigEndian bool, value interface{}) {
+	var raw [8]byte
+	order := byteOrderOf(bigEndian)
+	switch desc {
+	case 'B':
+		raw[0] = byte(value.(int64))
+	case 'C', 'S':
+		order.PutUint16(raw[:], uint16(value.(int64)))
+	case 'I':
+		order.PutUint32(raw[:], uint32(value.(int64)))
+	case 'J':
+		order.PutUint64(raw[:], uint64(value.(int64)))
+	case 'F':
+		order.PutUint32(raw[:], math.Float32bits(float32(value.(float64))))
+	default:
+		order.PutUint64(raw[:], math.Float64bits(value.(float64)))
+	}
+	for ix := 0; ix < bufferKinds[desc].size; ix++ {
+		bytes[at+ix] = types.JavaByte(raw[ix])
+	}
+}
+
+func narrowInt(desc byte, value interface{}) int64 {
+	v := value.(int64)
+	switch desc {
+	case 'C':
+		return int64(uint16(v))
+	case 'S':
+		return int64(int16(v))
+	case 'I':
+		return int64(int32(v))
+	}
+	return v
+}
+
+func narrowFloat(desc byte, value interface{}) float64 {
+	v := value.(float64)
+	if desc == 'F' {
+		return float64(float32(v))
+	}
+	return v
+}
+
+// --- Java arrays ---
+
+// arrayLength returns the length of a primitive Java array.
+func arrayLength(arr *object.Object) int {
+	switch storage := arr.FieldTable["value"].Fvalue.(type) {
+	case []types.JavaByte:
+		return len(storage)
+	case []int64:
+		return len(storage)
+	case []float64:
+		return len(storage)
+	}
+	return 0
+}
+
+func arrayGet(arr *object.Object, ix int) interface{} {
+	switch storage := arr.FieldTable["value"].Fvalue.(type) {
+	case []types.JavaByte:
+		return int64(storage[ix])
+	case []int64:
+		return storage[ix]
+	default:
+		return storage.([]float64)[ix]
+	}
+}
+
+func arraySet(arr *object.Object, ix int, value interface{}) {
+	switch storage := arr.FieldTable["value"].Fvalue.(type) {
+	case []types.JavaByte:
+		storage[ix] = types.JavaByte(value.(int64))
+	case []int64:
+		storage[ix] = value.(int64)
+	default:
+		storage.([]float64)[ix] = value.(float64)
+	}
+}
+
+// arrayRange returns the array argument at params[ix] and the range given by the offset and
+// length that follow it, if any.
+func arrayRange(params []interface{}, ix int, caller string) (*object.Object, int, int, *ghelpers.GErrBlk) {
+	arr, ok := params[ix].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": array is null")
+	}
+	offset, length := 0, arrayLength(arr)
+	if len(params) > ix+2 {
+		offset, length = int(params[ix+1].(int64)), int(params[ix+2].(int64))
+		if gerr := checkFromIndexSize(offset, length, arrayLength(arr)); gerr != nil {
+			return nil, 0, 0, gerr
+		}
+	}
+	return arr, offset, length, nil
+}
+
+// checkFromIndexSize reports an error, as java.util.Objects.checkFromIndexSize does, if the range
+// [from, from+size) is not within [0, length).
+func checkFromIndexSize(from, size, length int) *ghelpers.GErrBlk {
+	if from < 0 || size < 0 || from+size > length || from+size < 0 {
+		errMsg := fmt.Sprintf("Range [%d, %d + %d) out of bounds for length %d", from, from, size, length)
+		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	return nil
+}
+
+// checkIndex reports an error, as java.util.Objects.checkIndex does, if ix is not in [0, length).
+func checkIndex(ix, length int) *ghelpers.GErrBlk {
+	if ix < 0 || ix >= length {
+		errMsg := fmt.Sprintf("Index %d out of bounds for length %d", ix, length)
+		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	return nil
+}
+
+func readOnlyError() *ghelpers.GErrBlk {
+	return ghelpers.GetGErrBlk(excNames.ReadOnlyBufferException, "")
+}
+
+// --- creating buffers ---
+
+// bufferAllocateFor returns the G function for allocate(I), or for ByteBuffer.allocateDirect(I).
+func bufferAllocateFor(k *bufferKind, direct bool) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		capacity := params[0].(int64)
+		if capacity < 0 {
+			errMsg := fmt.Sprintf("capacity < 0: (%d < 0)", capacity)
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		}
+		b := newArrayBuffer(k, object.Make1DimArray(k.arrayType, capacity))
+		if direct {
+			b.direct, b.array = true, nil
+		}
+		return newBufferObject(b)
+	}
+}
+
+// wrap([X) and wrap([XII) -- the buffer's storage is the array
+func bufferWrap(params []interface{}) interface{} {
+	arr, offset, length, gerr := arrayRange(params, 0, "wrap")
+	if gerr != nil {
+		return gerr
+	}
+	arrType := object.GoStringFromStringPoolIndex(arr.KlassName)
+	desc := arrType[len(arrType)-1]
+	if desc == 'R' { // char arrays made by the interpreter are rune arrays
+		desc = 'C'
+	}
+	k, ok := bufferKinds[desc]
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "wrap: unsupported array type "+arrType)
+	}
+	b := newArrayBuffer(k, arr)
+	b.position, b.limit = offset, offset+length
+	return newBufferObject(b)
+}
+
+// slice() and slice(II)
+func bufferSlice(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "slice")
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 3 {
+		index, length := int(params[1].(int64)), int(params[2].(int64))
+		if gerr := checkFromIndexSize(index, length, b.limit); gerr != nil {
+			return gerr
+		}
+		return newBufferObject(b.derive(index, length))
+	}
+	return newBufferObject(b.derive(b.position, b.remaining()))
+}
+
+// duplicate() -- the new buffer has the same position, limit and mark
+func bufferDuplicate(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "duplicate")
+	if gerr != nil {
+		return gerr
+	}
+	nb := *b
+	if b.kind.desc == "B" {
+		nb.bigEndian = true
+	}
+	return newBufferObject(&nb)
+}
+
+// asReadOnlyBuffer() -- a duplicate that cannot be written and that hides its array
+func bufferAsReadOnly(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "asReadOnlyBuffer")
+	if gerr != nil {
+		return gerr
+	}
+	nb := *b
+	if b.kind.desc == "B" {
+		nb.bigEndian = true
+	}
+	nb.readOnly = true
+	return newBufferObject(&nb)
+}
+
+// --- position, limit and mark ---
+
+func bufferCapacity(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "capacity")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(b.capacity)
+}
+
+func bufferPosition(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "position")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(b.position)
+}
+
+func bufferLimit(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "limit")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(b.limit)
+}
+
+func bufferRemaining(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "remaining")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(b.remaining())
+}
+
+func bufferHasRemaining(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "hasRemaining")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.position < b.limit)
+}
+
+// position(I) -- discards the mark if it is beyond the new position
+func bufferSetPosition(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "position")
+	if gerr != nil {
+		return gerr
+	}
+	newPosition := int(params[1].(int64))
+	if newPosition > b.limit {
+		errMsg := fmt.Sprintf("newPosition > limit: (%d > %d)", newPosition, b.limit)
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	if newPosition < 0 {
+		errMsg := fmt.Sprintf("newPosition < 0: (%d < 0)", newPosition)
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	if b.mark > newPosition {
+		b.mark = -1
+	}
+	b.position = newPosition
+	return params[0]
+}
+
+// limit(I) -- moves the position back to the new limit if it is beyond it
+func bufferSetLimit(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "limit")
+	if gerr != nil {
+		return gerr
+	}
+	newLimit := int(params[1].(int64))
+	if newLimit > b.capacity {
+		errMsg := fmt.Sprintf("newLimit > capacity: (%d > %d)", newLimit, b.capacity)
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	if newLimit < 0 {
+		errMsg := fmt.Sprintf("newLimit < 0: (%d < 0)", newLimit)
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	b.limit = newLimit
+	if b.position > newLimit {
+		b.position = newLimit
+	}
+	if b.mark > newLimit {
+		b.mark = -1
+	}
+	return params[0]
+}
+
+func bufferMark(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "mark")
+	if gerr != nil {
+		return gerr
+	}
+	b.mark = b.position
+	return params[0]
+}
+
+func bufferReset(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "reset")
+	if gerr != nil {
+		return gerr
+	}
+	if b.mark < 0 {
+		return ghelpers.GetGErrBlk(excNames.InvalidMarkException, "")
+	}
+	b.position = b.mark
+	return params[0]
+}
+
+func bufferClear(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "clear")
+	if gerr != nil {
+		return gerr
+	}
+	b.position, b.limit, b.mark = 0, b.capacity, -1
+	return params[0]
+}
+
+func bufferFlip(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "flip")
+	if gerr != nil {
+		return gerr
+	}
+	b.position, b.limit, b.mark = 0, b.position, -1
+	return params[0]
+}
+
+func bufferRewind(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "rewind")
+	if gerr != nil {
+		return gerr
+	}
+	b.position, b.mark = 0, -1
+	return params[0]
+}
+
+// --- the backing array ---
+
+func bufferHasArray(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "hasArray")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.array != nil && !b.readOnly)
+}
+
+func bufferArray(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "array")
+	if gerr != nil {
+		return gerr
+	}
+	if b.array == nil {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "")
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	return b.array
+}
+
+func bufferArrayOffset(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "arrayOffset")
+	if gerr != nil {
+		return gerr
+	}
+	if b.array == nil {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "")
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	return int64(b.offset)
+}
+
+func bufferIsDirect(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "isDirect")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.direct)
+}
+
+func bufferIsReadOnly(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "isReadOnly")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.readOnly)
+}
+
+// order() -- for buffers other than ByteBuffer. Views have the order of the byte buffer they
+// were made from; other buffers have the native order.
+func bufferOrder(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "order")
+	if gerr != nil {
+		return gerr
+	}
+	return byteOrderObject(b.bigEndian)
+}
+
+// --- single elements ---
+
+// get() -- the element at the position, which is then incremented
+func bufferGet(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "get")
+	if gerr != nil {
+		return gerr
+	}
+	if b.position >= b.limit {
+		return ghelpers.GetGErrBlk(excNames.BufferUnderflowException, "")
+	}
+	b.position++
+	return b.get(b.position - 1)
+}
+
+// get(I) -- the element at an index; the position is unchanged
+func bufferGetAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "get")
+	if gerr != nil {
+		return gerr
+	}
+	ix := int(params[1].(int64))
+	if gerr := checkIndex(ix, b.limit); gerr != nil {
+		return gerr
+	}
+	return b.get(ix)
+}
+
+// put(X) -- stores the element at the position, which is then incremented
+func bufferPut(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	if b.position >= b.limit {
+		return ghelpers.GetGErrBlk(excNames.BufferOverflowException, "")
+	}
+	b.set(b.position, params[1])
+	b.position++
+	return params[0]
+}
+
+// put(IX) -- stores the element at an index; the position is unchanged
+func bufferPutAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	ix := int(params[1].(int64))
+	if gerr := checkIndex(ix, b.limit); gerr != nil {
+		return gerr
+	}
+	b.set(ix, params[2])
+	return params[0]
+}
+
+// --- bulk transfers ---
+
+// get([X) and get([XII) -- relative bulk get
+func bufferGetArray(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "get")
+	if gerr != nil {
+		return gerr
+	}
+	arr, offset, length, gerr := arrayRange(params, 1, "get")
+	if gerr != nil {
+		return gerr
+	}
+	if length > b.remaining() {
+		return ghelpers.GetGErrBlk(excNames.BufferUnderflowException, "")
+	}
+	for ix := 0; ix < length; ix++ {
+		arraySet(arr, offset+ix, b.get(b.position+ix))
+	}
+	b.position += length
+	return params[0]
+}
+
+// get(I[X) and get(I[XII) -- absolute bulk get
+func bufferGetArrayAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "get")
+	if gerr != nil {
+		return gerr
+	}
+	index := int(params[1].(int64))
+	arr, offset, length, gerr := arrayRange(append([]interface{}{params[0]}, params[2:]...), 1, "get")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := checkFromIndexSize(index, length, b.limit); gerr != nil {
+		return gerr
+	}
+	for ix := 0; ix < length; ix++ {
+		arraySet(arr, offset+ix, b.get(index+ix))
+	}
+	return params[0]
+}
+
+// put([X) and put([XII) -- relative bulk put
+func bufferPutArray(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	arr, offset, length, gerr := arrayRange(params, 1, "put")
+	if gerr != nil {
+		return gerr
+	}
+	if length > b.remaining() {
+		return ghelpers.GetGErrBlk(excNames.BufferOverflowException, "")
+	}
+	for ix := 0; ix < length; ix++ {
+		b.set(b.position+ix, arrayGet(arr, offset+ix))
+	}
+	b.position += length
+	return params[0]
+}
+
+// put(I[X) and put(I[XII) -- absolute bulk put
+func bufferPutArrayAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	index := int(params[1].(int64))
+	arr, offset, length, gerr := arrayRange(append([]interface{}{params[0]}, params[2:]...), 1, "put")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr := checkFromIndexSize(index, length, b.limit); gerr != nil {
+		return gerr
+	}
+	for ix := 0; ix < length; ix++ {
+		b.set(index+ix, arrayGet(arr, offset+ix))
+	}
+	return params[0]
+}
+
+// copyElements copies length elements of src, starting at srcIx, to dst at dstIx. The elements
+// are read before any is written, so overlapping buffers are copied correctly.
+func copyElements(dst *nioBuffer, dstIx int, src *nioBuffer, srcIx, length int) {
+	values := make([]interface{}, length)
+	for ix := range values {
+		values[ix] = src.get(srcIx + ix)
+	}
+	for ix, value := range values {
+		dst.set(dstIx+ix, value)
+	}
+}
+
+// put(XBuffer) -- transfers the remaining elements of the source buffer
+func bufferPutBuffer(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if params[1] == params[0] {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "The source buffer is this buffer")
+	}
+	src, gerr := bufferThis(params[1], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	length := src.remaining()
+	if length > b.remaining() {
+		return ghelpers.GetGErrBlk(excNames.BufferOverflowException, "")
+	}
+	copyElements(b, b.position, src, src.position, length)
+	b.position += length
+	src.position += length
+	return params[0]
+}
+
+// put(IXBufferII) -- absolute bulk put from another buffer; neither position changes
+func bufferPutBufferAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	src, gerr := bufferThis(params[2], "put")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	index, offset, length := int(params[1].(int64)), int(params[3].(int64)), int(params[4].(int64))
+	if gerr := checkFromIndexSize(index, length, b.limit); gerr != nil {
+		return gerr
+	}
+	if gerr := checkFromIndexSize(offset, length, src.limit); gerr != nil {
+		return gerr
+	}
+	copyElements(b, index, src, offset, length)
+	return params[0]
+}
+
+// compact() -- moves the remaining elements to the start of the buffer and makes the rest of it
+// available for writing
+func bufferCompact(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "compact")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return readOnlyError()
+	}
+	remaining := b.remaining()
+	copyElements(b, 0, b, b.position, remaining)
+	b.position, b.limit, b.mark = remaining, b.capacity, -1
+	return params[0]
+}
+
+// --- comparison ---
+
+// elementsEqual is the equality test of equals(): float elements are equal if they are == or
+// both NaN, so that -0.0 equals 0.0.
+func elementsEqual(x, y interface{}) bool {
+	if fx, ok := x.(float64); ok {
+		fy := y.(float64)
+		return fx == fy || (math.IsNaN(fx) && math.IsNaN(fy))
+	}
+	return x.(int64) == y.(int64)
+}
+
+// compareElements compares two elements as the compare() method of their wrapper class does.
+func compareElements(x, y interface{}) int {
+	if fx, ok := x.(float64); ok {
+		fy := y.(float64)
+		switch {
+		case fx < fy:
+			return -1
+		case fx > fy:
+			return 1
+		}
+		bx, by := int64(math.Float64bits(fx)), int64(math.Float64bits(fy))
+		if math.IsNaN(fx) {
+			bx = 0x7ff8000000000000
+		}
+		if math.IsNaN(fy) {
+			by = 0x7ff8000000000000
+		}
+		switch {
+		case bx < by:
+			return -1
+		case bx > by:
+			return 1
+		}
+		return 0
+	}
+	ix, iy := x.(int64), y.(int64)
+	switch {
+	case ix < iy:
+		return -1
+	case ix > iy:
+		return 1
+	}
+	return 0
+}
+
+// equals(Ljava/lang/Object;)Z -- buffers are equal if they have the same element type and their
+// remaining elements are equal
+func bufferEquals(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "equals")
+	if gerr != nil {
+		return gerr
+	}
+	that, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(that) {
+		return types.JavaBoolFalse
+	}
+	other, ok := that.FieldTable[nioBufferField].Fvalue.(*nioBuffer)
+	if !ok || other.kind != b.kind || other.remaining() != b.remaining() {
+		return types.JavaBoolFalse
+	}
+	for ix := 0; ix < b.remaining(); ix++ {
+		if !elementsEqual(b.get(b.position+ix), other.get(other.position+ix)) {
+			return types.JavaBoolFalse
+		}
+	}
+	return types.JavaBoolTrue
+}
+
+// hashCode()I -- depends only on the remaining elements, as in the JDK
+func bufferHashCode(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "hashCode")
+	if gerr != nil {
+		return gerr
+	}
+	hash := int32(1)
+	for ix := b.limit - 1; ix >= b.position; ix-- {
+		var elem int32
+		switch value := b.get(ix).(type) {
+		case int64:
+			elem = int32(value)
+		case float64: // as Java's (int) cast
+			switch {
+			case math.IsNaN(value):
+				elem = 0
+			case value >= math.MaxInt32:
+				elem = math.MaxInt32
+			case value <= math.MinInt32:
+				elem = math.MinInt32
+			default:
+				elem = int32(value)
+			}
+		}
+		hash = 31*hash + elem
+	}
+	return int64(hash)
+}
+
+// compareTo(XBuffer)I -- compares the remaining elements lexicographically
+func bufferCompareTo(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "compareTo")
+	if gerr != nil {
+		return gerr
+	}
+	other, gerr := bufferThis(params[1], "compareTo")
+	if gerr != nil {
+		return gerr
+	}
+	if other.kind != b.kind {
+		errMsg := fmt.Sprintf("compareTo: cannot compare a %sBuffer with a %sBuffer", b.kind.name, other.kind.name)
+		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	}
+	length := min(b.remaining(), other.remaining())
+	for ix := 0; ix < length; ix++ {
+		if c := compareElements(b.get(b.position+ix), other.get(other.position+ix)); c != 0 {
+			return int64(c)
+		}
+	}
+	return int64(b.remaining() - other.remaining())
+}
+
+// mismatch(XBuffer)I -- the relative index of the first remaining element that differs, or -1.
+// Float elements match if they have the same bits or are both NaN.
+func bufferMismatch(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "mismatch")
+	if gerr != nil {
+		return gerr
+	}
+	other, gerr := bufferThis(params[1], "mismatch")
+	if gerr != nil {
+		return gerr
+	}
+	length := min(b.remaining(), other.remaining())
+	for ix := 0; ix < length; ix++ {
+		x, y := b.get(b.position+ix), other.get(other.position+ix)
+		if fx, ok := x.(float64); ok {
+			fy := y.(float64)
+			if math.Float64bits(fx) == math.Float64bits(fy) || (math.IsNaN(fx) && math.IsNaN(fy)) {
+				continue
+			}
+			return int64(ix)
+		}
+		if x.(int64) != y.(int64) {
+			return int64(ix)
+		}
+	}
+	if b.remaining() == other.remaining() {
+		return int64(-1)
+	}
+	return int64(length)
+}
+
+// toString()Ljava/lang/String; -- a summary of the buffer's state. CharBuffer.toString()
+// returns the remaining characters instead.
+func bufferToString(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "toString")
+	if gerr != nil {
+		return gerr
+	}
+	str := fmt.Sprintf("%s[pos=%d lim=%d cap=%d]", b.implName(), b.position, b.limit, b.capacity)
+	return object.StringObjectFromGoString(str)
+}
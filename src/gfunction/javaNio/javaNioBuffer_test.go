@@ -0,0 +1,418 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"testing"
+)
+
+// The conformance suite below runs the same checks of the java.nio.Buffer contract against every
+// buffer type, and for each type against each way of making a buffer: allocate(), wrap(), and
+// for the types other than byte, views of a big-endian and a little-endian ByteBuffer.
+
+func loadBuffersForTest() {
+	globals.InitGlobals("test")
+	Load_Nio_Buffer()
+	Load_Nio_ByteBuffer()
+	Load_Nio_ByteOrder()
+	Load_Nio_CharBuffer()
+}
+
+// callBuffer calls the G function registered for sig.
+func callBuffer(t *testing.T, sig string, params ...interface{}) interface{} {
+	t.Helper()
+	gm, ok := ghelpers.MethodSignatures[sig]
+	if !ok {
+		t.Fatalf("no G function registered for %s", sig)
+	}
+	return gm.GFunction(params)
+}
+
+// sampleValue returns a distinct value of the element type of k for each ix.
+func sampleValue(k *bufferKind, ix int) interface{} {
+	switch k.desc {
+	case "F", "D":
+		return float64(ix) + 0.5
+	case "C":
+		return int64('a' + ix)
+	default:
+		return int64(ix*3 - 5)
+	}
+}
+
+type bufferFactory struct {
+	name string
+	make func(t *testing.T, k *bufferKind, capacity int) *object.Object
+}
+
+func bufferFactories(k *bufferKind) []bufferFactory {
+	factories := []bufferFactory{
+		{"allocate", func(t *testing.T, k *bufferKind, capacity int) *object.Object {
+			return callBuffer(t, k.className()+".allocate(I)"+k.classType(), int64(capacity)).(*object.Object)
+		}},
+		{"wrap", func(t *testing.T, k *bufferKind, capacity int) *object.Object {
+			arr := object.Make1DimArray(k.arrayType, int64(capacity))
+			return callBuffer(t, k.className()+".wrap(["+k.desc+")"+k.classType(), arr).(*object.Object)
+		}},
+	}
+	if k.desc == "B" {
+		return append(factories, bufferFactory{"allocateDirect", func(t *testing.T, k *bufferKind, capacity int) *object.Object {
+			return callBuffer(t, "java/nio/ByteBuffer.allocateDirect(I)Ljava/nio/ByteBuffer;", int64(capacity)).(*object.Object)
+		}})
+	}
+	for _, bigEndian := range []bool{true, false} {
+		name := "littleEndianView"
+		if bigEndian {
+			name = "bigEndianView"
+		}
+		factories = append(factories, bufferFactory{name, func(t *testing.T, k *bufferKind, capacity int) *object.Object {
+			bb := callBuffer(t, "java/nio/ByteBuffer.allocate(I)Ljava/nio/ByteBuffer;", int64(capacity*k.size))
+			callBuffer(t, "java/nio/ByteBuffer.order(Ljava/nio/ByteOrder;)Ljava/nio/ByteBuffer;", bb, byteOrderObject(bigEndian))
+			return callBuffer(t, "java/nio/ByteBuffer.as"+k.name+"Buffer()"+k.classType(), bb).(*object.Object)
+		}})
+	}
+	return factories
+}
+
+func TestBufferConformance(t *testing.T) {
+	loadBuffersForTest()
+	for _, desc := range bufferDescs {
+		k := bufferKinds[desc]
+		for _, factory := range bufferFactories(k) {
+			t.Run(k.name+"Buffer/"+factory.name, func(t *testing.T) {
+				runBufferConformance(t, k, func(capacity int) *object.Object { return factory.make(t, k, capacity) })
+			})
+		}
+	}
+}
+
+func runBufferConformance(t *testing.T, k *bufferKind, newBuffer func(int) *object.Object) {
+	cls, self, elem, arr := k.className()+".", k.classType(), k.desc, "["+k.desc
+	state := func(buf *object.Object) [3]int64 {
+		return [3]int64{
+			callBuffer(t, cls+"position()I", buf).(int64),
+			callBuffer(t, cls+"limit()I", buf).(int64),
+			callBuffer(t, cls+"capacity()I", buf).(int64),
+		}
+	}
+
+	t.Run("initialState", func(t *testing.T) {
+		buf := newBuffer(8)
+		if got := state(buf); got != [3]int64{0, 8, 8} {
+			t.Errorf("position, limit, capacity: expected [0 8 8], got %v", got)
+		}
+		if rem := callBuffer(t, cls+"remaining()I", buf).(int64); rem != 8 {
+			t.Errorf("remaining: expected 8, got %d", rem)
+		}
+		if callBuffer(t, cls+"isReadOnly()Z", buf).(int64) != 0 {
+			t.Errorf("a new buffer should not be read-only")
+		}
+	})
+
+	t.Run("relativePutFlipGet", func(t *testing.T) {
+		buf := newBuffer(8)
+		for ix := 0; ix < 3; ix++ {
+			if ret := callBuffer(t, cls+"put("+elem+")"+self, buf, sampleValue(k, ix)); ret != buf {
+				t.Fatalf("put should return the buffer, got %v", ret)
+			}
+		}
+		callBuffer(t, cls+"flip()"+self, buf)
+		if got := state(buf); got != [3]int64{0, 3, 8} {
+			t.Errorf("after flip: expected [0 3 8], got %v", got)
+		}
+		for ix := 0; ix < 3; ix++ {
+			if got := callBuffer(t, cls+"get()"+elem, buf); got != sampleValue(k, ix) {
+				t.Errorf("get %d: expected %v, got %v", ix, sampleValue(k, ix), got)
+			}
+		}
+		if callBuffer(t, cls+"hasRemaining()Z", buf).(int64) != 0 {
+			t.Errorf("hasRemaining should be false after reading to the limit")
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"get()"+elem, buf), excNames.BufferUnderflowException, "")
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		buf := newBuffer(2)
+		callBuffer(t, cls+"put("+elem+")"+self, buf, sampleValue(k, 0))
+		callBuffer(t, cls+"put("+elem+")"+self, buf, sampleValue(k, 1))
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put("+elem+")"+self, buf, sampleValue(k, 2)),
+			excNames.BufferOverflowException, "")
+	})
+
+	t.Run("absoluteGetPut", func(t *testing.T) {
+		buf := newBuffer(4)
+		callBuffer(t, cls+"put(I"+elem+")"+self, buf, int64(2), sampleValue(k, 7))
+		if got := callBuffer(t, cls+"get(I)"+elem, buf, int64(2)); got != sampleValue(k, 7) {
+			t.Errorf("get(2): expected %v, got %v", sampleValue(k, 7), got)
+		}
+		if pos := callBuffer(t, cls+"position()I", buf).(int64); pos != 0 {
+			t.Errorf("absolute access should not move the position, got %d", pos)
+		}
+		callBuffer(t, cls+"limit(I)"+self, buf, int64(2))
+		testutil.ExpectGErr(t, callBuffer(t, cls+"get(I)"+elem, buf, int64(2)),
+			excNames.IndexOutOfBoundsException, "")
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put(I"+elem+")"+self, buf, int64(-1), sampleValue(k, 0)),
+			excNames.IndexOutOfBoundsException, "")
+	})
+
+	t.Run("markResetPositionLimit", func(t *testing.T) {
+		buf := newBuffer(8)
+		testutil.ExpectGErr(t, callBuffer(t, cls+"reset()"+self, buf), excNames.InvalidMarkException, "")
+		callBuffer(t, cls+"position(I)"+self, buf, int64(3))
+		callBuffer(t, cls+"mark()"+self, buf)
+		callBuffer(t, cls+"position(I)"+self, buf, int64(6))
+		callBuffer(t, cls+"reset()"+self, buf)
+		if pos := callBuffer(t, cls+"position()I", buf).(int64); pos != 3 {
+			t.Errorf("reset: expected position 3, got %d", pos)
+		}
+		callBuffer(t, cls+"position(I)"+self, buf, int64(1))
+		testutil.ExpectGErr(t, callBuffer(t, cls+"reset()"+self, buf),
+			excNames.InvalidMarkException, "")
+
+		testutil.ExpectGErr(t, callBuffer(t, cls+"position(I)"+self, buf, int64(9)),
+			excNames.IllegalArgumentException, "")
+		testutil.ExpectGErr(t, callBuffer(t, cls+"limit(I)"+self, buf, int64(9)),
+			excNames.IllegalArgumentException, "")
+		callBuffer(t, cls+"position(I)"+self, buf, int64(5))
+		callBuffer(t, cls+"limit(I)"+self, buf, int64(4))
+		if got := state(buf); got != [3]int64{4, 4, 8} {
+			t.Errorf("lowering the limit below the position: expected [4 4 8], got %v", got)
+		}
+		callBuffer(t, cls+"clear()"+self, buf)
+		if got := state(buf); got != [3]int64{0, 8, 8} {
+			t.Errorf("after clear: expected [0 8 8], got %v", got)
+		}
+	})
+
+	t.Run("sliceSharesStorage", func(t *testing.T) {
+		buf := newBuffer(8)
+		callBuffer(t, cls+"position(I)"+self, buf, int64(2))
+		callBuffer(t, cls+"limit(I)"+self, buf, int64(6))
+		slice := callBuffer(t, cls+"slice()"+self, buf).(*object.Object)
+		if got := state(slice); got != [3]int64{0, 4, 4} {
+			t.Errorf("slice: expected [0 4 4], got %v", got)
+		}
+		callBuffer(t, cls+"put(I"+elem+")"+self, slice, int64(1), sampleValue(k, 5))
+		if got := callBuffer(t, cls+"get(I)"+elem, buf, int64(3)); got != sampleValue(k, 5) {
+			t.Errorf("a put to the slice should be seen in the buffer: expected %v, got %v", sampleValue(k, 5), got)
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"get(I)"+elem, slice, int64(4)),
+			excNames.IndexOutOfBoundsException, "")
+
+		part := callBuffer(t, cls+"slice(II)"+self, buf, int64(3), int64(2)).(*object.Object)
+		if got := callBuffer(t, cls+"get(I)"+elem, part, int64(0)); got != sampleValue(k, 5) {
+			t.Errorf("slice(3, 2).get(0): expected %v, got %v", sampleValue(k, 5), got)
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"slice(II)"+self, buf, int64(5), int64(2)),
+			excNames.IndexOutOfBoundsException, "")
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		buf := newBuffer(4)
+		callBuffer(t, cls+"position(I)"+self, buf, int64(1))
+		dup := callBuffer(t, cls+"duplicate()"+self, buf).(*object.Object)
+		if got := state(dup); got != [3]int64{1, 4, 4} {
+			t.Errorf("duplicate: expected [1 4 4], got %v", got)
+		}
+		callBuffer(t, cls+"put("+elem+")"+self, dup, sampleValue(k, 9))
+		if pos := callBuffer(t, cls+"position()I", buf).(int64); pos != 1 {
+			t.Errorf("the duplicate's position should be independent, got %d", pos)
+		}
+		if got := callBuffer(t, cls+"get()"+elem, buf); got != sampleValue(k, 9) {
+			t.Errorf("the duplicate should share storage: expected %v, got %v", sampleValue(k, 9), got)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		buf := newBuffer(5)
+		for ix := 0; ix < 5; ix++ {
+			callBuffer(t, cls+"put("+elem+")"+self, buf, sampleValue(k, ix))
+		}
+		callBuffer(t, cls+"flip()"+self, buf)
+		callBuffer(t, cls+"get()"+elem, buf)
+		callBuffer(t, cls+"get()"+elem, buf)
+		callBuffer(t, cls+"compact()"+self, buf)
+		if got := state(buf); got != [3]int64{3, 5, 5} {
+			t.Errorf("after compact: expected [3 5 5], got %v", got)
+		}
+		for ix := 0; ix < 3; ix++ {
+			if got := callBuffer(t, cls+"get(I)"+elem, buf, int64(ix)); got != sampleValue(k, ix+2) {
+				t.Errorf("compacted element %d: expected %v, got %v", ix, sampleValue(k, ix+2), got)
+			}
+		}
+	})
+
+	t.Run("readOnly", func(t *testing.T) {
+		buf := newBuffer(4)
+		ro := callBuffer(t, cls+"asReadOnlyBuffer()"+self, buf).(*object.Object)
+		if callBuffer(t, cls+"isReadOnly()Z", ro).(int64) != 1 {
+			t.Errorf("asReadOnlyBuffer should be read-only")
+		}
+		if callBuffer(t, cls+"hasArray()Z", ro).(int64) != 0 {
+			t.Errorf("a read-only buffer should not give access to its array")
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put("+elem+")"+self, ro, sampleValue(k, 0)),
+			excNames.ReadOnlyBufferException, "")
+		testutil.ExpectGErr(t, callBuffer(t, cls+"compact()"+self, ro), excNames.ReadOnlyBufferException, "")
+		callBuffer(t, cls+"put(I"+elem+")"+self, buf, int64(0), sampleValue(k, 4))
+		if got := callBuffer(t, cls+"get()"+elem, ro); got != sampleValue(k, 4) {
+			t.Errorf("the read-only buffer should see changes to the original: expected %v, got %v", sampleValue(k, 4), got)
+		}
+	})
+
+	t.Run("bulkTransfers", func(t *testing.T) {
+		buf := newBuffer(6)
+		src := object.Make1DimArray(k.arrayType, 4)
+		for ix := 0; ix < 4; ix++ {
+			arraySet(src, ix, sampleValue(k, ix))
+		}
+		callBuffer(t, cls+"put("+arr+"II)"+self, buf, src, int64(1), int64(3))
+		if pos := callBuffer(t, cls+"position()I", buf).(int64); pos != 3 {
+			t.Errorf("bulk put: expected position 3, got %d", pos)
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put("+arr+")"+self, buf, src),
+			excNames.BufferOverflowException, "")
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put("+arr+"II)"+self, buf, src, int64(3), int64(2)),
+			excNames.IndexOutOfBoundsException, "")
+
+		callBuffer(t, cls+"flip()"+self, buf)
+		dst := object.Make1DimArray(k.arrayType, 3)
+		callBuffer(t, cls+"get("+arr+")"+self, buf, dst)
+		for ix := 0; ix < 3; ix++ {
+			if got := arrayGet(dst, ix); got != sampleValue(k, ix+1) {
+				t.Errorf("bulk get %d: expected %v, got %v", ix, sampleValue(k, ix+1), got)
+			}
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"get("+arr+")"+self, buf, dst),
+			excNames.BufferUnderflowException, "")
+
+		abs := object.Make1DimArray(k.arrayType, 2)
+		callBuffer(t, cls+"get(I"+arr+")"+self, buf, int64(1), abs)
+		if got := arrayGet(abs, 1); got != sampleValue(k, 3) {
+			t.Errorf("absolute bulk get: expected %v, got %v", sampleValue(k, 3), got)
+		}
+
+		other := newBuffer(6)
+		callBuffer(t, cls+"rewind()"+self, buf)
+		callBuffer(t, cls+"put("+self+")"+self, other, buf)
+		if pos := callBuffer(t, cls+"position()I", other).(int64); pos != 3 {
+			t.Errorf("put(buffer): expected position 3, got %d", pos)
+		}
+		if got := callBuffer(t, cls+"get(I)"+elem, other, int64(2)); got != sampleValue(k, 3) {
+			t.Errorf("put(buffer): expected %v, got %v", sampleValue(k, 3), got)
+		}
+		testutil.ExpectGErr(t, callBuffer(t, cls+"put("+self+")"+self, other, other),
+			excNames.IllegalArgumentException, "")
+	})
+
+	t.Run("equalsCompareToHashCode", func(t *testing.T) {
+		a, b := newBuffer(4), newBuffer(6)
+		for ix := 0; ix < 3; ix++ {
+			callBuffer(t, cls+"put("+elem+")"+self, a, sampleValue(k, ix))
+			callBuffer(t, cls+"put("+elem+")"+self, b, sampleValue(k, ix))
+		}
+		callBuffer(t, cls+"flip()"+self, a)
+		callBuffer(t, cls+"flip()"+self, b)
+		if callBuffer(t, cls+"equals(Ljava/lang/Object;)Z", a, b).(int64) != 1 {
+			t.Errorf("buffers with the same remaining elements should be equal")
+		}
+		if ha, hb := callBuffer(t, cls+"hashCode()I", a), callBuffer(t, cls+"hashCode()I", b); ha != hb {
+			t.Errorf("equal buffers should have equal hash codes: %v and %v", ha, hb)
+		}
+		if c := callBuffer(t, cls+"compareTo("+self+")I", a, b).(int64); c != 0 {
+			t.Errorf("compareTo of equal buffers: expected 0, got %d", c)
+		}
+		if m := callBuffer(t, cls+"mismatch("+self+")I", a, b).(int64); m != -1 {
+			t.Errorf("mismatch of equal buffers: expected -1, got %d", m)
+		}
+
+		callBuffer(t, cls+"put(I"+elem+")"+self, b, int64(1), sampleValue(k, 6))
+		if callBuffer(t, cls+"equals(Ljava/lang/Object;)Z", a, b).(int64) != 0 {
+			t.Errorf("buffers with different elements should not be equal")
+		}
+		if c := callBuffer(t, cls+"compareTo("+self+")I", a, b).(int64); c >= 0 {
+			t.Errorf("compareTo: expected a negative result, got %d", c)
+		}
+		if m := callBuffer(t, cls+"mismatch("+self+")I", a, b).(int64); m != 1 {
+			t.Errorf("mismatch: expected 1, got %d", m)
+		}
+		callBuffer(t, cls+"get()"+elem, a)
+		if callBuffer(t, cls+"equals(Ljava/lang/Object;)Z", a, b).(int64) != 0 {
+			t.Errorf("buffers with different remaining counts should not be equal")
+		}
+	})
+}
+
+func TestBufferAllocateNegative(t *testing.T) {
+	loadBuffersForTest()
+	for _, desc := range bufferDescs {
+		k := bufferKinds[desc]
+		testutil.ExpectGErr(t, callBuffer(t, k.className()+".allocate(I)"+k.classType(), int64(-1)),
+			excNames.IllegalArgumentException, "")
+	}
+}
+
+func TestBufferWrapSharesArray(t *testing.T) {
+	loadBuffersForTest()
+	for _, desc := range bufferDescs {
+		k := bufferKinds[desc]
+		arr := object.Make1DimArray(k.arrayType, 6)
+		buf := callBuffer(t, k.className()+".wrap(["+k.desc+"II)"+k.classType(), arr, int64(2), int64(3)).(*object.Object)
+		b := buf.FieldTable[nioBufferField].Fvalue.(*nioBuffer)
+		if b.position != 2 || b.limit != 5 || b.capacity != 6 {
+			t.Errorf("%sBuffer.wrap(arr, 2, 3): expected position 2, limit 5, capacity 6, got %d %d %d",
+				k.name, b.position, b.limit, b.capacity)
+		}
+		arraySet(arr, 4, sampleValue(k, 3))
+		if got := callBuffer(t, k.className()+".get(I)"+k.desc, buf, int64(4)); got != sampleValue(k, 3) {
+			t.Errorf("%sBuffer should see a change to its array: expected %v, got %v", k.name, sampleValue(k, 3), got)
+		}
+		callBuffer(t, k.className()+".put("+k.desc+")"+k.classType(), buf, sampleValue(k, 1))
+		if got := arrayGet(arr, 2); got != sampleValue(k, 1) {
+			t.Errorf("%sBuffer.put should write to its array: expected %v, got %v", k.name, sampleValue(k, 1), got)
+		}
+		if callBuffer(t, k.className()+".array()["+k.desc, buf) != arr {
+			t.Errorf("%sBuffer.array() should return the wrapped array", k.name)
+		}
+		testutil.ExpectGErr(t, callBuffer(t, k.className()+".wrap(["+k.desc+"II)"+k.classType(), arr, int64(4), int64(3)),
+			excNames.IndexOutOfBoundsException, "")
+	}
+}
+
+func TestBufferFloatComparisons(t *testing.T) {
+	loadBuffersForTest()
+	for _, desc := range []byte{'F', 'D'} {
+		k := bufferKinds[desc]
+		cls, self := k.className()+".", k.classType()
+		a := callBuffer(t, cls+"allocate(I)"+self, int64(2))
+		b := callBuffer(t, cls+"allocate(I)"+self, int64(2))
+		negZero := float64(0)
+		negZero = -negZero
+		callBuffer(t, cls+"put(I"+k.desc+")"+self, a, int64(0), negZero)
+		nan := float64(0)
+		nan = nan / nan
+		callBuffer(t, cls+"put(I"+k.desc+")"+self, a, int64(1), nan)
+		callBuffer(t, cls+"put(I"+k.desc+")"+self, b, int64(1), nan)
+
+		// equals treats -0.0 and 0.0 as equal and NaN as equal to itself
+		if callBuffer(t, cls+"equals(Ljava/lang/Object;)Z", a, b).(int64) != 1 {
+			t.Errorf("%sBuffer.equals: -0.0 should equal 0.0 and NaN should equal NaN", k.name)
+		}
+		// compareTo and mismatch distinguish -0.0 from 0.0
+		if c := callBuffer(t, cls+"compareTo("+self+")I", a, b).(int64); c != -1 {
+			t.Errorf("%sBuffer.compareTo: -0.0 should be less than 0.0, got %d", k.name, c)
+		}
+		if m := callBuffer(t, cls+"mismatch("+self+")I", a, b).(int64); m != 0 {
+			t.Errorf("%sBuffer.mismatch: expected 0, got %d", k.name, m)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+)
+
+// The methods that only ByteBuffer has: direct allocation, the byte order, and reading and
+// writing the other primitive types and views of them. See javaNioBuffer.go for the rest.
+
+func Load_Nio_ByteBuffer() {
+	byteKind := bufferKinds['B']
+
+	ghelpers.MethodSignatures["java/nio/ByteBuffer.allocateDirect(I)Ljava/nio/ByteBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: bufferAllocateFor(byteKind, true)}
+
+	ghelpers.MethodSignatures["java/nio/ByteBuffer.order(Ljava/nio/ByteOrder;)Ljava/nio/ByteBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: byteBufferSetOrder}
+
+	// getChar()C, getChar(I)C, putChar(C)Ljava/nio/ByteBuffer;, asCharBuffer()Ljava/nio/CharBuffer; ...
+	for _, desc := range bufferDescs[1:] {
+		k := bufferKinds[desc]
+		prefix := "java/nio/ByteBuffer."
+		ghelpers.MethodSignatures[prefix+"get"+k.name+"()"+k.desc] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: byteBufferGetFor(desc)}
+		ghelpers.MethodSignatures[prefix+"get"+k.name+"(I)"+k.desc] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: byteBufferGetFor(desc)}
+		ghelpers.MethodSignatures[prefix+"put"+k.name+"("+k.desc+")Ljava/nio/ByteBuffer;"] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: byteBufferPutFor(desc)}
+		ghelpers.MethodSignatures[prefix+"put"+k.name+"(I"+k.desc+")Ljava/nio/ByteBuffer;"] =
+			ghelpers.GMeth{ParamSlots: 2, GFunction: byteBufferPutFor(desc)}
+		ghelpers.MethodSignatures[prefix+"as"+k.name+"Buffer()"+k.classType()] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: byteBufferViewFor(k)}
+	}
+}
+
+// java/nio/ByteBuffer.order(Ljava/nio/ByteOrder;)Ljava/nio/ByteBuffer;
+func byteBufferSetOrder(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "order")
+	if gerr != nil {
+		return gerr
+	}
+	b.bigEndian = isBigEndianOrder(params[1])
+	return params[0]
+}
+
+// byteBufferGetFor returns the G function for getX()X or getX(I)X, which read the bytes at the
+// position or at an index as a value of type desc in the buffer's byte order.
+func byteBufferGetFor(desc byte) func([]interface{}) interface{} {
+	size := bufferKinds[desc].size
+	return func(params []interface{}) interface{} {
+		b, gerr := bufferThis(params[0], "get")
+		if gerr != nil {
+			return gerr
+		}
+		var at int
+		if len(params) == 2 {
+			at = int(params[1].(int64))
+			if gerr := checkFromIndexSize(at, size, b.limit); gerr != nil {
+				return gerr
+			}
+		} else {
+			if b.remaining() < size {
+				return ghelpers.GetGErrBlk(excNames.BufferUnderflowException, "")
+			}
+			at = b.position
+			b.position += size
+		}
+		return readElement(b.bytes, b.offset+at, desc, b.bigEndian)
+	}
+}
+
+// byteBufferPutFor returns the G function for putX(X) or putX(IX), which write a value of type
+// desc at the position or at an index in the buffer's byte order.
+func byteBufferPutFor(desc byte) func([]interface{}) interface{} {
+	size := bufferKinds[desc].size
+	return func(params []interface{}) interface{} {
+		b, gerr := bufferThis(params[0], "put")
+		if gerr != nil {
+			return gerr
+		}
+		if b.readOnly {
+			return readOnlyError()
+		}
+		var at int
+		value := params[1]
+		if len(params) == 3 {
+			at, value = int(params[1].(int64)), params[2]
+			if gerr := checkFromIndexSize(at, size, b.limit); gerr != nil {
+				return gerr
+			}
+		} else {
+			if b.remaining() < size {
+				return ghelpers.GetGErrBlk(excNames.BufferOverflowException, "")
+			}
+			at = b.position
+			b.position += size
+		}
+		writeElement(b.bytes, b.offset+at, desc, b.bigEndian, value)
+		return params[0]
+	}
+}
+
+// byteBufferViewFor returns the G function for asXBuffer(), which makes a buffer of kind k over
+// the remaining bytes of a byte buffer, in its current byte order.
+func byteBufferViewFor(k *bufferKind) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		b, gerr := bufferThis(params[0], "as"+k.name+"Buffer")
+		if gerr != nil {
+			return gerr
+		}
+		capacity := b.remaining() / k.size
+		view := &nioBuffer{kind: k, view: true, bytes: b.bytes, offset: b.offset + b.position,
+			capacity: capacity, limit: capacity, mark: -1,
+			bigEndian: b.bigEndian, direct: b.direct, readOnly: b.readOnly, mapped: b.mapped}
+		return newBufferObject(view)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+const bbClass = "java/nio/ByteBuffer."
+const bbType = "Ljava/nio/ByteBuffer;"
+
+func byteBufferBytes(t *testing.T, buf *object.Object) []types.JavaByte {
+	t.Helper()
+	b := buf.FieldTable[nioBufferField].Fvalue.(*nioBuffer)
+	return b.bytes[b.offset : b.offset+b.capacity]
+}
+
+func TestByteOrder(t *testing.T) {
+	loadBuffersForTest()
+	byteOrderClinit(nil)
+
+	big := statics.GetStaticValue("java/nio/ByteOrder", "BIG_ENDIAN")
+	little := statics.GetStaticValue("java/nio/ByteOrder", "LITTLE_ENDIAN")
+	if big != byteOrderObject(true) || little != byteOrderObject(false) {
+		t.Fatalf("the ByteOrder statics should be the ByteOrder singletons")
+	}
+	if str := object.GoStringFromStringObject(byteOrderToString([]interface{}{big}).(*object.Object)); str != "BIG_ENDIAN" {
+		t.Errorf("BIG_ENDIAN.toString(): got %q", str)
+	}
+	if byteOrderNativeOrder(nil) != byteOrderObject(nativeBigEndian) {
+		t.Errorf("nativeOrder() should match the host")
+	}
+
+	buf := callBuffer(t, bbClass+"allocate(I)"+bbType, int64(4))
+	if callBuffer(t, bbClass+"order()Ljava/nio/ByteOrder;", buf) != big {
+		t.Errorf("a new ByteBuffer should be big-endian")
+	}
+	ints := callBuffer(t, "java/nio/IntBuffer.allocate(I)Ljava/nio/IntBuffer;", int64(4))
+	if callBuffer(t, "java/nio/IntBuffer.order()Ljava/nio/ByteOrder;", ints) != byteOrderNativeOrder(nil) {
+		t.Errorf("a new IntBuffer should have the native byte order")
+	}
+}
+
+func TestByteBufferTypedAccess(t *testing.T) {
+	loadBuffersForTest()
+	buf := callBuffer(t, bbClass+"allocate(I)"+bbType, int64(32)).(*object.Object)
+
+	callBuffer(t, bbClass+"putInt(I)"+bbType, buf, int64(0x01020304))
+	callBuffer(t, bbClass+"order(Ljava/nio/ByteOrder;)"+bbType, buf, byteOrderObject(false))
+	callBuffer(t, bbClass+"putInt(I)"+bbType, buf, int64(0x01020304))
+	got := byteBufferBytes(t, buf)[:8]
+	want := []types.JavaByte{1, 2, 3, 4, 4, 3, 2, 1}
+	for ix := range want {
+		if got[ix] != want[ix] {
+			t.Fatalf("putInt big-endian then little-endian: expected %v, got %v", want, got)
+		}
+	}
+
+	callBuffer(t, bbClass+"putShort(S)"+bbType, buf, int64(-2))
+	callBuffer(t, bbClass+"putChar(C)"+bbType, buf, int64('é'))
+	callBuffer(t, bbClass+"putLong(J)"+bbType, buf, int64(-1234567890123))
+	callBuffer(t, bbClass+"putFloat(F)"+bbType, buf, float64(float32(3.25)))
+	callBuffer(t, bbClass+"putDouble(ID)"+bbType, buf, int64(24), -0.125)
+	if pos := callBuffer(t, bbClass+"position()I", buf).(int64); pos != 24 {
+		t.Errorf("position after the relative puts: expected 24, got %d", pos)
+	}
+
+	callBuffer(t, bbClass+"position(I)"+bbType, buf, int64(8))
+	checks := []struct {
+		sig  string
+		want interface{}
+	}{
+		{"getShort()S", int64(-2)},
+		{"getChar()C", int64('é')},
+		{"getLong()J", int64(-1234567890123)},
+		{"getFloat()F", float64(float32(3.25))},
+	}
+	for _, c := range checks {
+		if got := callBuffer(t, bbClass+c.sig, buf); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.sig, c.want, got)
+		}
+	}
+	if got := callBuffer(t, bbClass+"getDouble(I)D", buf, int64(24)); got != -0.125 {
+		t.Errorf("getDouble(24): expected -0.125, got %v", got)
+	}
+	if got := callBuffer(t, bbClass+"getInt(I)I", buf, int64(4)); got != int64(0x01020304) {
+		t.Errorf("getInt(4) little-endian: expected 0x01020304, got %#x", got)
+	}
+	if got := callBuffer(t, bbClass+"get(I)B", buf, int64(7)); got != int64(1) {
+		t.Errorf("get(7): expected 1, got %v", got)
+	}
+
+	testutil.ExpectGErr(t, callBuffer(t, bbClass+"getLong(I)J", buf, int64(28)), excNames.IndexOutOfBoundsException, "")
+	callBuffer(t, bbClass+"position(I)"+bbType, buf, int64(30))
+	testutil.ExpectGErr(t, callBuffer(t, bbClass+"getInt()I", buf), excNames.BufferUnderflowException, "")
+	testutil.ExpectGErr(t, callBuffer(t, bbClass+"putInt(I)"+bbType, buf, int64(1)),
+		excNames.BufferOverflowException, "")
+}
+
+func TestByteBufferViews(t *testing.T) {
+	loadBuffersForTest()
+	buf := callBuffer(t, bbClass+"allocate(I)"+bbType, int64(18)).(*object.Object)
+	callBuffer(t, bbClass+"position(I)"+bbType, buf, int64(2))
+
+	ints := callBuffer(t, bbClass+"asIntBuffer()Ljava/nio/IntBuffer;", buf).(*object.Object)
+	if capacity := callBuffer(t, "java/nio/IntBuffer.capacity()I", ints).(int64); capacity != 4 {
+		t.Errorf("asIntBuffer over 16 bytes: expected capacity 4, got %d", capacity)
+	}
+	callBuffer(t, "java/nio/IntBuffer.put(II)Ljava/nio/IntBuffer;", ints, int64(1), int64(-2))
+	if got := callBuffer(t, bbClass+"getInt(I)I", buf, int64(6)); got != int64(-2) {
+		t.Errorf("a put to the view should be seen in the byte buffer: expected -2, got %v", got)
+	}
+	callBuffer(t, bbClass+"putInt(II)"+bbType, buf, int64(10), int64(77))
+	if got := callBuffer(t, "java/nio/IntBuffer.get(I)I", ints, int64(2)); got != int64(77) {
+		t.Errorf("a put to the byte buffer should be seen in the view: expected 77, got %v", got)
+	}
+	if callBuffer(t, "java/nio/IntBuffer.hasArray()Z", ints).(int64) != 0 {
+		t.Errorf("a view should not have an accessible array")
+	}
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/IntBuffer.array()[I", ints),
+		excNames.UnsupportedOperationException, "")
+
+	callBuffer(t, bbClass+"order(Ljava/nio/ByteOrder;)"+bbType, buf, byteOrderObject(false))
+	doubles := callBuffer(t, bbClass+"asDoubleBuffer()Ljava/nio/DoubleBuffer;", buf).(*object.Object)
+	if callBuffer(t, "java/nio/DoubleBuffer.order()Ljava/nio/ByteOrder;", doubles) != byteOrderObject(false) {
+		t.Errorf("a view should have the byte order of its byte buffer")
+	}
+	callBuffer(t, "java/nio/DoubleBuffer.put(D)Ljava/nio/DoubleBuffer;", doubles, 2.5)
+	if got := callBuffer(t, bbClass+"getDouble(I)D", buf, int64(2)); got != 2.5 {
+		t.Errorf("little-endian view: expected 2.5, got %v", got)
+	}
+	str := object.GoStringFromStringObject(callBuffer(t, "java/nio/DoubleBuffer.toString()Ljava/lang/String;", doubles).(*object.Object))
+	if str != "java.nio.ByteBufferAsDoubleBufferL[pos=1 lim=2 cap=2]" {
+		t.Errorf("view toString(): got %q", str)
+	}
+}
+
+func TestByteBufferWrapAndDirect(t *testing.T) {
+	loadBuffersForTest()
+	arr := object.Make1DimArray(object.T_BYTE, 8)
+	buf := callBuffer(t, bbClass+"wrap([B)"+bbType, arr).(*object.Object)
+	callBuffer(t, bbClass+"putInt(II)"+bbType, buf, int64(4), int64(0x7f00ff01))
+	raw := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	if raw[4] != 0x7f || raw[5] != 0 || raw[6] != -1 || raw[7] != 1 {
+		t.Errorf("putInt should write through to the wrapped byte[]: got %v", raw)
+	}
+	raw[0] = -128
+	if got := callBuffer(t, bbClass+"get()B", buf); got != int64(-128) {
+		t.Errorf("get() should read the wrapped byte[]: expected -128, got %v", got)
+	}
+	if callBuffer(t, bbClass+"arrayOffset()I", buf).(int64) != 0 {
+		t.Errorf("arrayOffset of a wrapped array: expected 0")
+	}
+	slice := callBuffer(t, bbClass+"slice()"+bbType, buf)
+	if callBuffer(t, bbClass+"arrayOffset()I", slice).(int64) != 1 {
+		t.Errorf("arrayOffset of a slice at position 1: expected 1")
+	}
+
+	direct := callBuffer(t, bbClass+"allocateDirect(I)"+bbType, int64(16)).(*object.Object)
+	if callBuffer(t, bbClass+"isDirect()Z", direct).(int64) != 1 {
+		t.Errorf("allocateDirect should make a direct buffer")
+	}
+	if callBuffer(t, bbClass+"hasArray()Z", direct).(int64) != 0 {
+		t.Errorf("a direct buffer should not have an accessible array")
+	}
+	if callBuffer(t, bbClass+"isDirect()Z", callBuffer(t, bbClass+"slice()"+bbType, direct)).(int64) != 1 {
+		t.Errorf("a slice of a direct buffer should be direct")
+	}
+	testutil.ExpectGErr(t, callBuffer(t, bbClass+"allocateDirect(I)"+bbType, int64(-1)),
+		excNames.IllegalArgumentException, "")
+
+	for _, c := range []struct {
+		buf  interface{}
+		want string
+	}{
+		{buf, "java.nio.HeapByteBuffer[pos=1 lim=8 cap=8]"},
+		{direct, "java.nio.DirectByteBuffer[pos=0 lim=16 cap=16]"},
+		{callBuffer(t, bbClass+"asReadOnlyBuffer()"+bbType, buf), "java.nio.HeapByteBufferR[pos=1 lim=8 cap=8]"},
+	} {
+		str := object.GoStringFromStringObject(callBuffer(t, bbClass+"toString()Ljava/lang/String;", c.buf).(*object.Object))
+		if str != c.want {
+			t.Errorf("toString(): expected %q, got %q", c.want, str)
+		}
+	}
+}
+
+func TestByteBufferDerivedBuffersAreBigEndian(t *testing.T) {
+	loadBuffersForTest()
+	buf := callBuffer(t, bbClass+"allocate(I)"+bbType, int64(8))
+	callBuffer(t, bbClass+"order(Ljava/nio/ByteOrder;)"+bbType, buf, byteOrderObject(false))
+	for _, sig := range []string{"slice()", "duplicate()", "asReadOnlyBuffer()"} {
+		derived := callBuffer(t, bbClass+sig+bbType, buf)
+		if callBuffer(t, bbClass+"order()Ljava/nio/ByteOrder;", derived) != byteOrderObject(true) {
+			t.Errorf("%s of a little-endian buffer should be big-endian", sig)
+		}
+	}
+}
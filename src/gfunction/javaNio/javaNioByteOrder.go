@@ -0,0 +1,91 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"sync"
+)
+
+func Load_Nio_ByteOrder() {
+	ghelpers.MethodSignatures["java/nio/ByteOrder.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  byteOrderClinit,
+		}
+
+	ghelpers.MethodSignatures["java/nio/ByteOrder.nativeOrder()Ljava/nio/ByteOrder;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  byteOrderNativeOrder,
+		}
+
+	ghelpers.MethodSignatures["java/nio/ByteOrder.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  byteOrderToString,
+		}
+}
+
+var byteOrderMutex = sync.Mutex{}
+var byteOrderClassName = "java/nio/ByteOrder"
+var byteOrderBig, byteOrderLittle *object.Object
+
+func ensureByteOrderInited() {
+	byteOrderMutex.Lock()
+	defer byteOrderMutex.Unlock()
+	if byteOrderBig != nil {
+		return
+	}
+	makeOrder := func(name string) *object.Object {
+		obj := object.MakeEmptyObjectWithClassName(&byteOrderClassName)
+		obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+		_ = statics.AddStatic(byteOrderClassName+"."+name, statics.Static{Type: "Ljava/nio/ByteOrder;", Value: obj})
+		return obj
+	}
+	byteOrderBig = makeOrder("BIG_ENDIAN")
+	byteOrderLittle = makeOrder("LITTLE_ENDIAN")
+}
+
+func byteOrderClinit([]interface{}) interface{} {
+	ensureByteOrderInited()
+	return nil
+}
+
+// byteOrderObject returns ByteOrder.BIG_ENDIAN or ByteOrder.LITTLE_ENDIAN.
+func byteOrderObject(bigEndian bool) *object.Object {
+	ensureByteOrderInited()
+	if bigEndian {
+		return byteOrderBig
+	}
+	return byteOrderLittle
+}
+
+// isBigEndianOrder reports whether order is ByteOrder.BIG_ENDIAN. As in the JDK, any other value,
+// null included, means little-endian.
+func isBigEndianOrder(order any) bool {
+	obj, ok := order.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return false
+	}
+	name, ok := obj.FieldTable["name"].Fvalue.(*object.Object)
+	return ok && object.GoStringFromStringObject(name) == "BIG_ENDIAN"
+}
+
+func byteOrderNativeOrder([]interface{}) interface{} {
+	return byteOrderObject(nativeBigEndian)
+}
+
+func byteOrderToString(params []interface{}) interface{} {
+	if isBigEndianOrder(params[0]) {
+		return object.StringObjectFromGoString("BIG_ENDIAN")
+	}
+	return object.StringObjectFromGoString("LITTLE_ENDIAN")
+}
@@ -0,0 +1,231 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// The methods that CharBuffer has as a CharSequence and an Appendable. Its positions and
+// lengths count characters (runes), not the UTF-8 bytes of Jacobin's strings.
+// See javaNioBuffer.go for the rest.
+
+func Load_Nio_CharBuffer() {
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.wrap(Ljava/lang/CharSequence;)Ljava/nio/CharBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: charBufferWrapSequence, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.wrap(Ljava/lang/CharSequence;II)Ljava/nio/CharBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: charBufferWrapSequence, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: charBufferToString}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.length()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: bufferRemaining}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: charBufferIsEmpty}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.charAt(I)C"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: charBufferCharAt}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.subSequence(II)Ljava/nio/CharBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: charBufferSubSequence}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.subSequence(II)Ljava/lang/CharSequence;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: charBufferSubSequence}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.put(Ljava/lang/String;)Ljava/nio/CharBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: charBufferPutString}
+
+	ghelpers.MethodSignatures["java/nio/CharBuffer.put(Ljava/lang/String;II)Ljava/nio/CharBuffer;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: charBufferPutString}
+
+	for _, ret := range []string{"Ljava/nio/CharBuffer;", "Ljava/lang/Appendable;"} {
+		ghelpers.MethodSignatures["java/nio/CharBuffer.append(C)"+ret] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: bufferPut}
+		ghelpers.MethodSignatures["java/nio/CharBuffer.append(Ljava/lang/CharSequence;)"+ret] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: charBufferAppend, NeedsContext: true}
+		ghelpers.MethodSignatures["java/nio/CharBuffer.append(Ljava/lang/CharSequence;II)"+ret] =
+			ghelpers.GMeth{ParamSlots: 3, GFunction: charBufferAppend, NeedsContext: true}
+	}
+}
+
+// charSequenceRunes returns the characters of a CharSequence argument. null is "null", as for
+// append(); other CharSequences than String are converted by their toString() method, which runs
+// on the frame stack fs.
+func charSequenceRunes(fs *list.List, arg any) ([]rune, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return []rune(types.NullString), nil
+	}
+	if object.IsStringObject(obj) {
+		return []rune(object.GoStringFromStringObject(obj)), nil
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, obj, "toString", "()Ljava/lang/String;")
+	switch r := ret.(type) {
+	case *object.Object:
+		return []rune(object.GoStringFromStringObject(r)), nil
+	case *ghelpers.GErrBlk:
+		return nil, r
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "charSequenceRunes: toString() failed")
+}
+
+// runeRange returns runes[start:end], checked as the start and end arguments of the CharSequence
+// methods are.
+func runeRange(runes []rune, start, end int64) ([]rune, *ghelpers.GErrBlk) {
+	if start < 0 || end < start || end > int64(len(runes)) {
+		errMsg := fmt.Sprintf("start %d, end %d, length %d", start, end, len(runes))
+		return nil, ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	return runes[start:end], nil
+}
+
+// java/nio/CharBuffer.wrap(Ljava/lang/CharSequence;) and wrap(Ljava/lang/CharSequence;II)
+// The buffer is read-only. Its contents are those of the sequence at the time of the call.
+func charBufferWrapSequence(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if obj, ok := params[0].(*object.Object); !ok || object.IsNull(obj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "wrap: char sequence is null")
+	}
+	runes, gerr := charSequenceRunes(fs, params[0])
+	if gerr != nil {
+		return gerr
+	}
+	start, end := 0, len(runes)
+	if len(params) == 3 {
+		if _, gerr := runeRange(runes, params[1].(int64), params[2].(int64)); gerr != nil {
+			return gerr
+		}
+		start, end = int(params[1].(int64)), int(params[2].(int64))
+	}
+	chars := make([]int64, len(runes))
+	for ix, r := range runes {
+		chars[ix] = int64(r)
+	}
+	b := &nioBuffer{kind: bufferKinds['C'], ints: chars, capacity: len(chars), limit: end, position: start,
+		mark: -1, bigEndian: nativeBigEndian, readOnly: true}
+	return newBufferObject(b)
+}
+
+// java/nio/CharBuffer.toString()Ljava/lang/String; -- the remaining characters
+func charBufferToString(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "toString")
+	if gerr != nil {
+		return gerr
+	}
+	runes := make([]rune, b.remaining())
+	for ix := range runes {
+		runes[ix] = rune(b.get(b.position + ix).(int64))
+	}
+	return object.StringObjectFromGoString(string(runes))
+}
+
+func charBufferIsEmpty(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "isEmpty")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.remaining() == 0)
+}
+
+// java/nio/CharBuffer.charAt(I)C -- the index is relative to the position
+func charBufferCharAt(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "charAt")
+	if gerr != nil {
+		return gerr
+	}
+	ix := int(params[1].(int64))
+	if gerr := checkIndex(ix, b.remaining()); gerr != nil {
+		return gerr
+	}
+	return b.get(b.position + ix)
+}
+
+// java/nio/CharBuffer.subSequence(II) -- a buffer over the same storage, with the same capacity,
+// whose position and limit are start and end relative to the current position
+func charBufferSubSequence(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "subSequence")
+	if gerr != nil {
+		return gerr
+	}
+	start, end := params[1].(int64), params[2].(int64)
+	if start < 0 || end < start || end > int64(b.remaining()) {
+		errMsg := fmt.Sprintf("start %d, end %d, length %d", start, end, b.remaining())
+		return ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	nb := *b
+	nb.position, nb.limit, nb.mark = b.position+int(start), b.position+int(end), -1
+	return newBufferObject(&nb)
+}
+
+// putRunes writes runes at the position of a char buffer.
+func putRunes(b *nioBuffer, runes []rune) *ghelpers.GErrBlk {
+	if b.readOnly {
+		return readOnlyError()
+	}
+	if len(runes) > b.remaining() {
+		return ghelpers.GetGErrBlk(excNames.BufferOverflowException, "")
+	}
+	for _, r := range runes {
+		b.set(b.position, int64(r))
+		b.position++
+	}
+	return nil
+}
+
+// java/nio/CharBuffer.put(Ljava/lang/String;) and put(Ljava/lang/String;II) -- start and end,
+// not offset and length
+func charBufferPutString(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "put")
+	if gerr != nil {
+		return gerr
+	}
+	strObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "put: string is null")
+	}
+	runes := []rune(object.GoStringFromStringObject(strObj))
+	if len(params) == 4 {
+		if runes, gerr = runeRange(runes, params[2].(int64), params[3].(int64)); gerr != nil {
+			return gerr
+		}
+	}
+	if gerr := putRunes(b, runes); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+// java/nio/CharBuffer.append(Ljava/lang/CharSequence;) and append(Ljava/lang/CharSequence;II)
+func charBufferAppend(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	b, gerr := bufferThis(params[0], "append")
+	if gerr != nil {
+		return gerr
+	}
+	runes, gerr := charSequenceRunes(fs, params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 4 {
+		if runes, gerr = runeRange(runes, params[2].(int64), params[3].(int64)); gerr != nil {
+			return gerr
+		}
+	}
+	if gerr := putRunes(b, runes); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
@@ -0,0 +1,97 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"testing"
+)
+
+const cbClass = "java/nio/CharBuffer."
+const cbType = "Ljava/nio/CharBuffer;"
+
+func charBufferString(t *testing.T, buf interface{}) string {
+	t.Helper()
+	return object.GoStringFromStringObject(callBuffer(t, cbClass+"toString()Ljava/lang/String;", buf).(*object.Object))
+}
+
+func TestCharBufferWrapCharSequence(t *testing.T) {
+	loadBuffersForTest()
+	buf := callBuffer(t, cbClass+"wrap(Ljava/lang/CharSequence;II)"+cbType,
+		object.StringObjectFromGoString("añb€c"), int64(1), int64(4)).(*object.Object)
+
+	if got := charBufferString(t, buf); got != "ñb€" {
+		t.Errorf("toString(): expected %q, got %q", "ñb€", got)
+	}
+	if n := callBuffer(t, cbClass+"length()I", buf).(int64); n != 3 {
+		t.Errorf("length(): expected 3, got %d", n)
+	}
+	if ch := callBuffer(t, cbClass+"charAt(I)C", buf, int64(2)).(int64); ch != '€' {
+		t.Errorf("charAt(2): expected '€', got %q", rune(ch))
+	}
+	testutil.ExpectGErr(t, callBuffer(t, cbClass+"charAt(I)C", buf, int64(3)), excNames.IndexOutOfBoundsException, "")
+	if callBuffer(t, cbClass+"isReadOnly()Z", buf).(int64) != 1 {
+		t.Errorf("a CharBuffer that wraps a CharSequence should be read-only")
+	}
+	testutil.ExpectGErr(t, callBuffer(t, cbClass+"put(C)"+cbType, buf, int64('x')), excNames.ReadOnlyBufferException, "")
+
+	sub := callBuffer(t, cbClass+"subSequence(II)"+cbType, buf, int64(1), int64(3))
+	if got := charBufferString(t, sub); got != "b€" {
+		t.Errorf("subSequence(1, 3): expected %q, got %q", "b€", got)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, cbClass+"subSequence(II)"+cbType, buf, int64(2), int64(4)),
+		excNames.IndexOutOfBoundsException, "")
+	testutil.ExpectGErr(t, callBuffer(t, cbClass+"wrap(Ljava/lang/CharSequence;)"+cbType, object.Null),
+		excNames.NullPointerException, "")
+}
+
+func TestCharBufferPutAndAppend(t *testing.T) {
+	loadBuffersForTest()
+	buf := callBuffer(t, cbClass+"allocate(I)"+cbType, int64(12)).(*object.Object)
+
+	callBuffer(t, cbClass+"put(Ljava/lang/String;)"+cbType, buf, object.StringObjectFromGoString("hé"))
+	callBuffer(t, cbClass+"put(Ljava/lang/String;II)"+cbType, buf, object.StringObjectFromGoString("xyz"), int64(1), int64(2))
+	callBuffer(t, cbClass+"append(C)"+cbType, buf, int64('!'))
+	callBuffer(t, cbClass+"append(Ljava/lang/CharSequence;)"+cbType, buf, object.Null)
+	ret := callBuffer(t, cbClass+"append(Ljava/lang/CharSequence;II)Ljava/lang/Appendable;", buf,
+		object.StringObjectFromGoString("abcd"), int64(2), int64(4))
+	if ret != buf {
+		t.Errorf("append should return the buffer")
+	}
+	callBuffer(t, cbClass+"flip()"+cbType, buf)
+	if got := charBufferString(t, buf); got != "héy!nullcd" {
+		t.Errorf("contents: expected %q, got %q", "héy!nullcd", got)
+	}
+
+	chars := buf.FieldTable[nioBufferField].Fvalue.(*nioBuffer).ints
+	if chars[1] != 'é' {
+		t.Errorf("the buffer should hold one element per character, got %v", chars[:4])
+	}
+
+	callBuffer(t, cbClass+"clear()"+cbType, buf)
+	callBuffer(t, cbClass+"position(I)"+cbType, buf, int64(10))
+	testutil.ExpectGErr(t, callBuffer(t, cbClass+"put(Ljava/lang/String;)"+cbType, buf, object.StringObjectFromGoString("abc")),
+		excNames.BufferOverflowException, "")
+	if pos := callBuffer(t, cbClass+"position()I", buf).(int64); pos != 10 {
+		t.Errorf("a put that overflows should not change the position, got %d", pos)
+	}
+}
+
+func TestCharBufferWrapCharArray(t *testing.T) {
+	loadBuffersForTest()
+	arr := object.MakePrimitiveObject("[C", "[C", []int64{'a', 'b', 'c'})
+	buf := callBuffer(t, cbClass+"wrap([C)"+cbType, arr)
+	callBuffer(t, cbClass+"put(IC)"+cbType, buf, int64(1), int64('Z'))
+	if got := charBufferString(t, buf); got != "aZc" {
+		t.Errorf("CharBuffer.wrap(char[]): expected %q, got %q", "aZc", got)
+	}
+	if arr.FieldTable["value"].Fvalue.([]int64)[1] != 'Z' {
+		t.Errorf("a put should write through to the wrapped char[]")
+	}
+}
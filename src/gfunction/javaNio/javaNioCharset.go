@@ -0,0 +1,645 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"strings"
+	"sync"
+)
+
+// java.nio.charset: Charset, StandardCharsets, CharsetEncoder, CharsetDecoder and
+// CodingErrorAction, over the charsets of ghelpers/charsets.go. There is one Charset object per
+// charset; its name field holds the canonical name, as in the JDK. An encoder or decoder holds its
+// Charset in its charset field and its state, a *charsetCoder, in its coderField field.
+
+const (
+	charsetClassName           = "java/nio/charset/Charset"
+	charsetEncoderClassName    = "java/nio/charset/CharsetEncoder"
+	charsetDecoderClassName    = "java/nio/charset/CharsetDecoder"
+	codingErrorActionClassName = "java/nio/charset/CodingErrorAction"
+	coderField                 = "coder"
+)
+
+// charsetCoder is the state of a CharsetEncoder or CharsetDecoder.
+type charsetCoder struct {
+	cs          *ghelpers.Charset
+	malformed   ghelpers.CodingAction
+	unmappable  ghelpers.CodingAction
+	replacement []byte // an encoder's replacement bytes
+	replaceWith string // a decoder's replacement string
+}
+
+func Load_Nio_Charset() {
+	ghelpers.MethodSignatures["java/nio/charset/Charset.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"forName(Ljava/lang/String;)Ljava/nio/charset/Charset;":                           {ParamSlots: 1, GFunction: charsetForName},
+		"forName(Ljava/lang/String;Ljava/nio/charset/Charset;)Ljava/nio/charset/Charset;": {ParamSlots: 2, GFunction: charsetForName},
+		"defaultCharset()Ljava/nio/charset/Charset;":                                      {ParamSlots: 0, GFunction: charsetDefault},
+		"isSupported(Ljava/lang/String;)Z":                                                {ParamSlots: 1, GFunction: charsetIsSupported},
+		"name()Ljava/lang/String;":                                                        {ParamSlots: 0, GFunction: charsetName},
+		"displayName()Ljava/lang/String;":                                                 {ParamSlots: 0, GFunction: charsetName},
+		"displayName(Ljava/util/Locale;)Ljava/lang/String;":                               {ParamSlots: 1, GFunction: charsetName},
+		"toString()Ljava/lang/String;":                                                    {ParamSlots: 0, GFunction: charsetName},
+		"isRegistered()Z":                                                                 {ParamSlots: 0, GFunction: ghelpers.ReturnTrue},
+		"canEncode()Z":                                                                    {ParamSlots: 0, GFunction: ghelpers.ReturnTrue},
+		"contains(Ljava/nio/charset/Charset;)Z":                                           {ParamSlots: 1, GFunction: charsetContains},
+		"equals(Ljava/lang/Object;)Z":                                                     {ParamSlots: 1, GFunction: charsetEquals},
+		"hashCode()I":                                                                     {ParamSlots: 0, GFunction: charsetHashCode},
+		"compareTo(Ljava/lang/Object;)I":                                                  {ParamSlots: 1, GFunction: charsetCompareTo},
+		"compareTo(Ljava/nio/charset/Charset;)I":                                          {ParamSlots: 1, GFunction: charsetCompareTo},
+		"newEncoder()Ljava/nio/charset/CharsetEncoder;":                                   {ParamSlots: 0, GFunction: charsetNewEncoder},
+		"newDecoder()Ljava/nio/charset/CharsetDecoder;":                                   {ParamSlots: 0, GFunction: charsetNewDecoder},
+		"encode(Ljava/lang/String;)Ljava/nio/ByteBuffer;":                                 {ParamSlots: 1, GFunction: charsetEncode},
+		"encode(Ljava/nio/CharBuffer;)Ljava/nio/ByteBuffer;":                              {ParamSlots: 1, GFunction: charsetEncode},
+		"decode(Ljava/nio/ByteBuffer;)Ljava/nio/CharBuffer;":                              {ParamSlots: 1, GFunction: charsetDecode},
+		"availableCharsets()Ljava/util/SortedMap;":                                        {ParamSlots: 0, GFunction: ghelpers.TrapFunction},
+		"aliases()Ljava/util/Set;":                                                        {ParamSlots: 0, GFunction: ghelpers.TrapFunction},
+	} {
+		ghelpers.MethodSignatures[charsetClassName+"."+sig] = gmeth
+	}
+
+	ghelpers.MethodSignatures["java/nio/charset/StandardCharsets.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: standardCharsetsClinit}
+
+	ghelpers.MethodSignatures["java/nio/charset/CodingErrorAction.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: codingErrorActionClinit}
+
+	ghelpers.MethodSignatures["java/nio/charset/CodingErrorAction.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: codingErrorActionToString}
+
+	coderMethods := map[string]ghelpers.GMeth{
+		"<clinit>()V":                         {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"charset()Ljava/nio/charset/Charset;": {ParamSlots: 0, GFunction: coderCharset},
+		"malformedInputAction()Ljava/nio/charset/CodingErrorAction;":      {ParamSlots: 0, GFunction: coderMalformedAction},
+		"unmappableCharacterAction()Ljava/nio/charset/CodingErrorAction;": {ParamSlots: 0, GFunction: coderUnmappableAction},
+	}
+	for sig, gmeth := range coderMethods {
+		ghelpers.MethodSignatures[charsetEncoderClassName+"."+sig] = gmeth
+		ghelpers.MethodSignatures[charsetDecoderClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"onMalformedInput(Ljava/nio/charset/CodingErrorAction;)Ljava/nio/charset/CharsetEncoder;":      {ParamSlots: 1, GFunction: coderOnMalformed},
+		"onUnmappableCharacter(Ljava/nio/charset/CodingErrorAction;)Ljava/nio/charset/CharsetEncoder;": {ParamSlots: 1, GFunction: coderOnUnmappable},
+		"replacement()[B": {ParamSlots: 0, GFunction: encoderReplacement},
+		"replaceWith([B)Ljava/nio/charset/CharsetEncoder;":   {ParamSlots: 1, GFunction: encoderReplaceWith},
+		"isLegalReplacement([B)Z":                            {ParamSlots: 1, GFunction: encoderIsLegalReplacement},
+		"averageBytesPerChar()F":                             {ParamSlots: 0, GFunction: encoderAverageBytesPerChar},
+		"maxBytesPerChar()F":                                 {ParamSlots: 0, GFunction: encoderMaxBytesPerChar},
+		"canEncode(C)Z":                                      {ParamSlots: 1, GFunction: encoderCanEncode, NeedsContext: true},
+		"canEncode(Ljava/lang/CharSequence;)Z":               {ParamSlots: 1, GFunction: encoderCanEncode, NeedsContext: true},
+		"encode(Ljava/nio/CharBuffer;)Ljava/nio/ByteBuffer;": {ParamSlots: 1, GFunction: encoderEncode},
+		"reset()Ljava/nio/charset/CharsetEncoder;":           {ParamSlots: 0, GFunction: coderReset},
+	} {
+		ghelpers.MethodSignatures[charsetEncoderClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"onMalformedInput(Ljava/nio/charset/CodingErrorAction;)Ljava/nio/charset/CharsetDecoder;":      {ParamSlots: 1, GFunction: coderOnMalformed},
+		"onUnmappableCharacter(Ljava/nio/charset/CodingErrorAction;)Ljava/nio/charset/CharsetDecoder;": {ParamSlots: 1, GFunction: coderOnUnmappable},
+		"replacement()Ljava/lang/String;":                                  {ParamSlots: 0, GFunction: decoderReplacement},
+		"replaceWith(Ljava/lang/String;)Ljava/nio/charset/CharsetDecoder;": {ParamSlots: 1, GFunction: decoderReplaceWith},
+		"averageCharsPerByte()F":                                           {ParamSlots: 0, GFunction: decoderAverageCharsPerByte},
+		"maxCharsPerByte()F":                                               {ParamSlots: 0, GFunction: decoderMaxCharsPerByte},
+		"decode(Ljava/nio/ByteBuffer;)Ljava/nio/CharBuffer;":               {ParamSlots: 1, GFunction: decoderDecode},
+		"reset()Ljava/nio/charset/CharsetDecoder;":                         {ParamSlots: 0, GFunction: coderReset},
+	} {
+		ghelpers.MethodSignatures[charsetDecoderClassName+"."+sig] = gmeth
+	}
+}
+
+// --- Charset ---
+
+var charsetMutex = sync.Mutex{}
+var charsetObjects = map[*ghelpers.Charset]*object.Object{}
+
+// CharsetObject returns the Charset object for cs. There is only one for each charset.
+func CharsetObject(cs *ghelpers.Charset) *object.Object {
+	charsetMutex.Lock()
+	defer charsetMutex.Unlock()
+	obj, ok := charsetObjects[cs]
+	if !ok {
+		className := charsetClassName
+		obj = object.MakeEmptyObjectWithClassName(&className)
+		obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(cs.Name)}
+		charsetObjects[cs] = obj
+	}
+	return obj
+}
+
+// charsetThis returns the charset of a Charset object.
+func charsetThis(this any) (*ghelpers.Charset, *ghelpers.GErrBlk) {
+	return ghelpers.CharsetFromObject(this)
+}
+
+// java/nio/charset/Charset.forName(Ljava/lang/String;) and forName(Ljava/lang/String;Ljava/nio/charset/Charset;)
+// The second returns its fallback for a charset that is not supported; both throw for an illegal name.
+func charsetForName(params []interface{}) interface{} {
+	nameObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Null charset name")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	if !ghelpers.IsLegalCharsetName(name) {
+		return ghelpers.GetGErrBlk(excNames.IllegalCharsetNameException, name)
+	}
+	if cs := ghelpers.LookupCharset(name); cs != nil {
+		return CharsetObject(cs)
+	}
+	if len(params) == 2 {
+		return params[1]
+	}
+	return ghelpers.GetGErrBlk(excNames.UnsupportedCharsetException, name)
+}
+
+// java/nio/charset/Charset.defaultCharset() -- the charset of file.encoding
+func charsetDefault([]interface{}) interface{} {
+	return CharsetObject(ghelpers.DefaultCharset())
+}
+
+func charsetIsSupported(params []interface{}) interface{} {
+	nameObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Null charset name")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	if !ghelpers.IsLegalCharsetName(name) {
+		return ghelpers.GetGErrBlk(excNames.IllegalCharsetNameException, name)
+	}
+	return types.ConvertGoBoolToJavaBool(ghelpers.LookupCharset(name) != nil)
+}
+
+// java/nio/charset/Charset.name(), displayName() and toString() -- the canonical name
+func charsetName(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(cs.Name)
+}
+
+func charsetContains(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, gerr := ghelpers.CharsetFromObject(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(cs.Contains(other))
+}
+
+func charsetEquals(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) || object.GoStringFromStringPoolIndex(other.KlassName) != charsetClassName {
+		return types.JavaBoolFalse
+	}
+	otherCs, gerr := ghelpers.CharsetFromObject(other)
+	return types.ConvertGoBoolToJavaBool(gerr == nil && otherCs == cs)
+}
+
+// java/nio/charset/Charset.hashCode()I -- the hash code of the name, as in the JDK
+func charsetHashCode(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	var hash int32
+	for _, ch := range cs.Name {
+		hash = 31*hash + ch
+	}
+	return int64(hash)
+}
+
+// java/nio/charset/Charset.compareTo -- by name, ignoring case
+func charsetCompareTo(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	other, gerr := ghelpers.CharsetFromObject(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(strings.Compare(strings.ToLower(cs.Name), strings.ToLower(other.Name)))
+}
+
+// java/nio/charset/Charset.encode -- replaces what the charset cannot encode
+func charsetEncode(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if str, ok := params[1].(*object.Object); ok && object.IsStringObject(str) {
+		return byteBufferFromGoBytes(cs.EncodeReplacing(object.GoStringFromStringObject(str)))
+	}
+	coder := newCharsetCoder(cs)
+	coder.malformed, coder.unmappable = ghelpers.CodingReplace, ghelpers.CodingReplace
+	return coder.encodeBuffer(params[1])
+}
+
+// java/nio/charset/Charset.decode -- replaces malformed and unmappable input with U+FFFD
+func charsetDecode(params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	coder := newCharsetCoder(cs)
+	coder.malformed, coder.unmappable = ghelpers.CodingReplace, ghelpers.CodingReplace
+	return coder.decodeBuffer(params[1])
+}
+
+// --- StandardCharsets and CodingErrorAction ---
+
+var standardCharsetsOnce sync.Once
+
+func standardCharsetsClinit([]interface{}) interface{} {
+	standardCharsetsOnce.Do(func() {
+		for name, cs := range map[string]*ghelpers.Charset{
+			"US_ASCII":   ghelpers.CharsetUSASCII,
+			"ISO_8859_1": ghelpers.CharsetISO88591,
+			"UTF_8":      ghelpers.CharsetUTF8,
+			"UTF_16BE":   ghelpers.CharsetUTF16BE,
+			"UTF_16LE":   ghelpers.CharsetUTF16LE,
+			"UTF_16":     ghelpers.CharsetUTF16,
+		} {
+			_ = statics.AddStatic("java/nio/charset/StandardCharsets."+name,
+				statics.Static{Type: "L" + charsetClassName + ";", Value: CharsetObject(cs)})
+		}
+	})
+	return nil
+}
+
+var codingErrorActionMutex = sync.Mutex{}
+var codingErrorActions = map[ghelpers.CodingAction]*object.Object{}
+var codingErrorActionNames = map[ghelpers.CodingAction]string{
+	ghelpers.CodingIgnore:  "IGNORE",
+	ghelpers.CodingReplace: "REPLACE",
+	ghelpers.CodingReport:  "REPORT",
+}
+
+// codingErrorAction returns CodingErrorAction.IGNORE, REPLACE or REPORT.
+func codingErrorAction(action ghelpers.CodingAction) *object.Object {
+	codingErrorActionMutex.Lock()
+	defer codingErrorActionMutex.Unlock()
+	if len(codingErrorActions) == 0 {
+		className := codingErrorActionClassName
+		for act, name := range codingErrorActionNames {
+			obj := object.MakeEmptyObjectWithClassName(&className)
+			obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+			_ = statics.AddStatic(codingErrorActionClassName+"."+name, statics.Static{Type: "L" + codingErrorActionClassName + ";", Value: obj})
+			codingErrorActions[act] = obj
+		}
+	}
+	return codingErrorActions[action]
+}
+
+func codingErrorActionClinit([]interface{}) interface{} {
+	codingErrorAction(ghelpers.CodingReport)
+	return nil
+}
+
+func codingErrorActionToString(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["name"].Fvalue
+}
+
+// codingActionOf returns the action of a CodingErrorAction object.
+func codingActionOf(arg any) (ghelpers.CodingAction, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Null action")
+	}
+	if nameObj, ok := obj.FieldTable["name"].Fvalue.(*object.Object); ok {
+		name := object.GoStringFromStringObject(nameObj)
+		for act, actName := range codingErrorActionNames {
+			if actName == name {
+				return act, nil
+			}
+		}
+	}
+	return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a CodingErrorAction")
+}
+
+// --- CharsetEncoder and CharsetDecoder ---
+
+func newCharsetCoder(cs *ghelpers.Charset) *charsetCoder {
+	return &charsetCoder{cs: cs, replacement: cs.DefaultReplacement(), replaceWith: "\uFFFD"}
+}
+
+func newCoderObject(className string, params []interface{}) interface{} {
+	cs, gerr := charsetThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable["charset"] = object.Field{Ftype: "L" + charsetClassName + ";", Fvalue: CharsetObject(cs)}
+	obj.FieldTable[coderField] = object.Field{Ftype: types.RawGoPointer, Fvalue: newCharsetCoder(cs)}
+	return obj
+}
+
+// java/nio/charset/Charset.newEncoder() -- an encoder that reports bad input, as in the JDK
+func charsetNewEncoder(params []interface{}) interface{} {
+	return newCoderObject(charsetEncoderClassName, params)
+}
+
+// java/nio/charset/Charset.newDecoder() -- a decoder that reports bad input, as in the JDK
+func charsetNewDecoder(params []interface{}) interface{} {
+	return newCoderObject(charsetDecoderClassName, params)
+}
+
+// CoderFromObject returns the charset and the error actions of a CharsetEncoder or
+// CharsetDecoder object, for the java.io classes that take one.
+func CoderFromObject(arg any) (*ghelpers.Charset, ghelpers.CodingAction, ghelpers.CodingAction, *ghelpers.GErrBlk) {
+	coder, gerr := coderThis(arg)
+	if gerr != nil {
+		return nil, 0, 0, gerr
+	}
+	return coder.cs, coder.malformed, coder.unmappable, nil
+}
+
+func coderThis(this any) (*charsetCoder, *ghelpers.GErrBlk) {
+	obj, ok := this.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "coder is null")
+	}
+	coder, ok := obj.FieldTable[coderField].Fvalue.(*charsetCoder)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "coder was not initialized")
+	}
+	return coder, nil
+}
+
+func coderCharset(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return CharsetObject(coder.cs)
+}
+
+func coderMalformedAction(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return codingErrorAction(coder.malformed)
+}
+
+func coderUnmappableAction(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return codingErrorAction(coder.unmappable)
+}
+
+func coderOnMalformed(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if coder.malformed, gerr = codingActionOf(params[1]); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+func coderOnUnmappable(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if coder.unmappable, gerr = codingActionOf(params[1]); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+// reset() -- the coders keep no state between calls of encode and decode
+func coderReset(params []interface{}) interface{} {
+	if _, gerr := coderThis(params[0]); gerr != nil {
+		return gerr
+	}
+	return params[0]
+}
+
+func encoderReplacement(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray(coder.replacement))
+}
+
+// isLegalReplacement reports whether the decoder of the charset would accept b
+func (coder *charsetCoder) isLegalReplacement(b []byte) bool {
+	_, bad := coder.cs.Decode(b, ghelpers.CodingReport, ghelpers.CodingReport, "")
+	return bad == nil
+}
+
+func encoderIsLegalReplacement(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	arr, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "isLegalReplacement: replacement is null")
+	}
+	return types.ConvertGoBoolToJavaBool(coder.isLegalReplacement(goBytesOf(arr)))
+}
+
+func encoderReplaceWith(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	arr, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Null replacement")
+	}
+	b := goBytesOf(arr)
+	switch {
+	case len(b) == 0:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Empty replacement")
+	case float64(len(b)) > coder.cs.MaxBytesPerChar:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Replacement too long")
+	case !coder.isLegalReplacement(b):
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Illegal replacement")
+	}
+	coder.replacement = b
+	return params[0]
+}
+
+func decoderReplacement(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(coder.replaceWith)
+}
+
+func decoderReplaceWith(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	str, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(str) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Null replacement")
+	}
+	s := object.GoStringFromStringObject(str)
+	if s == "" {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Empty replacement")
+	}
+	coder.replaceWith = s
+	return params[0]
+}
+
+func encoderAverageBytesPerChar(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.cs.AverageBytesPerChar
+}
+
+func encoderMaxBytesPerChar(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.cs.MaxBytesPerChar
+}
+
+func decoderAverageCharsPerByte(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.cs.AverageCharsPerByte
+}
+
+func decoderMaxCharsPerByte(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.cs.MaxCharsPerByte
+}
+
+// java/nio/charset/CharsetEncoder.canEncode(C) and canEncode(Ljava/lang/CharSequence;)
+func encoderCanEncode(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if ch, ok := params[1].(int64); ok {
+		return types.ConvertGoBoolToJavaBool(coder.cs.CanEncode(rune(ch)))
+	}
+	chars, gerr := charSequenceRunes(fs, params[1])
+	if gerr != nil {
+		return gerr
+	}
+	_, bad := coder.cs.EncodeChars(chars, ghelpers.CodingReport, ghelpers.CodingReport, nil)
+	return types.ConvertGoBoolToJavaBool(bad == nil)
+}
+
+// java/nio/charset/CharsetEncoder.encode(Ljava/nio/CharBuffer;)Ljava/nio/ByteBuffer;
+func encoderEncode(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.encodeBuffer(params[1])
+}
+
+// java/nio/charset/CharsetDecoder.decode(Ljava/nio/ByteBuffer;)Ljava/nio/CharBuffer;
+func decoderDecode(params []interface{}) interface{} {
+	coder, gerr := coderThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return coder.decodeBuffer(params[1])
+}
+
+// encodeBuffer encodes the remaining chars of a CharBuffer into a new ByteBuffer, leaving the
+// CharBuffer's position at its limit.
+func (coder *charsetCoder) encodeBuffer(arg any) interface{} {
+	in, gerr := bufferThis(arg, "encode")
+	if gerr != nil {
+		return gerr
+	}
+	chars := make([]rune, in.remaining())
+	for ix := range chars {
+		chars[ix] = rune(in.get(in.position + ix).(int64))
+	}
+	out, bad := coder.cs.EncodeChars(chars, coder.malformed, coder.unmappable, coder.replacement)
+	if bad != nil {
+		return bad.GErrBlk()
+	}
+	in.position = in.limit
+	return byteBufferFromGoBytes(out)
+}
+
+// decodeBuffer decodes the remaining bytes of a ByteBuffer into a new CharBuffer, leaving the
+// ByteBuffer's position at its limit.
+func (coder *charsetCoder) decodeBuffer(arg any) interface{} {
+	in, gerr := bufferThis(arg, "decode")
+	if gerr != nil {
+		return gerr
+	}
+	b := make([]byte, in.remaining())
+	for ix := range b {
+		b[ix] = byte(in.get(in.position + ix).(int64))
+	}
+	s, bad := coder.cs.Decode(b, coder.malformed, coder.unmappable, coder.replaceWith)
+	if bad != nil {
+		return bad.GErrBlk()
+	}
+	in.position = in.limit
+	runes := []rune(s)
+	chars := make([]int64, len(runes))
+	for ix, r := range runes {
+		chars[ix] = int64(r)
+	}
+	out := &nioBuffer{kind: bufferKinds['C'], ints: chars, capacity: len(chars), limit: len(chars),
+		mark: -1, bigEndian: nativeBigEndian}
+	return newBufferObject(out)
+}
+
+// byteBufferFromGoBytes returns a heap ByteBuffer that holds b, positioned at 0.
+func byteBufferFromGoBytes(b []byte) *object.Object {
+	arr := object.Make1DimArray(object.T_BYTE, int64(len(b)))
+	copy(arr.FieldTable["value"].Fvalue.([]types.JavaByte), object.JavaByteArrayFromGoByteArray(b))
+	return newBufferObject(newArrayBuffer(bufferKinds['B'], arr))
+}
+
+// goBytesOf returns the contents of a Java byte array.
+func goBytesOf(arr *object.Object) []byte {
+	switch value := arr.FieldTable["value"].Fvalue.(type) {
+	case []types.JavaByte:
+		return object.GoByteArrayFromJavaByteArray(value)
+	case []byte:
+		return value
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"bytes"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const csClass = "java/nio/charset/Charset."
+const csType = "Ljava/nio/charset/Charset;"
+
+func loadCharsetForTest() {
+	loadBuffersForTest()
+	Load_Nio_Charset()
+	Load_Nio_File_Files()
+}
+
+func forName(t *testing.T, name string) *object.Object {
+	t.Helper()
+	ret := callBuffer(t, csClass+"forName(Ljava/lang/String;)"+csType, object.StringObjectFromGoString(name))
+	cs, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("forName(%s): got %v", name, ret)
+	}
+	return cs
+}
+
+// remainingBytes returns the bytes of a byte buffer from its position to its limit.
+func remainingBytes(t *testing.T, buf any) []byte {
+	t.Helper()
+	b, gerr := bufferThis(buf, "remainingBytes")
+	if gerr != nil {
+		t.Fatalf("not a buffer: %v", buf)
+	}
+	out := make([]byte, b.remaining())
+	for ix := range out {
+		out[ix] = byte(b.get(b.position + ix).(int64))
+	}
+	return out
+}
+
+func TestCharset_ForName(t *testing.T) {
+	loadCharsetForTest()
+	latin1 := forName(t, "latin1")
+	if name := callBuffer(t, csClass+"name()Ljava/lang/String;", latin1); object.GoStringFromStringObject(name.(*object.Object)) != "ISO-8859-1" {
+		t.Errorf("name(): got %v", name)
+	}
+	if forName(t, "ISO-8859-1") != latin1 {
+		t.Errorf("forName should return the same Charset object for an alias")
+	}
+
+	testutil.ExpectGErr(t, callBuffer(t, csClass+"forName(Ljava/lang/String;)"+csType, object.StringObjectFromGoString("bad name")),
+		excNames.IllegalCharsetNameException, "")
+	testutil.ExpectGErr(t, callBuffer(t, csClass+"forName(Ljava/lang/String;)"+csType, object.StringObjectFromGoString("x-unknown")),
+		excNames.UnsupportedCharsetException, "")
+
+	fallback := forName(t, "UTF-8")
+	ret := callBuffer(t, csClass+"forName(Ljava/lang/String;Ljava/nio/charset/Charset;)"+csType,
+		object.StringObjectFromGoString("x-unknown"), fallback)
+	if ret != fallback {
+		t.Errorf("forName with a fallback: got %v", ret)
+	}
+
+	if ret := callBuffer(t, csClass+"isSupported(Ljava/lang/String;)Z", object.StringObjectFromGoString("windows-1252")); ret != types.JavaBoolTrue {
+		t.Errorf("isSupported(windows-1252): got %v", ret)
+	}
+	if ret := callBuffer(t, csClass+"contains(Ljava/nio/charset/Charset;)Z", fallback, latin1); ret != types.JavaBoolTrue {
+		t.Errorf("UTF-8 contains ISO-8859-1: got %v", ret)
+	}
+	if ret := callBuffer(t, csClass+"contains(Ljava/nio/charset/Charset;)Z", latin1, fallback); ret != types.JavaBoolFalse {
+		t.Errorf("ISO-8859-1 contains UTF-8: got %v", ret)
+	}
+}
+
+func TestStandardCharsets_Clinit(t *testing.T) {
+	loadCharsetForTest()
+	ghelpers.MethodSignatures["java/nio/charset/StandardCharsets.<clinit>()V"].GFunction(nil)
+	value := statics.GetStaticValue("java/nio/charset/StandardCharsets", "UTF_16LE")
+	if value != CharsetObject(ghelpers.CharsetUTF16LE) {
+		t.Errorf("StandardCharsets.UTF_16LE: got %v", value)
+	}
+}
+
+func TestCharset_EncodeDecodeReplace(t *testing.T) {
+	loadCharsetForTest()
+	ascii := forName(t, "US-ASCII")
+	encoded := callBuffer(t, csClass+"encode(Ljava/lang/String;)Ljava/nio/ByteBuffer;", ascii, object.StringObjectFromGoString("né"))
+	if got := remainingBytes(t, encoded); string(got) != "n?" {
+		t.Errorf("encode: got %q", got)
+	}
+	decoded := callBuffer(t, csClass+"decode(Ljava/nio/ByteBuffer;)Ljava/nio/CharBuffer;", ascii, byteBufferFromGoBytes([]byte{'n', 0xE9}))
+	b, _ := bufferThis(decoded, "test")
+	if b.limit != 2 || b.get(1).(int64) != 0xFFFD {
+		t.Errorf("decode: got %v", b.ints)
+	}
+}
+
+func TestCharsetEncoder_ReportsUnmappable(t *testing.T) {
+	loadCharsetForTest()
+	latin1 := forName(t, "ISO-8859-1")
+	encoder := callBuffer(t, csClass+"newEncoder()Ljava/nio/charset/CharsetEncoder;", latin1)
+	in := callBuffer(t, cbClass+"wrap(Ljava/lang/CharSequence;)"+cbType, object.StringObjectFromGoString("€"))
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/charset/CharsetEncoder.encode(Ljava/nio/CharBuffer;)Ljava/nio/ByteBuffer;", encoder, in),
+		excNames.UnmappableCharacterException, "")
+
+	// switch to REPLACE
+	ghelpers.MethodSignatures["java/nio/charset/CodingErrorAction.<clinit>()V"].GFunction(nil)
+	replace := codingErrorAction(ghelpers.CodingReplace)
+	callBuffer(t, "java/nio/charset/CharsetEncoder.onUnmappableCharacter(Ljava/nio/charset/CodingErrorAction;)Ljava/nio/charset/CharsetEncoder;",
+		encoder, replace)
+	out := callBuffer(t, "java/nio/charset/CharsetEncoder.encode(Ljava/nio/CharBuffer;)Ljava/nio/ByteBuffer;", encoder, in)
+	if got := remainingBytes(t, out); string(got) != "?" {
+		t.Errorf("encode with REPLACE: got %q", got)
+	}
+	action := callBuffer(t, "java/nio/charset/CharsetEncoder.unmappableCharacterAction()Ljava/nio/charset/CodingErrorAction;", encoder)
+	if action != replace {
+		t.Errorf("unmappableCharacterAction: got %v", action)
+	}
+}
+
+func TestCharsetDecoder_ReportsMalformed(t *testing.T) {
+	loadCharsetForTest()
+	decoder := callBuffer(t, csClass+"newDecoder()Ljava/nio/charset/CharsetDecoder;", forName(t, "UTF-8"))
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/charset/CharsetDecoder.decode(Ljava/nio/ByteBuffer;)Ljava/nio/CharBuffer;",
+		decoder, byteBufferFromGoBytes([]byte{0xFF})),
+		excNames.MalformedInputException, "")
+}
+
+func TestFiles_StringsWithCharset(t *testing.T) {
+	loadCharsetForTest()
+	path := newPath(filepath.Join(t.TempDir(), "latin1.txt"))
+	latin1 := forName(t, "ISO-8859-1")
+	noOptions := object.Make1DimRefArray("Ljava/nio/file/OpenOption;", 0)
+
+	ret := callBuffer(t, "java/nio/file/Files.writeString(Ljava/nio/file/Path;Ljava/lang/CharSequence;Ljava/nio/charset/Charset;[Ljava/nio/file/OpenOption;)Ljava/nio/file/Path;",
+		path, object.StringObjectFromGoString("café\nolé"), latin1, noOptions)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		t.Fatalf("writeString: %s", gerr.ErrMsg)
+	}
+	p, _ := pathToGoString(path)
+	data, _ := os.ReadFile(p)
+	if !bytes.Equal(data, []byte("caf\xe9\nol\xe9")) {
+		t.Errorf("file contents: got % X", data)
+	}
+
+	str := callBuffer(t, "java/nio/file/Files.readString(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/lang/String;", path, latin1)
+	if object.GoStringFromStringObject(str.(*object.Object)) != "café\nolé" {
+		t.Errorf("readString: got %v", str)
+	}
+	lines := callBuffer(t, "java/nio/file/Files.readAllLines(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/util/List;", path, latin1)
+	arr := lines.(*object.Object).FieldTable["value"].Fvalue.([]*object.Object)
+	if len(arr) != 2 || object.GoStringFromStringObject(arr[1]) != "olé" {
+		t.Errorf("readAllLines: got %v", arr)
+	}
+
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/file/Files.readString(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/lang/String;", path, forName(t, "UTF-8")),
+		excNames.MalformedInputException, "")
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/file/Files.writeString(Ljava/nio/file/Path;Ljava/lang/CharSequence;Ljava/nio/charset/Charset;[Ljava/nio/file/OpenOption;)Ljava/nio/file/Path;",
+		path, object.StringObjectFromGoString("é"), forName(t, "US-ASCII"), noOptions),
+		excNames.UnmappableCharacterException, "")
+}
@@ -0,0 +1,809 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"fmt"
+	"io/fs"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// java.nio.file.attribute: PosixFilePermission, PosixFilePermissions, the basic, owner and posix
+// file attribute views, PosixFileAttributes, and the user and group principals, along with the
+// Files methods that use them. A view holds the path it was made for; the attribute objects hold
+// the fs.FileInfo of the file in their info field, as BasicFileAttributes does. Owners and groups
+// are only known on Unix-like systems, where the posix view is supported.
+
+const (
+	posixPermissionClassName = "java/nio/file/attribute/PosixFilePermission"
+	posixPermissionType      = "Ljava/nio/file/attribute/PosixFilePermission;"
+	basicViewClassName       = "java/nio/file/attribute/BasicFileAttributeView"
+	ownerViewClassName       = "java/nio/file/attribute/FileOwnerAttributeView"
+	posixViewClassName       = "java/nio/file/attribute/PosixFileAttributeView"
+	basicAttrsClassName      = "java/nio/file/attribute/BasicFileAttributes"
+	posixAttrsClassName      = "java/nio/file/attribute/PosixFileAttributes"
+	userPrincipalClassName   = "java/nio/file/attribute/UserPrincipal"
+	groupPrincipalClassName  = "java/nio/file/attribute/GroupPrincipal"
+	fileAttributeClassName   = "java/nio/file/attribute/FileAttribute"
+	lookupServiceClassName   = "java/nio/file/attribute/UserPrincipalLookupService"
+	principalIDField         = "id"
+	viewPathField            = "path"
+	viewLinkOptionsField     = "options"
+	fileAttributeModeField   = "mode"
+)
+
+func Load_Nio_File_Attribute_Posix() {
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermission.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: posixPermissionClinit}
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermission.valueOf(Ljava/lang/String;)"+posixPermissionType] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: posixPermissionValueOf}
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermission.values()[Ljava/nio/file/attribute/PosixFilePermission;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: posixPermissionValues}
+
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermissions.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermissions.toString(Ljava/util/Set;)Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: posixPermissionsToString, NeedsContext: true}
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermissions.fromString(Ljava/lang/String;)Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: posixPermissionsFromString, NeedsContext: true}
+	ghelpers.MethodSignatures["java/nio/file/attribute/PosixFilePermissions.asFileAttribute(Ljava/util/Set;)Ljava/nio/file/attribute/FileAttribute;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: posixPermissionsAsFileAttribute, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/nio/file/attribute/FileAttribute.name()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: fileAttributeName}
+	ghelpers.MethodSignatures["java/nio/file/attribute/FileAttribute.value()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: fileAttributeValue}
+
+	for _, className := range []string{userPrincipalClassName, groupPrincipalClassName} {
+		ghelpers.MethodSignatures[className+".getName()Ljava/lang/String;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: principalGetName}
+		ghelpers.MethodSignatures[className+".toString()Ljava/lang/String;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: principalGetName}
+		ghelpers.MethodSignatures[className+".equals(Ljava/lang/Object;)Z"] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: principalEquals}
+		ghelpers.MethodSignatures[className+".hashCode()I"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: principalHashCode}
+	}
+
+	ghelpers.MethodSignatures["java/nio/file/FileSystem.getUserPrincipalLookupService()Ljava/nio/file/attribute/UserPrincipalLookupService;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: fileSystemGetLookupService}
+	ghelpers.MethodSignatures[lookupServiceClassName+".lookupPrincipalByName(Ljava/lang/String;)Ljava/nio/file/attribute/UserPrincipal;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: lookupPrincipalByName}
+	ghelpers.MethodSignatures[lookupServiceClassName+".lookupPrincipalByGroupName(Ljava/lang/String;)Ljava/nio/file/attribute/GroupPrincipal;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: lookupPrincipalByGroupName}
+
+	// the views: basic, owner and posix. The posix view is also a basic and an owner view.
+	for _, className := range []string{basicViewClassName, posixViewClassName} {
+		ghelpers.MethodSignatures[className+".setTimes(Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;)V"] =
+			ghelpers.GMeth{ParamSlots: 3, GFunction: viewSetTimes}
+	}
+	for _, className := range []string{ownerViewClassName, posixViewClassName} {
+		ghelpers.MethodSignatures[className+".getOwner()Ljava/nio/file/attribute/UserPrincipal;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: viewGetOwner}
+		ghelpers.MethodSignatures[className+".setOwner(Ljava/nio/file/attribute/UserPrincipal;)V"] =
+			ghelpers.GMeth{ParamSlots: 1, GFunction: viewSetOwner}
+	}
+	for _, className := range []string{basicViewClassName, ownerViewClassName, posixViewClassName} {
+		ghelpers.MethodSignatures[className+".name()Ljava/lang/String;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: viewName}
+	}
+	ghelpers.MethodSignatures[basicViewClassName+".readAttributes()Ljava/nio/file/attribute/BasicFileAttributes;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: viewReadAttributes}
+	ghelpers.MethodSignatures[posixViewClassName+".readAttributes()Ljava/nio/file/attribute/PosixFileAttributes;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: viewReadAttributes}
+	ghelpers.MethodSignatures[posixViewClassName+".readAttributes()Ljava/nio/file/attribute/BasicFileAttributes;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: viewReadAttributes}
+	ghelpers.MethodSignatures[posixViewClassName+".setPermissions(Ljava/util/Set;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewSetPermissions, NeedsContext: true}
+	ghelpers.MethodSignatures[posixViewClassName+".setGroup(Ljava/nio/file/attribute/GroupPrincipal;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewSetGroup}
+
+	// PosixFileAttributes: the BasicFileAttributes methods, and the owner, group and permissions
+	for sig, gfunc := range map[string]func([]interface{}) interface{}{
+		"isRegularFile()Z":  bfaIsRegularFile,
+		"isDirectory()Z":    bfaIsDirectory,
+		"isSymbolicLink()Z": bfaIsSymbolicLink,
+		"isOther()Z":        bfaIsOther,
+		"size()J":           bfaSize,
+		"lastModifiedTime()Ljava/nio/file/attribute/FileTime;": bfaLastModifiedTime,
+		"owner()Ljava/nio/file/attribute/UserPrincipal;":       pfaOwner,
+		"group()Ljava/nio/file/attribute/GroupPrincipal;":      pfaGroup,
+	} {
+		ghelpers.MethodSignatures[posixAttrsClassName+"."+sig] = ghelpers.GMeth{ParamSlots: 0, GFunction: gfunc}
+	}
+	ghelpers.MethodSignatures[posixAttrsClassName+".permissions()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: pfaPermissions, NeedsContext: true}
+
+}
+
+// --- PosixFilePermission ---
+
+// The permissions in the order of the enum. The permission with ordinal n is mode bit 0400 >> n.
+var posixPermissionNames = []string{"OWNER_READ", "OWNER_WRITE", "OWNER_EXECUTE",
+	"GROUP_READ", "GROUP_WRITE", "GROUP_EXECUTE", "OTHERS_READ", "OTHERS_WRITE", "OTHERS_EXECUTE"}
+var posixPermissionOnce sync.Once
+var posixPermissionInstances []*object.Object
+
+func posixPermissions() []*object.Object {
+	posixPermissionOnce.Do(func() {
+		className := posixPermissionClassName
+		posixPermissionInstances = make([]*object.Object, len(posixPermissionNames))
+		for ix, name := range posixPermissionNames {
+			obj := object.MakeEmptyObjectWithClassName(&className)
+			obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+			obj.FieldTable["ordinal"] = object.Field{Ftype: types.Int, Fvalue: int64(ix)}
+			posixPermissionInstances[ix] = obj
+			_ = statics.AddStatic(posixPermissionClassName+"."+name, statics.Static{Type: posixPermissionType, Value: obj})
+		}
+	})
+	return posixPermissionInstances
+}
+
+func posixPermissionClinit([]interface{}) interface{} {
+	posixPermissions()
+	return nil
+}
+
+func posixPermissionValueOf(params []interface{}) interface{} {
+	nameObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "PosixFilePermission.valueOf: name is null")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	for ix, permName := range posixPermissionNames {
+		if permName == name {
+			return posixPermissions()[ix]
+		}
+	}
+	errMsg := "No enum constant " + strings.ReplaceAll(posixPermissionClassName, "/", ".") + "." + name
+	return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+}
+
+func posixPermissionValues([]interface{}) interface{} {
+	perms := posixPermissions()
+	arr := object.Make1DimRefArray(posixPermissionType, int64(len(perms)))
+	copy(arr.FieldTable["value"].Fvalue.([]*object.Object), perms)
+	return arr
+}
+
+// permissionsFromMode returns the PosixFilePermission constants of the permission bits of mode.
+func permissionsFromMode(mode fs.FileMode) []*object.Object {
+	var perms []*object.Object
+	for ix, perm := range posixPermissions() {
+		if mode&(0o400>>ix) != 0 {
+			perms = append(perms, perm)
+		}
+	}
+	return perms
+}
+
+// modeFromPermissions returns the permission bits of a Set of PosixFilePermission.
+func modeFromPermissions(fs *list.List, set any) (fs.FileMode, *ghelpers.GErrBlk) {
+	elems, gerr := collectionElements(fs, set)
+	if gerr != nil {
+		return 0, gerr
+	}
+	var mode os.FileMode
+	perms := posixPermissions()
+	for _, elem := range elems {
+		ordinal := slices.Index(perms, elem)
+		if ordinal < 0 {
+			return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, "element is not a PosixFilePermission")
+		}
+		mode |= 0o400 >> ordinal
+	}
+	return mode, nil
+}
+
+// permissionString returns the permission bits of mode as rwxrwxrwx, with - for those not set.
+func permissionString(mode fs.FileMode) string {
+	var sb strings.Builder
+	for ix := range posixPermissionNames {
+		if mode&(0o400>>ix) != 0 {
+			sb.WriteByte("rwx"[ix%3])
+		} else {
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+// --- PosixFilePermissions and FileAttribute ---
+
+// java/nio/file/attribute/PosixFilePermissions.toString(Ljava/util/Set;)Ljava/lang/String;
+func posixPermissionsToString(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	mode, gerr := modeFromPermissions(fs, args[0])
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(permissionString(mode))
+}
+
+// java/nio/file/attribute/PosixFilePermissions.fromString(Ljava/lang/String;)Ljava/util/Set;
+// The string is nine characters, as ls -l shows them, such as rwxr-x---.
+func posixPermissionsFromString(params []interface{}) interface{} {
+	fsStack, args := ghelpers.SplitContext(params)
+	strObj, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "PosixFilePermissions.fromString: perms is null")
+	}
+	perms := object.GoStringFromStringObject(strObj)
+	if len(perms) != len(posixPermissionNames) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Invalid mode")
+	}
+	var mode fs.FileMode
+	for ix := range posixPermissionNames {
+		switch perms[ix] {
+		case "rwx"[ix%3]:
+			mode |= 0o400 >> ix
+		case '-':
+		default:
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Invalid mode")
+		}
+	}
+	return newHashSet(fsStack, permissionsFromMode(mode))
+}
+
+// java/nio/file/attribute/PosixFilePermissions.asFileAttribute(Ljava/util/Set;)Ljava/nio/file/attribute/FileAttribute;
+// The attribute is posix:permissions; Files.createFile and createDirectory use its mode field.
+func posixPermissionsAsFileAttribute(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	mode, gerr := modeFromPermissions(fs, args[0])
+	if gerr != nil {
+		return gerr
+	}
+	set := newHashSet(fs, permissionsFromMode(mode))
+	if gerr, ok := set.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	className := fileAttributeClassName
+	attr := object.MakeEmptyObjectWithClassName(&className)
+	attr.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString("posix:permissions")}
+	attr.FieldTable["value"] = object.Field{Ftype: types.Ref, Fvalue: set}
+	attr.FieldTable[fileAttributeModeField] = object.Field{Ftype: types.RawGoPointer, Fvalue: mode}
+	return attr
+}
+
+func fileAttributeName(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["name"].Fvalue
+}
+
+func fileAttributeValue(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["value"].Fvalue
+}
+
+// createMode returns the permissions that a FileAttribute[] of Files.createFile or
+// createDirectory asks for, or defaultMode if there are none.
+func createMode(attrs any, defaultMode fs.FileMode) (fs.FileMode, *ghelpers.GErrBlk) {
+	arr, ok := attrs.(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return defaultMode, nil
+	}
+	elems, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	for _, attr := range elems {
+		if mode, ok := attr.FieldTable[fileAttributeModeField].Fvalue.(fs.FileMode); ok {
+			defaultMode = mode
+			continue
+		}
+		name := "?"
+		if nameObj, ok := attr.FieldTable["name"].Fvalue.(*object.Object); ok {
+			name = object.GoStringFromStringObject(nameObj)
+		}
+		errMsg := fmt.Sprintf("'%s' not supported as initial attribute", name)
+		return 0, ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, errMsg)
+	}
+	return defaultMode, nil
+}
+
+// --- principals ---
+
+func newPrincipal(className, name string, id int64) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	obj.FieldTable[principalIDField] = object.Field{Ftype: types.Int, Fvalue: id}
+	return obj
+}
+
+// userPrincipal returns the UserPrincipal of uid, named after the user if it is known.
+func userPrincipal(uid int) *object.Object {
+	name := strconv.Itoa(uid)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	return newPrincipal(userPrincipalClassName, name, int64(uid))
+}
+
+// groupPrincipal returns the GroupPrincipal of gid, named after the group if it is known.
+func groupPrincipal(gid int) *object.Object {
+	name := strconv.Itoa(gid)
+	if g, err := user.LookupGroupId(name); err == nil {
+		name = g.Name
+	}
+	return newPrincipal(groupPrincipalClassName, name, int64(gid))
+}
+
+// principalID returns the user or group ID of a principal, which must be of the class given.
+func principalID(arg any, className string) (int, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "principal is null")
+	}
+	id, ok := obj.FieldTable[principalIDField].Fvalue.(int64)
+	if !ok || obj.KlassName != object.StringPoolIndexFromGoString(className) {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "principal is not of the expected class")
+	}
+	return int(id), nil
+}
+
+func principalGetName(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["name"].Fvalue
+}
+
+func principalEquals(params []interface{}) interface{} {
+	this := params[0].(*object.Object)
+	that, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(that) || that.KlassName != this.KlassName {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(this.FieldTable[principalIDField].Fvalue == that.FieldTable[principalIDField].Fvalue)
+}
+
+func principalHashCode(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable[principalIDField].Fvalue.(int64)
+}
+
+var lookupServiceOnce sync.Once
+var lookupService *object.Object
+
+// java/nio/file/FileSystem.getUserPrincipalLookupService()
+func fileSystemGetLookupService([]interface{}) interface{} {
+	lookupServiceOnce.Do(func() {
+		className := lookupServiceClassName
+		lookupService = object.MakeEmptyObjectWithClassName(&className)
+	})
+	return lookupService
+}
+
+// java/nio/file/attribute/UserPrincipalLookupService.lookupPrincipalByName(Ljava/lang/String;)
+func lookupPrincipalByName(params []interface{}) interface{} {
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "lookupPrincipalByName: name is null")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	u, err := user.Lookup(name)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UserPrincipalNotFoundException, name)
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "lookupPrincipalByName: user IDs are not numbers")
+	}
+	return newPrincipal(userPrincipalClassName, u.Username, int64(uid))
+}
+
+// java/nio/file/attribute/UserPrincipalLookupService.lookupPrincipalByGroupName(Ljava/lang/String;)
+func lookupPrincipalByGroupName(params []interface{}) interface{} {
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "lookupPrincipalByGroupName: group is null")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UserPrincipalNotFoundException, name)
+	}
+	gid, err := strconv.Atoi(g.Gid)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "lookupPrincipalByGroupName: group IDs are not numbers")
+	}
+	return newPrincipal(groupPrincipalClassName, g.Name, int64(gid))
+}
+
+// --- attribute views ---
+
+// viewPath returns the path of a view, and the file info of the file there.
+func viewFileInfo(view *object.Object, caller string) (string, fs.FileInfo, *ghelpers.GErrBlk) {
+	p, gerr := pathToGoString(view.FieldTable[viewPathField].Fvalue)
+	if gerr != nil {
+		return "", nil, gerr
+	}
+	info, err := statPath(p, view.FieldTable[viewLinkOptionsField].Fvalue)
+	if err != nil {
+		return "", nil, fileAttributeError(caller, p, err)
+	}
+	return p, info, nil
+}
+
+// fileAttributeError returns the exception for a failure to read or change the attributes of p.
+func fileAttributeError(caller, p string, err error) *ghelpers.GErrBlk {
+	switch {
+	case os.IsNotExist(err):
+		return ghelpers.GetGErrBlk(excNames.NoSuchFileException, p)
+	case os.IsPermission(err):
+		return ghelpers.GetGErrBlk(excNames.AccessDeniedException, p)
+	}
+	return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: %s", caller, err.Error()))
+}
+
+// newPosixFileAttributes returns the PosixFileAttributes of a file.
+func newPosixFileAttributes(info fs.FileInfo) *object.Object {
+	obj := newBasicFileAttributes(info)
+	obj.KlassName = object.StringPoolIndexFromGoString(posixAttrsClassName)
+	return obj
+}
+
+func viewName(params []interface{}) interface{} {
+	switch object.GoStringFromStringPoolIndex(params[0].(*object.Object).KlassName) {
+	case posixViewClassName:
+		return object.StringObjectFromGoString("posix")
+	case ownerViewClassName:
+		return object.StringObjectFromGoString("owner")
+	}
+	return object.StringObjectFromGoString("basic")
+}
+
+// readAttributes()Ljava/nio/file/attribute/BasicFileAttributes; and its posix view form
+func viewReadAttributes(params []interface{}) interface{} {
+	view := params[0].(*object.Object)
+	_, info, gerr := viewFileInfo(view, "readAttributes")
+	if gerr != nil {
+		return gerr
+	}
+	if object.GoStringFromStringPoolIndex(view.KlassName) == posixViewClassName {
+		return newPosixFileAttributes(info)
+	}
+	return newBasicFileAttributes(info)
+}
+
+// setTimes(Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;)V
+// A null time is left as it is. The creation time cannot be changed, so it is ignored.
+func viewSetTimes(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0].(*object.Object).FieldTable[viewPathField].Fvalue)
+	if gerr != nil {
+		return gerr
+	}
+	var mtime, atime time.Time
+	if ft, ok := params[1].(*object.Object); ok && !object.IsNull(ft) {
+		mtime = time.UnixMilli(ft.FieldTable["value"].Fvalue.(int64))
+	}
+	if ft, ok := params[2].(*object.Object); ok && !object.IsNull(ft) {
+		atime = time.UnixMilli(ft.FieldTable["value"].Fvalue.(int64))
+	}
+	if err := os.Chtimes(p, atime, mtime); err != nil {
+		return fileAttributeError("setTimes", p, err)
+	}
+	return nil
+}
+
+func viewGetOwner(params []interface{}) interface{} {
+	_, info, gerr := viewFileInfo(params[0].(*object.Object), "getOwner")
+	if gerr != nil {
+		return gerr
+	}
+	return fileOwner(info)
+}
+
+func viewSetOwner(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0].(*object.Object).FieldTable[viewPathField].Fvalue)
+	if gerr != nil {
+		return gerr
+	}
+	uid, gerr := principalID(params[1], userPrincipalClassName)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.Lchown(p, uid, -1); err != nil {
+		return fileAttributeError("setOwner", p, err)
+	}
+	return nil
+}
+
+func viewSetGroup(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0].(*object.Object).FieldTable[viewPathField].Fvalue)
+	if gerr != nil {
+		return gerr
+	}
+	gid, gerr := principalID(params[1], groupPrincipalClassName)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.Lchown(p, -1, gid); err != nil {
+		return fileAttributeError("setGroup", p, err)
+	}
+	return nil
+}
+
+// setPermissions(Ljava/util/Set;)V
+func viewSetPermissions(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	p, gerr := pathToGoString(args[0].(*object.Object).FieldTable[viewPathField].Fvalue)
+	if gerr != nil {
+		return gerr
+	}
+	return setPermissions(fs, p, args[1])
+}
+
+func setPermissions(fs *list.List, p string, set any) interface{} {
+	mode, gerr := modeFromPermissions(fs, set)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.Chmod(p, mode); err != nil {
+		return fileAttributeError("setPermissions", p, err)
+	}
+	return nil
+}
+
+// fileOwner returns the UserPrincipal that owns a file.
+func fileOwner(info fs.FileInfo) interface{} {
+	uid, _, ok := fileOwnerIDs(info)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "file owners are not supported on this platform")
+	}
+	return userPrincipal(uid)
+}
+
+// --- PosixFileAttributes ---
+
+func pfaOwner(params []interface{}) interface{} {
+	return fileOwner(params[0].(*object.Object).FieldTable["info"].Fvalue.(fs.FileInfo))
+}
+
+func pfaGroup(params []interface{}) interface{} {
+	_, gid, ok := fileOwnerIDs(params[0].(*object.Object).FieldTable["info"].Fvalue.(fs.FileInfo))
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "file groups are not supported on this platform")
+	}
+	return groupPrincipal(gid)
+}
+
+func pfaPermissions(params []interface{}) interface{} {
+	fsStack, args := ghelpers.SplitContext(params)
+	info := args[0].(*object.Object).FieldTable["info"].Fvalue.(fs.FileInfo)
+	return newHashSet(fsStack, permissionsFromMode(info.Mode().Perm()))
+}
+
+// --- Files ---
+
+// attributeViewClass returns the class of the view or attributes named by a Class argument.
+func attributeViewClass(arg any) (string, *ghelpers.GErrBlk) {
+	classObj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(classObj) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "type is null")
+	}
+	nameObj, ok := classObj.FieldTable["name"].Fvalue.(*object.Object)
+	if !ok {
+		return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "type is not a Class")
+	}
+	return strings.ReplaceAll(object.GoStringFromStringObject(nameObj), ".", "/"), nil
+}
+
+// java/nio/file/Files.getFileAttributeView(Ljava/nio/file/Path;Ljava/lang/Class;[Ljava/nio/file/LinkOption;)
+// Returns null for a view that is not available.
+func filesGetFileAttributeView(params []interface{}) interface{} {
+	if _, gerr := pathToGoString(params[0]); gerr != nil {
+		return gerr
+	}
+	className, gerr := attributeViewClass(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	switch className {
+	case basicViewClassName:
+	case ownerViewClassName, posixViewClassName:
+		if globals.OnWindows {
+			return object.Null
+		}
+	default:
+		return object.Null
+	}
+	view := object.MakeEmptyObjectWithClassName(&className)
+	view.FieldTable[viewPathField] = object.Field{Ftype: types.Ref, Fvalue: params[0]}
+	view.FieldTable[viewLinkOptionsField] = object.Field{Ftype: types.Ref, Fvalue: params[2]}
+	return view
+}
+
+// java/nio/file/Files.readAttributes(Ljava/nio/file/Path;Ljava/lang/Class;[Ljava/nio/file/LinkOption;)
+func filesReadAttributes(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	className, gerr := attributeViewClass(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	if className != basicAttrsClassName && (className != posixAttrsClassName || globals.OnWindows) {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "Files.readAttributes: "+className)
+	}
+	info, err := statPath(p, params[2])
+	if err != nil {
+		return fileAttributeError("Files.readAttributes", p, err)
+	}
+	if className == posixAttrsClassName {
+		return newPosixFileAttributes(info)
+	}
+	return newBasicFileAttributes(info)
+}
+
+// java/nio/file/Files.getAttribute(Ljava/nio/file/Path;Ljava/lang/String;[Ljava/nio/file/LinkOption;)
+// The attribute is [view:]name, where the view is basic (the default), owner, posix or unix.
+func filesGetAttribute(params []interface{}) interface{} {
+	fsStack, params := ghelpers.SplitContext(params)
+	p, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	attrObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(attrObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Files.getAttribute: attribute is null")
+	}
+	attribute := object.GoStringFromStringObject(attrObj)
+	view, name, found := strings.Cut(attribute, ":")
+	if !found {
+		view, name = "basic", attribute
+	}
+	if view != "basic" && globals.OnWindows {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "View '"+view+"' not available")
+	}
+
+	info, err := statPath(p, params[2])
+	if err != nil {
+		return fileAttributeError("Files.getAttribute", p, err)
+	}
+	mode := info.Mode()
+	uid, gid, _ := fileOwnerIDs(info)
+
+	var basic = map[string]func() interface{}{
+		"size":             func() interface{} { return object.MakePrimitiveObject("java/lang/Long", types.Long, info.Size()) },
+		"lastModifiedTime": func() interface{} { return newFileTime(info.ModTime()) },
+		"isRegularFile":    func() interface{} { return boxedBoolean(mode.IsRegular()) },
+		"isDirectory":      func() interface{} { return boxedBoolean(info.IsDir()) },
+		"isSymbolicLink":   func() interface{} { return boxedBoolean(mode&fs.ModeSymlink != 0) },
+		"isOther": func() interface{} {
+			return boxedBoolean(!mode.IsRegular() && !info.IsDir() && mode&fs.ModeSymlink == 0)
+		},
+	}
+	var attributes map[string]func() interface{}
+	switch view {
+	case "basic":
+		attributes = basic
+	case "owner":
+		attributes = map[string]func() interface{}{"owner": func() interface{} { return fileOwner(info) }}
+	case "posix", "unix":
+		attributes = basic
+		attributes["owner"] = func() interface{} { return fileOwner(info) }
+		attributes["group"] = func() interface{} { return groupPrincipal(gid) }
+		attributes["permissions"] = func() interface{} { return newHashSet(fsStack, permissionsFromMode(mode.Perm())) }
+		if view == "unix" {
+			attributes["uid"] = func() interface{} { return object.MakePrimitiveObject("java/lang/Integer", types.Int, int64(uid)) }
+			attributes["gid"] = func() interface{} { return object.MakePrimitiveObject("java/lang/Integer", types.Int, int64(gid)) }
+			attributes["mode"] = func() interface{} {
+				return object.MakePrimitiveObject("java/lang/Integer", types.Int, int64(unixMode(mode)))
+			}
+		}
+	default:
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "View '"+view+"' not available")
+	}
+	get, ok := attributes[name]
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "'"+attribute+"' not recognized")
+	}
+	return get()
+}
+
+func boxedBoolean(b bool) *object.Object {
+	return object.MakePrimitiveObject("java/lang/Boolean", types.Bool, types.ConvertGoBoolToJavaBool(b))
+}
+
+// unixMode returns the st_mode of a file: its type and permission bits as stat(2) gives them.
+func unixMode(mode fs.FileMode) uint32 {
+	bits := uint32(mode.Perm())
+	switch {
+	case mode&fs.ModeDir != 0:
+		bits |= 0o040000
+	case mode&fs.ModeSymlink != 0:
+		bits |= 0o120000
+	case mode&fs.ModeNamedPipe != 0:
+		bits |= 0o010000
+	case mode&fs.ModeSocket != 0:
+		bits |= 0o140000
+	case mode&fs.ModeCharDevice != 0:
+		bits |= 0o020000
+	case mode&fs.ModeDevice != 0:
+		bits |= 0o060000
+	default:
+		bits |= 0o100000
+	}
+	if mode&fs.ModeSetuid != 0 {
+		bits |= 0o4000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		bits |= 0o2000
+	}
+	if mode&fs.ModeSticky != 0 {
+		bits |= 0o1000
+	}
+	return bits
+}
+
+// java/nio/file/Files.getOwner(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)
+func filesGetOwner(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	info, err := statPath(p, params[1])
+	if err != nil {
+		return fileAttributeError("Files.getOwner", p, err)
+	}
+	return fileOwner(info)
+}
+
+// java/nio/file/Files.setOwner(Ljava/nio/file/Path;Ljava/nio/file/attribute/UserPrincipal;)
+func filesSetOwner(params []interface{}) interface{} {
+	p, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	uid, gerr := principalID(params[1], userPrincipalClassName)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.Chown(p, uid, -1); err != nil {
+		return fileAttributeError("Files.setOwner", p, err)
+	}
+	return params[0]
+}
+
+// java/nio/file/Files.getPosixFilePermissions(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)
+func filesGetPosixFilePermissions(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	p, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if globals.OnWindows {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "Files.getPosixFilePermissions: not supported on Windows")
+	}
+	info, err := statPath(p, params[1])
+	if err != nil {
+		return fileAttributeError("Files.getPosixFilePermissions", p, err)
+	}
+	return newHashSet(fs, permissionsFromMode(info.Mode().Perm()))
+}
+
+// java/nio/file/Files.setPosixFilePermissions(Ljava/nio/file/Path;Ljava/util/Set;)
+func filesSetPosixFilePermissions(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	p, gerr := pathToGoString(args[0])
+	if gerr != nil {
+		return gerr
+	}
+	if globals.OnWindows {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "Files.setPosixFilePermissions: not supported on Windows")
+	}
+	if ret := setPermissions(fs, p, args[1]); ret != nil {
+		return ret
+	}
+	return args[0]
+}
@@ -0,0 +1,194 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// loadPosixForTest loads the attribute G functions, along with stand-ins for the collections.
+func loadPosixForTest() {
+	globals.InitGlobals("test")
+	Load_Nio_File_Files()
+	Load_Nio_File_Attribute_Posix()
+	loadCollectionStandIns()
+}
+
+// loadCollectionStandIns registers stand-ins for java.util.HashSet and ArrayList, which are in
+// javaUtil, a package that this one cannot import. They keep their elements in a Go slice.
+func loadCollectionStandIns() {
+	for _, className := range []string{"java/util/HashSet", "java/util/ArrayList"} {
+		ghelpers.MethodSignatures[className+".<init>()V"] = ghelpers.GMeth{ParamSlots: 0,
+			GFunction: func(params []interface{}) interface{} {
+				coll := params[0].(*object.Object)
+				coll.FieldTable["elems"] = object.Field{Ftype: types.RefArray, Fvalue: []*object.Object{}}
+				return nil
+			}}
+		ghelpers.MethodSignatures[className+".add(Ljava/lang/Object;)Z"] = ghelpers.GMeth{ParamSlots: 1,
+			GFunction: func(params []interface{}) interface{} {
+				coll := params[0].(*object.Object)
+				elems := append(coll.FieldTable["elems"].Fvalue.([]*object.Object), params[1].(*object.Object))
+				coll.FieldTable["elems"] = object.Field{Ftype: types.RefArray, Fvalue: elems}
+				return types.JavaBoolTrue
+			}}
+		ghelpers.MethodSignatures[className+".toArray()[Ljava/lang/Object;"] = ghelpers.GMeth{ParamSlots: 0,
+			GFunction: func(params []interface{}) interface{} {
+				elems := params[0].(*object.Object).FieldTable["elems"].Fvalue.([]*object.Object)
+				arr := object.Make1DimRefArray("Ljava/lang/Object;", int64(len(elems)))
+				copy(arr.FieldTable["value"].Fvalue.([]*object.Object), elems)
+				return arr
+			}}
+	}
+}
+
+func permsFromString(t *testing.T, perms string) interface{} {
+	t.Helper()
+	return callBuffer(t, "java/nio/file/attribute/PosixFilePermissions.fromString(Ljava/lang/String;)Ljava/util/Set;",
+		object.StringObjectFromGoString(perms))
+}
+
+func permsToString(t *testing.T, set interface{}) string {
+	t.Helper()
+	ret := callBuffer(t, "java/nio/file/attribute/PosixFilePermissions.toString(Ljava/util/Set;)Ljava/lang/String;",
+		list.New(), set)
+	str, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("toString: got %v", ret)
+	}
+	return object.GoStringFromStringObject(str)
+}
+
+func TestPosixFilePermissions_FromAndToString(t *testing.T) {
+	loadPosixForTest()
+	for _, perms := range []string{"rwxr-x---", "---------", "rw-rw-rw-"} {
+		if got := permsToString(t, permsFromString(t, perms)); got != perms {
+			t.Errorf("round trip of %s: got %s", perms, got)
+		}
+	}
+	for _, bad := range []string{"rwx", "rwxrwxrwz", "wrxrwxrwx"} {
+		testutil.ExpectGErr(t, permsFromString(t, bad), excNames.IllegalArgumentException, "")
+	}
+
+	ownerRead := callBuffer(t, posixPermissionClassName+".valueOf(Ljava/lang/String;)"+posixPermissionType,
+		object.StringObjectFromGoString("OWNER_READ"))
+	values := callBuffer(t, posixPermissionClassName+".values()[Ljava/nio/file/attribute/PosixFilePermission;")
+	if arr := values.(*object.Object).FieldTable["value"].Fvalue.([]*object.Object); len(arr) != 9 || arr[0] != ownerRead {
+		t.Errorf("values: got %v", arr)
+	}
+}
+
+func TestFiles_PosixFilePermissions(t *testing.T) {
+	loadPosixForTest()
+	if globals.OnWindows {
+		t.Skip("POSIX permissions are not supported on Windows")
+	}
+	path := newPath(filepath.Join(t.TempDir(), "perms.txt"))
+	attr := callBuffer(t, "java/nio/file/attribute/PosixFilePermissions.asFileAttribute(Ljava/util/Set;)Ljava/nio/file/attribute/FileAttribute;",
+		list.New(), permsFromString(t, "rw-------"))
+	attrs := object.Make1DimRefArray("Ljava/nio/file/attribute/FileAttribute;", 1)
+	if gerr, ok := attr.(*ghelpers.GErrBlk); ok {
+		t.Fatalf("asFileAttribute: %s", gerr.ErrMsg)
+	}
+	attrs.FieldTable["value"].Fvalue.([]*object.Object)[0] = attr.(*object.Object)
+	if gerr, ok := filesCreateFile([]interface{}{path, attrs}).(*ghelpers.GErrBlk); ok {
+		t.Fatalf("createFile: %s", gerr.ErrMsg)
+	}
+
+	noLinkOptions := object.Make1DimRefArray("Ljava/nio/file/LinkOption;", 0)
+	perms := callBuffer(t, "java/nio/file/Files.getPosixFilePermissions(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)Ljava/util/Set;",
+		path, noLinkOptions)
+	if got := permsToString(t, perms); got != "rw-------" {
+		t.Errorf("permissions of the new file: got %s", got)
+	}
+
+	callBuffer(t, "java/nio/file/Files.setPosixFilePermissions(Ljava/nio/file/Path;Ljava/util/Set;)Ljava/nio/file/Path;",
+		list.New(), path, permsFromString(t, "rwxr-----"))
+	p, _ := pathToGoString(path)
+	if info, _ := os.Stat(p); info.Mode().Perm() != 0o740 {
+		t.Errorf("setPosixFilePermissions: got %o", info.Mode().Perm())
+	}
+}
+
+func TestFiles_GetAttribute(t *testing.T) {
+	loadPosixForTest()
+	p := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(p, []byte("12345"), 0o644); err != nil {
+		t.Fatalf("prep: %v", err)
+	}
+	noLinkOptions := object.Make1DimRefArray("Ljava/nio/file/LinkOption;", 0)
+	getAttribute := func(name string) interface{} {
+		return callBuffer(t, "java/nio/file/Files.getAttribute(Ljava/nio/file/Path;Ljava/lang/String;[Ljava/nio/file/LinkOption;)Ljava/lang/Object;",
+			newPath(p), object.StringObjectFromGoString(name), noLinkOptions)
+	}
+
+	size, ok := getAttribute("size").(*object.Object)
+	if !ok || size.FieldTable["value"].Fvalue != int64(5) {
+		t.Errorf("size: got %v", size)
+	}
+	isDir, ok := getAttribute("basic:isDirectory").(*object.Object)
+	if !ok || isDir.FieldTable["value"].Fvalue != types.JavaBoolFalse {
+		t.Errorf("basic:isDirectory: got %v", isDir)
+	}
+	testutil.ExpectGErr(t, getAttribute("basic:nope"), excNames.IllegalArgumentException, "")
+	testutil.ExpectGErr(t, getAttribute("acl:owner"), excNames.UnsupportedOperationException, "")
+
+	if globals.OnWindows {
+		return
+	}
+	mode, ok := getAttribute("unix:mode").(*object.Object)
+	if !ok || mode.FieldTable["value"].Fvalue != int64(0o100644) {
+		t.Errorf("unix:mode: got %v", mode)
+	}
+	owner, ok := getAttribute("posix:owner").(*object.Object)
+	if !ok || owner.FieldTable[principalIDField].Fvalue != int64(os.Getuid()) {
+		t.Errorf("posix:owner: got %v", owner)
+	}
+}
+
+func TestFiles_FileAttributeViews(t *testing.T) {
+	loadPosixForTest()
+	p := filepath.Join(t.TempDir(), "view.txt")
+	if err := os.WriteFile(p, []byte("abc"), 0o644); err != nil {
+		t.Fatalf("prep: %v", err)
+	}
+	noLinkOptions := object.Make1DimRefArray("Ljava/nio/file/LinkOption;", 0)
+	getView := func(className string) interface{} {
+		return callBuffer(t, "java/nio/file/Files.getFileAttributeView(Ljava/nio/file/Path;Ljava/lang/Class;[Ljava/nio/file/LinkOption;)Ljava/nio/file/attribute/FileAttributeView;",
+			newPath(p), classloader.MakeJlcObject(className), noLinkOptions)
+	}
+
+	if view := getView("java/nio/file/attribute/AclFileAttributeView"); view != object.Null {
+		t.Errorf("an unsupported view should be null, got %v", view)
+	}
+	basic := getView(basicViewClassName)
+	name := callBuffer(t, basicViewClassName+".name()Ljava/lang/String;", basic)
+	if object.GoStringFromStringObject(name.(*object.Object)) != "basic" {
+		t.Errorf("name: got %v", name)
+	}
+	attrs := callBuffer(t, basicViewClassName+".readAttributes()Ljava/nio/file/attribute/BasicFileAttributes;", basic)
+	if size := bfaSize([]interface{}{attrs}); size != int64(3) {
+		t.Errorf("size: got %v", size)
+	}
+
+	when := time.UnixMilli(1_600_000_000_000)
+	callBuffer(t, basicViewClassName+".setTimes(Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;Ljava/nio/file/attribute/FileTime;)V",
+		basic, newFileTime(when), object.Null, object.Null)
+	if info, _ := os.Stat(p); !info.ModTime().Equal(when) {
+		t.Errorf("setTimes: got %v", info.ModTime())
+	}
+}
@@ -0,0 +1,1123 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"math"
+	"os"
+	"sync"
+)
+
+// java.nio.channels.FileChannel, FileChannel.MapMode and FileLock. A channel object holds a
+// *fileChannel in its fileChannelField field and the *os.File in its ghelpers.FileHandle field,
+// like the java.io streams. A channel returned by getChannel() of a RandomAccessFile or a
+// FileInputStream shares the stream's *os.File, so the two see the same file position, and
+// closing either one closes both.
+//
+// map() uses mmap (see javaNioMappedByteBuffer.go). lock() and tryLock() take an advisory flock
+// on the whole file for as long as this JVM holds a lock on any region of it. The regions are
+// only tracked within the JVM, where overlapping locks throw OverlappingFileLockException as the
+// JDK's do.
+
+const fileChannelClassName = "java/nio/channels/FileChannel"
+const fileChannelField = "channel"
+const fileLockClassName = "java/nio/channels/FileLock"
+const fileLockField = "lock"
+
+// transferChunk is the most that transferTo() and transferFrom() copy at a time.
+const transferChunk = 64 * 1024
+
+var pageSize = os.Getpagesize()
+
+// errUnsupportedPlatform is returned by the mmap and flock helpers where they are not available.
+var errUnsupportedPlatform = errors.New("not supported on this platform")
+
+type fileChannel struct {
+	file          *os.File
+	path          string
+	readable      bool
+	writable      bool
+	appending     bool // every write goes to the end of the file
+	deleteOnClose bool
+	closed        bool
+}
+
+func Load_Nio_Channels_FileChannel() {
+
+	ghelpers.MethodSignatures["java/nio/channels/FileChannel.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/nio/channels/FileChannel.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapProtected}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)Ljava/nio/channels/FileChannel;":                             {ParamSlots: 2, GFunction: fileChannelOpen},
+		"open(Ljava/nio/file/Path;Ljava/util/Set;[Ljava/nio/file/attribute/FileAttribute;)Ljava/nio/channels/FileChannel;": {ParamSlots: 3, GFunction: fileChannelOpen},
+
+		"read(Ljava/nio/ByteBuffer;)I":                             {ParamSlots: 1, GFunction: fileChannelRead},
+		"read(Ljava/nio/ByteBuffer;J)I":                            {ParamSlots: 2, GFunction: fileChannelRead},
+		"read([Ljava/nio/ByteBuffer;)J":                            {ParamSlots: 1, GFunction: fileChannelScatter},
+		"read([Ljava/nio/ByteBuffer;II)J":                          {ParamSlots: 3, GFunction: fileChannelScatter},
+		"write(Ljava/nio/ByteBuffer;)I":                            {ParamSlots: 1, GFunction: fileChannelWrite},
+		"write(Ljava/nio/ByteBuffer;J)I":                           {ParamSlots: 2, GFunction: fileChannelWrite},
+		"write([Ljava/nio/ByteBuffer;)J":                           {ParamSlots: 1, GFunction: fileChannelGather},
+		"write([Ljava/nio/ByteBuffer;II)J":                         {ParamSlots: 3, GFunction: fileChannelGather},
+		"transferTo(JJLjava/nio/channels/WritableByteChannel;)J":   {ParamSlots: 3, GFunction: fileChannelTransferTo, NeedsContext: true},
+		"transferFrom(Ljava/nio/channels/ReadableByteChannel;JJ)J": {ParamSlots: 3, GFunction: fileChannelTransferFrom, NeedsContext: true},
+
+		"position()J": {ParamSlots: 0, GFunction: fileChannelPosition},
+		"position(J)Ljava/nio/channels/FileChannel;":         {ParamSlots: 1, GFunction: fileChannelSetPosition},
+		"position(J)Ljava/nio/channels/SeekableByteChannel;": {ParamSlots: 1, GFunction: fileChannelSetPosition},
+		"size()J": {ParamSlots: 0, GFunction: fileChannelSize},
+		"truncate(J)Ljava/nio/channels/FileChannel;":         {ParamSlots: 1, GFunction: fileChannelTruncate},
+		"truncate(J)Ljava/nio/channels/SeekableByteChannel;": {ParamSlots: 1, GFunction: fileChannelTruncate},
+		"force(Z)V": {ParamSlots: 1, GFunction: fileChannelForce},
+
+		"map(Ljava/nio/channels/FileChannel$MapMode;JJ)Ljava/nio/MappedByteBuffer;": {ParamSlots: 3, GFunction: fileChannelMap},
+
+		"lock()Ljava/nio/channels/FileLock;":       {ParamSlots: 0, GFunction: fileChannelLockFor(true)},
+		"lock(JJZ)Ljava/nio/channels/FileLock;":    {ParamSlots: 3, GFunction: fileChannelLockFor(true)},
+		"tryLock()Ljava/nio/channels/FileLock;":    {ParamSlots: 0, GFunction: fileChannelLockFor(false)},
+		"tryLock(JJZ)Ljava/nio/channels/FileLock;": {ParamSlots: 3, GFunction: fileChannelLockFor(false)},
+
+		"isOpen()Z": {ParamSlots: 0, GFunction: fileChannelIsOpen},
+		"close()V":  {ParamSlots: 0, GFunction: fileChannelClose},
+	} {
+		ghelpers.MethodSignatures[fileChannelClassName+"."+sig] = gmeth
+	}
+
+	ghelpers.MethodSignatures["java/nio/channels/FileChannel$MapMode.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: mapModeClinit}
+
+	ghelpers.MethodSignatures["java/nio/channels/FileChannel$MapMode.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: mapModeToString}
+
+	ghelpers.MethodSignatures["java/nio/channels/FileLock.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"channel()Ljava/nio/channels/FileChannel;": {ParamSlots: 0, GFunction: fileLockChannel},
+		"acquiredBy()Ljava/nio/channels/Channel;":  {ParamSlots: 0, GFunction: fileLockChannel},
+		"position()J":                  {ParamSlots: 0, GFunction: fileLockPosition},
+		"size()J":                      {ParamSlots: 0, GFunction: fileLockSize},
+		"isShared()Z":                  {ParamSlots: 0, GFunction: fileLockIsShared},
+		"overlaps(JJ)Z":                {ParamSlots: 2, GFunction: fileLockOverlaps},
+		"isValid()Z":                   {ParamSlots: 0, GFunction: fileLockIsValid},
+		"release()V":                   {ParamSlots: 0, GFunction: fileLockRelease},
+		"close()V":                     {ParamSlots: 0, GFunction: fileLockRelease},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: fileLockToString},
+	} {
+		ghelpers.MethodSignatures[fileLockClassName+"."+sig] = gmeth
+	}
+}
+
+// --- channel objects ---
+
+// NewFileChannelObject returns a FileChannel over a file that is already open, such as the file
+// of a RandomAccessFile or a FileInputStream. The channel and the stream share the *os.File.
+func NewFileChannelObject(file *os.File, path string, readable, writable bool) *object.Object {
+	return newFileChannelObject(&fileChannel{file: file, path: path, readable: readable, writable: writable})
+}
+
+func newFileChannelObject(fc *fileChannel) *object.Object {
+	className := fileChannelClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[fileChannelField] = object.Field{Ftype: types.RawGoPointer, Fvalue: fc}
+	obj.FieldTable[ghelpers.FileHandle] = object.Field{Ftype: ghelpers.FileHandle, Fvalue: fc.file}
+	return obj
+}
+
+// channelState returns the state of a channel object, open or not.
+func channelState(this any, caller string) (*fileChannel, *ghelpers.GErrBlk) {
+	self, ok := this.(*object.Object)
+	if !ok || object.IsNull(self) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": channel is null")
+	}
+	fc, ok := self.FieldTable[fileChannelField].Fvalue.(*fileChannel)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": channel was not initialized")
+	}
+	return fc, nil
+}
+
+// channelThis returns the state of an open channel object.
+func channelThis(this any, caller string) (*fileChannel, *ghelpers.GErrBlk) {
+	fc, gerr := channelState(this, caller)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if !fc.isOpen() {
+		return nil, ghelpers.GetGErrBlk(excNames.ClosedChannelException, "")
+	}
+	return fc, nil
+}
+
+// isOpen is false once the channel, or the stream that it shares its file with, is closed.
+func (fc *fileChannel) isOpen() bool {
+	return !fc.closed && fc.file.Fd() != ^uintptr(0)
+}
+
+// channelIOError returns the exception for an error from a file operation.
+func channelIOError(err error) *ghelpers.GErrBlk {
+	switch {
+	case errors.Is(err, os.ErrClosed):
+		return ghelpers.GetGErrBlk(excNames.ClosedChannelException, "")
+	case errors.Is(err, errUnsupportedPlatform):
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, err.Error())
+	default:
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+}
+
+// --- open ---
+
+// openOptionName returns the name of a StandardOpenOption or LinkOption constant.
+func openOptionName(opt any) (string, *ghelpers.GErrBlk) {
+	obj, ok := opt.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "open: option is null")
+	}
+	name, ok := obj.FieldTable["name"].Fvalue.(*object.Object)
+	if !ok {
+		return "", ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "open: unsupported option")
+	}
+	return object.GoStringFromStringObject(name), nil
+}
+
+// openOptionNames returns the names of the options in an OpenOption[] or a Set<OpenOption>.
+func openOptionNames(options any) ([]string, *ghelpers.GErrBlk) {
+	elems, gerr := collectionElements(nil, options)
+	if gerr != nil {
+		return nil, gerr
+	}
+	names := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		name, gerr := openOptionName(elem)
+		if gerr != nil {
+			return nil, gerr
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// java/nio/channels/FileChannel.open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;) and
+// open(Ljava/nio/file/Path;Ljava/util/Set;[Ljava/nio/file/attribute/FileAttribute;). File
+// attributes are not applied: a new file gets the usual permissions.
+func fileChannelOpen(params []interface{}) interface{} {
+	path, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	names, gerr := openOptionNames(params[1])
+	if gerr != nil {
+		return gerr
+	}
+
+	fc := &fileChannel{path: path}
+	var create, createNew, truncate, sync bool
+	for _, name := range names {
+		switch name {
+		case "READ":
+			fc.readable = true
+		case "WRITE":
+			fc.writable = true
+		case "APPEND":
+			fc.writable, fc.appending = true, true
+		case "CREATE":
+			create = true
+		case "CREATE_NEW":
+			createNew = true
+		case "TRUNCATE_EXISTING":
+			truncate = true
+		case "DELETE_ON_CLOSE":
+			fc.deleteOnClose = true
+		case "SYNC", "DSYNC":
+			sync = true
+		case "SPARSE", "NOFOLLOW_LINKS":
+		default:
+			return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, name+" not supported")
+		}
+	}
+	if !fc.writable {
+		fc.readable = true
+	}
+	if fc.readable && fc.appending {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "READ + APPEND not allowed")
+	}
+	if fc.appending && truncate {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "APPEND + TRUNCATE_EXISTING not allowed")
+	}
+
+	var flags int
+	switch {
+	case fc.readable && fc.writable:
+		flags = os.O_RDWR
+	case fc.writable:
+		flags = os.O_WRONLY
+	default:
+		flags = os.O_RDONLY
+	}
+	if fc.writable {
+		switch {
+		case createNew:
+			flags |= os.O_CREATE | os.O_EXCL
+		case create:
+			flags |= os.O_CREATE
+		}
+		if truncate {
+			flags |= os.O_TRUNC
+		}
+		if fc.appending {
+			flags |= os.O_APPEND
+		}
+		if sync {
+			flags |= os.O_SYNC
+		}
+	}
+
+	file, err := os.OpenFile(path, flags, ghelpers.CreateFilePermissions)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return ghelpers.GetGErrBlk(excNames.NoSuchFileException, path)
+		case errors.Is(err, fs.ErrExist):
+			return ghelpers.GetGErrBlk(excNames.FileAlreadyExistsException, path)
+		case errors.Is(err, fs.ErrPermission):
+			return ghelpers.GetGErrBlk(excNames.AccessDeniedException, path)
+		}
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	fc.file = file
+	return newFileChannelObject(fc)
+}
+
+// --- reading and writing ---
+
+// byteBufferArg returns the state of a ByteBuffer argument.
+func byteBufferArg(arg any, caller string) (*nioBuffer, *ghelpers.GErrBlk) {
+	if obj, ok := arg.(*object.Object); !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": buffer is null")
+	}
+	return bufferThis(arg, caller)
+}
+
+// readInto reads into the remaining bytes of b, at the channel's position if at is negative, and
+// advances the position of b. It returns -1 at the end of the file.
+func (fc *fileChannel) readInto(b *nioBuffer, at int64) (int, error) {
+	p := make([]byte, b.remaining())
+	if len(p) == 0 {
+		return 0, nil
+	}
+	var n int
+	var err error
+	if at < 0 {
+		n, err = fc.file.Read(p)
+	} else {
+		n, err = fc.file.ReadAt(p, at)
+	}
+	for ix := range n {
+		b.bytes[b.offset+b.position+ix] = types.JavaByte(p[ix])
+	}
+	b.position += n
+	if errors.Is(err, io.EOF) {
+		if n == 0 {
+			return -1, nil
+		}
+		err = nil
+	}
+	return n, err
+}
+
+// writeBytes writes p at the channel's position if at is negative. A positional write to a
+// channel opened for APPEND goes to the end of the file, as pwrite does on Linux.
+func (fc *fileChannel) writeBytes(p []byte, at int64) (int, error) {
+	if at < 0 || fc.appending {
+		return fc.file.Write(p)
+	}
+	return fc.file.WriteAt(p, at)
+}
+
+// writeFrom writes the remaining bytes of b and advances the position of b.
+func (fc *fileChannel) writeFrom(b *nioBuffer, at int64) (int, error) {
+	p := make([]byte, b.remaining())
+	for ix := range p {
+		p[ix] = byte(b.bytes[b.offset+b.position+ix])
+	}
+	n, err := fc.writeBytes(p, at)
+	b.position += n
+	return n, err
+}
+
+// java/nio/channels/FileChannel.read(Ljava/nio/ByteBuffer;) and read(Ljava/nio/ByteBuffer;J)
+func fileChannelRead(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "read")
+	if gerr != nil {
+		return gerr
+	}
+	b, gerr := byteBufferArg(params[1], "read")
+	if gerr != nil {
+		return gerr
+	}
+	if b.readOnly {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Read-only buffer")
+	}
+	at := int64(-1)
+	if len(params) == 3 {
+		if at = params[2].(int64); at < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position")
+		}
+	}
+	if !fc.readable {
+		return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+	}
+	n, err := fc.readInto(b, at)
+	if err != nil {
+		return channelIOError(err)
+	}
+	return int64(n)
+}
+
+// java/nio/channels/FileChannel.write(Ljava/nio/ByteBuffer;) and write(Ljava/nio/ByteBuffer;J)
+func fileChannelWrite(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "write")
+	if gerr != nil {
+		return gerr
+	}
+	b, gerr := byteBufferArg(params[1], "write")
+	if gerr != nil {
+		return gerr
+	}
+	at := int64(-1)
+	if len(params) == 3 {
+		if at = params[2].(int64); at < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position")
+		}
+	}
+	if !fc.writable {
+		return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+	}
+	n, err := fc.writeFrom(b, at)
+	if err != nil {
+		return channelIOError(err)
+	}
+	return int64(n)
+}
+
+// byteBufferArray returns the buffers of a ByteBuffer[] argument, or the length of them that
+// starts at an offset if params has both.
+func byteBufferArray(params []interface{}, caller string) ([]*nioBuffer, *ghelpers.GErrBlk) {
+	arr, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": buffer array is null")
+	}
+	elems, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	if len(params) == 4 {
+		offset, length := int(params[2].(int64)), int(params[3].(int64))
+		if gerr := checkFromIndexSize(offset, length, len(elems)); gerr != nil {
+			return nil, gerr
+		}
+		elems = elems[offset : offset+length]
+	}
+	buffers := make([]*nioBuffer, len(elems))
+	for ix, elem := range elems {
+		b, gerr := byteBufferArg(elem, caller)
+		if gerr != nil {
+			return nil, gerr
+		}
+		buffers[ix] = b
+	}
+	return buffers, nil
+}
+
+// java/nio/channels/FileChannel.read([Ljava/nio/ByteBuffer;) and read([Ljava/nio/ByteBuffer;II)
+// -- fill the buffers in turn until one is not filled
+func fileChannelScatter(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "read")
+	if gerr != nil {
+		return gerr
+	}
+	buffers, gerr := byteBufferArray(params, "read")
+	if gerr != nil {
+		return gerr
+	}
+	if !fc.readable {
+		return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+	}
+	var total int64
+	for _, b := range buffers {
+		if b.readOnly {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Read-only buffer")
+		}
+		n, err := fc.readInto(b, -1)
+		if err != nil {
+			return channelIOError(err)
+		}
+		if n < 0 {
+			if total == 0 {
+				return int64(-1)
+			}
+			break
+		}
+		total += int64(n)
+		if b.remaining() > 0 {
+			break
+		}
+	}
+	return total
+}
+
+// java/nio/channels/FileChannel.write([Ljava/nio/ByteBuffer;) and write([Ljava/nio/ByteBuffer;II)
+func fileChannelGather(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "write")
+	if gerr != nil {
+		return gerr
+	}
+	buffers, gerr := byteBufferArray(params, "write")
+	if gerr != nil {
+		return gerr
+	}
+	if !fc.writable {
+		return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+	}
+	var total int64
+	for _, b := range buffers {
+		n, err := fc.writeFrom(b, -1)
+		total += int64(n)
+		if err != nil {
+			return channelIOError(err)
+		}
+	}
+	return total
+}
+
+// checkTransferArgs checks the position and count of transferTo() and transferFrom().
+func checkTransferArgs(position, count int64) *ghelpers.GErrBlk {
+	if position < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position")
+	}
+	if count < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative count")
+	}
+	return nil
+}
+
+// java/nio/channels/FileChannel.transferTo(JJLjava/nio/channels/WritableByteChannel;)J
+// The target is another FileChannel or any object with a write(ByteBuffer) method. The channel's
+// position is not changed.
+func fileChannelTransferTo(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	fc, gerr := channelThis(params[0], "transferTo")
+	if gerr != nil {
+		return gerr
+	}
+	position, count := params[1].(int64), params[2].(int64)
+	target, ok := params[3].(*object.Object)
+	if !ok || object.IsNull(target) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "transferTo: target is null")
+	}
+	if gerr := checkTransferArgs(position, count); gerr != nil {
+		return gerr
+	}
+	targetChannel, isFileChannel := target.FieldTable[fileChannelField].Fvalue.(*fileChannel)
+	if isFileChannel {
+		if !targetChannel.isOpen() {
+			return ghelpers.GetGErrBlk(excNames.ClosedChannelException, "")
+		}
+		if !targetChannel.writable {
+			return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+		}
+	}
+	if !fc.readable {
+		return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+	}
+
+	var total int64
+	p := make([]byte, min(count, transferChunk))
+	for total < count {
+		n, err := fc.file.ReadAt(p[:min(count-total, int64(len(p)))], position+total)
+		if n > 0 {
+			var written int64
+			if isFileChannel {
+				w, werr := targetChannel.writeBytes(p[:n], -1)
+				written, err = int64(w), werr
+			} else {
+				var gerr *ghelpers.GErrBlk
+				if written, gerr = writeToChannel(fs, target, p[:n]); gerr != nil {
+					return gerr
+				}
+			}
+			total += written
+			if written < int64(n) {
+				break
+			}
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return channelIOError(err)
+		}
+		if n == 0 || err != nil {
+			break
+		}
+	}
+	return total
+}
+
+// writeToChannel writes p to a WritableByteChannel that is not a FileChannel, through its
+// write(ByteBuffer) method, which runs on the frame stack fs. It returns early if the channel stops
+// accepting bytes.
+func writeToChannel(fs *list.List, target *object.Object, p []byte) (int64, *ghelpers.GErrBlk) {
+	b := &nioBuffer{kind: bufferKinds['B'], bytes: object.JavaByteArrayFromGoByteArray(p),
+		capacity: len(p), limit: len(p), mark: -1, bigEndian: true}
+	bufObj := newBufferObject(b)
+	for b.remaining() > 0 {
+		ret := ghelpers.InvokeMethodOnObject(fs, target, "write", "(Ljava/nio/ByteBuffer;)I", bufObj)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return int64(b.position), gerr
+		}
+		if n, ok := ret.(int64); !ok || n <= 0 {
+			break
+		}
+	}
+	return int64(b.position), nil
+}
+
+// readFromChannel reads up to len(p) bytes from a ReadableByteChannel that is not a FileChannel,
+// through its read(ByteBuffer) method, which runs on the frame stack fs. It returns -1 at the end of
+// the stream.
+func readFromChannel(fs *list.List, src *object.Object, p []byte) (int, *ghelpers.GErrBlk) {
+	b := &nioBuffer{kind: bufferKinds['B'], bytes: make([]types.JavaByte, len(p)),
+		capacity: len(p), limit: len(p), mark: -1, bigEndian: true}
+	ret := ghelpers.InvokeMethodOnObject(fs, src, "read", "(Ljava/nio/ByteBuffer;)I", newBufferObject(b))
+	switch r := ret.(type) {
+	case *ghelpers.GErrBlk:
+		return 0, r
+	case int64:
+		if r < 0 {
+			return -1, nil
+		}
+	}
+	copy(p, object.GoByteArrayFromJavaByteArray(b.bytes[:b.position]))
+	return b.position, nil
+}
+
+// java/nio/channels/FileChannel.transferFrom(Ljava/nio/channels/ReadableByteChannel;JJ)J
+// The source is another FileChannel, which is read from its position, or any object with a
+// read(ByteBuffer) method. Nothing is transferred if position is past the end of the file.
+func fileChannelTransferFrom(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	fc, gerr := channelThis(params[0], "transferFrom")
+	if gerr != nil {
+		return gerr
+	}
+	src, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(src) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "transferFrom: source is null")
+	}
+	position, count := params[2].(int64), params[3].(int64)
+	if gerr := checkTransferArgs(position, count); gerr != nil {
+		return gerr
+	}
+	srcChannel, isFileChannel := src.FieldTable[fileChannelField].Fvalue.(*fileChannel)
+	if isFileChannel {
+		if !srcChannel.isOpen() {
+			return ghelpers.GetGErrBlk(excNames.ClosedChannelException, "")
+		}
+		if !srcChannel.readable {
+			return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+		}
+	}
+	if !fc.writable {
+		return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+	}
+	info, err := fc.file.Stat()
+	if err != nil {
+		return channelIOError(err)
+	}
+	if position > info.Size() {
+		return int64(0)
+	}
+
+	var total int64
+	p := make([]byte, min(count, transferChunk))
+	for total < count {
+		chunk := p[:min(count-total, int64(len(p)))]
+		var n int
+		if isFileChannel {
+			n, err = srcChannel.file.Read(chunk)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return channelIOError(err)
+			}
+		} else {
+			var gerr *ghelpers.GErrBlk
+			if n, gerr = readFromChannel(fs, src, chunk); gerr != nil {
+				return gerr
+			}
+		}
+		if n <= 0 {
+			break
+		}
+		if _, err := fc.writeBytes(chunk[:n], position+total); err != nil {
+			return channelIOError(err)
+		}
+		total += int64(n)
+	}
+	return total
+}
+
+// --- position, size and the file ---
+
+// java/nio/channels/FileChannel.position()J -- the size of the file in append mode
+func fileChannelPosition(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "position")
+	if gerr != nil {
+		return gerr
+	}
+	if fc.appending {
+		return fileChannelSize(params)
+	}
+	pos, err := fc.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return channelIOError(err)
+	}
+	return pos
+}
+
+// java/nio/channels/FileChannel.position(J) -- a position past the end of the file is allowed
+func fileChannelSetPosition(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "position")
+	if gerr != nil {
+		return gerr
+	}
+	pos := params[1].(int64)
+	if pos < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "")
+	}
+	if _, err := fc.file.Seek(pos, io.SeekStart); err != nil {
+		return channelIOError(err)
+	}
+	return params[0]
+}
+
+func fileChannelSize(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "size")
+	if gerr != nil {
+		return gerr
+	}
+	info, err := fc.file.Stat()
+	if err != nil {
+		return channelIOError(err)
+	}
+	return info.Size()
+}
+
+// java/nio/channels/FileChannel.truncate(J) -- a size at or past the end of the file changes
+// nothing but the position, which is moved back to the new size if it was past it.
+func fileChannelTruncate(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "truncate")
+	if gerr != nil {
+		return gerr
+	}
+	size := params[1].(int64)
+	if size < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative size")
+	}
+	if !fc.writable {
+		return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+	}
+	info, err := fc.file.Stat()
+	if err != nil {
+		return channelIOError(err)
+	}
+	if size < info.Size() {
+		if err := fc.file.Truncate(size); err != nil {
+			return channelIOError(err)
+		}
+	}
+	pos, err := fc.file.Seek(0, io.SeekCurrent)
+	if err == nil && pos > size {
+		_, err = fc.file.Seek(size, io.SeekStart)
+	}
+	if err != nil {
+		return channelIOError(err)
+	}
+	return params[0]
+}
+
+// java/nio/channels/FileChannel.force(Z)V -- metadata or not, the file is synced
+func fileChannelForce(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "force")
+	if gerr != nil {
+		return gerr
+	}
+	if err := fc.file.Sync(); err != nil {
+		return channelIOError(err)
+	}
+	return nil
+}
+
+func fileChannelIsOpen(params []interface{}) interface{} {
+	fc, gerr := channelState(params[0], "isOpen")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(fc.isOpen())
+}
+
+// java/nio/channels/FileChannel.close()V -- releases the channel's locks and closes the file,
+// and with it any stream that shares it. Closing a closed channel does nothing.
+func fileChannelClose(params []interface{}) interface{} {
+	fc, gerr := channelState(params[0], "close")
+	if gerr != nil {
+		return gerr
+	}
+	if fc.closed {
+		return nil
+	}
+	fc.closed = true
+	releaseChannelLocks(fc)
+	if err := fc.file.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return channelIOError(err)
+	}
+	if fc.deleteOnClose {
+		_ = os.Remove(fc.path)
+	}
+	return nil
+}
+
+// --- map ---
+
+var mapModeMutex = sync.Mutex{}
+var mapModeClassName = "java/nio/channels/FileChannel$MapMode"
+var mapModesInited bool
+
+func mapModeClinit([]interface{}) interface{} {
+	mapModeMutex.Lock()
+	defer mapModeMutex.Unlock()
+	if mapModesInited {
+		return nil
+	}
+	for _, name := range []string{"READ_ONLY", "READ_WRITE", "PRIVATE"} {
+		obj := object.MakeEmptyObjectWithClassName(&mapModeClassName)
+		obj.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+		_ = statics.AddStatic(mapModeClassName+"."+name, statics.Static{Type: "L" + mapModeClassName + ";", Value: obj})
+	}
+	mapModesInited = true
+	return nil
+}
+
+func mapModeToString(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["name"].Fvalue
+}
+
+// java/nio/channels/FileChannel.map(Ljava/nio/channels/FileChannel$MapMode;JJ)Ljava/nio/MappedByteBuffer;
+// A READ_WRITE or PRIVATE mapping past the end of the file extends the file first.
+func fileChannelMap(params []interface{}) interface{} {
+	fc, gerr := channelThis(params[0], "map")
+	if gerr != nil {
+		return gerr
+	}
+	modeObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(modeObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Mode is null")
+	}
+	mode, _ := openOptionName(modeObj)
+	position, size := params[2].(int64), params[3].(int64)
+	switch {
+	case position < 0:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position")
+	case size < 0:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative size")
+	case position > math.MaxInt64-size:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Position + size overflow")
+	case size > math.MaxInt32:
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Size exceeds Integer.MAX_VALUE")
+	}
+	switch mode {
+	case "READ_ONLY":
+		if !fc.readable {
+			return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+		}
+	case "READ_WRITE", "PRIVATE":
+		if !fc.readable {
+			return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+		}
+		if !fc.writable {
+			return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+		}
+	default:
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "map: unsupported mode "+mode)
+	}
+
+	info, err := fc.file.Stat()
+	if err != nil {
+		return channelIOError(err)
+	}
+	if info.Size() < position+size {
+		if !fc.writable {
+			return ghelpers.GetGErrBlk(excNames.IOException,
+				"Channel not open for writing - cannot extend file to required size")
+		}
+		if err := fc.file.Truncate(position + size); err != nil {
+			return channelIOError(err)
+		}
+	}
+
+	readOnly, shared := mode == "READ_ONLY", mode == "READ_WRITE"
+	if size == 0 {
+		return newBufferObject(newMappedBuffer(nil, 0, 0, shared, readOnly))
+	}
+	pageOffset := position % int64(pageSize)
+	data, err := mmapFile(fc.file, position-pageOffset, int(size+pageOffset), shared, mode == "PRIVATE")
+	if err != nil {
+		return channelIOError(err)
+	}
+	return newBufferObject(newMappedBuffer(data, int(pageOffset), int(size), shared, readOnly))
+}
+
+// --- locks ---
+
+type fileLock struct {
+	channel  *object.Object
+	fc       *fileChannel
+	position int64
+	size     int64
+	shared   bool
+	valid    bool
+}
+
+// lockedFile is a file on which this JVM holds locks. holder is the file whose flock stands for
+// all of them.
+type lockedFile struct {
+	info   os.FileInfo
+	holder *os.File
+	locks  []*fileLock
+}
+
+var fileLocksMutex = sync.Mutex{}
+var lockedFiles []*lockedFile
+
+// findLockedFile returns the entry in lockedFiles for the file described by info, or nil.
+// fileLocksMutex must be held.
+func findLockedFile(info os.FileInfo) *lockedFile {
+	for _, lf := range lockedFiles {
+		if os.SameFile(lf.info, info) {
+			return lf
+		}
+	}
+	return nil
+}
+
+// rangesOverlap reports whether [pos1, pos1+size1) and [pos2, pos2+size2) have a byte in common.
+// The sizes of locks are often Long.MAX_VALUE, so the ends saturate rather than overflow.
+func rangesOverlap(pos1, size1, pos2, size2 int64) bool {
+	end := func(pos, size int64) int64 {
+		if pos > math.MaxInt64-size {
+			return math.MaxInt64
+		}
+		return pos + size
+	}
+	return pos1 < end(pos2, size2) && pos2 < end(pos1, size1)
+}
+
+// fileChannelLockFor returns the G function for lock() and lock(JJZ) if block is true, or for
+// tryLock() and tryLock(JJZ), which return null if another process holds the file's lock.
+func fileChannelLockFor(block bool) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		fc, gerr := channelThis(params[0], "lock")
+		if gerr != nil {
+			return gerr
+		}
+		position, size, shared := int64(0), int64(math.MaxInt64), false
+		if len(params) == 4 {
+			position, size, shared = params[1].(int64), params[2].(int64), params[3].(int64) == types.JavaBoolTrue
+		}
+		switch {
+		case position < 0:
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position")
+		case size < 0:
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative size")
+		case position > math.MaxInt64-size:
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Negative position + size")
+		case shared && !fc.readable:
+			return ghelpers.GetGErrBlk(excNames.NonReadableChannelException, "")
+		case !shared && !fc.writable:
+			return ghelpers.GetGErrBlk(excNames.NonWritableChannelException, "")
+		}
+		info, err := fc.file.Stat()
+		if err != nil {
+			return channelIOError(err)
+		}
+		fl := &fileLock{channel: params[0].(*object.Object), fc: fc, position: position, size: size,
+			shared: shared, valid: true}
+
+		// The first lock on a file takes its flock, outside fileLocksMutex since it can block.
+		fileLocksMutex.Lock()
+		lf := findLockedFile(info)
+		if lf == nil {
+			fileLocksMutex.Unlock()
+			acquired, err := flockFile(fc.file, shared, block)
+			if err != nil {
+				return channelIOError(err)
+			}
+			if !acquired {
+				return object.Null
+			}
+			fileLocksMutex.Lock()
+			if lf = findLockedFile(info); lf == nil {
+				lf = &lockedFile{info: info, holder: fc.file}
+				lockedFiles = append(lockedFiles, lf)
+			}
+		}
+		defer fileLocksMutex.Unlock()
+		for _, held := range lf.locks {
+			if rangesOverlap(held.position, held.size, position, size) {
+				return ghelpers.GetGErrBlk(excNames.OverlappingFileLockException, "")
+			}
+		}
+		lf.locks = append(lf.locks, fl)
+
+		className := fileLockClassName
+		obj := object.MakeEmptyObjectWithClassName(&className)
+		obj.FieldTable[fileLockField] = object.Field{Ftype: types.RawGoPointer, Fvalue: fl}
+		return obj
+	}
+}
+
+// dropLockedFile unlocks a file on which no locks are left and forgets it. fileLocksMutex must
+// be held.
+func dropLockedFile(lf *lockedFile) {
+	_ = funlockFile(lf.holder)
+	for ix, entry := range lockedFiles {
+		if entry == lf {
+			lockedFiles = append(lockedFiles[:ix], lockedFiles[ix+1:]...)
+			break
+		}
+	}
+}
+
+// releaseLock invalidates fl. If it was the last lock on its file, the flock is released; if its
+// channel is closing and held the flock for other channels' locks, another of them takes it.
+// fileLocksMutex must be held.
+func releaseLock(fl *fileLock, closing bool) {
+	if !fl.valid {
+		return
+	}
+	fl.valid = false
+	for _, lf := range lockedFiles {
+		for ix, held := range lf.locks {
+			if held != fl {
+				continue
+			}
+			lf.locks = append(lf.locks[:ix], lf.locks[ix+1:]...)
+			switch {
+			case len(lf.locks) == 0:
+				dropLockedFile(lf)
+			case closing && lf.holder == fl.fc.file:
+				next := lf.locks[0].fc.file
+				if ok, _ := flockFile(next, lf.locks[0].shared, false); ok {
+					lf.holder = next
+				}
+			}
+			return
+		}
+	}
+}
+
+// releaseChannelLocks releases all the locks held through fc, which is being closed.
+func releaseChannelLocks(fc *fileChannel) {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	var held []*fileLock
+	for _, lf := range lockedFiles {
+		for _, fl := range lf.locks {
+			if fl.fc == fc {
+				held = append(held, fl)
+			}
+		}
+	}
+	for _, fl := range held {
+		releaseLock(fl, true)
+	}
+}
+
+// lockThis returns the state of a FileLock object.
+func lockThis(this any) *fileLock {
+	return this.(*object.Object).FieldTable[fileLockField].Fvalue.(*fileLock)
+}
+
+func fileLockChannel(params []interface{}) interface{} {
+	return lockThis(params[0]).channel
+}
+
+func fileLockPosition(params []interface{}) interface{} {
+	return lockThis(params[0]).position
+}
+
+func fileLockSize(params []interface{}) interface{} {
+	return lockThis(params[0]).size
+}
+
+func fileLockIsShared(params []interface{}) interface{} {
+	return types.ConvertGoBoolToJavaBool(lockThis(params[0]).shared)
+}
+
+func fileLockOverlaps(params []interface{}) interface{} {
+	fl := lockThis(params[0])
+	position, size := params[1].(int64), params[2].(int64)
+	if position < 0 || size < 0 {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(rangesOverlap(fl.position, fl.size, position, size))
+}
+
+func fileLockIsValid(params []interface{}) interface{} {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	fl := lockThis(params[0])
+	return types.ConvertGoBoolToJavaBool(fl.valid && fl.fc.isOpen())
+}
+
+// java/nio/channels/FileLock.release()V and close()V -- releasing a released lock does nothing
+func fileLockRelease(params []interface{}) interface{} {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	fl := lockThis(params[0])
+	if !fl.valid {
+		return nil
+	}
+	if !fl.fc.isOpen() {
+		return ghelpers.GetGErrBlk(excNames.ClosedChannelException, "")
+	}
+	releaseLock(fl, false)
+	return nil
+}
+
+// java/nio/channels/FileLock.toString() -- as the JDK's FileLockImpl
+func fileLockToString(params []interface{}) interface{} {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	fl := lockThis(params[0])
+	kind, state := "exclusive", "invalid"
+	if fl.shared {
+		kind = "shared"
+	}
+	if fl.valid && fl.fc.isOpen() {
+		state = "valid"
+	}
+	str := fmt.Sprintf("sun.nio.ch.FileLockImpl[%d:%d %s %s]", fl.position, fl.size, kind, state)
+	return object.StringObjectFromGoString(str)
+}
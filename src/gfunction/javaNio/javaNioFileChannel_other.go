@@ -0,0 +1,34 @@
+//go:build !unix
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import "os"
+
+// Memory-mapped files and file locks are only available on Unix-like systems for now. The
+// channel reports errUnsupportedPlatform as an UnsupportedOperationException.
+
+func flockFile(*os.File, bool, bool) (bool, error) {
+	return false, errUnsupportedPlatform
+}
+
+func funlockFile(*os.File) error {
+	return errUnsupportedPlatform
+}
+
+func mmapFile(*os.File, int64, int, bool, bool) ([]byte, error) {
+	return nil, errUnsupportedPlatform
+}
+
+func msyncMapping([]byte) error {
+	return errUnsupportedPlatform
+}
+
+func munmapFile([]byte) error {
+	return errUnsupportedPlatform
+}
@@ -0,0 +1,306 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+const fcClass = "java/nio/channels/FileChannel."
+const fcType = "Ljava/nio/channels/FileChannel;"
+
+func loadFileChannelForTest() {
+	loadBuffersForTest()
+	Load_Nio_Channels_FileChannel()
+	Load_Nio_MappedByteBuffer()
+}
+
+// openOptions returns an OpenOption[] of StandardOpenOption-like constants with the given names.
+func openOptions(names ...string) *object.Object {
+	arr := object.Make1DimRefArray("Ljava/nio/file/OpenOption;", int64(len(names)))
+	elems := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	className := "java/nio/file/StandardOpenOption"
+	for ix, name := range names {
+		elems[ix] = object.MakeEmptyObjectWithClassName(&className)
+		elems[ix].FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	}
+	return arr
+}
+
+func openChannel(t *testing.T, path string, options ...string) *object.Object {
+	t.Helper()
+	ret := callBuffer(t, fcClass+"open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)"+fcType,
+		newPath(path), openOptions(options...))
+	ch, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("open(%s, %v): got %v", path, options, ret)
+	}
+	return ch
+}
+
+func wrapBytes(t *testing.T, s string) *object.Object {
+	t.Helper()
+	arr := object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoString(s))
+	return callBuffer(t, bbClass+"wrap([B)"+bbType, arr).(*object.Object)
+}
+
+// flippedString returns the bytes of a byte buffer from 0 to its position.
+func flippedString(t *testing.T, buf *object.Object) string {
+	t.Helper()
+	b := buf.FieldTable[nioBufferField].Fvalue.(*nioBuffer)
+	return object.GoStringFromJavaByteArray(b.bytes[b.offset : b.offset+b.position])
+}
+
+func TestFileChannelOpenReadWrite(t *testing.T) {
+	loadFileChannelForTest()
+	path := filepath.Join(t.TempDir(), "data.bin")
+	ch := openChannel(t, path, "CREATE_NEW", "READ", "WRITE")
+	defer callBuffer(t, fcClass+"close()V", ch)
+
+	if n := callBuffer(t, fcClass+"write(Ljava/nio/ByteBuffer;)I", ch, wrapBytes(t, "hello world")); n != int64(11) {
+		t.Fatalf("write: expected 11, got %v", n)
+	}
+	if pos := callBuffer(t, fcClass+"position()J", ch); pos != int64(11) {
+		t.Errorf("position after the write: expected 11, got %v", pos)
+	}
+	if size := callBuffer(t, fcClass+"size()J", ch); size != int64(11) {
+		t.Errorf("size: expected 11, got %v", size)
+	}
+
+	dst := callBuffer(t, bbClass+"allocate(I)"+bbType, int64(5)).(*object.Object)
+	if n := callBuffer(t, fcClass+"read(Ljava/nio/ByteBuffer;J)I", ch, dst, int64(6)); n != int64(5) {
+		t.Fatalf("positional read: expected 5, got %v", n)
+	}
+	if got := flippedString(t, dst); got != "world" {
+		t.Errorf("positional read: expected %q, got %q", "world", got)
+	}
+	if pos := callBuffer(t, fcClass+"position()J", ch); pos != int64(11) {
+		t.Errorf("a positional read should not move the position, got %v", pos)
+	}
+	callBuffer(t, bbClass+"clear()"+bbType, dst)
+	if n := callBuffer(t, fcClass+"read(Ljava/nio/ByteBuffer;)I", ch, dst); n != int64(-1) {
+		t.Errorf("read at the end of the file: expected -1, got %v", n)
+	}
+
+	callBuffer(t, fcClass+"write(Ljava/nio/ByteBuffer;J)I", ch, wrapBytes(t, "W"), int64(6))
+	if data, _ := os.ReadFile(path); string(data) != "hello World" {
+		t.Errorf("positional write: got %q", data)
+	}
+
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)"+fcType,
+		newPath(path), openOptions("CREATE_NEW", "WRITE")),
+		excNames.FileAlreadyExistsException, "")
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)"+fcType,
+		newPath(path+".missing"), openOptions()),
+		excNames.NoSuchFileException, "")
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"open(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)"+fcType,
+		newPath(path), openOptions("READ", "APPEND")),
+		excNames.IllegalArgumentException, "")
+
+	readOnly := openChannel(t, path)
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"write(Ljava/nio/ByteBuffer;)I", readOnly, wrapBytes(t, "x")),
+		excNames.NonWritableChannelException, "")
+	callBuffer(t, fcClass+"close()V", readOnly)
+	if callBuffer(t, fcClass+"isOpen()Z", readOnly) != types.JavaBoolFalse {
+		t.Errorf("isOpen() after close(): expected false")
+	}
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"size()J", readOnly),
+		excNames.ClosedChannelException, "")
+}
+
+func TestFileChannelAppendTruncateAndGather(t *testing.T) {
+	loadFileChannelForTest()
+	path := filepath.Join(t.TempDir(), "log.txt")
+	if err := os.WriteFile(path, []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ch := openChannel(t, path, "APPEND")
+	bufs := object.Make1DimRefArray(bbType, 3)
+	elems := bufs.FieldTable["value"].Fvalue.([]*object.Object)
+	elems[0], elems[1], elems[2] = wrapBytes(t, "ab"), wrapBytes(t, "cd"), wrapBytes(t, "ef")
+	if n := callBuffer(t, fcClass+"write([Ljava/nio/ByteBuffer;II)J", ch, bufs, int64(1), int64(2)); n != int64(4) {
+		t.Errorf("gathering write: expected 4, got %v", n)
+	}
+	if pos := callBuffer(t, fcClass+"position()J", ch); pos != int64(14) {
+		t.Errorf("position in append mode: expected the size 14, got %v", pos)
+	}
+	callBuffer(t, fcClass+"close()V", ch)
+	if data, _ := os.ReadFile(path); string(data) != "0123456789cdef" {
+		t.Errorf("append: got %q", data)
+	}
+
+	ch = openChannel(t, path, "READ", "WRITE")
+	defer callBuffer(t, fcClass+"close()V", ch)
+	callBuffer(t, fcClass+"position(J)"+fcType, ch, int64(12))
+	callBuffer(t, fcClass+"truncate(J)"+fcType, ch, int64(4))
+	if pos := callBuffer(t, fcClass+"position()J", ch); pos != int64(4) {
+		t.Errorf("truncate below the position should move it back: expected 4, got %v", pos)
+	}
+	callBuffer(t, fcClass+"truncate(J)"+fcType, ch, int64(100))
+	if size := callBuffer(t, fcClass+"size()J", ch); size != int64(4) {
+		t.Errorf("truncate past the end should not grow the file: expected 4, got %v", size)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"truncate(J)"+fcType, ch, int64(-1)),
+		excNames.IllegalArgumentException, "")
+
+	callBuffer(t, fcClass+"position(J)"+fcType, ch, int64(0))
+	elems[0] = callBuffer(t, bbClass+"allocate(I)"+bbType, int64(3)).(*object.Object)
+	elems[1] = callBuffer(t, bbClass+"allocate(I)"+bbType, int64(3)).(*object.Object)
+	if n := callBuffer(t, fcClass+"read([Ljava/nio/ByteBuffer;II)J", ch, bufs, int64(0), int64(2)); n != int64(4) {
+		t.Errorf("scattering read: expected 4, got %v", n)
+	}
+	if a, b := flippedString(t, elems[0]), flippedString(t, elems[1]); a != "012" || b != "3" {
+		t.Errorf("scattering read: got %q and %q", a, b)
+	}
+}
+
+func TestFileChannelTransfer(t *testing.T) {
+	loadFileChannelForTest()
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	if err := os.WriteFile(src, []byte("the quick brown fox"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	in := openChannel(t, src)
+	out := openChannel(t, dst, "CREATE", "WRITE")
+	defer callBuffer(t, fcClass+"close()V", in)
+	defer callBuffer(t, fcClass+"close()V", out)
+
+	n := callBuffer(t, fcClass+"transferTo(JJLjava/nio/channels/WritableByteChannel;)J", in, int64(4), int64(100), out)
+	if n != int64(15) {
+		t.Errorf("transferTo: expected 15, got %v", n)
+	}
+	if pos := callBuffer(t, fcClass+"position()J", in); pos != int64(0) {
+		t.Errorf("transferTo should not move the source position, got %v", pos)
+	}
+
+	callBuffer(t, fcClass+"position(J)"+fcType, in, int64(10))
+	n = callBuffer(t, fcClass+"transferFrom(Ljava/nio/channels/ReadableByteChannel;JJ)J", out, in, int64(0), int64(5))
+	if n != int64(5) {
+		t.Errorf("transferFrom: expected 5, got %v", n)
+	}
+	if pos := callBuffer(t, fcClass+"position()J", in); pos != int64(15) {
+		t.Errorf("transferFrom should advance the source position: expected 15, got %v", pos)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "brown brown fox" {
+		t.Errorf("transfers: got %q", data)
+	}
+	n = callBuffer(t, fcClass+"transferFrom(Ljava/nio/channels/ReadableByteChannel;JJ)J", out, in, int64(99), int64(5))
+	if n != int64(0) {
+		t.Errorf("transferFrom past the end of the file: expected 0, got %v", n)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"transferTo(JJLjava/nio/channels/WritableByteChannel;)J", out, int64(0), int64(1), in),
+		excNames.NonWritableChannelException, "")
+}
+
+func TestFileChannelMap(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mapped files are not supported on Windows")
+	}
+	loadFileChannelForTest()
+	mapModeClinit(nil)
+	path := filepath.Join(t.TempDir(), "mapped")
+	if err := os.WriteFile(path, []byte("abcdefgh"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ch := openChannel(t, path, "READ", "WRITE")
+	defer callBuffer(t, fcClass+"close()V", ch)
+	mapSig := fcClass + "map(Ljava/nio/channels/FileChannel$MapMode;JJ)Ljava/nio/MappedByteBuffer;"
+	mode := func(name string) interface{} { return statics.GetStaticValue(mapModeClassName, name) }
+
+	buf := callBuffer(t, mapSig, ch, mode("READ_WRITE"), int64(5), int64(10)).(*object.Object)
+	if className := object.GoStringFromStringPoolIndex(buf.KlassName); className != mappedByteBufferClassName {
+		t.Errorf("map() should return a MappedByteBuffer, got %s", className)
+	}
+	if size := callBuffer(t, fcClass+"size()J", ch); size != int64(15) {
+		t.Errorf("a READ_WRITE mapping past the end should extend the file to 15, got %v", size)
+	}
+	if got := callBuffer(t, bbClass+"get(I)B", buf, int64(1)); got != int64('g') {
+		t.Errorf("get(1) of a mapping at 5: expected 'g', got %v", got)
+	}
+	callBuffer(t, bbClass+"putInt(II)"+bbType, buf, int64(0), int64(0x41424344))
+	callBuffer(t, mappedByteBufferClassName+".force()Ljava/nio/MappedByteBuffer;", buf)
+	if data, _ := os.ReadFile(path); string(data[:9]) != "abcdeABCD" {
+		t.Errorf("a put to a READ_WRITE mapping should reach the file, got %q", data)
+	}
+
+	private := callBuffer(t, mapSig, ch, mode("PRIVATE"), int64(0), int64(4))
+	callBuffer(t, mappedByteBufferClassName+".put(B)"+bbType, private, int64('z'))
+	if data, _ := os.ReadFile(path); data[0] != 'a' {
+		t.Errorf("a put to a PRIVATE mapping should not reach the file, got %q", data)
+	}
+
+	readOnly := callBuffer(t, mapSig, ch, mode("READ_ONLY"), int64(0), int64(4))
+	testutil.ExpectGErr(t, callBuffer(t, bbClass+"put(B)"+bbType, readOnly, int64(1)),
+		excNames.ReadOnlyBufferException, "")
+	str := object.GoStringFromStringObject(callBuffer(t, bbClass+"toString()Ljava/lang/String;", readOnly).(*object.Object))
+	if str != "java.nio.DirectByteBufferR[pos=0 lim=4 cap=4]" {
+		t.Errorf("toString() of a READ_ONLY mapping: got %q", str)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, mapSig, ch, mode("READ_ONLY"), int64(0), int64(-1)),
+		excNames.IllegalArgumentException, "")
+
+	reader := openChannel(t, path)
+	defer callBuffer(t, fcClass+"close()V", reader)
+	testutil.ExpectGErr(t, callBuffer(t, mapSig, reader, mode("READ_WRITE"), int64(0), int64(4)),
+		excNames.NonWritableChannelException, "")
+}
+
+func TestFileChannelLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file locks are not supported on Windows")
+	}
+	loadFileChannelForTest()
+	path := filepath.Join(t.TempDir(), "lockfile")
+	ch := openChannel(t, path, "CREATE", "READ", "WRITE")
+
+	lock := callBuffer(t, fcClass+"lock(JJZ)Ljava/nio/channels/FileLock;", ch, int64(0), int64(10), types.JavaBoolFalse).(*object.Object)
+	str := object.GoStringFromStringObject(callBuffer(t, fileLockClassName+".toString()Ljava/lang/String;", lock).(*object.Object))
+	if str != "sun.nio.ch.FileLockImpl[0:10 exclusive valid]" {
+		t.Errorf("toString(): got %q", str)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, fcClass+"tryLock(JJZ)Ljava/nio/channels/FileLock;", ch, int64(5), int64(10), types.JavaBoolTrue),
+		excNames.OverlappingFileLockException, "")
+	other := callBuffer(t, fcClass+"tryLock(JJZ)Ljava/nio/channels/FileLock;", ch, int64(10), int64(10), types.JavaBoolTrue)
+	if _, ok := other.(*object.Object); !ok || other == object.Null {
+		t.Fatalf("a lock on a disjoint region should be granted, got %v", other)
+	}
+	if callBuffer(t, fileLockClassName+".overlaps(JJ)Z", lock, int64(9), int64(1)) != types.JavaBoolTrue {
+		t.Errorf("overlaps(9, 1) of [0, 10): expected true")
+	}
+
+	// The file's flock is held for the JVM, so another open file description cannot take it.
+	probe, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer probe.Close()
+	if ok, _ := flockFile(probe, true, false); ok {
+		t.Errorf("the file should be flocked while the JVM holds a lock on it")
+	}
+
+	callBuffer(t, fileLockClassName+".release()V", lock)
+	if callBuffer(t, fileLockClassName+".isValid()Z", lock) != types.JavaBoolFalse {
+		t.Errorf("isValid() after release(): expected false")
+	}
+	callBuffer(t, fcClass+"close()V", ch)
+	if callBuffer(t, fileLockClassName+".isValid()Z", other) != types.JavaBoolFalse {
+		t.Errorf("closing the channel should release its locks")
+	}
+	if ok, _ := flockFile(probe, true, false); !ok {
+		t.Errorf("the flock should be released with the last lock")
+	}
+}
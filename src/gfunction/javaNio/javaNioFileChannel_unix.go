@@ -0,0 +1,71 @@
+//go:build unix
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// The system calls behind FileChannel.map() and FileChannel.lock() on Unix-like systems.
+
+// flockFile takes an advisory flock on the whole of f. If block is false and another process
+// holds a conflicting lock, it returns false and no error.
+func flockFile(f *os.File, shared, block bool) (bool, error) {
+	how := unix.LOCK_EX
+	if shared {
+		how = unix.LOCK_SH
+	}
+	if !block {
+		how |= unix.LOCK_NB
+	}
+	for {
+		err := unix.Flock(int(f.Fd()), how)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, unix.EINTR):
+			continue
+		case !block && errors.Is(err, unix.EWOULDBLOCK):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+}
+
+// funlockFile drops the flock on f.
+func funlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
+
+// mmapFile maps length bytes of f from offset, which must be a multiple of the page size. A
+// private mapping is writable but its changes are not written to the file.
+func mmapFile(f *os.File, offset int64, length int, writable, private bool) ([]byte, error) {
+	prot, flags := unix.PROT_READ, unix.MAP_SHARED
+	if writable || private {
+		prot |= unix.PROT_WRITE
+	}
+	if private {
+		flags = unix.MAP_PRIVATE
+	}
+	return unix.Mmap(int(f.Fd()), offset, length, prot, flags)
+}
+
+// msyncMapping writes the changed pages of a shared mapping to its file.
+func msyncMapping(data []byte) error {
+	return unix.Msync(data, unix.MS_SYNC)
+}
+
+// munmapFile removes a mapping made by mmapFile.
+func munmapFile(data []byte) error {
+	return unix.Munmap(data)
+}
@@ -0,0 +1,211 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// java.nio.file.DirectoryStream and Files.mismatch. Files.newDirectoryStream reads the
+// directory when the stream is opened, so the stream holds the entries that passed the filter,
+// each resolved against the directory, in its entries field. Its iterator is a snapshot
+// iterator over those entries, of the kind that the java.util collections return.
+
+const (
+	directoryStreamClassName = "java/nio/file/DirectoryStream"
+	dirStreamEntriesField    = "entries"
+	dirStreamIteratedField   = "iterated"
+	dirStreamClosedField     = "closed"
+)
+
+func Load_Nio_File_DirectoryStream() {
+	ghelpers.MethodSignatures[directoryStreamClassName+".iterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: directoryStreamIterator}
+	ghelpers.MethodSignatures[directoryStreamClassName+".close()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: directoryStreamClose}
+}
+
+// java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;
+func filesNewDirectoryStream(params []interface{}) interface{} {
+	return newDirectoryStream(params[0], func(*object.Object) (bool, interface{}) { return true, nil })
+}
+
+// java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;Ljava/lang/String;)Ljava/nio/file/DirectoryStream;
+// The glob is matched against the file name of each entry.
+func filesNewDirectoryStreamGlob(params []interface{}) interface{} {
+	globObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(globObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Files.newDirectoryStream: glob is null")
+	}
+	expr, gerr := globToRegex(object.GoStringFromStringObject(globObj))
+	if gerr != nil {
+		return gerr
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.PatternSyntaxException, err.Error())
+	}
+	return newDirectoryStream(params[0], func(entry *object.Object) (bool, interface{}) {
+		p, _ := pathToGoString(entry)
+		return regex.MatchString(filepath.Base(p)), nil
+	})
+}
+
+// java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;Ljava/nio/file/DirectoryStream$Filter;)Ljava/nio/file/DirectoryStream;
+// The filter's accept method is called for each entry.
+func filesNewDirectoryStreamFilter(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	filter, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(filter) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Files.newDirectoryStream: filter is null")
+	}
+	return newDirectoryStream(args[0], func(entry *object.Object) (bool, interface{}) {
+		ret := ghelpers.InvokeMethodOnObject(fs, filter, "accept", "(Ljava/lang/Object;)Z", entry)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return false, gerr
+		}
+		return ret == types.JavaBoolTrue, nil
+	})
+}
+
+// newDirectoryStream reads the directory at path and returns a DirectoryStream of the entries
+// that accept admits. If accept returns an error, that error is returned instead.
+func newDirectoryStream(path any, accept func(*object.Object) (bool, interface{})) interface{} {
+	dir, gerr := pathToGoString(path)
+	if gerr != nil {
+		return gerr
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fileAttributeError("Files.newDirectoryStream", dir, err)
+	}
+	if !info.IsDir() {
+		return ghelpers.GetGErrBlk(excNames.NotDirectoryException, dir)
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return fileAttributeError("Files.newDirectoryStream", dir, err)
+	}
+
+	entries := make([]any, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		entry := newPath(filepath.Join(dir, dirEntry.Name()))
+		ok, ret := accept(entry)
+		if ret != nil {
+			return ret
+		}
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	className := directoryStreamClassName
+	stream := object.MakeEmptyObjectWithClassName(&className)
+	stream.FieldTable[dirStreamEntriesField] = object.Field{Ftype: types.RefArray, Fvalue: entries}
+	stream.FieldTable[dirStreamIteratedField] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolFalse}
+	stream.FieldTable[dirStreamClosedField] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolFalse}
+	return stream
+}
+
+// java/nio/file/DirectoryStream.iterator()Ljava/util/Iterator; -- may be called only once
+func directoryStreamIterator(params []interface{}) interface{} {
+	stream := params[0].(*object.Object)
+	if stream.FieldTable[dirStreamClosedField].Fvalue == types.JavaBoolTrue {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "Directory stream is closed")
+	}
+	if stream.FieldTable[dirStreamIteratedField].Fvalue == types.JavaBoolTrue {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "Iterator already obtained")
+	}
+	stream.FieldTable[dirStreamIteratedField] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+
+	// the fields of a java.util snapshot iterator
+	iterClassName := "java/util/Iterator"
+	iter := object.MakeEmptyObjectWithClassName(&iterClassName)
+	iter.FieldTable["collection"] = object.Field{Ftype: types.NonArrayObject, Fvalue: stream}
+	iter.FieldTable["elements"] = object.Field{Ftype: types.RefArray, Fvalue: stream.FieldTable[dirStreamEntriesField].Fvalue}
+	iter.FieldTable["index"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+	iter.FieldTable["lastReturnedIndex"] = object.Field{Ftype: types.Int, Fvalue: int64(-1)}
+	return iter
+}
+
+// java/nio/file/DirectoryStream.close()V -- the entries are no longer returned
+func directoryStreamClose(params []interface{}) interface{} {
+	stream := params[0].(*object.Object)
+	stream.FieldTable[dirStreamClosedField] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	stream.FieldTable[dirStreamEntriesField] = object.Field{Ftype: types.RefArray, Fvalue: []any{}}
+	return nil
+}
+
+// java/nio/file/Files.mismatch(Ljava/nio/file/Path;Ljava/nio/file/Path;)J
+// Returns the position of the first byte that differs, or -1 if the files are the same.
+// If one file is a prefix of the other, the position is the size of the smaller file.
+func filesMismatch(params []interface{}) interface{} {
+	p1, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	p2, gerr := pathToGoString(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	f1, err := os.Open(p1)
+	if err != nil {
+		return fileAttributeError("Files.mismatch", p1, err)
+	}
+	defer f1.Close()
+	f2, err := os.Open(p2)
+	if err != nil {
+		return fileAttributeError("Files.mismatch", p2, err)
+	}
+	defer f2.Close()
+
+	buf1 := make([]byte, 8192)
+	buf2 := make([]byte, 8192)
+	var pos int64
+	for {
+		n1, err1 := io.ReadFull(f1, buf1)
+		n2, err2 := io.ReadFull(f2, buf2)
+		for _, err := range []error{err1, err2} {
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("Files.mismatch: %s", err.Error()))
+			}
+		}
+		n := min(n1, n2)
+		if ix := firstDifference(buf1[:n], buf2[:n]); ix >= 0 {
+			return pos + int64(ix)
+		}
+		if n1 != n2 {
+			return pos + int64(n)
+		}
+		if n1 < len(buf1) {
+			return int64(-1)
+		}
+		pos += int64(n)
+	}
+}
+
+// firstDifference returns the index of the first byte at which a and b differ, or -1.
+func firstDifference(a, b []byte) int {
+	if bytes.Equal(a, b) {
+		return -1
+	}
+	for ix := range a {
+		if a[ix] != b[ix] {
+			return ix
+		}
+	}
+	return -1
+}
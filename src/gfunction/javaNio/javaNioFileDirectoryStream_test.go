@@ -0,0 +1,114 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// streamNames returns the file names of the entries of a DirectoryStream, sorted.
+func streamNames(t *testing.T, stream interface{}) []string {
+	t.Helper()
+	iter, ok := callBuffer(t, directoryStreamClassName+".iterator()Ljava/util/Iterator;", stream).(*object.Object)
+	if !ok {
+		t.Fatalf("iterator: got %v", iter)
+	}
+	var names []string
+	for _, entry := range iter.FieldTable["elements"].Fvalue.([]any) {
+		p, _ := pathToGoString(entry)
+		names = append(names, filepath.Base(p))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func makeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("prep: %v", err)
+		}
+	}
+}
+
+func TestFiles_NewDirectoryStream(t *testing.T) {
+	globals.InitGlobals("test")
+	Load_Nio_File_Files()
+	Load_Nio_File_DirectoryStream()
+	dir := t.TempDir()
+	makeFiles(t, dir, "a.txt", "b.txt", "c.md")
+
+	all := callBuffer(t, "java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;", newPath(dir))
+	if names := streamNames(t, all); len(names) != 3 {
+		t.Errorf("all entries: got %v", names)
+	}
+	testutil.ExpectGErr(t, callBuffer(t, directoryStreamClassName+".iterator()Ljava/util/Iterator;", all),
+		excNames.IllegalStateException, "")
+
+	txt := callBuffer(t, "java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;Ljava/lang/String;)Ljava/nio/file/DirectoryStream;",
+		newPath(dir), object.StringObjectFromGoString("*.{txt,csv}"))
+	if names := streamNames(t, txt); len(names) != 2 || names[0] != "a.txt" || names[1] != "b.txt" {
+		t.Errorf("*.{txt,csv}: got %v", names)
+	}
+
+	closed := callBuffer(t, "java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;", newPath(dir))
+	callBuffer(t, directoryStreamClassName+".close()V", closed)
+	testutil.ExpectGErr(t, callBuffer(t, directoryStreamClassName+".iterator()Ljava/util/Iterator;", closed),
+		excNames.IllegalStateException, "")
+
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;",
+		newPath(filepath.Join(dir, "a.txt"))),
+		excNames.NotDirectoryException, "")
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;",
+		newPath(filepath.Join(dir, "nope"))),
+		excNames.NoSuchFileException, "")
+}
+
+func TestFiles_Mismatch(t *testing.T) {
+	globals.InitGlobals("test")
+	Load_Nio_File_Files()
+	dir := t.TempDir()
+	write := func(name string, data []byte) *object.Object {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatalf("prep: %v", err)
+		}
+		return newPath(p)
+	}
+	big := make([]byte, 20000)
+	for ix := range big {
+		big[ix] = byte(ix)
+	}
+	changed := append([]byte(nil), big...)
+	changed[12345] ^= 0xFF
+
+	tests := []struct {
+		name string
+		a, b []byte
+		want int64
+	}{
+		{"same", big, big, -1},
+		{"changed", big, changed, 12345},
+		{"prefix", big[:9000], big, 9000},
+		{"empty", nil, []byte("x"), 0},
+		{"both empty", nil, nil, -1},
+	}
+	for _, tt := range tests {
+		ret := callBuffer(t, "java/nio/file/Files.mismatch(Ljava/nio/file/Path;Ljava/nio/file/Path;)J",
+			write(tt.name+"-a", tt.a), write(tt.name+"-b", tt.b))
+		if ret != tt.want {
+			t.Errorf("%s: got %v, want %d", tt.name, ret, tt.want)
+		}
+	}
+}
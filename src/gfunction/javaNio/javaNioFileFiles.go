@@ -79,13 +79,25 @@ func Load_Nio_File_Files() {
 
 	// find
 	ghelpers.MethodSignatures["java/nio/file/Files.find(Ljava/nio/file/Path;ILjava/util/function/BiPredicate;[Ljava/nio/file/FileVisitOption;)Ljava/util/stream/Stream;"] =
-		ghelpers.GMeth{ParamSlots: 4, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 4, GFunction: filesStreamUnsupported}
 
 	// getAttribute / getFileAttributeView
 	ghelpers.MethodSignatures["java/nio/file/Files.getAttribute(Ljava/nio/file/Path;Ljava/lang/String;[Ljava/nio/file/LinkOption;)Ljava/lang/Object;"] =
-		ghelpers.GMeth{ParamSlots: 3, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 3, GFunction: filesGetAttribute, NeedsContext: true}
 	ghelpers.MethodSignatures["java/nio/file/Files.getFileAttributeView(Ljava/nio/file/Path;Ljava/lang/Class;[Ljava/nio/file/LinkOption;)Ljava/nio/file/attribute/FileAttributeView;"] =
-		ghelpers.GMeth{ParamSlots: 3, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 3, GFunction: filesGetFileAttributeView}
+
+	// getOwner / setOwner
+	ghelpers.MethodSignatures["java/nio/file/Files.getOwner(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)Ljava/nio/file/attribute/UserPrincipal;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesGetOwner}
+	ghelpers.MethodSignatures["java/nio/file/Files.setOwner(Ljava/nio/file/Path;Ljava/nio/file/attribute/UserPrincipal;)Ljava/nio/file/Path;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesSetOwner}
+
+	// getPosixFilePermissions / setPosixFilePermissions
+	ghelpers.MethodSignatures["java/nio/file/Files.getPosixFilePermissions(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesGetPosixFilePermissions, NeedsContext: true}
+	ghelpers.MethodSignatures["java/nio/file/Files.setPosixFilePermissions(Ljava/nio/file/Path;Ljava/util/Set;)Ljava/nio/file/Path;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesSetPosixFilePermissions, NeedsContext: true}
 
 	// isDirectory / isRegularFile / isSameFile / isSymbolicLink
 	ghelpers.MethodSignatures["java/nio/file/Files.isDirectory(Ljava/nio/file/Path;[Ljava/nio/file/LinkOption;)Z"] =
@@ -99,17 +111,17 @@ func Load_Nio_File_Files() {
 
 	// lines
 	ghelpers.MethodSignatures["java/nio/file/Files.lines(Ljava/nio/file/Path;)Ljava/util/stream/Stream;"] =
-		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 1, GFunction: filesStreamUnsupported}
 	ghelpers.MethodSignatures["java/nio/file/Files.lines(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/util/stream/Stream;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesStreamUnsupported}
 
 	// mismatch
 	ghelpers.MethodSignatures["java/nio/file/Files.mismatch(Ljava/nio/file/Path;Ljava/nio/file/Path;)J"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesMismatch}
 
 	// list
 	ghelpers.MethodSignatures["java/nio/file/Files.list(Ljava/nio/file/Path;)Ljava/util/stream/Stream;"] =
-		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 1, GFunction: filesStreamUnsupported}
 
 	// move
 	ghelpers.MethodSignatures["java/nio/file/Files.move(Ljava/nio/file/Path;Ljava/nio/file/Path;[Ljava/nio/file/CopyOption;)Ljava/nio/file/Path;"] =
@@ -117,11 +129,11 @@ func Load_Nio_File_Files() {
 
 	// newDirectoryStream
 	ghelpers.MethodSignatures["java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;)Ljava/nio/file/DirectoryStream;"] =
-		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 1, GFunction: filesNewDirectoryStream}
 	ghelpers.MethodSignatures["java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;Ljava/lang/String;)Ljava/nio/file/DirectoryStream;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesNewDirectoryStreamGlob}
 	ghelpers.MethodSignatures["java/nio/file/Files.newDirectoryStream(Ljava/nio/file/Path;Ljava/nio/file/DirectoryStream$Filter;)Ljava/nio/file/DirectoryStream;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesNewDirectoryStreamFilter, NeedsContext: true}
 
 	// newInputStream / newOutputStream
 	ghelpers.MethodSignatures["java/nio/file/Files.newInputStream(Ljava/nio/file/Path;[Ljava/nio/file/OpenOption;)Ljava/io/InputStream;"] =
@@ -135,11 +147,15 @@ func Load_Nio_File_Files() {
 	ghelpers.MethodSignatures["java/nio/file/Files.readAllLines(Ljava/nio/file/Path;)Ljava/util/List;"] =
 		ghelpers.GMeth{ParamSlots: 1, GFunction: filesReadAllLines}
 	ghelpers.MethodSignatures["java/nio/file/Files.readAllLines(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/util/List;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesReadAllLines}
 	ghelpers.MethodSignatures["java/nio/file/Files.readString(Ljava/nio/file/Path;)Ljava/lang/String;"] =
 		ghelpers.GMeth{ParamSlots: 1, GFunction: filesReadString}
 	ghelpers.MethodSignatures["java/nio/file/Files.readString(Ljava/nio/file/Path;Ljava/nio/charset/Charset;)Ljava/lang/String;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: filesReadString}
+
+	// readAttributes
+	ghelpers.MethodSignatures["java/nio/file/Files.readAttributes(Ljava/nio/file/Path;Ljava/lang/Class;[Ljava/nio/file/LinkOption;)Ljava/nio/file/attribute/BasicFileAttributes;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: filesReadAttributes}
 
 	// readSymbolicLink
 	ghelpers.MethodSignatures["java/nio/file/Files.readSymbolicLink(Ljava/nio/file/Path;)Ljava/nio/file/Path;"] =
@@ -165,7 +181,7 @@ func Load_Nio_File_Files() {
 	ghelpers.MethodSignatures["java/nio/file/Files.writeString(Ljava/nio/file/Path;Ljava/lang/CharSequence;[Ljava/nio/file/OpenOption;)Ljava/nio/file/Path;"] =
 		ghelpers.GMeth{ParamSlots: 3, GFunction: filesWriteString}
 	ghelpers.MethodSignatures["java/nio/file/Files.writeString(Ljava/nio/file/Path;Ljava/lang/CharSequence;Ljava/nio/charset/Charset;[Ljava/nio/file/OpenOption;)Ljava/nio/file/Path;"] =
-		ghelpers.GMeth{ParamSlots: 4, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 4, GFunction: filesWriteString}
 }
 
 // --- Helpers ---
@@ -183,6 +199,67 @@ func pathToGoString(p interface{}) (string, *ghelpers.GErrBlk) {
 	return object.GoStringFromStringObject(sval), nil
 }
 
+// collectionElements returns the elements of an array or a java.util.Collection. A collection
+// is read with its toArray() method, which may be Java code run on the frame stack fs.
+func collectionElements(fs *list.List, coll any) ([]*object.Object, *ghelpers.GErrBlk) {
+	obj, ok := coll.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "collection is null")
+	}
+	if _, isArray := obj.FieldTable["value"].Fvalue.([]*object.Object); !isArray {
+		ret := ghelpers.InvokeMethodOnObject(fs, obj, "toArray", "()[Ljava/lang/Object;")
+		switch r := ret.(type) {
+		case *ghelpers.GErrBlk:
+			return nil, r
+		case *object.Object:
+			obj = r
+		}
+	}
+	elems, _ := obj.FieldTable["value"].Fvalue.([]*object.Object)
+	return elems, nil
+}
+
+// newHashSet returns a java.util.HashSet that holds elements.
+func newHashSet(fs *list.List, elements []*object.Object) interface{} {
+	return newCollection(fs, "java/util/HashSet", elements)
+}
+
+// newArrayList returns a java.util.ArrayList that holds elements.
+func newArrayList(fs *list.List, elements []*object.Object) interface{} {
+	return newCollection(fs, "java/util/ArrayList", elements)
+}
+
+// newCollection returns a collection of the class given that holds elements. The collection is
+// made by the G functions of its class, which are in javaUtil, a package that imports this one.
+// They run on the frame stack fs, as adding an element can call its hashCode() and equals().
+func newCollection(fs *list.List, className string, elements []*object.Object) interface{} {
+	coll := object.MakeEmptyObjectWithClassName(&className)
+	if ret := ghelpers.InvokeMethodOnObject(fs, coll, "<init>", "()V"); ret != nil {
+		return ret
+	}
+	for _, elem := range elements {
+		ret := ghelpers.InvokeMethodOnObject(fs, coll, "add", "(Ljava/lang/Object;)Z", elem)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return coll
+}
+
+// statPath returns the file info of p. If the LinkOption[] options holds NOFOLLOW_LINKS, a
+// symbolic link is not followed.
+func statPath(p string, options any) (fs.FileInfo, error) {
+	if arr, ok := options.(*object.Object); ok && !object.IsNull(arr) {
+		elems, _ := arr.FieldTable["value"].Fvalue.([]*object.Object)
+		for _, elem := range elems {
+			if name, _ := openOptionName(elem); name == "NOFOLLOW_LINKS" {
+				return os.Lstat(p)
+			}
+		}
+	}
+	return os.Stat(p)
+}
+
 func boolToJava(b bool) types.JavaBool {
 	if b {
 		return types.JavaBoolTrue
@@ -303,7 +380,11 @@ func filesCreateFile(params []interface{}) interface{} {
 	if gerr != nil {
 		return gerr
 	}
-	f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o666)
+	mode, gerr := createMode(params[1], 0o666)
+	if gerr != nil {
+		return gerr
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_RDWR, mode)
 	if err != nil {
 		return ghelpers.GetGErrBlk(excNames.IOException,
 			fmt.Sprintf("Files.createFile: %s", err.Error()))
@@ -317,7 +398,11 @@ func filesCreateDirectory(params []interface{}) interface{} {
 	if gerr != nil {
 		return gerr
 	}
-	if err := os.Mkdir(p, 0o777); err != nil {
+	mode, gerr := createMode(params[1], 0o777)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.Mkdir(p, mode); err != nil {
 		return ghelpers.GetGErrBlk(excNames.IOException,
 			fmt.Sprintf("Files.createDirectory: %s", err.Error()))
 	}
@@ -329,7 +414,11 @@ func filesCreateDirectories(params []interface{}) interface{} {
 	if gerr != nil {
 		return gerr
 	}
-	if err := os.MkdirAll(p, 0o777); err != nil {
+	mode, gerr := createMode(params[1], 0o777)
+	if gerr != nil {
+		return gerr
+	}
+	if err := os.MkdirAll(p, mode); err != nil {
 		return ghelpers.GetGErrBlk(excNames.IOException,
 			fmt.Sprintf("Files.createDirectories: %s", err.Error()))
 	}
@@ -470,19 +559,41 @@ func filesWriteBytes(params []interface{}) interface{} {
 	return newPath(p)
 }
 
-func filesReadString(params []interface{}) interface{} {
+// filesReadFileText reads a file for readString and readAllLines. With a Charset argument,
+// the contents are decoded in that charset, and malformed or unmappable input is reported.
+func filesReadFileText(params []interface{}, caller string) ([]byte, *ghelpers.GErrBlk) {
 	p, gerr := pathToGoString(params[0])
 	if gerr != nil {
-		return gerr
+		return nil, gerr
 	}
 	data, err := os.ReadFile(p)
 	if err != nil {
-		return ghelpers.GetGErrBlk(excNames.IOException,
-			fmt.Sprintf("Files.readString: %s", err.Error()))
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: %s", caller, err.Error()))
+	}
+	if len(params) < 2 {
+		return data, nil
+	}
+	cs, gerr := ghelpers.CharsetFromObject(params[1])
+	if gerr != nil {
+		return nil, gerr
+	}
+	text, cerr := cs.Decode(data, ghelpers.CodingReport, ghelpers.CodingReport, "")
+	if cerr != nil {
+		return nil, cerr.GErrBlk()
+	}
+	return []byte(text), nil
+}
+
+func filesReadString(params []interface{}) interface{} {
+	data, gerr := filesReadFileText(params, "Files.readString")
+	if gerr != nil {
+		return gerr
 	}
 	return object.StringObjectFromGoString(string(data))
 }
 
+// filesWriteString writes the text as UTF-8, or in the charset given, in which case unmappable
+// characters are reported.
 func filesWriteString(params []interface{}) interface{} {
 	p, gerr := pathToGoString(params[0])
 	if gerr != nil {
@@ -491,7 +602,19 @@ func filesWriteString(params []interface{}) interface{} {
 	// CharSequence is a java.lang.String object in our use
 	sObj := params[1].(*object.Object)
 	text := object.GoStringFromStringObject(sObj)
-	if err := os.WriteFile(p, []byte(text), 0o666); err != nil {
+	data := []byte(text)
+	if len(params) > 3 {
+		cs, gerr := ghelpers.CharsetFromObject(params[2])
+		if gerr != nil {
+			return gerr
+		}
+		var cerr *ghelpers.CodingError
+		data, cerr = cs.Encode(text, ghelpers.CodingReport, ghelpers.CodingReport, nil)
+		if cerr != nil {
+			return cerr.GErrBlk()
+		}
+	}
+	if err := os.WriteFile(p, data, 0o666); err != nil {
 		return ghelpers.GetGErrBlk(excNames.IOException,
 			fmt.Sprintf("Files.writeString: %s", err.Error()))
 	}
@@ -499,14 +622,10 @@ func filesWriteString(params []interface{}) interface{} {
 }
 
 func filesReadAllLines(params []interface{}) interface{} {
-	p, gerr := pathToGoString(params[0])
+	data, gerr := filesReadFileText(params, "Files.readAllLines")
 	if gerr != nil {
 		return gerr
 	}
-	data, err := os.ReadFile(p)
-	if err != nil {
-		return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("Files.readAllLines: %s", err.Error()))
-	}
 	// Split on \n, drop trailing \r if present (CRLF)
 	var lines []string
 	start := 0
@@ -644,6 +763,13 @@ func filesWalk([]interface{}) interface{} {
 		"Files.walk is not yet supported (requires java.util.stream.Stream)")
 }
 
+// Files.lines, list and find return a Stream, as walk does. Use readAllLines or
+// newDirectoryStream instead.
+func filesStreamUnsupported([]interface{}) interface{} {
+	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+		"Files.lines, list and find are not yet supported (require java.util.stream.Stream)")
+}
+
 func invokeVisitor(fs *list.List, visitor *object.Object, methodName, methodType string, params []interface{}) interface{} {
 	className := "java/nio/file/FileVisitor"
 	fullSignature := className + "." + methodName + methodType
@@ -0,0 +1,17 @@
+//go:build !unix
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import "io/fs"
+
+// File owners are only known on Unix-like systems for now.
+
+func fileOwnerIDs(fs.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
@@ -102,11 +102,7 @@ func Load_Nio_File_Path() {
 	ghelpers.MethodSignatures["java/nio/file/Path.toUri()Ljava/net/URI;"] =
 		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
 
-	// leave register functions ghelpers.Trapped
-	ghelpers.MethodSignatures["java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;[Ljava/nio/file/WatchEvent$Modifier;)Ljava/nio/file/WatchKey;"] =
-		ghelpers.GMeth{ParamSlots: 3, GFunction: ghelpers.TrapFunction}
-	ghelpers.MethodSignatures["java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;)Ljava/nio/file/WatchKey;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+	// Path.register is in javaNioFileWatchService.go
 }
 
 // ---- GFunction implementation attempt
@@ -0,0 +1,239 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// java.nio.file.FileSystems, FileSystem and PathMatcher. Only the default file system exists;
+// its paths are the Path objects of javaNioFilePath.go. A PathMatcher holds the compiled
+// regular expression of its glob or regex pattern in its matcherRegexField field.
+
+const (
+	fileSystemClassName  = "java/nio/file/FileSystem"
+	pathMatcherClassName = "java/nio/file/PathMatcher"
+	matcherRegexField    = "regex"
+)
+
+func Load_Nio_File_FileSystems() {
+	ghelpers.MethodSignatures["java/nio/file/FileSystems.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	ghelpers.MethodSignatures["java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: fileSystemsGetDefault}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                      {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"close()V":                         {ParamSlots: 0, GFunction: fileSystemClose},
+		"getSeparator()Ljava/lang/String;": {ParamSlots: 0, GFunction: fileSystemGetSeparator},
+		"isOpen()Z":                        {ParamSlots: 0, GFunction: ghelpers.ReturnTrue},
+		"isReadOnly()Z":                    {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+		"supportedFileAttributeViews()Ljava/util/Set;": {ParamSlots: 0, GFunction: fileSystemSupportedViews,
+			NeedsContext: true},
+		"getPath(Ljava/lang/String;[Ljava/lang/String;)Ljava/nio/file/Path;": {ParamSlots: 2,
+			GFunction: fileSystemGetPath},
+		"getPathMatcher(Ljava/lang/String;)Ljava/nio/file/PathMatcher;": {ParamSlots: 1,
+			GFunction: fileSystemGetPathMatcher},
+	} {
+		ghelpers.MethodSignatures[fileSystemClassName+"."+sig] = gmeth
+	}
+
+	ghelpers.MethodSignatures["java/nio/file/PathMatcher.matches(Ljava/nio/file/Path;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: pathMatcherMatches}
+}
+
+var defaultFileSystemOnce sync.Once
+var defaultFileSystem *object.Object
+
+// java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;
+func fileSystemsGetDefault([]interface{}) interface{} {
+	defaultFileSystemOnce.Do(func() {
+		className := fileSystemClassName
+		defaultFileSystem = object.MakeEmptyObjectWithClassName(&className)
+		defaultFileSystem.FieldTable["provider"] = object.Field{Ftype: types.FileSystemProviderType,
+			Fvalue: types.FileSystemProviderValue}
+	})
+	return defaultFileSystem
+}
+
+// java/nio/file/FileSystem.close()V -- the default file system cannot be closed
+func fileSystemClose([]interface{}) interface{} {
+	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "FileSystem.close: the default file system cannot be closed")
+}
+
+func fileSystemGetSeparator([]interface{}) interface{} {
+	return object.StringObjectFromGoString(getSep())
+}
+
+// java/nio/file/FileSystem.getPath(Ljava/lang/String;[Ljava/lang/String;) is Paths.get()
+func fileSystemGetPath(params []interface{}) interface{} {
+	return pathsGet(params[1:])
+}
+
+// java/nio/file/FileSystem.supportedFileAttributeViews()Ljava/util/Set;
+func fileSystemSupportedViews(params []interface{}) interface{} {
+	fs, _ := ghelpers.SplitContext(params)
+	names := []string{"basic"}
+	if !globals.OnWindows {
+		names = append(names, "posix", "unix", "owner")
+	}
+	views := make([]*object.Object, len(names))
+	for ix, name := range names {
+		views[ix] = object.StringObjectFromGoString(name)
+	}
+	return newHashSet(fs, views)
+}
+
+// java/nio/file/FileSystem.getPathMatcher(Ljava/lang/String;)Ljava/nio/file/PathMatcher;
+// The argument is "glob:pattern" or "regex:pattern".
+func fileSystemGetPathMatcher(params []interface{}) interface{} {
+	argObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(argObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "FileSystem.getPathMatcher: syntaxAndPattern is null")
+	}
+	syntaxAndPattern := object.GoStringFromStringObject(argObj)
+	syntax, pattern, found := strings.Cut(syntaxAndPattern, ":")
+	if !found || syntax == "" {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "FileSystem.getPathMatcher: "+syntaxAndPattern)
+	}
+
+	var expr string
+	switch strings.ToLower(syntax) {
+	case "glob":
+		var gerr *ghelpers.GErrBlk
+		if expr, gerr = globToRegex(pattern); gerr != nil {
+			return gerr
+		}
+	case "regex":
+		expr = "^(?:" + pattern + ")$"
+	default:
+		errMsg := fmt.Sprintf("FileSystem.getPathMatcher: Syntax '%s' not recognized", syntax)
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, errMsg)
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.PatternSyntaxException, err.Error())
+	}
+	return newPathMatcher(regex)
+}
+
+func newPathMatcher(regex *regexp.Regexp) *object.Object {
+	className := pathMatcherClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[matcherRegexField] = object.Field{Ftype: types.RawGoPointer, Fvalue: regex}
+	return obj
+}
+
+// java/nio/file/PathMatcher.matches(Ljava/nio/file/Path;)Z -- the whole path string must match
+func pathMatcherMatches(params []interface{}) interface{} {
+	regex, ok := params[0].(*object.Object).FieldTable[matcherRegexField].Fvalue.(*regexp.Regexp)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "PathMatcher.matches: not a PathMatcher")
+	}
+	p, gerr := pathToGoString(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(regex.MatchString(p))
+}
+
+// globToRegex converts a glob to a regular expression, following the rules of
+// FileSystem.getPathMatcher: * matches within a name, ** across names, ? one character,
+// [...] a character class (negated by !), {a,b} a group of alternatives, and \ escapes.
+func globToRegex(glob string) (string, *ghelpers.GErrBlk) {
+	notSep := "[^/]"
+	if globals.OnWindows {
+		notSep = `[^\\/]`
+	}
+	syntaxError := func(desc string, index int) (string, *ghelpers.GErrBlk) {
+		errMsg := fmt.Sprintf("%s near index %d\n%s", desc, index, glob)
+		return "", ghelpers.GetGErrBlk(excNames.PatternSyntaxException, errMsg)
+	}
+
+	chars := []rune(glob)
+	var sb strings.Builder
+	sb.WriteString("^")
+	inGroup := false
+	for ix := 0; ix < len(chars); ix++ {
+		ch := chars[ix]
+		switch ch {
+		case '\\':
+			if ix+1 == len(chars) {
+				return syntaxError("No character to escape", ix)
+			}
+			ix++
+			sb.WriteString(regexp.QuoteMeta(string(chars[ix])))
+		case '/':
+			sb.WriteRune(ch)
+		case '[':
+			end := ix + 1
+			for end < len(chars) && chars[end] != ']' {
+				end++
+			}
+			if end == len(chars) {
+				return syntaxError("Missing ']'", ix)
+			}
+			class := chars[ix+1 : end]
+			sb.WriteByte('[')
+			if len(class) > 0 && class[0] == '!' {
+				sb.WriteString("^/") // a negated class never matches the separator
+				class = class[1:]
+			}
+			for _, c := range class {
+				if c == '\\' || c == '[' || c == '^' {
+					sb.WriteByte('\\')
+				}
+				sb.WriteRune(c)
+			}
+			sb.WriteByte(']')
+			ix = end
+		case '{':
+			if inGroup {
+				return syntaxError("Cannot nest groups", ix)
+			}
+			sb.WriteString("(?:(?:")
+			inGroup = true
+		case '}':
+			if inGroup {
+				sb.WriteString("))")
+				inGroup = false
+			} else {
+				sb.WriteString(`\}`)
+			}
+		case ',':
+			if inGroup {
+				sb.WriteString(")|(?:")
+			} else {
+				sb.WriteRune(ch)
+			}
+		case '*':
+			if ix+1 < len(chars) && chars[ix+1] == '*' {
+				sb.WriteString(".*")
+				ix++
+			} else {
+				sb.WriteString(notSep + "*")
+			}
+		case '?':
+			sb.WriteString(notSep)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	if inGroup {
+		return syntaxError("Missing '}'", len(chars)-1)
+	}
+	sb.WriteString("$")
+	return sb.String(), nil
+}
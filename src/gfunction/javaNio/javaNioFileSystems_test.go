@@ -0,0 +1,117 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"regexp"
+	"testing"
+)
+
+const fsClass = "java/nio/file/FileSystem."
+
+func pathMatcher(t *testing.T, syntaxAndPattern string) interface{} {
+	t.Helper()
+	fileSys := callBuffer(t, "java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;")
+	return callBuffer(t, fsClass+"getPathMatcher(Ljava/lang/String;)Ljava/nio/file/PathMatcher;",
+		fileSys, object.StringObjectFromGoString(syntaxAndPattern))
+}
+
+func TestGlobToRegex(t *testing.T) {
+	globals.InitGlobals("test")
+	if globals.OnWindows {
+		t.Skip("the separator classes differ on Windows")
+	}
+	tests := []struct {
+		glob    string
+		matches []string
+		misses  []string
+	}{
+		{"*.java", []string{"Foo.java", ".java"}, []string{"dir/Foo.java", "Foo.javac"}},
+		{"**/*.java", []string{"a/b/Foo.java", "a/Foo.java"}, []string{"Foo.java"}},
+		{"file?.txt", []string{"file1.txt"}, []string{"file.txt", "file/.txt"}},
+		{"[abc]*", []string{"apple", "cat"}, []string{"dog"}},
+		{"[!a-c]x", []string{"dx"}, []string{"ax", "/x"}},
+		{"*.{java,class}", []string{"A.java", "A.class"}, []string{"A.jar"}},
+		{`a\*b`, []string{"a*b"}, []string{"aXb"}},
+		{"a+b(c)", []string{"a+b(c)"}, []string{"aab(c)"}},
+	}
+	for _, tt := range tests {
+		expr, gerr := globToRegex(tt.glob)
+		if gerr != nil {
+			t.Errorf("globToRegex(%q): %s", tt.glob, gerr.ErrMsg)
+			continue
+		}
+		regex := regexp.MustCompile(expr)
+		for _, s := range tt.matches {
+			if !regex.MatchString(s) {
+				t.Errorf("glob %q (%s) should match %q", tt.glob, expr, s)
+			}
+		}
+		for _, s := range tt.misses {
+			if regex.MatchString(s) {
+				t.Errorf("glob %q (%s) should not match %q", tt.glob, expr, s)
+			}
+		}
+	}
+
+	for _, bad := range []string{"{a,{b}}", "{a,b", "[ab", `a\`} {
+		if _, gerr := globToRegex(bad); gerr == nil || gerr.ExceptionType != excNames.PatternSyntaxException {
+			t.Errorf("globToRegex(%q): expected a PatternSyntaxException, got %v", bad, gerr)
+		}
+	}
+}
+
+func TestPathMatcher(t *testing.T) {
+	globals.InitGlobals("test")
+	Load_Nio_File_FileSystems()
+	matchesSig := "java/nio/file/PathMatcher.matches(Ljava/nio/file/Path;)Z"
+
+	glob := pathMatcher(t, "glob:*.txt")
+	if ret := callBuffer(t, matchesSig, glob, newPath("notes.txt")); ret != types.JavaBoolTrue {
+		t.Errorf("glob:*.txt should match notes.txt, got %v", ret)
+	}
+	if ret := callBuffer(t, matchesSig, glob, newPath("notes.md")); ret != types.JavaBoolFalse {
+		t.Errorf("glob:*.txt should not match notes.md, got %v", ret)
+	}
+
+	regex := pathMatcher(t, "regex:[a-z]+\\.md")
+	if ret := callBuffer(t, matchesSig, regex, newPath("notes.md")); ret != types.JavaBoolTrue {
+		t.Errorf("regex should match notes.md, got %v", ret)
+	}
+	if ret := callBuffer(t, matchesSig, regex, newPath("x/notes.md")); ret != types.JavaBoolFalse {
+		t.Errorf("regex should match the whole path, got %v", ret)
+	}
+
+	testutil.ExpectGErr(t, pathMatcher(t, "*.txt"), excNames.IllegalArgumentException, "")
+	testutil.ExpectGErr(t, pathMatcher(t, "xpath:*.txt"), excNames.UnsupportedOperationException, "")
+	testutil.ExpectGErr(t, pathMatcher(t, "regex:a("), excNames.PatternSyntaxException, "")
+}
+
+func TestFileSystem_GetPathAndSeparator(t *testing.T) {
+	globals.InitGlobals("test")
+	Load_Nio_File_FileSystems()
+	fileSys := callBuffer(t, "java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;")
+	if again := callBuffer(t, "java/nio/file/FileSystems.getDefault()Ljava/nio/file/FileSystem;"); again != fileSys {
+		t.Errorf("getDefault should return the same FileSystem")
+	}
+	sep := callBuffer(t, fsClass+"getSeparator()Ljava/lang/String;", fileSys)
+	if object.GoStringFromStringObject(sep.(*object.Object)) != getSep() {
+		t.Errorf("getSeparator: got %v", sep)
+	}
+	more := object.Make1DimRefArray("Ljava/lang/String;", 1)
+	more.FieldTable["value"].Fvalue.([]*object.Object)[0] = object.StringObjectFromGoString("b")
+	p := callBuffer(t, fsClass+"getPath(Ljava/lang/String;[Ljava/lang/String;)Ljava/nio/file/Path;",
+		fileSys, object.StringObjectFromGoString("a"), more)
+	if s, _ := pathToGoString(p); s != "a"+getSep()+"b" {
+		t.Errorf("getPath: got %q", s)
+	}
+}
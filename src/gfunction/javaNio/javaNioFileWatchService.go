@@ -0,0 +1,579 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// java.nio.file.WatchService, WatchKey, WatchEvent and StandardWatchEventKinds.
+//
+// A WatchService object holds a *watchService, which receives the events of the operating
+// system (inotify on Linux; see javaNioFileWatchService_linux.go) on a goroutine of its own.
+// Each registered directory has a *watchKey, held by its WatchKey object. When an event arrives
+// for a key that is ready, the key is signalled and queued, and take() and poll() return the
+// keys in the order they were queued. As in the JDK, a signalled key collects further events
+// but is not queued again until it is reset.
+
+const (
+	watchServiceClassName = "java/nio/file/WatchService"
+	watchKeyClassName     = "java/nio/file/WatchKey"
+	watchEventClassName   = "java/nio/file/WatchEvent"
+	watchKindClassName    = "java/nio/file/WatchEvent$Kind"
+	watchKindType         = "Ljava/nio/file/WatchEvent$Kind;"
+	watchServiceField     = "service"
+	watchKeyField         = "key"
+)
+
+// The names of the StandardWatchEventKinds.
+const (
+	kindCreate   = "ENTRY_CREATE"
+	kindDelete   = "ENTRY_DELETE"
+	kindModify   = "ENTRY_MODIFY"
+	kindOverflow = "OVERFLOW"
+)
+
+func Load_Nio_File_WatchService() {
+	ghelpers.MethodSignatures["java/nio/file/StandardWatchEventKinds.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: standardWatchEventKindsClinit}
+	ghelpers.MethodSignatures["java/nio/file/FileSystem.newWatchService()Ljava/nio/file/WatchService;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: fileSystemNewWatchService}
+
+	ghelpers.MethodSignatures["java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;)Ljava/nio/file/WatchKey;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: pathRegister}
+	ghelpers.MethodSignatures["java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;[Ljava/nio/file/WatchEvent$Modifier;)Ljava/nio/file/WatchKey;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: pathRegister}
+
+	ghelpers.MethodSignatures[watchServiceClassName+".close()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchServiceClose}
+	ghelpers.MethodSignatures[watchServiceClassName+".poll()Ljava/nio/file/WatchKey;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchServicePoll}
+	ghelpers.MethodSignatures[watchServiceClassName+".poll(JLjava/util/concurrent/TimeUnit;)Ljava/nio/file/WatchKey;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: watchServicePollTimeout, NeedsContext: true}
+	ghelpers.MethodSignatures[watchServiceClassName+".take()Ljava/nio/file/WatchKey;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchServiceTake, NeedsContext: true}
+
+	ghelpers.MethodSignatures[watchKeyClassName+".cancel()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKeyCancel}
+	ghelpers.MethodSignatures[watchKeyClassName+".isValid()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKeyIsValid}
+	ghelpers.MethodSignatures[watchKeyClassName+".pollEvents()Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKeyPollEvents, NeedsContext: true}
+	ghelpers.MethodSignatures[watchKeyClassName+".reset()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKeyReset}
+	ghelpers.MethodSignatures[watchKeyClassName+".watchable()Ljava/nio/file/Watchable;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKeyWatchable}
+
+	ghelpers.MethodSignatures[watchEventClassName+".context()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchEventContext}
+	ghelpers.MethodSignatures[watchEventClassName+".count()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchEventCount}
+	ghelpers.MethodSignatures[watchEventClassName+".kind()Ljava/nio/file/WatchEvent$Kind;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchEventKind}
+
+	ghelpers.MethodSignatures[watchKindClassName+".name()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKindName}
+	ghelpers.MethodSignatures[watchKindClassName+".toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKindName}
+	ghelpers.MethodSignatures[watchKindClassName+".type()Ljava/lang/Class;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: watchKindTypeOf}
+}
+
+// --- StandardWatchEventKinds ---
+
+var watchKindsOnce sync.Once
+var watchKinds map[string]*object.Object
+
+// watchKind returns the WatchEvent.Kind constant of the name given.
+func watchKind(name string) *object.Object {
+	watchKindsOnce.Do(func() {
+		watchKinds = make(map[string]*object.Object)
+		for _, kindName := range []string{kindCreate, kindDelete, kindModify, kindOverflow} {
+			className := watchKindClassName
+			kind := object.MakeEmptyObjectWithClassName(&className)
+			kind.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(kindName)}
+			watchKinds[kindName] = kind
+			_ = statics.AddStatic("java/nio/file/StandardWatchEventKinds."+kindName,
+				statics.Static{Type: watchKindType, Value: kind})
+		}
+	})
+	return watchKinds[name]
+}
+
+func standardWatchEventKindsClinit([]interface{}) interface{} {
+	watchKind(kindOverflow)
+	return nil
+}
+
+func watchKindName(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["name"].Fvalue
+}
+
+// java/nio/file/WatchEvent$Kind.type()Ljava/lang/Class; -- Path for the entry kinds, Object for OVERFLOW
+func watchKindTypeOf(params []interface{}) interface{} {
+	name := object.GoStringFromStringObject(params[0].(*object.Object).FieldTable["name"].Fvalue.(*object.Object))
+	if name == kindOverflow {
+		return classloader.MakeJlcObject("java/lang/Object")
+	}
+	return classloader.MakeJlcObject("java/nio/file/Path")
+}
+
+// --- the watch service ---
+
+type watchService struct {
+	mu     sync.Mutex
+	keys   map[int32]*watchKey // by watch descriptor
+	queue  []*watchKey         // the signalled keys, in the order they were signalled
+	wake   chan struct{}       // has a value when a waiting take() or poll() should look again
+	closed bool
+	fd     int      // the inotify descriptor, on Linux
+	file   *os.File // and the same, for reading
+	obj    *object.Object
+}
+
+type watchKey struct {
+	service   *watchService
+	wd        int32
+	dir       *object.Object // the Path that was registered
+	kinds     map[string]bool
+	events    []watchEvent
+	signalled bool
+	valid     bool
+	obj       *object.Object
+}
+
+type watchEvent struct {
+	kind    string
+	context string // the file name, relative to the directory; empty for OVERFLOW
+	count   int64
+}
+
+// The most events a key holds. Beyond it, the events are replaced by an OVERFLOW event.
+const maxWatchEvents = 512
+
+// notify wakes a thread that waits in take() or poll(), if there is one. The caller holds ws.mu.
+func (ws *watchService) notify() {
+	select {
+	case ws.wake <- struct{}{}:
+	default:
+	}
+}
+
+// signal queues the key if it is ready. The caller holds ws.mu.
+func (k *watchKey) signal() {
+	if !k.signalled {
+		k.signalled = true
+		k.service.queue = append(k.service.queue, k)
+		k.service.notify()
+	}
+}
+
+// addEvent records an event for the key and signals it. An event of the same kind and context
+// as the last one is counted as a repeat of it. The caller holds ws.mu.
+func (k *watchKey) addEvent(kind, context string) {
+	if kind != kindOverflow && !k.kinds[kind] {
+		return
+	}
+	if n := len(k.events); n > 0 {
+		last := &k.events[n-1]
+		if last.kind == kind && last.context == context {
+			last.count++
+			return
+		}
+		if last.kind == kindOverflow {
+			last.count++
+			return
+		}
+		if n >= maxWatchEvents {
+			k.events = []watchEvent{{kind: kindOverflow, count: int64(n + 1)}}
+			k.signal()
+			return
+		}
+	}
+	k.events = append(k.events, watchEvent{kind: kind, context: context, count: 1})
+	k.signal()
+}
+
+// dispatch hands an event from the operating system to its key. It is called by the goroutine
+// that reads the events.
+func (ws *watchService) dispatch(wd int32, kind, name string) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if key := ws.keys[wd]; key != nil {
+		key.addEvent(kind, name)
+	}
+}
+
+// overflow records that events were lost, for every key.
+func (ws *watchService) overflow() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, key := range ws.keys {
+		key.addEvent(kindOverflow, "")
+	}
+}
+
+// invalidate cancels the key of a directory that has gone, and signals it so that the
+// program learns of it.
+func (ws *watchService) invalidate(wd int32) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if key := ws.keys[wd]; key != nil {
+		key.valid = false
+		delete(ws.keys, wd)
+		key.signal()
+	}
+}
+
+// --- FileSystem.newWatchService and Path.register ---
+
+// java/nio/file/FileSystem.newWatchService()Ljava/nio/file/WatchService;
+func fileSystemNewWatchService([]interface{}) interface{} {
+	ws := &watchService{keys: make(map[int32]*watchKey), wake: make(chan struct{}, 1)}
+	if err := openWatcher(ws); err != nil {
+		return watchError("FileSystem.newWatchService", err)
+	}
+	className := watchServiceClassName
+	ws.obj = object.MakeEmptyObjectWithClassName(&className)
+	ws.obj.FieldTable[watchServiceField] = object.Field{Ftype: types.RawGoPointer, Fvalue: ws}
+	return ws.obj
+}
+
+// watchError returns the exception for an error from the operating system's watch facility.
+func watchError(caller string, err error) *ghelpers.GErrBlk {
+	if err == errWatchUnsupported {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, caller+": "+err.Error())
+	}
+	return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: %s", caller, err.Error()))
+}
+
+func watchServiceThis(arg any) (*watchService, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "watch service is null")
+	}
+	ws, ok := obj.FieldTable[watchServiceField].Fvalue.(*watchService)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a watch service of the default file system")
+	}
+	return ws, nil
+}
+
+// java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;[Ljava/nio/file/WatchEvent$Modifier;)
+// and the form without modifiers. Modifiers, such as the JDK's sensitivity modifiers, are ignored.
+// Registering a directory again with the same service returns the same key, watching the new kinds.
+func pathRegister(params []interface{}) interface{} {
+	dir, gerr := pathToGoString(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ws, gerr := watchServiceThis(params[1])
+	if gerr != nil {
+		return gerr
+	}
+	kindObjs, gerr := collectionElements(nil, params[2])
+	if gerr != nil {
+		return gerr
+	}
+	kinds := make(map[string]bool)
+	for _, kindObj := range kindObjs {
+		if object.IsNull(kindObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Path.register: an event kind is null")
+		}
+		nameObj, ok := kindObj.FieldTable["name"].Fvalue.(*object.Object)
+		name := ""
+		if ok {
+			name = object.GoStringFromStringObject(nameObj)
+		}
+		switch name {
+		case kindCreate, kindDelete, kindModify:
+			kinds[name] = true
+		case kindOverflow: // always delivered
+		default:
+			return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "Path.register: unsupported event kind "+name)
+		}
+	}
+	if len(kinds) == 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Path.register: no events to register")
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fileAttributeError("Path.register", dir, err)
+	}
+	if !info.IsDir() {
+		return ghelpers.GetGErrBlk(excNames.NotDirectoryException, dir)
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.closed {
+		return ghelpers.GetGErrBlk(excNames.ClosedWatchServiceException, "Path.register: the watch service is closed")
+	}
+	wd, err := ws.addWatch(dir, kinds)
+	if err != nil {
+		return fileAttributeError("Path.register", dir, err)
+	}
+	if key := ws.keys[wd]; key != nil {
+		key.kinds = kinds
+		return key.obj
+	}
+	key := &watchKey{service: ws, wd: wd, dir: params[0].(*object.Object), kinds: kinds, valid: true}
+	className := watchKeyClassName
+	key.obj = object.MakeEmptyObjectWithClassName(&className)
+	key.obj.FieldTable[watchKeyField] = object.Field{Ftype: types.RawGoPointer, Fvalue: key}
+	ws.keys[wd] = key
+	return key.obj
+}
+
+// --- WatchService ---
+
+// java/nio/file/WatchService.close()V -- cancels every key and wakes the threads that wait
+func watchServiceClose(params []interface{}) interface{} {
+	ws, gerr := watchServiceThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.closed {
+		return nil
+	}
+	ws.closed = true
+	for wd, key := range ws.keys {
+		key.valid = false
+		delete(ws.keys, wd)
+	}
+	ws.queue = nil
+	ws.notify()
+	if err := ws.closeWatcher(); err != nil {
+		return watchError("WatchService.close", err)
+	}
+	return nil
+}
+
+// nextKey removes the first signalled key from the queue, or returns nil if there is none.
+func (ws *watchService) nextKey() (*object.Object, *ghelpers.GErrBlk) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.closed {
+		ws.notify() // pass the news on to any other waiting thread
+		return nil, ghelpers.GetGErrBlk(excNames.ClosedWatchServiceException, "the watch service is closed")
+	}
+	if len(ws.queue) == 0 {
+		return nil, nil
+	}
+	key := ws.queue[0]
+	ws.queue = ws.queue[1:]
+	if len(ws.queue) > 0 {
+		ws.notify()
+	}
+	return key.obj, nil
+}
+
+// waitForKey waits until a key is signalled, the timeout passes (a negative timeout waits for
+// ever), or the thread that runs on fs is interrupted.
+func (ws *watchService) waitForKey(fs *list.List, timeout time.Duration) interface{} {
+	deadline := time.Now().Add(timeout)
+	for {
+		key, gerr := ws.nextKey()
+		if gerr != nil {
+			return gerr
+		}
+		if key != nil {
+			return key
+		}
+		wait := time.Duration(-1)
+		if timeout >= 0 {
+			if wait = time.Until(deadline); wait <= 0 {
+				return object.Null
+			}
+		}
+		if _, gerr := ghelpers.AwaitInterruptibly(fs, ws.wake, wait); gerr != nil {
+			return gerr
+		}
+	}
+}
+
+// java/nio/file/WatchService.poll()Ljava/nio/file/WatchKey; -- does not wait
+func watchServicePoll(params []interface{}) interface{} {
+	ws, gerr := watchServiceThis(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	key, gerr := ws.nextKey()
+	if gerr != nil {
+		return gerr
+	}
+	if key == nil {
+		return object.Null
+	}
+	return key
+}
+
+// java/nio/file/WatchService.poll(JLjava/util/concurrent/TimeUnit;)Ljava/nio/file/WatchKey;
+func watchServicePollTimeout(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	ws, gerr := watchServiceThis(args[0])
+	if gerr != nil {
+		return gerr
+	}
+	timeout, gerr := timeUnitDuration(args[1].(int64), args[2])
+	if gerr != nil {
+		return gerr
+	}
+	return ws.waitForKey(fs, max(timeout, 0))
+}
+
+// java/nio/file/WatchService.take()Ljava/nio/file/WatchKey; -- waits for ever
+func watchServiceTake(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	ws, gerr := watchServiceThis(args[0])
+	if gerr != nil {
+		return gerr
+	}
+	return ws.waitForKey(fs, -1)
+}
+
+// The length of each java.util.concurrent.TimeUnit.
+var timeUnits = map[string]time.Duration{
+	"NANOSECONDS":  time.Nanosecond,
+	"MICROSECONDS": time.Microsecond,
+	"MILLISECONDS": time.Millisecond,
+	"SECONDS":      time.Second,
+	"MINUTES":      time.Minute,
+	"HOURS":        time.Hour,
+	"DAYS":         24 * time.Hour,
+}
+
+// timeUnitDuration returns amount of the TimeUnit unit as a time.Duration. As in
+// TimeUnit.toNanos, an amount that overflows saturates at Long.MAX_VALUE or Long.MIN_VALUE.
+func timeUnitDuration(amount int64, unit any) (time.Duration, *ghelpers.GErrBlk) {
+	unitObj, ok := unit.(*object.Object)
+	if !ok || object.IsNull(unitObj) {
+		return 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "TimeUnit is null")
+	}
+	name := ""
+	if nameObj, ok := unitObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+		name = object.GoStringFromStringObject(nameObj)
+	} else if object.IsStringObject(unitObj) {
+		name = object.GoStringFromStringObject(unitObj)
+	}
+	length, ok := timeUnits[name]
+	if !ok {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "not a TimeUnit: "+name)
+	}
+	limit := int64(math.MaxInt64 / length)
+	switch {
+	case amount > limit:
+		return math.MaxInt64, nil
+	case amount < -limit:
+		return math.MinInt64, nil
+	}
+	return time.Duration(amount) * length, nil
+}
+
+// --- WatchKey ---
+
+func watchKeyThis(arg any) *watchKey {
+	return arg.(*object.Object).FieldTable[watchKeyField].Fvalue.(*watchKey)
+}
+
+// java/nio/file/WatchKey.cancel()V
+func watchKeyCancel(params []interface{}) interface{} {
+	key := watchKeyThis(params[0])
+	ws := key.service
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if !key.valid {
+		return nil
+	}
+	key.valid = false
+	delete(ws.keys, key.wd)
+	ws.removeWatch(key.wd)
+	return nil
+}
+
+func watchKeyIsValid(params []interface{}) interface{} {
+	key := watchKeyThis(params[0])
+	key.service.mu.Lock()
+	defer key.service.mu.Unlock()
+	return types.ConvertGoBoolToJavaBool(key.valid)
+}
+
+// java/nio/file/WatchKey.pollEvents()Ljava/util/List; -- removes and returns the pending events
+func watchKeyPollEvents(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	key := watchKeyThis(args[0])
+	key.service.mu.Lock()
+	events := key.events
+	key.events = nil
+	key.service.mu.Unlock()
+
+	eventObjs := make([]*object.Object, len(events))
+	for ix, event := range events {
+		className := watchEventClassName
+		obj := object.MakeEmptyObjectWithClassName(&className)
+		obj.FieldTable["kind"] = object.Field{Ftype: watchKindType, Fvalue: watchKind(event.kind)}
+		obj.FieldTable["count"] = object.Field{Ftype: types.Int, Fvalue: event.count}
+		var context interface{} = object.Null
+		if event.kind != kindOverflow {
+			context = newPath(event.context)
+		}
+		obj.FieldTable["context"] = object.Field{Ftype: types.Ref, Fvalue: context}
+		eventObjs[ix] = obj
+	}
+	return newArrayList(fs, eventObjs)
+}
+
+// java/nio/file/WatchKey.reset()Z
+// A key with pending events is queued again at once; otherwise it is ready to be signalled.
+// Returns whether the key is still valid.
+func watchKeyReset(params []interface{}) interface{} {
+	key := watchKeyThis(params[0])
+	key.service.mu.Lock()
+	defer key.service.mu.Unlock()
+	if !key.valid {
+		return types.JavaBoolFalse
+	}
+	if key.signalled {
+		key.signalled = false
+		if len(key.events) > 0 {
+			key.signal()
+		}
+	}
+	return types.JavaBoolTrue
+}
+
+func watchKeyWatchable(params []interface{}) interface{} {
+	return watchKeyThis(params[0]).dir
+}
+
+// --- WatchEvent ---
+
+func watchEventContext(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["context"].Fvalue
+}
+
+func watchEventCount(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["count"].Fvalue
+}
+
+func watchEventKind(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable["kind"].Fvalue
+}
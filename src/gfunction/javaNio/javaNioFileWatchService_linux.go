@@ -0,0 +1,96 @@
+//go:build linux
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// The inotify side of the watch service. The inotify descriptor is non-blocking, so that
+// os.File reads it through the runtime poller and closing it ends the goroutine that reads it.
+// (os.File.Fd would make it blocking again, which is why the descriptor is kept as well.)
+
+var errWatchUnsupported = errors.New("the watch service is not supported on this platform")
+
+// The inotify events that make up each kind of watch event.
+var inotifyMasks = map[string]uint32{
+	kindCreate: syscall.IN_CREATE | syscall.IN_MOVED_TO,
+	kindDelete: syscall.IN_DELETE | syscall.IN_MOVED_FROM,
+	kindModify: syscall.IN_MODIFY | syscall.IN_ATTRIB,
+}
+
+func openWatcher(ws *watchService) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return os.NewSyscallError("inotify_init1", err)
+	}
+	ws.fd = fd
+	ws.file = os.NewFile(uintptr(fd), "inotify")
+	go readInotifyEvents(ws, ws.file)
+	return nil
+}
+
+// addWatch watches dir for the kinds of event given and returns its watch descriptor.
+// The caller holds ws.mu.
+func (ws *watchService) addWatch(dir string, kinds map[string]bool) (int32, error) {
+	mask := uint32(syscall.IN_ONLYDIR)
+	for kind := range kinds {
+		mask |= inotifyMasks[kind]
+	}
+	wd, err := syscall.InotifyAddWatch(ws.fd, dir, mask)
+	if err != nil {
+		return 0, &os.PathError{Op: "inotify_add_watch", Path: dir, Err: err}
+	}
+	return int32(wd), nil
+}
+
+// removeWatch stops watching a directory. The caller holds ws.mu.
+func (ws *watchService) removeWatch(wd int32) {
+	_, _ = syscall.InotifyRmWatch(ws.fd, uint32(wd))
+}
+
+// closeWatcher closes the inotify descriptor. The caller holds ws.mu.
+func (ws *watchService) closeWatcher() error {
+	return ws.file.Close()
+}
+
+// readInotifyEvents hands the events read from f to the watch service until f is closed.
+func readInotifyEvents(ws *watchService, f *os.File) {
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(event.Len)]
+			name := string(bytes.TrimRight(nameBytes, "\x00"))
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+			mask := event.Mask
+			switch {
+			case mask&syscall.IN_Q_OVERFLOW != 0:
+				ws.overflow()
+			case mask&syscall.IN_IGNORED != 0:
+				ws.invalidate(event.Wd)
+			default:
+				for kind, kindMask := range inotifyMasks {
+					if mask&kindMask != 0 {
+						ws.dispatch(event.Wd, kind, name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import "errors"
+
+// The watch service is built on inotify, so for now it is only available on Linux.
+
+var errWatchUnsupported = errors.New("the watch service is only supported on Linux")
+
+func openWatcher(*watchService) error {
+	return errWatchUnsupported
+}
+
+func (ws *watchService) addWatch(string, map[string]bool) (int32, error) {
+	return 0, errWatchUnsupported
+}
+
+func (ws *watchService) removeWatch(int32) {}
+
+func (ws *watchService) closeWatcher() error {
+	return nil
+}
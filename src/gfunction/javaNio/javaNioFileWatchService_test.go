@@ -0,0 +1,307 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+const wsClass = "java/nio/file/WatchService."
+const wkClass = "java/nio/file/WatchKey."
+
+func loadWatchServiceForTest(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("the watch service uses inotify")
+	}
+	globals.InitGlobals("test")
+	Load_Nio_File_WatchService()
+	loadCollectionStandIns()
+	standardWatchEventKindsClinit(nil)
+}
+
+func newWatchService(t *testing.T) *object.Object {
+	t.Helper()
+	ws, ok := callBuffer(t, "java/nio/file/FileSystem.newWatchService()Ljava/nio/file/WatchService;", object.Null).(*object.Object)
+	if !ok {
+		t.Fatalf("newWatchService: got %v", ws)
+	}
+	t.Cleanup(func() { watchServiceClose([]interface{}{ws}) })
+	return ws
+}
+
+// kinds returns a WatchEvent.Kind[] of the kinds named.
+func kinds(names ...string) *object.Object {
+	arr := object.Make1DimRefArray(watchKindType, int64(len(names)))
+	for ix, name := range names {
+		arr.FieldTable["value"].Fvalue.([]*object.Object)[ix] = watchKind(name)
+	}
+	return arr
+}
+
+func register(t *testing.T, dir string, ws *object.Object, names ...string) *object.Object {
+	t.Helper()
+	key, ok := callBuffer(t, "java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;)Ljava/nio/file/WatchKey;",
+		newPath(dir), ws, kinds(names...)).(*object.Object)
+	if !ok {
+		t.Fatalf("register: got %v", key)
+	}
+	return key
+}
+
+func timeUnit(name string) *object.Object {
+	className := "java/util/concurrent/TimeUnit"
+	unit := object.MakeEmptyObjectWithClassName(&className)
+	unit.FieldTable["name"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	return unit
+}
+
+func pollFor(t *testing.T, ws *object.Object, seconds int64) interface{} {
+	t.Helper()
+	return callBuffer(t, wsClass+"poll(JLjava/util/concurrent/TimeUnit;)Ljava/nio/file/WatchKey;",
+		list.New(), ws, seconds, timeUnit("SECONDS"))
+}
+
+// eventStrings returns the events of a key as kind:context strings.
+func eventStrings(t *testing.T, key *object.Object) []string {
+	t.Helper()
+	events := callBuffer(t, wkClass+"pollEvents()Ljava/util/List;", key).(*object.Object)
+	var out []string
+	for _, event := range events.FieldTable["elems"].Fvalue.([]*object.Object) {
+		kind := object.GoStringFromStringObject(watchEventKind([]interface{}{event}).(*object.Object).FieldTable["name"].Fvalue.(*object.Object))
+		context, _ := pathToGoString(watchEventContext([]interface{}{event}))
+		out = append(out, kind+":"+context)
+	}
+	return out
+}
+
+// waitForEvents waits until the key holds n events, so that a test does not race the goroutine
+// that reads them.
+func waitForEvents(t *testing.T, keyObj *object.Object, n int) {
+	t.Helper()
+	key := keyObj.FieldTable[watchKeyField].Fvalue.(*watchKey)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		key.service.mu.Lock()
+		count := len(key.events)
+		key.service.mu.Unlock()
+		if count >= n {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %d events", n)
+}
+
+func TestWatchService_CreateModifyDelete(t *testing.T) {
+	loadWatchServiceForTest(t)
+	dir := t.TempDir()
+	ws := newWatchService(t)
+	key := register(t, dir, ws, kindCreate, kindModify, kindDelete)
+
+	if ret := callBuffer(t, wsClass+"poll()Ljava/nio/file/WatchKey;", ws); ret != object.Null {
+		t.Fatalf("poll with no events: got %v", ret)
+	}
+
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatalf("prep: %v", err)
+	}
+	if got := callBuffer(t, wsClass+"take()Ljava/nio/file/WatchKey;", list.New(), ws); got != key {
+		t.Fatalf("take: got %v", got)
+	}
+	events := eventStrings(t, key)
+	if len(events) == 0 || events[0] != "ENTRY_CREATE:a.txt" {
+		t.Errorf("events after a create: got %v", events)
+	}
+	if ret := callBuffer(t, wkClass+"reset()Z", key); ret != types.JavaBoolTrue {
+		t.Errorf("reset: got %v", ret)
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := pollFor(t, ws, 5); got != key {
+		t.Fatalf("poll after a delete: got %v", got)
+	}
+	if events := eventStrings(t, key); events[len(events)-1] != "ENTRY_DELETE:a.txt" {
+		t.Errorf("events after a delete: got %v", events)
+	}
+	if watchKeyWatchable([]interface{}{key}) != key.FieldTable[watchKeyField].Fvalue.(*watchKey).dir {
+		t.Errorf("watchable should be the registered path")
+	}
+}
+
+func TestWatchService_SignalledKeyIsQueuedOnce(t *testing.T) {
+	loadWatchServiceForTest(t)
+	dir := t.TempDir()
+	ws := newWatchService(t)
+	key := register(t, dir, ws, kindCreate)
+
+	makeFiles(t, dir, "a", "b")
+	if got := pollFor(t, ws, 5); got != key {
+		t.Fatalf("poll: got %v", got)
+	}
+	makeFiles(t, dir, "c")
+	waitForEvents(t, key, 3)
+	if got := callBuffer(t, wsClass+"poll()Ljava/nio/file/WatchKey;", ws); got != object.Null {
+		t.Errorf("a signalled key should not be queued again before reset, got %v", got)
+	}
+	// the events that arrived meanwhile queue the key again on reset
+	callBuffer(t, wkClass+"reset()Z", key)
+	if got := pollFor(t, ws, 5); got != key {
+		t.Errorf("poll after reset: got %v", got)
+	}
+	if events := eventStrings(t, key); len(events) != 3 {
+		t.Errorf("events: got %v", events)
+	}
+}
+
+func TestWatchService_CancelAndClose(t *testing.T) {
+	loadWatchServiceForTest(t)
+	dir := t.TempDir()
+	ws := newWatchService(t)
+	key := register(t, dir, ws, kindCreate)
+
+	callBuffer(t, wkClass+"cancel()V", key)
+	if ret := callBuffer(t, wkClass+"isValid()Z", key); ret != types.JavaBoolFalse {
+		t.Errorf("isValid after cancel: got %v", ret)
+	}
+	if ret := callBuffer(t, wkClass+"reset()Z", key); ret != types.JavaBoolFalse {
+		t.Errorf("reset after cancel: got %v", ret)
+	}
+
+	done := make(chan interface{})
+	go func() {
+		done <- callBuffer(t, wsClass+"take()Ljava/nio/file/WatchKey;", list.New(), ws)
+	}()
+	callBuffer(t, wsClass+"close()V", ws)
+	if ret := <-done; ret == nil {
+		t.Errorf("take on a closed service: got nil")
+	} else {
+		testutil.ExpectGErr(t, ret, excNames.ClosedWatchServiceException, "")
+	}
+	testutil.ExpectGErr(t, callBuffer(t, "java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;)Ljava/nio/file/WatchKey;",
+		newPath(dir), ws, kinds(kindCreate)),
+		excNames.ClosedWatchServiceException, "")
+}
+
+func TestWatchService_DeletedDirectoryInvalidatesKey(t *testing.T) {
+	loadWatchServiceForTest(t)
+	dir := filepath.Join(t.TempDir(), "gone")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("prep: %v", err)
+	}
+	ws := newWatchService(t)
+	key := register(t, dir, ws, kindDelete)
+	if err := os.Remove(dir); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := pollFor(t, ws, 5); got != key {
+		t.Fatalf("poll: got %v", got)
+	}
+	if ret := callBuffer(t, wkClass+"reset()Z", key); ret != types.JavaBoolFalse {
+		t.Errorf("reset of a key whose directory is gone: got %v", ret)
+	}
+}
+
+func TestPathRegister_Errors(t *testing.T) {
+	loadWatchServiceForTest(t)
+	dir := t.TempDir()
+	makeFiles(t, dir, "file")
+	ws := newWatchService(t)
+	registerSig := "java/nio/file/Path.register(Ljava/nio/file/WatchService;[Ljava/nio/file/WatchEvent$Kind;)Ljava/nio/file/WatchKey;"
+
+	testutil.ExpectGErr(t, callBuffer(t, registerSig, newPath(dir), ws, kinds()), excNames.IllegalArgumentException, "")
+	testutil.ExpectGErr(t, callBuffer(t, registerSig, newPath(dir), ws, kinds(kindOverflow)),
+		excNames.IllegalArgumentException, "")
+	testutil.ExpectGErr(t, callBuffer(t, registerSig, newPath(filepath.Join(dir, "file")), ws, kinds(kindCreate)),
+		excNames.NotDirectoryException, "")
+	testutil.ExpectGErr(t, callBuffer(t, registerSig, newPath(filepath.Join(dir, "nope")), ws, kinds(kindCreate)),
+		excNames.NoSuchFileException, "")
+
+	first := register(t, dir, ws, kindCreate)
+	if again := register(t, dir, ws, kindDelete); again != first {
+		t.Errorf("registering a directory again should return the same key")
+	}
+}
+
+func TestWatchService_PollTimesOut(t *testing.T) {
+	loadWatchServiceForTest(t)
+	ws := newWatchService(t)
+	register(t, t.TempDir(), ws, kindCreate)
+	ret := callBuffer(t, wsClass+"poll(JLjava/util/concurrent/TimeUnit;)Ljava/nio/file/WatchKey;",
+		list.New(), ws, int64(30), timeUnit("MILLISECONDS"))
+	if ret != object.Null {
+		t.Errorf("poll with a timeout and no events: got %v", ret)
+	}
+	if _, gerr := timeUnitDuration(1, timeUnit("FORTNIGHTS")); gerr == nil {
+		t.Errorf("an unknown TimeUnit should be an error")
+	}
+}
+
+// A timeout too long for a time.Duration waits as long as it can, as in the JDK, rather than
+// wrapping around to a negative timeout that returns at once.
+func TestWatchService_PollWithHugeTimeout(t *testing.T) {
+	loadWatchServiceForTest(t)
+	if d, _ := timeUnitDuration(math.MaxInt64, timeUnit("SECONDS")); d != math.MaxInt64 {
+		t.Errorf("Long.MAX_VALUE seconds: got %d", d)
+	}
+	if d, _ := timeUnitDuration(-math.MaxInt64, timeUnit("DAYS")); d != math.MinInt64 {
+		t.Errorf("-Long.MAX_VALUE days: got %d", d)
+	}
+
+	dir := t.TempDir()
+	ws := newWatchService(t)
+	key := register(t, dir, ws, kindCreate)
+	done := make(chan interface{})
+	go func() {
+		done <- pollFor(t, ws, math.MaxInt64)
+	}()
+	select {
+	case ret := <-done:
+		t.Fatalf("poll(Long.MAX_VALUE, SECONDS) returned at once: %v", ret)
+	case <-time.After(100 * time.Millisecond):
+	}
+	makeFiles(t, dir, "a")
+	if got := <-done; got != key {
+		t.Errorf("poll after an event: got %v", got)
+	}
+}
+
+// The interpreter pops ParamSlots arguments for a G function, and the long timeout
+// of poll(long, TimeUnit) takes a single slot.
+func TestWatchService_PollThroughMethodTable(t *testing.T) {
+	loadWatchServiceForTest(t)
+	gm := ghelpers.MethodSignatures[wsClass+"poll(JLjava/util/concurrent/TimeUnit;)Ljava/nio/file/WatchKey;"]
+	if gm.ParamSlots != 2 || !gm.NeedsContext {
+		t.Fatalf("poll(long, TimeUnit): want 2 slots and a context, got %d slots, context %v",
+			gm.ParamSlots, gm.NeedsContext)
+	}
+
+	ws := newWatchService(t)
+	dir := t.TempDir()
+	key := register(t, dir, ws, kindCreate)
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	args := []interface{}{int64(5), timeUnit("SECONDS")}
+	ret := gm.GFunction(append([]interface{}{list.New(), ws}, args[:gm.ParamSlots]...))
+	if ret != key {
+		t.Errorf("poll(5, SECONDS) after a create: got %v", ret)
+	}
+}
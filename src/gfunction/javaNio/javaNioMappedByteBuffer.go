@@ -0,0 +1,119 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaNio
+
+import (
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/types"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// MappedByteBuffer is a direct ByteBuffer whose storage is a region of a file mapped into memory
+// by FileChannel.map(). The buffer's bytes are the mapped memory, so a put is a write to the file
+// (or, for a PRIVATE mapping, to a private copy of it). The mapping is removed when no buffer that
+// uses it is reachable any more.
+
+const mappedByteBufferClassName = "java/nio/MappedByteBuffer"
+
+// mappedRegion is a mapping made by mmapFile, shared by a mapped buffer and the buffers derived from it.
+type mappedRegion struct {
+	data   []byte
+	shared bool // true for a READ_WRITE mapping, whose changes go to the file
+	loaded bool // set by load()
+}
+
+func Load_Nio_MappedByteBuffer() {
+
+	// MappedByteBuffer inherits all of ByteBuffer, so it must be loaded after Load_Nio_ByteBuffer.
+	for sig, gmeth := range ghelpers.MethodSignatures {
+		if rest, ok := strings.CutPrefix(sig, "java/nio/ByteBuffer."); ok {
+			ghelpers.MethodSignatures[mappedByteBufferClassName+"."+rest] = gmeth
+		}
+	}
+
+	self := "Ljava/nio/MappedByteBuffer;"
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"position(I)" + self: {ParamSlots: 1, GFunction: bufferSetPosition},
+		"limit(I)" + self:    {ParamSlots: 1, GFunction: bufferSetLimit},
+		"mark()" + self:      {ParamSlots: 0, GFunction: bufferMark},
+		"reset()" + self:     {ParamSlots: 0, GFunction: bufferReset},
+		"clear()" + self:     {ParamSlots: 0, GFunction: bufferClear},
+		"flip()" + self:      {ParamSlots: 0, GFunction: bufferFlip},
+		"rewind()" + self:    {ParamSlots: 0, GFunction: bufferRewind},
+		"slice()" + self:     {ParamSlots: 0, GFunction: bufferSlice},
+		"slice(II)" + self:   {ParamSlots: 2, GFunction: bufferSlice},
+		"duplicate()" + self: {ParamSlots: 0, GFunction: bufferDuplicate},
+		"compact()" + self:   {ParamSlots: 0, GFunction: bufferCompact},
+		"force()" + self:     {ParamSlots: 0, GFunction: mappedForce},
+		"force(II)" + self:   {ParamSlots: 2, GFunction: mappedForce},
+		"load()" + self:      {ParamSlots: 0, GFunction: mappedLoad},
+		"isLoaded()Z":        {ParamSlots: 0, GFunction: mappedIsLoaded},
+	} {
+		ghelpers.MethodSignatures[mappedByteBufferClassName+"."+sig] = gmeth
+	}
+}
+
+// newMappedBuffer returns a byte buffer of size bytes over data, which starts offset bytes before
+// the mapped position. The mapping is removed once the region is no longer reachable.
+func newMappedBuffer(data []byte, offset, size int, shared, readOnly bool) *nioBuffer {
+	region := &mappedRegion{data: data, shared: shared}
+	var bytes []types.JavaByte
+	if len(data) > 0 {
+		bytes = unsafe.Slice((*types.JavaByte)(unsafe.Pointer(unsafe.SliceData(data))), len(data))
+		runtime.AddCleanup(region, func(data []byte) { _ = munmapFile(data) }, data)
+	}
+	return &nioBuffer{kind: bufferKinds['B'], bytes: bytes, offset: offset, capacity: size, limit: size,
+		mark: -1, bigEndian: true, direct: true, readOnly: readOnly, mapped: region}
+}
+
+// java/nio/MappedByteBuffer.force() and force(II) -- write the changes in a READ_WRITE mapping to
+// the file. The whole mapping is written whatever the range.
+func mappedForce(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "force")
+	if gerr != nil {
+		return gerr
+	}
+	if len(params) == 3 {
+		if gerr := checkFromIndexSize(int(params[1].(int64)), int(params[2].(int64)), b.limit); gerr != nil {
+			return gerr
+		}
+	}
+	if b.mapped != nil && b.mapped.shared && len(b.mapped.data) > 0 {
+		if err := msyncMapping(b.mapped.data); err != nil {
+			return channelIOError(err)
+		}
+	}
+	return params[0]
+}
+
+// java/nio/MappedByteBuffer.load() -- touch every page of the mapping so that it is resident
+func mappedLoad(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "load")
+	if gerr != nil {
+		return gerr
+	}
+	if b.mapped != nil {
+		var sum byte
+		for ix := 0; ix < len(b.mapped.data); ix += pageSize {
+			sum += b.mapped.data[ix]
+		}
+		runtime.KeepAlive(sum)
+		b.mapped.loaded = true
+	}
+	return params[0]
+}
+
+// java/nio/MappedByteBuffer.isLoaded()Z -- only a hint, as in the JDK: true once load() has run
+func mappedIsLoaded(params []interface{}) interface{} {
+	b, gerr := bufferThis(params[0], "isLoaded")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(b.mapped != nil && b.mapped.loaded)
+}
@@ -7,9 +7,12 @@
 package javaSecurity
 
 import (
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
 	"jacobin/src/types"
+	"slices"
+	"sync"
 )
 
 // Load_Security initializes java/security/Security methods
@@ -27,7 +30,7 @@ func Load_Security() {
 	ghelpers.MethodSignatures["java/security/Security.getProvider(Ljava/lang/String;)Ljava/security/Provider;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1, // provider name
-			GFunction:  SecurityGetProvider,
+			GFunction:  securityGetProviderByName,
 		}
 
 	// Security.getProviders()
@@ -37,40 +40,146 @@ func Load_Security() {
 			GFunction:  securityGetProviders,
 		}
 
-	// Security.addProvider(Provider) - ghelpers.TrapFunction, since we only allow DefaultSecurityProvider
+	// Security.addProvider(Provider)
 	ghelpers.MethodSignatures["java/security/Security.addProvider(Ljava/security/Provider;)I"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  securityAddProvider,
 		}
 
-	// Security.insertProviderAt(Provider, int) - ghelpers.TrapFunction
+	// Security.insertProviderAt(Provider, int)
 	ghelpers.MethodSignatures["java/security/Security.insertProviderAt(Ljava/security/Provider;I)I"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  securityInsertProviderAt,
 		}
 
-	// Security.removeProvider(String) - ghelpers.TrapFunction
+	// Security.removeProvider(String)
 	ghelpers.MethodSignatures["java/security/Security.removeProvider(Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  securityRemoveProvider,
 		}
 
 }
 
+// ----------------------- Installed Providers -----------------------
+
+// installedProviders holds the providers of Security in order of preference, the most preferred
+// first. The Go runtime provider is held as nil, so that it is always the current
+// ghelpers.DefaultSecurityProvider. Providers implemented in Java are added by addProvider and
+// insertProviderAt.
+var installedProviders = []*object.Object{nil}
+var installedProvidersLock sync.Mutex
+
+// InstalledProviders returns the installed providers in order of preference.
+func InstalledProviders() []*object.Object {
+	installedProvidersLock.Lock()
+	defer installedProvidersLock.Unlock()
+	providers := make([]*object.Object, 0, len(installedProviders))
+	for _, provider := range installedProviders {
+		if provider == nil {
+			provider = ghelpers.GetDefaultSecurityProvider()
+		}
+		if provider != nil {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// providerName returns the name of provider, or "" if it has none.
+func providerName(provider *object.Object) string {
+	if provider == nil || object.IsNull(provider) {
+		return ""
+	}
+	if nameObj, ok := provider.FieldTable["name"].Fvalue.(*object.Object); ok {
+		return object.GoStringFromStringObject(nameObj)
+	}
+	return ""
+}
+
+// findInstalledProvider returns the installed provider that is named name, or nil.
+func findInstalledProvider(name string) *object.Object {
+	for _, provider := range InstalledProviders() {
+		if providerName(provider) == name {
+			return provider
+		}
+	}
+	return nil
+}
+
+// installedProviderIndex returns the index in installedProviders of the provider named name, or -1.
+// The caller holds installedProvidersLock.
+func installedProviderIndex(name string) int {
+	return slices.IndexFunc(installedProviders, func(provider *object.Object) bool {
+		if provider == nil {
+			return name == types.SecurityProviderName
+		}
+		return providerName(provider) == name
+	})
+}
+
 // ----------------------- Member Functions -----------------------
 
 func SecurityGetProvider([]any) any {
 	return ghelpers.GetDefaultSecurityProvider()
 }
 
+// getProvider(String) -> the installed provider of that name, or null
+func securityGetProviderByName(params []any) any {
+	nameObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityGetProviderByName: name is null")
+	}
+	if provider := findInstalledProvider(object.GoStringFromStringObject(nameObj)); provider != nil {
+		return provider
+	}
+	return object.Null
+}
+
 // getProviders() -> Provider[]
 func securityGetProviders(params []any) any {
-	provider := ghelpers.GetDefaultSecurityProvider()
-	if provider == nil {
-		return []*object.Object{}
+	return object.MakeOneFieldObject(types.ObjectClassName, "value", types.RefArray, InstalledProviders())
+}
+
+// addProvider(Provider) -> the 1-based position of the provider, or -1 if it was already installed
+func securityAddProvider(params []any) any {
+	return securityInsertProviderAt([]any{params[0], int64(-1)})
+}
+
+// insertProviderAt(Provider, int) -> the 1-based position of the provider, or -1 if it was already
+// installed. A position that is out of range adds the provider at the end.
+func securityInsertProviderAt(params []any) any {
+	provider, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(provider) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityInsertProviderAt: provider is null")
+	}
+	position := params[1].(int64)
+
+	installedProvidersLock.Lock()
+	defer installedProvidersLock.Unlock()
+	if installedProviderIndex(providerName(provider)) >= 0 {
+		return int64(-1)
+	}
+	if position < 1 || position > int64(len(installedProviders)) {
+		position = int64(len(installedProviders)) + 1
+	}
+	installedProviders = slices.Insert(installedProviders, int(position-1), provider)
+	return position
+}
+
+// removeProvider(String) -- removing a provider that is not installed does nothing
+func securityRemoveProvider(params []any) any {
+	nameObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityRemoveProvider: name is null")
+	}
+
+	installedProvidersLock.Lock()
+	defer installedProvidersLock.Unlock()
+	if ix := installedProviderIndex(object.GoStringFromStringObject(nameObj)); ix >= 0 {
+		installedProviders = slices.Delete(installedProviders, ix, ix+1)
 	}
-	return object.MakeOneFieldObject(types.ObjectClassName, "value", types.RefArray, []*object.Object{provider})
+	return nil
 }
@@ -0,0 +1,621 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"time"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// This file holds CertPath and the PKIX CertPathValidator, with its TrustAnchor, PKIXParameters
+// and PKIXCertPathValidatorResult. Validation checks name chaining, signatures, validity periods,
+// basic constraints and key usage of the CA certificates, and unrecognised critical extensions.
+// Revocation is not checked, so the revocationEnabled flag of PKIXParameters has no effect.
+
+func Load_Security_Cert_CertPath() {
+	ghelpers.MethodSignatures["java/security/cert/CertPath.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.equals(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  certPathEquals,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.getCertificates()Ljava/util/List;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certPathGetCertificates,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.getEncoded()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certPathGetEncoded,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.getEncoded(Ljava/lang/String;)[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  certPathGetEncoded,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.getType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetType,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPath.hashCode()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certPathHashCode,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.getAlgorithm()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certPathValidatorGetAlgorithm,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.getDefaultType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certPathValidatorGetDefaultType,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.getInstance(Ljava/lang/String;)Ljava/security/cert/CertPathValidator;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  certPathValidatorGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.getProvider()Ljava/security/Provider;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certificateFactoryGetProvider,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertPathValidator.validate(Ljava/security/cert/CertPath;Ljava/security/cert/CertPathParameters;)Ljava/security/cert/CertPathValidatorResult;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  certPathValidatorValidate,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXCertPathValidatorResult.getPolicyTree()Ljava/security/cert/PolicyNode;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ReturnNull,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXCertPathValidatorResult.getPublicKey()Ljava/security/PublicKey;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pkixResultGetPublicKey,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXCertPathValidatorResult.getTrustAnchor()Ljava/security/cert/TrustAnchor;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pkixResultGetTrustAnchor,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.<init>(Ljava/security/KeyStore;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pkixParametersInitKeyStore,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.<init>(Ljava/util/Set;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    pkixParametersSetTrustAnchors,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.getDate()Ljava/util/Date;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pkixParametersGetDate,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.getTrustAnchors()Ljava/util/Set;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pkixParametersGetTrustAnchors,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.isRevocationEnabled()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  pkixParametersIsRevocationEnabled,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.setDate(Ljava/util/Date;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pkixParametersSetDate,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.setRevocationEnabled(Z)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  pkixParametersSetRevocationEnabled,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/PKIXParameters.setTrustAnchors(Ljava/util/Set;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    pkixParametersSetTrustAnchors,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.<init>(Ljava/security/cert/X509Certificate;[B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  trustAnchorInitCertificate,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.<init>(Ljavax/security/auth/x500/X500Principal;Ljava/security/PublicKey;[B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 3,
+			GFunction:  trustAnchorInitPrincipal,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.getCA()Ljavax/security/auth/x500/X500Principal;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  trustAnchorGetCA,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.getCAName()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  trustAnchorGetCAName,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.getCAPublicKey()Ljava/security/PublicKey;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  trustAnchorGetCAPublicKey,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.getNameConstraints()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ReturnNull,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/TrustAnchor.getTrustedCert()Ljava/security/cert/X509Certificate;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  trustAnchorGetTrustedCert,
+		}
+}
+
+// newCertPathObject returns a CertPath of X.509 certificates, which run from the target
+// certificate towards the trust anchor.
+func newCertPathObject(certs []*x509.Certificate) *object.Object {
+	certPath := object.MakeEmptyObjectWithClassName(&types.ClassNameCertPath)
+	certPath.FieldTable["value"] = object.Field{Ftype: types.RawGoPointer, Fvalue: certs}
+	return certPath
+}
+
+func certPathCertificates(arg any) ([]*x509.Certificate, bool) {
+	certPath, ok := arg.(*object.Object)
+	if !ok || object.IsNull(certPath) {
+		return nil, false
+	}
+	certs, ok := certPath.FieldTable["value"].Fvalue.([]*x509.Certificate)
+	return certs, ok
+}
+
+func certPathGetCertificates(params []any) any {
+	certs, _ := certPathCertificates(params[0])
+	elements := make([]any, len(certs))
+	for i, cert := range certs {
+		elements[i] = NewX509CertificateObject(cert)
+	}
+	return object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, elements)
+}
+
+func certPathGetEncoded(params []any) any {
+	if len(params) > 1 {
+		encodingObj, ok := params[1].(*object.Object)
+		if !ok || object.IsNull(encodingObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "encoding is null")
+		}
+		if object.GoStringFromStringObject(encodingObj) != certPathEncodingPkiPath {
+			return ghelpers.GetGErrBlk(excNames.CertificateEncodingException, "unsupported encoding")
+		}
+	}
+	certs, _ := certPathCertificates(params[0])
+	encoded, err := encodePkiPath(certs)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertificateEncodingException, err.Error())
+	}
+	return javaBytes(encoded)
+}
+
+func certPathEquals(params []any) any {
+	certs, _ := certPathCertificates(params[0])
+	other, ok := certPathCertificates(params[1])
+	if !ok || len(certs) != len(other) {
+		return types.JavaBoolFalse
+	}
+	for i := range certs {
+		if !certs[i].Equal(other[i]) {
+			return types.JavaBoolFalse
+		}
+	}
+	return types.JavaBoolTrue
+}
+
+// certPathHashCode returns type.hashCode() * 31 + getCertificates().hashCode(), as the JDK does.
+func certPathHashCode(params []any) any {
+	certs, _ := certPathCertificates(params[0])
+	var typeHash int32
+	for _, r := range "X.509" {
+		typeHash = 31*typeHash + r
+	}
+	listHash := int32(1)
+	for _, cert := range certs {
+		listHash = 31*listHash + certificateHashCode(cert)
+	}
+	return int64(typeHash*31 + listHash)
+}
+
+// ---- TrustAnchor ----
+
+func trustAnchorInitCertificate(params []any) any {
+	self := params[0].(*object.Object)
+	cert, ok := x509CertificateFromObject(params[1])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "the trustedCert parameter must be non-null")
+	}
+	if gerr := checkNameConstraints(params[2]); gerr != nil {
+		return gerr
+	}
+	self.FieldTable["trustedCert"] = object.Field{Ftype: types.RawGoPointer, Fvalue: cert}
+	return nil
+}
+
+func trustAnchorInitPrincipal(params []any) any {
+	self := params[0].(*object.Object)
+	caObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(caObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "the caPrincipal parameter must be non-null")
+	}
+	keyObj, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "the pubKey parameter must be non-null")
+	}
+	if gerr := checkNameConstraints(params[3]); gerr != nil {
+		return gerr
+	}
+	self.FieldTable["caPrincipal"] = object.Field{Ftype: types.Ref, Fvalue: caObj}
+	self.FieldTable["caPublicKey"] = object.Field{Ftype: types.Ref, Fvalue: keyObj}
+	return nil
+}
+
+// checkNameConstraints rejects name constraints, which the validator does not apply.
+func checkNameConstraints(arg any) *ghelpers.GErrBlk {
+	if !object.IsNull(arg) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "name constraints are not supported")
+	}
+	return nil
+}
+
+// trustAnchorSubject returns the DER-encoded name and the Go public key of a trust anchor.
+func trustAnchorSubject(anchor *object.Object) ([]byte, any) {
+	if cert, ok := anchor.FieldTable["trustedCert"].Fvalue.(*x509.Certificate); ok {
+		return cert.RawSubject, cert.PublicKey
+	}
+	caObj, _ := anchor.FieldTable["caPrincipal"].Fvalue.(*object.Object)
+	keyObj, _ := anchor.FieldTable["caPublicKey"].Fvalue.(*object.Object)
+	if caObj == nil || keyObj == nil {
+		return nil, nil
+	}
+	return x500PrincipalDER(caObj), keyObj.FieldTable["value"].Fvalue
+}
+
+func trustAnchorGetTrustedCert(params []any) any {
+	if cert, ok := params[0].(*object.Object).FieldTable["trustedCert"].Fvalue.(*x509.Certificate); ok {
+		return NewX509CertificateObject(cert)
+	}
+	return object.Null
+}
+
+func trustAnchorGetCA(params []any) any {
+	if caObj, ok := params[0].(*object.Object).FieldTable["caPrincipal"].Fvalue.(*object.Object); ok {
+		return caObj
+	}
+	return object.Null
+}
+
+func trustAnchorGetCAName(params []any) any {
+	caObj, ok := params[0].(*object.Object).FieldTable["caPrincipal"].Fvalue.(*object.Object)
+	if !ok {
+		return object.Null
+	}
+	return x500PrincipalGetName([]any{caObj})
+}
+
+func trustAnchorGetCAPublicKey(params []any) any {
+	if keyObj, ok := params[0].(*object.Object).FieldTable["caPublicKey"].Fvalue.(*object.Object); ok {
+		return keyObj
+	}
+	return object.Null
+}
+
+// ---- PKIXParameters ----
+
+// pkixParametersSetTrustAnchors serves both the PKIXParameters(Set) constructor and setTrustAnchors.
+func pkixParametersSetTrustAnchors(params []any) any {
+	fs, args := ghelpers.SplitContext(params)
+	self := args[0].(*object.Object)
+	setObj, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(setObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "the trustAnchors parameters must be non-null")
+	}
+	elements, gerr := javaUtil.CollectionElements(fs, setObj)
+	if gerr != nil {
+		return gerr
+	}
+	if len(elements) == 0 {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "the trustAnchors parameter must be non-empty")
+	}
+	anchors := make([]*object.Object, len(elements))
+	for i, elem := range elements {
+		anchor, ok := elem.(*object.Object)
+		if !ok || object.IsNull(anchor) || object.GoStringFromStringPoolIndex(anchor.KlassName) != types.ClassNameTrustAnchor {
+			return ghelpers.GetGErrBlk(excNames.ClassCastException, "all elements of set must be of type java.security.cert.TrustAnchor")
+		}
+		anchors[i] = anchor
+	}
+	setPKIXDefaults(self)
+	self.FieldTable["trustAnchors"] = object.Field{Ftype: types.RawGoPointer, Fvalue: anchors}
+	return nil
+}
+
+// pkixParametersInitKeyStore makes a trust anchor of each trusted certificate entry of a key store.
+func pkixParametersInitKeyStore(params []any) any {
+	self := params[0].(*object.Object)
+	ksObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(ksObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "the keystore parameter must be non-null")
+	}
+	state, gerr := loadedKeyStore(ksObj)
+	if gerr != nil {
+		return gerr
+	}
+	var anchors []*object.Object
+	for _, entry := range state.entries {
+		if entry.trusted != nil {
+			anchor := object.MakeEmptyObjectWithClassName(&types.ClassNameTrustAnchor)
+			anchor.FieldTable["trustedCert"] = object.Field{Ftype: types.RawGoPointer, Fvalue: entry.trusted}
+			anchors = append(anchors, anchor)
+		}
+	}
+	if len(anchors) == 0 {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "the trustAnchors parameter must be non-empty")
+	}
+	setPKIXDefaults(self)
+	self.FieldTable["trustAnchors"] = object.Field{Ftype: types.RawGoPointer, Fvalue: anchors}
+	return nil
+}
+
+func setPKIXDefaults(self *object.Object) {
+	if _, ok := self.FieldTable["revocationEnabled"]; !ok {
+		self.FieldTable["revocationEnabled"] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	}
+}
+
+func pkixParametersGetTrustAnchors(params []any) any {
+	anchors, _ := params[0].(*object.Object).FieldTable["trustAnchors"].Fvalue.([]*object.Object)
+	elements := make([]any, len(anchors))
+	for i, anchor := range anchors {
+		elements[i] = anchor
+	}
+	set, gerr := javaUtil.NewHashSet(elements)
+	if gerr != nil {
+		return gerr
+	}
+	return set
+}
+
+func pkixParametersSetDate(params []any) any {
+	self := params[0].(*object.Object)
+	if object.IsNull(params[1]) {
+		delete(self.FieldTable, "date")
+		return nil
+	}
+	millis, gerr := javaUtil.DateGetMillis(params[1].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	self.FieldTable["date"] = object.Field{Ftype: types.Long, Fvalue: millis}
+	return nil
+}
+
+func pkixParametersGetDate(params []any) any {
+	millis, ok := params[0].(*object.Object).FieldTable["date"].Fvalue.(int64)
+	if !ok {
+		return object.Null
+	}
+	return newDateObject(time.UnixMilli(millis))
+}
+
+func pkixParametersSetRevocationEnabled(params []any) any {
+	params[0].(*object.Object).FieldTable["revocationEnabled"] = object.Field{Ftype: types.Bool, Fvalue: params[1].(int64)}
+	return nil
+}
+
+func pkixParametersIsRevocationEnabled(params []any) any {
+	if enabled, ok := params[0].(*object.Object).FieldTable["revocationEnabled"].Fvalue.(int64); ok {
+		return enabled
+	}
+	return types.JavaBoolTrue
+}
+
+// ---- CertPathValidator ----
+
+func certPathValidatorGetInstance(params []any) any {
+	algObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(algObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null algorithm name")
+	}
+	algorithm := object.GoStringFromStringObject(algObj)
+	if algorithm != "PKIX" {
+		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException, algorithm+" CertPathValidator not available")
+	}
+	validator := object.MakeEmptyObjectWithClassName(&types.ClassNameCertPathValidator)
+	validator.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassRef, Fvalue: algObj}
+	return validator
+}
+
+func certPathValidatorGetAlgorithm(params []any) any {
+	return params[0].(*object.Object).FieldTable["algorithm"].Fvalue
+}
+
+func certPathValidatorGetDefaultType(params []any) any {
+	return object.StringObjectFromGoString("PKIX")
+}
+
+func certPathValidatorValidate(params []any) any {
+	certs, ok := certPathCertificates(params[1])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "inappropriate CertPath type specified, must be X.509 or X509")
+	}
+	pkix, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(pkix) {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "inappropriate params, must be an instance of PKIXParameters")
+	}
+	anchors, ok := pkix.FieldTable["trustAnchors"].Fvalue.([]*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "inappropriate params, must be an instance of PKIXParameters")
+	}
+	at := time.Now()
+	if millis, ok := pkix.FieldTable["date"].Fvalue.(int64); ok {
+		at = time.UnixMilli(millis)
+	}
+
+	anchor, gerr := validateCertPath(certs, anchors, at)
+	if gerr != nil {
+		return gerr
+	}
+
+	result := object.MakeEmptyObjectWithClassName(&types.ClassNamePKIXCertPathValidatorResult)
+	result.FieldTable["trustAnchor"] = object.Field{Ftype: types.Ref, Fvalue: anchor}
+	targetKey := any(nil)
+	if len(certs) > 0 {
+		targetKey = certs[0].PublicKey
+	} else {
+		_, targetKey = trustAnchorSubject(anchor)
+	}
+	keyObj, err := NewPublicKeyObject(targetKey)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertPathValidatorException, err.Error())
+	}
+	result.FieldTable["publicKey"] = object.Field{Ftype: types.Ref, Fvalue: keyObj}
+	return result
+}
+
+// validateCertPath checks a path of certificates, which runs from the target, against the
+// trust anchors at time at. It returns the anchor the path chains to.
+func validateCertPath(certs []*x509.Certificate, anchors []*object.Object, at time.Time) (*object.Object, *ghelpers.GErrBlk) {
+	fail := func(msg string) (*object.Object, *ghelpers.GErrBlk) {
+		return nil, ghelpers.GetGErrBlk(excNames.CertPathValidatorException, msg)
+	}
+	if len(certs) == 0 {
+		return anchors[0], nil
+	}
+
+	// Find the anchor that issued the certificate nearest it. A path may also end with the
+	// anchor's own certificate.
+	last := certs[len(certs)-1]
+	var anchor *object.Object
+	var issuerName []byte
+	var issuerKey any
+	for _, candidate := range anchors {
+		if trusted, ok := candidate.FieldTable["trustedCert"].Fvalue.(*x509.Certificate); ok && trusted.Equal(last) {
+			anchor, issuerName, issuerKey = candidate, trusted.RawSubject, trusted.PublicKey
+			certs = certs[:len(certs)-1]
+			break
+		}
+		name, key := trustAnchorSubject(candidate)
+		if bytes.Equal(name, last.RawIssuer) && verifyCertificateSignature(last, key) == nil {
+			anchor, issuerName, issuerKey = candidate, name, key
+			break
+		}
+	}
+	if anchor == nil {
+		return fail("Path does not chain with any of the trust anchors")
+	}
+
+	maxPathLength := len(certs)
+	for i := len(certs) - 1; i >= 0; i-- {
+		cert := certs[i]
+		if !bytes.Equal(cert.RawIssuer, issuerName) {
+			return fail("subject/issuer name chaining check failed")
+		}
+		if verifyCertificateSignature(cert, issuerKey) != nil {
+			return fail("signature check failed")
+		}
+		if gerr := checkCertificateValidity(cert, at); gerr != nil {
+			return fail("validity check failed: " + gerr.ErrMsg)
+		}
+		if len(cert.UnhandledCriticalExtensions) > 0 {
+			return fail("Unrecognized critical extension(s)")
+		}
+
+		if i > 0 { // a CA certificate
+			if !cert.BasicConstraintsValid || !cert.IsCA {
+				return fail("basic constraints check failed: this is not a CA certificate")
+			}
+			if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+				return fail("CA key usage check failed: keyCertSign bit is not set")
+			}
+			if !bytes.Equal(cert.RawSubject, cert.RawIssuer) {
+				if maxPathLength <= 0 {
+					return fail("basic constraints check failed: pathLenConstraint violated - " +
+						"this cert must be the last cert in the certification path")
+				}
+				maxPathLength--
+			}
+			if (cert.MaxPathLen > 0 || cert.MaxPathLenZero) && cert.MaxPathLen < maxPathLength {
+				maxPathLength = cert.MaxPathLen
+			}
+		}
+		issuerName, issuerKey = cert.RawSubject, cert.PublicKey
+	}
+	return anchor, nil
+}
+
+func pkixResultGetTrustAnchor(params []any) any {
+	return params[0].(*object.Object).FieldTable["trustAnchor"].Fvalue
+}
+
+func pkixResultGetPublicKey(params []any) any {
+	return params[0].(*object.Object).FieldTable["publicKey"].Fvalue
+}
@@ -0,0 +1,217 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// newTestPKIXParameters returns PKIXParameters with a single trust anchor for cert.
+func newTestPKIXParameters(t *testing.T, cert *x509.Certificate) *object.Object {
+	t.Helper()
+	classloader.InitMethodArea()
+	anchor := object.MakeEmptyObjectWithClassName(&types.ClassNameTrustAnchor)
+	if res := trustAnchorInitCertificate([]any{anchor, NewX509CertificateObject(cert), object.Null}); res != nil {
+		t.Fatalf("TrustAnchor(X509Certificate, byte[]) failed: %v", res)
+	}
+	anchors, gerr := javaUtil.NewHashSet([]any{anchor})
+	if gerr != nil {
+		t.Fatalf("NewHashSet failed: %v", gerr.ErrMsg)
+	}
+	pkixParams := object.MakeEmptyObjectWithClassName(&types.ClassNamePKIXParameters)
+	if res := pkixParametersSetTrustAnchors([]any{pkixParams, anchors}); res != nil {
+		t.Fatalf("PKIXParameters(Set) failed: %v", res)
+	}
+	pkixParametersSetRevocationEnabled([]any{pkixParams, types.JavaBoolFalse})
+	return pkixParams
+}
+
+func newTestCertPathValidator(t *testing.T) *object.Object {
+	t.Helper()
+	validator, ok := certPathValidatorGetInstance([]any{object.StringObjectFromGoString("PKIX")}).(*object.Object)
+	if !ok {
+		t.Fatalf("CertPathValidator.getInstance(PKIX) failed")
+	}
+	return validator
+}
+
+func TestLoad_Security_Cert_CertPath(t *testing.T) {
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Security_Cert_CertPath()
+
+	methods := []string{
+		"java/security/cert/CertPath.getCertificates()Ljava/util/List;",
+		"java/security/cert/CertPathValidator.getInstance(Ljava/lang/String;)Ljava/security/cert/CertPathValidator;",
+		"java/security/cert/CertPathValidator.validate(Ljava/security/cert/CertPath;Ljava/security/cert/CertPathParameters;)Ljava/security/cert/CertPathValidatorResult;",
+		"java/security/cert/PKIXParameters.<init>(Ljava/util/Set;)V",
+		"java/security/cert/PKIXParameters.<init>(Ljava/security/KeyStore;)V",
+		"java/security/cert/TrustAnchor.<init>(Ljava/security/cert/X509Certificate;[B)V",
+	}
+	for _, m := range methods {
+		if _, ok := ghelpers.MethodSignatures[m]; !ok {
+			t.Errorf("CertPath method signature not registered: %s", m)
+		}
+	}
+}
+
+func TestCertPathValidator_Valid(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, leafKey := testChain(t)
+	validator := newTestCertPathValidator(t)
+	pkixParams := newTestPKIXParameters(t, root)
+
+	res := certPathValidatorValidate([]any{validator, newCertPathObject([]*x509.Certificate{leaf}), pkixParams})
+	result, ok := res.(*object.Object)
+	if !ok {
+		t.Fatalf("validate failed: %v", res)
+	}
+	pub := pkixResultGetPublicKey([]any{result}).(*object.Object)
+	if !leafKey.PublicKey.Equal(pub.FieldTable["value"].Fvalue) {
+		t.Errorf("result public key is not the target's key")
+	}
+	anchor := pkixResultGetTrustAnchor([]any{result}).(*object.Object)
+	if got, _ := x509CertificateFromObject(trustAnchorGetTrustedCert([]any{anchor})); !got.Equal(root) {
+		t.Errorf("result trust anchor is not the root")
+	}
+
+	// A path that ends with the anchor's own certificate is also valid.
+	res = certPathValidatorValidate([]any{validator, newCertPathObject([]*x509.Certificate{leaf, root}), pkixParams})
+	if _, ok := res.(*object.Object); !ok {
+		t.Errorf("validate of a path ending with the anchor failed: %v", res)
+	}
+}
+
+func TestCertPathValidator_Failures(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, leafKey := testChain(t)
+	otherRoot, _, _, _ := testChain(t)
+	validator := newTestCertPathValidator(t)
+	path := newCertPathObject([]*x509.Certificate{leaf})
+
+	// The wrong trust anchor.
+	res := certPathValidatorValidate([]any{validator, path, newTestPKIXParameters(t, otherRoot)})
+	testutil.ExpectGErr(t, res, excNames.CertPathValidatorException, "Path does not chain with any of the trust anchors")
+
+	// Validation at a date after the leaf expires.
+	pkixParams := newTestPKIXParameters(t, root)
+	pkixParametersSetDate([]any{pkixParams, newDateObject(time.Now().Add(18 * time.Hour))})
+	res = certPathValidatorValidate([]any{validator, path, pkixParams})
+	testutil.ExpectGErr(t, res, excNames.CertPathValidatorException, "validity check failed")
+
+	// A certificate issued by an end-entity certificate.
+	now := time.Now()
+	child, _ := testCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "child"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, leaf, leafKey)
+	path = newCertPathObject([]*x509.Certificate{child, leaf})
+	res = certPathValidatorValidate([]any{validator, path, newTestPKIXParameters(t, root)})
+	testutil.ExpectGErr(t, res, excNames.CertPathValidatorException, "basic constraints check failed")
+
+	// The anchor's own path length constraint of 0 does not apply to the path below it.
+	zeroRoot, zeroKey := testCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(4),
+		Subject:               pkix.Name{CommonName: "Zero Root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}, nil, nil)
+	intermediate, intermediateKey := testCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(5),
+		Subject:               pkix.Name{CommonName: "Intermediate"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, zeroRoot, zeroKey)
+	target, _ := testCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(6),
+		Subject:      pkix.Name{CommonName: "target"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, intermediate, intermediateKey)
+	path = newCertPathObject([]*x509.Certificate{target, intermediate, zeroRoot})
+	res = certPathValidatorValidate([]any{validator, path, newTestPKIXParameters(t, zeroRoot)})
+	if _, ok := res.(*object.Object); !ok {
+		t.Errorf("validate of a path below a self-issued anchor failed: %v", res)
+	}
+
+	// But the path length constraint of an intermediate CA does.
+	zeroIntermediate, zeroIntermediateKey := testCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(7),
+		Subject:               pkix.Name{CommonName: "Zero Intermediate"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}, zeroRoot, zeroKey)
+	subCA, subCAKey := testCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(8),
+		Subject:               pkix.Name{CommonName: "Sub CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, zeroIntermediate, zeroIntermediateKey)
+	target, _ = testCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(9),
+		Subject:      pkix.Name{CommonName: "target"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, subCA, subCAKey)
+	path = newCertPathObject([]*x509.Certificate{target, subCA, zeroIntermediate})
+	res = certPathValidatorValidate([]any{validator, path, newTestPKIXParameters(t, zeroRoot)})
+	testutil.ExpectGErr(t, res, excNames.CertPathValidatorException, "basic constraints check failed: pathLenConstraint violated")
+}
+
+func TestCertPath_EncodeAndGenerate(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, _ := testChain(t)
+	path := newCertPathObject([]*x509.Certificate{leaf, root})
+
+	encoded, ok := certPathGetEncoded([]any{path}).(*object.Object)
+	if !ok {
+		t.Fatalf("getEncoded failed")
+	}
+	der := object.GoByteArrayFromJavaByteArray(encoded.FieldTable["value"].Fvalue.([]types.JavaByte))
+
+	factory := newTestCertificateFactory(t)
+	res := certificateFactoryGenerateCertPathFromStream([]any{factory, bytes.NewReader(der)})
+	certs, ok := certPathCertificates(res)
+	if !ok {
+		t.Fatalf("generateCertPath failed: %v", res)
+	}
+	if len(certs) != 2 || !certs[0].Equal(leaf) || !certs[1].Equal(root) {
+		t.Errorf("generated CertPath does not match the encoded one")
+	}
+	if certPathEquals([]any{path, res}) != types.JavaBoolTrue {
+		t.Errorf("equal CertPaths compared unequal")
+	}
+}
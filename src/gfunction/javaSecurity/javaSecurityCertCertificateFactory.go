@@ -0,0 +1,372 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"io"
+	"strings"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+func Load_Security_Cert_CertificateFactory() {
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.generateCertPath(Ljava/io/InputStream;)Ljava/security/cert/CertPath;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    certificateFactoryGenerateCertPathFromStream,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.generateCertPath(Ljava/io/InputStream;Ljava/lang/String;)Ljava/security/cert/CertPath;"] =
+		ghelpers.GMeth{
+			ParamSlots:   2,
+			GFunction:    certificateFactoryGenerateCertPathFromStream,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.generateCertPath(Ljava/util/List;)Ljava/security/cert/CertPath;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    certificateFactoryGenerateCertPathFromList,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.generateCertificate(Ljava/io/InputStream;)Ljava/security/cert/Certificate;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    certificateFactoryGenerateCertificate,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.generateCertificates(Ljava/io/InputStream;)Ljava/util/Collection;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    certificateFactoryGenerateCertificates,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getCertPathEncodings()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certificateFactoryGetCertPathEncodings,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getInstance(Ljava/lang/String;)Ljava/security/cert/CertificateFactory;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  certificateFactoryGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getInstance(Ljava/lang/String;Ljava/lang/String;)Ljava/security/cert/CertificateFactory;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  certificateFactoryGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljava/security/cert/CertificateFactory;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  certificateFactoryGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getProvider()Ljava/security/Provider;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certificateFactoryGetProvider,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/CertificateFactory.getType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certificateFactoryGetType,
+		}
+}
+
+// certPathEncodingPkiPath is the default CertPath encoding, an ASN.1 SEQUENCE of the
+// certificates from the trust anchor end to the target.
+const certPathEncodingPkiPath = "PkiPath"
+
+func certificateFactoryGetInstance(params []any) any {
+	typeObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(typeObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null type name")
+	}
+	certType := object.GoStringFromStringObject(typeObj)
+	if !strings.EqualFold(certType, "X.509") && !strings.EqualFold(certType, "X509") {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, certType+" not found")
+	}
+	factory := object.MakeEmptyObjectWithClassName(&types.ClassNameCertificateFactory)
+	factory.FieldTable["type"] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(certType)}
+	return factory
+}
+
+func certificateFactoryGetType(params []any) any {
+	return params[0].(*object.Object).FieldTable["type"].Fvalue
+}
+
+func certificateFactoryGetProvider(params []any) any {
+	return ghelpers.GetDefaultSecurityProvider()
+}
+
+// certReader reads a byte at a time, so that reading one certificate does not consume any
+// of the stream beyond it.
+type certReader struct {
+	r io.Reader
+}
+
+func (cr certReader) readByte() (byte, error) {
+	var b [1]byte
+	for {
+		n, err := cr.r.Read(b[:])
+		if n == 1 {
+			return b[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (cr certReader) readFull(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(cr.r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// readLine reads up to and including the next newline, or to the end of the stream.
+func (cr certReader) readLine() (string, error) {
+	var line []byte
+	for {
+		b, err := cr.readByte()
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return string(line), nil
+			}
+			return string(line), err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			return string(line), nil
+		}
+	}
+}
+
+// readCertificateBytes reads the DER encoding of the next certificate in a stream, which may
+// hold it in DER or in PEM form. It returns io.EOF if the stream holds nothing more than
+// white space.
+func readCertificateBytes(cr certReader) ([]byte, error) {
+	var first byte
+	for {
+		b, err := cr.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			first = b
+			break
+		}
+	}
+
+	if first == 0x30 { // an ASN.1 SEQUENCE, so DER
+		header := []byte{first}
+		lenByte, err := cr.readByte()
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		header = append(header, lenByte)
+		length := int(lenByte)
+		if lenByte&0x80 != 0 {
+			count := int(lenByte & 0x7f)
+			if count == 0 || count > 4 {
+				return nil, errors.New("invalid DER length")
+			}
+			lenBytes, err := cr.readFull(count)
+			if err != nil {
+				return nil, io.ErrUnexpectedEOF
+			}
+			header = append(header, lenBytes...)
+			length = 0
+			for _, b := range lenBytes {
+				length = length<<8 | int(b)
+			}
+		}
+		body, err := cr.readFull(length)
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return append(header, body...), nil
+	}
+
+	// Otherwise it must be PEM: read through the END line.
+	rest, err := cr.readLine()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	text := string(first) + rest
+	if !strings.HasPrefix(text, "-----BEGIN ") {
+		return nil, errors.New("Unable to parse the certificate: not DER or PEM")
+	}
+	for {
+		line, err := cr.readLine()
+		text += line
+		if strings.HasPrefix(line, "-----END ") {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("End tag not found")
+		}
+	}
+	block, _ := pem.Decode([]byte(text))
+	if block == nil || !strings.HasSuffix(block.Type, "CERTIFICATE") {
+		return nil, errors.New("Unable to parse the certificate: invalid PEM data")
+	}
+	return block.Bytes, nil
+}
+
+// readCertificate reads and parses the next certificate in a stream.
+func readCertificate(cr certReader) (*x509.Certificate, error) {
+	der, err := readCertificateBytes(cr)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func certificateFactoryGenerateCertificate(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	if object.IsNull(params[1]) {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "Missing input stream")
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "CertificateFactory.generateCertificate")
+	if gerr != nil {
+		return gerr
+	}
+	cert, err := readCertificate(certReader{r})
+	if err == io.EOF {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "Could not parse certificate: java.io.IOException: Empty input")
+	}
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "Could not parse certificate: "+err.Error())
+	}
+	return NewX509CertificateObject(cert)
+}
+
+// certificateFactoryGenerateCertificates reads certificates until the end of the stream.
+func certificateFactoryGenerateCertificates(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	if object.IsNull(params[1]) {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "Missing input stream")
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "CertificateFactory.generateCertificates")
+	if gerr != nil {
+		return gerr
+	}
+	certs := []any{}
+	for {
+		cert, err := readCertificate(certReader{r})
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ghelpers.GetGErrBlk(excNames.CertificateException, "Could not parse certificate: "+err.Error())
+		}
+		certs = append(certs, NewX509CertificateObject(cert))
+	}
+	return object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, certs)
+}
+
+// certificateFactoryGenerateCertPathFromStream reads a CertPath in PkiPath encoding.
+// The PKCS7 encoding is not supported.
+func certificateFactoryGenerateCertPathFromStream(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	if object.IsNull(params[1]) {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "Missing input stream")
+	}
+	if len(params) > 2 {
+		encodingObj, ok := params[2].(*object.Object)
+		if !ok || object.IsNull(encodingObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "encoding is null")
+		}
+		if encoding := object.GoStringFromStringObject(encodingObj); encoding != certPathEncodingPkiPath {
+			return ghelpers.GetGErrBlk(excNames.CertificateException, "unsupported encoding")
+		}
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "CertificateFactory.generateCertPath")
+	if gerr != nil {
+		return gerr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "IOException parsing PkiPath data: "+err.Error())
+	}
+	var raws []asn1.RawValue
+	if rest, err := asn1.Unmarshal(data, &raws); err != nil || len(rest) > 0 {
+		return ghelpers.GetGErrBlk(excNames.CertificateException, "IOException parsing PkiPath data")
+	}
+	certs := make([]*x509.Certificate, len(raws))
+	for i, raw := range raws {
+		cert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			return ghelpers.GetGErrBlk(excNames.CertificateException, "IOException parsing PkiPath data: "+err.Error())
+		}
+		// PkiPath runs from the anchor end, a CertPath from the target
+		certs[len(raws)-1-i] = cert
+	}
+	return newCertPathObject(certs)
+}
+
+func certificateFactoryGenerateCertPathFromList(params []any) any {
+	fs, args := ghelpers.SplitContext(params)
+	listObj, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(listObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "certs cannot be null")
+	}
+	elements, gerr := javaUtil.CollectionElements(fs, listObj)
+	if gerr != nil {
+		return gerr
+	}
+	certs := make([]*x509.Certificate, len(elements))
+	for i, elem := range elements {
+		cert, ok := x509CertificateFromObject(elem)
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.CertificateException, "List is not all X509Certificates")
+		}
+		certs[i] = cert
+	}
+	return newCertPathObject(certs)
+}
+
+func certificateFactoryGetCertPathEncodings(params []any) any {
+	return javaUtil.NewIterator(object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList,
+		[]any{object.StringObjectFromGoString(certPathEncodingPkiPath)}))
+}
+
+// encodePkiPath encodes certificates, which run from the target, as a PkiPath.
+func encodePkiPath(certs []*x509.Certificate) ([]byte, error) {
+	var content bytes.Buffer
+	for i := len(certs) - 1; i >= 0; i-- {
+		content.Write(certs[i].Raw)
+	}
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: content.Bytes()})
+}
@@ -0,0 +1,604 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// An X509Certificate holds the *x509.Certificate that Go parsed in its "value" field.
+
+var (
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidExtensionSubjectAltName   = asn1.ObjectIdentifier{2, 5, 29, 17}
+)
+
+// x509SignatureAlgorithmNames maps signature algorithm OIDs to the JDK's standard names.
+var x509SignatureAlgorithmNames = map[string]string{
+	"1.2.840.113549.1.1.4":   "MD5withRSA",
+	"1.2.840.113549.1.1.5":   "SHA1withRSA",
+	"1.2.840.113549.1.1.14":  "SHA224withRSA",
+	"1.2.840.113549.1.1.11":  "SHA256withRSA",
+	"1.2.840.113549.1.1.12":  "SHA384withRSA",
+	"1.2.840.113549.1.1.13":  "SHA512withRSA",
+	"1.2.840.113549.1.1.10":  "RSASSA-PSS",
+	"1.2.840.10040.4.3":      "SHA1withDSA",
+	"2.16.840.1.101.3.4.3.1": "SHA224withDSA",
+	"2.16.840.1.101.3.4.3.2": "SHA256withDSA",
+	"1.2.840.10045.4.1":      "SHA1withECDSA",
+	"1.2.840.10045.4.3.1":    "SHA224withECDSA",
+	"1.2.840.10045.4.3.2":    "SHA256withECDSA",
+	"1.2.840.10045.4.3.3":    "SHA384withECDSA",
+	"1.2.840.10045.4.3.4":    "SHA512withECDSA",
+	"1.3.101.112":            "Ed25519",
+	"1.3.101.113":            "Ed448",
+}
+
+func Load_Security_Cert_X509Certificate() {
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.checkValidity()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateCheckValidity,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.checkValidity(Ljava/util/Date;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x509CertificateCheckValidity,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.equals(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x509CertificateEquals,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getBasicConstraints()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetBasicConstraints,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getCriticalExtensionOIDs()Ljava/util/Set;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetCriticalExtensionOIDs,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getEncoded()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetEncoded,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getExtendedKeyUsage()Ljava/util/List;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetExtendedKeyUsage,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getExtensionValue(Ljava/lang/String;)[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x509CertificateGetExtensionValue,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getIssuerDN()Ljava/security/Principal;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetIssuerX500Principal,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getIssuerX500Principal()Ljavax/security/auth/x500/X500Principal;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetIssuerX500Principal,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getKeyUsage()[Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetKeyUsage,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getNonCriticalExtensionOIDs()Ljava/util/Set;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetNonCriticalExtensionOIDs,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getNotAfter()Ljava/util/Date;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetNotAfter,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getNotBefore()Ljava/util/Date;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetNotBefore,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getPublicKey()Ljava/security/PublicKey;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetPublicKey,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSerialNumber()Ljava/math/BigInteger;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSerialNumber,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSigAlgName()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSigAlgName,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSigAlgOID()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSigAlgOID,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSignature()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSignature,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSubjectAlternativeNames()Ljava/util/Collection;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSubjectAlternativeNames,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSubjectDN()Ljava/security/Principal;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSubjectX500Principal,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getSubjectX500Principal()Ljavax/security/auth/x500/X500Principal;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetSubjectX500Principal,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getTBSCertificate()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetTBSCertificate,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetType,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.getVersion()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateGetVersion,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.hasUnsupportedCriticalExtension()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateHasUnsupportedCriticalExtension,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.hashCode()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateHashCode,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x509CertificateToString,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.verify(Ljava/security/PublicKey;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x509CertificateVerify,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.verify(Ljava/security/PublicKey;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  x509CertificateVerify,
+		}
+
+	ghelpers.MethodSignatures["java/security/cert/X509Certificate.verify(Ljava/security/PublicKey;Ljava/security/Provider;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  x509CertificateVerify,
+		}
+}
+
+// NewX509CertificateObject returns an X509Certificate object for a parsed certificate.
+func NewX509CertificateObject(cert *x509.Certificate) *object.Object {
+	certObj := object.MakeEmptyObjectWithClassName(&types.ClassNameX509Certificate)
+	certObj.FieldTable["value"] = object.Field{Ftype: types.RawGoPointer, Fvalue: cert}
+	return certObj
+}
+
+// x509CertificateFromObject returns the Go certificate held by an X509Certificate object.
+func x509CertificateFromObject(arg any) (*x509.Certificate, bool) {
+	certObj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(certObj) {
+		return nil, false
+	}
+	cert, ok := certObj.FieldTable["value"].Fvalue.(*x509.Certificate)
+	return cert, ok
+}
+
+func thisX509Certificate(params []any) *x509.Certificate {
+	cert, _ := x509CertificateFromObject(params[0])
+	return cert
+}
+
+// newDateObject returns a java.util.Date for t.
+func newDateObject(t time.Time) *object.Object {
+	return object.MakePrimitiveObject("java/util/Date", types.Long, t.UnixMilli())
+}
+
+// formatJavaDate formats t the way Date.toString() does.
+func formatJavaDate(t time.Time) string {
+	return t.UTC().Format("Mon Jan 02 15:04:05 MST 2006")
+}
+
+func javaBytes(b []byte) *object.Object {
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray(bytes.Clone(b)))
+}
+
+// checkCertificateValidity returns the exception the JDK throws if t is outside the
+// validity period of cert.
+func checkCertificateValidity(cert *x509.Certificate, t time.Time) *ghelpers.GErrBlk {
+	if t.After(cert.NotAfter) {
+		return ghelpers.GetGErrBlk(excNames.CertificateExpiredException, "NotAfter: "+formatJavaDate(cert.NotAfter))
+	}
+	if t.Before(cert.NotBefore) {
+		return ghelpers.GetGErrBlk(excNames.CertificateNotYetValidException, "NotBefore: "+formatJavaDate(cert.NotBefore))
+	}
+	return nil
+}
+
+func x509CertificateCheckValidity(params []any) any {
+	cert := thisX509Certificate(params)
+	at := time.Now()
+	if len(params) > 1 {
+		dateObj, ok := params[1].(*object.Object)
+		if !ok || object.IsNull(dateObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "date is null")
+		}
+		millis, gerr := javaUtil.DateGetMillis(dateObj)
+		if gerr != nil {
+			return gerr
+		}
+		at = time.UnixMilli(millis)
+	}
+	if gerr := checkCertificateValidity(cert, at); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func x509CertificateEquals(params []any) any {
+	other, ok := x509CertificateFromObject(params[1])
+	if !ok {
+		return types.JavaBoolFalse
+	}
+	return types.ConvertGoBoolToJavaBool(thisX509Certificate(params).Equal(other))
+}
+
+// x509CertificateHashCode returns Arrays.hashCode(getEncoded()), as Certificate.hashCode() does.
+func x509CertificateHashCode(params []any) any {
+	return int64(certificateHashCode(thisX509Certificate(params)))
+}
+
+func certificateHashCode(cert *x509.Certificate) int32 {
+	hash := int32(1)
+	for _, b := range cert.Raw {
+		hash = 31*hash + int32(int8(b))
+	}
+	return hash
+}
+
+// x509CertificateGetBasicConstraints returns -1 for an end-entity certificate, and for a CA
+// certificate, the path length constraint or Integer.MAX_VALUE if there is none.
+func x509CertificateGetBasicConstraints(params []any) any {
+	cert := thisX509Certificate(params)
+	switch {
+	case !cert.BasicConstraintsValid || !cert.IsCA:
+		return int64(-1)
+	case cert.MaxPathLen < 0, cert.MaxPathLen == 0 && !cert.MaxPathLenZero:
+		return int64(math.MaxInt32)
+	}
+	return int64(cert.MaxPathLen)
+}
+
+// extensionOIDs returns a Set of the OIDs of the critical or non-critical extensions, or null
+// if the certificate has no extensions.
+func extensionOIDs(cert *x509.Certificate, critical bool) any {
+	if len(cert.Extensions) == 0 {
+		return object.Null
+	}
+	var oids []any
+	for _, ext := range cert.Extensions {
+		if ext.Critical == critical {
+			oids = append(oids, object.StringObjectFromGoString(ext.Id.String()))
+		}
+	}
+	set, gerr := javaUtil.NewHashSet(oids)
+	if gerr != nil {
+		return gerr
+	}
+	return set
+}
+
+func x509CertificateGetCriticalExtensionOIDs(params []any) any {
+	return extensionOIDs(thisX509Certificate(params), true)
+}
+
+func x509CertificateGetNonCriticalExtensionOIDs(params []any) any {
+	return extensionOIDs(thisX509Certificate(params), false)
+}
+
+func x509CertificateHasUnsupportedCriticalExtension(params []any) any {
+	return types.ConvertGoBoolToJavaBool(len(thisX509Certificate(params).UnhandledCriticalExtensions) > 0)
+}
+
+// findExtension returns the extension with the given OID, if the certificate has it.
+func findExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) (pkix.Extension, bool) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return ext, true
+		}
+	}
+	return pkix.Extension{}, false
+}
+
+// x509CertificateGetExtensionValue returns the DER-encoded OCTET STRING that holds the value
+// of an extension, or null if the certificate does not have it.
+func x509CertificateGetExtensionValue(params []any) any {
+	oidObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(oidObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "oid is null")
+	}
+	oid, err := parseOID(object.GoStringFromStringObject(oidObj))
+	if err != nil {
+		return object.Null
+	}
+	ext, found := findExtension(thisX509Certificate(params), oid)
+	if !found {
+		return object.Null
+	}
+	encoded, err := asn1.Marshal(ext.Value)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalStateException, err.Error())
+	}
+	return javaBytes(encoded)
+}
+
+// x509CertificateGetKeyUsage returns the nine key usage bits, from digitalSignature to
+// decipherOnly, or null if the certificate has no key usage extension.
+func x509CertificateGetKeyUsage(params []any) any {
+	cert := thisX509Certificate(params)
+	if _, found := findExtension(cert, oidExtensionKeyUsage); !found {
+		return object.Null
+	}
+	bits := make([]types.JavaByte, 9)
+	for i := range bits {
+		if cert.KeyUsage&(1<<i) != 0 {
+			bits[i] = 1
+		}
+	}
+	return object.MakePrimitiveObject(types.BoolArray, types.BoolArray, bits)
+}
+
+// x509CertificateGetExtendedKeyUsage returns the OIDs of the extended key usages as a List of
+// strings, or null if the certificate has no extended key usage extension.
+func x509CertificateGetExtendedKeyUsage(params []any) any {
+	ext, found := findExtension(thisX509Certificate(params), oidExtensionExtendedKeyUsage)
+	if !found {
+		return object.Null
+	}
+	var oids []asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ext.Value, &oids); err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertificateParsingException, err.Error())
+	}
+	usages := make([]any, len(oids))
+	for i, oid := range oids {
+		usages[i] = object.StringObjectFromGoString(oid.String())
+	}
+	return object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, usages)
+}
+
+// x509CertificateGetSubjectAlternativeNames returns the e-mail (1), DNS (2), URI (6) and IP
+// address (7) subject alternative names as a Collection of two-element Lists, each holding
+// the name type as an Integer and the name as a String. It returns null if there are none.
+func x509CertificateGetSubjectAlternativeNames(params []any) any {
+	cert := thisX509Certificate(params)
+	if _, found := findExtension(cert, oidExtensionSubjectAltName); !found {
+		return object.Null
+	}
+	var names []any
+	add := func(nameType int64, name string) {
+		entry := []any{
+			object.MakePrimitiveObject("java/lang/Integer", types.Int, nameType),
+			object.StringObjectFromGoString(name),
+		}
+		names = append(names, object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, entry))
+	}
+	for _, email := range cert.EmailAddresses {
+		add(1, email)
+	}
+	for _, dns := range cert.DNSNames {
+		add(2, dns)
+	}
+	for _, uri := range cert.URIs {
+		add(6, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		add(7, ip.String())
+	}
+	return object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, names)
+}
+
+func x509CertificateGetEncoded(params []any) any {
+	return javaBytes(thisX509Certificate(params).Raw)
+}
+
+func x509CertificateGetTBSCertificate(params []any) any {
+	return javaBytes(thisX509Certificate(params).RawTBSCertificate)
+}
+
+func x509CertificateGetSignature(params []any) any {
+	return javaBytes(thisX509Certificate(params).Signature)
+}
+
+func x509CertificateGetIssuerX500Principal(params []any) any {
+	return NewX500PrincipalObject(thisX509Certificate(params).RawIssuer)
+}
+
+func x509CertificateGetSubjectX500Principal(params []any) any {
+	return NewX500PrincipalObject(thisX509Certificate(params).RawSubject)
+}
+
+func x509CertificateGetNotBefore(params []any) any {
+	return newDateObject(thisX509Certificate(params).NotBefore)
+}
+
+func x509CertificateGetNotAfter(params []any) any {
+	return newDateObject(thisX509Certificate(params).NotAfter)
+}
+
+func x509CertificateGetPublicKey(params []any) any {
+	keyObj, err := NewPublicKeyObject(thisX509Certificate(params).PublicKey)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.CertificateParsingException, err.Error())
+	}
+	return keyObj
+}
+
+func x509CertificateGetSerialNumber(params []any) any {
+	return object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, thisX509Certificate(params).SerialNumber)
+}
+
+func x509CertificateGetVersion(params []any) any {
+	return int64(thisX509Certificate(params).Version)
+}
+
+func x509CertificateGetType(params []any) any {
+	return object.StringObjectFromGoString("X.509")
+}
+
+// signatureAlgorithmOID returns the OID of the signature algorithm named in the outer
+// Certificate structure.
+func signatureAlgorithmOID(cert *x509.Certificate) string {
+	var outer struct {
+		TBS       asn1.RawValue
+		Algorithm pkix.AlgorithmIdentifier
+		Signature asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.Raw, &outer); err != nil {
+		return ""
+	}
+	return outer.Algorithm.Algorithm.String()
+}
+
+func x509CertificateGetSigAlgOID(params []any) any {
+	return object.StringObjectFromGoString(signatureAlgorithmOID(thisX509Certificate(params)))
+}
+
+func x509CertificateGetSigAlgName(params []any) any {
+	oid := signatureAlgorithmOID(thisX509Certificate(params))
+	if name, ok := x509SignatureAlgorithmNames[oid]; ok {
+		return object.StringObjectFromGoString(name)
+	}
+	return object.StringObjectFromGoString(oid)
+}
+
+// verifyCertificateSignature checks that cert was signed with the private key of pub.
+func verifyCertificateSignature(cert *x509.Certificate, pub any) *ghelpers.GErrBlk {
+	issuer := &x509.Certificate{PublicKey: pub}
+	err := issuer.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, x509.ErrUnsupportedAlgorithm):
+		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException, err.Error())
+	case strings.Contains(err.Error(), "public key of type"), strings.Contains(err.Error(), "unsupported public key"):
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, err.Error())
+	}
+	return ghelpers.GetGErrBlk(excNames.SignatureException, "Signature does not match.")
+}
+
+func x509CertificateVerify(params []any) any {
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Public key cannot be null")
+	}
+	if gerr := verifyCertificateSignature(thisX509Certificate(params), keyObj.FieldTable["value"].Fvalue); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// x509CertificateToString gives a short form of the JDK's description of a certificate.
+func x509CertificateToString(params []any) any {
+	cert := thisX509Certificate(params)
+	oid := signatureAlgorithmOID(cert)
+	algName := x509SignatureAlgorithmNames[oid]
+	if algName == "" {
+		algName = oid
+	}
+	subject, _ := formatX500Name(cert.RawSubject, x500FormatRFC1779)
+	issuer, _ := formatX500Name(cert.RawIssuer, x500FormatRFC1779)
+
+	var sb strings.Builder
+	sb.WriteString("[\n[\n")
+	fmt.Fprintf(&sb, "  Version: V%d\n", cert.Version)
+	fmt.Fprintf(&sb, "  Subject: %s\n", subject)
+	fmt.Fprintf(&sb, "  Signature Algorithm: %s, OID = %s\n\n", algName, oid)
+	fmt.Fprintf(&sb, "  Key:  %s public key\n", cert.PublicKeyAlgorithm)
+	fmt.Fprintf(&sb, "  Validity: [From: %s,\n               To: %s]\n",
+		formatJavaDate(cert.NotBefore), formatJavaDate(cert.NotAfter))
+	fmt.Fprintf(&sb, "  Issuer: %s\n", issuer)
+	fmt.Fprintf(&sb, "  SerialNumber: %x\n", cert.SerialNumber)
+	sb.WriteString("]\n]")
+	return object.StringObjectFromGoString(sb.String())
+}
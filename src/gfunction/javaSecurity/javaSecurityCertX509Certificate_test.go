@@ -0,0 +1,249 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testCertificate issues a certificate from template, signed by parent's key, or self-signed
+// if parent is nil.
+func testCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate failed: %v", err)
+	}
+	return cert, key
+}
+
+// testChain returns a root CA, and a leaf certificate issued by it, with their keys.
+func testChain(t *testing.T) (root *x509.Certificate, rootKey *ecdsa.PrivateKey, leaf *x509.Certificate, leafKey *ecdsa.PrivateKey) {
+	t.Helper()
+	now := time.Now()
+	root, rootKey = testCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root", Organization: []string{"Jacobin"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+	}, nil, nil)
+	leaf, leafKey = testCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf.example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(12 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"leaf.example.com"},
+	}, root, rootKey)
+	return
+}
+
+func pemCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func newTestCertificateFactory(t *testing.T) *object.Object {
+	t.Helper()
+	factory, ok := certificateFactoryGetInstance([]any{object.StringObjectFromGoString("X.509")}).(*object.Object)
+	if !ok {
+		t.Fatalf("certificateFactoryGetInstance did not return a factory")
+	}
+	return factory
+}
+
+func TestLoad_Security_Cert_X509Certificate(t *testing.T) {
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Security_Cert_X509Certificate()
+	Load_Security_Cert_CertificateFactory()
+
+	methods := []string{
+		"java/security/cert/CertificateFactory.getInstance(Ljava/lang/String;)Ljava/security/cert/CertificateFactory;",
+		"java/security/cert/CertificateFactory.generateCertificate(Ljava/io/InputStream;)Ljava/security/cert/Certificate;",
+		"java/security/cert/CertificateFactory.generateCertificates(Ljava/io/InputStream;)Ljava/util/Collection;",
+		"java/security/cert/X509Certificate.getPublicKey()Ljava/security/PublicKey;",
+		"java/security/cert/X509Certificate.getSubjectX500Principal()Ljavax/security/auth/x500/X500Principal;",
+		"java/security/cert/X509Certificate.verify(Ljava/security/PublicKey;)V",
+	}
+	for _, m := range methods {
+		if _, ok := ghelpers.MethodSignatures[m]; !ok {
+			t.Errorf("X.509 method signature not registered: %s", m)
+		}
+	}
+}
+
+func TestCertificateFactory_GetInstanceUnknownType(t *testing.T) {
+	globals.InitGlobals("test")
+	res := certificateFactoryGetInstance([]any{object.StringObjectFromGoString("PGP")})
+	gerr, ok := res.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.CertificateException {
+		t.Fatalf("expected CertificateException, got %v", res)
+	}
+}
+
+func TestCertificateFactory_GeneratePEMAndDER(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, _ := testChain(t)
+	factory := newTestCertificateFactory(t)
+
+	for name, data := range map[string][]byte{"PEM": pemCertificate(leaf), "DER": leaf.Raw} {
+		res := certificateFactoryGenerateCertificate([]any{factory, bytes.NewReader(data)})
+		got, ok := x509CertificateFromObject(res)
+		if !ok {
+			t.Fatalf("%s: generateCertificate failed: %v", name, res)
+		}
+		if !got.Equal(leaf) {
+			t.Errorf("%s: parsed certificate differs from the original", name)
+		}
+	}
+
+	// Two certificates in one stream, one PEM and one DER.
+	stream := append(pemCertificate(leaf), root.Raw...)
+	res := certificateFactoryGenerateCertificates([]any{factory, bytes.NewReader(stream)})
+	list, ok := res.(*object.Object)
+	if !ok {
+		t.Fatalf("generateCertificates failed: %v", res)
+	}
+	certs := list.FieldTable["value"].Fvalue.([]any)
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates, got %d", len(certs))
+	}
+	if got, _ := x509CertificateFromObject(certs[1]); !got.Equal(root) {
+		t.Errorf("second certificate is not the root")
+	}
+
+	res = certificateFactoryGenerateCertificate([]any{factory, bytes.NewReader(nil)})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.CertificateException {
+		t.Errorf("expected CertificateException for empty input, got %v", res)
+	}
+}
+
+func TestX509Certificate_Getters(t *testing.T) {
+	globals.InitGlobals("test")
+	_, _, leaf, leafKey := testChain(t)
+	certObj := NewX509CertificateObject(leaf)
+
+	if got := object.GoStringFromStringObject(x509CertificateGetType([]any{certObj}).(*object.Object)); got != "X.509" {
+		t.Errorf("getType: expected X.509, got %s", got)
+	}
+	if got := x509CertificateGetVersion([]any{certObj}); got != int64(3) {
+		t.Errorf("getVersion: expected 3, got %v", got)
+	}
+	serial := x509CertificateGetSerialNumber([]any{certObj}).(*object.Object)
+	if serial.FieldTable["value"].Fvalue.(*big.Int).Int64() != 2 {
+		t.Errorf("getSerialNumber: expected 2")
+	}
+	if got := object.GoStringFromStringObject(x509CertificateGetSigAlgName([]any{certObj}).(*object.Object)); got != "SHA256withECDSA" {
+		t.Errorf("getSigAlgName: expected SHA256withECDSA, got %s", got)
+	}
+
+	subject := x509CertificateGetSubjectX500Principal([]any{certObj}).(*object.Object)
+	if got := object.GoStringFromStringObject(x500PrincipalGetName([]any{subject}).(*object.Object)); got != "CN=leaf.example.com" {
+		t.Errorf("subject: expected CN=leaf.example.com, got %s", got)
+	}
+	issuer := x509CertificateGetIssuerX500Principal([]any{certObj}).(*object.Object)
+	if got := object.GoStringFromStringObject(x500PrincipalGetName([]any{issuer}).(*object.Object)); got != "CN=Test Root,O=Jacobin" {
+		t.Errorf("issuer: expected CN=Test Root,O=Jacobin, got %s", got)
+	}
+
+	pub := x509CertificateGetPublicKey([]any{certObj}).(*object.Object)
+	if !leafKey.PublicKey.Equal(pub.FieldTable["value"].Fvalue) {
+		t.Errorf("getPublicKey does not hold the certificate's key")
+	}
+	if got := object.GoStringFromStringPoolIndex(pub.KlassName); got != types.ClassNameECPublicKey {
+		t.Errorf("getPublicKey: expected %s, got %s", types.ClassNameECPublicKey, got)
+	}
+
+	if got := x509CertificateGetBasicConstraints([]any{certObj}); got != int64(-1) {
+		t.Errorf("getBasicConstraints: expected -1 for an end-entity certificate, got %v", got)
+	}
+	if res := x509CertificateCheckValidity([]any{certObj}); res != nil {
+		t.Errorf("checkValidity: unexpected error %v", res)
+	}
+	future := newDateObject(time.Now().Add(48 * time.Hour))
+	res := x509CertificateCheckValidity([]any{certObj, future})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.CertificateExpiredException {
+		t.Errorf("checkValidity(Date): expected CertificateExpiredException, got %v", res)
+	}
+}
+
+func TestX509Certificate_Verify(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, leafKey := testChain(t)
+	rootPub, _ := NewPublicKeyObject(root.PublicKey)
+	leafPub, _ := NewPublicKeyObject(&leafKey.PublicKey)
+
+	if res := x509CertificateVerify([]any{NewX509CertificateObject(leaf), rootPub}); res != nil {
+		t.Errorf("verify with the issuer's key failed: %v", res)
+	}
+	res := x509CertificateVerify([]any{NewX509CertificateObject(leaf), leafPub})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.SignatureException {
+		t.Errorf("verify with the wrong key: expected SignatureException, got %v", res)
+	}
+}
+
+func TestX500Principal_Names(t *testing.T) {
+	globals.InitGlobals("test")
+	name := "CN=Duke\\, Jr.,OU=JavaSoft,O=Sun Microsystems,C=US"
+	principal := object.MakeEmptyObjectWithClassName(&types.ClassNameX500Principal)
+	if res := x500PrincipalInitString([]any{principal, object.StringObjectFromGoString(name)}); res != nil {
+		t.Fatalf("X500Principal(String) failed: %v", res)
+	}
+
+	tests := []struct {
+		format, expected string
+	}{
+		{"RFC2253", "CN=Duke\\, Jr.,OU=JavaSoft,O=Sun Microsystems,C=US"},
+		{"RFC1779", "CN=\"Duke, Jr.\", OU=JavaSoft, O=Sun Microsystems, C=US"},
+		{"CANONICAL", "cn=duke\\, jr.,ou=javasoft,o=sun microsystems,c=us"},
+	}
+	for _, tt := range tests {
+		got, err := formatX500Name(x500PrincipalDER(principal), tt.format)
+		if err != nil {
+			t.Errorf("%s: %v", tt.format, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.format, tt.expected, got)
+		}
+	}
+
+	other := NewX500PrincipalObject(x500PrincipalDER(principal))
+	if x500PrincipalEquals([]any{principal, other}) != types.JavaBoolTrue {
+		t.Errorf("equal principals compared unequal")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/mlkem"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/asn1"
@@ -120,6 +121,15 @@ func keyGetEncoded(params []any) any {
 		encoded, err = x509.MarshalPKIXPublicKey(k)
 	case *ecdh.PrivateKey:
 		encoded, err = x509.MarshalPKCS8PrivateKey(k)
+	case *mlkem.EncapsulationKey768:
+		encoded = k.Bytes()
+	case *mlkem.EncapsulationKey1024:
+		encoded = k.Bytes()
+	case *mlkem.DecapsulationKey768:
+		// the seed from which the key is expanded
+		encoded = k.Bytes()
+	case *mlkem.DecapsulationKey1024:
+		encoded = k.Bytes()
 	case []types.JavaByte:
 		// Java byte arrays (signed)
 		encoded = object.GoByteArrayFromJavaByteArray(k)
@@ -427,6 +427,31 @@ func keypairgeneratorInitializeWithParmSpec(params []any) any {
 		// Set a keySize if you want (EdDSA curves are fixed size)
 		keySize = 256 // Ed25519 / X25519
 
+	case "ML-KEM", "ML-KEM-768", "ML-KEM-1024":
+		if paramSpecClassName != "java/security/spec/NamedParameterSpec" {
+			return ghelpers.GetGErrBlk(
+				excNames.InvalidAlgorithmParameterException,
+				fmt.Sprintf("ML-KEM requires NamedParameterSpec, got %s", paramSpecClassName),
+			)
+		}
+		name := object.GoStringFromStringObject(paramSpecObj.FieldTable["name"].Fvalue.(*object.Object))
+		switch {
+		case algorithm != "ML-KEM" && name != algorithm:
+			return ghelpers.GetGErrBlk(
+				excNames.InvalidAlgorithmParameterException,
+				fmt.Sprintf("%s does not match %s", name, algorithm),
+			)
+		case name == "ML-KEM-768":
+			keySize = 768
+		case name == "ML-KEM-1024":
+			keySize = 1024
+		default:
+			return ghelpers.GetGErrBlk(
+				excNames.InvalidAlgorithmParameterException,
+				fmt.Sprintf("unsupported NamedParameterSpec name: %s", name),
+			)
+		}
+
 	case "Ed25519", "Ed448", "X25519", "X448", "XDH":
 		// These algorithms don't use parameter specs
 		return ghelpers.GetGErrBlk(
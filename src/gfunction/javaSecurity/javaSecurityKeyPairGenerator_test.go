@@ -7,6 +7,7 @@
 package javaSecurity
 
 import (
+	"crypto/mlkem"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -166,6 +167,45 @@ func TestKeyPairGeneratorInitializeParmSpec(t *testing.T) {
 	}
 }
 
+func TestKeyPairGeneratorMLKEM(t *testing.T) {
+	globals.InitGlobals("test")
+
+	kpgObj := object.MakeEmptyObjectWithClassName(&types.ClassNameKeyPairGenerator)
+	kpgObj.FieldTable["algorithm"] = object.Field{
+		Ftype:  types.StringClassName,
+		Fvalue: object.StringObjectFromGoString("ML-KEM"),
+	}
+	className := "java/security/spec/NamedParameterSpec"
+	specObj := object.MakeEmptyObjectWithClassName(&className)
+	namedParameterSpecInit([]any{specObj, object.StringObjectFromGoString("ML-KEM-1024")})
+
+	if result := keypairgeneratorInitializeWithParmSpec([]any{kpgObj, specObj}); result != nil {
+		t.Fatalf("keypairgeneratorInitializeWithParmSpec failed: %v", result)
+	}
+	keyPairObj, ok := keypairgeneratorGenerateKeyPair([]any{kpgObj}).(*object.Object)
+	if !ok {
+		t.Fatal("keypairgeneratorGenerateKeyPair did not return a KeyPair")
+	}
+	publicKeyObj := keyPairObj.FieldTable["public"].Fvalue.(*object.Object)
+	if _, ok := publicKeyObj.FieldTable["value"].Fvalue.(*mlkem.EncapsulationKey1024); !ok {
+		t.Errorf("Expected an ML-KEM-1024 public key, got %T", publicKeyObj.FieldTable["value"].Fvalue)
+	}
+	encoded := keyGetEncoded([]any{publicKeyObj}).(*object.Object)
+	if n := len(encoded.FieldTable["value"].Fvalue.([]types.JavaByte)); n != mlkem.EncapsulationKeySize1024 {
+		t.Errorf("Expected a %d byte encoding, got %d", mlkem.EncapsulationKeySize1024, n)
+	}
+
+	// a generator of one parameter set cannot be initialized for another
+	kpgObj.FieldTable["algorithm"] = object.Field{
+		Ftype:  types.StringClassName,
+		Fvalue: object.StringObjectFromGoString("ML-KEM-768"),
+	}
+	result := keypairgeneratorInitializeWithParmSpec([]any{kpgObj, specObj})
+	if err, ok := result.(*ghelpers.GErrBlk); !ok || err.ExceptionType != excNames.InvalidAlgorithmParameterException {
+		t.Errorf("Expected InvalidAlgorithmParameterException, got %v", result)
+	}
+}
+
 func TestKeyPairGeneratorGetters(t *testing.T) {
 	globals.InitGlobals("test")
 
@@ -0,0 +1,695 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// KeyStore supports the PKCS12 type, which is also the default type. It holds private key
+// entries, each with its certificate chain, and trusted certificate entries. Secret key
+// entries are not supported. As in the JDK, aliases are not case-sensitive and are kept in
+// lower case, and a private key stays encrypted with its own password until getKey.
+
+// keyStoreEntry is a private key entry (key and chain set) or a trusted certificate entry
+// (trusted set).
+type keyStoreEntry struct {
+	alias    string
+	key      []byte // EncryptedPrivateKeyInfo, or PKCS#8 if plainKey
+	plainKey bool
+	chain    []*x509.Certificate
+	trusted  *x509.Certificate
+	created  time.Time
+}
+
+type keyStoreState struct {
+	loaded  bool
+	entries []*keyStoreEntry
+}
+
+func Load_Security_KeyStore() {
+	ghelpers.MethodSignatures["java/security/KeyStore.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.aliases()Ljava/util/Enumeration;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  keyStoreAliases,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.containsAlias(Ljava/lang/String;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreContainsAlias,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.deleteEntry(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreDeleteEntry,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getCertificate(Ljava/lang/String;)Ljava/security/cert/Certificate;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreGetCertificate,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getCertificateAlias(Ljava/security/cert/Certificate;)Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreGetCertificateAlias,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getCertificateChain(Ljava/lang/String;)[Ljava/security/cert/Certificate;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreGetCertificateChain,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getCreationDate(Ljava/lang/String;)Ljava/util/Date;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreGetCreationDate,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getDefaultType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  keyStoreGetDefaultType,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getInstance(Ljava/lang/String;)Ljava/security/KeyStore;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getInstance(Ljava/lang/String;Ljava/lang/String;)Ljava/security/KeyStore;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  keyStoreGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljava/security/KeyStore;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  keyStoreGetInstance,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getKey(Ljava/lang/String;[C)Ljava/security/Key;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  keyStoreGetKey,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getProvider()Ljava/security/Provider;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  certificateFactoryGetProvider,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.getType()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  keyStoreGetType,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.isCertificateEntry(Ljava/lang/String;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreIsCertificateEntry,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.isKeyEntry(Ljava/lang/String;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  keyStoreIsKeyEntry,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.load(Ljava/io/InputStream;[C)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   2,
+			GFunction:    keyStoreLoad,
+			NeedsContext: true,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.setCertificateEntry(Ljava/lang/String;Ljava/security/cert/Certificate;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  keyStoreSetCertificateEntry,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.setKeyEntry(Ljava/lang/String;Ljava/security/Key;[C[Ljava/security/cert/Certificate;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 4,
+			GFunction:  keyStoreSetKeyEntry,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.size()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  keyStoreSize,
+		}
+
+	ghelpers.MethodSignatures["java/security/KeyStore.store(Ljava/io/OutputStream;[C)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   2,
+			GFunction:    keyStoreStore,
+			NeedsContext: true,
+		}
+}
+
+func keyStoreGetDefaultType(params []any) any {
+	return object.StringObjectFromGoString("pkcs12")
+}
+
+func keyStoreGetInstance(params []any) any {
+	typeObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(typeObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null type name")
+	}
+	ksType := object.GoStringFromStringObject(typeObj)
+	if !strings.EqualFold(ksType, "PKCS12") {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, ksType+" not found")
+	}
+	ks := object.MakeEmptyObjectWithClassName(&types.ClassNameKeyStore)
+	ks.FieldTable["type"] = object.Field{Ftype: types.StringClassRef, Fvalue: typeObj}
+	ks.FieldTable["state"] = object.Field{Ftype: types.RawGoPointer, Fvalue: &keyStoreState{}}
+	return ks
+}
+
+func keyStoreGetType(params []any) any {
+	return params[0].(*object.Object).FieldTable["type"].Fvalue
+}
+
+// loadedKeyStore returns the state of a key store, which must have been loaded.
+func loadedKeyStore(ks *object.Object) (*keyStoreState, *ghelpers.GErrBlk) {
+	state, ok := ks.FieldTable["state"].Fvalue.(*keyStoreState)
+	if !ok || !state.loaded {
+		return nil, ghelpers.GetGErrBlk(excNames.KeyStoreException, "Uninitialized keystore")
+	}
+	return state, nil
+}
+
+// keyStoreEntryArgs returns the state of the key store in params[0] and the lower-case alias in params[1].
+func keyStoreEntryArgs(params []any) (*keyStoreState, string, *ghelpers.GErrBlk) {
+	state, gerr := loadedKeyStore(params[0].(*object.Object))
+	if gerr != nil {
+		return nil, "", gerr
+	}
+	aliasObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(aliasObj) {
+		return nil, "", ghelpers.GetGErrBlk(excNames.NullPointerException, "alias must not be null")
+	}
+	return state, strings.ToLower(object.GoStringFromStringObject(aliasObj)), nil
+}
+
+func (state *keyStoreState) find(alias string) *keyStoreEntry {
+	for _, entry := range state.entries {
+		if entry.alias == alias {
+			return entry
+		}
+	}
+	return nil
+}
+
+// put adds an entry, replacing any entry with the same alias.
+func (state *keyStoreState) put(entry *keyStoreEntry) {
+	for i, e := range state.entries {
+		if e.alias == entry.alias {
+			state.entries[i] = entry
+			return
+		}
+	}
+	state.entries = append(state.entries, entry)
+}
+
+// PasswordRunes converts a Java char[] to runes. A null char[] gives nil.
+func PasswordRunes(arg any) []rune {
+	pwObj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(pwObj) {
+		return nil
+	}
+	chars, _ := pwObj.FieldTable["value"].Fvalue.([]int64)
+	runes := make([]rune, len(chars))
+	for i, c := range chars {
+		runes[i] = rune(c)
+	}
+	return runes
+}
+
+// keyStoreLoad loads the store from a stream, or with a null stream, initialises it empty.
+func keyStoreLoad(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	ks := params[0].(*object.Object)
+	state := ks.FieldTable["state"].Fvalue.(*keyStoreState)
+	if object.IsNull(params[1]) {
+		*state = keyStoreState{loaded: true}
+		return nil
+	}
+
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "KeyStore.load")
+	if gerr != nil {
+		return gerr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	contents, err := decodePKCS12(data, PasswordRunes(params[2]))
+	switch {
+	case errors.Is(err, errPKCS12MAC), errors.Is(err, errPKCS12Decrypt):
+		return ghelpers.GetGErrBlk(excNames.IOException, "keystore password was incorrect")
+	case err != nil:
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+
+	loaded := keyStoreState{loaded: true}
+	for _, key := range contents.keys {
+		entry := &keyStoreEntry{
+			alias:    strings.ToLower(key.friendlyName),
+			key:      key.encrypted,
+			plainKey: key.plain,
+			created:  creationTime(key.localKeyID),
+		}
+		if entry.alias == "" {
+			entry.alias = hex.EncodeToString(key.localKeyID)
+		}
+		// The chain starts with the certificate that shares the key's local key ID, and then
+		// follows issuers among the store's certificates.
+		for _, c := range contents.certs {
+			if key.localKeyID != nil && bytes.Equal(c.localKeyID, key.localKeyID) {
+				entry.chain = append(entry.chain, c.cert)
+				break
+			}
+		}
+		for len(entry.chain) > 0 && len(entry.chain) <= len(contents.certs) {
+			last := entry.chain[len(entry.chain)-1]
+			if bytes.Equal(last.RawIssuer, last.RawSubject) {
+				break
+			}
+			var issuer *x509.Certificate
+			for _, c := range contents.certs {
+				if bytes.Equal(c.cert.RawSubject, last.RawIssuer) {
+					issuer = c.cert
+					break
+				}
+			}
+			if issuer == nil {
+				break
+			}
+			entry.chain = append(entry.chain, issuer)
+		}
+		loaded.put(entry)
+	}
+	for _, c := range contents.certs {
+		if !c.trusted {
+			continue
+		}
+		alias := strings.ToLower(c.friendlyName)
+		if alias == "" {
+			alias = strings.ToLower(c.cert.Subject.String())
+		}
+		loaded.put(&keyStoreEntry{alias: alias, trusted: c.cert, created: creationTime(c.localKeyID)})
+	}
+	*state = loaded
+	return nil
+}
+
+// creationTime recovers the creation date from a JDK local key ID of the form "Time <millis>".
+func creationTime(localKeyID []byte) time.Time {
+	if millis, found := strings.CutPrefix(string(localKeyID), "Time "); found {
+		if ms, err := strconv.ParseInt(millis, 10, 64); err == nil {
+			return time.UnixMilli(ms)
+		}
+	}
+	return time.Now()
+}
+
+func keyStoreStore(params []any) any {
+	fs, params := ghelpers.SplitContext(params)
+	state, gerr := loadedKeyStore(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if object.IsNull(params[1]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "output stream is null")
+	}
+	password := PasswordRunes(params[2])
+
+	contents := &pkcs12Contents{}
+	for _, entry := range state.entries {
+		localKeyID := []byte(fmt.Sprintf("Time %d", entry.created.UnixMilli()))
+		if entry.trusted != nil {
+			contents.certs = append(contents.certs, pkcs12Cert{
+				friendlyName: entry.alias, trusted: true, cert: entry.trusted,
+			})
+			continue
+		}
+		key := entry.key
+		if entry.plainKey {
+			// An unencrypted key bag read from another store is stored encrypted with the
+			// store password.
+			var err error
+			if key, err = encryptPrivateKey(entry.key, password); err != nil {
+				return ghelpers.GetGErrBlk(excNames.KeyStoreException, err.Error())
+			}
+		}
+		contents.keys = append(contents.keys, pkcs12Key{friendlyName: entry.alias, localKeyID: localKeyID, encrypted: key})
+		for i, cert := range entry.chain {
+			c := pkcs12Cert{cert: cert}
+			if i == 0 {
+				c.friendlyName, c.localKeyID = entry.alias, localKeyID
+			}
+			contents.certs = append(contents.certs, c)
+		}
+	}
+
+	data, err := encodePKCS12(contents, password)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, err.Error())
+	}
+	w, gerr := ghelpers.GoWriterWithContext(fs, params[1], "KeyStore.store")
+	if gerr != nil {
+		return gerr
+	}
+	if _, err = w.Write(data); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		if err = f.Flush(); err != nil {
+			return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+		}
+	}
+	return nil
+}
+
+func keyStoreGetKey(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	entry := state.find(alias)
+	if entry == nil || entry.key == nil {
+		return object.Null
+	}
+	priv, gerr := entry.privateKey(PasswordRunes(params[2]))
+	if gerr != nil {
+		return gerr
+	}
+	keyObj, err := NewPrivateKeyObject(priv)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, "Get Key failed: "+err.Error())
+	}
+	return keyObj
+}
+
+// privateKey decrypts the key of a private key entry with password.
+func (entry *keyStoreEntry) privateKey(password []rune) (any, *ghelpers.GErrBlk) {
+	pkcs8 := entry.key
+	if !entry.plainKey {
+		if password == nil {
+			return nil, ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, "Get Key failed: null password")
+		}
+		var err error
+		if pkcs8, err = decryptPrivateKey(entry.key, password); err != nil {
+			if errors.Is(err, errPKCS12Decrypt) {
+				return nil, ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, "Get Key failed: Given final "+
+					"block not properly padded. Such issues can arise if a bad key is used during decryption.")
+			}
+			return nil, ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, "Get Key failed: "+err.Error())
+		}
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(pkcs8)
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, "Get Key failed: "+err.Error())
+	}
+	return priv, nil
+}
+
+// KeyStoreKeyEntry is a private key entry of a KeyStore, with its key decrypted.
+type KeyStoreKeyEntry struct {
+	Alias string
+	Key   crypto.Signer
+	Chain []*x509.Certificate
+}
+
+// KeyStoreKeyEntries returns the private key entries of a loaded KeyStore, decrypted with
+// password, which the key managers of javax.net.ssl use.
+func KeyStoreKeyEntries(ks *object.Object, password []rune) ([]KeyStoreKeyEntry, *ghelpers.GErrBlk) {
+	state, gerr := loadedKeyStore(ks)
+	if gerr != nil {
+		return nil, gerr
+	}
+	var entries []KeyStoreKeyEntry
+	for _, entry := range state.entries {
+		if entry.key == nil {
+			continue
+		}
+		priv, gerr := entry.privateKey(password)
+		if gerr != nil {
+			return nil, gerr
+		}
+		signer, ok := priv.(crypto.Signer)
+		if !ok {
+			return nil, ghelpers.GetGErrBlk(excNames.UnrecoverableKeyException, fmt.Sprintf("unsupported key type %T", priv))
+		}
+		entries = append(entries, KeyStoreKeyEntry{Alias: entry.alias, Key: signer, Chain: entry.chain})
+	}
+	return entries, nil
+}
+
+// KeyStoreTrustedCertificates returns the trusted certificate entries of a loaded KeyStore.
+func KeyStoreTrustedCertificates(ks *object.Object) ([]*x509.Certificate, *ghelpers.GErrBlk) {
+	state, gerr := loadedKeyStore(ks)
+	if gerr != nil {
+		return nil, gerr
+	}
+	var certs []*x509.Certificate
+	for _, entry := range state.entries {
+		if entry.trusted != nil {
+			certs = append(certs, entry.trusted)
+		}
+	}
+	return certs, nil
+}
+
+// keyStoreSetKeyEntry encrypts a private key with its password and stores it with its chain.
+func keyStoreSetKeyEntry(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	keyObj, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "key must not be null")
+	}
+	password := PasswordRunes(params[3])
+	if password == nil {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, "non-null password required to create PrivateKeyEntry")
+	}
+	chain, gerr := certificateArray(params[4])
+	if gerr != nil {
+		return gerr
+	}
+	if len(chain) == 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Private key must be accompanied by certificate chain")
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(keyObj.FieldTable["value"].Fvalue)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, "Key protection algorithm not supported: "+err.Error())
+	}
+	encrypted, err := encryptPrivateKey(pkcs8, password)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, err.Error())
+	}
+	state.put(&keyStoreEntry{alias: alias, key: encrypted, chain: chain, created: time.Now()})
+	return nil
+}
+
+// certificateArray returns the certificates in a Certificate[] argument.
+func certificateArray(arg any) ([]*x509.Certificate, *ghelpers.GErrBlk) {
+	arrObj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(arrObj) {
+		return nil, nil
+	}
+	elements, _ := arrObj.FieldTable["value"].Fvalue.([]*object.Object)
+	chain := make([]*x509.Certificate, len(elements))
+	for i, elem := range elements {
+		cert, ok := x509CertificateFromObject(elem)
+		if !ok {
+			return nil, ghelpers.GetGErrBlk(excNames.KeyStoreException, "Certificate chain is not valid")
+		}
+		chain[i] = cert
+	}
+	return chain, nil
+}
+
+func keyStoreSetCertificateEntry(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	cert, ok := x509CertificateFromObject(params[2])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, "Only X.509 certificates are supported")
+	}
+	if entry := state.find(alias); entry != nil && entry.key != nil {
+		return ghelpers.GetGErrBlk(excNames.KeyStoreException, "Cannot overwrite own certificate")
+	}
+	state.put(&keyStoreEntry{alias: alias, trusted: cert, created: time.Now()})
+	return nil
+}
+
+func keyStoreDeleteEntry(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	for i, entry := range state.entries {
+		if entry.alias == alias {
+			state.entries = append(state.entries[:i], state.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func keyStoreGetCertificate(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	if cert := state.find(alias).certificate(); cert != nil {
+		return NewX509CertificateObject(cert)
+	}
+	return object.Null
+}
+
+// certificate returns the trusted certificate of an entry or the first in its chain.
+func (entry *keyStoreEntry) certificate() *x509.Certificate {
+	switch {
+	case entry == nil:
+		return nil
+	case entry.trusted != nil:
+		return entry.trusted
+	case len(entry.chain) > 0:
+		return entry.chain[0]
+	}
+	return nil
+}
+
+func keyStoreGetCertificateChain(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	entry := state.find(alias)
+	if entry == nil || entry.key == nil || len(entry.chain) == 0 {
+		return object.Null
+	}
+	certObjs := make([]*object.Object, len(entry.chain))
+	for i, cert := range entry.chain {
+		certObjs[i] = NewX509CertificateObject(cert)
+	}
+	return object.MakePrimitiveObject("[Ljava/security/cert/Certificate;", types.RefArray, certObjs)
+}
+
+func keyStoreGetCertificateAlias(params []any) any {
+	state, gerr := loadedKeyStore(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	cert, ok := x509CertificateFromObject(params[1])
+	if !ok {
+		return object.Null
+	}
+	for _, entry := range state.entries {
+		if c := entry.certificate(); c != nil && c.Equal(cert) {
+			return object.StringObjectFromGoString(entry.alias)
+		}
+	}
+	return object.Null
+}
+
+func keyStoreGetCreationDate(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	entry := state.find(alias)
+	if entry == nil {
+		return object.Null
+	}
+	return newDateObject(entry.created)
+}
+
+func keyStoreAliases(params []any) any {
+	state, gerr := loadedKeyStore(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	aliases := make([]any, len(state.entries))
+	for i, entry := range state.entries {
+		aliases[i] = object.StringObjectFromGoString(entry.alias)
+	}
+	return javaUtil.NewEnumeration(aliases)
+}
+
+func keyStoreContainsAlias(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(state.find(alias) != nil)
+}
+
+func keyStoreIsKeyEntry(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	entry := state.find(alias)
+	return types.ConvertGoBoolToJavaBool(entry != nil && entry.key != nil)
+}
+
+func keyStoreIsCertificateEntry(params []any) any {
+	state, alias, gerr := keyStoreEntryArgs(params)
+	if gerr != nil {
+		return gerr
+	}
+	entry := state.find(alias)
+	return types.ConvertGoBoolToJavaBool(entry != nil && entry.trusted != nil)
+}
+
+func keyStoreSize(params []any) any {
+	state, gerr := loadedKeyStore(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(state.entries))
+}
@@ -0,0 +1,252 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+// The fixtures hold an EC key and its self-signed certificate (CN=Fixture, O=Jacobin), exported
+// by OpenSSL 3 with the password "changeit": first with the default PBES2 and AES-256 encryption,
+// then with the legacy PKCS#12 3DES encryption and a SHA-1 MAC.
+const (
+	keyStoreFixturePBES2 = "MIIEawIBAzCCBCEGCSqGSIb3DQEHAaCCBBIEggQOMIIECjCCAqIGCSqGSIb3DQEHBqCCApMwggKP" +
+		"AgEAMIICiAYJKoZIhvcNAQcBMFcGCSqGSIb3DQEFDTBKMCkGCSqGSIb3DQEFDDAcBAjip4/I3dpF" +
+		"yQICCAAwDAYIKoZIhvcNAgkFADAdBglghkgBZQMEASoEEKXQAKIzZBzlvgsAyqzjC8KAggIgrLly" +
+		"3do1/mabIn2hesj7a+dOJhD18lBxxbkFh9tL2EiFjVorWSpRsKn8DvQIsIuWQGPIF2I4Wl9GNM3d" +
+		"L7gHE7T8LOdREiw6v0H2fIIRTw00gwUEoWaX6r27S1pBGwBWY9FwPuNfUs8yWmC46+QZ9yoRYyX9" +
+		"3BzJGuQ5Emyqu7BFtuM1hWtQ2AdYFOO6GuRKFv/+s/B7g38aaRmg2brbmu86j+AisvLcwOulei9z" +
+		"gTUah3oGHJ4mPXEVMVcvKb7MjHl0b3gEsS3GBWl6jcnz9W94PVLI6Ah82xopftLuqkl/8YNLMlVJ" +
+		"B/6yU9WKvGFfF5fp9vVJjZhD6E+Q9XxNELUeQ8N3YiMFj8XsGJrh5qk7o0aTIZCJzdZ5VRCG5KKq" +
+		"h3KcTYHD0/NTJc/ekJFbPpgNeFuLRTA0xnvQ60ca8JRLBapYXOG6Hng1g32eGvV2cAuozjl6rOlB" +
+		"JRU3PV2DBt30k6wjHTuDW5dHcrrQoHWY0C8b04QDrxe1nTes9z+1OUndCDOzp/tRrbnXAva6Ovut" +
+		"4tyB0jXXK1sm22Co0fy/o93tzy2Bz1pPLpvYyTZu3sXzNSuuaDYKFQk4mJMF/V7Aq8rpQv12hFcD" +
+		"KREvWIUZHrokb12De9qXrZ4OKO06dfalf3Li2RXCVBZ3+uKbGZ5c7pO5A0PIyJzhhUTgXqOMaXm7" +
+		"loAILJXWXUhdFVUIO6UGyByCAa63aUEO/VE/PDCCAWAGCSqGSIb3DQEHAaCCAVEEggFNMIIBSTCC" +
+		"AUUGCyqGSIb3DQEMCgECoIHvMIHsMFcGCSqGSIb3DQEFDTBKMCkGCSqGSIb3DQEFDDAcBAhdqS4T" +
+		"o6/VvgICCAAwDAYIKoZIhvcNAgkFADAdBglghkgBZQMEASoEEM4kAHDGiXKVg38m/M3yWSQEgZAN" +
+		"aQkF3NvV3QfyFLwCocy+Ks9oKIDh3WeHMGIYREWUaY1P+x8uuDEcJ1xMokcAs4jkNCGXkg9pbT+m" +
+		"d/LBYwT7BEHxrfaR+1mV+B7APL5dxVmq5XsPatLrgQO5hVlPDBUSGqjTY5Uj/PUXIflqJUelnQyE" +
+		"6F3SUvkkUNXkXFTcVCSIZOBroF6nqXYKrR3Mp0ExRDAdBgkqhkiG9w0BCRQxEB4OAGYAaQB4AHQA" +
+		"dQByAGUwIwYJKoZIhvcNAQkVMRYEFBCNUoaEwTnwoMjMV60Fq+WEdmMtMEEwMTANBglghkgBZQME" +
+		"AgEFAAQgYHQH0vH/duUbrDjehUF4DZGpHI330PZl2VutGBtp+1wECJH4+SI8UH2VAgIIAA=="
+
+	keyStoreFixtureLegacy = "MIID4wIBAzCCA6kGCSqGSIb3DQEHAaCCA5oEggOWMIIDkjCCAmcGCSqGSIb3DQEHBqCCAlgwggJU" +
+		"AgEAMIICTQYJKoZIhvcNAQcBMBwGCiqGSIb3DQEMAQMwDgQIsSiVbkZ5LTcCAggAgIICIOAyZ5FV" +
+		"kcRDkbhv4wagK6ozVbE6q/gC+qwdImAc511vCKYoJiIIfD7hec17kRDkZ129/YT6G110jt/Q5LsF" +
+		"MhXa03+iH20wjYX1qzy4ej5hV8L11HUnXr2nH6FJOp2Z5ZW0jVXDmiTs98cdKX+L/0z4VB1eVMcF" +
+		"5S94trq/Ge36MUBJvuNXtirflsCYskQ6HW0SfmPf5T/8p9P+LPRmrzdztiYv0AMhTFha2WlyNHIZ" +
+		"TnvdWNYaDb2Vi4vHH64QvWxZ/Ib09XaWMIR/BrDlhrwQMk7dolFLs+w2ITy55JSWNq3oLMhlK0iE" +
+		"Cq398f0W3cUiTpcrRGDjfWuXdANkfzM5b1wTyqqQgosRhoeNWHfGzG//JeYKonkREvoaxjeZ0LAN" +
+		"Hv2ZPv2mUoNhzuIiPTXGxkRt/vmR0SrmqCQWrUlWOGhRe1OhQSczrqPBP3bT98UjEQwAKRREiTTo" +
+		"skOUdm1m5TCszUJLwkggaZz8UEsMDEbnmCsFiog8uiPmUroqqDSH8ASx+bNp3tvXPGJvzVHGb33+" +
+		"wBjl94PgUAFZBOo1pSyYTQwKhEu9M2IvLNGH7ISZeiMHhaBJbe1KlzWCWAOJLJDkUJxNd4FC+Wa7" +
+		"frkBZod9LHyrGX4bmMDUbRSFmTlxEvOWo/bIutLHjX3gZWQPn3h9gghRlMI6RFBC1ZMOQJX/e4YC" +
+		"XFYRiOzCaoDTJu4RnaS7hejt8/z+vkwSLC0wggEjBgkqhkiG9w0BBwGgggEUBIIBEDCCAQwwggEI" +
+		"BgsqhkiG9w0BDAoBAqCBtDCBsTAcBgoqhkiG9w0BDAEDMA4ECAppunDQeiOwAgIIAASBkLEMF9Mq" +
+		"UTYoIQTozo5P3976neyVaO7++IDHaPKQLse9EaK2i1TsJ2tZyDuI09tW/BFEy1zMhNHjUVELGtIn" +
+		"pg+i2T6MEGmh9t166hPljqm+R5vlsovpZUJEDNGB07ll3maD021rC0y6Jm5Q0PTDRR19usFA8OZl" +
+		"D4W3fZfBorm3Rg/pfHjNb8QUcWRm4Tv26jFCMBsGCSqGSIb3DQEJFDEOHgwAbABlAGcAYQBjAHkw" +
+		"IwYJKoZIhvcNAQkVMRYEFBCNUoaEwTnwoMjMV60Fq+WEdmMtMDEwITAJBgUrDgMCGgUABBQCltuS" +
+		"i/BYXwWF+aWnZHbDYRWZxwQIteOJYdMFxi0CAggA"
+)
+
+func javaChars(s string) *object.Object {
+	var chars []int64
+	for _, r := range s {
+		chars = append(chars, int64(r))
+	}
+	return object.MakePrimitiveObject("[C", types.CharArray, chars)
+}
+
+func newTestKeyStore(t *testing.T) *object.Object {
+	t.Helper()
+	ks, ok := keyStoreGetInstance([]any{object.StringObjectFromGoString("PKCS12")}).(*object.Object)
+	if !ok {
+		t.Fatalf("KeyStore.getInstance(PKCS12) failed")
+	}
+	return ks
+}
+
+func TestLoad_Security_KeyStore(t *testing.T) {
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Security_KeyStore()
+
+	methods := []string{
+		"java/security/KeyStore.getInstance(Ljava/lang/String;)Ljava/security/KeyStore;",
+		"java/security/KeyStore.load(Ljava/io/InputStream;[C)V",
+		"java/security/KeyStore.store(Ljava/io/OutputStream;[C)V",
+		"java/security/KeyStore.getKey(Ljava/lang/String;[C)Ljava/security/Key;",
+		"java/security/KeyStore.getCertificate(Ljava/lang/String;)Ljava/security/cert/Certificate;",
+		"java/security/KeyStore.setKeyEntry(Ljava/lang/String;Ljava/security/Key;[C[Ljava/security/cert/Certificate;)V",
+	}
+	for _, m := range methods {
+		if _, ok := ghelpers.MethodSignatures[m]; !ok {
+			t.Errorf("KeyStore method signature not registered: %s", m)
+		}
+	}
+}
+
+func TestKeyStore_UninitializedAndUnknownType(t *testing.T) {
+	globals.InitGlobals("test")
+	res := keyStoreGetInstance([]any{object.StringObjectFromGoString("JKS")})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.KeyStoreException {
+		t.Errorf("getInstance(JKS): expected KeyStoreException, got %v", res)
+	}
+
+	ks := newTestKeyStore(t)
+	res = keyStoreSize([]any{ks})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.KeyStoreException {
+		t.Errorf("size before load: expected KeyStoreException, got %v", res)
+	}
+}
+
+func TestKeyStore_LoadOpenSSLFixtures(t *testing.T) {
+	globals.InitGlobals("test")
+	for alias, fixture := range map[string]string{"fixture": keyStoreFixturePBES2, "legacy": keyStoreFixtureLegacy} {
+		data, _ := base64.StdEncoding.DecodeString(fixture)
+		ks := newTestKeyStore(t)
+		if res := keyStoreLoad([]any{ks, bytes.NewReader(data), javaChars("changeit")}); res != nil {
+			t.Fatalf("%s: load failed: %v", alias, res)
+		}
+		aliasObj := object.StringObjectFromGoString(alias)
+		if keyStoreIsKeyEntry([]any{ks, aliasObj}) != types.JavaBoolTrue {
+			t.Fatalf("%s: expected a key entry", alias)
+		}
+
+		keyObj, ok := keyStoreGetKey([]any{ks, aliasObj, javaChars("changeit")}).(*object.Object)
+		if !ok {
+			t.Fatalf("%s: getKey failed", alias)
+		}
+		priv, ok := keyObj.FieldTable["value"].Fvalue.(*ecdsa.PrivateKey)
+		if !ok {
+			t.Fatalf("%s: expected an EC private key, got %T", alias, keyObj.FieldTable["value"].Fvalue)
+		}
+		cert, ok := x509CertificateFromObject(keyStoreGetCertificate([]any{ks, aliasObj}))
+		if !ok {
+			t.Fatalf("%s: getCertificate failed", alias)
+		}
+		if !priv.PublicKey.Equal(cert.PublicKey) {
+			t.Errorf("%s: the key does not match the certificate", alias)
+		}
+		if cert.Subject.CommonName != "Fixture" {
+			t.Errorf("%s: expected CN=Fixture, got %s", alias, cert.Subject.CommonName)
+		}
+
+		res := keyStoreGetKey([]any{ks, aliasObj, javaChars("wrong")})
+		if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.UnrecoverableKeyException {
+			t.Errorf("%s: getKey with the wrong password: expected UnrecoverableKeyException, got %v", alias, res)
+		}
+	}
+}
+
+func TestKeyStore_LoadWrongPassword(t *testing.T) {
+	globals.InitGlobals("test")
+	data, _ := base64.StdEncoding.DecodeString(keyStoreFixturePBES2)
+	ks := newTestKeyStore(t)
+	res := keyStoreLoad([]any{ks, bytes.NewReader(data), javaChars("wrong")})
+	gerr, ok := res.(*ghelpers.GErrBlk)
+	if !ok || gerr.ExceptionType != excNames.IOException || gerr.ErrMsg != "keystore password was incorrect" {
+		t.Errorf("expected IOException \"keystore password was incorrect\", got %v", res)
+	}
+}
+
+func TestKeyStore_StoreAndReload(t *testing.T) {
+	globals.InitGlobals("test")
+	root, _, leaf, leafKey := testChain(t)
+
+	ks := newTestKeyStore(t)
+	if res := keyStoreLoad([]any{ks, object.Null, object.Null}); res != nil {
+		t.Fatalf("load(null, null) failed: %v", res)
+	}
+	keyObj, err := NewPrivateKeyObject(leafKey)
+	if err != nil {
+		t.Fatalf("NewPrivateKeyObject failed: %v", err)
+	}
+	chain := object.MakePrimitiveObject("[Ljava/security/cert/Certificate;", types.RefArray,
+		[]*object.Object{NewX509CertificateObject(leaf), NewX509CertificateObject(root)})
+	res := keyStoreSetKeyEntry([]any{ks, object.StringObjectFromGoString("Server"), keyObj, javaChars("keypass"), chain})
+	if res != nil {
+		t.Fatalf("setKeyEntry failed: %v", res)
+	}
+	res = keyStoreSetCertificateEntry([]any{ks, object.StringObjectFromGoString("root"), NewX509CertificateObject(root)})
+	if res != nil {
+		t.Fatalf("setCertificateEntry failed: %v", res)
+	}
+	res = keyStoreSetCertificateEntry([]any{ks, object.StringObjectFromGoString("server"), NewX509CertificateObject(root)})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.KeyStoreException {
+		t.Errorf("setCertificateEntry over a key entry: expected KeyStoreException, got %v", res)
+	}
+
+	var out bytes.Buffer
+	if res = keyStoreStore([]any{ks, &out, javaChars("storepass")}); res != nil {
+		t.Fatalf("store failed: %v", res)
+	}
+
+	reloaded := newTestKeyStore(t)
+	if res = keyStoreLoad([]any{reloaded, bytes.NewReader(out.Bytes()), javaChars("storepass")}); res != nil {
+		t.Fatalf("reload failed: %v", res)
+	}
+	if got := keyStoreSize([]any{reloaded}); got != int64(2) {
+		t.Errorf("expected 2 entries, got %v", got)
+	}
+	server := object.StringObjectFromGoString("server")
+	if keyStoreIsKeyEntry([]any{reloaded, server}) != types.JavaBoolTrue {
+		t.Errorf("server is not a key entry")
+	}
+	if keyStoreIsCertificateEntry([]any{reloaded, object.StringObjectFromGoString("ROOT")}) != types.JavaBoolTrue {
+		t.Errorf("root is not a certificate entry")
+	}
+
+	keyObj, ok := keyStoreGetKey([]any{reloaded, server, javaChars("keypass")}).(*object.Object)
+	if !ok || !leafKey.Equal(keyObj.FieldTable["value"].Fvalue) {
+		t.Fatalf("getKey did not return the stored key")
+	}
+	chainObj, ok := keyStoreGetCertificateChain([]any{reloaded, server}).(*object.Object)
+	if !ok {
+		t.Fatalf("getCertificateChain failed")
+	}
+	certObjs := chainObj.FieldTable["value"].Fvalue.([]*object.Object)
+	if len(certObjs) != 2 {
+		t.Fatalf("expected a chain of 2 certificates, got %d", len(certObjs))
+	}
+	if got, _ := x509CertificateFromObject(certObjs[1]); !got.Equal(root) {
+		t.Errorf("the chain does not end with the root")
+	}
+	if alias := keyStoreGetCertificateAlias([]any{reloaded, NewX509CertificateObject(leaf)}).(*object.Object); object.GoStringFromStringObject(alias) != "server" {
+		t.Errorf("getCertificateAlias: expected server, got %s", object.GoStringFromStringObject(alias))
+	}
+
+	if res = keyStoreDeleteEntry([]any{reloaded, server}); res != nil {
+		t.Fatalf("deleteEntry failed: %v", res)
+	}
+	if keyStoreContainsAlias([]any{reloaded, server}) != types.JavaBoolFalse {
+		t.Errorf("server still present after deleteEntry")
+	}
+}
+
+func TestKeyStore_SetKeyEntryWithoutChain(t *testing.T) {
+	globals.InitGlobals("test")
+	_, _, _, leafKey := testChain(t)
+	ks := newTestKeyStore(t)
+	keyStoreLoad([]any{ks, object.Null, object.Null})
+	keyObj, _ := NewPrivateKeyObject(leafKey)
+
+	res := keyStoreSetKeyEntry([]any{ks, object.StringObjectFromGoString("k"), keyObj, javaChars("pw"), object.Null})
+	if gerr, ok := res.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("expected IllegalArgumentException, got %v", res)
+	}
+}
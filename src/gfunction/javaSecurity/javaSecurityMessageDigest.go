@@ -108,6 +108,22 @@ func Load_Security_MessageDigest() {
 
 	ghelpers.MethodSignatures["java/security/MessageDigest.digest([BII)I"] =
 		ghelpers.GMeth{ParamSlots: 3, GFunction: msgdigDigestBytesII}
+
+	// Digests of providers written in Java run on their MessageDigestSpi.
+	InstallSpiDispatch(SpiEngine{
+		ClassName:   types.ClassNameMessageDigest,
+		ServiceType: "MessageDigest",
+		Methods: map[string]SpiFunc{
+			"digest()[B":         SpiCall("engineDigest", "()[B"),
+			"digest([B)[B":       SpiThen(SpiCall("engineUpdate", "([BII)V", SpiWholeArray), SpiCall("engineDigest", "()[B")),
+			"digest([BII)I":      SpiCall("engineDigest", "([BII)I"),
+			"getDigestLength()I": SpiCall("engineGetDigestLength", "()I"),
+			"reset()V":           SpiCall("engineReset", "()V"),
+			"update(B)V":         SpiCall("engineUpdate", "(B)V"),
+			"update([B)V":        SpiCall("engineUpdate", "([BII)V", SpiWholeArray),
+			"update([BII)V":      SpiCall("engineUpdate", "([BII)V"),
+		},
+	})
 }
 
 // ===================== Helper Functions =====================
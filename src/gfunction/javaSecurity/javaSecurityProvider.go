@@ -21,14 +21,14 @@ func Load_Security_Provider() {
 	ghelpers.MethodSignatures["java/security/Provider.<clinit>()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  securityProviderInit,
+			GFunction:  ghelpers.ClinitGeneric,
 		}
 
 	// ---------- Constructors ----------
 	ghelpers.MethodSignatures["java/security/Provider.<init>(Ljava/lang/String;DLjava/lang/String;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 3,
-			GFunction:  ghelpers.TrapDeprecated,
+			GFunction:  securityProviderInit,
 		}
 
 	ghelpers.MethodSignatures["java/security/Provider.<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V"] =
@@ -49,7 +49,7 @@ func Load_Security_Provider() {
 		ghelpers.GMeth{ParamSlots: 0, GFunction: securityProviderGetName}
 
 	ghelpers.MethodSignatures["java/security/Provider.getProperty(Ljava/lang/String;)Ljava/lang/String;"] =
-		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 1, GFunction: securityProviderGetProperty}
 
 	ghelpers.MethodSignatures["java/security/Provider.getService(Ljava/lang/String;Ljava/lang/String;)Ljava/security/Provider$Service;"] =
 		ghelpers.GMeth{ParamSlots: 2, GFunction: securityProviderGetService}
@@ -61,7 +61,7 @@ func Load_Security_Provider() {
 		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapDeprecated}
 
 	ghelpers.MethodSignatures["java/security/Provider.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
-		ghelpers.GMeth{ParamSlots: 2, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 2, GFunction: securityProviderPut}
 
 	ghelpers.MethodSignatures["java/security/Provider.putAll(Ljava/util/Map;)V"] =
 		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
@@ -70,7 +70,7 @@ func Load_Security_Provider() {
 		ghelpers.GMeth{ParamSlots: 1, GFunction: securityProviderPutService}
 
 	ghelpers.MethodSignatures["java/security/Provider.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
-		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+		ghelpers.GMeth{ParamSlots: 1, GFunction: securityProviderRemove}
 
 	ghelpers.MethodSignatures["java/security/Provider.removeService(Ljava/security/Provider$Service;)V"] =
 		ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
@@ -137,16 +137,29 @@ func securityProviderInit(params []any) any {
 	// Initialize services map in an empty state.
 	this.FieldTable["services"] = object.Field{Ftype: types.Map, Fvalue: map[string]*object.Object{}}
 
+	// Initialize the legacy properties, set by put(), in an empty state.
+	this.FieldTable["properties"] = object.Field{Ftype: types.Map, Fvalue: map[string]*object.Object{}}
+
 	return nil
 }
 
 // ----------------------- Getters -----------------------
 
-func securityProviderGetName([]any) any {
+func securityProviderGetName(params []any) any {
+	if this, ok := params[0].(*object.Object); ok {
+		if nameObj, ok := this.FieldTable["name"].Fvalue.(*object.Object); ok {
+			return nameObj
+		}
+	}
 	return object.StringObjectFromGoString(types.SecurityProviderName)
 }
 
-func securityProviderGetInfo([]any) any {
+func securityProviderGetInfo(params []any) any {
+	if this, ok := params[0].(*object.Object); ok {
+		if infoObj, ok := this.FieldTable["info"].Fvalue.(*object.Object); ok {
+			return infoObj
+		}
+	}
 	return object.StringObjectFromGoString(types.SecurityProviderInfo)
 }
 
@@ -225,7 +238,11 @@ func securityProviderGetService(params []any) any {
 		key := typeStr + "/" + algUpShifted
 		svc, ok = services[key]
 		if !ok {
-			// Both as-is and upshifted failed.
+			// Algorithm names are case-insensitive, as are those registered by put().
+			svc, ok = findServiceIgnoreCase(services, typeStr+"/"+algStr)
+		}
+		if !ok {
+			// As-is, upshifted and case-insensitive lookups all failed.
 			return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
 				fmt.Sprintf("securityProviderGetService: unsupported type/algorithm %s/%s", typeStr, algStr))
 		}
@@ -247,3 +264,144 @@ func securityProviderPutService(params []any) any {
 	services[key] = svc
 	return nil
 }
+
+func findServiceIgnoreCase(services map[string]*object.Object, key string) (*object.Object, bool) {
+	for k, svc := range services {
+		if strings.EqualFold(k, key) {
+			return svc, true
+		}
+	}
+	return nil, false
+}
+
+// ----------------------- Legacy Properties -----------------------
+
+// A provider implemented in Java registers its services with legacy properties, e.g.
+//
+//	put("Cipher.AES", "com.acme.AesSpi")          // the SPI class of a service
+//	put("Alg.Alias.Cipher.Rijndael", "AES")       // an alias of a service
+//	put("Cipher.AES SupportedModes", "ECB|CBC")   // an attribute of a service
+//
+// The services made from the properties are rebuilt whenever a property is put or removed.
+
+func securityProviderPut(params []any) any {
+	this := params[0].(*object.Object)
+	if object.IsNull(params[1]) || object.IsNull(params[2]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityProviderPut: key or value is null")
+	}
+	keyObj, ok1 := params[1].(*object.Object)
+	valueObj, ok2 := params[2].(*object.Object)
+	if !ok1 || !ok2 || !object.IsStringObject(keyObj) || !object.IsStringObject(valueObj) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException,
+			"securityProviderPut: only String keys and values are supported")
+	}
+
+	properties := providerProperties(this)
+	key := strings.TrimSpace(object.GoStringFromStringObject(keyObj))
+	previous, ok := properties[key]
+	properties[key] = object.StringObjectFromGoString(strings.TrimSpace(object.GoStringFromStringObject(valueObj)))
+	rebuildPropertyServices(this)
+	if !ok {
+		return object.Null
+	}
+	return previous
+}
+
+func securityProviderGetProperty(params []any) any {
+	this := params[0].(*object.Object)
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityProviderGetProperty: key is null")
+	}
+	if value, ok := providerProperties(this)[object.GoStringFromStringObject(keyObj)]; ok {
+		return value
+	}
+	return object.Null
+}
+
+func securityProviderRemove(params []any) any {
+	this := params[0].(*object.Object)
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "securityProviderRemove: key is null")
+	}
+	properties := providerProperties(this)
+	key := object.GoStringFromStringObject(keyObj)
+	previous, ok := properties[key]
+	if !ok {
+		return object.Null
+	}
+	delete(properties, key)
+	rebuildPropertyServices(this)
+	return previous
+}
+
+// providerProperties returns the legacy properties of provider, creating them if the provider
+// was not made by securityProviderInit.
+func providerProperties(provider *object.Object) map[string]*object.Object {
+	if properties, ok := provider.FieldTable["properties"].Fvalue.(map[string]*object.Object); ok {
+		return properties
+	}
+	properties := map[string]*object.Object{}
+	provider.FieldTable["properties"] = object.Field{Ftype: types.Map, Fvalue: properties}
+	return properties
+}
+
+// rebuildPropertyServices replaces the services of provider that were made from its legacy
+// properties. Services added by putService() are left alone.
+func rebuildPropertyServices(provider *object.Object) {
+	services, ok := provider.FieldTable["services"].Fvalue.(map[string]*object.Object)
+	if !ok {
+		services = map[string]*object.Object{}
+		provider.FieldTable["services"] = object.Field{Ftype: types.Map, Fvalue: services}
+	}
+	for key, svc := range services {
+		if _, legacy := svc.FieldTable["legacy"]; legacy {
+			delete(services, key)
+		}
+	}
+
+	// First the services themselves, then their aliases and attributes, which refer to them.
+	properties := providerProperties(provider)
+	for key, value := range properties {
+		if strings.HasPrefix(key, "Alg.Alias.") || strings.HasPrefix(key, "Provider.") || strings.Contains(key, " ") {
+			continue
+		}
+		typ, alg, ok := strings.Cut(key, ".")
+		if !ok || typ == "" || alg == "" {
+			continue
+		}
+		services[typ+"/"+alg] = newPropertyService(provider, typ, alg, object.GoStringFromStringObject(value))
+	}
+	for key, value := range properties {
+		if rest, isAlias := strings.CutPrefix(key, "Alg.Alias."); isAlias {
+			typ, alias, _ := strings.Cut(rest, ".")
+			svc, found := findServiceIgnoreCase(services, typ+"/"+object.GoStringFromStringObject(value))
+			if !found || alias == "" {
+				continue
+			}
+			aliases := svc.FieldTable["aliases"].Fvalue.([]*object.Object)
+			svc.FieldTable["aliases"] = object.Field{Ftype: types.StringArrayClassName,
+				Fvalue: append(aliases, object.StringObjectFromGoString(alias))}
+			services[typ+"/"+alias] = svc
+		} else if name, attribute, ok := strings.Cut(key, " "); ok {
+			typ, alg, _ := strings.Cut(name, ".")
+			if svc, found := findServiceIgnoreCase(services, typ+"/"+alg); found {
+				svc.FieldTable["attributes"].Fvalue.(map[string]*object.Object)[attribute] = value
+			}
+		}
+	}
+}
+
+// newPropertyService returns the Provider$Service for the legacy property type.algorithm=className.
+func newPropertyService(provider *object.Object, typ, algorithm, className string) *object.Object {
+	svc := object.MakeEmptyObjectWithClassName(&types.ClassNameSecurityProviderService)
+	svc.FieldTable["provider"] = object.Field{Ftype: types.Ref, Fvalue: provider}
+	svc.FieldTable["type"] = object.Field{Ftype: types.StringClassName, Fvalue: object.StringObjectFromGoString(typ)}
+	svc.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassName, Fvalue: object.StringObjectFromGoString(algorithm)}
+	svc.FieldTable["className"] = object.Field{Ftype: types.StringClassName, Fvalue: object.StringObjectFromGoString(className)}
+	svc.FieldTable["aliases"] = object.Field{Ftype: types.StringArrayClassName, Fvalue: []*object.Object{}}
+	svc.FieldTable["attributes"] = object.Field{Ftype: types.Map, Fvalue: map[string]*object.Object{}}
+	svc.FieldTable["legacy"] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	return svc
+}
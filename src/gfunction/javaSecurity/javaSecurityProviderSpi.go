@@ -0,0 +1,319 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strings"
+)
+
+/*
+Engine classes such as MessageDigest and javax.crypto.Cipher are implemented by the Go runtime
+provider. A provider written in Java instead names, with put("Cipher.AES", "com.acme.AesSpi"), the
+SPI class (a subclass of CipherSpi, MacSpi, etc.) that implements a service. When getInstance
+picks such a provider, the engine object that it returns holds an instance of that class, and the
+engine's methods are run by calling the SPI's engineXxx methods through RunJavaFromG.
+*/
+
+// SpiFunc runs a method of an engine class, such as MessageDigest.update([B)V, on the SPI object
+// that implements the engine. args are the arguments of the engine method, without the engine.
+type SpiFunc func(fs *list.List, spi *object.Object, args []any) any
+
+// SpiEngine describes an engine class whose instances can be implemented by the SPI classes of
+// providers written in Java.
+type SpiEngine struct {
+	ClassName   string             // the engine class, e.g. java/security/MessageDigest
+	ServiceType string             // the type of its services, e.g. MessageDigest
+	Methods     map[string]SpiFunc // engine method name+type -> how the SPI implements it
+}
+
+// The engine field that holds the SPI object.
+const spiField = "spi"
+
+// InstallSpiDispatch wraps the G functions of engine.ClassName, which must already be loaded, so
+// that getInstance searches the installed providers in order and the methods of an engine that was
+// made by a Java provider run on its SPI. Engines of the Go runtime provider are unaffected.
+func InstallSpiDispatch(engine SpiEngine) {
+	prefix := engine.ClassName + "."
+	goGetInstance, ok := ghelpers.MethodSignatures[prefix+"getInstance(Ljava/lang/String;)L"+engine.ClassName+";"]
+	if !ok {
+		return
+	}
+
+	for fqn, gmeth := range ghelpers.MethodSignatures {
+		methSig, ok := strings.CutPrefix(fqn, prefix)
+		if !ok || strings.HasPrefix(methSig, "<") {
+			continue
+		}
+		wrapped := ghelpers.GMeth{ParamSlots: gmeth.ParamSlots, NeedsContext: true}
+		if strings.HasPrefix(methSig, "getInstance(") {
+			wrapped.GFunction = engine.getInstance(goGetInstance)
+		} else {
+			wrapped.GFunction = engine.dispatch(methSig, gmeth)
+		}
+		ghelpers.MethodSignatures[fqn] = wrapped
+	}
+}
+
+// callGFunction calls gmeth with args, and the frame stack fs if gmeth needs it.
+func callGFunction(gmeth ghelpers.GMeth, fs *list.List, args []any) any {
+	if gmeth.NeedsContext {
+		return gmeth.GFunction(append([]any{fs}, args...))
+	}
+	return gmeth.GFunction(args)
+}
+
+func isGoProvider(provider *object.Object) bool {
+	return providerName(provider) == types.SecurityProviderName
+}
+
+// getInstance returns the G function of the getInstance methods of engine. Without a provider,
+// the installed providers are tried in order of preference; the Go runtime provider is tried by
+// calling goGetInstance, the engine's original getInstance(String).
+func (engine SpiEngine) getInstance(goGetInstance ghelpers.GMeth) func([]any) any {
+	return func(params []any) any {
+		fs, args := ghelpers.SplitContext(params)
+		algorithmObj, ok := args[0].(*object.Object)
+		if !ok || object.IsNull(algorithmObj) {
+			return callGFunction(goGetInstance, fs, args[:1])
+		}
+		algorithm := object.GoStringFromStringObject(algorithmObj)
+
+		providers := InstalledProviders()
+		explicit := len(args) > 1
+		if explicit {
+			providerObj, ok := args[1].(*object.Object)
+			if !ok || object.IsNull(providerObj) {
+				return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "missing provider")
+			}
+			if object.IsStringObject(providerObj) {
+				name := object.GoStringFromStringObject(providerObj)
+				if name == "" {
+					return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "missing provider")
+				}
+				if providerObj = findInstalledProvider(name); providerObj == nil {
+					return ghelpers.GetGErrBlk(excNames.NoSuchProviderException, "no such provider: "+name)
+				}
+			}
+			providers = []*object.Object{providerObj}
+		}
+
+		var goErr any
+		for _, provider := range providers {
+			if isGoProvider(provider) {
+				ret := callGFunction(goGetInstance, fs, args[:1])
+				if _, failed := ret.(*ghelpers.GErrBlk); !failed || explicit {
+					return ret
+				}
+				goErr = ret
+				continue
+			}
+			if ret, found := engine.newSpiEngine(fs, provider, algorithm); found {
+				return ret
+			}
+		}
+
+		switch {
+		case explicit:
+			return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+				fmt.Sprintf("no such algorithm: %s for provider %s", algorithm, providerName(providers[0])))
+		case goErr != nil:
+			return goErr
+		}
+		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+			fmt.Sprintf("%s %s not available", algorithm, engine.ServiceType))
+	}
+}
+
+// spiCandidate is a service that can implement an algorithm, and the engineSetMode and
+// engineSetPadding calls that adapt it to the algorithm.
+type spiCandidate struct {
+	algorithm     string
+	mode, padding string
+}
+
+// spiCandidates returns the services that can implement algorithm, best first. As in the JDK, a
+// Cipher transformation "alg/mode/padding" may be implemented by a service for the whole
+// transformation, or for "alg/mode", "alg//padding" or "alg" given the rest with engineSetMode and
+// engineSetPadding.
+func spiCandidates(serviceType, algorithm string) []spiCandidate {
+	parts := strings.Split(algorithm, "/")
+	if serviceType != "Cipher" || len(parts) != 3 {
+		return []spiCandidate{{algorithm: algorithm}}
+	}
+	alg, mode, padding := parts[0], parts[1], parts[2]
+	return []spiCandidate{
+		{algorithm: algorithm},
+		{algorithm: alg + "/" + mode, padding: padding},
+		{algorithm: alg + "//" + padding, mode: mode},
+		{algorithm: alg, mode: mode, padding: padding},
+	}
+}
+
+// newSpiEngine returns an engine object for algorithm that runs on a new instance of the SPI
+// class that provider registered for it. If provider has no such service, found is false.
+func (engine SpiEngine) newSpiEngine(fs *list.List, provider *object.Object, algorithm string) (ret any, found bool) {
+	for _, candidate := range spiCandidates(engine.ServiceType, algorithm) {
+		svc, ok := securityProviderGetService([]any{provider,
+			object.StringObjectFromGoString(engine.ServiceType),
+			object.StringObjectFromGoString(candidate.algorithm)}).(*object.Object)
+		if !ok {
+			continue
+		}
+
+		className := object.GoStringFromStringObject(svc.FieldTable["className"].Fvalue.(*object.Object))
+		spi, errBlk := newSpiObject(fs, className)
+		if errBlk != nil {
+			return errBlk, true
+		}
+		if candidate.mode != "" {
+			if ret := ghelpers.InvokeMethodOnObject(fs, spi, "engineSetMode", "(Ljava/lang/String;)V",
+				object.StringObjectFromGoString(candidate.mode)); ret != nil {
+				return ret, true
+			}
+		}
+		if candidate.padding != "" {
+			if ret := ghelpers.InvokeMethodOnObject(fs, spi, "engineSetPadding", "(Ljava/lang/String;)V",
+				object.StringObjectFromGoString(candidate.padding)); ret != nil {
+				return ret, true
+			}
+		}
+
+		obj := object.MakeEmptyObjectWithClassName(&engine.ClassName)
+		obj.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassName,
+			Fvalue: object.StringObjectFromGoString(algorithm)}
+		obj.FieldTable["provider"] = object.Field{Ftype: types.ClassNameSecurityProvider, Fvalue: provider}
+		obj.FieldTable[spiField] = object.Field{Ftype: types.Ref, Fvalue: spi}
+		return obj, true
+	}
+	return nil, false
+}
+
+// newSpiObject instantiates the SPI class className, a binary name such as com.acme.AesSpi, with
+// its public no-arg constructor, as the JDK does.
+func newSpiObject(fs *list.List, className string) (*object.Object, *ghelpers.GErrBlk) {
+	internalName := strings.ReplaceAll(className, ".", "/")
+	ret, err := globals.GetGlobalRef().FuncInstantiateClass(internalName, fs)
+	spi, ok := ret.(*object.Object)
+	if err != nil || !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+			fmt.Sprintf("class configured for %s cannot be instantiated", className))
+	}
+	if _, clName := ghelpers.FindInstanceMethod(spi, "<init>", "()V"); clName != internalName {
+		return nil, ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+			fmt.Sprintf("%s has no public no-arg constructor", className))
+	}
+	if ret := ghelpers.InvokeMethodOnObject(fs, spi, "<init>", "()V"); ret != nil {
+		if errBlk, ok := ret.(*ghelpers.GErrBlk); ok {
+			return nil, errBlk
+		}
+	}
+	return spi, nil
+}
+
+// dispatch returns the G function of the engine method methSig: the SPI's implementation of it
+// if the engine was made by a Java provider, else goMeth, the engine's original G function.
+func (engine SpiEngine) dispatch(methSig string, goMeth ghelpers.GMeth) func([]any) any {
+	spiFunc, hasSpiFunc := engine.Methods[methSig]
+	return func(params []any) any {
+		fs, args := ghelpers.SplitContext(params)
+		this, spi := engineSpi(args)
+		if spi == nil {
+			return callGFunction(goMeth, fs, args)
+		}
+
+		switch {
+		case hasSpiFunc:
+			return spiFunc(fs, spi, args[1:])
+		case methSig == "getAlgorithm()Ljava/lang/String;":
+			return this.FieldTable["algorithm"].Fvalue
+		case methSig == "getProvider()Ljava/security/Provider;":
+			return this.FieldTable["provider"].Fvalue
+		case methSig == "toString()Ljava/lang/String;":
+			algorithm := object.GoStringFromStringObject(this.FieldTable["algorithm"].Fvalue.(*object.Object))
+			provider := providerName(this.FieldTable["provider"].Fvalue.(*object.Object))
+			return object.StringObjectFromGoString(
+				fmt.Sprintf("%s.%s from: %s", engine.ServiceType, algorithm, provider))
+		}
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+			fmt.Sprintf("%s.%s is not supported by the SPI of a provider written in Java",
+				engine.ServiceType, methSig))
+	}
+}
+
+// engineSpi returns the engine object at args[0] and its SPI, or a nil SPI if args[0] is not an
+// engine that was made by a Java provider.
+func engineSpi(args []any) (*object.Object, *object.Object) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(this) {
+		return nil, nil
+	}
+	spi, _ := this.FieldTable[spiField].Fvalue.(*object.Object)
+	return this, spi
+}
+
+// ----------------------- SpiFunc constructors -----------------------
+
+// SpiCall returns the SpiFunc that calls the SPI method name+desc with the arguments of the engine
+// method, after rewriting them with each adapter in turn.
+func SpiCall(name, desc string, adapters ...func([]any) ([]any, *ghelpers.GErrBlk)) SpiFunc {
+	return func(fs *list.List, spi *object.Object, args []any) any {
+		for _, adapt := range adapters {
+			var errBlk *ghelpers.GErrBlk
+			if args, errBlk = adapt(args); errBlk != nil {
+				return errBlk
+			}
+		}
+		return ghelpers.InvokeMethodOnObject(fs, spi, name, desc, args...)
+	}
+}
+
+// SpiThen returns the SpiFunc that runs first with the arguments of the engine method and then,
+// if that succeeded, runs last without arguments, returning its result. E.g. digest([B)[B is
+// engineUpdate of the array followed by engineDigest().
+func SpiThen(first, last SpiFunc) SpiFunc {
+	return func(fs *list.List, spi *object.Object, args []any) any {
+		if errBlk, ok := first(fs, spi, args).(*ghelpers.GErrBlk); ok {
+			return errBlk
+		}
+		return last(fs, spi, nil)
+	}
+}
+
+// SpiWholeArray rewrites arguments that start with a byte array b into b, 0, b.length, as the
+// engine methods that take a whole array pass it to the SPI.
+func SpiWholeArray(args []any) ([]any, *ghelpers.GErrBlk) {
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Input cannot be null")
+	}
+	length := int64(len(arr.FieldTable["value"].Fvalue.([]types.JavaByte)))
+	return append([]any{arr, int64(0), length}, args[1:]...), nil
+}
+
+// SpiPrependArgs returns the adapter that puts values before the arguments of the engine method.
+func SpiPrependArgs(values ...any) func([]any) ([]any, *ghelpers.GErrBlk) {
+	return func(args []any) ([]any, *ghelpers.GErrBlk) {
+		return append(append([]any{}, values...), args...), nil
+	}
+}
+
+// SpiAppendArgs returns the adapter that puts values after the arguments of the engine method.
+func SpiAppendArgs(values ...any) func([]any) ([]any, *ghelpers.GErrBlk) {
+	return func(args []any) ([]any, *ghelpers.GErrBlk) {
+		return append(append([]any{}, args...), values...), nil
+	}
+}
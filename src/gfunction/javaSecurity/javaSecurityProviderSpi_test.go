@@ -0,0 +1,258 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
+	"testing"
+)
+
+const fakeDigestSpi = "test/FakeDigestSpi"
+
+// newJavaProvider returns a provider as a Java subclass of Provider would make it.
+func newJavaProvider(t *testing.T, name string) *object.Object {
+	t.Helper()
+	className := "com/acme/AcmeProvider"
+	provider := object.MakeEmptyObjectWithClassName(&className)
+	if ret := securityProviderInit([]any{provider, object.StringObjectFromGoString(name), 1.0,
+		object.StringObjectFromGoString(name + " provider")}); ret != nil {
+		t.Fatalf("securityProviderInit: %v", ret)
+	}
+	return provider
+}
+
+func putProperty(t *testing.T, provider *object.Object, key, value string) {
+	t.Helper()
+	if ret := securityProviderPut([]any{provider, object.StringObjectFromGoString(key),
+		object.StringObjectFromGoString(value)}); ret != object.Null {
+		t.Fatalf("put(%s) returned %v", key, ret)
+	}
+}
+
+// installProvider adds provider at position, removing it again when the test ends.
+func installProvider(t *testing.T, provider *object.Object, position int64) {
+	t.Helper()
+	if ret := securityInsertProviderAt([]any{provider, position}); ret != position {
+		t.Fatalf("insertProviderAt(%d) returned %v", position, ret)
+	}
+	t.Cleanup(func() {
+		securityRemoveProvider([]any{provider.FieldTable["name"].Fvalue})
+	})
+}
+
+// loadFakeDigestSpi registers a MessageDigestSpi whose digest is the byte count and the sum of
+// the bytes, and makes FuncInstantiateClass able to instantiate it.
+func loadFakeDigestSpi(t *testing.T) {
+	t.Helper()
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Security_Provider()
+	Load_Security_MessageDigest()
+
+	glob := globals.GetGlobalRef()
+	glob.FuncInstantiateClass = func(className string, _ *list.List) (any, error) {
+		return object.MakeEmptyObjectWithClassName(&className), nil
+	}
+
+	ghelpers.MethodSignatures[fakeDigestSpi+".<init>()V"] = ghelpers.GMeth{ParamSlots: 0,
+		GFunction: func(params []any) any {
+			params[0].(*object.Object).FieldTable["sum"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+			params[0].(*object.Object).FieldTable["count"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+			return nil
+		}}
+	ghelpers.MethodSignatures[fakeDigestSpi+".engineUpdate([BII)V"] = ghelpers.GMeth{ParamSlots: 3,
+		GFunction: func(params []any) any {
+			this := params[0].(*object.Object)
+			data := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
+			sum, count := this.FieldTable["sum"].Fvalue.(int64), this.FieldTable["count"].Fvalue.(int64)
+			for _, b := range data[params[2].(int64) : params[2].(int64)+params[3].(int64)] {
+				sum += int64(b)
+				count++
+			}
+			this.FieldTable["sum"] = object.Field{Ftype: types.Int, Fvalue: sum}
+			this.FieldTable["count"] = object.Field{Ftype: types.Int, Fvalue: count}
+			return nil
+		}}
+	ghelpers.MethodSignatures[fakeDigestSpi+".engineDigest()[B"] = ghelpers.GMeth{ParamSlots: 0,
+		GFunction: func(params []any) any {
+			this := params[0].(*object.Object)
+			digest := []byte{byte(this.FieldTable["count"].Fvalue.(int64)), byte(this.FieldTable["sum"].Fvalue.(int64))}
+			this.FieldTable["sum"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+			this.FieldTable["count"] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+			return makeByteArrayObject(digest)
+		}}
+}
+
+func callMessageDigest(t *testing.T, methSig string, params ...any) any {
+	t.Helper()
+	gmeth, ok := ghelpers.MethodSignatures["java/security/MessageDigest."+methSig]
+	if !ok {
+		t.Fatalf("MessageDigest.%s is not registered", methSig)
+	}
+	if gmeth.NeedsContext {
+		params = append([]any{(*list.List)(nil)}, params...)
+	}
+	return gmeth.GFunction(params)
+}
+
+func TestProviderPutRegistersServices(t *testing.T) {
+	globals.InitGlobals("test")
+	provider := newJavaProvider(t, "Acme")
+	putProperty(t, provider, "MessageDigest.FAKE", "test.FakeDigestSpi")
+	putProperty(t, provider, "Alg.Alias.MessageDigest.PHONY", "FAKE")
+	putProperty(t, provider, "MessageDigest.FAKE ImplementedIn", "Software")
+
+	if name := object.GoStringFromStringObject(securityProviderGetName([]any{provider}).(*object.Object)); name != "Acme" {
+		t.Errorf("getName: expected Acme, got %s", name)
+	}
+	value := securityProviderGetProperty([]any{provider, object.StringObjectFromGoString("MessageDigest.FAKE")})
+	if object.GoStringFromStringObject(value.(*object.Object)) != "test.FakeDigestSpi" {
+		t.Errorf("getProperty returned %v", value)
+	}
+
+	svc, ok := securityProviderGetService([]any{provider, object.StringObjectFromGoString("MessageDigest"),
+		object.StringObjectFromGoString("phony")}).(*object.Object)
+	if !ok {
+		t.Fatal("expected the service to be found by its alias")
+	}
+	if className := object.GoStringFromStringObject(svc.FieldTable["className"].Fvalue.(*object.Object)); className != "test.FakeDigestSpi" {
+		t.Errorf("expected className test.FakeDigestSpi, got %s", className)
+	}
+	if attr := securityProvSvcGetAttribute([]any{svc, object.StringObjectFromGoString("ImplementedIn")}); object.GoStringFromStringObject(attr.(*object.Object)) != "Software" {
+		t.Errorf("expected ImplementedIn attribute, got %v", attr)
+	}
+
+	previous := securityProviderRemove([]any{provider, object.StringObjectFromGoString("MessageDigest.FAKE")})
+	if object.GoStringFromStringObject(previous.(*object.Object)) != "test.FakeDigestSpi" {
+		t.Errorf("remove returned %v", previous)
+	}
+	ret := securityProviderGetService([]any{provider, object.StringObjectFromGoString("MessageDigest"),
+		object.StringObjectFromGoString("PHONY")})
+	if _, ok := ret.(*ghelpers.GErrBlk); !ok {
+		t.Errorf("expected no service after remove, got %v", ret)
+	}
+}
+
+func TestSecurityInstalledProviders(t *testing.T) {
+	globals.InitGlobals("test")
+	first, last := newJavaProvider(t, "First"), newJavaProvider(t, "Last")
+
+	if pos := securityAddProvider([]any{last}); pos != int64(2) {
+		t.Fatalf("addProvider: expected position 2, got %v", pos)
+	}
+	t.Cleanup(func() { securityRemoveProvider([]any{object.StringObjectFromGoString("Last")}) })
+	installProvider(t, first, 1)
+	if pos := securityAddProvider([]any{first}); pos != int64(-1) {
+		t.Errorf("adding an installed provider: expected -1, got %v", pos)
+	}
+
+	names := []string{}
+	for _, provider := range InstalledProviders() {
+		names = append(names, providerName(provider))
+	}
+	if !slices.Equal(names, []string{"First", types.SecurityProviderName, "Last"}) {
+		t.Errorf("unexpected provider order %v", names)
+	}
+	providers := securityGetProviders(nil).(*object.Object).FieldTable["value"].Fvalue.([]*object.Object)
+	if len(providers) != 3 || providers[2] != last {
+		t.Errorf("getProviders returned %v", providers)
+	}
+
+	if got := securityGetProviderByName([]any{object.StringObjectFromGoString("Last")}); got != last {
+		t.Errorf("getProvider(Last) returned %v", got)
+	}
+	securityRemoveProvider([]any{object.StringObjectFromGoString("Last")})
+	if got := securityGetProviderByName([]any{object.StringObjectFromGoString("Last")}); got != object.Null {
+		t.Errorf("getProvider of a removed provider returned %v", got)
+	}
+}
+
+func TestMessageDigestRunsOnJavaSpi(t *testing.T) {
+	loadFakeDigestSpi(t)
+	provider := newJavaProvider(t, "Acme")
+	putProperty(t, provider, "MessageDigest.FAKE", "test.FakeDigestSpi")
+	installProvider(t, provider, 1)
+
+	md, ok := callMessageDigest(t, "getInstance(Ljava/lang/String;)Ljava/security/MessageDigest;",
+		object.StringObjectFromGoString("fake")).(*object.Object)
+	if !ok {
+		t.Fatal("getInstance(fake) did not return a MessageDigest")
+	}
+	if got := callMessageDigest(t, "getProvider()Ljava/security/Provider;", md); got != provider {
+		t.Errorf("getProvider returned %v", got)
+	}
+
+	callMessageDigest(t, "update([B)V", md, makeByteArrayObject([]byte{1, 2, 3}))
+	digest := callMessageDigest(t, "digest([B)[B", md, makeByteArrayObject([]byte{4}))
+	got := object.GoByteArrayFromJavaByteArray(digest.(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte))
+	if !slices.Equal(got, []byte{4, 10}) {
+		t.Errorf("expected digest [4 10], got %v", got)
+	}
+
+	ret := callMessageDigest(t, "update(Ljava/nio/ByteBuffer;)V", md, object.Null)
+	if errBlk, ok := ret.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != excNames.UnsupportedOperationException {
+		t.Errorf("expected UnsupportedOperationException, got %v", ret)
+	}
+}
+
+func TestMessageDigestProviderSelection(t *testing.T) {
+	loadFakeDigestSpi(t)
+	provider := newJavaProvider(t, "Acme")
+	putProperty(t, provider, "MessageDigest.FAKE", "test.FakeDigestSpi")
+	installProvider(t, provider, 1)
+
+	// The Java provider does not implement SHA-256, so the Go provider does.
+	md := callMessageDigest(t, "getInstance(Ljava/lang/String;)Ljava/security/MessageDigest;",
+		object.StringObjectFromGoString("SHA-256")).(*object.Object)
+	if _, ok := md.FieldTable[spiField]; ok {
+		t.Error("expected SHA-256 from the Go provider")
+	}
+
+	md = callMessageDigest(t, "getInstance(Ljava/lang/String;Ljava/lang/String;)Ljava/security/MessageDigest;",
+		object.StringObjectFromGoString("FAKE"), object.StringObjectFromGoString("Acme")).(*object.Object)
+	if _, ok := md.FieldTable[spiField]; !ok {
+		t.Error("expected FAKE from the Acme provider")
+	}
+
+	for _, tc := range []struct {
+		algorithm, provider string
+		exc                 int
+	}{
+		{"FAKE", "Nobody", excNames.NoSuchProviderException},
+		{"SHA-256", "Acme", excNames.NoSuchAlgorithmException},
+		{"FAKE", "", excNames.IllegalArgumentException},
+	} {
+		ret := callMessageDigest(t, "getInstance(Ljava/lang/String;Ljava/lang/String;)Ljava/security/MessageDigest;",
+			object.StringObjectFromGoString(tc.algorithm), object.StringObjectFromGoString(tc.provider))
+		if errBlk, ok := ret.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != tc.exc {
+			t.Errorf("getInstance(%s, %q): expected %s, got %v", tc.algorithm, tc.provider,
+				excNames.JVMexceptionNames[tc.exc], ret)
+		}
+	}
+}
+
+func TestSpiCandidatesForCipherTransformations(t *testing.T) {
+	got := spiCandidates("Cipher", "AES/CBC/PKCS5Padding")
+	expected := []spiCandidate{
+		{algorithm: "AES/CBC/PKCS5Padding"},
+		{algorithm: "AES/CBC", padding: "PKCS5Padding"},
+		{algorithm: "AES//PKCS5Padding", mode: "CBC"},
+		{algorithm: "AES", mode: "CBC", padding: "PKCS5Padding"},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := spiCandidates("MessageDigest", "SHA-512/256"); len(got) != 1 || got[0].algorithm != "SHA-512/256" {
+		t.Errorf("expected only the algorithm itself, got %v", got)
+	}
+}
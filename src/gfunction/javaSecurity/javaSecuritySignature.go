@@ -137,6 +137,25 @@ func Load_Security_Signature() {
 			ParamSlots: 3,
 			GFunction:  signatureVerifyRange,
 		}
+
+	// Signatures of providers written in Java run on their SignatureSpi.
+	InstallSpiDispatch(SpiEngine{
+		ClassName:   types.ClassNameSignature,
+		ServiceType: "Signature",
+		Methods: map[string]SpiFunc{
+			"initSign(Ljava/security/PrivateKey;)V": SpiCall("engineInitSign", "(Ljava/security/PrivateKey;)V"),
+			"initSign(Ljava/security/PrivateKey;Ljava/security/SecureRandom;)V": SpiCall("engineInitSign",
+				"(Ljava/security/PrivateKey;Ljava/security/SecureRandom;)V"),
+			"initVerify(Ljava/security/PublicKey;)V": SpiCall("engineInitVerify", "(Ljava/security/PublicKey;)V"),
+			"sign()[B":                               SpiCall("engineSign", "()[B"),
+			"sign([BII)I":                            SpiCall("engineSign", "([BII)I"),
+			"update(B)V":                             SpiCall("engineUpdate", "(B)V"),
+			"update([B)V":                            SpiCall("engineUpdate", "([BII)V", SpiWholeArray),
+			"update([BII)V":                          SpiCall("engineUpdate", "([BII)V"),
+			"verify([B)Z":                            SpiCall("engineVerify", "([B)Z"),
+			"verify([BII)Z":                          SpiCall("engineVerify", "([BII)Z"),
+		},
+	})
 }
 
 // signatureGetInstance creates a new Signature object for the given algorithm
@@ -62,6 +62,9 @@ func namedParameterSpecClinit([]interface{}) interface{} {
 	_ = statics.AddStatic(className+".X448", statics.Static{Type: types.Ref, Value: makeSpec("X448")})
 	_ = statics.AddStatic(className+".ED25519", statics.Static{Type: types.Ref, Value: makeSpec("Ed25519")})
 	_ = statics.AddStatic(className+".ED448", statics.Static{Type: types.Ref, Value: makeSpec("Ed448")})
+	_ = statics.AddStatic(className+".ML_KEM_512", statics.Static{Type: types.Ref, Value: makeSpec("ML-KEM-512")})
+	_ = statics.AddStatic(className+".ML_KEM_768", statics.Static{Type: types.Ref, Value: makeSpec("ML-KEM-768")})
+	_ = statics.AddStatic(className+".ML_KEM_1024", statics.Static{Type: types.Ref, Value: makeSpec("ML-KEM-1024")})
 
 	return nil
 }
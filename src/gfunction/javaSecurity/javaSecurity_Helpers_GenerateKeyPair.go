@@ -11,6 +11,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/mlkem"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -116,10 +117,7 @@ func keypairgeneratorGenerateKeyPair(params []any) any {
 			priv.Parameters = *params
 			err = dsa.GenerateKey(priv, rand.Reader)
 			if err == nil {
-				dsaParamsObj := NewGoRuntimeService("DSA", "DSA", types.ClassNameDSAParameterSpec)
-				dsaParamsObj.FieldTable["p"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.P)}
-				dsaParamsObj.FieldTable["q"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Q)}
-				dsaParamsObj.FieldTable["g"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.G)}
+				dsaParamsObj := newDSAParameterSpecObject(params)
 
 				pubKey := &dsa.PublicKey{Parameters: *params, Y: new(big.Int).Set(priv.PublicKey.Y)}
 				publicKeyObj := NewGoRuntimeService("DSA", "DSA", types.ClassNameDSAPublicKey)
@@ -154,23 +152,7 @@ func keypairgeneratorGenerateKeyPair(params []any) any {
 		if err == nil {
 			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
 			if err == nil {
-				params := curve.Params()
-				curveObj := NewGoRuntimeService("EC", "EC", types.ClassNameEllipticCurve)
-				curveObj.FieldTable["p"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.P)}
-				curveObj.FieldTable["a"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, big.NewInt(-3))}
-				curveObj.FieldTable["b"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.B)}
-
-				generatorObj := NewGoRuntimeService("EC", "EC", types.ClassNameECPoint)
-				generatorObj.FieldTable["x"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Gx)}
-				generatorObj.FieldTable["y"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Gy)}
-
-				curveObj.FieldTable["generator"] = object.Field{Ftype: types.ECPoint, Fvalue: generatorObj}
-
-				ecSpecObj := NewGoRuntimeService("EC", "EC", types.ClassNameECParameterSpec)
-				ecSpecObj.FieldTable["curve"] = object.Field{Ftype: types.Ref, Fvalue: curveObj}
-				ecSpecObj.FieldTable["g"] = object.Field{Ftype: types.Ref, Fvalue: generatorObj}
-				ecSpecObj.FieldTable["n"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.N)}
-				ecSpecObj.FieldTable["h"] = object.Field{Ftype: types.Int, Fvalue: int64(1)}
+				ecSpecObj := newECParameterSpecObject(curve)
 
 				pubKey := &ecdsa.PublicKey{Curve: priv.PublicKey.Curve, X: new(big.Int).Set(priv.PublicKey.X), Y: new(big.Int).Set(priv.PublicKey.Y)}
 				publicKeyObj := NewGoRuntimeService("EC", "EC", types.ClassNameECPublicKey)
@@ -302,6 +284,44 @@ func keypairgeneratorGenerateKeyPair(params []any) any {
 			keyPairObj.FieldTable["public"] = object.Field{Ftype: types.PublicKey, Fvalue: publicKeyObj}
 		}
 
+	case "ML-KEM", "ML-KEM-768", "ML-KEM-1024":
+		// The parameter set is named by the algorithm or, for ML-KEM, by a NamedParameterSpec.
+		// It is kept by the Go type of the keys; ML-KEM-512 is not in crypto/mlkem.
+		parameterSet := mlkemParameterSet(kpgObj)
+		var pub, priv any
+		switch parameterSet {
+		case "ML-KEM-768":
+			var dk *mlkem.DecapsulationKey768
+			dk, err = mlkem.GenerateKey768()
+			if err == nil {
+				pub, priv = dk.EncapsulationKey(), dk
+			}
+		case "ML-KEM-1024":
+			var dk *mlkem.DecapsulationKey1024
+			dk, err = mlkem.GenerateKey1024()
+			if err == nil {
+				pub, priv = dk.EncapsulationKey(), dk
+			}
+		default:
+			return ghelpers.GetGErrBlk(
+				excNames.InvalidAlgorithmParameterException,
+				"unsupported ML-KEM parameter set: "+parameterSet,
+			)
+		}
+		if err != nil {
+			break
+		}
+
+		publicKeyObj := NewGoRuntimeService("ML-KEM", "ML-KEM", types.ClassNamePublicKey)
+		publicKeyObj.FieldTable["value"] = object.Field{Ftype: types.PublicKey, Fvalue: pub}
+
+		privateKeyObj := NewGoRuntimeService("ML-KEM", "ML-KEM", types.ClassNamePrivateKey)
+		privateKeyObj.FieldTable["value"] = object.Field{Ftype: types.PrivateKey, Fvalue: priv}
+
+		keyPairObj = NewGoRuntimeService(types.SecurityServiceKeyPairGenerator, "ML-KEM", types.ClassNameKeyPair)
+		keyPairObj.FieldTable["private"] = object.Field{Ftype: types.PrivateKey, Fvalue: privateKeyObj}
+		keyPairObj.FieldTable["public"] = object.Field{Ftype: types.PublicKey, Fvalue: publicKeyObj}
+
 	default:
 		return ghelpers.GetGErrBlk(
 			excNames.GeneralSecurityException,
@@ -318,3 +338,19 @@ func keypairgeneratorGenerateKeyPair(params []any) any {
 
 	return keyPairObj
 }
+
+// mlkemParameterSet returns the ML-KEM parameter set of a KeyPairGenerator: the one its
+// algorithm names, else the one of the NamedParameterSpec it was initialized with, else
+// ML-KEM-768, the JDK's default.
+func mlkemParameterSet(kpgObj *object.Object) string {
+	algorithm := object.GoStringFromStringObject(kpgObj.FieldTable["algorithm"].Fvalue.(*object.Object))
+	if algorithm != "ML-KEM" {
+		return algorithm
+	}
+	if paramSpecObj, ok := kpgObj.FieldTable["paramSpec"].Fvalue.(*object.Object); ok {
+		if nameObj, ok := paramSpecObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+			return object.GoStringFromStringObject(nameObj)
+		}
+	}
+	return "ML-KEM-768"
+}
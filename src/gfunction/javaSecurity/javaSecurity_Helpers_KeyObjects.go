@@ -0,0 +1,94 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// newECParameterSpecObject builds the ECParameterSpec object (with its EllipticCurve and
+// generator ECPoint) for one of the NIST curves.
+func newECParameterSpecObject(curve elliptic.Curve) *object.Object {
+	params := curve.Params()
+	curveObj := NewGoRuntimeService("EC", "EC", types.ClassNameEllipticCurve)
+	curveObj.FieldTable["p"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.P)}
+	curveObj.FieldTable["a"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, big.NewInt(-3))}
+	curveObj.FieldTable["b"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.B)}
+
+	generatorObj := NewGoRuntimeService("EC", "EC", types.ClassNameECPoint)
+	generatorObj.FieldTable["x"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Gx)}
+	generatorObj.FieldTable["y"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Gy)}
+
+	curveObj.FieldTable["generator"] = object.Field{Ftype: types.ECPoint, Fvalue: generatorObj}
+
+	ecSpecObj := NewGoRuntimeService("EC", "EC", types.ClassNameECParameterSpec)
+	ecSpecObj.FieldTable["curve"] = object.Field{Ftype: types.Ref, Fvalue: curveObj}
+	ecSpecObj.FieldTable["g"] = object.Field{Ftype: types.Ref, Fvalue: generatorObj}
+	ecSpecObj.FieldTable["n"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.N)}
+	ecSpecObj.FieldTable["h"] = object.Field{Ftype: types.Int, Fvalue: int64(1)}
+	return ecSpecObj
+}
+
+// newDSAParameterSpecObject builds the DSAParameterSpec object for a set of DSA domain parameters.
+func newDSAParameterSpecObject(params *dsa.Parameters) *object.Object {
+	dsaParamsObj := NewGoRuntimeService("DSA", "DSA", types.ClassNameDSAParameterSpec)
+	dsaParamsObj.FieldTable["p"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.P)}
+	dsaParamsObj.FieldTable["q"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.Q)}
+	dsaParamsObj.FieldTable["g"] = object.Field{Ftype: types.BigInteger, Fvalue: object.MakePrimitiveObject(types.ClassNameBigInteger, types.BigInteger, params.G)}
+	return dsaParamsObj
+}
+
+// NewPublicKeyObject wraps a Go public key, such as one parsed from a certificate, in the same
+// kind of key object that KeyPairGenerator produces, so that Signature, Cipher and the key
+// interfaces accept it.
+func NewPublicKeyObject(pub any) (*object.Object, error) {
+	var keyObj *object.Object
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		keyObj = NewGoRuntimeService("RSA", "RSA", types.ClassNameRSAPublicKey)
+	case *ecdsa.PublicKey:
+		keyObj = NewGoRuntimeService("EC", "EC", types.ClassNameECPublicKey)
+		keyObj.FieldTable["params"] = object.Field{Ftype: types.Ref, Fvalue: newECParameterSpecObject(k.Curve)}
+	case *dsa.PublicKey:
+		keyObj = NewGoRuntimeService("DSA", "DSA", types.ClassNameDSAPublicKey)
+		keyObj.FieldTable["params"] = object.Field{Ftype: types.Ref, Fvalue: newDSAParameterSpecObject(&k.Parameters)}
+	case ed25519.PublicKey:
+		keyObj = NewGoRuntimeService("Ed25519", "Ed25519", types.ClassNameEdECPublicKey)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	keyObj.FieldTable["value"] = object.Field{Ftype: types.PublicKey, Fvalue: pub}
+	return keyObj, nil
+}
+
+// NewPrivateKeyObject wraps a Go private key, such as one decoded from a key store, in the same
+// kind of key object that KeyPairGenerator produces.
+func NewPrivateKeyObject(priv any) (*object.Object, error) {
+	var keyObj *object.Object
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		keyObj = NewGoRuntimeService("RSA", "RSA", types.ClassNameRSAPrivateKey)
+	case *ecdsa.PrivateKey:
+		keyObj = NewGoRuntimeService("EC", "EC", types.ClassNameECPrivateKey)
+		keyObj.FieldTable["params"] = object.Field{Ftype: types.Ref, Fvalue: newECParameterSpecObject(k.Curve)}
+	case ed25519.PrivateKey:
+		keyObj = NewGoRuntimeService("Ed25519", "Ed25519", types.ClassNameEdECPrivateKey)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	keyObj.FieldTable["value"] = object.Field{Ftype: types.PrivateKey, Fvalue: priv}
+	return keyObj, nil
+}
@@ -0,0 +1,732 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+	"unicode/utf16"
+)
+
+// This file holds the PKCS#12 (RFC 7292) codec behind KeyStore type PKCS12. It reads stores
+// protected with the legacy PKCS#12 PBE scheme (pbeWithSHAAnd3-KeyTripleDES-CBC) or with PBES2
+// (PBKDF2 and AES or 3DES), with a MAC over SHA-1 or the SHA-2 family. It writes stores the way
+// the JDK does by default: keys and certificates encrypted with PBES2 (PBKDF2 with HmacSHA256
+// and AES-256) and an HmacPBESHA256 MAC, all with 10000 iterations. The 40-bit RC2 scheme of old
+// stores is not supported.
+
+var (
+	oidPKCS7Data          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS7EncryptedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+
+	oidKeyBag              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 1}
+	oidPKCS8ShroudedKeyBag = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidX509CertType        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+
+	oidFriendlyName        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidTrustedKeyUsage     = asn1.ObjectIdentifier{2, 16, 840, 1, 113894, 746875, 1, 1}
+	oidAnyExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37, 0}
+
+	oidPBEWithSHAAnd3KeyTripleDESCBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPBEWithSHAAnd2KeyTripleDESCBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 4}
+	oidPBES2                         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2                        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHmacWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHmacWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHmacWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHmacWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHmacWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+
+	oidSHA1   = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA224 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 4}
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+)
+
+// pkcs12Iterations is the iteration count the JDK uses for encryption and the MAC when storing.
+const pkcs12Iterations = 10000
+
+var (
+	// errPKCS12MAC means the integrity check of the store failed, almost always because the
+	// password is wrong.
+	errPKCS12MAC = errors.New("PKCS#12 MAC verification failed")
+	// errPKCS12Decrypt means a PBE decryption did not give well-padded plaintext, which
+	// is again usually a wrong password.
+	errPKCS12Decrypt = errors.New("PBE decryption failed")
+)
+
+type pkcs12PFX struct {
+	Version  int
+	AuthSafe pkcs12ContentInfo
+	MacData  pkcs12MacData `asn1:"optional"`
+}
+
+type pkcs12ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type pkcs12EncryptedData struct {
+	Version              int
+	EncryptedContentInfo pkcs12EncryptedContentInfo
+}
+
+type pkcs12EncryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type pkcs12MacData struct {
+	Mac        pkcs12DigestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type pkcs12DigestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type pkcs12SafeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue     `asn1:"tag:0,explicit"`
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID     asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+type pkcs12CertBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type pkcs12EncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pkcs12PBEParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// pkcs12Key is a private key bag. encrypted holds an EncryptedPrivateKeyInfo, or for an
+// unencrypted key bag, the PKCS#8 encoding of the key itself.
+type pkcs12Key struct {
+	friendlyName string
+	localKeyID   []byte
+	encrypted    []byte
+	plain        bool
+}
+
+// pkcs12Cert is a certificate bag. trusted is set for a certificate that the JDK stored as a
+// trusted certificate entry.
+type pkcs12Cert struct {
+	friendlyName string
+	localKeyID   []byte
+	trusted      bool
+	cert         *x509.Certificate
+}
+
+// pkcs12Contents is what a PKCS#12 store holds.
+type pkcs12Contents struct {
+	keys  []pkcs12Key
+	certs []pkcs12Cert
+}
+
+// pkcs12Hash returns the hash function for a digest OID, with the block size that the PKCS#12
+// key derivation function needs.
+func pkcs12Hash(oid asn1.ObjectIdentifier) (func() hash.Hash, int, error) {
+	switch {
+	case oid.Equal(oidSHA1):
+		return sha1.New, 64, nil
+	case oid.Equal(oidSHA224):
+		return sha256.New224, 64, nil
+	case oid.Equal(oidSHA256):
+		return sha256.New, 64, nil
+	case oid.Equal(oidSHA384):
+		return sha512.New384, 128, nil
+	case oid.Equal(oidSHA512):
+		return sha512.New, 128, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported MAC digest algorithm %s", oid)
+}
+
+// bmpPassword encodes a password as a NUL-terminated big-endian UTF-16 string, as the PKCS#12
+// key derivation function takes it.
+func bmpPassword(password []rune) []byte {
+	units := utf16.Encode(password)
+	out := make([]byte, 0, 2*len(units)+2)
+	for _, u := range units {
+		out = append(out, byte(u>>8), byte(u))
+	}
+	return append(out, 0, 0)
+}
+
+// pkcs12KDF is the key derivation function of RFC 7292 appendix B.2. id is 1 for a key,
+// 2 for an IV and 3 for a MAC key.
+func pkcs12KDF(newHash func() hash.Hash, v int, password, salt []byte, iterations int, id byte, size int) []byte {
+	fill := func(src []byte) []byte {
+		if len(src) == 0 {
+			return nil
+		}
+		out := make([]byte, v*((len(src)+v-1)/v))
+		for i := range out {
+			out[i] = src[i%len(src)]
+		}
+		return out
+	}
+
+	d := bytes.Repeat([]byte{id}, v)
+	input := append(fill(salt), fill(password)...)
+	var result []byte
+	for len(result) < size {
+		h := newHash()
+		h.Write(d)
+		h.Write(input)
+		a := h.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			h.Reset()
+			h.Write(a)
+			a = h.Sum(a[:0])
+		}
+		result = append(result, a...)
+
+		// I_j = (I_j + B + 1) mod 2^(8v) for each v-byte block I_j of the input
+		b := fill(a)[:v]
+		for j := 0; j < len(input); j += v {
+			carry := 1
+			for k := v - 1; k >= 0; k-- {
+				sum := int(input[j+k]) + int(b[k]) + carry
+				input[j+k] = byte(sum)
+				carry = sum >> 8
+			}
+		}
+	}
+	return result[:size]
+}
+
+// pbeCipher returns the block cipher, IV and PBE parameters described by a PBE algorithm identifier.
+func pbeCipher(alg pkix.AlgorithmIdentifier, password []rune) (cipher.Block, []byte, error) {
+	switch {
+	case alg.Algorithm.Equal(oidPBEWithSHAAnd3KeyTripleDESCBC), alg.Algorithm.Equal(oidPBEWithSHAAnd2KeyTripleDESCBC):
+		var params pkcs12PBEParams
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, nil, fmt.Errorf("invalid PBE parameters: %v", err)
+		}
+		pw := bmpPassword(password)
+		keyLen := 24
+		if alg.Algorithm.Equal(oidPBEWithSHAAnd2KeyTripleDESCBC) {
+			keyLen = 16
+		}
+		key := pkcs12KDF(sha1.New, 64, pw, params.Salt, params.Iterations, 1, keyLen)
+		if keyLen == 16 {
+			key = append(key, key[:8]...)
+		}
+		iv := pkcs12KDF(sha1.New, 64, pw, params.Salt, params.Iterations, 2, des.BlockSize)
+		block, err := des.NewTripleDESCipher(key)
+		return block, iv, err
+
+	case alg.Algorithm.Equal(oidPBES2):
+		var params pbes2Params
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, nil, fmt.Errorf("invalid PBES2 parameters: %v", err)
+		}
+		if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+			return nil, nil, fmt.Errorf("unsupported key derivation function %s", params.KeyDerivationFunc.Algorithm)
+		}
+		var kdf pbkdf2Params
+		if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+			return nil, nil, fmt.Errorf("invalid PBKDF2 parameters: %v", err)
+		}
+		prf, err := pbkdf2PRF(kdf.PRF.Algorithm)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var keyLen int
+		var newCipher func([]byte) (cipher.Block, error)
+		scheme := params.EncryptionScheme.Algorithm
+		switch {
+		case scheme.Equal(oidAES128CBC):
+			keyLen, newCipher = 16, aes.NewCipher
+		case scheme.Equal(oidAES192CBC):
+			keyLen, newCipher = 24, aes.NewCipher
+		case scheme.Equal(oidAES256CBC):
+			keyLen, newCipher = 32, aes.NewCipher
+		case scheme.Equal(oidDESEDE3CBC):
+			keyLen, newCipher = 24, des.NewTripleDESCipher
+		default:
+			return nil, nil, fmt.Errorf("unsupported encryption scheme %s", scheme)
+		}
+		var iv []byte
+		if _, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+			return nil, nil, fmt.Errorf("invalid IV: %v", err)
+		}
+		key, err := pbkdf2.Key(prf, string(password), kdf.Salt, kdf.IterationCount, keyLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		block, err := newCipher(key)
+		if err == nil && len(iv) != block.BlockSize() {
+			err = fmt.Errorf("invalid IV length %d", len(iv))
+		}
+		return block, iv, err
+	}
+	return nil, nil, fmt.Errorf("unsupported PBE algorithm %s", alg.Algorithm)
+}
+
+// pbkdf2PRF returns the hash of a PBKDF2 pseudo-random function. The default is HmacSHA1.
+func pbkdf2PRF(oid asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(oid) == 0, oid.Equal(oidHmacWithSHA1):
+		return sha1.New, nil
+	case oid.Equal(oidHmacWithSHA224):
+		return sha256.New224, nil
+	case oid.Equal(oidHmacWithSHA256):
+		return sha256.New, nil
+	case oid.Equal(oidHmacWithSHA384):
+		return sha512.New384, nil
+	case oid.Equal(oidHmacWithSHA512):
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("unsupported PBKDF2 PRF %s", oid)
+}
+
+// pbeDecrypt decrypts data that was encrypted with a PBE algorithm and PKCS#5 padding.
+func pbeDecrypt(alg pkix.AlgorithmIdentifier, password []rune, data []byte) ([]byte, error) {
+	block, iv, err := pbeCipher(alg, password)
+	if err != nil {
+		return nil, err
+	}
+	bs := block.BlockSize()
+	if len(data) == 0 || len(data)%bs != 0 {
+		return nil, errPKCS12Decrypt
+	}
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	pad := int(out[len(out)-1])
+	if pad == 0 || pad > bs || !bytes.Equal(out[len(out)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, errPKCS12Decrypt
+	}
+	return out[:len(out)-pad], nil
+}
+
+// pbeEncrypt encrypts data with PBES2, PBKDF2 with HmacSHA256, and AES-256 in CBC mode.
+// It returns the algorithm identifier and the ciphertext.
+func pbeEncrypt(password []rune, data []byte) (pkix.AlgorithmIdentifier, []byte, error) {
+	salt := make([]byte, 20)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pkcs12Iterations,
+		KeyLength:      32,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHmacWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+	alg := pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}}
+
+	block, iv, err := pbeCipher(alg, password)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+	pad := block.BlockSize() - len(data)%block.BlockSize()
+	padded := append(bytes.Clone(data), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(padded, padded)
+	return alg, padded, nil
+}
+
+// encryptPrivateKey returns the EncryptedPrivateKeyInfo of a PKCS#8 private key.
+func encryptPrivateKey(pkcs8 []byte, password []rune) ([]byte, error) {
+	alg, encrypted, err := pbeEncrypt(password, pkcs8)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs12EncryptedPrivateKeyInfo{Algorithm: alg, EncryptedData: encrypted})
+}
+
+// decryptPrivateKey returns the PKCS#8 encoding of the private key in an EncryptedPrivateKeyInfo.
+func decryptPrivateKey(encrypted []byte, password []rune) ([]byte, error) {
+	var info pkcs12EncryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(encrypted, &info); err != nil {
+		return nil, fmt.Errorf("invalid EncryptedPrivateKeyInfo: %v", err)
+	}
+	return pbeDecrypt(info.Algorithm, password, info.EncryptedData)
+}
+
+// pkcs12MAC computes the MAC of a store's content with a key from the PKCS#12 key derivation function.
+func pkcs12MAC(digestAlg asn1.ObjectIdentifier, password []rune, salt []byte, iterations int, content []byte) ([]byte, error) {
+	newHash, v, err := pkcs12Hash(digestAlg)
+	if err != nil {
+		return nil, err
+	}
+	key := pkcs12KDF(newHash, v, bmpPassword(password), salt, iterations, 3, newHash().Size())
+	mac := hmac.New(newHash, key)
+	mac.Write(content)
+	return mac.Sum(nil), nil
+}
+
+// decodePKCS12 decodes a PKCS#12 store. A nil password skips the integrity check, as the JDK
+// does, and any certificates that are encrypted are then skipped.
+func decodePKCS12(data []byte, password []rune) (*pkcs12Contents, error) {
+	var pfx pkcs12PFX
+	if rest, err := asn1.Unmarshal(data, &pfx); err != nil {
+		return nil, fmt.Errorf("invalid PKCS#12 data: %v", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("invalid PKCS#12 data: trailing bytes")
+	}
+	if pfx.Version != 3 {
+		return nil, fmt.Errorf("unsupported PKCS#12 version %d", pfx.Version)
+	}
+	if !pfx.AuthSafe.ContentType.Equal(oidPKCS7Data) {
+		return nil, errors.New("only password-protected PKCS#12 stores are supported")
+	}
+	var authSafe []byte
+	if _, err := asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		return nil, fmt.Errorf("invalid PKCS#12 content: %v", err)
+	}
+
+	if password != nil && len(pfx.MacData.Mac.Digest) > 0 {
+		mac, err := pkcs12MAC(pfx.MacData.Mac.Algorithm.Algorithm, password, pfx.MacData.MacSalt,
+			pfx.MacData.Iterations, authSafe)
+		if err != nil {
+			return nil, err
+		}
+		if !hmac.Equal(mac, pfx.MacData.Mac.Digest) {
+			return nil, errPKCS12MAC
+		}
+	}
+
+	var contentInfos []pkcs12ContentInfo
+	if _, err := asn1.Unmarshal(authSafe, &contentInfos); err != nil {
+		return nil, fmt.Errorf("invalid PKCS#12 authenticated safe: %v", err)
+	}
+
+	contents := &pkcs12Contents{}
+	for _, ci := range contentInfos {
+		var safeContents []byte
+		switch {
+		case ci.ContentType.Equal(oidPKCS7Data):
+			if _, err := asn1.Unmarshal(ci.Content.Bytes, &safeContents); err != nil {
+				return nil, fmt.Errorf("invalid PKCS#12 safe contents: %v", err)
+			}
+		case ci.ContentType.Equal(oidPKCS7EncryptedData):
+			if password == nil {
+				continue
+			}
+			var ed pkcs12EncryptedData
+			if _, err := asn1.Unmarshal(ci.Content.Bytes, &ed); err != nil {
+				return nil, fmt.Errorf("invalid PKCS#12 encrypted data: %v", err)
+			}
+			plain, err := pbeDecrypt(ed.EncryptedContentInfo.ContentEncryptionAlgorithm, password,
+				ed.EncryptedContentInfo.EncryptedContent)
+			if err != nil {
+				return nil, err
+			}
+			safeContents = plain
+		default:
+			return nil, fmt.Errorf("unsupported PKCS#12 content type %s", ci.ContentType)
+		}
+		if err := contents.addBags(safeContents); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// addBags adds the key and certificate bags of a SafeContents. Other bag types are ignored.
+func (contents *pkcs12Contents) addBags(safeContents []byte) error {
+	var bags []pkcs12SafeBag
+	if _, err := asn1.Unmarshal(safeContents, &bags); err != nil {
+		return fmt.Errorf("invalid PKCS#12 safe bags: %v", err)
+	}
+	for _, bag := range bags {
+		friendlyName, localKeyID, trusted, err := bagAttributes(bag.Attributes)
+		if err != nil {
+			return err
+		}
+		switch {
+		case bag.ID.Equal(oidPKCS8ShroudedKeyBag), bag.ID.Equal(oidKeyBag):
+			contents.keys = append(contents.keys, pkcs12Key{
+				friendlyName: friendlyName,
+				localKeyID:   localKeyID,
+				encrypted:    bag.Value.Bytes,
+				plain:        bag.ID.Equal(oidKeyBag),
+			})
+		case bag.ID.Equal(oidCertBag):
+			var certBag pkcs12CertBag
+			if _, err := asn1.Unmarshal(bag.Value.Bytes, &certBag); err != nil {
+				return fmt.Errorf("invalid PKCS#12 certificate bag: %v", err)
+			}
+			if !certBag.ID.Equal(oidX509CertType) {
+				continue
+			}
+			cert, err := x509.ParseCertificate(certBag.Data)
+			if err != nil {
+				return err
+			}
+			contents.certs = append(contents.certs, pkcs12Cert{
+				friendlyName: friendlyName,
+				localKeyID:   localKeyID,
+				trusted:      trusted,
+				cert:         cert,
+			})
+		}
+	}
+	return nil
+}
+
+// bagAttributes returns the friendly name and local key ID of a safe bag, and whether it is
+// marked as a trusted certificate.
+func bagAttributes(attrs []pkcs12Attribute) (friendlyName string, localKeyID []byte, trusted bool, err error) {
+	for _, attr := range attrs {
+		switch {
+		case attr.ID.Equal(oidFriendlyName):
+			var bmp asn1.RawValue
+			if _, err = asn1.Unmarshal(attr.Values.Bytes, &bmp); err != nil {
+				return "", nil, false, fmt.Errorf("invalid friendlyName: %v", err)
+			}
+			if len(bmp.Bytes)%2 != 0 {
+				return "", nil, false, errors.New("invalid friendlyName: odd length")
+			}
+			units := make([]uint16, len(bmp.Bytes)/2)
+			for i := range units {
+				units[i] = uint16(bmp.Bytes[2*i])<<8 | uint16(bmp.Bytes[2*i+1])
+			}
+			friendlyName = string(utf16.Decode(units))
+		case attr.ID.Equal(oidLocalKeyID):
+			if _, err = asn1.Unmarshal(attr.Values.Bytes, &localKeyID); err != nil {
+				return "", nil, false, fmt.Errorf("invalid localKeyId: %v", err)
+			}
+		case attr.ID.Equal(oidTrustedKeyUsage):
+			trusted = true
+		}
+	}
+	return friendlyName, localKeyID, trusted, nil
+}
+
+// encodePKCS12 encodes a PKCS#12 store. Key bags must already be encrypted. With a nil
+// password, the certificates are not encrypted and there is no MAC, as in the JDK.
+func encodePKCS12(contents *pkcs12Contents, password []rune) ([]byte, error) {
+	var keyBags, certBags []pkcs12SafeBag
+	for _, key := range contents.keys {
+		attrs, err := makeBagAttributes(key.friendlyName, key.localKeyID, false)
+		if err != nil {
+			return nil, err
+		}
+		keyBags = append(keyBags, pkcs12SafeBag{
+			ID:         oidPKCS8ShroudedKeyBag,
+			Value:      asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: key.encrypted},
+			Attributes: attrs,
+		})
+	}
+	for _, cert := range contents.certs {
+		attrs, err := makeBagAttributes(cert.friendlyName, cert.localKeyID, cert.trusted)
+		if err != nil {
+			return nil, err
+		}
+		certBag, err := asn1.Marshal(pkcs12CertBag{ID: oidX509CertType, Data: cert.cert.Raw})
+		if err != nil {
+			return nil, err
+		}
+		certBags = append(certBags, pkcs12SafeBag{
+			ID:         oidCertBag,
+			Value:      asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certBag},
+			Attributes: attrs,
+		})
+	}
+
+	var contentInfos []pkcs12ContentInfo
+	if len(certBags) > 0 {
+		safeContents, err := asn1.Marshal(certBags)
+		if err != nil {
+			return nil, err
+		}
+		var ci pkcs12ContentInfo
+		if password == nil {
+			ci, err = dataContentInfo(safeContents)
+		} else {
+			ci, err = encryptedContentInfo(safeContents, password)
+		}
+		if err != nil {
+			return nil, err
+		}
+		contentInfos = append(contentInfos, ci)
+	}
+	if len(keyBags) > 0 {
+		safeContents, err := asn1.Marshal(keyBags)
+		if err != nil {
+			return nil, err
+		}
+		ci, err := dataContentInfo(safeContents)
+		if err != nil {
+			return nil, err
+		}
+		contentInfos = append(contentInfos, ci)
+	}
+
+	authSafe, err := asn1.Marshal(contentInfos)
+	if err != nil {
+		return nil, err
+	}
+	authSafeInfo, err := dataContentInfo(authSafe)
+	if err != nil {
+		return nil, err
+	}
+	pfx := pkcs12PFX{Version: 3, AuthSafe: authSafeInfo}
+
+	if password != nil {
+		salt := make([]byte, 20)
+		if _, err = rand.Read(salt); err != nil {
+			return nil, err
+		}
+		mac, err := pkcs12MAC(oidSHA256, password, salt, pkcs12Iterations, authSafe)
+		if err != nil {
+			return nil, err
+		}
+		pfx.MacData = pkcs12MacData{
+			Mac: pkcs12DigestInfo{
+				Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256, Parameters: asn1.NullRawValue},
+				Digest:    mac,
+			},
+			MacSalt:    salt,
+			Iterations: pkcs12Iterations,
+		}
+	}
+	return asn1.Marshal(pfx)
+}
+
+// dataContentInfo wraps content in a ContentInfo of type data.
+func dataContentInfo(content []byte) (pkcs12ContentInfo, error) {
+	octets, err := asn1.Marshal(content)
+	if err != nil {
+		return pkcs12ContentInfo{}, err
+	}
+	return pkcs12ContentInfo{
+		ContentType: oidPKCS7Data,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: octets},
+	}, nil
+}
+
+// encryptedContentInfo encrypts content into a ContentInfo of type encryptedData.
+func encryptedContentInfo(content []byte, password []rune) (pkcs12ContentInfo, error) {
+	alg, encrypted, err := pbeEncrypt(password, content)
+	if err != nil {
+		return pkcs12ContentInfo{}, err
+	}
+	ed, err := asn1.Marshal(pkcs12EncryptedData{
+		Version: 0,
+		EncryptedContentInfo: pkcs12EncryptedContentInfo{
+			ContentType:                oidPKCS7Data,
+			ContentEncryptionAlgorithm: alg,
+			EncryptedContent:           encrypted,
+		},
+	})
+	if err != nil {
+		return pkcs12ContentInfo{}, err
+	}
+	return pkcs12ContentInfo{
+		ContentType: oidPKCS7EncryptedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: ed},
+	}, nil
+}
+
+// makeBagAttributes returns the friendlyName, localKeyId and trusted-certificate attributes of a bag.
+func makeBagAttributes(friendlyName string, localKeyID []byte, trusted bool) ([]pkcs12Attribute, error) {
+	var attrs []pkcs12Attribute
+	add := func(oid asn1.ObjectIdentifier, value any) error {
+		encoded, err := asn1.Marshal(value)
+		if err != nil {
+			return err
+		}
+		attrs = append(attrs, pkcs12Attribute{
+			ID:     oid,
+			Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: encoded},
+		})
+		return nil
+	}
+
+	if friendlyName != "" {
+		var bmp []byte
+		for _, u := range utf16.Encode([]rune(friendlyName)) {
+			bmp = append(bmp, byte(u>>8), byte(u))
+		}
+		if err := add(oidFriendlyName, asn1.RawValue{Tag: asn1.TagBMPString, Bytes: bmp}); err != nil {
+			return nil, err
+		}
+	}
+	if localKeyID != nil {
+		if err := add(oidLocalKeyID, localKeyID); err != nil {
+			return nil, err
+		}
+	}
+	if trusted {
+		if err := add(oidTrustedKeyUsage, oidAnyExtendedKeyUsage); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
@@ -93,6 +93,15 @@ var SecurityProviderServices = map[string]map[string]func() *object.Object{
 		"X448": func() *object.Object {
 			return NewGoRuntimeService(types.SecurityServiceKeyPairGenerator, "X448", types.ClassNameKeyPairGenerator)
 		},
+		"ML-KEM": func() *object.Object {
+			return NewGoRuntimeService(types.SecurityServiceKeyPairGenerator, "ML-KEM", types.ClassNameKeyPairGenerator)
+		},
+		"ML-KEM-768": func() *object.Object {
+			return NewGoRuntimeService(types.SecurityServiceKeyPairGenerator, "ML-KEM-768", types.ClassNameKeyPairGenerator)
+		},
+		"ML-KEM-1024": func() *object.Object {
+			return NewGoRuntimeService(types.SecurityServiceKeyPairGenerator, "ML-KEM-1024", types.ClassNameKeyPairGenerator)
+		},
 	},
 	types.SecurityServiceMessageDigest: {
 		"MD5": func() *object.Object {
@@ -0,0 +1,414 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaSecurity
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+)
+
+// X500Principal holds the DER encoding of a distinguished name in its "value" field and
+// formats it on demand in the RFC 2253, RFC 1779 and canonical forms of the JDK.
+
+const (
+	x500FormatRFC1779   = "RFC1779"
+	x500FormatRFC2253   = "RFC2253"
+	x500FormatCanonical = "CANONICAL"
+)
+
+// x500Keywords maps the attribute type keywords the JDK recognises in a name string to their OIDs.
+var x500Keywords = map[string]asn1.ObjectIdentifier{
+	"CN":           {2, 5, 4, 3},
+	"C":            {2, 5, 4, 6},
+	"L":            {2, 5, 4, 7},
+	"ST":           {2, 5, 4, 8},
+	"S":            {2, 5, 4, 8},
+	"O":            {2, 5, 4, 10},
+	"OU":           {2, 5, 4, 11},
+	"T":            {2, 5, 4, 12},
+	"STREET":       {2, 5, 4, 9},
+	"SERIALNUMBER": {2, 5, 4, 5},
+	"DC":           {0, 9, 2342, 19200300, 100, 1, 25},
+	"UID":          {0, 9, 2342, 19200300, 100, 1, 1},
+	"EMAILADDRESS": {1, 2, 840, 113549, 1, 9, 1},
+}
+
+// x500RFC2253Keywords are the keywords RFC 2253 output uses. Other attribute types are shown
+// as a dotted OID with a hex-encoded value, as the JDK does.
+var x500RFC2253Keywords = []string{"CN", "C", "L", "ST", "O", "OU", "STREET", "DC", "UID"}
+
+// x500RFC1779Keywords are the keywords RFC 1779 output (and toString) uses.
+var x500RFC1779Keywords = []string{"CN", "C", "L", "ST", "O", "OU", "STREET"}
+
+func Load_Security_Auth_X500Principal() {
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x500PrincipalClinit,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.<init>(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x500PrincipalInitString,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.<init>([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x500PrincipalInitBytes,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.equals(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x500PrincipalEquals,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.getEncoded()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x500PrincipalGetEncoded,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.getName()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x500PrincipalGetName,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.getName(Ljava/lang/String;)Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  x500PrincipalGetName,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.hashCode()I"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x500PrincipalHashCode,
+		}
+
+	ghelpers.MethodSignatures["javax/security/auth/x500/X500Principal.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  x500PrincipalToString,
+		}
+}
+
+func x500PrincipalClinit(params []any) any {
+	for _, format := range []string{x500FormatRFC1779, x500FormatRFC2253, x500FormatCanonical} {
+		_ = statics.AddStatic(types.ClassNameX500Principal+"."+format,
+			statics.Static{Type: types.StringClassRef, Value: object.StringObjectFromGoString(format)})
+	}
+	return nil
+}
+
+// NewX500PrincipalObject returns an X500Principal for the DER encoding of a distinguished name.
+func NewX500PrincipalObject(der []byte) *object.Object {
+	principal := object.MakeEmptyObjectWithClassName(&types.ClassNameX500Principal)
+	principal.FieldTable["value"] = object.Field{Ftype: types.GoByteArray, Fvalue: bytes.Clone(der)}
+	return principal
+}
+
+// x500PrincipalDER returns the DER encoding held by an X500Principal.
+func x500PrincipalDER(obj *object.Object) []byte {
+	der, _ := obj.FieldTable["value"].Fvalue.([]byte)
+	return der
+}
+
+func x500PrincipalInitString(params []any) any {
+	self := params[0].(*object.Object)
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "provided null name")
+	}
+	rdns, err := parseX500Name(object.GoStringFromStringObject(nameObj))
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "improperly specified input name: "+
+			object.GoStringFromStringObject(nameObj)+": "+err.Error())
+	}
+	der, err := asn1.Marshal(rdns)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "improperly specified input name: "+err.Error())
+	}
+	self.FieldTable["value"] = object.Field{Ftype: types.GoByteArray, Fvalue: der}
+	return nil
+}
+
+func x500PrincipalInitBytes(params []any) any {
+	self := params[0].(*object.Object)
+	derObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(derObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "provided null name")
+	}
+	der := object.GoByteArrayFromJavaByteArray(derObj.FieldTable["value"].Fvalue.([]types.JavaByte))
+	var rdns pkix.RDNSequence
+	if rest, err := asn1.Unmarshal(der, &rdns); err != nil || len(rest) > 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "improperly specified input name")
+	}
+	self.FieldTable["value"] = object.Field{Ftype: types.GoByteArray, Fvalue: bytes.Clone(der)}
+	return nil
+}
+
+func x500PrincipalGetEncoded(params []any) any {
+	der := x500PrincipalDER(params[0].(*object.Object))
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray(bytes.Clone(der)))
+}
+
+func x500PrincipalGetName(params []any) any {
+	format := x500FormatRFC2253
+	if len(params) > 1 {
+		formatObj, ok := params[1].(*object.Object)
+		if !ok || object.IsNull(formatObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "format cannot be null")
+		}
+		format = object.GoStringFromStringObject(formatObj)
+	}
+	name, err := formatX500Name(x500PrincipalDER(params[0].(*object.Object)), format)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, err.Error())
+	}
+	return object.StringObjectFromGoString(name)
+}
+
+func x500PrincipalToString(params []any) any {
+	name, err := formatX500Name(x500PrincipalDER(params[0].(*object.Object)), x500FormatRFC1779)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, err.Error())
+	}
+	return object.StringObjectFromGoString(name)
+}
+
+// x500PrincipalEquals compares the canonical forms of the two names, as the JDK does.
+func x500PrincipalEquals(params []any) any {
+	self := params[0].(*object.Object)
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) || other.KlassName != self.KlassName {
+		return types.JavaBoolFalse
+	}
+	a, errA := formatX500Name(x500PrincipalDER(self), x500FormatCanonical)
+	b, errB := formatX500Name(x500PrincipalDER(other), x500FormatCanonical)
+	return types.ConvertGoBoolToJavaBool(errA == nil && errB == nil && a == b)
+}
+
+func x500PrincipalHashCode(params []any) any {
+	name, _ := formatX500Name(x500PrincipalDER(params[0].(*object.Object)), x500FormatCanonical)
+	var hash int32
+	for _, r := range name {
+		hash = 31*hash + int32(r)
+	}
+	return int64(hash)
+}
+
+// formatX500Name formats the DER encoding of a distinguished name. As in the JDK, the
+// relative distinguished names appear in the reverse of their encoded order.
+func formatX500Name(der []byte, format string) (string, error) {
+	var rdns pkix.RDNSequence
+	if _, err := asn1.Unmarshal(der, &rdns); err != nil {
+		return "", fmt.Errorf("invalid distinguished name: %v", err)
+	}
+
+	var keywords []string
+	separator := ","
+	switch strings.ToUpper(format) {
+	case x500FormatRFC2253, x500FormatCanonical:
+		keywords = x500RFC2253Keywords
+	case x500FormatRFC1779:
+		keywords = x500RFC1779Keywords
+		separator = ", "
+	default:
+		return "", fmt.Errorf("invalid format specified")
+	}
+	canonical := strings.EqualFold(format, x500FormatCanonical)
+	quoted := strings.EqualFold(format, x500FormatRFC1779)
+
+	parts := make([]string, 0, len(rdns))
+	for i := len(rdns) - 1; i >= 0; i-- {
+		atvs := make([]string, 0, len(rdns[i]))
+		for _, atv := range rdns[i] {
+			atvs = append(atvs, formatX500Attribute(atv, keywords, canonical, quoted))
+		}
+		if canonical {
+			// The canonical form sorts the attributes of a multi-valued RDN.
+			slices.Sort(atvs)
+		}
+		parts = append(parts, strings.Join(atvs, "+"))
+	}
+	return strings.Join(parts, separator), nil
+}
+
+// formatX500Attribute formats one type=value pair of a relative distinguished name. RFC 1779
+// quotes a value with special characters rather than escaping them.
+func formatX500Attribute(atv pkix.AttributeTypeAndValue, keywords []string, canonical, quoted bool) string {
+	keyword := ""
+	for _, kw := range keywords {
+		if x500Keywords[kw].Equal(atv.Type) {
+			keyword = kw
+			break
+		}
+	}
+
+	value, isString := atv.Value.(string)
+	if keyword == "" || !isString {
+		// Unknown attribute types and non-string values are shown as the hex of their DER encoding.
+		encoded, _ := asn1.Marshal(atv.Value)
+		return atv.Type.String() + "=#" + hex.EncodeToString(encoded)
+	}
+
+	if canonical {
+		keyword = strings.ToLower(keyword)
+		value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+	}
+	if quoted && strings.ContainsAny(value, ",+=\"\\<>#;\n") {
+		return keyword + "=\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value) + "\""
+	}
+	return keyword + "=" + escapeX500Value(value)
+}
+
+// escapeX500Value escapes the characters of an attribute value that are special in RFC 2253.
+func escapeX500Value(value string) string {
+	var sb strings.Builder
+	for i, r := range value {
+		switch {
+		case strings.ContainsRune(",+\"\\<>;", r),
+			i == 0 && (r == '#' || r == ' '),
+			i == len(value)-1 && r == ' ':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// parseX500Name parses a distinguished name string in RFC 1779 or RFC 2253 form. The relative
+// distinguished names are returned in encoded order, which is the reverse of the string order.
+func parseX500Name(name string) (pkix.RDNSequence, error) {
+	var rdns pkix.RDNSequence
+	if strings.TrimSpace(name) == "" {
+		return pkix.RDNSequence{}, nil
+	}
+	for _, rdnString := range splitX500Name(name, ",;") {
+		var rdn pkix.RelativeDistinguishedNameSET
+		for _, atvString := range splitX500Name(rdnString, "+") {
+			keyword, value, found := strings.Cut(atvString, "=")
+			if !found {
+				return nil, fmt.Errorf("no equals sign in %q", strings.TrimSpace(atvString))
+			}
+			keyword = strings.TrimSpace(keyword)
+			oid, ok := x500Keywords[strings.ToUpper(keyword)]
+			if !ok {
+				var err error
+				if oid, err = parseOID(strings.TrimPrefix(strings.ToUpper(keyword), "OID.")); err != nil {
+					return nil, fmt.Errorf("invalid keyword %q", keyword)
+				}
+			}
+			parsed, err := unescapeX500Value(strings.TrimSpace(value))
+			if err != nil {
+				return nil, err
+			}
+			rdn = append(rdn, pkix.AttributeTypeAndValue{Type: oid, Value: parsed})
+		}
+		rdns = append(rdns, rdn)
+	}
+	// reverse into encoded order
+	for i, j := 0, len(rdns)-1; i < j; i, j = i+1, j-1 {
+		rdns[i], rdns[j] = rdns[j], rdns[i]
+	}
+	return rdns, nil
+}
+
+// splitX500Name splits s at each separator that is not escaped or quoted.
+func splitX500Name(s, separators string) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.IndexByte(separators, s[i]) >= 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeX500Value removes the quotes and escapes from an attribute value. A value beginning
+// with '#' is the hex of a DER encoding.
+func unescapeX500Value(value string) (any, error) {
+	if strings.HasPrefix(value, "#") {
+		der, err := hex.DecodeString(value[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex value %q", value)
+		}
+		var raw asn1.RawValue
+		if _, err = asn1.Unmarshal(der, &raw); err != nil {
+			return nil, fmt.Errorf("invalid DER value %q", value)
+		}
+		return raw, nil
+	}
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			sb.WriteByte(value[i])
+			continue
+		}
+		i++
+		if i == len(value) {
+			return nil, fmt.Errorf("trailing backslash")
+		}
+		// an escaped pair of hex digits stands for a byte
+		if i+1 < len(value) && isHexDigit(rune(value[i])) && isHexDigit(rune(value[i+1])) {
+			b, _ := hex.DecodeString(value[i : i+2])
+			sb.Write(b)
+			i++
+			continue
+		}
+		sb.WriteByte(value[i])
+	}
+	return sb.String(), nil
+}
+
+func isHexDigit(r rune) bool {
+	return unicode.Is(unicode.ASCII_Hex_Digit, r)
+}
+
+// parseOID parses a dotted object identifier such as "2.5.4.3".
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	var oid asn1.ObjectIdentifier
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid = append(oid, n)
+	}
+	if len(oid) < 2 {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	return oid, nil
+}
@@ -0,0 +1,481 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
+)
+
+// ArrayDeque holds its elements in a Go slice, head first. As in the JDK, null elements are
+// rejected with a NullPointerException.
+
+var classNameArrayDeque = "java/util/ArrayDeque"
+var fieldNameDeque = "deque"
+
+type arrayDeque struct {
+	elements []any
+}
+
+func Load_Util_ArrayDeque() {
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeInit}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.<init>(I)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeInit}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.<init>(Ljava/util/Collection;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.add(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeOfferLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.addAll(Ljava/util/Collection;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeAddAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.addFirst(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeAddFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.addLast(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeAddLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeClear}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.clone()Ljava/util/ArrayDeque;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeClone}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.contains(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeContains, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.descendingIterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeDescendingIterator}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.element()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeGetFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.forEach(Ljava/util/function/Consumer;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeForEach, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.getFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeGetFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.getLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeGetLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeIsEmpty}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.iterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: setIterator}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.offer(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeOfferLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.offerFirst(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeOfferFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.offerLast(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeOfferLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.peek()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePeekFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.peekFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePeekFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.peekLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePeekLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.poll()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePollFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.pollFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePollFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.pollLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequePollLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.pop()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeRemoveFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.push(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeAddFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.remove()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeRemoveFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.remove(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeRemoveFirstOccurrence, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.removeFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeRemoveFirst}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.removeFirstOccurrence(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeRemoveFirstOccurrence, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.removeIf(Ljava/util/function/Predicate;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeRemoveIf, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.removeLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeRemoveLast}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.removeLastOccurrence(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: arraydequeRemoveLastOccurrence, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeSize}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.spliterator()Ljava/util/Spliterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+
+	ghelpers.MethodSignatures["java/util/ArrayDeque.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: arraydequeToString, NeedsContext: true}
+}
+
+// --- helpers for getting at the Go state of ArrayDeque objects ---
+
+func arraydequeThis(params []interface{}, caller string) (*list.List, []interface{}, *arrayDeque, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing 'this' parameter")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(this) {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": 'this' is null")
+	}
+	dq, ok := this.FieldTable[fieldNameDeque].Fvalue.(*arrayDeque)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(this.KlassName)
+		errMsg := fmt.Sprintf("%s: %s object has not been initialized", caller, className)
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+	}
+	return fs, args, dq, nil
+}
+
+func setArrayDeque(obj *object.Object, dq *arrayDeque) {
+	obj.FieldTable[fieldNameDeque] = object.Field{Ftype: types.RawGoPointer, Fvalue: dq}
+}
+
+// removeOccurrence removes the first (or, if last is set, the last) element that equals() target.
+func (dq *arrayDeque) removeOccurrence(fs *list.List, target any, last bool) (bool, *ghelpers.GErrBlk) {
+	if isNullValue(target) {
+		return false, nil
+	}
+	for ix := range dq.elements {
+		if last {
+			ix = len(dq.elements) - 1 - ix
+		}
+		eq, gerr := javaEquals(fs, target, dq.elements[ix])
+		if gerr != nil {
+			return false, gerr
+		}
+		if eq {
+			dq.elements = slices.Delete(dq.elements, ix, ix+1)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// --- ArrayDeque G functions ---
+
+// ArrayDeque() and ArrayDeque(int)
+func arraydequeInit(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "arraydequeInit: invalid 'this' argument")
+	}
+	dq := &arrayDeque{}
+	if len(params) > 1 {
+		if capacity, ok := params[1].(int64); ok && capacity > 0 {
+			dq.elements = make([]any, 0, capacity)
+		}
+	}
+	setArrayDeque(this, dq)
+	return nil
+}
+
+func arraydequeInitFromCollection(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "arraydequeInitFromCollection: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeInitFromCollection: collection is null")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	if slices.ContainsFunc(elements, isNullValue) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeInitFromCollection: null elements are not permitted")
+	}
+	setArrayDeque(this, &arrayDeque{elements: elements})
+	return nil
+}
+
+// addFirst() and push()
+func arraydequeAddFirst(params []interface{}) interface{} {
+	ret := arraydequeOfferFirst(params)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	return nil
+}
+
+func arraydequeAddLast(params []interface{}) interface{} {
+	ret := arraydequeOfferLast(params)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	return nil
+}
+
+func arraydequeOfferFirst(params []interface{}) interface{} {
+	_, args, dq, gerr := arraydequeThis(params, "arraydequeOfferFirst")
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(args[1]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeOfferFirst: null elements are not permitted")
+	}
+	dq.elements = slices.Insert(dq.elements, 0, args[1])
+	return types.JavaBoolTrue
+}
+
+// add(), offer() and offerLast()
+func arraydequeOfferLast(params []interface{}) interface{} {
+	_, args, dq, gerr := arraydequeThis(params, "arraydequeOfferLast")
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(args[1]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeOfferLast: null elements are not permitted")
+	}
+	dq.elements = append(dq.elements, args[1])
+	return types.JavaBoolTrue
+}
+
+func arraydequeAddAll(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeAddAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeAddAll: collection is null")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	if slices.ContainsFunc(elements, isNullValue) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "arraydequeAddAll: null elements are not permitted")
+	}
+	dq.elements = append(dq.elements, elements...)
+	return object.JavaBooleanFromGoBoolean(len(elements) > 0)
+}
+
+// arraydequeEnd returns (and, if remove is set, removes) the first or last element. An empty deque
+// yields NoSuchElementException if mustExist is set, otherwise null.
+func arraydequeEnd(params []interface{}, caller string, last, remove, mustExist bool) interface{} {
+	_, _, dq, gerr := arraydequeThis(params, caller)
+	if gerr != nil {
+		return gerr
+	}
+	if len(dq.elements) == 0 {
+		if mustExist {
+			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, caller+": deque is empty")
+		}
+		return object.Null
+	}
+	ix := 0
+	if last {
+		ix = len(dq.elements) - 1
+	}
+	elem := dq.elements[ix]
+	if remove {
+		dq.elements = slices.Delete(dq.elements, ix, ix+1)
+	}
+	return elem
+}
+
+// getFirst() and element()
+func arraydequeGetFirst(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequeGetFirst", false, false, true)
+}
+
+func arraydequeGetLast(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequeGetLast", true, false, true)
+}
+
+// peekFirst() and peek()
+func arraydequePeekFirst(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequePeekFirst", false, false, false)
+}
+
+func arraydequePeekLast(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequePeekLast", true, false, false)
+}
+
+// pollFirst() and poll()
+func arraydequePollFirst(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequePollFirst", false, true, false)
+}
+
+func arraydequePollLast(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequePollLast", true, true, false)
+}
+
+// removeFirst(), remove() and pop()
+func arraydequeRemoveFirst(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequeRemoveFirst", false, true, true)
+}
+
+func arraydequeRemoveLast(params []interface{}) interface{} {
+	return arraydequeEnd(params, "arraydequeRemoveLast", true, true, true)
+}
+
+// removeFirstOccurrence() and remove(Object)
+func arraydequeRemoveFirstOccurrence(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeRemoveFirstOccurrence")
+	if gerr != nil {
+		return gerr
+	}
+	removed, gerr := dq.removeOccurrence(fs, args[1], false)
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(removed)
+}
+
+func arraydequeRemoveLastOccurrence(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeRemoveLastOccurrence")
+	if gerr != nil {
+		return gerr
+	}
+	removed, gerr := dq.removeOccurrence(fs, args[1], true)
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(removed)
+}
+
+func arraydequeContains(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeContains")
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(args[1]) {
+		return types.JavaBoolFalse
+	}
+	for _, elem := range dq.elements {
+		eq, gerr := javaEquals(fs, args[1], elem)
+		if gerr != nil {
+			return gerr
+		}
+		if eq {
+			return types.JavaBoolTrue
+		}
+	}
+	return types.JavaBoolFalse
+}
+
+// forEach(Consumer) visits the elements from head to tail.
+func arraydequeForEach(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeForEach")
+	if gerr != nil {
+		return gerr
+	}
+	action, _ := args[1].(*object.Object)
+	if gerr = forEachElement(fs, action, slices.Clone(dq.elements), "arraydequeForEach"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func arraydequeRemoveIf(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeRemoveIf")
+	if gerr != nil {
+		return gerr
+	}
+	filter, _ := args[1].(*object.Object)
+	kept, removed, gerr := filterElements(fs, filter, slices.Clone(dq.elements), "arraydequeRemoveIf")
+	if gerr != nil {
+		return gerr
+	}
+	if removed {
+		dq.elements = kept
+	}
+	return object.JavaBooleanFromGoBoolean(removed)
+}
+
+func arraydequeSize(params []interface{}) interface{} {
+	_, _, dq, gerr := arraydequeThis(params, "arraydequeSize")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(dq.elements))
+}
+
+func arraydequeIsEmpty(params []interface{}) interface{} {
+	_, _, dq, gerr := arraydequeThis(params, "arraydequeIsEmpty")
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(len(dq.elements) == 0)
+}
+
+func arraydequeClear(params []interface{}) interface{} {
+	_, _, dq, gerr := arraydequeThis(params, "arraydequeClear")
+	if gerr != nil {
+		return gerr
+	}
+	dq.elements = nil
+	return nil
+}
+
+func arraydequeClone(params []interface{}) interface{} {
+	_, args, dq, gerr := arraydequeThis(params, "arraydequeClone")
+	if gerr != nil {
+		return gerr
+	}
+	this := args[0].(*object.Object)
+	clone := object.MakeEmptyObjectWithClassName(new(object.GoStringFromStringPoolIndex(this.KlassName)))
+	setArrayDeque(clone, &arrayDeque{elements: slices.Clone(dq.elements)})
+	return clone
+}
+
+func arraydequeDescendingIterator(params []interface{}) interface{} {
+	_, args, dq, gerr := arraydequeThis(params, "arraydequeDescendingIterator")
+	if gerr != nil {
+		return gerr
+	}
+	elements := slices.Clone(dq.elements)
+	slices.Reverse(elements)
+	return newSnapshotIterator(args[0].(*object.Object), elements)
+}
+
+func arraydequeToString(params []interface{}) interface{} {
+	fs, args, dq, gerr := arraydequeThis(params, "arraydequeToString")
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(formatElements(fs, args[0].(*object.Object), slices.Clone(dq.elements)))
+}
@@ -0,0 +1,136 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newArrayDeque(t *testing.T) *object.Object {
+	t.Helper()
+	dq := object.MakeEmptyObjectWithClassName(&classNameArrayDeque)
+	if ret := arraydequeInit([]interface{}{dq}); ret != nil {
+		t.Fatalf("arraydequeInit returned error: %v", ret)
+	}
+	return dq
+}
+
+func dequeString(t *testing.T, dq *object.Object) string {
+	t.Helper()
+	return object.GoStringFromStringObject(arraydequeToString([]interface{}{dq}).(*object.Object))
+}
+
+func TestArrayDeque_StackAndQueueOperations(t *testing.T) {
+	globals.InitStringPool()
+	dq := newArrayDeque(t)
+
+	arraydequeAddLast([]interface{}{dq, intKey(2)})
+	arraydequeAddFirst([]interface{}{dq, intKey(1)})
+	arraydequeOfferLast([]interface{}{dq, intKey(3)})
+	if got := dequeString(t, dq); got != "[1, 2, 3]" {
+		t.Errorf("unexpected contents: %q", got)
+	}
+
+	if got := keyInt(t, arraydequePeekLast([]interface{}{dq})); got != 3 {
+		t.Errorf("peekLast: expected 3, got %d", got)
+	}
+	if got := keyInt(t, arraydequeRemoveFirst([]interface{}{dq})); got != 1 {
+		t.Errorf("pop: expected 1, got %d", got)
+	}
+	if got := keyInt(t, arraydequePollLast([]interface{}{dq})); got != 3 {
+		t.Errorf("pollLast: expected 3, got %d", got)
+	}
+	if size := arraydequeSize([]interface{}{dq}).(int64); size != 1 {
+		t.Errorf("expected size 1, got %d", size)
+	}
+
+	arraydequeClear([]interface{}{dq})
+	if got := arraydequePollFirst([]interface{}{dq}); got != object.Null {
+		t.Errorf("pollFirst on an empty deque should return null")
+	}
+	ret := arraydequeGetFirst([]interface{}{dq})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NoSuchElementException {
+		t.Errorf("getFirst on an empty deque: expected NoSuchElementException, got %v", ret)
+	}
+}
+
+func TestArrayDeque_RejectsNull(t *testing.T) {
+	globals.InitStringPool()
+	dq := newArrayDeque(t)
+	ret := arraydequeAddFirst([]interface{}{dq, object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NullPointerException {
+		t.Errorf("addFirst(null): expected NullPointerException, got %v", ret)
+	}
+}
+
+func TestArrayDeque_RemoveOccurrencesAndIterators(t *testing.T) {
+	globals.InitStringPool()
+	dq := newArrayDeque(t)
+	for _, v := range []int64{1, 2, 1, 3} {
+		arraydequeAddLast([]interface{}{dq, intKey(v)})
+	}
+
+	if ret := arraydequeRemoveLastOccurrence([]interface{}{dq, intKey(1)}); ret != types.JavaBoolTrue {
+		t.Errorf("removeLastOccurrence(1) should return true")
+	}
+	if got := dequeString(t, dq); got != "[1, 2, 3]" {
+		t.Errorf("after removeLastOccurrence: %q", got)
+	}
+
+	iter := arraydequeDescendingIterator([]interface{}{dq}).(*object.Object)
+	var got []int64
+	for iteratorHasNext([]interface{}{iter}) == types.JavaBoolTrue {
+		got = append(got, keyInt(t, iteratorNext([]interface{}{iter})))
+	}
+	if len(got) != 3 || got[0] != 3 || got[2] != 1 {
+		t.Errorf("descendingIterator: %v", got)
+	}
+
+	iter = NewIterator(dq)
+	iteratorNext([]interface{}{iter})
+	iteratorRemove([]interface{}{iter})
+	if got := dequeString(t, dq); got != "[2, 3]" {
+		t.Errorf("after iterator remove: %q", got)
+	}
+}
+
+func TestArrayDeque_RemoveIfAndForEach(t *testing.T) {
+	globals.InitStringPool()
+	dq := newArrayDeque(t)
+	for _, v := range []int64{1, 2, 3, 4, 5} {
+		arraydequeAddLast([]interface{}{dq, intKey(v)})
+	}
+	isOdd := newTestFunction("test/IsOdd", "test", methTypeTest, func(args []interface{}) interface{} {
+		return object.JavaBooleanFromGoBoolean(intOf(args[0])%2 == 1)
+	})
+	if ret := arraydequeRemoveIf([]interface{}{dq, isOdd}); ret != types.JavaBoolTrue {
+		t.Fatalf("removeIf: expected true, got %v", ret)
+	}
+	if got := dequeString(t, dq); got != "[2, 4]" {
+		t.Errorf("after removeIf: %q", got)
+	}
+
+	var seen []int64
+	collector := newTestFunction("test/DequeCollector", "accept", methTypeAccept, func(args []interface{}) interface{} {
+		seen = append(seen, intOf(args[0]))
+		return nil
+	})
+	arraydequeAddFirst([]interface{}{dq, intKey(0)})
+	if ret := arraydequeForEach([]interface{}{dq, collector}); ret != nil {
+		t.Fatalf("forEach returned %v", ret)
+	}
+	if len(seen) != 3 || seen[0] != 0 || seen[1] != 2 || seen[2] != 4 {
+		t.Errorf("forEach: expected [0 2 4], got %v", seen)
+	}
+	testutil.ExpectGErr(t, arraydequeForEach([]interface{}{dq, object.Null}), excNames.NullPointerException, "action is null")
+}
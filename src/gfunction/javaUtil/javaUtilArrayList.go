@@ -281,6 +281,9 @@ func GetArrayListFromObject(self *object.Object) ([]interface{}, interface{}) {
 
 func arraylistAdd(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.add"); gerr != nil {
+		return gerr
+	}
 	element := params[1]
 
 	list, err := GetArrayListFromObject(self)
@@ -295,6 +298,9 @@ func arraylistAdd(params []interface{}) interface{} {
 
 func arraylistAddAtIndex(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.add"); gerr != nil {
+		return gerr
+	}
 	index, ok1 := params[1].(int64)
 	element := params[2]
 
@@ -343,6 +349,9 @@ func arraylistGet(params []interface{}) interface{} {
 
 func arraylistSet(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.set"); gerr != nil {
+		return gerr
+	}
 	index, ok := params[1].(int64)
 	element := params[2]
 
@@ -416,6 +425,9 @@ func arraylistIsEmpty(params []interface{}) interface{} {
 
 func arraylistClear(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.clear"); gerr != nil {
+		return gerr
+	}
 	list, err := GetArrayListFromObject(self)
 	if err != nil {
 		return err
@@ -427,6 +439,9 @@ func arraylistClear(params []interface{}) interface{} {
 
 func arraylistRemoveAtIndex(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.remove"); gerr != nil {
+		return gerr
+	}
 	index, ok := params[1].(int64)
 
 	if !ok {
@@ -451,6 +466,9 @@ func arraylistRemoveAtIndex(params []interface{}) interface{} {
 
 func arraylistRemoveObject(params []interface{}) interface{} {
 	self := params[0].(*object.Object)
+	if gerr := checkModifiable(self, "ArrayList.remove"); gerr != nil {
+		return gerr
+	}
 	target := params[1]
 
 	list, err := GetArrayListFromObject(self)
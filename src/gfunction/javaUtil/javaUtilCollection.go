@@ -20,8 +20,9 @@ func Load_Util_Collection() {
 
 	ghelpers.MethodSignatures["java/util/Collection.add(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetAdd,
+			ParamSlots:   1,
+			GFunction:    hashsetAdd,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Collection.addAll(Ljava/util/Collection;)Z"] =
@@ -33,13 +34,14 @@ func Load_Util_Collection() {
 	ghelpers.MethodSignatures["java/util/Collection.clear()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClear,
 		}
 
 	ghelpers.MethodSignatures["java/util/Collection.contains(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetContains,
+			ParamSlots:   1,
+			GFunction:    hashsetContains,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Collection.containsAll(Ljava/util/Collection;)Z"] =
@@ -74,8 +76,9 @@ func Load_Util_Collection() {
 
 	ghelpers.MethodSignatures["java/util/Collection.remove(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetRemove,
+			ParamSlots:   1,
+			GFunction:    hashsetRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Collection.removeAll(Ljava/util/Collection;)Z"] =
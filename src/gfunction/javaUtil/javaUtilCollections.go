@@ -0,0 +1,764 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/frames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Implementation of java.util.Collections.
+//
+// The immutable collections returned by the factory methods (emptyList, singleton, nCopies, ...)
+// and by List.of, Set.of and Map.of are ordinary ArrayList, HashSet and HashMap objects that carry
+// the fieldNameUnmodifiable marker. The mutators of those classes check for the marker and throw
+// UnsupportedOperationException.
+//
+// The unmodifiable* and synchronized* wrappers are objects of the JDK's own view classes whose
+// fieldNameBacking field holds the wrapped collection. Every method of a view delegates to the
+// backing collection, so changes to it are visible through the view.
+
+const (
+	fieldNameUnmodifiable = "unmodifiable"
+	fieldNameBacking      = "backing"
+	fieldNameSynchronized = "synchronized"
+)
+
+const (
+	classNameUnmodifiableCollection = "java/util/Collections$UnmodifiableCollection"
+	classNameUnmodifiableList       = "java/util/Collections$UnmodifiableRandomAccessList"
+	classNameUnmodifiableSet        = "java/util/Collections$UnmodifiableSet"
+	classNameUnmodifiableMap        = "java/util/Collections$UnmodifiableMap"
+	classNameSynchronizedCollection = "java/util/Collections$SynchronizedCollection"
+	classNameSynchronizedList       = "java/util/Collections$SynchronizedRandomAccessList"
+	classNameSynchronizedSet        = "java/util/Collections$SynchronizedSet"
+	classNameSynchronizedMap        = "java/util/Collections$SynchronizedMap"
+)
+
+func Load_Util_Collections() {
+
+	ghelpers.MethodSignatures["java/util/Collections.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	// ordering
+
+	ghelpers.MethodSignatures["java/util/Collections.sort(Ljava/util/List;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsSort, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.sort(Ljava/util/List;Ljava/util/Comparator;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsSort, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.reverse(Ljava/util/List;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsReverse, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.shuffle(Ljava/util/List;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsShuffle, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.shuffle(Ljava/util/List;Ljava/util/Random;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsShuffle, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.reverseOrder()Ljava/util/Comparator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: collectionsReverseOrder}
+	ghelpers.MethodSignatures["java/util/Collections.reverseOrder(Ljava/util/Comparator;)Ljava/util/Comparator;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsReverseOrder}
+
+	// searching and counting
+
+	ghelpers.MethodSignatures["java/util/Collections.binarySearch(Ljava/util/List;Ljava/lang/Object;)I"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsBinarySearch, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.binarySearch(Ljava/util/List;Ljava/lang/Object;Ljava/util/Comparator;)I"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: collectionsBinarySearch, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.min(Ljava/util/Collection;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsMin, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.min(Ljava/util/Collection;Ljava/util/Comparator;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsMin, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.max(Ljava/util/Collection;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsMax, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.max(Ljava/util/Collection;Ljava/util/Comparator;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsMax, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.frequency(Ljava/util/Collection;Ljava/lang/Object;)I"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsFrequency, NeedsContext: true}
+
+	// immutable collections
+
+	ghelpers.MethodSignatures["java/util/Collections.nCopies(ILjava/lang/Object;)Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsNCopies}
+	ghelpers.MethodSignatures["java/util/Collections.emptyList()Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: collectionsEmptyList}
+	ghelpers.MethodSignatures["java/util/Collections.emptySet()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: collectionsEmptySet}
+	ghelpers.MethodSignatures["java/util/Collections.emptyMap()Ljava/util/Map;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: collectionsEmptyMap}
+	ghelpers.MethodSignatures["java/util/Collections.singletonList(Ljava/lang/Object;)Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsSingletonList}
+	ghelpers.MethodSignatures["java/util/Collections.singleton(Ljava/lang/Object;)Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: collectionsSingleton, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Collections.singletonMap(Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Map;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: collectionsSingletonMap, NeedsContext: true}
+
+	// wrapper views
+
+	ghelpers.MethodSignatures["java/util/Collections.unmodifiableCollection(Ljava/util/Collection;)Ljava/util/Collection;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameUnmodifiableCollection)}
+	ghelpers.MethodSignatures["java/util/Collections.unmodifiableList(Ljava/util/List;)Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameUnmodifiableList)}
+	ghelpers.MethodSignatures["java/util/Collections.unmodifiableSet(Ljava/util/Set;)Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameUnmodifiableSet)}
+	ghelpers.MethodSignatures["java/util/Collections.unmodifiableMap(Ljava/util/Map;)Ljava/util/Map;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameUnmodifiableMap)}
+	ghelpers.MethodSignatures["java/util/Collections.synchronizedCollection(Ljava/util/Collection;)Ljava/util/Collection;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameSynchronizedCollection)}
+	ghelpers.MethodSignatures["java/util/Collections.synchronizedList(Ljava/util/List;)Ljava/util/List;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameSynchronizedList)}
+	ghelpers.MethodSignatures["java/util/Collections.synchronizedSet(Ljava/util/Set;)Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameSynchronizedSet)}
+	ghelpers.MethodSignatures["java/util/Collections.synchronizedMap(Ljava/util/Map;)Ljava/util/Map;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: viewFactory(classNameSynchronizedMap)}
+
+	loadCollectionViews()
+}
+
+// --- immutability ---
+
+// markUnmodifiable flags a collection so that its mutators throw UnsupportedOperationException.
+func markUnmodifiable(obj *object.Object) *object.Object {
+	obj.FieldTable[fieldNameUnmodifiable] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	return obj
+}
+
+// isUnmodifiable reports whether obj is an immutable collection or an unmodifiable view.
+func isUnmodifiable(obj *object.Object) bool {
+	if obj == nil || object.IsNull(obj) {
+		return false
+	}
+	if fld, ok := obj.FieldTable[fieldNameUnmodifiable]; ok && fld.Fvalue == types.JavaBoolTrue {
+		return true
+	}
+	_, isView := obj.FieldTable[fieldNameBacking]
+	_, isSync := obj.FieldTable[fieldNameSynchronized]
+	return isView && !isSync
+}
+
+// checkModifiable returns an UnsupportedOperationException if obj may not be modified.
+func checkModifiable(obj *object.Object, caller string) *ghelpers.GErrBlk {
+	if isUnmodifiable(obj) {
+		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, caller+": collection is unmodifiable")
+	}
+	return nil
+}
+
+// makeImmutableList returns an unmodifiable ArrayList holding elements.
+func makeImmutableList(elements []any) *object.Object {
+	return markUnmodifiable(object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, elements))
+}
+
+// --- ordering ---
+
+// listReplaceElements stores elements, a reordering of the contents of listObj, back into the list.
+func listReplaceElements(fs *list.List, listObj *object.Object, elements []any) *ghelpers.GErrBlk {
+	if backing, ok := listObj.FieldTable[fieldNameBacking].Fvalue.(*object.Object); ok {
+		if isUnmodifiable(listObj) {
+			return checkModifiable(listObj, "listReplaceElements")
+		}
+		return listReplaceElements(fs, backing, elements)
+	}
+	if gerr := checkModifiable(listObj, "listReplaceElements"); gerr != nil {
+		return gerr
+	}
+
+	if fld, ok := listObj.FieldTable["value"]; ok {
+		switch value := fld.Fvalue.(type) {
+		case []interface{}: // ArrayList, Vector
+			copy(value, elements)
+			return nil
+		case *list.List: // LinkedList
+			ix := 0
+			for elem := value.Front(); elem != nil; elem = elem.Next() {
+				elem.Value = elements[ix]
+				ix++
+			}
+			return nil
+		}
+	}
+
+	// Any other List is updated through its own set() method.
+	for ix, elem := range elements {
+		ret := ghelpers.InvokeMethodOnObject(fs, listObj, "set", "(ILjava/lang/Object;)Ljava/lang/Object;", int64(ix), elem)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// listArg fetches the List argument of a Collections method, which must not be null.
+func listArg(args []interface{}, caller string) (*object.Object, *ghelpers.GErrBlk) {
+	if len(args) == 0 {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing collection argument")
+	}
+	obj, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": collection is null")
+	}
+	return obj, nil
+}
+
+// comparatorArg returns the Comparator argument at index ix, or nil for natural ordering.
+func comparatorArg(args []interface{}, ix int) *object.Object {
+	if ix < len(args) {
+		if cmp, ok := args[ix].(*object.Object); ok && !object.IsNull(cmp) {
+			return cmp
+		}
+	}
+	return nil
+}
+
+// java/util/Collections.sort(Ljava/util/List;)V and sort(Ljava/util/List;Ljava/util/Comparator;)V
+// The sort is stable, as the JDK's is.
+func collectionsSort(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	listObj, gerr := listArg(args, "Collections.sort")
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, listObj)
+	if gerr != nil {
+		return gerr
+	}
+	cmp := comparatorArg(args, 1)
+
+	var sortErr *ghelpers.GErrBlk
+	slices.SortStableFunc(elements, func(a, b any) int {
+		if sortErr != nil {
+			return 0
+		}
+		result, gerr := javaCompare(fs, cmp, a, b)
+		if gerr != nil {
+			sortErr = gerr
+		}
+		return result
+	})
+	if sortErr != nil {
+		return sortErr
+	}
+	if gerr = listReplaceElements(fs, listObj, elements); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/Collections.reverse(Ljava/util/List;)V
+func collectionsReverse(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	listObj, gerr := listArg(args, "Collections.reverse")
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, listObj)
+	if gerr != nil {
+		return gerr
+	}
+	slices.Reverse(elements)
+	if gerr = listReplaceElements(fs, listObj, elements); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/Collections.shuffle(Ljava/util/List;)V and shuffle(Ljava/util/List;Ljava/util/Random;)V
+// The elements are permuted with the JDK's algorithm, and a Random draws from the JDK's generator,
+// so a Random with a given seed produces the permutation that the JDK does.
+func collectionsShuffle(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	listObj, gerr := listArg(args, "Collections.shuffle")
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, listObj)
+	if gerr != nil {
+		return gerr
+	}
+
+	var nextInt func(bound int) (int, *ghelpers.GErrBlk)
+	if len(args) < 2 {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		nextInt = func(bound int) (int, *ghelpers.GErrBlk) { return int(r.Int31n(int32(bound))), nil }
+	} else {
+		rnd, ok := args[1].(*object.Object)
+		if !ok || object.IsNull(rnd) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Collections.shuffle: Random is null")
+		}
+		if r, ok := rnd.FieldTable["value"].Fvalue.(Random); ok {
+			nextInt = func(bound int) (int, *ghelpers.GErrBlk) { return int(r.lcg.nextInt(int32(bound))), nil }
+		} else { // a user subclass of Random
+			nextInt = func(bound int) (int, *ghelpers.GErrBlk) {
+				ret := ghelpers.InvokeMethodOnObject(fs, rnd, "nextInt", "(I)I", int64(bound))
+				switch r := ret.(type) {
+				case int64:
+					return int(r), nil
+				case *ghelpers.GErrBlk:
+					return 0, r
+				}
+				return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, "Collections.shuffle: nextInt did not return an int")
+			}
+		}
+	}
+
+	for ix := len(elements); ix > 1; ix-- {
+		jx, gerr := nextInt(ix)
+		if gerr != nil {
+			return gerr
+		}
+		elements[ix-1], elements[jx] = elements[jx], elements[ix-1]
+	}
+	if gerr = listReplaceElements(fs, listObj, elements); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/Collections.reverseOrder()Ljava/util/Comparator; and reverseOrder(Ljava/util/Comparator;)Ljava/util/Comparator;
+func collectionsReverseOrder(params []interface{}) interface{} {
+	cmp := comparatorArg(params, 0)
+	rev := makeReverseComparator(cmp)
+	if object.IsNull(rev) {
+		// reverseOrder(reverseOrder()) imposes the natural ordering; reversing the reverse comparator gives that.
+		rev = object.MakeEmptyObjectWithClassName(&classNameReverseComparator2)
+		rev.FieldTable["cmp"] = object.Field{Ftype: "Ljava/util/Comparator;", Fvalue: cmp}
+	}
+	return rev
+}
+
+// --- searching and counting ---
+
+// java/util/Collections.binarySearch(Ljava/util/List;Ljava/lang/Object;)I and the Comparator variant.
+// The list must be sorted; if the key is not present, -(insertion point) - 1 is returned.
+func collectionsBinarySearch(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	listObj, gerr := listArg(args, "Collections.binarySearch")
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, listObj)
+	if gerr != nil {
+		return gerr
+	}
+	key := args[1]
+	cmp := comparatorArg(args, 2)
+
+	low, high := 0, len(elements)-1
+	for low <= high {
+		mid := int(uint(low+high) >> 1)
+		result, gerr := javaCompare(fs, cmp, elements[mid], key)
+		if gerr != nil {
+			return gerr
+		}
+		switch {
+		case result < 0:
+			low = mid + 1
+		case result > 0:
+			high = mid - 1
+		default:
+			return int64(mid)
+		}
+	}
+	return int64(-(low + 1))
+}
+
+// collectionsExtreme returns the element of the collection that sorts first when compared with sign.
+func collectionsExtreme(params []interface{}, sign int, caller string) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	coll, gerr := listArg(args, caller)
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, coll)
+	if gerr != nil {
+		return gerr
+	}
+	if len(elements) == 0 {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, caller+": collection is empty")
+	}
+	cmp := comparatorArg(args, 1)
+
+	candidate := elements[0]
+	for _, elem := range elements[1:] {
+		result, gerr := javaCompare(fs, cmp, elem, candidate)
+		if gerr != nil {
+			return gerr
+		}
+		if result*sign < 0 {
+			candidate = elem
+		}
+	}
+	return candidate
+}
+
+// java/util/Collections.min(Ljava/util/Collection;)Ljava/lang/Object; and the Comparator variant
+func collectionsMin(params []interface{}) interface{} {
+	return collectionsExtreme(params, 1, "Collections.min")
+}
+
+// java/util/Collections.max(Ljava/util/Collection;)Ljava/lang/Object; and the Comparator variant
+func collectionsMax(params []interface{}) interface{} {
+	return collectionsExtreme(params, -1, "Collections.max")
+}
+
+// java/util/Collections.frequency(Ljava/util/Collection;Ljava/lang/Object;)I
+func collectionsFrequency(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	coll, gerr := listArg(args, "Collections.frequency")
+	if gerr != nil {
+		return gerr
+	}
+	elements, gerr := collectionElements(fs, coll)
+	if gerr != nil {
+		return gerr
+	}
+	count := int64(0)
+	for _, elem := range elements {
+		var equal bool
+		if isNullValue(args[1]) {
+			equal = isNullValue(elem)
+		} else if equal, gerr = javaEquals(fs, args[1], elem); gerr != nil {
+			return gerr
+		}
+		if equal {
+			count++
+		}
+	}
+	return count
+}
+
+// --- immutable collections ---
+
+// java/util/Collections.nCopies(ILjava/lang/Object;)Ljava/util/List;
+func collectionsNCopies(params []interface{}) interface{} {
+	n, ok := params[0].(int64)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Collections.nCopies: invalid count")
+	}
+	if n < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("List length = %d", n))
+	}
+	elements := make([]any, n)
+	for ix := range elements {
+		elements[ix] = params[1]
+	}
+	return makeImmutableList(elements)
+}
+
+// java/util/Collections.emptyList()Ljava/util/List;
+func collectionsEmptyList([]interface{}) interface{} {
+	return makeImmutableList([]any{})
+}
+
+// java/util/Collections.singletonList(Ljava/lang/Object;)Ljava/util/List;
+func collectionsSingletonList(params []interface{}) interface{} {
+	return makeImmutableList([]any{params[0]})
+}
+
+// java/util/Collections.emptySet()Ljava/util/Set;
+func collectionsEmptySet([]interface{}) interface{} {
+	set, gerr := makeHashSetInOrder(nil, nil)
+	if gerr != nil {
+		return gerr
+	}
+	return markUnmodifiable(set)
+}
+
+// java/util/Collections.singleton(Ljava/lang/Object;)Ljava/util/Set;
+func collectionsSingleton(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	set, gerr := makeHashSetInOrder(fs, []any{args[0]})
+	if gerr != nil {
+		return gerr
+	}
+	return markUnmodifiable(set)
+}
+
+// java/util/Collections.emptyMap()Ljava/util/Map;
+func collectionsEmptyMap([]interface{}) interface{} {
+	hm := object.MakeEmptyObjectWithClassName(&classNameHashMap)
+	if ret := hashmapInit([]interface{}{hm}); ret != nil {
+		return ret
+	}
+	return markUnmodifiable(hm)
+}
+
+// java/util/Collections.singletonMap(Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Map;
+func collectionsSingletonMap(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	hm := object.MakeEmptyObjectWithClassName(&classNameHashMap)
+	if ret := hashmapInit([]interface{}{hm}); ret != nil {
+		return ret
+	}
+	if ret, ok := hashmapPut([]interface{}{fs, hm, args[0], args[1]}).(*ghelpers.GErrBlk); ok {
+		return ret
+	}
+	return markUnmodifiable(hm)
+}
+
+// --- wrapper views ---
+
+// viewFactory returns the G function for Collections.unmodifiableX or synchronizedX, which
+// wraps its argument in a view of class viewClass.
+func viewFactory(viewClass string) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		backing, ok := params[0].(*object.Object)
+		if !ok || object.IsNull(backing) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Collections: cannot wrap a null collection")
+		}
+		// As in the JDK, wrapping an unmodifiable view of the same kind returns it unchanged.
+		if object.GoStringFromStringPoolIndex(backing.KlassName) == viewClass {
+			return backing
+		}
+		return makeView(viewClass, backing)
+	}
+}
+
+// makeView wraps backing in a view of class viewClass.
+func makeView(viewClass string, backing *object.Object) *object.Object {
+	view := object.MakeEmptyObjectWithClassName(&viewClass)
+	view.FieldTable[fieldNameBacking] = object.Field{Ftype: types.Ref, Fvalue: backing}
+	if slices.Contains(synchronizedViewClasses, viewClass) {
+		view.FieldTable[fieldNameSynchronized] = object.Field{Ftype: types.Bool, Fvalue: types.JavaBoolTrue}
+	}
+	return view
+}
+
+var unmodifiableViewClasses = []string{classNameUnmodifiableCollection, classNameUnmodifiableList,
+	classNameUnmodifiableSet, classNameUnmodifiableMap}
+var synchronizedViewClasses = []string{classNameSynchronizedCollection, classNameSynchronizedList,
+	classNameSynchronizedSet, classNameSynchronizedMap}
+
+// viewMethod describes a method of the view classes. Methods with a non-empty wrapIn return a
+// view of class wrapIn (or its synchronized counterpart) around the backing collection's result.
+type viewMethod struct {
+	name, methType string
+	paramSlots     int
+	mutator        bool
+	wrapIn         string
+}
+
+var collectionViewMethods = []viewMethod{
+	{"size", "()I", 0, false, ""},
+	{"isEmpty", "()Z", 0, false, ""},
+	{"contains", "(Ljava/lang/Object;)Z", 1, false, ""},
+	{"containsAll", "(Ljava/util/Collection;)Z", 1, false, ""},
+	{"toArray", "()[Ljava/lang/Object;", 0, false, ""},
+	{"toArray", "([Ljava/lang/Object;)[Ljava/lang/Object;", 1, false, ""},
+	{"toString", "()Ljava/lang/String;", 0, false, ""},
+	{"equals", "(Ljava/lang/Object;)Z", 1, false, ""},
+	{"hashCode", "()I", 0, false, ""},
+	{"forEach", "(Ljava/util/function/Consumer;)V", 1, false, ""},
+	{"add", "(Ljava/lang/Object;)Z", 1, true, ""},
+	{"addAll", "(Ljava/util/Collection;)Z", 1, true, ""},
+	{"remove", "(Ljava/lang/Object;)Z", 1, true, ""},
+	{"removeAll", "(Ljava/util/Collection;)Z", 1, true, ""},
+	{"retainAll", "(Ljava/util/Collection;)Z", 1, true, ""},
+	{"removeIf", "(Ljava/util/function/Predicate;)Z", 1, true, ""},
+	{"clear", "()V", 0, true, ""},
+}
+
+var listViewMethods = []viewMethod{
+	{"get", "(I)Ljava/lang/Object;", 1, false, ""},
+	{"indexOf", "(Ljava/lang/Object;)I", 1, false, ""},
+	{"lastIndexOf", "(Ljava/lang/Object;)I", 1, false, ""},
+	{"subList", "(II)Ljava/util/List;", 2, false, classNameUnmodifiableList},
+	{"set", "(ILjava/lang/Object;)Ljava/lang/Object;", 2, true, ""},
+	{"add", "(ILjava/lang/Object;)V", 2, true, ""},
+	{"remove", "(I)Ljava/lang/Object;", 1, true, ""},
+	{"addAll", "(ILjava/util/Collection;)Z", 2, true, ""},
+	{"sort", "(Ljava/util/Comparator;)V", 1, true, ""},
+	{"replaceAll", "(Ljava/util/function/UnaryOperator;)V", 1, true, ""},
+}
+
+var mapViewMethods = []viewMethod{
+	{"size", "()I", 0, false, ""},
+	{"isEmpty", "()Z", 0, false, ""},
+	{"containsKey", "(Ljava/lang/Object;)Z", 1, false, ""},
+	{"containsValue", "(Ljava/lang/Object;)Z", 1, false, ""},
+	{"get", "(Ljava/lang/Object;)Ljava/lang/Object;", 1, false, ""},
+	{"getOrDefault", "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;", 2, false, ""},
+	{"toString", "()Ljava/lang/String;", 0, false, ""},
+	{"equals", "(Ljava/lang/Object;)Z", 1, false, ""},
+	{"hashCode", "()I", 0, false, ""},
+	{"forEach", "(Ljava/util/function/BiConsumer;)V", 1, false, ""},
+	{"keySet", "()Ljava/util/Set;", 0, false, classNameUnmodifiableSet},
+	{"values", "()Ljava/util/Collection;", 0, false, classNameUnmodifiableCollection},
+	{"entrySet", "()Ljava/util/Set;", 0, false, classNameUnmodifiableSet},
+	{"put", "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;", 2, true, ""},
+	{"putAll", "(Ljava/util/Map;)V", 1, true, ""},
+	{"remove", "(Ljava/lang/Object;)Ljava/lang/Object;", 1, true, ""},
+	{"remove", "(Ljava/lang/Object;Ljava/lang/Object;)Z", 2, true, ""},
+	{"clear", "()V", 0, true, ""},
+	{"putIfAbsent", "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;", 2, true, ""},
+	{"replace", "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;", 2, true, ""},
+	{"replace", "(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Z", 3, true, ""},
+	{"replaceAll", "(Ljava/util/function/BiFunction;)V", 1, true, ""},
+	{"computeIfAbsent", "(Ljava/lang/Object;Ljava/util/function/Function;)Ljava/lang/Object;", 2, true, ""},
+	{"computeIfPresent", "(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;", 2, true, ""},
+	{"compute", "(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;", 2, true, ""},
+	{"merge", "(Ljava/lang/Object;Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;", 3, true, ""},
+}
+
+// loadCollectionViews registers the methods of the view classes. The JDK's own bytecode for
+// these classes would otherwise shadow any registration on a superclass, so every method is
+// registered on each concrete view class.
+func loadCollectionViews() {
+	register := func(className string, methods []viewMethod) {
+		for _, m := range methods {
+			ghelpers.MethodSignatures[className+"."+m.name+m.methType] =
+				ghelpers.GMeth{ParamSlots: m.paramSlots, GFunction: viewDelegate(m), NeedsContext: true}
+		}
+		ghelpers.MethodSignatures[className+".<clinit>()V"] = ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	}
+
+	for ix, className := range unmodifiableViewClasses {
+		syncClassName := synchronizedViewClasses[ix]
+		switch className {
+		case classNameUnmodifiableMap:
+			register(className, mapViewMethods)
+			register(syncClassName, mapViewMethods)
+			continue
+		case classNameUnmodifiableList:
+			register(className, listViewMethods)
+			register(syncClassName, listViewMethods)
+			for _, meth := range []string{"listIterator()Ljava/util/ListIterator;", "listIterator(I)Ljava/util/ListIterator;"} {
+				slots := 0
+				if meth == "listIterator(I)Ljava/util/ListIterator;" {
+					slots = 1
+				}
+				ghelpers.MethodSignatures[className+"."+meth] =
+					ghelpers.GMeth{ParamSlots: slots, GFunction: viewListIterator, NeedsContext: true}
+				ghelpers.MethodSignatures[syncClassName+"."+meth] =
+					ghelpers.GMeth{ParamSlots: slots, GFunction: viewListIterator, NeedsContext: true}
+			}
+		}
+		register(className, collectionViewMethods)
+		register(syncClassName, collectionViewMethods)
+		ghelpers.MethodSignatures[className+".iterator()Ljava/util/Iterator;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: viewIterator, NeedsContext: true}
+		ghelpers.MethodSignatures[syncClassName+".iterator()Ljava/util/Iterator;"] =
+			ghelpers.GMeth{ParamSlots: 0, GFunction: viewIterator, NeedsContext: true}
+	}
+}
+
+// viewThis returns the view object and its backing collection.
+func viewThis(args []interface{}, caller string) (*object.Object, *object.Object, *ghelpers.GErrBlk) {
+	view, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(view) {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": invalid 'this' argument")
+	}
+	backing, ok := view.FieldTable[fieldNameBacking].Fvalue.(*object.Object)
+	if !ok {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": view has no backing collection")
+	}
+	return view, backing, nil
+}
+
+// lockView takes the monitor of a synchronized view, as the JDK's wrappers synchronize on the
+// wrapper itself. It returns the function that releases it.
+func lockView(fs *list.List, view *object.Object) func() {
+	if _, ok := view.FieldTable[fieldNameSynchronized]; !ok || fs == nil || fs.Len() == 0 {
+		return func() {}
+	}
+	threadID := int32(fs.Front().Value.(*frames.Frame).Thread)
+	if view.ObjLock(threadID) != nil {
+		return func() {}
+	}
+	return func() { _ = view.ObjUnlock(threadID) }
+}
+
+// viewDelegate returns the G function for one method of the view classes.
+func viewDelegate(m viewMethod) func([]interface{}) interface{} {
+	caller := "Collections view." + m.name
+	return func(params []interface{}) interface{} {
+		fs, args := ghelpers.SplitContext(params)
+		view, backing, gerr := viewThis(args, caller)
+		if gerr != nil {
+			return gerr
+		}
+		if m.mutator {
+			if gerr = checkModifiable(view, caller); gerr != nil {
+				return gerr
+			}
+		}
+		unlock := lockView(fs, view)
+		defer unlock()
+
+		ret := ghelpers.InvokeMethodOnObject(fs, backing, m.name, m.methType, args[1:]...)
+		if result, ok := ret.(*object.Object); ok && m.wrapIn != "" && !object.IsNull(result) {
+			wrapClass := m.wrapIn
+			if _, ok := view.FieldTable[fieldNameSynchronized]; ok {
+				wrapClass = synchronizedViewClasses[slices.Index(unmodifiableViewClasses, m.wrapIn)]
+			}
+			return makeView(wrapClass, result)
+		}
+		return ret
+	}
+}
+
+// viewIterator is iterator() for the view classes. It iterates over a snapshot of the backing
+// collection; its remove() goes through orderedRemove and is refused for unmodifiable views.
+func viewIterator(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	view, backing, gerr := viewThis(args, "Collections view.iterator")
+	if gerr != nil {
+		return gerr
+	}
+	unlock := lockView(fs, view)
+	defer unlock()
+	elements, gerr := collectionElements(fs, backing)
+	if gerr != nil {
+		return gerr
+	}
+	return newSnapshotIterator(view, elements)
+}
+
+// viewListIterator is listIterator() and listIterator(int) for the list views.
+func viewListIterator(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	view, backing, gerr := viewThis(args, "Collections view.listIterator")
+	if gerr != nil {
+		return gerr
+	}
+	index := int64(0)
+	if len(args) > 1 {
+		index, _ = args[1].(int64)
+	}
+	if isUnmodifiable(view) {
+		elements, gerr := collectionElements(fs, backing)
+		if gerr != nil {
+			return gerr
+		}
+		return NewListIterator(makeImmutableList(elements), int(index))
+	}
+	return NewListIterator(backing, int(index))
+}
+
+// viewRemove removes elem from the backing collection of a view; see orderedRemove.
+func viewRemove(fs *list.List, view, backing *object.Object, elem any) *ghelpers.GErrBlk {
+	if gerr := checkModifiable(view, "Iterator.remove"); gerr != nil {
+		return gerr
+	}
+	if _, ok, _ := orderedElements(fs, backing); ok {
+		return orderedRemove(fs, backing, elem)
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, backing, "remove", "(Ljava/lang/Object;)Z", elem)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return gerr
+	}
+	return nil
+}
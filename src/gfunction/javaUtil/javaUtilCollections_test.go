@@ -0,0 +1,202 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"slices"
+	"testing"
+)
+
+func newIntArrayList(values ...int64) *object.Object {
+	elements := make([]interface{}, len(values))
+	for ix, v := range values {
+		elements[ix] = intKey(v)
+	}
+	return object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, elements)
+}
+
+func listInts(t *testing.T, listObj *object.Object) []int64 {
+	t.Helper()
+	elements, gerr := collectionElements(nil, listObj)
+	if gerr != nil {
+		t.Fatalf("collectionElements: %s", gerr.ErrMsg)
+	}
+	var ret []int64
+	for _, elem := range elements {
+		ret = append(ret, keyInt(t, elem))
+	}
+	return ret
+}
+
+func TestCollections_SortAndReverse(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	Load_Util_TreeMap() // registers the reverse comparators
+
+	lst := newIntArrayList(3, 1, 2)
+	if ret := collectionsSort([]interface{}{lst}); ret != nil {
+		t.Fatalf("sort returned %v", ret)
+	}
+	if got := listInts(t, lst); !slices.Equal(got, []int64{1, 2, 3}) {
+		t.Errorf("sort: got %v", got)
+	}
+
+	if ret := collectionsSort([]interface{}{lst, makeReverseComparator(nil)}); ret != nil {
+		t.Fatalf("sort with comparator returned %v", ret)
+	}
+	if got := listInts(t, lst); !slices.Equal(got, []int64{3, 2, 1}) {
+		t.Errorf("sort with reverseOrder(): got %v", got)
+	}
+
+	collectionsReverse([]interface{}{lst})
+	if got := listInts(t, lst); !slices.Equal(got, []int64{1, 2, 3}) {
+		t.Errorf("reverse: got %v", got)
+	}
+}
+
+// A seeded Random gives the permutation that the JDK gives for the same seed.
+func TestCollections_ShuffleWithSeedMatchesJDK(t *testing.T) {
+	globals.InitStringPool()
+
+	rnd := object.MakeEmptyObjectWithClassName(new("java/util/Random"))
+	randomInitLong([]interface{}{rnd, int64(42)})
+	lst := newIntArrayList(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if ret := collectionsShuffle([]interface{}{lst, rnd}); ret != nil {
+		t.Fatalf("shuffle returned %v", ret)
+	}
+	// Collections.shuffle(List.of(1, ..., 10) in an ArrayList, new Random(42)) in the JDK
+	if got := listInts(t, lst); !slices.Equal(got, []int64{5, 7, 3, 2, 8, 10, 9, 6, 4, 1}) {
+		t.Errorf("shuffle with seed 42: got %v", got)
+	}
+}
+
+func TestCollections_SearchingAndCounting(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	Load_Util_TreeMap()
+	lst := newIntArrayList(10, 20, 20, 30)
+
+	if got := collectionsBinarySearch([]interface{}{lst, intKey(30)}).(int64); got != 3 {
+		t.Errorf("binarySearch(30): expected 3, got %d", got)
+	}
+	if got := collectionsBinarySearch([]interface{}{lst, intKey(25)}).(int64); got != -4 {
+		t.Errorf("binarySearch(25): expected -4, got %d", got)
+	}
+	if got := keyInt(t, collectionsMin([]interface{}{lst})); got != 10 {
+		t.Errorf("min: expected 10, got %d", got)
+	}
+	if got := keyInt(t, collectionsMax([]interface{}{lst})); got != 30 {
+		t.Errorf("max: expected 30, got %d", got)
+	}
+	if got := keyInt(t, collectionsMax([]interface{}{lst, makeReverseComparator(nil)})); got != 10 {
+		t.Errorf("max with reverseOrder(): expected 10, got %d", got)
+	}
+	if got := collectionsFrequency([]interface{}{lst, intKey(20)}).(int64); got != 2 {
+		t.Errorf("frequency(20): expected 2, got %d", got)
+	}
+
+	ret := collectionsMin([]interface{}{newIntArrayList()})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NoSuchElementException {
+		t.Errorf("min of an empty list: expected NoSuchElementException, got %v", ret)
+	}
+}
+
+func TestCollections_ImmutableFactories(t *testing.T) {
+	globals.InitStringPool()
+
+	copies := collectionsNCopies([]interface{}{int64(3), intKey(7)}).(*object.Object)
+	if got := listInts(t, copies); !slices.Equal(got, []int64{7, 7, 7}) {
+		t.Errorf("nCopies: got %v", got)
+	}
+	testutil.ExpectGErr(t, arraylistAdd([]interface{}{copies, intKey(1)}), excNames.UnsupportedOperationException, "")
+
+	ret := collectionsNCopies([]interface{}{int64(-1), intKey(7)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("nCopies(-1): expected IllegalArgumentException, got %v", ret)
+	}
+
+	testutil.ExpectGErr(t, hashmapPut([]interface{}{collectionsEmptyMap(nil), intKey(1), intKey(2)}), excNames.UnsupportedOperationException, "")
+	testutil.ExpectGErr(t, hashsetAdd([]interface{}{collectionsEmptySet(nil), intKey(1)}), excNames.UnsupportedOperationException, "")
+	testutil.ExpectGErr(t, arraylistClear([]interface{}{listOf([]interface{}{intKey(1)})}), excNames.UnsupportedOperationException, "")
+	testutil.ExpectGErr(t, hashsetRemove([]interface{}{setOf([]interface{}{intKey(1)}), intKey(1)}), excNames.UnsupportedOperationException, "")
+
+	single := collectionsSingletonMap([]interface{}{strKey("k"), strKey("v")}).(*object.Object)
+	if got := hashmapGet([]interface{}{single, strKey("k")}).(*object.Object); object.GoStringFromStringObject(got) != "v" {
+		t.Errorf("singletonMap.get: got %q", object.GoStringFromStringObject(got))
+	}
+}
+
+func TestCollections_MapOfRejectsDuplicatesAndNulls(t *testing.T) {
+	globals.InitStringPool()
+
+	m := mapOf([]interface{}{strKey("a"), intKey(1), strKey("b"), intKey(2)}).(*object.Object)
+	if size := hashmapSize([]interface{}{m}).(int64); size != 2 {
+		t.Errorf("Map.of size: expected 2, got %d", size)
+	}
+	testutil.ExpectGErr(t, hashmapRemove([]interface{}{m, strKey("a")}), excNames.UnsupportedOperationException, "")
+
+	ret := mapOf([]interface{}{strKey("a"), intKey(1), strKey("a"), intKey(2)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("Map.of with a duplicate key: expected IllegalArgumentException, got %v", ret)
+	}
+	ret = mapOf([]interface{}{strKey("a"), object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NullPointerException {
+		t.Errorf("Map.of with a null value: expected NullPointerException, got %v", ret)
+	}
+}
+
+func TestCollections_UnmodifiableViewReflectsBacking(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_Collections()
+	Load_Util_ArrayList()
+
+	backing := newIntArrayList(1, 2)
+	view := viewFactory(classNameUnmodifiableList)([]interface{}{backing}).(*object.Object)
+
+	size := ghelpers.Invoke(classNameUnmodifiableList+".size()I", []interface{}{view})
+	if size != int64(2) {
+		t.Errorf("view size: expected 2, got %v", size)
+	}
+	arraylistAdd([]interface{}{backing, intKey(3)})
+	if got := listInts(t, view); !slices.Equal(got, []int64{1, 2, 3}) {
+		t.Errorf("view should show the backing list's new element, got %v", got)
+	}
+
+	testutil.ExpectGErr(t, ghelpers.Invoke(classNameUnmodifiableList+".add(Ljava/lang/Object;)Z", []interface{}{view, intKey(4)}), excNames.UnsupportedOperationException, "")
+	testutil.ExpectGErr(t, collectionsSort([]interface{}{view}), excNames.UnsupportedOperationException, "")
+
+	iter := viewIterator([]interface{}{view}).(*object.Object)
+	iteratorNext([]interface{}{iter})
+	testutil.ExpectGErr(t, iteratorRemove([]interface{}{iter}), excNames.UnsupportedOperationException, "")
+}
+
+func TestCollections_SynchronizedViewWritesThrough(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_Collections()
+	Load_Util_ArrayList()
+
+	backing := newIntArrayList(2, 1)
+	view := viewFactory(classNameSynchronizedList)([]interface{}{backing}).(*object.Object)
+
+	ret := ghelpers.Invoke(classNameSynchronizedList+".add(Ljava/lang/Object;)Z", []interface{}{view, intKey(0)})
+	if ret != types.JavaBoolTrue {
+		t.Fatalf("synchronized add returned %v", ret)
+	}
+	if ret := collectionsSort([]interface{}{view}); ret != nil {
+		t.Fatalf("sort of a synchronized view returned %v", ret)
+	}
+	if got := listInts(t, backing); !slices.Equal(got, []int64{0, 1, 2}) {
+		t.Errorf("backing list after add and sort: got %v", got)
+	}
+}
@@ -0,0 +1,609 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Helpers shared by the ordered and sorted collections (TreeMap, TreeSet, LinkedHashMap,
+// PriorityQueue, ArrayDeque, ...). Strings and the boxed primitive types are handled natively;
+// any other object is handled by running its Java method (compareTo, equals, toString)
+// via ghelpers.InvokeMethodOnObject.
+
+const (
+	methTypeCompare   = "(Ljava/lang/Object;Ljava/lang/Object;)I"
+	methTypeCompareTo = "(Ljava/lang/Object;)I"
+	methTypeEquals    = "(Ljava/lang/Object;)Z"
+	methTypeToString  = "()Ljava/lang/String;"
+	methTypeAccept    = "(Ljava/lang/Object;)V"
+	methTypeTest      = "(Ljava/lang/Object;)Z"
+)
+
+// boxedIntegralClasses are the wrapper classes whose "value" field holds an int64.
+var boxedIntegralClasses = map[string]bool{
+	"java/lang/Integer":   true,
+	"java/lang/Long":      true,
+	"java/lang/Short":     true,
+	"java/lang/Byte":      true,
+	"java/lang/Character": true,
+	"java/lang/Boolean":   true,
+}
+
+// boxedFloatingClasses are the wrapper classes whose "value" field holds a float64.
+var boxedFloatingClasses = map[string]bool{
+	"java/lang/Double": true,
+	"java/lang/Float":  true,
+}
+
+// isNullValue reports whether a collection element is a Java null.
+func isNullValue(v any) bool {
+	return v == nil || object.IsNull(v)
+}
+
+// boxedValue returns the primitive value held in a boxed-primitive object, along with its class name.
+func boxedValue(obj *object.Object) (any, string, bool) {
+	className := object.GoStringFromStringPoolIndex(obj.KlassName)
+	if !boxedIntegralClasses[className] && !boxedFloatingClasses[className] {
+		return nil, className, false
+	}
+	fld, ok := obj.FieldTable["value"]
+	if !ok {
+		return nil, className, false
+	}
+	switch fld.Fvalue.(type) {
+	case int64, float64:
+		return fld.Fvalue, className, true
+	}
+	return nil, className, false
+}
+
+// compareJavaStrings compares two strings the way String.compareTo does: lexicographically by UTF-16 code unit.
+func compareJavaStrings(a, b string) int {
+	ascii := true
+	for ix := 0; ix < len(a) && ascii; ix++ {
+		ascii = a[ix] < 0x80
+	}
+	for ix := 0; ix < len(b) && ascii; ix++ {
+		ascii = b[ix] < 0x80
+	}
+	if ascii {
+		return strings.Compare(a, b)
+	}
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for ix := 0; ix < len(ua) && ix < len(ub); ix++ {
+		if ua[ix] != ub[ix] {
+			return int(ua[ix]) - int(ub[ix])
+		}
+	}
+	return len(ua) - len(ub)
+}
+
+// compareFloats follows Double.compare: -0.0 is less than 0.0 and NaN is greater than everything.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	aBits := int64(math.Float64bits(a))
+	bBits := int64(math.Float64bits(b))
+	if math.IsNaN(a) {
+		aBits = 0x7ff8000000000000
+	}
+	if math.IsNaN(b) {
+		bBits = 0x7ff8000000000000
+	}
+	switch {
+	case aBits < bBits:
+		return -1
+	case aBits > bBits:
+		return 1
+	}
+	return 0
+}
+
+// javaCompare compares a and b. If comparator is a non-null Comparator object, its compare()
+// method decides; otherwise the natural ordering (Comparable) of a is used.
+func javaCompare(fs *list.List, comparator *object.Object, a, b any) (int, *ghelpers.GErrBlk) {
+	if comparator != nil && !object.IsNull(comparator) {
+		ret := ghelpers.InvokeMethodOnObject(fs, comparator, "compare", methTypeCompare, a, b)
+		switch r := ret.(type) {
+		case int64:
+			return int(r), nil
+		case *ghelpers.GErrBlk:
+			return 0, r
+		}
+		errMsg := fmt.Sprintf("javaCompare: Comparator.compare returned %T, expected int", ret)
+		return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	}
+	return naturalCompare(fs, a, b)
+}
+
+// naturalCompare compares two objects using their natural ordering (Comparable.compareTo).
+func naturalCompare(fs *list.List, a, b any) (int, *ghelpers.GErrBlk) {
+	if isNullValue(a) || isNullValue(b) {
+		return 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "naturalCompare: null element in natural ordering")
+	}
+
+	// Raw primitives can turn up when a G function builds a collection directly.
+	switch av := a.(type) {
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return compareInt64(av, bv), nil
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return compareFloats(av, bv), nil
+		}
+	}
+
+	aObj, okA := a.(*object.Object)
+	bObj, okB := b.(*object.Object)
+	if !okA || !okB {
+		errMsg := fmt.Sprintf("naturalCompare: cannot compare %T with %T", a, b)
+		return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	}
+
+	if object.IsStringObject(aObj) && object.IsStringObject(bObj) {
+		return compareJavaStrings(object.GoStringFromStringObject(aObj), object.GoStringFromStringObject(bObj)), nil
+	}
+
+	if av, aClass, ok := boxedValue(aObj); ok {
+		bv, bClass, ok := boxedValue(bObj)
+		if !ok || aClass != bClass {
+			errMsg := fmt.Sprintf("class %s cannot be cast to class %s",
+				strings.ReplaceAll(bClass, "/", "."), strings.ReplaceAll(aClass, "/", "."))
+			return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+		}
+		if ai, ok := av.(int64); ok {
+			return compareInt64(ai, bv.(int64)), nil
+		}
+		return compareFloats(av.(float64), bv.(float64)), nil
+	}
+
+	ret := ghelpers.InvokeMethodOnObject(fs, aObj, "compareTo", methTypeCompareTo, bObj)
+	switch r := ret.(type) {
+	case int64:
+		return int(r), nil
+	case *ghelpers.GErrBlk:
+		if r.ExceptionType == excNames.NoSuchMethodError {
+			className := strings.ReplaceAll(object.GoStringFromStringPoolIndex(aObj.KlassName), "/", ".")
+			errMsg := fmt.Sprintf("class %s cannot be cast to class java.lang.Comparable", className)
+			return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+		}
+		return 0, r
+	}
+	errMsg := fmt.Sprintf("naturalCompare: compareTo returned %T, expected int", ret)
+	return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// javaEquals reports whether a.equals(b), using native comparisons for strings and boxed primitives.
+func javaEquals(fs *list.List, a, b any) (bool, *ghelpers.GErrBlk) {
+	if isNullValue(a) || isNullValue(b) {
+		return isNullValue(a) && isNullValue(b), nil
+	}
+	if a == b {
+		return true, nil
+	}
+
+	aObj, okA := a.(*object.Object)
+	bObj, okB := b.(*object.Object)
+	if !okA || !okB {
+		return a == b, nil
+	}
+
+	if object.IsStringObject(aObj) {
+		return object.IsStringObject(bObj) && object.EqualStringObjects(aObj, bObj), nil
+	}
+	if av, aClass, ok := boxedValue(aObj); ok {
+		bv, bClass, ok := boxedValue(bObj)
+		if !ok || aClass != bClass {
+			return false, nil
+		}
+		if af, ok := av.(float64); ok { // Double.equals compares bit patterns
+			return math.Float64bits(af) == math.Float64bits(bv.(float64)), nil
+		}
+		return av == bv, nil
+	}
+
+	ret := ghelpers.InvokeMethodOnObject(fs, aObj, "equals", methTypeEquals, bObj)
+	switch r := ret.(type) {
+	case int64:
+		return r == types.JavaBoolTrue, nil
+	case *ghelpers.GErrBlk:
+		if r.ExceptionType == excNames.NoSuchMethodError {
+			return false, nil // Object.equals: identity, already checked above
+		}
+		return false, r
+	}
+	return false, nil
+}
+
+// javaDoubleToString formats a double (or, if isFloat, a float) the way Double.toString does.
+func javaDoubleToString(f float64, isFloat bool) string {
+	bitSize := 64
+	if isFloat {
+		bitSize = 32
+	}
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f == 0:
+		if math.Signbit(f) {
+			return "-0.0"
+		}
+		return "0.0"
+	}
+	abs := math.Abs(f)
+	if abs >= 1e-3 && abs < 1e7 {
+		str := strconv.FormatFloat(f, 'f', -1, bitSize)
+		if !strings.Contains(str, ".") {
+			str += ".0"
+		}
+		return str
+	}
+	str := strconv.FormatFloat(f, 'E', -1, bitSize)
+	mantissa, exponent, _ := strings.Cut(str, "E")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(exp)
+}
+
+// javaToString returns the Java toString() form of a collection element.
+func javaToString(fs *list.List, v any) string {
+	if isNullValue(v) {
+		return types.NullString
+	}
+	switch val := v.(type) {
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return javaDoubleToString(val, false)
+	case *object.Object:
+		if object.IsStringObject(val) {
+			return object.GoStringFromStringObject(val)
+		}
+		if bv, className, ok := boxedValue(val); ok {
+			switch className {
+			case "java/lang/Boolean":
+				if bv.(int64) == types.JavaBoolTrue {
+					return "true"
+				}
+				return "false"
+			case "java/lang/Character":
+				return string(rune(bv.(int64)))
+			case "java/lang/Double":
+				return javaDoubleToString(bv.(float64), false)
+			case "java/lang/Float":
+				return javaDoubleToString(bv.(float64), true)
+			default:
+				return strconv.FormatInt(bv.(int64), 10)
+			}
+		}
+		ret := ghelpers.InvokeMethodOnObject(fs, val, "toString", methTypeToString)
+		if strObj, ok := ret.(*object.Object); ok && object.IsStringObject(strObj) {
+			return object.GoStringFromStringObject(strObj)
+		}
+		className := strings.ReplaceAll(object.GoStringFromStringPoolIndex(val.KlassName), "/", ".")
+		return fmt.Sprintf("%s@%x", className, val.Mark.Hash)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// javaToStringObject builds a Java String object holding the Java toString() form of v.
+func javaToStringObject(fs *list.List, v any) *object.Object {
+	return object.StringObjectFromGoString(javaToString(fs, v))
+}
+
+// formatElements renders elements in the AbstractCollection.toString form: [a, b, c].
+// self is the owning collection, which is shown as "(this Collection)" if it contains itself.
+func formatElements(fs *list.List, self *object.Object, elements []any) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for ix, elem := range elements {
+		if ix > 0 {
+			sb.WriteString(", ")
+		}
+		if elem == self {
+			sb.WriteString("(this Collection)")
+			continue
+		}
+		sb.WriteString(javaToString(fs, elem))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Collections.reverseOrder() and Collections.reverseOrder(Comparator) return objects of these classes.
+var classNameReverseComparator = "java/util/Collections$ReverseComparator"
+var classNameReverseComparator2 = "java/util/Collections$ReverseComparator2"
+
+// makeReverseComparator returns a Comparator that imposes the reverse of cmp, or the reverse of
+// the natural ordering if cmp is nil.
+func makeReverseComparator(cmp *object.Object) *object.Object {
+	if cmp == nil || object.IsNull(cmp) {
+		return object.MakeEmptyObjectWithClassName(&classNameReverseComparator)
+	}
+	if object.GoStringFromStringPoolIndex(cmp.KlassName) == classNameReverseComparator {
+		return object.Null // the reverse of reverseOrder() is natural ordering
+	}
+	if inner, ok := cmp.FieldTable["cmp"].Fvalue.(*object.Object); ok &&
+		object.GoStringFromStringPoolIndex(cmp.KlassName) == classNameReverseComparator2 {
+		return inner
+	}
+	rev := object.MakeEmptyObjectWithClassName(&classNameReverseComparator2)
+	rev.FieldTable["cmp"] = object.Field{Ftype: "Ljava/util/Comparator;", Fvalue: cmp}
+	return rev
+}
+
+// reverseComparatorCompare is compare(a, b) for the reverse comparators.
+func reverseComparatorCompare(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "reverseComparatorCompare: invalid 'this' argument")
+	}
+	inner, _ := this.FieldTable["cmp"].Fvalue.(*object.Object)
+	cmp, gerr := javaCompare(fs, inner, args[2], args[1])
+	if gerr != nil {
+		return gerr
+	}
+	return int64(cmp)
+}
+
+// makeMapEntry builds an immutable Map.Entry snapshot holding key and value.
+func makeMapEntry(key, value any) *object.Object {
+	entryObj := object.MakeEmptyObjectWithClassName(new("java/util/AbstractMap$SimpleImmutableEntry"))
+	entryObj.FieldTable["key"] = object.Field{Ftype: "Ljava/lang/Object;", Fvalue: key}
+	entryObj.FieldTable["value"] = object.Field{Ftype: "Ljava/lang/Object;", Fvalue: value}
+	return entryObj
+}
+
+// CollectionElements returns a snapshot of the elements of a Collection, for G functions
+// in other packages that take a Collection argument.
+func CollectionElements(fs *list.List, coll *object.Object) ([]any, *ghelpers.GErrBlk) {
+	return collectionElements(fs, coll)
+}
+
+// collectionElements returns a snapshot of the elements of any collection that Jacobin knows
+// about natively. For other Collection objects, the elements are fetched by running the
+// object's iterator() through the JVM.
+func collectionElements(fs *list.List, coll *object.Object) ([]any, *ghelpers.GErrBlk) {
+	if coll == nil || object.IsNull(coll) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "collectionElements: collection is null")
+	}
+
+	if elements, ok, gerr := orderedElements(fs, coll); ok || gerr != nil {
+		return elements, gerr
+	}
+
+	if fld, ok := coll.FieldTable["value"]; ok {
+		switch value := fld.Fvalue.(type) {
+		case []interface{}: // ArrayList, Vector
+			snapshot := make([]any, len(value))
+			copy(snapshot, value)
+			return snapshot, nil
+		case *list.List: // LinkedList
+			snapshot := make([]any, 0, value.Len())
+			for elem := value.Front(); elem != nil; elem = elem.Next() {
+				snapshot = append(snapshot, elem.Value)
+			}
+			return snapshot, nil
+		case []*object.Object: // an array of references
+			snapshot := make([]any, len(value))
+			for ix, elem := range value {
+				snapshot[ix] = elem
+			}
+			return snapshot, nil
+		}
+	}
+
+	// Fall back to the collection's own Java iterator.
+	iter := ghelpers.InvokeMethodOnObject(fs, coll, "iterator", "()Ljava/util/Iterator;")
+	iterObj, ok := iter.(*object.Object)
+	if !ok {
+		if gerr, ok := iter.(*ghelpers.GErrBlk); ok {
+			return nil, gerr
+		}
+		return nil, ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "collectionElements: no iterator available")
+	}
+	var snapshot []any
+	for {
+		hasNext := ghelpers.InvokeMethodOnObject(fs, iterObj, "hasNext", "()Z")
+		if gerr, ok := hasNext.(*ghelpers.GErrBlk); ok {
+			return nil, gerr
+		}
+		if hasNext != types.JavaBoolTrue {
+			return snapshot, nil
+		}
+		next := ghelpers.InvokeMethodOnObject(fs, iterObj, "next", "()Ljava/lang/Object;")
+		if gerr, ok := next.(*ghelpers.GErrBlk); ok {
+			return nil, gerr
+		}
+		snapshot = append(snapshot, next)
+	}
+}
+
+// orderedElements returns the elements of a TreeSet, LinkedHashSet, HashSet, PriorityQueue, ArrayDeque or
+// Collections view (or the keys of a TreeMap, LinkedHashMap or HashMap) in iteration order. ok is false if coll is none of these.
+func orderedElements(fs *list.List, coll *object.Object) ([]any, bool, *ghelpers.GErrBlk) {
+	if backing, ok := coll.FieldTable[fieldNameBacking].Fvalue.(*object.Object); ok { // a Collections view
+		elements, gerr := collectionElements(fs, backing)
+		return elements, true, gerr
+	}
+	if state, ok := coll.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		elements, gerr := treeKeys(fs, state)
+		return elements, true, gerr
+	}
+	if state, ok := coll.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		return state.keys(), true, nil
+	}
+	if hm, ok := coll.FieldTable[fieldNameMap].Fvalue.(types.DefHashMap); ok {
+		_, hs, _ := getHashMap(coll, "orderedElements")
+		entries := hashEntries(coll, hm, hs)
+		elements := make([]any, len(entries))
+		for ix, entry := range entries {
+			elements[ix] = entry.key
+		}
+		return elements, true, nil
+	}
+	if state, ok := coll.FieldTable[fieldNamePriorityQueue].Fvalue.(*priorityQueue); ok {
+		return slices.Clone(state.heap), true, nil
+	}
+	if state, ok := coll.FieldTable[fieldNameDeque].Fvalue.(*arrayDeque); ok {
+		return slices.Clone(state.elements), true, nil
+	}
+	return nil, false, nil
+}
+
+// orderedRemove removes elem, an element previously returned by an iterator over coll, from one of
+// the collections handled by orderedElements. The element is found by identity, except in a sorted
+// set, where it is found by its key.
+func orderedRemove(fs *list.List, coll *object.Object, elem any) *ghelpers.GErrBlk {
+	if backing, ok := coll.FieldTable[fieldNameBacking].Fvalue.(*object.Object); ok {
+		return viewRemove(fs, coll, backing, elem)
+	}
+	if state, ok := coll.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		_, gerr := state.remove(fs, elem)
+		return gerr
+	}
+	if state, ok := coll.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		state.remove(elem)
+		return nil
+	}
+	if hm, ok := coll.FieldTable[fieldNameMap].Fvalue.(types.DefHashMap); ok {
+		if gerr := checkModifiable(coll, "Iterator.remove"); gerr != nil {
+			return gerr
+		}
+		_, hs, _ := getHashMap(coll, "orderedRemove")
+		_, _, gerr := hashRemove(fs, hm, hs, elem)
+		return gerr
+	}
+	if state, ok := coll.FieldTable[fieldNamePriorityQueue].Fvalue.(*priorityQueue); ok {
+		for ix, e := range state.heap {
+			if e == elem {
+				_, gerr := state.removeAt(fs, ix)
+				return gerr
+			}
+		}
+		return nil
+	}
+	if state, ok := coll.FieldTable[fieldNameDeque].Fvalue.(*arrayDeque); ok {
+		if ix := slices.Index(state.elements, elem); ix >= 0 {
+			state.elements = slices.Delete(state.elements, ix, ix+1)
+		}
+		return nil
+	}
+	className := object.GoStringFromStringPoolIndex(coll.KlassName)
+	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+		fmt.Sprintf("orderedRemove: unsupported collection type %s", className))
+}
+
+// forEachElement runs action.accept() on each element, as Iterable.forEach() does.
+func forEachElement(fs *list.List, action *object.Object, elements []any, caller string) *ghelpers.GErrBlk {
+	if action == nil || object.IsNull(action) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": action is null")
+	}
+	for _, elem := range elements {
+		ret := ghelpers.InvokeMethodOnObject(fs, action, "accept", methTypeAccept, elem)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// filterElements runs filter.test() on every element and returns the elements it rejected, in
+// order, as Collection.removeIf() keeps them. The filter sees every element before any is removed.
+func filterElements(fs *list.List, filter *object.Object, elements []any, caller string) ([]any, bool, *ghelpers.GErrBlk) {
+	if filter == nil || object.IsNull(filter) {
+		return nil, false, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": filter is null")
+	}
+	kept := make([]any, 0, len(elements))
+	for _, elem := range elements {
+		ret := ghelpers.InvokeMethodOnObject(fs, filter, "test", methTypeTest, elem)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return nil, false, gerr
+		}
+		if ret != types.JavaBoolTrue {
+			kept = append(kept, elem)
+		}
+	}
+	return kept, len(kept) < len(elements), nil
+}
+
+// goKeyToJavaObject turns a Go-level HashMap key back into a Java object.
+func goKeyToJavaObject(key any) any {
+	switch v := key.(type) {
+	case hashNullKey:
+		return object.Null
+	case hashValueKey:
+		return valueKeyToJavaObject(v)
+	case string:
+		return object.StringObjectFromGoString(v)
+	case int64:
+		return object.MakePrimitiveObject("java/lang/Integer", types.Int, v)
+	case float64:
+		return object.MakePrimitiveObject("java/lang/Double", types.Double, v)
+	}
+	return key
+}
+
+// boxedFieldTypes gives the type of the "value" field of each boxed-primitive class.
+var boxedFieldTypes = map[string]string{
+	"java/lang/Integer":   types.Int,
+	"java/lang/Long":      types.Long,
+	"java/lang/Short":     types.Short,
+	"java/lang/Byte":      types.Byte,
+	"java/lang/Character": types.Char,
+	"java/lang/Boolean":   types.Bool,
+	"java/lang/Double":    types.Double,
+	"java/lang/Float":     types.Float,
+}
+
+// valueKeyToJavaObject rebuilds the string or boxed primitive that a hashValueKey stands for.
+func valueKeyToJavaObject(vk hashValueKey) *object.Object {
+	switch v := vk.value.(type) {
+	case string:
+		return object.StringObjectFromGoString(v)
+	case uint64: // the bit pattern of a Double or Float
+		if vk.className == "java/lang/Float" {
+			return object.MakePrimitiveObject(vk.className, types.Float, float64(math.Float32frombits(uint32(v))))
+		}
+		return object.MakePrimitiveObject(vk.className, types.Double, math.Float64frombits(v))
+	}
+	return object.MakePrimitiveObject(vk.className, boxedFieldTypes[vk.className], vk.value)
+}
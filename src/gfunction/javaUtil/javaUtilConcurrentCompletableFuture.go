@@ -0,0 +1,384 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// java.util.concurrent.CompletableFuture, a value or failure that some goroutine supplies
+// later. G functions that work asynchronously, such as HttpClient.sendAsync(), create one with
+// SupplyAsync().
+//
+// Lambdas are not yet supported, so the dependent stages (thenApply, thenAccept, ...) are not
+// run when the future completes: they wait for it and then call the function object on the
+// calling thread.
+
+const completableFutureClassName = "java/util/concurrent/CompletableFuture"
+
+func Load_Util_Concurrent_CompletableFuture() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                   {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                     {ParamSlots: 0, GFunction: completableFutureInit},
+		"cancel(Z)Z":                    {ParamSlots: 1, GFunction: completableFutureCancel},
+		"complete(Ljava/lang/Object;)Z": {ParamSlots: 1, GFunction: completableFutureComplete},
+		"completeExceptionally(Ljava/lang/Throwable;)Z": {ParamSlots: 1,
+			GFunction: completableFutureCompleteExceptionally},
+		"completedFuture(Ljava/lang/Object;)Ljava/util/concurrent/CompletableFuture;": {ParamSlots: 1,
+			GFunction: completableFutureCompletedFuture},
+		"get()Ljava/lang/Object;": {ParamSlots: 0, GFunction: completableFutureGet, NeedsContext: true},
+		"get(JLjava/util/concurrent/TimeUnit;)Ljava/lang/Object;": {ParamSlots: 2,
+			GFunction: completableFutureGet, NeedsContext: true},
+		"getNow(Ljava/lang/Object;)Ljava/lang/Object;": {ParamSlots: 1, GFunction: completableFutureGetNow},
+		"isCancelled()Z":               {ParamSlots: 0, GFunction: completableFutureIsCancelled},
+		"isCompletedExceptionally()Z":  {ParamSlots: 0, GFunction: completableFutureIsCompletedExceptionally},
+		"isDone()Z":                    {ParamSlots: 0, GFunction: completableFutureIsDone},
+		"join()Ljava/lang/Object;":     {ParamSlots: 0, GFunction: completableFutureJoin, NeedsContext: true},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: completableFutureToString},
+		"thenAccept(Ljava/util/function/Consumer;)Ljava/util/concurrent/CompletableFuture;": {ParamSlots: 1,
+			GFunction: completableFutureThenAccept, NeedsContext: true},
+		"thenApply(Ljava/util/function/Function;)Ljava/util/concurrent/CompletableFuture;": {ParamSlots: 1,
+			GFunction: completableFutureThenApply, NeedsContext: true},
+	} {
+		ghelpers.MethodSignatures[completableFutureClassName+"."+sig] = gmeth
+	}
+}
+
+// completableFuture is the Go state of a CompletableFuture. done is closed when the future
+// completes; after that, the other fields do not change.
+type completableFuture struct {
+	mu        sync.Mutex
+	done      chan struct{}
+	value     any
+	cause     string // the toString() of the exception it failed with, if it failed
+	failed    bool
+	cancelled bool
+}
+
+// NewCompletableFuture returns an incomplete CompletableFuture.
+func NewCompletableFuture() *object.Object {
+	className := completableFutureClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable["state"] = object.Field{Ftype: types.RawGoPointer, Fvalue: &completableFuture{done: make(chan struct{})}}
+	return obj
+}
+
+// SupplyAsync returns a CompletableFuture that is completed with the result of supply, which
+// runs in a new goroutine. If supply returns an error, the future fails with it.
+func SupplyAsync(supply func() (any, *ghelpers.GErrBlk)) *object.Object {
+	obj := NewCompletableFuture()
+	cf := obj.FieldTable["state"].Fvalue.(*completableFuture)
+	go func() {
+		value, gerr := supply()
+		if gerr != nil {
+			cf.fail(causeString(gerr), false)
+			return
+		}
+		cf.complete(value)
+	}()
+	return obj
+}
+
+// causeString returns what toString() would return for the exception in gerr.
+func causeString(gerr *ghelpers.GErrBlk) string {
+	name := excNames.JVMexceptionNames[gerr.ExceptionType]
+	if gerr.ErrMsg == "" {
+		return name
+	}
+	return name + ": " + gerr.ErrMsg
+}
+
+func getCompletableFuture(obj any) (*completableFuture, *ghelpers.GErrBlk) {
+	o, ok := obj.(*object.Object)
+	if !ok || object.IsNull(o) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "CompletableFuture is null")
+	}
+	cf, ok := o.FieldTable["state"].Fvalue.(*completableFuture)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "CompletableFuture not initialized")
+	}
+	return cf, nil
+}
+
+// complete sets the value of the future, if it is not yet complete.
+func (cf *completableFuture) complete(value any) bool {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if cf.isDone() {
+		return false
+	}
+	cf.value = value
+	close(cf.done)
+	return true
+}
+
+// fail completes the future with a failure, if it is not yet complete.
+func (cf *completableFuture) fail(cause string, cancelled bool) bool {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if cf.isDone() {
+		return false
+	}
+	cf.cause, cf.failed, cf.cancelled = cause, true, cancelled
+	close(cf.done)
+	return true
+}
+
+func (cf *completableFuture) isDone() bool {
+	select {
+	case <-cf.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// result returns the value of a completed future, or the exception that get() throws for it:
+// a CancellationException, or wrap with the cause as its message.
+func (cf *completableFuture) result(wrap int) (any, *ghelpers.GErrBlk) {
+	if cf.cancelled {
+		return nil, ghelpers.GetGErrBlk(excNames.CancellationException, "")
+	}
+	if cf.failed {
+		return nil, ghelpers.GetGErrBlk(wrap, cf.cause)
+	}
+	return cf.value, nil
+}
+
+func completableFutureInit(params []interface{}) interface{} {
+	params[0].(*object.Object).FieldTable["state"] = object.Field{Ftype: types.RawGoPointer,
+		Fvalue: &completableFuture{done: make(chan struct{})}}
+	return nil
+}
+
+// java/util/concurrent/CompletableFuture.completedFuture(Ljava/lang/Object;)Ljava/util/concurrent/CompletableFuture;
+func completableFutureCompletedFuture(params []interface{}) interface{} {
+	obj := NewCompletableFuture()
+	obj.FieldTable["state"].Fvalue.(*completableFuture).complete(params[0])
+	return obj
+}
+
+func completableFutureComplete(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(cf.complete(params[1]))
+}
+
+// java/util/concurrent/CompletableFuture.completeExceptionally(Ljava/lang/Throwable;)Z
+func completableFutureCompleteExceptionally(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	ex, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(ex) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "completeExceptionally: exception is null")
+	}
+	cause := strings.ReplaceAll(object.GoStringFromStringPoolIndex(ex.KlassName), "/", ".")
+	if msg, ok := ex.FieldTable["detailMessage"].Fvalue.(*object.Object); ok && !object.IsNull(msg) {
+		cause += ": " + object.GoStringFromStringObject(msg)
+	}
+	return types.ConvertGoBoolToJavaBool(cf.fail(cause, false))
+}
+
+// java/util/concurrent/CompletableFuture.cancel(Z)Z -- completes the future with a
+// CancellationException; nothing is interrupted, as in the JDK
+func completableFutureCancel(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	cf.fail(excNames.JVMexceptionNames[excNames.CancellationException], true)
+	return types.ConvertGoBoolToJavaBool(cf.cancelled)
+}
+
+// await waits, interruptibly, for the future in params[0] to complete. A negative timeout
+// waits for as long as it takes.
+func await(fs *list.List, params []interface{}, timeout time.Duration) (*completableFuture, *ghelpers.GErrBlk) {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return nil, gerr
+	}
+	ready, gerr := ghelpers.AwaitInterruptibly(fs, cf.done, timeout)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if !ready {
+		return nil, ghelpers.GetGErrBlk(excNames.TimeoutException, "")
+	}
+	return cf, nil
+}
+
+// java/util/concurrent/CompletableFuture.get()Ljava/lang/Object; and
+// get(JLjava/util/concurrent/TimeUnit;)Ljava/lang/Object; -- a failure is thrown as an
+// ExecutionException
+func completableFutureGet(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	timeout := time.Duration(-1)
+	if len(params) > 1 {
+		nanos, gerr := timeUnitToNanos(params[1].(int64), params[2])
+		if gerr != nil {
+			return gerr
+		}
+		timeout = time.Duration(max(nanos, 0))
+	}
+	cf, gerr := await(fs, params, timeout)
+	if gerr != nil {
+		return gerr
+	}
+	value, gerr := cf.result(excNames.ExecutionException)
+	if gerr != nil {
+		return gerr
+	}
+	return value
+}
+
+// java/util/concurrent/CompletableFuture.join()Ljava/lang/Object; -- as get(), but a failure is
+// thrown as a CompletionException
+func completableFutureJoin(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	cf, gerr := await(fs, params, -1)
+	if gerr != nil {
+		return gerr
+	}
+	value, gerr := cf.result(excNames.CompletionException)
+	if gerr != nil {
+		return gerr
+	}
+	return value
+}
+
+// java/util/concurrent/CompletableFuture.getNow(Ljava/lang/Object;)Ljava/lang/Object;
+func completableFutureGetNow(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	if !cf.isDone() {
+		return params[1]
+	}
+	value, gerr := cf.result(excNames.CompletionException)
+	if gerr != nil {
+		return gerr
+	}
+	return value
+}
+
+func completableFutureIsDone(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(cf.isDone())
+}
+
+func completableFutureIsCancelled(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(cf.isDone() && cf.cancelled)
+}
+
+func completableFutureIsCompletedExceptionally(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(cf.isDone() && cf.failed)
+}
+
+// java/util/concurrent/CompletableFuture.thenApply(Ljava/util/function/Function;)Ljava/util/concurrent/CompletableFuture;
+func completableFutureThenApply(params []interface{}) interface{} {
+	return thenRun(params, "apply", "(Ljava/lang/Object;)Ljava/lang/Object;")
+}
+
+// java/util/concurrent/CompletableFuture.thenAccept(Ljava/util/function/Consumer;)Ljava/util/concurrent/CompletableFuture;
+func completableFutureThenAccept(params []interface{}) interface{} {
+	return thenRun(params, "accept", "(Ljava/lang/Object;)V")
+}
+
+// thenRun waits for the future in params[0] and returns a new future that holds the result of
+// calling methName on the function object in params[1] with its value. If the future failed,
+// the new one fails in the same way, without the function being called.
+func thenRun(params []interface{}, methName, methType string) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	fn, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(fn) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, methName+": function is null")
+	}
+	cf, gerr := await(fs, params, -1)
+	if gerr != nil {
+		return gerr
+	}
+	next := NewCompletableFuture()
+	nextCf := next.FieldTable["state"].Fvalue.(*completableFuture)
+	if cf.failed {
+		nextCf.fail(cf.cause, cf.cancelled)
+		return next
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, fn, methName, methType, cf.value)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		nextCf.fail(causeString(gerr), false)
+		return next
+	}
+	if methType[len(methType)-1] == 'V' {
+		ret = object.Null
+	}
+	nextCf.complete(ret)
+	return next
+}
+
+// java/util/concurrent/CompletableFuture.toString()Ljava/lang/String; -- as in the JDK, the
+// state of the future, in brackets
+func completableFutureToString(params []interface{}) interface{} {
+	cf, gerr := getCompletableFuture(params[0])
+	if gerr != nil {
+		return gerr
+	}
+	state := "[Incomplete]"
+	if cf.isDone() {
+		state = "[Completed normally]"
+		if cf.failed {
+			state = "[Completed exceptionally: " + cf.cause + "]"
+		}
+	}
+	obj := params[0].(*object.Object)
+	str := fmt.Sprintf("java.util.concurrent.CompletableFuture@%x%s", obj.Mark.Hash, state)
+	return object.StringObjectFromGoString(str)
+}
+
+// timeUnitToNanos converts duration in the TimeUnit unit to nanoseconds.
+func timeUnitToNanos(duration int64, unit any) (int64, *ghelpers.GErrBlk) {
+	unitObj, ok := unit.(*object.Object)
+	if !ok || object.IsNull(unitObj) {
+		return 0, ghelpers.GetGErrBlk(excNames.NullPointerException, "TimeUnit is null")
+	}
+	unitName := object.GoStringFromStringObject(unitObj)
+	if name, ok := unitObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+		unitName = object.GoStringFromStringObject(name) // an enum constant of the JDK's TimeUnit
+	}
+	factor, ok := timeUnitConversion[NANOSECONDS][unitName]
+	if !ok {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid TimeUnit: "+unitName)
+	}
+	if duration > math.MaxInt64/factor {
+		return math.MaxInt64, nil
+	}
+	return duration * factor, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin Authors. All rights reserved.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0)  Consult jacobin.org.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+	"time"
+)
+
+func TestCompletableFuture_SupplyAsync(t *testing.T) {
+	globals.InitStringPool()
+	release := make(chan struct{})
+	value := object.StringObjectFromGoString("done")
+	cf := SupplyAsync(func() (any, *ghelpers.GErrBlk) {
+		<-release
+		return value, nil
+	})
+
+	if completableFutureIsDone([]interface{}{cf}) != types.JavaBoolFalse {
+		t.Fatal("expected the future to be incomplete")
+	}
+	dflt := object.StringObjectFromGoString("default")
+	if ret := completableFutureGetNow([]interface{}{cf, dflt}); ret != dflt {
+		t.Fatalf("expected getNow to return its argument, got %v", ret)
+	}
+
+	close(release)
+	if ret := completableFutureGet([]interface{}{list.New(), cf}); ret != value {
+		t.Fatalf("expected get to return the value, got %v", ret)
+	}
+	if ret := completableFutureJoin([]interface{}{list.New(), cf}); ret != value {
+		t.Fatalf("expected join to return the value, got %v", ret)
+	}
+	if completableFutureIsDone([]interface{}{cf}) != types.JavaBoolTrue {
+		t.Fatal("expected the future to be done")
+	}
+	if completableFutureComplete([]interface{}{cf, dflt}) != types.JavaBoolFalse {
+		t.Fatal("expected complete on a done future to return false")
+	}
+}
+
+func TestCompletableFuture_Failure(t *testing.T) {
+	globals.InitStringPool()
+	cf := SupplyAsync(func() (any, *ghelpers.GErrBlk) {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "no route")
+	})
+
+	testutil.ExpectGErr(t, completableFutureGet([]interface{}{list.New(), cf}),
+		excNames.ExecutionException, "java.io.IOException: no route")
+	testutil.ExpectGErr(t, completableFutureJoin([]interface{}{list.New(), cf}),
+		excNames.CompletionException, "java.io.IOException: no route")
+	if completableFutureIsCompletedExceptionally([]interface{}{cf}) != types.JavaBoolTrue {
+		t.Fatal("expected the future to have completed exceptionally")
+	}
+	if completableFutureIsCancelled([]interface{}{cf}) != types.JavaBoolFalse {
+		t.Fatal("expected the future not to be cancelled")
+	}
+}
+
+func TestCompletableFuture_CompleteExceptionally(t *testing.T) {
+	globals.InitStringPool()
+	cf := NewCompletableFuture()
+	className := "java/lang/IllegalStateException"
+	ex := object.MakeEmptyObjectWithClassName(&className)
+	ex.FieldTable["detailMessage"] = object.Field{Ftype: types.StringClassName,
+		Fvalue: object.StringObjectFromGoString("bad state")}
+
+	if completableFutureCompleteExceptionally([]interface{}{cf, ex}) != types.JavaBoolTrue {
+		t.Fatal("expected completeExceptionally to return true")
+	}
+	testutil.ExpectGErr(t, completableFutureGet([]interface{}{list.New(), cf}),
+		excNames.ExecutionException, "java.lang.IllegalStateException: bad state")
+}
+
+func TestCompletableFuture_Cancel(t *testing.T) {
+	globals.InitStringPool()
+	cf := NewCompletableFuture()
+	if completableFutureCancel([]interface{}{cf, types.JavaBoolTrue}) != types.JavaBoolTrue {
+		t.Fatal("expected cancel to return true")
+	}
+	if completableFutureIsCancelled([]interface{}{cf}) != types.JavaBoolTrue {
+		t.Fatal("expected the future to be cancelled")
+	}
+	testutil.ExpectGErr(t, completableFutureGet([]interface{}{list.New(), cf}), excNames.CancellationException, "")
+
+	done := NewCompletableFuture()
+	completableFutureComplete([]interface{}{done, object.Null})
+	if completableFutureCancel([]interface{}{done, types.JavaBoolTrue}) != types.JavaBoolFalse {
+		t.Fatal("expected cancel of a completed future to return false")
+	}
+}
+
+func TestCompletableFuture_GetTimeout(t *testing.T) {
+	globals.InitStringPool()
+	cf := NewCompletableFuture()
+	unit := object.StringObjectFromGoString(MILLISECONDS)
+
+	start := time.Now()
+	testutil.ExpectGErr(t, completableFutureGet([]interface{}{list.New(), cf, int64(50), unit}),
+		excNames.TimeoutException, "")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("get returned after %v, before its timeout", elapsed)
+	}
+
+	completableFutureComplete([]interface{}{cf, int64(7)})
+	if ret := completableFutureGet([]interface{}{list.New(), cf, int64(1), unit}); ret != int64(7) {
+		t.Fatalf("expected 7, got %v", ret)
+	}
+}
+
+func TestCompletableFuture_CompletedFutureToString(t *testing.T) {
+	globals.InitStringPool()
+	cf := completableFutureCompletedFuture([]interface{}{int64(1)}).(*object.Object)
+	str := object.GoStringFromStringObject(completableFutureToString([]interface{}{cf}).(*object.Object))
+	if want := "[Completed normally]"; len(str) < len(want) || str[len(str)-len(want):] != want {
+		t.Fatalf("unexpected toString: %s", str)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// An Enumeration returned by a G function is a snapshot of the elements it enumerates, which
+// are held in its elements field, like the snapshot iterators of javaUtilIterator.go.
+
+const enumerationClassName = "java/util/Enumeration"
+
+func Load_Util_Enumeration() {
+
+	ghelpers.MethodSignatures["java/util/Enumeration.hasMoreElements()Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  enumerationHasMoreElements,
+		}
+
+	ghelpers.MethodSignatures["java/util/Enumeration.nextElement()Ljava/lang/Object;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  enumerationNextElement,
+		}
+}
+
+// NewEnumeration returns an Enumeration of elements.
+func NewEnumeration(elements []any) *object.Object {
+	className := enumerationClassName
+	o := object.MakeEmptyObjectWithClassName(&className)
+	o.FieldTable[iteratorElementsField] = object.Field{Ftype: types.RefArray, Fvalue: elements}
+	o.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+	return o
+}
+
+// java/util/Enumeration.hasMoreElements()Z
+func enumerationHasMoreElements(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	elements, _ := self.FieldTable[iteratorElementsField].Fvalue.([]any)
+	index, _ := self.FieldTable[iteratorIndexField].Fvalue.(int64)
+	return types.ConvertGoBoolToJavaBool(index < int64(len(elements)))
+}
+
+// java/util/Enumeration.nextElement()Ljava/lang/Object;
+func enumerationNextElement(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	elements, _ := self.FieldTable[iteratorElementsField].Fvalue.([]any)
+	index, _ := self.FieldTable[iteratorIndexField].Fvalue.(int64)
+	if index >= int64(len(elements)) {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "Enumeration.nextElement: no more elements")
+	}
+	self.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: index + 1}
+	return elements[index]
+}
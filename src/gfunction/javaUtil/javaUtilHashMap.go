@@ -11,8 +11,9 @@ import (
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
-	"jacobin/src/stringPool"
 	"jacobin/src/types"
+	"math"
+	"slices"
 	"sync"
 )
 
@@ -49,20 +50,21 @@ func Load_Util_Hash_Map() {
 
 	ghelpers.MethodSignatures["java/util/HashMap.<init>(Ljava/util/Map;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    hashmapInitFromMap,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.clear()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClear,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.clone()Ljava/lang/Object;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClone,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.compute(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
@@ -85,26 +87,30 @@ func Load_Util_Hash_Map() {
 
 	ghelpers.MethodSignatures["java/util/HashMap.containsKey(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashmapContainsKey,
+			ParamSlots:   1,
+			GFunction:    hashmapContainsKey,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.containsValue(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    hashmapContainsValue,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.equals(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    mapEquals,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.entrySet()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  mapEntrySet,
+			ParamSlots:   0,
+			GFunction:    mapEntrySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.forEach(Ljava/util/function/BiConsumer;)V"] =
@@ -115,20 +121,23 @@ func Load_Util_Hash_Map() {
 
 	ghelpers.MethodSignatures["java/util/HashMap.get(Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashmapGet,
+			ParamSlots:   1,
+			GFunction:    hashmapGet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.getOrDefault(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  hashmapGetOrDefault,
+			ParamSlots:   2,
+			GFunction:    hashmapGetOrDefault,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.hashCode()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    mapHashCode,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.isEmpty()Z"] =
@@ -145,8 +154,9 @@ func Load_Util_Hash_Map() {
 
 	ghelpers.MethodSignatures["java/util/HashMap.keySet()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  mapKeySet,
+			ParamSlots:   0,
+			GFunction:    mapKeySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.merge(Ljava/lang/Object;Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
@@ -158,19 +168,21 @@ func Load_Util_Hash_Map() {
 	ghelpers.MethodSignatures["java/util/HashMap.newHashMap(I)Ljava/util/HashMap;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapNewHashMap,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  hashmapPut,
+			ParamSlots:   2,
+			GFunction:    hashmapPut,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.putAll(Ljava/util/Map;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashmapPutAll,
+			ParamSlots:   1,
+			GFunction:    hashmapPutAll,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.putIfAbsent(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
@@ -181,8 +193,9 @@ func Load_Util_Hash_Map() {
 
 	ghelpers.MethodSignatures["java/util/HashMap.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashmapRemove,
+			ParamSlots:   1,
+			GFunction:    hashmapRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashMap.remove(Ljava/lang/Object;Ljava/lang/Object;)Z"] =
@@ -223,254 +236,190 @@ func Load_Util_Hash_Map() {
 
 }
 
-// Initialise a hash map object to an empty state.
+// Initialise a hash map object to an empty state: HashMap(), HashMap(int) and HashMap(int, float),
+// and the same constructors of HashSet.
 func hashmapInit(params []interface{}) interface{} {
+	obj, ok := params[0].(*object.Object)
+	if !ok || obj == nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "hashmapInit: invalid 'this' argument")
+	}
+	capacity := int64(hashDefaultCapacity)
+	loadFactor := float32(hashDefaultLoadFactor)
+	if len(params) > 1 {
+		capacity, _ = params[1].(int64)
+		if capacity < 0 {
+			errMsg := fmt.Sprintf("hashmapInit: Illegal initial capacity: %d", capacity)
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		}
+	}
+	if len(params) > 2 {
+		lf, _ := params[2].(float64)
+		if lf <= 0 || lf != lf {
+			errMsg := fmt.Sprintf("hashmapInit: Illegal load factor: %s", javaDoubleToString(lf, true))
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		}
+		loadFactor = float32(lf)
+	}
+
 	hashmapMutex.Lock()
 	defer hashmapMutex.Unlock()
-	nilMap := make(types.DefHashMap)
-	obj := params[0].(*object.Object)
 	if obj.KlassName == 0 || obj.KlassName == types.InvalidStringIndex {
 		obj.KlassName = object.StringPoolIndexFromGoString(classNameHashMap)
 	}
 	fld := obj.FieldTable[fieldNameMap]
 	fld.Ftype = types.HashMap
-	fld.Fvalue = nilMap
+	fld.Fvalue = make(types.DefHashMap)
 	obj.FieldTable[fieldNameMap] = fld
+	obj.FieldTable[fieldNameHashState] = object.Field{
+		Ftype: types.RawGoPointer, Fvalue: newHashState(int(capacity), loadFactor)}
 	return nil
 }
 
-// An internal function to extract the HashMap key field value.
-func _getKey(param interface{}) (interface{}, bool) {
-	keyObj, ok := param.(*object.Object)
-	if !ok || keyObj == nil {
-		errMsg := "HashMap:_getKey: Key parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg), false
-	}
-	if object.IsStringObject(keyObj) {
-		return object.GoStringFromStringObject(keyObj), true
-	}
-	fvalue := keyObj.FieldTable[fieldNameValue].Fvalue
-	switch fvalue.(type) {
-	case int64, float64:
-	default:
-		ftype := keyObj.FieldTable[fieldNameValue].Ftype
-		errMsg := fmt.Sprintf("HashMap:_getKey: Unsupported key type: {Ftype: %s, Fvalue: %T}", ftype, fvalue)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg), false
-	}
-	return fvalue, true
-}
-
-// Put inserts a key-value pair into the HashMap and returns the previous value or null.
-func hashmapPut(params []interface{}) interface{} {
-	hashmapMutex.Lock()
-	defer hashmapMutex.Unlock()
-
-	if len(params) < 3 {
-		errMsg := "hashmapPut: requires 3 parameters: HashMap, key, and value"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// "java/util/HashMap.<init>(Ljava/util/Map;)V"
+func hashmapInitFromMap(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "hashmapInitFromMap: requires 2 parameters: HashMap and Map")
 	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapPut: HashMap parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	that, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(that) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "hashmapInitFromMap: map is null")
 	}
+	if ret := hashmapInit(args[:1]); ret != nil {
+		return ret
+	}
+	return hashmapPutAll([]interface{}{fs, args[0], that})
+}
 
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapPut: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
+// "java/util/HashMap.newHashMap(I)Ljava/util/HashMap;": a HashMap sized for the expected number of mappings
+func hashmapNewHashMap(params []interface{}) interface{} {
+	numMappings, _ := params[0].(int64)
+	if numMappings < 0 {
+		errMsg := fmt.Sprintf("hashmapNewHashMap: Negative number of mappings: %d", numMappings)
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
 	}
+	hm := object.MakeEmptyObjectWithClassName(&classNameHashMap)
+	capacity := int64(math.Ceil(float64(numMappings) / hashDefaultLoadFactor))
+	if ret := hashmapInit([]interface{}{hm, capacity}); ret != nil {
+		return ret
+	}
+	return hm
+}
 
-	// Extract the key.
-	key, ok := _getKey(params[1])
-	if !ok {
-		return key
+// Remove all entries. Unlike a new hash map, a cleared one keeps the capacity it grew to.
+func hashmapClear(params []interface{}) interface{} {
+	_, _, hm, hs, gerr := hashThisForUpdate(params, 1, "hashmapClear")
+	if gerr != nil {
+		return gerr
 	}
+	hashmapMutex.Lock()
+	defer hashmapMutex.Unlock()
+	clear(hm)
+	hs.cleared()
+	return nil
+}
 
-	// Use the value object as-is.
-	value := params[2]
+// Return a shallow copy: the keys and values themselves are not cloned.
+func hashmapClone(params []interface{}) interface{} {
+	_, args, hm, hs, gerr := hashThis(params, 1, "hashmapClone")
+	if gerr != nil {
+		return gerr
+	}
+	this := args[0].(*object.Object)
+
+	hashmapMutex.RLock()
+	defer hashmapMutex.RUnlock()
+	className := object.GoStringFromStringPoolIndex(this.KlassName)
+	clone := object.MakeEmptyObjectWithClassName(&className)
+	cloneMap := make(types.DefHashMap, len(hm))
+	for goKey, value := range hm {
+		cloneMap[goKey] = value
+	}
+	cloneState := *hs
+	cloneState.buckets = make(map[int32][]*object.Object, len(hs.buckets))
+	for hash, bucket := range hs.buckets {
+		cloneState.buckets[hash] = slices.Clone(bucket)
+	}
+	cloneState.meta = make(map[any]*hashMeta, len(hs.meta))
+	for goKey, meta := range hs.meta {
+		metaCopy := *meta
+		cloneState.meta[goKey] = &metaCopy
+	}
+	clone.FieldTable[fieldNameMap] = object.Field{Ftype: types.HashMap, Fvalue: cloneMap}
+	clone.FieldTable[fieldNameHashState] = object.Field{Ftype: types.RawGoPointer, Fvalue: &cloneState}
+	return clone
+}
 
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapPut: HashMap parameter is missing its \"value\" field"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// Put inserts a key-value pair into the HashMap and returns the previous value or null.
+func hashmapPut(params []interface{}) interface{} {
+	fs, args, hm, hs, gerr := hashThisForUpdate(params, 3, "hashmapPut")
+	if gerr != nil {
+		return gerr
+	}
+	prevValue, exists, gerr := hashPut(fs, hm, hs, args[1], args[2])
+	if gerr != nil {
+		return gerr
 	}
-
-	// Check for the previous value associated with the key.
-	prevValue, exists := hm[key]
 	if !exists {
-		prevValue = object.Null
+		return object.Null
 	}
-
-	// Store the new key-value pair in the hash map.
-	hm[key] = value
-	fld.Fvalue = hm
-	this.FieldTable[fieldNameMap] = fld
-
-	// Return the previous value for that key.
 	return prevValue
 }
 
-// Get a hash map entry. Return nil if there is not one that matches the key.
+// Get a hash map entry. Return null if there is not one that matches the key.
 func hashmapGet(params []interface{}) interface{} {
-	if len(params) < 2 {
-		errMsg := "hashmapGet: Requires 2 parameters: HashMap and key"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapGet: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	fs, args, hm, hs, gerr := hashThis(params, 2, "hashmapGet")
+	if gerr != nil {
+		return gerr
 	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapGet: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	goKey, exists, gerr := hashLookup(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
-
-	// Extract the key.
-	key, ok := _getKey(params[1])
-	if !ok {
-		return key
-	}
-
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapGet: The HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Retrieve the field associated with the key
-	value, exists := hm[key]
 	if !exists {
 		return object.Null
 	}
-
-	return value
+	return hm[goKey]
 }
 
 // Get a hash map entry. If it is not present, return the default value.
 func hashmapGetOrDefault(params []interface{}) interface{} {
-	if len(params) < 3 {
-		errMsg := "hashmapGetOrDefault: Requires 3 parameters: HashMap, key, and default value"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapGetOrDefault: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapGetOrDefault: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, args, hm, hs, gerr := hashThis(params, 3, "hashmapGetOrDefault")
+	if gerr != nil {
+		return gerr
 	}
-
-	// Extract the key.
-	key, ok := _getKey(params[1])
-	if !ok {
-		return key
+	goKey, exists, gerr := hashLookup(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
-
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapGetOrDefault: The HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Retrieve the field associated with the key
-	value, exists := hm[key]
 	if !exists {
-		return params[2]
+		return args[2]
 	}
-
-	return value
+	return hm[goKey]
 }
 
-// Remove a hash map entry. Return the removed value or nil if there is not one that matches the key.
+// Remove a hash map entry. Return the removed value or null if there is not one that matches the key.
 func hashmapRemove(params []interface{}) interface{} {
-	hashmapMutex.Lock()
-	defer hashmapMutex.Unlock()
-
-	if len(params) < 2 {
-		errMsg := "hashmapRemove: Requires 2 parameters: HashMap and key"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapRemove: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapRemove: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Extract the key.
-	key, ok := _getKey(params[1])
-	if !ok {
-		return key
+	fs, args, hm, hs, gerr := hashThisForUpdate(params, 2, "hashmapRemove")
+	if gerr != nil {
+		return gerr
 	}
-
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapRemove: The HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	value, exists, gerr := hashRemove(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
-
-	// Retrieve the field associated with the key
-	value, exists := hm[key]
 	if !exists {
 		return object.Null
 	}
-
-	// Delete key-value entry.
-	delete(hm, key)
-
-	// Return the deleted value object.
 	return value
 }
 
 // Get the size of the hash map.
 func hashmapSize(params []interface{}) interface{} {
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapSize: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	_, _, hm, _, gerr := hashThis(params, 1, "hashmapSize")
+	if gerr != nil {
+		return gerr
 	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapSize: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapSize: The HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Return the current size.
 	return int64(len(hm))
 }
 
@@ -488,99 +437,56 @@ func hashmapIsEmpty(params []interface{}) interface{} {
 	return types.JavaBoolFalse
 }
 
+// Copy all of the mappings of another map (of any kind Jacobin knows) into this one.
 func hashmapPutAll(params []interface{}) interface{} {
-	hashmapMutex.Lock()
-	defer hashmapMutex.Unlock()
-
-	if len(params) < 2 {
-		errMsg := "hashmapPutAll: requires 2 parameters: this HashMap and that HashMap"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, args, hm, hs, gerr := hashThisForUpdate(params, 2, "hashmapPutAll")
+	if gerr != nil {
+		return gerr
 	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapPutAll: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	that, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(that) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "hashmapPutAll: map is null")
 	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapPutAll: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Get the hash map from this.
-	thisFld := this.FieldTable[fieldNameMap]
-	thisHmap, ok := thisFld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapPutAll: The HashMap is not present in the 1st parameter"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	that, ok := params[1].(*object.Object)
-	if !ok {
-		errMsg := "hashmapPutAll: The 2nd parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	if *stringPool.GetStringPointer(this.KlassName) != classNameHashMap {
-		errMsg := "hashmapPutAll: The 2nd parameter is not a HashMap"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Get the hash map from that.
-	thatFld := that.FieldTable[fieldNameMap]
-	thatHmap, ok := thatFld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapPutAll: The HashMap is not present in the 2nd parameter"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	entries, gerr := mapEntries(fs, that)
+	if gerr != nil {
+		return gerr
 	}
-
-	for key, value := range thatHmap {
-		thisHmap[key] = value
+	hs.presize(len(entries))
+	for _, entry := range entries {
+		if _, _, gerr = hashPut(fs, hm, hs, entry.key, entry.value); gerr != nil {
+			return gerr
+		}
 	}
-
 	return nil
 }
 
-// Does the hash map Have the given key?
+// Does the hash map have the given key?
 func hashmapContainsKey(params []interface{}) interface{} {
-	if len(params) < 2 {
-		errMsg := "hashmapContainsKey: Requires 2 parameters: HashMap and key"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	fs, args, hm, hs, gerr := hashThis(params, 2, "hashmapContainsKey")
+	if gerr != nil {
+		return gerr
 	}
-
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashmapContainsKey: The first parameter is not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashMap && actualKlassName != classNameHashSet {
-		errMsg := fmt.Sprintf("hashmapContainsKey: expected %s or %s, got %s", classNameHashMap, classNameHashSet, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Extract the key.
-	key, ok := _getKey(params[1])
-	if !ok {
-		return key
+	_, exists, gerr := hashLookup(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
+	return object.JavaBooleanFromGoBoolean(exists)
+}
 
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashmapContainsKey: The HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// Does the hash map map any key to the given value? Values are compared with equals().
+func hashmapContainsValue(params []interface{}) interface{} {
+	fs, args, hm, hs, gerr := hashThis(params, 2, "hashmapContainsValue")
+	if gerr != nil {
+		return gerr
 	}
-
-	// Retrieve the field associated with the key
-	_, exists := hm[key]
-	if exists {
-		return types.JavaBoolTrue
+	for _, goKey := range hs.orderedKeys(hm) {
+		equal, gerr := javaEquals(fs, args[1], hm[goKey])
+		if gerr != nil {
+			return gerr
+		}
+		if equal {
+			return types.JavaBoolTrue
+		}
 	}
-
 	return types.JavaBoolFalse
 }
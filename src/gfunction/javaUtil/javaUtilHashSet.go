@@ -7,14 +7,10 @@
 package javaUtil
 
 import (
-	"fmt"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
-	"jacobin/src/stringPool"
 	"jacobin/src/types"
-	"jacobin/src/util"
-	"strconv"
 )
 
 var classNameObject = "java/lang/Object"
@@ -45,10 +41,18 @@ func Load_Util_Hash_Set() {
 			GFunction:  hashmapInit,
 		}
 
+	ghelpers.MethodSignatures["java/util/HashSet.<init>(Ljava/util/Collection;)V"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    hashsetInitFromCollection,
+			NeedsContext: true,
+		}
+
 	ghelpers.MethodSignatures["java/util/HashSet.add(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetAdd,
+			ParamSlots:   1,
+			GFunction:    hashsetAdd,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.addAll(Ljava/util/Collection;)Z"] =
@@ -60,19 +64,20 @@ func Load_Util_Hash_Set() {
 	ghelpers.MethodSignatures["java/util/HashSet.clear()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClear,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.clone()Ljava/lang/Object;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClone,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.contains(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetContains,
+			ParamSlots:   1,
+			GFunction:    hashsetContains,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.containsAll(Ljava/util/Collection;)Z"] =
@@ -96,13 +101,14 @@ func Load_Util_Hash_Set() {
 	ghelpers.MethodSignatures["java/util/HashSet.newHashSet(I)Ljava/util/HashSet;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  hashmapInit,
+			GFunction:  hashsetNewHashSet,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.remove(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetRemove,
+			ParamSlots:   1,
+			GFunction:    hashsetRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/HashSet.removeAll(Ljava/util/Collection;)Z"] =
@@ -143,218 +149,115 @@ func Load_Util_Hash_Set() {
 
 }
 
-// Compute the hash of the object being added to the HashSet.
-// Is it already present? Remember for later.
-// Use HashMap.put to add key=hash, value=parameter.
+// Add an element to the HashSet. Elements are matched with their own hashCode() and equals().
 // Return true if this entry did not previously exist; else return false.
 func hashsetAdd(params []interface{}) interface{} {
-
-	// Validate HashSet object parameter.
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashsetAdd: HashSet parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+	fs, args, hm, hs, gerr := hashThisForUpdate(params, 2, "hashsetAdd")
+	if gerr != nil {
+		return gerr
 	}
-
-	// It must be a HashSet or HashMap object.
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashSet && actualKlassName != classNameHashMap {
-		errMsg := fmt.Sprintf("hashsetAdd: expected %s or %s, got %s", classNameHashSet, classNameHashMap, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Get argument to add.
-	that, ok := params[1].(*object.Object)
-	if !ok || that == nil {
-		errMsg := "hashsetAdd: Argument parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	// As in the JDK, adding an element equal to one already present leaves the original in place.
+	_, exists, gerr := hashLookup(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
-	fld, ok := that.FieldTable["value"]
-	if !ok || that == nil {
-		errMsg := "hashsetAdd: Argument parameter is missing the \"value\" field"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Hash the argument and build a composite key including the element's class name
-	hashedUint64, err := util.HashAnything(fld.Fvalue)
-	if err != nil {
-		errMsg := fmt.Sprintf("hashsetAdd: util.HashAnything failed, err: %v", err)
-		return ghelpers.GetGErrBlk(excNames.VirtualMachineError, errMsg)
-	}
-	className := *stringPool.GetStringPointer(that.KlassName)
-	keyString := className + ":" + strconv.FormatUint(hashedUint64, 10)
-
-	// Remember whether the key already exists.
-	flagReturn := types.JavaBoolTrue // Assume not existing yet.
-	fld = this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashsetAdd: HashMap is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-	_, exists := hm[keyString]
 	if exists {
-		flagReturn = types.JavaBoolFalse
+		return types.JavaBoolFalse
 	}
-
-	// Add this argument.
-	var extparams = new([]interface{})
-	key := object.StringObjectFromGoString(keyString)
-	*extparams = append(*extparams, this)
-	*extparams = append(*extparams, key)
-	*extparams = append(*extparams, that)
-	result := hashmapPut(*extparams)
-	switch result.(type) {
-	case *ghelpers.GErrBlk:
-		return result
+	if _, _, gerr = hashPut(fs, hm, hs, args[1], args[1]); gerr != nil {
+		return gerr
 	}
-
-	return flagReturn
+	return types.JavaBoolTrue
 }
 
-// Remove a hash set entry. Return true if something was actually removed; else return false.
-func hashsetRemove(params []interface{}) interface{} {
-	// Validate HashSet object parameter.
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashsetRemove: HashSet parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+// NewHashSet returns a HashSet holding elements, for G functions that return a Set.
+func NewHashSet(elements []any) (*object.Object, *ghelpers.GErrBlk) {
+	set := object.MakeEmptyObjectWithClassName(&classNameHashSet)
+	capacity := max(int64(float64(len(elements))/hashDefaultLoadFactor)+1, hashDefaultCapacity)
+	if ret := hashmapInit([]interface{}{set, capacity}); ret != nil {
+		return nil, ret.(*ghelpers.GErrBlk)
 	}
-
-	// It must be a HashSet or HashMap object.
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashSet && actualKlassName != classNameHashMap {
-		errMsg := fmt.Sprintf("hashsetRemove: expected %s or %s, got %s", classNameHashSet, classNameHashMap, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	hm, hs, _ := getHashMap(set, "NewHashSet")
+	for _, elem := range elements {
+		if _, _, gerr := hashPut(nil, hm, hs, elem, elem); gerr != nil {
+			return nil, gerr
+		}
 	}
+	return set, nil
+}
 
-	// Get argument to remove.
-	that, ok := params[1].(*object.Object)
-	if !ok || that == nil {
-		errMsg := "hashsetRemove: Argument parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// "java/util/HashSet.<init>(Ljava/util/Collection;)V"
+func hashsetInitFromCollection(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "hashsetInitFromCollection: requires 2 parameters: HashSet and Collection")
 	}
-	fld, ok := that.FieldTable["value"]
-	if !ok || that == nil {
-		errMsg := "hashsetRemove: Argument parameter is missing the \"value\" field"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	that, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(that) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "hashsetInitFromCollection: collection is null")
 	}
-
-	// Hash the argument and build a composite key including the element's class name
-	hashedUint64, err := util.HashAnything(fld.Fvalue)
-	if err != nil {
-		errMsg := fmt.Sprintf("hashsetRemove: util.HashAnything failed, err: %v", err)
-		return ghelpers.GetGErrBlk(excNames.VirtualMachineError, errMsg)
+	elements, gerr := collectionElements(fs, that)
+	if gerr != nil {
+		return gerr
 	}
-	className := *stringPool.GetStringPointer(that.KlassName)
-	hashedString := className + ":" + strconv.FormatUint(hashedUint64, 10)
-
-	// Remove this argument.
-	var extparams = new([]interface{})
-	key := object.StringObjectFromGoString(hashedString)
-	*extparams = append(*extparams, this)
-	*extparams = append(*extparams, key)
-	result := hashmapRemove(*extparams)
-	switch result.(type) {
-	case *ghelpers.GErrBlk:
-		return result
+	capacity := max(int64(float64(len(elements))/hashDefaultLoadFactor)+1, hashDefaultCapacity)
+	if ret := hashmapInit([]interface{}{args[0], capacity}); ret != nil {
+		return ret
 	}
-
-	if result == object.Null {
-		return types.JavaBoolFalse
+	hm, hs, _ := getHashMap(args[0].(*object.Object), "hashsetInitFromCollection")
+	for _, elem := range elements {
+		if _, _, gerr = hashPut(fs, hm, hs, elem, elem); gerr != nil {
+			return gerr
+		}
 	}
-	return types.JavaBoolTrue
+	return nil
 }
 
-// Does the hash map Have the given key?
-func hashsetContains(params []interface{}) interface{} {
-	// Validate HashSet object parameter.
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashsetContains: HashSet parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	// It must be a HashSet or HashMap object.
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashSet && actualKlassName != classNameHashMap {
-		errMsg := fmt.Sprintf("hashsetContains: expected %s or %s, got %s", classNameHashSet, classNameHashMap, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// "java/util/HashSet.newHashSet(I)Ljava/util/HashSet;": a HashSet sized for the expected number of elements
+func hashsetNewHashSet(params []interface{}) interface{} {
+	ret := hashmapNewHashMap(params)
+	if set, ok := ret.(*object.Object); ok {
+		set.KlassName = object.StringPoolIndexFromGoString(classNameHashSet)
 	}
+	return ret
+}
 
-	// Get argument to look for.
-	that, ok := params[1].(*object.Object)
-	if !ok || that == nil {
-		errMsg := "hashsetContains: Argument parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+// Remove a hash set entry. Return true if something was actually removed; else return false.
+func hashsetRemove(params []interface{}) interface{} {
+	fs, args, hm, hs, gerr := hashThisForUpdate(params, 2, "hashsetRemove")
+	if gerr != nil {
+		return gerr
 	}
-	fld, ok := that.FieldTable["value"]
-	if !ok {
-		errMsg := "hashsetContains: Argument parameter is missing the \"value\" field"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	_, exists, gerr := hashRemove(fs, hm, hs, args[1])
+	if gerr != nil {
+		return gerr
 	}
+	return object.JavaBooleanFromGoBoolean(exists)
+}
 
-	// Hash the argument and build a composite key including the element's class name
-	hashedUint64, err := util.HashAnything(fld.Fvalue)
-	if err != nil {
-		errMsg := fmt.Sprintf("hashsetContains: util.HashAnything failed, err: %v", err)
-		return ghelpers.GetGErrBlk(excNames.VirtualMachineError, errMsg)
-	}
-	className := *stringPool.GetStringPointer(that.KlassName)
-	hashedString := className + ":" + strconv.FormatUint(hashedUint64, 10)
-
-	// Do the search.
-	var extparams = new([]interface{})
-	key := object.StringObjectFromGoString(hashedString)
-	*extparams = append(*extparams, this)
-	*extparams = append(*extparams, key)
-	return hashmapContainsKey(*extparams)
+// Does the hash set have the given element?
+func hashsetContains(params []interface{}) interface{} {
+	return hashmapContainsKey(params)
 }
 
 func hashsetIsEmpty(params []interface{}) interface{} {
-
-	result := hashmapSize(params)
-	switch result.(type) {
-	case *ghelpers.GErrBlk:
-		return result
-	}
-
-	if result.(int64) == 0 {
-		return types.JavaBoolTrue
-	}
-	return types.JavaBoolFalse
+	return hashmapIsEmpty(params)
 }
 
+// Return the elements of the hash set in iteration order.
 func hashsetToArray(params []interface{}) interface{} {
-
-	// Validate HashSet object parameter.
-	this, ok := params[0].(*object.Object)
-	if !ok || this == nil {
-		errMsg := "hashsetToArray: HashSet parameter is nil or not an object"
-		return ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
-	}
-
-	// It must be a HashSet or HashMap object.
-	actualKlassName := *stringPool.GetStringPointer(this.KlassName)
-	if actualKlassName != classNameHashSet && actualKlassName != classNameHashMap {
-		errMsg := fmt.Sprintf("hashsetToArray: expected %s or %s, got %s", classNameHashSet, classNameHashMap, actualKlassName)
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
-	}
-
-	// Get the current hash map.
-	fld := this.FieldTable[fieldNameMap]
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		errMsg := "hashsetToArray: HashMap field is not present"
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	_, args, hm, hs, gerr := hashThis(params, 1, "hashsetToArray")
+	if gerr != nil {
+		return gerr
 	}
 
 	// Create an array of objects.
-	objArray := make([]*object.Object, 0, len(hm))
-	for _, value := range hm {
-		objArray = append(objArray, value.(*object.Object))
+	entries := hashEntries(args[0].(*object.Object), hm, hs)
+	objArray := make([]*object.Object, 0, len(entries))
+	for _, entry := range entries {
+		obj, _ := entry.value.(*object.Object)
+		objArray = append(objArray, obj)
 	}
 
 	return object.MakePrimitiveObject(classNameObject, types.RefArray, objArray)
-
 }
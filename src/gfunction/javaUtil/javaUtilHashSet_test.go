@@ -1,6 +1,7 @@
 package javaUtil
 
 import (
+	"jacobin/src/classloader"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -135,15 +136,13 @@ func TestHashSet_ErrorPaths(t *testing.T) {
 		}
 	}
 
-	// Element missing value field -> IllegalArgumentException
-	badElem := object.MakeEmptyObjectWithClassName(&classNameObject) // no "value" field
-	if err := hashsetAdd([]interface{}{hs, badElem}); err == nil {
-		t.Fatalf("expected error for missing value field in element")
-	} else if geb, ok := err.(*ghelpers.GErrBlk); ok {
-		if geb.ExceptionType != excNames.IllegalArgumentException {
-			t.Fatalf("expected IllegalArgumentException, got %d", geb.ExceptionType)
-		}
-	}
+	// A plain Object element (no "value" field) is matched by identity
+	classloader.InitMethodArea()
+	plainElem := object.MakeEmptyObjectWithClassName(&classNameObject)
+	assertJavaBool(t, hashsetAdd([]interface{}{hs, plainElem}), types.JavaBoolTrue, "add plain Object")
+	assertJavaBool(t, hashsetAdd([]interface{}{hs, plainElem}), types.JavaBoolFalse, "add same plain Object again")
+	otherElem := object.MakeEmptyObjectWithClassName(&classNameObject)
+	assertJavaBool(t, hashsetContains([]interface{}{hs, otherElem}), types.JavaBoolFalse, "contains a different plain Object")
 
 	// Similar error paths for contains and remove
 	if err := hashsetContains([]interface{}{hs, int64(1)}); err == nil {
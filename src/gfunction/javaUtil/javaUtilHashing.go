@@ -0,0 +1,641 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"slices"
+	"unicode/utf16"
+)
+
+// Java hashCode()/equals() semantics for the hash-based collections (HashMap, HashSet, LinkedHashMap,
+// LinkedHashSet). Each Java key is resolved to the Go value that indexes it in a Go map:
+//
+//   - null, strings and boxed primitives are indexed by value (hashValueKey), with the hash code
+//     computed natively, exactly as String.hashCode(), Integer.hashCode() etc. would;
+//   - objects whose class does not override hashCode() (including enums) are indexed by identity;
+//   - objects whose class does override hashCode() are grouped by the hash code their Java method
+//     returns and matched with their equals() method, which is run via ghelpers.InvokeMethodOnObject.
+//
+// HashMap and HashSet also track the table capacity the JDK would have and the order in which keys
+// were inserted, so that they iterate in the same order as the JDK's HashMap does.
+
+var fieldNameHashState = "hashState"
+
+const (
+	methTypeHashCode          = "()I"
+	hashDefaultCapacity       = 16
+	hashDefaultLoadFactor     = 0.75
+	hashMaximumCapacity       = 1 << 30
+	classNameEnum             = "java/lang/Enum"
+	boxedBooleanTrueHashCode  = 1231
+	boxedBooleanFalseHashCode = 1237
+)
+
+// hashNullKey indexes the Java null key.
+type hashNullKey struct{}
+
+// hashValueKey indexes keys that are matched by value: strings and boxed primitives. Doubles and
+// floats are held as their canonical bit patterns, since that is what Double.equals() compares.
+type hashValueKey struct {
+	className string
+	value     any
+}
+
+// hashKeys holds the keys of a collection whose classes override hashCode(), by hash code.
+type hashKeys struct {
+	buckets map[int32][]*object.Object
+}
+
+// hashMeta records the original Java key of an entry, its hash code and when it was inserted.
+type hashMeta struct {
+	key  any
+	hash int32
+	seq  int64
+}
+
+// hashState is the Go state that a HashMap or HashSet keeps next to its Go map (field "map").
+type hashState struct {
+	hashKeys
+	meta       map[any]*hashMeta
+	capacity   int // 0 until the first insertion, as the JDK allocates its table lazily
+	initialCap int // the capacity to allocate on the first insertion
+	loadFactor float32
+	nextSeq    int64
+	keepsOrder bool // iterate in insertion order: used for keySet() and entrySet() snapshots
+}
+
+func newHashKeys() hashKeys {
+	return hashKeys{buckets: make(map[int32][]*object.Object)}
+}
+
+func newHashState(initialCapacity int, loadFactor float32) *hashState {
+	return &hashState{
+		hashKeys:   newHashKeys(),
+		meta:       make(map[any]*hashMeta),
+		initialCap: hashTableSizeFor(initialCapacity),
+		loadFactor: loadFactor,
+	}
+}
+
+// hashTableSizeFor returns the power of two the JDK uses as the table size for capacity.
+func hashTableSizeFor(capacity int) int {
+	n := 1
+	for n < capacity && n < hashMaximumCapacity {
+		n <<= 1
+	}
+	return n
+}
+
+// javaStringHashCode computes String.hashCode(), which works on UTF-16 code units.
+func javaStringHashCode(str string) int32 {
+	hash := int32(0)
+	for ix := 0; ix < len(str); ix++ {
+		if str[ix] >= 0x80 {
+			hash = 0
+			for _, unit := range utf16.Encode([]rune(str)) {
+				hash = 31*hash + int32(unit)
+			}
+			return hash
+		}
+		hash = 31*hash + int32(str[ix])
+	}
+	return hash
+}
+
+// boxedHashCode computes hashCode() of a boxed primitive, given its class name and value.
+func boxedHashCode(className string, value any) int32 {
+	switch v := value.(type) {
+	case int64:
+		switch className {
+		case "java/lang/Long":
+			return int32(uint64(v) ^ (uint64(v) >> 32))
+		case "java/lang/Boolean":
+			if v != 0 {
+				return boxedBooleanTrueHashCode
+			}
+			return boxedBooleanFalseHashCode
+		}
+		return int32(v)
+	case float64:
+		if className == "java/lang/Float" {
+			bits := math.Float32bits(float32(v))
+			if v != v { // NaN
+				bits = 0x7fc00000
+			}
+			return int32(bits)
+		}
+		bits := canonicalDoubleBits(v)
+		return int32(bits ^ (bits >> 32))
+	}
+	return 0
+}
+
+// canonicalDoubleBits is Double.doubleToLongBits(): all NaNs collapse to a single bit pattern.
+func canonicalDoubleBits(f float64) uint64 {
+	if math.IsNaN(f) {
+		return 0x7ff8000000000000
+	}
+	return math.Float64bits(f)
+}
+
+// identityHashCode returns the hash code that Object.hashCode() gives obj.
+func identityHashCode(obj *object.Object) int32 {
+	if gm, ok := ghelpers.MethodSignatures["java/lang/Object.hashCode()I"]; ok {
+		if hash, ok := gm.GFunction([]any{obj}).(int64); ok {
+			return int32(hash)
+		}
+	}
+	return int32(obj.Mark.Hash)
+}
+
+// valueKey returns the by-value index key and hash code of a null, string or boxed-primitive key.
+// ok is false for any other key.
+func valueKey(key any) (any, int32, bool) {
+	if isNullValue(key) {
+		return hashNullKey{}, 0, true
+	}
+	obj, ok := key.(*object.Object)
+	if !ok {
+		return nil, 0, false
+	}
+	if object.IsStringObject(obj) {
+		str := object.GoStringFromStringObject(obj)
+		return hashValueKey{className: types.StringClassName, value: str}, javaStringHashCode(str), true
+	}
+	if value, className, ok := boxedValue(obj); ok {
+		hash := boxedHashCode(className, value)
+		if f, ok := value.(float64); ok {
+			if className == "java/lang/Float" {
+				value = uint64(math.Float32bits(float32(f)))
+				if math.IsNaN(f) {
+					value = uint64(0x7fc00000)
+				}
+			} else {
+				value = canonicalDoubleBits(f)
+			}
+		}
+		return hashValueKey{className: className, value: value}, hash, true
+	}
+	return nil, 0, false
+}
+
+// overridesHashCode reports whether the class of obj has its own hashCode(), rather than the
+// identity hash code of Object (or Enum, whose hashCode() is final).
+func overridesHashCode(obj *object.Object) bool {
+	_, clName := ghelpers.FindInstanceMethod(obj, "hashCode", methTypeHashCode)
+	return clName != "" && clName != types.ObjectClassName && clName != classNameEnum
+}
+
+// javaHashCode returns v.hashCode(), or 0 for null.
+func javaHashCode(fs *list.List, v any) (int32, *ghelpers.GErrBlk) {
+	if _, hash, ok := valueKey(v); ok {
+		return hash, nil
+	}
+	obj, ok := v.(*object.Object)
+	if !ok {
+		errMsg := fmt.Sprintf("javaHashCode: cannot compute the hash code of %T", v)
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	if !overridesHashCode(obj) {
+		return identityHashCode(obj), nil
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, obj, "hashCode", methTypeHashCode)
+	switch r := ret.(type) {
+	case int64:
+		return int32(r), nil
+	case *ghelpers.GErrBlk:
+		return 0, r
+	}
+	errMsg := fmt.Sprintf("javaHashCode: hashCode() returned %T, expected int", ret)
+	return 0, ghelpers.GetGErrBlk(excNames.ClassCastException, errMsg)
+}
+
+// resolve returns the Go value that indexes key, and the key's hash code. If the key's class overrides
+// hashCode(), the Go value is the key already held (if any) that equals() it; otherwise it is key itself,
+// and inBucket reports that add() must be called if the key is inserted.
+func (hk *hashKeys) resolve(fs *list.List, key any) (goKey any, hash int32, inBucket bool, gerr *ghelpers.GErrBlk) {
+	if vk, hash, ok := valueKey(key); ok {
+		return vk, hash, false, nil
+	}
+	obj, ok := key.(*object.Object)
+	if !ok {
+		errMsg := fmt.Sprintf("hashKeys.resolve: key is not an object, saw: %T", key)
+		return nil, 0, false, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	if !overridesHashCode(obj) {
+		return obj, identityHashCode(obj), false, nil
+	}
+
+	hash, gerr = javaHashCode(fs, obj)
+	if gerr != nil {
+		return nil, 0, false, gerr
+	}
+	for _, candidate := range hk.buckets[hash] {
+		if candidate == obj {
+			return candidate, hash, false, nil
+		}
+		equal, gerr := javaEquals(fs, obj, candidate)
+		if gerr != nil {
+			return nil, 0, false, gerr
+		}
+		if equal {
+			return candidate, hash, false, nil
+		}
+	}
+	return obj, hash, true, nil
+}
+
+// add records a newly inserted key that resolve() reported as inBucket.
+func (hk *hashKeys) add(goKey any, hash int32) {
+	if obj, ok := goKey.(*object.Object); ok {
+		hk.buckets[hash] = append(hk.buckets[hash], obj)
+	}
+}
+
+// forget drops a removed key from its bucket, if it is in one.
+func (hk *hashKeys) forget(goKey any, hash int32) {
+	obj, ok := goKey.(*object.Object)
+	if !ok {
+		return
+	}
+	bucket := hk.buckets[hash]
+	if ix := slices.Index(bucket, obj); ix >= 0 {
+		bucket = slices.Delete(bucket, ix, ix+1)
+		if len(bucket) == 0 {
+			delete(hk.buckets, hash)
+		} else {
+			hk.buckets[hash] = bucket
+		}
+	}
+}
+
+func (hk *hashKeys) clearKeys() {
+	hk.buckets = make(map[int32][]*object.Object)
+}
+
+// inserted records a key newly added to the Go map and grows the table as the JDK would.
+func (hs *hashState) inserted(goKey, key any, hash int32, inBucket bool, size int) {
+	if inBucket {
+		hs.add(goKey, hash)
+	}
+	hs.meta[goKey] = &hashMeta{key: key, hash: hash, seq: hs.nextSeq}
+	hs.nextSeq++
+	if hs.capacity == 0 {
+		hs.capacity = hs.initialCap
+	}
+	for hs.capacity < hashMaximumCapacity && float32(size) > float32(hs.capacity)*hs.loadFactor {
+		hs.capacity <<= 1
+	}
+}
+
+// removed forgets a key that has been deleted from the Go map.
+func (hs *hashState) removed(goKey any) {
+	if meta, ok := hs.meta[goKey]; ok {
+		hs.forget(goKey, meta.hash)
+		delete(hs.meta, goKey)
+	}
+}
+
+// cleared forgets all keys. As in the JDK, the table keeps its capacity.
+func (hs *hashState) cleared() {
+	hs.clearKeys()
+	hs.meta = make(map[any]*hashMeta)
+}
+
+// presize is what HashMap.putAll() does to an empty table before adding size entries.
+func (hs *hashState) presize(size int) {
+	if hs.capacity != 0 || size == 0 {
+		return
+	}
+	wanted := int(float32(size)/hs.loadFactor + 1.0)
+	if capacity := hashTableSizeFor(wanted); capacity > hs.initialCap {
+		hs.initialCap = capacity
+	}
+}
+
+// javaKey returns the Java object for goKey: the key originally inserted, if known.
+func (hs *hashState) javaKey(goKey any) any {
+	if meta, ok := hs.meta[goKey]; ok {
+		return meta.key
+	}
+	return goKeyToJavaObject(goKey)
+}
+
+// orderedKeys returns the Go keys of hm in the order the JDK's HashMap iterates them: by table bin,
+// and by insertion order within a bin.
+func (hs *hashState) orderedKeys(hm types.DefHashMap) []any {
+	type ordered struct {
+		goKey any
+		bin   int
+		seq   int64
+	}
+	capacity := max(hs.capacity, 1)
+	keys := make([]ordered, 0, len(hm))
+	for goKey := range hm {
+		entry := ordered{goKey: goKey, seq: math.MaxInt64}
+		hash := int32(0)
+		if meta, ok := hs.meta[goKey]; ok {
+			hash, entry.seq = meta.hash, meta.seq
+		} else if _, h, ok := valueKey(goKeyToJavaObject(goKey)); ok {
+			hash = h
+		}
+		spread := uint32(hash) ^ (uint32(hash) >> 16)
+		if !hs.keepsOrder {
+			entry.bin = int(spread & uint32(capacity-1))
+		}
+		keys = append(keys, entry)
+	}
+	slices.SortStableFunc(keys, func(a, b ordered) int {
+		if a.bin != b.bin {
+			return a.bin - b.bin
+		}
+		return compareInt64(a.seq, b.seq)
+	})
+	goKeys := make([]any, len(keys))
+	for ix, entry := range keys {
+		goKeys[ix] = entry.goKey
+	}
+	return goKeys
+}
+
+// --- helpers for getting at the Go state of HashMap and HashSet objects ---
+
+// getHashMap returns the Go map and hash state of a HashMap or HashSet (or a subclass of either).
+func getHashMap(obj *object.Object, caller string) (types.DefHashMap, *hashState, *ghelpers.GErrBlk) {
+	if obj == nil || object.IsNull(obj) {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": object is null")
+	}
+	hm, ok := obj.FieldTable[fieldNameMap].Fvalue.(types.DefHashMap)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(obj.KlassName)
+		errMsg := fmt.Sprintf("%s: expected %s or %s, got %s without a hash map", caller, classNameHashMap, classNameHashSet, className)
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	hs, ok := obj.FieldTable[fieldNameHashState].Fvalue.(*hashState)
+	if !ok { // the Go map was built directly, without hashmapInit()
+		hs = newHashState(hashDefaultCapacity, hashDefaultLoadFactor)
+		obj.FieldTable[fieldNameHashState] = object.Field{Ftype: types.RawGoPointer, Fvalue: hs}
+	}
+	return hm, hs, nil
+}
+
+// hashThis unpacks the frame stack (if present), the HashMap/Set object, its Go map and hash state.
+func hashThis(params []interface{}, minArgs int, caller string) (*list.List, []interface{}, types.DefHashMap, *hashState, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < minArgs {
+		errMsg := fmt.Sprintf("%s: requires %d parameters, got %d", caller, minArgs, len(args))
+		return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || this == nil {
+		return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.ClassCastException, caller+": 'this' is not an object")
+	}
+	hm, hs, gerr := getHashMap(this, caller)
+	return fs, args, hm, hs, gerr
+}
+
+// hashThisForUpdate is hashThis for the mutators, which are refused if the map or set is immutable.
+func hashThisForUpdate(params []interface{}, minArgs int, caller string) (*list.List, []interface{}, types.DefHashMap, *hashState, *ghelpers.GErrBlk) {
+	fs, args, hm, hs, gerr := hashThis(params, minArgs, caller)
+	if gerr == nil {
+		gerr = checkModifiable(args[0].(*object.Object), caller)
+	}
+	return fs, args, hm, hs, gerr
+}
+
+// hashEntries returns the entries of a HashMap, or the elements (as keys) of a HashSet, in iteration order.
+func hashEntries(obj *object.Object, hm types.DefHashMap, hs *hashState) []*treeEntry {
+	goKeys := hs.orderedKeys(hm)
+	entries := make([]*treeEntry, len(goKeys))
+	isSet := isHashSet(obj)
+	for ix, goKey := range goKeys {
+		if isSet {
+			entries[ix] = &treeEntry{key: hm[goKey], value: hm[goKey]}
+		} else {
+			entries[ix] = &treeEntry{key: hs.javaKey(goKey), value: hm[goKey]}
+		}
+	}
+	return entries
+}
+
+// isHashSet reports whether obj is a HashSet (whose Go map holds each element as its own value)
+// rather than a HashMap.
+func isHashSet(obj *object.Object) bool {
+	className := object.GoStringFromStringPoolIndex(obj.KlassName)
+	if className == classNameHashSet {
+		return true
+	}
+	if className == classNameHashMap {
+		return false
+	}
+	_, clName := ghelpers.FindInstanceMethod(obj, "add", "(Ljava/lang/Object;)Z")
+	return clName == classNameHashSet
+}
+
+// hashLookup finds key in a HashMap or HashSet, returning the Go key that indexes it.
+func hashLookup(fs *list.List, hm types.DefHashMap, hs *hashState, key any) (any, bool, *ghelpers.GErrBlk) {
+	goKey, _, _, gerr := hs.resolve(fs, key)
+	if gerr != nil {
+		return nil, false, gerr
+	}
+	_, ok := hm[goKey]
+	return goKey, ok, nil
+}
+
+// hashPut inserts or replaces key in a HashMap or HashSet, returning the previous value (or nil, false).
+func hashPut(fs *list.List, hm types.DefHashMap, hs *hashState, key, value any) (any, bool, *ghelpers.GErrBlk) {
+	goKey, hash, inBucket, gerr := hs.resolve(fs, key)
+	if gerr != nil {
+		return nil, false, gerr
+	}
+	hashmapMutex.Lock()
+	defer hashmapMutex.Unlock()
+	prev, exists := hm[goKey]
+	hm[goKey] = value
+	if !exists {
+		hs.inserted(goKey, key, hash, inBucket, len(hm))
+	}
+	return prev, exists, nil
+}
+
+// hashRemove removes key from a HashMap or HashSet, returning the value it had (or nil, false).
+func hashRemove(fs *list.List, hm types.DefHashMap, hs *hashState, key any) (any, bool, *ghelpers.GErrBlk) {
+	goKey, exists, gerr := hashLookup(fs, hm, hs, key)
+	if gerr != nil || !exists {
+		return nil, false, gerr
+	}
+	hashmapMutex.Lock()
+	defer hashmapMutex.Unlock()
+	prev := hm[goKey]
+	delete(hm, goKey)
+	hs.removed(goKey)
+	return prev, true, nil
+}
+
+// makeHashSetInOrder creates a HashSet holding elements that iterates them in the order given,
+// as the keySet() and entrySet() views of a map do.
+func makeHashSetInOrder(fs *list.List, elements []any) (*object.Object, *ghelpers.GErrBlk) {
+	set := object.MakeEmptyObjectWithClassName(&classNameHashSet)
+	hashmapInit([]any{set})
+	hm, hs, _ := getHashMap(set, "makeHashSetInOrder")
+	hs.keepsOrder = true
+	for _, elem := range elements {
+		if _, _, gerr := hashPut(fs, hm, hs, elem, elem); gerr != nil {
+			return nil, gerr
+		}
+	}
+	return set, nil
+}
+
+// --- Map.equals() and Map.hashCode() for any map Jacobin knows natively ---
+
+// mapLookup returns the value of key in mapObj, and whether mapObj contains key.
+func mapLookup(fs *list.List, mapObj *object.Object, key any) (any, bool, *ghelpers.GErrBlk) {
+	if tv, ok := mapObj.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		if isNullValue(key) && tv.store.comparator == nil {
+			return nil, false, nil
+		}
+		entry, gerr := tv.get(fs, key)
+		if gerr != nil || entry == nil {
+			return nil, false, gerr
+		}
+		return entry.value, true, nil
+	}
+	if lv, ok := mapObj.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		entry := lv.peek(key)
+		if entry == nil {
+			return nil, false, nil
+		}
+		return entry.value, true, nil
+	}
+	if hm, ok := mapObj.FieldTable[fieldNameMap].Fvalue.(types.DefHashMap); ok {
+		_, hs, _ := getHashMap(mapObj, "mapLookup")
+		goKey, exists, gerr := hashLookup(fs, hm, hs, key)
+		if gerr != nil || !exists {
+			return nil, false, gerr
+		}
+		return hm[goKey], true, nil
+	}
+
+	// A Map implemented in Java: ask it.
+	ret := ghelpers.InvokeMethodOnObject(fs, mapObj, "containsKey", "(Ljava/lang/Object;)Z", key)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return nil, false, gerr
+	}
+	if ret != types.JavaBoolTrue {
+		return nil, false, nil
+	}
+	ret = ghelpers.InvokeMethodOnObject(fs, mapObj, "get", "(Ljava/lang/Object;)Ljava/lang/Object;", key)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return nil, false, gerr
+	}
+	return ret, true, nil
+}
+
+// mapsEqual implements Map.equals(): same size, and every key of this maps to an equal value in other.
+func mapsEqual(fs *list.List, this, other *object.Object) (bool, *ghelpers.GErrBlk) {
+	if this == other {
+		return true, nil
+	}
+	if other == nil || object.IsNull(other) {
+		return false, nil
+	}
+	thisEntries, gerr := mapEntries(fs, this)
+	if gerr != nil {
+		return false, gerr
+	}
+	otherEntries, gerr := mapEntries(fs, other)
+	if gerr != nil {
+		if gerr.ExceptionType == excNames.UnsupportedOperationException {
+			return false, nil // not a Map
+		}
+		return false, gerr
+	}
+	if len(thisEntries) != len(otherEntries) {
+		return false, nil
+	}
+	for _, entry := range thisEntries {
+		value, found, gerr := mapLookup(fs, other, entry.key)
+		if gerr != nil {
+			if gerr.ExceptionType == excNames.ClassCastException || gerr.ExceptionType == excNames.NullPointerException {
+				return false, nil // as AbstractMap.equals() does
+			}
+			return false, gerr
+		}
+		if !found {
+			return false, nil
+		}
+		equal, gerr := javaEquals(fs, entry.value, value)
+		if gerr != nil || !equal {
+			return false, gerr
+		}
+	}
+	return true, nil
+}
+
+// mapHashCodeOf implements Map.hashCode(): the sum over all entries of key.hashCode() ^ value.hashCode().
+func mapHashCodeOf(fs *list.List, this *object.Object) (int32, *ghelpers.GErrBlk) {
+	entries, gerr := mapEntries(fs, this)
+	if gerr != nil {
+		return 0, gerr
+	}
+	sum := int32(0)
+	for _, entry := range entries {
+		keyHash, gerr := javaHashCode(fs, entry.key)
+		if gerr != nil {
+			return 0, gerr
+		}
+		valueHash, gerr := javaHashCode(fs, entry.value)
+		if gerr != nil {
+			return 0, gerr
+		}
+		sum += keyHash ^ valueHash
+	}
+	return sum, nil
+}
+
+// "java/util/Map.equals(Ljava/lang/Object;)Z" and the same method of the Map classes
+func mapEquals(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapEquals: missing parameters")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || this == nil {
+		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapEquals: 'this' is not an object")
+	}
+	other, _ := args[1].(*object.Object)
+	equal, gerr := mapsEqual(fs, this, other)
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(equal)
+}
+
+// "java/util/Map.hashCode()I" and the same method of the Map classes
+func mapHashCode(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapHashCode: missing 'this' parameter")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || this == nil {
+		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapHashCode: 'this' is not an object")
+	}
+	hash, gerr := mapHashCodeOf(fs, this)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(hash)
+}
@@ -0,0 +1,143 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/classloader"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strconv"
+	"testing"
+)
+
+// iterationOrder returns the keys of a HashMap, or the elements of a HashSet, as their iterator returns them.
+func iterationOrder(t *testing.T, coll *object.Object) []string {
+	t.Helper()
+	var got []string
+	iter := NewIterator(coll)
+	for iteratorHasNext([]interface{}{iter}) == types.JavaBoolTrue {
+		elem := iteratorNext([]interface{}{iter}).(*object.Object)
+		if object.IsStringObject(elem) {
+			got = append(got, object.GoStringFromStringObject(elem))
+		} else {
+			got = append(got, strconv.FormatInt(elem.FieldTable["value"].Fvalue.(int64), 10))
+		}
+	}
+	return got
+}
+
+func TestHashing_StringAndBoxedHashCodes(t *testing.T) {
+	globals.InitStringPool()
+
+	if got := javaStringHashCode("hello"); got != 99162322 {
+		t.Errorf(`"hello".hashCode(): expected 99162322, got %d`, got)
+	}
+	if got := javaStringHashCode("banana"); got != -1396355227 {
+		t.Errorf(`"banana".hashCode(): expected -1396355227, got %d`, got)
+	}
+	if got, _ := javaHashCode(nil, longObj(1<<32|5)); got != 4 {
+		t.Errorf("Long(0x100000005).hashCode(): expected 4, got %d", got)
+	}
+	if got, _ := javaHashCode(nil, object.Null); got != 0 {
+		t.Errorf("null hash code: expected 0, got %d", got)
+	}
+}
+
+func TestHashing_IntegerAndLongKeysAreDistinct(t *testing.T) {
+	globals.InitStringPool()
+	hm := newHashMapObj()
+	hmInit(t, hm)
+
+	hashmapPut([]interface{}{hm, intKey(1), strKey("int")})
+	hashmapPut([]interface{}{hm, longObj(1), strKey("long")})
+	hashmapPut([]interface{}{hm, intKey(1), strKey("int again")})
+
+	if size := hashmapSize([]interface{}{hm}).(int64); size != 2 {
+		t.Fatalf("expected size 2, got %d", size)
+	}
+	got := hashmapGet([]interface{}{hm, longObj(1)}).(*object.Object)
+	if object.GoStringFromStringObject(got) != "long" {
+		t.Errorf("get(1L): expected long, got %q", object.GoStringFromStringObject(got))
+	}
+}
+
+func TestHashing_NullKey(t *testing.T) {
+	globals.InitStringPool()
+	hm := newHashMapObj()
+	hmInit(t, hm)
+
+	hashmapPut([]interface{}{hm, object.Null, strKey("nothing")})
+	assertJavaBool(t, hashmapContainsKey([]interface{}{hm, object.Null}), types.JavaBoolTrue, "containsKey(null)")
+	if ret := hashmapRemove([]interface{}{hm, object.Null}); object.IsNull(ret) {
+		t.Errorf("remove(null) should return the previous value")
+	}
+	if size := hashmapSize([]interface{}{hm}).(int64); size != 0 {
+		t.Errorf("expected an empty map, got size %d", size)
+	}
+}
+
+func TestHashing_IterationOrderMatchesJDK(t *testing.T) {
+	globals.InitStringPool()
+
+	// "banana" falls in bin 0, and "apple" and "cherry" share bin 1, in insertion order
+	set := object.MakeEmptyObjectWithClassName(&classNameHashSet)
+	hmInit(t, set)
+	for _, s := range []string{"cherry", "apple", "banana"} {
+		hashsetAdd([]interface{}{set, strKey(s)})
+	}
+	got := iterationOrder(t, set)
+	if len(got) != 3 || got[0] != "banana" || got[1] != "cherry" || got[2] != "apple" {
+		t.Errorf("HashSet order: expected [banana cherry apple], got %v", got)
+	}
+
+	// 16 and 0 share bin 0 of the default table; once the table grows to 32 they no longer do
+	hm := newHashMapObj()
+	hmInit(t, hm)
+	hashmapPut([]interface{}{hm, intKey(16), object.Null})
+	hashmapPut([]interface{}{hm, intKey(0), object.Null})
+	if got := iterationOrder(t, hm); len(got) != 2 || got[0] != "16" || got[1] != "0" {
+		t.Errorf("order before resize: expected [16 0], got %v", got)
+	}
+	for i := int64(20); i > 0; i-- {
+		hashmapPut([]interface{}{hm, intKey(i), object.Null})
+	}
+	got = iterationOrder(t, hm)
+	if len(got) != 21 || got[0] != "0" || got[16] != "16" || got[20] != "20" {
+		t.Errorf("order after resize: expected ascending keys, got %v", got)
+	}
+}
+
+func TestHashing_MapEqualsAndHashCode(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+
+	hm := newHashMapObj()
+	hmInit(t, hm)
+	tm := newTreeMap(t, nil)
+	for _, k := range []int64{3, 1, 2} {
+		hashmapPut([]interface{}{hm, intKey(k), strKey("v")})
+	}
+	tmPutInts(t, tm, 1, 2, 3)
+
+	assertJavaBool(t, mapEquals([]interface{}{hm, tm}), types.JavaBoolTrue, "HashMap.equals(TreeMap)")
+	assertJavaBool(t, mapEquals([]interface{}{tm, hm}), types.JavaBoolTrue, "TreeMap.equals(HashMap)")
+	if mapHashCode([]interface{}{hm}) != mapHashCode([]interface{}{tm}) {
+		t.Errorf("equal maps should have equal hash codes")
+	}
+
+	hashmapPut([]interface{}{hm, intKey(3), strKey("w")})
+	assertJavaBool(t, mapEquals([]interface{}{hm, tm}), types.JavaBoolFalse, "maps with a different value")
+
+	// {"a"="b"}.hashCode() is "a".hashCode() ^ "b".hashCode() == 97 ^ 98
+	single := newHashMapObj()
+	hmInit(t, single)
+	hashmapPut([]interface{}{single, strKey("a"), strKey("b")})
+	if got := mapHashCode([]interface{}{single}).(int64); got != 3 {
+		t.Errorf(`{"a"="b"}.hashCode(): expected 3, got %d`, got)
+	}
+}
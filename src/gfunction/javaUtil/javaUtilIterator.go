@@ -37,8 +37,9 @@ func Load_Util_Iterator() {
 
 	ghelpers.MethodSignatures["java/util/Iterator.remove()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  iteratorRemove,
+			ParamSlots:   0,
+			GFunction:    iteratorRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Iterator.forEachRemaining(Ljava/util/function/Consumer;)V"] =
@@ -54,7 +55,7 @@ const (
 	iteratorLastReturnedIndexField = "lastReturnedIndex" // for ArrayList
 	iteratorNextNodeField          = "nextNode"          // for LinkedList
 	iteratorLastReturnedNodeField  = "lastReturnedNode"  // for LinkedList
-	iteratorMapKeysField           = "mapKeys"           // for HashMap/HashSet
+	iteratorElementsField          = "elements"          // for snapshot iterators (TreeSet, ArrayDeque, etc.)
 )
 
 func iteratorHasNext(params []interface{}) interface{} {
@@ -68,6 +69,11 @@ func iteratorHasNext(params []interface{}) interface{} {
 		return types.JavaBoolFalse
 	}
 
+	if elements, ok := self.FieldTable[iteratorElementsField].Fvalue.([]any); ok {
+		index := self.FieldTable[iteratorIndexField].Fvalue.(int64)
+		return object.JavaBooleanFromGoBoolean(index < int64(len(elements)))
+	}
+
 	className := object.GoStringFromStringPoolIndex(colObj.KlassName)
 
 	switch className {
@@ -91,15 +97,6 @@ func iteratorHasNext(params []interface{}) interface{} {
 		if nextNode != nil && nextNode != (*list.Element)(nil) {
 			return types.JavaBoolTrue
 		}
-	case "java/util/HashMap", "java/util/HashSet":
-		mapKeys, ok := self.FieldTable[iteratorMapKeysField].Fvalue.([]interface{})
-		if !ok {
-			return types.JavaBoolFalse
-		}
-		index := self.FieldTable[iteratorIndexField].Fvalue.(int64)
-		if index < int64(len(mapKeys)) {
-			return types.JavaBoolTrue
-		}
 	default:
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("iteratorHasNext: Unsupported collection type %s", className))
 	}
@@ -118,6 +115,16 @@ func iteratorNext(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "iteratorNext: No collection")
 	}
 
+	if elements, ok := self.FieldTable[iteratorElementsField].Fvalue.([]any); ok {
+		index := self.FieldTable[iteratorIndexField].Fvalue.(int64)
+		if index >= int64(len(elements)) {
+			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "iteratorNext: No more elements")
+		}
+		self.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: index + 1}
+		self.FieldTable[iteratorLastReturnedIndexField] = object.Field{Ftype: types.Int, Fvalue: index}
+		return elements[index]
+	}
+
 	className := object.GoStringFromStringPoolIndex(colObj.KlassName)
 
 	switch className {
@@ -151,42 +158,13 @@ func iteratorNext(params []interface{}) interface{} {
 		self.FieldTable[iteratorLastReturnedNodeField] = object.Field{Ftype: types.NonArrayObject, Fvalue: nextNode}
 		return val
 
-	case "java/util/HashMap", "java/util/HashSet":
-		mapKeys, ok := self.FieldTable[iteratorMapKeysField].Fvalue.([]interface{})
-		if !ok {
-			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "iteratorNext: No more elements")
-		}
-		index := self.FieldTable[iteratorIndexField].Fvalue.(int64)
-		if index >= int64(len(mapKeys)) {
-			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "iteratorNext: No more elements")
-		}
-		key := mapKeys[index]
-		self.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: index + 1}
-
-		fld := colObj.FieldTable["map"]
-		hm := fld.Fvalue.(types.DefHashMap)
-		val := hm[key]
-
-		// For HashSet, the value in the map is the original object.
-		if className == "java/util/HashSet" {
-			return val
-		}
-
-		// For HashMap.iterator() (if used), we return the key.
-		// Note: Key in mapKeys for HashMap is the Go-level key (string or object)
-		if keyObj, ok := key.(*object.Object); ok {
-			return keyObj
-		}
-		if s, ok := key.(string); ok {
-			return object.StringObjectFromGoString(s)
-		}
-		return key
 	}
 
 	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("iteratorNext: Unsupported collection type %s", className))
 }
 
 func iteratorRemove(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
 	self, ok := params[0].(*object.Object)
 	if !ok {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "iteratorRemove: Invalid self argument")
@@ -197,6 +175,22 @@ func iteratorRemove(params []interface{}) interface{} {
 		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "iteratorRemove: No collection")
 	}
 
+	// Snapshot iterators remove the last element returned from the underlying collection.
+	if elements, ok := self.FieldTable[iteratorElementsField].Fvalue.([]any); ok {
+		lastIdx := self.FieldTable[iteratorLastReturnedIndexField].Fvalue.(int64)
+		if lastIdx < 0 {
+			return ghelpers.GetGErrBlk(excNames.IllegalStateException, "iteratorRemove: next() has not been called, or remove() has already been called")
+		}
+		if gerr := orderedRemove(fs, colObj, elements[lastIdx]); gerr != nil {
+			return gerr
+		}
+		self.FieldTable[iteratorLastReturnedIndexField] = object.Field{Ftype: types.Int, Fvalue: int64(-1)}
+		return nil
+	}
+
+	if gerr := checkModifiable(colObj, "Iterator.remove"); gerr != nil {
+		return gerr
+	}
 	className := object.GoStringFromStringPoolIndex(colObj.KlassName)
 
 	switch className {
@@ -247,9 +241,6 @@ func iteratorRemove(params []interface{}) interface{} {
 		llst.Remove(lastNode)
 		self.FieldTable[iteratorLastReturnedNodeField] = object.Field{Ftype: types.NonArrayObject, Fvalue: nil}
 
-	case "java/util/HashMap", "java/util/HashSet":
-		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "iteratorRemove: Not supported for maps")
-
 	default:
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("iteratorRemove: Unsupported collection type %s", className))
 	}
@@ -258,6 +249,10 @@ func iteratorRemove(params []interface{}) interface{} {
 }
 
 func NewIterator(collection *object.Object) *object.Object {
+	if elements, ok, gerr := orderedElements(nil, collection); ok && gerr == nil {
+		return newSnapshotIterator(collection, elements)
+	}
+
 	iterClassName := "java/util/Iterator"
 	o := object.MakeEmptyObjectWithClassName(&iterClassName)
 	o.FieldTable[iteratorCollectionField] = object.Field{Ftype: types.NonArrayObject, Fvalue: collection}
@@ -275,16 +270,19 @@ func NewIterator(collection *object.Object) *object.Object {
 		} else {
 			o.FieldTable[iteratorNextNodeField] = object.Field{Ftype: types.NonArrayObject, Fvalue: nil}
 		}
-	case "java/util/HashMap", "java/util/HashSet":
-		o.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
-		fld := collection.FieldTable["map"]
-		if hm, ok := fld.Fvalue.(types.DefHashMap); ok {
-			keys := make([]interface{}, 0, len(hm))
-			for k := range hm {
-				keys = append(keys, k)
-			}
-			o.FieldTable[iteratorMapKeysField] = object.Field{Ftype: types.RefArray, Fvalue: keys}
-		}
 	}
 	return o
 }
+
+// newSnapshotIterator returns an iterator over a snapshot of the elements of collection, which is
+// one of the collections handled by orderedElements(). Its remove() removes the last element
+// returned from the collection itself.
+func newSnapshotIterator(collection *object.Object, elements []any) *object.Object {
+	iterClassName := "java/util/Iterator"
+	o := object.MakeEmptyObjectWithClassName(&iterClassName)
+	o.FieldTable[iteratorCollectionField] = object.Field{Ftype: types.NonArrayObject, Fvalue: collection}
+	o.FieldTable[iteratorElementsField] = object.Field{Ftype: types.RefArray, Fvalue: elements}
+	o.FieldTable[iteratorIndexField] = object.Field{Ftype: types.Int, Fvalue: int64(0)}
+	o.FieldTable[iteratorLastReturnedIndexField] = object.Field{Ftype: types.Int, Fvalue: int64(-1)}
+	return o
+}
@@ -0,0 +1,700 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+	"strings"
+)
+
+// java.util.jar.Manifest, Attributes and Attributes.Name. An Attributes keeps its names and
+// values in insertion order, as the JDK's LinkedHashMap does, and matches names without regard
+// to case. A Manifest holds its main Attributes and a LinkedHashMap of the Attributes of its
+// named sections, which getEntries() returns, so that changes to the map are written out.
+//
+// Manifests are read and written as the JDK does: lines end with CR LF when written, and lines
+// longer than 72 bytes are continued on lines that begin with a space.
+
+const (
+	manifestClassName       = "java/util/jar/Manifest"
+	attributesClassName     = "java/util/jar/Attributes"
+	attributesNameClassName = "java/util/jar/Attributes$Name"
+	attributesNameField     = "name"
+
+	manifestLineLen    = 72
+	manifestMaxLineLen = 512
+)
+
+// the constants of Attributes.Name
+var attributesNames = map[string]string{
+	"CLASS_PATH":             "Class-Path",
+	"CONTENT_TYPE":           "Content-Type",
+	"EXTENSION_LIST":         "Extension-List",
+	"EXTENSION_NAME":         "Extension-Name",
+	"IMPLEMENTATION_TITLE":   "Implementation-Title",
+	"IMPLEMENTATION_VENDOR":  "Implementation-Vendor",
+	"IMPLEMENTATION_VERSION": "Implementation-Version",
+	"LAUNCHER_AGENT_CLASS":   "Launcher-Agent-Class",
+	"MAIN_CLASS":             "Main-Class",
+	"MANIFEST_VERSION":       "Manifest-Version",
+	"MULTI_RELEASE":          "Multi-Release",
+	"SEALED":                 "Sealed",
+	"SIGNATURE_VERSION":      "Signature-Version",
+	"SPECIFICATION_TITLE":    "Specification-Title",
+	"SPECIFICATION_VENDOR":   "Specification-Vendor",
+	"SPECIFICATION_VERSION":  "Specification-Version",
+}
+
+func Load_Util_Jar_Manifest() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                       {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                         {ParamSlots: 0, GFunction: manifestInit},
+		"<init>(Ljava/io/InputStream;)V":    {ParamSlots: 1, GFunction: manifestInitRead, NeedsContext: true},
+		"<init>(Ljava/util/jar/Manifest;)V": {ParamSlots: 1, GFunction: manifestInitCopy},
+		"clear()V":                          {ParamSlots: 0, GFunction: manifestClear},
+		"getAttributes(Ljava/lang/String;)Ljava/util/jar/Attributes;": {ParamSlots: 1,
+			GFunction: manifestGetAttributes},
+		"getEntries()Ljava/util/Map;":                   {ParamSlots: 0, GFunction: manifestGetEntries},
+		"getMainAttributes()Ljava/util/jar/Attributes;": {ParamSlots: 0, GFunction: manifestGetMainAttributes},
+		"read(Ljava/io/InputStream;)V":                  {ParamSlots: 1, GFunction: manifestRead, NeedsContext: true},
+		"write(Ljava/io/OutputStream;)V":                {ParamSlots: 1, GFunction: manifestWrite, NeedsContext: true},
+	} {
+		ghelpers.MethodSignatures[manifestClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                         {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":                           {ParamSlots: 0, GFunction: attributesInit},
+		"<init>(I)V":                          {ParamSlots: 1, GFunction: attributesInit},
+		"<init>(Ljava/util/jar/Attributes;)V": {ParamSlots: 1, GFunction: attributesInitCopy},
+		"clear()V":                            {ParamSlots: 0, GFunction: attributesClear},
+		"containsKey(Ljava/lang/Object;)Z":    {ParamSlots: 1, GFunction: attributesContainsKey},
+		"get(Ljava/lang/Object;)Ljava/lang/Object;":      {ParamSlots: 1, GFunction: attributesGetValue},
+		"getValue(Ljava/lang/String;)Ljava/lang/String;": {ParamSlots: 1, GFunction: attributesGetValue},
+		"getValue(Ljava/util/jar/Attributes$Name;)Ljava/lang/String;": {ParamSlots: 1,
+			GFunction: attributesGetValue},
+		"isEmpty()Z": {ParamSlots: 0, GFunction: attributesIsEmpty},
+		"put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;": {ParamSlots: 2,
+			GFunction: attributesPut},
+		"putValue(Ljava/lang/String;Ljava/lang/String;)Ljava/lang/String;": {ParamSlots: 2,
+			GFunction: attributesPut},
+		"remove(Ljava/lang/Object;)Ljava/lang/Object;": {ParamSlots: 1, GFunction: attributesRemove},
+		"size()I": {ParamSlots: 0, GFunction: attributesSize},
+	} {
+		ghelpers.MethodSignatures[attributesClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                  {ParamSlots: 0, GFunction: attributesNameClinit},
+		"<init>(Ljava/lang/String;)V":  {ParamSlots: 1, GFunction: attributesNameInit},
+		"equals(Ljava/lang/Object;)Z":  {ParamSlots: 1, GFunction: attributesNameEquals},
+		"hashCode()I":                  {ParamSlots: 0, GFunction: attributesNameHashCode},
+		"toString()Ljava/lang/String;": {ParamSlots: 0, GFunction: attributesNameToString},
+	} {
+		ghelpers.MethodSignatures[attributesNameClassName+"."+sig] = gmeth
+	}
+}
+
+// --- Attributes.Name ---
+
+// validAttributeName reports whether name is a valid attribute name: 1 to 70 letters, digits,
+// hyphens and underscores.
+func validAttributeName(name string) bool {
+	if len(name) == 0 || len(name) > 70 {
+		return false
+	}
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func newAttributesName(name string) *object.Object {
+	className := attributesNameClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[attributesNameField] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	return obj
+}
+
+func attributesNameClinit([]interface{}) interface{} {
+	for field, name := range attributesNames {
+		_ = statics.AddStatic(attributesNameClassName+"."+field,
+			statics.Static{Type: "L" + attributesNameClassName + ";", Value: newAttributesName(name)})
+	}
+	return nil
+}
+
+// java/util/jar/Attributes$Name.<init>(Ljava/lang/String;)V
+func attributesNameInit(params []interface{}) interface{} {
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Attributes.Name: name is null")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	if !validAttributeName(name) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, name)
+	}
+	params[0].(*object.Object).FieldTable[attributesNameField] = object.Field{Ftype: types.StringClassRef, Fvalue: nameObj}
+	return nil
+}
+
+func attributesNameToString(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable[attributesNameField].Fvalue
+}
+
+// java/util/jar/Attributes$Name.equals(Ljava/lang/Object;)Z -- names are equal regardless of case
+func attributesNameEquals(params []interface{}) interface{} {
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) || other.KlassName != params[0].(*object.Object).KlassName {
+		return types.JavaBoolFalse
+	}
+	name, _ := attributeKey(params[0])
+	otherName, _ := attributeKey(other)
+	return types.ConvertGoBoolToJavaBool(strings.EqualFold(name, otherName))
+}
+
+func attributesNameHashCode(params []interface{}) interface{} {
+	name, _ := attributeKey(params[0])
+	return int64(javaStringHashCode(strings.ToLower(name)))
+}
+
+// attributeKey returns the attribute name that key, an Attributes.Name or a String, stands for.
+func attributeKey(key any) (string, bool) {
+	obj, ok := key.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return "", false
+	}
+	if object.IsStringObject(obj) {
+		return object.GoStringFromStringObject(obj), true
+	}
+	nameObj, ok := obj.FieldTable[attributesNameField].Fvalue.(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return "", false
+	}
+	return object.GoStringFromStringObject(nameObj), true
+}
+
+// --- Attributes ---
+
+// attributes is the Go state of an Attributes.
+type attributes struct {
+	names  []string          // in insertion order, as first put
+	values map[string]string // by lower-case name
+}
+
+func newAttributesState() *attributes {
+	return &attributes{values: make(map[string]string)}
+}
+
+func (a *attributes) get(name string) (string, bool) {
+	value, ok := a.values[strings.ToLower(name)]
+	return value, ok
+}
+
+func (a *attributes) put(name, value string) (string, bool) {
+	key := strings.ToLower(name)
+	prev, existed := a.values[key]
+	if !existed {
+		a.names = append(a.names, name)
+	}
+	a.values[key] = value
+	return prev, existed
+}
+
+func (a *attributes) remove(name string) (string, bool) {
+	key := strings.ToLower(name)
+	prev, existed := a.values[key]
+	if existed {
+		delete(a.values, key)
+		for ix, n := range a.names {
+			if strings.ToLower(n) == key {
+				a.names = append(a.names[:ix], a.names[ix+1:]...)
+				break
+			}
+		}
+	}
+	return prev, existed
+}
+
+func (a *attributes) copy() *attributes {
+	c := newAttributesState()
+	for _, name := range a.names {
+		value, _ := a.get(name)
+		c.put(name, value)
+	}
+	return c
+}
+
+func newAttributes(a *attributes) *object.Object {
+	className := attributesClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: a}
+	return obj
+}
+
+func getAttributes(obj *object.Object) (*attributes, *ghelpers.GErrBlk) {
+	a, ok := obj.FieldTable[zipStateField].Fvalue.(*attributes)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "Attributes is not initialized")
+	}
+	return a, nil
+}
+
+// java/util/jar/Attributes.<init>()V and <init>(I)V
+func attributesInit(params []interface{}) interface{} {
+	params[0].(*object.Object).FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: newAttributesState()}
+	return nil
+}
+
+// java/util/jar/Attributes.<init>(Ljava/util/jar/Attributes;)V
+func attributesInitCopy(params []interface{}) interface{} {
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Attributes: attributes is null")
+	}
+	a, gerr := getAttributes(other)
+	if gerr != nil {
+		return gerr
+	}
+	params[0].(*object.Object).FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: a.copy()}
+	return nil
+}
+
+// java/util/jar/Attributes.getValue(Ljava/lang/String;)Ljava/lang/String;, the variant with an
+// Attributes.Name, and get(Ljava/lang/Object;)Ljava/lang/Object;
+func attributesGetValue(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	name, ok := attributeKey(params[1])
+	if !ok {
+		return object.Null
+	}
+	if value, ok := a.get(name); ok {
+		return object.StringObjectFromGoString(value)
+	}
+	return object.Null
+}
+
+// java/util/jar/Attributes.putValue(Ljava/lang/String;Ljava/lang/String;)Ljava/lang/String; and
+// put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object; -- returns the previous value
+func attributesPut(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	name, ok := attributeKey(params[1])
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Attributes.put: name is null")
+	}
+	if !validAttributeName(name) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, name)
+	}
+	valueObj, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(valueObj) || !object.IsStringObject(valueObj) {
+		return ghelpers.GetGErrBlk(excNames.ClassCastException, "Attributes.put: value is not a String")
+	}
+	if prev, existed := a.put(name, object.GoStringFromStringObject(valueObj)); existed {
+		return object.StringObjectFromGoString(prev)
+	}
+	return object.Null
+}
+
+// java/util/jar/Attributes.remove(Ljava/lang/Object;)Ljava/lang/Object;
+func attributesRemove(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	name, ok := attributeKey(params[1])
+	if !ok {
+		return object.Null
+	}
+	if prev, existed := a.remove(name); existed {
+		return object.StringObjectFromGoString(prev)
+	}
+	return object.Null
+}
+
+func attributesContainsKey(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	name, ok := attributeKey(params[1])
+	if !ok {
+		return types.JavaBoolFalse
+	}
+	_, exists := a.get(name)
+	return types.ConvertGoBoolToJavaBool(exists)
+}
+
+func attributesSize(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(a.names))
+}
+
+func attributesIsEmpty(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(len(a.names) == 0)
+}
+
+func attributesClear(params []interface{}) interface{} {
+	a, gerr := getAttributes(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	*a = *newAttributesState()
+	return nil
+}
+
+// --- Manifest ---
+
+// manifest is the Go state of a Manifest.
+type manifest struct {
+	main    *object.Object // Attributes
+	entries *object.Object // LinkedHashMap of section name to Attributes
+}
+
+func newManifestState() *manifest {
+	entries := object.MakeEmptyObjectWithClassName(&classNameLinkedHashMap)
+	setLinkedView(entries, &linkedView{linkedStore: newLinkedStore(false)})
+	return &manifest{main: newAttributes(newAttributesState()), entries: entries}
+}
+
+func newManifest(m *manifest) *object.Object {
+	className := manifestClassName
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: m}
+	return obj
+}
+
+func getManifest(obj *object.Object) (*manifest, *ghelpers.GErrBlk) {
+	m, ok := obj.FieldTable[zipStateField].Fvalue.(*manifest)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "Manifest is not initialized")
+	}
+	return m, nil
+}
+
+// section returns the Attributes of the section name, adding the section if create is set.
+func (m *manifest) section(name string, create bool) *object.Object {
+	lv, _ := getLinkedView(m.entries, "Manifest")
+	key := object.StringObjectFromGoString(name)
+	if entry := lv.peek(key); entry != nil {
+		if attrs, ok := entry.value.(*object.Object); ok {
+			return attrs
+		}
+	}
+	if !create {
+		return nil
+	}
+	attrs := newAttributes(newAttributesState())
+	lv.put(key, attrs, false)
+	return attrs
+}
+
+// sections returns the names and Attributes of the sections, in order.
+func (m *manifest) sections() ([]string, []*attributes) {
+	lv, _ := getLinkedView(m.entries, "Manifest")
+	var names []string
+	var attrs []*attributes
+	for _, entry := range lv.snapshot() {
+		name, ok := entry.key.(*object.Object)
+		value, ok2 := entry.value.(*object.Object)
+		if !ok || !ok2 || object.IsNull(name) || object.IsNull(value) {
+			continue
+		}
+		if a, gerr := getAttributes(value); gerr == nil {
+			names = append(names, object.GoStringFromStringObject(name))
+			attrs = append(attrs, a)
+		}
+	}
+	return names, attrs
+}
+
+// read adds what the manifest in r holds to m, as Manifest.read does.
+func (m *manifest) read(r io.Reader) *ghelpers.GErrBlk {
+	br := bufio.NewReader(r)
+	lineNumber := 0
+	// readSection reads header lines up to a blank line or the end of the input, joining
+	// continuation lines. It returns io.EOF if there is nothing more to read.
+	readSection := func() ([][2]string, error) {
+		var headers [][2]string
+		var current []byte
+		flush := func() error {
+			if current == nil {
+				return nil
+			}
+			name, value, found := bytes.Cut(current, []byte(": "))
+			if !found {
+				return fmt.Errorf("invalid header field (line %d)", lineNumber)
+			}
+			headers = append(headers, [2]string{string(name), string(value)})
+			current = nil
+			return nil
+		}
+		readAny := false
+		for {
+			line, err := br.ReadBytes('\n')
+			if len(line) == 0 && err == io.EOF {
+				if !readAny {
+					return nil, io.EOF
+				}
+				return headers, flush()
+			}
+			readAny = true
+			lineNumber++
+			if err == io.EOF || len(line) > manifestMaxLineLen {
+				return nil, fmt.Errorf("line too long (line %d)", lineNumber)
+			}
+			if err != nil {
+				return nil, err
+			}
+			line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+			switch {
+			case len(line) == 0:
+				return headers, flush()
+			case line[0] == ' ':
+				if current == nil {
+					return nil, fmt.Errorf("misplaced continuation line (line %d)", lineNumber)
+				}
+				current = append(current, line[1:]...)
+			default:
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				current = append([]byte{}, line...)
+			}
+		}
+	}
+	ioError := func(err error) *ghelpers.GErrBlk {
+		return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+	}
+
+	mainHeaders, err := readSection()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return ioError(err)
+	}
+	main, _ := getAttributes(m.main)
+	for _, header := range mainHeaders {
+		if !validAttributeName(header[0]) {
+			return ioError(fmt.Errorf("invalid header field name: %s", header[0]))
+		}
+		main.put(header[0], header[1])
+	}
+
+	for {
+		headers, err := readSection()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return ioError(err)
+		}
+		if len(headers) == 0 {
+			continue
+		}
+		if !strings.EqualFold(headers[0][0], "Name") {
+			return ioError(errors.New("invalid manifest format"))
+		}
+		attrs, _ := getAttributes(m.section(headers[0][1], true))
+		for _, header := range headers[1:] {
+			if !validAttributeName(header[0]) {
+				return ioError(fmt.Errorf("invalid header field name: %s", header[0]))
+			}
+			attrs.put(header[0], header[1])
+		}
+	}
+}
+
+// write writes m to w as Manifest.write does. If the main section has no Manifest-Version
+// (or Signature-Version), none of its attributes are written.
+func (m *manifest) write(w io.Writer) error {
+	var buf bytes.Buffer
+	main, _ := getAttributes(m.main)
+	versionName := "Manifest-Version"
+	version, ok := main.get(versionName)
+	if !ok {
+		versionName = "Signature-Version"
+		version, ok = main.get(versionName)
+	}
+	if ok {
+		writeManifestLine(&buf, versionName+": "+version)
+		for _, name := range main.names {
+			if !strings.EqualFold(name, versionName) {
+				value, _ := main.get(name)
+				writeManifestLine(&buf, name+": "+value)
+			}
+		}
+	}
+	buf.WriteString("\r\n")
+
+	names, sections := m.sections()
+	for ix, name := range names {
+		writeManifestLine(&buf, "Name: "+name)
+		for _, attrName := range sections[ix].names {
+			value, _ := sections[ix].get(attrName)
+			writeManifestLine(&buf, attrName+": "+value)
+		}
+		buf.WriteString("\r\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeManifestLine writes line in UTF-8, broken into lines of at most 72 bytes, where each
+// continuation line begins with a space. Lines are broken between bytes, as the JDK breaks them.
+func writeManifestLine(buf *bytes.Buffer, line string) {
+	b := []byte(line)
+	if len(b) > 0 {
+		buf.WriteByte(b[0])
+		pos := 1
+		for len(b)-pos > manifestLineLen-1 {
+			buf.Write(b[pos : pos+manifestLineLen-1])
+			pos += manifestLineLen - 1
+			buf.WriteString("\r\n ")
+		}
+		buf.Write(b[pos:])
+	}
+	buf.WriteString("\r\n")
+}
+
+// java/util/jar/Manifest.<init>()V
+func manifestInit(params []interface{}) interface{} {
+	params[0].(*object.Object).FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: newManifestState()}
+	return nil
+}
+
+// java/util/jar/Manifest.<init>(Ljava/io/InputStream;)V
+func manifestInitRead(params []interface{}) interface{} {
+	_, args := ghelpers.SplitContext(params)
+	manifestInit(args)
+	return manifestRead(params)
+}
+
+// java/util/jar/Manifest.<init>(Ljava/util/jar/Manifest;)V -- a deep copy
+func manifestInitCopy(params []interface{}) interface{} {
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Manifest: manifest is null")
+	}
+	src, gerr := getManifest(other)
+	if gerr != nil {
+		return gerr
+	}
+	m := newManifestState()
+	main, _ := getAttributes(src.main)
+	m.main = newAttributes(main.copy())
+	names, sections := src.sections()
+	for ix, name := range names {
+		attrs, _ := getAttributes(m.section(name, true))
+		*attrs = *sections[ix].copy()
+	}
+	params[0].(*object.Object).FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: m}
+	return nil
+}
+
+// java/util/jar/Manifest.read(Ljava/io/InputStream;)V -- adds to what the Manifest holds
+func manifestRead(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "Manifest.read")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr = m.read(r); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/jar/Manifest.write(Ljava/io/OutputStream;)V
+func manifestWrite(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	w, gerr := ghelpers.GoWriterWithContext(fs, params[1], "Manifest.write")
+	if gerr != nil {
+		return gerr
+	}
+	if err := m.write(w); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Manifest.write: "+err.Error())
+	}
+	if gerr = flushJavaWriter(w); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func manifestGetMainAttributes(params []interface{}) interface{} {
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return m.main
+}
+
+func manifestGetEntries(params []interface{}) interface{} {
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return m.entries
+}
+
+// java/util/jar/Manifest.getAttributes(Ljava/lang/String;)Ljava/util/jar/Attributes; -- null if
+// there is no section of that name
+func manifestGetAttributes(params []interface{}) interface{} {
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return object.Null
+	}
+	if attrs := m.section(object.GoStringFromStringObject(nameObj), false); attrs != nil {
+		return attrs
+	}
+	return object.Null
+}
+
+func manifestClear(params []interface{}) interface{} {
+	m, gerr := getManifest(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	*m = *newManifestState()
+	return nil
+}
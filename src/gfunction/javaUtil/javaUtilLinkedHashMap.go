@@ -0,0 +1,938 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+)
+
+// LinkedHashMap and LinkedHashSet keep their entries in a Go doubly linked list, in insertion
+// order or (for a LinkedHashMap created with accessOrder == true) in access order, plus an index
+// from key to list element. Keys that are strings or boxed primitives are matched by value; other
+// keys are matched by identity.
+//
+// After a new key is inserted, LinkedHashMap calls removeEldestEntry() if a user subclass
+// overrides it, so that LRU caches built in the usual way work as they do in the JDK.
+
+var classNameLinkedHashMap = "java/util/LinkedHashMap"
+var classNameLinkedHashSet = "java/util/LinkedHashSet"
+var fieldNameLinkedMap = "linkedMap"
+
+const methTypeRemoveEldestEntry = "(Ljava/util/Map$Entry;)Z"
+
+// linkedStore holds the entries of a LinkedHashMap or LinkedHashSet.
+type linkedStore struct {
+	index       map[any]*list.Element // element values are *treeEntry
+	order       *list.List
+	accessOrder bool
+}
+
+// linkedView is the Go state held by each LinkedHashMap or LinkedHashSet object. reversed() returns
+// an object that shares the store of the original but presents its entries in reverse order.
+type linkedView struct {
+	*linkedStore
+	reversed bool
+}
+
+func Load_Util_LinkedHashMap() {
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<init>(I)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<init>(IF)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<init>(IFZ)V"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.<init>(Ljava/util/Map;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapInitFromMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedClear}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.clone()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedClone}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.compute(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedCompute, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.computeIfAbsent(Ljava/lang/Object;Ljava/util/function/Function;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedComputeIfAbsent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.computeIfPresent(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedComputeIfPresent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.containsKey(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapContainsKey}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.containsValue(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapContainsValue, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.entrySet()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapEntrySet}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.equals(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: mapEquals, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.firstEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapFirstEntry}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.forEach(Ljava/util/function/BiConsumer;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapForEach, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.get(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapGet}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.getOrDefault(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapGetOrDefault}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.hashCode()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: mapHashCode, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedIsEmpty}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.keySet()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapKeySet}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.lastEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapLastEntry}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.merge(Ljava/lang/Object;Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: orderedMerge, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.pollFirstEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapPollFirstEntry}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.pollLastEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapPollLastEntry}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapPut, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.putAll(Ljava/util/Map;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapPutAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.putFirst(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapPutFirst}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.putIfAbsent(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapPutIfAbsent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.putLast(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapPutLast}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapRemove}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.removeEldestEntry(Ljava/util/Map$Entry;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapRemoveEldestEntry}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.replace(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapReplace}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.replaceAll(Ljava/util/function/BiFunction;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: orderedReplaceAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.reversed()Ljava/util/SequencedMap;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedReversed}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.sequencedEntrySet()Ljava/util/SequencedSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapEntrySet}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.sequencedKeySet()Ljava/util/SequencedSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapKeySet}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.sequencedValues()Ljava/util/SequencedCollection;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapValues}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedSize}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapToString, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashMap.values()Ljava/util/Collection;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapValues}
+
+	// LinkedHashSet
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.<init>(I)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.<init>(IF)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: linkedhashmapInit}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.<init>(Ljava/util/Collection;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.add(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetAdd}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.addAll(Ljava/util/Collection;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetAddAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.addFirst(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetAddFirst}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.addLast(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetAddLast}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedClear}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.clone()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedClone}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.contains(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashmapContainsKey}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.getFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashsetGetFirst}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.getLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashsetGetLast}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedIsEmpty}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.iterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: setIterator}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.remove(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: linkedhashsetRemove}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.removeFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashsetRemoveFirst}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.removeLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashsetRemoveLast}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.reversed()Ljava/util/SequencedSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedReversed}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedSize}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.spliterator()Ljava/util/Spliterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+
+	ghelpers.MethodSignatures["java/util/LinkedHashSet.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: linkedhashsetToString, NeedsContext: true}
+}
+
+// --- linkedStore mechanics ---
+
+func newLinkedStore(accessOrder bool) *linkedStore {
+	return &linkedStore{index: make(map[any]*list.Element), order: list.New(), accessOrder: accessOrder}
+}
+
+// linkedKey returns the Go value used to index key: strings and boxed primitives are matched by
+// value, the way HashMap matches them, and other keys by identity.
+func linkedKey(key any) any {
+	if vk, _, ok := valueKey(key); ok {
+		return vk
+	}
+	return key
+}
+
+func (lv *linkedView) get(key any) *treeEntry {
+	elem, ok := lv.index[linkedKey(key)]
+	if !ok {
+		return nil
+	}
+	if lv.accessOrder {
+		lv.order.MoveToBack(elem)
+	}
+	return elem.Value.(*treeEntry)
+}
+
+// peek returns the entry for key, or nil, without counting as an access.
+func (lv *linkedView) peek(key any) *treeEntry {
+	elem, ok := lv.index[linkedKey(key)]
+	if !ok {
+		return nil
+	}
+	return elem.Value.(*treeEntry)
+}
+
+func (lv *linkedView) contains(key any) bool {
+	_, ok := lv.index[linkedKey(key)]
+	return ok
+}
+
+// put inserts or updates key. New keys go at the end of the encounter order (the front if atFront is
+// set). It returns the previous entry, or nil if the key is new.
+func (lv *linkedView) put(key, value any, atFront bool) *treeEntry {
+	if lv.reversed {
+		atFront = !atFront
+	}
+	ik := linkedKey(key)
+	if elem, ok := lv.index[ik]; ok {
+		entry := elem.Value.(*treeEntry)
+		prev := &treeEntry{key: entry.key, value: entry.value}
+		entry.value = value
+		if lv.accessOrder {
+			lv.order.MoveToBack(elem)
+		}
+		return prev
+	}
+	entry := &treeEntry{key: key, value: value}
+	if atFront {
+		lv.index[ik] = lv.order.PushFront(entry)
+	} else {
+		lv.index[ik] = lv.order.PushBack(entry)
+	}
+	return nil
+}
+
+// reposition moves an existing key to the front or back, as putFirst() and putLast() and the
+// SequencedSet addFirst() and addLast() methods do.
+func (lv *linkedView) reposition(key any, toFront bool) {
+	if lv.reversed {
+		toFront = !toFront
+	}
+	if elem, ok := lv.index[linkedKey(key)]; ok {
+		if toFront {
+			lv.order.MoveToFront(elem)
+		} else {
+			lv.order.MoveToBack(elem)
+		}
+	}
+}
+
+func (lv *linkedView) remove(key any) *treeEntry {
+	ik := linkedKey(key)
+	elem, ok := lv.index[ik]
+	if !ok {
+		return nil
+	}
+	delete(lv.index, ik)
+	return lv.order.Remove(elem).(*treeEntry)
+}
+
+func (lv *linkedView) clear() {
+	lv.index = make(map[any]*list.Element)
+	lv.order.Init()
+}
+
+// end returns the first (last == false) or last entry in encounter order, or nil if empty.
+func (lv *linkedView) end(last bool) *treeEntry {
+	var elem *list.Element
+	if last != lv.reversed {
+		elem = lv.order.Back()
+	} else {
+		elem = lv.order.Front()
+	}
+	if elem == nil {
+		return nil
+	}
+	return elem.Value.(*treeEntry)
+}
+
+// snapshot returns the entries in encounter order.
+func (lv *linkedView) snapshot() []*treeEntry {
+	entries := make([]*treeEntry, 0, lv.order.Len())
+	if lv.reversed {
+		for elem := lv.order.Back(); elem != nil; elem = elem.Prev() {
+			entries = append(entries, elem.Value.(*treeEntry))
+		}
+	} else {
+		for elem := lv.order.Front(); elem != nil; elem = elem.Next() {
+			entries = append(entries, elem.Value.(*treeEntry))
+		}
+	}
+	return entries
+}
+
+func (lv *linkedView) keys() []any {
+	entries := lv.snapshot()
+	keys := make([]any, len(entries))
+	for ix, entry := range entries {
+		keys[ix] = entry.key
+	}
+	return keys
+}
+
+// --- helpers for getting at the Go state of LinkedHashMap and LinkedHashSet objects ---
+
+func setLinkedView(obj *object.Object, lv *linkedView) {
+	obj.FieldTable[fieldNameLinkedMap] = object.Field{Ftype: types.RawGoPointer, Fvalue: lv}
+}
+
+func getLinkedView(obj *object.Object, caller string) (*linkedView, *ghelpers.GErrBlk) {
+	if obj == nil || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": object is null")
+	}
+	lv, ok := obj.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(obj.KlassName)
+		errMsg := fmt.Sprintf("%s: %s object has not been initialized", caller, className)
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+	}
+	return lv, nil
+}
+
+// linkedThis unpacks the frame stack (if present), the LinkedHashMap/Set object, and its view.
+func linkedThis(params []interface{}, caller string) (*list.List, []interface{}, *linkedView, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing 'this' parameter")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.ClassCastException, caller+": 'this' is not an object")
+	}
+	lv, gerr := getLinkedView(this, caller)
+	return fs, args, lv, gerr
+}
+
+// makeLinkedHashSetFromElements creates a LinkedHashSet holding elements, in order.
+func makeLinkedHashSetFromElements(elements []any) *object.Object {
+	set := object.MakeEmptyObjectWithClassName(&classNameLinkedHashSet)
+	lv := &linkedView{linkedStore: newLinkedStore(false)}
+	for _, elem := range elements {
+		lv.put(elem, nil, false)
+	}
+	setLinkedView(set, lv)
+	return set
+}
+
+// --- LinkedHashMap G functions (several are shared with LinkedHashSet) ---
+
+// LinkedHashMap(), (int), (int, float), (int, float, boolean), and the LinkedHashSet equivalents.
+// Only the accessOrder argument matters here.
+func linkedhashmapInit(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "linkedhashmapInit: invalid 'this' argument")
+	}
+	if len(params) > 1 {
+		if capacity, ok := params[1].(int64); ok && capacity < 0 {
+			errMsg := fmt.Sprintf("linkedhashmapInit: Illegal initial capacity: %d", capacity)
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		}
+	}
+	if len(params) > 2 {
+		if loadFactor, ok := params[2].(float64); ok && (loadFactor <= 0 || math.IsNaN(loadFactor)) {
+			errMsg := fmt.Sprintf("linkedhashmapInit: Illegal load factor: %s", javaDoubleToString(loadFactor, true))
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+		}
+	}
+	accessOrder := len(params) > 3 && params[3] == types.JavaBoolTrue
+	setLinkedView(this, &linkedView{linkedStore: newLinkedStore(accessOrder)})
+	return nil
+}
+
+func linkedhashmapInitFromMap(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "linkedhashmapInitFromMap: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "linkedhashmapInitFromMap: map is null")
+	}
+	entries, gerr := mapEntries(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	lv := &linkedView{linkedStore: newLinkedStore(false)}
+	for _, entry := range entries {
+		lv.put(entry.key, entry.value, false)
+	}
+	setLinkedView(this, lv)
+	return nil
+}
+
+func linkedhashmapPut(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapPut")
+	if gerr != nil {
+		return gerr
+	}
+	prev := lv.put(args[1], args[2], false)
+	if prev != nil {
+		return prev.value
+	}
+	if gerr = linkedAfterInsert(fs, args[0].(*object.Object), lv); gerr != nil {
+		return gerr
+	}
+	return object.Null
+}
+
+// linkedAfterInsert gives a user subclass's removeEldestEntry() the chance to evict the eldest entry.
+func linkedAfterInsert(fs *list.List, this *object.Object, lv *linkedView) *ghelpers.GErrBlk {
+	if !ghelpers.HasJavaOverride(this, "removeEldestEntry", methTypeRemoveEldestEntry, classNameLinkedHashMap) {
+		return nil
+	}
+	eldest := lv.order.Front()
+	if eldest == nil {
+		return nil
+	}
+	entry := eldest.Value.(*treeEntry)
+	ret := ghelpers.InvokeMethodOnObject(fs, this, "removeEldestEntry", methTypeRemoveEldestEntry,
+		makeMapEntry(entry.key, entry.value))
+	switch r := ret.(type) {
+	case *ghelpers.GErrBlk:
+		return r
+	case int64:
+		if r == types.JavaBoolTrue {
+			lv.remove(entry.key)
+		}
+	}
+	return nil
+}
+
+func linkedhashmapPutIfAbsent(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapPutIfAbsent")
+	if gerr != nil {
+		return gerr
+	}
+	if entry := lv.get(args[1]); entry != nil && !isNullValue(entry.value) {
+		return entry.value
+	}
+	if prev := lv.put(args[1], args[2], false); prev == nil {
+		if gerr = linkedAfterInsert(fs, args[0].(*object.Object), lv); gerr != nil {
+			return gerr
+		}
+	}
+	return object.Null
+}
+
+func linkedhashmapPutAll(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapPutAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "linkedhashmapPutAll: map is null")
+	}
+	entries, gerr := mapEntries(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		if prev := lv.put(entry.key, entry.value, false); prev == nil {
+			if gerr = linkedAfterInsert(fs, args[0].(*object.Object), lv); gerr != nil {
+				return gerr
+			}
+		}
+	}
+	return nil
+}
+
+func linkedhashmapPutFirst(params []interface{}) interface{} {
+	return linkedhashmapPutAt(params, "linkedhashmapPutFirst", true)
+}
+
+func linkedhashmapPutLast(params []interface{}) interface{} {
+	return linkedhashmapPutAt(params, "linkedhashmapPutLast", false)
+}
+
+// linkedhashmapPutAt inserts the mapping, or updates it, and moves it to the front or back.
+func linkedhashmapPutAt(params []interface{}, caller string, atFront bool) interface{} {
+	_, args, lv, gerr := linkedThis(params, caller)
+	if gerr != nil {
+		return gerr
+	}
+	prev := lv.put(args[1], args[2], atFront)
+	if prev == nil {
+		return object.Null
+	}
+	lv.reposition(args[1], atFront)
+	return prev.value
+}
+
+func linkedhashmapGet(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashmapGet")
+	if gerr != nil {
+		return gerr
+	}
+	if entry := lv.get(args[1]); entry != nil {
+		return entry.value
+	}
+	return object.Null
+}
+
+func linkedhashmapGetOrDefault(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashmapGetOrDefault")
+	if gerr != nil {
+		return gerr
+	}
+	if entry := lv.get(args[1]); entry != nil {
+		return entry.value
+	}
+	return args[2]
+}
+
+// containsKey() for LinkedHashMap and contains() for LinkedHashSet
+func linkedhashmapContainsKey(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashmapContainsKey")
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(lv.contains(args[1]))
+}
+
+func linkedhashmapContainsValue(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapContainsValue")
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range lv.snapshot() {
+		eq, gerr := javaEquals(fs, args[1], entry.value)
+		if gerr != nil {
+			return gerr
+		}
+		if eq {
+			return types.JavaBoolTrue
+		}
+	}
+	return types.JavaBoolFalse
+}
+
+func linkedhashmapRemove(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashmapRemove")
+	if gerr != nil {
+		return gerr
+	}
+	if entry := lv.remove(args[1]); entry != nil {
+		return entry.value
+	}
+	return object.Null
+}
+
+func linkedhashmapReplace(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashmapReplace")
+	if gerr != nil {
+		return gerr
+	}
+	entry := lv.get(args[1])
+	if entry == nil {
+		return object.Null
+	}
+	prev := entry.value
+	entry.value = args[2]
+	return prev
+}
+
+// The JDK's removeEldestEntry() always returns false; subclasses override it to evict entries.
+func linkedhashmapRemoveEldestEntry([]interface{}) interface{} {
+	return types.JavaBoolFalse
+}
+
+// size() for LinkedHashMap and LinkedHashSet
+func linkedSize(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedSize")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(lv.order.Len())
+}
+
+// isEmpty() for LinkedHashMap and LinkedHashSet
+func linkedIsEmpty(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedIsEmpty")
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(lv.order.Len() == 0)
+}
+
+// clear() for LinkedHashMap and LinkedHashSet
+func linkedClear(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedClear")
+	if gerr != nil {
+		return gerr
+	}
+	lv.clear()
+	return nil
+}
+
+// clone() for LinkedHashMap and LinkedHashSet: a shallow copy in the same encounter order
+func linkedClone(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedClone")
+	if gerr != nil {
+		return gerr
+	}
+	nv := &linkedView{linkedStore: newLinkedStore(lv.accessOrder)}
+	for _, entry := range lv.snapshot() {
+		nv.put(entry.key, entry.value, false)
+	}
+	this := args[0].(*object.Object)
+	clone := object.MakeEmptyObjectWithClassName(new(object.GoStringFromStringPoolIndex(this.KlassName)))
+	setLinkedView(clone, nv)
+	return clone
+}
+
+// reversed() for LinkedHashMap and LinkedHashSet: a view that shares the original's entries
+func linkedReversed(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedReversed")
+	if gerr != nil {
+		return gerr
+	}
+	className := classNameLinkedHashMap
+	if object.GoStringFromStringPoolIndex(args[0].(*object.Object).KlassName) == classNameLinkedHashSet {
+		className = classNameLinkedHashSet
+	}
+	reversed := object.MakeEmptyObjectWithClassName(&className)
+	setLinkedView(reversed, &linkedView{linkedStore: lv.linkedStore, reversed: !lv.reversed})
+	return reversed
+}
+
+func linkedhashmapFirstEntry(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapFirstEntry")
+	if gerr != nil {
+		return gerr
+	}
+	return entryOrNull(lv.end(false))
+}
+
+func linkedhashmapLastEntry(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapLastEntry")
+	if gerr != nil {
+		return gerr
+	}
+	return entryOrNull(lv.end(true))
+}
+
+func linkedhashmapPollFirstEntry(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapPollFirstEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry := lv.end(false)
+	if entry != nil {
+		lv.remove(entry.key)
+	}
+	return entryOrNull(entry)
+}
+
+func linkedhashmapPollLastEntry(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapPollLastEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry := lv.end(true)
+	if entry != nil {
+		lv.remove(entry.key)
+	}
+	return entryOrNull(entry)
+}
+
+// keySet() returns a LinkedHashSet holding a snapshot of the keys in encounter order.
+func linkedhashmapKeySet(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapKeySet")
+	if gerr != nil {
+		return gerr
+	}
+	return makeLinkedHashSetFromElements(lv.keys())
+}
+
+// entrySet() returns a LinkedHashSet holding a snapshot of the entries in encounter order.
+func linkedhashmapEntrySet(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapEntrySet")
+	if gerr != nil {
+		return gerr
+	}
+	entries := lv.snapshot()
+	elements := make([]any, len(entries))
+	for ix, entry := range entries {
+		elements[ix] = makeMapEntry(entry.key, entry.value)
+	}
+	return makeLinkedHashSetFromElements(elements)
+}
+
+// values() returns an ArrayList holding a snapshot of the values in encounter order.
+func linkedhashmapValues(params []interface{}) interface{} {
+	_, _, lv, gerr := linkedThis(params, "linkedhashmapValues")
+	if gerr != nil {
+		return gerr
+	}
+	entries := lv.snapshot()
+	values := make([]interface{}, len(entries))
+	for ix, entry := range entries {
+		values[ix] = entry.value
+	}
+	return object.MakePrimitiveObject(classNameArrayList, types.ArrayList, values)
+}
+
+func linkedhashmapForEach(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapForEach")
+	if gerr != nil {
+		return gerr
+	}
+	action, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(action) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "linkedhashmapForEach: action is null")
+	}
+	for _, entry := range lv.snapshot() {
+		ret := ghelpers.InvokeMethodOnObject(fs, action, "accept", "(Ljava/lang/Object;Ljava/lang/Object;)V", entry.key, entry.value)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return nil
+}
+
+func linkedhashmapToString(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashmapToString")
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(formatMapEntries(fs, args[0].(*object.Object), lv.snapshot()))
+}
+
+// --- LinkedHashSet G functions ---
+
+func linkedhashsetInitFromCollection(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "linkedhashsetInitFromCollection: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "linkedhashsetInitFromCollection: collection is null")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	lv := &linkedView{linkedStore: newLinkedStore(false)}
+	for _, elem := range elements {
+		lv.put(elem, nil, false)
+	}
+	setLinkedView(this, lv)
+	return nil
+}
+
+func linkedhashsetAdd(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashsetAdd")
+	if gerr != nil {
+		return gerr
+	}
+	if lv.contains(args[1]) {
+		return types.JavaBoolFalse
+	}
+	lv.put(args[1], nil, false)
+	return types.JavaBoolTrue
+}
+
+func linkedhashsetAddAll(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashsetAddAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "linkedhashsetAddAll: collection is null")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	changed := false
+	for _, elem := range elements {
+		if !lv.contains(elem) {
+			lv.put(elem, nil, false)
+			changed = true
+		}
+	}
+	return object.JavaBooleanFromGoBoolean(changed)
+}
+
+func linkedhashsetAddFirst(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashsetAddFirst")
+	if gerr != nil {
+		return gerr
+	}
+	if lv.put(args[1], nil, true) != nil {
+		lv.reposition(args[1], true)
+	}
+	return nil
+}
+
+func linkedhashsetAddLast(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashsetAddLast")
+	if gerr != nil {
+		return gerr
+	}
+	if lv.put(args[1], nil, false) != nil {
+		lv.reposition(args[1], false)
+	}
+	return nil
+}
+
+func linkedhashsetRemove(params []interface{}) interface{} {
+	_, args, lv, gerr := linkedThis(params, "linkedhashsetRemove")
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(lv.remove(args[1]) != nil)
+}
+
+func linkedhashsetGetFirst(params []interface{}) interface{} {
+	return linkedhashsetEnd(params, "linkedhashsetGetFirst", false, false)
+}
+
+func linkedhashsetGetLast(params []interface{}) interface{} {
+	return linkedhashsetEnd(params, "linkedhashsetGetLast", true, false)
+}
+
+func linkedhashsetRemoveFirst(params []interface{}) interface{} {
+	return linkedhashsetEnd(params, "linkedhashsetRemoveFirst", false, true)
+}
+
+func linkedhashsetRemoveLast(params []interface{}) interface{} {
+	return linkedhashsetEnd(params, "linkedhashsetRemoveLast", true, true)
+}
+
+// linkedhashsetEnd returns (and, if remove is set, removes) the first or last element of the set.
+func linkedhashsetEnd(params []interface{}, caller string, last, remove bool) interface{} {
+	_, _, lv, gerr := linkedThis(params, caller)
+	if gerr != nil {
+		return gerr
+	}
+	entry := lv.end(last)
+	if entry == nil {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, caller+": set is empty")
+	}
+	if remove {
+		lv.remove(entry.key)
+	}
+	return entry.key
+}
+
+func linkedhashsetToString(params []interface{}) interface{} {
+	fs, args, lv, gerr := linkedThis(params, "linkedhashsetToString")
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(formatElements(fs, args[0].(*object.Object), lv.keys()))
+}
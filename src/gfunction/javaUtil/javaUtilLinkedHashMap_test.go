@@ -0,0 +1,166 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/classloader"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newLinkedHashMap(t *testing.T, accessOrder bool) *object.Object {
+	t.Helper()
+	lhm := object.MakeEmptyObjectWithClassName(&classNameLinkedHashMap)
+	ret := linkedhashmapInit([]interface{}{lhm, int64(16), float64(0.75), object.JavaBooleanFromGoBoolean(accessOrder)})
+	if ret != nil {
+		t.Fatalf("linkedhashmapInit returned error: %v", ret)
+	}
+	return lhm
+}
+
+func lhmString(t *testing.T, lhm *object.Object) string {
+	t.Helper()
+	return object.GoStringFromStringObject(linkedhashmapToString([]interface{}{lhm}).(*object.Object))
+}
+
+func TestLinkedHashMap_InsertionOrder(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	lhm := newLinkedHashMap(t, false)
+	for _, k := range []string{"c", "a", "b"} {
+		linkedhashmapPut([]interface{}{lhm, strKey(k), intKey(1)})
+	}
+	// re-inserting an existing key does not change the order
+	linkedhashmapPut([]interface{}{lhm, strKey("c"), intKey(2)})
+
+	if got := lhmString(t, lhm); got != "{c=2, a=1, b=1}" {
+		t.Errorf("unexpected order: %q", got)
+	}
+
+	// keys are matched by value, not identity
+	got := linkedhashmapGet([]interface{}{lhm, strKey("a")})
+	if keyInt(t, got) != 1 {
+		t.Errorf("get(a): expected 1")
+	}
+	linkedhashmapRemove([]interface{}{lhm, strKey("c")})
+	if got := lhmString(t, lhm); got != "{a=1, b=1}" {
+		t.Errorf("after remove: %q", got)
+	}
+}
+
+func TestLinkedHashMap_AccessOrder(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	lhm := newLinkedHashMap(t, true)
+	for _, k := range []int64{1, 2, 3} {
+		linkedhashmapPut([]interface{}{lhm, intKey(k), strKey("v")})
+	}
+	linkedhashmapGet([]interface{}{lhm, intKey(1)})
+
+	if got := lhmString(t, lhm); got != "{2=v, 3=v, 1=v}" {
+		t.Errorf("unexpected access order: %q", got)
+	}
+	if got := entryKeyInt(t, linkedhashmapFirstEntry([]interface{}{lhm})); got != 2 {
+		t.Errorf("eldest entry: expected 2, got %d", got)
+	}
+}
+
+func TestLinkedHashMap_SequencedMethods(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	lhm := newLinkedHashMap(t, false)
+	for _, k := range []int64{1, 2, 3} {
+		linkedhashmapPut([]interface{}{lhm, intKey(k), strKey("v")})
+	}
+
+	linkedhashmapPutFirst([]interface{}{lhm, intKey(3), strKey("w")})
+	if got := lhmString(t, lhm); got != "{3=w, 1=v, 2=v}" {
+		t.Errorf("after putFirst: %q", got)
+	}
+
+	rev := linkedReversed([]interface{}{lhm}).(*object.Object)
+	if got := lhmString(t, rev); got != "{2=v, 1=v, 3=w}" {
+		t.Errorf("reversed: %q", got)
+	}
+	if got := entryKeyInt(t, sequencedmapPollFirstEntry([]interface{}{rev})); got != 2 {
+		t.Errorf("reversed pollFirstEntry: expected 2, got %d", got)
+	}
+	if got := lhmString(t, lhm); got != "{3=w, 1=v}" {
+		t.Errorf("original after polling the reversed view: %q", got)
+	}
+}
+
+func TestLinkedHashMap_NativeRemoveEldestEntryIsFalse(t *testing.T) {
+	if ret := linkedhashmapRemoveEldestEntry([]interface{}{object.Null, object.Null}); ret != types.JavaBoolFalse {
+		t.Errorf("expected removeEldestEntry to return false")
+	}
+}
+
+func TestLinkedHashMap_InitRejectsBadArguments(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	lhm := object.MakeEmptyObjectWithClassName(&classNameLinkedHashMap)
+	ret := linkedhashmapInit([]interface{}{lhm, int64(-1)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("negative capacity: expected IllegalArgumentException, got %v", ret)
+	}
+}
+
+func TestLinkedHashSet_OrderAndIterator(t *testing.T) {
+	globals.InitStringPool()
+	classloader.InitMethodArea()
+	lhs := object.MakeEmptyObjectWithClassName(&classNameLinkedHashSet)
+	linkedhashmapInit([]interface{}{lhs})
+	for _, k := range []int64{3, 1, 3, 2} {
+		linkedhashsetAdd([]interface{}{lhs, intKey(k)})
+	}
+	str := object.GoStringFromStringObject(linkedhashsetToString([]interface{}{lhs}).(*object.Object))
+	if str != "[3, 1, 2]" {
+		t.Errorf("unexpected contents: %q", str)
+	}
+
+	iter := NewIterator(lhs)
+	var got []int64
+	for iteratorHasNext([]interface{}{iter}) == types.JavaBoolTrue {
+		got = append(got, keyInt(t, iteratorNext([]interface{}{iter})))
+	}
+	if len(got) != 3 || got[0] != 3 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("iteration order: %v", got)
+	}
+
+	if got := keyInt(t, linkedhashsetRemoveLast([]interface{}{lhs})); got != 2 {
+		t.Errorf("removeLast: expected 2, got %d", got)
+	}
+}
+
+func TestLinkedHashMap_ComputeAndMergeKeepEncounterOrder(t *testing.T) {
+	globals.InitStringPool()
+	lhm := newLinkedHashMap(t, false)
+	for _, k := range []string{"b", "a"} {
+		linkedhashmapPut([]interface{}{lhm, strKey(k), intKey(1)})
+	}
+	sum := newTestFunction("test/LinkedSum", "apply", methTypeApply2, func(args []interface{}) interface{} {
+		return intKey(intOf(args[0]) + intOf(args[1]))
+	})
+	length := newTestFunction("test/Length", "apply", methTypeApply1, func(args []interface{}) interface{} {
+		return intKey(int64(len(object.GoStringFromStringObject(args[0].(*object.Object)))))
+	})
+	toNull := newTestFunction("test/LinkedToNull", "apply", methTypeApply2, func([]interface{}) interface{} {
+		return object.Null
+	})
+
+	orderedMerge([]interface{}{lhm, strKey("b"), intKey(4), sum})
+	orderedComputeIfAbsent([]interface{}{lhm, strKey("ccc"), length})
+	orderedCompute([]interface{}{lhm, strKey("a"), toNull})
+	if got := lhmString(t, lhm); got != "{b=5, ccc=3}" {
+		t.Errorf("unexpected map after merge and compute: %q", got)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
-	"jacobin/src/types"
 )
 
 func Load_Util_List() {
@@ -93,6 +92,13 @@ func Load_Util_List() {
 			GFunction:  listOfVarargs,
 		}
 
+	ghelpers.MethodSignatures["java/util/List.copyOf(Ljava/util/Collection;)Ljava/util/List;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    listCopyOf,
+			NeedsContext: true,
+		}
+
 	// traps for functions that reference forbidden types: Collection, Consumer, ListIterator, Spliterator, UnaryOperator, Comparator.
 
 	ghelpers.MethodSignatures["java/util/List.addAll(Ljava/util/Collection;)Z"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
@@ -100,7 +106,6 @@ func Load_Util_List() {
 	ghelpers.MethodSignatures["java/util/List.containsAll(Ljava/util/Collection;)Z"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
 	ghelpers.MethodSignatures["java/util/List.removeAll(Ljava/util/Collection;)Z"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
 	ghelpers.MethodSignatures["java/util/List.retainAll(Ljava/util/Collection;)Z"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
-	ghelpers.MethodSignatures["java/util/List.copyOf(Ljava/util/Collection;)Ljava/util/List;"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
 
 	ghelpers.MethodSignatures["java/util/List.forEach(Ljava/util/function/Consumer;)V"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
 
@@ -205,13 +210,29 @@ func listOf(params []interface{}) interface{} {
 		}
 	}
 
-	// We'll return an ArrayList for now as a minimal implementation.
+	// The result is an ArrayList marked as unmodifiable; see javaUtilCollections.go.
 	// Copy params to a new slice to ensure it's independent.
 	list := make([]interface{}, len(params))
 	copy(list, params)
 
-	listObj := object.MakePrimitiveObject("java/util/ArrayList", types.ArrayList, list)
-	return listObj
+	return makeImmutableList(list)
+}
+
+// java/util/List.copyOf(Ljava/util/Collection;)Ljava/util/List;
+func listCopyOf(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	coll, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(coll) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "List.copyOf: collection is null")
+	}
+	if isUnmodifiable(coll) && object.GoStringFromStringPoolIndex(coll.KlassName) == "java/util/ArrayList" {
+		return coll // already an immutable list
+	}
+	elements, gerr := collectionElements(fs, coll)
+	if gerr != nil {
+		return gerr
+	}
+	return listOf(elements)
 }
 
 func listOfVarargs(params []interface{}) interface{} {
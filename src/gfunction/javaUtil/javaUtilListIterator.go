@@ -192,6 +192,9 @@ func listiteratorRemove(params []interface{}) interface{} {
 	if err != nil {
 		return err
 	}
+	if gerr := checkModifiable(state.collection, "ListIterator.remove"); gerr != nil {
+		return gerr
+	}
 	if state.lastReturned == -1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "ListIterator.remove: next/previous not called or remove/add already called")
 	}
@@ -219,6 +222,9 @@ func listiteratorSet(params []interface{}) interface{} {
 	if err != nil {
 		return err
 	}
+	if gerr := checkModifiable(state.collection, "ListIterator.set"); gerr != nil {
+		return gerr
+	}
 	if state.lastReturned == -1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalStateException, "ListIterator.set: next/previous not called or remove/add already called")
 	}
@@ -242,6 +248,9 @@ func listiteratorAdd(params []interface{}) interface{} {
 	if err != nil {
 		return err
 	}
+	if gerr := checkModifiable(state.collection, "ListIterator.add"); gerr != nil {
+		return gerr
+	}
 	obj := params[1]
 	className := object.GoStringFromStringPoolIndex(state.collection.KlassName)
 	if className == "java/util/ArrayList" {
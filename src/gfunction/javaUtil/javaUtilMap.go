@@ -1,13 +1,13 @@
 package javaUtil
 
 import (
+	"container/list"
 	"fmt"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
 	"jacobin/src/stringPool"
-	"jacobin/src/types"
-	"strconv"
+	"strings"
 )
 
 func Load_Util_Map() {
@@ -20,8 +20,9 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.clear()V"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  mapClear,
+			ParamSlots:   0,
+			GFunction:    mapClear,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.compute(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
@@ -44,8 +45,9 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.containsKey(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  mapContainsKey,
+			ParamSlots:   1,
+			GFunction:    mapContainsKey,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.containsValue(Ljava/lang/Object;)Z"] =
@@ -56,8 +58,9 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.entrySet()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  mapEntrySet,
+			ParamSlots:   0,
+			GFunction:    mapEntrySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map$Entry.comparingByKey()Ljava/util/Comparator;"] =
@@ -134,8 +137,9 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.equals(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    mapEquals,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.forEach(Ljava/util/function/BiConsumer;)V"] =
@@ -146,20 +150,23 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.get(Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  mapGet,
+			ParamSlots:   1,
+			GFunction:    mapGet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.getOrDefault(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  mapGetOrDefault,
+			ParamSlots:   2,
+			GFunction:    mapGetOrDefault,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.hashCode()I"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    mapHashCode,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.isEmpty()Z"] =
@@ -170,8 +177,9 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.keySet()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  mapKeySet,
+			ParamSlots:   0,
+			GFunction:    mapKeySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.merge(Ljava/lang/Object;Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
@@ -182,26 +190,30 @@ func Load_Util_Map() {
 
 	ghelpers.MethodSignatures["java/util/Map.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  mapPut,
+			ParamSlots:   2,
+			GFunction:    mapPut,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.putAll(Ljava/util/Map;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  mapPutAll,
+			ParamSlots:   1,
+			GFunction:    mapPutAll,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.putIfAbsent(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  mapPutIfAbsent,
+			ParamSlots:   2,
+			GFunction:    mapPutIfAbsent,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  mapRemove,
+			ParamSlots:   1,
+			GFunction:    mapRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.remove(Ljava/lang/Object;Ljava/lang/Object;)Z"] =
@@ -243,70 +255,110 @@ func Load_Util_Map() {
 	// Java 21 methods from SequencedMap
 	ghelpers.MethodSignatures["java/util/Map.firstEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapFirstEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.lastEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapLastEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.pollFirstEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapPollFirstEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.pollLastEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapPollLastEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.putFirst(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    sequencedmapPutFirst,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.putLast(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    sequencedmapPutLast,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.reversed()Ljava/util/SequencedMap;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapReversed,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.sequencedEntrySet()Ljava/util/SequencedSet;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapEntrySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.sequencedKeySet()Ljava/util/SequencedSet;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapKeySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Map.sequencedValues()Ljava/util/SequencedCollection;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapValues,
+			NeedsContext: true,
 		}
+
+	// Map.of() through Map.of(k1, v1, ..., k10, v10)
+	for pairs := 0; pairs <= 10; pairs++ {
+		methType := "(" + strings.Repeat("Ljava/lang/Object;", 2*pairs) + ")Ljava/util/Map;"
+		ghelpers.MethodSignatures["java/util/Map.of"+methType] =
+			ghelpers.GMeth{
+				ParamSlots:   2 * pairs,
+				GFunction:    mapOf,
+				NeedsContext: true,
+			}
+	}
+
+	ghelpers.MethodSignatures["java/util/Map.copyOf(Ljava/util/Map;)Ljava/util/Map;"] =
+		ghelpers.GMeth{
+			ParamSlots:   1,
+			GFunction:    mapCopyOf,
+			NeedsContext: true,
+		}
+}
+
+// orderedMapFunc returns treeFn for a TreeMap, linkedFn for a LinkedHashMap, or nil for any other map.
+func orderedMapFunc(this *object.Object, treeFn, linkedFn func([]interface{}) interface{}) func([]interface{}) interface{} {
+	if _, ok := this.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		return treeFn
+	}
+	if _, ok := this.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		return linkedFn
+	}
+	return nil
 }
 
 func mapClear(params []interface{}) interface{} {
-	if len(params) < 1 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapClear: missing 'this' parameter")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapClear: 'this' is not an object")
 	}
@@ -318,17 +370,21 @@ func mapClear(params []interface{}) interface{} {
 	className := *klassNamePtr
 	switch className {
 	case "java/util/HashMap":
-		return hashmapInit(params)
+		return hashmapClear(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapClear, linkedClear); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapClear not supported for class %s", className))
 	}
 }
 
 func mapGet(params []interface{}) interface{} {
-	if len(params) < 2 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapGet: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapGet: 'this' is not an object")
 	}
@@ -342,31 +398,36 @@ func mapGet(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapGet(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapGet, linkedhashmapGet); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapGet not supported for class %s", className))
 	}
 }
 
 func mapGetOrDefault(params []interface{}) interface{} {
-	if len(params) < 3 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 3 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapGetOrDefault: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapGetOrDefault: 'this' is not an object")
 	}
 
-	v := mapGet([]any{this, params[1]})
+	v := mapGet(params[:len(params)-1])
 	if v == object.Null {
-		return params[2]
+		return args[2]
 	}
 	return v
 }
 
 func mapContainsKey(params []interface{}) interface{} {
-	if len(params) < 2 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapContainsKey: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapContainsKey: 'this' is not an object")
 	}
@@ -380,15 +441,19 @@ func mapContainsKey(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapContainsKey(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapContainsKey, linkedhashmapContainsKey); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapContainsKey not supported for class %s", className))
 	}
 }
 
 func mapIsEmpty(params []interface{}) interface{} {
-	if len(params) < 1 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapIsEmpty: missing 'this' parameter")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapIsEmpty: 'this' is not an object")
 	}
@@ -402,15 +467,19 @@ func mapIsEmpty(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapIsEmpty(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapIsEmpty, linkedIsEmpty); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapIsEmpty not supported for class %s", className))
 	}
 }
 
 func mapPut(params []interface{}) interface{} {
-	if len(params) < 3 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 3 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapPut: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapPut: 'this' is not an object")
 	}
@@ -424,15 +493,19 @@ func mapPut(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapPut(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapPut, linkedhashmapPut); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapPut not supported for class %s", className))
 	}
 }
 
 func mapRemove(params []interface{}) interface{} {
-	if len(params) < 2 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapRemove: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapRemove: 'this' is not an object")
 	}
@@ -446,15 +519,19 @@ func mapRemove(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapRemove(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapRemove, linkedhashmapRemove); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapRemove not supported for class %s", className))
 	}
 }
 
 func mapSize(params []interface{}) interface{} {
-	if len(params) < 1 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapSize: missing 'this' parameter")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapSize: 'this' is not an object")
 	}
@@ -468,15 +545,19 @@ func mapSize(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapSize(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapSize, linkedSize); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapSize not supported for class %s", className))
 	}
 }
 
 func mapPutAll(params []interface{}) interface{} {
-	if len(params) < 2 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapPutAll: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapPutAll: 'this' is not an object")
 	}
@@ -490,21 +571,25 @@ func mapPutAll(params []interface{}) interface{} {
 	case "java/util/HashMap":
 		return hashmapPutAll(params)
 	default:
+		if orderedFn := orderedMapFunc(this, treemapPutAll, linkedhashmapPutAll); orderedFn != nil {
+			return orderedFn(params)
+		}
 		return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, fmt.Sprintf("mapPutAll not supported for class %s", className))
 	}
 }
 
 func mapPutIfAbsent(params []interface{}) interface{} {
-	if len(params) < 3 {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) < 3 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapPutIfAbsent: missing parameters")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapPutIfAbsent: 'this' is not an object")
 	}
 
 	// Default implementation for putIfAbsent
-	v := mapGet([]any{this, params[1]})
+	v := mapGet(params[:len(params)-1])
 	if v == object.Null {
 		return mapPut(params)
 	}
@@ -512,49 +597,36 @@ func mapPutIfAbsent(params []interface{}) interface{} {
 }
 
 func mapEntrySet(params []interface{}) interface{} {
-	if len(params) < 1 {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapEntrySet: missing 'this' parameter")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapEntrySet: 'this' is not an object")
 	}
+	if orderedFn := orderedMapFunc(this, treemapEntrySet, linkedhashmapEntrySet); orderedFn != nil {
+		return orderedFn(params)
+	}
 
 	// Get the current hash map.
 	this.ThMutex.RLock()
-	fld, ok := this.FieldTable[fieldNameMap]
+	hm, hs, gerr := getHashMap(this, "mapEntrySet")
 	this.ThMutex.RUnlock()
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapEntrySet: 'this' does not have a 'map' field")
-	}
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapEntrySet: 'map' field is not a HashMap")
+	if gerr != nil {
+		return gerr
 	}
 
-	// Create a new HashSet (which is a HashMap object in Jacobin)
-	entrySet := object.MakeEmptyObjectWithClassName(&classNameHashSet)
-	if ret := hashmapInit([]any{entrySet}); ret != nil {
-		return ret
+	// Represent each entry as a SimpleImmutableEntry, in the iteration order of the map
+	entries := hashEntries(this, hm, hs)
+	elements := make([]any, len(entries))
+	for ix, entry := range entries {
+		elements[ix] = makeMapEntry(entry.key, entry.value)
 	}
-
-	// Iterate over the source map
-	for k, v := range hm {
-		// Represent each entry as a SimpleImmutableEntry
-		entryObj := object.MakeEmptyObjectWithClassName(new("java/util/AbstractMap$SimpleImmutableEntry"))
-		entryObj.ThMutex.Lock()
-		entryObj.FieldTable["key"] = object.Field{Ftype: "Ljava/lang/Object;", Fvalue: k}
-		entryObj.FieldTable["value"] = object.Field{Ftype: "Ljava/lang/Object;", Fvalue: v}
-		entryObj.ThMutex.Unlock()
-
-		// Add entry to entrySet (which is a HashSet, so it needs hashed key)
-		// We use the object's hash code (from its address) to avoid JSON marshal issues with unsafe.Pointer
-		keyString := "java/util/AbstractMap$SimpleImmutableEntry:" + strconv.FormatUint(uint64(entryObj.Mark.Hash), 10)
-		keyObj := object.StringObjectFromGoString(keyString)
-
-		hashmapPut([]any{entrySet, keyObj, entryObj})
+	entrySet, gerr := makeHashSetInOrder(fs, elements)
+	if gerr != nil {
+		return gerr
 	}
-
 	return entrySet
 }
 
@@ -615,56 +687,296 @@ func mapEntrySetValue(params []interface{}) interface{} {
 }
 
 func mapKeySet(params []interface{}) interface{} {
-	if len(params) < 1 {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapKeySet: missing 'this' parameter")
 	}
-	this, ok := params[0].(*object.Object)
+	this, ok := args[0].(*object.Object)
 	if !ok || this == nil {
 		return ghelpers.GetGErrBlk(excNames.ClassCastException, "mapKeySet: 'this' is not an object")
 	}
+	if orderedFn := orderedMapFunc(this, treemapNavigableKeySet, linkedhashmapKeySet); orderedFn != nil {
+		return orderedFn(params)
+	}
 
 	// Get the current hash map.
 	this.ThMutex.RLock()
-	fld, ok := this.FieldTable[fieldNameMap]
+	hm, hs, gerr := getHashMap(this, "mapKeySet")
 	this.ThMutex.RUnlock()
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapKeySet: 'this' does not have a 'map' field")
+	if gerr != nil {
+		return gerr
 	}
-	hm, ok := fld.Fvalue.(types.DefHashMap)
-	if !ok {
-		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "mapKeySet: 'map' field is not a HashMap")
+
+	// The keys go into a HashSet in the iteration order of the map
+	entries := hashEntries(this, hm, hs)
+	keys := make([]any, len(entries))
+	for ix, entry := range entries {
+		keys[ix] = entry.key
+	}
+	keySet, gerr := makeHashSetInOrder(fs, keys)
+	if gerr != nil {
+		return gerr
 	}
+	return keySet
+}
 
-	// Create a new HashSet (which is a HashMap object in Jacobin)
-	keySet := object.MakeEmptyObjectWithClassName(&classNameHashSet)
-	if ret := hashmapInit([]any{keySet}); ret != nil {
+// makeImmutableMap returns an unmodifiable HashMap holding the given keys and values.
+// As with Map.of, null keys and values and duplicate keys are rejected.
+func makeImmutableMap(fs *list.List, keys, values []any, caller string) interface{} {
+	hm := object.MakeEmptyObjectWithClassName(&classNameHashMap)
+	if ret := hashmapInit([]interface{}{hm}); ret != nil {
 		return ret
 	}
+	for ix, key := range keys {
+		if isNullValue(key) || isNullValue(values[ix]) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": null key or value")
+		}
+		ret := hashmapPut([]interface{}{fs, hm, key, values[ix]})
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		if !object.IsNull(ret) {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": duplicate key: "+javaToString(fs, key))
+		}
+	}
+	return markUnmodifiable(hm)
+}
 
-	// Iterate over the source map
-	for k := range hm {
-		// Add key to keySet (which is a HashSet)
-		var keyObj *object.Object
-		switch v := k.(type) {
-		case string:
-			keyObj = object.StringObjectFromGoString(v)
-		case int64:
-			keyObj = object.MakePrimitiveObject("java/lang/Integer", types.Int, v)
-		case float64:
-			keyObj = object.MakePrimitiveObject("java/lang/Double", types.Double, v)
-		case *object.Object:
-			keyObj = v
-		default:
-			// Fallback for other types if any
-			keyObj = object.MakeOneFieldObject("java/lang/Object", "value", "Ljava/lang/Object;", v)
-		}
-
-		if ret := hashsetAdd([]any{keySet, keyObj}); ret != nil {
-			if _, ok := ret.(*ghelpers.GErrBlk); ok {
-				return ret
-			}
+// java/util/Map.of(...)Ljava/util/Map; with zero to ten key-value pairs
+func mapOf(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	var keys, values []any
+	for ix := 0; ix+1 < len(args); ix += 2 {
+		keys = append(keys, args[ix])
+		values = append(values, args[ix+1])
+	}
+	return makeImmutableMap(fs, keys, values, "Map.of")
+}
+
+// java/util/Map.copyOf(Ljava/util/Map;)Ljava/util/Map;
+func mapCopyOf(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	src, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(src) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Map.copyOf: map is null")
+	}
+	if isUnmodifiable(src) && object.GoStringFromStringPoolIndex(src.KlassName) == classNameHashMap {
+		return src // already an immutable map
+	}
+	entries, gerr := mapEntries(fs, src)
+	if gerr != nil {
+		return gerr
+	}
+	keys := make([]any, len(entries))
+	values := make([]any, len(entries))
+	for ix, entry := range entries {
+		keys[ix], values[ix] = entry.key, entry.value
+	}
+	return makeImmutableMap(fs, keys, values, "Map.copyOf")
+}
+
+// --- compute(), computeIfAbsent(), computeIfPresent(), merge() and replaceAll() for TreeMap and
+// LinkedHashMap, which follow the default methods of Map ---
+
+const (
+	methTypeApply1 = "(Ljava/lang/Object;)Ljava/lang/Object;"
+	methTypeApply2 = "(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"
+)
+
+// orderedMap gives the default methods access to the entries of a TreeMap or LinkedHashMap.
+type orderedMap struct {
+	get     func(key any) (*treeEntry, *ghelpers.GErrBlk)
+	put     func(key, value any) *ghelpers.GErrBlk
+	remove  func(key any) *ghelpers.GErrBlk
+	entries func() ([]*treeEntry, *ghelpers.GErrBlk)
+}
+
+// orderedMapThis unpacks the frame stack, the map object and its entries, and checks that the
+// function passed as the last argument is not null.
+func orderedMapThis(params []interface{}, caller string) (*list.List, []interface{}, *orderedMap, *object.Object, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 2 {
+		return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing parameters")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(this) {
+		return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": 'this' is null")
+	}
+	fn, ok := args[len(args)-1].(*object.Object)
+	if !ok || object.IsNull(fn) {
+		return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": function is null")
+	}
+
+	if tv, ok := this.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		return fs, args, &orderedMap{
+			get: func(key any) (*treeEntry, *ghelpers.GErrBlk) { return tv.get(fs, key) },
+			put: func(key, value any) *ghelpers.GErrBlk {
+				_, gerr := tv.put(fs, key, value)
+				return gerr
+			},
+			remove: func(key any) *ghelpers.GErrBlk {
+				_, gerr := tv.remove(fs, key)
+				return gerr
+			},
+			entries: func() ([]*treeEntry, *ghelpers.GErrBlk) { return tv.entries(fs) },
+		}, fn, nil
+	}
+	if lv, ok := this.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		return fs, args, &orderedMap{
+			get: func(key any) (*treeEntry, *ghelpers.GErrBlk) { return lv.get(key), nil },
+			put: func(key, value any) *ghelpers.GErrBlk {
+				if prev := lv.put(key, value, false); prev == nil {
+					return linkedAfterInsert(fs, this, lv)
+				}
+				return nil
+			},
+			remove: func(key any) *ghelpers.GErrBlk {
+				lv.remove(key)
+				return nil
+			},
+			entries: func() ([]*treeEntry, *ghelpers.GErrBlk) { return lv.snapshot(), nil },
+		}, fn, nil
+	}
+	className := object.GoStringFromStringPoolIndex(this.KlassName)
+	errMsg := fmt.Sprintf("%s: %s object has not been initialized", caller, className)
+	return nil, nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+}
+
+// applyFunction runs fn.apply() on one or two arguments and returns the result.
+func applyFunction(fs *list.List, fn *object.Object, args ...any) (any, *ghelpers.GErrBlk) {
+	methType := methTypeApply1
+	if len(args) == 2 {
+		methType = methTypeApply2
+	}
+	ret := ghelpers.InvokeMethodOnObject(fs, fn, "apply", methType, args...)
+	if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+		return nil, gerr
+	}
+	if ret == nil {
+		return object.Null, nil
+	}
+	return ret, nil
+}
+
+// storeComputed puts value, or removes key if value is null, and returns value.
+func (om *orderedMap) storeComputed(key, value any) interface{} {
+	if isNullValue(value) {
+		if gerr := om.remove(key); gerr != nil {
+			return gerr
 		}
+		return object.Null
+	}
+	if gerr := om.put(key, value); gerr != nil {
+		return gerr
 	}
+	return value
+}
 
-	return keySet
+// compute(key, BiFunction)
+func orderedCompute(params []interface{}) interface{} {
+	fs, args, om, fn, gerr := orderedMapThis(params, "Map.compute")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := om.get(args[1])
+	if gerr != nil {
+		return gerr
+	}
+	var oldValue any = object.Null
+	if entry != nil {
+		oldValue = entry.value
+	}
+	newValue, gerr := applyFunction(fs, fn, args[1], oldValue)
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(newValue) && entry == nil {
+		return object.Null
+	}
+	return om.storeComputed(args[1], newValue)
+}
+
+// computeIfAbsent(key, Function)
+func orderedComputeIfAbsent(params []interface{}) interface{} {
+	fs, args, om, fn, gerr := orderedMapThis(params, "Map.computeIfAbsent")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := om.get(args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry != nil && !isNullValue(entry.value) {
+		return entry.value
+	}
+	newValue, gerr := applyFunction(fs, fn, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(newValue) {
+		return object.Null
+	}
+	return om.storeComputed(args[1], newValue)
+}
+
+// computeIfPresent(key, BiFunction)
+func orderedComputeIfPresent(params []interface{}) interface{} {
+	fs, args, om, fn, gerr := orderedMapThis(params, "Map.computeIfPresent")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := om.get(args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil || isNullValue(entry.value) {
+		return object.Null
+	}
+	newValue, gerr := applyFunction(fs, fn, args[1], entry.value)
+	if gerr != nil {
+		return gerr
+	}
+	return om.storeComputed(args[1], newValue)
+}
+
+// merge(key, value, BiFunction)
+func orderedMerge(params []interface{}) interface{} {
+	fs, args, om, fn, gerr := orderedMapThis(params, "Map.merge")
+	if gerr != nil {
+		return gerr
+	}
+	if isNullValue(args[2]) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Map.merge: value is null")
+	}
+	entry, gerr := om.get(args[1])
+	if gerr != nil {
+		return gerr
+	}
+	newValue := args[2]
+	if entry != nil && !isNullValue(entry.value) {
+		if newValue, gerr = applyFunction(fs, fn, entry.value, args[2]); gerr != nil {
+			return gerr
+		}
+	}
+	return om.storeComputed(args[1], newValue)
+}
+
+// replaceAll(BiFunction) replaces each value with function.apply(key, value), in iteration order.
+func orderedReplaceAll(params []interface{}) interface{} {
+	fs, _, om, fn, gerr := orderedMapThis(params, "Map.replaceAll")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := om.entries()
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		newValue, gerr := applyFunction(fs, fn, entry.key, entry.value)
+		if gerr != nil {
+			return gerr
+		}
+		entry.value = newValue
+	}
+	return nil
 }
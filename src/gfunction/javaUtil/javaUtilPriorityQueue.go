@@ -0,0 +1,498 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
+)
+
+// PriorityQueue is a binary min-heap held in a Go slice, ordered by the natural ordering of its
+// elements or by a Java Comparator. As in the JDK, iteration and toString() visit the elements
+// in heap order, not in priority order.
+
+var classNamePriorityQueue = "java/util/PriorityQueue"
+var fieldNamePriorityQueue = "heap"
+
+type priorityQueue struct {
+	heap       []any
+	comparator *object.Object // nil for natural ordering
+}
+
+func Load_Util_PriorityQueue() {
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueInit}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(I)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueInit}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(Ljava/util/Comparator;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueInit}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(ILjava/util/Comparator;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: priorityqueueInit}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(Ljava/util/Collection;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(Ljava/util/PriorityQueue;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.<init>(Ljava/util/SortedSet;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.add(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueOffer, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.addAll(Ljava/util/Collection;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueAddAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueClear}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.comparator()Ljava/util/Comparator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueComparator}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.contains(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueContains, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.element()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueElement}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.forEach(Ljava/util/function/Consumer;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueForEach, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueIsEmpty}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.iterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: setIterator}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.offer(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueOffer, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.peek()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueuePeek}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.poll()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueuePoll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.remove()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueRemoveHead, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.remove(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueRemove, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.removeIf(Ljava/util/function/Predicate;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: priorityqueueRemoveIf, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueSize}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.spliterator()Ljava/util/Spliterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.toArray()[Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueToArray}
+
+	ghelpers.MethodSignatures["java/util/PriorityQueue.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: priorityqueueToString, NeedsContext: true}
+}
+
+// --- heap mechanics ---
+
+func (pq *priorityQueue) less(fs *list.List, ix, jx int) (bool, *ghelpers.GErrBlk) {
+	cmp, gerr := javaCompare(fs, pq.comparator, pq.heap[ix], pq.heap[jx])
+	return cmp < 0, gerr
+}
+
+func (pq *priorityQueue) siftUp(fs *list.List, ix int) *ghelpers.GErrBlk {
+	for ix > 0 {
+		parent := (ix - 1) / 2
+		isLess, gerr := pq.less(fs, ix, parent)
+		if gerr != nil {
+			return gerr
+		}
+		if !isLess {
+			break
+		}
+		pq.heap[ix], pq.heap[parent] = pq.heap[parent], pq.heap[ix]
+		ix = parent
+	}
+	return nil
+}
+
+func (pq *priorityQueue) siftDown(fs *list.List, ix int) *ghelpers.GErrBlk {
+	size := len(pq.heap)
+	for {
+		smallest := ix
+		for _, child := range []int{2*ix + 1, 2*ix + 2} {
+			if child >= size {
+				continue
+			}
+			isLess, gerr := pq.less(fs, child, smallest)
+			if gerr != nil {
+				return gerr
+			}
+			if isLess {
+				smallest = child
+			}
+		}
+		if smallest == ix {
+			return nil
+		}
+		pq.heap[ix], pq.heap[smallest] = pq.heap[smallest], pq.heap[ix]
+		ix = smallest
+	}
+}
+
+func (pq *priorityQueue) offer(fs *list.List, elem any) *ghelpers.GErrBlk {
+	if isNullValue(elem) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "PriorityQueue: null elements are not permitted")
+	}
+	// A lone element is compared with itself so that an uncomparable element is rejected, as in the JDK.
+	if len(pq.heap) == 0 {
+		if _, gerr := javaCompare(fs, pq.comparator, elem, elem); gerr != nil {
+			return gerr
+		}
+	}
+	pq.heap = append(pq.heap, elem)
+	return pq.siftUp(fs, len(pq.heap)-1)
+}
+
+// removeAt removes the element at index ix and restores the heap property.
+func (pq *priorityQueue) removeAt(fs *list.List, ix int) (any, *ghelpers.GErrBlk) {
+	elem := pq.heap[ix]
+	last := len(pq.heap) - 1
+	pq.heap[ix] = pq.heap[last]
+	pq.heap[last] = nil
+	pq.heap = pq.heap[:last]
+	if ix < last {
+		if gerr := pq.siftDown(fs, ix); gerr != nil {
+			return nil, gerr
+		}
+		if gerr := pq.siftUp(fs, ix); gerr != nil {
+			return nil, gerr
+		}
+	}
+	return elem, nil
+}
+
+// heapify restores the heap property of the whole slice, after elements have been removed from it.
+func (pq *priorityQueue) heapify(fs *list.List) *ghelpers.GErrBlk {
+	for ix := len(pq.heap)/2 - 1; ix >= 0; ix-- {
+		if gerr := pq.siftDown(fs, ix); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// --- helpers for getting at the Go state of PriorityQueue objects ---
+
+func priorityqueueThis(params []interface{}, caller string) (*list.List, []interface{}, *priorityQueue, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing 'this' parameter")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(this) {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": 'this' is null")
+	}
+	pq, ok := this.FieldTable[fieldNamePriorityQueue].Fvalue.(*priorityQueue)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(this.KlassName)
+		errMsg := fmt.Sprintf("%s: %s object has not been initialized", caller, className)
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+	}
+	return fs, args, pq, nil
+}
+
+func setPriorityQueue(obj *object.Object, pq *priorityQueue) {
+	obj.FieldTable[fieldNamePriorityQueue] = object.Field{Ftype: types.RawGoPointer, Fvalue: pq}
+}
+
+// --- PriorityQueue G functions ---
+
+// PriorityQueue(), (int), (Comparator), (int, Comparator)
+func priorityqueueInit(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "priorityqueueInit: invalid 'this' argument")
+	}
+	pq := &priorityQueue{}
+	for _, param := range params[1:] {
+		switch p := param.(type) {
+		case int64:
+			if p < 1 {
+				return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "priorityqueueInit: initial capacity must be at least 1")
+			}
+		case *object.Object:
+			if !object.IsNull(p) {
+				pq.comparator = p
+			}
+		}
+	}
+	setPriorityQueue(this, pq)
+	return nil
+}
+
+// PriorityQueue(Collection), (PriorityQueue), (SortedSet). As in the JDK, a sorted set or
+// priority queue passes along its comparator even when passed as a plain Collection.
+func priorityqueueInitFromCollection(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "priorityqueueInitFromCollection: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "priorityqueueInitFromCollection: collection is null")
+	}
+	pq := &priorityQueue{}
+	pq.comparator, _ = comparatorOf(source)
+	setPriorityQueue(this, pq)
+
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, elem := range elements {
+		if gerr = pq.offer(fs, elem); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+// add() and offer()
+func priorityqueueOffer(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueOffer")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr = pq.offer(fs, args[1]); gerr != nil {
+		return gerr
+	}
+	return types.JavaBoolTrue
+}
+
+func priorityqueueAddAll(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueAddAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "priorityqueueAddAll: collection is null")
+	}
+	if source == args[0] {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "priorityqueueAddAll: cannot add a queue to itself")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, elem := range elements {
+		if gerr = pq.offer(fs, elem); gerr != nil {
+			return gerr
+		}
+	}
+	return object.JavaBooleanFromGoBoolean(len(elements) > 0)
+}
+
+func priorityqueuePeek(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueuePeek")
+	if gerr != nil {
+		return gerr
+	}
+	if len(pq.heap) == 0 {
+		return object.Null
+	}
+	return pq.heap[0]
+}
+
+func priorityqueueElement(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueElement")
+	if gerr != nil {
+		return gerr
+	}
+	if len(pq.heap) == 0 {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "priorityqueueElement: queue is empty")
+	}
+	return pq.heap[0]
+}
+
+func priorityqueuePoll(params []interface{}) interface{} {
+	fs, _, pq, gerr := priorityqueueThis(params, "priorityqueuePoll")
+	if gerr != nil {
+		return gerr
+	}
+	if len(pq.heap) == 0 {
+		return object.Null
+	}
+	elem, gerr := pq.removeAt(fs, 0)
+	if gerr != nil {
+		return gerr
+	}
+	return elem
+}
+
+// remove() with no arguments removes the head of the queue.
+func priorityqueueRemoveHead(params []interface{}) interface{} {
+	fs, _, pq, gerr := priorityqueueThis(params, "priorityqueueRemoveHead")
+	if gerr != nil {
+		return gerr
+	}
+	if len(pq.heap) == 0 {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "priorityqueueRemoveHead: queue is empty")
+	}
+	elem, gerr := pq.removeAt(fs, 0)
+	if gerr != nil {
+		return gerr
+	}
+	return elem
+}
+
+// remove(Object) removes a single element that equals() the argument.
+func priorityqueueRemove(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueRemove")
+	if gerr != nil {
+		return gerr
+	}
+	for ix, elem := range pq.heap {
+		eq, gerr := javaEquals(fs, args[1], elem)
+		if gerr != nil {
+			return gerr
+		}
+		if eq {
+			if _, gerr = pq.removeAt(fs, ix); gerr != nil {
+				return gerr
+			}
+			return types.JavaBoolTrue
+		}
+	}
+	return types.JavaBoolFalse
+}
+
+func priorityqueueContains(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueContains")
+	if gerr != nil {
+		return gerr
+	}
+	for _, elem := range pq.heap {
+		eq, gerr := javaEquals(fs, args[1], elem)
+		if gerr != nil {
+			return gerr
+		}
+		if eq {
+			return types.JavaBoolTrue
+		}
+	}
+	return types.JavaBoolFalse
+}
+
+func priorityqueueSize(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueSize")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(pq.heap))
+}
+
+func priorityqueueIsEmpty(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueIsEmpty")
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(len(pq.heap) == 0)
+}
+
+func priorityqueueClear(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueClear")
+	if gerr != nil {
+		return gerr
+	}
+	pq.heap = nil
+	return nil
+}
+
+func priorityqueueComparator(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueComparator")
+	if gerr != nil {
+		return gerr
+	}
+	if pq.comparator == nil {
+		return object.Null
+	}
+	return pq.comparator
+}
+
+// forEach(Consumer) visits the elements in heap order, as the iterator does.
+func priorityqueueForEach(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueForEach")
+	if gerr != nil {
+		return gerr
+	}
+	action, _ := args[1].(*object.Object)
+	if gerr = forEachElement(fs, action, slices.Clone(pq.heap), "priorityqueueForEach"); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+func priorityqueueRemoveIf(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueRemoveIf")
+	if gerr != nil {
+		return gerr
+	}
+	filter, _ := args[1].(*object.Object)
+	kept, removed, gerr := filterElements(fs, filter, slices.Clone(pq.heap), "priorityqueueRemoveIf")
+	if gerr != nil {
+		return gerr
+	}
+	if removed {
+		pq.heap = kept
+		if gerr = pq.heapify(fs); gerr != nil {
+			return gerr
+		}
+	}
+	return object.JavaBooleanFromGoBoolean(removed)
+}
+
+// toArray() returns the elements in heap order.
+func priorityqueueToArray(params []interface{}) interface{} {
+	_, _, pq, gerr := priorityqueueThis(params, "priorityqueueToArray")
+	if gerr != nil {
+		return gerr
+	}
+	arr := object.Make1DimRefArray("java/lang/Object;", int64(len(pq.heap)))
+	refs := arr.FieldTable["value"].Fvalue.([]*object.Object)
+	for ix, elem := range pq.heap {
+		refs[ix], _ = elem.(*object.Object)
+	}
+	return arr
+}
+
+func priorityqueueToString(params []interface{}) interface{} {
+	fs, args, pq, gerr := priorityqueueThis(params, "priorityqueueToString")
+	if gerr != nil {
+		return gerr
+	}
+	elements := make([]any, len(pq.heap))
+	copy(elements, pq.heap)
+	return object.StringObjectFromGoString(formatElements(fs, args[0].(*object.Object), elements))
+}
@@ -0,0 +1,120 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newPriorityQueue(t *testing.T, comparator *object.Object) *object.Object {
+	t.Helper()
+	pq := object.MakeEmptyObjectWithClassName(&classNamePriorityQueue)
+	params := []interface{}{pq}
+	if comparator != nil {
+		params = append(params, comparator)
+	}
+	if ret := priorityqueueInit(params); ret != nil {
+		t.Fatalf("priorityqueueInit returned error: %v", ret)
+	}
+	return pq
+}
+
+func TestPriorityQueue_PollsInPriorityOrder(t *testing.T) {
+	globals.InitStringPool()
+	pq := newPriorityQueue(t, nil)
+	for _, v := range []int64{5, 1, 4, 2, 3, 1} {
+		if ret := priorityqueueOffer([]interface{}{pq, intKey(v)}); ret != types.JavaBoolTrue {
+			t.Fatalf("offer(%d) returned %v", v, ret)
+		}
+	}
+	if got := keyInt(t, priorityqueuePeek([]interface{}{pq})); got != 1 {
+		t.Errorf("peek: expected 1, got %d", got)
+	}
+	var got []int64
+	for priorityqueueIsEmpty([]interface{}{pq}) == types.JavaBoolFalse {
+		got = append(got, keyInt(t, priorityqueuePoll([]interface{}{pq})))
+	}
+	want := []int64{1, 1, 2, 3, 4, 5}
+	for ix := range want {
+		if got[ix] != want[ix] {
+			t.Fatalf("poll order: expected %v, got %v", want, got)
+		}
+	}
+	if ret := priorityqueuePoll([]interface{}{pq}); ret != object.Null {
+		t.Errorf("poll on an empty queue should return null")
+	}
+	ret := priorityqueueRemoveHead([]interface{}{pq})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NoSuchElementException {
+		t.Errorf("remove() on an empty queue: expected NoSuchElementException, got %v", ret)
+	}
+}
+
+func TestPriorityQueue_ComparatorAndRemove(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_TreeMap() // registers the reverse comparator
+	pq := newPriorityQueue(t, makeReverseComparator(nil))
+	for _, v := range []int64{2, 9, 4} {
+		priorityqueueOffer([]interface{}{pq, intKey(v)})
+	}
+	if ret := priorityqueueRemove([]interface{}{pq, intKey(4)}); ret != types.JavaBoolTrue {
+		t.Errorf("remove(4) should return true")
+	}
+	if got := keyInt(t, priorityqueuePoll([]interface{}{pq})); got != 9 {
+		t.Errorf("poll with reverse order: expected 9, got %d", got)
+	}
+	if got := keyInt(t, priorityqueuePoll([]interface{}{pq})); got != 2 {
+		t.Errorf("poll with reverse order: expected 2, got %d", got)
+	}
+}
+
+func TestPriorityQueue_RejectsNull(t *testing.T) {
+	globals.InitStringPool()
+	pq := newPriorityQueue(t, nil)
+	ret := priorityqueueOffer([]interface{}{pq, object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NullPointerException {
+		t.Errorf("offer(null): expected NullPointerException, got %v", ret)
+	}
+}
+
+func TestPriorityQueue_RemoveIfAndForEach(t *testing.T) {
+	globals.InitStringPool()
+	pq := newPriorityQueue(t, nil)
+	for _, v := range []int64{6, 3, 8, 1, 4, 7, 2, 5} {
+		priorityqueueOffer([]interface{}{pq, intKey(v)})
+	}
+	isEven := newTestFunction("test/IsEven", "test", methTypeTest, func(args []interface{}) interface{} {
+		return object.JavaBooleanFromGoBoolean(intOf(args[0])%2 == 0)
+	})
+	if ret := priorityqueueRemoveIf([]interface{}{pq, isEven}); ret != types.JavaBoolTrue {
+		t.Fatalf("removeIf: expected true, got %v", ret)
+	}
+
+	var sum int64
+	adder := newTestFunction("test/PQAdder", "accept", methTypeAccept, func(args []interface{}) interface{} {
+		sum += intOf(args[0])
+		return nil
+	})
+	if ret := priorityqueueForEach([]interface{}{pq, adder}); ret != nil {
+		t.Fatalf("forEach returned %v", ret)
+	}
+	if sum != 1+3+5+7 {
+		t.Errorf("forEach: expected a sum of 16, got %d", sum)
+	}
+	for _, want := range []int64{1, 3, 5, 7} {
+		if got := keyInt(t, priorityqueuePoll([]interface{}{pq})); got != want {
+			t.Fatalf("poll after removeIf: expected %d, got %d", want, got)
+		}
+	}
+	if ret := priorityqueueRemoveIf([]interface{}{pq, isEven}); ret != types.JavaBoolFalse {
+		t.Errorf("removeIf on an empty queue: expected false, got %v", ret)
+	}
+}
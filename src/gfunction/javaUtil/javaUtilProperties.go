@@ -7,13 +7,23 @@
 package javaUtil
 
 import (
+	"container/list"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
 	"jacobin/src/object"
 	"jacobin/src/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode/utf16"
 )
 
 // Implementation of some of the functions in Java/util/Locale.
@@ -65,38 +75,43 @@ func Load_Util_Properties() {
 
 	ghelpers.MethodSignatures["java/util/Properties.list(Ljava/io/PrintStream;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    propertiesList,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.list(Ljava/io/PrintWriter;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    propertiesList,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.load(Ljava/io/InputStream;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    propertiesLoadStream,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.load(Ljava/io/Reader;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    propertiesLoadReader,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.loadFromXML(Ljava/io/InputStream;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    propertiesLoadFromXML,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.propertyNames()Ljava/util/Enumeration;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  propertiesPropertyNames,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
@@ -107,8 +122,9 @@ func Load_Util_Properties() {
 
 	ghelpers.MethodSignatures["java/util/Properties.save(Ljava/io/OutputStream;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    propertiesSave,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.setProperty(Ljava/lang/String;Ljava/lang/String;)Ljava/lang/Object;"] =
@@ -125,38 +141,44 @@ func Load_Util_Properties() {
 
 	ghelpers.MethodSignatures["java/util/Properties.store(Ljava/io/OutputStream;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    propertiesStoreStream,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.store(Ljava/io/Writer;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    propertiesStoreWriter,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.storeToXML(Ljava/io/OutputStream;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    propertiesStoreToXML,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.storeToXML(Ljava/io/OutputStream;Ljava/lang/String;Ljava/lang/String;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   3,
+			GFunction:    propertiesStoreToXML,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.storeToXML(Ljava/io/OutputStream;Ljava/lang/String;Ljava/nio/charset/Charset;)V"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   3,
+			GFunction:    propertiesStoreToXML,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.stringPropertyNames()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    propertiesStringPropertyNames,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Properties.toString()Ljava/lang/String;"] =
@@ -405,3 +427,684 @@ func propertiesToString(params []interface{}) interface{} {
 	// Return longString as a Java String.
 	return object.StringObjectFromGoString(longString)
 }
+
+// --- load, store, list and the XML form ---
+
+// getPropertiesTable returns the properties table of the Properties object this.
+func getPropertiesTable(this any, caller string) (types.DefProperties, *ghelpers.GErrBlk) {
+	obj, ok := this.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		errMsg := fmt.Sprintf("%s: Properties object is invalid: {type %T, value %v}", caller, this, this)
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	properties, ok := obj.FieldTable[types.FieldNameProperties].Fvalue.(types.DefProperties)
+	if !ok {
+		errMsg := caller + ": properties table is missing or invalid"
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
+	}
+	return properties, nil
+}
+
+// sortedPropertyKeys returns the keys of properties in ascending order, the order in which
+// store() writes them.
+func sortedPropertyKeys(properties types.DefProperties) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// java/util/Properties.load(Ljava/io/InputStream;)V -- the stream is read as ISO 8859-1
+func propertiesLoadStream(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "Properties.load")
+	if gerr != nil {
+		return gerr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ghelpers.StreamErrBlk(err)
+	}
+	chars := make([]uint16, len(data))
+	for ix, b := range data {
+		chars[ix] = uint16(b)
+	}
+	return propertiesLoad(params[0], chars)
+}
+
+// java/util/Properties.load(Ljava/io/Reader;)V
+func propertiesLoadReader(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	chars, gerr := readAllChars(fs, params[1], "Properties.load")
+	if gerr != nil {
+		return gerr
+	}
+	return propertiesLoad(params[0], chars)
+}
+
+// readAllChars reads the rest of the Java Reader source as UTF-16 chars. A reader with a file
+// handle is decoded with its charset; any other reader is read through its read() method, which
+// runs on the frame stack fs.
+func readAllChars(fs *list.List, source any, caller string) ([]uint16, *ghelpers.GErrBlk) {
+	switch src := source.(type) {
+	case *object.Object:
+		if object.IsNull(src) {
+			return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": reader is null")
+		}
+		if f, ok := src.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+			cr, ok := src.FieldTable[ghelpers.FileDecoder].Fvalue.(*ghelpers.CharReader)
+			if !ok {
+				cs, ok := src.FieldTable[ghelpers.FileCharset].Fvalue.(*ghelpers.Charset)
+				if !ok {
+					cs = ghelpers.DefaultCharset()
+				}
+				cr = ghelpers.NewCharReader(cs, f)
+			}
+			var chars []uint16
+			for {
+				r, err := cr.ReadCodePoint()
+				if err == io.EOF {
+					return chars, nil
+				}
+				if err != nil {
+					return nil, ghelpers.GetGErrBlk(excNames.IOException, caller+": "+err.Error())
+				}
+				chars = utf16.AppendRune(chars, r)
+			}
+		}
+		cs, gerr := ghelpers.GoCharSourceWithContext(fs, src, caller)
+		if gerr != nil {
+			return nil, gerr
+		}
+		var chars []uint16
+		for {
+			ch, err := cs.ReadChar()
+			if err == io.EOF {
+				return chars, nil
+			}
+			if err != nil {
+				return nil, ghelpers.StreamErrBlk(err)
+			}
+			chars = append(chars, uint16(ch))
+		}
+	case io.Reader:
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return nil, ghelpers.GetGErrBlk(excNames.IOException, caller+": "+err.Error())
+		}
+		return utf16.Encode([]rune(string(data))), nil
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": reader is null")
+}
+
+// propertiesLoad adds the properties in chars, which hold a .properties file, to the table of
+// the Properties object this.
+func propertiesLoad(this any, chars []uint16) interface{} {
+	properties, gerr := getPropertiesTable(this, "Properties.load")
+	if gerr != nil {
+		return gerr
+	}
+	loaded := make(types.DefProperties)
+	pos := 0
+	for {
+		line, ok := nextLogicalLine(chars, &pos)
+		if !ok {
+			break
+		}
+		key, value, gerr := splitPropertyLine(line)
+		if gerr != nil {
+			return gerr
+		}
+		loaded[key] = value
+	}
+
+	propertiesMutex.Lock()
+	defer propertiesMutex.Unlock()
+	for key, value := range loaded {
+		properties[key] = value
+	}
+	return nil
+}
+
+func isPropertyWhitespace(c uint16) bool {
+	return c == ' ' || c == '\t' || c == '\f'
+}
+
+// nextLogicalLine returns the next logical line of the .properties text chars, starting at
+// *pos, and advances *pos past it. Leading whitespace, blank lines and comment lines are
+// skipped, and a line that ends with an odd number of backslashes is joined to the next line,
+// whose leading whitespace is dropped. It returns false at the end of the text.
+func nextLogicalLine(chars []uint16, pos *int) ([]uint16, bool) {
+	var line []uint16
+	skipWhitespace := true // at the start of a natural line
+	isNewLine := true      // at the start of a logical line
+	isComment := false
+	backslashes := 0 // the number of backslashes that the line ends with
+	for {
+		if *pos >= len(chars) {
+			if isComment || len(line) == 0 {
+				return nil, false
+			}
+			if backslashes%2 == 1 {
+				line = line[:len(line)-1] // a continuation at the end of the text ends the line
+			}
+			return line, true
+		}
+		c := chars[*pos]
+		*pos++
+
+		if skipWhitespace {
+			if isPropertyWhitespace(c) {
+				continue
+			}
+			if !isNewLine && (c == '\r' || c == '\n') {
+				continue // a continuation line that holds only whitespace
+			}
+			skipWhitespace = false
+			isNewLine = false
+			if len(line) == 0 && !isComment && (c == '#' || c == '!') {
+				isComment = true
+				continue
+			}
+		}
+
+		if c != '\n' && c != '\r' {
+			if !isComment {
+				line = append(line, c)
+				if c == '\\' {
+					backslashes++
+				} else {
+					backslashes = 0
+				}
+			}
+			continue
+		}
+
+		// the end of a natural line
+		if c == '\r' && *pos < len(chars) && chars[*pos] == '\n' {
+			*pos++
+		}
+		if isComment || len(line) == 0 {
+			isComment = false
+			isNewLine = true
+			skipWhitespace = true
+			line = line[:0]
+			backslashes = 0
+			continue
+		}
+		if backslashes%2 == 1 {
+			line = line[:len(line)-1]
+			backslashes = 0
+			skipWhitespace = true
+			continue
+		}
+		return line, true
+	}
+}
+
+// splitPropertyLine splits a logical line into its key and its value, which are unescaped.
+// The key ends at the first unescaped '=', ':' or whitespace.
+func splitPropertyLine(line []uint16) (string, string, *ghelpers.GErrBlk) {
+	keyLen := 0
+	valueStart := len(line)
+	hasSep := false
+	precedingBackslash := false
+	for keyLen < len(line) {
+		c := line[keyLen]
+		if (c == '=' || c == ':') && !precedingBackslash {
+			valueStart = keyLen + 1
+			hasSep = true
+			break
+		}
+		if isPropertyWhitespace(c) && !precedingBackslash {
+			valueStart = keyLen + 1
+			break
+		}
+		precedingBackslash = c == '\\' && !precedingBackslash
+		keyLen++
+	}
+	for valueStart < len(line) {
+		c := line[valueStart]
+		if !isPropertyWhitespace(c) {
+			if !hasSep && (c == '=' || c == ':') {
+				hasSep = true
+			} else {
+				break
+			}
+		}
+		valueStart++
+	}
+	key, gerr := unescapeProperty(line[:keyLen])
+	if gerr != nil {
+		return "", "", gerr
+	}
+	value, gerr := unescapeProperty(line[valueStart:])
+	if gerr != nil {
+		return "", "", gerr
+	}
+	return key, value, nil
+}
+
+// unescapeProperty converts the escapes \t, \n, \r, \f and \uXXXX in chars; a backslash
+// before any other char is dropped.
+func unescapeProperty(chars []uint16) (string, *ghelpers.GErrBlk) {
+	out := make([]uint16, 0, len(chars))
+	for ix := 0; ix < len(chars); ix++ {
+		c := chars[ix]
+		if c != '\\' {
+			out = append(out, c)
+			continue
+		}
+		ix++
+		if ix >= len(chars) {
+			break
+		}
+		c = chars[ix]
+		switch c {
+		case 'u':
+			if ix+4 >= len(chars) {
+				return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Malformed \\uxxxx encoding.")
+			}
+			value, err := strconv.ParseUint(string(utf16.Decode(chars[ix+1:ix+5])), 16, 16)
+			if err != nil {
+				return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Malformed \\uxxxx encoding.")
+			}
+			out = append(out, uint16(value))
+			ix += 4
+		case 't':
+			out = append(out, '\t')
+		case 'r':
+			out = append(out, '\r')
+		case 'n':
+			out = append(out, '\n')
+		case 'f':
+			out = append(out, '\f')
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(utf16.Decode(out)), nil
+}
+
+// escapeProperty escapes s for store(), as the JDK does. Spaces are escaped everywhere in keys,
+// and only at the start of values. If escUnicode is set, chars outside printable ASCII are
+// written as \uXXXX.
+func escapeProperty(s string, escapeSpace, escUnicode bool) string {
+	var sb strings.Builder
+	for ix, c := range utf16.Encode([]rune(s)) {
+		if c > 61 && c < 127 {
+			if c == '\\' {
+				sb.WriteString(`\\`)
+			} else {
+				sb.WriteByte(byte(c))
+			}
+			continue
+		}
+		switch c {
+		case ' ':
+			if ix == 0 || escapeSpace {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteByte(byte(c))
+		default:
+			if (c < 0x20 || c > 0x7e) && escUnicode {
+				fmt.Fprintf(&sb, `\u%04X`, c)
+			} else {
+				sb.WriteString(string(utf16.Decode([]uint16{c})))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// propertiesComments returns the comment lines that store() writes for comments: each line
+// begins with '#' unless it already begins with '#' or '!', and chars above ÿ are escaped.
+func propertiesComments(comments, newline string) string {
+	var sb strings.Builder
+	sb.WriteByte('#')
+	chars := utf16.Encode([]rune(comments))
+	last := 0
+	for ix := 0; ix < len(chars); ix++ {
+		c := chars[ix]
+		if c <= 0xff && c != '\n' && c != '\r' {
+			continue
+		}
+		sb.WriteString(string(utf16.Decode(chars[last:ix])))
+		if c > 0xff {
+			fmt.Fprintf(&sb, `\u%04X`, c)
+		} else {
+			sb.WriteString(newline)
+			if c == '\r' && ix != len(chars)-1 && chars[ix+1] == '\n' {
+				ix++
+			}
+			if ix == len(chars)-1 || (chars[ix+1] != '#' && chars[ix+1] != '!') {
+				sb.WriteByte('#')
+			}
+		}
+		last = ix + 1
+	}
+	sb.WriteString(string(utf16.Decode(chars[last:])))
+	sb.WriteString(newline)
+	return sb.String()
+}
+
+// propertiesStoreText returns what store() writes for the Properties object this: the comments,
+// a date line, and the properties in key order.
+func propertiesStoreText(this any, commentsObj any, escUnicode bool) (string, *ghelpers.GErrBlk) {
+	properties, gerr := getPropertiesTable(this, "Properties.store")
+	if gerr != nil {
+		return "", gerr
+	}
+	newline := globals.GetSystemProperty("line.separator")
+	if newline == "" {
+		newline = "\n"
+	}
+
+	var sb strings.Builder
+	if obj, ok := commentsObj.(*object.Object); ok && !object.IsNull(obj) {
+		sb.WriteString(propertiesComments(object.GoStringFromStringObject(obj), newline))
+	}
+	// The system property java.properties.date replaces the date, for reproducible output.
+	if date := globals.GetSystemProperty("java.properties.date"); date != "" {
+		sb.WriteString(propertiesComments(date, newline))
+	} else {
+		sb.WriteString("#" + time.Now().Format("Mon Jan 02 15:04:05 MST 2006") + newline)
+	}
+
+	propertiesMutex.RLock()
+	defer propertiesMutex.RUnlock()
+	for _, key := range sortedPropertyKeys(properties) {
+		sb.WriteString(escapeProperty(key, true, escUnicode))
+		sb.WriteByte('=')
+		sb.WriteString(escapeProperty(properties[key], false, escUnicode))
+		sb.WriteString(newline)
+	}
+	return sb.String(), nil
+}
+
+// java/util/Properties.store(Ljava/io/OutputStream;Ljava/lang/String;)V -- written in ISO 8859-1
+func propertiesStoreStream(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	text, gerr := propertiesStoreText(params[0], params[2], true)
+	if gerr != nil {
+		return gerr
+	}
+	return writePropertiesText(fs, params[1], ghelpers.CharsetISO88591.EncodeReplacing(text), "Properties.store")
+}
+
+// java/util/Properties.store(Ljava/io/Writer;Ljava/lang/String;)V
+func propertiesStoreWriter(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	text, gerr := propertiesStoreText(params[0], params[2], false)
+	if gerr != nil {
+		return gerr
+	}
+	return writePropertiesText(fs, params[1], []byte(text), "Properties.store")
+}
+
+// java/util/Properties.save(Ljava/io/OutputStream;Ljava/lang/String;)V -- store() that ignores
+// I/O errors
+func propertiesSave(params []interface{}) interface{} {
+	if gerr, ok := propertiesStoreStream(params).(*ghelpers.GErrBlk); ok && gerr.ExceptionType != excNames.IOException {
+		return gerr
+	}
+	return nil
+}
+
+func writePropertiesText(fs *list.List, target any, data []byte, caller string) interface{} {
+	w, gerr := ghelpers.GoWriterWithContext(fs, target, caller)
+	if gerr != nil {
+		return gerr
+	}
+	if _, err := w.Write(data); err != nil {
+		return ghelpers.StreamErrBlk(err)
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return ghelpers.StreamErrBlk(err)
+		}
+	}
+	return nil
+}
+
+// java/util/Properties.list(Ljava/io/PrintStream;)V and list(Ljava/io/PrintWriter;)V -- values
+// longer than 40 chars are cut to 37 chars and "..."
+func propertiesList(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	properties, gerr := getPropertiesTable(params[0], "Properties.list")
+	if gerr != nil {
+		return gerr
+	}
+	var sb strings.Builder
+	sb.WriteString("-- listing properties --\n")
+	propertiesMutex.RLock()
+	for _, key := range sortedPropertyKeys(properties) {
+		value := utf16.Encode([]rune(properties[key]))
+		if len(value) > 40 {
+			value = append(value[:37], '.', '.', '.')
+		}
+		sb.WriteString(key + "=" + string(utf16.Decode(value)) + "\n")
+	}
+	propertiesMutex.RUnlock()
+	return writePropertiesText(fs, params[1], []byte(sb.String()), "Properties.list")
+}
+
+// java/util/Properties.propertyNames()Ljava/util/Enumeration;
+func propertiesPropertyNames(params []interface{}) interface{} {
+	properties, gerr := getPropertiesTable(params[0], "Properties.propertyNames")
+	if gerr != nil {
+		return gerr
+	}
+	propertiesMutex.RLock()
+	defer propertiesMutex.RUnlock()
+	keys := sortedPropertyKeys(properties)
+	elements := make([]any, len(keys))
+	for ix, key := range keys {
+		elements[ix] = object.StringObjectFromGoString(key)
+	}
+	return NewEnumeration(elements)
+}
+
+// java/util/Properties.stringPropertyNames()Ljava/util/Set; -- an unmodifiable set
+func propertiesStringPropertyNames(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	properties, gerr := getPropertiesTable(params[0], "Properties.stringPropertyNames")
+	if gerr != nil {
+		return gerr
+	}
+	propertiesMutex.RLock()
+	keys := sortedPropertyKeys(properties)
+	propertiesMutex.RUnlock()
+	elements := make([]any, len(keys))
+	for ix, key := range keys {
+		elements[ix] = object.StringObjectFromGoString(key)
+	}
+	set, gerr := makeHashSetInOrder(fs, elements)
+	if gerr != nil {
+		return gerr
+	}
+	return markUnmodifiable(set)
+}
+
+// The XML form, which follows http://java.sun.com/dtd/properties.dtd:
+//
+//	<!ELEMENT properties ( comment?, entry* ) >
+//	<!ELEMENT comment (#PCDATA) >
+//	<!ELEMENT entry (#PCDATA) >
+//	<!ATTLIST entry key CDATA #REQUIRED>
+
+const propertiesDoctype = `<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">`
+
+// java/util/Properties.storeToXML(Ljava/io/OutputStream;Ljava/lang/String;)V and the variants
+// that take an encoding name or a Charset. The default encoding is UTF-8.
+func propertiesStoreToXML(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	properties, gerr := getPropertiesTable(params[0], "Properties.storeToXML")
+	if gerr != nil {
+		return gerr
+	}
+	cs := ghelpers.LookupCharset("UTF-8")
+	if len(params) > 3 {
+		encObj, ok := params[3].(*object.Object)
+		if !ok || object.IsNull(encObj) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Properties.storeToXML: encoding is null")
+		}
+		if object.IsStringObject(encObj) {
+			name := object.GoStringFromStringObject(encObj)
+			if cs = ghelpers.LookupCharset(name); cs == nil {
+				return ghelpers.GetGErrBlk(excNames.UnsupportedEncodingException, name)
+			}
+		} else if cs, gerr = ghelpers.CharsetFromObject(encObj); gerr != nil {
+			return gerr
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="` + cs.Name + `" standalone="no"?>` + "\n")
+	sb.WriteString(propertiesDoctype + "\n")
+	sb.WriteString("<properties>\n")
+	if obj, ok := params[2].(*object.Object); ok && !object.IsNull(obj) {
+		sb.WriteString("<comment>" + escapeXMLText(object.GoStringFromStringObject(obj), cs, false) + "</comment>\n")
+	}
+	propertiesMutex.RLock()
+	for _, key := range sortedPropertyKeys(properties) {
+		sb.WriteString(`<entry key="` + escapeXMLText(key, cs, true) + `">`)
+		sb.WriteString(escapeXMLText(properties[key], cs, false) + "</entry>\n")
+	}
+	propertiesMutex.RUnlock()
+	sb.WriteString("</properties>\n")
+
+	encoded, _ := cs.Encode(sb.String(), ghelpers.CodingReplace, ghelpers.CodingReplace, cs.DefaultReplacement())
+	return writePropertiesText(fs, params[1], encoded, "Properties.storeToXML")
+}
+
+// escapeXMLText escapes s for XML content, or for an attribute value if inAttr is set. Chars
+// that cs cannot encode are written as character references.
+func escapeXMLText(s string, cs *ghelpers.Charset, inAttr bool) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '&':
+			sb.WriteString("&amp;")
+		case r == '<':
+			sb.WriteString("&lt;")
+		case r == '>':
+			sb.WriteString("&gt;")
+		case r == '"' && inAttr:
+			sb.WriteString("&quot;")
+		case !cs.CanEncode(r):
+			fmt.Fprintf(&sb, "&#%d;", r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// java/util/Properties.loadFromXML(Ljava/io/InputStream;)V -- the stream is closed afterwards
+func propertiesLoadFromXML(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	properties, gerr := getPropertiesTable(params[0], "Properties.loadFromXML")
+	if gerr != nil {
+		return gerr
+	}
+	r, gerr := ghelpers.GoReaderWithContext(fs, params[1], "Properties.loadFromXML")
+	if gerr != nil {
+		return gerr
+	}
+	loaded, err := parsePropertiesXML(r)
+	if in, ok := params[1].(*object.Object); ok {
+		closeJavaStream(fs, in)
+	}
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.InvalidPropertiesFormatException, err.Error())
+	}
+	propertiesMutex.Lock()
+	defer propertiesMutex.Unlock()
+	for key, value := range loaded {
+		properties[key] = value
+	}
+	return nil
+}
+
+// parsePropertiesXML reads the XML form of a Properties object.
+func parsePropertiesXML(r io.Reader) (types.DefProperties, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		cs := ghelpers.LookupCharset(label)
+		if cs == nil {
+			return nil, fmt.Errorf("unsupported encoding: %s", label)
+		}
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(cs.DecodeReplacing(data)), nil
+	}
+
+	loaded := make(types.DefProperties)
+	depth := 0
+	seenEntry := false
+	var key string
+	var text strings.Builder
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.New("XML document structures must start and end within the same entity.")
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1 && t.Name.Local != "properties":
+				return nil, fmt.Errorf("Element type \"%s\" must be declared: expected \"properties\"", t.Name.Local)
+			case depth == 2 && t.Name.Local == "comment":
+				if seenEntry {
+					return nil, errors.New("Element \"comment\" must precede the \"entry\" elements")
+				}
+			case depth == 2 && t.Name.Local == "entry":
+				seenEntry = true
+				found := false
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "key" {
+						key, found = attr.Value, true
+					}
+				}
+				if !found {
+					return nil, errors.New("Attribute \"key\" is required and must be specified for element type \"entry\"")
+				}
+				text.Reset()
+			case depth >= 2:
+				return nil, fmt.Errorf("Element type \"%s\" is not allowed here", t.Name.Local)
+			}
+		case xml.CharData:
+			if depth == 2 {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if depth == 2 && t.Name.Local == "entry" {
+				loaded[key] = text.String()
+			}
+			depth--
+			if depth == 0 {
+				return loaded, nil
+			}
+		}
+	}
+}
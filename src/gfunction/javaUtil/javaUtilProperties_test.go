@@ -1,11 +1,15 @@
 package javaUtil
 
 import (
+	"bytes"
+	"container/list"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
 	"jacobin/src/object"
+	"jacobin/src/testutil"
 	"jacobin/src/types"
+	"strings"
 	"testing"
 )
 
@@ -23,17 +27,6 @@ func propInit(t *testing.T, p *object.Object) {
 
 func s(str string) *object.Object { return object.StringObjectFromGoString(str) }
 
-func expectErrType(t *testing.T, got interface{}, expected int) {
-	t.Helper()
-	geb, ok := got.(*ghelpers.GErrBlk)
-	if !ok {
-		t.Fatalf("expected error block, got %T", got)
-	}
-	if geb.ExceptionType != expected {
-		t.Fatalf("expected exception type %d, got %d", expected, geb.ExceptionType)
-	}
-}
-
 func TestProperties_Set_Get_Size_Remove_ToString(t *testing.T) {
 	globals.InitStringPool()
 
@@ -104,7 +97,7 @@ func TestProperties_Error_Paths(t *testing.T) {
 	if err := propertiesSize([]interface{}{int64(5)}); err == nil {
 		t.Fatalf("expected error for non-object first param in size")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
 	}
 
 	// Missing map field (uninitialized) -> error
@@ -112,34 +105,213 @@ func TestProperties_Error_Paths(t *testing.T) {
 	if err := propertiesSize([]interface{}{raw}); err == nil {
 		t.Fatalf("expected error for missing map field in size")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
 	}
 
 	// setProperty with non-object key -> error
 	if err := PropertiesSetProperty([]interface{}{p, int64(1), s("x")}); err == nil {
 		t.Fatalf("expected error for non-object key in setProperty")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
 	}
 
 	// setProperty with non-object value -> error
 	if err := PropertiesSetProperty([]interface{}{p, s("k"), int64(7)}); err == nil {
 		t.Fatalf("expected error for non-object value in setProperty")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
 	}
 
 	// getProperty with non-object key -> error
 	if err := propertiesGetProperty([]interface{}{p, int64(3)}); err == nil {
 		t.Fatalf("expected error for non-object key in getProperty")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
 	}
 
 	// remove with non-object key -> error
 	if err := propertiesRemove([]interface{}{p, int64(9)}); err == nil {
 		t.Fatalf("expected error for non-object key in remove")
 	} else {
-		expectErrType(t, err, excNames.IllegalArgumentException)
+		testutil.ExpectGErr(t, err, excNames.IllegalArgumentException, "")
+	}
+}
+
+func propValue(t *testing.T, p *object.Object, key string) string {
+	t.Helper()
+	v, ok := propertiesGetProperty([]interface{}{p, s(key)}).(*object.Object)
+	if !ok || object.IsNull(v) {
+		t.Fatalf("property %q is missing", key)
+	}
+	return object.GoStringFromStringObject(v)
+}
+
+func TestProperties_Load_Grammar(t *testing.T) {
+	globals.InitStringPool()
+	p := newPropertiesObj()
+	propInit(t, p)
+
+	src := "# a comment\n" +
+		"! another comment \\\n" +
+		"   \n" +
+		"  alpha = one\n" +
+		"beta:two\r\n" +
+		"gamma three\n" +
+		"delta\t  =  four\n" +
+		"fruits apple, banana, \\\n" +
+		"        cherry\r" +
+		"key\\ with\\ spaces=v\\=x\n" +
+		"uni=caf\\u00e9\\tend\n" +
+		"empty\n" +
+		"last=trailing\\"
+	if ret := propertiesLoadStream([]interface{}{p, strings.NewReader(src)}); ret != nil {
+		t.Fatalf("load returned %v", ret)
+	}
+	want := map[string]string{
+		"alpha":           "one",
+		"beta":            "two",
+		"gamma":           "three",
+		"delta":           "four",
+		"fruits":          "apple, banana, cherry",
+		"key with spaces": "v=x",
+		"uni":             "café\tend",
+		"empty":           "",
+		"last":            "trailing",
+	}
+	for key, value := range want {
+		if got := propValue(t, p, key); got != value {
+			t.Errorf("%q: expected %q, got %q", key, value, got)
+		}
+	}
+	if sz := propertiesSize([]interface{}{p}).(int64); sz != int64(len(want)) {
+		t.Fatalf("expected %d properties, got %d", len(want), sz)
+	}
+
+	bad := newPropertiesObj()
+	propInit(t, bad)
+	testutil.ExpectGErr(t, propertiesLoadStream([]interface{}{bad, strings.NewReader("k=\\u12")}),
+		excNames.IllegalArgumentException, "")
+}
+
+func TestProperties_Load_ReaderException(t *testing.T) {
+	globals.InitStringPool()
+	p := newPropertiesObj()
+	propInit(t, p)
+
+	chars := []rune("k=v\n")
+	reader := newTestFunction("test/PropertiesFailingReader", "read", "()I", func(args []interface{}) interface{} {
+		if len(chars) == 0 {
+			return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "reader failed")
+		}
+		ch := chars[0]
+		chars = chars[1:]
+		return int64(ch)
+	})
+	testutil.ExpectGErr(t, propertiesLoadReader([]interface{}{p, reader}),
+		excNames.UnsupportedOperationException, "reader failed")
+}
+
+func TestProperties_Store_Escaping(t *testing.T) {
+	globals.InitGlobals("test")
+	globals.SetSystemProperty("java.properties.date", "fixed")
+	defer globals.RemoveSystemProperty("java.properties.date")
+
+	p := newPropertiesObj()
+	propInit(t, p)
+	_ = PropertiesSetProperty([]interface{}{p, s("b key"), s(" lead=x:y#z")})
+	_ = PropertiesSetProperty([]interface{}{p, s("a"), s("é€\n")})
+
+	var out bytes.Buffer
+	if ret := propertiesStoreStream([]interface{}{p, &out, s("line1\nline2")}); ret != nil {
+		t.Fatalf("store returned %v", ret)
+	}
+	want := "#line1\n#line2\n#fixed\na=\\u00E9\\u20AC\\n\nb\\ key=\\ lead\\=x\\:y\\#z\n"
+	if out.String() != want {
+		t.Fatalf("store wrote %q, expected %q", out.String(), want)
+	}
+
+	// what store wrote loads back the same
+	q := newPropertiesObj()
+	propInit(t, q)
+	propertiesLoadStream([]interface{}{q, bytes.NewReader(out.Bytes())})
+	if got := propValue(t, q, "a"); got != "é€\n" {
+		t.Fatalf("round trip of a: got %q", got)
+	}
+	if got := propValue(t, q, "b key"); got != " lead=x:y#z" {
+		t.Fatalf("round trip of b key: got %q", got)
+	}
+
+	// a Writer gets the chars unescaped
+	out.Reset()
+	propertiesStoreWriter([]interface{}{p, &out, object.Null})
+	if !strings.Contains(out.String(), "a=é€\\n\n") {
+		t.Fatalf("store(Writer) wrote %q", out.String())
+	}
+}
+
+func TestProperties_XML_RoundTrip(t *testing.T) {
+	globals.InitStringPool()
+	p := newPropertiesObj()
+	propInit(t, p)
+	_ = PropertiesSetProperty([]interface{}{p, s("url"), s("a<b & \"c\"")})
+	_ = PropertiesSetProperty([]interface{}{p, s("name"), s("Zoë")})
+
+	var out bytes.Buffer
+	if ret := propertiesStoreToXML([]interface{}{p, &out, s("settings")}); ret != nil {
+		t.Fatalf("storeToXML returned %v", ret)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+		`<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">` + "\n" +
+		"<properties>\n<comment>settings</comment>\n" +
+		`<entry key="name">Zoë</entry>` + "\n" +
+		`<entry key="url">a&lt;b &amp; "c"</entry>` + "\n</properties>\n"
+	if out.String() != want {
+		t.Fatalf("storeToXML wrote\n%s\nexpected\n%s", out.String(), want)
+	}
+
+	q := newPropertiesObj()
+	propInit(t, q)
+	if ret := propertiesLoadFromXML([]interface{}{q, bytes.NewReader(out.Bytes())}); ret != nil {
+		t.Fatalf("loadFromXML returned %v", ret)
+	}
+	if got := propValue(t, q, "url"); got != "a<b & \"c\"" {
+		t.Fatalf("url: got %q", got)
+	}
+	if got := propValue(t, q, "name"); got != "Zoë" {
+		t.Fatalf("name: got %q", got)
+	}
+
+	testutil.ExpectGErr(t, propertiesLoadFromXML([]interface{}{q, strings.NewReader("<props/>")}),
+		excNames.InvalidPropertiesFormatException, "")
+	testutil.ExpectGErr(t, propertiesLoadFromXML([]interface{}{q, strings.NewReader("<properties><entry>x</entry></properties>")}),
+		excNames.InvalidPropertiesFormatException, "")
+}
+
+func TestProperties_List_And_Names(t *testing.T) {
+	globals.InitStringPool()
+	p := newPropertiesObj()
+	propInit(t, p)
+	_ = PropertiesSetProperty([]interface{}{p, s("short"), s("v")})
+	_ = PropertiesSetProperty([]interface{}{p, s("long"), s(strings.Repeat("x", 45))})
+
+	var out bytes.Buffer
+	propertiesList([]interface{}{p, &out})
+	want := "-- listing properties --\nlong=" + strings.Repeat("x", 37) + "...\nshort=v\n"
+	if out.String() != want {
+		t.Fatalf("list wrote %q", out.String())
+	}
+
+	enum := propertiesPropertyNames([]interface{}{p}).(*object.Object)
+	var names []string
+	for enumerationHasMoreElements([]interface{}{enum}) == types.JavaBoolTrue {
+		names = append(names, object.GoStringFromStringObject(enumerationNextElement([]interface{}{enum}).(*object.Object)))
+	}
+	if strings.Join(names, ",") != "long,short" {
+		t.Fatalf("propertyNames returned %v", names)
+	}
+
+	set := propertiesStringPropertyNames([]interface{}{list.New(), p}).(*object.Object)
+	if !isUnmodifiable(set) {
+		t.Fatalf("stringPropertyNames should return an unmodifiable set")
 	}
 }
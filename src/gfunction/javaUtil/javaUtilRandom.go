@@ -181,10 +181,60 @@ ChatGPT key Points:
 
 type Random struct {
 	rand                 *rand.Rand
+	lcg                  *randomLCG // the source of rand
 	nextNextGaussian     float64
 	haveNextNextGaussian bool
 }
 
+// randomLCG is the 48-bit linear congruential generator of the JDK's java.util.Random. It is
+// the rand.Source of a Random, so that nextInt(bound) and Collections.shuffle produce the
+// JDK's sequence for a given seed.
+type randomLCG struct {
+	seed int64
+}
+
+const (
+	randomMultiplier = 0x5DEECE66D
+	randomAddend     = 0xB
+	randomMask       = 1<<48 - 1
+)
+
+func newRandomLCG(seed int64) *randomLCG {
+	lcg := &randomLCG{}
+	lcg.Seed(seed)
+	return lcg
+}
+
+// Seed scrambles the seed as Random.setSeed does.
+func (lcg *randomLCG) Seed(seed int64) {
+	lcg.seed = (seed ^ randomMultiplier) & randomMask
+}
+
+// next is Random.next(bits): the next bits pseudorandom bits, as a signed int.
+func (lcg *randomLCG) next(bits uint) int32 {
+	lcg.seed = (lcg.seed*randomMultiplier + randomAddend) & randomMask
+	return int32(lcg.seed >> (48 - bits))
+}
+
+func (lcg *randomLCG) Int63() int64 {
+	return int64(lcg.next(31))<<32 | int64(uint32(lcg.next(32)))
+}
+
+// nextInt is Random.nextInt(bound), for a positive bound.
+func (lcg *randomLCG) nextInt(bound int32) int32 {
+	r := lcg.next(31)
+	m := bound - 1
+	if bound&m == 0 { // a power of 2
+		return int32(int64(bound) * int64(r) >> 31)
+	}
+	for u := r; ; u = lcg.next(31) {
+		r = u % bound
+		if u-r+m >= 0 { // the int overflow rejects the values of u that would bias r
+			return r
+		}
+	}
+}
+
 // Primitive to update a Random object with a Random struct.
 func UpdateRandomObjectFromStruct(objPtr *object.Object, argStruct Random) {
 	fld := object.Field{Ftype: types.Struct, Fvalue: argStruct}
@@ -201,9 +251,10 @@ func GetStructFromRandomObject(objPtr *object.Object) Random {
 // NewRandom creates a new Random instance initialized with the current time as seed.
 // chatGPT generated: func NewRandom() *Random
 func randomInitVoid(params []interface{}) interface{} {
-	source := rand.NewSource(time.Now().UnixNano())
+	lcg := newRandomLCG(time.Now().UnixNano())
 	randStruct := Random{
-		rand:                 rand.New(source),
+		rand:                 rand.New(lcg),
+		lcg:                  lcg,
 		nextNextGaussian:     0.0,
 		haveNextNextGaussian: false,
 	}
@@ -216,9 +267,10 @@ func randomInitVoid(params []interface{}) interface{} {
 // Same as randomInitVoid except a seed is supplied.
 func randomInitLong(params []interface{}) interface{} {
 	seed := params[1].(int64)
-	source := rand.NewSource(seed)
+	lcg := newRandomLCG(seed)
 	randStruct := Random{
-		rand:                 rand.New(source),
+		rand:                 rand.New(lcg),
+		lcg:                  lcg,
 		nextNextGaussian:     0.0,
 		haveNextNextGaussian: false,
 	}
@@ -268,7 +320,7 @@ func randomNextIntBound(params []interface{}) interface{} {
 		errMsg := fmt.Sprintf("randomNextIntBound: Bound must be positive, observed: %d", bound)
 		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, errMsg)
 	}
-	output := int64(r.rand.Int31n(int32(bound)))
+	output := int64(r.lcg.nextInt(int32(bound)))
 	return output
 }
 
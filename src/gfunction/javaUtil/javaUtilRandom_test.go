@@ -150,3 +150,29 @@ func TestRandom_NextBytes_FillsArray(t *testing.T) {
 		t.Fatalf("nextBytes produced all zeros (unlikely)")
 	}
 }
+
+// nextInt(bound) draws the JDK's sequence for a seed, for bounds that are powers of 2 and not.
+func TestRandom_NextIntBound_MatchesJDK(t *testing.T) {
+	globals.InitGlobals("test")
+	r := newRandomObj()
+	_ = randomInitLong([]interface{}{r, int64(42)})
+	if got := GetStructFromRandomObject(r).lcg.next(32); got != -1170105035 { // new Random(42).nextInt()
+		t.Fatalf("next(32) with seed 42: expected -1170105035, got %d", got)
+	}
+	cases := []struct {
+		bound    int64
+		expected []int64
+	}{
+		{100, []int64{30, 63, 48, 84, 70}},
+		{16, []int64{11, 0, 10, 0, 4}},
+	}
+	for _, c := range cases {
+		r = newRandomObj()
+		_ = randomInitLong([]interface{}{r, int64(42)})
+		for ix, want := range c.expected {
+			if got := randomNextIntBound([]interface{}{r, c.bound}).(int64); got != want {
+				t.Errorf("new Random(42).nextInt(%d) #%d: expected %d, got %d", c.bound, ix, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,910 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Implementation of java.util.Scanner. The Go state of a Scanner is a *scannerState held in the
+// fieldNameScanner field. Input is read on demand into a buffer, so a Scanner over System.in
+// only blocks when it needs more input to decide what the next token or line is.
+//
+// As in the JDK, the token-returning methods leave the delimiter that follows a token unread,
+// so nextLine() after nextInt() returns the rest of the current line. A token that cannot be
+// translated into the requested type is not consumed.
+
+var classNameScanner = "java/util/Scanner"
+
+const fieldNameScanner = "scanner"
+
+// The JDK's default delimiter is \p{javaWhitespace}+.
+const scannerDefaultDelimiter = `[\t\n\x0B\f\r\x1C-\x1F\x{1680}\x{2000}-\x{2006}\x{2008}-\x{200A}\x{2028}\x{2029}\x{205F}\x{3000} ]+`
+
+var scannerLineSeparator = regexp.MustCompile("\r\n|[\n\r  \u0085]")
+
+var (
+	scannerDecimalSyntax = regexp.MustCompile(`^[-+]?((\d{1,3}(,\d{3})+|\d+)(\.\d*)?|\.\d+)([eE][-+]?\d+)?$`)
+	scannerHexFloat      = regexp.MustCompile(`^[-+]?0[xX][0-9a-fA-F]*\.?[0-9a-fA-F]+([pP][-+]?\d+)?$`)
+	scannerNonNumber     = regexp.MustCompile(`^[-+]?(NaN|Infinity|∞)$`)
+	scannerGroupedDigits = regexp.MustCompile(`^[-+]?\d{1,3}(,\d{3})+$`)
+	scannerBoolean       = regexp.MustCompile(`(?i)^(true|false)$`)
+)
+
+func Load_Util_Scanner() {
+
+	ghelpers.MethodSignatures["java/util/Scanner.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  ghelpers.ClinitGeneric,
+		}
+
+	// constructors: the input is decoded with the charset named, or else read as UTF-8
+
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerInit, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/io/InputStream;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: scannerInit, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/lang/Readable;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerInit, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/lang/String;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerInit, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/io/File;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerInitFile, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/io/File;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: scannerInitFile, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/nio/file/Path;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerInitPath, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.<init>(Ljava/nio/file/Path;Ljava/lang/String;)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: scannerInitPath, NeedsContext: true}
+
+	// tokens and lines
+
+	ghelpers.MethodSignatures["java/util/Scanner.hasNext()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNext, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.next()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNext, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNext(Ljava/lang/String;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerHasNextPattern, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.next(Ljava/lang/String;)Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerNextPattern, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextLine()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextLine, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextLine()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextLine, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.findInLine(Ljava/lang/String;)Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerFindInLine, NeedsContext: true}
+
+	// primitive values
+
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextBoolean()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextBoolean, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextBoolean()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextBoolean, NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextByte()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextInteger(8), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextByte(I)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerHasNextInteger(8), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextByte()B"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextInteger(8), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextByte(I)B"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerNextInteger(8), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextShort()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextInteger(16), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextShort(I)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerHasNextInteger(16), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextShort()S"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextInteger(16), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextShort(I)S"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerNextInteger(16), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextInt()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextInteger(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextInt(I)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerHasNextInteger(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextInt()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextInteger(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextInt(I)I"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerNextInteger(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextLong()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextInteger(64), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextLong(I)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerHasNextInteger(64), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextLong()J"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextInteger(64), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextLong(I)J"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerNextInteger(64), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextFloat()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextFloating(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextFloat()F"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextFloating(32), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.hasNextDouble()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerHasNextFloating(64), NeedsContext: true}
+	ghelpers.MethodSignatures["java/util/Scanner.nextDouble()D"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerNextFloating(64), NeedsContext: true}
+
+	// settings and lifecycle
+
+	ghelpers.MethodSignatures["java/util/Scanner.useDelimiter(Ljava/lang/String;)Ljava/util/Scanner;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerUseDelimiter}
+	ghelpers.MethodSignatures["java/util/Scanner.useRadix(I)Ljava/util/Scanner;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: scannerUseRadix}
+	ghelpers.MethodSignatures["java/util/Scanner.radix()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerRadix}
+	ghelpers.MethodSignatures["java/util/Scanner.close()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: scannerClose}
+
+	// traps for functions that reference unsupported types: Pattern, Locale, MatchResult, Stream
+
+	ghelpers.MethodSignatures["java/util/Scanner.useDelimiter(Ljava/util/regex/Pattern;)Ljava/util/Scanner;"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+	ghelpers.MethodSignatures["java/util/Scanner.delimiter()Ljava/util/regex/Pattern;"] = ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+	ghelpers.MethodSignatures["java/util/Scanner.useLocale(Ljava/util/Locale;)Ljava/util/Scanner;"] = ghelpers.GMeth{ParamSlots: 1, GFunction: ghelpers.TrapFunction}
+	ghelpers.MethodSignatures["java/util/Scanner.match()Ljava/util/regex/MatchResult;"] = ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+	ghelpers.MethodSignatures["java/util/Scanner.tokens()Ljava/util/stream/Stream;"] = ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+}
+
+// scannerState is the Go side of a Scanner.
+type scannerState struct {
+	src       io.Reader
+	closer    io.Closer           // the source to close in close(); nil for System.in
+	source    *object.Object      // a Java stream or reader that is read through its own methods
+	chars     ghelpers.CharSource // the chars of source
+	buf       []byte              // input that has been read but not consumed
+	eof       bool
+	readErr   error // what reading source threw, to be thrown by the Scanner method that read it
+	closed    bool
+	delim     *regexp.Regexp
+	delimHead *regexp.Regexp // delim anchored at the start of the input
+	radix     int
+}
+
+// newScannerState returns the state of a Scanner that reads src. If cs is not nil, src is decoded
+// with it; otherwise src is read as UTF-8.
+func newScannerState(src io.Reader, closer io.Closer, cs *ghelpers.Charset) *scannerState {
+	s := &scannerState{src: src, closer: closer, radix: 10}
+	if cs != nil {
+		s.chars = ghelpers.NewCharReader(cs, bufio.NewReader(src))
+	}
+	s.delim = regexp.MustCompile(scannerDefaultDelimiter)
+	s.delimHead = regexp.MustCompile(`^(?:` + scannerDefaultDelimiter + `)`)
+	return s
+}
+
+// readSource makes the Scanner read its Java InputStream or Reader, which Jacobin has no Go
+// handle for, by calling the stream's read methods on the frame stack fs.
+func (s *scannerState) readSource(fs *list.List, caller string) *ghelpers.GErrBlk {
+	if cr, ok := s.chars.(*ghelpers.CharReader); ok {
+		r, gerr := ghelpers.GoReaderWithContext(fs, s.source, caller)
+		if gerr != nil {
+			return gerr
+		}
+		cr.SetReader(r)
+		return nil
+	}
+	chars, gerr := ghelpers.GoCharSourceWithContext(fs, s.source, caller)
+	if gerr != nil {
+		return gerr
+	}
+	s.chars = chars
+	return nil
+}
+
+// fill reads more input into the buffer.
+func (s *scannerState) fill() {
+	if s.eof {
+		return
+	}
+	var err error
+	if s.chars != nil {
+		err = s.fillChars()
+	} else {
+		chunk := make([]byte, 4096)
+		var n int
+		n, err = s.src.Read(chunk)
+		s.buf = append(s.buf, chunk[:n]...)
+	}
+	if err != nil {
+		s.eof = true
+		if !errors.Is(err, io.EOF) {
+			s.readErr = err
+		}
+	}
+}
+
+// fillChars reads chars from s.chars up to the end of the current line, so that interactive
+// input is not read further ahead than it has to be.
+func (s *scannerState) fillChars() error {
+	for range 4096 {
+		ch, err := s.chars.ReadChar()
+		if err != nil {
+			return err
+		}
+		if utf16.IsSurrogate(ch) && ch < 0xDC00 {
+			low, err := s.chars.ReadChar()
+			if err != nil {
+				s.buf = utf8.AppendRune(s.buf, utf8.RuneError)
+				return err
+			}
+			if pair := utf16.DecodeRune(ch, low); pair != utf8.RuneError {
+				ch = pair
+			} else {
+				s.buf = utf8.AppendRune(s.buf, utf8.RuneError)
+				ch = low
+			}
+		}
+		s.buf = utf8.AppendRune(s.buf, ch)
+		if ch == '\n' {
+			return nil
+		}
+	}
+	return nil
+}
+
+// takeReadErr returns the exception that reading the source threw, if the input ended with one.
+// The exception is thrown once; after it the Scanner is at the end of its input.
+func (s *scannerState) takeReadErr() *ghelpers.GErrBlk {
+	if s.readErr == nil {
+		return nil
+	}
+	gerr := ghelpers.StreamErrBlk(s.readErr)
+	s.readErr = nil
+	return gerr
+}
+
+// peekToken finds the next complete token without consuming it. The token is s.buf[start:end].
+// gerr is the exception that reading the input threw.
+func (s *scannerState) peekToken() (start, end int, ok bool, gerr *ghelpers.GErrBlk) {
+	start, end, ok = s.findToken()
+	return start, end, ok, s.takeReadErr()
+}
+
+func (s *scannerState) findToken() (start, end int, ok bool) {
+	for {
+		start = 0
+		if loc := s.delimHead.FindIndex(s.buf); loc != nil {
+			if loc[1] == len(s.buf) && !s.eof {
+				s.fill() // the delimiter might continue
+				continue
+			}
+			start = loc[1]
+		}
+		if start == len(s.buf) {
+			if s.eof {
+				return 0, 0, false
+			}
+			s.fill()
+			continue
+		}
+		// two adjacent delimiters surround an empty token, as in the JDK
+		for _, loc := range s.delim.FindAllIndex(s.buf[start:], -1) {
+			if loc[1] > 0 {
+				return start, start + loc[0], true
+			}
+		}
+		if s.eof {
+			return start, len(s.buf), true
+		}
+		s.fill()
+	}
+}
+
+// peekLine finds the end of the current line: s.buf[:lineEnd] is the line and s.buf[next:] is
+// the input that follows its line separator. gerr is the exception that reading the input threw.
+func (s *scannerState) peekLine() (lineEnd, next int, ok bool, gerr *ghelpers.GErrBlk) {
+	lineEnd, next, ok = s.findLine()
+	return lineEnd, next, ok, s.takeReadErr()
+}
+
+func (s *scannerState) findLine() (lineEnd, next int, ok bool) {
+	for {
+		loc := scannerLineSeparator.FindIndex(s.buf)
+		// a trailing \r might be the first half of \r\n
+		if loc != nil && (s.buf[loc[0]] != '\r' || loc[1] < len(s.buf) || loc[1]-loc[0] == 2 || s.eof) {
+			return loc[0], loc[1], true
+		}
+		if s.eof {
+			if len(s.buf) == 0 {
+				return 0, 0, false
+			}
+			return len(s.buf), len(s.buf), true
+		}
+		s.fill()
+	}
+}
+
+func (s *scannerState) consume(n int) {
+	s.buf = s.buf[n:]
+}
+
+// scannerThis returns the Scanner's state, refreshing the frame stack that Java streams are read with.
+func scannerThis(params []interface{}, caller string) ([]interface{}, *scannerState, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(this) {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": Scanner is null")
+	}
+	s, ok := this.FieldTable[fieldNameScanner].Fvalue.(*scannerState)
+	if !ok {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, caller+": Scanner was not initialized")
+	}
+	if s.closed {
+		return nil, nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "Scanner closed")
+	}
+	if s.source != nil {
+		if gerr := s.readSource(fs, caller); gerr != nil {
+			return nil, nil, gerr
+		}
+	}
+	return args, s, nil
+}
+
+// compileJavaRegex compiles a regular expression passed in from Java.
+func compileJavaRegex(pattern any, caller string) (*regexp.Regexp, *ghelpers.GErrBlk) {
+	patObj, ok := pattern.(*object.Object)
+	if !ok || object.IsNull(patObj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": pattern is null")
+	}
+	re, err := regexp.Compile(object.GoStringFromStringObject(patObj))
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.PatternSyntaxException, fmt.Sprintf("%s: %s", caller, err.Error()))
+	}
+	return re, nil
+}
+
+// --- constructors ---
+
+// setScannerState installs the Go state in a new Scanner object.
+func setScannerState(this *object.Object, s *scannerState) {
+	this.FieldTable[fieldNameScanner] = object.Field{Ftype: types.RawGoPointer, Fvalue: s}
+}
+
+// java/util/Scanner.<init>(Ljava/io/InputStream;)V, <init>(Ljava/io/InputStream;Ljava/lang/String;)V,
+// <init>(Ljava/lang/Readable;)V, <init>(Ljava/lang/String;)V
+func scannerInit(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "scannerInit: invalid 'this' argument")
+	}
+
+	cs, gerr := scannerCharset(args)
+	if gerr != nil {
+		return gerr
+	}
+
+	switch src := args[1].(type) {
+	case *os.File: // System.in
+		setScannerState(this, newScannerState(src, nil, cs))
+		return nil
+	case *object.Object:
+		if object.IsNull(src) {
+			return ghelpers.GetGErrBlk(excNames.NullPointerException, "scannerInit: source is null")
+		}
+		if object.IsStringObject(src) {
+			setScannerState(this, newScannerState(strings.NewReader(object.GoStringFromStringObject(src)), nil, nil))
+			return nil
+		}
+		if f, ok := src.FieldTable[ghelpers.FileHandle].Fvalue.(*os.File); ok {
+			setScannerState(this, newScannerState(f, f, cs))
+			return nil
+		}
+		// any other stream or reader is read through its own read methods
+		s := newScannerState(nil, nil, nil)
+		s.source = src
+		if _, clName := ghelpers.FindInstanceMethod(src, "read", "([BII)I"); clName != "" {
+			if cs == nil {
+				cs = ghelpers.DefaultCharset()
+			}
+			s.chars = ghelpers.NewCharReader(cs, nil)
+		}
+		if gerr := s.readSource(fs, "scannerInit"); gerr != nil {
+			return gerr
+		}
+		setScannerState(this, s)
+		return nil
+	}
+	return ghelpers.GetGErrBlk(excNames.NullPointerException, "scannerInit: source is null")
+}
+
+// scannerCharset returns the charset named by the charset argument of a constructor, or nil if
+// there is none. As in the JDK, an unknown charset is an IllegalArgumentException.
+func scannerCharset(args []interface{}) (*ghelpers.Charset, *ghelpers.GErrBlk) {
+	if len(args) < 3 {
+		return nil, nil
+	}
+	nameObj, ok := args[2].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "charsetName")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	if cs := ghelpers.LookupCharset(name); cs != nil {
+		return cs, nil
+	}
+	cause := "java.nio.charset.UnsupportedCharsetException"
+	if !ghelpers.IsLegalCharsetName(name) {
+		cause = "java.nio.charset.IllegalCharsetNameException"
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, cause+": "+name)
+}
+
+// java/util/Scanner.<init>(Ljava/io/File;)V and <init>(Ljava/io/File;Ljava/lang/String;)V
+func scannerInitFile(params []interface{}) interface{} {
+	_, args := ghelpers.SplitContext(params)
+	this := args[0].(*object.Object)
+	cs, gerr := scannerCharset(args)
+	if gerr != nil {
+		return gerr
+	}
+	fileObj, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(fileObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "scannerInitFile: file is null")
+	}
+	path, ok := fileObj.FieldTable[ghelpers.FilePath].Fvalue.([]types.JavaByte)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "scannerInitFile: File object lacks a path")
+	}
+	pathStr := object.GoStringFromJavaByteArray(path)
+	f, err := os.Open(pathStr)
+	if err != nil {
+		errMsg := fmt.Sprintf("%s (%s)", pathStr, scannerOpenErrorReason(err))
+		return ghelpers.GetGErrBlk(excNames.FileNotFoundException, errMsg)
+	}
+	setScannerState(this, newScannerState(f, f, cs))
+	return nil
+}
+
+// java/util/Scanner.<init>(Ljava/nio/file/Path;)V and <init>(Ljava/nio/file/Path;Ljava/lang/String;)V
+func scannerInitPath(params []interface{}) interface{} {
+	_, args := ghelpers.SplitContext(params)
+	this := args[0].(*object.Object)
+	cs, gerr := scannerCharset(args)
+	if gerr != nil {
+		return gerr
+	}
+	pathObj, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(pathObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "scannerInitPath: path is null")
+	}
+	strObj, ok := pathObj.FieldTable["value"].Fvalue.(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "scannerInitPath: Path object lacks a value")
+	}
+	pathStr := object.GoStringFromStringObject(strObj)
+	f, err := os.Open(pathStr)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, pathStr+": "+scannerOpenErrorReason(err))
+	}
+	setScannerState(this, newScannerState(f, f, cs))
+	return nil
+}
+
+// scannerOpenErrorReason words an os.Open error the way the JDK does.
+func scannerOpenErrorReason(err error) string {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "No such file or directory"
+	case errors.Is(err, os.ErrPermission):
+		return "Permission denied"
+	}
+	return err.Error()
+}
+
+// --- tokens and lines ---
+
+// java/util/Scanner.hasNext()Z
+func scannerHasNext(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.hasNext")
+	if gerr != nil {
+		return gerr
+	}
+	_, _, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ok)
+}
+
+// java/util/Scanner.next()Ljava/lang/String;
+func scannerNext(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.next")
+	if gerr != nil {
+		return gerr
+	}
+	start, end, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return gerr
+	}
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "")
+	}
+	token := string(s.buf[start:end])
+	s.consume(end)
+	return object.StringObjectFromGoString(token)
+}
+
+// scannerMatchingToken returns the next token if it matches the pattern argument in its entirety.
+func scannerMatchingToken(params []interface{}, caller string) (*scannerState, string, int, bool, *ghelpers.GErrBlk) {
+	args, s, gerr := scannerThis(params, caller)
+	if gerr != nil {
+		return nil, "", 0, false, gerr
+	}
+	re, gerr := compileJavaRegex(args[1], caller)
+	if gerr != nil {
+		return nil, "", 0, false, gerr
+	}
+	start, end, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return nil, "", 0, false, gerr
+	}
+	if !ok {
+		return s, "", 0, false, nil
+	}
+	token := string(s.buf[start:end])
+	loc := re.FindStringIndex(token)
+	return s, token, end, loc != nil && loc[0] == 0 && loc[1] == len(token), nil
+}
+
+// java/util/Scanner.hasNext(Ljava/lang/String;)Z
+func scannerHasNextPattern(params []interface{}) interface{} {
+	_, _, _, matches, gerr := scannerMatchingToken(params, "Scanner.hasNext")
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(matches)
+}
+
+// java/util/Scanner.next(Ljava/lang/String;)Ljava/lang/String;
+func scannerNextPattern(params []interface{}) interface{} {
+	s, token, end, matches, gerr := scannerMatchingToken(params, "Scanner.next")
+	if gerr != nil {
+		return gerr
+	}
+	if !matches {
+		return scannerNoMatch(s)
+	}
+	s.consume(end)
+	return object.StringObjectFromGoString(token)
+}
+
+// scannerNoMatch is the exception for a token of the wrong form: InputMismatchException, or
+// NoSuchElementException if the input is exhausted.
+func scannerNoMatch(s *scannerState) *ghelpers.GErrBlk {
+	_, _, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return gerr
+	}
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "")
+	}
+	return ghelpers.GetGErrBlk(excNames.InputMismatchException, "")
+}
+
+// java/util/Scanner.hasNextLine()Z
+func scannerHasNextLine(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.hasNextLine")
+	if gerr != nil {
+		return gerr
+	}
+	_, _, ok, gerr := s.peekLine()
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ok)
+}
+
+// java/util/Scanner.nextLine()Ljava/lang/String;
+func scannerNextLine(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.nextLine")
+	if gerr != nil {
+		return gerr
+	}
+	lineEnd, next, ok, gerr := s.peekLine()
+	if gerr != nil {
+		return gerr
+	}
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "No line found")
+	}
+	line := string(s.buf[:lineEnd])
+	s.consume(next)
+	return object.StringObjectFromGoString(line)
+}
+
+// java/util/Scanner.findInLine(Ljava/lang/String;)Ljava/lang/String;
+// Delimiters are ignored. If the pattern occurs before the next line separator, the scanner
+// advances past the match and returns it; otherwise null is returned and nothing is consumed.
+func scannerFindInLine(params []interface{}) interface{} {
+	args, s, gerr := scannerThis(params, "Scanner.findInLine")
+	if gerr != nil {
+		return gerr
+	}
+	re, gerr := compileJavaRegex(args[1], "Scanner.findInLine")
+	if gerr != nil {
+		return gerr
+	}
+	lineEnd, _, ok, gerr := s.peekLine()
+	if gerr != nil {
+		return gerr
+	}
+	if !ok {
+		return object.Null
+	}
+	loc := re.FindIndex(s.buf[:lineEnd])
+	if loc == nil {
+		return object.Null
+	}
+	found := string(s.buf[loc[0]:loc[1]])
+	s.consume(loc[1])
+	return object.StringObjectFromGoString(found)
+}
+
+// --- primitive values ---
+
+// parseScannerInteger translates a token into an integer of the given bit size, as nextInt()
+// and its siblings do. In radix 10, thousands separators are accepted.
+func parseScannerInteger(token string, radix, bitSize int) (int64, *ghelpers.GErrBlk) {
+	digits := token
+	if radix == 10 && scannerGroupedDigits.MatchString(token) {
+		digits = strings.ReplaceAll(token, ",", "")
+	}
+	if strings.HasPrefix(digits, "+") && (len(digits) == 1 || digits[1] == '-' || digits[1] == '+') {
+		return 0, ghelpers.GetGErrBlk(excNames.InputMismatchException, "")
+	}
+	value, err := strconv.ParseInt(digits, radix, bitSize)
+	if err == nil {
+		return value, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		// Integer.parseInt's message, which the JDK's Scanner passes on
+		errMsg := fmt.Sprintf("For input string: \"%s\"", digits)
+		if radix != 10 {
+			errMsg += fmt.Sprintf(" under radix %d", radix)
+		}
+		return 0, ghelpers.GetGErrBlk(excNames.InputMismatchException, errMsg)
+	}
+	return 0, ghelpers.GetGErrBlk(excNames.InputMismatchException, "")
+}
+
+// parseScannerFloating translates a token into a double, or a float if bitSize is 32.
+func parseScannerFloating(token string, bitSize int) (float64, bool) {
+	switch {
+	case scannerNonNumber.MatchString(token):
+		negative := strings.HasPrefix(token, "-")
+		if strings.HasSuffix(token, "NaN") {
+			return math.NaN(), true
+		}
+		if negative {
+			return math.Inf(-1), true
+		}
+		return math.Inf(1), true
+	case scannerHexFloat.MatchString(token):
+		if !strings.ContainsAny(token, "pP") {
+			token += "p0"
+		}
+	case scannerDecimalSyntax.MatchString(token):
+		token = strings.ReplaceAll(token, ",", "")
+	default:
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(token, bitSize)
+	if err != nil && !errors.Is(err, strconv.ErrRange) { // out-of-range values become infinities, as in Java
+		return 0, false
+	}
+	return value, true
+}
+
+// scannerRadixArg returns the radix argument of nextInt(int) and the like, or the scanner's radix.
+func scannerRadixArg(args []interface{}, s *scannerState) (int, *ghelpers.GErrBlk) {
+	if len(args) < 2 {
+		return s.radix, nil
+	}
+	radix, _ := args[1].(int64)
+	if radix < ghelpers.MinRadix || radix > ghelpers.MaxRadix {
+		return 0, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("radix:%d", radix))
+	}
+	return int(radix), nil
+}
+
+// scannerHasNextInteger returns hasNextByte, hasNextShort, hasNextInt or hasNextLong.
+func scannerHasNextInteger(bitSize int) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		args, s, gerr := scannerThis(params, "Scanner.hasNextInt")
+		if gerr != nil {
+			return gerr
+		}
+		radix, gerr := scannerRadixArg(args, s)
+		if gerr != nil {
+			return gerr
+		}
+		start, end, ok, gerr := s.peekToken()
+		if gerr != nil {
+			return gerr
+		}
+		if !ok {
+			return types.JavaBoolFalse
+		}
+		_, gerr = parseScannerInteger(string(s.buf[start:end]), radix, bitSize)
+		return types.ConvertGoBoolToJavaBool(gerr == nil)
+	}
+}
+
+// scannerNextInteger returns nextByte, nextShort, nextInt or nextLong.
+func scannerNextInteger(bitSize int) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		args, s, gerr := scannerThis(params, "Scanner.nextInt")
+		if gerr != nil {
+			return gerr
+		}
+		radix, gerr := scannerRadixArg(args, s)
+		if gerr != nil {
+			return gerr
+		}
+		start, end, ok, gerr := s.peekToken()
+		if gerr != nil {
+			return gerr
+		}
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "")
+		}
+		value, gerr := parseScannerInteger(string(s.buf[start:end]), radix, bitSize)
+		if gerr != nil {
+			return gerr
+		}
+		s.consume(end)
+		return value
+	}
+}
+
+// scannerHasNextFloating returns hasNextFloat or hasNextDouble.
+func scannerHasNextFloating(bitSize int) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		_, s, gerr := scannerThis(params, "Scanner.hasNextDouble")
+		if gerr != nil {
+			return gerr
+		}
+		start, end, ok, gerr := s.peekToken()
+		if gerr != nil {
+			return gerr
+		}
+		if !ok {
+			return types.JavaBoolFalse
+		}
+		_, ok = parseScannerFloating(string(s.buf[start:end]), bitSize)
+		return types.ConvertGoBoolToJavaBool(ok)
+	}
+}
+
+// scannerNextFloating returns nextFloat or nextDouble.
+func scannerNextFloating(bitSize int) func([]interface{}) interface{} {
+	return func(params []interface{}) interface{} {
+		_, s, gerr := scannerThis(params, "Scanner.nextDouble")
+		if gerr != nil {
+			return gerr
+		}
+		start, end, ok, gerr := s.peekToken()
+		if gerr != nil {
+			return gerr
+		}
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "")
+		}
+		value, ok := parseScannerFloating(string(s.buf[start:end]), bitSize)
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.InputMismatchException, "")
+		}
+		s.consume(end)
+		return value
+	}
+}
+
+// java/util/Scanner.hasNextBoolean()Z
+func scannerHasNextBoolean(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.hasNextBoolean")
+	if gerr != nil {
+		return gerr
+	}
+	start, end, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(ok && scannerBoolean.Match(s.buf[start:end]))
+}
+
+// java/util/Scanner.nextBoolean()Z
+func scannerNextBoolean(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.nextBoolean")
+	if gerr != nil {
+		return gerr
+	}
+	start, end, ok, gerr := s.peekToken()
+	if gerr != nil {
+		return gerr
+	}
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "")
+	}
+	token := s.buf[start:end]
+	if !scannerBoolean.Match(token) {
+		return ghelpers.GetGErrBlk(excNames.InputMismatchException, "")
+	}
+	s.consume(end)
+	return types.ConvertGoBoolToJavaBool(strings.EqualFold(string(token), "true"))
+}
+
+// --- settings and lifecycle ---
+
+// java/util/Scanner.useDelimiter(Ljava/lang/String;)Ljava/util/Scanner;
+func scannerUseDelimiter(params []interface{}) interface{} {
+	args, s, gerr := scannerThis(params, "Scanner.useDelimiter")
+	if gerr != nil {
+		return gerr
+	}
+	re, gerr := compileJavaRegex(args[1], "Scanner.useDelimiter")
+	if gerr != nil {
+		return gerr
+	}
+	s.delim = re
+	s.delimHead = regexp.MustCompile(`^(?:` + re.String() + `)`)
+	return args[0]
+}
+
+// java/util/Scanner.useRadix(I)Ljava/util/Scanner;
+func scannerUseRadix(params []interface{}) interface{} {
+	args, s, gerr := scannerThis(params, "Scanner.useRadix")
+	if gerr != nil {
+		return gerr
+	}
+	radix, gerr := scannerRadixArg(args, s)
+	if gerr != nil {
+		return gerr
+	}
+	s.radix = radix
+	return args[0]
+}
+
+// java/util/Scanner.radix()I
+func scannerRadix(params []interface{}) interface{} {
+	_, s, gerr := scannerThis(params, "Scanner.radix")
+	if gerr != nil {
+		return gerr
+	}
+	return int64(s.radix)
+}
+
+// java/util/Scanner.close()V
+// Closing a Scanner closes the file or stream that it reads. System.in is left open so that
+// the rest of Jacobin can still use it. Closing an already-closed Scanner has no effect.
+func scannerClose(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "scannerClose: invalid 'this' argument")
+	}
+	s, ok := this.FieldTable[fieldNameScanner].Fvalue.(*scannerState)
+	if !ok || s.closed {
+		return nil
+	}
+	s.closed = true
+	s.buf = nil
+	if s.closer != nil {
+		_ = s.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil_test
+
+import (
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaUtil"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const scannerStdinHelperEnv = "JACOBIN_SCANNER_STDIN_HELPER"
+
+func scannerCall(t *testing.T, scanner *object.Object, method string, args ...interface{}) interface{} {
+	t.Helper()
+	return ghelpers.Invoke("java/util/Scanner."+method, append([]interface{}{scanner}, args...))
+}
+
+func newScanner(t *testing.T, ctor string, source interface{}) *object.Object {
+	t.Helper()
+	globals.InitStringPool()
+	javaUtil.Load_Util_Scanner()
+	scanner := object.MakeEmptyObjectWithClassName(new("java/util/Scanner"))
+	if ret := scannerCall(t, scanner, ctor, source); ret != nil {
+		t.Fatalf("%s returned %v", ctor, ret)
+	}
+	return scanner
+}
+
+func scannerString(t *testing.T, ret interface{}) string {
+	t.Helper()
+	strObj, ok := ret.(*object.Object)
+	if !ok || object.IsNull(strObj) {
+		t.Fatalf("expected a String, got %v", ret)
+	}
+	return object.GoStringFromStringObject(strObj)
+}
+
+// TestScanner_SystemIn runs this test binary again with scripted standard input. The child
+// process reads the System.in of the statics through a Scanner and prints what it read.
+func TestScanner_SystemIn(t *testing.T) {
+	if os.Getenv(scannerStdinHelperEnv) == "1" {
+		scannerEchoStdin(t)
+		return
+	}
+
+	t.Setenv(scannerStdinHelperEnv, "1")
+	input := "42 3.5\nhello world\nnot-a-number\n"
+	rc, out := testutil.RunnerWithInput(os.Args[0], "-test.run=^TestScanner_SystemIn$", input, 60, false)
+	if rc != testutil.RcRunnerSuccess {
+		t.Fatalf("helper process failed: rc=%d, output: %s", rc, out)
+	}
+	expected := "int=42\ndouble=3.5\nrest=\"\"\nline=\"hello world\"\nhasNextInt=false\nnext=not-a-number\nhasNext=false\n"
+	if !strings.HasPrefix(out, expected) {
+		t.Errorf("expected output to begin with:\n%s\nobserved:\n%s", expected, out)
+	}
+}
+
+func scannerEchoStdin(t *testing.T) {
+	statics.LoadProgramStatics()
+	scanner := newScanner(t, "<init>(Ljava/io/InputStream;)V", statics.GetStaticValue("java/lang/System", "in"))
+	fmt.Printf("int=%d\n", scannerCall(t, scanner, "nextInt()I"))
+	fmt.Printf("double=%v\n", scannerCall(t, scanner, "nextDouble()D"))
+	fmt.Printf("rest=%q\n", scannerString(t, scannerCall(t, scanner, "nextLine()Ljava/lang/String;")))
+	fmt.Printf("line=%q\n", scannerString(t, scannerCall(t, scanner, "nextLine()Ljava/lang/String;")))
+	fmt.Printf("hasNextInt=%t\n", scannerCall(t, scanner, "hasNextInt()Z") == types.JavaBoolTrue)
+	fmt.Printf("next=%s\n", scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")))
+	fmt.Printf("hasNext=%t\n", scannerCall(t, scanner, "hasNext()Z") == types.JavaBoolTrue)
+}
+
+func TestScanner_StringTokensAndNumbers(t *testing.T) {
+	scanner := newScanner(t, "<init>(Ljava/lang/String;)V", object.StringObjectFromGoString("  12  -7 1,234,567\t9999999999 ff 2.5e3 word"))
+
+	if got := scannerCall(t, scanner, "nextInt()I"); got != int64(12) {
+		t.Errorf("nextInt: expected 12, got %v", got)
+	}
+	if got := scannerCall(t, scanner, "nextShort()S"); got != int64(-7) {
+		t.Errorf("nextShort: expected -7, got %v", got)
+	}
+	if got := scannerCall(t, scanner, "nextInt()I"); got != int64(1234567) {
+		t.Errorf("nextInt with grouping: expected 1234567, got %v", got)
+	}
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "nextInt()I"), excNames.InputMismatchException, "")
+	if got := scannerCall(t, scanner, "nextLong()J"); got != int64(9999999999) {
+		t.Errorf("nextLong: expected 9999999999, got %v", got)
+	}
+	if got := scannerCall(t, scanner, "nextInt(I)I", int64(16)); got != int64(255) {
+		t.Errorf("nextInt(16): expected 255, got %v", got)
+	}
+	if got := scannerCall(t, scanner, "nextDouble()D"); got != 2500.0 {
+		t.Errorf("nextDouble: expected 2500.0, got %v", got)
+	}
+
+	// a mismatched token is left in place
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "nextInt()I"), excNames.InputMismatchException, "")
+	if got := scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")); got != "word" {
+		t.Errorf("next after a mismatch: expected word, got %q", got)
+	}
+
+	if scannerCall(t, scanner, "hasNext()Z") != types.JavaBoolFalse {
+		t.Errorf("hasNext at end of input: expected false")
+	}
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "next()Ljava/lang/String;"), excNames.NoSuchElementException, "")
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "nextLine()Ljava/lang/String;"), excNames.NoSuchElementException, "")
+}
+
+func TestScanner_DelimiterAndFindInLine(t *testing.T) {
+	scanner := newScanner(t, "<init>(Ljava/lang/String;)V", object.StringObjectFromGoString("a, b,,c\nid=77 rest\n"))
+
+	if ret := scannerCall(t, scanner, "useDelimiter(Ljava/lang/String;)Ljava/util/Scanner;", object.StringObjectFromGoString(`,\s*|\n`)); ret != scanner {
+		t.Fatalf("useDelimiter should return the scanner, got %v", ret)
+	}
+	var tokens []string
+	for range 4 {
+		tokens = append(tokens, scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")))
+	}
+	if strings.Join(tokens, "|") != "a|b||c" {
+		t.Errorf("tokens with a custom delimiter: got %v", tokens)
+	}
+	scannerCall(t, scanner, "nextLine()Ljava/lang/String;") // the end of the first line
+
+	if got := scannerCall(t, scanner, "findInLine(Ljava/lang/String;)Ljava/lang/String;", object.StringObjectFromGoString("missing")); got != object.Null {
+		t.Errorf("findInLine without a match: expected null, got %v", got)
+	}
+	if got := scannerString(t, scannerCall(t, scanner, "findInLine(Ljava/lang/String;)Ljava/lang/String;", object.StringObjectFromGoString(`\d+`))); got != "77" {
+		t.Errorf("findInLine: expected 77, got %q", got)
+	}
+	if got := scannerString(t, scannerCall(t, scanner, "nextLine()Ljava/lang/String;")); got != " rest" {
+		t.Errorf("nextLine after findInLine: expected \" rest\", got %q", got)
+	}
+
+	ret := scannerCall(t, scanner, "useDelimiter(Ljava/lang/String;)Ljava/util/Scanner;", object.StringObjectFromGoString("("))
+	testutil.ExpectGErr(t, ret, excNames.PatternSyntaxException, "")
+}
+
+// newFailingReader returns a Java Reader of a made-up class whose read() returns the chars of
+// text and then throws an IllegalStateException.
+func newFailingReader(className, text string) *object.Object {
+	chars := []rune(text)
+	ghelpers.MethodSignatures[className+".read()I"] = ghelpers.GMeth{
+		ParamSlots: 0,
+		GFunction: func(params []interface{}) interface{} {
+			if len(chars) == 0 {
+				return ghelpers.GetGErrBlk(excNames.IllegalStateException, "reader failed")
+			}
+			ch := chars[0]
+			chars = chars[1:]
+			return int64(ch)
+		},
+	}
+	return object.MakeEmptyObjectWithClassName(&className)
+}
+
+func TestScanner_JavaReaderException(t *testing.T) {
+	globals.InitStringPool()
+	javaUtil.Load_Util_Scanner()
+	reader := newFailingReader("test/ScannerFailingReader", "one café\n")
+	scanner := object.MakeEmptyObjectWithClassName(new("java/util/Scanner"))
+	if ret := scannerCall(t, scanner, "<init>(Ljava/lang/Readable;)V", reader); ret != nil {
+		t.Fatalf("<init> returned %v", ret)
+	}
+
+	if got := scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")); got != "one" {
+		t.Errorf("next: expected one, got %q", got)
+	}
+	if got := scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")); got != "café" {
+		t.Errorf("next: expected café, got %q", got)
+	}
+	// the exception that read() throws is passed on, and the input then ends
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "next()Ljava/lang/String;"), excNames.IllegalStateException, "reader failed")
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "next()Ljava/lang/String;"), excNames.NoSuchElementException, "")
+}
+
+func TestScanner_FileAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "numbers.txt")
+	if err := os.WriteFile(path, []byte("1\n2\n3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileObj := object.MakeEmptyObjectWithClassName(new("java/io/File"))
+	fileObj.FieldTable[ghelpers.FilePath] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoString(path)}
+
+	scanner := newScanner(t, "<init>(Ljava/io/File;)V", fileObj)
+	var sum int64
+	for scannerCall(t, scanner, "hasNextInt()Z") == types.JavaBoolTrue {
+		sum += scannerCall(t, scanner, "nextInt()I").(int64)
+	}
+	if sum != 6 {
+		t.Errorf("sum of the file's numbers: expected 6, got %d", sum)
+	}
+
+	scannerCall(t, scanner, "close()V")
+	testutil.ExpectGErr(t, scannerCall(t, scanner, "hasNext()Z"), excNames.IllegalStateException, "")
+
+	missing := object.MakeEmptyObjectWithClassName(new("java/io/File"))
+	missing.FieldTable[ghelpers.FilePath] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoString(path + ".missing")}
+	other := object.MakeEmptyObjectWithClassName(new("java/util/Scanner"))
+	testutil.ExpectGErr(t, scannerCall(t, other, "<init>(Ljava/io/File;)V", missing), excNames.FileNotFoundException, "")
+}
+
+func TestScanner_FileCharset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "latin1.txt")
+	if err := os.WriteFile(path, []byte("caf\xe9 na\xefve\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	globals.InitStringPool()
+	javaUtil.Load_Util_Scanner()
+	fileObj := object.MakeEmptyObjectWithClassName(new("java/io/File"))
+	fileObj.FieldTable[ghelpers.FilePath] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.JavaByteArrayFromGoString(path)}
+	ctor := "<init>(Ljava/io/File;Ljava/lang/String;)V"
+
+	scanner := object.MakeEmptyObjectWithClassName(new("java/util/Scanner"))
+	if ret := scannerCall(t, scanner, ctor, fileObj, object.StringObjectFromGoString("ISO-8859-1")); ret != nil {
+		t.Fatalf("%s returned %v", ctor, ret)
+	}
+	if got := scannerString(t, scannerCall(t, scanner, "next()Ljava/lang/String;")); got != "café" {
+		t.Errorf("next: expected café, got %q", got)
+	}
+	if got := scannerString(t, scannerCall(t, scanner, "nextLine()Ljava/lang/String;")); got != " naïve" {
+		t.Errorf("nextLine: expected \" naïve\", got %q", got)
+	}
+	scannerCall(t, scanner, "close()V")
+
+	other := object.MakeEmptyObjectWithClassName(new("java/util/Scanner"))
+	testutil.ExpectGErr(t, scannerCall(t, other, ctor, fileObj, object.StringObjectFromGoString("no-such-charset")),
+		excNames.IllegalArgumentException, "UnsupportedCharsetException: no-such-charset")
+	testutil.ExpectGErr(t, scannerCall(t, other, ctor, fileObj, object.Null), excNames.NullPointerException, "charsetName")
+}
@@ -7,7 +7,10 @@
 package javaUtil
 
 import (
+	"fmt"
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
 )
 
 func Load_Util_SequencedMap() {
@@ -20,61 +23,138 @@ func Load_Util_SequencedMap() {
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.firstEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapFirstEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.lastEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapLastEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.pollFirstEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapPollFirstEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.pollLastEntry()Ljava/util/Map$Entry;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapPollLastEntry,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.putFirst(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    sequencedmapPutFirst,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.putLast(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   2,
+			GFunction:    sequencedmapPutLast,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.reversed()Ljava/util/SequencedMap;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapReversed,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.sequencedEntrySet()Ljava/util/SequencedSet;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapEntrySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.sequencedKeySet()Ljava/util/SequencedSet;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapKeySet,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/SequencedMap.sequencedValues()Ljava/util/SequencedCollection;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedmapValues,
+			NeedsContext: true,
 		}
 }
+
+// The SequencedMap methods are implemented by TreeMap and LinkedHashMap. These functions serve
+// calls made through the SequencedMap and Map interfaces by dispatching on the map's Go state.
+
+// sequencedMapFunc returns treeFn or linkedFn according to the kind of map params holds, or a
+// function that reports UnsupportedOperationException for any other map.
+func sequencedMapFunc(params []interface{}, caller string, treeFn, linkedFn func([]interface{}) interface{}) func([]interface{}) interface{} {
+	_, args := ghelpers.SplitContext(params)
+	if len(args) > 0 {
+		if this, ok := args[0].(*object.Object); ok && !object.IsNull(this) {
+			if _, ok := this.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+				return treeFn
+			}
+			if _, ok := this.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+				return linkedFn
+			}
+			className := object.GoStringFromStringPoolIndex(this.KlassName)
+			errMsg := fmt.Sprintf("%s: not supported for class %s", caller, className)
+			return func([]interface{}) interface{} {
+				return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, errMsg)
+			}
+		}
+	}
+	return func([]interface{}) interface{} {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": 'this' is null")
+	}
+}
+
+func sequencedmapFirstEntry(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapFirstEntry", treemapFirstEntry, linkedhashmapFirstEntry)(params)
+}
+
+func sequencedmapLastEntry(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapLastEntry", treemapLastEntry, linkedhashmapLastEntry)(params)
+}
+
+func sequencedmapPollFirstEntry(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapPollFirstEntry", treemapPollFirstEntry, linkedhashmapPollFirstEntry)(params)
+}
+
+func sequencedmapPollLastEntry(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapPollLastEntry", treemapPollLastEntry, linkedhashmapPollLastEntry)(params)
+}
+
+func sequencedmapPutFirst(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapPutFirst", sortedPutFirstLast, linkedhashmapPutFirst)(params)
+}
+
+func sequencedmapPutLast(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapPutLast", sortedPutFirstLast, linkedhashmapPutLast)(params)
+}
+
+func sequencedmapReversed(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapReversed", treemapDescendingMap, linkedReversed)(params)
+}
+
+func sequencedmapEntrySet(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapEntrySet", treemapEntrySet, linkedhashmapEntrySet)(params)
+}
+
+func sequencedmapKeySet(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapKeySet", treemapNavigableKeySet, linkedhashmapKeySet)(params)
+}
+
+func sequencedmapValues(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedmapValues", treemapValues, linkedhashmapValues)(params)
+}
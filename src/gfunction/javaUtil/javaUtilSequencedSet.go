@@ -20,7 +20,13 @@ func Load_Util_SequencedSet() {
 
 	ghelpers.MethodSignatures["java/util/SequencedSet.reversed()Ljava/util/SequencedSet;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   0,
+			GFunction:    sequencedsetReversed,
+			NeedsContext: true,
 		}
 }
+
+// reversed() for TreeSet and LinkedHashSet, when called through the SequencedSet interface
+func sequencedsetReversed(params []interface{}) interface{} {
+	return sequencedMapFunc(params, "sequencedsetReversed", treemapDescendingKeySet, linkedReversed)(params)
+}
@@ -10,6 +10,8 @@ import (
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/object"
+	"jacobin/src/types"
+	"slices"
 )
 
 func Load_Util_Set() {
@@ -22,8 +24,9 @@ func Load_Util_Set() {
 
 	ghelpers.MethodSignatures["java/util/Set.add(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetAdd,
+			ParamSlots:   1,
+			GFunction:    hashsetAdd,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.addAll(Ljava/util/Collection;)Z"] =
@@ -35,13 +38,14 @@ func Load_Util_Set() {
 	ghelpers.MethodSignatures["java/util/Set.clear()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  hashmapInit,
+			GFunction:  hashmapClear,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.contains(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetContains,
+			ParamSlots:   1,
+			GFunction:    hashsetContains,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.containsAll(Ljava/util/Collection;)Z"] =
@@ -76,8 +80,9 @@ func Load_Util_Set() {
 
 	ghelpers.MethodSignatures["java/util/Set.remove(Ljava/lang/Object;)Z"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  hashsetRemove,
+			ParamSlots:   1,
+			GFunction:    hashsetRemove,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.removeAll(Ljava/util/Collection;)Z"] =
@@ -118,80 +123,93 @@ func Load_Util_Set() {
 
 	ghelpers.MethodSignatures["java/util/Set.of()Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  setOf,
+			ParamSlots:   0,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  setOf,
+			ParamSlots:   1,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 2,
-			GFunction:  setOf,
+			ParamSlots:   2,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 3,
-			GFunction:  setOf,
+			ParamSlots:   3,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 4,
-			GFunction:  setOf,
+			ParamSlots:   4,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 5,
-			GFunction:  setOf,
+			ParamSlots:   5,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 6,
-			GFunction:  setOf,
+			ParamSlots:   6,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 7,
-			GFunction:  setOf,
+			ParamSlots:   7,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 8,
-			GFunction:  setOf,
+			ParamSlots:   8,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 9,
-			GFunction:  setOf,
+			ParamSlots:   9,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of(Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 10,
-			GFunction:  setOf,
+			ParamSlots:   10,
+			GFunction:    setOf,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.of([Ljava/lang/Object;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  setOfVarargs,
+			ParamSlots:   1,
+			GFunction:    setOfVarargs,
+			NeedsContext: true,
 		}
 
 	ghelpers.MethodSignatures["java/util/Set.copyOf(Ljava/util/Collection;)Ljava/util/Set;"] =
 		ghelpers.GMeth{
-			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots:   1,
+			GFunction:    setCopyOf,
+			NeedsContext: true,
 		}
 }
 
@@ -206,37 +224,37 @@ func setIterator(params []interface{}) interface{} {
 func setOf(params []interface{}) interface{} {
 	// Java Set.of(...) returns an unmodifiable set.
 	// In Java, Set.of(...) also forbids null elements and duplicates.
-	seen := make(map[interface{}]bool)
+	fs, params := ghelpers.SplitContext(params)
 	for _, p := range params {
 		if p == nil || p == object.Null {
 			return ghelpers.GetGErrBlk(excNames.NullPointerException, "Set.of: null element")
 		}
-		// In a real VM we should check for value equality.
-		// For now, pointer equality for objects.
-		if seen[p] {
-			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Set.of: duplicate element")
-		}
-		seen[p] = true
 	}
 
-	// We'll return a HashSet for now as a minimal implementation.
+	// The result is a HashSet marked as unmodifiable; see javaUtilCollections.go.
 	hs := object.MakeEmptyObjectWithClassName(&classNameHashSet)
 	if ret := hashmapInit([]interface{}{hs}); ret != nil {
 		return ret
 	}
 
 	for _, p := range params {
-		if ret := hashsetAdd([]interface{}{hs, p}); ret != nil {
-			if _, ok := ret.(*ghelpers.GErrBlk); ok {
-				return ret
-			}
+		ret := hashsetAdd([]interface{}{fs, hs, p})
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+		if ret == types.JavaBoolFalse {
+			return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Set.of: duplicate element: "+javaToString(fs, p))
 		}
 	}
 
-	return hs
+	return markUnmodifiable(hs)
 }
 
 func setOfVarargs(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	setOf := func(elements []interface{}) interface{} {
+		return setOf(append([]interface{}{fs}, elements...))
+	}
 	if len(params) == 0 {
 		return setOf([]interface{}{})
 	}
@@ -267,3 +285,28 @@ func setOfVarargs(params []interface{}) interface{} {
 
 	return setOf(ifaceElements)
 }
+
+// java/util/Set.copyOf(Ljava/util/Collection;)Ljava/util/Set;
+// Unlike Set.of, duplicate elements are allowed; only the first of them is kept.
+func setCopyOf(params []interface{}) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	coll, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(coll) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Set.copyOf: collection is null")
+	}
+	if isUnmodifiable(coll) && isHashSet(coll) {
+		return coll // already an immutable set
+	}
+	elements, gerr := collectionElements(fs, coll)
+	if gerr != nil {
+		return gerr
+	}
+	if slices.ContainsFunc(elements, isNullValue) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "Set.copyOf: null element")
+	}
+	set, gerr := makeHashSetInOrder(fs, elements)
+	if gerr != nil {
+		return gerr
+	}
+	return markUnmodifiable(set)
+}
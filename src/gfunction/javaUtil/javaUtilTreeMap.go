@@ -0,0 +1,1352 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"fmt"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"math/rand/v2"
+	"strings"
+)
+
+// TreeMap (and TreeSet, which shares its machinery) keeps its entries sorted by key in a treap, a
+// randomized balanced binary search tree, whose nodes also count the entries below them. Lookups,
+// insertions and removals take O(log n) comparisons, and an entry can be found by its index in key
+// order as well as by its key, which is how the bounds of views are worked out.
+// The ordering is either the natural ordering of the keys or a Java Comparator, whose compare()
+// method is run through the JVM. Views such as headMap(), tailMap(), subMap() and descendingMap()
+// are TreeMap objects that share the backing store of the map they came from, so that changes
+// made through a view are visible in the original map and vice versa, as in the JDK.
+//
+// Like the JDK's TreeMap, these maps are not synchronized.
+
+var classNameTreeMap = "java/util/TreeMap"
+var fieldNameTree = "tree"
+
+// treeEntry is a single key/value pair. TreeSet entries carry a nil value.
+type treeEntry struct {
+	key   any
+	value any
+}
+
+// treeStore holds the sorted entries shared by a map and all of its views.
+type treeStore struct {
+	root       *treeNode
+	comparator *object.Object // nil for natural ordering
+}
+
+// treeView is the Go state held by each TreeMap or TreeSet object.
+type treeView struct {
+	store      *treeStore
+	lo, hi     any
+	hasLo      bool
+	hasHi      bool
+	loIncl     bool
+	hiIncl     bool
+	descending bool
+}
+
+func Load_Util_TreeMap() {
+
+	ghelpers.MethodSignatures["java/util/TreeMap.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapInit}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.<init>(Ljava/util/Comparator;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapInit}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.<init>(Ljava/util/Map;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapInitFromMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.<init>(Ljava/util/SortedMap;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapInitFromSortedMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.ceilingEntry(Ljava/lang/Object;)Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapCeilingEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.ceilingKey(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapCeilingKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapClear, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.clone()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapClone, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.comparator()Ljava/util/Comparator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treeComparator}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.compute(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedCompute, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.computeIfAbsent(Ljava/lang/Object;Ljava/util/function/Function;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedComputeIfAbsent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.computeIfPresent(Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: orderedComputeIfPresent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.containsKey(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapContainsKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.containsValue(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapContainsValue, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.descendingKeySet()Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapDescendingKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.descendingMap()Ljava/util/NavigableMap;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapDescendingMap}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.entrySet()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapEntrySet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.equals(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: mapEquals, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.firstEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapFirstEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.firstKey()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapFirstKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.floorEntry(Ljava/lang/Object;)Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapFloorEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.floorKey(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapFloorKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.forEach(Ljava/util/function/BiConsumer;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapForEach, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.get(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapGet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.getOrDefault(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapGetOrDefault, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.hashCode()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: mapHashCode, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.headMap(Ljava/lang/Object;)Ljava/util/SortedMap;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapHeadMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.headMap(Ljava/lang/Object;Z)Ljava/util/NavigableMap;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapHeadMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.higherEntry(Ljava/lang/Object;)Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapHigherEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.higherKey(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapHigherKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapIsEmpty, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.keySet()Ljava/util/Set;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapNavigableKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.lastEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapLastEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.lastKey()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapLastKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.lowerEntry(Ljava/lang/Object;)Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapLowerEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.lowerKey(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapLowerKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.merge(Ljava/lang/Object;Ljava/lang/Object;Ljava/util/function/BiFunction;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 3, GFunction: orderedMerge, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.navigableKeySet()Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapNavigableKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.pollFirstEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapPollFirstEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.pollLastEntry()Ljava/util/Map$Entry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapPollLastEntry, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapPut, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.putAll(Ljava/util/Map;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapPutAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.putFirst(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: sortedPutFirstLast}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.putIfAbsent(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapPutIfAbsent, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.putLast(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: sortedPutFirstLast}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.remove(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapRemove, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.replace(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapReplace, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.replaceAll(Ljava/util/function/BiFunction;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: orderedReplaceAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.reversed()Ljava/util/SequencedMap;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapDescendingMap}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.sequencedEntrySet()Ljava/util/SequencedSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapEntrySet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.sequencedKeySet()Ljava/util/SequencedSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapNavigableKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.sequencedValues()Ljava/util/SequencedCollection;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapValues, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapSize, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.subMap(Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/SortedMap;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapSubMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.subMap(Ljava/lang/Object;ZLjava/lang/Object;Z)Ljava/util/NavigableMap;"] =
+		ghelpers.GMeth{ParamSlots: 4, GFunction: treemapSubMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.tailMap(Ljava/lang/Object;)Ljava/util/SortedMap;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapTailMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.tailMap(Ljava/lang/Object;Z)Ljava/util/NavigableMap;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treemapTailMap, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapToString, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeMap.values()Ljava/util/Collection;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapValues, NeedsContext: true}
+
+	// the Comparator returned by comparator() for descending views
+	ghelpers.MethodSignatures[classNameReverseComparator+".compare"+methTypeCompare] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: reverseComparatorCompare, NeedsContext: true}
+
+	ghelpers.MethodSignatures[classNameReverseComparator2+".compare"+methTypeCompare] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: reverseComparatorCompare, NeedsContext: true}
+}
+
+// --- treeStore and treeView mechanics ---
+
+// treeNode is a node of the treap that holds the entries of a treeStore. Each node records the
+// size of its subtree, so that entries can be found by their index in key order.
+type treeNode struct {
+	entry       *treeEntry
+	priority    uint32
+	size        int
+	left, right *treeNode
+}
+
+func nodeSize(n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treeNode) resize() {
+	n.size = nodeSize(n.left) + 1 + nodeSize(n.right)
+}
+
+// splitNodes splits the treap rooted at n into the first count entries and the rest.
+func splitNodes(n *treeNode, count int) (*treeNode, *treeNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if nodeSize(n.left) < count {
+		left, right := splitNodes(n.right, count-nodeSize(n.left)-1)
+		n.right = left
+		n.resize()
+		return n, right
+	}
+	left, right := splitNodes(n.left, count)
+	n.left = right
+	n.resize()
+	return left, n
+}
+
+// mergeNodes joins two treaps, all of whose entries in left come before those in right.
+func mergeNodes(left, right *treeNode) *treeNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.right = mergeNodes(left.right, right)
+		left.resize()
+		return left
+	}
+	right.left = mergeNodes(left, right.left)
+	right.resize()
+	return right
+}
+
+func (st *treeStore) len() int {
+	return nodeSize(st.root)
+}
+
+// at returns the entry at index idx in key order.
+func (st *treeStore) at(idx int) *treeEntry {
+	n := st.root
+	for {
+		leftSize := nodeSize(n.left)
+		switch {
+		case idx < leftSize:
+			n = n.left
+		case idx == leftSize:
+			return n.entry
+		default:
+			idx -= leftSize + 1
+			n = n.right
+		}
+	}
+}
+
+// insertAt inserts entry so that it ends up at index idx.
+func (st *treeStore) insertAt(idx int, entry *treeEntry) {
+	left, right := splitNodes(st.root, idx)
+	node := &treeNode{entry: entry, priority: rand.Uint32(), size: 1}
+	st.root = mergeNodes(mergeNodes(left, node), right)
+}
+
+// removeRange deletes the entries with indexes in [from, to).
+func (st *treeStore) removeRange(from, to int) {
+	left, rest := splitNodes(st.root, from)
+	_, right := splitNodes(rest, to-from)
+	st.root = mergeNodes(left, right)
+}
+
+// slice returns the entries with indexes in [from, to), in key order.
+func (st *treeStore) slice(from, to int) []*treeEntry {
+	entries := make([]*treeEntry, 0, to-from)
+	var walk func(n *treeNode, offset int)
+	walk = func(n *treeNode, offset int) {
+		if n == nil || offset >= to || offset+n.size <= from {
+			return
+		}
+		walk(n.left, offset)
+		if idx := offset + nodeSize(n.left); idx >= from && idx < to {
+			entries = append(entries, n.entry)
+		}
+		walk(n.right, offset+nodeSize(n.left)+1)
+	}
+	walk(st.root, 0)
+	return entries
+}
+
+// search returns the index of the first entry whose key is >= key and whether that entry's key equals key.
+func (st *treeStore) search(fs *list.List, key any) (int, bool, *ghelpers.GErrBlk) {
+	idx := st.len()
+	offset := 0
+	for n := st.root; n != nil; {
+		cmp, gerr := javaCompare(fs, st.comparator, n.entry.key, key)
+		if gerr != nil {
+			return 0, false, gerr
+		}
+		if cmp == 0 {
+			return offset + nodeSize(n.left), true, nil
+		}
+		if cmp > 0 {
+			idx = offset + nodeSize(n.left)
+			n = n.left
+		} else {
+			offset += nodeSize(n.left) + 1
+			n = n.right
+		}
+	}
+	return idx, false, nil
+}
+
+// upperBound returns the index of the first entry whose key is > key.
+func (st *treeStore) upperBound(fs *list.List, key any) (int, *ghelpers.GErrBlk) {
+	idx, found, gerr := st.search(fs, key)
+	if gerr != nil {
+		return 0, gerr
+	}
+	if found {
+		idx++
+	}
+	return idx, nil
+}
+
+// bounds returns the half-open range [from, to) of store indexes visible through the view.
+func (tv *treeView) bounds(fs *list.List) (int, int, *ghelpers.GErrBlk) {
+	from, to := 0, tv.store.len()
+	var gerr *ghelpers.GErrBlk
+	if tv.hasLo {
+		if tv.loIncl {
+			from, _, gerr = tv.store.search(fs, tv.lo)
+		} else {
+			from, gerr = tv.store.upperBound(fs, tv.lo)
+		}
+		if gerr != nil {
+			return 0, 0, gerr
+		}
+	}
+	if tv.hasHi {
+		if tv.hiIncl {
+			to, gerr = tv.store.upperBound(fs, tv.hi)
+		} else {
+			to, _, gerr = tv.store.search(fs, tv.hi)
+		}
+		if gerr != nil {
+			return 0, 0, gerr
+		}
+	}
+	if to < from {
+		to = from
+	}
+	return from, to, nil
+}
+
+// inRange reports whether key falls within the bounds of the view.
+func (tv *treeView) inRange(fs *list.List, key any) (bool, *ghelpers.GErrBlk) {
+	if tv.hasLo {
+		cmp, gerr := javaCompare(fs, tv.store.comparator, key, tv.lo)
+		if gerr != nil {
+			return false, gerr
+		}
+		if cmp < 0 || (cmp == 0 && !tv.loIncl) {
+			return false, nil
+		}
+	}
+	if tv.hasHi {
+		cmp, gerr := javaCompare(fs, tv.store.comparator, key, tv.hi)
+		if gerr != nil {
+			return false, gerr
+		}
+		if cmp > 0 || (cmp == 0 && !tv.hiIncl) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// entries returns a snapshot of the view's entries in view order.
+func (tv *treeView) entries(fs *list.List) ([]*treeEntry, *ghelpers.GErrBlk) {
+	from, to, gerr := tv.bounds(fs)
+	if gerr != nil {
+		return nil, gerr
+	}
+	snapshot := tv.store.slice(from, to)
+	if tv.descending {
+		for ix, jx := 0, len(snapshot)-1; ix < jx; ix, jx = ix+1, jx-1 {
+			snapshot[ix], snapshot[jx] = snapshot[jx], snapshot[ix]
+		}
+	}
+	return snapshot, nil
+}
+
+func (tv *treeView) size(fs *list.List) (int, *ghelpers.GErrBlk) {
+	if !tv.hasLo && !tv.hasHi {
+		return tv.store.len(), nil
+	}
+	from, to, gerr := tv.bounds(fs)
+	return to - from, gerr
+}
+
+// get returns the entry for key, or nil if there is none in the view.
+func (tv *treeView) get(fs *list.List, key any) (*treeEntry, *ghelpers.GErrBlk) {
+	if isNullValue(key) && tv.store.comparator == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "TreeMap: null keys are not permitted with natural ordering")
+	}
+	ok, gerr := tv.inRange(fs, key)
+	if gerr != nil || !ok {
+		return nil, gerr
+	}
+	idx, found, gerr := tv.store.search(fs, key)
+	if gerr != nil || !found {
+		return nil, gerr
+	}
+	return tv.store.at(idx), nil
+}
+
+// put inserts or replaces key. It returns the previous entry (nil if there was none).
+func (tv *treeView) put(fs *list.List, key, value any) (*treeEntry, *ghelpers.GErrBlk) {
+	if isNullValue(key) && tv.store.comparator == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "TreeMap: null keys are not permitted with natural ordering")
+	}
+	ok, gerr := tv.inRange(fs, key)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "key out of range")
+	}
+
+	// The first entry is compared with itself so that an uncomparable key is rejected, as in the JDK.
+	if tv.store.len() == 0 {
+		if _, gerr = javaCompare(fs, tv.store.comparator, key, key); gerr != nil {
+			return nil, gerr
+		}
+	}
+
+	idx, found, gerr := tv.store.search(fs, key)
+	if gerr != nil {
+		return nil, gerr
+	}
+	if found {
+		entry := tv.store.at(idx)
+		prev := &treeEntry{key: entry.key, value: entry.value}
+		entry.value = value
+		return prev, nil
+	}
+	tv.store.insertAt(idx, &treeEntry{key: key, value: value})
+	return nil, nil
+}
+
+// remove deletes key from the view, returning the removed entry or nil.
+func (tv *treeView) remove(fs *list.List, key any) (*treeEntry, *ghelpers.GErrBlk) {
+	entry, gerr := tv.get(fs, key)
+	if gerr != nil || entry == nil {
+		return nil, gerr
+	}
+	return entry, tv.removeEntry(fs, entry)
+}
+
+// removeEntry deletes a specific entry, which is looked up by its key, from the backing store.
+func (tv *treeView) removeEntry(fs *list.List, entry *treeEntry) *ghelpers.GErrBlk {
+	idx, found, gerr := tv.store.search(fs, entry.key)
+	if gerr != nil {
+		return gerr
+	}
+	if found && tv.store.at(idx) == entry {
+		tv.store.removeRange(idx, idx+1)
+	}
+	return nil
+}
+
+// clear removes every entry visible through the view.
+func (tv *treeView) clear(fs *list.List) *ghelpers.GErrBlk {
+	from, to, gerr := tv.bounds(fs)
+	if gerr != nil {
+		return gerr
+	}
+	tv.store.removeRange(from, to)
+	return nil
+}
+
+// end returns the first (last == false) or last (last == true) entry of the view in view order.
+func (tv *treeView) end(fs *list.List, last bool) (*treeEntry, *ghelpers.GErrBlk) {
+	from, to, gerr := tv.bounds(fs)
+	if gerr != nil || from == to {
+		return nil, gerr
+	}
+	if last != tv.descending {
+		return tv.store.at(to - 1), nil
+	}
+	return tv.store.at(from), nil
+}
+
+// Navigation relations, expressed in terms of the view's ordering.
+const (
+	navLower = iota
+	navFloor
+	navCeiling
+	navHigher
+)
+
+// navigate implements lower(), floor(), ceiling() and higher() for the view.
+func (tv *treeView) navigate(fs *list.List, key any, relation int) (*treeEntry, *ghelpers.GErrBlk) {
+	if isNullValue(key) && tv.store.comparator == nil {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "TreeMap: null keys are not permitted with natural ordering")
+	}
+	if tv.descending { // a descending view reverses each relation
+		relation = navHigher - relation
+	}
+	from, to, gerr := tv.bounds(fs)
+	if gerr != nil {
+		return nil, gerr
+	}
+	lower, found, gerr := tv.store.search(fs, key)
+	if gerr != nil {
+		return nil, gerr
+	}
+	upper := lower
+	if found {
+		upper++
+	}
+
+	idx := -1
+	switch relation {
+	case navLower:
+		idx = min(lower, to) - 1
+	case navFloor:
+		idx = min(upper, to) - 1
+	case navCeiling:
+		idx = max(lower, from)
+	case navHigher:
+		idx = max(upper, from)
+	}
+	if idx < from || idx >= to {
+		return nil, nil
+	}
+	return tv.store.at(idx), nil
+}
+
+// subView returns a new view over the same store, narrowed by the given bounds, which are
+// expressed in terms of this view's ordering.
+func (tv *treeView) subView(fs *list.List, from any, hasFrom, fromIncl bool, to any, hasTo, toIncl bool) (*treeView, *ghelpers.GErrBlk) {
+	if tv.descending {
+		from, to = to, from
+		hasFrom, hasTo = hasTo, hasFrom
+		fromIncl, toIncl = toIncl, fromIncl
+	}
+	if hasFrom && hasTo {
+		cmp, gerr := javaCompare(fs, tv.store.comparator, from, to)
+		if gerr != nil {
+			return nil, gerr
+		}
+		if cmp > 0 {
+			return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "fromKey > toKey")
+		}
+	}
+
+	nv := *tv
+	for _, bound := range []struct {
+		key  any
+		has  bool
+		incl bool
+		isLo bool
+	}{{from, hasFrom, fromIncl, true}, {to, hasTo, toIncl, false}} {
+		if !bound.has {
+			continue
+		}
+		if isNullValue(bound.key) && tv.store.comparator == nil {
+			return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "TreeMap: null keys are not permitted with natural ordering")
+		}
+		ok, gerr := tv.inRange(fs, bound.key)
+		if gerr != nil {
+			return nil, gerr
+		}
+		if !ok && !tv.isOnEdge(fs, bound.key) {
+			return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "key out of range")
+		}
+		if bound.isLo {
+			nv.lo, nv.hasLo, nv.loIncl = bound.key, true, bound.incl
+		} else {
+			nv.hi, nv.hasHi, nv.hiIncl = bound.key, true, bound.incl
+		}
+	}
+	return &nv, nil
+}
+
+// isOnEdge reports whether key equals an exclusive bound of the view, which is still a legal
+// bound for a narrower view.
+func (tv *treeView) isOnEdge(fs *list.List, key any) bool {
+	for _, edge := range []struct {
+		has bool
+		key any
+	}{{tv.hasLo, tv.lo}, {tv.hasHi, tv.hi}} {
+		if edge.has {
+			if cmp, gerr := javaCompare(fs, tv.store.comparator, key, edge.key); gerr == nil && cmp == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// --- helpers for getting at the Go state of TreeMap and TreeSet objects ---
+
+func newTreeView(comparator *object.Object) *treeView {
+	if comparator != nil && object.IsNull(comparator) {
+		comparator = nil
+	}
+	return &treeView{store: &treeStore{comparator: comparator}}
+}
+
+func setTreeView(obj *object.Object, tv *treeView) {
+	obj.FieldTable[fieldNameTree] = object.Field{Ftype: types.RawGoPointer, Fvalue: tv}
+}
+
+func getTreeView(obj *object.Object, caller string) (*treeView, *ghelpers.GErrBlk) {
+	if obj == nil || object.IsNull(obj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": object is null")
+	}
+	tv, ok := obj.FieldTable[fieldNameTree].Fvalue.(*treeView)
+	if !ok {
+		className := object.GoStringFromStringPoolIndex(obj.KlassName)
+		errMsg := fmt.Sprintf("%s: %s object has not been initialized", caller, className)
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, errMsg)
+	}
+	return tv, nil
+}
+
+// treemapThis unpacks the frame stack (if present), the TreeMap object, and its view.
+func treemapThis(params []interface{}, caller string) (*list.List, []interface{}, *treeView, *ghelpers.GErrBlk) {
+	fs, args := ghelpers.SplitContext(params)
+	if len(args) < 1 {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, caller+": missing 'this' parameter")
+	}
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return nil, nil, nil, ghelpers.GetGErrBlk(excNames.ClassCastException, caller+": 'this' is not an object")
+	}
+	tv, gerr := getTreeView(this, caller)
+	return fs, args, tv, gerr
+}
+
+// makeTreeObject creates a new object of the given class that holds the view.
+func makeTreeObject(className string, tv *treeView) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	setTreeView(obj, tv)
+	return obj
+}
+
+// entryOrNull converts a treeEntry to a Map.Entry snapshot, or Java null.
+func entryOrNull(entry *treeEntry) any {
+	if entry == nil {
+		return object.Null
+	}
+	return makeMapEntry(entry.key, entry.value)
+}
+
+// keyOrNull returns an entry's key, or Java null.
+func keyOrNull(entry *treeEntry) any {
+	if entry == nil {
+		return object.Null
+	}
+	return entry.key
+}
+
+// mapEntries returns a snapshot of key/value pairs of any map Jacobin knows about natively.
+func mapEntries(fs *list.List, mapObj *object.Object) ([]*treeEntry, *ghelpers.GErrBlk) {
+	if mapObj == nil || object.IsNull(mapObj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "mapEntries: map is null")
+	}
+	if backing, ok := mapObj.FieldTable[fieldNameBacking].Fvalue.(*object.Object); ok { // a Collections view
+		return mapEntries(fs, backing)
+	}
+	if tv, ok := mapObj.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		return tv.entries(fs)
+	}
+	if lv, ok := mapObj.FieldTable[fieldNameLinkedMap].Fvalue.(*linkedView); ok {
+		return lv.snapshot(), nil
+	}
+	if hm, ok := mapObj.FieldTable[fieldNameMap].Fvalue.(types.DefHashMap); ok {
+		_, hs, _ := getHashMap(mapObj, "mapEntries")
+		return hashEntries(mapObj, hm, hs), nil
+	}
+	className := object.GoStringFromStringPoolIndex(mapObj.KlassName)
+	return nil, ghelpers.GetGErrBlk(excNames.UnsupportedOperationException,
+		fmt.Sprintf("mapEntries: maps of class %s are not supported", className))
+}
+
+// comparatorOf returns the Comparator of a sorted map or set, or nil for natural ordering.
+func comparatorOf(obj *object.Object) (*object.Object, bool) {
+	if tv, ok := obj.FieldTable[fieldNameTree].Fvalue.(*treeView); ok {
+		return tv.store.comparator, true
+	}
+	if pq, ok := obj.FieldTable[fieldNamePriorityQueue].Fvalue.(*priorityQueue); ok {
+		return pq.comparator, true
+	}
+	return nil, false
+}
+
+// --- TreeMap G functions ---
+
+// TreeMap() and TreeMap(Comparator)
+func treemapInit(params []interface{}) interface{} {
+	this, ok := params[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "treemapInit: invalid 'this' argument")
+	}
+	var comparator *object.Object
+	if len(params) > 1 {
+		comparator, _ = params[1].(*object.Object)
+	}
+	setTreeView(this, newTreeView(comparator))
+	return nil
+}
+
+// TreeMap(Map) uses the natural ordering of the keys.
+func treemapInitFromMap(params []interface{}) interface{} {
+	return treemapInitFrom(params, false)
+}
+
+// TreeMap(SortedMap) uses the same ordering as the sorted map.
+func treemapInitFromSortedMap(params []interface{}) interface{} {
+	return treemapInitFrom(params, true)
+}
+
+func treemapInitFrom(params []interface{}, keepComparator bool) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "treemapInitFromMap: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "treemapInitFromMap: map is null")
+	}
+	var comparator *object.Object
+	if keepComparator {
+		comparator, _ = comparatorOf(source)
+	}
+	tv := newTreeView(comparator)
+	setTreeView(this, tv)
+
+	entries, gerr := mapEntries(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		if _, gerr = tv.put(fs, entry.key, entry.value); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+func treemapPut(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapPut")
+	if gerr != nil {
+		return gerr
+	}
+	prev, gerr := tv.put(fs, args[1], args[2])
+	if gerr != nil {
+		return gerr
+	}
+	if prev == nil {
+		return object.Null
+	}
+	return prev.value
+}
+
+func treemapPutIfAbsent(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapPutIfAbsent")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry != nil && !isNullValue(entry.value) {
+		return entry.value
+	}
+	if _, gerr = tv.put(fs, args[1], args[2]); gerr != nil {
+		return gerr
+	}
+	return object.Null
+}
+
+func treemapPutAll(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapPutAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "treemapPutAll: map is null")
+	}
+	entries, gerr := mapEntries(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		if _, gerr = tv.put(fs, entry.key, entry.value); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+func treemapGet(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapGet")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return object.Null
+	}
+	return entry.value
+}
+
+func treemapGetOrDefault(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapGetOrDefault")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return args[2]
+	}
+	return entry.value
+}
+
+func treemapContainsKey(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapContainsKey")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(entry != nil)
+}
+
+func treemapContainsValue(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapContainsValue")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		eq, gerr := javaEquals(fs, args[1], entry.value)
+		if gerr != nil {
+			return gerr
+		}
+		if eq {
+			return types.JavaBoolTrue
+		}
+	}
+	return types.JavaBoolFalse
+}
+
+func treemapRemove(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapRemove")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.remove(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return object.Null
+	}
+	return entry.value
+}
+
+func treemapReplace(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapReplace")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return object.Null
+	}
+	prev := entry.value
+	entry.value = args[2]
+	return prev
+}
+
+func treemapSize(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapSize")
+	if gerr != nil {
+		return gerr
+	}
+	size, gerr := tv.size(fs)
+	if gerr != nil {
+		return gerr
+	}
+	return int64(size)
+}
+
+func treemapIsEmpty(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapIsEmpty")
+	if gerr != nil {
+		return gerr
+	}
+	size, gerr := tv.size(fs)
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(size == 0)
+}
+
+func treemapClear(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapClear")
+	if gerr != nil {
+		return gerr
+	}
+	if gerr = tv.clear(fs); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// clone() copies the entries visible through the view into a new, independent TreeMap.
+func treemapClone(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapClone")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	if tv.descending {
+		for ix, jx := 0, len(entries)-1; ix < jx; ix, jx = ix+1, jx-1 {
+			entries[ix], entries[jx] = entries[jx], entries[ix]
+		}
+	}
+	nv := newTreeView(tv.store.comparator)
+	for ix, entry := range entries {
+		nv.store.insertAt(ix, &treeEntry{key: entry.key, value: entry.value})
+	}
+	this := args[0].(*object.Object)
+	return makeTreeObject(object.GoStringFromStringPoolIndex(this.KlassName), nv)
+}
+
+// comparator() for TreeMap and TreeSet
+func treeComparator(params []interface{}) interface{} {
+	_, _, tv, gerr := treemapThis(params, "treeComparator")
+	if gerr != nil {
+		return gerr
+	}
+	if tv.descending {
+		return makeReverseComparator(tv.store.comparator)
+	}
+	if tv.store.comparator == nil {
+		return object.Null
+	}
+	return tv.store.comparator
+}
+
+func treemapFirstEntry(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapFirstEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, false)
+	if gerr != nil {
+		return gerr
+	}
+	return entryOrNull(entry)
+}
+
+func treemapLastEntry(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapLastEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, true)
+	if gerr != nil {
+		return gerr
+	}
+	return entryOrNull(entry)
+}
+
+func treemapFirstKey(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapFirstKey")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, false)
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "TreeMap.firstKey: map is empty")
+	}
+	return entry.key
+}
+
+func treemapLastKey(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapLastKey")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, true)
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		return ghelpers.GetGErrBlk(excNames.NoSuchElementException, "TreeMap.lastKey: map is empty")
+	}
+	return entry.key
+}
+
+func treemapPollFirstEntry(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapPollFirstEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, false)
+	if gerr != nil {
+		return gerr
+	}
+	if entry != nil {
+		if gerr = tv.removeEntry(fs, entry); gerr != nil {
+			return gerr
+		}
+	}
+	return entryOrNull(entry)
+}
+
+func treemapPollLastEntry(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapPollLastEntry")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, true)
+	if gerr != nil {
+		return gerr
+	}
+	if entry != nil {
+		if gerr = tv.removeEntry(fs, entry); gerr != nil {
+			return gerr
+		}
+	}
+	return entryOrNull(entry)
+}
+
+// treemapNavigate is the common code for the lower/floor/ceiling/higher Entry and Key methods.
+func treemapNavigate(params []interface{}, caller string, relation int, wantEntry bool) interface{} {
+	fs, args, tv, gerr := treemapThis(params, caller)
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.navigate(fs, args[1], relation)
+	if gerr != nil {
+		return gerr
+	}
+	if wantEntry {
+		return entryOrNull(entry)
+	}
+	return keyOrNull(entry)
+}
+
+func treemapLowerEntry(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapLowerEntry", navLower, true)
+}
+
+func treemapLowerKey(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapLowerKey", navLower, false)
+}
+
+func treemapFloorEntry(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapFloorEntry", navFloor, true)
+}
+
+func treemapFloorKey(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapFloorKey", navFloor, false)
+}
+
+func treemapCeilingEntry(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapCeilingEntry", navCeiling, true)
+}
+
+func treemapCeilingKey(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapCeilingKey", navCeiling, false)
+}
+
+func treemapHigherEntry(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapHigherEntry", navHigher, true)
+}
+
+func treemapHigherKey(params []interface{}) interface{} {
+	return treemapNavigate(params, "treemapHigherKey", navHigher, false)
+}
+
+// headMap(toKey) and headMap(toKey, inclusive)
+func treemapHeadMap(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapHeadMap")
+	if gerr != nil {
+		return gerr
+	}
+	inclusive := len(args) > 2 && args[2] == types.JavaBoolTrue
+	nv, gerr := tv.subView(fs, nil, false, false, args[1], true, inclusive)
+	if gerr != nil {
+		return gerr
+	}
+	return makeTreeObject(classNameTreeMap, nv)
+}
+
+// tailMap(fromKey) and tailMap(fromKey, inclusive)
+func treemapTailMap(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapTailMap")
+	if gerr != nil {
+		return gerr
+	}
+	inclusive := len(args) < 3 || args[2] == types.JavaBoolTrue
+	nv, gerr := tv.subView(fs, args[1], true, inclusive, nil, false, false)
+	if gerr != nil {
+		return gerr
+	}
+	return makeTreeObject(classNameTreeMap, nv)
+}
+
+// subMap(fromKey, toKey) and subMap(fromKey, fromInclusive, toKey, toInclusive)
+func treemapSubMap(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapSubMap")
+	if gerr != nil {
+		return gerr
+	}
+	var nv *treeView
+	if len(args) == 3 {
+		nv, gerr = tv.subView(fs, args[1], true, true, args[2], true, false)
+	} else {
+		nv, gerr = tv.subView(fs, args[1], true, args[2] == types.JavaBoolTrue, args[3], true, args[4] == types.JavaBoolTrue)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	return makeTreeObject(classNameTreeMap, nv)
+}
+
+func treemapDescendingMap(params []interface{}) interface{} {
+	_, _, tv, gerr := treemapThis(params, "treemapDescendingMap")
+	if gerr != nil {
+		return gerr
+	}
+	nv := *tv
+	nv.descending = !tv.descending
+	return makeTreeObject(classNameTreeMap, &nv)
+}
+
+// keySet() and navigableKeySet() return a TreeSet view backed by the map.
+func treemapNavigableKeySet(params []interface{}) interface{} {
+	_, _, tv, gerr := treemapThis(params, "treemapNavigableKeySet")
+	if gerr != nil {
+		return gerr
+	}
+	nv := *tv
+	return makeTreeObject(classNameTreeSet, &nv)
+}
+
+func treemapDescendingKeySet(params []interface{}) interface{} {
+	_, _, tv, gerr := treemapThis(params, "treemapDescendingKeySet")
+	if gerr != nil {
+		return gerr
+	}
+	nv := *tv
+	nv.descending = !tv.descending
+	return makeTreeObject(classNameTreeSet, &nv)
+}
+
+// entrySet() returns an insertion-ordered set holding a snapshot of the map's entries in key order.
+func treemapEntrySet(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapEntrySet")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	elements := make([]any, len(entries))
+	for ix, entry := range entries {
+		elements[ix] = makeMapEntry(entry.key, entry.value)
+	}
+	return makeLinkedHashSetFromElements(elements)
+}
+
+// values() returns a snapshot of the map's values, in key order.
+func treemapValues(params []interface{}) interface{} {
+	fs, _, tv, gerr := treemapThis(params, "treemapValues")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	values := make([]interface{}, len(entries))
+	for ix, entry := range entries {
+		values[ix] = entry.value
+	}
+	return object.MakePrimitiveObject(classNameArrayList, types.ArrayList, values)
+}
+
+// forEach(BiConsumer) runs action.accept(key, value) for each entry in key order.
+func treemapForEach(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapForEach")
+	if gerr != nil {
+		return gerr
+	}
+	action, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(action) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "treemapForEach: action is null")
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	for _, entry := range entries {
+		ret := ghelpers.InvokeMethodOnObject(fs, action, "accept", "(Ljava/lang/Object;Ljava/lang/Object;)V", entry.key, entry.value)
+		if gerr, ok := ret.(*ghelpers.GErrBlk); ok {
+			return gerr
+		}
+	}
+	return nil
+}
+
+func treemapToString(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treemapToString")
+	if gerr != nil {
+		return gerr
+	}
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(formatMapEntries(fs, args[0].(*object.Object), entries))
+}
+
+// formatMapEntries renders entries in the AbstractMap.toString form: {k1=v1, k2=v2}.
+func formatMapEntries(fs *list.List, self *object.Object, entries []*treeEntry) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for ix, entry := range entries {
+		if ix > 0 {
+			sb.WriteString(", ")
+		}
+		for jx, part := range []any{entry.key, entry.value} {
+			if jx > 0 {
+				sb.WriteByte('=')
+			}
+			if part == self {
+				sb.WriteString("(this Map)")
+			} else {
+				sb.WriteString(javaToString(fs, part))
+			}
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// putFirst() and putLast() are not supported by sorted maps, as the position of an entry is
+// determined by its key.
+func sortedPutFirstLast([]interface{}) interface{} {
+	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "putFirst/putLast are not supported by sorted maps")
+}
@@ -0,0 +1,387 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"jacobin/src/util"
+	"testing"
+)
+
+func newTreeMap(t *testing.T, comparator *object.Object) *object.Object {
+	t.Helper()
+	tm := object.MakeEmptyObjectWithClassName(&classNameTreeMap)
+	params := []interface{}{tm}
+	if comparator != nil {
+		params = append(params, comparator)
+	}
+	if ret := treemapInit(params); ret != nil {
+		t.Fatalf("treemapInit returned error: %v", ret)
+	}
+	return tm
+}
+
+func tmPutInts(t *testing.T, tm *object.Object, keys ...int64) {
+	t.Helper()
+	for _, k := range keys {
+		ret := treemapPut([]interface{}{tm, intKey(k), object.StringObjectFromGoString("v")})
+		if _, ok := ret.(*ghelpers.GErrBlk); ok {
+			t.Fatalf("treemapPut(%d) returned error: %v", k, ret)
+		}
+	}
+}
+
+// keyInt unwraps an Integer key, or returns -1 for null.
+func keyInt(t *testing.T, v interface{}) int64 {
+	t.Helper()
+	if object.IsNull(v) {
+		return -1
+	}
+	obj, ok := v.(*object.Object)
+	if !ok {
+		t.Fatalf("expected an Integer object, got %T", v)
+	}
+	return obj.FieldTable["value"].Fvalue.(int64)
+}
+
+func entryKeyInt(t *testing.T, v interface{}) int64 {
+	t.Helper()
+	if object.IsNull(v) {
+		return -1
+	}
+	return keyInt(t, v.(*object.Object).FieldTable["key"].Fvalue)
+}
+
+func TestTreeMap_SortedOrderAndToString(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 30, 10, 20)
+
+	str := object.GoStringFromStringObject(treemapToString([]interface{}{tm}).(*object.Object))
+	if str != "{10=v, 20=v, 30=v}" {
+		t.Errorf("unexpected toString: %q", str)
+	}
+	if size := treemapSize([]interface{}{tm}).(int64); size != 3 {
+		t.Errorf("expected size 3, got %d", size)
+	}
+
+	// replacing a value keeps the size and returns the previous value
+	prev := treemapPut([]interface{}{tm, intKey(20), object.StringObjectFromGoString("w")})
+	if object.GoStringFromStringObject(prev.(*object.Object)) != "v" {
+		t.Errorf("expected previous value v")
+	}
+	if size := treemapSize([]interface{}{tm}).(int64); size != 3 {
+		t.Errorf("expected size 3 after replace, got %d", size)
+	}
+}
+
+func TestTreeMap_StringKeysUseUTF16Order(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	for _, k := range []string{"b", "a", "B", "\U0001F600", "Ａ"} {
+		treemapPut([]interface{}{tm, strKey(k), object.Null})
+	}
+	first := treemapFirstKey([]interface{}{tm}).(*object.Object)
+	if object.GoStringFromStringObject(first) != "B" {
+		t.Errorf("expected first key B, got %q", object.GoStringFromStringObject(first))
+	}
+	// U+1F600 is a surrogate pair (0xD83D...), which sorts before U+FF21 in UTF-16
+	last := treemapLastKey([]interface{}{tm}).(*object.Object)
+	if object.GoStringFromStringObject(last) != "Ａ" {
+		t.Errorf("expected last key U+FF21, got %q", object.GoStringFromStringObject(last))
+	}
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 10, 20, 30)
+
+	tests := []struct {
+		name string
+		fn   func([]interface{}) interface{}
+		arg  int64
+		want int64
+	}{
+		{"floorKey exact", treemapFloorKey, 20, 20},
+		{"floorKey between", treemapFloorKey, 25, 20},
+		{"floorKey below", treemapFloorKey, 5, -1},
+		{"ceilingKey between", treemapCeilingKey, 15, 20},
+		{"ceilingKey above", treemapCeilingKey, 35, -1},
+		{"lowerKey exact", treemapLowerKey, 20, 10},
+		{"higherKey exact", treemapHigherKey, 20, 30},
+		{"higherKey last", treemapHigherKey, 30, -1},
+	}
+	for _, tt := range tests {
+		if got := keyInt(t, tt.fn([]interface{}{tm, intKey(tt.arg)})); got != tt.want {
+			t.Errorf("%s(%d): expected %d, got %d", tt.name, tt.arg, tt.want, got)
+		}
+	}
+
+	if got := entryKeyInt(t, treemapFloorEntry([]interface{}{tm, intKey(29)})); got != 20 {
+		t.Errorf("floorEntry(29): expected 20, got %d", got)
+	}
+}
+
+func TestTreeMap_HeadTailSubMapAreLiveViews(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 10, 20, 30, 40)
+
+	head := treemapHeadMap([]interface{}{tm, intKey(30)}).(*object.Object)
+	if size := treemapSize([]interface{}{head}).(int64); size != 2 {
+		t.Errorf("headMap(30): expected size 2, got %d", size)
+	}
+	tail := treemapTailMap([]interface{}{tm, intKey(30), types.JavaBoolFalse}).(*object.Object)
+	if size := treemapSize([]interface{}{tail}).(int64); size != 1 {
+		t.Errorf("tailMap(30, false): expected size 1, got %d", size)
+	}
+
+	// a change to the backing map shows up in the view
+	tmPutInts(t, tm, 15)
+	if size := treemapSize([]interface{}{head}).(int64); size != 3 {
+		t.Errorf("headMap after put: expected size 3, got %d", size)
+	}
+
+	// a put through the view shows up in the backing map, and out-of-range keys are rejected
+	treemapPut([]interface{}{head, intKey(5), object.Null})
+	if size := treemapSize([]interface{}{tm}).(int64); size != 6 {
+		t.Errorf("map after put via view: expected size 6, got %d", size)
+	}
+	ret := treemapPut([]interface{}{head, intKey(35), object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("expected IllegalArgumentException for out-of-range put, got %v", ret)
+	}
+
+	sub := treemapSubMap([]interface{}{tm, intKey(15), intKey(40)}).(*object.Object)
+	str := object.GoStringFromStringObject(treemapToString([]interface{}{sub}).(*object.Object))
+	if str != "{15=v, 20=v, 30=v}" {
+		t.Errorf("unexpected subMap: %q", str)
+	}
+
+	ret = treemapSubMap([]interface{}{tm, intKey(40), intKey(15)})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.IllegalArgumentException {
+		t.Errorf("expected IllegalArgumentException for fromKey > toKey, got %v", ret)
+	}
+}
+
+func TestTreeMap_DescendingMap(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 10, 20, 30)
+
+	desc := treemapDescendingMap([]interface{}{tm}).(*object.Object)
+	if got := entryKeyInt(t, treemapFirstEntry([]interface{}{desc})); got != 30 {
+		t.Errorf("descending firstEntry: expected 30, got %d", got)
+	}
+	if got := keyInt(t, treemapHigherKey([]interface{}{desc, intKey(20)})); got != 10 {
+		t.Errorf("descending higherKey(20): expected 10, got %d", got)
+	}
+	if got := keyInt(t, treemapCeilingKey([]interface{}{desc, intKey(25)})); got != 20 {
+		t.Errorf("descending ceilingKey(25): expected 20, got %d", got)
+	}
+	head := treemapHeadMap([]interface{}{desc, intKey(20)}).(*object.Object)
+	str := object.GoStringFromStringObject(treemapToString([]interface{}{head}).(*object.Object))
+	if str != "{30=v}" {
+		t.Errorf("descending headMap(20): got %q", str)
+	}
+}
+
+func TestTreeMap_PollFirstAndLastEntry(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 10, 20, 30)
+
+	if got := entryKeyInt(t, treemapPollFirstEntry([]interface{}{tm})); got != 10 {
+		t.Errorf("pollFirstEntry: expected 10, got %d", got)
+	}
+	if got := entryKeyInt(t, treemapPollLastEntry([]interface{}{tm})); got != 30 {
+		t.Errorf("pollLastEntry: expected 30, got %d", got)
+	}
+	if size := treemapSize([]interface{}{tm}).(int64); size != 1 {
+		t.Errorf("expected size 1, got %d", size)
+	}
+	treemapClear([]interface{}{tm})
+	if got := treemapFirstEntry([]interface{}{tm}); got != object.Null {
+		t.Errorf("firstEntry of empty map: expected null, got %v", got)
+	}
+	ret := treemapFirstKey([]interface{}{tm})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NoSuchElementException {
+		t.Errorf("firstKey of empty map: expected NoSuchElementException, got %v", ret)
+	}
+}
+
+func TestTreeMap_ComparatorAndNullKeys(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_TreeMap()
+
+	tm := newTreeMap(t, makeReverseComparator(nil))
+	tmPutInts(t, tm, 10, 30, 20)
+	if got := keyInt(t, treemapFirstKey([]interface{}{tm})); got != 30 {
+		t.Errorf("reverse-ordered firstKey: expected 30, got %d", got)
+	}
+
+	natural := newTreeMap(t, nil)
+	ret := treemapPut([]interface{}{natural, object.Null, object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.NullPointerException {
+		t.Errorf("null key with natural ordering: expected NullPointerException, got %v", ret)
+	}
+
+	// keys of different classes cannot be compared
+	tmPutInts(t, natural, 1)
+	ret = treemapPut([]interface{}{natural, strKey("x"), object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.ClassCastException {
+		t.Errorf("mixed key classes: expected ClassCastException, got %v", ret)
+	}
+}
+
+func TestTreeMap_SequencedMapDispatch(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 10, 20)
+
+	if got := entryKeyInt(t, sequencedmapFirstEntry([]interface{}{tm})); got != 10 {
+		t.Errorf("Map.firstEntry: expected 10, got %d", got)
+	}
+	rev := sequencedmapReversed([]interface{}{tm}).(*object.Object)
+	if got := entryKeyInt(t, sequencedmapPollFirstEntry([]interface{}{rev})); got != 20 {
+		t.Errorf("Map.reversed().pollFirstEntry: expected 20, got %d", got)
+	}
+	ret := sequencedmapPutFirst([]interface{}{tm, intKey(1), object.Null})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.UnsupportedOperationException {
+		t.Errorf("putFirst on TreeMap: expected UnsupportedOperationException, got %v", ret)
+	}
+
+	hm := newHashMapObj()
+	hmInit(t, hm)
+	ret = sequencedmapFirstEntry([]interface{}{hm})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.UnsupportedOperationException {
+		t.Errorf("firstEntry on HashMap: expected UnsupportedOperationException, got %v", ret)
+	}
+
+	if got := mapSize([]interface{}{tm}).(int64); got != 1 {
+		t.Errorf("Map.size on TreeMap: expected 1, got %d", got)
+	}
+}
+
+// newTestFunction returns an object of a made-up class whose method methName+methType is the G
+// function fn, standing in for a lambda passed to a collection.
+func newTestFunction(className, methName, methType string, fn func(args []interface{}) interface{}) *object.Object {
+	ghelpers.MethodSignatures[className+"."+methName+methType] = ghelpers.GMeth{
+		ParamSlots: len(util.ParseIncomingParamsFromMethTypeString(methType)),
+		GFunction:  func(params []interface{}) interface{} { return fn(params[1:]) },
+	}
+	return object.MakeEmptyObjectWithClassName(&className)
+}
+
+// intOf unwraps an Integer, as the test functions see their arguments.
+func intOf(v interface{}) int64 {
+	return v.(*object.Object).FieldTable["value"].Fvalue.(int64)
+}
+
+func TestTreeMap_ManyInsertsAndRemovesStaySorted(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_Map()
+	tm := newTreeMap(t, nil)
+	const count = 1000
+	for ix := int64(0); ix < count; ix++ {
+		tmPutInts(t, tm, ix*7919%count) // every key in [0, count), out of order
+	}
+	for ix := int64(0); ix < count; ix += 2 {
+		treemapRemove([]interface{}{tm, intKey(ix)})
+	}
+
+	tv, _ := getTreeView(tm, "test")
+	entries, _ := tv.entries(nil)
+	if len(entries) != count/2 {
+		t.Fatalf("expected %d entries, got %d", count/2, len(entries))
+	}
+	for ix, entry := range entries {
+		if got := keyInt(t, entry.key); got != int64(2*ix+1) {
+			t.Fatalf("entry %d: expected key %d, got %d", ix, 2*ix+1, got)
+		}
+	}
+	if got := keyInt(t, treemapFloorKey([]interface{}{tm, intKey(500)})); got != 499 {
+		t.Errorf("floorKey(500): expected 499, got %d", got)
+	}
+	sub := treemapSubMap([]interface{}{tm, intKey(100), intKey(200)}).(*object.Object)
+	if size := treemapSize([]interface{}{sub}).(int64); size != 50 {
+		t.Errorf("subMap(100, 200): expected size 50, got %d", size)
+	}
+	if ret := mapClear([]interface{}{sub}); ret != nil {
+		t.Fatalf("Map.clear on a subMap returned %v", ret)
+	}
+	if size := treemapSize([]interface{}{tm}).(int64); size != count/2-50 {
+		t.Errorf("after clearing the subMap: expected size %d, got %d", count/2-50, size)
+	}
+	if !ghelpers.MethodSignatures["java/util/Map.clear()V"].NeedsContext {
+		t.Errorf("Map.clear() should be registered with NeedsContext")
+	}
+}
+
+func TestTreeMap_ComputeAndMerge(t *testing.T) {
+	globals.InitStringPool()
+	Load_Util_TreeMap()
+	tm := newTreeMap(t, nil)
+	treemapPut([]interface{}{tm, intKey(1), intKey(10)})
+	treemapPut([]interface{}{tm, intKey(2), intKey(20)})
+
+	sum := newTestFunction("test/Sum", "apply", methTypeApply2, func(args []interface{}) interface{} {
+		if object.IsNull(args[1]) {
+			return object.Null
+		}
+		return intKey(intOf(args[0]) + intOf(args[1]))
+	})
+	times100 := newTestFunction("test/Times100", "apply", methTypeApply1, func(args []interface{}) interface{} {
+		return intKey(intOf(args[0]) * 100)
+	})
+	toNull := newTestFunction("test/ToNull", "apply", methTypeApply2, func([]interface{}) interface{} {
+		return object.Null
+	})
+
+	if got := keyInt(t, orderedCompute([]interface{}{tm, intKey(1), sum})); got != 11 {
+		t.Errorf("compute(1): expected 11, got %d", got)
+	}
+	if ret := orderedCompute([]interface{}{tm, intKey(3), sum}); ret != object.Null {
+		t.Errorf("compute(3) returning null: expected null, got %v", ret)
+	}
+	if got := keyInt(t, orderedComputeIfAbsent([]interface{}{tm, intKey(3), times100})); got != 300 {
+		t.Errorf("computeIfAbsent(3): expected 300, got %d", got)
+	}
+	if got := keyInt(t, orderedComputeIfAbsent([]interface{}{tm, intKey(1), times100})); got != 11 {
+		t.Errorf("computeIfAbsent(1): expected the existing 11, got %d", got)
+	}
+	if ret := orderedComputeIfPresent([]interface{}{tm, intKey(2), toNull}); ret != object.Null {
+		t.Errorf("computeIfPresent(2) returning null: expected null, got %v", ret)
+	}
+	if got := keyInt(t, orderedMerge([]interface{}{tm, intKey(1), intKey(5), sum})); got != 16 {
+		t.Errorf("merge(1, 5): expected 16, got %d", got)
+	}
+	if got := keyInt(t, orderedMerge([]interface{}{tm, intKey(4), intKey(7), sum})); got != 7 {
+		t.Errorf("merge(4, 7): expected 7, got %d", got)
+	}
+	str := object.GoStringFromStringObject(treemapToString([]interface{}{tm}).(*object.Object))
+	if str != "{1=16, 3=300, 4=7}" {
+		t.Errorf("unexpected map after compute and merge: %q", str)
+	}
+
+	if ret := orderedReplaceAll([]interface{}{tm, sum}); ret != nil {
+		t.Fatalf("replaceAll returned %v", ret)
+	}
+	str = object.GoStringFromStringObject(treemapToString([]interface{}{tm}).(*object.Object))
+	if str != "{1=17, 3=303, 4=11}" {
+		t.Errorf("unexpected map after replaceAll: %q", str)
+	}
+
+	testutil.ExpectGErr(t, orderedCompute([]interface{}{tm, intKey(1), object.Null}), excNames.NullPointerException, "function is null")
+	testutil.ExpectGErr(t, orderedMerge([]interface{}{tm, intKey(1), object.Null, sum}), excNames.NullPointerException, "value is null")
+}
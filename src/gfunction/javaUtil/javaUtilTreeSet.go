@@ -0,0 +1,363 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"container/list"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// TreeSet is a TreeMap whose entries have no values, so it uses the treeStore and treeView
+// machinery in javaUtilTreeMap.go. TreeMap.keySet() and navigableKeySet() return TreeSet views
+// that share the map's store.
+
+var classNameTreeSet = "java/util/TreeSet"
+
+func Load_Util_TreeSet() {
+
+	ghelpers.MethodSignatures["java/util/TreeSet.<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.<init>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapInit}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.<init>(Ljava/util/Comparator;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapInit}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.<init>(Ljava/util/Collection;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetInitFromCollection, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.<init>(Ljava/util/SortedSet;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetInitFromSortedSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.add(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetAdd, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.addAll(Ljava/util/Collection;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetAddAll, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.addFirst(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetAddFirstLast}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.addLast(Ljava/lang/Object;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetAddFirstLast}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.ceiling(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapCeilingKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.clear()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapClear, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.clone()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapClone, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.comparator()Ljava/util/Comparator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treeComparator}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.contains(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapContainsKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.descendingIterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetDescendingIterator, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.descendingSet()Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapDescendingKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.first()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetFirst, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.floor(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapFloorKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.getFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetFirst, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.getLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetLast, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.headSet(Ljava/lang/Object;)Ljava/util/SortedSet;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetHeadSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.headSet(Ljava/lang/Object;Z)Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treesetHeadSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.higher(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapHigherKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.isEmpty()Z"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapIsEmpty, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.iterator()Ljava/util/Iterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetIterator, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.last()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetLast, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.lower(Ljava/lang/Object;)Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treemapLowerKey, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.pollFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetPollFirst, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.pollLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetPollLast, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.remove(Ljava/lang/Object;)Z"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetRemove, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.removeFirst()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetRemoveFirst, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.removeLast()Ljava/lang/Object;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetRemoveLast, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.reversed()Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapDescendingKeySet}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.size()I"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treemapSize, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.spliterator()Ljava/util/Spliterator;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.TrapFunction}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.subSet(Ljava/lang/Object;Ljava/lang/Object;)Ljava/util/SortedSet;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treesetSubSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.subSet(Ljava/lang/Object;ZLjava/lang/Object;Z)Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 4, GFunction: treesetSubSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.tailSet(Ljava/lang/Object;)Ljava/util/SortedSet;"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: treesetTailSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.tailSet(Ljava/lang/Object;Z)Ljava/util/NavigableSet;"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: treesetTailSet, NeedsContext: true}
+
+	ghelpers.MethodSignatures["java/util/TreeSet.toString()Ljava/lang/String;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: treesetToString, NeedsContext: true}
+}
+
+// TreeSet(Collection) uses the natural ordering of the elements.
+func treesetInitFromCollection(params []interface{}) interface{} {
+	return treesetInitFrom(params, false)
+}
+
+// TreeSet(SortedSet) uses the same ordering as the sorted set.
+func treesetInitFromSortedSet(params []interface{}) interface{} {
+	return treesetInitFrom(params, true)
+}
+
+func treesetInitFrom(params []interface{}, keepComparator bool) interface{} {
+	fs, args := ghelpers.SplitContext(params)
+	this, ok := args[0].(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "treesetInitFromCollection: invalid 'this' argument")
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "treesetInitFromCollection: collection is null")
+	}
+	var comparator *object.Object
+	if keepComparator {
+		comparator, _ = comparatorOf(source)
+	}
+	tv := newTreeView(comparator)
+	setTreeView(this, tv)
+
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	for _, elem := range elements {
+		if _, gerr = tv.put(fs, elem, nil); gerr != nil {
+			return gerr
+		}
+	}
+	return nil
+}
+
+func treesetAdd(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetAdd")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.get(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	if entry != nil {
+		return types.JavaBoolFalse
+	}
+	if _, gerr = tv.put(fs, args[1], nil); gerr != nil {
+		return gerr
+	}
+	return types.JavaBoolTrue
+}
+
+func treesetAddAll(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetAddAll")
+	if gerr != nil {
+		return gerr
+	}
+	source, ok := args[1].(*object.Object)
+	if !ok || object.IsNull(source) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "treesetAddAll: collection is null")
+	}
+	elements, gerr := collectionElements(fs, source)
+	if gerr != nil {
+		return gerr
+	}
+	changed := false
+	for _, elem := range elements {
+		prev, gerr := tv.put(fs, elem, nil)
+		if gerr != nil {
+			return gerr
+		}
+		changed = changed || prev == nil
+	}
+	return object.JavaBooleanFromGoBoolean(changed)
+}
+
+// addFirst() and addLast() are not supported by sorted sets.
+func treesetAddFirstLast([]interface{}) interface{} {
+	return ghelpers.GetGErrBlk(excNames.UnsupportedOperationException, "addFirst/addLast are not supported by sorted sets")
+}
+
+func treesetRemove(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetRemove")
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.remove(fs, args[1])
+	if gerr != nil {
+		return gerr
+	}
+	return object.JavaBooleanFromGoBoolean(entry != nil)
+}
+
+func treesetFirst(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetFirst", false, false, true)
+}
+
+func treesetLast(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetLast", true, false, true)
+}
+
+func treesetPollFirst(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetPollFirst", false, true, false)
+}
+
+func treesetPollLast(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetPollLast", true, true, false)
+}
+
+func treesetRemoveFirst(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetRemoveFirst", false, true, true)
+}
+
+func treesetRemoveLast(params []interface{}) interface{} {
+	return treesetEnd(params, "treesetRemoveLast", true, true, true)
+}
+
+// treesetEnd returns the first or last element of the set, removing it if remove is set. An empty
+// set yields NoSuchElementException if mustExist is set, otherwise null (as pollFirst() does).
+func treesetEnd(params []interface{}, caller string, last, remove, mustExist bool) interface{} {
+	fs, _, tv, gerr := treemapThis(params, caller)
+	if gerr != nil {
+		return gerr
+	}
+	entry, gerr := tv.end(fs, last)
+	if gerr != nil {
+		return gerr
+	}
+	if entry == nil {
+		if mustExist {
+			return ghelpers.GetGErrBlk(excNames.NoSuchElementException, caller+": set is empty")
+		}
+		return object.Null
+	}
+	if remove {
+		if gerr = tv.removeEntry(fs, entry); gerr != nil {
+			return gerr
+		}
+	}
+	return entry.key
+}
+
+func treesetHeadSet(params []interface{}) interface{} {
+	return treesetSubView(treemapHeadMap(params))
+}
+
+func treesetTailSet(params []interface{}) interface{} {
+	return treesetSubView(treemapTailMap(params))
+}
+
+func treesetSubSet(params []interface{}) interface{} {
+	return treesetSubView(treemapSubMap(params))
+}
+
+// treesetSubView turns the TreeMap view returned by the sub-map functions into a TreeSet view.
+func treesetSubView(ret interface{}) interface{} {
+	if view, ok := ret.(*object.Object); ok {
+		tv := view.FieldTable[fieldNameTree].Fvalue.(*treeView)
+		return makeTreeObject(classNameTreeSet, tv)
+	}
+	return ret
+}
+
+func treesetIterator(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetIterator")
+	if gerr != nil {
+		return gerr
+	}
+	keys, gerr := treeKeys(fs, tv)
+	if gerr != nil {
+		return gerr
+	}
+	return newSnapshotIterator(args[0].(*object.Object), keys)
+}
+
+func treesetDescendingIterator(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetDescendingIterator")
+	if gerr != nil {
+		return gerr
+	}
+	nv := *tv
+	nv.descending = !tv.descending
+	keys, gerr := treeKeys(fs, &nv)
+	if gerr != nil {
+		return gerr
+	}
+	return newSnapshotIterator(args[0].(*object.Object), keys)
+}
+
+func treesetToString(params []interface{}) interface{} {
+	fs, args, tv, gerr := treemapThis(params, "treesetToString")
+	if gerr != nil {
+		return gerr
+	}
+	keys, gerr := treeKeys(fs, tv)
+	if gerr != nil {
+		return gerr
+	}
+	return object.StringObjectFromGoString(formatElements(fs, args[0].(*object.Object), keys))
+}
+
+// treeKeys returns the keys visible through the view, in view order.
+func treeKeys(fs *list.List, tv *treeView) ([]any, *ghelpers.GErrBlk) {
+	entries, gerr := tv.entries(fs)
+	if gerr != nil {
+		return nil, gerr
+	}
+	keys := make([]any, len(entries))
+	for ix, entry := range entries {
+		keys[ix] = entry.key
+	}
+	return keys, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newTreeSet(t *testing.T, elements ...int64) *object.Object {
+	t.Helper()
+	ts := object.MakeEmptyObjectWithClassName(&classNameTreeSet)
+	if ret := treemapInit([]interface{}{ts}); ret != nil {
+		t.Fatalf("treemapInit returned error: %v", ret)
+	}
+	for _, e := range elements {
+		treesetAdd([]interface{}{ts, intKey(e)})
+	}
+	return ts
+}
+
+func treeSetString(t *testing.T, ts *object.Object) string {
+	t.Helper()
+	return object.GoStringFromStringObject(treesetToString([]interface{}{ts}).(*object.Object))
+}
+
+func TestTreeSet_AddIgnoresDuplicates(t *testing.T) {
+	globals.InitStringPool()
+	ts := newTreeSet(t, 3, 1, 2)
+
+	if ret := treesetAdd([]interface{}{ts, intKey(2)}); ret != types.JavaBoolFalse {
+		t.Errorf("adding a duplicate should return false")
+	}
+	if got := treeSetString(t, ts); got != "[1, 2, 3]" {
+		t.Errorf("unexpected contents: %q", got)
+	}
+	if ret := treemapContainsKey([]interface{}{ts, intKey(3)}); ret != types.JavaBoolTrue {
+		t.Errorf("expected contains(3) to be true")
+	}
+}
+
+func TestTreeSet_NavigationAndPolling(t *testing.T) {
+	globals.InitStringPool()
+	ts := newTreeSet(t, 10, 20, 30)
+
+	if got := keyInt(t, treemapFloorKey([]interface{}{ts, intKey(25)})); got != 20 {
+		t.Errorf("floor(25): expected 20, got %d", got)
+	}
+	if got := keyInt(t, treemapCeilingKey([]interface{}{ts, intKey(25)})); got != 30 {
+		t.Errorf("ceiling(25): expected 30, got %d", got)
+	}
+	if got := keyInt(t, treesetPollFirst([]interface{}{ts})); got != 10 {
+		t.Errorf("pollFirst: expected 10, got %d", got)
+	}
+	if got := keyInt(t, treesetLast([]interface{}{ts})); got != 30 {
+		t.Errorf("last: expected 30, got %d", got)
+	}
+
+	empty := newTreeSet(t)
+	if got := treesetPollFirst([]interface{}{empty}); got != object.Null {
+		t.Errorf("pollFirst on an empty set should return null")
+	}
+}
+
+func TestTreeSet_ViewsAndIterator(t *testing.T) {
+	globals.InitStringPool()
+	ts := newTreeSet(t, 1, 2, 3, 4, 5)
+
+	head := treesetHeadSet([]interface{}{ts, intKey(3), types.JavaBoolTrue}).(*object.Object)
+	if got := treeSetString(t, head); got != "[1, 2, 3]" {
+		t.Errorf("headSet(3, true): got %q", got)
+	}
+	desc := treemapDescendingKeySet([]interface{}{ts}).(*object.Object)
+	if got := treeSetString(t, desc); got != "[5, 4, 3, 2, 1]" {
+		t.Errorf("descendingSet: got %q", got)
+	}
+
+	// removing through the iterator removes from the set
+	iter := treesetIterator([]interface{}{ts}).(*object.Object)
+	for iteratorHasNext([]interface{}{iter}) == types.JavaBoolTrue {
+		elem := iteratorNext([]interface{}{iter})
+		if keyInt(t, elem)%2 == 0 {
+			if ret := iteratorRemove([]interface{}{iter}); ret != nil {
+				t.Fatalf("iteratorRemove returned %v", ret)
+			}
+		}
+	}
+	if got := treeSetString(t, ts); got != "[1, 3, 5]" {
+		t.Errorf("after removing even elements: got %q", got)
+	}
+}
+
+func TestTreeMap_KeySetIsTreeSetView(t *testing.T) {
+	globals.InitStringPool()
+	tm := newTreeMap(t, nil)
+	tmPutInts(t, tm, 2, 1)
+
+	keys := treemapNavigableKeySet([]interface{}{tm}).(*object.Object)
+	if got := treeSetString(t, keys); got != "[1, 2]" {
+		t.Errorf("keySet: got %q", got)
+	}
+	treesetRemove([]interface{}{keys, intKey(1)})
+	if size := treemapSize([]interface{}{tm}).(int64); size != 1 {
+		t.Errorf("removing via keySet should shrink the map, size = %d", size)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"hash"
+	"hash/adler32"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.util.zip.Deflater compresses to zlib data, or to raw deflate data if it is created with
+// nowrap set. The deflate data comes from Go's compress/flate, so it is not the same, byte for
+// byte, as the JDK's, though it decompresses to the same thing. The zlib header and trailer are
+// written here and are those that the JDK writes.
+
+const deflaterClassName = "java/util/zip/Deflater"
+
+// the constants of Deflater
+const (
+	deflaterDefaultCompression = -1
+	deflaterDefaultStrategy    = 0
+	deflaterFiltered           = 1
+	deflaterHuffmanOnly        = 2
+	deflaterNoFlush            = 0
+	deflaterSyncFlush          = 2
+	deflaterFullFlush          = 3
+)
+
+func Load_Util_Zip_Deflater() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":          {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":            {ParamSlots: 0, GFunction: deflaterInit},
+		"<init>(I)V":           {ParamSlots: 1, GFunction: deflaterInit},
+		"<init>(IZ)V":          {ParamSlots: 2, GFunction: deflaterInit},
+		"deflate([B)I":         {ParamSlots: 1, GFunction: deflaterDeflate},
+		"deflate([BII)I":       {ParamSlots: 3, GFunction: deflaterDeflate},
+		"deflate([BIII)I":      {ParamSlots: 4, GFunction: deflaterDeflate},
+		"end()V":               {ParamSlots: 0, GFunction: deflaterEnd},
+		"finish()V":            {ParamSlots: 0, GFunction: deflaterFinish},
+		"finished()Z":          {ParamSlots: 0, GFunction: deflaterFinished},
+		"getAdler()I":          {ParamSlots: 0, GFunction: deflaterGetAdler},
+		"getBytesRead()J":      {ParamSlots: 0, GFunction: deflaterGetBytesRead},
+		"getBytesWritten()J":   {ParamSlots: 0, GFunction: deflaterGetBytesWritten},
+		"getTotalIn()I":        {ParamSlots: 0, GFunction: deflaterGetTotalIn},
+		"getTotalOut()I":       {ParamSlots: 0, GFunction: deflaterGetTotalOut},
+		"needsInput()Z":        {ParamSlots: 0, GFunction: deflaterNeedsInput},
+		"reset()V":             {ParamSlots: 0, GFunction: deflaterReset},
+		"setDictionary([B)V":   {ParamSlots: 1, GFunction: deflaterSetDictionary},
+		"setDictionary([BII)V": {ParamSlots: 3, GFunction: deflaterSetDictionary},
+		"setInput([B)V":        {ParamSlots: 1, GFunction: deflaterSetInput},
+		"setInput([BII)V":      {ParamSlots: 3, GFunction: deflaterSetInput},
+		"setLevel(I)V":         {ParamSlots: 1, GFunction: deflaterSetLevel},
+		"setStrategy(I)V":      {ParamSlots: 1, GFunction: deflaterSetStrategy},
+	} {
+		ghelpers.MethodSignatures[deflaterClassName+"."+sig] = gmeth
+	}
+}
+
+// deflater is the Go state of a Deflater.
+type deflater struct {
+	level        int
+	strategy     int
+	nowrap       bool
+	dict         []byte
+	input        []byte       // input that has not been compressed yet
+	pending      bytes.Buffer // compressed data that deflate() has not returned yet
+	fw           *flate.Writer
+	paramsChange bool // the level or strategy has changed since fw was created
+	finishCalled bool
+	closed       bool // fw has been closed and the trailer written
+	bytesRead    int64
+	bytesWritten int64
+	adler        hash.Hash32 // of the input compressed so far
+	ended        bool
+}
+
+// flateLevel returns the level to give to compress/flate for the level and strategy of d.
+// The filtered strategy has no counterpart there.
+func (d *deflater) flateLevel() int {
+	if d.strategy == deflaterHuffmanOnly {
+		return flate.HuffmanOnly
+	}
+	return d.level
+}
+
+// start creates the compressor, and writes the zlib header, unless that is already done.
+// If the level or strategy has changed, the data so far is flushed and a new compressor with
+// the new settings continues the deflate stream.
+func (d *deflater) start() error {
+	var err error
+	switch {
+	case d.fw == nil:
+		if !d.nowrap {
+			d.pending.Write(zlibHeader(d.level, d.strategy, d.dict))
+		}
+		d.fw, err = flate.NewWriterDict(&d.pending, d.flateLevel(), d.dict)
+	case d.paramsChange:
+		if err = d.fw.Flush(); err == nil {
+			d.fw, err = flate.NewWriter(&d.pending, d.flateLevel())
+		}
+	}
+	d.paramsChange = false
+	return err
+}
+
+// zlibHeader returns the zlib header that zlib writes for level, strategy and dictionary dict.
+func zlibHeader(level, strategy int, dict []byte) []byte {
+	flevel := uint16(2)
+	switch {
+	case strategy == deflaterHuffmanOnly || (level >= 0 && level < 2):
+		flevel = 0
+	case level >= 2 && level < 6:
+		flevel = 1
+	case level > 6:
+		flevel = 3
+	}
+	header := uint16(0x78)<<8 | flevel<<6
+	if dict != nil {
+		header |= 0x20
+	}
+	header += 31 - header%31
+	b := binary.BigEndian.AppendUint16(nil, header)
+	if dict != nil {
+		b = binary.BigEndian.AppendUint32(b, adler32.Checksum(dict))
+	}
+	return b
+}
+
+// getDeflater returns the Go state of the Deflater obj, which must not have been ended.
+func getDeflater(obj *object.Object) (*deflater, *ghelpers.GErrBlk) {
+	d, ok := obj.FieldTable[zipStateField].Fvalue.(*deflater)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "Deflater is not initialized")
+	}
+	if d.ended {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "Deflater has been closed")
+	}
+	return d, nil
+}
+
+func checkDeflateLevel(level int64) *ghelpers.GErrBlk {
+	if level < deflaterDefaultCompression || level > flate.BestCompression {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid compression level")
+	}
+	return nil
+}
+
+// java/util/zip/Deflater.<init>()V, <init>(I)V and <init>(IZ)V
+func deflaterInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	level := int64(deflaterDefaultCompression)
+	if len(params) > 1 {
+		level = params[1].(int64)
+	}
+	if gerr := checkDeflateLevel(level); gerr != nil {
+		return gerr
+	}
+	nowrap := len(params) > 2 && params[2] == types.JavaBoolTrue
+	d := &deflater{level: int(level), nowrap: nowrap, adler: adler32.New()}
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: d}
+	return nil
+}
+
+// java/util/zip/Deflater.setInput([B)V and setInput([BII)V
+func deflaterSetInput(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Deflater.setInput")
+	if gerr != nil {
+		return gerr
+	}
+	d.input = object.GoByteArrayFromJavaByteArray(jbytes[off : off+length])
+	return nil
+}
+
+// java/util/zip/Deflater.setDictionary([B)V and setDictionary([BII)V -- only before compressing
+func deflaterSetDictionary(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Deflater.setDictionary")
+	if gerr != nil {
+		return gerr
+	}
+	if d.fw != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Deflater.setDictionary: compression has started")
+	}
+	d.dict = object.GoByteArrayFromJavaByteArray(jbytes[off : off+length])
+	return nil
+}
+
+// java/util/zip/Deflater.setLevel(I)V -- takes effect for the input that follows
+func deflaterSetLevel(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	level := params[1].(int64)
+	if gerr = checkDeflateLevel(level); gerr != nil {
+		return gerr
+	}
+	if int(level) != d.level {
+		d.level = int(level)
+		d.paramsChange = d.fw != nil
+	}
+	return nil
+}
+
+// java/util/zip/Deflater.setStrategy(I)V -- takes effect for the input that follows
+func deflaterSetStrategy(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	strategy := params[1].(int64)
+	if strategy < deflaterDefaultStrategy || strategy > deflaterHuffmanOnly {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid compression strategy")
+	}
+	if int(strategy) != d.strategy {
+		d.strategy = int(strategy)
+		d.paramsChange = d.fw != nil
+	}
+	return nil
+}
+
+// java/util/zip/Deflater.finish()V -- the input set so far is the last
+func deflaterFinish(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	d.finishCalled = true
+	return nil
+}
+
+// java/util/zip/Deflater.deflate([B)I, deflate([BII)I and deflate([BIII)I
+// Compresses the input and returns as much of the compressed data as fits. With NO_FLUSH,
+// the compressor may hold on to data until it has more input.
+func deflaterDeflate(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Deflater.deflate")
+	if gerr != nil {
+		return gerr
+	}
+	flush := int64(deflaterNoFlush)
+	if len(params) > 4 {
+		flush = params[4].(int64)
+	}
+	if flush != deflaterNoFlush && flush != deflaterSyncFlush && flush != deflaterFullFlush {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Deflater.deflate: invalid flush mode")
+	}
+
+	if err := d.compress(flush); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "Deflater.deflate: "+err.Error())
+	}
+	out := d.pending.Next(int(length))
+	n := copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(out))
+	d.bytesWritten += int64(n)
+	return int64(n)
+}
+
+// compress compresses the pending input and, if it is asked for, flushes or finishes the stream.
+func (d *deflater) compress(flush int64) error {
+	if d.closed {
+		return nil
+	}
+	if len(d.input) > 0 {
+		if err := d.start(); err != nil {
+			return err
+		}
+		if _, err := d.fw.Write(d.input); err != nil {
+			return err
+		}
+		d.adler.Write(d.input)
+		d.bytesRead += int64(len(d.input))
+		d.input = nil
+	}
+	if flush != deflaterNoFlush && !d.finishCalled {
+		if err := d.start(); err != nil {
+			return err
+		}
+		return d.fw.Flush()
+	}
+	if d.finishCalled {
+		if err := d.start(); err != nil {
+			return err
+		}
+		if err := d.fw.Close(); err != nil {
+			return err
+		}
+		if !d.nowrap {
+			d.pending.Write(binary.BigEndian.AppendUint32(nil, d.adler.Sum32()))
+		}
+		d.closed = true
+	}
+	return nil
+}
+
+// java/util/zip/Deflater.needsInput()Z
+func deflaterNeedsInput(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(len(d.input) == 0)
+}
+
+// java/util/zip/Deflater.finished()Z -- finish() was called and all the compressed data returned
+func deflaterFinished(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(d.closed && d.pending.Len() == 0)
+}
+
+// java/util/zip/Deflater.getAdler()I -- the checksum of the input so far, or of the dictionary
+// if no input has been compressed
+func deflaterGetAdler(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if d.fw == nil && d.dict != nil && !d.nowrap {
+		return int64(int32(adler32.Checksum(d.dict)))
+	}
+	return int64(int32(d.adler.Sum32()))
+}
+
+func deflaterGetBytesRead(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return d.bytesRead
+}
+
+func deflaterGetBytesWritten(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return d.bytesWritten
+}
+
+func deflaterGetTotalIn(params []interface{}) interface{} {
+	ret := deflaterGetBytesRead(params)
+	if n, ok := ret.(int64); ok {
+		return int64(int32(n))
+	}
+	return ret
+}
+
+func deflaterGetTotalOut(params []interface{}) interface{} {
+	ret := deflaterGetBytesWritten(params)
+	if n, ok := ret.(int64); ok {
+		return int64(int32(n))
+	}
+	return ret
+}
+
+// java/util/zip/Deflater.reset()V -- a new stream is begun with the same level and strategy
+func deflaterReset(params []interface{}) interface{} {
+	d, gerr := getDeflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	level, strategy, nowrap := d.level, d.strategy, d.nowrap
+	*d = deflater{level: level, strategy: strategy, nowrap: nowrap, adler: adler32.New()}
+	return nil
+}
+
+// java/util/zip/Deflater.end()V
+func deflaterEnd(params []interface{}) interface{} {
+	if d, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(*deflater); ok {
+		*d = deflater{ended: true}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"strings"
+	"time"
+)
+
+// java.util.zip.ZipEntry and java.util.jar.JarEntry, which adds nothing that is implemented
+// here. An entry keeps its metadata in the fields below; a value of -1 means "not set", as in
+// the JDK. The time is in milliseconds since the epoch.
+
+const (
+	zipEntryClassName = "java/util/zip/ZipEntry"
+	jarEntryClassName = "java/util/jar/JarEntry"
+
+	zipEntryName    = "name"
+	zipEntryTime    = "time"
+	zipEntrySize    = "size"
+	zipEntryCsize   = "csize"
+	zipEntryCrc     = "crc"
+	zipEntryMethod  = "method"
+	zipEntryComment = "comment"
+	zipEntryExtra   = "extra"
+
+	zipStored   = 0
+	zipDeflated = 8
+)
+
+func Load_Util_Zip_ZipEntry() {
+	for _, className := range []string{zipEntryClassName, jarEntryClassName} {
+		for sig, gmeth := range map[string]ghelpers.GMeth{
+			"<clinit>()V":                       {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+			"<init>(Ljava/lang/String;)V":       {ParamSlots: 1, GFunction: zipEntryInit},
+			"<init>(Ljava/util/zip/ZipEntry;)V": {ParamSlots: 1, GFunction: zipEntryInitCopy},
+			"clone()Ljava/lang/Object;":         {ParamSlots: 0, GFunction: zipEntryClone},
+			"getComment()Ljava/lang/String;":    {ParamSlots: 0, GFunction: zipEntryGetComment},
+			"getCompressedSize()J":              {ParamSlots: 0, GFunction: zipEntryGetCompressedSize},
+			"getCrc()J":                         {ParamSlots: 0, GFunction: zipEntryGetCrc},
+			"getExtra()[B":                      {ParamSlots: 0, GFunction: zipEntryGetExtra},
+			"getMethod()I":                      {ParamSlots: 0, GFunction: zipEntryGetMethod},
+			"getName()Ljava/lang/String;":       {ParamSlots: 0, GFunction: zipEntryGetName},
+			"getSize()J":                        {ParamSlots: 0, GFunction: zipEntryGetSize},
+			"getTime()J":                        {ParamSlots: 0, GFunction: zipEntryGetTime},
+			"hashCode()I":                       {ParamSlots: 0, GFunction: zipEntryHashCode},
+			"isDirectory()Z":                    {ParamSlots: 0, GFunction: zipEntryIsDirectory},
+			"setComment(Ljava/lang/String;)V":   {ParamSlots: 1, GFunction: zipEntrySetComment},
+			"setCompressedSize(J)V":             {ParamSlots: 1, GFunction: zipEntrySetCompressedSize},
+			"setCrc(J)V":                        {ParamSlots: 1, GFunction: zipEntrySetCrc},
+			"setExtra([B)V":                     {ParamSlots: 1, GFunction: zipEntrySetExtra},
+			"setMethod(I)V":                     {ParamSlots: 1, GFunction: zipEntrySetMethod},
+			"setSize(J)V":                       {ParamSlots: 1, GFunction: zipEntrySetSize},
+			"setTime(J)V":                       {ParamSlots: 1, GFunction: zipEntrySetTime},
+			"toString()Ljava/lang/String;":      {ParamSlots: 0, GFunction: zipEntryGetName},
+		} {
+			ghelpers.MethodSignatures[className+"."+sig] = gmeth
+		}
+	}
+	ghelpers.MethodSignatures[jarEntryClassName+".<init>(Ljava/util/jar/JarEntry;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: zipEntryInitCopy}
+}
+
+// newZipEntry returns a ZipEntry, or an entry of the subclass className, named name.
+func newZipEntry(className, name string) *object.Object {
+	entry := object.MakeEmptyObjectWithClassName(&className)
+	setZipEntryFields(entry, name)
+	return entry
+}
+
+func setZipEntryFields(entry *object.Object, name string) {
+	entry.FieldTable[zipEntryName] = object.Field{Ftype: types.StringClassRef, Fvalue: object.StringObjectFromGoString(name)}
+	for _, field := range []string{zipEntryTime, zipEntrySize, zipEntryCsize, zipEntryCrc} {
+		setEntryLong(entry, field, -1)
+	}
+	entry.FieldTable[zipEntryMethod] = object.Field{Ftype: types.Int, Fvalue: int64(-1)}
+	entry.FieldTable[zipEntryComment] = object.Field{Ftype: types.StringClassRef, Fvalue: object.Null}
+	entry.FieldTable[zipEntryExtra] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.Null}
+}
+
+// newZipEntryFromHeader returns an entry of class className with the metadata in fh.
+func newZipEntryFromHeader(className string, fh *zip.FileHeader) *object.Object {
+	entry := newZipEntry(className, fh.Name)
+	setEntryLong(entry, zipEntryTime, entryTime(fh.ModifiedDate, fh.ModifiedTime, fh.Extra))
+	setEntryLong(entry, zipEntrySize, int64(fh.UncompressedSize64))
+	setEntryLong(entry, zipEntryCsize, int64(fh.CompressedSize64))
+	setEntryLong(entry, zipEntryCrc, int64(fh.CRC32))
+	entry.FieldTable[zipEntryMethod] = object.Field{Ftype: types.Int, Fvalue: int64(fh.Method)}
+	if fh.Comment != "" {
+		entry.FieldTable[zipEntryComment] = object.Field{Ftype: types.StringClassRef,
+			Fvalue: object.StringObjectFromGoString(fh.Comment)}
+	}
+	setEntryExtra(entry, fh.Extra)
+	return entry
+}
+
+func entryLong(entry *object.Object, field string) int64 {
+	v, ok := entry.FieldTable[field].Fvalue.(int64)
+	if !ok {
+		return -1
+	}
+	return v
+}
+
+func setEntryLong(entry *object.Object, field string, v int64) {
+	ftype := types.Long
+	if field == zipEntryMethod {
+		ftype = types.Int
+	}
+	entry.FieldTable[field] = object.Field{Ftype: ftype, Fvalue: v}
+}
+
+func entryName(entry *object.Object) string {
+	nameObj, ok := entry.FieldTable[zipEntryName].Fvalue.(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ""
+	}
+	return object.GoStringFromStringObject(nameObj)
+}
+
+func entryComment(entry *object.Object) string {
+	commentObj, ok := entry.FieldTable[zipEntryComment].Fvalue.(*object.Object)
+	if !ok || object.IsNull(commentObj) {
+		return ""
+	}
+	return object.GoStringFromStringObject(commentObj)
+}
+
+func entryExtra(entry *object.Object) []byte {
+	extraObj, ok := entry.FieldTable[zipEntryExtra].Fvalue.(*object.Object)
+	if !ok || object.IsNull(extraObj) {
+		return nil
+	}
+	jbytes, _ := extraObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	return object.GoByteArrayFromJavaByteArray(jbytes)
+}
+
+func setEntryExtra(entry *object.Object, extra []byte) {
+	var value any = object.Null
+	if len(extra) > 0 {
+		value = object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(extra))
+	}
+	entry.FieldTable[zipEntryExtra] = object.Field{Ftype: types.JavaByteArray, Fvalue: value}
+}
+
+// entryTime returns the modification time of an entry in milliseconds: the time in the
+// extended timestamp extra field if there is one, else the MS-DOS date and time, which are
+// local time.
+func entryTime(dosDate, dosTime uint16, extra []byte) int64 {
+	for len(extra) >= 4 {
+		tag := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		if size > len(extra)-4 {
+			break
+		}
+		data := extra[4 : 4+size]
+		if tag == 0x5455 && len(data) >= 5 && data[0]&1 != 0 {
+			return int64(int32(binary.LittleEndian.Uint32(data[1:]))) * 1000
+		}
+		extra = extra[4+size:]
+	}
+	if dosDate == 0 && dosTime == 0 {
+		return -1
+	}
+	t := time.Date(1980+int(dosDate>>9), time.Month(dosDate>>5&0xf), int(dosDate&0x1f),
+		int(dosTime>>11), int(dosTime>>5&0x3f), int(dosTime&0x1f)*2, 0, time.Local)
+	return t.UnixMilli()
+}
+
+// dosDateTime converts a time in milliseconds to an MS-DOS date and time in local time. Times
+// before 1980 become 1 January 1980, as in the JDK.
+func dosDateTime(millis int64) (uint16, uint16) {
+	t := time.UnixMilli(millis).In(time.Local)
+	if t.Year() < 1980 {
+		return 1<<5 | 1, 0
+	}
+	date := uint16(t.Year()-1980)<<9 | uint16(t.Month())<<5 | uint16(t.Day())
+	clock := uint16(t.Hour())<<11 | uint16(t.Minute())<<5 | uint16(t.Second()/2)
+	return date, clock
+}
+
+// java/util/zip/ZipEntry.<init>(Ljava/lang/String;)V
+func zipEntryInit(params []interface{}) interface{} {
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipEntry: name is null")
+	}
+	name := object.GoStringFromStringObject(nameObj)
+	if len(name) > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "entry name too long")
+	}
+	setZipEntryFields(params[0].(*object.Object), name)
+	return nil
+}
+
+// java/util/zip/ZipEntry.<init>(Ljava/util/zip/ZipEntry;)V -- copies the metadata of an entry
+func zipEntryInitCopy(params []interface{}) interface{} {
+	other, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(other) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipEntry: entry is null")
+	}
+	copyZipEntryFields(params[0].(*object.Object), other)
+	return nil
+}
+
+func copyZipEntryFields(dst, src *object.Object) {
+	for _, field := range []string{zipEntryName, zipEntryTime, zipEntrySize, zipEntryCsize, zipEntryCrc,
+		zipEntryMethod, zipEntryComment} {
+		dst.FieldTable[field] = src.FieldTable[field]
+	}
+	setEntryExtra(dst, entryExtra(src))
+}
+
+// java/util/zip/ZipEntry.clone()Ljava/lang/Object;
+func zipEntryClone(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	className := object.GoStringFromStringPoolIndex(self.KlassName)
+	clone := object.MakeEmptyObjectWithClassName(&className)
+	copyZipEntryFields(clone, self)
+	return clone
+}
+
+func zipEntryGetName(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable[zipEntryName].Fvalue
+}
+
+func zipEntryGetComment(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable[zipEntryComment].Fvalue
+}
+
+func zipEntryGetExtra(params []interface{}) interface{} {
+	return params[0].(*object.Object).FieldTable[zipEntryExtra].Fvalue
+}
+
+func zipEntryGetTime(params []interface{}) interface{} {
+	return entryLong(params[0].(*object.Object), zipEntryTime)
+}
+
+func zipEntryGetSize(params []interface{}) interface{} {
+	return entryLong(params[0].(*object.Object), zipEntrySize)
+}
+
+func zipEntryGetCompressedSize(params []interface{}) interface{} {
+	return entryLong(params[0].(*object.Object), zipEntryCsize)
+}
+
+func zipEntryGetCrc(params []interface{}) interface{} {
+	return entryLong(params[0].(*object.Object), zipEntryCrc)
+}
+
+func zipEntryGetMethod(params []interface{}) interface{} {
+	return entryLong(params[0].(*object.Object), zipEntryMethod)
+}
+
+// java/util/zip/ZipEntry.isDirectory()Z -- the name ends with a slash
+func zipEntryIsDirectory(params []interface{}) interface{} {
+	return types.ConvertGoBoolToJavaBool(strings.HasSuffix(entryName(params[0].(*object.Object)), "/"))
+}
+
+// java/util/zip/ZipEntry.hashCode()I -- the hash code of the name
+func zipEntryHashCode(params []interface{}) interface{} {
+	return int64(javaStringHashCode(entryName(params[0].(*object.Object))))
+}
+
+func zipEntrySetTime(params []interface{}) interface{} {
+	setEntryLong(params[0].(*object.Object), zipEntryTime, params[1].(int64))
+	return nil
+}
+
+func zipEntrySetSize(params []interface{}) interface{} {
+	size := params[1].(int64)
+	if size < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid entry size")
+	}
+	setEntryLong(params[0].(*object.Object), zipEntrySize, size)
+	return nil
+}
+
+func zipEntrySetCompressedSize(params []interface{}) interface{} {
+	setEntryLong(params[0].(*object.Object), zipEntryCsize, params[1].(int64))
+	return nil
+}
+
+func zipEntrySetCrc(params []interface{}) interface{} {
+	crc := params[1].(int64)
+	if crc < 0 || crc > 0xffffffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid entry crc-32")
+	}
+	setEntryLong(params[0].(*object.Object), zipEntryCrc, crc)
+	return nil
+}
+
+func zipEntrySetMethod(params []interface{}) interface{} {
+	method := params[1].(int64)
+	if method != zipStored && method != zipDeflated {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid compression method")
+	}
+	setEntryLong(params[0].(*object.Object), zipEntryMethod, method)
+	return nil
+}
+
+func zipEntrySetComment(params []interface{}) interface{} {
+	comment, ok := params[1].(*object.Object)
+	if !ok {
+		comment = object.Null
+	}
+	params[0].(*object.Object).FieldTable[zipEntryComment] = object.Field{Ftype: types.StringClassRef, Fvalue: comment}
+	return nil
+}
+
+func zipEntrySetExtra(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	extraObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(extraObj) {
+		setEntryExtra(self, nil)
+		return nil
+	}
+	jbytes, _ := extraObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	if len(jbytes) > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid extra field length")
+	}
+	setEntryExtra(self, object.GoByteArrayFromJavaByteArray(jbytes))
+	return nil
+}
@@ -0,0 +1,311 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"os"
+	"strings"
+)
+
+// java.util.zip.ZipFile and java.util.jar.JarFile read the entries of a zip file in any order,
+// using the central directory, with Go's archive/zip. The entries of a JarFile are JarEntry
+// objects, and a JarFile can return its manifest.
+
+const (
+	zipFileClassName            = "java/util/zip/ZipFile"
+	jarFileClassName            = "java/util/jar/JarFile"
+	zipFileInputStreamClassName = "java/util/zip/ZipFile$ZipFileInputStream"
+
+	zipFileOpenRead   = 1
+	zipFileOpenDelete = 4
+
+	jarManifestName = "META-INF/MANIFEST.MF"
+)
+
+func Load_Util_Zip_ZipFile() {
+	for _, className := range []string{zipFileClassName, jarFileClassName} {
+		for sig, gmeth := range map[string]ghelpers.GMeth{
+			"<clinit>()V":                      {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+			"close()V":                         {ParamSlots: 0, GFunction: zipFileClose},
+			"entries()Ljava/util/Enumeration;": {ParamSlots: 0, GFunction: zipFileEntries},
+			"getComment()Ljava/lang/String;":   {ParamSlots: 0, GFunction: zipFileGetComment},
+			"getEntry(Ljava/lang/String;)Ljava/util/zip/ZipEntry;": {ParamSlots: 1,
+				GFunction: zipFileGetEntry},
+			"getInputStream(Ljava/util/zip/ZipEntry;)Ljava/io/InputStream;": {ParamSlots: 1,
+				GFunction: zipFileGetInputStream},
+			"getName()Ljava/lang/String;": {ParamSlots: 0, GFunction: zipFileGetName},
+			"size()I":                     {ParamSlots: 0, GFunction: zipFileSize},
+		} {
+			ghelpers.MethodSignatures[className+"."+sig] = gmeth
+		}
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<init>(Ljava/lang/String;)V": {ParamSlots: 1, GFunction: zipFileInit},
+		"<init>(Ljava/io/File;)V":     {ParamSlots: 1, GFunction: zipFileInit},
+		"<init>(Ljava/io/File;I)V":    {ParamSlots: 2, GFunction: zipFileInitMode},
+	} {
+		ghelpers.MethodSignatures[zipFileClassName+"."+sig] = gmeth
+	}
+
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<init>(Ljava/lang/String;)V":  {ParamSlots: 1, GFunction: jarFileInit},
+		"<init>(Ljava/lang/String;Z)V": {ParamSlots: 2, GFunction: jarFileInit},
+		"<init>(Ljava/io/File;)V":      {ParamSlots: 1, GFunction: jarFileInit},
+		"<init>(Ljava/io/File;Z)V":     {ParamSlots: 2, GFunction: jarFileInit},
+		"<init>(Ljava/io/File;ZI)V":    {ParamSlots: 3, GFunction: jarFileInitMode},
+		"getJarEntry(Ljava/lang/String;)Ljava/util/jar/JarEntry;": {ParamSlots: 1,
+			GFunction: zipFileGetEntry},
+		"getManifest()Ljava/util/jar/Manifest;": {ParamSlots: 0, GFunction: jarFileGetManifest},
+	} {
+		ghelpers.MethodSignatures[jarFileClassName+"."+sig] = gmeth
+	}
+
+	ghelpers.MethodSignatures[zipFileInputStreamClassName+".<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	loadZipStreamReads(zipFileInputStreamClassName)
+}
+
+// zipFile is the Go state of a ZipFile or JarFile.
+type zipFile struct {
+	rc             *zip.ReadCloser
+	name           string
+	entryClassName string // ZipEntry or JarEntry
+	files          map[string]*zip.File
+	closed         bool
+}
+
+// zipFilePath returns the path that arg, a String or a File, names.
+func zipFilePath(arg any) (string, *ghelpers.GErrBlk) {
+	obj, ok := arg.(*object.Object)
+	if !ok || object.IsNull(obj) {
+		return "", ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipFile: name is null")
+	}
+	if object.IsStringObject(obj) {
+		return object.GoStringFromStringObject(obj), nil
+	}
+	if path, ok := obj.FieldTable[ghelpers.FilePath].Fvalue.([]types.JavaByte); ok {
+		return object.GoStringFromJavaByteArray(path), nil
+	}
+	return "", ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "ZipFile: not a file name")
+}
+
+// openZipFile opens the zip file named by arg for the ZipFile or JarFile self, whose entries
+// are of class entryClassName.
+func openZipFile(self *object.Object, arg any, mode int64, entryClassName string) interface{} {
+	path, gerr := zipFilePath(arg)
+	if gerr != nil {
+		return gerr
+	}
+	if mode&zipFileOpenRead == 0 || mode&^(zipFileOpenRead|zipFileOpenDelete) != 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, fmt.Sprintf("Illegal mode: 0x%x", mode))
+	}
+	rc, err := zip.OpenReader(path)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return ghelpers.GetGErrBlk(excNames.NoSuchFileException, path)
+		case errors.Is(err, zip.ErrFormat):
+			return ghelpers.GetGErrBlk(excNames.ZipException, "zip END header not found")
+		}
+		return ghelpers.GetGErrBlk(excNames.IOException, "ZipFile: "+err.Error())
+	}
+	if mode&zipFileOpenDelete != 0 {
+		_ = os.Remove(path)
+	}
+
+	zf := &zipFile{rc: rc, name: path, entryClassName: entryClassName, files: make(map[string]*zip.File)}
+	for _, f := range rc.File {
+		if _, dup := zf.files[f.Name]; !dup {
+			zf.files[f.Name] = f
+		}
+	}
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: zf}
+	return nil
+}
+
+// java/util/zip/ZipFile.<init>(Ljava/lang/String;)V and <init>(Ljava/io/File;)V
+func zipFileInit(params []interface{}) interface{} {
+	return openZipFile(params[0].(*object.Object), params[1], zipFileOpenRead, zipEntryClassName)
+}
+
+// java/util/zip/ZipFile.<init>(Ljava/io/File;I)V
+func zipFileInitMode(params []interface{}) interface{} {
+	return openZipFile(params[0].(*object.Object), params[1], params[2].(int64), zipEntryClassName)
+}
+
+// java/util/jar/JarFile.<init>(Ljava/lang/String;)V and the other constructors that take no
+// mode. Jar verification is not implemented, so the verify argument is ignored.
+func jarFileInit(params []interface{}) interface{} {
+	return openZipFile(params[0].(*object.Object), params[1], zipFileOpenRead, jarEntryClassName)
+}
+
+// java/util/jar/JarFile.<init>(Ljava/io/File;ZI)V
+func jarFileInitMode(params []interface{}) interface{} {
+	return openZipFile(params[0].(*object.Object), params[1], params[3].(int64), jarEntryClassName)
+}
+
+// getZipFile returns the Go state of the ZipFile obj, which must be open.
+func getZipFile(obj *object.Object) (*zipFile, *ghelpers.GErrBlk) {
+	zf, ok := obj.FieldTable[zipStateField].Fvalue.(*zipFile)
+	if !ok || zf.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "zip file closed")
+	}
+	return zf, nil
+}
+
+// lookup returns the file named name, or, if there is none, the directory of that name.
+func (zf *zipFile) lookup(name string) *zip.File {
+	if f, ok := zf.files[name]; ok {
+		return f
+	}
+	if !strings.HasSuffix(name, "/") {
+		return zf.files[name+"/"]
+	}
+	return nil
+}
+
+// java/util/zip/ZipFile.entries()Ljava/util/Enumeration; -- in the order of the central directory
+func zipFileEntries(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	elements := make([]any, 0, len(zf.rc.File))
+	for _, f := range zf.rc.File {
+		elements = append(elements, newZipEntryFromHeader(zf.entryClassName, &f.FileHeader))
+	}
+	return NewEnumeration(elements)
+}
+
+// java/util/zip/ZipFile.getEntry(Ljava/lang/String;)Ljava/util/zip/ZipEntry; -- null if not found
+func zipFileGetEntry(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	nameObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(nameObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipFile.getEntry: name is null")
+	}
+	f := zf.lookup(object.GoStringFromStringObject(nameObj))
+	if f == nil {
+		return object.Null
+	}
+	return newZipEntryFromHeader(zf.entryClassName, &f.FileHeader)
+}
+
+// java/util/zip/ZipFile.getInputStream(Ljava/util/zip/ZipEntry;)Ljava/io/InputStream; -- null
+// if the file has no entry of that name
+func zipFileGetInputStream(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	entry, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(entry) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipFile.getInputStream: entry is null")
+	}
+	f := zf.lookup(entryName(entry))
+	if f == nil {
+		return object.Null
+	}
+	rc, err := f.Open()
+	if err != nil {
+		if errors.Is(err, zip.ErrAlgorithm) {
+			return ghelpers.GetGErrBlk(excNames.ZipException, "invalid compression method")
+		}
+		return ghelpers.GetGErrBlk(excNames.ZipException, "ZipFile.getInputStream: "+err.Error())
+	}
+	zs := &zipStream{r: rc, close: func() interface{} {
+		_ = rc.Close()
+		return nil
+	}}
+	className := zipFileInputStreamClassName
+	stream := object.MakeEmptyObjectWithClassName(&className)
+	stream.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: zs}
+	return stream
+}
+
+func zipFileGetName(params []interface{}) interface{} {
+	zf, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(*zipFile)
+	if !ok {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(zf.name)
+}
+
+// java/util/zip/ZipFile.getComment()Ljava/lang/String; -- null if there is no comment
+func zipFileGetComment(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if zf.rc.Comment == "" {
+		return object.Null
+	}
+	return object.StringObjectFromGoString(zf.rc.Comment)
+}
+
+func zipFileSize(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(zf.rc.File))
+}
+
+// java/util/zip/ZipFile.close()V -- closing twice has no effect
+func zipFileClose(params []interface{}) interface{} {
+	zf, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(*zipFile)
+	if !ok || zf.closed {
+		return nil
+	}
+	zf.closed = true
+	if err := zf.rc.Close(); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "ZipFile.close: "+err.Error())
+	}
+	return nil
+}
+
+// java/util/jar/JarFile.getManifest()Ljava/util/jar/Manifest; -- null if there is none
+func jarFileGetManifest(params []interface{}) interface{} {
+	zf, gerr := getZipFile(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	f := zf.files[jarManifestName]
+	if f == nil {
+		for name, file := range zf.files {
+			if strings.EqualFold(name, jarManifestName) {
+				f = file
+				break
+			}
+		}
+	}
+	if f == nil {
+		return object.Null
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "JarFile.getManifest: "+err.Error())
+	}
+	defer rc.Close()
+	m := newManifestState()
+	if gerr = m.read(rc); gerr != nil {
+		return gerr
+	}
+	return newManifest(m)
+}
@@ -0,0 +1,241 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"container/list"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.util.zip.GZIPInputStream and GZIPOutputStream. GZIPInputStream reads with Go's
+// compress/gzip, which, like the JDK, reads concatenated gzip members as one stream.
+// GZIPOutputStream writes the same header and trailer as the JDK (no file name, no
+// modification time, and an OS byte of 255), around deflate data from compress/flate.
+
+const (
+	gzipInputStreamClassName  = "java/util/zip/GZIPInputStream"
+	gzipOutputStreamClassName = "java/util/zip/GZIPOutputStream"
+)
+
+// the header that the JDK's GZIPOutputStream writes
+var gzipHeader = []byte{0x1f, 0x8b, 8, 0, 0, 0, 0, 0, 0, 0xff}
+
+func Load_Util_Zip_GZIPInputStream() {
+	ghelpers.MethodSignatures[gzipInputStreamClassName+".<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	ghelpers.MethodSignatures[gzipInputStreamClassName+".<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: gzipInputStreamInit, NeedsContext: true}
+	ghelpers.MethodSignatures[gzipInputStreamClassName+".<init>(Ljava/io/InputStream;I)V"] =
+		ghelpers.GMeth{ParamSlots: 2, GFunction: gzipInputStreamInit, NeedsContext: true}
+	loadZipStreamReads(gzipInputStreamClassName)
+}
+
+func Load_Util_Zip_GZIPOutputStream() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                       {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>(Ljava/io/OutputStream;)V":   {ParamSlots: 1, GFunction: gzipOutputStreamInit, NeedsContext: true},
+		"<init>(Ljava/io/OutputStream;I)V":  {ParamSlots: 2, GFunction: gzipOutputStreamInitSize, NeedsContext: true},
+		"<init>(Ljava/io/OutputStream;Z)V":  {ParamSlots: 2, GFunction: gzipOutputStreamInitSync, NeedsContext: true},
+		"<init>(Ljava/io/OutputStream;IZ)V": {ParamSlots: 3, GFunction: gzipOutputStreamInitSizeSync, NeedsContext: true},
+		"close()V":                          {ParamSlots: 0, GFunction: gzipOutputStreamClose},
+		"finish()V":                         {ParamSlots: 0, GFunction: gzipOutputStreamFinish},
+		"flush()V":                          {ParamSlots: 0, GFunction: gzipOutputStreamFlush},
+		"write(I)V":                         {ParamSlots: 1, GFunction: gzipOutputStreamWrite},
+		"write([B)V":                        {ParamSlots: 1, GFunction: gzipOutputStreamWrite},
+		"write([BII)V":                      {ParamSlots: 3, GFunction: gzipOutputStreamWrite},
+	} {
+		ghelpers.MethodSignatures[gzipOutputStreamClassName+"."+sig] = gmeth
+	}
+}
+
+// java/util/zip/GZIPInputStream.<init>(Ljava/io/InputStream;)V and <init>(Ljava/io/InputStream;I)V
+// The header of the first member is read here, as the JDK does.
+func gzipInputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	if len(params) > 2 && params[2].(int64) <= 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "buffer size <= 0")
+	}
+	in, _ := params[1].(*object.Object)
+	src, gerr := ghelpers.GoReaderWithContext(fs, params[1], "GZIPInputStream")
+	if gerr != nil {
+		return gerr
+	}
+	gr, err := gzip.NewReader(src)
+	if err == io.EOF {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "")
+	}
+	if err != nil {
+		return zipReadError(err, "GZIPInputStream")
+	}
+	zs := &zipStream{r: gr, close: func() interface{} { return closeJavaStream(fs, in) }}
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: zs}
+	return nil
+}
+
+// gzipOutput is the Go state of a GZIPOutputStream.
+type gzipOutput struct {
+	out       *object.Object
+	fs        *list.List // the frame stack on which the Java methods of out run
+	w         io.Writer  // writes to out
+	fw        *flate.Writer
+	crc       hash.Hash32
+	size      uint32 // of the uncompressed data, modulo 2^32
+	syncFlush bool
+	finished  bool
+	closed    bool
+}
+
+// java/util/zip/GZIPOutputStream.<init>(Ljava/io/OutputStream;)V
+func gzipOutputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	return initGzipOutput(fs, params[0].(*object.Object), params[1], false)
+}
+
+// java/util/zip/GZIPOutputStream.<init>(Ljava/io/OutputStream;I)V
+func gzipOutputStreamInitSize(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if params[2].(int64) <= 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "buffer size <= 0")
+	}
+	return initGzipOutput(fs, params[0].(*object.Object), params[1], false)
+}
+
+// java/util/zip/GZIPOutputStream.<init>(Ljava/io/OutputStream;Z)V
+func gzipOutputStreamInitSync(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	return initGzipOutput(fs, params[0].(*object.Object), params[1], params[2] == types.JavaBoolTrue)
+}
+
+// java/util/zip/GZIPOutputStream.<init>(Ljava/io/OutputStream;IZ)V
+func gzipOutputStreamInitSizeSync(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	if params[2].(int64) <= 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "buffer size <= 0")
+	}
+	return initGzipOutput(fs, params[0].(*object.Object), params[1], params[3] == types.JavaBoolTrue)
+}
+
+// initGzipOutput sets up the GZIPOutputStream self, which writes to out, and writes the header,
+// as the JDK does. If syncFlush is set, flush() flushes the compressor too. The Java methods of
+// out run on the frame stack fs.
+func initGzipOutput(fs *list.List, self *object.Object, out any, syncFlush bool) interface{} {
+	w, gerr := ghelpers.GoWriterWithContext(fs, out, "GZIPOutputStream")
+	if gerr != nil {
+		return gerr
+	}
+	if _, err := w.Write(gzipHeader); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream: "+err.Error())
+	}
+	fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+	outObj, _ := out.(*object.Object)
+	gz := &gzipOutput{out: outObj, fs: fs, w: w, fw: fw, crc: crc32.NewIEEE(), syncFlush: syncFlush}
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: gz}
+	return nil
+}
+
+// getGzipOutput returns the Go state of the GZIPOutputStream obj, which must be open.
+func getGzipOutput(obj *object.Object) (*gzipOutput, *ghelpers.GErrBlk) {
+	gz, ok := obj.FieldTable[zipStateField].Fvalue.(*gzipOutput)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream is not initialized")
+	}
+	if gz.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "Stream closed")
+	}
+	return gz, nil
+}
+
+// java/util/zip/GZIPOutputStream.write(I)V, write([B)V and write([BII)V
+func gzipOutputStreamWrite(params []interface{}) interface{} {
+	gz, gerr := getGzipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	var data []byte
+	if b, ok := params[1].(int64); ok {
+		data = []byte{byte(b)}
+	} else {
+		jbytes, off, length, gerr := byteArrayRange(params[1:], "GZIPOutputStream.write")
+		if gerr != nil {
+			return gerr
+		}
+		data = object.GoByteArrayFromJavaByteArray(jbytes[off : off+length])
+	}
+	if gz.finished {
+		return ghelpers.GetGErrBlk(excNames.IOException, "write beyond end of stream")
+	}
+	if _, err := gz.fw.Write(data); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream.write: "+err.Error())
+	}
+	gz.crc.Write(data)
+	gz.size += uint32(len(data))
+	return nil
+}
+
+// java/util/zip/GZIPOutputStream.flush()V -- the compressor is flushed only if syncFlush is set
+func gzipOutputStreamFlush(params []interface{}) interface{} {
+	gz, gerr := getGzipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if gz.syncFlush && !gz.finished {
+		if err := gz.fw.Flush(); err != nil {
+			return ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream.flush: "+err.Error())
+		}
+	}
+	if gerr = flushJavaWriter(gz.w); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/zip/GZIPOutputStream.finish()V -- writes the rest of the compressed data and the
+// trailer without closing the underlying stream
+func gzipOutputStreamFinish(params []interface{}) interface{} {
+	gz, gerr := getGzipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if gz.finished {
+		return nil
+	}
+	gz.finished = true
+	if err := gz.fw.Close(); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream.finish: "+err.Error())
+	}
+	trailer := binary.LittleEndian.AppendUint32(nil, gz.crc.Sum32())
+	trailer = binary.LittleEndian.AppendUint32(trailer, gz.size)
+	if _, err := gz.w.Write(trailer); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IOException, "GZIPOutputStream.finish: "+err.Error())
+	}
+	return nil
+}
+
+// java/util/zip/GZIPOutputStream.close()V -- finishes and closes the underlying stream
+func gzipOutputStreamClose(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	gz, ok := self.FieldTable[zipStateField].Fvalue.(*gzipOutput)
+	if !ok || gz.closed {
+		return nil
+	}
+	ret := gzipOutputStreamFinish(params)
+	gz.closed = true
+	if closeRet := closeJavaStream(gz.fs, gz.out); ret == nil {
+		ret = closeRet
+	}
+	return ret
+}
@@ -0,0 +1,297 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/adler32"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.util.zip.Inflater decompresses zlib data, or raw deflate data if it is created with
+// nowrap set, using Go's compress/zlib and compress/flate.
+//
+// Go's decompressors read from an io.Reader and cannot be suspended until more input arrives,
+// so an Inflater keeps all the input it has been given and decompresses it again, from the
+// start, each time more input is set. Whatever the input decompresses to is kept until
+// inflate() returns it. Programs almost always set the whole input at once, which then is
+// decompressed only once. GZIPInputStream and ZipInputStream do not use Inflater.
+
+const inflaterClassName = "java/util/zip/Inflater"
+
+func Load_Util_Zip_Inflater() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":          {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>()V":            {ParamSlots: 0, GFunction: inflaterInit},
+		"<init>(Z)V":           {ParamSlots: 1, GFunction: inflaterInit},
+		"end()V":               {ParamSlots: 0, GFunction: inflaterEnd},
+		"finished()Z":          {ParamSlots: 0, GFunction: inflaterFinished},
+		"getAdler()I":          {ParamSlots: 0, GFunction: inflaterGetAdler},
+		"getBytesRead()J":      {ParamSlots: 0, GFunction: inflaterGetBytesRead},
+		"getBytesWritten()J":   {ParamSlots: 0, GFunction: inflaterGetBytesWritten},
+		"getRemaining()I":      {ParamSlots: 0, GFunction: inflaterGetRemaining},
+		"getTotalIn()I":        {ParamSlots: 0, GFunction: inflaterGetTotalIn},
+		"getTotalOut()I":       {ParamSlots: 0, GFunction: inflaterGetTotalOut},
+		"inflate([B)I":         {ParamSlots: 1, GFunction: inflaterInflate},
+		"inflate([BII)I":       {ParamSlots: 3, GFunction: inflaterInflate},
+		"needsDictionary()Z":   {ParamSlots: 0, GFunction: inflaterNeedsDictionary},
+		"needsInput()Z":        {ParamSlots: 0, GFunction: inflaterNeedsInput},
+		"reset()V":             {ParamSlots: 0, GFunction: inflaterReset},
+		"setDictionary([B)V":   {ParamSlots: 1, GFunction: inflaterSetDictionary},
+		"setDictionary([BII)V": {ParamSlots: 3, GFunction: inflaterSetDictionary},
+		"setInput([B)V":        {ParamSlots: 1, GFunction: inflaterSetInput},
+		"setInput([BII)V":      {ParamSlots: 3, GFunction: inflaterSetInput},
+	} {
+		ghelpers.MethodSignatures[inflaterClassName+"."+sig] = gmeth
+	}
+}
+
+// inflater is the Go state of an Inflater.
+type inflater struct {
+	nowrap    bool
+	input     []byte // all the input since the Inflater was created or reset
+	dict      []byte
+	output    []byte // what input decompresses to
+	returned  int    // how much of output inflate() has returned
+	consumed  int    // how much of input the decompressor has read
+	done      bool   // the decompressor reached the end of the compressed data
+	needsDict bool
+	err       error
+	adler     hash.Hash32 // of output[:returned]
+	ended     bool
+}
+
+// decode decompresses the input from the start.
+func (inf *inflater) decode() {
+	inf.output, inf.done, inf.needsDict, inf.err = nil, false, false, nil
+	src := bytes.NewReader(inf.input) // an io.ByteReader, so the decompressor reads no more than it needs
+	defer func() { inf.consumed = len(inf.input) - src.Len() }()
+
+	var r io.Reader
+	if inf.nowrap {
+		r = flate.NewReaderDict(src, inf.dict)
+	} else {
+		zr, err := zlib.NewReaderDict(src, inf.dict)
+		switch {
+		case errors.Is(err, zlib.ErrDictionary):
+			inf.needsDict = true
+			return
+		case err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF):
+			return // the header is not all there yet
+		case err != nil:
+			inf.err = err
+			return
+		}
+		r = zr
+	}
+
+	output, err := io.ReadAll(r)
+	inf.output = output
+	switch {
+	case err == nil:
+		inf.done = true
+	case !errors.Is(err, io.ErrUnexpectedEOF):
+		inf.err = err
+	}
+}
+
+// getInflater returns the Go state of the Inflater obj, which must not have been ended.
+func getInflater(obj *object.Object) (*inflater, *ghelpers.GErrBlk) {
+	inf, ok := obj.FieldTable[zipStateField].Fvalue.(*inflater)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "Inflater is not initialized")
+	}
+	if inf.ended {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "Inflater has been closed")
+	}
+	return inf, nil
+}
+
+// java/util/zip/Inflater.<init>()V and <init>(Z)V
+func inflaterInit(params []interface{}) interface{} {
+	self := params[0].(*object.Object)
+	nowrap := len(params) > 1 && params[1] == types.JavaBoolTrue
+	inf := &inflater{nowrap: nowrap, adler: adler32.New()}
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: inf}
+	return nil
+}
+
+// java/util/zip/Inflater.setInput([B)V and setInput([BII)V
+func inflaterSetInput(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Inflater.setInput")
+	if gerr != nil {
+		return gerr
+	}
+	inf.input = append(inf.input, object.GoByteArrayFromJavaByteArray(jbytes[off:off+length])...)
+	if !inf.done { // input after the end of the compressed data is only counted by getRemaining
+		inf.decode()
+	}
+	return nil
+}
+
+// java/util/zip/Inflater.setDictionary([B)V and setDictionary([BII)V
+func inflaterSetDictionary(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Inflater.setDictionary")
+	if gerr != nil {
+		return gerr
+	}
+	inf.dict = object.GoByteArrayFromJavaByteArray(jbytes[off : off+length])
+	inf.decode()
+	if inf.err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, inf.err.Error())
+	}
+	return nil
+}
+
+// java/util/zip/Inflater.inflate([B)I and inflate([BII)I -- returns 0 if more input or a
+// dictionary is needed
+func inflaterInflate(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "Inflater.inflate")
+	if gerr != nil {
+		return gerr
+	}
+	if inf.err != nil && inf.returned == len(inf.output) {
+		return ghelpers.GetGErrBlk(excNames.DataFormatException, inflateErrorMessage(inf.err))
+	}
+	n := copy(jbytes[off:off+length], object.JavaByteArrayFromGoByteArray(inf.output[inf.returned:]))
+	inf.adler.Write(inf.output[inf.returned : inf.returned+n])
+	inf.returned += n
+	return int64(n)
+}
+
+// inflateErrorMessage returns the message of the DataFormatException that zlib would give for err.
+func inflateErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, zlib.ErrHeader):
+		return "incorrect header check"
+	case errors.Is(err, zlib.ErrChecksum):
+		return "incorrect data check"
+	}
+	return "invalid deflate data: " + err.Error()
+}
+
+// java/util/zip/Inflater.needsInput()Z
+func inflaterNeedsInput(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	needs := !inf.done && !inf.needsDict && inf.err == nil && inf.returned == len(inf.output)
+	return types.ConvertGoBoolToJavaBool(needs)
+}
+
+// java/util/zip/Inflater.needsDictionary()Z
+func inflaterNeedsDictionary(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(inf.needsDict)
+}
+
+// java/util/zip/Inflater.finished()Z -- the end of the compressed data has been reached and
+// all of the uncompressed data has been returned
+func inflaterFinished(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return types.ConvertGoBoolToJavaBool(inf.done && inf.returned == len(inf.output))
+}
+
+// java/util/zip/Inflater.getRemaining()I -- the number of input bytes that were not needed
+func inflaterGetRemaining(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(len(inf.input) - inf.consumed)
+}
+
+// java/util/zip/Inflater.getAdler()I -- the checksum of the uncompressed data returned so far,
+// or the ID of the dictionary that is needed
+func inflaterGetAdler(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if inf.needsDict && len(inf.input) >= 6 {
+		return int64(int32(binary.BigEndian.Uint32(inf.input[2:6])))
+	}
+	return int64(int32(inf.adler.Sum32()))
+}
+
+func inflaterGetBytesRead(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(inf.consumed)
+}
+
+func inflaterGetBytesWritten(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return int64(inf.returned)
+}
+
+func inflaterGetTotalIn(params []interface{}) interface{} {
+	ret := inflaterGetBytesRead(params)
+	if n, ok := ret.(int64); ok {
+		return int64(int32(n))
+	}
+	return ret
+}
+
+func inflaterGetTotalOut(params []interface{}) interface{} {
+	ret := inflaterGetBytesWritten(params)
+	if n, ok := ret.(int64); ok {
+		return int64(int32(n))
+	}
+	return ret
+}
+
+// java/util/zip/Inflater.reset()V -- the Inflater can then decompress a new stream
+func inflaterReset(params []interface{}) interface{} {
+	inf, gerr := getInflater(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	*inf = inflater{nowrap: inf.nowrap, adler: adler32.New()}
+	return nil
+}
+
+// java/util/zip/Inflater.end()V
+func inflaterEnd(params []interface{}) interface{} {
+	if inf, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(*inflater); ok {
+		*inf = inflater{ended: true}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"bufio"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// java.util.zip.ZipInputStream reads a zip file sequentially, entry by entry, from the local
+// file headers, as the JDK does. Go's archive/zip needs random access to the file, so the
+// headers are read here; the entry data is decompressed with compress/flate.
+
+const (
+	zipInputStreamClassName = "java/util/zip/ZipInputStream"
+
+	zipLocalHeaderSig   = 0x04034b50
+	zipDataDescSig      = 0x08074b50
+	zipFlagEncrypted    = 0x1
+	zipFlagDataDesc     = 0x8
+	zipLocalHeaderLen   = 26 // after the signature
+	zip64ExtraTag       = 0x0001
+	zip32SizeUnknown    = 0xffffffff
+	zipInputCopyBufSize = 8192
+)
+
+func Load_Util_Zip_ZipInputStream() {
+	ghelpers.MethodSignatures[zipInputStreamClassName+".<clinit>()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: ghelpers.ClinitGeneric}
+	ghelpers.MethodSignatures[zipInputStreamClassName+".<init>(Ljava/io/InputStream;)V"] =
+		ghelpers.GMeth{ParamSlots: 1, GFunction: zipInputStreamInit, NeedsContext: true}
+	ghelpers.MethodSignatures[zipInputStreamClassName+".getNextEntry()Ljava/util/zip/ZipEntry;"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: zipInputStreamGetNextEntry}
+	ghelpers.MethodSignatures[zipInputStreamClassName+".closeEntry()V"] =
+		ghelpers.GMeth{ParamSlots: 0, GFunction: zipInputStreamCloseEntry}
+	loadZipStreamReads(zipInputStreamClassName)
+}
+
+// zipInput is the Go state of a ZipInputStream. Its zipStream reads the data of the current
+// entry, if there is one.
+type zipInput struct {
+	zipStream
+	src *countingReader
+}
+
+// countingReader counts the bytes read from a buffered reader. It is an io.ByteReader, so
+// compress/flate reads no further than the end of the compressed data of an entry.
+type countingReader struct {
+	br *bufio.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.br.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *countingReader) ReadByte() (byte, error) {
+	b, err := cr.br.ReadByte()
+	if err == nil {
+		cr.n++
+	}
+	return b, err
+}
+
+// zipEntryReader returns the data of one entry and, at its end, reads the data descriptor, if
+// there is one, and checks the CRC and sizes.
+type zipEntryReader struct {
+	src      *countingReader
+	data     io.Reader
+	entry    *object.Object
+	flags    uint16
+	zip64    bool
+	crc      hash.Hash32
+	size     int64
+	start    int64 // the offset in src of the entry data
+	expCrc   int64 // the expected values, or -1 if they are in the data descriptor
+	expSize  int64
+	expCsize int64
+}
+
+func (er *zipEntryReader) Read(p []byte) (int, error) {
+	n, err := er.data.Read(p)
+	er.crc.Write(p[:n])
+	er.size += int64(n)
+	if err == io.EOF {
+		if gerr := er.finish(); gerr != nil {
+			return n, &zipError{gerr}
+		}
+	}
+	return n, err
+}
+
+// finish reads the data descriptor, if there is one, and checks the entry.
+func (er *zipEntryReader) finish() *ghelpers.GErrBlk {
+	csize := er.src.n - er.start
+	if er.flags&zipFlagDataDesc != 0 {
+		var buf [24]byte
+		if _, err := io.ReadFull(er.src, buf[:4]); err != nil {
+			return ghelpers.GetGErrBlk(excNames.EOFException, "Unexpected end of ZLIB input stream")
+		}
+		desc := buf[:4]
+		sizeLen := 4
+		if er.zip64 {
+			sizeLen = 8
+		}
+		want := 4 + 2*sizeLen
+		if binary.LittleEndian.Uint32(desc) == zipDataDescSig {
+			want += 4
+		}
+		if _, err := io.ReadFull(er.src, buf[4:want]); err != nil {
+			return ghelpers.GetGErrBlk(excNames.EOFException, "Unexpected end of ZLIB input stream")
+		}
+		desc = buf[want-4-2*sizeLen : want]
+		er.expCrc = int64(binary.LittleEndian.Uint32(desc))
+		if er.zip64 {
+			er.expCsize = int64(binary.LittleEndian.Uint64(desc[4:]))
+			er.expSize = int64(binary.LittleEndian.Uint64(desc[12:]))
+		} else {
+			er.expCsize = int64(binary.LittleEndian.Uint32(desc[4:]))
+			er.expSize = int64(binary.LittleEndian.Uint32(desc[8:]))
+		}
+		setEntryLong(er.entry, zipEntryCrc, er.expCrc)
+		setEntryLong(er.entry, zipEntryCsize, er.expCsize)
+		setEntryLong(er.entry, zipEntrySize, er.expSize)
+	}
+
+	if er.size != er.expSize {
+		errMsg := fmt.Sprintf("invalid entry size (expected %d but got %d bytes)", er.expSize, er.size)
+		return ghelpers.GetGErrBlk(excNames.ZipException, errMsg)
+	}
+	if csize != er.expCsize {
+		errMsg := fmt.Sprintf("invalid entry compressed size (expected %d but got %d bytes)", er.expCsize, csize)
+		return ghelpers.GetGErrBlk(excNames.ZipException, errMsg)
+	}
+	if crc := int64(er.crc.Sum32()); crc != er.expCrc {
+		errMsg := fmt.Sprintf("invalid entry CRC (expected 0x%x but got 0x%x)", er.expCrc, crc)
+		return ghelpers.GetGErrBlk(excNames.ZipException, errMsg)
+	}
+	return nil
+}
+
+// java/util/zip/ZipInputStream.<init>(Ljava/io/InputStream;)V
+func zipInputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	in, _ := params[1].(*object.Object)
+	src, gerr := ghelpers.GoReaderWithContext(fs, params[1], "ZipInputStream")
+	if gerr != nil {
+		return gerr
+	}
+	zi := &zipInput{src: &countingReader{br: bufio.NewReader(src)}}
+	zi.close = func() interface{} { return closeJavaStream(fs, in) }
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: zi}
+	return nil
+}
+
+func getZipInput(obj *object.Object, caller string) (*zipInput, *ghelpers.GErrBlk) {
+	if _, gerr := getZipStream(obj, caller); gerr != nil {
+		return nil, gerr
+	}
+	zi, ok := obj.FieldTable[zipStateField].Fvalue.(*zipInput)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, caller+": not a ZipInputStream")
+	}
+	return zi, nil
+}
+
+// java/util/zip/ZipInputStream.closeEntry()V -- skips the rest of the current entry
+func zipInputStreamCloseEntry(params []interface{}) interface{} {
+	zi, gerr := getZipInput(params[0].(*object.Object), "ZipInputStream.closeEntry")
+	if gerr != nil {
+		return gerr
+	}
+	return zi.closeEntry()
+}
+
+func (zi *zipInput) closeEntry() interface{} {
+	if zi.r == nil {
+		return nil
+	}
+	if !zi.eof {
+		if _, err := io.CopyBuffer(io.Discard, zi.r, make([]byte, zipInputCopyBufSize)); err != nil {
+			zi.r = nil
+			return zipReadError(err, "ZipInputStream.closeEntry")
+		}
+	}
+	zi.r = nil
+	return nil
+}
+
+// java/util/zip/ZipInputStream.getNextEntry()Ljava/util/zip/ZipEntry; -- closes the current
+// entry and reads the next local file header. Returns null at the end of the entries.
+func zipInputStreamGetNextEntry(params []interface{}) interface{} {
+	zi, gerr := getZipInput(params[0].(*object.Object), "ZipInputStream.getNextEntry")
+	if gerr != nil {
+		return gerr
+	}
+	if ret := zi.closeEntry(); ret != nil {
+		return ret
+	}
+
+	var sig [4]byte
+	if _, err := io.ReadFull(zi.src, sig[:]); err != nil || binary.LittleEndian.Uint32(sig[:]) != zipLocalHeaderSig {
+		return object.Null // the central directory, or the end of the stream
+	}
+	var hdr [zipLocalHeaderLen]byte
+	if _, err := io.ReadFull(zi.src, hdr[:]); err != nil {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "Unexpected end of ZLIB input stream")
+	}
+	flags := binary.LittleEndian.Uint16(hdr[2:])
+	method := binary.LittleEndian.Uint16(hdr[4:])
+	dosTime := binary.LittleEndian.Uint16(hdr[6:])
+	dosDate := binary.LittleEndian.Uint16(hdr[8:])
+	crc := binary.LittleEndian.Uint32(hdr[10:])
+	csize := int64(binary.LittleEndian.Uint32(hdr[14:]))
+	size := int64(binary.LittleEndian.Uint32(hdr[18:]))
+	nameAndExtra := make([]byte, int(binary.LittleEndian.Uint16(hdr[22:]))+int(binary.LittleEndian.Uint16(hdr[24:])))
+	if _, err := io.ReadFull(zi.src, nameAndExtra); err != nil {
+		return ghelpers.GetGErrBlk(excNames.EOFException, "Unexpected end of ZLIB input stream")
+	}
+	nameLen := int(binary.LittleEndian.Uint16(hdr[22:]))
+	name, extra := string(nameAndExtra[:nameLen]), nameAndExtra[nameLen:]
+
+	if flags&zipFlagEncrypted != 0 {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "encrypted ZIP entry not supported")
+	}
+	if method != zipStored && method != zipDeflated {
+		return ghelpers.GetGErrBlk(excNames.ZipException, fmt.Sprintf("invalid compression method: %d", method))
+	}
+	if method == zipStored && flags&zipFlagDataDesc != 0 {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "only DEFLATED entries can have EXT descriptor")
+	}
+
+	entry := newZipEntry(zipEntryClassName, name)
+	setEntryLong(entry, zipEntryTime, entryTime(dosDate, dosTime, extra))
+	setEntryLong(entry, zipEntryMethod, int64(method))
+	setEntryExtra(entry, extra)
+	zip64, sizes64 := zip64Sizes(extra)
+	if zip64 && size == zip32SizeUnknown && csize == zip32SizeUnknown && len(sizes64) == 2 {
+		size, csize = sizes64[0], sizes64[1]
+	}
+
+	er := &zipEntryReader{src: zi.src, entry: entry, flags: flags, zip64: zip64, crc: crc32.NewIEEE(),
+		start: zi.src.n, expCrc: -1, expSize: -1, expCsize: -1}
+	if flags&zipFlagDataDesc == 0 {
+		er.expCrc, er.expSize, er.expCsize = int64(crc), size, csize
+		setEntryLong(entry, zipEntryCrc, int64(crc))
+		setEntryLong(entry, zipEntrySize, size)
+		setEntryLong(entry, zipEntryCsize, csize)
+	}
+	if method == zipStored {
+		er.data = io.LimitReader(zi.src, csize)
+	} else {
+		er.data = flate.NewReader(zi.src)
+	}
+	zi.r, zi.eof = er, false
+	return entry
+}
+
+// zip64Sizes reports whether extra has a Zip64 extended information field and returns the
+// sizes in it.
+func zip64Sizes(extra []byte) (bool, []int64) {
+	for len(extra) >= 4 {
+		tag := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		if size > len(extra)-4 {
+			break
+		}
+		if tag == zip64ExtraTag {
+			var sizes []int64
+			for data := extra[4 : 4+size]; len(data) >= 8 && len(sizes) < 2; data = data[8:] {
+				sizes = append(sizes, int64(binary.LittleEndian.Uint64(data)))
+			}
+			return true, sizes
+		}
+		extra = extra[4+size:]
+	}
+	return false, nil
+}
@@ -0,0 +1,354 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"container/list"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+	"time"
+)
+
+// java.util.zip.ZipOutputStream writes a zip file with Go's archive/zip. As in the JDK, a
+// DEFLATED entry is followed by a data descriptor, while a STORED entry must have its size and
+// CRC set beforehand, so that they go in its local header. Entries carry only the MS-DOS
+// modification time, as they do when the JDK writes them.
+//
+// archive/zip learns the compressed size of a DEFLATED entry only when the next entry is begun
+// or the stream is finished, so that is when the entry's compressed size is set.
+
+const zipOutputStreamClassName = "java/util/zip/ZipOutputStream"
+
+func Load_Util_Zip_ZipOutputStream() {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"<clinit>()V":                     {ParamSlots: 0, GFunction: ghelpers.ClinitGeneric},
+		"<init>(Ljava/io/OutputStream;)V": {ParamSlots: 1, GFunction: zipOutputStreamInit, NeedsContext: true},
+		"close()V":                        {ParamSlots: 0, GFunction: zipOutputStreamClose},
+		"closeEntry()V":                   {ParamSlots: 0, GFunction: zipOutputStreamCloseEntry},
+		"finish()V":                       {ParamSlots: 0, GFunction: zipOutputStreamFinish},
+		"flush()V":                        {ParamSlots: 0, GFunction: zipOutputStreamFlush},
+		"putNextEntry(Ljava/util/zip/ZipEntry;)V": {ParamSlots: 1, GFunction: zipOutputStreamPutNextEntry},
+		"setComment(Ljava/lang/String;)V":         {ParamSlots: 1, GFunction: zipOutputStreamSetComment},
+		"setLevel(I)V":                            {ParamSlots: 1, GFunction: zipOutputStreamSetLevel},
+		"setMethod(I)V":                           {ParamSlots: 1, GFunction: zipOutputStreamSetMethod},
+		"write(I)V":                               {ParamSlots: 1, GFunction: zipOutputStreamWrite},
+		"write([B)V":                              {ParamSlots: 1, GFunction: zipOutputStreamWrite},
+		"write([BII)V":                            {ParamSlots: 3, GFunction: zipOutputStreamWrite},
+	} {
+		ghelpers.MethodSignatures[zipOutputStreamClassName+"."+sig] = gmeth
+	}
+}
+
+// zipOutput is the Go state of a ZipOutputStream.
+type zipOutput struct {
+	out      *object.Object
+	fs       *list.List // the frame stack on which the Java methods of out run
+	w        io.Writer  // writes to out
+	zw       *zip.Writer
+	method   int64
+	level    int
+	names    map[string]bool
+	entry    *object.Object // the current entry
+	ew       io.Writer      // writes the data of the current entry
+	crc      hash.Hash32
+	size     int64
+	closing  *object.Object // the last DEFLATED entry, whose compressed size is not known yet
+	closingH *zip.FileHeader
+	finished bool
+	closed   bool
+}
+
+// java/util/zip/ZipOutputStream.<init>(Ljava/io/OutputStream;)V
+func zipOutputStreamInit(params []interface{}) interface{} {
+	fs, params := ghelpers.SplitContext(params)
+	self := params[0].(*object.Object)
+	w, gerr := ghelpers.GoWriterWithContext(fs, params[1], "ZipOutputStream")
+	if gerr != nil {
+		return gerr
+	}
+	out, _ := params[1].(*object.Object)
+	zo := &zipOutput{out: out, fs: fs, w: w, zw: zip.NewWriter(w), method: zipDeflated,
+		level: flate.DefaultCompression, names: make(map[string]bool)}
+	zo.zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(w, zo.level) // the level when the entry is begun
+	})
+	self.FieldTable[zipStateField] = object.Field{Ftype: types.RawGoPointer, Fvalue: zo}
+	return nil
+}
+
+// getZipOutput returns the Go state of the ZipOutputStream obj, which must be open.
+func getZipOutput(obj *object.Object) (*zipOutput, *ghelpers.GErrBlk) {
+	zo, ok := obj.FieldTable[zipStateField].Fvalue.(*zipOutput)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "ZipOutputStream is not initialized")
+	}
+	if zo.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "Stream closed")
+	}
+	return zo, nil
+}
+
+func zipWriteError(caller string, err error) *ghelpers.GErrBlk {
+	return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: %s", caller, err.Error()))
+}
+
+// java/util/zip/ZipOutputStream.putNextEntry(Ljava/util/zip/ZipEntry;)V -- closes the current
+// entry and begins a new one. An entry with no time is given the current time.
+func zipOutputStreamPutNextEntry(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	entry, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(entry) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "ZipOutputStream.putNextEntry: entry is null")
+	}
+	if gerr = zo.closeEntry(); gerr != nil {
+		return gerr
+	}
+	if zo.finished {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "ZipOutputStream.putNextEntry: stream is finished")
+	}
+
+	name := entryName(entry)
+	if zo.names[name] {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "duplicate entry: "+name)
+	}
+	method := entryLong(entry, zipEntryMethod)
+	if method == -1 {
+		method = zo.method
+	}
+	if entryLong(entry, zipEntryTime) == -1 {
+		setEntryLong(entry, zipEntryTime, time.Now().UnixMilli())
+	}
+	fh := &zip.FileHeader{Name: name, Comment: entryComment(entry), Extra: entryExtra(entry), Method: uint16(method)}
+	fh.ModifiedDate, fh.ModifiedTime = dosDateTime(entryLong(entry, zipEntryTime))
+	setEntryLong(entry, zipEntryMethod, method)
+
+	var ew io.Writer
+	var err error
+	if method == zipStored {
+		size, csize, crc := entryLong(entry, zipEntrySize), entryLong(entry, zipEntryCsize), entryLong(entry, zipEntryCrc)
+		switch {
+		case size == -1 && csize == -1:
+			return ghelpers.GetGErrBlk(excNames.ZipException, "STORED entry missing size, compressed size, or crc-32")
+		case size == -1:
+			size = csize
+		case csize == -1:
+			csize = size
+		}
+		if size != csize {
+			return ghelpers.GetGErrBlk(excNames.ZipException, "STORED entry where compressed != uncompressed size")
+		}
+		if crc == -1 {
+			return ghelpers.GetGErrBlk(excNames.ZipException, "STORED entry missing size, compressed size, or crc-32")
+		}
+		setEntryLong(entry, zipEntrySize, size)
+		setEntryLong(entry, zipEntryCsize, csize)
+		fh.CRC32, fh.UncompressedSize64, fh.CompressedSize64 = uint32(crc), uint64(size), uint64(csize)
+		ew, err = zo.zw.CreateRaw(fh) // the data is written as is, with no data descriptor
+	} else {
+		ew, err = zo.zw.CreateHeader(fh)
+	}
+	if err != nil {
+		return zipWriteError("ZipOutputStream.putNextEntry", err)
+	}
+	zo.updateClosing()
+	if method == zipDeflated {
+		zo.closing, zo.closingH = entry, fh
+	}
+	zo.names[name] = true
+	zo.entry, zo.ew, zo.crc, zo.size = entry, ew, crc32.NewIEEE(), 0
+	return nil
+}
+
+// updateClosing sets the compressed size of the last DEFLATED entry, which archive/zip knows
+// once the entry after it has been begun or the stream finished.
+func (zo *zipOutput) updateClosing() {
+	if zo.closing != nil {
+		setEntryLong(zo.closing, zipEntryCsize, int64(zo.closingH.CompressedSize64))
+		zo.closing, zo.closingH = nil, nil
+	}
+}
+
+// closeEntry ends the current entry, if there is one, and sets its size and CRC. The data of a
+// STORED entry must match the size and CRC that were set beforehand.
+func (zo *zipOutput) closeEntry() *ghelpers.GErrBlk {
+	entry := zo.entry
+	if entry == nil {
+		return nil
+	}
+	zo.entry, zo.ew = nil, nil
+	crc := int64(zo.crc.Sum32())
+	if entryLong(entry, zipEntryMethod) == zipStored {
+		if expected := entryLong(entry, zipEntrySize); zo.size != expected {
+			errMsg := fmt.Sprintf("invalid entry size (expected %d but got %d bytes)", expected, zo.size)
+			return ghelpers.GetGErrBlk(excNames.ZipException, errMsg)
+		}
+		if expected := entryLong(entry, zipEntryCrc); crc != expected {
+			errMsg := fmt.Sprintf("invalid entry crc-32 (expected 0x%x but got 0x%x)", expected, crc)
+			return ghelpers.GetGErrBlk(excNames.ZipException, errMsg)
+		}
+		return nil
+	}
+	setEntryLong(entry, zipEntrySize, zo.size)
+	setEntryLong(entry, zipEntryCrc, crc)
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.closeEntry()V
+func zipOutputStreamCloseEntry(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if gerr = zo.closeEntry(); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.write(I)V, write([B)V and write([BII)V -- writes to the current entry
+func zipOutputStreamWrite(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	var data []byte
+	if b, ok := params[1].(int64); ok {
+		data = []byte{byte(b)}
+	} else {
+		jbytes, off, length, gerr := byteArrayRange(params[1:], "ZipOutputStream.write")
+		if gerr != nil {
+			return gerr
+		}
+		data = object.GoByteArrayFromJavaByteArray(jbytes[off : off+length])
+	}
+	if zo.ew == nil {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "no current ZIP entry")
+	}
+	if entryLong(zo.entry, zipEntryMethod) == zipStored && zo.size+int64(len(data)) > entryLong(zo.entry, zipEntrySize) {
+		return ghelpers.GetGErrBlk(excNames.ZipException, "attempt to write past end of STORED entry")
+	}
+	if _, err := zo.ew.Write(data); err != nil {
+		return zipWriteError("ZipOutputStream.write", err)
+	}
+	zo.crc.Write(data)
+	zo.size += int64(len(data))
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.setComment(Ljava/lang/String;)V -- the comment of the zip file
+func zipOutputStreamSetComment(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	comment := ""
+	if commentObj, ok := params[1].(*object.Object); ok && !object.IsNull(commentObj) {
+		comment = object.GoStringFromStringObject(commentObj)
+	}
+	if len(comment) > 0xffff {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "ZIP file comment too long.")
+	}
+	if err := zo.zw.SetComment(comment); err != nil {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, err.Error())
+	}
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.setMethod(I)V -- the method of entries that do not set one
+func zipOutputStreamSetMethod(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	method := params[1].(int64)
+	if method != zipStored && method != zipDeflated {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "invalid compression method")
+	}
+	zo.method = method
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.setLevel(I)V -- the compression level of the entries that follow
+func zipOutputStreamSetLevel(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	level := params[1].(int64)
+	if gerr = checkDeflateLevel(level); gerr != nil {
+		return gerr
+	}
+	zo.level = int(level)
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.finish()V -- writes the central directory without closing the
+// underlying stream
+func zipOutputStreamFinish(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	return zo.finish()
+}
+
+func (zo *zipOutput) finish() interface{} {
+	if zo.finished {
+		return nil
+	}
+	if gerr := zo.closeEntry(); gerr != nil {
+		return gerr
+	}
+	zo.finished = true
+	if err := zo.zw.Close(); err != nil {
+		return zipWriteError("ZipOutputStream.finish", err)
+	}
+	zo.updateClosing()
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.flush()V
+func zipOutputStreamFlush(params []interface{}) interface{} {
+	zo, gerr := getZipOutput(params[0].(*object.Object))
+	if gerr != nil {
+		return gerr
+	}
+	if !zo.finished {
+		if err := zo.zw.Flush(); err != nil {
+			return zipWriteError("ZipOutputStream.flush", err)
+		}
+	}
+	if gerr = flushJavaWriter(zo.w); gerr != nil {
+		return gerr
+	}
+	return nil
+}
+
+// java/util/zip/ZipOutputStream.close()V -- finishes and closes the underlying stream
+func zipOutputStreamClose(params []interface{}) interface{} {
+	zo, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(*zipOutput)
+	if !ok || zo.closed {
+		return nil
+	}
+	ret := zo.finish()
+	zo.closed = true
+	if closeRet := closeJavaStream(zo.fs, zo.out); ret == nil {
+		ret = closeRet
+	}
+	return ret
+}
@@ -0,0 +1,262 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaUtil
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
+)
+
+// The input streams of java.util.zip and java.util.jar that return uncompressed data
+// (GZIPInputStream, ZipInputStream, and the streams of ZipFile entries) read it from a Go
+// reader. Each keeps its Go state in its zipStateField field; the state includes a zipStream,
+// whose G functions, registered for each class by loadZipStreamReads, are shared.
+
+const zipStateField = "zipState"
+
+// zipStream is the part of the Go state of an input stream that the read methods use.
+type zipStream struct {
+	r      io.Reader          // the uncompressed data, or nil if there is none (between zip entries)
+	eof    bool               // r has returned all of its data
+	closed bool               // the Java stream has been closed
+	close  func() interface{} // closes whatever the Java stream reads from
+}
+
+// zipStreamHolder is implemented by the Go states that contain a zipStream.
+type zipStreamHolder interface {
+	stream() *zipStream
+}
+
+func (zs *zipStream) stream() *zipStream { return zs }
+
+// loadZipStreamReads registers the read methods of the input stream class className.
+func loadZipStreamReads(className string) {
+	for sig, gmeth := range map[string]ghelpers.GMeth{
+		"available()I":     {ParamSlots: 0, GFunction: zipStreamAvailable},
+		"close()V":         {ParamSlots: 0, GFunction: zipStreamClose},
+		"markSupported()Z": {ParamSlots: 0, GFunction: ghelpers.ReturnFalse},
+		"read()I":          {ParamSlots: 0, GFunction: zipStreamRead},
+		"read([B)I":        {ParamSlots: 1, GFunction: zipStreamReadBytes},
+		"read([BII)I":      {ParamSlots: 3, GFunction: zipStreamReadBytes},
+		"skip(J)J":         {ParamSlots: 1, GFunction: zipStreamSkip},
+	} {
+		ghelpers.MethodSignatures[className+"."+sig] = gmeth
+	}
+}
+
+// getZipStream returns the zipStream of the input stream obj, which must be open.
+func getZipStream(obj *object.Object, caller string) (*zipStream, *ghelpers.GErrBlk) {
+	holder, ok := obj.FieldTable[zipStateField].Fvalue.(zipStreamHolder)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, caller+": stream is not initialized")
+	}
+	zs := holder.stream()
+	if zs.closed {
+		return nil, ghelpers.GetGErrBlk(excNames.IOException, "Stream closed")
+	}
+	return zs, nil
+}
+
+// read reads up to len(p) bytes into p. It returns -1 at the end of the data.
+func (zs *zipStream) read(p []byte, caller string) (int, *ghelpers.GErrBlk) {
+	if zs.r == nil || zs.eof {
+		return -1, nil
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		n, err := zs.r.Read(p)
+		if err == io.EOF {
+			zs.eof = true
+			if n == 0 {
+				return -1, nil
+			}
+			return n, nil
+		}
+		if err != nil {
+			return 0, zipReadError(err, caller)
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// java/util/zip/GZIPInputStream.read()I and its peers
+func zipStreamRead(params []interface{}) interface{} {
+	zs, gerr := getZipStream(params[0].(*object.Object), "read")
+	if gerr != nil {
+		return gerr
+	}
+	var b [1]byte
+	n, gerr := zs.read(b[:], "read")
+	if gerr != nil {
+		return gerr
+	}
+	if n < 0 {
+		return int64(-1)
+	}
+	return int64(b[0])
+}
+
+// java/util/zip/GZIPInputStream.read([B)I and read([BII)I and their peers
+func zipStreamReadBytes(params []interface{}) interface{} {
+	zs, gerr := getZipStream(params[0].(*object.Object), "read")
+	if gerr != nil {
+		return gerr
+	}
+	jbytes, off, length, gerr := byteArrayRange(params[1:], "read")
+	if gerr != nil {
+		return gerr
+	}
+	buf := make([]byte, length)
+	n, gerr := zs.read(buf, "read")
+	if gerr != nil {
+		return gerr
+	}
+	if n > 0 {
+		copy(jbytes[off:], object.JavaByteArrayFromGoByteArray(buf[:n]))
+	}
+	return int64(n)
+}
+
+// java/util/zip/GZIPInputStream.skip(J)J and its peers
+func zipStreamSkip(params []interface{}) interface{} {
+	zs, gerr := getZipStream(params[0].(*object.Object), "skip")
+	if gerr != nil {
+		return gerr
+	}
+	n := params[1].(int64)
+	if n < 0 {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "negative skip length")
+	}
+	buf := make([]byte, min(n, 8192))
+	var skipped int64
+	for skipped < n {
+		nr, gerr := zs.read(buf[:min(n-skipped, int64(len(buf)))], "skip")
+		if gerr != nil {
+			return gerr
+		}
+		if nr < 0 {
+			break
+		}
+		skipped += int64(nr)
+	}
+	return skipped
+}
+
+// java/util/zip/GZIPInputStream.available()I and its peers -- 0 at the end of the data, else 1
+func zipStreamAvailable(params []interface{}) interface{} {
+	zs, gerr := getZipStream(params[0].(*object.Object), "available")
+	if gerr != nil {
+		return gerr
+	}
+	if zs.r == nil || zs.eof {
+		return int64(0)
+	}
+	return int64(1)
+}
+
+// java/util/zip/GZIPInputStream.close()V and its peers -- closing twice has no effect
+func zipStreamClose(params []interface{}) interface{} {
+	holder, ok := params[0].(*object.Object).FieldTable[zipStateField].Fvalue.(zipStreamHolder)
+	if !ok {
+		return nil
+	}
+	zs := holder.stream()
+	if zs.closed {
+		return nil
+	}
+	zs.closed = true
+	if zs.close != nil {
+		return zs.close()
+	}
+	return nil
+}
+
+// byteArrayRange returns the byte array and the offset and length of the range of it that
+// the arguments ([B) or ([BII) of a read or write method select.
+func byteArrayRange(args []interface{}, caller string) ([]types.JavaByte, int64, int64, *ghelpers.GErrBlk) {
+	arr, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(arr) {
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.NullPointerException, caller+": byte array is null")
+	}
+	jbytes, _ := arr.FieldTable["value"].Fvalue.([]types.JavaByte)
+	off, length := int64(0), int64(len(jbytes))
+	if len(args) >= 3 {
+		off, length = args[1].(int64), args[2].(int64)
+	}
+	if off < 0 || length < 0 || off+length > int64(len(jbytes)) {
+		errMsg := fmt.Sprintf("%s: offset %d, length %d, array length %d", caller, off, length, len(jbytes))
+		return nil, 0, 0, ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException, errMsg)
+	}
+	return jbytes, off, length, nil
+}
+
+// zipReadError converts an error from a Go decompressor to the exception that the JDK throws.
+func zipReadError(err error, caller string) *ghelpers.GErrBlk {
+	var corrupt flate.CorruptInputError
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return ghelpers.GetGErrBlk(excNames.EOFException, "Unexpected end of ZLIB input stream")
+	case errors.Is(err, gzip.ErrHeader):
+		return ghelpers.GetGErrBlk(excNames.ZipException, "Not in GZIP format")
+	case errors.Is(err, gzip.ErrChecksum):
+		return ghelpers.GetGErrBlk(excNames.ZipException, "Corrupt GZIP trailer")
+	case errors.Is(err, zlib.ErrChecksum):
+		return ghelpers.GetGErrBlk(excNames.ZipException, "incorrect data check")
+	case errors.Is(err, zip.ErrChecksum):
+		return ghelpers.GetGErrBlk(excNames.ZipException, "invalid entry CRC")
+	case errors.As(err, &corrupt):
+		return ghelpers.GetGErrBlk(excNames.ZipException, "invalid deflate data: "+err.Error())
+	}
+	var gerr *zipError
+	if errors.As(err, &gerr) {
+		return gerr.gerr
+	}
+	return ghelpers.GetGErrBlk(excNames.IOException, fmt.Sprintf("%s: %s", caller, err.Error()))
+}
+
+// zipError carries an exception out of a Go reader, such as a ZipInputStream entry reader
+// that finds a bad CRC in a data descriptor.
+type zipError struct {
+	gerr *ghelpers.GErrBlk
+}
+
+func (e *zipError) Error() string { return e.gerr.ErrMsg }
+
+// closeJavaStream calls the close method of the Java stream obj on the frame stack fs.
+func closeJavaStream(fs *list.List, obj *object.Object) interface{} {
+	if obj == nil || object.IsNull(obj) {
+		return nil
+	}
+	if _, clName := ghelpers.FindInstanceMethod(obj, "close", "()V"); clName == "" {
+		return nil
+	}
+	return ghelpers.InvokeMethodOnObject(fs, obj, "close", "()V")
+}
+
+// flushJavaWriter flushes w, which GoWriterWithContext returned, if it can be flushed.
+func flushJavaWriter(w io.Writer) *ghelpers.GErrBlk {
+	if f, ok := w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return ghelpers.GetGErrBlk(excNames.IOException, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package javaUtil
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"hash/crc32"
+	"io"
+	"jacobin/src/excNames"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readAllZipStream reads what remains of a stream that uses the zipStream read methods.
+func readAllZipStream(t *testing.T, stream *object.Object) []byte {
+	t.Helper()
+	var out []byte
+	buf := makeByteArrayObject(make([]byte, 7))
+	for {
+		ret := zipStreamReadBytes([]interface{}{stream, buf})
+		n, ok := ret.(int64)
+		if !ok {
+			t.Fatalf("read failed: %v", ret)
+		}
+		if n < 0 {
+			return out
+		}
+		out = append(out, object.GoByteArrayFromJavaByteArray(getJavaBytesFromArrayObject(buf))[:n]...)
+	}
+}
+
+func TestDeflaterInflater_RoundTrip(t *testing.T) {
+	globals.InitStringPool()
+	data := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog. ", 50))
+
+	for _, nowrap := range []types.JavaBool{types.JavaBoolFalse, types.JavaBoolTrue} {
+		def := object.MakeEmptyObjectWithClassName(new("java/util/zip/Deflater"))
+		if ret := deflaterInit([]interface{}{def, int64(9), nowrap}); ret != nil {
+			t.Fatalf("deflaterInit: %v", ret)
+		}
+		deflaterSetInput([]interface{}{def, makeByteArrayObject(data)})
+		deflaterFinish([]interface{}{def})
+		var compressed []byte
+		buf := makeByteArrayObject(make([]byte, 64))
+		for deflaterFinished([]interface{}{def}) != types.JavaBoolTrue {
+			n := deflaterDeflate([]interface{}{def, buf}).(int64)
+			compressed = append(compressed, object.GoByteArrayFromJavaByteArray(getJavaBytesFromArrayObject(buf))[:n]...)
+		}
+		if len(compressed) >= len(data) {
+			t.Fatalf("expected compression, got %d bytes from %d", len(compressed), len(data))
+		}
+		if nowrap == types.JavaBoolFalse && (compressed[0] != 0x78 || compressed[1] != 0xda) {
+			t.Fatalf("unexpected zlib header % x", compressed[:2])
+		}
+		if got := deflaterGetTotalIn([]interface{}{def}).(int64); got != int64(len(data)) {
+			t.Fatalf("getTotalIn: expected %d, got %d", len(data), got)
+		}
+
+		inf := object.MakeEmptyObjectWithClassName(new("java/util/zip/Inflater"))
+		if ret := inflaterInit([]interface{}{inf, nowrap}); ret != nil {
+			t.Fatalf("inflaterInit: %v", ret)
+		}
+		inflaterSetInput([]interface{}{inf, makeByteArrayObject(compressed)})
+		var inflated []byte
+		out := makeByteArrayObject(make([]byte, 100))
+		for inflaterFinished([]interface{}{inf}) != types.JavaBoolTrue {
+			ret := inflaterInflate([]interface{}{inf, out})
+			n, ok := ret.(int64)
+			if !ok {
+				t.Fatalf("inflate: %v", ret)
+			}
+			inflated = append(inflated, object.GoByteArrayFromJavaByteArray(getJavaBytesFromArrayObject(out))[:n]...)
+		}
+		if !bytes.Equal(inflated, data) {
+			t.Fatalf("round trip mismatch (nowrap=%v)", nowrap)
+		}
+	}
+}
+
+func TestDeflater_InvalidLevel(t *testing.T) {
+	globals.InitStringPool()
+	def := object.MakeEmptyObjectWithClassName(new("java/util/zip/Deflater"))
+	testutil.ExpectGErr(t, deflaterInit([]interface{}{def, int64(10)}),
+		excNames.IllegalArgumentException, "invalid compression level")
+}
+
+func TestInflater_BadHeader(t *testing.T) {
+	globals.InitStringPool()
+	inf := object.MakeEmptyObjectWithClassName(new("java/util/zip/Inflater"))
+	inflaterInit([]interface{}{inf})
+	inflaterSetInput([]interface{}{inf, makeByteArrayObject([]byte{1, 2, 3, 4})})
+	testutil.ExpectGErr(t, inflaterInflate([]interface{}{inf, makeByteArrayObject(make([]byte, 10))}),
+		excNames.DataFormatException, "incorrect header check")
+}
+
+func TestGZIP_RoundTrip(t *testing.T) {
+	globals.InitStringPool()
+	data := []byte("id,name\n1,alpha\n2,beta\n")
+
+	var sink bytes.Buffer
+	gzOut := object.MakeEmptyObjectWithClassName(new("java/util/zip/GZIPOutputStream"))
+	if ret := gzipOutputStreamInit([]interface{}{gzOut, &sink}); ret != nil {
+		t.Fatalf("gzipOutputStreamInit: %v", ret)
+	}
+	gzipOutputStreamWrite([]interface{}{gzOut, makeByteArrayObject(data[:5])})
+	gzipOutputStreamWrite([]interface{}{gzOut, makeByteArrayObject(data), int64(5), int64(len(data) - 5)})
+	if ret := gzipOutputStreamClose([]interface{}{gzOut}); ret != nil {
+		t.Fatalf("close: %v", ret)
+	}
+	wantHeader := []byte{0x1f, 0x8b, 8, 0, 0, 0, 0, 0, 0, 0xff}
+	if !bytes.HasPrefix(sink.Bytes(), wantHeader) {
+		t.Fatalf("unexpected gzip header % x", sink.Bytes()[:10])
+	}
+	testutil.ExpectGErr(t, gzipOutputStreamWrite([]interface{}{gzOut, int64('x')}), excNames.IOException, "")
+
+	// Go can read it
+	gr, err := gzip.NewReader(bytes.NewReader(sink.Bytes()))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	if got, _ := io.ReadAll(gr); !bytes.Equal(got, data) {
+		t.Fatalf("Go read %q", got)
+	}
+
+	// and so can GZIPInputStream
+	gzIn := object.MakeEmptyObjectWithClassName(new("java/util/zip/GZIPInputStream"))
+	if ret := gzipInputStreamInit([]interface{}{gzIn, bytes.NewReader(sink.Bytes())}); ret != nil {
+		t.Fatalf("gzipInputStreamInit: %v", ret)
+	}
+	if got := readAllZipStream(t, gzIn); !bytes.Equal(got, data) {
+		t.Fatalf("GZIPInputStream read %q", got)
+	}
+	zipStreamClose([]interface{}{gzIn})
+	testutil.ExpectGErr(t, zipStreamRead([]interface{}{gzIn}), excNames.IOException, "Stream closed")
+}
+
+func TestGZIPInputStream_NotGzip(t *testing.T) {
+	globals.InitStringPool()
+	gzIn := object.MakeEmptyObjectWithClassName(new("java/util/zip/GZIPInputStream"))
+	testutil.ExpectGErr(t, gzipInputStreamInit([]interface{}{gzIn, bytes.NewReader([]byte("plain text here"))}),
+		excNames.ZipException, "Not in GZIP format")
+}
+
+// writeTestZip writes a zip with a DEFLATED and a STORED entry through ZipOutputStream.
+func writeTestZip(t *testing.T, w io.Writer) {
+	t.Helper()
+	zo := object.MakeEmptyObjectWithClassName(new("java/util/zip/ZipOutputStream"))
+	if ret := zipOutputStreamInit([]interface{}{zo, w}); ret != nil {
+		t.Fatalf("zipOutputStreamInit: %v", ret)
+	}
+
+	e1 := newZipEntry(zipEntryClassName, "dir/a.txt")
+	if ret := zipOutputStreamPutNextEntry([]interface{}{zo, e1}); ret != nil {
+		t.Fatalf("putNextEntry: %v", ret)
+	}
+	zipOutputStreamWrite([]interface{}{zo, makeByteArrayObject([]byte("hello, deflated world"))})
+	zipOutputStreamCloseEntry([]interface{}{zo})
+
+	stored := []byte("stored bytes")
+	e2 := newZipEntry(zipEntryClassName, "b.bin")
+	zipEntrySetMethod([]interface{}{e2, int64(zipStored)})
+	testutil.ExpectGErr(t, zipOutputStreamPutNextEntry([]interface{}{zo, e2}),
+		excNames.ZipException, "STORED entry missing size, compressed size, or crc-32")
+	zipEntrySetSize([]interface{}{e2, int64(len(stored))})
+	zipEntrySetCompressedSize([]interface{}{e2, int64(len(stored))})
+	zipEntrySetCrc([]interface{}{e2, int64(crc32.ChecksumIEEE(stored))})
+	if ret := zipOutputStreamPutNextEntry([]interface{}{zo, e2}); ret != nil {
+		t.Fatalf("putNextEntry stored: %v", ret)
+	}
+	zipOutputStreamWrite([]interface{}{zo, makeByteArrayObject(stored)})
+
+	testutil.ExpectGErr(t, zipOutputStreamPutNextEntry([]interface{}{zo, newZipEntry(zipEntryClassName, "b.bin")}),
+		excNames.ZipException, "duplicate entry: b.bin")
+
+	zipOutputStreamSetComment([]interface{}{zo, object.StringObjectFromGoString("bundle")})
+	if ret := zipOutputStreamClose([]interface{}{zo}); ret != nil {
+		t.Fatalf("close: %v", ret)
+	}
+}
+
+func TestZipStreams_RoundTrip(t *testing.T) {
+	globals.InitStringPool()
+	var sink bytes.Buffer
+	writeTestZip(t, &sink)
+
+	// Go's archive/zip can read what ZipOutputStream wrote
+	zr, err := zip.NewReader(bytes.NewReader(sink.Bytes()), int64(sink.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	if len(zr.File) != 2 || zr.Comment != "bundle" || zr.File[1].Method != zip.Store {
+		t.Fatalf("unexpected archive: %d files, comment %q", len(zr.File), zr.Comment)
+	}
+
+	zi := object.MakeEmptyObjectWithClassName(new(zipInputStreamClassName))
+	if ret := zipInputStreamInit([]interface{}{zi, bytes.NewReader(sink.Bytes())}); ret != nil {
+		t.Fatalf("zipInputStreamInit: %v", ret)
+	}
+	want := map[string]string{"dir/a.txt": "hello, deflated world", "b.bin": "stored bytes"}
+	count := 0
+	for {
+		ret := zipInputStreamGetNextEntry([]interface{}{zi})
+		entry, ok := ret.(*object.Object)
+		if !ok {
+			t.Fatalf("getNextEntry: %v", ret)
+		}
+		if object.IsNull(entry) {
+			break
+		}
+		name := entryName(entry)
+		if got := string(readAllZipStream(t, zi)); got != want[name] {
+			t.Fatalf("entry %s: read %q", name, got)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 entries, got %d", count)
+	}
+}
+
+func TestZipFile_RandomAccess(t *testing.T) {
+	globals.InitStringPool()
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestZip(t, f)
+	f.Close()
+
+	zf := object.MakeEmptyObjectWithClassName(new(zipFileClassName))
+	if ret := zipFileInit([]interface{}{zf, object.StringObjectFromGoString(path)}); ret != nil {
+		t.Fatalf("zipFileInit: %v", ret)
+	}
+	if n := zipFileSize([]interface{}{zf}).(int64); n != 2 {
+		t.Fatalf("size: expected 2, got %d", n)
+	}
+	if c := object.GoStringFromStringObject(zipFileGetComment([]interface{}{zf}).(*object.Object)); c != "bundle" {
+		t.Fatalf("comment: got %q", c)
+	}
+	entry := zipFileGetEntry([]interface{}{zf, object.StringObjectFromGoString("b.bin")}).(*object.Object)
+	if entryLong(entry, zipEntrySize) != int64(len("stored bytes")) {
+		t.Fatalf("unexpected size %d", entryLong(entry, zipEntrySize))
+	}
+	stream := zipFileGetInputStream([]interface{}{zf, entry}).(*object.Object)
+	if got := string(readAllZipStream(t, stream)); got != "stored bytes" {
+		t.Fatalf("read %q", got)
+	}
+	if ret := zipFileGetEntry([]interface{}{zf, object.StringObjectFromGoString("missing")}); ret != object.Null {
+		t.Fatalf("expected null for a missing entry, got %v", ret)
+	}
+
+	zipFileClose([]interface{}{zf})
+	testutil.ExpectGErr(t, zipFileSize([]interface{}{zf}), excNames.IllegalStateException, "zip file closed")
+
+	missing := object.MakeEmptyObjectWithClassName(new(zipFileClassName))
+	testutil.ExpectGErr(t, zipFileInit([]interface{}{missing, object.StringObjectFromGoString(path + ".none")}),
+		excNames.NoSuchFileException, ".none")
+}
+
+func TestManifest_ReadWrite(t *testing.T) {
+	globals.InitStringPool()
+	src := "Manifest-Version: 1.0\r\nMain-Class: com.example.Main\r\nCreated-By: test\r\n" +
+		" continued\r\n\r\nName: com/example/\r\nSealed: true\r\n\r\n"
+	mf := object.MakeEmptyObjectWithClassName(new(manifestClassName))
+	if ret := manifestInitRead([]interface{}{mf, strings.NewReader(src)}); ret != nil {
+		t.Fatalf("manifestInitRead: %v", ret)
+	}
+	main := manifestGetMainAttributes([]interface{}{mf}).(*object.Object)
+	got := attributesGetValue([]interface{}{main, object.StringObjectFromGoString("created-by")})
+	if object.GoStringFromStringObject(got.(*object.Object)) != "testcontinued" {
+		t.Fatalf("continuation not joined: %v", got)
+	}
+	section := manifestGetAttributes([]interface{}{mf, object.StringObjectFromGoString("com/example/")})
+	if attributesSize([]interface{}{section}).(int64) != 1 {
+		t.Fatalf("expected one attribute in the section")
+	}
+
+	long := strings.Repeat("x", 100)
+	attributesPut([]interface{}{main, object.StringObjectFromGoString("Class-Path"), object.StringObjectFromGoString(long)})
+	var out bytes.Buffer
+	if ret := manifestWrite([]interface{}{mf, &out}); ret != nil {
+		t.Fatalf("manifestWrite: %v", ret)
+	}
+	lines := strings.Split(out.String(), "\r\n")
+	if lines[0] != "Manifest-Version: 1.0" {
+		t.Fatalf("Manifest-Version must come first, got %q", lines[0])
+	}
+	for _, line := range lines {
+		if len(line) > 72 {
+			t.Fatalf("line longer than 72 bytes: %q", line)
+		}
+	}
+
+	// what was written reads back the same
+	copyMf := object.MakeEmptyObjectWithClassName(new(manifestClassName))
+	manifestInitRead([]interface{}{copyMf, bytes.NewReader(out.Bytes())})
+	copyMain := manifestGetMainAttributes([]interface{}{copyMf}).(*object.Object)
+	cp := attributesGetValue([]interface{}{copyMain, object.StringObjectFromGoString("Class-Path")})
+	if object.GoStringFromStringObject(cp.(*object.Object)) != long {
+		t.Fatalf("Class-Path did not survive the round trip")
+	}
+}
+
+func TestManifest_BadInput(t *testing.T) {
+	globals.InitStringPool()
+	mf := object.MakeEmptyObjectWithClassName(new(manifestClassName))
+	testutil.ExpectGErr(t, manifestInitRead([]interface{}{mf, strings.NewReader(" leading\r\n")}),
+		excNames.IOException, "misplaced continuation line")
+	mf = object.MakeEmptyObjectWithClassName(new(manifestClassName))
+	testutil.ExpectGErr(t, manifestInitRead([]interface{}{mf, strings.NewReader("NoColon\r\n")}),
+		excNames.IOException, "invalid header field")
+
+	name := object.MakeEmptyObjectWithClassName(new(attributesNameClassName))
+	testutil.ExpectGErr(t, attributesNameInit([]interface{}{name, object.StringObjectFromGoString("bad name")}),
+		excNames.IllegalArgumentException, "bad name")
+}
@@ -260,6 +260,58 @@ func Load_Crypto_Cipher() {
 			ParamSlots: 1,
 			GFunction:  ghelpers.TrapFunction,
 		}
+
+	// Ciphers of providers written in Java run on their CipherSpi.
+	javaSecurity.InstallSpiDispatch(javaSecurity.SpiEngine{
+		ClassName:   types.ClassNameCipher,
+		ServiceType: "Cipher",
+		Methods:     cipherSpiMethods(),
+	})
+}
+
+// cipherSpiMethods returns how the methods of a Cipher run on a CipherSpi.
+func cipherSpiMethods() map[string]javaSecurity.SpiFunc {
+	const (
+		initRandom = "(ILjava/security/Key;Ljava/security/SecureRandom;)V"
+		initSpec   = "(ILjava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;Ljava/security/SecureRandom;)V"
+		initParams = "(ILjava/security/Key;Ljava/security/AlgorithmParameters;Ljava/security/SecureRandom;)V"
+	)
+	call := javaSecurity.SpiCall
+	whole := javaSecurity.SpiWholeArray
+	noInput := javaSecurity.SpiPrependArgs(object.Null, int64(0), int64(0))
+	noRandom := javaSecurity.SpiAppendArgs(object.Null)
+	atStart := javaSecurity.SpiAppendArgs(int64(0))
+
+	return map[string]javaSecurity.SpiFunc{
+		"doFinal()[B":       call("engineDoFinal", "([BII)[B", noInput),
+		"doFinal([B)[B":     call("engineDoFinal", "([BII)[B", whole),
+		"doFinal([BI)I":     call("engineDoFinal", "([BII[BI)I", noInput),
+		"doFinal([BII)[B":   call("engineDoFinal", "([BII)[B"),
+		"doFinal([BII[B)I":  call("engineDoFinal", "([BII[BI)I", atStart),
+		"doFinal([BII[BI)I": call("engineDoFinal", "([BII[BI)I"),
+		"getBlockSize()I":   call("engineGetBlockSize", "()I"),
+		"getIV()[B":         call("engineGetIV", "()[B"),
+		"getOutputSize(I)I": call("engineGetOutputSize", "(I)I"),
+		"getParameters()Ljava/security/AlgorithmParameters;": call("engineGetParameters",
+			"()Ljava/security/AlgorithmParameters;"),
+		"init(ILjava/security/Key;)V":                                            call("engineInit", initRandom, noRandom),
+		"init(ILjava/security/Key;Ljava/security/SecureRandom;)V":                call("engineInit", initRandom),
+		"init(ILjava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;)V": call("engineInit", initSpec, noRandom),
+		"init(ILjava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;Ljava/security/SecureRandom;)V": call(
+			"engineInit", initSpec),
+		"init(ILjava/security/Key;Ljava/security/AlgorithmParameters;)V": call("engineInit", initParams, noRandom),
+		"init(ILjava/security/Key;Ljava/security/AlgorithmParameters;Ljava/security/SecureRandom;)V": call(
+			"engineInit", initParams),
+		"unwrap([BLjava/lang/String;I)Ljava/security/Key;": call("engineUnwrap",
+			"([BLjava/lang/String;I)Ljava/security/Key;"),
+		"update([B)[B":                call("engineUpdate", "([BII)[B", whole),
+		"update([BII)[B":              call("engineUpdate", "([BII)[B"),
+		"update([BII[B)I":             call("engineUpdate", "([BII[BI)I", atStart),
+		"update([BII[BI)I":            call("engineUpdate", "([BII[BI)I"),
+		"updateAAD([B)V":              call("engineUpdateAAD", "([BII)V", whole),
+		"updateAAD([BII)V":            call("engineUpdateAAD", "([BII)V"),
+		"wrap(Ljava/security/Key;)[B": call("engineWrap", "(Ljava/security/Key;)[B"),
+	}
 }
 
 func cipherClinit(params []any) any {
@@ -897,6 +949,13 @@ func handleInitSpec(self *object.Object, spec *object.Object, ivProvided *bool)
 			self.FieldTable["iv"] = saltField // Default: use salt as IV. May be overwritten by derived IV later.
 			*ivProvided = true
 		}
+		// PBEWithHmac*AndAES_* ciphers take their IV from the IvParameterSpec inside the PBEParameterSpec
+		if paramSpec, ok := spec.FieldTable["paramSpec"].Fvalue.(*object.Object); ok && !object.IsNull(paramSpec) {
+			if ivField, ok := paramSpec.FieldTable["iv"]; ok {
+				self.FieldTable["iv"] = ivField
+				*ivProvided = true
+			}
+		}
 
 		// Perform PBE key derivation if the key is not already derived
 		keyField, ok := self.FieldTable["key"]
@@ -909,22 +968,27 @@ func handleInitSpec(self *object.Object, spec *object.Object, ivProvided *bool)
 				// For PBKDF2-based PBE, we need salt and iterations.
 				iterations := spec.FieldTable["iterationCount"].Fvalue.(int64)
 
-				// Re-derive the key only if it's not already derived.
-				// A derived key will have a "key" field in its FieldTable.
-				if _, ok := keyObj.FieldTable["key"]; !ok {
+				// A derived key will have a "key" field in its FieldTable. Keys from SecretKeyFactory
+				// also keep their password, so they are derived afresh from the salt and iteration count
+				// of this spec, as the JDK does; other keys are derived only if they are not already.
+				passwordCharsObj, hasPassword := keyObj.FieldTable["password"].Fvalue.(*object.Object)
+				_, derived := keyObj.FieldTable["key"]
+				if hasPassword || !derived {
 					// Create a PBEKeySpec from the existing password and the new salt/iterations
-					var passwordBytes []byte
-					switch v := keyObj.FieldTable["value"].Fvalue.(type) {
-					case []types.JavaByte:
-						passwordBytes = object.GoByteArrayFromJavaByteArray(v)
-					case []byte:
-						passwordBytes = v
-					}
-					passwordChars := make([]int64, len(passwordBytes))
-					for i, b := range passwordBytes {
-						passwordChars[i] = int64(b)
+					if !hasPassword {
+						var passwordBytes []byte
+						switch v := keyObj.FieldTable["value"].Fvalue.(type) {
+						case []types.JavaByte:
+							passwordBytes = object.GoByteArrayFromJavaByteArray(v)
+						case []byte:
+							passwordBytes = v
+						}
+						passwordChars := make([]int64, len(passwordBytes))
+						for i, b := range passwordBytes {
+							passwordChars[i] = int64(b)
+						}
+						passwordCharsObj = object.MakePrimitiveObject(types.CharArray, types.CharArray, passwordChars)
 					}
-					passwordCharsObj := object.MakePrimitiveObject(types.CharArray, types.CharArray, passwordChars)
 
 					pbeKeySpecClassName := "javax/crypto/spec/PBEKeySpec"
 					pbeKeySpec := object.MakeEmptyObjectWithClassName(&pbeKeySpecClassName)
@@ -955,7 +1019,7 @@ func handleInitSpec(self *object.Object, spec *object.Object, ivProvided *bool)
 					if derivedKeyObj, ok := derivedKey.(*object.Object); ok {
 						self.FieldTable["key"] = object.Field{Ftype: "java/security/Key", Fvalue: derivedKeyObj}
 						// Store the password in the cipher for potential reuse later (in case of another init with underived key)
-						if pwVal, ok := keyObj.FieldTable["value"]; ok {
+						if pwVal, ok := keyObj.FieldTable["value"]; ok && !derived {
 							self.FieldTable["pbe_password"] = pwVal
 						}
 
@@ -7,7 +7,23 @@
 package javaxCrypto
 
 import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hkdf"
+	"crypto/mlkem"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/types"
 )
 
 func Load_Crypto_KEM() {
@@ -22,141 +38,634 @@ func Load_Crypto_KEM() {
 	ghelpers.MethodSignatures["javax/crypto/KEM.getInstance(Ljava/lang/String;)Ljavax/crypto/KEM;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/crypto/KEM;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljavax/crypto/KEM;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.getAlgorithm()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemGetAlgorithm,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.getProvider()Ljava/security/Provider;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemGetProvider,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.newDecapsulator(Ljava/security/PrivateKey;)Ljavax/crypto/KEM$Decapsulator;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemNewDecapsulator,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.newDecapsulator(Ljava/security/PrivateKey;Ljava/security/spec/AlgorithmParameterSpec;)Ljavax/crypto/KEM$Decapsulator;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemNewDecapsulator,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.newEncapsulator(Ljava/security/PublicKey;)Ljavax/crypto/KEM$Encapsulator;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemNewEncapsulator,
+		}
+
+	ghelpers.MethodSignatures["javax/crypto/KEM.newEncapsulator(Ljava/security/PublicKey;Ljava/security/SecureRandom;)Ljavax/crypto/KEM$Encapsulator;"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  kemNewEncapsulatorWithRandom,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM.newEncapsulator(Ljava/security/PublicKey;Ljava/security/spec/AlgorithmParameterSpec;Ljava/security/SecureRandom;)Ljavax/crypto/KEM$Encapsulator;"] =
 		ghelpers.GMeth{
 			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemNewEncapsulator,
 		}
 
 	// KEM$Encapsulated class
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulated.<init>(Ljavax/crypto/SecretKey;[B[B)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulatedInit,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulated.encapsulation()[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulatedEncapsulation,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulated.key()Ljavax/crypto/SecretKey;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulatedKey,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulated.params()[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulatedParams,
 		}
 
 	// KEM$Encapsulator class
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.encapsulate()Ljavax/crypto/KEM$Encapsulated;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulate,
 		}
 
-	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.encapsulate(IIILjava/lang/String;)Ljavax/crypto/KEM$Encapsulated;"] =
+	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.encapsulate(IILjava/lang/String;)Ljavax/crypto/KEM$Encapsulated;"] =
 		ghelpers.GMeth{
-			ParamSlots: 4,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots: 3,
+			GFunction:  kemEncapsulate,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.encapsulationSize()I"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulationSize,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.providerName()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemProviderName,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Encapsulator.secretSize()I"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemSecretSize,
 		}
 
 	// KEM$Decapsulator class
 	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.decapsulate([B)Ljavax/crypto/SecretKey;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemDecapsulate,
 		}
 
-	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.decapsulate([BIIILjava/lang/String;)Ljavax/crypto/SecretKey;"] =
+	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.decapsulate([BIILjava/lang/String;)Ljavax/crypto/SecretKey;"] =
 		ghelpers.GMeth{
-			ParamSlots: 5,
-			GFunction:  ghelpers.TrapFunction,
+			ParamSlots: 4,
+			GFunction:  kemDecapsulate,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.encapsulationSize()I"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemEncapsulationSize,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.providerName()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemProviderName,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/KEM$Decapsulator.secretSize()I"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  kemSecretSize,
+		}
+}
+
+var (
+	classNameKEM             = "javax/crypto/KEM"
+	classNameKEMEncapsulator = "javax/crypto/KEM$Encapsulator"
+	classNameKEMDecapsulator = "javax/crypto/KEM$Decapsulator"
+	classNameKEMEncapsulated = "javax/crypto/KEM$Encapsulated"
+)
+
+// kemAlgorithms are the KEMs supported. DHKEM is that of RFC 9180 over the curve of its key;
+// ML-KEM is that of FIPS 203 over the parameter set of its key, unless the algorithm names one.
+// ML-KEM-512 is not in crypto/mlkem.
+var kemAlgorithms = []string{"DHKEM", "ML-KEM", "ML-KEM-768", "ML-KEM-1024"}
+
+// kemState is the Go state of an Encapsulator or a Decapsulator. Only one of encapsulate and
+// decapsulate is set.
+type kemState struct {
+	secretSize        int
+	encapsulationSize int
+	encapsulate       func() (secret, encapsulation []byte, err error)
+	decapsulate       func(encapsulation []byte) (secret []byte, err error)
+}
+
+func kemGetInstance(params []any) any {
+	algorithmObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(algorithmObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null algorithm name")
+	}
+	algorithm := object.GoStringFromStringObject(algorithmObj)
+
+	index := slices.IndexFunc(kemAlgorithms, func(name string) bool { return strings.EqualFold(name, algorithm) })
+	if index < 0 {
+		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+			fmt.Sprintf("%s KEM not available", algorithm))
+	}
+
+	// Check provider parameter if provided
+	if len(params) > 1 {
+		if pObj, ok := params[1].(*object.Object); ok && !object.IsNull(pObj) {
+			var pName string
+			if object.IsStringObject(pObj) {
+				pName = object.GoStringFromStringObject(pObj)
+			} else if nameObj, ok := pObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+				pName = object.GoStringFromStringObject(nameObj)
+			}
+			if pName != "" && pName != types.SecurityProviderName {
+				return ghelpers.GetGErrBlk(excNames.ProviderNotFoundException,
+					fmt.Sprintf("kemGetInstance: provider %s not found", pName))
+			}
+		}
+	}
+
+	kem := object.MakeEmptyObjectWithClassName(&classNameKEM)
+	kem.FieldTable["algorithm"] = object.Field{
+		Ftype:  types.StringClassName,
+		Fvalue: object.StringObjectFromGoString(kemAlgorithms[index]),
+	}
+	kem.FieldTable["provider"] = object.Field{
+		Ftype:  types.ClassNameSecurityProvider,
+		Fvalue: ghelpers.GetDefaultSecurityProvider(),
+	}
+	return kem
+}
+
+func kemGetAlgorithm(params []any) any {
+	self := params[0].(*object.Object)
+	return self.FieldTable["algorithm"].Fvalue
+}
+
+func kemGetProvider(params []any) any {
+	self := params[0].(*object.Object)
+	return self.FieldTable["provider"].Fvalue
+}
+
+// kemNewEncapsulator handles newEncapsulator(PublicKey) and
+// newEncapsulator(PublicKey, AlgorithmParameterSpec, SecureRandom). Neither KEM takes parameters.
+// The SecureRandom is not used: the ephemeral keys and the ML-KEM randomness come from
+// crypto/rand.
+func kemNewEncapsulator(params []any) any {
+	self := params[0].(*object.Object)
+	if len(params) > 2 && !object.IsNull(params[2]) {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "no spec needed")
+	}
+
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "input key is null")
+	}
+
+	var state *kemState
+	var gerr *ghelpers.GErrBlk
+	algorithm := object.GoStringFromStringObject(self.FieldTable["algorithm"].Fvalue.(*object.Object))
+	if algorithm == "DHKEM" {
+		state, gerr = dhkemEncapsulator(keyObj)
+	} else {
+		state, gerr = mlkemEncapsulator(algorithm, keyObj)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	return newKEMStateObject(classNameKEMEncapsulator, self, state)
+}
+
+// kemNewEncapsulatorWithRandom handles newEncapsulator(PublicKey, SecureRandom).
+func kemNewEncapsulatorWithRandom(params []any) any {
+	return kemNewEncapsulator([]any{params[0], params[1], object.Null, params[2]})
+}
+
+// kemNewDecapsulator handles newDecapsulator(PrivateKey) and
+// newDecapsulator(PrivateKey, AlgorithmParameterSpec).
+func kemNewDecapsulator(params []any) any {
+	self := params[0].(*object.Object)
+	if len(params) > 2 && !object.IsNull(params[2]) {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "no spec needed")
+	}
+
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "input key is null")
+	}
+
+	var state *kemState
+	var gerr *ghelpers.GErrBlk
+	algorithm := object.GoStringFromStringObject(self.FieldTable["algorithm"].Fvalue.(*object.Object))
+	if algorithm == "DHKEM" {
+		state, gerr = dhkemDecapsulator(keyObj)
+	} else {
+		state, gerr = mlkemDecapsulator(algorithm, keyObj)
+	}
+	if gerr != nil {
+		return gerr
+	}
+	return newKEMStateObject(classNameKEMDecapsulator, self, state)
+}
+
+func newKEMStateObject(className string, kem *object.Object, state *kemState) *object.Object {
+	obj := object.MakeEmptyObjectWithClassName(&className)
+	obj.FieldTable["provider"] = kem.FieldTable["provider"]
+	obj.FieldTable["state"] = object.Field{
+		Ftype:  types.RawGoPointer,
+		Fvalue: state,
+	}
+	return obj
+}
+
+func getKEMState(self *object.Object) *kemState {
+	return self.FieldTable["state"].Fvalue.(*kemState)
+}
+
+func kemSecretSize(params []any) any {
+	return int64(getKEMState(params[0].(*object.Object)).secretSize)
+}
+
+func kemEncapsulationSize(params []any) any {
+	return int64(getKEMState(params[0].(*object.Object)).encapsulationSize)
+}
+
+func kemProviderName(params []any) any {
+	self := params[0].(*object.Object)
+	if providerObj, ok := self.FieldTable["provider"].Fvalue.(*object.Object); ok {
+		if nameObj, ok := providerObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+			return nameObj
+		}
+	}
+	return object.StringObjectFromGoString(types.SecurityProviderName)
+}
+
+// kemSecretRange returns the from, to and algorithm arguments of the range-based encapsulate
+// and decapsulate, or those of the whole secret as a "Generic" key if there are none.
+func kemSecretRange(args []any, secretSize int) (int, int, string, *ghelpers.GErrBlk) {
+	if len(args) == 0 {
+		return 0, secretSize, "Generic", nil
+	}
+	from, to := args[0].(int64), args[1].(int64)
+	if from < 0 || from > to || to > int64(secretSize) {
+		return 0, 0, "", ghelpers.GetGErrBlk(excNames.IndexOutOfBoundsException,
+			fmt.Sprintf("Range [%d, %d) out of bounds for length %d", from, to, secretSize))
+	}
+	algorithmObj, ok := args[2].(*object.Object)
+	if !ok || object.IsNull(algorithmObj) {
+		return 0, 0, "", ghelpers.GetGErrBlk(excNames.NullPointerException, "null algorithm")
+	}
+	return int(from), int(to), object.GoStringFromStringObject(algorithmObj), nil
+}
+
+// kemEncapsulate handles encapsulate() and encapsulate(int, int, String): the key of the
+// Encapsulated is the range of the shared secret given, as a SecretKey of the algorithm given.
+func kemEncapsulate(params []any) any {
+	state := getKEMState(params[0].(*object.Object))
+	from, to, algorithm, gerr := kemSecretRange(params[1:], state.secretSize)
+	if gerr != nil {
+		return gerr
+	}
+
+	secret, encapsulation, err := state.encapsulate()
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.ProviderException, "encapsulation failed: "+err.Error())
+	}
+
+	encapsulated := object.MakeEmptyObjectWithClassName(&classNameKEMEncapsulated)
+	encapsulated.FieldTable["key"] = object.Field{
+		Ftype:  types.Ref,
+		Fvalue: newRawSecretKey(algorithm, secret[from:to]),
+	}
+	encapsulated.FieldTable["encapsulation"] = object.Field{
+		Ftype:  types.JavaByteArray,
+		Fvalue: javaByteArray(encapsulation),
+	}
+	encapsulated.FieldTable["params"] = object.Field{
+		Ftype:  types.JavaByteArray,
+		Fvalue: object.Null,
+	}
+	return encapsulated
+}
+
+// kemDecapsulate handles decapsulate(byte[]) and decapsulate(byte[], int, int, String).
+func kemDecapsulate(params []any) any {
+	state := getKEMState(params[0].(*object.Object))
+	encapsulationObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(encapsulationObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null encapsulation")
+	}
+	from, to, algorithm, gerr := kemSecretRange(params[2:], state.secretSize)
+	if gerr != nil {
+		return gerr
+	}
+
+	encapsulation := object.GoByteArrayFromJavaByteArray(encapsulationObj.FieldTable["value"].Fvalue.([]types.JavaByte))
+	if len(encapsulation) != state.encapsulationSize {
+		return ghelpers.GetGErrBlk(excNames.DecapsulateException, "incorrect encapsulation size")
+	}
+	secret, err := state.decapsulate(encapsulation)
+	if err != nil {
+		return ghelpers.GetGErrBlk(excNames.DecapsulateException, "decapsulation failed: "+err.Error())
+	}
+	return newRawSecretKey(algorithm, secret[from:to])
+}
+
+// kemEncapsulatedInit is the constructor KEM.Encapsulated(SecretKey, byte[], byte[]). The
+// arrays are copied; the params may be null.
+func kemEncapsulatedInit(params []any) any {
+	self := params[0].(*object.Object)
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null key")
+	}
+	encapsulationObj, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(encapsulationObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null encapsulation")
+	}
+
+	self.FieldTable["key"] = object.Field{Ftype: types.Ref, Fvalue: keyObj}
+	self.FieldTable["encapsulation"] = object.Field{Ftype: types.JavaByteArray, Fvalue: cloneJavaByteArray(encapsulationObj)}
+	self.FieldTable["params"] = object.Field{Ftype: types.JavaByteArray, Fvalue: object.Null}
+	if paramsObj, ok := params[3].(*object.Object); ok && !object.IsNull(paramsObj) {
+		self.FieldTable["params"] = object.Field{Ftype: types.JavaByteArray, Fvalue: cloneJavaByteArray(paramsObj)}
+	}
+	return nil
+}
+
+func cloneJavaByteArray(arrayObj *object.Object) *object.Object {
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		slices.Clone(arrayObj.FieldTable["value"].Fvalue.([]types.JavaByte)))
+}
+
+func kemEncapsulatedKey(params []any) any {
+	self := params[0].(*object.Object)
+	return self.FieldTable["key"].Fvalue
+}
+
+// kemEncapsulatedEncapsulation and kemEncapsulatedParams return copies of the arrays, as the
+// JDK's accessors do.
+func kemEncapsulatedEncapsulation(params []any) any {
+	self := params[0].(*object.Object)
+	return cloneJavaByteArray(self.FieldTable["encapsulation"].Fvalue.(*object.Object))
+}
+
+func kemEncapsulatedParams(params []any) any {
+	self := params[0].(*object.Object)
+	paramsObj := self.FieldTable["params"].Fvalue.(*object.Object)
+	if object.IsNull(paramsObj) {
+		return object.Null
+	}
+	return cloneJavaByteArray(paramsObj)
+}
+
+// ---------------------------------------------------------------------------------------------
+// DHKEM (RFC 9180, section 4.1)
+// ---------------------------------------------------------------------------------------------
+
+// dhkemSuite is the DHKEM of one curve.
+type dhkemSuite struct {
+	id         uint16
+	hash       func() hash.Hash
+	secretSize int
+}
+
+func dhkemSuiteOf(curve ecdh.Curve) (dhkemSuite, bool) {
+	switch curve {
+	case ecdh.P256():
+		return dhkemSuite{id: 0x0010, hash: sha256.New, secretSize: 32}, true
+	case ecdh.P384():
+		return dhkemSuite{id: 0x0011, hash: sha512.New384, secretSize: 48}, true
+	case ecdh.P521():
+		return dhkemSuite{id: 0x0012, hash: sha512.New, secretSize: 64}, true
+	case ecdh.X25519():
+		return dhkemSuite{id: 0x0020, hash: sha256.New, secretSize: 32}, true
+	}
+	return dhkemSuite{}, false
+}
+
+// extractAndExpand derives the shared secret from the Diffie-Hellman secret and the KEM context,
+// the encapsulation followed by the recipient's public key.
+func (suite dhkemSuite) extractAndExpand(dh, kemContext []byte) ([]byte, error) {
+	suiteID := binary.BigEndian.AppendUint16([]byte("KEM"), suite.id)
+
+	labeledIKM := slices.Concat([]byte("HPKE-v1"), suiteID, []byte("eae_prk"), dh)
+	prk, err := hkdf.Extract(suite.hash, labeledIKM, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	labeledInfo := binary.BigEndian.AppendUint16(nil, uint16(suite.secretSize))
+	labeledInfo = slices.Concat(labeledInfo, []byte("HPKE-v1"), suiteID, []byte("shared_secret"), kemContext)
+	return hkdf.Expand(suite.hash, prk, string(labeledInfo), suite.secretSize)
+}
+
+// xdhKeyBytes returns the raw bytes of an X25519 key made by KeyPairGenerator.
+func xdhKeyBytes(keyObj *object.Object) ([]byte, bool) {
+	algorithmObj, ok := keyObj.FieldTable["algorithm"].Fvalue.(*object.Object)
+	if !ok {
+		return nil, false
+	}
+	if algorithm := object.GoStringFromStringObject(algorithmObj); algorithm != "X25519" && algorithm != "XDH" {
+		return nil, false
+	}
+	switch v := keyObj.FieldTable["value"].Fvalue.(type) {
+	case []byte:
+		return v, true
+	case []types.JavaByte:
+		return object.GoByteArrayFromJavaByteArray(v), true
+	}
+	return nil, false
+}
+
+// dhkemPublicKey returns the ECDH key of an EC or X25519 public key.
+func dhkemPublicKey(keyObj *object.Object) (*ecdh.PublicKey, error) {
+	switch v := keyObj.FieldTable["value"].Fvalue.(type) {
+	case *ecdsa.PublicKey:
+		if object.GoStringFromStringPoolIndex(keyObj.KlassName) == types.ClassNameECPublicKey {
+			return v.ECDH()
+		}
+	case *ecdh.PublicKey:
+		return v, nil
+	}
+	if raw, ok := xdhKeyBytes(keyObj); ok && object.GoStringFromStringPoolIndex(keyObj.KlassName) == types.ClassNameEdECPublicKey {
+		return ecdh.X25519().NewPublicKey(raw)
+	}
+	return nil, errors.New("Unsupported key")
+}
+
+// dhkemPrivateKey returns the ECDH key of an EC or X25519 private key.
+func dhkemPrivateKey(keyObj *object.Object) (*ecdh.PrivateKey, error) {
+	switch v := keyObj.FieldTable["value"].Fvalue.(type) {
+	case *ecdsa.PrivateKey:
+		return v.ECDH()
+	case *ecdh.PrivateKey:
+		return v, nil
+	}
+	if raw, ok := xdhKeyBytes(keyObj); ok && object.GoStringFromStringPoolIndex(keyObj.KlassName) == types.ClassNameEdECPrivateKey {
+		return ecdh.X25519().NewPrivateKey(raw)
+	}
+	return nil, errors.New("Unsupported key")
+}
+
+func dhkemEncapsulator(keyObj *object.Object) (*kemState, *ghelpers.GErrBlk) {
+	recipient, err := dhkemPublicKey(keyObj)
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, err.Error())
+	}
+	suite, ok := dhkemSuiteOf(recipient.Curve())
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Unsupported key")
+	}
+
+	return &kemState{
+		secretSize:        suite.secretSize,
+		encapsulationSize: len(recipient.Bytes()),
+		encapsulate: func() ([]byte, []byte, error) {
+			ephemeral, err := recipient.Curve().GenerateKey(nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			dh, err := ephemeral.ECDH(recipient)
+			if err != nil {
+				return nil, nil, err
+			}
+			encapsulation := ephemeral.PublicKey().Bytes()
+			secret, err := suite.extractAndExpand(dh, slices.Concat(encapsulation, recipient.Bytes()))
+			return secret, encapsulation, err
+		},
+	}, nil
+}
+
+func dhkemDecapsulator(keyObj *object.Object) (*kemState, *ghelpers.GErrBlk) {
+	recipient, err := dhkemPrivateKey(keyObj)
+	if err != nil {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, err.Error())
+	}
+	suite, ok := dhkemSuiteOf(recipient.Curve())
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Unsupported key")
+	}
+
+	recipientPublic := recipient.PublicKey().Bytes()
+	return &kemState{
+		secretSize:        suite.secretSize,
+		encapsulationSize: len(recipientPublic),
+		decapsulate: func(encapsulation []byte) ([]byte, error) {
+			ephemeral, err := recipient.Curve().NewPublicKey(encapsulation)
+			if err != nil {
+				return nil, err
+			}
+			dh, err := recipient.ECDH(ephemeral)
+			if err != nil {
+				return nil, err
+			}
+			return suite.extractAndExpand(dh, slices.Concat(encapsulation, recipientPublic))
+		},
+	}, nil
+}
+
+// ---------------------------------------------------------------------------------------------
+// ML-KEM (FIPS 203)
+// ---------------------------------------------------------------------------------------------
+
+// mlkemEncapsulator returns the state of an Encapsulator of an ML-KEM public key. A KEM named
+// for a parameter set only takes keys of that set.
+func mlkemEncapsulator(algorithm string, keyObj *object.Object) (*kemState, *ghelpers.GErrBlk) {
+	switch key := keyObj.FieldTable["value"].Fvalue.(type) {
+	case *mlkem.EncapsulationKey768:
+		if algorithm != "ML-KEM-1024" {
+			return &kemState{
+				secretSize:        mlkem.SharedKeySize,
+				encapsulationSize: mlkem.CiphertextSize768,
+				encapsulate: func() ([]byte, []byte, error) {
+					secret, ciphertext := key.Encapsulate()
+					return secret, ciphertext, nil
+				},
+			}, nil
+		}
+	case *mlkem.EncapsulationKey1024:
+		if algorithm != "ML-KEM-768" {
+			return &kemState{
+				secretSize:        mlkem.SharedKeySize,
+				encapsulationSize: mlkem.CiphertextSize1024,
+				encapsulate: func() ([]byte, []byte, error) {
+					secret, ciphertext := key.Encapsulate()
+					return secret, ciphertext, nil
+				},
+			}, nil
+		}
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Unsupported key")
+}
+
+// mlkemDecapsulator returns the state of a Decapsulator of an ML-KEM private key.
+func mlkemDecapsulator(algorithm string, keyObj *object.Object) (*kemState, *ghelpers.GErrBlk) {
+	switch key := keyObj.FieldTable["value"].Fvalue.(type) {
+	case *mlkem.DecapsulationKey768:
+		if algorithm != "ML-KEM-1024" {
+			return &kemState{
+				secretSize:        mlkem.SharedKeySize,
+				encapsulationSize: mlkem.CiphertextSize768,
+				decapsulate:       key.Decapsulate,
+			}, nil
+		}
+	case *mlkem.DecapsulationKey1024:
+		if algorithm != "ML-KEM-768" {
+			return &kemState{
+				secretSize:        mlkem.SharedKeySize,
+				encapsulationSize: mlkem.CiphertextSize1024,
+				decapsulate:       key.Decapsulate,
+			}, nil
 		}
+	}
+	return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Unsupported key")
 }
@@ -0,0 +1,255 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaxCrypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newTestKEM(t *testing.T, algorithm string) *object.Object {
+	t.Helper()
+	kem, ok := kemGetInstance([]any{object.StringObjectFromGoString(algorithm)}).(*object.Object)
+	if !ok {
+		t.Fatalf("getInstance(%s) did not return a KEM", algorithm)
+	}
+	return kem
+}
+
+// generateTestKeyPair returns the public and private keys of a key pair made by KeyPairGenerator.
+// A keySize of 0 leaves the generator uninitialized.
+func generateTestKeyPair(t *testing.T, algorithm string, keySize int64) (*object.Object, *object.Object) {
+	t.Helper()
+	javaSecurity.Load_KeyPairGenerator()
+	kpg := ghelpers.MethodSignatures["java/security/KeyPairGenerator.getInstance(Ljava/lang/String;)Ljava/security/KeyPairGenerator;"].
+		GFunction([]any{object.StringObjectFromGoString(algorithm)})
+	if keySize != 0 {
+		ghelpers.MethodSignatures["java/security/KeyPairGenerator.initialize(I)V"].GFunction([]any{kpg, keySize})
+	}
+	ret := ghelpers.MethodSignatures["java/security/KeyPairGenerator.generateKeyPair()Ljava/security/KeyPair;"].
+		GFunction([]any{kpg})
+	keyPair, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("generateKeyPair(%s): %v", algorithm, ret)
+	}
+	return keyPair.FieldTable["public"].Fvalue.(*object.Object), keyPair.FieldTable["private"].Fvalue.(*object.Object)
+}
+
+func secretKeyBytes(t *testing.T, ret any) []byte {
+	t.Helper()
+	key, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("expected a SecretKey, got %v", ret)
+	}
+	return object.GoByteArrayFromJavaByteArray(key.FieldTable["value"].Fvalue.([]types.JavaByte))
+}
+
+// kemRoundTrip encapsulates to the public key and decapsulates with the private key, and returns
+// the encapsulator, the secret and the encapsulation.
+func kemRoundTrip(t *testing.T, kem, pub, priv *object.Object) (*object.Object, []byte, []byte) {
+	t.Helper()
+	encapsulator, ok := kemNewEncapsulator([]any{kem, pub}).(*object.Object)
+	if !ok {
+		t.Fatal("newEncapsulator did not return an Encapsulator")
+	}
+	decapsulator, ok := kemNewDecapsulator([]any{kem, priv}).(*object.Object)
+	if !ok {
+		t.Fatal("newDecapsulator did not return a Decapsulator")
+	}
+
+	encapsulated := kemEncapsulate([]any{encapsulator}).(*object.Object)
+	secret := secretKeyBytes(t, kemEncapsulatedKey([]any{encapsulated}))
+	encapsulationObj := kemEncapsulatedEncapsulation([]any{encapsulated}).(*object.Object)
+	encapsulation := object.GoByteArrayFromJavaByteArray(encapsulationObj.FieldTable["value"].Fvalue.([]types.JavaByte))
+
+	if got := int(kemSecretSize([]any{encapsulator}).(int64)); got != len(secret) {
+		t.Errorf("secretSize() is %d, the secret has %d bytes", got, len(secret))
+	}
+	if got := int(kemEncapsulationSize([]any{decapsulator}).(int64)); got != len(encapsulation) {
+		t.Errorf("encapsulationSize() is %d, the encapsulation has %d bytes", got, len(encapsulation))
+	}
+	if got := secretKeyBytes(t, kemDecapsulate([]any{decapsulator, encapsulationObj})); !bytes.Equal(got, secret) {
+		t.Errorf("decapsulated secret %x, encapsulated %x", got, secret)
+	}
+	return encapsulator, secret, encapsulation
+}
+
+func TestLoad_Crypto_KEM(t *testing.T) {
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	Load_Crypto_KEM()
+
+	methods := []string{
+		"javax/crypto/KEM.getInstance(Ljava/lang/String;)Ljavax/crypto/KEM;",
+		"javax/crypto/KEM.newEncapsulator(Ljava/security/PublicKey;)Ljavax/crypto/KEM$Encapsulator;",
+		"javax/crypto/KEM.newDecapsulator(Ljava/security/PrivateKey;)Ljavax/crypto/KEM$Decapsulator;",
+		"javax/crypto/KEM$Encapsulator.encapsulate(IILjava/lang/String;)Ljavax/crypto/KEM$Encapsulated;",
+		"javax/crypto/KEM$Decapsulator.decapsulate([BIILjava/lang/String;)Ljavax/crypto/SecretKey;",
+		"javax/crypto/KEM$Encapsulated.<init>(Ljavax/crypto/SecretKey;[B[B)V",
+	}
+	for _, m := range methods {
+		gmeth, ok := ghelpers.MethodSignatures[m]
+		if !ok {
+			t.Errorf("KEM method signature not registered: %s", m)
+		} else if gmeth.GFunction == nil {
+			t.Errorf("KEM method has no G function: %s", m)
+		}
+	}
+}
+
+// Test vector A.1 of RFC 9180: DHKEM(X25519, HKDF-SHA256)
+func TestKEM_DHKEMX25519_RFC9180(t *testing.T) {
+	globals.InitGlobals("test")
+	javaSecurity.InitDefaultSecurityProvider()
+
+	skRm, _ := hex.DecodeString("4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8")
+	enc, _ := hex.DecodeString("37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431")
+	sharedSecret := "fe0e18c9f024ce43799ae393c7e8fe8fce9d218875e8227b0187c04e7d2ea1fc"
+
+	privateKeyObj := javaSecurity.NewGoRuntimeService("X25519", "X25519", types.ClassNameEdECPrivateKey)
+	privateKeyObj.FieldTable["value"] = object.Field{Ftype: types.PrivateKey, Fvalue: skRm}
+
+	decapsulator := kemNewDecapsulator([]any{newTestKEM(t, "DHKEM"), privateKeyObj}).(*object.Object)
+	secret := secretKeyBytes(t, kemDecapsulate([]any{decapsulator, makeByteArrayObject(enc)}))
+	if hex.EncodeToString(secret) != sharedSecret {
+		t.Errorf("shared secret %x, expected %s", secret, sharedSecret)
+	}
+
+	// the range of the secret given, as a key of the algorithm given
+	keyObj := kemDecapsulate([]any{decapsulator, makeByteArrayObject(enc), int64(0), int64(16),
+		object.StringObjectFromGoString("AES")}).(*object.Object)
+	if got := hex.EncodeToString(secretKeyBytes(t, keyObj)); got != sharedSecret[:32] {
+		t.Errorf("range [0, 16) of the secret: %s", got)
+	}
+	if got := object.GoStringFromStringObject(keyObj.FieldTable["algorithm"].Fvalue.(*object.Object)); got != "AES" {
+		t.Errorf("key algorithm: %s", got)
+	}
+}
+
+func TestKEM_DHKEMRoundTrip(t *testing.T) {
+	globals.InitGlobals("test")
+	javaSecurity.InitDefaultSecurityProvider()
+	kem := newTestKEM(t, "DHKEM")
+
+	tests := []struct {
+		algorithm         string
+		keySize           int64
+		secretSize        int
+		encapsulationSize int
+	}{
+		{"X25519", 0, 32, 32},
+		{"EC", 256, 32, 65},
+		{"EC", 384, 48, 97},
+		{"EC", 521, 64, 133},
+	}
+	for _, tt := range tests {
+		pub, priv := generateTestKeyPair(t, tt.algorithm, tt.keySize)
+		_, secret, encapsulation := kemRoundTrip(t, kem, pub, priv)
+		if len(secret) != tt.secretSize || len(encapsulation) != tt.encapsulationSize {
+			t.Errorf("%s %d: %d byte secret, %d byte encapsulation", tt.algorithm, tt.keySize,
+				len(secret), len(encapsulation))
+		}
+	}
+
+	// keys of the wrong kind
+	pub, priv := generateTestKeyPair(t, "X25519", 0)
+	testutil.ExpectGErr(t, kemNewEncapsulator([]any{kem, priv}), excNames.InvalidKeyException, "Unsupported key")
+	testutil.ExpectGErr(t, kemNewDecapsulator([]any{kem, pub}), excNames.InvalidKeyException, "Unsupported key")
+	edPub, _ := generateTestKeyPair(t, "Ed25519", 0)
+	testutil.ExpectGErr(t, kemNewEncapsulator([]any{kem, edPub}), excNames.InvalidKeyException, "Unsupported key")
+	testutil.ExpectGErr(t, kemNewEncapsulator([]any{kem, object.Null}), excNames.InvalidKeyException, "input key is null")
+}
+
+func TestKEM_MLKEM(t *testing.T) {
+	globals.InitGlobals("test")
+	javaSecurity.InitDefaultSecurityProvider()
+
+	pub768, priv768 := generateTestKeyPair(t, "ML-KEM", 0)
+	pub1024, priv1024 := generateTestKeyPair(t, "ML-KEM-1024", 0)
+
+	encapsulator, secret, encapsulation := kemRoundTrip(t, newTestKEM(t, "ML-KEM"), pub768, priv768)
+	if len(secret) != 32 || len(encapsulation) != 1088 {
+		t.Errorf("ML-KEM-768: %d byte secret, %d byte encapsulation", len(secret), len(encapsulation))
+	}
+	if got := object.GoStringFromStringObject(kemProviderName([]any{encapsulator}).(*object.Object)); got != types.SecurityProviderName {
+		t.Errorf("providerName(): %s", got)
+	}
+	_, secret, encapsulation = kemRoundTrip(t, newTestKEM(t, "ml-kem-1024"), pub1024, priv1024)
+	if len(secret) != 32 || len(encapsulation) != 1568 {
+		t.Errorf("ML-KEM-1024: %d byte secret, %d byte encapsulation", len(secret), len(encapsulation))
+	}
+
+	// a KEM named for a parameter set takes no keys of another, and DHKEM takes none
+	testutil.ExpectGErr(t, kemNewEncapsulator([]any{newTestKEM(t, "ML-KEM-768"), pub1024}),
+		excNames.InvalidKeyException, "Unsupported key")
+	testutil.ExpectGErr(t, kemNewDecapsulator([]any{newTestKEM(t, "DHKEM"), priv768}),
+		excNames.InvalidKeyException, "Unsupported key")
+
+	// a decapsulation of the wrong size
+	decapsulator := kemNewDecapsulator([]any{newTestKEM(t, "ML-KEM"), priv768}).(*object.Object)
+	testutil.ExpectGErr(t, kemDecapsulate([]any{decapsulator, makeByteArrayObject(encapsulation)}),
+		excNames.DecapsulateException, "incorrect encapsulation size")
+}
+
+func TestKEM_Errors(t *testing.T) {
+	globals.InitGlobals("test")
+	javaSecurity.InitDefaultSecurityProvider()
+
+	testutil.ExpectGErr(t, kemGetInstance([]any{object.StringObjectFromGoString("ML-KEM-512")}),
+		excNames.NoSuchAlgorithmException, "ML-KEM-512 KEM not available")
+	ret := kemGetInstance([]any{object.StringObjectFromGoString("DHKEM"), object.StringObjectFromGoString("SunJCE")})
+	if gerr, ok := ret.(*ghelpers.GErrBlk); !ok || gerr.ExceptionType != excNames.ProviderNotFoundException {
+		t.Errorf("getInstance with another provider: %v", ret)
+	}
+
+	kem := newTestKEM(t, "DHKEM")
+	pub, _ := generateTestKeyPair(t, "X25519", 0)
+	spec := object.MakeEmptyObjectWithClassName(&types.ClassNameECGenParameterSpec)
+	testutil.ExpectGErr(t, kemNewEncapsulator([]any{kem, pub, spec, object.Null}),
+		excNames.InvalidAlgorithmParameterException, "no spec needed")
+
+	encapsulator := kemNewEncapsulatorWithRandom([]any{kem, pub, object.Null}).(*object.Object)
+	testutil.ExpectGErr(t, kemEncapsulate([]any{encapsulator, int64(16), int64(33), object.StringObjectFromGoString("AES")}),
+		excNames.IndexOutOfBoundsException, "Range [16, 33) out of bounds for length 32")
+	testutil.ExpectGErr(t, kemEncapsulate([]any{encapsulator, int64(0), int64(16), object.Null}),
+		excNames.NullPointerException, "null algorithm")
+}
+
+func TestKEM_Encapsulated(t *testing.T) {
+	globals.InitGlobals("test")
+
+	className := "javax/crypto/KEM$Encapsulated"
+	encapsulated := object.MakeEmptyObjectWithClassName(&className)
+	key := newRawSecretKey("AES", make([]byte, 16))
+	encapsulation := makeByteArrayObject([]byte{1, 2, 3})
+	if ret := kemEncapsulatedInit([]any{encapsulated, key, encapsulation, object.Null}); ret != nil {
+		t.Fatalf("Encapsulated(): %v", ret)
+	}
+	encapsulation.FieldTable["value"].Fvalue.([]types.JavaByte)[0] = 9
+
+	if kemEncapsulatedKey([]any{encapsulated}) != key {
+		t.Error("key() is not the key given")
+	}
+	if kemEncapsulatedParams([]any{encapsulated}) != object.Null {
+		t.Error("params() is not null")
+	}
+	got := kemEncapsulatedEncapsulation([]any{encapsulated}).(*object.Object)
+	if !bytes.Equal(object.GoByteArrayFromJavaByteArray(got.FieldTable["value"].Fvalue.([]types.JavaByte)), []byte{1, 2, 3}) {
+		t.Error("encapsulation() is not a copy of the array given")
+	}
+
+	testutil.ExpectGErr(t, kemEncapsulatedInit([]any{encapsulated, object.Null, encapsulation, object.Null}),
+		excNames.NullPointerException, "null key")
+}
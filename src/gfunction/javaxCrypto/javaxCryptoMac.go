@@ -7,7 +7,18 @@
 package javaxCrypto
 
 import (
+	"container/list"
+	"crypto/hmac"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/object"
+	"jacobin/src/types"
 )
 
 func Load_Crypto_Mac() {
@@ -21,85 +32,85 @@ func Load_Crypto_Mac() {
 	ghelpers.MethodSignatures["javax/crypto/Mac.clone()Ljava/lang/Object;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macClone,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.doFinal()[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macDoFinal,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.doFinal([B)[B"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macDoFinal,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.doFinal([BI)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macDoFinalInto,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getAlgorithm()Ljava/lang/String;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetAlgorithm,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getInstance(Ljava/lang/String;)Ljavax/crypto/Mac;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/crypto/Mac;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getInstance(Ljava/lang/String;Ljava/security/Provider;)Ljavax/crypto/Mac;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetInstance,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getMacLength()I"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetMacLength,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.getProvider()Ljava/security/Provider;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macGetProvider,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.init(Ljava/security/Key;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macInit,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.init(Ljava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macInit,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.reset()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macReset,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.update(B)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macUpdate,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.update(Ljava/nio/ByteBuffer;)V"] =
@@ -111,12 +122,326 @@ func Load_Crypto_Mac() {
 	ghelpers.MethodSignatures["javax/crypto/Mac.update([B)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macUpdate,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/Mac.update([BII)V"] =
 		ghelpers.GMeth{
 			ParamSlots: 3,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  macUpdate,
+		}
+
+	// Macs of providers written in Java run on their MacSpi.
+	javaSecurity.InstallSpiDispatch(javaSecurity.SpiEngine{
+		ClassName:   types.ClassNameMac,
+		ServiceType: "Mac",
+		Methods: map[string]javaSecurity.SpiFunc{
+			"doFinal()[B": javaSecurity.SpiCall("engineDoFinal", "()[B"),
+			"doFinal([B)[B": javaSecurity.SpiThen(javaSecurity.SpiCall("engineUpdate", "([BII)V", javaSecurity.SpiWholeArray),
+				javaSecurity.SpiCall("engineDoFinal", "()[B")),
+			"doFinal([BI)V":   macSpiDoFinalInto,
+			"getMacLength()I": javaSecurity.SpiCall("engineGetMacLength", "()I"),
+			"init(Ljava/security/Key;)V": javaSecurity.SpiCall("engineInit",
+				"(Ljava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;)V", javaSecurity.SpiAppendArgs(object.Null)),
+			"init(Ljava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;)V": javaSecurity.SpiCall("engineInit",
+				"(Ljava/security/Key;Ljava/security/spec/AlgorithmParameterSpec;)V"),
+			"reset()V":      javaSecurity.SpiCall("engineReset", "()V"),
+			"update(B)V":    javaSecurity.SpiCall("engineUpdate", "(B)V"),
+			"update([B)V":   javaSecurity.SpiCall("engineUpdate", "([BII)V", javaSecurity.SpiWholeArray),
+			"update([BII)V": javaSecurity.SpiCall("engineUpdate", "([BII)V"),
+		},
+	})
+}
+
+// macSpiDoFinalInto is doFinal([BI)V of a Mac that runs on a MacSpi, which has no such method.
+func macSpiDoFinalInto(fs *list.List, spi *object.Object, args []any) any {
+	ret := ghelpers.InvokeMethodOnObject(fs, spi, "engineDoFinal", "()[B")
+	macObj, ok := ret.(*object.Object)
+	if !ok {
+		return ret
+	}
+	mac, _ := macObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+
+	outputObj, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(outputObj) {
+		return ghelpers.GetGErrBlk(excNames.ShortBufferException, "Cannot store MAC in output buffer")
+	}
+	output, _ := outputObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	offset := args[1].(int64)
+	if offset < 0 || int64(len(output))-offset < int64(len(mac)) {
+		return ghelpers.GetGErrBlk(excNames.ShortBufferException, "Cannot store MAC in output buffer")
+	}
+	copy(output[offset:], mac)
+	return nil
+}
+
+// macState is the Go state of an initialized Mac. The HMACs of crypto/hmac over SHA-3 cannot be
+// cloned, so for those the input since the last reset is kept, to be replayed into a clone.
+type macState struct {
+	algorithm MacAlgorithm
+	key       []byte
+	hmac      hash.Hash
+	cloneable bool
+	input     []byte // only kept if the HMAC is not cloneable
+}
+
+func newMacState(algorithm MacAlgorithm, key []byte) *macState {
+	state := &macState{algorithm: algorithm, key: slices.Clone(key), hmac: hmac.New(algorithm.New, key)}
+	if cloner, ok := state.hmac.(hash.Cloner); ok {
+		_, err := cloner.Clone()
+		state.cloneable = err == nil
+	}
+	return state
+}
+
+func (state *macState) write(data []byte) {
+	state.hmac.Write(data)
+	if !state.cloneable {
+		state.input = append(state.input, data...)
+	}
+}
+
+func (state *macState) reset() {
+	state.hmac.Reset()
+	state.input = nil
+}
+
+// sum returns the MAC of the input and resets the state, as the JDK's doFinal() does.
+func (state *macState) sum() []byte {
+	mac := state.hmac.Sum(nil)
+	state.reset()
+	return mac
+}
+
+func (state *macState) clone() (*macState, error) {
+	clone := &macState{algorithm: state.algorithm, key: state.key, cloneable: state.cloneable}
+	if !state.cloneable {
+		clone.hmac = hmac.New(state.algorithm.New, state.key)
+		clone.write(state.input)
+		return clone, nil
+	}
+	h, err := state.hmac.(hash.Cloner).Clone()
+	if err != nil {
+		return nil, err
+	}
+	clone.hmac = h
+	return clone, nil
+}
+
+// getMacState returns the state of an initialized Mac, or an IllegalStateException.
+func getMacState(self *object.Object) (*macState, *ghelpers.GErrBlk) {
+	state, ok := self.FieldTable["state"].Fvalue.(*macState)
+	if !ok {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalStateException, "MAC not initialized")
+	}
+	return state, nil
+}
+
+func macGetInstance(params []any) any {
+	algorithmObj, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(algorithmObj) {
+		return ghelpers.GetGErrBlk(excNames.NullPointerException, "null algorithm name")
+	}
+	algorithm := object.GoStringFromStringObject(algorithmObj)
+
+	config, ok := ValidateMacAlgorithm(algorithm)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException,
+			fmt.Sprintf("Algorithm %s not available", algorithm))
+	}
+
+	// Check provider parameter if provided
+	if len(params) > 1 {
+		if pObj, ok := params[1].(*object.Object); ok && !object.IsNull(pObj) {
+			var pName string
+			if object.IsStringObject(pObj) {
+				pName = object.GoStringFromStringObject(pObj)
+			} else if nameObj, ok := pObj.FieldTable["name"].Fvalue.(*object.Object); ok {
+				pName = object.GoStringFromStringObject(nameObj)
+			}
+			if pName != "" && pName != types.SecurityProviderName {
+				return ghelpers.GetGErrBlk(excNames.ProviderNotFoundException,
+					fmt.Sprintf("macGetInstance: provider %s not found", pName))
+			}
+		}
+	}
+
+	mac := object.MakeEmptyObjectWithClassName(&types.ClassNameMac)
+	mac.FieldTable["algorithm"] = object.Field{
+		Ftype:  types.StringClassName,
+		Fvalue: algorithmObj,
+	}
+	mac.FieldTable["provider"] = object.Field{
+		Ftype:  types.ClassNameSecurityProvider,
+		Fvalue: ghelpers.GetDefaultSecurityProvider(),
+	}
+	mac.FieldTable["config"] = object.Field{
+		Ftype:  types.Struct,
+		Fvalue: config,
+	}
+	return mac
+}
+
+func macGetAlgorithm(params []any) any {
+	self := params[0].(*object.Object)
+	return self.FieldTable["algorithm"].Fvalue
+}
+
+func macGetProvider(params []any) any {
+	self := params[0].(*object.Object)
+	return self.FieldTable["provider"].Fvalue
+}
+
+func macGetMacLength(params []any) any {
+	self := params[0].(*object.Object)
+	config := self.FieldTable["config"].Fvalue.(MacAlgorithm)
+	return int64(config.MacLength)
+}
+
+// macInit keys the Mac with a SecretKey. HMAC takes no parameters, so a parameter spec, if one
+// is given, must be null.
+func macInit(params []any) any {
+	self := params[0].(*object.Object)
+	config := self.FieldTable["config"].Fvalue.(MacAlgorithm)
+
+	if len(params) > 2 && !object.IsNull(params[2]) {
+		return ghelpers.GetGErrBlk(excNames.InvalidAlgorithmParameterException, "HMAC does not use parameters")
+	}
+
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Secret key expected")
+	}
+	var keyBytes []byte
+	for _, fieldName := range []string{"key", "value"} {
+		switch v := keyObj.FieldTable[fieldName].Fvalue.(type) {
+		case []byte:
+			keyBytes = v
+		case []types.JavaByte:
+			keyBytes = object.GoByteArrayFromJavaByteArray(v)
+		}
+		if keyBytes != nil {
+			break
+		}
+	}
+	if keyBytes == nil {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Missing key data")
+	}
+
+	self.FieldTable["state"] = object.Field{
+		Ftype:  types.RawGoPointer,
+		Fvalue: newMacState(config, keyBytes),
+	}
+	return nil
+}
+
+// macUpdate handles update(B), update([B) and update([BII).
+func macUpdate(params []any) any {
+	self := params[0].(*object.Object)
+	state, gerr := getMacState(self)
+	if gerr != nil {
+		return gerr
+	}
+
+	if b, ok := params[1].(int64); ok {
+		state.write([]byte{byte(b)})
+		return nil
+	}
+
+	input, gerr := macInputBytes(params[1:])
+	if gerr != nil {
+		return gerr
+	}
+	state.write(input)
+	return nil
+}
+
+// macInputBytes returns the bytes of a byte array argument, or of the range of it given by the
+// offset and length that follow it.
+func macInputBytes(args []any) ([]byte, *ghelpers.GErrBlk) {
+	inputObj, ok := args[0].(*object.Object)
+	if !ok || object.IsNull(inputObj) {
+		if len(args) > 1 {
+			return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "No input buffer given")
+		}
+		return nil, nil // update(null) and doFinal(null) are ignored, as in the JDK
+	}
+	jBytes, _ := inputObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	if len(args) == 1 {
+		return object.GoByteArrayFromJavaByteArray(jBytes), nil
+	}
+	offset, length := args[1].(int64), args[2].(int64)
+	if offset < 0 || length < 0 || offset > int64(len(jBytes))-length {
+		return nil, ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "Bad arguments")
+	}
+	return object.GoByteArrayFromJavaByteArray(jBytes[offset : offset+length]), nil
+}
+
+// macDoFinal handles doFinal() and doFinal([B): it returns the MAC and resets the Mac.
+func macDoFinal(params []any) any {
+	self := params[0].(*object.Object)
+	state, gerr := getMacState(self)
+	if gerr != nil {
+		return gerr
+	}
+
+	if len(params) > 1 {
+		input, gerr := macInputBytes(params[1:2])
+		if gerr != nil {
+			return gerr
+		}
+		state.write(input)
+	}
+
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray(state.sum()))
+}
+
+// macDoFinalInto handles doFinal([BI)V: the MAC is stored in the array at the offset given.
+func macDoFinalInto(params []any) any {
+	self := params[0].(*object.Object)
+	state, gerr := getMacState(self)
+	if gerr != nil {
+		return gerr
+	}
+
+	outputObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(outputObj) {
+		return ghelpers.GetGErrBlk(excNames.ShortBufferException, "Cannot store MAC in output buffer")
+	}
+	output, _ := outputObj.FieldTable["value"].Fvalue.([]types.JavaByte)
+	offset := params[2].(int64)
+	if offset < 0 || int64(len(output))-offset < int64(state.algorithm.MacLength) {
+		return ghelpers.GetGErrBlk(excNames.ShortBufferException, "Cannot store MAC in output buffer")
+	}
+
+	copy(output[offset:], object.JavaByteArrayFromGoByteArray(state.sum()))
+	return nil
+}
+
+func macReset(params []any) any {
+	self := params[0].(*object.Object)
+	if state, ok := self.FieldTable["state"].Fvalue.(*macState); ok {
+		state.reset()
+	}
+	return nil
+}
+
+// macClone returns a Mac in the same state, which goes on independently of this one.
+func macClone(params []any) any {
+	self := params[0].(*object.Object)
+
+	clone := object.MakeEmptyObjectWithClassName(&types.ClassNameMac)
+	for _, fieldName := range []string{"algorithm", "provider", "config"} {
+		clone.FieldTable[fieldName] = self.FieldTable[fieldName]
+	}
+	if state, ok := self.FieldTable["state"].Fvalue.(*macState); ok {
+		stateClone, err := state.clone()
+		if err != nil {
+			return ghelpers.GetGErrBlk(excNames.CloneNotSupportedException,
+				strings.TrimPrefix(err.Error(), "crypto/hmac: "))
 		}
+		clone.FieldTable["state"] = object.Field{Ftype: types.RawGoPointer, Fvalue: stateClone}
+	}
+	return clone
 }
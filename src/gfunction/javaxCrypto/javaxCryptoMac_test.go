@@ -0,0 +1,265 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaxCrypto
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/hex"
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/gfunction/javaSecurity"
+	"jacobin/src/globals"
+	"jacobin/src/object"
+	"jacobin/src/testutil"
+	"jacobin/src/types"
+	"testing"
+)
+
+func newTestMac(t *testing.T, algorithm string, key []byte) *object.Object {
+	t.Helper()
+	mac, ok := macGetInstance([]any{object.StringObjectFromGoString(algorithm)}).(*object.Object)
+	if !ok {
+		t.Fatalf("getInstance(%s) did not return a Mac", algorithm)
+	}
+	className := "javax/crypto/spec/SecretKeySpec"
+	keySpec := object.MakeEmptyObjectWithClassName(&className)
+	if ret := secretKeySpecInit([]any{keySpec, makeByteArrayObject(key), object.StringObjectFromGoString(algorithm)}); ret != nil {
+		t.Fatalf("SecretKeySpec(%s): %v", algorithm, ret)
+	}
+	if ret := macInit([]any{mac, keySpec}); ret != nil {
+		t.Fatalf("init(%s): %v", algorithm, ret)
+	}
+	return mac
+}
+
+func macResultBytes(t *testing.T, ret any) []byte {
+	t.Helper()
+	obj, ok := ret.(*object.Object)
+	if !ok {
+		t.Fatalf("expected a byte array, got %v", ret)
+	}
+	return object.GoByteArrayFromJavaByteArray(obj.FieldTable["value"].Fvalue.([]types.JavaByte))
+}
+
+// Test cases 1, 2 and 6 of RFC 4231
+func TestMac_RFC4231(t *testing.T) {
+	globals.InitGlobals("test")
+
+	largeKey := bytes.Repeat([]byte{0xaa}, 131)
+	cases := []struct {
+		algorithm, data, want string
+		key                   []byte
+	}{
+		{"HmacSHA224", "Hi There", "896fb1128abbdf196832107cd49df33f47b4b1169912ba4f53684b22",
+			bytes.Repeat([]byte{0x0b}, 20)},
+		{"HmacSHA256", "Hi There", "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+			bytes.Repeat([]byte{0x0b}, 20)},
+		{"HmacSHA384", "Hi There", "afd03944d84895626b0825f4ab46907f15f9dadbe4101ec682aa034c7cebc59c" +
+			"faea9ea9076ede7f4af152e8b2fa9cb6", bytes.Repeat([]byte{0x0b}, 20)},
+		{"HmacSHA512", "Hi There", "87aa7cdea5ef619d4ff0b4241a1d6cb02379f4e2ce4ec2787ad0b30545e17cde" +
+			"daa833b7d6b8a702038b274eaea3f4e4be9d914eeb61f1702e696c203a126854", bytes.Repeat([]byte{0x0b}, 20)},
+		{"HmacSHA224", "what do ya want for nothing?",
+			"a30e01098bc6dbbf45690f3a7e9e6d0f8bbea2a39e6148008fd05e44", []byte("Jefe")},
+		{"HmacSHA256", "what do ya want for nothing?",
+			"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", []byte("Jefe")},
+		{"HmacSHA384", "what do ya want for nothing?",
+			"af45d2e376484031617f78d2b58a6b1b9c7ef464f5a01b47e42ec3736322445e" +
+				"8e2240ca5e69e2c78b3239ecfab21649", []byte("Jefe")},
+		{"HmacSHA512", "what do ya want for nothing?",
+			"164b7a7bfcf819e2e395fbe73b56e0a387bd64222e831fd610270cd7ea250554" +
+				"9758bf75c05a994a6d034f65f8f0e6fdcaeab1a34d4a6b4b636e070a38bce737", []byte("Jefe")},
+		{"HmacSHA224", "Test Using Larger Than Block-Size Key - Hash Key First",
+			"95e9a0db962095adaebe9b2d6f0dbce2d499f112f2d2b7273fa6870e", largeKey},
+		{"HmacSHA256", "Test Using Larger Than Block-Size Key - Hash Key First",
+			"60e431591ee0b67f0d8a26aacbf5b77f8e0bc6213728c5140546040f0ee37f54", largeKey},
+		{"HmacSHA384", "Test Using Larger Than Block-Size Key - Hash Key First",
+			"4ece084485813e9088d2c63a041bc5b44f9ef1012a2b588f3cd11f05033ac4c6" +
+				"0c2ef6ab4030fe8296248df163f44952", largeKey},
+		{"HmacSHA512", "Test Using Larger Than Block-Size Key - Hash Key First",
+			"80b24263c7c1a3ebb71493c1dd7be8b49b46d1f41b4aeec1121b013783f8f352" +
+				"6b56d037e05f2598bd0fd2215d6a1e5295e64f73f63f0aec8b915a985d786598", largeKey},
+	}
+
+	for _, c := range cases {
+		mac := newTestMac(t, c.algorithm, c.key)
+		got := macResultBytes(t, macDoFinal([]any{mac, makeByteArrayObject([]byte(c.data))}))
+		if hex.EncodeToString(got) != c.want {
+			t.Errorf("%s(%q): expected %s, got %x", c.algorithm, c.data, c.want, got)
+		}
+		if length := macGetMacLength([]any{mac}); length != int64(len(c.want)/2) {
+			t.Errorf("%s: expected getMacLength %d, got %v", c.algorithm, len(c.want)/2, length)
+		}
+	}
+}
+
+func TestMac_IncrementalUpdateAndReset(t *testing.T) {
+	globals.InitGlobals("test")
+
+	mac := newTestMac(t, "HmacSHA256", []byte("Jefe"))
+	data := []byte("what do ya want for nothing?")
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+
+	// update(B), update([BII) and update([B) in turn
+	if ret := macUpdate([]any{mac, int64(data[0])}); ret != nil {
+		t.Fatalf("update(B): %v", ret)
+	}
+	if ret := macUpdate([]any{mac, makeByteArrayObject(data), int64(1), int64(9)}); ret != nil {
+		t.Fatalf("update([BII): %v", ret)
+	}
+	if ret := macUpdate([]any{mac, makeByteArrayObject(data[10:])}); ret != nil {
+		t.Fatalf("update([B): %v", ret)
+	}
+	if got := hex.EncodeToString(macResultBytes(t, macDoFinal([]any{mac}))); got != want {
+		t.Errorf("incremental update: expected %s, got %s", want, got)
+	}
+
+	// doFinal resets the Mac, so the same input gives the same MAC again
+	out := makeByteArrayObject(make([]byte, 40))
+	macUpdate([]any{mac, makeByteArrayObject(data)})
+	if ret := macDoFinalInto([]any{mac, out, int64(8)}); ret != nil {
+		t.Fatalf("doFinal([BI): %v", ret)
+	}
+	if got := hex.EncodeToString(macResultBytes(t, out)[8:]); got != want {
+		t.Errorf("doFinal([BI): expected %s, got %s", want, got)
+	}
+
+	// reset discards the input so far
+	macUpdate([]any{mac, makeByteArrayObject([]byte("garbage"))})
+	macReset([]any{mac})
+	if got := hex.EncodeToString(macResultBytes(t, macDoFinal([]any{mac, makeByteArrayObject(data)}))); got != want {
+		t.Errorf("reset: expected %s, got %s", want, got)
+	}
+}
+
+func TestMac_Clone(t *testing.T) {
+	globals.InitGlobals("test")
+
+	for _, algorithm := range []string{"HmacSHA256", "HmacSHA3-256"} {
+		mac := newTestMac(t, algorithm, []byte("key"))
+		macUpdate([]any{mac, makeByteArrayObject([]byte("common "))})
+
+		clone, ok := macClone([]any{mac}).(*object.Object)
+		if !ok {
+			t.Fatalf("%s: clone did not return a Mac", algorithm)
+		}
+		first := macResultBytes(t, macDoFinal([]any{mac, makeByteArrayObject([]byte("prefix"))}))
+		second := macResultBytes(t, macDoFinal([]any{clone, makeByteArrayObject([]byte("prefix"))}))
+		if !bytes.Equal(first, second) {
+			t.Errorf("%s: clone gave %x, original gave %x", algorithm, second, first)
+		}
+
+		// after doFinal both are reset, and each goes on independently
+		macUpdate([]any{clone, makeByteArrayObject([]byte("other"))})
+		fresh := macResultBytes(t, macDoFinal([]any{mac, makeByteArrayObject([]byte("common prefix"))}))
+		if !bytes.Equal(first, fresh) {
+			t.Errorf("%s: updating the clone changed the original", algorithm)
+		}
+		if algo := object.GoStringFromStringObject(macGetAlgorithm([]any{clone}).(*object.Object)); algo != algorithm {
+			t.Errorf("%s: clone has algorithm %s", algorithm, algo)
+		}
+	}
+}
+
+func TestMac_Errors(t *testing.T) {
+	globals.InitGlobals("test")
+
+	testutil.ExpectGErr(t, macGetInstance([]any{object.Null}), excNames.NullPointerException, "null algorithm name")
+	testutil.ExpectGErr(t, macGetInstance([]any{object.StringObjectFromGoString("HmacSHA999")}),
+		excNames.NoSuchAlgorithmException, "Algorithm HmacSHA999 not available")
+
+	// algorithm names are not case-sensitive
+	mac := macGetInstance([]any{object.StringObjectFromGoString("hmacsha3-512")}).(*object.Object)
+	if length := macGetMacLength([]any{mac}); length != int64(64) {
+		t.Errorf("expected getMacLength 64, got %v", length)
+	}
+
+	testutil.ExpectGErr(t, macUpdate([]any{mac, int64(1)}), excNames.IllegalStateException, "MAC not initialized")
+	testutil.ExpectGErr(t, macDoFinal([]any{mac}), excNames.IllegalStateException, "MAC not initialized")
+	testutil.ExpectGErr(t, macInit([]any{mac, object.Null}), excNames.InvalidKeyException, "Secret key expected")
+	testutil.ExpectGErr(t, macInit([]any{mac, object.Null, object.StringObjectFromGoString("spec")}),
+		excNames.InvalidAlgorithmParameterException, "HMAC does not use parameters")
+
+	mac = newTestMac(t, "HmacSHA512", []byte("key"))
+	testutil.ExpectGErr(t, macUpdate([]any{mac, makeByteArrayObject([]byte("abc")), int64(2), int64(2)}),
+		excNames.IllegalArgumentException, "Bad arguments")
+	testutil.ExpectGErr(t, macDoFinalInto([]any{mac, makeByteArrayObject(make([]byte, 64)), int64(1)}),
+		excNames.ShortBufferException, "Cannot store MAC in output buffer")
+}
+
+// TestMac_JavaProviderSpi runs a Mac on the MacSpi of a provider written in Java, whose MAC is
+// the bytes given to it, reversed.
+func TestMac_JavaProviderSpi(t *testing.T) {
+	globals.InitGlobals("test")
+	ghelpers.MethodSignatures = make(map[string]ghelpers.GMeth)
+	javaSecurity.Load_Security()
+	javaSecurity.Load_Security_Provider()
+	Load_Crypto_Mac()
+	globals.GetGlobalRef().FuncInstantiateClass = func(className string, _ *list.List) (any, error) {
+		return object.MakeEmptyObjectWithClassName(&className), nil
+	}
+
+	const spiClass = "test/ReverseMacSpi"
+	var input []types.JavaByte
+	ghelpers.MethodSignatures[spiClass+".<init>()V"] = ghelpers.GMeth{GFunction: func([]any) any { return nil }}
+	ghelpers.MethodSignatures[spiClass+".engineUpdate([BII)V"] = ghelpers.GMeth{ParamSlots: 3,
+		GFunction: func(params []any) any {
+			data := params[1].(*object.Object).FieldTable["value"].Fvalue.([]types.JavaByte)
+			input = append(input, data[params[2].(int64):params[2].(int64)+params[3].(int64)]...)
+			return nil
+		}}
+	ghelpers.MethodSignatures[spiClass+".engineDoFinal()[B"] = ghelpers.GMeth{
+		GFunction: func([]any) any {
+			mac := make([]types.JavaByte, len(input))
+			for i, b := range input {
+				mac[len(input)-1-i] = b
+			}
+			input = nil
+			return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, mac)
+		}}
+
+	call := func(sig string, params ...any) any {
+		gmeth := ghelpers.MethodSignatures[sig]
+		if gmeth.NeedsContext {
+			params = append([]any{(*list.List)(nil)}, params...)
+		}
+		return gmeth.GFunction(params)
+	}
+	className := "com/acme/AcmeProvider"
+	provider := object.MakeEmptyObjectWithClassName(&className)
+	call("java/security/Provider.<init>(Ljava/lang/String;Ljava/lang/String;Ljava/lang/String;)V", provider,
+		object.StringObjectFromGoString("Acme"), object.StringObjectFromGoString("1.0"),
+		object.StringObjectFromGoString("Acme provider"))
+	call("java/security/Provider.put(Ljava/lang/Object;Ljava/lang/Object;)Ljava/lang/Object;", provider,
+		object.StringObjectFromGoString("Mac.Reverse"), object.StringObjectFromGoString("test.ReverseMacSpi"))
+	call("java/security/Security.addProvider(Ljava/security/Provider;)I", provider)
+	defer call("java/security/Security.removeProvider(Ljava/lang/String;)V", object.StringObjectFromGoString("Acme"))
+
+	mac, ok := call("javax/crypto/Mac.getInstance(Ljava/lang/String;)Ljavax/crypto/Mac;",
+		object.StringObjectFromGoString("Reverse")).(*object.Object)
+	if !ok {
+		t.Fatal("getInstance(Reverse) did not return a Mac")
+	}
+	call("javax/crypto/Mac.update([B)V", mac, makeByteArrayObject([]byte{1, 2, 3}))
+	output := makeByteArrayObject(make([]byte, 4))
+	if ret := call("javax/crypto/Mac.doFinal([BI)V", mac, output, int64(1)); ret != nil {
+		t.Fatalf("doFinal([BI): %v", ret)
+	}
+	if got := macResultBytes(t, output); !bytes.Equal(got, []byte{0, 3, 2, 1}) {
+		t.Errorf("expected [0 3 2 1], got %v", got)
+	}
+
+	call("javax/crypto/Mac.update([B)V", mac, makeByteArrayObject([]byte{1, 2, 3}))
+	ret := call("javax/crypto/Mac.doFinal([BI)V", mac, makeByteArrayObject(make([]byte, 2)), int64(0))
+	testutil.ExpectGErr(t, ret, excNames.ShortBufferException, "Cannot store MAC in output buffer")
+
+	// HmacSHA256 is not in the Java provider, so the Go provider still makes it.
+	if hmac := call("javax/crypto/Mac.getInstance(Ljava/lang/String;)Ljavax/crypto/Mac;",
+		object.StringObjectFromGoString("HmacSHA256")).(*object.Object); hmac.FieldTable["config"].Fvalue == nil {
+		t.Error("expected HmacSHA256 from the Go provider")
+	}
+}
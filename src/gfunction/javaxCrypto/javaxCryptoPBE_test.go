@@ -8,6 +8,8 @@ package javaxCrypto
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
 	"jacobin/src/object"
@@ -335,3 +337,55 @@ func TestPBEWithHmac(t *testing.T) {
 		})
 	}
 }
+
+// PBEWithHmacSHA256AndAES_128 encrypts with AES/CBC/PKCS5Padding under the key PBKDF2WithHmacSHA256
+// derives from the password and the salt and iteration count of the PBEParameterSpec, and with the
+// IV of the IvParameterSpec inside it.
+func TestPBEWithHmacDerivesThroughSecretKeyFactory(t *testing.T) {
+	globals.InitGlobals("test")
+
+	iv := []byte("fedcba9876543210")
+	plaintext := []byte("PBES2 known-answer plaintext")
+	encrypt := func(key *object.Object, salt []byte) []byte {
+		t.Helper()
+		ivSpec := object.MakeEmptyObjectWithClassName(new("javax/crypto/spec/IvParameterSpec"))
+		ivParameterSpecInit([]any{ivSpec, makeByteArrayObject(iv)})
+		pbeParamSpec := object.MakeEmptyObjectWithClassName(&types.ClassNamePBEParameterSpec)
+		if ret := pbeParameterSpecInitWithSpec([]any{pbeParamSpec, makeByteArrayObject(salt), int64(1000), ivSpec}); ret != nil {
+			t.Fatalf("PBEParameterSpec: %v", ret)
+		}
+		cipherObj := cipherGetInstance([]any{object.StringObjectFromGoString("PBEWithHmacSHA256AndAES_128")}).(*object.Object)
+		if ret := cipherInit([]any{cipherObj, int64(1), key, pbeParamSpec}); ret != nil {
+			t.Fatalf("init: %v", ret)
+		}
+		return macResultBytes(t, cipherDoFinal([]any{cipherObj, makeByteArrayObject(plaintext)}))
+	}
+	expected := func(salt []byte) []byte {
+		t.Helper()
+		skf := newTestSecretKeyFactory(t, "PBKDF2WithHmacSHA256")
+		key := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("password", salt, 1000, 128)}).(*object.Object)
+		block, err := aes.NewCipher(encodedKeyBytes(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		padded := applyPadding(plaintext, aes.BlockSize, "PKCS5Padding")
+		ciphertext := make([]byte, len(padded))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+		return ciphertext
+	}
+
+	saltA := []byte("saltsaltsaltsalt")
+	saltB := []byte("another salt")
+	skf := newTestSecretKeyFactory(t, "PBEWithHmacSHA256AndAES_128")
+	passwordKey := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("password", nil, 0, 0)}).(*object.Object)
+
+	if got, want := encrypt(passwordKey, saltA), expected(saltA); !bytes.Equal(got, want) {
+		t.Errorf("password key: expected %x, got %x", want, got)
+	}
+
+	// a key derived with one salt is derived again from its password with the salt of the spec
+	derivedKey := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("password", saltA, 1000, 0)}).(*object.Object)
+	if got, want := encrypt(derivedKey, saltB), expected(saltB); !bytes.Equal(got, want) {
+		t.Errorf("derived key with another salt: expected %x, got %x", want, got)
+	}
+}
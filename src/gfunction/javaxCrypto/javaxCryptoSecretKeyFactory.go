@@ -13,6 +13,7 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"hash"
+	"slices"
 	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
@@ -36,6 +37,12 @@ func Load_Crypto_SecretKeyFactory() {
 			GFunction:  secretKeyFactoryGenerateSecret,
 		}
 
+	ghelpers.MethodSignatures["javax/crypto/SecretKeyFactory.getAlgorithm()Ljava/lang/String;"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  secretKeyFactoryGetAlgorithm,
+		}
+
 	ghelpers.MethodSignatures["javax/crypto/SecretKeyFactory.getInstance(Ljava/lang/String;Ljava/lang/String;)Ljavax/crypto/SecretKeyFactory;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
@@ -57,19 +64,19 @@ func Load_Crypto_SecretKeyFactory() {
 	ghelpers.MethodSignatures["javax/crypto/SecretKeyFactory.getKeySpec(Ljavax/crypto/SecretKey;Ljava/lang/Class;)Ljava/security/spec/KeySpec;"] =
 		ghelpers.GMeth{
 			ParamSlots: 2,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  secretKeyFactoryGetKeySpec,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/SecretKeyFactory.getProvider()Ljava/security/Provider;"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  secretKeyFactoryGetProvider,
 		}
 
 	ghelpers.MethodSignatures["javax/crypto/SecretKeyFactory.translateKey(Ljavax/crypto/SecretKey;)Ljavax/crypto/SecretKey;"] =
 		ghelpers.GMeth{
 			ParamSlots: 1,
-			GFunction:  ghelpers.TrapFunction,
+			GFunction:  secretKeyFactoryTranslateKey,
 		}
 }
 
@@ -87,6 +94,10 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 	algorithmObj := this.FieldTable["algorithm"].Fvalue.(*object.Object)
 	algorithm := object.GoStringFromStringObject(algorithmObj)
 
+	if strings.EqualFold(algorithm, "DESede") {
+		return desedeGenerateSecret(keySpec)
+	}
+
 	// Implementation depends on what KeySpecs we support.
 	// For now, let's look for SecretKeySpec which is common.
 	if keySpec.KlassName == object.StringPoolIndexFromGoString("javax/crypto/spec/SecretKeySpec") {
@@ -128,6 +139,26 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 		iterations := keySpec.FieldTable["iterationCount"].Fvalue.(int64)
 		keyLength := keySpec.FieldTable["keyLength"].Fvalue.(int64)
 
+		// PBKDF2 derives the key at once, so it needs all of the parameters
+		if isPBKDF2 {
+			var msg string
+			switch {
+			case object.IsNull(saltVal):
+				msg = "Salt not found"
+			case iterations == 0:
+				msg = "Iteration count not found"
+			case iterations < 0:
+				msg = "Iteration count is negative"
+			case keyLength == 0:
+				msg = "Key length not found"
+			case keyLength < 0:
+				msg = "Key length is negative"
+			}
+			if msg != "" {
+				return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, msg)
+			}
+		}
+
 		if keyLength == 0 {
 			algoUpper := strings.ToUpper(algorithm)
 			if isPBEAES {
@@ -154,7 +185,7 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 			}
 		}
 
-		if (isPBKDF2 || isPBEAES) && (len(salt) == 0 || iterations == 0 || keyLength == 0) {
+		if isPBEAES && (len(salt) == 0 || iterations == 0 || keyLength == 0) {
 			// If salt, iterations or keyLength are missing, we can't derive the key yet.
 			// Return a SecretKey that just contains the password and needs derivation.
 			sk := object.MakePrimitiveObject(types.ClassNameSecretKey, types.JavaByteArray, object.JavaByteArrayFromGoByteArray([]byte(password)))
@@ -162,6 +193,7 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 			if keyLength > 0 {
 				sk.FieldTable["inferred_key_length"] = object.Field{Ftype: types.Int, Fvalue: keyLength}
 			}
+			setPBEKeyFields(sk, keySpec)
 			return sk
 		}
 
@@ -174,6 +206,7 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 				// If not provided, we must defer derivation.
 				sk := object.MakePrimitiveObject(types.ClassNameSecretKey, types.JavaByteArray, object.JavaByteArrayFromGoByteArray([]byte(password)))
 				sk.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassName, Fvalue: algorithmObj}
+				setPBEKeyFields(sk, keySpec)
 				return sk
 			}
 			// For legacy PBE, we use the password and salt to derive the key using a simple MD5/SHA1 hash loop.
@@ -303,10 +336,11 @@ func secretKeyFactoryGenerateSecret(params []any) any {
 		sk := object.MakePrimitiveObject(types.ClassNameSecretKey, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(keyPart))
 		sk.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassName, Fvalue: algorithmObj}
 		sk.FieldTable["key"] = object.Field{Ftype: types.GoByteArray, Fvalue: derivedKey} // Full material (Key + IV)
+		setPBEKeyFields(sk, keySpec)
 		return sk
 	}
 
-	return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "secretKeyFactoryGenerateSecret: unsupported KeySpec")
+	return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "secretKeyFactoryGenerateSecret: unsupported KeySpec")
 }
 
 func secretKeyFactoryGetInstance(params []any) any {
@@ -316,7 +350,7 @@ func secretKeyFactoryGetInstance(params []any) any {
 	}
 	algorithm := object.GoStringFromStringObject(algorithmObj)
 
-	if _, ok := ValidateCipherTransformation(algorithm); !ok {
+	if _, ok := ValidateCipherTransformation(algorithm); !ok && !strings.EqualFold(algorithm, "DESede") {
 		return ghelpers.GetGErrBlk(excNames.NoSuchAlgorithmException, fmt.Sprintf("secretKeyFactoryGetInstance: %s not found", algorithm))
 	}
 
@@ -358,3 +392,167 @@ func secretKeyFactoryGetInstance(params []any) any {
 	// We don't strictly need a separate SPI object for now as we trap the methods on the factory itself
 	return skf
 }
+
+func secretKeyFactoryGetAlgorithm(params []any) any {
+	this := params[0].(*object.Object)
+	return this.FieldTable["algorithm"].Fvalue
+}
+
+func secretKeyFactoryGetProvider(params []any) any {
+	this := params[0].(*object.Object)
+	return this.FieldTable["provider"].Fvalue
+}
+
+// secretKeyFactoryGetKeySpec returns the key material of a key in the form of the KeySpec class asked for:
+// a DESedeKeySpec or SecretKeySpec for DESede keys, a PBEKeySpec for PBE and PBKDF2 keys.
+func secretKeyFactoryGetKeySpec(params []any) any {
+	this := params[0].(*object.Object)
+	algorithm := object.GoStringFromStringObject(this.FieldTable["algorithm"].Fvalue.(*object.Object))
+
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) || !strings.EqualFold(secretKeyAlgorithm(keyObj), algorithm) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Inappropriate key format/algorithm")
+	}
+	classObj, ok := params[2].(*object.Object)
+	if !ok || object.IsNull(classObj) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Inappropriate key specification")
+	}
+	nameObj, ok := classObj.FieldTable["name"].Fvalue.(*object.Object)
+	if !ok {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Inappropriate key specification")
+	}
+	specName := strings.ReplaceAll(object.GoStringFromStringObject(nameObj), ".", "/")
+	encoded := encodedKeyBytes(keyObj)
+
+	switch {
+	case strings.EqualFold(algorithm, "DESede") && specName == types.ClassNameDESedeKeySpec:
+		spec := object.MakeEmptyObjectWithClassName(&types.ClassNameDESedeKeySpec)
+		if ret := desedeKeySpecInit([]any{spec, javaByteArray(encoded)}); ret != nil {
+			return ret
+		}
+		return spec
+
+	case specName == "javax/crypto/spec/PBEKeySpec":
+		passwordObj, ok := keyObj.FieldTable["password"].Fvalue.(*object.Object)
+		if !ok {
+			return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Invalid key spec")
+		}
+		password := object.MakePrimitiveObject(types.CharArray, types.CharArray,
+			slices.Clone(passwordObj.FieldTable["value"].Fvalue.([]int64)))
+		spec := object.MakeEmptyObjectWithClassName(&specName)
+		if !strings.HasPrefix(strings.ToUpper(algorithm), "PBKDF2") {
+			return constructed(pbeKeySpecInit([]any{spec, password}), spec)
+		}
+		salt := object.Null
+		if saltObj, ok := keyObj.FieldTable["salt"].Fvalue.(*object.Object); ok && !object.IsNull(saltObj) {
+			salt = javaByteArray(object.GoByteArrayFromJavaByteArray(saltObj.FieldTable["value"].Fvalue.([]types.JavaByte)))
+		}
+		iterations, _ := keyObj.FieldTable["iterationCount"].Fvalue.(int64)
+		return constructed(pbeKeySpecInit([]any{spec, password, salt, iterations, int64(len(encoded) * 8)}), spec)
+
+	case specName == "javax/crypto/spec/SecretKeySpec" && keyObj.FieldTable["password"].Fvalue == nil:
+		spec := object.MakeEmptyObjectWithClassName(&specName)
+		return constructed(secretKeySpecInit([]any{spec, javaByteArray(encoded), object.StringObjectFromGoString(algorithm)}), spec)
+	}
+
+	return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Inappropriate key specification")
+}
+
+// secretKeyFactoryTranslateKey returns a key of this factory's algorithm as a key of the factory's own kind.
+// Keys the factory made are returned as they are; DESede key specs are turned into parity-adjusted keys.
+func secretKeyFactoryTranslateKey(params []any) any {
+	this := params[0].(*object.Object)
+	algorithm := object.GoStringFromStringObject(this.FieldTable["algorithm"].Fvalue.(*object.Object))
+
+	keyObj, ok := params[1].(*object.Object)
+	if !ok || object.IsNull(keyObj) || !strings.EqualFold(secretKeyAlgorithm(keyObj), algorithm) {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Inappropriate key format/algorithm")
+	}
+	if object.GoStringFromStringPoolIndex(keyObj.KlassName) == types.ClassNameSecretKey {
+		return keyObj
+	}
+
+	upper := strings.ToUpper(algorithm)
+	switch {
+	case upper == "DESEDE":
+		encoded := encodedKeyBytes(keyObj)
+		if len(encoded) < DESedeKeyLength {
+			return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Wrong key size")
+		}
+		return newRawSecretKey(algorithm, setDESParity(encoded[:DESedeKeyLength]))
+	case strings.HasPrefix(upper, "PBKDF2"), strings.HasPrefix(upper, "PBEWITH"):
+		// only PBE keys, which carry their password, can be translated
+		return ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Invalid key format/algorithm")
+	}
+	return keyObj
+}
+
+// desedeGenerateSecret makes a DESede key, with its parity adjusted, from a DESedeKeySpec or SecretKeySpec.
+func desedeGenerateSecret(keySpec *object.Object) any {
+	var key []byte
+	switch object.GoStringFromStringPoolIndex(keySpec.KlassName) {
+	case types.ClassNameDESedeKeySpec:
+		key, _ = keySpec.FieldTable["key"].Fvalue.([]byte)
+	case "javax/crypto/spec/SecretKeySpec":
+		key = encodedKeyBytes(keySpec)
+	default:
+		return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Inappropriate key specification")
+	}
+	if len(key) < DESedeKeyLength {
+		return ghelpers.GetGErrBlk(excNames.InvalidKeySpecException, "Wrong key size")
+	}
+	return newRawSecretKey("DESede", setDESParity(key[:DESedeKeyLength]))
+}
+
+// setPBEKeyFields keeps the password, salt and iteration count of a PBE key, which getKeySpec returns
+// and from which the PBE ciphers derive their keys.
+func setPBEKeyFields(sk, keySpec *object.Object) {
+	if passwordObj, ok := keySpec.FieldTable["password"].Fvalue.(*object.Object); ok && !object.IsNull(passwordObj) {
+		password := slices.Clone(passwordObj.FieldTable["value"].Fvalue.([]int64))
+		sk.FieldTable["password"] = object.Field{Ftype: types.CharArray,
+			Fvalue: object.MakePrimitiveObject(types.CharArray, types.CharArray, password)}
+	}
+	if saltObj, ok := keySpec.FieldTable["salt"].Fvalue.(*object.Object); ok && !object.IsNull(saltObj) {
+		salt := object.GoByteArrayFromJavaByteArray(saltObj.FieldTable["value"].Fvalue.([]types.JavaByte))
+		sk.FieldTable["salt"] = object.Field{Ftype: types.JavaByteArray, Fvalue: javaByteArray(salt)}
+	}
+	sk.FieldTable["iterationCount"] = keySpec.FieldTable["iterationCount"]
+}
+
+func newRawSecretKey(algorithm string, key []byte) *object.Object {
+	sk := object.MakePrimitiveObject(types.ClassNameSecretKey, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(key))
+	sk.FieldTable["algorithm"] = object.Field{Ftype: types.StringClassName, Fvalue: object.StringObjectFromGoString(algorithm)}
+	return sk
+}
+
+func secretKeyAlgorithm(keyObj *object.Object) string {
+	if algorithmObj, ok := keyObj.FieldTable["algorithm"].Fvalue.(*object.Object); ok && !object.IsNull(algorithmObj) {
+		return object.GoStringFromStringObject(algorithmObj)
+	}
+	return ""
+}
+
+// encodedKeyBytes returns what getEncoded() returns for a SecretKey or SecretKeySpec.
+func encodedKeyBytes(keyObj *object.Object) []byte {
+	for _, fieldName := range []string{"value", "key"} {
+		switch v := keyObj.FieldTable[fieldName].Fvalue.(type) {
+		case []types.JavaByte:
+			return object.GoByteArrayFromJavaByteArray(v)
+		case []byte:
+			return v
+		}
+	}
+	return nil
+}
+
+func javaByteArray(b []byte) *object.Object {
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray, object.JavaByteArrayFromGoByteArray(b))
+}
+
+// constructed returns the error from a constructor, or else the object constructed.
+func constructed(ret any, obj *object.Object) any {
+	if ret != nil {
+		return ret
+	}
+	return obj
+}
@@ -7,6 +7,8 @@
 package javaxCrypto
 
 import (
+	"bytes"
+	"encoding/hex"
 	"jacobin/src/excNames"
 	"jacobin/src/gfunction/ghelpers"
 	"jacobin/src/globals"
@@ -192,3 +194,197 @@ func TestSecretKeyFactory(t *testing.T) {
 		t.Errorf("Expected key length %d, got %d for %s", 64/8, len(derivedKey), pbeLegacyAlgo)
 	}
 }
+
+func makePBEKeySpec(password string, salt []byte, iterations, keyLength int64) *object.Object {
+	passwordChars := make([]int64, 0, len(password))
+	for _, c := range password {
+		passwordChars = append(passwordChars, int64(c))
+	}
+	className := "javax/crypto/spec/PBEKeySpec"
+	spec := object.MakeEmptyObjectWithClassName(&className)
+	passwordObj := object.MakePrimitiveObject(types.CharArray, types.CharArray, passwordChars)
+	if salt == nil {
+		pbeKeySpecInit([]any{spec, passwordObj})
+		return spec
+	}
+	pbeKeySpecInit([]any{spec, passwordObj, makeByteArrayObject(salt), iterations, keyLength})
+	return spec
+}
+
+func makeClassObject(className string) *object.Object {
+	classClassName := "java/lang/Class"
+	classObj := object.MakeEmptyObjectWithClassName(&classClassName)
+	classObj.FieldTable["name"] = object.Field{Ftype: types.StringClassName, Fvalue: object.StringObjectFromGoString(className)}
+	return classObj
+}
+
+func newTestSecretKeyFactory(t *testing.T, algorithm string) *object.Object {
+	t.Helper()
+	skf, ok := secretKeyFactoryGetInstance([]any{object.StringObjectFromGoString(algorithm)}).(*object.Object)
+	if !ok {
+		t.Fatalf("getInstance(%s) did not return a SecretKeyFactory", algorithm)
+	}
+	return skf
+}
+
+// The PBKDF2-HMAC-SHA1 vectors of RFC 6070, the PBKDF2-HMAC-SHA256 vector of RFC 7914 section 11,
+// and the widely published SHA-256/SHA-512 counterparts of RFC 6070's: the JDK gives the same keys.
+func TestSecretKeyFactoryPBKDF2KnownAnswers(t *testing.T) {
+	globals.InitGlobals("test")
+
+	cases := []struct {
+		algorithm, password, salt string
+		iterations, keyLength     int64
+		want                      string
+	}{
+		{"PBKDF2WithHmacSHA1", "password", "salt", 1, 160, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"PBKDF2WithHmacSHA1", "password", "salt", 4096, 160, "4b007901b765489abead49d926f721d065a429c1"},
+		{"PBKDF2WithHmacSHA1", "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 200,
+			"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+		{"PBKDF2WithHmacSHA256", "password", "salt", 1, 256,
+			"120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"PBKDF2WithHmacSHA256", "password", "salt", 4096, 256,
+			"c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"PBKDF2WithHmacSHA256", "passwd", "salt", 1, 512,
+			"55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+				"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"PBKDF2WithHmacSHA512", "password", "salt", 1, 512,
+			"867f70cf1ade02cff3752599a3a53dc4af34c7a669815ae5d513554e1c8cf252" +
+				"c02d470a285a0501bad999bfe943c08f050235d7d68b1da55e63f73b60a57fce"},
+	}
+
+	for _, c := range cases {
+		skf := newTestSecretKeyFactory(t, c.algorithm)
+		res := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec(c.password, []byte(c.salt), c.iterations, c.keyLength)})
+		key, ok := res.(*object.Object)
+		if !ok {
+			t.Fatalf("%s: expected a key, got %v", c.algorithm, res)
+		}
+		if got := hex.EncodeToString(encodedKeyBytes(key)); got != c.want {
+			t.Errorf("%s(%s, %s, %d): expected %s, got %s", c.algorithm, c.password, c.salt, c.iterations, c.want, got)
+		}
+	}
+}
+
+func TestSecretKeyFactoryPBKDF2KeySpec(t *testing.T) {
+	globals.InitGlobals("test")
+
+	skf := newTestSecretKeyFactory(t, "PBKDF2WithHmacSHA256")
+
+	for _, c := range []struct {
+		salt                  []byte
+		iterations, keyLength int64
+		msg                   string
+	}{
+		{nil, 0, 0, "Salt not found"},
+		{[]byte("salt"), 0, 256, "Iteration count not found"},
+		{[]byte("salt"), 1000, 0, "Key length not found"},
+	} {
+		res := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("password", c.salt, c.iterations, c.keyLength)})
+		errBlk, ok := res.(*ghelpers.GErrBlk)
+		if !ok || errBlk.ExceptionType != excNames.InvalidKeySpecException || errBlk.ErrMsg != c.msg {
+			t.Errorf("expected InvalidKeySpecException %q, got %v", c.msg, res)
+		}
+	}
+
+	key := secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("pässword", []byte("salt"), 1000, 128)}).(*object.Object)
+	res := secretKeyFactoryGetKeySpec([]any{skf, key, makeClassObject("javax.crypto.spec.PBEKeySpec")})
+	spec, ok := res.(*object.Object)
+	if !ok {
+		t.Fatalf("getKeySpec: expected a PBEKeySpec, got %v", res)
+	}
+	password := object.GoStringFromJavaCharArray(spec.FieldTable["password"].Fvalue.(*object.Object).FieldTable["value"].Fvalue.([]int64))
+	if password != "pässword" || spec.FieldTable["iterationCount"].Fvalue != int64(1000) ||
+		spec.FieldTable["keyLength"].Fvalue != int64(128) {
+		t.Errorf("getKeySpec: got password %q, iterations %v, key length %v", password,
+			spec.FieldTable["iterationCount"].Fvalue, spec.FieldTable["keyLength"].Fvalue)
+	}
+
+	// the key spec gives the key back
+	again := secretKeyFactoryGenerateSecret([]any{skf, spec}).(*object.Object)
+	if !bytes.Equal(encodedKeyBytes(again), encodedKeyBytes(key)) {
+		t.Error("generateSecret(getKeySpec(key)) gave a different key")
+	}
+
+	res = secretKeyFactoryGetKeySpec([]any{skf, key, makeClassObject("javax.crypto.spec.SecretKeySpec")})
+	if errBlk, ok := res.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != excNames.InvalidKeySpecException {
+		t.Errorf("getKeySpec(SecretKeySpec): expected InvalidKeySpecException, got %v", res)
+	}
+
+	if secretKeyFactoryTranslateKey([]any{skf, key}) != key {
+		t.Error("translateKey: expected the factory's own key back")
+	}
+	aesKey := newRawSecretKey("AES", make([]byte, 16))
+	res = secretKeyFactoryTranslateKey([]any{skf, aesKey})
+	if errBlk, ok := res.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != excNames.InvalidKeyException {
+		t.Errorf("translateKey(AES key): expected InvalidKeyException, got %v", res)
+	}
+
+	if algo := object.GoStringFromStringObject(secretKeyFactoryGetAlgorithm([]any{skf}).(*object.Object)); algo != "PBKDF2WithHmacSHA256" {
+		t.Errorf("getAlgorithm: got %s", algo)
+	}
+}
+
+func TestSecretKeyFactoryDESede(t *testing.T) {
+	globals.InitGlobals("test")
+
+	skf := newTestSecretKeyFactory(t, "DESede")
+	raw := []byte("0123456789abcdefghijklmnopq") // 27 bytes: only the first 24 are used
+
+	spec := object.MakeEmptyObjectWithClassName(&types.ClassNameDESedeKeySpec)
+	if ret := desedeKeySpecInit([]any{spec, makeByteArrayObject(raw)}); ret != nil {
+		t.Fatalf("DESedeKeySpec: %v", ret)
+	}
+	if desedeKeySpecIsParityAdjusted([]any{makeByteArrayObject(raw), int64(0)}) != types.JavaBoolFalse {
+		t.Error("expected the raw key not to be parity-adjusted")
+	}
+
+	key, ok := secretKeyFactoryGenerateSecret([]any{skf, spec}).(*object.Object)
+	if !ok {
+		t.Fatal("generateSecret(DESedeKeySpec) did not return a key")
+	}
+	encoded := encodedKeyBytes(key)
+	if len(encoded) != DESedeKeyLength {
+		t.Fatalf("expected a %d-byte key, got %d bytes", DESedeKeyLength, len(encoded))
+	}
+	if desedeKeySpecIsParityAdjusted([]any{makeByteArrayObject(encoded), int64(0)}) != types.JavaBoolTrue {
+		t.Errorf("expected the generated key to be parity-adjusted, got %x", encoded)
+	}
+	for i := range encoded {
+		if encoded[i]&^1 != raw[i]&^1 {
+			t.Fatalf("byte %d: parity adjustment changed more than the low bit: %x vs %x", i, encoded[i], raw[i])
+		}
+	}
+
+	res := secretKeyFactoryGetKeySpec([]any{skf, key, makeClassObject("javax/crypto/spec/DESedeKeySpec")})
+	keySpec, ok := res.(*object.Object)
+	if !ok {
+		t.Fatalf("getKeySpec(DESedeKeySpec): got %v", res)
+	}
+	if got := macResultBytes(t, desedeKeySpecGetKey([]any{keySpec})); !bytes.Equal(got, encoded) {
+		t.Errorf("getKeySpec(DESedeKeySpec): expected %x, got %x", encoded, got)
+	}
+	res = secretKeyFactoryGetKeySpec([]any{skf, key, makeClassObject("javax.crypto.spec.SecretKeySpec")})
+	if secretKeySpec, ok := res.(*object.Object); !ok || !bytes.Equal(encodedKeyBytes(secretKeySpec), encoded) {
+		t.Errorf("getKeySpec(SecretKeySpec): got %v", res)
+	}
+
+	// a SecretKeySpec of the algorithm is translated into a parity-adjusted key
+	className := "javax/crypto/spec/SecretKeySpec"
+	secretKeySpec := object.MakeEmptyObjectWithClassName(&className)
+	secretKeySpecInit([]any{secretKeySpec, makeByteArrayObject(raw[:24]), object.StringObjectFromGoString("DESede")})
+	translated, ok := secretKeyFactoryTranslateKey([]any{skf, secretKeySpec}).(*object.Object)
+	if !ok || !bytes.Equal(encodedKeyBytes(translated), encoded) {
+		t.Errorf("translateKey: expected %x, got %v", encoded, translated)
+	}
+
+	short := object.MakeEmptyObjectWithClassName(&types.ClassNameDESedeKeySpec)
+	res = desedeKeySpecInit([]any{short, makeByteArrayObject(raw), int64(4)})
+	if errBlk, ok := res.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != excNames.InvalidKeyException || errBlk.ErrMsg != "Wrong key size" {
+		t.Errorf("DESedeKeySpec(key, 4): expected InvalidKeyException, got %v", res)
+	}
+	res = secretKeyFactoryGenerateSecret([]any{skf, makePBEKeySpec("password", nil, 0, 0)})
+	if errBlk, ok := res.(*ghelpers.GErrBlk); !ok || errBlk.ExceptionType != excNames.InvalidKeySpecException {
+		t.Errorf("generateSecret(PBEKeySpec) for DESede: expected InvalidKeySpecException, got %v", res)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package javaxCrypto
+
+import (
+	"math/bits"
+	"slices"
+
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"jacobin/src/object"
+	"jacobin/src/statics"
+	"jacobin/src/types"
+)
+
+// DESedeKeyLength is the length in bytes of a DES-EDE key: DESedeKeySpec.DES_EDE_KEY_LEN
+const DESedeKeyLength = 24
+
+func Load_Crypto_Spec_DESedeKeySpec() {
+	ghelpers.MethodSignatures["javax/crypto/spec/DESedeKeySpec.<clinit>()V"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  desedeKeySpecClinit,
+		}
+
+	ghelpers.MethodSignatures["javax/crypto/spec/DESedeKeySpec.<init>([B)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 1,
+			GFunction:  desedeKeySpecInit,
+		}
+
+	ghelpers.MethodSignatures["javax/crypto/spec/DESedeKeySpec.<init>([BI)V"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  desedeKeySpecInit,
+		}
+
+	ghelpers.MethodSignatures["javax/crypto/spec/DESedeKeySpec.getKey()[B"] =
+		ghelpers.GMeth{
+			ParamSlots: 0,
+			GFunction:  desedeKeySpecGetKey,
+		}
+
+	ghelpers.MethodSignatures["javax/crypto/spec/DESedeKeySpec.isParityAdjusted([BI)Z"] =
+		ghelpers.GMeth{
+			ParamSlots: 2,
+			GFunction:  desedeKeySpecIsParityAdjusted,
+		}
+}
+
+func desedeKeySpecClinit(params []any) any {
+	_ = statics.AddStatic(types.ClassNameDESedeKeySpec+".DES_EDE_KEY_LEN",
+		statics.Static{Type: types.Int, Value: int64(DESedeKeyLength)})
+	return nil
+}
+
+// desedeKeyBytes returns the 24 key bytes at the offset in a byte array argument.
+func desedeKeyBytes(keyArg any, offset int64) ([]byte, *ghelpers.GErrBlk) {
+	keyObj, ok := keyArg.(*object.Object)
+	if !ok || object.IsNull(keyObj) {
+		return nil, ghelpers.GetGErrBlk(excNames.NullPointerException, "key cannot be null")
+	}
+	key := object.GoByteArrayFromJavaByteArray(keyObj.FieldTable["value"].Fvalue.([]types.JavaByte))
+	if offset < 0 || int64(len(key))-offset < DESedeKeyLength {
+		return nil, ghelpers.GetGErrBlk(excNames.InvalidKeyException, "Wrong key size")
+	}
+	return slices.Clone(key[offset : offset+DESedeKeyLength]), nil
+}
+
+func desedeKeySpecInit(params []any) any {
+	self, ok := params[0].(*object.Object)
+	if !ok || object.IsNull(self) {
+		return ghelpers.GetGErrBlk(excNames.IllegalArgumentException, "desedeKeySpecInit: invalid 'this'")
+	}
+
+	offset := int64(0)
+	if len(params) > 2 {
+		offset = params[2].(int64)
+	}
+	key, gerr := desedeKeyBytes(params[1], offset)
+	if gerr != nil {
+		return gerr
+	}
+
+	self.FieldTable["key"] = object.Field{Ftype: types.GoByteArray, Fvalue: key}
+	return nil
+}
+
+func desedeKeySpecGetKey(params []any) any {
+	self := params[0].(*object.Object)
+	key, _ := self.FieldTable["key"].Fvalue.([]byte)
+	return object.MakePrimitiveObject(types.JavaByteArray, types.JavaByteArray,
+		object.JavaByteArrayFromGoByteArray(slices.Clone(key)))
+}
+
+// desedeKeySpecIsParityAdjusted reports whether each byte of the three DES keys has odd parity.
+func desedeKeySpecIsParityAdjusted(params []any) any {
+	key, gerr := desedeKeyBytes(params[0], params[1].(int64))
+	if gerr != nil {
+		return gerr
+	}
+	for _, b := range key {
+		if bits.OnesCount8(b)%2 == 0 {
+			return types.JavaBoolFalse
+		}
+	}
+	return types.JavaBoolTrue
+}
+
+// setDESParity sets the low bit of each byte of a DES or DES-EDE key to give it odd parity,
+// as the JDK's DES and DESede keys do.
+func setDESParity(key []byte) []byte {
+	adjusted := make([]byte, len(key))
+	for i, b := range key {
+		b &^= 1
+		if bits.OnesCount8(b)%2 == 0 {
+			b |= 1
+		}
+		adjusted[i] = b
+	}
+	return adjusted
+}
@@ -6,7 +6,15 @@
 
 package javaxCrypto
 
-import "strings"
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"hash"
+	"strings"
+)
 
 // CipherTransformation entry definition
 type CipherTransformation struct {
@@ -1255,6 +1263,36 @@ var SecretKeySpecAlgorithmTable = map[string]SecretKeySpecAlgorithm{
 		Enabled: true,
 		Notes:   "HMAC with SHA-512",
 	},
+	"HmacSHA512/224": {
+		Name:    "HmacSHA512/224",
+		Enabled: true,
+		Notes:   "HMAC with SHA-512/224",
+	},
+	"HmacSHA512/256": {
+		Name:    "HmacSHA512/256",
+		Enabled: true,
+		Notes:   "HMAC with SHA-512/256",
+	},
+	"HmacSHA3-224": {
+		Name:    "HmacSHA3-224",
+		Enabled: true,
+		Notes:   "HMAC with SHA3-224",
+	},
+	"HmacSHA3-256": {
+		Name:    "HmacSHA3-256",
+		Enabled: true,
+		Notes:   "HMAC with SHA3-256",
+	},
+	"HmacSHA3-384": {
+		Name:    "HmacSHA3-384",
+		Enabled: true,
+		Notes:   "HMAC with SHA3-384",
+	},
+	"HmacSHA3-512": {
+		Name:    "HmacSHA3-512",
+		Enabled: true,
+		Notes:   "HMAC with SHA3-512",
+	},
 	"PBEWithMD5AndDES": {
 		Name:    "PBEWithMD5AndDES",
 		Enabled: true,
@@ -1419,6 +1457,35 @@ func (ct CipherTransformation) GetRequiredParameters() []string {
 	return params
 }
 
+// MacAlgorithm entry definition
+type MacAlgorithm struct {
+	Name      string
+	Enabled   bool
+	New       func() hash.Hash // the underlying hash
+	MacLength int              // in bytes
+}
+
+func newSHA3_224() hash.Hash { return sha3.New224() }
+func newSHA3_256() hash.Hash { return sha3.New256() }
+func newSHA3_384() hash.Hash { return sha3.New384() }
+func newSHA3_512() hash.Hash { return sha3.New512() }
+
+// MacAlgorithmTable contains the HMAC algorithms supported by Mac.
+var MacAlgorithmTable = map[string]MacAlgorithm{
+	"HmacMD5":        {Name: "HmacMD5", Enabled: true, New: md5.New, MacLength: md5.Size},
+	"HmacSHA1":       {Name: "HmacSHA1", Enabled: true, New: sha1.New, MacLength: sha1.Size},
+	"HmacSHA224":     {Name: "HmacSHA224", Enabled: true, New: sha256.New224, MacLength: sha256.Size224},
+	"HmacSHA256":     {Name: "HmacSHA256", Enabled: true, New: sha256.New, MacLength: sha256.Size},
+	"HmacSHA384":     {Name: "HmacSHA384", Enabled: true, New: sha512.New384, MacLength: sha512.Size384},
+	"HmacSHA512":     {Name: "HmacSHA512", Enabled: true, New: sha512.New, MacLength: sha512.Size},
+	"HmacSHA512/224": {Name: "HmacSHA512/224", Enabled: true, New: sha512.New512_224, MacLength: sha512.Size224},
+	"HmacSHA512/256": {Name: "HmacSHA512/256", Enabled: true, New: sha512.New512_256, MacLength: sha512.Size256},
+	"HmacSHA3-224":   {Name: "HmacSHA3-224", Enabled: true, New: newSHA3_224, MacLength: 28},
+	"HmacSHA3-256":   {Name: "HmacSHA3-256", Enabled: true, New: newSHA3_256, MacLength: 32},
+	"HmacSHA3-384":   {Name: "HmacSHA3-384", Enabled: true, New: newSHA3_384, MacLength: 48},
+	"HmacSHA3-512":   {Name: "HmacSHA3-512", Enabled: true, New: newSHA3_512, MacLength: 64},
+}
+
 func ValidateCipherTransformation(transformation string) (CipherTransformation, bool) {
 	for name, config := range CipherConfigTable {
 		if strings.EqualFold(name, transformation) {
@@ -1436,3 +1503,12 @@ func ValidateSecretKeySpecAlgorithm(algorithm string) (SecretKeySpecAlgorithm, b
 	}
 	return SecretKeySpecAlgorithm{}, false
 }
+
+func ValidateMacAlgorithm(algorithm string) (MacAlgorithm, bool) {
+	for name, config := range MacAlgorithmTable {
+		if strings.EqualFold(name, algorithm) {
+			return config, config.Enabled
+		}
+	}
+	return MacAlgorithm{}, false
+}
@@ -78,24 +78,6 @@ func Load_Other_Methods() {
 			GFunction:  ghelpers.ClinitGeneric,
 		}
 
-	ghelpers.MethodSignatures["java/nio/charset/Charset.<clinit>()V"] =
-		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.ClinitGeneric,
-		}
-
-	ghelpers.MethodSignatures["java/nio/charset/Charset.defaultCharset()Ljava/nio/charset/Charset;"] =
-		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.ReturnCharsetName,
-		}
-
-	ghelpers.MethodSignatures["java/nio/charset/Charset.name()Ljava/lang/String;"] =
-		ghelpers.GMeth{
-			ParamSlots: 0,
-			GFunction:  ghelpers.ReturnCharsetName,
-		}
-
 	ghelpers.MethodSignatures["java/security/AccessController.<clinit>()V"] =
 		ghelpers.GMeth{
 			ParamSlots: 0,
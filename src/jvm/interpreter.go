@@ -322,9 +322,10 @@ func interpret(fs *list.List) {
 			thro := object.MakeEmptyObject()
 			params := []interface{}{fs, thro}
 			_ = javaLang.ThrowableInitNull(params)
-			stackTrace := thro.FieldTable["stackTrace"].Fvalue.(*object.Object)
-			traceEntries := stackTrace.FieldTable["value"].Fvalue.([]*object.Object)
-			exceptions.ShowJVMstackTrace(traceEntries, glob)
+			lines := exceptions.StackTraceLines(thro, nil)
+			for _, line := range lines[1:] { // the frames, without the dummy exception's name
+				trace.AsIs(line)
+			}
 
 			// exceptions.ShowFrameStack(fs)
 			exceptions.ShowGoStackTrace(nil)
@@ -3636,101 +3637,9 @@ func doAthrow(fr *frames.Frame, _ int64) int {
 	// with whether we want the standard JDK info as elected with the -strictJDK
 	// command-line option)
 	if catchFrame == nil {
-		// if the exception is not caught, then print the data from the stackTraceElements (STEs)
-		// in the Throwable object or subclass (which is generally the specific exception class).
-
-		// start by printing out the name of the exception/error and the thread it occurred on
-		errMsg := ""
-		if fr.Thread == 1 { // if it's thread #1, use its name, "main"
-			errMsg = fmt.Sprintf("Exception in thread \"main\" %s", exceptionName)
-		} else {
-			errMsg = fmt.Sprintf("Exception in thread %d %s", fr.Thread, exceptionName)
-		}
-
-		objectRef.ThMutex.RLock()
-		appMsgFld, ok := objectRef.FieldTable["detailMessage"]
-		objectRef.ThMutex.RUnlock()
-		if ok {
-			appMsg := appMsgFld.Fvalue
-			if appMsg != object.Null && appMsg != nil {
-				switch appMsg.(type) {
-				case []types.JavaByte:
-					jbarray := appMsg.([]types.JavaByte)
-					errMsg += fmt.Sprintf(": %s", object.GoStringFromJavaByteArray(jbarray))
-				case *object.Object:
-					var value any
-					obj := appMsg.(*object.Object)
-					obj.ThMutex.RLock()
-					fld, ok := obj.FieldTable["value"]
-					obj.ThMutex.RUnlock()
-					if !ok {
-						value = "<missing>"
-					} else {
-						value = fld.Fvalue
-					}
-					switch value.(type) {
-					case []byte:
-						obj.ThMutex.RLock()
-						errMsg += fmt.Sprintf(": %s", string(obj.FieldTable["value"].Fvalue.([]byte)))
-						obj.ThMutex.RUnlock()
-					case []types.JavaByte:
-						obj.ThMutex.RLock()
-						errMsg += fmt.Sprintf(": %s", object.GoStringFromJavaByteArray(obj.FieldTable["value"].Fvalue.([]types.JavaByte)))
-						obj.ThMutex.RUnlock()
-					case uint32:
-						str := stringPool.GetStringPointer(value.(uint32))
-						errMsg += fmt.Sprintf(": %s", *str)
-					default:
-						errMsg += fmt.Sprintf(": <default value> %v", value)
-					}
-				default:
-					errMsg += ": objectRef.FieldTable[\"detailMessage\"] is object.Null"
-				}
-			}
-		}
-		trace.AsIs(errMsg)
-
-		objectRef.ThMutex.RLock()
-		steArrayPtrObj := objectRef.FieldTable["stackTrace"].Fvalue.(*object.Object)
-		objectRef.ThMutex.RUnlock()
-
-		steArrayPtrObj.ThMutex.RLock()
-		rawSteArray := steArrayPtrObj.FieldTable["value"].Fvalue.([]*object.Object) // []*object.Object (each of which is an STE)
-		steArrayPtrObj.ThMutex.RUnlock()
-
-		for i := 0; i < len(rawSteArray); i++ {
-			ste := rawSteArray[i]
-			ste.ThMutex.RLock()
-			rawClassName := ste.FieldTable["declaringClass"].Fvalue.(string)
-			ste.ThMutex.RUnlock()
-			if rawClassName == "java/lang/Throwable" || // don't show Throwable methods
-				rawClassName == "java/lang/Error" || // don't show Error methods
-				rawClassName == "java/lang/AssertionError" { // don't show AssertionError methods
-				continue
-			}
-			ste.ThMutex.RLock()
-			methodName := ste.FieldTable["methodName"].Fvalue.(string)
-			ste.ThMutex.RUnlock()
-			if methodName == "<init>" { // don't show constructors
-				continue
-			}
-			className := strings.Replace(rawClassName, "/", ".", -1)
-
-			ste.ThMutex.RLock()
-			sourceLine := ste.FieldTable["sourceLine"].Fvalue.(string)
-			fileName := ste.FieldTable["fileName"].Fvalue
-			ste.ThMutex.RUnlock()
-
-			var errMsg string
-			if sourceLine != "" {
-				errMsg = fmt.Sprintf("\tat %s.%s(%s:%s)", className,
-					methodName, fileName, sourceLine)
-			} else {
-				errMsg = fmt.Sprintf("\tat %s.%s(%s)", className,
-					methodName, fileName)
-			}
-			trace.AsIs(errMsg)
-		}
+		// if the exception is not caught, then print its stack trace, with those of its causes
+		// and suppressed exceptions, as the JDK's default handler for uncaught exceptions does.
+		exceptions.ShowJVMstackTrace(objectRef, fr.Thread, javaLang.ThrowableDescriber(fr.FrameStack))
 
 		// show Jacobin's JVM stack info if -strictJDK is not set
 		if globals.GetGlobalRef().StrictJDK == false {
@@ -359,6 +359,7 @@ func makeStackTraceArray(elems []*object.Object) *object.Object {
 // ATHROW: Uncaught exception with detailMessage as []types.JavaByte
 func TestAthrow_Uncaught_WithJavaByteMessage(t *testing.T) {
 	globals.InitGlobals("test")
+	classloader.InitMethodArea() // for finding overrides of Throwable methods
 
 	normalStderr := os.Stderr
 	r, w, _ := os.Pipe()
@@ -418,6 +419,7 @@ func TestAthrow_Uncaught_WithJavaByteMessage(t *testing.T) {
 // ATHROW: Uncaught exception with detailMessage as a String-like object (value is []byte)
 func TestAthrow_Uncaught_WithStringObjectMessageBytes(t *testing.T) {
 	globals.InitGlobals("test")
+	classloader.InitMethodArea() // for finding overrides of Throwable methods
 
 	normalStderr := os.Stderr
 	r, w, _ := os.Pipe()
@@ -1062,6 +1062,9 @@ func TestPeekWithStackUnderflowStrictJDK(t *testing.T) {
 		!strings.Contains(msg, "com.sun.jdi.InternalException") { // use the HotSpot error message
 		t.Errorf("got unexpected error message: %s", msg)
 	}
+	if strings.Contains(msg, "FQN:") { // only the JDK's header and frames are shown
+		t.Errorf("got Jacobin's exception line under -strictJDK: %s", msg)
+	}
 }
 
 // POP: pop item off stack and discard it
@@ -14,7 +14,6 @@ import (
 	"jacobin/src/object"
 	"jacobin/src/opcodes"
 	"jacobin/src/stringPool"
-	"jacobin/src/testutil"
 	"jacobin/src/trace"
 	"jacobin/src/types"
 	"strings"
@@ -311,14 +310,14 @@ func TestCheckCastArray3(t *testing.T) {
 
 func TestPushPeekPop(t *testing.T) {
 
-	testutil.UTinit(t)
+	UTinit(t)
 	globals.TraceVerbose = false
 	var ret, thing interface{}
 	flagDeepTracing := false
 
 	// Let verbose trace messages go into a pipe that we will never see.
 	// We only care about evaluating the return from pop() in the loop.
-	testutil.UTnewConsole(t)
+	UTnewConsole(t)
 
 	// Create frame (fr).
 	fr := frames.CreateFrame(13)
@@ -474,20 +473,20 @@ func TestPushPeekPop(t *testing.T) {
 	}
 
 	// Restore console for go test.
-	testutil.UTrestoreConsole(t)
+	UTrestoreConsole(t)
 
 }
 
 func TestEmitTraceData(t *testing.T) {
 
-	testutil.UTinit(t)
+	UTinit(t)
 	globals.TraceVerbose = true
 	var ret interface{}
 	flagDeepTracing := false
 
 	// Let verbose trace messages go into a pipe that we will never see.
 	// We only care about evaluating the return from pop() in the loop.
-	testutil.UTnewConsole(t)
+	UTnewConsole(t)
 
 	// Create frame (fr).
 	fr := frames.CreateFrame(13)
@@ -619,11 +618,11 @@ func TestEmitTraceData(t *testing.T) {
 	}
 
 	// Restore console for go test.
-	testutil.UTrestoreConsole(t)
+	UTrestoreConsole(t)
 }
 
 func TestGetSuperclasses(t *testing.T) {
-	testutil.UTinit(t)
+	UTinit(t)
 
 	// Try an invalid index.
 	aryUint32 := getSuperclasses(types.InvalidStringIndex)
@@ -669,7 +668,7 @@ func TestGetSuperclasses(t *testing.T) {
 }
 
 func TestCheckcastNonArrayObject(t *testing.T) {
-	testutil.UTinit(t)
+	UTinit(t)
 
 	// Try java/lang/String.
 	className := "java/lang/String"
@@ -688,15 +687,15 @@ func TestCheckcastNonArrayObject(t *testing.T) {
 	// Try aaa/bbb/ccc.
 	className = "aaa/bbb/ccc"
 	obj = object.StringObjectFromGoString(className)
-	testutil.UTnewConsole(t) // Avoid: Attempt to access uninitialized ThrowEx pointer func
+	UTnewConsole(t) // Avoid: Attempt to access uninitialized ThrowEx pointer func
 	if checkcastNonArrayObject(obj, className) {
 		t.Errorf("TestCheckcastNonArrayObject: checkcastNonArrayObject(%s) returned true", className)
 	}
-	testutil.UTrestoreConsole(t)
+	UTrestoreConsole(t)
 }
 
 func TestCheckcastArray(t *testing.T) {
-	testutil.UTinit(t)
+	UTinit(t)
 
 	// java/lang/String.
 	className := "java/lang/String"
@@ -715,20 +714,20 @@ func TestCheckcastArray(t *testing.T) {
 	// aaa/bbb/ccc.
 	className = "aaa/bbb/ccc"
 	obj = object.StringObjectFromGoString(className)
-	testutil.UTnewConsole(t)
+	UTnewConsole(t)
 	if checkcastArray(obj, className) {
 		t.Errorf("TestCheckcastArray: TestCheckcastArray(%s) returned true", className)
 	}
-	testutil.UTrestoreConsole(t)
+	UTrestoreConsole(t)
 
 	// types.InvalidStringIndex
 	obj = object.MakePrimitiveObject("will be replaced", "nonsense", 42)
 	obj.KlassName = types.InvalidStringIndex
-	testutil.UTnewConsole(t) // Avoid: Attempt to access uninitialized ThrowEx pointer func
+	UTnewConsole(t) // Avoid: Attempt to access uninitialized ThrowEx pointer func
 	if checkcastArray(obj, className) {
 		t.Errorf("TestCheckcastArray: TestCheckcastArray(%s) returned true", className)
 	}
-	testutil.UTrestoreConsole(t)
+	UTrestoreConsole(t)
 
 	// Setup for primitive array types comparisons.
 	className1 := "[Lmy/long/array;"
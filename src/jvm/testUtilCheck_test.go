@@ -1,4 +1,4 @@
-package testutil
+package jvm
 
 import (
 	"jacobin/src/gfunction/ghelpers"
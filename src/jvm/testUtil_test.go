@@ -1,4 +1,4 @@
-package testutil
+package jvm
 
 import (
 	"fmt"
@@ -12,6 +12,10 @@ import (
 	"testing"
 )
 
+// Helpers that start Jacobin's infrastructure for the jvm tests and run G functions through it.
+// They are kept here rather than in testutil because they import gfunction, and the tests of the
+// G function packages import testutil.
+
 // ***** NOT THREAD-SAFE *****
 var originalStderr *os.File
 var originalStdout *os.File
@@ -0,0 +1,29 @@
+/*
+ * Jacobin VM - A Java virtual machine
+ * Copyright (c) 2026 by  the Jacobin authors. Consult jacobin.org.
+ * Licensed under Mozilla Public License 2.0 (MPL 2.0) All rights reserved.
+ */
+
+package testutil
+
+import (
+	"jacobin/src/excNames"
+	"jacobin/src/gfunction/ghelpers"
+	"strings"
+	"testing"
+)
+
+// ExpectGErr checks that ret, which a G function returned, is the exception excType with a
+// message that contains msgPart. An empty msgPart matches any message.
+func ExpectGErr(t testing.TB, ret any, excType int, msgPart string) {
+	t.Helper()
+	gerr, ok := ret.(*ghelpers.GErrBlk)
+	if !ok {
+		t.Errorf("expected %s, got %T (%v)", excNames.JVMexceptionNames[excType], ret, ret)
+		return
+	}
+	if gerr.ExceptionType != excType || !strings.Contains(gerr.ErrMsg, msgPart) {
+		t.Errorf("expected %s containing %q, got %s %q", excNames.JVMexceptionNames[excType], msgPart,
+			excNames.JVMexceptionNames[gerr.ExceptionType], gerr.ErrMsg)
+	}
+}
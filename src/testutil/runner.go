@@ -20,6 +20,8 @@ Inputs:
 	argDeadline: Timeout in seconds
 	argVerbose: If true, some activity will be logged to stderr (debug tool).
 
+RunnerWithInput also takes argStdin, the text that the subprocess reads from its standard input.
+
 Returns:
 
 	Result code (RcRunner* defined below)
@@ -30,6 +32,10 @@ const RcRunnerFailure = 1
 const RcRunnerTimeout = 2
 
 func Runner(argCmdExec string, argOpts string, argDeadlineSecs int, argVerbose bool) (int, string) {
+	return RunnerWithInput(argCmdExec, argOpts, "", argDeadlineSecs, argVerbose)
+}
+
+func RunnerWithInput(argCmdExec string, argOpts string, argStdin string, argDeadlineSecs int, argVerbose bool) (int, string) {
 	var cwd string
 	var err error
 
@@ -55,6 +61,7 @@ func Runner(argCmdExec string, argOpts string, argDeadlineSecs int, argVerbose b
 
 	// Create the command context for cmd.CombinedOutput().
 	cmd := exec.CommandContext(ctx, argCmdExec, sliceOpts[:]...)
+	cmd.Stdin = strings.NewReader(argStdin)
 
 	// Run the command. Get the combined stdout and stderr text.
 	outBytes, err := cmd.CombinedOutput()
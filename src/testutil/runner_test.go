@@ -25,3 +25,10 @@ func TestRcFromRunner(t *testing.T) {
 	}
 
 }
+
+func TestRunnerWithInput(t *testing.T) {
+	rc, outstr := RunnerWithInput("head", "-n 1", "first\nsecond\n", 10, false)
+	if rc != RcRunnerSuccess || outstr != "first\n" {
+		t.Errorf("TestRunnerWithInput: expected rc=%d and \"first\\n\", observed rc=%d, outstr=%q", RcRunnerSuccess, rc, outstr)
+	}
+}
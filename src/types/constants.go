@@ -87,9 +87,20 @@ var ClassNameSecurityProvider = "java/security/Provider"
 var ClassNameSecurityProviderService = "java/security/Provider$Service"
 var ClassNameSignature = "java/security/Signature"
 var ClassNameCipher = "javax/crypto/Cipher"
+var ClassNameMac = "javax/crypto/Mac"
+var ClassNameDESedeKeySpec = "javax/crypto/spec/DESedeKeySpec"
 var ClassNamePBEParameterSpec = "javax/crypto/spec/PBEParameterSpec"
 var ClassNameSecretKey = "javax/crypto/SecretKey"
 var ClassNameSecureRandom = "java/security/SecureRandom"
+var ClassNameKeyStore = "java/security/KeyStore"
+var ClassNameCertificateFactory = "java/security/cert/CertificateFactory"
+var ClassNameX509Certificate = "java/security/cert/X509Certificate"
+var ClassNameCertPath = "java/security/cert/CertPath"
+var ClassNameCertPathValidator = "java/security/cert/CertPathValidator"
+var ClassNamePKIXCertPathValidatorResult = "java/security/cert/PKIXCertPathValidatorResult"
+var ClassNamePKIXParameters = "java/security/cert/PKIXParameters"
+var ClassNameTrustAnchor = "java/security/cert/TrustAnchor"
+var ClassNameX500Principal = "javax/security/auth/x500/X500Principal"
 
 // File system
 var FileSystemProviderValue = &struct{}{}